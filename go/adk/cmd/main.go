@@ -19,6 +19,9 @@ import (
 	runnerpkg "github.com/kagent-dev/kagent/go/adk/pkg/runner"
 	"github.com/kagent-dev/kagent/go/adk/pkg/session"
 	"github.com/kagent-dev/kagent/go/adk/pkg/telemetry"
+	"github.com/kagent-dev/kagent/go/adk/pkg/toolstats"
+	"github.com/kagent-dev/kagent/go/api/adk"
+	apiutils "github.com/kagent-dev/kagent/go/api/utils"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
@@ -85,6 +88,10 @@ func main() {
 		logger.Error(err, "Failed to materialize agent config from environment", "configDir", configDir)
 		os.Exit(1)
 	}
+	if err := config.LoadAPIKeyFiles(); err != nil {
+		logger.Error(err, "Failed to load API key files")
+		os.Exit(1)
+	}
 
 	agentConfig, agentCard, err := config.LoadAgentConfigs(configDir)
 	if err != nil {
@@ -170,13 +177,15 @@ func main() {
 
 	// Build memory service if configured.
 	var memoryService *kagentmemory.KagentMemoryService
-	if agentConfig.Memory != nil && kagentURL != "" {
+	if agentConfig.Memory != nil && (kagentURL != "" || agentConfig.Memory.Qdrant != nil) {
 		memSvc, err := kagentmemory.New(kagentmemory.Config{
 			AgentName:       appName,
 			APIURL:          kagentURL,
 			HTTPClient:      httpClient,
 			TTLDays:         agentConfig.Memory.TTLDays,
 			EmbeddingConfig: agentConfig.Memory.Embedding,
+			Qdrant:          agentConfig.Memory.Qdrant,
+			QdrantAPIKey:    os.Getenv("QDRANT_API_KEY"),
 		})
 		if err != nil {
 			logger.Error(err, "Failed to create memory service")
@@ -192,6 +201,15 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Report the calls the agent makes to its tool servers so operators can
+	// see which of them are slow or failing.
+	if kagentURL != "" && httpClient != nil {
+		reporter := toolstats.NewReporter(toolstats.Default, kagentURL, httpClient, appName, logger)
+		go reporter.Run(ctx)
+		// Report the calls made since the last report on shutdown.
+		defer reporter.Report(ctx)
+	}
+
 	stream := agentConfig.GetStream()
 	executor := a2a.NewKAgentExecutor(a2a.KAgentExecutorConfig{
 		RunnerConfig:       runnerConfig,
@@ -199,9 +217,23 @@ func main() {
 		SessionService:     sessionService,
 		Stream:             stream,
 		AppName:            appName,
+		TaskTimeout:        agentConfig.Timeouts.TaskTimeout(),
+		Budget:             agentConfig.Budget,
 		Logger:             logger,
 	})
 
+	// Apply edits to the mounted config, such as instruction, tool or model
+	// changes, to new invocations without a restart.
+	watcher := config.NewWatcher(configDir, agentConfig, func(cfg *adk.AgentConfig) error {
+		runnerConfig, subagentSessionIDs, err := runnerpkg.CreateRunnerConfig(ctx, cfg, sessionService, appName, memoryService, kagentURL, httpClient)
+		if err != nil {
+			return err
+		}
+		executor.SetRunnerConfig(runnerConfig, subagentSessionIDs, cfg.Timeouts.TaskTimeout(), cfg.Budget)
+		return nil
+	}, logger)
+	go watcher.Run(ctx)
+
 	// Build the agent card.
 	if agentCard == nil {
 		agentCard = &a2atype.AgentCard{
@@ -227,6 +259,7 @@ func main() {
 		Logger:          logger,
 		HTTPClient:      httpClient,
 		Agent:           runnerConfig.Agent,
+		Streaming:       streamOptions(agentConfig.Streaming),
 	}, executor)
 	if err != nil {
 		logger.Error(err, "Failed to create app")
@@ -239,6 +272,25 @@ func main() {
 	}
 }
 
+// streamOptions converts the SSE tuning from the agent config, where
+// durations are in seconds.
+func streamOptions(cfg *adk.StreamingConfig) apiutils.StreamOptions {
+	var opts apiutils.StreamOptions
+	if cfg == nil {
+		return opts
+	}
+	if cfg.FlushInterval != nil {
+		opts.FlushInterval = time.Duration(*cfg.FlushInterval * float64(time.Second))
+	}
+	if cfg.WriteTimeout != nil {
+		opts.WriteTimeout = time.Duration(*cfg.WriteTimeout * float64(time.Second))
+	}
+	if cfg.MaxEventSize != nil {
+		opts.MaxEventSize = *cfg.MaxEventSize
+	}
+	return opts
+}
+
 func deriveAppName(kagentName, kagentNamespace string, agentCard *a2atype.AgentCard, logger logr.Logger) string {
 	if kagentNamespace != "" && kagentName != "" {
 		namespace := strings.ReplaceAll(kagentNamespace, "-", "_")
@@ -0,0 +1,105 @@
+package a2a
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	a2atype "github.com/a2aproject/a2a-go/a2a"
+	adksession "google.golang.org/adk/v2/session"
+	"google.golang.org/genai"
+)
+
+// errTaskCanceled is the cause of the cancellation of a run stopped by
+// tasks/cancel.
+var errTaskCanceled = errors.New("task canceled")
+
+// canceledToolResponse is the result recorded for tool calls a canceled run
+// left without one.
+var canceledToolResponse = map[string]any{"error": "The tool call was canceled because the task was canceled."}
+
+// taskRun is a run of a task in progress.
+type taskRun struct {
+	cancel context.CancelCauseFunc
+}
+
+// startRun registers the run of taskID so Cancel can stop it, and returns its
+// context and the function to call once the run is over.
+func (e *KAgentExecutor) startRun(ctx context.Context, taskID a2atype.TaskID) (context.Context, func()) {
+	runCtx, cancel := context.WithCancelCause(ctx)
+	run := &taskRun{cancel: cancel}
+	e.runs.Store(taskID, run)
+	return runCtx, func() {
+		e.runs.CompareAndDelete(taskID, run)
+		cancel(nil)
+	}
+}
+
+// cancelRun stops the run of taskID, if one is in progress. The model
+// request and tool calls of the run are aborted through its context.
+func (e *KAgentExecutor) cancelRun(taskID a2atype.TaskID) bool {
+	run, ok := e.runs.Load(taskID)
+	if ok {
+		run.(*taskRun).cancel(errTaskCanceled)
+	}
+	return ok
+}
+
+// closeCanceledInvocation records a canceled result for each tool call the
+// invocation left without one, so the next turn of the session doesn't send
+// the model a tool call without its result, which providers reject.
+func (e *KAgentExecutor) closeCanceledInvocation(ctx context.Context, userID, sessionID, invocationID string) error {
+	if e.sessionService == nil || invocationID == "" {
+		return nil
+	}
+	// The session is updated after the run's context is canceled.
+	ctx = context.WithoutCancel(ctx)
+	resp, err := e.sessionService.Get(ctx, &adksession.GetRequest{AppName: e.appName, UserID: userID, SessionID: sessionID})
+	if err != nil {
+		return fmt.Errorf("failed to get session: %w", err)
+	}
+
+	var calls []*adksession.Event
+	answered := map[string]bool{}
+	for event := range resp.Session.Events().All() {
+		if event.InvocationID != invocationID || event.Content == nil {
+			continue
+		}
+		for _, part := range event.Content.Parts {
+			switch {
+			case part == nil:
+			case part.FunctionCall != nil:
+				calls = append(calls, event)
+			case part.FunctionResponse != nil:
+				answered[part.FunctionResponse.ID] = true
+			}
+		}
+	}
+
+	for _, call := range calls {
+		var parts []*genai.Part
+		for _, part := range call.Content.Parts {
+			if part == nil || part.FunctionCall == nil || answered[part.FunctionCall.ID] {
+				continue
+			}
+			answered[part.FunctionCall.ID] = true
+			parts = append(parts, &genai.Part{FunctionResponse: &genai.FunctionResponse{
+				ID:       part.FunctionCall.ID,
+				Name:     part.FunctionCall.Name,
+				Response: canceledToolResponse,
+			}})
+		}
+		if len(parts) == 0 {
+			continue
+		}
+		event := adksession.NewEvent(ctx, invocationID)
+		event.Author = call.Author
+		event.Branch = call.Branch
+		event.IsolationScope = call.IsolationScope
+		event.Content = genai.NewContentFromParts(parts, genai.RoleUser)
+		if err := e.sessionService.AppendEvent(ctx, resp.Session, event); err != nil {
+			return fmt.Errorf("failed to record canceled tool calls: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package a2a
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	a2atype "github.com/a2aproject/a2a-go/a2a"
+	"github.com/go-logr/logr"
+	adksession "google.golang.org/adk/v2/session"
+	"google.golang.org/genai"
+)
+
+func TestCancelRun_StopsRunningTask(t *testing.T) {
+	e := NewKAgentExecutor(KAgentExecutorConfig{Logger: logr.Discard()})
+	taskID := a2atype.TaskID("task-1")
+
+	runCtx, endRun := e.startRun(context.Background(), taskID)
+	if !e.cancelRun(taskID) {
+		t.Fatal("cancelRun did not find the running task")
+	}
+	if !errors.Is(context.Cause(runCtx), errTaskCanceled) {
+		t.Errorf("cause = %v, want %v", context.Cause(runCtx), errTaskCanceled)
+	}
+
+	endRun()
+	if e.cancelRun(taskID) {
+		t.Error("cancelRun found a task whose run is over")
+	}
+}
+
+func TestCloseCanceledInvocation_AnswersPendingToolCalls(t *testing.T) {
+	ctx := context.Background()
+	sessions := adksession.InMemoryService()
+	e := NewKAgentExecutor(KAgentExecutorConfig{SessionService: sessions, AppName: "app", Logger: logr.Discard()})
+
+	created, err := sessions.Create(ctx, &adksession.CreateRequest{AppName: "app", UserID: "user", SessionID: "session"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	appendEvent := func(invocationID string, role genai.Role, parts ...*genai.Part) {
+		event := adksession.NewEvent(ctx, invocationID)
+		event.Author = "agent"
+		event.Content = genai.NewContentFromParts(parts, role)
+		if err := sessions.AppendEvent(ctx, created.Session, event); err != nil {
+			t.Fatal(err)
+		}
+	}
+	appendEvent("inv-1", genai.RoleModel,
+		&genai.Part{FunctionCall: &genai.FunctionCall{ID: "call-done", Name: "get_pods"}},
+		&genai.Part{FunctionCall: &genai.FunctionCall{ID: "call-pending", Name: "get_logs"}},
+	)
+	appendEvent("inv-1", genai.RoleUser,
+		&genai.Part{FunctionResponse: &genai.FunctionResponse{ID: "call-done", Name: "get_pods", Response: map[string]any{"result": "ok"}}},
+	)
+	appendEvent("inv-0", genai.RoleModel,
+		&genai.Part{FunctionCall: &genai.FunctionCall{ID: "call-other", Name: "get_pods"}},
+	)
+
+	canceled, stop := context.WithCancel(ctx)
+	stop()
+	if err := e.closeCanceledInvocation(canceled, "user", "session", "inv-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := sessions.Get(ctx, &adksession.GetRequest{AppName: "app", UserID: "user", SessionID: "session"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var responses []*genai.FunctionResponse
+	for event := range resp.Session.Events().All() {
+		for _, part := range event.Content.Parts {
+			if part.FunctionResponse != nil {
+				responses = append(responses, part.FunctionResponse)
+			}
+		}
+	}
+	if len(responses) != 2 {
+		t.Fatalf("got %d function responses, want 2", len(responses))
+	}
+	last := responses[1]
+	if last.ID != "call-pending" || last.Name != "get_logs" || last.Response["error"] == nil {
+		t.Errorf("canceled response = %+v, want an error result for call-pending", last)
+	}
+}
@@ -13,8 +13,13 @@ const (
 	A2ADataPartMetadataIsLongRunningKey     = "is_long_running"
 	A2ADataPartMetadataTypeFunctionCall     = "function_call"
 	A2ADataPartMetadataTypeFunctionResponse = "function_response"
+	A2ADataPartMetadataTypeToolProgress     = "tool_progress"
 )
 
+// StreamSequenceMetadataKey, prefixed with kagent_, numbers the partial text
+// events of each run of a task from 1, in the order the model streamed them.
+const StreamSequenceMetadataKey = "stream_sequence"
+
 // DataPart map keys for GenAI-style function call / response content.
 const (
 	PartKeyName     = "name"
@@ -23,6 +28,14 @@ const (
 	PartKeyID       = "id"
 )
 
+// DataPart map keys of tool progress updates, besides PartKeyName and
+// PartKeyID.
+const (
+	PartKeyProgress = "progress"
+	PartKeyTotal    = "total"
+	PartKeyMessage  = "message"
+)
+
 // HITL batch/rejection/ask-user constants.
 const (
 	KAgentHitlDecisionTypeBatch   = "batch"
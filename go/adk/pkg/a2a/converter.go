@@ -6,6 +6,9 @@ import (
 	"maps"
 
 	a2atype "github.com/a2aproject/a2a-go/a2a"
+	"github.com/kagent-dev/kagent/go/adk/pkg/budget"
+	"github.com/kagent-dev/kagent/go/adk/pkg/models"
+	"github.com/kagent-dev/kagent/go/adk/pkg/moderation"
 	"google.golang.org/adk/v2/server/adka2a" //nolint:staticcheck // kagent still uses a2a-go v1; this ADK package is the compatibility adapter.
 	adksession "google.golang.org/adk/v2/session"
 	"google.golang.org/genai"
@@ -192,5 +195,18 @@ func buildEventMeta(baseMeta map[string]any, adkEvent *adksession.Event) map[str
 	if adkEvent.ErrorCode != "" {
 		result[adka2a.ToA2AMetaKey("error_code")] = adkEvent.ErrorCode
 	}
+	// Set by models.FallbackLLM when the agent has model fallbacks.
+	if served, ok := adkEvent.CustomMetadata[models.ServedModelMetadataKey].(string); ok {
+		result[adka2a.ToA2AMetaKey(models.ServedModelMetadataKey)] = served
+	}
+	// Set by moderation.Moderator when a message fails moderation.
+	if verdict, ok := adkEvent.CustomMetadata[moderation.MetadataKey]; ok {
+		result[adka2a.ToA2AMetaKey(moderation.MetadataKey)] = verdict
+	}
+	// Set by budget.BeforeModelCallback on the summary of a task that ran out
+	// of budget.
+	if exceeded, ok := adkEvent.CustomMetadata[budget.ExceededMetadataKey]; ok {
+		result[adka2a.ToA2AMetaKey(budget.ExceededMetadataKey)] = exceeded
+	}
 	return result
 }
@@ -2,19 +2,27 @@ package a2a
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"maps"
 	"os"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	a2atype "github.com/a2aproject/a2a-go/a2a"
 	"github.com/a2aproject/a2a-go/a2asrv"
 	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
 	"github.com/go-logr/logr"
 	"github.com/kagent-dev/kagent/go/adk/pkg/auth"
+	"github.com/kagent-dev/kagent/go/adk/pkg/budget"
+	"github.com/kagent-dev/kagent/go/adk/pkg/dryrun"
 	"github.com/kagent-dev/kagent/go/adk/pkg/models"
 	"github.com/kagent-dev/kagent/go/adk/pkg/skills"
 	"github.com/kagent-dev/kagent/go/adk/pkg/telemetry"
+	"github.com/kagent-dev/kagent/go/adk/pkg/toolprogress"
+	"github.com/kagent-dev/kagent/go/api/adk"
 	"go.opentelemetry.io/otel/attribute"
 	adkagent "google.golang.org/adk/v2/agent"
 	"google.golang.org/adk/v2/runner"
@@ -36,18 +44,33 @@ type KAgentExecutorConfig struct {
 	Stream             bool
 	AppName            string
 	SkillsDirectory    string
-	Logger             logr.Logger
+	// TaskTimeout bounds each run of the agent on a task. Zero means no bound.
+	TaskTimeout time.Duration
+	// Budget bounds the tokens, tool calls and model turns of each run of
+	// the agent on a task. Nil means no bound.
+	Budget *adk.BudgetConfig
+	Logger logr.Logger
 }
 
 // KAgentExecutor implements a2asrv.AgentExecutor
 type KAgentExecutor struct {
+	// agent is swapped by SetRunnerConfig when the agent config is reloaded.
+	agent           atomic.Pointer[executorAgent]
+	sessionService  adksession.Service
+	stream          bool
+	appName         string
+	skillsDirectory string
+	logger          logr.Logger
+	// runs holds the *taskRun of each task being run, by task ID.
+	runs sync.Map
+}
+
+// executorAgent is the runner config an invocation runs with.
+type executorAgent struct {
 	runnerConfig       runner.Config
 	subagentSessionIDs map[string]string
-	sessionService     adksession.Service
-	stream             bool
-	appName            string
-	skillsDirectory    string
-	logger             logr.Logger
+	taskTimeout        time.Duration
+	budget             *adk.BudgetConfig
 }
 
 var _ a2asrv.AgentExecutor = (*KAgentExecutor)(nil)
@@ -61,15 +84,21 @@ func NewKAgentExecutor(cfg KAgentExecutorConfig) *KAgentExecutor {
 	if skillsDir == "" {
 		skillsDir = defaultSkillsDirectory
 	}
-	return &KAgentExecutor{
-		runnerConfig:       cfg.RunnerConfig,
-		subagentSessionIDs: cfg.SubagentSessionIDs,
-		sessionService:     cfg.SessionService,
-		stream:             cfg.Stream,
-		appName:            cfg.AppName,
-		skillsDirectory:    skillsDir,
-		logger:             cfg.Logger.WithName("kagent-executor"),
+	e := &KAgentExecutor{
+		sessionService:  cfg.SessionService,
+		stream:          cfg.Stream,
+		appName:         cfg.AppName,
+		skillsDirectory: skillsDir,
+		logger:          cfg.Logger.WithName("kagent-executor"),
 	}
+	e.SetRunnerConfig(cfg.RunnerConfig, cfg.SubagentSessionIDs, cfg.TaskTimeout, cfg.Budget)
+	return e
+}
+
+// SetRunnerConfig replaces the runner config of the agent. Invocations
+// already running finish with the config they started with.
+func (e *KAgentExecutor) SetRunnerConfig(runnerConfig runner.Config, subagentSessionIDs map[string]string, taskTimeout time.Duration, budget *adk.BudgetConfig) {
+	e.agent.Store(&executorAgent{runnerConfig: runnerConfig, subagentSessionIDs: subagentSessionIDs, taskTimeout: taskTimeout, budget: budget})
 }
 
 // UserIDCallInterceptor returns an a2asrv.CallInterceptor that extracts the
@@ -127,6 +156,27 @@ func newAgentStatusEvent(reqCtx *a2asrv.RequestContext, parts a2atype.ContentPar
 	return statusEv
 }
 
+// newToolProgressEvent builds a working TaskStatusUpdateEvent whose agent
+// message carries a tool_progress DataPart with the progress a running tool
+// reported, keyed to its function call like the function_call part.
+func newToolProgressEvent(reqCtx *a2asrv.RequestContext, u toolprogress.Update, meta map[string]any) *a2atype.TaskStatusUpdateEvent {
+	data := map[string]any{
+		PartKeyName:     u.Tool,
+		PartKeyID:       u.FunctionCallID,
+		PartKeyProgress: u.Progress,
+		PartKeyMessage:  u.Message,
+	}
+	if u.Total > 0 {
+		data[PartKeyTotal] = u.Total
+	}
+	return newAgentStatusEvent(reqCtx, a2atype.ContentParts{a2atype.DataPart{
+		Data: data,
+		Metadata: map[string]any{
+			GetKAgentMetadataKey(A2ADataPartMetadataTypeKey): A2ADataPartMetadataTypeToolProgress,
+		},
+	}}, meta)
+}
+
 // Execute implements a2asrv.AgentExecutor.
 // It follows the Python _handle_request pattern: set up session, handle HITL,
 // convert inbound message, run the agent loop, and emit A2A events.
@@ -169,6 +219,16 @@ func (e *KAgentExecutor) Execute(ctx context.Context, reqCtx *a2asrv.RequestCont
 
 	telemetry.SetMessageMetadataAttributes(ctx, reqCtx.Message.Metadata)
 
+	// A dry-run invocation previews mutating tool calls instead of running them.
+	dryRun := false
+	if v, ok := ReadMetadataValue(reqCtx.Message.Metadata, dryrun.MetadataKey); ok {
+		dryRun, _ = v.(bool)
+	}
+	if dryRun {
+		ctx = dryrun.NewContext(ctx)
+		invocationSpan.SetAttributes(attribute.Bool("kagent.dry_run", true))
+	}
+
 	// 3. Initialize skills session path.
 	if e.skillsDirectory != "" && sessionID != "" {
 		if _, err := skills.InitializeSessionPath(sessionID, e.skillsDirectory); err != nil {
@@ -178,8 +238,8 @@ func (e *KAgentExecutor) Execute(ctx context.Context, reqCtx *a2asrv.RequestCont
 	}
 
 	// 4. Create / lookup session via sessionService.
+	var sess adksession.Session
 	if e.sessionService != nil {
-		var sess adksession.Session
 		resp, err := e.sessionService.Get(ctx, &adksession.GetRequest{AppName: e.appName, UserID: userID, SessionID: sessionID})
 		if err != nil {
 			e.logger.V(1).Info("Session lookup failed, will create", "error", err, "sessionID", sessionID)
@@ -219,10 +279,29 @@ func (e *KAgentExecutor) Execute(ctx context.Context, reqCtx *a2asrv.RequestCont
 	}
 
 	// 7. Use pre-built subagent session ID map (built by runner bundle).
-	subagentSessionIDs := e.subagentSessionIDs
+	agent := e.agent.Load()
+	subagentSessionIDs := agent.subagentSessionIDs
+
+	// The budget of the agent, tightened by the invocation's.
+	limits := agent.budget
+	if v, ok := ReadMetadataValue(reqCtx.Message.Metadata, budget.MetadataKey); ok {
+		override, err := budget.ParseOverride(v)
+		if err != nil {
+			return fmt.Errorf("invalid %s metadata: %w", budget.MetadataKey, err)
+		}
+		limits = limits.Tighten(override)
+	}
+	var sessionTokens int64
+	if sess != nil && limits.Enabled() && limits.MaxSessionTokens != nil {
+		sessionTokens = budget.SessionTokens(sess.Events().All())
+	}
+	tracker := budget.New(limits, sessionTokens)
+	if tracker != nil {
+		ctx = budget.NewContext(ctx, tracker)
+	}
 
 	// 8. Create runner.
-	r, err := runner.New(e.runnerConfig)
+	r, err := runner.New(agent.runnerConfig)
 	if err != nil {
 		return fmt.Errorf("failed to create runner: %w", err)
 	}
@@ -251,6 +330,9 @@ func (e *KAgentExecutor) Execute(ctx context.Context, reqCtx *a2asrv.RequestCont
 		adka2a.ToA2AMetaKey("user_id"):    userID,
 		adka2a.ToA2AMetaKey("session_id"): sessionID,
 	}
+	if dryRun {
+		baseMeta[adka2a.ToA2AMetaKey(dryrun.MetadataKey)] = true
+	}
 
 	working := a2atype.NewStatusUpdateEvent(reqCtx, a2atype.TaskStateWorking, nil)
 	working.Metadata = maps.Clone(baseMeta)
@@ -269,10 +351,29 @@ func (e *KAgentExecutor) Execute(ctx context.Context, reqCtx *a2asrv.RequestCont
 		invocationID        string
 		lastNonPartialParts a2atype.ContentParts
 		hitlParts           a2atype.ContentParts
+		streamSequence      int
 		runErr              error
 	)
 
-	for adkEvent, adkErr := range r.Run(ctx, userID, sessionID, content, runConfig) {
+	// Cancellation and the task timeout only stop the run; events are still
+	// written with ctx so the outcome can be reported.
+	runCtx, endRun := e.startRun(ctx, reqCtx.TaskID)
+	defer endRun()
+	if agent.taskTimeout > 0 {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithTimeout(ctx, agent.taskTimeout)
+		defer cancel()
+	}
+
+	// Long-running tools report their progress while the agent waits for
+	// them; relay it as working status updates.
+	runCtx = toolprogress.WithReporter(runCtx, func(u toolprogress.Update) {
+		if err := queue.Write(ctx, newToolProgressEvent(reqCtx, u, maps.Clone(baseMeta))); err != nil {
+			e.logger.V(1).Info("Failed to write tool progress event", "taskID", reqCtx.TaskID, "tool", u.Tool, "error", err)
+		}
+	})
+
+	for adkEvent, adkErr := range r.Run(runCtx, userID, sessionID, content, runConfig) {
 		if adkErr != nil {
 			runErr = adkErr
 			break
@@ -351,10 +452,14 @@ func (e *KAgentExecutor) Execute(ctx context.Context, reqCtx *a2asrv.RequestCont
 			// so we don't need to emit a separate partial artifact update.
 			// However, this is done here in order to match the Python executor's behavior.
 			// Go ADK executor also uses different A2A response formats than Python ADK.
+			// Each delta is written as soon as the model yields it, numbered so
+			// consumers can order and deduplicate chunks.
 			textOnly := filterTextParts(a2aParts)
 			if len(textOnly) > 0 {
+				streamSequence++
 				mirrorMeta := maps.Clone(eventMeta)
 				mirrorMeta[adka2a.ToA2AMetaKey("partial")] = true
+				mirrorMeta[GetKAgentMetadataKey(StreamSequenceMetadataKey)] = streamSequence
 				statusEv := newAgentStatusEvent(reqCtx, textOnly, mirrorMeta)
 				if err := queue.Write(ctx, statusEv); err != nil {
 					return fmt.Errorf("failed to write partial status event: %w", err)
@@ -378,11 +483,28 @@ func (e *KAgentExecutor) Execute(ctx context.Context, reqCtx *a2asrv.RequestCont
 		}
 	}
 
+	if errors.Is(context.Cause(runCtx), errTaskCanceled) {
+		// Cancel reports the canceled state.
+		if err := e.closeCanceledInvocation(ctx, userID, sessionID, invocationID); err != nil {
+			e.logger.Error(err, "Failed to clean up the session of a canceled task", "taskID", reqCtx.TaskID, "sessionID", sessionID)
+		}
+		return nil
+	}
+	if ctx.Err() == nil && errors.Is(runCtx.Err(), context.DeadlineExceeded) {
+		runErr = fmt.Errorf("task timed out after %s", agent.taskTimeout)
+	}
+
 	// 11. Emit final event.
 	finalMeta := maps.Clone(baseMeta)
 	if invocationID != "" {
 		finalMeta[adka2a.ToA2AMetaKey("invocation_id")] = invocationID
 	}
+	if exceeded := tracker.Exceeded(); exceeded != nil {
+		// The summary of the run was its final response.
+		e.logger.Info("Task ran out of budget", "taskID", reqCtx.TaskID, "limit", exceeded.Limit, "max", exceeded.Max, "used", exceeded.Used)
+		invocationSpan.SetAttributes(attribute.String("kagent.budget_exceeded", exceeded.Limit))
+		finalMeta[adka2a.ToA2AMetaKey(budget.ExceededMetadataKey)] = exceeded.Metadata()
+	}
 
 	if runErr != nil {
 		errMsg := newAgentMessage(reqCtx, a2atype.TextPart{Text: runErr.Error()})
@@ -416,8 +538,12 @@ func (e *KAgentExecutor) Execute(ctx context.Context, reqCtx *a2asrv.RequestCont
 	return queue.Write(ctx, completed)
 }
 
-// Cancel implements a2asrv.AgentExecutor.
+// Cancel implements a2asrv.AgentExecutor. A run of the task in progress is
+// stopped, aborting its model request and tool calls.
 func (e *KAgentExecutor) Cancel(ctx context.Context, reqCtx *a2asrv.RequestContext, queue eventqueue.Queue) error {
+	if e.cancelRun(reqCtx.TaskID) {
+		e.logger.Info("Canceled task run", "taskID", reqCtx.TaskID)
+	}
 	event := a2atype.NewStatusUpdateEvent(reqCtx, a2atype.TaskStateCanceled, nil)
 	event.Final = true
 	return queue.Write(ctx, event)
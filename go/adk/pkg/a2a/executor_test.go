@@ -1,10 +1,26 @@
 package a2a
 
 import (
+	"context"
+	"iter"
+	"maps"
+	"slices"
+	"strings"
 	"testing"
 
 	a2atype "github.com/a2aproject/a2a-go/a2a"
 	"github.com/a2aproject/a2a-go/a2asrv"
+	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
+	"github.com/go-logr/logr"
+	"github.com/kagent-dev/kagent/go/adk/pkg/toolprogress"
+	adkagent "google.golang.org/adk/v2/agent"
+	"google.golang.org/adk/v2/agent/llmagent"
+	adkmodel "google.golang.org/adk/v2/model"
+	"google.golang.org/adk/v2/runner"
+	adksession "google.golang.org/adk/v2/session"
+	"google.golang.org/adk/v2/tool"
+	"google.golang.org/adk/v2/tool/functiontool"
+	"google.golang.org/genai"
 )
 
 // TestNewAgentMessage_StampsContextAndTaskID verifies agent messages carry the
@@ -65,3 +81,160 @@ func TestNewAgentStatusEvent_MessageCarriesIDs(t *testing.T) {
 		t.Errorf("event TaskID = %q, want %q", ev.TaskID, a2atype.TaskID("task-xyz"))
 	}
 }
+
+// streamingLLM streams its text as one partial response per chunk, followed
+// by the aggregated final response.
+type streamingLLM struct {
+	chunks []string
+}
+
+func (streamingLLM) Name() string { return "streaming-model" }
+
+func (m streamingLLM) GenerateContent(_ context.Context, _ *adkmodel.LLMRequest, stream bool) iter.Seq2[*adkmodel.LLMResponse, error] {
+	return func(yield func(*adkmodel.LLMResponse, error) bool) {
+		if stream {
+			for _, chunk := range m.chunks {
+				if !yield(&adkmodel.LLMResponse{Partial: true, Content: genai.NewContentFromText(chunk, genai.RoleModel)}, nil) {
+					return
+				}
+			}
+		}
+		yield(&adkmodel.LLMResponse{TurnComplete: true, Content: genai.NewContentFromText(strings.Join(m.chunks, ""), genai.RoleModel)}, nil)
+	}
+}
+
+// recordingQueue records the events written to it.
+type recordingQueue struct {
+	eventqueue.Queue
+	events []a2atype.Event
+}
+
+func (q *recordingQueue) Write(_ context.Context, event a2atype.Event) error {
+	q.events = append(q.events, event)
+	return nil
+}
+
+func TestExecute_RelaysPartialTextInOrder(t *testing.T) {
+	agent, err := llmagent.New(llmagent.Config{Name: "k8s_agent", Model: streamingLLM{chunks: []string{"Pods ", "are ", "running."}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sessions := adksession.InMemoryService()
+	e := NewKAgentExecutor(KAgentExecutorConfig{
+		RunnerConfig:    runner.Config{AppName: "app", Agent: agent, SessionService: sessions},
+		SessionService:  sessions,
+		Stream:          true,
+		AppName:         "app",
+		SkillsDirectory: t.TempDir(),
+		Logger:          logr.Discard(),
+	})
+	reqCtx := &a2asrv.RequestContext{
+		ContextID: "ctx-1",
+		TaskID:    a2atype.TaskID("task-1"),
+		Message:   a2atype.NewMessage(a2atype.MessageRoleUser, a2atype.TextPart{Text: "are my pods healthy?"}),
+	}
+	queue := &recordingQueue{}
+	if err := e.Execute(context.Background(), reqCtx, queue); err != nil {
+		t.Fatal(err)
+	}
+
+	var deltas []string
+	var sequences []any
+	var mirrored string
+	for _, event := range queue.events {
+		statusEv, ok := event.(*a2atype.TaskStatusUpdateEvent)
+		if !ok || statusEv.Status.State != a2atype.TaskStateWorking || statusEv.Status.Message == nil {
+			continue
+		}
+		text := statusEv.Status.Message.Parts[0].(a2atype.TextPart).Text
+		if partial, _ := ReadMetadataValue(statusEv.Metadata, "partial"); partial == true {
+			deltas = append(deltas, text)
+			sequences = append(sequences, statusEv.Metadata[GetKAgentMetadataKey(StreamSequenceMetadataKey)])
+		} else {
+			mirrored = text
+		}
+	}
+	if want := []string{"Pods ", "are ", "running."}; !slices.Equal(deltas, want) {
+		t.Errorf("partial deltas = %q, want %q", deltas, want)
+	}
+	if want := []any{1, 2, 3}; !slices.Equal(sequences, want) {
+		t.Errorf("sequences = %v, want %v", sequences, want)
+	}
+	if mirrored != "Pods are running." {
+		t.Errorf("final message = %q, want the aggregated text", mirrored)
+	}
+	last := queue.events[len(queue.events)-1].(*a2atype.TaskStatusUpdateEvent)
+	if last.Status.State != a2atype.TaskStateCompleted || !last.Final {
+		t.Errorf("last event state = %q final = %v, want a final completed event", last.Status.State, last.Final)
+	}
+}
+
+// toolCallingLLM asks for a call of tool, then answers with text once the
+// tool's response is in the request.
+type toolCallingLLM struct {
+	tool string
+}
+
+func (toolCallingLLM) Name() string { return "tool-calling-model" }
+
+func (m toolCallingLLM) GenerateContent(_ context.Context, req *adkmodel.LLMRequest, _ bool) iter.Seq2[*adkmodel.LLMResponse, error] {
+	return func(yield func(*adkmodel.LLMResponse, error) bool) {
+		last := req.Contents[len(req.Contents)-1]
+		if last.Parts[0].FunctionResponse != nil {
+			yield(&adkmodel.LLMResponse{TurnComplete: true, Content: genai.NewContentFromText("Upgraded.", genai.RoleModel)}, nil)
+			return
+		}
+		call := genai.NewContentFromFunctionCall(m.tool, map[string]any{}, genai.RoleModel)
+		call.Parts[0].FunctionCall.ID = "call-1"
+		yield(&adkmodel.LLMResponse{TurnComplete: true, Content: call}, nil)
+	}
+}
+
+func TestExecute_RelaysToolProgress(t *testing.T) {
+	helmUpgrade, err := functiontool.New(functiontool.Config{Name: "helm_upgrade", Description: "Upgrade a release"},
+		func(ctx adkagent.Context, _ map[string]any) (map[string]any, error) {
+			toolprogress.Report(ctx, toolprogress.Update{Tool: "helm_upgrade", FunctionCallID: ctx.FunctionCallID(), Progress: 1, Total: 2, Message: "Waiting for rollout"})
+			return map[string]any{"status": "deployed"}, nil
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+	agent, err := llmagent.New(llmagent.Config{Name: "helm_agent", Model: toolCallingLLM{tool: "helm_upgrade"}, Tools: []tool.Tool{helmUpgrade}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sessions := adksession.InMemoryService()
+	e := NewKAgentExecutor(KAgentExecutorConfig{
+		RunnerConfig:    runner.Config{AppName: "app", Agent: agent, SessionService: sessions},
+		SessionService:  sessions,
+		AppName:         "app",
+		SkillsDirectory: t.TempDir(),
+		Logger:          logr.Discard(),
+	})
+	reqCtx := &a2asrv.RequestContext{
+		ContextID: "ctx-1",
+		TaskID:    a2atype.TaskID("task-1"),
+		Message:   a2atype.NewMessage(a2atype.MessageRoleUser, a2atype.TextPart{Text: "upgrade istio"}),
+	}
+	queue := &recordingQueue{}
+	if err := e.Execute(context.Background(), reqCtx, queue); err != nil {
+		t.Fatal(err)
+	}
+
+	var progress []map[string]any
+	for _, event := range queue.events {
+		statusEv, ok := event.(*a2atype.TaskStatusUpdateEvent)
+		if !ok || statusEv.Status.State != a2atype.TaskStateWorking || statusEv.Status.Message == nil {
+			continue
+		}
+		for _, part := range statusEv.Status.Message.Parts {
+			if dp, ok := part.(a2atype.DataPart); ok && dp.Metadata[GetKAgentMetadataKey(A2ADataPartMetadataTypeKey)] == A2ADataPartMetadataTypeToolProgress {
+				progress = append(progress, dp.Data)
+			}
+		}
+	}
+	want := map[string]any{PartKeyName: "helm_upgrade", PartKeyID: "call-1", PartKeyProgress: 1.0, PartKeyTotal: 2.0, PartKeyMessage: "Waiting for rollout"}
+	if len(progress) != 1 || !maps.Equal(progress[0], want) {
+		t.Errorf("tool progress = %v, want [%v]", progress, want)
+	}
+}
@@ -13,6 +13,7 @@ import (
 	a2atype "github.com/a2aproject/a2a-go/a2a"
 	"github.com/a2aproject/a2a-go/a2asrv"
 	"github.com/go-logr/logr"
+	apiutils "github.com/kagent-dev/kagent/go/api/utils"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
 
@@ -21,6 +22,8 @@ type ServerConfig struct {
 	Host            string
 	Port            string
 	ShutdownTimeout time.Duration
+	// Streaming tunes how SSE responses are flushed and bounded.
+	Streaming apiutils.StreamOptions
 }
 
 // A2AServer wraps the A2A server with health endpoints and graceful shutdown.
@@ -39,7 +42,7 @@ func NewA2AServer(agentCard a2atype.AgentCard, executor a2asrv.AgentExecutor, lo
 	mux := http.NewServeMux()
 	RegisterHealthEndpoints(mux)
 	mux.Handle(a2asrv.WellKnownAgentCardPath, a2asrv.NewStaticAgentCardHandler(&agentCard))
-	mux.Handle("/", jsonrpcHandler)
+	mux.Handle("/", apiutils.NewStreamHandler(jsonrpcHandler, config.Streaming))
 	// Wrap the whole server mux to enable trace context extraction and an inbound
 	// HTTP server span for each request.
 	instrumentedHandler := otelhttp.NewHandler(
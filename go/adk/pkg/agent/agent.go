@@ -6,17 +6,25 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/kagent-dev/kagent/go/adk/pkg/budget"
+	"github.com/kagent-dev/kagent/go/adk/pkg/dryrun"
 	"github.com/kagent-dev/kagent/go/adk/pkg/mcp"
 	"github.com/kagent-dev/kagent/go/adk/pkg/models"
+	"github.com/kagent-dev/kagent/go/adk/pkg/moderation"
+	"github.com/kagent-dev/kagent/go/adk/pkg/promptcapture"
+	"github.com/kagent-dev/kagent/go/adk/pkg/promptguard"
 	"github.com/kagent-dev/kagent/go/adk/pkg/sts"
+	"github.com/kagent-dev/kagent/go/adk/pkg/toolresult"
 	"github.com/kagent-dev/kagent/go/adk/pkg/tools"
+	"github.com/kagent-dev/kagent/go/adk/pkg/toolschema"
+	"github.com/kagent-dev/kagent/go/adk/pkg/tooltimeout"
 	"github.com/kagent-dev/kagent/go/api/adk"
 	"google.golang.org/adk/v2/agent"
 	"google.golang.org/adk/v2/agent/llmagent"
 	adkmodel "google.golang.org/adk/v2/model"
-	adkgemini "google.golang.org/adk/v2/model/gemini"
 	"google.golang.org/adk/v2/tool"
 	"google.golang.org/adk/v2/tool/loadmemorytool"
 	"google.golang.org/adk/v2/tool/preloadmemorytool"
@@ -30,6 +38,9 @@ const (
 	DefaultOllamaModel    = "llama3.2"
 )
 
+// prewarmTimeout bounds the startup prewarm of the model provider connection.
+const prewarmTimeout = 30 * time.Second
+
 // CreateGoogleADKAgent creates a Google ADK agent from AgentConfig.
 // agentName is used as the ADK agent identity (appears in event Author field).
 // extraTools are appended to the agent's tool list (e.g. save_memory).
@@ -50,12 +61,24 @@ func CreateGoogleADKAgentWithSubagentSessionIDs(ctx context.Context, agentConfig
 		return nil, nil, fmt.Errorf("agent config is required")
 	}
 
+	if agentName == "" {
+		agentName = "agent"
+	}
+
+	if agentConfig.Workflow != nil && agentConfig.Workflow.Pattern != adk.WorkflowPatternRouter {
+		workflowAgent, err := createWorkflowAgent(agentConfig, agentName, log)
+		if err != nil {
+			return nil, nil, err
+		}
+		return workflowAgent, map[string]string{}, nil
+	}
+
 	propagateToken := strings.ToLower(os.Getenv("KAGENT_PROPAGATE_TOKEN")) == "true"
 	var dynamicHeaderProvider mcp.DynamicHeaderProvider
 	if stsPlugin != nil {
 		dynamicHeaderProvider = stsPlugin.HeaderProvider
 	}
-	toolsets := mcp.CreateToolsets(ctx, agentConfig.HttpTools, agentConfig.SseTools, propagateToken, dynamicHeaderProvider)
+	toolsets := mcp.CreateToolsets(ctx, agentConfig.HttpTools, agentConfig.SseTools, propagateToken, dynamicHeaderProvider, agentConfig.ToolPolicy)
 	mcpAppToolNames := mcp.MCPAppToolNamesFromToolsets(toolsets)
 	subagentSessionIDs := make(map[string]string)
 
@@ -89,9 +112,83 @@ func CreateGoogleADKAgentWithSubagentSessionIDs(ctx context.Context, agentConfig
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create LLM: %w", err)
 	}
+	prewarmLLM(ctx, llmModel, log)
+	// Each model in the fallback chain gets the whole timeout, so a request
+	// that times out can still fail over.
+	llmTimeout := agentConfig.Timeouts.LLMRequestTimeout()
+	llmModel = models.NewTimeoutLLM(llmModel, llmTimeout)
+	var fallbacks []models.Fallback
+	for i, fallback := range agentConfig.ModelFallbacks {
+		fallbackModel, err := CreateLLM(ctx, fallback.Model, log)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create fallback LLM %d: %w", i, err)
+		}
+		fallbacks = append(fallbacks, models.Fallback{LLM: models.NewTimeoutLLM(fallbackModel, llmTimeout), Triggers: fallback.Triggers})
+	}
+	if llmTimeout > 0 {
+		log.Info("Model request timeout enabled", "timeout", llmTimeout)
+	}
+	if len(fallbacks) > 0 {
+		llmModel = models.NewFallbackLLM(llmModel, fallbacks, log)
+		log.Info("Model fallbacks enabled", "count", len(fallbacks))
+	}
 
-	if agentName == "" {
-		agentName = "agent"
+	if agentConfig.PromptCapture != nil {
+		recorder, err := promptcapture.NewRecorder(agentConfig.PromptCapture, agentName, log)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to set up prompt capture: %w", err)
+		}
+		llmModel = recorder.Wrap(llmModel)
+		log.Info("Prompt capture enabled", "path", agentConfig.PromptCapture.Path, "sampleRate", agentConfig.PromptCapture.SampleRate)
+	}
+
+	afterToolCallbacks := []llmagent.AfterToolCallback{makeAfterToolCallback(log)}
+	if agentConfig.PromptInjection != nil {
+		guard, err := promptguard.New(agentConfig.PromptInjection, log)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to set up prompt injection detection: %w", err)
+		}
+		// Runs after the logging callback, which always passes the result through.
+		afterToolCallbacks = append(afterToolCallbacks, guard.AfterToolCallback())
+		log.Info("Prompt injection detection enabled", "action", agentConfig.PromptInjection.Action)
+	}
+
+	// Timeouts bound the tool call itself, not the handling of its result.
+	if limiter := tooltimeout.New(agentConfig.Timeouts, log); limiter.Enabled() {
+		for i, ts := range toolsets {
+			toolsets[i] = limiter.Wrap(ts)
+		}
+		log.Info("Tool call timeouts enabled", "overrides", len(agentConfig.Timeouts.Tools))
+	}
+
+	// Results are validated before they are limited, so the schema sees the
+	// whole result.
+	if agentConfig.ToolResultValidation != nil {
+		validator := toolschema.New(agentConfig.ToolResultValidation, log)
+		for i, ts := range toolsets {
+			toolsets[i] = validator.Wrap(ts)
+		}
+		log.Info("Tool result validation enabled", "coerce", agentConfig.ToolResultValidation.Coerce)
+	}
+
+	if agentConfig.ToolResultLimit != nil {
+		limiter, err := toolresult.New(agentConfig.ToolResultLimit, llmModel, log)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to set up tool result limit: %w", err)
+		}
+		// MCP App tool names were collected above; wrapping hides the
+		// toolsets' concrete type from later lookups.
+		for i, ts := range toolsets {
+			toolsets[i] = limiter.Wrap(ts)
+		}
+		if limiter.Strategy() == adk.ToolResultStrategyPaginate {
+			pageTool, err := limiter.PageTool()
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to create %s tool: %w", toolresult.PageToolName, err)
+			}
+			localTools = append(localTools, pageTool)
+		}
+		log.Info("Tool result limit enabled", "maxBytes", agentConfig.ToolResultLimit.MaxBytes, "strategy", limiter.Strategy())
 	}
 
 	// Collect tool names that require approval from HttpTools and SseTools.
@@ -107,16 +204,23 @@ func CreateGoogleADKAgentWithSubagentSessionIDs(ctx context.Context, agentConfig
 		}
 	}
 
-	// Build BeforeToolCallbacks. Approval gating runs first.
+	// Build BeforeToolCallbacks. Dry-run previews run first, so a call that is
+	// only previewed is not held for approval, then approval gating.
 	beforeToolCallbacks := []llmagent.BeforeToolCallback{}
 	// Strip synthetic HITL tool messages from the model request to avoid unnecessary token usage.
 	beforeModelCallbacks := []llmagent.BeforeModelCallback{}
 
+	beforeToolCallbacks = append(beforeToolCallbacks, dryrun.New(agentConfig.DryRunToolPatterns(), log).BeforeToolCallback())
+
 	if len(approvalSet) > 0 {
 		log.Info("Wiring approval callback", "toolCount", len(approvalSet))
 		beforeToolCallbacks = append(beforeToolCallbacks, MakeApprovalCallback(approvalSet))
 		beforeModelCallbacks = append(beforeModelCallbacks, MakeStripConfirmationPartsCallback())
 	}
+	// Budgets can be set per invocation, so they are always wired. Calls held
+	// for approval count once they run.
+	beforeToolCallbacks = append(beforeToolCallbacks, budget.BeforeToolCallback())
+	beforeModelCallbacks = append(beforeModelCallbacks, budget.BeforeModelCallback())
 	if len(mcpAppToolNames) > 0 {
 		// For MCP App-capable tools, keep rich tool payloads in chat history for UI rendering,
 		// but compact what is sent back to the model to avoid redundant polling/tool churn.
@@ -125,6 +229,20 @@ func CreateGoogleADKAgentWithSubagentSessionIDs(ctx context.Context, agentConfig
 	}
 	beforeToolCallbacks = append(beforeToolCallbacks, makeBeforeToolCallback(log))
 
+	afterModelCallbacks := []llmagent.AfterModelCallback{budget.AfterModelCallback()}
+	if agentConfig.Moderation != nil {
+		moderator, err := moderation.New(agentConfig.Moderation, os.Getenv("KAGENT_MODERATION_API_KEY"), log)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to set up moderation: %w", err)
+		}
+		afterModelCallbacks = append(afterModelCallbacks, moderator.AfterModelCallback())
+		// Input is moderated last, once the other callbacks have shaped the request.
+		if moderator.CheckInput() {
+			beforeModelCallbacks = append(beforeModelCallbacks, moderator.BeforeModelCallback())
+		}
+		log.Info("Moderation enabled", "action", moderator.Action(), "checkInput", moderator.CheckInput())
+	}
+
 	llmAgentConfig := llmagent.Config{
 		Name:                 agentName,
 		Description:          agentConfig.Description,
@@ -135,9 +253,8 @@ func CreateGoogleADKAgentWithSubagentSessionIDs(ctx context.Context, agentConfig
 		Toolsets:             toolsets,
 		BeforeToolCallbacks:  beforeToolCallbacks,
 		BeforeModelCallbacks: beforeModelCallbacks,
-		AfterToolCallbacks: []llmagent.AfterToolCallback{
-			makeAfterToolCallback(log),
-		},
+		AfterModelCallbacks:  afterModelCallbacks,
+		AfterToolCallbacks:   afterToolCallbacks,
 		OnToolErrorCallbacks: []llmagent.OnToolErrorCallback{
 			makeOnToolErrorCallback(log),
 		},
@@ -192,6 +309,30 @@ func buildAgentTools(agentConfig *adk.AgentConfig, remoteAgentTools, extraTools
 	return localTools, nil
 }
 
+// prewarmLLM warms the provider connection of llm in the background, as
+// selected by KAGENT_MODEL_PREWARM (off, connect or ping; default connect),
+// so that the first request does not wait for connection setup. Failures are
+// logged and otherwise ignored: the first request simply connects itself.
+func prewarmLLM(ctx context.Context, llm adkmodel.LLM, log logr.Logger) {
+	mode := strings.ToLower(strings.TrimSpace(os.Getenv("KAGENT_MODEL_PREWARM")))
+	if mode == "" {
+		mode = models.PrewarmConnect
+	}
+	if mode == models.PrewarmOff {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), prewarmTimeout)
+		defer cancel()
+		start := time.Now()
+		if err := models.Prewarm(ctx, llm, mode); err != nil {
+			log.Info("Model prewarm failed", "mode", mode, "error", err.Error())
+			return
+		}
+		log.Info("Model prewarmed", "mode", mode, "duration", time.Since(start).String())
+	}()
+}
+
 // CreateLLM creates an adkmodel.LLM from the model configuration.
 // This is exported to allow reuse of model creation logic (e.g., for memory summarization).
 func CreateLLM(ctx context.Context, m adk.Model, log logr.Logger) (adkmodel.LLM, error) {
@@ -236,10 +377,10 @@ func CreateLLM(ctx context.Context, m adk.Model, log logr.Logger) (adkmodel.LLM,
 		if err != nil {
 			return nil, fmt.Errorf("failed to build HTTP client for Gemini: %w", err)
 		}
-		return adkgemini.NewModel(ctx, modelName, &genai.ClientConfig{
+		return models.NewGeminiModelWithLogger(ctx, geminiConfig(modelName, m.GeminiOptions), &genai.ClientConfig{
 			APIKey:     apiKey,
 			HTTPClient: httpClient,
-		})
+		}, log)
 
 	case *adk.GeminiVertexAI:
 		project := os.Getenv("GOOGLE_CLOUD_PROJECT")
@@ -254,11 +395,11 @@ func CreateLLM(ctx context.Context, m adk.Model, log logr.Logger) (adkmodel.LLM,
 		if modelName == "" {
 			modelName = DefaultGeminiModel
 		}
-		return adkgemini.NewModel(ctx, modelName, &genai.ClientConfig{
+		return models.NewGeminiModelWithLogger(ctx, geminiConfig(modelName, m.GeminiOptions), &genai.ClientConfig{
 			Backend:  genai.BackendVertexAI,
 			Project:  project,
 			Location: location,
-		})
+		}, log)
 
 	case *adk.Anthropic:
 		modelName := m.Model
@@ -266,13 +407,16 @@ func CreateLLM(ctx context.Context, m adk.Model, log logr.Logger) (adkmodel.LLM,
 			modelName = DefaultAnthropicModel
 		}
 		cfg := &models.AnthropicConfig{
-			TransportConfig: transportConfigFromBase(m.BaseModel, m.Timeout),
-			Model:           modelName,
-			BaseUrl:         m.BaseUrl,
-			MaxTokens:       m.MaxTokens,
-			Temperature:     m.Temperature,
-			TopP:            m.TopP,
-			TopK:            m.TopK,
+			TransportConfig:        transportConfigFromBase(m.BaseModel, m.Timeout),
+			Model:                  modelName,
+			BaseUrl:                m.BaseUrl,
+			MaxTokens:              m.MaxTokens,
+			Temperature:            m.Temperature,
+			TopP:                   m.TopP,
+			TopK:                   m.TopK,
+			CacheSystemInstruction: m.CacheSystemInstruction,
+			CacheTools:             m.CacheTools,
+			CacheTTL:               m.CacheTTL,
 		}
 		return models.NewAnthropicModelWithLogger(cfg, log)
 
@@ -311,6 +455,7 @@ func CreateLLM(ctx context.Context, m adk.Model, log logr.Logger) (adkmodel.LLM,
 			TransportConfig:              transportConfigFromBase(m.BaseModel, nil),
 			Model:                        modelName,
 			Region:                       region,
+			Endpoint:                     m.Endpoint,
 			AdditionalModelRequestFields: m.AdditionalModelRequestFields,
 			PromptCaching:                m.PromptCaching,
 			CacheTTL:                     m.CacheTTL,
@@ -353,6 +498,30 @@ func CreateLLM(ctx context.Context, m adk.Model, log logr.Logger) (adkmodel.LLM,
 	}
 }
 
+// geminiConfig builds the GeminiConfig of the Gemini and GeminiVertexAI model types.
+func geminiConfig(modelName string, o adk.GeminiOptions) *models.GeminiConfig {
+	cfg := &models.GeminiConfig{
+		Model:                modelName,
+		Temperature:          o.Temperature,
+		TopP:                 o.TopP,
+		TopK:                 o.TopK,
+		MaxOutputTokens:      o.MaxOutputTokens,
+		CandidateCount:       o.CandidateCount,
+		StopSequences:        o.StopSequences,
+		ResponseMimeType:     o.ResponseMimeType,
+		ToolChoice:           o.ToolChoice,
+		AllowedFunctionNames: o.AllowedFunctionNames,
+		ParallelToolCalls:    o.ParallelToolCalls,
+	}
+	for _, s := range o.SafetySettings {
+		cfg.SafetySettings = append(cfg.SafetySettings, &genai.SafetySetting{
+			Category:  genai.HarmCategory(s.Category),
+			Threshold: genai.HarmBlockThreshold(s.Threshold),
+		})
+	}
+	return cfg
+}
+
 // transportConfigFromBase builds a TransportConfig from the shared BaseModel fields.
 func transportConfigFromBase(b adk.BaseModel, timeout *int) models.TransportConfig {
 	return models.TransportConfig{
@@ -0,0 +1,80 @@
+package agent
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/a2aproject/a2a-go/a2aclient"
+	"github.com/a2aproject/a2a-go/a2aclient/agentcard"
+	"github.com/go-logr/logr"
+	"github.com/kagent-dev/kagent/go/api/adk"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"google.golang.org/adk/v2/agent"
+	"google.golang.org/adk/v2/agent/remoteagent" //nolint:staticcheck // kagent still uses a2a-go v1; this ADK package is the compatibility adapter.
+	"google.golang.org/adk/v2/agent/workflowagents/parallelagent"
+	"google.golang.org/adk/v2/agent/workflowagents/sequentialagent"
+)
+
+// createWorkflowAgent creates an ADK workflow agent that runs the remote
+// agents of agentConfig as its sub-agents, in order or all at once. The
+// router pattern is not a workflow agent: it is an LLM agent calling the
+// remote agents as tools.
+func createWorkflowAgent(agentConfig *adk.AgentConfig, agentName string, log logr.Logger) (agent.Agent, error) {
+	httpClient := &http.Client{Transport: otelhttp.NewTransport(http.DefaultTransport)}
+
+	var subAgents []agent.Agent
+	for _, remoteAgent := range agentConfig.RemoteAgents {
+		if remoteAgent.Url == "" {
+			log.Info("Skipping remote agent with empty URL", "name", remoteAgent.Name)
+			continue
+		}
+		subAgent, err := newRemoteSubAgent(remoteAgent, httpClient)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create remote agent %s: %w", remoteAgent.Name, err)
+		}
+		subAgents = append(subAgents, subAgent)
+		log.Info("Wired remote A2A sub-agent", "name", remoteAgent.Name, "url", remoteAgent.Url)
+	}
+	if len(subAgents) == 0 {
+		return nil, fmt.Errorf("workflow has no remote agents")
+	}
+
+	cfg := agent.Config{
+		Name:        agentName,
+		Description: agentConfig.Description,
+		SubAgents:   subAgents,
+	}
+	log.Info("Creating Google ADK workflow agent", "name", agentName, "pattern", agentConfig.Workflow.Pattern, "subAgents", len(subAgents))
+	switch agentConfig.Workflow.Pattern {
+	case adk.WorkflowPatternSequential:
+		return sequentialagent.New(sequentialagent.Config{AgentConfig: cfg})
+	case adk.WorkflowPatternParallel:
+		return parallelagent.New(parallelagent.Config{AgentConfig: cfg})
+	default:
+		return nil, fmt.Errorf("unsupported workflow pattern %q", agentConfig.Workflow.Pattern)
+	}
+}
+
+// newRemoteSubAgent creates an agent that forwards its invocation to the
+// A2A agent at remoteAgent.Url. The agent card is resolved on first use.
+func newRemoteSubAgent(remoteAgent adk.RemoteAgentConfig, httpClient *http.Client) (agent.Agent, error) {
+	// Mark calls as agent-originated, as the remote A2A tool does.
+	meta := a2aclient.CallMeta{}
+	meta.Append("x-kagent-source", "agent")
+	var resolveOpts []agentcard.ResolveOption
+	for k, v := range remoteAgent.Headers {
+		meta.Append(k, v)
+		resolveOpts = append(resolveOpts, agentcard.WithRequestHeader(k, v))
+	}
+
+	return remoteagent.NewA2A(remoteagent.A2AConfig{
+		Name:               remoteAgent.Name,
+		Description:        remoteAgent.Description,
+		AgentCardSource:    remoteAgent.Url,
+		CardResolveOptions: resolveOpts,
+		ClientFactory: a2aclient.NewFactory(
+			a2aclient.WithJSONRPCTransport(httpClient),
+			a2aclient.WithInterceptors(a2aclient.NewStaticCallMetaInjector(meta)),
+		),
+	})
+}
@@ -0,0 +1,54 @@
+package agent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kagent-dev/kagent/go/api/adk"
+)
+
+func TestCreateGoogleADKAgent_Workflow(t *testing.T) {
+	remoteAgents := []adk.RemoteAgentConfig{
+		{Name: "kagent__NS__triage_agent", Url: "http://triage-agent.kagent:8080", Description: "Triages alerts"},
+		{Name: "kagent__NS__fix_agent", Url: "http://fix-agent.kagent:8080", Headers: map[string]string{"x-team": "sre"}},
+	}
+
+	for _, pattern := range []adk.WorkflowPattern{adk.WorkflowPatternSequential, adk.WorkflowPatternParallel} {
+		t.Run(string(pattern), func(t *testing.T) {
+			cfg := &adk.AgentConfig{
+				Description:  "Triage then fix",
+				RemoteAgents: remoteAgents,
+				Workflow:     &adk.WorkflowConfig{Pattern: pattern},
+			}
+			// No model is needed: the sub-agents do the work.
+			a, _, err := CreateGoogleADKAgentWithSubagentSessionIDs(context.Background(), cfg, "kagent__NS__incident_workflow", nil)
+			if err != nil {
+				t.Fatalf("CreateGoogleADKAgentWithSubagentSessionIDs() error = %v", err)
+			}
+			if a.Name() != "kagent__NS__incident_workflow" {
+				t.Errorf("Name() = %q", a.Name())
+			}
+			var names []string
+			for _, sub := range a.SubAgents() {
+				names = append(names, sub.Name())
+			}
+			if len(names) != 2 || names[0] != "kagent__NS__triage_agent" || names[1] != "kagent__NS__fix_agent" {
+				t.Errorf("SubAgents() = %v, want the remote agents in order", names)
+			}
+		})
+	}
+
+	t.Run("no remote agents", func(t *testing.T) {
+		cfg := &adk.AgentConfig{Workflow: &adk.WorkflowConfig{Pattern: adk.WorkflowPatternSequential}}
+		if _, _, err := CreateGoogleADKAgentWithSubagentSessionIDs(context.Background(), cfg, "wf", nil); err == nil {
+			t.Error("expected an error for a workflow without remote agents")
+		}
+	})
+
+	t.Run("unknown pattern", func(t *testing.T) {
+		cfg := &adk.AgentConfig{RemoteAgents: remoteAgents, Workflow: &adk.WorkflowConfig{Pattern: "loop"}}
+		if _, _, err := CreateGoogleADKAgentWithSubagentSessionIDs(context.Background(), cfg, "wf", nil); err == nil {
+			t.Error("expected an error for an unknown pattern")
+		}
+	})
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/kagent-dev/kagent/go/adk/pkg/auth"
 	"github.com/kagent-dev/kagent/go/adk/pkg/session"
 	"github.com/kagent-dev/kagent/go/adk/pkg/taskstore"
+	apiutils "github.com/kagent-dev/kagent/go/api/utils"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	adkagent "google.golang.org/adk/v2/agent"
@@ -51,6 +52,10 @@ type AppConfig struct {
 	// ShutdownTimeout is the graceful shutdown timeout. Defaults to 5 seconds.
 	ShutdownTimeout time.Duration
 
+	// Streaming tunes how the A2A server writes SSE responses. The zero value
+	// flushes every event immediately with no deadline or size limit.
+	Streaming apiutils.StreamOptions
+
 	// Logger is the structured logger. If nil, a production zap logger is created.
 	Logger logr.Logger
 
@@ -135,6 +140,7 @@ func New(cfg AppConfig, executor a2asrv.AgentExecutor) (*KAgentApp, error) {
 		Host:            cfg.Host,
 		Port:            cfg.Port,
 		ShutdownTimeout: cfg.ShutdownTimeout,
+		Streaming:       cfg.Streaming,
 	}
 
 	a2aServer, err := server.NewA2AServer(cfg.AgentCard, executor, log, serverConfig, handlerOpts...)
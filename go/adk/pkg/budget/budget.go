@@ -0,0 +1,287 @@
+// Package budget bounds the work an agent does on a task: its model tokens,
+// tool calls and model turns. When a limit is reached the agent makes no
+// further tool calls, and its next model request is answered with a summary
+// of the work done, so the task completes instead of looping until it times
+// out. Limits come from the agent config and can be lowered for one
+// invocation with the budget A2A message metadata key.
+package budget
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/kagent-dev/kagent/go/api/adk"
+	"google.golang.org/adk/v2/agent"
+	"google.golang.org/adk/v2/agent/llmagent"
+	"google.golang.org/adk/v2/model"
+	adksession "google.golang.org/adk/v2/session"
+	"google.golang.org/adk/v2/tool"
+	"google.golang.org/genai"
+)
+
+const (
+	// MetadataKey is the A2A message metadata key (with the adk_ or kagent_
+	// prefix) holding an adk.BudgetConfig that tightens the limits of the
+	// agent for an invocation.
+	MetadataKey = "budget"
+	// ExceededMetadataKey is the event custom metadata key, and A2A metadata
+	// key with the adk_ or kagent_ prefix, holding the Exceeded limit of a
+	// task that ran out of budget.
+	ExceededMetadataKey = "budget_exceeded"
+	// NotExecuted is returned to the model for tool calls over the budget.
+	NotExecuted = "Budget exceeded: this tool call was not executed."
+)
+
+// Limit names of Exceeded, matching the adk.BudgetConfig JSON fields.
+const (
+	LimitTotalTokens   = "max_total_tokens"
+	LimitSessionTokens = "max_session_tokens"
+	LimitToolCalls     = "max_tool_calls"
+	LimitTurns         = "max_turns"
+)
+
+// Exceeded describes the limit a task reached.
+type Exceeded struct {
+	Limit string `json:"limit"`
+	Max   int64  `json:"max"`
+	Used  int64  `json:"used"`
+}
+
+// Metadata returns e as event metadata.
+func (e *Exceeded) Metadata() map[string]any {
+	return map[string]any{"limit": e.Limit, "max": e.Max, "used": e.Used}
+}
+
+// Tracker counts the work of one run of the agent on a task against its
+// limits. It is safe for concurrent use by parallel tool calls.
+type Tracker struct {
+	limits adk.BudgetConfig
+	// sessionTokens are the tokens the session used before the run.
+	sessionTokens int64
+
+	mu        sync.Mutex
+	tokens    int64
+	turns     int
+	toolCalls int
+	tools     map[string]int
+	lastText  string
+	exceeded  *Exceeded
+}
+
+// New returns a Tracker for a run of the agent on a task in a session that
+// already used sessionTokens, or nil when limits sets no limit.
+func New(limits *adk.BudgetConfig, sessionTokens int64) *Tracker {
+	if !limits.Enabled() {
+		return nil
+	}
+	return &Tracker{limits: *limits, sessionTokens: sessionTokens, tools: map[string]int{}}
+}
+
+// SessionTokens returns the tokens used by the model responses among events.
+func SessionTokens(events iter.Seq[*adksession.Event]) int64 {
+	var total int64
+	for event := range events {
+		if event != nil && !event.Partial && event.UsageMetadata != nil {
+			total += int64(event.UsageMetadata.TotalTokenCount)
+		}
+	}
+	return total
+}
+
+// ParseOverride reads the budget of an invocation from the value of its
+// MetadataKey message metadata.
+func ParseOverride(v any) (*adk.BudgetConfig, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var override adk.BudgetConfig
+	if err := json.Unmarshal(raw, &override); err != nil {
+		return nil, err
+	}
+	return &override, nil
+}
+
+type contextKey struct{}
+
+// NewContext returns a context whose run is bounded by t.
+func NewContext(ctx context.Context, t *Tracker) context.Context {
+	return context.WithValue(ctx, contextKey{}, t)
+}
+
+// FromContext returns the Tracker of the run of ctx, or nil when the run has
+// no budget.
+func FromContext(ctx context.Context) *Tracker {
+	t, _ := ctx.Value(contextKey{}).(*Tracker)
+	return t
+}
+
+// Exceeded returns the limit the run reached, or nil while it is within its
+// budget or has none.
+func (t *Tracker) Exceeded() *Exceeded {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.exceeded
+}
+
+// tokenLimitLocked stops the run when it used up a token limit.
+func (t *Tracker) tokenLimitLocked() *Exceeded {
+	if t.exceeded != nil {
+		return t.exceeded
+	}
+	if limit := t.limits.MaxTotalTokens; limit != nil && t.tokens >= *limit {
+		t.exceeded = &Exceeded{Limit: LimitTotalTokens, Max: *limit, Used: t.tokens}
+	} else if limit := t.limits.MaxSessionTokens; limit != nil && t.sessionTokens+t.tokens >= *limit {
+		t.exceeded = &Exceeded{Limit: LimitSessionTokens, Max: *limit, Used: t.sessionTokens + t.tokens}
+	}
+	return t.exceeded
+}
+
+// startTurn counts a model request, or returns the limit that stops it.
+func (t *Tracker) startTurn() *Exceeded {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if ex := t.tokenLimitLocked(); ex != nil {
+		return ex
+	}
+	if limit := t.limits.MaxTurns; limit != nil && t.turns >= *limit {
+		t.exceeded = &Exceeded{Limit: LimitTurns, Max: int64(*limit), Used: int64(t.turns)}
+		return t.exceeded
+	}
+	t.turns++
+	return nil
+}
+
+// endTurn counts the tokens of a model response.
+func (t *Tracker) endTurn(resp *model.LLMResponse) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if resp.UsageMetadata != nil {
+		t.tokens += int64(resp.UsageMetadata.TotalTokenCount)
+	}
+	if text := textOf(resp.Content); text != "" {
+		t.lastText = text
+	}
+}
+
+// startToolCall counts a call of the named tool, or returns the limit that
+// stops it.
+func (t *Tracker) startToolCall(name string) *Exceeded {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if ex := t.tokenLimitLocked(); ex != nil {
+		return ex
+	}
+	if limit := t.limits.MaxToolCalls; limit != nil && t.toolCalls >= *limit {
+		t.exceeded = &Exceeded{Limit: LimitToolCalls, Max: int64(*limit), Used: int64(t.toolCalls)}
+		return t.exceeded
+	}
+	t.toolCalls++
+	t.tools[name]++
+	return nil
+}
+
+// Summary describes the work of the run for the user once it stopped.
+func (t *Tracker) Summary() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var b strings.Builder
+	if t.exceeded != nil {
+		fmt.Fprintf(&b, "I stopped working on this task because it reached %s.\n\n", describe(t.exceeded))
+	}
+	fmt.Fprintf(&b, "Work done: %d model turns, %d tool calls and %d tokens.", t.turns, t.toolCalls, t.tokens)
+	if len(t.tools) > 0 {
+		calls := make([]string, 0, len(t.tools))
+		for _, name := range slices.Sorted(maps.Keys(t.tools)) {
+			calls = append(calls, fmt.Sprintf("%s (%d)", name, t.tools[name]))
+		}
+		fmt.Fprintf(&b, "\nTools called: %s.", strings.Join(calls, ", "))
+	}
+	if t.lastText != "" {
+		fmt.Fprintf(&b, "\n\nLast update:\n%s", t.lastText)
+	}
+	return b.String()
+}
+
+func describe(e *Exceeded) string {
+	switch e.Limit {
+	case LimitTotalTokens:
+		return fmt.Sprintf("its budget of %d tokens", e.Max)
+	case LimitSessionTokens:
+		return fmt.Sprintf("the session's budget of %d tokens", e.Max)
+	case LimitToolCalls:
+		return fmt.Sprintf("its budget of %d tool calls", e.Max)
+	case LimitTurns:
+		return fmt.Sprintf("its budget of %d model turns", e.Max)
+	default:
+		return "its budget"
+	}
+}
+
+// BeforeModelCallback answers the model requests of a run over its budget
+// with the summary of the run, which ends it.
+func BeforeModelCallback() llmagent.BeforeModelCallback {
+	return func(ctx agent.Context, _ *model.LLMRequest) (*model.LLMResponse, error) {
+		t := FromContext(ctx)
+		if t == nil {
+			return nil, nil
+		}
+		ex := t.startTurn()
+		if ex == nil {
+			return nil, nil
+		}
+		return &model.LLMResponse{
+			Content:        genai.NewContentFromText(t.Summary(), genai.RoleModel),
+			CustomMetadata: map[string]any{ExceededMetadataKey: ex.Metadata()},
+			TurnComplete:   true,
+		}, nil
+	}
+}
+
+// AfterModelCallback counts the tokens of the model responses of a run.
+func AfterModelCallback() llmagent.AfterModelCallback {
+	return func(ctx agent.Context, resp *model.LLMResponse, err error) (*model.LLMResponse, error) {
+		t := FromContext(ctx)
+		if t == nil || err != nil || resp == nil || resp.Partial {
+			return nil, nil
+		}
+		t.endTurn(resp)
+		return nil, nil
+	}
+}
+
+// BeforeToolCallback skips the tool calls of a run over its budget.
+func BeforeToolCallback() llmagent.BeforeToolCallback {
+	return func(ctx agent.Context, tl tool.Tool, _ map[string]any) (map[string]any, error) {
+		t := FromContext(ctx)
+		if t == nil {
+			return nil, nil
+		}
+		if ex := t.startToolCall(tl.Name()); ex != nil {
+			return map[string]any{"executed": false, "result": NotExecuted, ExceededMetadataKey: ex.Metadata()}, nil
+		}
+		return nil, nil
+	}
+}
+
+func textOf(content *genai.Content) string {
+	if content == nil {
+		return ""
+	}
+	var parts []string
+	for _, part := range content.Parts {
+		if part != nil && part.Text != "" && !part.Thought {
+			parts = append(parts, part.Text)
+		}
+	}
+	return strings.Join(parts, "")
+}
@@ -0,0 +1,129 @@
+package budget
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kagent-dev/kagent/go/api/adk"
+	"google.golang.org/adk/v2/agent"
+	"google.golang.org/adk/v2/model"
+	"google.golang.org/genai"
+)
+
+// fakeContext is the part of agent.Context the callbacks use.
+type fakeContext struct {
+	agent.Context
+	ctx context.Context
+}
+
+func (c *fakeContext) Deadline() (time.Time, bool) { return c.ctx.Deadline() }
+func (c *fakeContext) Done() <-chan struct{}       { return c.ctx.Done() }
+func (c *fakeContext) Err() error                  { return c.ctx.Err() }
+func (c *fakeContext) Value(key any) any           { return c.ctx.Value(key) }
+
+type fakeTool struct {
+	name string
+}
+
+func (t *fakeTool) Name() string        { return t.name }
+func (t *fakeTool) Description() string { return "" }
+func (t *fakeTool) IsLongRunning() bool { return false }
+
+func response(text string, tokens int32) *model.LLMResponse {
+	return &model.LLMResponse{
+		Content:       genai.NewContentFromText(text, genai.RoleModel),
+		UsageMetadata: &genai.GenerateContentResponseUsageMetadata{TotalTokenCount: tokens},
+	}
+}
+
+func TestCallbacks(t *testing.T) {
+	beforeModel, afterModel, beforeTool := BeforeModelCallback(), AfterModelCallback(), BeforeToolCallback()
+
+	t.Run("no budget", func(t *testing.T) {
+		ctx := &fakeContext{ctx: context.Background()}
+		if resp, _ := beforeModel(ctx, &model.LLMRequest{}); resp != nil {
+			t.Errorf("BeforeModelCallback() = %v, want nil", resp)
+		}
+		if got, _ := beforeTool(ctx, &fakeTool{name: "k8s_get_resources"}, nil); got != nil {
+			t.Errorf("BeforeToolCallback() = %v, want nil", got)
+		}
+	})
+
+	t.Run("tool calls", func(t *testing.T) {
+		tracker := New(&adk.BudgetConfig{MaxToolCalls: new(2)}, 0)
+		ctx := &fakeContext{ctx: NewContext(context.Background(), tracker)}
+		if resp, _ := beforeModel(ctx, &model.LLMRequest{}); resp != nil {
+			t.Fatalf("BeforeModelCallback() = %v, want nil", resp)
+		}
+		afterModel(ctx, response("Checking the pods.", 100), nil) //nolint:errcheck
+		for range 2 {
+			if got, _ := beforeTool(ctx, &fakeTool{name: "k8s_get_resources"}, nil); got != nil {
+				t.Fatalf("BeforeToolCallback() = %v, want nil", got)
+			}
+		}
+		got, _ := beforeTool(ctx, &fakeTool{name: "k8s_get_resources"}, nil)
+		if got == nil || got["executed"] != false {
+			t.Fatalf("BeforeToolCallback() = %v, want the call skipped", got)
+		}
+
+		resp, _ := beforeModel(ctx, &model.LLMRequest{})
+		if resp == nil {
+			t.Fatal("BeforeModelCallback() = nil, want the summary")
+		}
+		summary := resp.Content.Parts[0].Text
+		for _, want := range []string{"budget of 2 tool calls", "1 model turns, 2 tool calls and 100 tokens", "k8s_get_resources (2)", "Checking the pods."} {
+			if !strings.Contains(summary, want) {
+				t.Errorf("summary %q does not contain %q", summary, want)
+			}
+		}
+		if got := tracker.Exceeded(); got == nil || got.Limit != LimitToolCalls || got.Used != 2 {
+			t.Errorf("Exceeded() = %+v, want the tool call limit", got)
+		}
+	})
+
+	t.Run("turns", func(t *testing.T) {
+		ctx := &fakeContext{ctx: NewContext(context.Background(), New(&adk.BudgetConfig{MaxTurns: new(1)}, 0))}
+		if resp, _ := beforeModel(ctx, &model.LLMRequest{}); resp != nil {
+			t.Fatalf("BeforeModelCallback() = %v, want nil", resp)
+		}
+		resp, _ := beforeModel(ctx, &model.LLMRequest{})
+		if resp == nil || resp.CustomMetadata[ExceededMetadataKey] == nil {
+			t.Fatalf("BeforeModelCallback() = %v, want the summary", resp)
+		}
+	})
+
+	t.Run("session tokens", func(t *testing.T) {
+		tracker := New(&adk.BudgetConfig{MaxSessionTokens: new(int64(1000))}, 900)
+		ctx := &fakeContext{ctx: NewContext(context.Background(), tracker)}
+		if resp, _ := beforeModel(ctx, &model.LLMRequest{}); resp != nil {
+			t.Fatalf("BeforeModelCallback() = %v, want nil", resp)
+		}
+		afterModel(ctx, response("", 150), nil) //nolint:errcheck
+		if got, _ := beforeTool(ctx, &fakeTool{name: "helm_list"}, nil); got == nil {
+			t.Fatal("BeforeToolCallback() = nil, want the call skipped")
+		}
+		if got := tracker.Exceeded(); got == nil || got.Limit != LimitSessionTokens || got.Used != 1050 {
+			t.Errorf("Exceeded() = %+v, want the session token limit", got)
+		}
+	})
+}
+
+func TestTighten(t *testing.T) {
+	agentBudget := &adk.BudgetConfig{MaxToolCalls: new(20), MaxTurns: new(10)}
+	override, err := ParseOverride(map[string]any{"max_tool_calls": 50, "max_turns": 5, "max_total_tokens": 10000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := agentBudget.Tighten(override)
+	if *got.MaxToolCalls != 20 || *got.MaxTurns != 5 || *got.MaxTotalTokens != 10000 || got.MaxSessionTokens != nil {
+		t.Errorf("Tighten() = %+v, want the smaller of each limit", got)
+	}
+	if New(agentBudget.Tighten(nil), 0) == nil {
+		t.Error("New() = nil, want a tracker for the agent's budget")
+	}
+	if New((*adk.BudgetConfig)(nil).Tighten(nil), 0) != nil {
+		t.Error("New() = a tracker, want nil without limits")
+	}
+}
@@ -0,0 +1,32 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// apiKeyFileSuffix marks an env var holding the path of a file with an API
+// key, e.g. OPENAI_API_KEY_FILE for OPENAI_API_KEY. The controller sets them
+// for ModelConfigs whose key is read from a file in the pod, such as a CSI
+// volume.
+const apiKeyFileSuffix = "_API_KEY_FILE"
+
+// LoadAPIKeyFiles sets every API key env var given as a file to the file's
+// content, so model clients find the key where they always look for it.
+func LoadAPIKeyFiles() error {
+	for _, kv := range os.Environ() {
+		name, path, _ := strings.Cut(kv, "=")
+		if !strings.HasSuffix(name, apiKeyFileSuffix) || path == "" {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("read %s: %w", name, err)
+		}
+		if err := os.Setenv(strings.TrimSuffix(name, "_FILE"), strings.TrimSpace(string(data))); err != nil {
+			return fmt.Errorf("set %s: %w", strings.TrimSuffix(name, "_FILE"), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadAPIKeyFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "openai")
+	if err := os.WriteFile(path, []byte("sk-test\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("OPENAI_API_KEY", "")
+	t.Setenv("OPENAI_API_KEY_FILE", path)
+	t.Setenv("UNRELATED_FILE", filepath.Join(t.TempDir(), "missing"))
+
+	if err := LoadAPIKeyFiles(); err != nil {
+		t.Fatalf("LoadAPIKeyFiles() error = %v", err)
+	}
+	if got := os.Getenv("OPENAI_API_KEY"); got != "sk-test" {
+		t.Fatalf("OPENAI_API_KEY = %q, want %q", got, "sk-test")
+	}
+
+	t.Setenv("ANTHROPIC_API_KEY_FILE", filepath.Join(t.TempDir(), "missing"))
+	if err := LoadAPIKeyFiles(); err == nil {
+		t.Fatal("LoadAPIKeyFiles() succeeded with a missing key file")
+	}
+}
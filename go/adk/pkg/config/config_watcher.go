@@ -0,0 +1,100 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kagent-dev/kagent/go/api/adk"
+)
+
+// defaultWatchInterval is how often the Watcher reads config.json.
+const defaultWatchInterval = 10 * time.Second
+
+// Watcher reloads config.json from the config directory when it changes, so
+// edits to the instruction, tools or model apply without a restart. The
+// directory is mounted from the agent's Secret, which the kubelet updates in
+// place by swapping a symlink, so the file is polled rather than watched.
+//
+// Changes to settings read only at startup (adk.AgentConfig.RestartSettings)
+// are not applied; the controller replaces the pod for those.
+type Watcher struct {
+	path     string
+	interval time.Duration
+	apply    func(*adk.AgentConfig) error
+	log      logr.Logger
+
+	current *adk.AgentConfig
+	data    []byte
+}
+
+// NewWatcher returns a Watcher for the config.json in configDir, which was
+// loaded as current. apply is called with each valid new config; when it
+// fails, the config is tried again on its next change only.
+func NewWatcher(configDir string, current *adk.AgentConfig, apply func(*adk.AgentConfig) error, log logr.Logger) *Watcher {
+	path := filepath.Join(configDir, "config.json")
+	data, _ := os.ReadFile(path)
+	return &Watcher{
+		path:     path,
+		interval: defaultWatchInterval,
+		apply:    apply,
+		log:      log.WithName("config-watcher"),
+		current:  current,
+		data:     data,
+	}
+}
+
+// Run checks for config changes until ctx is cancelled.
+func (w *Watcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			w.check()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (w *Watcher) check() {
+	data, err := os.ReadFile(w.path)
+	if err != nil {
+		w.log.Error(err, "Failed to read agent config", "path", w.path)
+		return
+	}
+	if bytes.Equal(data, w.data) {
+		return
+	}
+	w.data = data
+
+	var cfg adk.AgentConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		w.log.Error(err, "Ignoring invalid agent config", "path", w.path)
+		return
+	}
+	if err := ValidateAgentConfigUsage(&cfg); err != nil {
+		w.log.Error(err, "Ignoring invalid agent config", "path", w.path)
+		return
+	}
+	if !reflect.DeepEqual(cfg.RestartSettings(), w.current.RestartSettings()) {
+		w.log.Info("Agent config changed settings that apply on restart only, keeping the current config until the pod is replaced")
+		return
+	}
+	if err := w.apply(&cfg); err != nil {
+		w.log.Error(err, "Failed to apply reloaded agent config")
+		return
+	}
+	w.current = &cfg
+	w.log.Info("Reloaded agent config",
+		"model", cfg.Model.GetType(),
+		"httpTools", len(cfg.HttpTools),
+		"sseTools", len(cfg.SseTools),
+		"remoteAgents", len(cfg.RemoteAgents))
+}
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/kagent-dev/kagent/go/api/adk"
+)
+
+func TestWatcher(t *testing.T) {
+	dir := t.TempDir()
+	write := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, "config.json"), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(`{"model": {"type": "openai", "model": "gpt-4o"}, "instruction": "Be brief.", "stream": true}`)
+	current, err := LoadAgentConfig(filepath.Join(dir, "config.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var applied []*adk.AgentConfig
+	var applyErr error
+	w := NewWatcher(dir, current, func(cfg *adk.AgentConfig) error {
+		if applyErr != nil {
+			return applyErr
+		}
+		applied = append(applied, cfg)
+		return nil
+	}, logr.Discard())
+
+	w.check()
+	if len(applied) != 0 {
+		t.Fatalf("unchanged config applied: %v", applied)
+	}
+
+	write(`{"model": {"type": "openai", "model": "gpt-4o-mini"}, "instruction": "Be thorough.", "stream": true}`)
+	w.check()
+	if len(applied) != 1 || applied[0].Instruction != "Be thorough." {
+		t.Fatalf("changed instruction not applied: %v", applied)
+	}
+
+	write(`{"instruction": "No model."}`)
+	w.check()
+	if len(applied) != 1 {
+		t.Errorf("invalid config applied: %v", applied[1:])
+	}
+
+	write(`{"model": {"type": "openai", "model": "gpt-4o-mini"}, "instruction": "Be thorough.", "stream": false}`)
+	w.check()
+	if len(applied) != 1 {
+		t.Errorf("config with a restart setting change applied: %v", applied[1:])
+	}
+
+	applyErr = errors.New("tool server unreachable")
+	write(`{"model": {"type": "openai", "model": "gpt-4o-mini"}, "instruction": "Be nice.", "stream": true}`)
+	w.check()
+	if w.current.Instruction != "Be thorough." {
+		t.Errorf("current config = %q after a failed apply, want the previous one", w.current.Instruction)
+	}
+}
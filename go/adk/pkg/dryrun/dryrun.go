@@ -0,0 +1,129 @@
+// Package dryrun previews mutating tool calls instead of running them. A
+// caller turns dry-run on for one invocation with the dry_run A2A message
+// metadata key (kagent invoke --dry-run), so they can review what the agent
+// would change before letting it apply the change.
+package dryrun
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"path"
+
+	"github.com/go-logr/logr"
+	"google.golang.org/adk/v2/agent"
+	"google.golang.org/adk/v2/agent/llmagent"
+	"google.golang.org/adk/v2/tool"
+	"google.golang.org/genai"
+)
+
+const (
+	// MetadataKey is the A2A message metadata key (with the adk_ or kagent_
+	// prefix) that enables dry-run for an invocation.
+	MetadataKey = "dry_run"
+	// ArgName is the tool parameter through which a tool server is asked to
+	// preview a change, e.g. with a server-side dry-run or helm --dry-run.
+	ArgName = "dry_run"
+	// NotExecuted is returned to the model for tools that cannot preview.
+	NotExecuted = "Dry run: this tool call was not executed. Do not retry it; " +
+		"describe to the user what it would change so they can review the plan."
+)
+
+type contextKey struct{}
+
+// NewContext returns a context whose invocation runs in dry-run mode.
+func NewContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, contextKey{}, true)
+}
+
+// Enabled reports whether ctx belongs to a dry-run invocation.
+func Enabled(ctx context.Context) bool {
+	enabled, _ := ctx.Value(contextKey{}).(bool)
+	return enabled
+}
+
+// Interceptor previews the calls of tools that match its patterns when the
+// invocation is a dry run.
+type Interceptor struct {
+	patterns []string
+	log      logr.Logger
+}
+
+// New builds an Interceptor for the given tool-name glob patterns.
+func New(patterns []string, log logr.Logger) *Interceptor {
+	return &Interceptor{patterns: patterns, log: log.WithName("dry-run")}
+}
+
+// Matches reports whether the named tool is one dry-run intercepts.
+func (i *Interceptor) Matches(name string) bool {
+	for _, pattern := range i.patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Intercept handles a call of t in a dry-run invocation. Tools that accept a
+// dry_run argument get it set to true in args and run; Intercept returns nil
+// for them and for tools it does not match. For other matching tools it
+// returns the result to give the model in place of running the tool.
+func (i *Interceptor) Intercept(ctx context.Context, t tool.Tool, args map[string]any) map[string]any {
+	if !Enabled(ctx) || !i.Matches(t.Name()) {
+		return nil
+	}
+	if acceptsDryRun(t) && args != nil {
+		args[ArgName] = true
+		i.log.V(1).Info("Running tool in dry-run mode", "tool", t.Name())
+		return nil
+	}
+	i.log.Info("Skipped tool call in dry-run mode", "tool", t.Name())
+	return map[string]any{
+		"dry_run":  true,
+		"executed": false,
+		"tool":     t.Name(),
+		"args":     maps.Clone(args),
+		"result":   NotExecuted,
+	}
+}
+
+// BeforeToolCallback wraps Intercept as an ADK callback.
+func (i *Interceptor) BeforeToolCallback() llmagent.BeforeToolCallback {
+	return func(ctx agent.Context, t tool.Tool, args map[string]any) (map[string]any, error) {
+		return i.Intercept(ctx, t, args), nil
+	}
+}
+
+// acceptsDryRun reports whether the tool's declared parameters include
+// dry_run. MCP tools declare their input as a JSON schema.
+func acceptsDryRun(t tool.Tool) bool {
+	declarer, ok := t.(interface {
+		Declaration() *genai.FunctionDeclaration
+	})
+	if !ok {
+		return false
+	}
+	decl := declarer.Declaration()
+	if decl == nil {
+		return false
+	}
+	if decl.Parameters != nil {
+		_, ok := decl.Parameters.Properties[ArgName]
+		return ok
+	}
+	if decl.ParametersJsonSchema == nil {
+		return false
+	}
+	raw, err := json.Marshal(decl.ParametersJsonSchema)
+	if err != nil {
+		return false
+	}
+	var schema struct {
+		Properties map[string]json.RawMessage `json:"properties"`
+	}
+	if err := json.Unmarshal(raw, &schema); err != nil {
+		return false
+	}
+	_, ok = schema.Properties[ArgName]
+	return ok
+}
@@ -0,0 +1,70 @@
+package dryrun
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"google.golang.org/genai"
+)
+
+type fakeTool struct {
+	name   string
+	schema any
+}
+
+func (t *fakeTool) Name() string        { return t.name }
+func (t *fakeTool) Description() string { return "" }
+func (t *fakeTool) IsLongRunning() bool { return false }
+func (t *fakeTool) Declaration() *genai.FunctionDeclaration {
+	return &genai.FunctionDeclaration{Name: t.name, ParametersJsonSchema: t.schema}
+}
+
+func TestIntercept(t *testing.T) {
+	i := New([]string{"k8s_apply_manifest", "helm_*"}, logr.Discard())
+	dryRunCtx := NewContext(context.Background())
+	withDryRunArg := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"manifest": map[string]any{"type": "string"},
+			"dry_run":  map[string]any{"type": "boolean"},
+		},
+	}
+
+	t.Run("not a dry run", func(t *testing.T) {
+		args := map[string]any{"manifest": "kind: Pod"}
+		if got := i.Intercept(context.Background(), &fakeTool{name: "k8s_apply_manifest"}, args); got != nil {
+			t.Errorf("Intercept() = %v, want nil", got)
+		}
+	})
+
+	t.Run("tool not matched", func(t *testing.T) {
+		if got := i.Intercept(dryRunCtx, &fakeTool{name: "k8s_get_resources"}, map[string]any{}); got != nil {
+			t.Errorf("Intercept() = %v, want nil", got)
+		}
+	})
+
+	t.Run("tool with dry_run parameter runs with it set", func(t *testing.T) {
+		args := map[string]any{"manifest": "kind: Pod"}
+		if got := i.Intercept(dryRunCtx, &fakeTool{name: "k8s_apply_manifest", schema: withDryRunArg}, args); got != nil {
+			t.Errorf("Intercept() = %v, want nil", got)
+		}
+		if args[ArgName] != true {
+			t.Errorf("args[%q] = %v, want true", ArgName, args[ArgName])
+		}
+	})
+
+	t.Run("tool without dry_run parameter is not run", func(t *testing.T) {
+		args := map[string]any{"release": "kagent"}
+		got := i.Intercept(dryRunCtx, &fakeTool{name: "helm_upgrade", schema: map[string]any{"type": "object"}}, args)
+		if got == nil {
+			t.Fatal("Intercept() = nil, want a preview result")
+		}
+		if got["executed"] != false || got["tool"] != "helm_upgrade" || got["result"] != NotExecuted {
+			t.Errorf("Intercept() = %v", got)
+		}
+		if _, ok := args[ArgName]; ok {
+			t.Errorf("args were modified: %v", args)
+		}
+	})
+}
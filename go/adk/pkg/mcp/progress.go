@@ -0,0 +1,90 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/kagent-dev/kagent/go/adk/pkg/toolprogress"
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// progressRelay asks MCP servers for the progress of tool calls made while a
+// toolprogress reporter is set, and passes the progress notifications they
+// send to the reporter of the call.
+type progressRelay struct {
+	mu    sync.Mutex
+	next  uint64
+	calls map[string]progressCall
+}
+
+// progressCall is a tool call whose progress is relayed.
+type progressCall struct {
+	ctx            context.Context
+	tool           string
+	functionCallID string
+}
+
+// newProgressClient returns the MCP client of a toolset, which relays the
+// progress of its tool calls.
+func newProgressClient() *mcpsdk.Client {
+	relay := &progressRelay{calls: map[string]progressCall{}}
+	client := mcpsdk.NewClient(&mcpsdk.Implementation{Name: "kagent-adk"}, &mcpsdk.ClientOptions{
+		ProgressNotificationHandler: relay.notify,
+	})
+	client.AddSendingMiddleware(relay.middleware)
+	return client
+}
+
+// middleware sets a progress token on tools/call requests made with a
+// toolprogress reporter, so that the server reports the call's progress.
+func (r *progressRelay) middleware(next mcpsdk.MethodHandler) mcpsdk.MethodHandler {
+	return func(ctx context.Context, method string, req mcpsdk.Request) (mcpsdk.Result, error) {
+		params, ok := req.GetParams().(*mcpsdk.CallToolParams)
+		if !ok || !toolprogress.Enabled(ctx) {
+			return next(ctx, method, req)
+		}
+		call := progressCall{ctx: ctx, tool: params.Name}
+		// ADK runs tools with an agent.Context, which knows the call's id.
+		if fc, ok := ctx.(interface{ FunctionCallID() string }); ok {
+			call.functionCallID = fc.FunctionCallID()
+		}
+		token := r.register(call)
+		defer r.unregister(token)
+		params.SetProgressToken(token)
+		return next(ctx, method, req)
+	}
+}
+
+func (r *progressRelay) register(call progressCall) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.next++
+	token := fmt.Sprintf("kagent-%d", r.next)
+	r.calls[token] = call
+	return token
+}
+
+func (r *progressRelay) unregister(token string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.calls, token)
+}
+
+// notify passes a progress notification to the reporter of its call. Late
+// notifications of finished calls are dropped.
+func (r *progressRelay) notify(_ context.Context, req *mcpsdk.ProgressNotificationClientRequest) {
+	r.mu.Lock()
+	call, ok := r.calls[fmt.Sprint(req.Params.ProgressToken)]
+	r.mu.Unlock()
+	if !ok {
+		return
+	}
+	toolprogress.Report(call.ctx, toolprogress.Update{
+		Tool:           call.tool,
+		FunctionCallID: call.functionCallID,
+		Progress:       req.Params.Progress,
+		Total:          req.Params.Total,
+		Message:        req.Params.Message,
+	})
+}
@@ -0,0 +1,100 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kagent-dev/kagent/go/adk/pkg/toolprogress"
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// callContext is the agent.Context method the relay reads the call id from.
+type callContext struct {
+	context.Context
+}
+
+func (callContext) FunctionCallID() string { return "call-1" }
+
+func TestProgressClientRelaysToolProgress(t *testing.T) {
+	// Notifications are handled concurrently with the call's result, so the
+	// tool waits for them to be relayed before it returns.
+	relayed := make(chan struct{})
+	server := mcpsdk.NewServer(&mcpsdk.Implementation{Name: "helm-test", Version: "1.0.0"}, nil)
+	mcpsdk.AddTool(server, &mcpsdk.Tool{Name: "helm_upgrade"}, func(ctx context.Context, req *mcpsdk.CallToolRequest, _ map[string]any) (*mcpsdk.CallToolResult, map[string]any, error) {
+		token := req.Params.GetProgressToken()
+		if token == nil {
+			return nil, map[string]any{"progress": false}, nil
+		}
+		for i, step := range []string{"Rendering chart", "Waiting for rollout"} {
+			if err := req.Session.NotifyProgress(ctx, &mcpsdk.ProgressNotificationParams{
+				ProgressToken: token,
+				Progress:      float64(i + 1),
+				Total:         2,
+				Message:       step,
+			}); err != nil {
+				return nil, nil, err
+			}
+		}
+		select {
+		case <-relayed:
+		case <-time.After(5 * time.Second):
+			return nil, nil, errors.New("progress was not relayed")
+		}
+		return nil, map[string]any{"progress": true}, nil
+	})
+
+	clientTransport, serverTransport := mcpsdk.NewInMemoryTransports()
+	serverSession, err := server.Connect(t.Context(), serverTransport, nil)
+	if err != nil {
+		t.Fatalf("server.Connect() error = %v", err)
+	}
+	t.Cleanup(func() { _ = serverSession.Close() })
+	session, err := newProgressClient().Connect(t.Context(), clientTransport, nil)
+	if err != nil {
+		t.Fatalf("client.Connect() error = %v", err)
+	}
+	t.Cleanup(func() { _ = session.Close() })
+
+	// Without a reporter the server is not asked for progress.
+	res, err := session.CallTool(t.Context(), &mcpsdk.CallToolParams{Name: "helm_upgrade"})
+	if err != nil {
+		t.Fatalf("CallTool() error = %v", err)
+	}
+	if got := res.StructuredContent.(map[string]any)["progress"]; got != false {
+		t.Fatalf("progress requested without a reporter")
+	}
+
+	var (
+		mu      sync.Mutex
+		updates []toolprogress.Update
+	)
+	ctx := toolprogress.WithReporter(t.Context(), func(u toolprogress.Update) {
+		mu.Lock()
+		defer mu.Unlock()
+		updates = append(updates, u)
+		if len(updates) == 2 {
+			close(relayed)
+		}
+	})
+	if _, err := session.CallTool(callContext{Context: ctx}, &mcpsdk.CallToolParams{Name: "helm_upgrade"}); err != nil {
+		t.Fatalf("CallTool() error = %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := []toolprogress.Update{
+		{Tool: "helm_upgrade", FunctionCallID: "call-1", Progress: 1, Total: 2, Message: "Rendering chart"},
+		{Tool: "helm_upgrade", FunctionCallID: "call-1", Progress: 2, Total: 2, Message: "Waiting for rollout"},
+	}
+	if len(updates) != len(want) {
+		t.Fatalf("updates = %+v, want %+v", updates, want)
+	}
+	for i := range want {
+		if updates[i] != want[i] {
+			t.Errorf("updates[%d] = %+v, want %+v", i, updates[i], want[i])
+		}
+	}
+}
@@ -6,14 +6,20 @@ import (
 	"crypto/x509"
 	"fmt"
 	"net/http"
+	"net/url"
 	"os"
 	"time"
 
 	"github.com/a2aproject/a2a-go/a2asrv"
 	"github.com/go-logr/logr"
 	"github.com/kagent-dev/kagent/go/adk/pkg/constants"
+	"github.com/kagent-dev/kagent/go/adk/pkg/toolstats"
 	"github.com/kagent-dev/kagent/go/api/adk"
 	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+	"google.golang.org/adk/v2/agent"
 	"google.golang.org/adk/v2/tool"
 	"google.golang.org/adk/v2/tool/mcptoolset"
 )
@@ -26,6 +32,9 @@ type DynamicHeaderProvider func(ctx context.Context) map[string]string
 const (
 	// Default timeout matching Python KAGENT_REMOTE_AGENT_TIMEOUT
 	defaultTimeout = 30 * time.Minute
+
+	// oauth2TokenRequestTimeout bounds a single OAuth2 token request.
+	oauth2TokenRequestTimeout = 30 * time.Second
 )
 
 // allowedRequestHeaders reads the incoming A2A request metadata from ctx and
@@ -76,6 +85,8 @@ type mcpServerParams struct {
 	TLSInsecureSkipVerify *bool
 	TLSCACertPath         *string
 	TLSDisableSystemCAs   *bool
+	OAuth2                *adk.OAuth2ClientCredentialsConfig // optional client credentials grant for an Authorization bearer token
+	ServerRef             string                             // namespace/name of the tool server, which tool call stats are recorded for
 }
 
 // CreateToolsets creates toolsets from all configured HTTP and SSE MCP servers.
@@ -89,12 +100,16 @@ type mcpServerParams struct {
 //
 // Optional headerProvider can be used to inject per-request headers
 // derived from invocation context (e.g., STS exchanged access tokens).
+//
+// A non-nil policy hides the tools it denies from every toolset, including
+// tools a server adds after startup.
 func CreateToolsets(
 	ctx context.Context,
 	httpTools []adk.HttpMcpServerConfig,
 	sseTools []adk.SseMcpServerConfig,
 	propagateToken bool,
 	headerProvider DynamicHeaderProvider,
+	policy *adk.ToolPolicy,
 ) []tool.Toolset {
 	log := logr.FromContextOrDiscard(ctx)
 	var toolsets []tool.Toolset
@@ -113,8 +128,10 @@ func CreateToolsets(
 			TLSInsecureSkipVerify: httpTool.Params.TLSInsecureSkipVerify,
 			TLSCACertPath:         httpTool.Params.TLSCACertPath,
 			TLSDisableSystemCAs:   httpTool.Params.TLSDisableSystemCAs,
+			OAuth2:                httpTool.Params.OAuth2,
+			ServerRef:             httpTool.ServerRef,
 		}
-		ts, err := addToolset(ctx, log, params, httpTool.Tools, "HTTP", i+1)
+		ts, err := addToolset(ctx, log, params, httpTool.Tools, policy, "HTTP", i+1)
 		if err != nil {
 			continue
 		}
@@ -135,8 +152,10 @@ func CreateToolsets(
 			TLSInsecureSkipVerify: sseTool.Params.TLSInsecureSkipVerify,
 			TLSCACertPath:         sseTool.Params.TLSCACertPath,
 			TLSDisableSystemCAs:   sseTool.Params.TLSDisableSystemCAs,
+			OAuth2:                sseTool.Params.OAuth2,
+			ServerRef:             sseTool.ServerRef,
 		}
-		ts, err := addToolset(ctx, log, params, sseTool.Tools, "SSE", i+1)
+		ts, err := addToolset(ctx, log, params, sseTool.Tools, policy, "SSE", i+1)
 		if err != nil {
 			continue
 		}
@@ -147,7 +166,7 @@ func CreateToolsets(
 }
 
 // addToolset logs, initializes, and returns a single MCP toolset.
-func addToolset(ctx context.Context, log logr.Logger, params mcpServerParams, tools []string, label string, index int) (tool.Toolset, error) {
+func addToolset(ctx context.Context, log logr.Logger, params mcpServerParams, tools []string, policy *adk.ToolPolicy, label string, index int) (tool.Toolset, error) {
 	if params.Headers == nil {
 		params.Headers = make(map[string]string)
 	}
@@ -163,7 +182,7 @@ func addToolset(ctx context.Context, log logr.Logger, params mcpServerParams, to
 		log.Info(fmt.Sprintf("Adding %s MCP tool", label), "index", index, "url", params.URL, "toolFilterCount", "all")
 	}
 
-	ts, err := initializeToolSet(ctx, params, toolFilter)
+	ts, err := initializeToolSet(ctx, params, toolFilter, policy)
 	if err != nil {
 		log.Error(err, fmt.Sprintf("Failed to fetch tools from %s MCP server", label), "url", params.URL)
 		return nil, err
@@ -226,9 +245,14 @@ func createTransport(ctx context.Context, params mcpServerParams) (mcpsdk.Transp
 	}
 
 	var httpTransport http.RoundTripper = baseTransport
+	if params.OAuth2 != nil {
+		// Below headerRoundTripper, so the bearer token replaces an
+		// Authorization header from any other source.
+		httpTransport = &oauth2.Transport{Source: newOAuth2TokenSource(params.OAuth2), Base: baseTransport}
+	}
 	if len(params.Headers) > 0 || len(params.AllowedHeaders) > 0 || params.PropagateToken || params.HeaderProvider != nil {
 		httpTransport = &headerRoundTripper{
-			base:           baseTransport,
+			base:           httpTransport,
 			headers:        params.Headers,
 			allowedHeaders: params.AllowedHeaders,
 			propagateToken: params.PropagateToken,
@@ -236,9 +260,10 @@ func createTransport(ctx context.Context, params mcpServerParams) (mcpsdk.Transp
 		}
 	}
 
+	// Propagate W3C trace context so MCP server spans join the agent's trace.
 	httpClient := &http.Client{
 		Timeout:   httpTimeout,
-		Transport: httpTransport,
+		Transport: otelhttp.NewTransport(httpTransport),
 	}
 
 	var mcpTransport mcpsdk.Transport
@@ -257,6 +282,27 @@ func createTransport(ctx context.Context, params mcpServerParams) (mcpsdk.Transp
 	return mcpTransport, nil
 }
 
+// newOAuth2TokenSource returns a token source for the client credentials
+// grant. It caches the token and fetches a new one shortly before it expires,
+// so one toolset shares a token across all of its MCP sessions. The client
+// credentials are sent with HTTP Basic authentication, matching the
+// controller and the Python ADK.
+func newOAuth2TokenSource(cfg *adk.OAuth2ClientCredentialsConfig) oauth2.TokenSource {
+	cc := &clientcredentials.Config{
+		ClientID:     cfg.ClientID,
+		ClientSecret: cfg.ClientSecret,
+		TokenURL:     cfg.TokenURL,
+		Scopes:       cfg.Scopes,
+		AuthStyle:    oauth2.AuthStyleInHeader,
+	}
+	if cfg.Audience != "" {
+		cc.EndpointParams = url.Values{"audience": {cfg.Audience}}
+	}
+	// The context is kept for every refresh, so it must not be request-scoped.
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, &http.Client{Timeout: oauth2TokenRequestTimeout})
+	return cc.TokenSource(ctx)
+}
+
 // headerRoundTripper wraps an http.RoundTripper to add custom headers to all
 // requests. It supports four sources of headers, applied in this order so that
 // higher-priority sources win on collision:
@@ -311,8 +357,9 @@ func (rt *headerRoundTripper) RoundTrip(req *http.Request) (*http.Response, erro
 
 // initializeToolSet fetches tools from an MCP server using Google ADK's
 // mcptoolset and wraps the result with any MCP App-capable tool names found
-// during classification.
-func initializeToolSet(ctx context.Context, params mcpServerParams, toolFilter map[string]bool) (tool.Toolset, error) {
+// during classification. The tool policy, when set, is applied on top of the
+// tool filter each time the toolset lists its tools.
+func initializeToolSet(ctx context.Context, params mcpServerParams, toolFilter map[string]bool, policy *adk.ToolPolicy) (tool.Toolset, error) {
 	mcpTransport, err := createTransport(ctx, params)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create transport for %s: %w", params.URL, err)
@@ -335,7 +382,12 @@ func initializeToolSet(ctx context.Context, params mcpServerParams, toolFilter m
 		}
 	}
 
+	if policy != nil {
+		toolPredicate = policyPredicate(policy, toolPredicate)
+	}
+
 	cfg := mcptoolset.Config{
+		Client:     newProgressClient(),
 		Transport:  mcpTransport,
 		ToolFilter: toolPredicate,
 	}
@@ -345,5 +397,19 @@ func initializeToolSet(ctx context.Context, params mcpServerParams, toolFilter m
 		return nil, fmt.Errorf("failed to create MCP toolset for %s: %w", params.URL, err)
 	}
 
-	return &mcpAppToolset{inner: toolset, appToolNames: appToolNames}, nil
+	var inner tool.Toolset = toolset
+	if params.ServerRef != "" {
+		inner = toolstats.Default.Wrap(params.ServerRef, toolset)
+	}
+	return &mcpAppToolset{inner: inner, appToolNames: appToolNames}, nil
+}
+
+// policyPredicate narrows next, which may be nil, to the tools policy allows.
+func policyPredicate(policy *adk.ToolPolicy, next tool.Predicate) tool.Predicate {
+	return func(ctx agent.ReadonlyContext, t tool.Tool) bool {
+		if !policy.Allows(t.Name()) {
+			return false
+		}
+		return next == nil || next(ctx, t)
+	}
 }
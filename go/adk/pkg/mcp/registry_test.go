@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/a2aproject/a2a-go/a2asrv"
+	"github.com/kagent-dev/kagent/go/api/adk"
 	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
 	adkagent "google.golang.org/adk/v2/agent"
 	"google.golang.org/adk/v2/session"
@@ -212,7 +213,7 @@ func TestInitializeToolSetRecoversWhenServerStartsAfterInitialization(t *testing
 	toolset, err := initializeToolSet(t.Context(), mcpServerParams{
 		URL:        serverURL,
 		ServerType: "http",
-	}, map[string]bool{"getWeather": true, "disabledTool": false})
+	}, map[string]bool{"getWeather": true, "disabledTool": false}, nil)
 	if err != nil {
 		t.Fatalf("initializeToolSet() error = %v, want lazy fallback", err)
 	}
@@ -247,6 +248,40 @@ func TestInitializeToolSetRecoversWhenServerStartsAfterInitialization(t *testing
 	}
 }
 
+func TestInitializeToolSetAppliesToolPolicy(t *testing.T) {
+	mcpServer := mcpsdk.NewServer(&mcpsdk.Implementation{Name: "k8s-test", Version: "1.0.0"}, nil)
+	for _, name := range []string{"k8s_get_pods", "k8s_delete_pod", "helm_list"} {
+		mcpsdk.AddTool(mcpServer, &mcpsdk.Tool{Name: name}, func(context.Context, *mcpsdk.CallToolRequest, map[string]any) (*mcpsdk.CallToolResult, map[string]any, error) {
+			return nil, nil, nil
+		})
+	}
+	srv := httptest.NewServer(mcpsdk.NewStreamableHTTPHandler(func(*http.Request) *mcpsdk.Server {
+		return mcpServer
+	}, nil))
+	// The toolset keeps a standalone SSE stream open, so drop it before Close
+	// waits for outstanding requests.
+	t.Cleanup(func() {
+		srv.CloseClientConnections()
+		srv.Close()
+	})
+
+	toolset, err := initializeToolSet(t.Context(), mcpServerParams{
+		URL:        srv.URL,
+		ServerType: "http",
+	}, nil, &adk.ToolPolicy{Allow: []string{"k8s_*"}, Deny: []string{"*delete*"}})
+	if err != nil {
+		t.Fatalf("initializeToolSet() error = %v", err)
+	}
+
+	tools, err := toolset.Tools(testReadonlyContext{Context: t.Context()})
+	if err != nil {
+		t.Fatalf("toolset.Tools() error = %v", err)
+	}
+	if len(tools) != 1 || tools[0].Name() != "k8s_get_pods" {
+		t.Fatalf("toolset.Tools() = %#v, want only k8s_get_pods", tools)
+	}
+}
+
 type testReadonlyContext struct {
 	context.Context
 }
@@ -555,3 +590,59 @@ func TestStaticHeaders_OverrideDynamic(t *testing.T) {
 		t.Errorf("Authorization: got %q, want %q", capturedAuth, "Bearer static")
 	}
 }
+
+// TestOAuth2_OverridesStaticAuthorization verifies that a client credentials
+// token is fetched once, reused across requests and replaces a static
+// Authorization header.
+func TestOAuth2_OverridesStaticAuthorization(t *testing.T) {
+	t.Parallel()
+	tokenRequests := 0
+	tokenSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokenRequests++
+		if id, secret, ok := r.BasicAuth(); !ok || id != "kagent" || secret != "s3cret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"oauth-token","token_type":"Bearer","expires_in":3600}`))
+	}))
+	defer tokenSrv.Close()
+
+	var capturedAuth []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		capturedAuth = append(capturedAuth, r.Header.Get("Authorization"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	transport, err := createTransport(context.Background(), mcpServerParams{
+		URL:        srv.URL,
+		Headers:    map[string]string{"Authorization": "Bearer static"},
+		ServerType: "http",
+		OAuth2: &adk.OAuth2ClientCredentialsConfig{
+			TokenURL:     tokenSrv.URL,
+			ClientID:     "kagent",
+			ClientSecret: "s3cret",
+		},
+	})
+	if err != nil {
+		t.Fatalf("createTransport failed: %v", err)
+	}
+	httpClient := transport.(*mcpsdk.StreamableClientTransport).HTTPClient
+	for range 2 {
+		resp, err := httpClient.Get(srv.URL)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	if tokenRequests != 1 {
+		t.Errorf("token requests: got %d, want 1", tokenRequests)
+	}
+	for i, got := range capturedAuth {
+		if got != "Bearer oauth-token" {
+			t.Errorf("request %d Authorization: got %q, want %q", i, got, "Bearer oauth-token")
+		}
+	}
+}
@@ -18,8 +18,16 @@ import (
 	"google.golang.org/genai"
 )
 
+const (
+	// searchLimit is the number of memories a search returns at most.
+	searchLimit = 5
+	// searchMinScore is the similarity memories must reach to be returned.
+	searchMinScore = 0.3
+)
+
 // KagentMemoryService implements memory.Service by storing memories
-// via the Kagent backend API (backed by pgvector).
+// via the Kagent backend API (backed by pgvector), or in a Qdrant collection
+// when one is configured.
 type KagentMemoryService struct {
 	agentName       string
 	apiURL          string
@@ -27,13 +35,15 @@ type KagentMemoryService struct {
 	ttlDays         int
 	embeddingClient *embedding.Client
 	model           adkmodel.LLM // Optional: for session summarization
+	qdrant          *qdrantStore // Optional: replaces the Kagent API as the store
 }
 
 // Config for creating a new KagentMemoryService.
 type Config struct {
 	// AgentName is used as the namespace for memory storage
 	AgentName string
-	// APIURL is the base URL of the Kagent API (e.g., "http://kagent-controller:8083")
+	// APIURL is the base URL of the Kagent API (e.g., "http://kagent-controller:8083").
+	// Not required when Qdrant is set.
 	APIURL string
 	// HTTPClient for making requests (optional, uses http.DefaultClient if nil)
 	HTTPClient *http.Client
@@ -43,6 +53,10 @@ type Config struct {
 	EmbeddingConfig *adk.EmbeddingConfig
 	// Model for session summarization (optional)
 	Model adkmodel.LLM
+	// Qdrant stores memories in a Qdrant collection instead of the Kagent API (optional)
+	Qdrant *adk.QdrantMemoryConfig
+	// QdrantAPIKey authenticates to Qdrant (optional)
+	QdrantAPIKey string
 }
 
 // New creates a new KagentMemoryService.
@@ -50,7 +64,7 @@ func New(cfg Config) (*KagentMemoryService, error) {
 	if cfg.AgentName == "" {
 		return nil, fmt.Errorf("agent name is required")
 	}
-	if cfg.APIURL == "" {
+	if cfg.APIURL == "" && cfg.Qdrant == nil {
 		return nil, fmt.Errorf("API URL is required")
 	}
 
@@ -69,6 +83,14 @@ func New(cfg Config) (*KagentMemoryService, error) {
 		return nil, fmt.Errorf("failed to create embedding client: %w", err)
 	}
 
+	var qdrant *qdrantStore
+	if cfg.Qdrant != nil {
+		qdrant, err = newQdrantStore(cfg.Qdrant, cfg.QdrantAPIKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &KagentMemoryService{
 		agentName:       cfg.AgentName,
 		apiURL:          strings.TrimSuffix(cfg.APIURL, "/"),
@@ -76,6 +98,7 @@ func New(cfg Config) (*KagentMemoryService, error) {
 		ttlDays:         cfg.TTLDays,
 		embeddingClient: embClient,
 		model:           cfg.Model,
+		qdrant:          qdrant,
 	}, nil
 }
 
@@ -126,8 +149,12 @@ func (s *KagentMemoryService) AddSessionToMemory(ctx context.Context, session ad
 	return nil
 }
 
-// storeMemory stores a single memory item via the Kagent API.
+// storeMemory stores a single memory item via the Kagent API or in Qdrant.
 func (s *KagentMemoryService) storeMemory(ctx context.Context, userID, content string, vector []float32) error {
+	if s.qdrant != nil {
+		return s.qdrant.upsert(ctx, s.agentName, userID, content, vector, s.ttlDays)
+	}
+
 	req := addSessionRequest{
 		AgentName: s.agentName,
 		UserID:    userID,
@@ -186,13 +213,42 @@ func (s *KagentMemoryService) SearchMemory(ctx context.Context, req *memory.Sear
 		return &memory.SearchResponse{Memories: []memory.Entry{}}, nil
 	}
 
-	// Prepare API request
+	var results []searchResultItem
+	if s.qdrant != nil {
+		results, err = s.qdrant.search(ctx, s.agentName, req.UserID, vector, searchLimit, searchMinScore)
+	} else {
+		results, err = s.searchKagent(ctx, req.UserID, vector)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to memory.Entry
+	memories := make([]memory.Entry, 0, len(results))
+	for _, item := range results {
+		content := &genai.Content{
+			Role: "user",
+			Parts: []*genai.Part{
+				{Text: item.Content},
+			},
+		}
+		memories = append(memories, memory.Entry{
+			Content: content,
+		})
+	}
+
+	log.Info("Found memories", "count", len(memories), "query", req.Query)
+	return &memory.SearchResponse{Memories: memories}, nil
+}
+
+// searchKagent finds the memories of userID closest to vector via the Kagent API.
+func (s *KagentMemoryService) searchKagent(ctx context.Context, userID string, vector []float32) ([]searchResultItem, error) {
 	searchReq := searchRequest{
 		AgentName: s.agentName,
-		UserID:    req.UserID,
+		UserID:    userID,
 		Vector:    vector,
-		Limit:     5,
-		MinScore:  0.3,
+		Limit:     searchLimit,
+		MinScore:  searchMinScore,
 	}
 
 	body, err := json.Marshal(searchReq)
@@ -223,23 +279,7 @@ func (s *KagentMemoryService) SearchMemory(ctx context.Context, req *memory.Sear
 	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
-
-	// Convert to memory.Entry
-	memories := make([]memory.Entry, 0, len(results))
-	for _, item := range results {
-		content := &genai.Content{
-			Role: "user",
-			Parts: []*genai.Part{
-				{Text: item.Content},
-			},
-		}
-		memories = append(memories, memory.Entry{
-			Content: content,
-		})
-	}
-
-	log.Info("Found memories", "count", len(memories), "query", req.Query)
-	return &memory.SearchResponse{Memories: memories}, nil
+	return results, nil
 }
 
 // summarizeContent uses the LLM to extract key facts from conversation content.
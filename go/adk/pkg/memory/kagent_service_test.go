@@ -439,6 +439,30 @@ func TestNew(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "qdrant_without_api_url",
+			config: Config{
+				AgentName: "test-agent",
+				EmbeddingConfig: &adk.EmbeddingConfig{
+					Provider: "openai",
+					Model:    "text-embedding-3-small",
+				},
+				Qdrant: &adk.QdrantMemoryConfig{URL: "http://qdrant:6333", Collection: "memories"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "qdrant_missing_collection",
+			config: Config{
+				AgentName: "test-agent",
+				EmbeddingConfig: &adk.EmbeddingConfig{
+					Provider: "openai",
+					Model:    "text-embedding-3-small",
+				},
+				Qdrant: &adk.QdrantMemoryConfig{URL: "http://qdrant:6333"},
+			},
+			wantErr: true,
+		},
 		{
 			name: "missing_api_url",
 			config: Config{
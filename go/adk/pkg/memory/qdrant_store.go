@@ -0,0 +1,143 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kagent-dev/kagent/go/api/adk"
+)
+
+// defaultTTLDays matches the TTL the Kagent API applies when none is given.
+const defaultTTLDays = 15
+
+// qdrantStore keeps memories as points in a Qdrant collection. The
+// controller creates the collection and its payload indexes and prunes
+// expired points; searches skip expired points that are yet to be pruned.
+type qdrantStore struct {
+	baseURL    string
+	collection string
+	apiKey     string
+	client     *http.Client
+}
+
+func newQdrantStore(cfg *adk.QdrantMemoryConfig, apiKey string) (*qdrantStore, error) {
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("qdrant URL is required")
+	}
+	if cfg.Collection == "" {
+		return nil, fmt.Errorf("qdrant collection is required")
+	}
+	return &qdrantStore{
+		baseURL:    strings.TrimSuffix(cfg.URL, "/"),
+		collection: cfg.Collection,
+		apiKey:     apiKey,
+		// Not the Kagent API client: that one carries the agent's token.
+		client: &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+// upsert stores content with its embedding for agentName and userID.
+func (q *qdrantStore) upsert(ctx context.Context, agentName, userID, content string, vector []float32, ttlDays int) error {
+	if ttlDays <= 0 {
+		ttlDays = defaultTTLDays
+	}
+	now := time.Now()
+	body := map[string]any{
+		"points": []any{
+			map[string]any{
+				"id":     uuid.NewString(),
+				"vector": vector,
+				"payload": map[string]any{
+					"agent_name": agentName,
+					"user_id":    userID,
+					"content":    content,
+					"created_at": now.Unix(),
+					"expires_at": now.AddDate(0, 0, ttlDays).Unix(),
+				},
+			},
+		},
+	}
+	return q.do(ctx, http.MethodPut, "/points?wait=true", body, nil)
+}
+
+// search returns the memories of agentName and userID closest to vector.
+func (q *qdrantStore) search(ctx context.Context, agentName, userID string, vector []float32, limit int, minScore float64) ([]searchResultItem, error) {
+	body := map[string]any{
+		"vector":          vector,
+		"limit":           limit,
+		"score_threshold": minScore,
+		"with_payload":    true,
+		"filter": map[string]any{
+			"must": []any{
+				map[string]any{"key": "agent_name", "match": map[string]any{"value": agentName}},
+				map[string]any{"key": "user_id", "match": map[string]any{"value": userID}},
+				map[string]any{"key": "expires_at", "range": map[string]any{"gt": time.Now().Unix()}},
+			},
+		},
+	}
+	var points []struct {
+		ID      any     `json:"id"`
+		Score   float64 `json:"score"`
+		Payload struct {
+			Content string `json:"content"`
+		} `json:"payload"`
+	}
+	if err := q.do(ctx, http.MethodPost, "/points/search", body, &points); err != nil {
+		return nil, err
+	}
+
+	results := make([]searchResultItem, 0, len(points))
+	for _, p := range points {
+		results = append(results, searchResultItem{
+			ID:      fmt.Sprint(p.ID),
+			Content: p.Payload.Content,
+			Score:   p.Score,
+		})
+	}
+	return results, nil
+}
+
+// do sends a request to path under the collection and decodes the "result"
+// field of the response into result, if non-nil.
+func (q *qdrantStore) do(ctx context.Context, method, path string, body, result any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	u := fmt.Sprintf("%s/collections/%s%s", q.baseURL, url.PathEscape(q.collection), path)
+	req, err := http.NewRequestWithContext(ctx, method, u, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if q.apiKey != "" {
+		req.Header.Set("api-key", q.apiKey)
+	}
+
+	resp, err := q.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("qdrant returned status %d", resp.StatusCode)
+	}
+	if result != nil {
+		envelope := struct {
+			Result any `json:"result"`
+		}{Result: result}
+		if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kagent-dev/kagent/go/api/adk"
+	"google.golang.org/adk/v2/memory"
+)
+
+func TestQdrantStore(t *testing.T) {
+	var upserted map[string]any
+	var searched map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("api-key") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		switch r.Method + " " + r.URL.Path {
+		case "PUT /collections/memories/points":
+			upserted = body
+			json.NewEncoder(w).Encode(map[string]any{"result": map[string]any{"status": "completed"}})
+		case "POST /collections/memories/points/search":
+			searched = body
+			json.NewEncoder(w).Encode(map[string]any{"result": []map[string]any{
+				{"id": "a", "score": 0.9, "payload": map[string]any{"content": "User prefers dark mode"}},
+			}})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	embClient, embServer := newMockEmbeddingClient(t)
+	defer embServer.Close()
+	store, err := newQdrantStore(&adk.QdrantMemoryConfig{URL: server.URL + "/", Collection: "memories"}, "secret")
+	if err != nil {
+		t.Fatalf("newQdrantStore() error = %v", err)
+	}
+	svc := &KagentMemoryService{
+		agentName:       "test-agent",
+		ttlDays:         7,
+		embeddingClient: embClient,
+		qdrant:          store,
+	}
+
+	vector := make([]float32, 768)
+	if err := svc.storeMemory(context.Background(), "user-1", "User prefers dark mode", vector); err != nil {
+		t.Fatalf("storeMemory() error = %v", err)
+	}
+	points, _ := upserted["points"].([]any)
+	if len(points) != 1 {
+		t.Fatalf("expected 1 upserted point, got %v", upserted)
+	}
+	payload := points[0].(map[string]any)["payload"].(map[string]any)
+	if payload["agent_name"] != "test-agent" || payload["user_id"] != "user-1" || payload["content"] != "User prefers dark mode" {
+		t.Errorf("unexpected payload %v", payload)
+	}
+	if got := payload["expires_at"].(float64) - payload["created_at"].(float64); got != 7*24*60*60 {
+		t.Errorf("expected a 7 day TTL, got %vs", got)
+	}
+
+	resp, err := svc.SearchMemory(context.Background(), &memory.SearchRequest{Query: "theme", UserID: "user-1"})
+	if err != nil {
+		t.Fatalf("SearchMemory() error = %v", err)
+	}
+	if len(resp.Memories) != 1 || resp.Memories[0].Content.Parts[0].Text != "User prefers dark mode" {
+		t.Errorf("unexpected memories %v", resp.Memories)
+	}
+	must, _ := searched["filter"].(map[string]any)["must"].([]any)
+	if len(must) != 3 {
+		t.Errorf("expected agent, user and expiry filters, got %v", searched["filter"])
+	}
+	if searched["score_threshold"] != searchMinScore {
+		t.Errorf("expected score threshold %v, got %v", searchMinScore, searched["score_threshold"])
+	}
+}
@@ -35,6 +35,14 @@ type AnthropicConfig struct {
 	Temperature *float64
 	TopP        *float64
 	TopK        *int
+	// CacheSystemInstruction, when true, sets cache_control on the last system
+	// prompt block so the tools and system prefix are cached across calls.
+	CacheSystemInstruction bool
+	// CacheTools, when true, sets cache_control on the last tool definition.
+	CacheTools bool
+	// CacheTTL selects the cache retention window: "" or "5m" for the default
+	// 5-minute cache, "1h" for extended caching.
+	CacheTTL string
 }
 
 // AnthropicModel implements model.LLM for Anthropic Claude models.
@@ -42,6 +50,8 @@ type AnthropicModel struct {
 	Config *AnthropicConfig
 	Client anthropic.Client
 	Logger logr.Logger
+
+	providerConn
 }
 
 // NewAnthropicModelWithLogger creates a new Anthropic model instance with a logger
@@ -81,10 +91,15 @@ func newAnthropicModelFromConfig(config *AnthropicConfig, apiKey string, logger
 		logger.Info("Initialized Anthropic model", "model", config.Model, "baseUrl", config.BaseUrl)
 	}
 
+	endpoint := config.BaseUrl
+	if endpoint == "" {
+		endpoint = "https://api.anthropic.com/"
+	}
 	return &AnthropicModel{
-		Config: config,
-		Client: client,
-		Logger: logger,
+		Config:       config,
+		Client:       client,
+		Logger:       logger,
+		providerConn: providerConn{httpClient: httpClient, endpoint: endpoint},
 	}, nil
 }
 
@@ -138,8 +153,9 @@ func NewAnthropicBedrockModelWithLogger(ctx context.Context, config *AnthropicCo
 	logger.Info("Initialized Anthropic Bedrock model", "model", config.Model, "region", region)
 
 	return &AnthropicModel{
-		Config: config,
-		Client: client,
-		Logger: logger,
+		Config:       config,
+		Client:       client,
+		Logger:       logger,
+		providerConn: providerConn{httpClient: httpClient, endpoint: bedrockRuntimeEndpoint(region)},
 	}, nil
 }
@@ -79,6 +79,8 @@ func (m *AnthropicModel) GenerateContent(ctx context.Context, req *model.LLMRequ
 			params.Tools = genaiToolsToAnthropicTools(req.Config.Tools)
 		}
 
+		applyAnthropicPromptCaching(&params, m.Config)
+
 		if stream {
 			runAnthropicStreaming(ctx, m, params, yield)
 		} else {
@@ -102,6 +104,42 @@ func applyAnthropicConfig(params *anthropic.MessageNewParams, cfg *AnthropicConf
 	}
 }
 
+// applyAnthropicPromptCaching sets cache_control breakpoints on the last
+// system block and/or the last tool definition. Anthropic caches the prefix
+// up to each breakpoint in the order tools, system, messages.
+func applyAnthropicPromptCaching(params *anthropic.MessageNewParams, cfg *AnthropicConfig) {
+	if cfg == nil {
+		return
+	}
+	cacheControl := anthropic.NewCacheControlEphemeralParam()
+	if cfg.CacheTTL == string(anthropic.CacheControlEphemeralTTLTTL1h) {
+		cacheControl.TTL = anthropic.CacheControlEphemeralTTLTTL1h
+	}
+	if cfg.CacheTools && len(params.Tools) > 0 {
+		if tool := params.Tools[len(params.Tools)-1].OfTool; tool != nil {
+			tool.CacheControl = cacheControl
+		}
+	}
+	if cfg.CacheSystemInstruction && len(params.System) > 0 {
+		params.System[len(params.System)-1].CacheControl = cacheControl
+	}
+}
+
+// anthropicUsageMetadata converts Anthropic usage to genai usage. Anthropic
+// reports cached prompt tokens separately from input_tokens, so they are added
+// back into the prompt count.
+func anthropicUsageMetadata(inputTokens, cacheCreationTokens, cacheReadTokens, outputTokens int64) *genai.GenerateContentResponseUsageMetadata {
+	promptTokens := inputTokens + cacheCreationTokens + cacheReadTokens
+	if promptTokens == 0 && outputTokens == 0 {
+		return nil
+	}
+	return &genai.GenerateContentResponseUsageMetadata{
+		PromptTokenCount:        int32(promptTokens),
+		CachedContentTokenCount: int32(cacheReadTokens),
+		CandidatesTokenCount:    int32(outputTokens),
+	}
+}
+
 func genaiContentsToAnthropicMessages(contents []*genai.Content, config *genai.GenerateContentConfig) ([]anthropic.MessageParam, string) {
 	systemPrompt := mergeSystemInstructionFromConfig("", config)
 
@@ -130,10 +168,7 @@ func genaiContentsToAnthropicMessages(contents []*genai.Content, config *genai.G
 
 		var textParts []string
 		var functionCalls []*genai.FunctionCall
-		var imageParts []struct {
-			mimeType string
-			data     []byte
-		}
+		var fileBlocks []anthropic.ContentBlockParamUnion
 
 		for _, part := range content.Parts {
 			if part == nil {
@@ -143,11 +178,17 @@ func genaiContentsToAnthropicMessages(contents []*genai.Content, config *genai.G
 				textParts = append(textParts, part.Text)
 			} else if part.FunctionCall != nil {
 				functionCalls = append(functionCalls, part.FunctionCall)
-			} else if part.InlineData != nil && strings.HasPrefix(part.InlineData.MIMEType, "image/") {
-				imageParts = append(imageParts, struct {
-					mimeType string
-					data     []byte
-				}{part.InlineData.MIMEType, part.InlineData.Data})
+			} else if part.InlineData != nil {
+				switch {
+				case strings.HasPrefix(part.InlineData.MIMEType, "image/"):
+					fileBlocks = append(fileBlocks, anthropic.NewImageBlockBase64(part.InlineData.MIMEType, base64.StdEncoding.EncodeToString(part.InlineData.Data)))
+				case part.InlineData.MIMEType == mimeTypePDF:
+					fileBlocks = append(fileBlocks, anthropic.NewDocumentBlock(anthropic.Base64PDFSourceParam{Data: base64.StdEncoding.EncodeToString(part.InlineData.Data)}))
+				default:
+					if text, ok := attachedText(part.InlineData); ok {
+						textParts = append(textParts, text)
+					}
+				}
 			}
 		}
 
@@ -189,10 +230,8 @@ func genaiContentsToAnthropicMessages(contents []*genai.Content, config *genai.G
 			// Regular user message
 			var contentBlocks []anthropic.ContentBlockParamUnion
 
-			// Add images first
-			for _, img := range imageParts {
-				contentBlocks = append(contentBlocks, anthropic.NewImageBlockBase64(img.mimeType, base64.StdEncoding.EncodeToString(img.data)))
-			}
+			// Add images and documents first
+			contentBlocks = append(contentBlocks, fileBlocks...)
 
 			// Add text
 			if len(textParts) > 0 {
@@ -264,7 +303,7 @@ func runAnthropicStreaming(ctx context.Context, m *AnthropicModel, params anthro
 		inputJSON string
 	})
 	var stopReason anthropic.StopReason
-	var inputTokens, outputTokens int64
+	var inputTokens, cacheCreationTokens, cacheReadTokens, outputTokens int64
 
 	for stream.Next() {
 		event := stream.Current()
@@ -272,6 +311,8 @@ func runAnthropicStreaming(ctx context.Context, m *AnthropicModel, params anthro
 		switch e := event.AsAny().(type) {
 		case anthropic.MessageStartEvent:
 			inputTokens = e.Message.Usage.InputTokens
+			cacheCreationTokens = e.Message.Usage.CacheCreationInputTokens
+			cacheReadTokens = e.Message.Usage.CacheReadInputTokens
 		case anthropic.ContentBlockStartEvent:
 			idx := int(e.Index)
 			if e.ContentBlock.Type == "tool_use" {
@@ -338,13 +379,7 @@ func runAnthropicStreaming(ctx context.Context, m *AnthropicModel, params anthro
 		}
 	}
 
-	var usage *genai.GenerateContentResponseUsageMetadata
-	if inputTokens > 0 || outputTokens > 0 {
-		usage = &genai.GenerateContentResponseUsageMetadata{
-			PromptTokenCount:     int32(inputTokens),
-			CandidatesTokenCount: int32(outputTokens),
-		}
-	}
+	usage := anthropicUsageMetadata(inputTokens, cacheCreationTokens, cacheReadTokens, outputTokens)
 	resp := &model.LLMResponse{
 		Partial:       false,
 		TurnComplete:  true,
@@ -385,13 +420,8 @@ func runAnthropicNonStreaming(ctx context.Context, m *AnthropicModel, params ant
 	}
 
 	// Build usage metadata
-	var usage *genai.GenerateContentResponseUsageMetadata
-	if message.Usage.InputTokens > 0 || message.Usage.OutputTokens > 0 {
-		usage = &genai.GenerateContentResponseUsageMetadata{
-			PromptTokenCount:     int32(message.Usage.InputTokens),
-			CandidatesTokenCount: int32(message.Usage.OutputTokens),
-		}
-	}
+	usage := anthropicUsageMetadata(message.Usage.InputTokens, message.Usage.CacheCreationInputTokens,
+		message.Usage.CacheReadInputTokens, message.Usage.OutputTokens)
 
 	resp := &model.LLMResponse{
 		Partial:       false,
@@ -0,0 +1,145 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/anthropics/anthropic-sdk-go"
+	"google.golang.org/genai"
+)
+
+func TestApplyAnthropicPromptCaching(t *testing.T) {
+	newParams := func() anthropic.MessageNewParams {
+		return anthropic.MessageNewParams{
+			Model:     "claude-sonnet-4-20250514",
+			MaxTokens: 1024,
+			System:    []anthropic.TextBlockParam{{Text: "You are a Kubernetes expert."}},
+			Tools: []anthropic.ToolUnionParam{
+				{OfTool: &anthropic.ToolParam{Name: "get_pods", InputSchema: anthropic.ToolInputSchemaParam{}}},
+				{OfTool: &anthropic.ToolParam{Name: "get_logs", InputSchema: anthropic.ToolInputSchemaParam{}}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name            string
+		cfg             *AnthropicConfig
+		wantSystemCache string
+		wantToolCache   []string
+	}{
+		{
+			name:          "disabled",
+			cfg:           &AnthropicConfig{},
+			wantToolCache: []string{"", ""},
+		},
+		{
+			name:            "system only",
+			cfg:             &AnthropicConfig{CacheSystemInstruction: true},
+			wantSystemCache: "ephemeral",
+			wantToolCache:   []string{"", ""},
+		},
+		{
+			name:          "tools mark only the last tool",
+			cfg:           &AnthropicConfig{CacheTools: true},
+			wantToolCache: []string{"", "ephemeral"},
+		},
+		{
+			name:            "both with one hour ttl",
+			cfg:             &AnthropicConfig{CacheSystemInstruction: true, CacheTools: true, CacheTTL: "1h"},
+			wantSystemCache: "ephemeral/1h",
+			wantToolCache:   []string{"", "ephemeral/1h"},
+		},
+	}
+
+	type cacheControl struct {
+		Type string `json:"type"`
+		TTL  string `json:"ttl"`
+	}
+	type block struct {
+		CacheControl *cacheControl `json:"cache_control"`
+	}
+	describe := func(b block) string {
+		if b.CacheControl == nil {
+			return ""
+		}
+		if b.CacheControl.TTL != "" {
+			return b.CacheControl.Type + "/" + b.CacheControl.TTL
+		}
+		return b.CacheControl.Type
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := newParams()
+			applyAnthropicPromptCaching(&params, tt.cfg)
+
+			data, err := json.Marshal(params)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got struct {
+				System []block `json:"system"`
+				Tools  []block `json:"tools"`
+			}
+			if err := json.Unmarshal(data, &got); err != nil {
+				t.Fatal(err)
+			}
+			if s := describe(got.System[0]); s != tt.wantSystemCache {
+				t.Errorf("system cache_control = %q, want %q", s, tt.wantSystemCache)
+			}
+			for i, want := range tt.wantToolCache {
+				if s := describe(got.Tools[i]); s != want {
+					t.Errorf("tools[%d] cache_control = %q, want %q", i, s, want)
+				}
+			}
+		})
+	}
+}
+
+func TestAnthropicUsageMetadata(t *testing.T) {
+	if got := anthropicUsageMetadata(0, 0, 0, 0); got != nil {
+		t.Errorf("expected nil usage for empty counts, got %+v", got)
+	}
+
+	got := anthropicUsageMetadata(50, 200, 1000, 30)
+	if got.PromptTokenCount != 1250 {
+		t.Errorf("PromptTokenCount = %d, want 1250", got.PromptTokenCount)
+	}
+	if got.CachedContentTokenCount != 1000 {
+		t.Errorf("CachedContentTokenCount = %d, want 1000", got.CachedContentTokenCount)
+	}
+	if got.CandidatesTokenCount != 30 {
+		t.Errorf("CandidatesTokenCount = %d, want 30", got.CandidatesTokenCount)
+	}
+}
+
+func TestGenaiContentsToAnthropicMessagesAttachments(t *testing.T) {
+	contents := []*genai.Content{{
+		Role: string(genai.RoleUser),
+		Parts: []*genai.Part{
+			{Text: "What is wrong?"},
+			{InlineData: &genai.Blob{MIMEType: "image/png", Data: []byte("png")}},
+			{InlineData: &genai.Blob{MIMEType: "application/pdf", Data: []byte("pdf"), DisplayName: "runbook.pdf"}},
+			{InlineData: &genai.Blob{MIMEType: "text/plain", Data: []byte("OOMKilled"), DisplayName: "pod.log"}},
+			{InlineData: &genai.Blob{MIMEType: "application/octet-stream", Data: []byte{0}}},
+		},
+	}}
+	msgs, _ := genaiContentsToAnthropicMessages(contents, nil)
+	if len(msgs) != 1 {
+		t.Fatalf("len(messages) = %d, want 1", len(msgs))
+	}
+	blocks := msgs[0].Content
+	if len(blocks) != 3 {
+		t.Fatalf("len(blocks) = %d, want 3", len(blocks))
+	}
+	if blocks[0].OfImage == nil || blocks[0].OfImage.Source.OfBase64.Data != base64.StdEncoding.EncodeToString([]byte("png")) {
+		t.Errorf("blocks[0] = %+v, want the image", blocks[0])
+	}
+	if blocks[1].OfDocument == nil || blocks[1].OfDocument.Source.OfBase64.Data != base64.StdEncoding.EncodeToString([]byte("pdf")) {
+		t.Errorf("blocks[1] = %+v, want the PDF", blocks[1])
+	}
+	if blocks[2].OfText == nil || blocks[2].OfText.Text != "What is wrong?\n<file name=\"pod.log\" type=\"text/plain\">\nOOMKilled\n</file>" {
+		t.Errorf("blocks[2] = %+v, want the text with the text file", blocks[2])
+	}
+}
@@ -1,17 +1,27 @@
 package models
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"google.golang.org/genai"
 )
 
 // defaultTimeout is the default execution timeout used by model implementations.
 const defaultTimeout = 30 * time.Minute
 
+// maxIdleConnsPerHost sizes the idle connection pool of each model client.
+// http.DefaultTransport keeps only two idle connections per host, so
+// concurrent sessions would otherwise redo the TCP and TLS handshakes before
+// their first token.
+const maxIdleConnsPerHost = 32
+
 // TransportConfig holds TLS, passthrough, and header settings shared by all model providers.
 type TransportConfig struct {
 	Headers               map[string]string
@@ -23,10 +33,13 @@ type TransportConfig struct {
 }
 
 // BuildHTTPClient creates an http.Client with the full transport stack:
-// TLS → custom headers → timeout.
+// pooled connections → TLS → custom headers → tracing → timeout. Tracing
+// records a client span for each provider request under the generate_content
+// span. Each client owns its connection pool, so a model created once per
+// agent keeps its provider connections warm across requests.
 func BuildHTTPClient(tc TransportConfig) (*http.Client, error) {
 	transport, err := BuildTLSTransport(
-		http.DefaultTransport,
+		newPooledTransport(),
 		tc.TLSInsecureSkipVerify,
 		tc.TLSCACertPath,
 		tc.TLSDisableSystemCAs,
@@ -44,7 +57,17 @@ func BuildHTTPClient(tc TransportConfig) (*http.Client, error) {
 		timeout = time.Duration(*tc.Timeout) * time.Second
 	}
 
-	return &http.Client{Timeout: timeout, Transport: transport}, nil
+	return &http.Client{Timeout: timeout, Transport: otelhttp.NewTransport(transport)}, nil
+}
+
+// newPooledTransport returns a copy of http.DefaultTransport that keeps more
+// idle keep-alive connections per host and negotiates HTTP/2 where the
+// provider supports it.
+func newPooledTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConnsPerHost = maxIdleConnsPerHost
+	transport.ForceAttemptHTTP2 = true
+	return transport
 }
 
 // BearerTokenKey is the context key for storing the bearer token for API key passthrough
@@ -186,3 +209,30 @@ func mergeSystemInstructionFromConfig(existing string, config *genai.GenerateCon
 	}
 	return strings.TrimSpace(b.String())
 }
+
+const mimeTypePDF = "application/pdf"
+
+// textMIMETypes are the non-text/* media types of files whose content is
+// passed to models as text.
+var textMIMETypes = []string{"application/json", "application/yaml", "application/x-yaml"}
+
+// attachedText returns the content of a text file attached inline to a
+// message, labelled with its name, for providers that take only images and
+// documents as binary input. It reports false for other files.
+func attachedText(blob *genai.Blob) (string, bool) {
+	mimeType, _, _ := strings.Cut(blob.MIMEType, ";")
+	mimeType = strings.TrimSpace(mimeType)
+	if !strings.HasPrefix(mimeType, "text/") && !slices.Contains(textMIMETypes, mimeType) {
+		return "", false
+	}
+	// The ADK encodes A2A data parts it cannot convert as text/plain blobs
+	// with this prefix; they are not files.
+	if bytes.HasPrefix(blob.Data, []byte("<a2a_datapart_json>")) {
+		return "", false
+	}
+	name := blob.DisplayName
+	if name == "" {
+		name = "attachment"
+	}
+	return fmt.Sprintf("<file name=%q type=%q>\n%s\n</file>", name, mimeType, blob.Data), true
+}
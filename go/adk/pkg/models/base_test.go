@@ -64,3 +64,41 @@ func TestMergeSystemInstructionFromConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestAttachedText(t *testing.T) {
+	tests := []struct {
+		name   string
+		blob   *genai.Blob
+		want   string
+		wantOK bool
+	}{
+		{
+			name:   "text file",
+			blob:   &genai.Blob{MIMEType: "text/plain; charset=utf-8", Data: []byte("OOMKilled"), DisplayName: "pod.log"},
+			want:   "<file name=\"pod.log\" type=\"text/plain\">\nOOMKilled\n</file>",
+			wantOK: true,
+		},
+		{
+			name:   "unnamed yaml file",
+			blob:   &genai.Blob{MIMEType: "application/yaml", Data: []byte("kind: Pod")},
+			want:   "<file name=\"attachment\" type=\"application/yaml\">\nkind: Pod\n</file>",
+			wantOK: true,
+		},
+		{
+			name: "data part",
+			blob: &genai.Blob{MIMEType: "text/plain", Data: []byte(`<a2a_datapart_json>{"a":1}</a2a_datapart_json>`)},
+		},
+		{
+			name: "binary file",
+			blob: &genai.Blob{MIMEType: "application/octet-stream", Data: []byte{0, 1}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := attachedText(tt.blob)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("attachedText() = %q, %v, want %q, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
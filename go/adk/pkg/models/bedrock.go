@@ -71,8 +71,11 @@ func sanitizeBedrockToolID(id string, idMap map[string]string, counter *int) str
 // BedrockConfig holds Bedrock configuration for the Converse API
 type BedrockConfig struct {
 	TransportConfig
-	Model                        string
-	Region                       string
+	Model  string
+	Region string
+	// Endpoint, when set, replaces the regional Bedrock runtime endpoint,
+	// e.g. with a VPC interface endpoint. Requests are still signed for Region.
+	Endpoint                     string
 	MaxTokens                    *int
 	Temperature                  *float64
 	TopP                         *float64
@@ -106,12 +109,19 @@ func bedrockCachePointBlock(cacheTTL string) types.CachePointBlock {
 	return block
 }
 
+// bedrockRuntimeEndpoint returns the default Bedrock runtime endpoint of region.
+func bedrockRuntimeEndpoint(region string) string {
+	return fmt.Sprintf("https://bedrock-runtime.%s.amazonaws.com", region)
+}
+
 // BedrockModel implements model.LLM for Amazon Bedrock using the Converse API.
 // This supports all Bedrock model families (Anthropic, Amazon, Mistral, Cohere, etc.)
 type BedrockModel struct {
 	Config *BedrockConfig
 	Client *bedrockruntime.Client
 	Logger logr.Logger
+
+	providerConn
 }
 
 // Name returns the model name.
@@ -149,16 +159,24 @@ func NewBedrockModelWithLogger(ctx context.Context, config *BedrockConfig, logge
 	// Create Bedrock runtime client
 	client := bedrockruntime.NewFromConfig(awsCfg, func(o *bedrockruntime.Options) {
 		o.HTTPClient = httpClient
+		if config.Endpoint != "" {
+			o.BaseEndpoint = aws.String(config.Endpoint)
+		}
 	})
 
 	if logger.GetSink() != nil {
-		logger.Info("Initialized Bedrock Converse API model", "model", config.Model, "region", region)
+		logger.Info("Initialized Bedrock Converse API model", "model", config.Model, "region", region, "endpoint", config.Endpoint)
 	}
 
+	endpoint := config.Endpoint
+	if endpoint == "" {
+		endpoint = bedrockRuntimeEndpoint(region)
+	}
 	return &BedrockModel{
-		Config: config,
-		Client: client,
-		Logger: logger,
+		Config:       config,
+		Client:       client,
+		Logger:       logger,
+		providerConn: providerConn{httpClient: httpClient, endpoint: endpoint},
 	}, nil
 }
 
@@ -1,12 +1,17 @@
 package models
 
 import (
+	"context"
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime/types"
+	"github.com/go-logr/logr"
 	"github.com/google/jsonschema-go/jsonschema"
+	"google.golang.org/adk/v2/model"
 	"google.golang.org/genai"
 )
 
@@ -815,3 +820,44 @@ func TestConvertGenaiToolsToBedrockPromptCaching(t *testing.T) {
 		}
 	})
 }
+
+func TestBedrockEndpointOverride(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv("AWS_CONFIG_FILE", "/dev/null")
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", "/dev/null")
+
+	var gotPath, gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotAuth = r.URL.Path, r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"output":{"message":{"role":"assistant","content":[{"text":"hi"}]}},"stopReason":"end_turn","usage":{"inputTokens":1,"outputTokens":1,"totalTokens":2}}`))
+	}))
+	defer server.Close()
+
+	m, err := NewBedrockModelWithLogger(context.Background(), &BedrockConfig{
+		Model:    "anthropic.claude-3-haiku-20240307-v1:0",
+		Region:   "eu-west-1",
+		Endpoint: server.URL,
+	}, logr.Discard())
+	if err != nil {
+		t.Fatalf("NewBedrockModelWithLogger() error = %v", err)
+	}
+
+	req := &model.LLMRequest{Contents: []*genai.Content{genai.NewContentFromText("hello", genai.RoleUser)}}
+	for resp, err := range m.GenerateContent(context.Background(), req, false) {
+		if err != nil {
+			t.Fatalf("GenerateContent() error = %v", err)
+		}
+		if resp.Content == nil || resp.Content.Parts[0].Text != "hi" {
+			t.Errorf("unexpected response %+v", resp)
+		}
+	}
+
+	if gotPath != "/model/anthropic.claude-3-haiku-20240307-v1:0/converse" {
+		t.Errorf("request path = %q", gotPath)
+	}
+	if !strings.HasPrefix(gotAuth, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/") || !strings.Contains(gotAuth, "/eu-west-1/bedrock/aws4_request") {
+		t.Errorf("request not SigV4-signed for eu-west-1: %q", gotAuth)
+	}
+}
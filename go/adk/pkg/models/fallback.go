@@ -0,0 +1,215 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"net"
+	"regexp"
+	"slices"
+
+	"github.com/anthropics/anthropic-sdk-go"
+	"github.com/go-logr/logr"
+	"github.com/kagent-dev/kagent/go/api/adk"
+	"github.com/ollama/ollama/api"
+	"github.com/openai/openai-go/v3"
+	adkmodel "google.golang.org/adk/v2/model"
+	"google.golang.org/genai"
+)
+
+const (
+	// ServedModelMetadataKey is the LLMResponse.CustomMetadata key naming the
+	// model that produced the response.
+	ServedModelMetadataKey = "served_model"
+	// FallbackIndexMetadataKey is the LLMResponse.CustomMetadata key holding
+	// the 1-based position in the fallback chain of the model that produced
+	// the response. It is absent when the primary model served it.
+	FallbackIndexMetadataKey = "model_fallback_index"
+)
+
+// Fallback is a model to fail over to, restricted to the error classes in
+// Triggers (see adk.ModelFallback).
+type Fallback struct {
+	LLM      adkmodel.LLM
+	Triggers []string
+}
+
+// FallbackLLM forwards requests to a primary model and, when it fails with a
+// server error, rate limit or timeout before producing any response, retries
+// them against the first applicable fallback. Provider clients retry
+// transient errors themselves, so failover only happens once those retries
+// are exhausted. Once a model has yielded a response its stream is passed
+// through unchanged, so partial output is never duplicated.
+type FallbackLLM struct {
+	adkmodel.LLM
+	fallbacks []Fallback
+	log       logr.Logger
+}
+
+// NewFallbackLLM returns primary wrapped with the given fallbacks, or primary
+// itself when there are none.
+func NewFallbackLLM(primary adkmodel.LLM, fallbacks []Fallback, log logr.Logger) adkmodel.LLM {
+	if len(fallbacks) == 0 {
+		return primary
+	}
+	return &FallbackLLM{LLM: primary, fallbacks: fallbacks, log: log}
+}
+
+func (m *FallbackLLM) GenerateContent(ctx context.Context, req *adkmodel.LLMRequest, stream bool) iter.Seq2[*adkmodel.LLMResponse, error] {
+	return func(yield func(*adkmodel.LLMResponse, error) bool) {
+		llm, index := m.LLM, 0
+		for {
+			failedWith := ""
+			started := false
+			for resp, err := range llm.GenerateContent(ctx, requestFor(req, llm, index), stream) {
+				if !started {
+					started = true
+					// A cancelled or expired request would fail on every model.
+					if class := classifyModelFailure(resp, err); class != "" && ctx.Err() == nil && m.next(index, class) > 0 {
+						failedWith = class
+						m.log.Info("Model request failed, failing over", "model", llm.Name(), "reason", class, "error", failureMessage(resp, err))
+						break
+					}
+				}
+				if resp != nil {
+					tagServedModel(resp, llm.Name(), index)
+				}
+				if !yield(resp, err) {
+					return
+				}
+			}
+			if failedWith == "" {
+				return
+			}
+			index = m.next(index, failedWith)
+			llm = m.fallbacks[index-1].LLM
+		}
+	}
+}
+
+// next returns the chain position (1-based into fallbacks) of the first
+// fallback after position from that handles class, or 0 if there is none.
+func (m *FallbackLLM) next(from int, class string) int {
+	for i := from; i < len(m.fallbacks); i++ {
+		if triggers := m.fallbacks[i].Triggers; len(triggers) == 0 || slices.Contains(triggers, class) {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// requestFor points req at llm. The agent fills in the primary's name, which
+// several providers prefer over their own configured model.
+func requestFor(req *adkmodel.LLMRequest, llm adkmodel.LLM, index int) *adkmodel.LLMRequest {
+	if index == 0 || req == nil {
+		return req
+	}
+	clone := *req
+	clone.Model = llm.Name()
+	return &clone
+}
+
+func tagServedModel(resp *adkmodel.LLMResponse, name string, index int) {
+	if resp.CustomMetadata == nil {
+		resp.CustomMetadata = map[string]any{}
+	}
+	resp.CustomMetadata[ServedModelMetadataKey] = name
+	if index > 0 {
+		resp.CustomMetadata[FallbackIndexMetadataKey] = index
+	}
+}
+
+func failureMessage(resp *adkmodel.LLMResponse, err error) string {
+	if err != nil {
+		return err.Error()
+	}
+	return resp.ErrorCode + ": " + resp.ErrorMessage
+}
+
+var (
+	rateLimitedMessage = regexp.MustCompile(`(?i)\b429\b|rate.?limit|too many requests`)
+	serverErrorMessage = regexp.MustCompile(`(?i)\b5\d\d\b|internal server error|service unavailable|bad gateway|overloaded`)
+	timeoutMessage     = regexp.MustCompile(`(?i)time[d ]?out|deadline exceeded`)
+)
+
+// classifyModelFailure returns the adk.ModelFallbackOn* class of a failed
+// model call, or "" if the response is not a failure that warrants failover.
+func classifyModelFailure(resp *adkmodel.LLMResponse, err error) string {
+	if err != nil {
+		return classifyModelError(err)
+	}
+	if resp == nil || resp.ErrorCode == "" {
+		return ""
+	}
+	return classifyErrorMessage(resp.ErrorMessage)
+}
+
+func classifyModelError(err error) string {
+	if errors.Is(err, context.Canceled) {
+		return ""
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return adk.ModelFallbackOnTimeout
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return adk.ModelFallbackOnTimeout
+	}
+	if status := errorStatusCode(err); status != 0 {
+		return classifyStatusCode(status)
+	}
+	return classifyErrorMessage(err.Error())
+}
+
+// errorStatusCode extracts the HTTP status code from the provider SDK errors
+// returned by this package's models.
+func errorStatusCode(err error) int {
+	var openaiErr *openai.Error
+	if errors.As(err, &openaiErr) {
+		return openaiErr.StatusCode
+	}
+	var anthropicErr *anthropic.Error
+	if errors.As(err, &anthropicErr) {
+		return anthropicErr.StatusCode
+	}
+	var genaiErr genai.APIError
+	if errors.As(err, &genaiErr) {
+		return genaiErr.Code
+	}
+	var ollamaErr api.StatusError
+	if errors.As(err, &ollamaErr) {
+		return ollamaErr.StatusCode
+	}
+	// AWS SDK errors.
+	var httpErr interface{ HTTPStatusCode() int }
+	if errors.As(err, &httpErr) {
+		return httpErr.HTTPStatusCode()
+	}
+	return 0
+}
+
+func classifyStatusCode(status int) string {
+	switch {
+	case status == 429:
+		return adk.ModelFallbackOnRateLimited
+	case status == 408 || status == 504:
+		return adk.ModelFallbackOnTimeout
+	case status >= 500:
+		return adk.ModelFallbackOnServerError
+	}
+	return ""
+}
+
+// classifyErrorMessage handles providers that report failures as an
+// LLMResponse error message rather than a typed error.
+func classifyErrorMessage(message string) string {
+	switch {
+	case rateLimitedMessage.MatchString(message):
+		return adk.ModelFallbackOnRateLimited
+	case timeoutMessage.MatchString(message):
+		return adk.ModelFallbackOnTimeout
+	case serverErrorMessage.MatchString(message):
+		return adk.ModelFallbackOnServerError
+	}
+	return ""
+}
@@ -0,0 +1,205 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/kagent-dev/kagent/go/api/adk"
+	"github.com/openai/openai-go/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	adkmodel "google.golang.org/adk/v2/model"
+	"google.golang.org/genai"
+)
+
+// scriptedLLM yields a fixed sequence of responses and records the model
+// names it was asked for.
+type scriptedLLM struct {
+	name      string
+	responses []*adkmodel.LLMResponse
+	err       error
+	requested []string
+}
+
+func (m *scriptedLLM) Name() string { return m.name }
+
+func (m *scriptedLLM) GenerateContent(_ context.Context, req *adkmodel.LLMRequest, _ bool) iter.Seq2[*adkmodel.LLMResponse, error] {
+	m.requested = append(m.requested, req.Model)
+	return func(yield func(*adkmodel.LLMResponse, error) bool) {
+		if m.err != nil {
+			yield(nil, m.err)
+			return
+		}
+		for _, resp := range m.responses {
+			if !yield(resp, nil) {
+				return
+			}
+		}
+	}
+}
+
+func openAIError(status int) *openai.Error {
+	return &openai.Error{
+		StatusCode: status,
+		Request:    httptest.NewRequest(http.MethodPost, "https://api.openai.com/v1/chat/completions", nil),
+		Response:   &http.Response{StatusCode: status},
+	}
+}
+
+func textResponse(text string) *adkmodel.LLMResponse {
+	return &adkmodel.LLMResponse{Content: genai.NewContentFromText(text, genai.RoleModel)}
+}
+
+func TestFallbackLLM(t *testing.T) {
+	rateLimited := openAIError(http.StatusTooManyRequests)
+	serverError := fmt.Errorf("anthropic API error: %w", genai.APIError{Code: http.StatusServiceUnavailable})
+
+	tests := []struct {
+		name          string
+		primary       *scriptedLLM
+		fallbacks     []*scriptedLLM
+		triggers      [][]string
+		wantText      []string
+		wantErr       bool
+		wantServed    string
+		wantRequested []int
+	}{
+		{
+			name:          "primary succeeds",
+			primary:       &scriptedLLM{name: "gpt-4o", responses: []*adkmodel.LLMResponse{textResponse("hi")}},
+			fallbacks:     []*scriptedLLM{{name: "claude"}},
+			wantText:      []string{"hi"},
+			wantServed:    "gpt-4o",
+			wantRequested: []int{1, 0},
+		},
+		{
+			name:          "rate limit fails over",
+			primary:       &scriptedLLM{name: "gpt-4o", err: rateLimited},
+			fallbacks:     []*scriptedLLM{{name: "claude", responses: []*adkmodel.LLMResponse{textResponse("a"), textResponse("b")}}},
+			wantText:      []string{"a", "b"},
+			wantServed:    "claude",
+			wantRequested: []int{1, 1},
+		},
+		{
+			name:          "error response fails over",
+			primary:       &scriptedLLM{name: "llama", responses: []*adkmodel.LLMResponse{{ErrorCode: "API_ERROR", ErrorMessage: "500 Internal Server Error: model crashed"}}},
+			fallbacks:     []*scriptedLLM{{name: "claude", responses: []*adkmodel.LLMResponse{textResponse("ok")}}},
+			wantText:      []string{"ok"},
+			wantServed:    "claude",
+			wantRequested: []int{1, 1},
+		},
+		{
+			name:          "chain skips fallbacks not triggered by the error",
+			primary:       &scriptedLLM{name: "gpt-4o", err: serverError},
+			fallbacks:     []*scriptedLLM{{name: "azure"}, {name: "claude", responses: []*adkmodel.LLMResponse{textResponse("ok")}}},
+			triggers:      [][]string{{adk.ModelFallbackOnRateLimited}, nil},
+			wantText:      []string{"ok"},
+			wantServed:    "claude",
+			wantRequested: []int{1, 0, 1},
+		},
+		{
+			name:          "chain continues after a failed fallback",
+			primary:       &scriptedLLM{name: "gpt-4o", err: rateLimited},
+			fallbacks:     []*scriptedLLM{{name: "azure", err: context.DeadlineExceeded}, {name: "claude", responses: []*adkmodel.LLMResponse{textResponse("ok")}}},
+			wantText:      []string{"ok"},
+			wantServed:    "claude",
+			wantRequested: []int{1, 1, 1},
+		},
+		{
+			name:          "last error is returned when the chain is exhausted",
+			primary:       &scriptedLLM{name: "gpt-4o", err: rateLimited},
+			fallbacks:     []*scriptedLLM{{name: "claude", err: rateLimited}},
+			wantErr:       true,
+			wantRequested: []int{1, 1},
+		},
+		{
+			name:          "client errors do not fail over",
+			primary:       &scriptedLLM{name: "gpt-4o", err: openAIError(http.StatusBadRequest)},
+			fallbacks:     []*scriptedLLM{{name: "claude"}},
+			wantErr:       true,
+			wantRequested: []int{1, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fallbacks []Fallback
+			for i, llm := range tt.fallbacks {
+				fallback := Fallback{LLM: llm}
+				if i < len(tt.triggers) {
+					fallback.Triggers = tt.triggers[i]
+				}
+				fallbacks = append(fallbacks, fallback)
+			}
+			llm := NewFallbackLLM(tt.primary, fallbacks, logr.Discard())
+			assert.Equal(t, tt.primary.name, llm.Name())
+
+			var texts []string
+			var gotErr error
+			for resp, err := range llm.GenerateContent(context.Background(), &adkmodel.LLMRequest{Model: tt.primary.name}, true) {
+				if err != nil {
+					gotErr = err
+					continue
+				}
+				texts = append(texts, resp.Content.Parts[0].Text)
+				assert.Equal(t, tt.wantServed, resp.CustomMetadata[ServedModelMetadataKey])
+			}
+			if tt.wantErr {
+				require.Error(t, gotErr)
+			} else {
+				require.NoError(t, gotErr)
+			}
+			assert.Equal(t, tt.wantText, texts)
+
+			requested := []int{len(tt.primary.requested)}
+			for _, fallback := range tt.fallbacks {
+				requested = append(requested, len(fallback.requested))
+				for _, model := range fallback.requested {
+					assert.Equal(t, fallback.name, model, "fallbacks are asked for their own model")
+				}
+			}
+			assert.Equal(t, tt.wantRequested, requested)
+		})
+	}
+}
+
+func TestFallbackLLMDoesNotFailOverCancelledRequests(t *testing.T) {
+	primary := &scriptedLLM{name: "gpt-4o", err: context.Canceled}
+	fallback := &scriptedLLM{name: "claude"}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, err := range NewFallbackLLM(primary, []Fallback{{LLM: fallback}}, logr.Discard()).GenerateContent(ctx, &adkmodel.LLMRequest{}, false) {
+		assert.True(t, errors.Is(err, context.Canceled))
+	}
+	assert.Empty(t, fallback.requested)
+}
+
+func TestClassifyModelFailure(t *testing.T) {
+	tests := []struct {
+		name string
+		resp *adkmodel.LLMResponse
+		err  error
+		want string
+	}{
+		{name: "success", resp: textResponse("ok")},
+		{name: "openai rate limit", err: fmt.Errorf("request failed: %w", openAIError(429)), want: adk.ModelFallbackOnRateLimited},
+		{name: "gateway timeout", err: openAIError(504), want: adk.ModelFallbackOnTimeout},
+		{name: "genai server error", err: genai.APIError{Code: 500}, want: adk.ModelFallbackOnServerError},
+		{name: "bad request", err: openAIError(400)},
+		{name: "deadline", err: fmt.Errorf("call: %w", context.DeadlineExceeded), want: adk.ModelFallbackOnTimeout},
+		{name: "untyped error", err: errors.New("SAP AI Core request failed: status 503"), want: adk.ModelFallbackOnServerError},
+		{name: "error response rate limit", resp: &adkmodel.LLMResponse{ErrorCode: "API_ERROR", ErrorMessage: "ThrottlingException: Rate exceeded, too many requests"}, want: adk.ModelFallbackOnRateLimited},
+		{name: "error response timeout", resp: &adkmodel.LLMResponse{ErrorCode: "STREAM_ERROR", ErrorMessage: "read tcp: i/o timeout"}, want: adk.ModelFallbackOnTimeout},
+		{name: "error response other", resp: &adkmodel.LLMResponse{ErrorCode: "API_ERROR", ErrorMessage: "No choices in response"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, classifyModelFailure(tt.resp, tt.err))
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package models
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"google.golang.org/adk/v2/model"
+	adkgemini "google.golang.org/adk/v2/model/gemini"
+	"google.golang.org/genai"
+)
+
+// GeminiConfig holds the generation, function-calling and safety options
+// applied to every request of a Gemini model (Gemini API or Vertex AI).
+type GeminiConfig struct {
+	Model            string
+	Temperature      *float64
+	TopP             *float64
+	TopK             *float64
+	MaxOutputTokens  *int
+	CandidateCount   *int
+	StopSequences    []string
+	ResponseMimeType string
+	// ToolChoice is auto, any, none or validated. A forced choice (any) only
+	// applies to the first model call of a turn, see toolConfig.
+	ToolChoice           string
+	AllowedFunctionNames []string
+	// ParallelToolCalls false keeps only the first function call of each
+	// response; Gemini has no request option to disable parallel calls.
+	ParallelToolCalls *bool
+	SafetySettings    []*genai.SafetySetting
+}
+
+// GeminiModel implements model.LLM for Gemini models. It shapes requests and
+// maps responses around the genai client used by the ADK Gemini model.
+type GeminiModel struct {
+	Config *GeminiConfig
+	// llm is the ADK Gemini model, used for streaming requests.
+	llm    model.LLM
+	client *genai.Client
+	Logger logr.Logger
+}
+
+// NewGeminiModelWithLogger creates a Gemini model for the backend selected by
+// clientConfig.
+func NewGeminiModelWithLogger(ctx context.Context, config *GeminiConfig, clientConfig *genai.ClientConfig, logger logr.Logger) (*GeminiModel, error) {
+	if err := validateGeminiToolChoice(config.ToolChoice); err != nil {
+		return nil, err
+	}
+	llm, err := adkgemini.NewModel(ctx, config.Model, clientConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Gemini client: %w", err)
+	}
+	withClient, ok := llm.(interface{ Client() *genai.Client })
+	if !ok {
+		return nil, fmt.Errorf("ADK Gemini model does not expose its genai client")
+	}
+	return &GeminiModel{
+		Config: config,
+		llm:    llm,
+		client: withClient.Client(),
+		Logger: logger,
+	}, nil
+}
+
+// Name returns the model name.
+func (m *GeminiModel) Name() string {
+	return m.Config.Model
+}
+
+// GetGoogleLLMVariant returns the genai backend. The ADK checks it to decide
+// which Gemini features, such as output schemas with tools, the model supports.
+func (m *GeminiModel) GetGoogleLLMVariant() genai.Backend {
+	return m.client.ClientConfig().Backend
+}
+
+// Client returns the genai client, which the ADK uses for live sessions.
+func (m *GeminiModel) Client() *genai.Client {
+	return m.client
+}
+
+func validateGeminiToolChoice(choice string) error {
+	switch choice {
+	case "", "auto", "any", "none", "validated":
+		return nil
+	default:
+		return fmt.Errorf("invalid Gemini tool choice %q: must be one of auto, any, none, validated", choice)
+	}
+}
@@ -0,0 +1,313 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+
+	"github.com/kagent-dev/kagent/go/adk/pkg/telemetry"
+	"google.golang.org/adk/v2/model"
+	"google.golang.org/genai"
+)
+
+// Error codes of the LLMResponses of requests or responses blocked by Gemini.
+const (
+	// ErrorCodeSafetyBlocked is set when a safety filter blocked the response.
+	ErrorCodeSafetyBlocked = "SAFETY_BLOCKED"
+	// ErrorCodePromptBlocked is set when the prompt was blocked and no
+	// response was generated.
+	ErrorCodePromptBlocked = "PROMPT_BLOCKED"
+	// ErrorCodeRecitationBlocked is set when the response was stopped for
+	// reciting training data.
+	ErrorCodeRecitationBlocked = "RECITATION_BLOCKED"
+)
+
+var geminiSafetyFinishReasons = []genai.FinishReason{
+	genai.FinishReasonSafety,
+	genai.FinishReasonBlocklist,
+	genai.FinishReasonProhibitedContent,
+	genai.FinishReasonSPII,
+	genai.FinishReasonImageSafety,
+	genai.FinishReasonImageProhibitedContent,
+}
+
+var geminiRecitationFinishReasons = []genai.FinishReason{
+	genai.FinishReasonRecitation,
+	genai.FinishReasonImageRecitation,
+}
+
+var geminiBlockedReasons = []genai.BlockedReason{
+	genai.BlockedReasonSafety,
+	genai.BlockedReasonOther,
+	genai.BlockedReasonBlocklist,
+	genai.BlockedReasonProhibitedContent,
+	genai.BlockedReasonImageSafety,
+	genai.BlockedReasonModelArmor,
+	genai.BlockedReasonJailbreak,
+}
+
+// geminiContinuePrompt ends a request on a user turn, as the ADK Gemini model
+// does, so the model continues after tool results.
+const geminiContinuePrompt = "Continue processing previous requests as instructed. Exit or provide a summary if no more outputs are needed."
+
+// GenerateContent implements model.LLM. Streaming requests go through the ADK
+// Gemini model, which aggregates partial responses; non-streaming requests
+// call the genai client directly so that a blocked prompt, which has no
+// candidates, is reported as an error code rather than a failed call.
+func (m *GeminiModel) GenerateContent(ctx context.Context, req *model.LLMRequest, stream bool) iter.Seq2[*model.LLMResponse, error] {
+	m.prepareRequest(req)
+	telemetry.SetLLMRequestAttributes(ctx, m.modelName(req), req)
+
+	if stream {
+		return func(yield func(*model.LLMResponse, error) bool) {
+			for resp, err := range m.llm.GenerateContent(ctx, req, true) {
+				if err == nil && resp != nil {
+					m.processResponse(resp)
+				}
+				if !yield(resp, err) {
+					return
+				}
+			}
+		}
+	}
+	return func(yield func(*model.LLMResponse, error) bool) {
+		yield(m.generate(ctx, req))
+	}
+}
+
+func (m *GeminiModel) modelName(req *model.LLMRequest) string {
+	if req.Model != "" {
+		return req.Model
+	}
+	return m.Config.Model
+}
+
+func (m *GeminiModel) generate(ctx context.Context, req *model.LLMRequest) (*model.LLMResponse, error) {
+	contents := req.Contents
+	if n := len(contents); n > 0 && contents[n-1] != nil && contents[n-1].Role != genai.RoleUser {
+		contents = append(slices.Clip(contents), genai.NewContentFromText(geminiContinuePrompt, genai.RoleUser))
+	}
+	resp, err := m.client.Models.GenerateContent(ctx, m.modelName(req), contents, req.Config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call model: %w", err)
+	}
+	llmResp := geminiResponseToLLM(resp)
+	m.processResponse(llmResp)
+	return llmResp, nil
+}
+
+// prepareRequest applies the model's configuration to the fields of the
+// request config that the agent left unset.
+func (m *GeminiModel) prepareRequest(req *model.LLMRequest) {
+	cfg := genai.GenerateContentConfig{}
+	if req.Config != nil {
+		cfg = *req.Config
+	}
+	c := m.Config
+	if cfg.Temperature == nil && c.Temperature != nil {
+		cfg.Temperature = genai.Ptr(float32(*c.Temperature))
+	}
+	if cfg.TopP == nil && c.TopP != nil {
+		cfg.TopP = genai.Ptr(float32(*c.TopP))
+	}
+	if cfg.TopK == nil && c.TopK != nil {
+		cfg.TopK = genai.Ptr(float32(*c.TopK))
+	}
+	if cfg.MaxOutputTokens == 0 && c.MaxOutputTokens != nil {
+		cfg.MaxOutputTokens = int32(*c.MaxOutputTokens)
+	}
+	if cfg.CandidateCount == 0 && c.CandidateCount != nil {
+		cfg.CandidateCount = int32(*c.CandidateCount)
+	}
+	if len(cfg.StopSequences) == 0 {
+		cfg.StopSequences = c.StopSequences
+	}
+	if cfg.ResponseMIMEType == "" {
+		cfg.ResponseMIMEType = c.ResponseMimeType
+	}
+	if len(cfg.SafetySettings) == 0 {
+		cfg.SafetySettings = c.SafetySettings
+	}
+	if cfg.ToolConfig == nil && hasFunctionDeclarations(cfg.Tools) {
+		cfg.ToolConfig = m.toolConfig(req.Contents)
+	}
+	req.Config = &cfg
+}
+
+// toolConfig returns the function-calling config of a request. A forced tool
+// call (any) applies only until the model has called a tool in this turn:
+// once the request ends with tool results the mode falls back to auto, so the
+// model can answer instead of calling tools forever.
+func (m *GeminiModel) toolConfig(contents []*genai.Content) *genai.ToolConfig {
+	var mode genai.FunctionCallingConfigMode
+	switch m.Config.ToolChoice {
+	case "":
+		return nil
+	case "auto":
+		mode = genai.FunctionCallingConfigModeAuto
+	case "any":
+		mode = genai.FunctionCallingConfigModeAny
+		if endsWithFunctionResponse(contents) {
+			return &genai.ToolConfig{FunctionCallingConfig: &genai.FunctionCallingConfig{Mode: genai.FunctionCallingConfigModeAuto}}
+		}
+	case "none":
+		mode = genai.FunctionCallingConfigModeNone
+	case "validated":
+		mode = genai.FunctionCallingConfigModeValidated
+	}
+	fc := &genai.FunctionCallingConfig{Mode: mode}
+	if mode == genai.FunctionCallingConfigModeAny || mode == genai.FunctionCallingConfigModeValidated {
+		fc.AllowedFunctionNames = m.Config.AllowedFunctionNames
+	}
+	return &genai.ToolConfig{FunctionCallingConfig: fc}
+}
+
+func hasFunctionDeclarations(tools []*genai.Tool) bool {
+	for _, t := range tools {
+		if t != nil && len(t.FunctionDeclarations) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func endsWithFunctionResponse(contents []*genai.Content) bool {
+	if len(contents) == 0 || contents[len(contents)-1] == nil {
+		return false
+	}
+	for _, p := range contents[len(contents)-1].Parts {
+		if p != nil && p.FunctionResponse != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// processResponse maps blocked prompts and responses to kagent error codes and
+// enforces ParallelToolCalls.
+func (m *GeminiModel) processResponse(resp *model.LLMResponse) {
+	switch {
+	case resp.FinishReason == "" && slices.Contains(geminiBlockedReasons, genai.BlockedReason(resp.ErrorCode)):
+		// The ADK model reports a blocked prompt with its block reason as the error code.
+		resp.ErrorMessage = promptBlockedMessage(genai.BlockedReason(resp.ErrorCode), resp.ErrorMessage)
+		resp.ErrorCode = ErrorCodePromptBlocked
+	case slices.Contains(geminiSafetyFinishReasons, resp.FinishReason):
+		resp.ErrorCode = ErrorCodeSafetyBlocked
+		if resp.ErrorMessage == "" {
+			resp.ErrorMessage = safetyBlockedMessage(resp.FinishReason, "", nil)
+		}
+	case slices.Contains(geminiRecitationFinishReasons, resp.FinishReason):
+		resp.ErrorCode = ErrorCodeRecitationBlocked
+		if resp.ErrorMessage == "" {
+			resp.ErrorMessage = fmt.Sprintf("Gemini stopped the response for reciting training data (finish reason %s)", resp.FinishReason)
+		}
+	}
+
+	if m.Config.ParallelToolCalls != nil && !*m.Config.ParallelToolCalls && resp.Content != nil {
+		if dropped := keepFirstFunctionCall(resp.Content); len(dropped) > 0 {
+			m.Logger.Info("Dropped parallel function calls", "kept", firstFunctionCallName(resp.Content), "dropped", dropped)
+		}
+	}
+}
+
+// geminiResponseToLLM converts the first candidate of resp. Responses without
+// content that did not stop normally carry their finish reason as error code,
+// as in the ADK Gemini model.
+func geminiResponseToLLM(resp *genai.GenerateContentResponse) *model.LLMResponse {
+	if len(resp.Candidates) == 0 || resp.Candidates[0] == nil {
+		if resp.PromptFeedback != nil && resp.PromptFeedback.BlockReason != "" {
+			return &model.LLMResponse{
+				ErrorCode:     ErrorCodePromptBlocked,
+				ErrorMessage:  promptBlockedMessage(resp.PromptFeedback.BlockReason, resp.PromptFeedback.BlockReasonMessage, resp.PromptFeedback.SafetyRatings...),
+				UsageMetadata: resp.UsageMetadata,
+				ModelVersion:  resp.ModelVersion,
+			}
+		}
+		return &model.LLMResponse{
+			ErrorCode:     "API_ERROR",
+			ErrorMessage:  "Gemini returned no candidates",
+			UsageMetadata: resp.UsageMetadata,
+			ModelVersion:  resp.ModelVersion,
+		}
+	}
+
+	candidate := resp.Candidates[0]
+	llmResp := &model.LLMResponse{
+		Content:           candidate.Content,
+		FinishReason:      candidate.FinishReason,
+		GroundingMetadata: candidate.GroundingMetadata,
+		CitationMetadata:  candidate.CitationMetadata,
+		AvgLogprobs:       candidate.AvgLogprobs,
+		LogprobsResult:    candidate.LogprobsResult,
+		UsageMetadata:     resp.UsageMetadata,
+		ModelVersion:      resp.ModelVersion,
+	}
+	hasContent := candidate.Content != nil && len(candidate.Content.Parts) > 0
+	if !hasContent && candidate.FinishReason != genai.FinishReasonStop {
+		llmResp.ErrorCode = string(candidate.FinishReason)
+		llmResp.ErrorMessage = candidate.FinishMessage
+	}
+	if slices.Contains(geminiSafetyFinishReasons, candidate.FinishReason) {
+		llmResp.ErrorMessage = safetyBlockedMessage(candidate.FinishReason, candidate.FinishMessage, candidate.SafetyRatings)
+	}
+	return llmResp
+}
+
+func promptBlockedMessage(reason genai.BlockedReason, message string, ratings ...*genai.SafetyRating) string {
+	return blockedMessage(fmt.Sprintf("the prompt (reason %s)", reason), message, ratings)
+}
+
+func safetyBlockedMessage(reason genai.FinishReason, message string, ratings []*genai.SafetyRating) string {
+	return blockedMessage(fmt.Sprintf("the response (finish reason %s)", reason), message, ratings)
+}
+
+func blockedMessage(what, message string, ratings []*genai.SafetyRating) string {
+	msg := "Gemini blocked " + what
+	if categories := blockedCategories(ratings); categories != "" {
+		msg += " in categories " + categories
+	}
+	if message != "" {
+		msg += ": " + message
+	}
+	return msg
+}
+
+func blockedCategories(ratings []*genai.SafetyRating) string {
+	var categories []string
+	for _, r := range ratings {
+		if r != nil && r.Blocked {
+			categories = append(categories, string(r.Category))
+		}
+	}
+	return strings.Join(categories, ", ")
+}
+
+// keepFirstFunctionCall removes every function call part of content but the
+// first and returns the names of the removed calls.
+func keepFirstFunctionCall(content *genai.Content) []string {
+	var dropped []string
+	seen := false
+	content.Parts = slices.DeleteFunc(content.Parts, func(p *genai.Part) bool {
+		if p == nil || p.FunctionCall == nil {
+			return false
+		}
+		if !seen {
+			seen = true
+			return false
+		}
+		dropped = append(dropped, p.FunctionCall.Name)
+		return true
+	})
+	return dropped
+}
+
+func firstFunctionCallName(content *genai.Content) string {
+	for _, p := range content.Parts {
+		if p != nil && p.FunctionCall != nil {
+			return p.FunctionCall.Name
+		}
+	}
+	return ""
+}
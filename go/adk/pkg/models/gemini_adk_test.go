@@ -0,0 +1,221 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"google.golang.org/adk/v2/model"
+	"google.golang.org/genai"
+)
+
+func newTestGeminiModel(t *testing.T, cfg *GeminiConfig, handler http.HandlerFunc) *GeminiModel {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	if cfg.Model == "" {
+		cfg.Model = "gemini-2.5-flash"
+	}
+	m, err := NewGeminiModelWithLogger(context.Background(), cfg, &genai.ClientConfig{
+		APIKey:      "test-key",
+		Backend:     genai.BackendGeminiAPI,
+		HTTPOptions: genai.HTTPOptions{BaseURL: server.URL},
+	}, logr.Discard())
+	if err != nil {
+		t.Fatalf("NewGeminiModelWithLogger() error = %v", err)
+	}
+	return m
+}
+
+func TestNewGeminiModelRejectsUnknownToolChoice(t *testing.T) {
+	_, err := NewGeminiModelWithLogger(context.Background(), &GeminiConfig{Model: "gemini-2.5-flash", ToolChoice: "required"}, &genai.ClientConfig{APIKey: "k", Backend: genai.BackendGeminiAPI}, logr.Discard())
+	if err == nil || !strings.Contains(err.Error(), "invalid Gemini tool choice") {
+		t.Fatalf("error = %v, want invalid tool choice", err)
+	}
+}
+
+func TestGeminiPrepareRequest(t *testing.T) {
+	tools := []*genai.Tool{{FunctionDeclarations: []*genai.FunctionDeclaration{{Name: "k8s_get_resources"}}}}
+	toolResult := &genai.Content{Role: genai.RoleUser, Parts: []*genai.Part{genai.NewPartFromFunctionResponse("k8s_get_resources", map[string]any{"ok": true})}}
+	m := &GeminiModel{Config: &GeminiConfig{
+		Temperature:          genai.Ptr(0.2),
+		MaxOutputTokens:      genai.Ptr(1024),
+		ToolChoice:           "any",
+		AllowedFunctionNames: []string{"k8s_get_resources"},
+		SafetySettings: []*genai.SafetySetting{
+			{Category: genai.HarmCategoryDangerousContent, Threshold: genai.HarmBlockThresholdBlockOnlyHigh},
+		},
+	}}
+
+	t.Run("applies config and forces a tool call", func(t *testing.T) {
+		req := &model.LLMRequest{
+			Contents: []*genai.Content{genai.NewContentFromText("list pods", genai.RoleUser)},
+			Config:   &genai.GenerateContentConfig{Tools: tools, TopP: genai.Ptr[float32](0.5)},
+		}
+		m.prepareRequest(req)
+		cfg := req.Config
+		if cfg.Temperature == nil || *cfg.Temperature != 0.2 || cfg.MaxOutputTokens != 1024 || len(cfg.SafetySettings) != 1 {
+			t.Errorf("config not applied: %+v", cfg)
+		}
+		if cfg.TopP == nil || *cfg.TopP != 0.5 {
+			t.Errorf("TopP = %v, want the request's 0.5", cfg.TopP)
+		}
+		fc := cfg.ToolConfig.FunctionCallingConfig
+		if fc.Mode != genai.FunctionCallingConfigModeAny || len(fc.AllowedFunctionNames) != 1 {
+			t.Errorf("FunctionCallingConfig = %+v, want ANY restricted to k8s_get_resources", fc)
+		}
+	})
+
+	t.Run("falls back to auto after tool results", func(t *testing.T) {
+		req := &model.LLMRequest{
+			Contents: []*genai.Content{genai.NewContentFromText("list pods", genai.RoleUser), toolResult},
+			Config:   &genai.GenerateContentConfig{Tools: tools},
+		}
+		m.prepareRequest(req)
+		fc := req.Config.ToolConfig.FunctionCallingConfig
+		if fc.Mode != genai.FunctionCallingConfigModeAuto || len(fc.AllowedFunctionNames) != 0 {
+			t.Errorf("FunctionCallingConfig = %+v, want AUTO", fc)
+		}
+	})
+
+	t.Run("no tool config without tools", func(t *testing.T) {
+		req := &model.LLMRequest{Contents: []*genai.Content{genai.NewContentFromText("hi", genai.RoleUser)}}
+		m.prepareRequest(req)
+		if req.Config.ToolConfig != nil {
+			t.Errorf("ToolConfig = %+v, want nil", req.Config.ToolConfig)
+		}
+	})
+}
+
+func TestGeminiProcessResponse(t *testing.T) {
+	calls := func(names ...string) *genai.Content {
+		content := &genai.Content{Role: genai.RoleModel}
+		for _, name := range names {
+			content.Parts = append(content.Parts, genai.NewPartFromFunctionCall(name, nil))
+		}
+		return content
+	}
+
+	tests := []struct {
+		name          string
+		parallel      *bool
+		resp          *model.LLMResponse
+		wantCode      string
+		wantMessage   string
+		wantCallCount int
+	}{
+		{
+			name:          "parallel calls kept by default",
+			resp:          &model.LLMResponse{Content: calls("a", "b")},
+			wantCallCount: 2,
+		},
+		{
+			name:          "parallel calls disabled",
+			parallel:      genai.Ptr(false),
+			resp:          &model.LLMResponse{Content: calls("a", "b", "c")},
+			wantCallCount: 1,
+		},
+		{
+			name:        "safety finish reason",
+			resp:        &model.LLMResponse{FinishReason: genai.FinishReasonSafety, ErrorCode: "SAFETY"},
+			wantCode:    ErrorCodeSafetyBlocked,
+			wantMessage: "Gemini blocked the response (finish reason SAFETY)",
+		},
+		{
+			name:        "blocked prompt from the stream",
+			resp:        &model.LLMResponse{ErrorCode: "PROHIBITED_CONTENT"},
+			wantCode:    ErrorCodePromptBlocked,
+			wantMessage: "Gemini blocked the prompt (reason PROHIBITED_CONTENT)",
+		},
+		{
+			name:        "recitation",
+			resp:        &model.LLMResponse{FinishReason: genai.FinishReasonRecitation, ErrorCode: "RECITATION"},
+			wantCode:    ErrorCodeRecitationBlocked,
+			wantMessage: "Gemini stopped the response for reciting training data (finish reason RECITATION)",
+		},
+		{
+			name:     "malformed function call is left as is",
+			resp:     &model.LLMResponse{FinishReason: genai.FinishReasonMalformedFunctionCall, ErrorCode: "MALFORMED_FUNCTION_CALL"},
+			wantCode: "MALFORMED_FUNCTION_CALL",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &GeminiModel{Config: &GeminiConfig{ParallelToolCalls: tt.parallel}, Logger: logr.Discard()}
+			m.processResponse(tt.resp)
+			if tt.resp.ErrorCode != tt.wantCode {
+				t.Errorf("ErrorCode = %q, want %q", tt.resp.ErrorCode, tt.wantCode)
+			}
+			if tt.wantMessage != "" && tt.resp.ErrorMessage != tt.wantMessage {
+				t.Errorf("ErrorMessage = %q, want %q", tt.resp.ErrorMessage, tt.wantMessage)
+			}
+			if tt.resp.Content != nil && len(tt.resp.Content.Parts) != tt.wantCallCount {
+				t.Errorf("got %d function calls, want %d", len(tt.resp.Content.Parts), tt.wantCallCount)
+			}
+		})
+	}
+}
+
+func TestGeminiGenerateContentBlocked(t *testing.T) {
+	tests := []struct {
+		name        string
+		response    map[string]any
+		wantCode    string
+		wantMessage string
+	}{
+		{
+			name: "prompt blocked",
+			response: map[string]any{
+				"promptFeedback": map[string]any{
+					"blockReason": "SAFETY",
+					"safetyRatings": []map[string]any{
+						{"category": "HARM_CATEGORY_DANGEROUS_CONTENT", "probability": "HIGH", "blocked": true},
+					},
+				},
+			},
+			wantCode:    ErrorCodePromptBlocked,
+			wantMessage: "Gemini blocked the prompt (reason SAFETY) in categories HARM_CATEGORY_DANGEROUS_CONTENT",
+		},
+		{
+			name: "response blocked",
+			response: map[string]any{
+				"candidates": []map[string]any{{
+					"finishReason": "SAFETY",
+					"safetyRatings": []map[string]any{
+						{"category": "HARM_CATEGORY_HARASSMENT", "probability": "MEDIUM", "blocked": true},
+						{"category": "HARM_CATEGORY_HATE_SPEECH", "probability": "NEGLIGIBLE"},
+					},
+				}},
+			},
+			wantCode:    ErrorCodeSafetyBlocked,
+			wantMessage: "Gemini blocked the response (finish reason SAFETY) in categories HARM_CATEGORY_HARASSMENT",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestGeminiModel(t, &GeminiConfig{}, func(w http.ResponseWriter, r *http.Request) {
+				if !strings.HasSuffix(r.URL.Path, ":generateContent") {
+					t.Errorf("unexpected request path %s", r.URL.Path)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(tt.response)
+			})
+
+			req := &model.LLMRequest{Contents: []*genai.Content{genai.NewContentFromText("hi", genai.RoleUser)}}
+			for resp, err := range m.GenerateContent(context.Background(), req, false) {
+				if err != nil {
+					t.Fatalf("GenerateContent() error = %v", err)
+				}
+				if resp.ErrorCode != tt.wantCode || resp.ErrorMessage != tt.wantMessage {
+					t.Errorf("got %q %q, want %q %q", resp.ErrorCode, resp.ErrorMessage, tt.wantCode, tt.wantMessage)
+				}
+			}
+		})
+	}
+}
@@ -23,6 +23,8 @@ type OllamaModel struct {
 	Config *OllamaConfig
 	Client *api.Client
 	Logger logr.Logger
+
+	providerConn
 }
 
 // Name returns the model name.
@@ -136,8 +138,9 @@ func NewOllamaModelWithLogger(config *OllamaConfig, logger logr.Logger) (*Ollama
 	}
 
 	return &OllamaModel{
-		Config: config,
-		Client: client,
-		Logger: logger,
+		Config:       config,
+		Client:       client,
+		Logger:       logger,
+		providerConn: providerConn{httpClient: httpClient, endpoint: baseURL.String()},
 	}, nil
 }
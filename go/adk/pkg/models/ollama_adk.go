@@ -2,8 +2,10 @@ package models
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"iter"
+	"net/http"
 	"strings"
 
 	"github.com/google/uuid"
@@ -13,6 +15,17 @@ import (
 	"google.golang.org/genai"
 )
 
+// Error codes of the LLMResponses of failed Ollama requests.
+const (
+	// ErrorCodeModelNotFound is set when the model has not been pulled onto
+	// the Ollama server.
+	ErrorCodeModelNotFound = "MODEL_NOT_FOUND"
+	// ErrorCodeModelLoading is set when the request failed while the model
+	// was still being loaded into the memory of the Ollama server, which for
+	// large models can take longer than the request timeout.
+	ErrorCodeModelLoading = "MODEL_LOADING"
+)
+
 // GenerateContent implements model.LLM for Ollama models using the native SDK.
 // It converts genai.Content to Ollama message format and handles tool conversion.
 func (m *OllamaModel) GenerateContent(ctx context.Context, req *model.LLMRequest, stream bool) iter.Seq2[*model.LLMResponse, error] {
@@ -50,16 +63,21 @@ func (m *OllamaModel) GenerateContent(ctx context.Context, req *model.LLMRequest
 		// Set telemetry attributes
 		telemetry.SetLLMRequestAttributes(ctx, modelName, req)
 
+		loaded := m.modelLoaded(ctx, modelName)
+		if !loaded {
+			m.Logger.Info("Ollama model is not loaded yet, the first response may take a while", "model", modelName)
+		}
+
 		if stream {
-			m.generateStreaming(ctx, modelName, messages, tools, options, yield)
+			m.generateStreaming(ctx, modelName, messages, tools, options, loaded, yield)
 		} else {
-			m.generateNonStreaming(ctx, modelName, messages, tools, options, yield)
+			m.generateNonStreaming(ctx, modelName, messages, tools, options, loaded, yield)
 		}
 	}
 }
 
 // generateStreaming handles streaming responses from Ollama.
-func (m *OllamaModel) generateStreaming(ctx context.Context, modelName string, messages []api.Message, tools []api.Tool, options map[string]any, yield func(*model.LLMResponse, error) bool) {
+func (m *OllamaModel) generateStreaming(ctx context.Context, modelName string, messages []api.Message, tools []api.Tool, options map[string]any, loaded bool, yield func(*model.LLMResponse, error) bool) {
 	var aggregatedText strings.Builder
 	// Ollama streams tool calls in intermediate chunks (done=false), not in the
 	// final done=true chunk, so accumulate them across all chunks.
@@ -74,7 +92,9 @@ func (m *OllamaModel) generateStreaming(ctx context.Context, modelName string, m
 		Stream:   &streamValue,
 	}
 
+	received := false
 	err := m.Client.Chat(ctx, chatReq, func(resp api.ChatResponse) error {
+		received = true
 		// Accumulate tool calls from any chunk that carries them.
 		aggregatedToolCalls = append(aggregatedToolCalls, resp.Message.ToolCalls...)
 
@@ -154,15 +174,13 @@ func (m *OllamaModel) generateStreaming(ctx context.Context, modelName string, m
 	})
 
 	if err != nil {
-		yield(&model.LLMResponse{
-			ErrorCode:    "API_ERROR",
-			ErrorMessage: err.Error(),
-		}, nil)
+		// Once chunks arrived the model was loaded, whatever failed later.
+		yield(ollamaErrorResponse(err, modelName, loaded || received), nil)
 	}
 }
 
 // generateNonStreaming handles non-streaming responses from Ollama.
-func (m *OllamaModel) generateNonStreaming(ctx context.Context, modelName string, messages []api.Message, tools []api.Tool, options map[string]any, yield func(*model.LLMResponse, error) bool) {
+func (m *OllamaModel) generateNonStreaming(ctx context.Context, modelName string, messages []api.Message, tools []api.Tool, options map[string]any, loaded bool, yield func(*model.LLMResponse, error) bool) {
 	streamValue := false
 	chatReq := &api.ChatRequest{
 		Model:    modelName,
@@ -179,10 +197,7 @@ func (m *OllamaModel) generateNonStreaming(ctx context.Context, modelName string
 	})
 
 	if err != nil {
-		yield(&model.LLMResponse{
-			ErrorCode:    "API_ERROR",
-			ErrorMessage: err.Error(),
-		}, nil)
+		yield(ollamaErrorResponse(err, modelName, loaded), nil)
 		return
 	}
 
@@ -237,6 +252,57 @@ func (m *OllamaModel) generateNonStreaming(ctx context.Context, modelName string
 	yield(response, nil)
 }
 
+// modelLoaded reports whether the Ollama server has modelName loaded into
+// memory. It reports true when the server can't tell, so that only failures
+// known to happen while loading are reported as such.
+func (m *OllamaModel) modelLoaded(ctx context.Context, modelName string) bool {
+	running, err := m.Client.ListRunning(ctx)
+	if err != nil {
+		return true
+	}
+	for _, r := range running.Models {
+		if sameOllamaModel(r.Name, modelName) || sameOllamaModel(r.Model, modelName) {
+			return true
+		}
+	}
+	return false
+}
+
+// sameOllamaModel reports whether two Ollama model names refer to the same
+// model, where a name without a tag stands for its "latest" tag.
+func sameOllamaModel(a, b string) bool {
+	withTag := func(name string) string {
+		if strings.Contains(name, ":") {
+			return name
+		}
+		return name + ":latest"
+	}
+	return withTag(a) == withTag(b)
+}
+
+// ollamaErrorResponse builds the LLMResponse of a failed chat request. The
+// message keeps the error of the request, which the fallback model
+// classifies the failure by.
+func ollamaErrorResponse(err error, modelName string, loaded bool) *model.LLMResponse {
+	var statusErr api.StatusError
+	switch {
+	case errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound:
+		return &model.LLMResponse{
+			ErrorCode:    ErrorCodeModelNotFound,
+			ErrorMessage: fmt.Sprintf("model %s is not on the Ollama server, pull it with `ollama pull %s` or set spec.ollama.pullModel on the ModelConfig: %v", modelName, modelName, err),
+		}
+	case !loaded && !errors.Is(err, context.Canceled):
+		return &model.LLMResponse{
+			ErrorCode:    ErrorCodeModelLoading,
+			ErrorMessage: fmt.Sprintf("model %s was still loading on the Ollama server: %v", modelName, err),
+		}
+	}
+	return &model.LLMResponse{
+		ErrorCode:    "API_ERROR",
+		ErrorMessage: err.Error(),
+	}
+}
+
 // convertGenaiContentsToOllamaMessages converts genai.Content to Ollama message format.
 // Returns messages and system instruction (extracted from system role content).
 func convertGenaiContentsToOllamaMessages(contents []*genai.Content, config *genai.GenerateContentConfig) ([]api.Message, string) {
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/ollama/ollama/api"
@@ -80,3 +81,81 @@ func TestGenerateStreamingAggregatesToolCalls(t *testing.T) {
 		t.Errorf("expected location \"New York\", got %v", got)
 	}
 }
+
+func TestGenerateContentOllamaErrorCodes(t *testing.T) {
+	tests := []struct {
+		name       string
+		running    string
+		chatStatus int
+		chatError  string
+		wantCode   string
+		wantInMsg  string
+	}{
+		{
+			name:       "model not pulled",
+			running:    `{"models":[]}`,
+			chatStatus: http.StatusNotFound,
+			chatError:  `model "llama3.2" not found, try pulling it first`,
+			wantCode:   ErrorCodeModelNotFound,
+			wantInMsg:  "spec.ollama.pullModel",
+		},
+		{
+			name:       "model still loading",
+			running:    `{"models":[]}`,
+			chatStatus: http.StatusServiceUnavailable,
+			chatError:  "server busy",
+			wantCode:   ErrorCodeModelLoading,
+			wantInMsg:  "server busy",
+		},
+		{
+			name:       "loaded model failing",
+			running:    `{"models":[{"name":"llama3.2:latest","model":"llama3.2:latest"}]}`,
+			chatStatus: http.StatusInternalServerError,
+			chatError:  "out of memory",
+			wantCode:   "API_ERROR",
+			wantInMsg:  "out of memory",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				if r.URL.Path == "/api/ps" {
+					_, _ = w.Write([]byte(tt.running))
+					return
+				}
+				w.WriteHeader(tt.chatStatus)
+				_, _ = w.Write([]byte(`{"error":"` + tt.chatError + `"}`))
+			}))
+			defer srv.Close()
+
+			baseURL, err := url.Parse(srv.URL)
+			if err != nil {
+				t.Fatalf("parse url: %v", err)
+			}
+			m := &OllamaModel{
+				Config: &OllamaConfig{Model: "llama3.2"},
+				Client: api.NewClient(baseURL, http.DefaultClient),
+			}
+			req := &model.LLMRequest{
+				Contents: []*genai.Content{{Role: "user", Parts: []*genai.Part{{Text: "hi"}}}},
+			}
+
+			for _, stream := range []bool{true, false} {
+				var got *model.LLMResponse
+				for resp, err := range m.GenerateContent(context.Background(), req, stream) {
+					if err != nil {
+						t.Fatalf("unexpected error: %v", err)
+					}
+					got = resp
+				}
+				if got == nil || got.ErrorCode != tt.wantCode {
+					t.Fatalf("stream=%v: expected error code %s, got %+v", stream, tt.wantCode, got)
+				}
+				if !strings.Contains(got.ErrorMessage, tt.wantInMsg) {
+					t.Errorf("stream=%v: expected message to contain %q, got %q", stream, tt.wantInMsg, got.ErrorMessage)
+				}
+			}
+		})
+	}
+}
@@ -44,6 +44,8 @@ type OpenAIModel struct {
 	Client  openai.Client
 	IsAzure bool
 	Logger  logr.Logger
+
+	providerConn
 }
 
 // NewOpenAIModelWithLogger creates a new OpenAI model instance with a logger
@@ -98,11 +100,16 @@ func newOpenAIModelFromConfig(config *OpenAIConfig, apiKey string, logger logr.L
 	if logger.GetSink() != nil {
 		logger.Info("Initialized OpenAI model", "model", config.Model, "baseUrl", config.BaseUrl)
 	}
+	endpoint := config.BaseUrl
+	if endpoint == "" {
+		endpoint = "https://api.openai.com/v1/"
+	}
 	return &OpenAIModel{
-		Config:  config,
-		Client:  client,
-		IsAzure: false,
-		Logger:  logger,
+		Config:       config,
+		Client:       client,
+		IsAzure:      false,
+		Logger:       logger,
+		providerConn: providerConn{httpClient: httpClient, endpoint: endpoint},
 	}, nil
 }
 
@@ -144,10 +151,11 @@ func NewAzureOpenAIModelWithLogger(config *AzureOpenAIConfig, logger logr.Logger
 		logger.Info("Initialized Azure OpenAI model", "model", config.Model, "endpoint", azureEndpoint, "apiVersion", apiVersion)
 	}
 	return &OpenAIModel{
-		Config:  &OpenAIConfig{Model: config.Model},
-		Client:  client,
-		IsAzure: true,
-		Logger:  logger,
+		Config:       &OpenAIConfig{Model: config.Model},
+		Client:       client,
+		IsAzure:      true,
+		Logger:       logger,
+		providerConn: providerConn{httpClient: httpClient, endpoint: azureEndpoint},
 	}, nil
 }
 
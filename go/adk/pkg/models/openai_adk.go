@@ -225,7 +225,7 @@ func genaiContentsToOpenAIMessages(contents []*genai.Content, config *genai.Gene
 		role := strings.TrimSpace(content.Role)
 		var textParts []string
 		var functionCalls []*genai.FunctionCall
-		var imageParts []openai.ChatCompletionContentPartImageImageURLParam
+		var fileParts []openai.ChatCompletionContentPartUnionParam
 
 		for _, part := range content.Parts {
 			if part == nil {
@@ -235,10 +235,22 @@ func genaiContentsToOpenAIMessages(contents []*genai.Content, config *genai.Gene
 				textParts = append(textParts, part.Text)
 			} else if part.FunctionCall != nil {
 				functionCalls = append(functionCalls, part.FunctionCall)
-			} else if part.InlineData != nil && strings.HasPrefix(part.InlineData.MIMEType, "image/") {
-				imageParts = append(imageParts, openai.ChatCompletionContentPartImageImageURLParam{
-					URL: fmt.Sprintf("data:%s;base64,%s", part.InlineData.MIMEType, base64.StdEncoding.EncodeToString(part.InlineData.Data)),
-				})
+			} else if part.InlineData != nil {
+				dataURL := fmt.Sprintf("data:%s;base64,%s", part.InlineData.MIMEType, base64.StdEncoding.EncodeToString(part.InlineData.Data))
+				switch {
+				case strings.HasPrefix(part.InlineData.MIMEType, "image/"):
+					fileParts = append(fileParts, openai.ImageContentPart(openai.ChatCompletionContentPartImageImageURLParam{URL: dataURL}))
+				case part.InlineData.MIMEType == mimeTypePDF:
+					file := openai.ChatCompletionContentPartFileFileParam{FileData: param.NewOpt(dataURL)}
+					if part.InlineData.DisplayName != "" {
+						file.Filename = param.NewOpt(part.InlineData.DisplayName)
+					}
+					fileParts = append(fileParts, openai.FileContentPart(file))
+				default:
+					if text, ok := attachedText(part.InlineData); ok {
+						textParts = append(textParts, text)
+					}
+				}
 			}
 		}
 
@@ -288,14 +300,12 @@ func genaiContentsToOpenAIMessages(contents []*genai.Content, config *genai.Gene
 			messages = append(messages, openai.ChatCompletionMessageParamUnion{OfAssistant: &asst})
 			messages = append(messages, toolResponseMessages...)
 		} else {
-			if len(imageParts) > 0 {
-				parts := make([]openai.ChatCompletionContentPartUnionParam, 0, len(textParts)+len(imageParts))
+			if len(fileParts) > 0 {
+				parts := make([]openai.ChatCompletionContentPartUnionParam, 0, len(textParts)+len(fileParts))
 				for _, t := range textParts {
 					parts = append(parts, openai.TextContentPart(t))
 				}
-				for _, img := range imageParts {
-					parts = append(parts, openai.ImageContentPart(img))
-				}
+				parts = append(parts, fileParts...)
 				messages = append(messages, openai.UserMessage(parts))
 			} else if len(textParts) > 0 {
 				messages = append(messages, openai.UserMessage(strings.Join(textParts, "\n")))
@@ -372,7 +382,7 @@ func runStreaming(ctx context.Context, m *OpenAIModel, params openai.ChatComplet
 			aggregatedText.WriteString(delta.Content)
 			if !yield(&model.LLMResponse{
 				Partial:      true,
-				TurnComplete: choice.FinishReason != "",
+				TurnComplete: false,
 				Content:      &genai.Content{Role: string(genai.RoleModel), Parts: []*genai.Part{{Text: delta.Content}}},
 			}, nil) {
 				return
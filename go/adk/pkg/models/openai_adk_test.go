@@ -197,6 +197,35 @@ func TestGenaiContentsToOpenAIMessages(t *testing.T) {
 		// First message should be user message (we only assert count and no panic)
 	})
 
+	t.Run("user content with attachments", func(t *testing.T) {
+		contents := []*genai.Content{{
+			Role: string(genai.RoleUser),
+			Parts: []*genai.Part{
+				{Text: "What is wrong?"},
+				{InlineData: &genai.Blob{MIMEType: "image/png", Data: []byte("png")}},
+				{InlineData: &genai.Blob{MIMEType: "application/pdf", Data: []byte("pdf"), DisplayName: "runbook.pdf"}},
+				{InlineData: &genai.Blob{MIMEType: "text/plain", Data: []byte("OOMKilled"), DisplayName: "pod.log"}},
+			},
+		}}
+		msgs, _ := genaiContentsToOpenAIMessages(contents, nil)
+		if len(msgs) != 1 || msgs[0].OfUser == nil {
+			t.Fatalf("messages = %+v, want one user message", msgs)
+		}
+		parts := msgs[0].OfUser.Content.OfArrayOfContentParts
+		if len(parts) != 4 {
+			t.Fatalf("len(parts) = %d, want 4", len(parts))
+		}
+		if parts[1].OfText == nil || parts[1].OfText.Text != "<file name=\"pod.log\" type=\"text/plain\">\nOOMKilled\n</file>" {
+			t.Errorf("parts[1] = %+v, want the text file", parts[1])
+		}
+		if parts[2].OfImageURL == nil || parts[2].OfImageURL.ImageURL.URL != "data:image/png;base64,"+base64.StdEncoding.EncodeToString([]byte("png")) {
+			t.Errorf("parts[2] = %+v, want the image", parts[2])
+		}
+		if parts[3].OfFile == nil || parts[3].OfFile.File.Filename.Value != "runbook.pdf" || parts[3].OfFile.File.FileData.Value != "data:application/pdf;base64,"+base64.StdEncoding.EncodeToString([]byte("pdf")) {
+			t.Errorf("parts[3] = %+v, want the PDF", parts[3])
+		}
+	})
+
 	t.Run("content with role system skipped", func(t *testing.T) {
 		contents := []*genai.Content{
 			{Role: "system", Parts: []*genai.Part{{Text: "sys"}}},
@@ -0,0 +1,78 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"google.golang.org/adk/v2/model"
+	"google.golang.org/genai"
+)
+
+// TestRunStreamingYieldsDeltasImmediately verifies that each text delta is
+// yielded as soon as its chunk arrives, before the model finishes the stream.
+func TestRunStreamingYieldsDeltasImmediately(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		flusher, _ := w.(http.Flusher)
+		writeChunk := func(data string) {
+			_, _ = fmt.Fprintf(w, "data: %s\n\n", data)
+			flusher.Flush()
+		}
+		writeChunk(`{"id":"1","object":"chat.completion.chunk","model":"gpt-4o","choices":[{"index":0,"delta":{"role":"assistant","content":"Hello"}}]}`)
+		<-release
+		writeChunk(`{"id":"1","object":"chat.completion.chunk","model":"gpt-4o","choices":[{"index":0,"delta":{"content":" world"},"finish_reason":"stop"}]}`)
+		writeChunk(`[DONE]`)
+	}))
+	defer srv.Close()
+
+	m, err := NewOpenAICompatibleModelWithLogger(srv.URL, "gpt-4o", nil, "test-key", logr.Discard())
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := &model.LLMRequest{
+		Contents: []*genai.Content{genai.NewContentFromText("hi", genai.RoleUser)},
+	}
+
+	responses := make(chan *model.LLMResponse)
+	go func() {
+		defer close(responses)
+		for resp, err := range m.GenerateContent(context.Background(), req, true) {
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			responses <- resp
+		}
+	}()
+
+	select {
+	case first := <-responses:
+		if !first.Partial || first.TurnComplete || first.Content.Parts[0].Text != "Hello" {
+			t.Errorf("first response = %+v, want the partial text \"Hello\"", first)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("first delta was not yielded before the stream finished")
+	}
+	close(release)
+
+	var got []*model.LLMResponse
+	for resp := range responses {
+		got = append(got, resp)
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %d more responses, want 2", len(got))
+	}
+	if !got[0].Partial || got[0].TurnComplete || got[0].Content.Parts[0].Text != " world" {
+		t.Errorf("second response = %+v, want the partial text \" world\" without TurnComplete", got[0])
+	}
+	final := got[1]
+	if final.Partial || !final.TurnComplete || final.Content.Parts[0].Text != "Hello world" {
+		t.Errorf("final response = %+v, want the complete text", final)
+	}
+}
@@ -0,0 +1,83 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"google.golang.org/adk/v2/model"
+	"google.golang.org/genai"
+)
+
+// Prewarm modes, selected with the KAGENT_MODEL_PREWARM environment variable.
+const (
+	// PrewarmOff leaves the first request to open the provider connection.
+	PrewarmOff = "off"
+	// PrewarmConnect opens a keep-alive connection to the provider endpoint.
+	PrewarmConnect = "connect"
+	// PrewarmPing opens the connection and sends a one-token request, which
+	// also validates credentials and loads lazily started models.
+	PrewarmPing = "ping"
+)
+
+// Connector is implemented by models that can open a connection to their
+// provider ahead of the first request.
+type Connector interface {
+	Connect(ctx context.Context) error
+}
+
+// Prewarm warms llm according to mode so that the first user request does not
+// pay for DNS, TCP, TLS and HTTP/2 setup. Models that do not implement
+// Connector are only warmed by PrewarmPing.
+func Prewarm(ctx context.Context, llm model.LLM, mode string) error {
+	switch mode {
+	case "", PrewarmOff:
+		return nil
+	case PrewarmConnect:
+		if c, ok := llm.(Connector); ok {
+			return c.Connect(ctx)
+		}
+		return nil
+	case PrewarmPing:
+		req := &model.LLMRequest{
+			Model:    llm.Name(),
+			Contents: []*genai.Content{genai.NewContentFromText("ping", genai.RoleUser)},
+			Config:   &genai.GenerateContentConfig{MaxOutputTokens: 1},
+		}
+		for _, err := range llm.GenerateContent(ctx, req, false) {
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown prewarm mode %q, expected %s, %s or %s", mode, PrewarmOff, PrewarmConnect, PrewarmPing)
+	}
+}
+
+// providerConn is the pooled HTTP client a model sends requests with and the
+// endpoint it sends them to. Embedding it makes the model a Connector.
+type providerConn struct {
+	httpClient *http.Client
+	endpoint   string
+}
+
+// Connect sends an unauthenticated HEAD request to the provider endpoint. The
+// response status is irrelevant: the point is to leave an established
+// connection in the client's pool for the first real request.
+func (c providerConn) Connect(ctx context.Context) error {
+	if c.httpClient == nil || c.endpoint == "" {
+		return nil
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, c.endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create prewarm request: %w", err)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", c.endpoint, err)
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return resp.Body.Close()
+}
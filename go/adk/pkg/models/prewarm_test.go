@@ -0,0 +1,148 @@
+package models
+
+import (
+	"context"
+	"iter"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	adkmodel "google.golang.org/adk/v2/model"
+)
+
+// pingLLM records the requests it receives.
+type pingLLM struct {
+	requests []*adkmodel.LLMRequest
+}
+
+func (m *pingLLM) Name() string { return "ping-model" }
+
+func (m *pingLLM) GenerateContent(_ context.Context, req *adkmodel.LLMRequest, _ bool) iter.Seq2[*adkmodel.LLMResponse, error] {
+	m.requests = append(m.requests, req)
+	return func(yield func(*adkmodel.LLMResponse, error) bool) {
+		yield(textResponse("p"), nil)
+	}
+}
+
+// countingServer starts a TLS server with HTTP/2 enabled that counts the
+// connections and requests it accepts.
+func countingServer(t testing.TB) (*httptest.Server, *atomic.Int64, *atomic.Int64) {
+	var conns, requests atomic.Int64
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	srv.EnableHTTP2 = true
+	srv.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			conns.Add(1)
+		}
+	}
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+	return srv, &conns, &requests
+}
+
+func insecureClient(t testing.TB) *http.Client {
+	client, err := BuildHTTPClient(TransportConfig{TLSInsecureSkipVerify: new(true)})
+	require.NoError(t, err)
+	return client
+}
+
+func TestPrewarmConnect(t *testing.T) {
+	srv, conns, requests := countingServer(t)
+	client := insecureClient(t)
+	llm := &OpenAIModel{
+		Config:       &OpenAIConfig{Model: "gpt-4o"},
+		providerConn: providerConn{httpClient: client, endpoint: srv.URL},
+	}
+
+	require.NoError(t, Prewarm(context.Background(), llm, PrewarmConnect))
+	assert.Equal(t, int64(1), requests.Load())
+
+	// The first real request reuses the prewarmed connection.
+	resp, err := client.Get(srv.URL)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, int64(1), conns.Load())
+	assert.Equal(t, "HTTP/2.0", resp.Proto)
+}
+
+func TestPrewarm(t *testing.T) {
+	t.Run("off sends nothing", func(t *testing.T) {
+		llm := &pingLLM{}
+		require.NoError(t, Prewarm(context.Background(), llm, PrewarmOff))
+		assert.Empty(t, llm.requests)
+	})
+
+	t.Run("connect skips models without a connection", func(t *testing.T) {
+		llm := &pingLLM{}
+		require.NoError(t, Prewarm(context.Background(), llm, PrewarmConnect))
+		assert.Empty(t, llm.requests)
+	})
+
+	t.Run("ping requests a single token", func(t *testing.T) {
+		llm := &pingLLM{}
+		require.NoError(t, Prewarm(context.Background(), llm, PrewarmPing))
+		require.Len(t, llm.requests, 1)
+		assert.Equal(t, "ping-model", llm.requests[0].Model)
+		assert.Equal(t, int32(1), llm.requests[0].Config.MaxOutputTokens)
+	})
+
+	t.Run("unknown mode", func(t *testing.T) {
+		require.Error(t, Prewarm(context.Background(), &pingLLM{}, "eager"))
+	})
+}
+
+func TestBuildHTTPClientReusesConnections(t *testing.T) {
+	srv, conns, _ := countingServer(t)
+	client := insecureClient(t)
+
+	for range 5 {
+		resp, err := client.Get(srv.URL)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+	}
+	assert.Equal(t, int64(1), conns.Load())
+}
+
+// BenchmarkFirstRequestLatency compares the latency of an agent's first
+// provider request with a freshly built client against one whose connection
+// was prewarmed at startup. The p50-ns metric is the median first request.
+func BenchmarkFirstRequestLatency(b *testing.B) {
+	srv, _, _ := countingServer(b)
+
+	run := func(b *testing.B, prewarm bool) {
+		latencies := make([]time.Duration, 0, b.N)
+		for range b.N {
+			b.StopTimer()
+			client := insecureClient(b)
+			if prewarm {
+				conn := providerConn{httpClient: client, endpoint: srv.URL}
+				require.NoError(b, conn.Connect(context.Background()))
+			}
+			b.StartTimer()
+
+			start := time.Now()
+			resp, err := client.Get(srv.URL)
+			require.NoError(b, err)
+			require.NoError(b, resp.Body.Close())
+			latencies = append(latencies, time.Since(start))
+
+			b.StopTimer()
+			client.CloseIdleConnections()
+			b.StartTimer()
+		}
+		slices.Sort(latencies)
+		b.ReportMetric(float64(latencies[len(latencies)/2].Nanoseconds()), "p50-ns")
+	}
+
+	b.Run("cold", func(b *testing.B) { run(b, false) })
+	b.Run("prewarmed", func(b *testing.B) { run(b, true) })
+}
@@ -44,10 +44,15 @@ func NewSAPAICoreModelWithLogger(config SAPAICoreConfig, logger logr.Logger) (*S
 	return &SAPAICoreModel{
 		Config:     config,
 		Logger:     logger,
-		httpClient: &http.Client{Timeout: 5 * time.Minute},
+		httpClient: &http.Client{Timeout: 5 * time.Minute, Transport: newPooledTransport()},
 	}, nil
 }
 
+// Connect opens a connection to the SAP AI Core API ahead of the first request.
+func (m *SAPAICoreModel) Connect(ctx context.Context) error {
+	return providerConn{httpClient: m.httpClient, endpoint: m.Config.BaseUrl}.Connect(ctx)
+}
+
 func (m *SAPAICoreModel) ensureToken(ctx context.Context) (string, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"time"
+
+	adkmodel "google.golang.org/adk/v2/model"
+)
+
+// TimeoutLLM bounds each request to a model, including the streaming of its
+// response. A request that runs out of time fails with an error wrapping
+// context.DeadlineExceeded, so FallbackLLM treats it as a timeout.
+type TimeoutLLM struct {
+	adkmodel.LLM
+	timeout time.Duration
+}
+
+// NewTimeoutLLM returns llm with its requests bounded by timeout, or llm
+// itself when timeout is not positive.
+func NewTimeoutLLM(llm adkmodel.LLM, timeout time.Duration) adkmodel.LLM {
+	if timeout <= 0 {
+		return llm
+	}
+	return &TimeoutLLM{LLM: llm, timeout: timeout}
+}
+
+func (m *TimeoutLLM) GenerateContent(ctx context.Context, req *adkmodel.LLMRequest, stream bool) iter.Seq2[*adkmodel.LLMResponse, error] {
+	return func(yield func(*adkmodel.LLMResponse, error) bool) {
+		reqCtx, cancel := context.WithTimeout(ctx, m.timeout)
+		defer cancel()
+		for resp, err := range m.LLM.GenerateContent(reqCtx, req, stream) {
+			// Only report the timeout when it was this request's own deadline
+			// that expired, not the caller's.
+			if err != nil && ctx.Err() == nil && errors.Is(reqCtx.Err(), context.DeadlineExceeded) {
+				err = fmt.Errorf("request to model %s timed out after %s: %w", m.Name(), m.timeout, context.DeadlineExceeded)
+			}
+			if !yield(resp, err) {
+				return
+			}
+		}
+	}
+}
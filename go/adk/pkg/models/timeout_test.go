@@ -0,0 +1,64 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	adkmodel "google.golang.org/adk/v2/model"
+)
+
+// hangingLLM blocks until the request's context is done.
+type hangingLLM struct{}
+
+func (hangingLLM) Name() string { return "slow" }
+
+func (hangingLLM) GenerateContent(ctx context.Context, _ *adkmodel.LLMRequest, _ bool) iter.Seq2[*adkmodel.LLMResponse, error] {
+	return func(yield func(*adkmodel.LLMResponse, error) bool) {
+		<-ctx.Done()
+		yield(nil, ctx.Err())
+	}
+}
+
+func TestTimeoutLLM(t *testing.T) {
+	llm := NewTimeoutLLM(hangingLLM{}, 10*time.Millisecond)
+	var errs []error
+	for _, err := range llm.GenerateContent(context.Background(), &adkmodel.LLMRequest{}, false) {
+		errs = append(errs, err)
+	}
+	require.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], context.DeadlineExceeded)
+	assert.Contains(t, errs[0].Error(), "request to model slow timed out after 10ms")
+
+	fast := &scriptedLLM{name: "gpt-4o", responses: []*adkmodel.LLMResponse{textResponse("hi")}}
+	for resp, err := range NewTimeoutLLM(fast, time.Minute).GenerateContent(context.Background(), &adkmodel.LLMRequest{}, false) {
+		require.NoError(t, err)
+		assert.Equal(t, "hi", resp.Content.Parts[0].Text)
+	}
+
+	assert.Same(t, fast, NewTimeoutLLM(fast, 0))
+}
+
+func TestTimeoutLLMPassesCallerCancellationThrough(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, err := range NewTimeoutLLM(hangingLLM{}, time.Minute).GenerateContent(ctx, &adkmodel.LLMRequest{}, false) {
+		assert.True(t, errors.Is(err, context.Canceled))
+	}
+}
+
+func TestTimeoutLLMFailsOver(t *testing.T) {
+	fallback := &scriptedLLM{name: "claude", responses: []*adkmodel.LLMResponse{textResponse("ok")}}
+	llm := NewFallbackLLM(NewTimeoutLLM(hangingLLM{}, 10*time.Millisecond), []Fallback{{LLM: fallback}}, logr.Discard())
+	var text []string
+	for resp, err := range llm.GenerateContent(context.Background(), &adkmodel.LLMRequest{}, false) {
+		require.NoError(t, err)
+		text = append(text, resp.Content.Parts[0].Text)
+	}
+	assert.Equal(t, []string{"ok"}, text)
+}
@@ -0,0 +1,339 @@
+// Package moderation checks the model's responses, and optionally the user's
+// messages, against content rules before they reach the user or the model.
+// A message is checked against keywords and patterns first, then against a
+// moderation service if one is configured; a failing message is blocked,
+// redacted or flagged in the metadata of its event.
+package moderation
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"github.com/kagent-dev/kagent/go/api/adk"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/adk/v2/agent"
+	"google.golang.org/adk/v2/agent/llmagent"
+	"google.golang.org/adk/v2/model"
+	"google.golang.org/genai"
+)
+
+const (
+	// MetadataKey is the event custom metadata key holding the verdict of a
+	// message that failed moderation.
+	MetadataKey = "moderation"
+	// BlockedOutput replaces a blocked model response.
+	BlockedOutput = "The response was withheld because it failed content moderation."
+	// BlockedInput answers a blocked user message instead of the model.
+	BlockedInput = "Your message was not sent to the model because it failed content moderation."
+	// redacted replaces matching text when the action is redact.
+	redacted = "[removed by moderation]"
+	// SpanEventName is the trace event recorded for each failed message.
+	SpanEventName = "kagent.security.moderation"
+)
+
+// Directions of a moderated message.
+const (
+	DirectionInput  = "input"
+	DirectionOutput = "output"
+)
+
+// Reasons a message fails moderation, besides the provider's categories.
+const (
+	ReasonKeyword       = "keyword"
+	ReasonPattern       = "pattern"
+	ReasonProviderError = "provider_error"
+)
+
+// Verdict is the outcome of checking a message that failed moderation.
+type Verdict struct {
+	Direction string
+	Action    string
+	Reasons   []string
+	// located reports whether every reason has a match that redaction
+	// removes. Provider categories don't say which text they apply to.
+	located bool
+}
+
+// Moderator applies the configured action to messages that fail moderation.
+type Moderator struct {
+	action     string
+	checkInput bool
+	rules      []*regexp.Regexp
+	keywords   *regexp.Regexp
+	provider   provider
+	log        logr.Logger
+
+	// flagged holds the verdicts of flagged user messages by invocation, to
+	// be attached to the model's response.
+	flagged sync.Map
+}
+
+// New builds a Moderator from the agent's config. apiKey authenticates calls
+// to the moderation service.
+func New(cfg *adk.ModerationConfig, apiKey string, log logr.Logger) (*Moderator, error) {
+	m := &Moderator{
+		action:     cfg.Action,
+		checkInput: cfg.CheckInput,
+		log:        log.WithName("moderation"),
+	}
+	switch m.action {
+	case "":
+		m.action = adk.ModerationActionBlock
+	case adk.ModerationActionBlock, adk.ModerationActionRedact, adk.ModerationActionFlag:
+	default:
+		return nil, fmt.Errorf("invalid moderation action %q", cfg.Action)
+	}
+	if len(cfg.Keywords) > 0 {
+		quoted := make([]string, len(cfg.Keywords))
+		for i, k := range cfg.Keywords {
+			quoted[i] = regexp.QuoteMeta(k)
+		}
+		m.keywords = regexp.MustCompile(`(?i)\b(?:` + strings.Join(quoted, "|") + `)\b`)
+	}
+	for _, p := range cfg.Patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid moderation pattern %q: %w", p, err)
+		}
+		m.rules = append(m.rules, re)
+	}
+	if cfg.Provider != nil {
+		p, err := newProvider(cfg.Provider, apiKey)
+		if err != nil {
+			return nil, err
+		}
+		m.provider = p
+	}
+	return m, nil
+}
+
+// Action returns the action applied to messages that fail moderation.
+func (m *Moderator) Action() string {
+	return m.action
+}
+
+// CheckInput reports whether user messages are moderated.
+func (m *Moderator) CheckInput() bool {
+	return m.checkInput
+}
+
+// Check returns the verdict on text, or nil when it passes moderation.
+func (m *Moderator) Check(ctx agent.Context, text, direction string) *Verdict {
+	v := &Verdict{Direction: direction, Action: m.action, located: true}
+	if m.keywords != nil && m.keywords.MatchString(text) {
+		v.Reasons = append(v.Reasons, ReasonKeyword)
+	}
+	if slices.ContainsFunc(m.rules, func(re *regexp.Regexp) bool { return re.MatchString(text) }) {
+		v.Reasons = append(v.Reasons, ReasonPattern)
+	}
+	// The service is only asked about text the local rules pass, or, when
+	// redacting, the text that remains after redaction.
+	if m.provider != nil && (len(v.Reasons) == 0 || m.action == adk.ModerationActionRedact) {
+		categories, err := m.provider.check(ctx, m.redact(text), direction)
+		switch {
+		case err != nil && m.provider.failOpen():
+			m.log.Error(err, "Moderation provider failed, passing the message through", "direction", direction)
+		case err != nil:
+			m.log.Error(err, "Moderation provider failed, failing the message", "direction", direction)
+			v.Reasons = append(v.Reasons, ReasonProviderError)
+			v.located = false
+		case len(categories) > 0:
+			v.Reasons = append(v.Reasons, categories...)
+			v.located = false
+		}
+	}
+	if len(v.Reasons) == 0 {
+		return nil
+	}
+	return v
+}
+
+// Metadata returns the verdict in the form it takes in event metadata.
+func (v *Verdict) Metadata() map[string]any {
+	reasons := make([]any, len(v.Reasons))
+	for i, r := range v.Reasons {
+		reasons[i] = r
+	}
+	return map[string]any{"direction": v.Direction, "action": v.Action, "reasons": reasons}
+}
+
+// Blocks reports whether a message with verdict v is withheld.
+func (v *Verdict) Blocks() bool {
+	return v.Action == adk.ModerationActionBlock || (v.Action == adk.ModerationActionRedact && !v.located)
+}
+
+// Apply returns the text to pass on for a message with verdict v.
+func (m *Moderator) Apply(text string, v *Verdict, blocked string) string {
+	switch {
+	case v == nil || v.Action == adk.ModerationActionFlag:
+		return text
+	case v.Blocks():
+		return blocked
+	default:
+		return m.redact(text)
+	}
+}
+
+func (m *Moderator) redact(s string) string {
+	if m.keywords != nil {
+		s = m.keywords.ReplaceAllLiteralString(s, redacted)
+	}
+	for _, re := range m.rules {
+		s = re.ReplaceAllLiteralString(s, redacted)
+	}
+	return s
+}
+
+// AfterModelCallback returns a callback that moderates the text of the
+// model's final responses. Under block and redact the text of partial
+// responses is held back, since it can only be moderated once complete.
+func (m *Moderator) AfterModelCallback() llmagent.AfterModelCallback {
+	return func(ctx agent.Context, resp *model.LLMResponse, err error) (*model.LLMResponse, error) {
+		if err != nil || resp == nil || resp.Content == nil {
+			return nil, nil
+		}
+		if resp.Partial {
+			if m.action == adk.ModerationActionFlag || !hasText(resp.Content) {
+				return nil, nil
+			}
+			out := *resp
+			out.Content = withoutText(resp.Content)
+			return &out, nil
+		}
+		flaggedInput, _ := m.flagged.LoadAndDelete(ctx.InvocationID())
+		text := textOf(resp.Content)
+		var v *Verdict
+		if text != "" {
+			v = m.Check(ctx, text, DirectionOutput)
+		}
+		if v == nil && flaggedInput == nil {
+			return nil, nil
+		}
+		out := *resp
+		out.CustomMetadata = make(map[string]any, len(resp.CustomMetadata)+1)
+		for k, val := range resp.CustomMetadata {
+			out.CustomMetadata[k] = val
+		}
+		if v != nil {
+			m.record(ctx, v)
+			out.Content = withText(resp.Content, m.Apply(text, v, BlockedOutput))
+			out.CustomMetadata[MetadataKey] = v.Metadata()
+		} else {
+			out.CustomMetadata[MetadataKey] = flaggedInput.(*Verdict).Metadata()
+		}
+		return &out, nil
+	}
+}
+
+// BeforeModelCallback returns a callback that moderates the user's latest
+// message before it is sent to the model. A blocked message is answered with
+// a notice instead of a model response.
+func (m *Moderator) BeforeModelCallback() llmagent.BeforeModelCallback {
+	return func(ctx agent.Context, req *model.LLMRequest) (*model.LLMResponse, error) {
+		last := len(req.Contents) - 1
+		if last < 0 || !isUserText(req.Contents[last]) {
+			return nil, nil
+		}
+		content := req.Contents[last]
+		text := textOf(content)
+		if text == "" {
+			return nil, nil
+		}
+		v := m.Check(ctx, text, DirectionInput)
+		if v == nil {
+			return nil, nil
+		}
+		m.record(ctx, v)
+		switch {
+		case v.Action == adk.ModerationActionFlag:
+			m.flagged.Store(ctx.InvocationID(), v)
+		case v.Blocks():
+			return &model.LLMResponse{
+				Content:        genai.NewContentFromText(BlockedInput, genai.RoleModel),
+				CustomMetadata: map[string]any{MetadataKey: v.Metadata()},
+				TurnComplete:   true,
+			}, nil
+		default:
+			// The contents are shared with the session's events, which keep
+			// the message as the user sent it.
+			req.Contents[last] = withText(content, m.Apply(text, v, BlockedInput))
+		}
+		return nil, nil
+	}
+}
+
+// record logs a failed message as a security event and adds it to the
+// active trace span.
+func (m *Moderator) record(ctx agent.Context, v *Verdict) {
+	m.log.Info("Message failed moderation",
+		"securityEvent", "moderation",
+		"direction", v.Direction,
+		"reasons", v.Reasons,
+		"action", v.Action,
+		"sessionID", ctx.SessionID(),
+		"invocationID", ctx.InvocationID(),
+	)
+	trace.SpanFromContext(ctx).AddEvent(SpanEventName, trace.WithAttributes(
+		attribute.String("kagent.security.direction", v.Direction),
+		attribute.StringSlice("kagent.security.reasons", v.Reasons),
+		attribute.String("kagent.security.action", v.Action),
+	))
+}
+
+// isUserText reports whether c is a message from the user, rather than the
+// result of a tool call the request continues after.
+func isUserText(c *genai.Content) bool {
+	return c != nil && c.Role == genai.RoleUser && hasText(c)
+}
+
+func hasText(c *genai.Content) bool {
+	return slices.ContainsFunc(c.Parts, isText)
+}
+
+func isText(p *genai.Part) bool {
+	return p != nil && p.Text != "" && !p.Thought
+}
+
+func textOf(c *genai.Content) string {
+	var b strings.Builder
+	for _, p := range c.Parts {
+		if isText(p) {
+			b.WriteString(p.Text)
+		}
+	}
+	return b.String()
+}
+
+// withoutText returns a copy of c without its text parts.
+func withoutText(c *genai.Content) *genai.Content {
+	out := &genai.Content{Role: c.Role}
+	for _, p := range c.Parts {
+		if !isText(p) {
+			out.Parts = append(out.Parts, p)
+		}
+	}
+	return out
+}
+
+// withText returns a copy of c whose text parts are replaced by one part
+// holding text, in the place of the first.
+func withText(c *genai.Content, text string) *genai.Content {
+	out := &genai.Content{Role: c.Role}
+	replaced := false
+	for _, p := range c.Parts {
+		if !isText(p) {
+			out.Parts = append(out.Parts, p)
+			continue
+		}
+		if !replaced {
+			out.Parts = append(out.Parts, genai.NewPartFromText(text))
+			replaced = true
+		}
+	}
+	return out
+}
@@ -0,0 +1,225 @@
+package moderation
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kagent-dev/kagent/go/api/adk"
+	"google.golang.org/adk/v2/agent"
+	"google.golang.org/adk/v2/model"
+	"google.golang.org/genai"
+)
+
+// fakeContext is the part of agent.Context a model callback uses.
+type fakeContext struct {
+	agent.Context
+	ctx context.Context
+}
+
+func (c *fakeContext) Deadline() (time.Time, bool) { return c.ctx.Deadline() }
+func (c *fakeContext) Done() <-chan struct{}       { return c.ctx.Done() }
+func (c *fakeContext) Err() error                  { return c.ctx.Err() }
+func (c *fakeContext) Value(key any) any           { return c.ctx.Value(key) }
+func (c *fakeContext) InvocationID() string        { return "inv-1" }
+func (c *fakeContext) SessionID() string           { return "session-1" }
+
+var ctx = &fakeContext{ctx: context.Background()}
+
+func newModerator(t *testing.T, cfg *adk.ModerationConfig) *Moderator {
+	t.Helper()
+	m, err := New(cfg, "sk-test", logr.Discard())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func textResponse(text string, partial bool) *model.LLMResponse {
+	return &model.LLMResponse{Content: genai.NewContentFromText(text, genai.RoleModel), Partial: partial}
+}
+
+func TestCheck(t *testing.T) {
+	m := newModerator(t, &adk.ModerationConfig{
+		Keywords: []string{"Project Falcon"},
+		Patterns: []string{`(?i)password\s*[:=]\s*\S+`},
+	})
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{text: "The launch date of project falcon is secret.", want: []string{ReasonKeyword}},
+		{text: "Use password: hunter2", want: []string{ReasonPattern}},
+		{text: "Project Falcons migrate in winter.", want: nil},
+		{text: "The deployment is healthy.", want: nil},
+	}
+	for _, tt := range tests {
+		v := m.Check(ctx, tt.text, DirectionOutput)
+		var got []string
+		if v != nil {
+			got = v.Reasons
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("Check(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestAfterModelCallback(t *testing.T) {
+	cfg := &adk.ModerationConfig{Keywords: []string{"falcon"}}
+	const text = "Project falcon ships Monday."
+
+	cfg.Action = adk.ModerationActionBlock
+	out, err := newModerator(t, cfg).AfterModelCallback()(ctx, textResponse(text, false), nil)
+	if err != nil || out == nil {
+		t.Fatalf("expected a blocked response, got %v, %v", out, err)
+	}
+	if got := out.Content.Parts[0].Text; got != BlockedOutput {
+		t.Errorf("blocked text = %q", got)
+	}
+	verdict := out.CustomMetadata[MetadataKey].(map[string]any)
+	if verdict["direction"] != DirectionOutput || verdict["action"] != adk.ModerationActionBlock {
+		t.Errorf("unexpected verdict %v", verdict)
+	}
+
+	cfg.Action = adk.ModerationActionRedact
+	m := newModerator(t, cfg)
+	out, _ = m.AfterModelCallback()(ctx, textResponse(text, false), nil)
+	if got := out.Content.Parts[0].Text; got != "Project "+redacted+" ships Monday." {
+		t.Errorf("redacted text = %q", got)
+	}
+	out, _ = m.AfterModelCallback()(ctx, textResponse("Project", true), nil)
+	if out == nil || len(out.Content.Parts) != 0 {
+		t.Errorf("expected the partial text to be held back, got %v", out)
+	}
+	if out, _ = m.AfterModelCallback()(ctx, textResponse("All good.", false), nil); out != nil {
+		t.Errorf("expected a passing response to be left alone, got %v", out)
+	}
+
+	cfg.Action = adk.ModerationActionFlag
+	m = newModerator(t, cfg)
+	if out, _ = m.AfterModelCallback()(ctx, textResponse("Project", true), nil); out != nil {
+		t.Errorf("expected partial text to stream when flagging, got %v", out)
+	}
+	out, _ = m.AfterModelCallback()(ctx, textResponse(text, false), nil)
+	if got := out.Content.Parts[0].Text; got != text {
+		t.Errorf("flagged text = %q", got)
+	}
+	if out.CustomMetadata[MetadataKey] == nil {
+		t.Error("expected the flagged response to carry the verdict")
+	}
+}
+
+func TestBeforeModelCallback(t *testing.T) {
+	cfg := &adk.ModerationConfig{Keywords: []string{"falcon"}, CheckInput: true}
+	request := func() *model.LLMRequest {
+		return &model.LLMRequest{Contents: []*genai.Content{genai.NewContentFromText("Tell me about falcon.", genai.RoleUser)}}
+	}
+
+	cfg.Action = adk.ModerationActionBlock
+	resp, err := newModerator(t, cfg).BeforeModelCallback()(ctx, request())
+	if err != nil || resp == nil || resp.Content.Parts[0].Text != BlockedInput {
+		t.Fatalf("expected the message to be answered with a notice, got %v, %v", resp, err)
+	}
+
+	cfg.Action = adk.ModerationActionRedact
+	req := request()
+	original := req.Contents[0]
+	if resp, _ = newModerator(t, cfg).BeforeModelCallback()(ctx, req); resp != nil {
+		t.Fatalf("expected the redacted message to be sent, got %v", resp)
+	}
+	if got := req.Contents[0].Parts[0].Text; got != "Tell me about "+redacted+"." {
+		t.Errorf("redacted request = %q", got)
+	}
+	if original.Parts[0].Text != "Tell me about falcon." {
+		t.Error("expected the session's copy of the message to be kept")
+	}
+
+	cfg.Action = adk.ModerationActionFlag
+	m := newModerator(t, cfg)
+	if resp, _ = m.BeforeModelCallback()(ctx, request()); resp != nil {
+		t.Fatalf("expected the flagged message to be sent, got %v", resp)
+	}
+	out, _ := m.AfterModelCallback()(ctx, textResponse("Falcons are birds of prey.", false), nil)
+	verdict := out.CustomMetadata[MetadataKey].(map[string]any)
+	if verdict["direction"] != DirectionInput {
+		t.Errorf("expected the response to carry the input verdict, got %v", verdict)
+	}
+}
+
+func TestOpenAIProvider(t *testing.T) {
+	var inputs []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/moderations" || r.Header.Get("Authorization") != "Bearer sk-test" {
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+			return
+		}
+		var body struct {
+			Model string `json:"model"`
+			Input string `json:"input"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		inputs = append(inputs, body.Input)
+		flagged := body.Input == "I will hurt them."
+		_ = json.NewEncoder(w).Encode(map[string]any{"results": []any{map[string]any{
+			"flagged":    flagged,
+			"categories": map[string]bool{"violence": flagged, "harassment": false},
+		}}})
+	}))
+	defer srv.Close()
+
+	m := newModerator(t, &adk.ModerationConfig{
+		Action:   adk.ModerationActionRedact,
+		Keywords: []string{"falcon"},
+		Provider: &adk.ModerationProviderConfig{Type: adk.ModerationProviderOpenAI, URL: srv.URL + "/v1"},
+	})
+	v := m.Check(ctx, "I will hurt them.", DirectionOutput)
+	if v == nil || !slices.Equal(v.Reasons, []string{"violence"}) {
+		t.Fatalf("expected the provider's category, got %v", v)
+	}
+	if got := m.Apply("I will hurt them.", v, BlockedOutput); got != BlockedOutput {
+		t.Errorf("expected text flagged by the provider to be blocked, got %q", got)
+	}
+	if v := m.Check(ctx, "Project falcon ships Monday.", DirectionOutput); v == nil || v.Blocks() {
+		t.Errorf("expected a keyword match to be redacted, got %v", v)
+	}
+	if inputs[len(inputs)-1] != "Project "+redacted+" ships Monday." {
+		t.Errorf("expected the provider to see the redacted text, got %q", inputs[len(inputs)-1])
+	}
+}
+
+func TestProviderFailure(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	provider := &adk.ModerationProviderConfig{Type: adk.ModerationProviderHTTP, URL: srv.URL}
+	v := newModerator(t, &adk.ModerationConfig{Provider: provider}).Check(ctx, "hello", DirectionInput)
+	if v == nil || !slices.Equal(v.Reasons, []string{ReasonProviderError}) || !v.Blocks() {
+		t.Errorf("expected the message to fail closed, got %v", v)
+	}
+
+	provider.FailOpen = true
+	if v := newModerator(t, &adk.ModerationConfig{Provider: provider}).Check(ctx, "hello", DirectionInput); v != nil {
+		t.Errorf("expected the message to pass with failOpen, got %v", v)
+	}
+}
+
+func TestNewRejectsInvalidConfig(t *testing.T) {
+	for _, cfg := range []*adk.ModerationConfig{
+		{Action: "delete"},
+		{Patterns: []string{"("}},
+		{Provider: &adk.ModerationProviderConfig{Type: adk.ModerationProviderHTTP}},
+		{Provider: &adk.ModerationProviderConfig{Type: "azure"}},
+	} {
+		if _, err := New(cfg, "", logr.Discard()); err == nil {
+			t.Errorf("New(%+v) succeeded, want an error", cfg)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package moderation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/kagent-dev/kagent/go/api/adk"
+)
+
+const (
+	// defaultOpenAIModel is the OpenAI moderation model used when none is set.
+	defaultOpenAIModel = "omni-moderation-latest"
+	// defaultOpenAIURL is the OpenAI API used when no URL is set.
+	defaultOpenAIURL = "https://api.openai.com/v1"
+	// providerTimeout bounds each call to the moderation service.
+	providerTimeout = 10 * time.Second
+	// maxResponseBytes bounds the response read from the moderation service.
+	maxResponseBytes = 1 << 20
+)
+
+// provider classifies a message with a moderation service. It returns the
+// categories the message was flagged for, or none when it passes.
+type provider interface {
+	check(ctx context.Context, text, direction string) ([]string, error)
+	failOpen() bool
+}
+
+func newProvider(cfg *adk.ModerationProviderConfig, apiKey string) (provider, error) {
+	base := httpProvider{
+		apiKey: apiKey,
+		open:   cfg.FailOpen,
+		client: &http.Client{Timeout: providerTimeout},
+	}
+	switch cfg.Type {
+	case adk.ModerationProviderOpenAI:
+		base.url = strings.TrimSuffix(cfg.URL, "/") + "/moderations"
+		if cfg.URL == "" {
+			base.url = defaultOpenAIURL + "/moderations"
+		}
+		model := cfg.Model
+		if model == "" {
+			model = defaultOpenAIModel
+		}
+		return &openAIProvider{httpProvider: base, model: model}, nil
+	case adk.ModerationProviderHTTP:
+		if cfg.URL == "" {
+			return nil, fmt.Errorf("moderation provider %q requires a url", cfg.Type)
+		}
+		base.url = cfg.URL
+		return &base, nil
+	default:
+		return nil, fmt.Errorf("invalid moderation provider %q", cfg.Type)
+	}
+}
+
+// httpProvider posts {"text", "direction"} to a custom endpoint, which
+// answers {"flagged", "categories"}.
+type httpProvider struct {
+	url    string
+	apiKey string
+	open   bool
+	client *http.Client
+}
+
+func (p *httpProvider) failOpen() bool {
+	return p.open
+}
+
+func (p *httpProvider) check(ctx context.Context, text, direction string) ([]string, error) {
+	var out struct {
+		Flagged    bool     `json:"flagged"`
+		Categories []string `json:"categories"`
+	}
+	if err := p.post(ctx, map[string]any{"text": text, "direction": direction}, &out); err != nil {
+		return nil, err
+	}
+	if !out.Flagged {
+		return nil, nil
+	}
+	if len(out.Categories) == 0 {
+		return []string{"flagged"}, nil
+	}
+	return out.Categories, nil
+}
+
+func (p *httpProvider) post(ctx context.Context, body, out any) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("moderation request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return fmt.Errorf("failed to read moderation response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("moderation request failed with status %d: %s", resp.StatusCode, bytes.TrimSpace(data))
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to decode moderation response: %w", err)
+	}
+	return nil
+}
+
+// openAIProvider uses the OpenAI moderations API.
+type openAIProvider struct {
+	httpProvider
+	model string
+}
+
+func (p *openAIProvider) check(ctx context.Context, text, _ string) ([]string, error) {
+	var out struct {
+		Results []struct {
+			Flagged    bool            `json:"flagged"`
+			Categories map[string]bool `json:"categories"`
+		} `json:"results"`
+	}
+	if err := p.post(ctx, map[string]any{"model": p.model, "input": text}, &out); err != nil {
+		return nil, err
+	}
+	var categories []string
+	for _, r := range out.Results {
+		if !r.Flagged {
+			continue
+		}
+		for name, flagged := range r.Categories {
+			if flagged && !slices.Contains(categories, name) {
+				categories = append(categories, name)
+			}
+		}
+		if len(categories) == 0 {
+			categories = append(categories, "flagged")
+		}
+	}
+	slices.Sort(categories)
+	return categories, nil
+}
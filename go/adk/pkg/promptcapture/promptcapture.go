@@ -0,0 +1,312 @@
+// Package promptcapture records sampled, redacted model prompt/response pairs
+// as JSONL for curating fine-tuning and evaluation datasets. Records use the
+// chat "messages" layout most fine-tuning tools accept. It also stores sampled
+// raw model calls with their session for debugging; see Tracer.
+package promptcapture
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kagent-dev/kagent/go/api/adk"
+	adkmodel "google.golang.org/adk/v2/model"
+	"google.golang.org/genai"
+)
+
+// Record is one captured model call.
+type Record struct {
+	Timestamp time.Time `json:"timestamp"`
+	Agent     string    `json:"agent"`
+	Model     string    `json:"model"`
+	Messages  []Message `json:"messages"`
+	Tools     []Tool    `json:"tools,omitempty"`
+	Usage     *Usage    `json:"usage,omitempty"`
+}
+
+// Message is a chat message in a captured conversation.
+type Message struct {
+	Role       string     `json:"role"`
+	Content    string     `json:"content,omitempty"`
+	Name       string     `json:"name,omitempty"`
+	ToolCallID string     `json:"tool_call_id,omitempty"`
+	ToolCalls  []ToolCall `json:"tool_calls,omitempty"`
+}
+
+// ToolCall is a function call requested by the model.
+type ToolCall struct {
+	ID       string       `json:"id,omitempty"`
+	Type     string       `json:"type"`
+	Function FunctionCall `json:"function"`
+}
+
+// FunctionCall holds a tool call's name and JSON-encoded arguments.
+type FunctionCall struct {
+	Name      string `json:"name"`
+	Arguments string `json:"arguments"`
+}
+
+// Tool describes a function the model could call.
+type Tool struct {
+	Type     string       `json:"type"`
+	Function ToolFunction `json:"function"`
+}
+
+// ToolFunction is a tool's declaration.
+type ToolFunction struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Parameters  any    `json:"parameters,omitempty"`
+}
+
+// Usage holds the token counts reported for the call.
+type Usage struct {
+	PromptTokens     int32 `json:"prompt_tokens"`
+	CompletionTokens int32 `json:"completion_tokens"`
+}
+
+// Recorder appends records to rotating JSONL files in a directory, stopping
+// once the directory holds MaxTotalBytes of captures.
+type Recorder struct {
+	dir           string
+	agent         string
+	sampleRate    float64
+	maxFileBytes  int64
+	maxTotalBytes int64
+	redactor      *redactor
+	log           logr.Logger
+
+	mu         sync.Mutex
+	file       *os.File
+	fileBytes  int64
+	totalBytes int64
+	full       bool
+
+	// sample and now are replaced in tests.
+	sample func() float64
+	now    func() time.Time
+}
+
+// NewRecorder creates the capture directory and accounts for captures already
+// in it, so the total size cap holds across restarts.
+func NewRecorder(cfg *adk.PromptCaptureConfig, agentName string, log logr.Logger) (*Recorder, error) {
+	redactor, err := newRedactor(cfg.RedactPatterns)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(cfg.Path, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create prompt capture directory: %w", err)
+	}
+	r := &Recorder{
+		dir:           cfg.Path,
+		agent:         agentName,
+		sampleRate:    cfg.SampleRate,
+		maxFileBytes:  cfg.MaxFileBytes,
+		maxTotalBytes: cfg.MaxTotalBytes,
+		redactor:      redactor,
+		log:           log.WithName("prompt-capture"),
+		sample:        rand.Float64,
+		now:           time.Now,
+	}
+	entries, err := os.ReadDir(cfg.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read prompt capture directory: %w", err)
+	}
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".jsonl" {
+			continue
+		}
+		if info, err := e.Info(); err == nil {
+			r.totalBytes += info.Size()
+		}
+	}
+	return r, nil
+}
+
+// Wrap returns an LLM that forwards to llm and records sampled calls.
+func (r *Recorder) Wrap(llm adkmodel.LLM) adkmodel.LLM {
+	return &capturingLLM{LLM: llm, recorder: r}
+}
+
+type capturingLLM struct {
+	adkmodel.LLM
+	recorder *Recorder
+}
+
+func (m *capturingLLM) GenerateContent(ctx context.Context, req *adkmodel.LLMRequest, stream bool) iter.Seq2[*adkmodel.LLMResponse, error] {
+	responses := m.LLM.GenerateContent(ctx, req, stream)
+	if m.recorder.sample() >= m.recorder.sampleRate {
+		return responses
+	}
+	return func(yield func(*adkmodel.LLMResponse, error) bool) {
+		var final *adkmodel.LLMResponse
+		for resp, err := range responses {
+			if err == nil && resp != nil && !resp.Partial && resp.Content != nil {
+				final = resp
+			}
+			if !yield(resp, err) {
+				break
+			}
+		}
+		if final != nil {
+			m.recorder.record(m.Name(), req, final)
+		}
+	}
+}
+
+func (r *Recorder) record(modelName string, req *adkmodel.LLMRequest, resp *adkmodel.LLMResponse) {
+	if req.Model != "" {
+		modelName = req.Model
+	}
+	rec := Record{
+		Timestamp: r.now().UTC(),
+		Agent:     r.agent,
+		Model:     modelName,
+		Messages:  r.messages(req, resp),
+		Tools:     tools(req.Config),
+	}
+	if u := resp.UsageMetadata; u != nil {
+		rec.Usage = &Usage{PromptTokens: u.PromptTokenCount, CompletionTokens: u.CandidatesTokenCount}
+	}
+	line, err := json.Marshal(rec)
+	if err != nil {
+		r.log.Error(err, "Failed to encode prompt capture")
+		return
+	}
+	if err := r.write(append(line, '\n')); err != nil {
+		r.log.Error(err, "Failed to write prompt capture")
+	}
+}
+
+// write appends line to the current file, rotating it when it would exceed
+// maxFileBytes. Captures are dropped once maxTotalBytes is reached.
+func (r *Recorder) write(line []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	size := int64(len(line))
+	if r.maxTotalBytes > 0 && r.totalBytes+size > r.maxTotalBytes {
+		if !r.full {
+			r.full = true
+			r.log.Info("Prompt capture size limit reached, dropping further captures", "limitBytes", r.maxTotalBytes)
+		}
+		return nil
+	}
+	if r.file != nil && r.maxFileBytes > 0 && r.fileBytes+size > r.maxFileBytes {
+		_ = r.file.Close()
+		r.file = nil
+	}
+	if r.file == nil {
+		host, _ := os.Hostname()
+		name := fmt.Sprintf("%s-%s.jsonl", host, r.now().UTC().Format("20060102T150405.000000000"))
+		f, err := os.OpenFile(filepath.Join(r.dir, name), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o640)
+		if err != nil {
+			return fmt.Errorf("failed to open capture file: %w", err)
+		}
+		r.file, r.fileBytes = f, 0
+	}
+	n, err := r.file.Write(line)
+	r.fileBytes += int64(n)
+	r.totalBytes += int64(n)
+	return err
+}
+
+// messages flattens the request history and the final response into chat
+// messages, redacting all free text and tool arguments.
+func (r *Recorder) messages(req *adkmodel.LLMRequest, resp *adkmodel.LLMResponse) []Message {
+	var out []Message
+	if cfg := req.Config; cfg != nil && cfg.SystemInstruction != nil {
+		if text := contentText(cfg.SystemInstruction); text != "" {
+			out = append(out, Message{Role: "system", Content: r.redactor.redact(text)})
+		}
+	}
+	for _, c := range req.Contents {
+		out = append(out, r.contentMessages(c)...)
+	}
+	return append(out, r.contentMessages(resp.Content)...)
+}
+
+func (r *Recorder) contentMessages(c *genai.Content) []Message {
+	if c == nil {
+		return nil
+	}
+	role := "user"
+	if c.Role == genai.RoleModel {
+		role = "assistant"
+	}
+	msg := Message{Role: role}
+	var texts []string
+	var results []Message
+	for _, p := range c.Parts {
+		switch {
+		case p == nil || p.Thought:
+		case p.FunctionCall != nil:
+			args, _ := json.Marshal(p.FunctionCall.Args)
+			msg.ToolCalls = append(msg.ToolCalls, ToolCall{
+				ID:       p.FunctionCall.ID,
+				Type:     "function",
+				Function: FunctionCall{Name: p.FunctionCall.Name, Arguments: r.redactor.redact(string(args))},
+			})
+		case p.FunctionResponse != nil:
+			body, _ := json.Marshal(p.FunctionResponse.Response)
+			results = append(results, Message{
+				Role:       "tool",
+				Name:       p.FunctionResponse.Name,
+				ToolCallID: p.FunctionResponse.ID,
+				Content:    r.redactor.redact(string(body)),
+			})
+		case p.Text != "":
+			texts = append(texts, p.Text)
+		}
+	}
+	msg.Content = r.redactor.redact(strings.Join(texts, "\n"))
+	var out []Message
+	if msg.Content != "" || len(msg.ToolCalls) > 0 {
+		out = append(out, msg)
+	}
+	return append(out, results...)
+}
+
+func contentText(c *genai.Content) string {
+	var texts []string
+	for _, p := range c.Parts {
+		if p != nil && p.Text != "" {
+			texts = append(texts, p.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
+func tools(cfg *genai.GenerateContentConfig) []Tool {
+	if cfg == nil {
+		return nil
+	}
+	var out []Tool
+	for _, t := range cfg.Tools {
+		if t == nil {
+			continue
+		}
+		for _, fd := range t.FunctionDeclarations {
+			if fd == nil {
+				continue
+			}
+			var params any = fd.ParametersJsonSchema
+			if params == nil && fd.Parameters != nil {
+				params = fd.Parameters
+			}
+			out = append(out, Tool{
+				Type:     "function",
+				Function: ToolFunction{Name: fd.Name, Description: fd.Description, Parameters: params},
+			})
+		}
+	}
+	return out
+}
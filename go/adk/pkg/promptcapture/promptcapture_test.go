@@ -0,0 +1,164 @@
+package promptcapture
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"iter"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kagent-dev/kagent/go/api/adk"
+	adkmodel "google.golang.org/adk/v2/model"
+	"google.golang.org/genai"
+)
+
+type fakeLLM struct{}
+
+func (fakeLLM) Name() string { return "fake-model" }
+
+func (fakeLLM) GenerateContent(_ context.Context, _ *adkmodel.LLMRequest, _ bool) iter.Seq2[*adkmodel.LLMResponse, error] {
+	return func(yield func(*adkmodel.LLMResponse, error) bool) {
+		if !yield(&adkmodel.LLMResponse{Partial: true, Content: genai.NewContentFromText("The pod", genai.RoleModel)}, nil) {
+			return
+		}
+		yield(&adkmodel.LLMResponse{
+			Content:       genai.NewContentFromText("The pod is OOMKilled.", genai.RoleModel),
+			UsageMetadata: &genai.GenerateContentResponseUsageMetadata{PromptTokenCount: 42, CandidatesTokenCount: 7},
+		}, nil)
+	}
+}
+
+func testRequest() *adkmodel.LLMRequest {
+	return &adkmodel.LLMRequest{
+		Config: &genai.GenerateContentConfig{
+			SystemInstruction: genai.NewContentFromText("You are a Kubernetes expert.", genai.RoleUser),
+			Tools: []*genai.Tool{{FunctionDeclarations: []*genai.FunctionDeclaration{
+				{Name: "get_pods", Description: "List pods"},
+			}}},
+		},
+		Contents: []*genai.Content{
+			genai.NewContentFromText("Why is my pod failing? My api_key=abc123 and token Bearer eyJhbGciOi.payload.sig", genai.RoleUser),
+			{Role: genai.RoleModel, Parts: []*genai.Part{genai.NewPartFromFunctionCall("get_pods", map[string]any{"namespace": "cust-123456"})}},
+			{Role: genai.RoleUser, Parts: []*genai.Part{genai.NewPartFromFunctionResponse("get_pods", map[string]any{"result": "OOMKilled"})}},
+		},
+	}
+}
+
+func newTestRecorder(t *testing.T, cfg adk.PromptCaptureConfig) *Recorder {
+	t.Helper()
+	if cfg.Path == "" {
+		cfg.Path = filepath.Join(t.TempDir(), "agent")
+	}
+	r, err := NewRecorder(&cfg, "k8s-agent", logr.Discard())
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.sample = func() float64 { return 0.5 }
+	tick := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	r.now = func() time.Time {
+		tick = tick.Add(time.Second)
+		return tick
+	}
+	return r
+}
+
+func drain(llm adkmodel.LLM) {
+	for range llm.GenerateContent(context.Background(), testRequest(), true) {
+	}
+}
+
+func readRecords(t *testing.T, dir string) []Record {
+	t.Helper()
+	files, err := filepath.Glob(filepath.Join(dir, "*.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out []Record
+	for _, name := range files {
+		f, err := os.Open(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			var rec Record
+			if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+				t.Fatal(err)
+			}
+			out = append(out, rec)
+		}
+		f.Close()
+	}
+	return out
+}
+
+func TestRecorderCapturesRedactedConversation(t *testing.T) {
+	r := newTestRecorder(t, adk.PromptCaptureConfig{SampleRate: 1, RedactPatterns: []string{`cust-[0-9]{6}`}})
+	drain(r.Wrap(fakeLLM{}))
+
+	records := readRecords(t, r.dir)
+	if len(records) != 1 {
+		t.Fatalf("got %d records, want 1", len(records))
+	}
+	rec := records[0]
+	if rec.Model != "fake-model" || rec.Agent != "k8s-agent" {
+		t.Errorf("unexpected record header: %+v", rec)
+	}
+	if rec.Usage == nil || rec.Usage.PromptTokens != 42 || rec.Usage.CompletionTokens != 7 {
+		t.Errorf("usage = %+v", rec.Usage)
+	}
+	if len(rec.Tools) != 1 || rec.Tools[0].Function.Name != "get_pods" {
+		t.Errorf("tools = %+v", rec.Tools)
+	}
+
+	roles := make([]string, 0, len(rec.Messages))
+	for _, m := range rec.Messages {
+		roles = append(roles, m.Role)
+	}
+	if got := strings.Join(roles, ","); got != "system,user,assistant,tool,assistant" {
+		t.Fatalf("roles = %s", got)
+	}
+	user := rec.Messages[1].Content
+	if strings.Contains(user, "abc123") || strings.Contains(user, "eyJhbGciOi") || !strings.Contains(user, "api_key=<redacted>") {
+		t.Errorf("user message not redacted: %q", user)
+	}
+	if args := rec.Messages[2].ToolCalls[0].Function.Arguments; strings.Contains(args, "cust-123456") {
+		t.Errorf("tool arguments not redacted: %q", args)
+	}
+	if got := rec.Messages[4].Content; got != "The pod is OOMKilled." {
+		t.Errorf("response = %q, want the final non-partial content", got)
+	}
+}
+
+func TestRecorderSampling(t *testing.T) {
+	r := newTestRecorder(t, adk.PromptCaptureConfig{SampleRate: 0.25})
+	drain(r.Wrap(fakeLLM{}))
+	if records := readRecords(t, r.dir); len(records) != 0 {
+		t.Errorf("got %d records, want none when the call is not sampled", len(records))
+	}
+}
+
+func TestRecorderSizeLimits(t *testing.T) {
+	r := newTestRecorder(t, adk.PromptCaptureConfig{SampleRate: 1, MaxFileBytes: 1})
+	llm := r.Wrap(fakeLLM{})
+	drain(llm)
+	drain(llm)
+
+	files, _ := filepath.Glob(filepath.Join(r.dir, "*.jsonl"))
+	if len(files) != 2 {
+		t.Errorf("got %d files, want a new file per record when over maxFileBytes", len(files))
+	}
+
+	// A fresh recorder on the same directory counts existing captures against
+	// the total limit.
+	limited := newTestRecorder(t, adk.PromptCaptureConfig{Path: r.dir, SampleRate: 1, MaxTotalBytes: r.totalBytes + 10})
+	drain(limited.Wrap(fakeLLM{}))
+	if records := readRecords(t, r.dir); len(records) != 2 {
+		t.Errorf("got %d records, want the total limit to drop the third", len(records))
+	}
+}
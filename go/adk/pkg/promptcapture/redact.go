@@ -0,0 +1,49 @@
+package promptcapture
+
+import (
+	"fmt"
+	"regexp"
+)
+
+const redacted = "<redacted>"
+
+// credentialKeyPattern keeps the key of key=value / key: value credentials
+// and masks only the value.
+var credentialKeyPattern = regexp.MustCompile(`(?i)\b((?:password|passwd|secret|token|api[_-]?key|access[_-]?key)["']?\s*[:=]\s*["']?)[^\s"',}]+`)
+
+// defaultPatterns match common credential formats that are masked entirely.
+var defaultPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)\bbearer\s+[a-z0-9._~+/-]+=*`),
+	regexp.MustCompile(`\beyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+`),
+	regexp.MustCompile(`\bsk-[A-Za-z0-9_-]{16,}`),
+	regexp.MustCompile(`\bAKIA[0-9A-Z]{16}\b`),
+	regexp.MustCompile(`\bgh[pousr]_[A-Za-z0-9]{36,}\b`),
+}
+
+// redactor masks credentials and user-supplied patterns in captured text.
+type redactor struct {
+	patterns []*regexp.Regexp
+}
+
+func newRedactor(extra []string) (*redactor, error) {
+	r := &redactor{patterns: append([]*regexp.Regexp(nil), defaultPatterns...)}
+	for _, p := range extra {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redact pattern %q: %w", p, err)
+		}
+		r.patterns = append(r.patterns, re)
+	}
+	return r, nil
+}
+
+func (r *redactor) redact(s string) string {
+	if s == "" {
+		return s
+	}
+	s = credentialKeyPattern.ReplaceAllString(s, "${1}"+redacted)
+	for _, re := range r.patterns {
+		s = re.ReplaceAllString(s, redacted)
+	}
+	return s
+}
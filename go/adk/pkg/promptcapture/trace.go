@@ -0,0 +1,274 @@
+package promptcapture
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kagent-dev/kagent/go/api/adk"
+	"google.golang.org/adk/v2/agent"
+	adkmodel "google.golang.org/adk/v2/model"
+	adkplugin "google.golang.org/adk/v2/plugin"
+	"google.golang.org/genai"
+)
+
+// traceTimeout bounds storing one trace, which delays the model response.
+const traceTimeout = 10 * time.Second
+
+// Tracer stores the redacted request and response of sampled model calls
+// with their session through the Kagent API, for debugging prompts. The
+// agent's sample rate can be overridden per session with the
+// adk.LLMTraceSampleRateStateKey state key.
+type Tracer struct {
+	baseURL    string
+	client     *http.Client
+	sampleRate float64
+	redactor   *redactor
+	log        logr.Logger
+
+	mu sync.Mutex
+	// pending holds the redacted requests of sampled calls awaiting their
+	// response, by invocation and then by agent branch.
+	pending map[string]map[string]pendingTrace
+
+	// sample is replaced in tests.
+	sample func() float64
+}
+
+type pendingTrace struct {
+	model   string
+	request json.RawMessage
+}
+
+// traceRequest and traceResponse are the stored model call payloads.
+type traceRequest struct {
+	Model    string                       `json:"model,omitempty"`
+	Contents []*genai.Content             `json:"contents"`
+	Config   *genai.GenerateContentConfig `json:"config,omitempty"`
+}
+
+type traceResponse struct {
+	Content       *genai.Content                              `json:"content,omitempty"`
+	UsageMetadata *genai.GenerateContentResponseUsageMetadata `json:"usageMetadata,omitempty"`
+	FinishReason  genai.FinishReason                          `json:"finishReason,omitempty"`
+	ModelVersion  string                                      `json:"modelVersion,omitempty"`
+	ErrorCode     string                                      `json:"errorCode,omitempty"`
+	ErrorMessage  string                                      `json:"errorMessage,omitempty"`
+}
+
+// NewTracer returns a Tracer that stores traces through the Kagent API at
+// baseURL. cfg may be nil, in which case only sessions that set the sample
+// rate state key are traced.
+func NewTracer(cfg *adk.LLMTraceConfig, baseURL string, httpClient *http.Client, log logr.Logger) (*Tracer, error) {
+	t := &Tracer{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		client:  httpClient,
+		log:     log.WithName("llm-trace"),
+		pending: make(map[string]map[string]pendingTrace),
+		sample:  rand.Float64,
+	}
+	var patterns []string
+	if cfg != nil {
+		t.sampleRate = cfg.SampleRate
+		patterns = cfg.RedactPatterns
+	}
+	redactor, err := newRedactor(patterns)
+	if err != nil {
+		return nil, err
+	}
+	t.redactor = redactor
+	return t, nil
+}
+
+// ADKPlugin returns the ADK plugin that records model calls.
+func (t *Tracer) ADKPlugin() (*adkplugin.Plugin, error) {
+	return adkplugin.New(adkplugin.Config{
+		Name:                 "kagent-llm-trace",
+		BeforeModelCallback:  t.beforeModel,
+		AfterModelCallback:   t.afterModel,
+		OnModelErrorCallback: t.onModelError,
+		AfterRunCallback:     t.afterRun,
+	})
+}
+
+// rate returns the sample rate for the session of ctx.
+func (t *Tracer) rate(ctx agent.ReadonlyContext) float64 {
+	v, err := ctx.ReadonlyState().Get(adk.LLMTraceSampleRateStateKey)
+	if err != nil {
+		return t.sampleRate
+	}
+	switch v := v.(type) {
+	case bool:
+		if v {
+			return 1
+		}
+		return 0
+	case float64:
+		return v
+	case int:
+		return float64(v)
+	default:
+		return t.sampleRate
+	}
+}
+
+func (t *Tracer) beforeModel(ctx agent.Context, req *adkmodel.LLMRequest) (*adkmodel.LLMResponse, error) {
+	if rate := t.rate(ctx); rate <= 0 || t.sample() >= rate {
+		return nil, nil
+	}
+	request, err := t.redactJSON(traceRequest{Model: req.Model, Contents: req.Contents, Config: req.Config})
+	if err != nil {
+		t.log.Error(err, "Failed to encode LLM trace request")
+		return nil, nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	calls := t.pending[ctx.InvocationID()]
+	if calls == nil {
+		calls = make(map[string]pendingTrace)
+		t.pending[ctx.InvocationID()] = calls
+	}
+	calls[traceBranch(ctx)] = pendingTrace{model: req.Model, request: request}
+	return nil, nil
+}
+
+func (t *Tracer) afterModel(ctx agent.Context, resp *adkmodel.LLMResponse, respErr error) (*adkmodel.LLMResponse, error) {
+	if respErr == nil && (resp == nil || resp.Partial) {
+		return nil, nil
+	}
+	pending, ok := t.take(ctx)
+	if !ok {
+		return nil, nil
+	}
+	body := map[string]any{"model": pending.model, "request": pending.request}
+	if resp != nil {
+		response, err := t.redactJSON(traceResponse{
+			Content:       resp.Content,
+			UsageMetadata: resp.UsageMetadata,
+			FinishReason:  resp.FinishReason,
+			ModelVersion:  resp.ModelVersion,
+			ErrorCode:     resp.ErrorCode,
+			ErrorMessage:  resp.ErrorMessage,
+		})
+		if err != nil {
+			t.log.Error(err, "Failed to encode LLM trace response")
+			return nil, nil
+		}
+		body["response"] = response
+	}
+	if respErr != nil {
+		body["error"] = t.redactor.redact(respErr.Error())
+	}
+	t.store(ctx, body)
+	return nil, nil
+}
+
+func (t *Tracer) onModelError(ctx agent.Context, _ *adkmodel.LLMRequest, modelErr error) (*adkmodel.LLMResponse, error) {
+	if pending, ok := t.take(ctx); ok {
+		t.store(ctx, map[string]any{
+			"model":   pending.model,
+			"request": pending.request,
+			"error":   t.redactor.redact(modelErr.Error()),
+		})
+	}
+	return nil, nil
+}
+
+// afterRun drops requests whose response never came, e.g. because a
+// callback answered in place of the model.
+func (t *Tracer) afterRun(ctx agent.InvocationContext) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.pending, ctx.InvocationID())
+}
+
+func (t *Tracer) take(ctx agent.Context) (pendingTrace, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	calls := t.pending[ctx.InvocationID()]
+	branch := traceBranch(ctx)
+	pending, ok := calls[branch]
+	delete(calls, branch)
+	return pending, ok
+}
+
+// traceBranch tells apart the model calls of agents running concurrently in
+// one invocation.
+func traceBranch(ctx agent.ReadonlyContext) string {
+	return ctx.Branch() + "/" + ctx.AgentName()
+}
+
+// store posts a trace to the session. Failures are logged; tracing never
+// fails the model call.
+func (t *Tracer) store(ctx agent.Context, body map[string]any) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		t.log.Error(err, "Failed to encode LLM trace")
+		return
+	}
+	reqCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), traceTimeout)
+	defer cancel()
+
+	u := fmt.Sprintf("%s/api/sessions/%s/llm-traces?user_id=%s", t.baseURL, url.PathEscape(ctx.SessionID()), url.QueryEscape(ctx.UserID()))
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodPost, u, bytes.NewReader(data))
+	if err != nil {
+		t.log.Error(err, "Failed to build LLM trace request")
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-User-ID", ctx.UserID())
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		t.log.Error(err, "Failed to store LLM trace", "sessionID", ctx.SessionID())
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		t.log.Info("Failed to store LLM trace", "sessionID", ctx.SessionID(), "status", resp.StatusCode, "body", string(msg))
+	}
+}
+
+// redactJSON encodes v as JSON with every string value redacted. Keys are
+// kept so the payload stays comparable to the provider's.
+func (t *Tracer) redactJSON(v any) (json.RawMessage, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var generic any
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return nil, err
+	}
+	return json.Marshal(t.redactValue(generic))
+}
+
+func (t *Tracer) redactValue(v any) any {
+	switch v := v.(type) {
+	case string:
+		return t.redactor.redact(v)
+	case map[string]any:
+		for k, child := range v {
+			v[k] = t.redactValue(child)
+		}
+		return v
+	case []any:
+		for i, child := range v {
+			v[i] = t.redactValue(child)
+		}
+		return v
+	default:
+		return v
+	}
+}
@@ -0,0 +1,169 @@
+package promptcapture
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/kagent-dev/kagent/go/api/adk"
+	adkagent "google.golang.org/adk/v2/agent"
+	"google.golang.org/adk/v2/agent/llmagent"
+	adkmodel "google.golang.org/adk/v2/model"
+	adkplugin "google.golang.org/adk/v2/plugin"
+	"google.golang.org/adk/v2/runner"
+	adksession "google.golang.org/adk/v2/session"
+	"google.golang.org/genai"
+)
+
+type failingLLM struct{}
+
+func (failingLLM) Name() string { return "failing-model" }
+
+func (failingLLM) GenerateContent(_ context.Context, _ *adkmodel.LLMRequest, _ bool) iter.Seq2[*adkmodel.LLMResponse, error] {
+	return func(yield func(*adkmodel.LLMResponse, error) bool) {
+		yield(nil, errors.New("upstream rejected token=s3cr3t"))
+	}
+}
+
+type storedTrace struct {
+	path  string
+	user  string
+	trace map[string]any
+}
+
+// traceServer records the traces posted to it.
+type traceServer struct {
+	mu     sync.Mutex
+	traces []storedTrace
+}
+
+func (s *traceServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var trace map[string]any
+	_ = json.NewDecoder(r.Body).Decode(&trace)
+	s.mu.Lock()
+	s.traces = append(s.traces, storedTrace{path: r.URL.Path, user: r.Header.Get("X-User-ID"), trace: trace})
+	s.mu.Unlock()
+	w.WriteHeader(http.StatusCreated)
+}
+
+// jsonText encodes v without escaping the angle brackets of redactions.
+func jsonText(v any) string {
+	var sb strings.Builder
+	enc := json.NewEncoder(&sb)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(v)
+	return sb.String()
+}
+
+// runTraced sends one message to an agent backed by llm, with sessionState
+// as the initial state of the session, and returns the stored traces.
+func runTraced(t *testing.T, cfg *adk.LLMTraceConfig, llm adkmodel.LLM, sessionState map[string]any) []storedTrace {
+	t.Helper()
+	ctx := context.Background()
+	server := &traceServer{}
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	tracer, err := NewTracer(cfg, ts.URL+"/", ts.Client(), logr.Discard())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tracer.sample = func() float64 { return 0.5 }
+	plugin, err := tracer.ADKPlugin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, err := llmagent.New(llmagent.Config{Name: "k8s_agent", Model: llm, Instruction: "You are a Kubernetes expert."})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sessions := adksession.InMemoryService()
+	r, err := runner.New(runner.Config{
+		AppName:        "test",
+		Agent:          a,
+		SessionService: sessions,
+		PluginConfig:   runner.PluginConfig{Plugins: []*adkplugin.Plugin{plugin}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sess, err := sessions.Create(ctx, &adksession.CreateRequest{AppName: "test", UserID: "alice", SessionID: "sess-1", State: sessionState})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg := genai.NewContentFromText("Why is my pod failing? My api_key=abc123", genai.RoleUser)
+	for _, err := range r.Run(ctx, "alice", sess.Session.ID(), msg, adkagent.RunConfig{}) {
+		if err != nil {
+			break
+		}
+	}
+	if len(tracer.pending) != 0 {
+		t.Errorf("pending traces left after the run: %v", tracer.pending)
+	}
+	return server.traces
+}
+
+func TestTracer(t *testing.T) {
+	t.Run("stores a redacted request and response", func(t *testing.T) {
+		traces := runTraced(t, &adk.LLMTraceConfig{SampleRate: 1, RedactPatterns: []string{`OOM\w+`}}, fakeLLM{}, nil)
+		if len(traces) != 1 {
+			t.Fatalf("got %d traces, want 1", len(traces))
+		}
+		got := traces[0]
+		if got.path != "/api/sessions/sess-1/llm-traces" || got.user != "alice" {
+			t.Errorf("trace posted to %q as %q", got.path, got.user)
+		}
+		request := jsonText(got.trace["request"])
+		if strings.Contains(request, "abc123") || !strings.Contains(request, "api_key=<redacted>") {
+			t.Errorf("request not redacted: %s", request)
+		}
+		if !strings.Contains(request, "You are a Kubernetes expert.") {
+			t.Errorf("request lacks the system instruction: %s", request)
+		}
+		response := jsonText(got.trace["response"])
+		if !strings.Contains(response, "The pod is <redacted>.") {
+			t.Errorf("response not recorded or redacted: %s", response)
+		}
+		if !strings.Contains(response, `"promptTokenCount":42`) {
+			t.Errorf("response lacks usage: %s", response)
+		}
+	})
+
+	t.Run("skips calls outside the sample", func(t *testing.T) {
+		if traces := runTraced(t, &adk.LLMTraceConfig{SampleRate: 0.25}, fakeLLM{}, nil); len(traces) != 0 {
+			t.Errorf("got %d traces, want 0", len(traces))
+		}
+	})
+
+	t.Run("session state overrides the agent sample rate", func(t *testing.T) {
+		state := map[string]any{adk.LLMTraceSampleRateStateKey: true}
+		if traces := runTraced(t, nil, fakeLLM{}, state); len(traces) != 1 {
+			t.Errorf("got %d traces with tracing on for the session, want 1", len(traces))
+		}
+		state = map[string]any{adk.LLMTraceSampleRateStateKey: 0.0}
+		if traces := runTraced(t, &adk.LLMTraceConfig{SampleRate: 1}, fakeLLM{}, state); len(traces) != 0 {
+			t.Errorf("got %d traces with tracing off for the session, want 0", len(traces))
+		}
+	})
+
+	t.Run("stores the error of a failed call", func(t *testing.T) {
+		traces := runTraced(t, &adk.LLMTraceConfig{SampleRate: 1}, failingLLM{}, nil)
+		if len(traces) != 1 {
+			t.Fatalf("got %d traces, want 1", len(traces))
+		}
+		if got := traces[0].trace["error"]; got != "upstream rejected token=<redacted>" {
+			t.Errorf("error = %v", got)
+		}
+		if _, ok := traces[0].trace["response"]; ok {
+			t.Errorf("failed call has a response: %v", traces[0].trace["response"])
+		}
+	})
+}
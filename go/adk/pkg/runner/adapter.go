@@ -10,6 +10,7 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/kagent-dev/kagent/go/adk/pkg/agent"
 	kagentmemory "github.com/kagent-dev/kagent/go/adk/pkg/memory"
+	"github.com/kagent-dev/kagent/go/adk/pkg/promptcapture"
 	"github.com/kagent-dev/kagent/go/adk/pkg/sts"
 	"github.com/kagent-dev/kagent/go/adk/pkg/tools"
 	"github.com/kagent-dev/kagent/go/api/adk"
@@ -101,6 +102,21 @@ func CreateRunnerConfig(
 		}
 	}
 
+	if kagentURL != "" && httpClient != nil {
+		tracer, err := promptcapture.NewTracer(agentConfig.LLMTrace, kagentURL, httpClient, log)
+		if err != nil {
+			return runner.Config{}, nil, fmt.Errorf("failed to set up LLM trace capture: %w", err)
+		}
+		p, err := tracer.ADKPlugin()
+		if err != nil {
+			return runner.Config{}, nil, fmt.Errorf("failed to create LLM trace ADK plugin: %w", err)
+		}
+		adkPlugins = append(adkPlugins, p)
+		if agentConfig.LLMTrace != nil {
+			log.Info("LLM trace capture enabled", "sampleRate", agentConfig.LLMTrace.SampleRate)
+		}
+	}
+
 	cfg := runner.Config{
 		AppName:        appName,
 		Agent:          adkAgent,
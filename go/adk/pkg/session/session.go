@@ -170,17 +170,98 @@ func (s *KAgentSessionService) Get(ctx context.Context, req *adksession.GetReque
 		adkEvents = append(adkEvents, e)
 	}
 
+	// A controller without the session state API leaves the state empty
+	// rather than failing the session.
+	state, err := s.getState(ctx, result.Data.Session.ID, req.UserID)
+	if err != nil {
+		log.Info("Failed to load session state", "sessionID", result.Data.Session.ID, "error", err)
+		state = make(map[string]any)
+	}
+
 	return &adksession.GetResponse{
 		Session: &localSession{
 			appName:   req.AppName,
 			userID:    result.Data.Session.UserID,
 			sessionID: result.Data.Session.ID,
 			events:    adkEvents,
-			state:     make(map[string]any),
+			state:     state,
 		},
 	}, nil
 }
 
+// getState fetches the persisted key/value state of a session.
+func (s *KAgentSessionService) getState(ctx context.Context, sessionID, userID string) (map[string]any, error) {
+	url := fmt.Sprintf("%s/api/sessions/%s/state?user_id=%s", s.BaseURL, url.PathEscape(sessionID), url.QueryEscape(userID))
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build get session state request: %w", err)
+	}
+	httpReq.Header.Set("X-User-ID", userID)
+
+	resp, err := s.Client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get session state request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("get session state: status %d, body: %s", resp.StatusCode, string(b))
+	}
+
+	var result struct {
+		Data []struct {
+			Key   string          `json:"key"`
+			Value json.RawMessage `json:"value"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode get session state response: %w", err)
+	}
+
+	state := make(map[string]any, len(result.Data))
+	for _, entry := range result.Data {
+		var value any
+		if err := json.Unmarshal(entry.Value, &value); err != nil {
+			return nil, fmt.Errorf("failed to decode session state %q: %w", entry.Key, err)
+		}
+		state[entry.Key] = value
+	}
+	return state, nil
+}
+
+// putState persists one key of a session's state.
+func (s *KAgentSessionService) putState(ctx context.Context, sessionID, userID, key string, value any) error {
+	valueJSON, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal session state %q: %w", key, err)
+	}
+	body, err := json.Marshal(map[string]json.RawMessage{"value": valueJSON})
+	if err != nil {
+		return fmt.Errorf("failed to marshal put session state request: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/api/sessions/%s/state/%s?user_id=%s", s.BaseURL, url.PathEscape(sessionID), url.PathEscape(key), url.QueryEscape(userID))
+	httpReq, err := http.NewRequestWithContext(ctx, "PUT", url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build put session state request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("X-User-ID", userID)
+
+	resp, err := s.Client.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("failed to execute put session state request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("put session state %q: status %d, body: %s", key, resp.StatusCode, string(b))
+	}
+	return nil
+}
+
 // List implements adksession.Service.
 func (s *KAgentSessionService) List(_ context.Context, _ *adksession.ListRequest) (*adksession.ListResponse, error) {
 	return &adksession.ListResponse{Sessions: []adksession.Session{}}, nil
@@ -274,6 +355,16 @@ func (s *KAgentSessionService) AppendEvent(ctx context.Context, adkSess adksessi
 		}
 	}
 
+	// Persist the state the event changed, so it survives restarts. Temporary
+	// keys only live for the invocation.
+	if !event.Partial {
+		for key, value := range trimTempDeltaState(event).Actions.StateDelta {
+			if err := s.putState(persistCtx, adkSess.ID(), adkSess.UserID(), key, value); err != nil {
+				return err
+			}
+		}
+	}
+
 	return nil
 }
 
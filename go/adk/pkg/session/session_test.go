@@ -245,6 +245,91 @@ func TestAppendEvent_PersistsAndUpdatesLocalSession(t *testing.T) {
 	}
 }
 
+func TestGet_LoadsState(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/sessions/sess-1", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(mustJSON(t, map[string]any{
+			"data": map[string]any{"session": map[string]any{"id": "sess-1", "user_id": "u"}},
+		}))
+	})
+	mux.HandleFunc("/api/sessions/sess-1/state", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(mustJSON(t, map[string]any{
+			"data": []any{
+				map[string]any{"key": "favorite_color", "value": "blue"},
+				map[string]any{"key": "visits", "value": 3},
+			},
+		}))
+	})
+
+	svc := newService(t, mux)
+	resp, err := svc.Get(context.Background(), &adksession.GetRequest{AppName: "app", UserID: "u", SessionID: "sess-1"})
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got, err := resp.Session.State().Get("favorite_color"); err != nil || got != "blue" {
+		t.Errorf("state favorite_color = %v, %v, want blue", got, err)
+	}
+	if got, err := resp.Session.State().Get("visits"); err != nil || got != float64(3) {
+		t.Errorf("state visits = %v, %v, want 3", got, err)
+	}
+}
+
+func TestGet_StateUnavailable(t *testing.T) {
+	// Without the state API the session still loads, with empty state.
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/sessions/sess-1", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(mustJSON(t, map[string]any{
+			"data": map[string]any{"session": map[string]any{"id": "sess-1", "user_id": "u"}},
+		}))
+	})
+
+	svc := newService(t, mux)
+	resp, err := svc.Get(context.Background(), &adksession.GetRequest{AppName: "app", UserID: "u", SessionID: "sess-1"})
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	for key := range resp.Session.State().All() {
+		t.Errorf("unexpected state key %q", key)
+	}
+}
+
+func TestAppendEvent_PersistsStateDelta(t *testing.T) {
+	put := map[string]json.RawMessage{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/sessions/sess-1/events", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	})
+	mux.HandleFunc("/api/sessions/sess-1/state/{key}", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("method = %s, want PUT", r.Method)
+		}
+		var body struct {
+			Value json.RawMessage `json:"value"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		put[r.PathValue("key")] = body.Value
+	})
+
+	svc := newService(t, mux)
+	ls := &localSession{appName: "app", userID: "u", sessionID: "sess-1", state: make(map[string]any)}
+
+	event := &adksession.Event{ID: "evt-1", Author: "agent"}
+	event.Actions.StateDelta = map[string]any{
+		"favorite_color": "blue",
+		"temp:scratch":   "discarded",
+	}
+	if err := svc.AppendEvent(context.Background(), ls, event); err != nil {
+		t.Fatalf("AppendEvent() error = %v", err)
+	}
+
+	if len(put) != 1 || string(put["favorite_color"]) != `"blue"` {
+		t.Errorf("persisted state = %v, want only favorite_color", put)
+	}
+	if got, _ := ls.State().Get("favorite_color"); got != "blue" {
+		t.Errorf("local state favorite_color = %v, want blue", got)
+	}
+}
+
 func TestCreateSession_Success(t *testing.T) {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/api/sessions", func(w http.ResponseWriter, r *http.Request) {
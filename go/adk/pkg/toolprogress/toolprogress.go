@@ -0,0 +1,44 @@
+// Package toolprogress carries the progress long-running tools report while
+// they run, such as the progress notifications of MCP tool calls, to the A2A
+// task of the run, so users see what a tool is doing instead of a silent
+// wait.
+package toolprogress
+
+import "context"
+
+// Update is a progress report of a tool call.
+type Update struct {
+	// Tool is the name of the tool and FunctionCallID the id of its call.
+	Tool           string
+	FunctionCallID string
+	// Progress increases with every update. Total is the value Progress
+	// reaches once the call is done, or zero when unknown.
+	Progress float64
+	Total    float64
+	// Message describes the current step, e.g. "Waiting for rollout".
+	Message string
+}
+
+// Reporter receives the progress of the tool calls of a run. It may be
+// called concurrently.
+type Reporter func(Update)
+
+type reporterKey struct{}
+
+// WithReporter returns a context whose tool calls report their progress to r.
+func WithReporter(ctx context.Context, r Reporter) context.Context {
+	return context.WithValue(ctx, reporterKey{}, r)
+}
+
+// Enabled reports whether the tool calls made with ctx report progress.
+func Enabled(ctx context.Context) bool {
+	_, ok := ctx.Value(reporterKey{}).(Reporter)
+	return ok
+}
+
+// Report passes u to the reporter of ctx, if any.
+func Report(ctx context.Context, u Update) {
+	if r, ok := ctx.Value(reporterKey{}).(Reporter); ok {
+		r(u)
+	}
+}
@@ -0,0 +1,274 @@
+// Package toolresult caps the size of MCP tool results before they are added
+// to the conversation. A single `kubectl get -o json` can be larger than the
+// model's context window, so results over the limit are cut down with the
+// configured strategy and carry a note telling the model what was left out.
+package toolresult
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/go-logr/logr"
+	"github.com/kagent-dev/kagent/go/adk/pkg/toolwrap"
+	"github.com/kagent-dev/kagent/go/api/adk"
+	"google.golang.org/adk/v2/agent"
+	"google.golang.org/adk/v2/model"
+	"google.golang.org/adk/v2/session"
+	"google.golang.org/adk/v2/tool"
+	"google.golang.org/adk/v2/tool/functiontool"
+	"google.golang.org/genai"
+)
+
+const (
+	// NoteKey is added to every limited tool result.
+	NoteKey = "kagent_truncation_note"
+	// PageToolName is the tool the model calls to read the later pages of a
+	// paginated result.
+	PageToolName = "read_tool_result_page"
+	// stateKeyPrefix namespaces paginated results in session state; the
+	// function call ID completes the key.
+	stateKeyPrefix = "kagent_tool_result:"
+	// summaryInputFactor bounds how much of an oversized result is sent to the
+	// model for summarization, as a multiple of the limit.
+	summaryInputFactor = 16
+)
+
+// Limiter applies the configured size limit to tool results.
+type Limiter struct {
+	maxBytes int
+	strategy string
+	exclude  []string
+	llm      model.LLM
+	log      logr.Logger
+}
+
+// New builds a Limiter from the agent's config. llm is used by the summarize
+// strategy and may be nil otherwise.
+func New(cfg *adk.ToolResultLimitConfig, llm model.LLM, log logr.Logger) (*Limiter, error) {
+	if cfg.MaxBytes <= 0 {
+		return nil, fmt.Errorf("invalid tool result limit %d: must be positive", cfg.MaxBytes)
+	}
+	l := &Limiter{
+		maxBytes: cfg.MaxBytes,
+		strategy: cfg.Strategy,
+		exclude:  cfg.ExcludeTools,
+		llm:      llm,
+		log:      log.WithName("tool-result-limit"),
+	}
+	switch l.strategy {
+	case "":
+		l.strategy = adk.ToolResultStrategyHead
+	case adk.ToolResultStrategyHead, adk.ToolResultStrategyTail, adk.ToolResultStrategyPaginate:
+	case adk.ToolResultStrategySummarize:
+		if llm == nil {
+			return nil, fmt.Errorf("tool result strategy %q requires a model", cfg.Strategy)
+		}
+	default:
+		return nil, fmt.Errorf("invalid tool result strategy %q", cfg.Strategy)
+	}
+	return l, nil
+}
+
+// Strategy returns the strategy applied to results over the limit.
+func (l *Limiter) Strategy() string {
+	return l.strategy
+}
+
+// Wrap returns a toolset whose tools apply the limit to their results.
+func (l *Limiter) Wrap(ts tool.Toolset) tool.Toolset {
+	return toolwrap.Toolset(ts, func(toolwrap.Tool) toolwrap.RunFunc { return l.run })
+}
+
+func (l *Limiter) run(ctx agent.Context, inner toolwrap.Tool, args any) (map[string]any, error) {
+	result, err := inner.Run(ctx, args)
+	if err != nil {
+		return result, err
+	}
+	if limited := l.Apply(ctx, inner.Name(), result); limited != nil {
+		return limited, nil
+	}
+	return result, nil
+}
+
+// Apply returns nil when the result is within the limit or the tool is
+// excluded, otherwise the result the model should see.
+func (l *Limiter) Apply(ctx agent.Context, toolName string, result map[string]any) map[string]any {
+	if result == nil || slices.Contains(l.exclude, toolName) {
+		return nil
+	}
+	text := resultText(result)
+	if len(text) <= l.maxBytes {
+		return nil
+	}
+
+	var out, note string
+	switch l.strategy {
+	case adk.ToolResultStrategyTail:
+		out = tail(text, l.maxBytes)
+		note = fmt.Sprintf("This tool result was %d bytes, over the %d-byte limit. Only the last %d bytes are shown; the beginning was cut off.",
+			len(text), l.maxBytes, len(out))
+	case adk.ToolResultStrategySummarize:
+		out, note = l.summarize(ctx, toolName, text)
+	case adk.ToolResultStrategyPaginate:
+		out, note = l.paginate(ctx.State(), ctx.FunctionCallID(), text)
+	default:
+		out = head(text, l.maxBytes)
+		note = headNote(len(text), l.maxBytes, len(out))
+	}
+	l.log.Info("Tool result over the size limit",
+		"tool", toolName,
+		"strategy", l.strategy,
+		"bytes", len(text),
+		"limit", l.maxBytes,
+		"functionCallID", ctx.FunctionCallID(),
+	)
+	return map[string]any{"output": out, NoteKey: note}
+}
+
+func headNote(total, limit, kept int) string {
+	return fmt.Sprintf("This tool result was %d bytes, over the %d-byte limit. Only the first %d bytes are shown; the rest was cut off. "+
+		"If you need the missing part, call the tool again with narrower arguments.", total, limit, kept)
+}
+
+// summarize asks the model for a summary of the result that fits the limit,
+// falling back to the head of the result when that fails.
+func (l *Limiter) summarize(ctx context.Context, toolName, text string) (string, string) {
+	input := head(text, l.maxBytes*summaryInputFactor)
+	prompt := fmt.Sprintf(`Summarize the following output of the tool %q in at most %d characters.
+Keep identifiers, names, counts, error messages and anything that looks abnormal; drop repetitive detail.
+Output only the summary.
+
+Tool output:
+%s`, toolName, l.maxBytes, input)
+	req := &model.LLMRequest{
+		Contents: []*genai.Content{
+			{Role: "user", Parts: []*genai.Part{{Text: prompt}}},
+		},
+	}
+
+	var summary strings.Builder
+	for resp, err := range l.llm.GenerateContent(ctx, req, false) {
+		if err != nil {
+			l.log.Error(err, "Failed to summarize tool result, keeping its beginning instead", "tool", toolName)
+			summary.Reset()
+			break
+		}
+		if resp.Content != nil {
+			for _, part := range resp.Content.Parts {
+				summary.WriteString(part.Text)
+			}
+		}
+	}
+	out := strings.TrimSpace(summary.String())
+	if out == "" {
+		kept := head(text, l.maxBytes)
+		return kept, headNote(len(text), l.maxBytes, len(kept))
+	}
+	out = head(out, l.maxBytes)
+	note := fmt.Sprintf("This tool result was %d bytes, over the %d-byte limit, and was replaced by a summary.", len(text), l.maxBytes)
+	if len(input) < len(text) {
+		note += fmt.Sprintf(" Only the first %d bytes were summarized.", len(input))
+	}
+	return out, note + " Call the tool again with narrower arguments if you need exact details."
+}
+
+// paginate stores the full result in session state and returns its first
+// page, with a note telling the model how to read the others.
+func (l *Limiter) paginate(state session.State, functionCallID, text string) (string, string) {
+	pages := split(text, l.maxBytes)
+	if err := state.Set(stateKeyPrefix+functionCallID, text); err != nil {
+		l.log.Error(err, "Failed to store paginated tool result, keeping its beginning instead", "functionCallID", functionCallID)
+		return pages[0], headNote(len(text), l.maxBytes, len(pages[0]))
+	}
+	return pages[0], fmt.Sprintf("This tool result was %d bytes, over the %d-byte limit, and was split into %d pages. This is page 1. "+
+		"To read another page, call %s with result_id %q and the page number (2 to %d). Only read the pages you need.",
+		len(text), l.maxBytes, len(pages), PageToolName, functionCallID, len(pages))
+}
+
+type pageInput struct {
+	ResultID string `json:"result_id"`
+	Page     int    `json:"page"`
+}
+
+// PageTool returns the tool that reads the later pages of paginated results.
+func (l *Limiter) PageTool() (tool.Tool, error) {
+	return functiontool.New(functiontool.Config{
+		Name:        PageToolName,
+		Description: "Read a page of a tool result that was too large to return at once and was split into pages.",
+	}, func(ctx agent.Context, in pageInput) (map[string]any, error) {
+		return l.readPage(ctx.State(), in.ResultID, in.Page)
+	})
+}
+
+func (l *Limiter) readPage(state session.State, resultID string, page int) (map[string]any, error) {
+	stored, err := state.Get(stateKeyPrefix + resultID)
+	if err != nil {
+		return nil, fmt.Errorf("no paginated tool result with result_id %q", resultID)
+	}
+	text, _ := stored.(string)
+	pages := split(text, l.maxBytes)
+	if page < 1 || page > len(pages) {
+		return nil, fmt.Errorf("page %d is out of range: the result has %d pages", page, len(pages))
+	}
+	return map[string]any{"output": pages[page-1], "page": page, "pages": len(pages)}, nil
+}
+
+// resultText returns the text the model would see for a result: the MCP
+// toolset puts it under "output", as a string or as structured content.
+func resultText(result map[string]any) string {
+	var v any = result
+	if output, ok := result["output"]; ok && len(result) == 1 {
+		if s, ok := output.(string); ok {
+			return s
+		}
+		v = output
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
+// head returns at most n bytes from the start of s without splitting a rune.
+func head(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+// tail returns at most n bytes from the end of s without splitting a rune.
+func tail(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	start := len(s) - n
+	for start < len(s) && !utf8.RuneStart(s[start]) {
+		start++
+	}
+	return s[start:]
+}
+
+// split cuts s into pages of at most n bytes without splitting runes.
+func split(s string, n int) []string {
+	var pages []string
+	for len(s) > n {
+		page := head(s, n)
+		if page == "" {
+			// n is smaller than the rune; take it whole rather than loop.
+			_, size := utf8.DecodeRuneInString(s)
+			page = s[:size]
+		}
+		pages = append(pages, page)
+		s = s[len(page):]
+	}
+	return append(pages, s)
+}
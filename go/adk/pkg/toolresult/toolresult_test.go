@@ -0,0 +1,193 @@
+package toolresult
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"maps"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/kagent-dev/kagent/go/api/adk"
+	"google.golang.org/adk/v2/model"
+	"google.golang.org/adk/v2/session"
+	"google.golang.org/genai"
+)
+
+type fakeState map[string]any
+
+func (s fakeState) Get(key string) (any, error) {
+	v, ok := s[key]
+	if !ok {
+		return nil, session.ErrStateKeyNotExist
+	}
+	return v, nil
+}
+
+func (s fakeState) Set(key string, value any) error {
+	s[key] = value
+	return nil
+}
+
+func (s fakeState) All() iter.Seq2[string, any] {
+	return maps.All(s)
+}
+
+type fakeLLM struct {
+	reply string
+	err   error
+	got   *model.LLMRequest
+}
+
+func (m *fakeLLM) Name() string { return "fake" }
+
+func (m *fakeLLM) GenerateContent(_ context.Context, req *model.LLMRequest, _ bool) iter.Seq2[*model.LLMResponse, error] {
+	m.got = req
+	return func(yield func(*model.LLMResponse, error) bool) {
+		if m.err != nil {
+			yield(nil, m.err)
+			return
+		}
+		yield(&model.LLMResponse{Content: genai.NewContentFromText(m.reply, genai.RoleModel)}, nil)
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     adk.ToolResultLimitConfig
+		llm     model.LLM
+		want    string
+		wantErr bool
+	}{
+		{name: "default strategy", cfg: adk.ToolResultLimitConfig{MaxBytes: 100}, want: adk.ToolResultStrategyHead},
+		{name: "paginate", cfg: adk.ToolResultLimitConfig{MaxBytes: 100, Strategy: adk.ToolResultStrategyPaginate}, want: adk.ToolResultStrategyPaginate},
+		{name: "summarize", cfg: adk.ToolResultLimitConfig{MaxBytes: 100, Strategy: adk.ToolResultStrategySummarize}, llm: &fakeLLM{}, want: adk.ToolResultStrategySummarize},
+		{name: "summarize without model", cfg: adk.ToolResultLimitConfig{MaxBytes: 100, Strategy: adk.ToolResultStrategySummarize}, wantErr: true},
+		{name: "unknown strategy", cfg: adk.ToolResultLimitConfig{MaxBytes: 100, Strategy: "middle"}, wantErr: true},
+		{name: "no limit", cfg: adk.ToolResultLimitConfig{}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, err := New(&tt.cfg, tt.llm, logr.Discard())
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if l.Strategy() != tt.want {
+				t.Errorf("Strategy() = %q, want %q", l.Strategy(), tt.want)
+			}
+		})
+	}
+}
+
+func TestHeadTailSplit(t *testing.T) {
+	s := "aé" + strings.Repeat("b", 5) + "é"
+	if got := head(s, 2); got != "a" {
+		t.Errorf("head() = %q, want %q: must not split é", got, "a")
+	}
+	if got := tail(s, 3); got != "bé" {
+		t.Errorf("tail() = %q, want %q", got, "bé")
+	}
+	if got := tail(s, 1); got != "" {
+		t.Errorf("tail() = %q, want empty: must not split é", got)
+	}
+	pages := split(s, 4)
+	if strings.Join(pages, "") != s {
+		t.Fatalf("split() pages %q do not add up to the input", pages)
+	}
+	for _, p := range pages {
+		if len(p) > 4 {
+			t.Errorf("page %q is over the limit", p)
+		}
+	}
+}
+
+func TestResultText(t *testing.T) {
+	if got := resultText(map[string]any{"output": "pods"}); got != "pods" {
+		t.Errorf("text output: got %q", got)
+	}
+	if got := resultText(map[string]any{"output": map[string]any{"items": []any{}}}); got != `{"items":[]}` {
+		t.Errorf("structured output: got %q", got)
+	}
+	if got := resultText(map[string]any{"a": 1, "b": "x"}); got != `{"a":1,"b":"x"}` {
+		t.Errorf("other result: got %q", got)
+	}
+}
+
+func TestApplyPassesThrough(t *testing.T) {
+	l, err := New(&adk.ToolResultLimitConfig{MaxBytes: 10, ExcludeTools: []string{"get_release_notes"}}, nil, logr.Discard())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Results that are not limited return before the context is used.
+	if got := l.Apply(nil, "kubectl_get", map[string]any{"output": "small"}); got != nil {
+		t.Errorf("result within the limit was changed: %v", got)
+	}
+	if got := l.Apply(nil, "get_release_notes", map[string]any{"output": strings.Repeat("x", 100)}); got != nil {
+		t.Errorf("excluded tool result was changed: %v", got)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	text := strings.Repeat("x", 1000)
+
+	llm := &fakeLLM{reply: "  3 pods, all running  "}
+	l, err := New(&adk.ToolResultLimitConfig{MaxBytes: 100, Strategy: adk.ToolResultStrategySummarize}, llm, logr.Discard())
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, note := l.summarize(context.Background(), "kubectl_get", text)
+	if out != "3 pods, all running" {
+		t.Errorf("summary = %q", out)
+	}
+	if !strings.Contains(note, "replaced by a summary") {
+		t.Errorf("note = %q", note)
+	}
+	if prompt := llm.got.Contents[0].Parts[0].Text; !strings.Contains(prompt, `"kubectl_get"`) || !strings.Contains(prompt, text) {
+		t.Errorf("prompt does not carry the tool name and output: %q", prompt)
+	}
+
+	l.llm = &fakeLLM{err: errors.New("rate limited")}
+	out, note = l.summarize(context.Background(), "kubectl_get", text)
+	if out != text[:100] || !strings.Contains(note, "first 100 bytes") {
+		t.Errorf("fallback = %q, note %q; want the head of the result", out, note)
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	l, err := New(&adk.ToolResultLimitConfig{MaxBytes: 10, Strategy: adk.ToolResultStrategyPaginate}, nil, logr.Discard())
+	if err != nil {
+		t.Fatal(err)
+	}
+	state := fakeState{}
+	text := "0123456789abcdefghijKLMNO"
+
+	first, note := l.paginate(state, "call-1", text)
+	if first != "0123456789" {
+		t.Errorf("first page = %q", first)
+	}
+	if !strings.Contains(note, "3 pages") || !strings.Contains(note, `"call-1"`) || !strings.Contains(note, PageToolName) {
+		t.Errorf("note = %q", note)
+	}
+
+	page, err := l.readPage(state, "call-1", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page["output"] != "KLMNO" || page["page"] != 3 || page["pages"] != 3 {
+		t.Errorf("page 3 = %v", page)
+	}
+	if _, err := l.readPage(state, "call-1", 4); err == nil {
+		t.Error("expected an error for a page out of range")
+	}
+	if _, err := l.readPage(state, "call-2", 1); err == nil {
+		t.Error("expected an error for an unknown result")
+	}
+}
@@ -0,0 +1,215 @@
+// Package toolschema checks MCP tool results against the output schema their
+// tool declares before they are added to the conversation. A result that does
+// not match is replaced by a tool error naming the violation, so the model
+// does not reason over malformed data. Values that only differ from the
+// schema in their JSON type, such as numbers sent as strings, can be coerced
+// instead of rejected.
+package toolschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/kagent-dev/kagent/go/adk/pkg/toolwrap"
+	"github.com/kagent-dev/kagent/go/api/adk"
+	"google.golang.org/adk/v2/agent"
+	"google.golang.org/adk/v2/tool"
+	"google.golang.org/genai"
+)
+
+// ViolationKey holds, on a rejected result, why it did not match the schema.
+const ViolationKey = "kagent_schema_violation"
+
+// Validator checks tool results against their tool's output schema.
+type Validator struct {
+	coerce  bool
+	exclude []string
+	log     logr.Logger
+}
+
+// New builds a Validator from the agent's config.
+func New(cfg *adk.ToolResultValidationConfig, log logr.Logger) *Validator {
+	return &Validator{
+		coerce:  cfg.Coerce,
+		exclude: cfg.ExcludeTools,
+		log:     log.WithName("tool-result-validation"),
+	}
+}
+
+// Wrap returns a toolset whose tools validate their results.
+func (v *Validator) Wrap(ts tool.Toolset) tool.Toolset {
+	return toolwrap.Toolset(ts, func(t toolwrap.Tool) toolwrap.RunFunc {
+		if decl := t.Declaration(); decl == nil || decl.ResponseJsonSchema == nil {
+			return nil
+		}
+		var (
+			once   sync.Once
+			schema *jsonschema.Resolved
+		)
+		return func(ctx agent.Context, inner toolwrap.Tool, args any) (map[string]any, error) {
+			result, err := inner.Run(ctx, args)
+			if err != nil {
+				return result, err
+			}
+			once.Do(func() {
+				resolved, err := resolveOutputSchema(inner.Declaration())
+				if err != nil {
+					v.log.Error(err, "Ignoring unusable output schema", "tool", inner.Name())
+				}
+				schema = resolved
+			})
+			if validated := v.Apply(inner.Name(), schema, result); validated != nil {
+				return validated, nil
+			}
+			return result, nil
+		}
+	})
+}
+
+// Apply returns nil when the result needs no change: the tool is excluded,
+// declares no output schema, or its result matches the schema as is.
+// Otherwise it returns the coerced result, or a tool error when the result
+// does not match.
+func (v *Validator) Apply(toolName string, schema *jsonschema.Resolved, result map[string]any) map[string]any {
+	if schema == nil || result == nil || slices.Contains(v.exclude, toolName) {
+		return nil
+	}
+	if _, failed := result["error"]; failed {
+		return nil
+	}
+	output, ok := result["output"]
+	if !ok || len(result) != 1 {
+		return nil
+	}
+
+	// Servers without structured content return the JSON as text.
+	value, parsed := output, false
+	if text, isText := output.(string); isText {
+		if err := json.Unmarshal([]byte(text), &value); err != nil {
+			return v.reject(toolName, fmt.Errorf("result is not JSON: %w", err))
+		}
+		parsed = true
+	}
+
+	err := schema.Validate(value)
+	if err != nil && v.coerce {
+		coerced := coerce(schema.Schema(), value)
+		if coerr := schema.Validate(coerced); coerr == nil {
+			v.log.V(1).Info("Coerced tool result to its output schema", "tool", toolName, "violation", err.Error())
+			return map[string]any{"output": coerced}
+		}
+	}
+	if err != nil {
+		return v.reject(toolName, err)
+	}
+	if parsed {
+		return map[string]any{"output": value}
+	}
+	return nil
+}
+
+func (v *Validator) reject(toolName string, err error) map[string]any {
+	v.log.Info("Tool result does not match its output schema", "tool", toolName, "violation", err.Error())
+	return map[string]any{
+		"error": fmt.Sprintf("Tool %s returned a result that does not match its declared output schema, so the result was discarded. "+
+			"Do not guess its content; call the tool again or tell the user it returned malformed data.", toolName),
+		ViolationKey: err.Error(),
+	}
+}
+
+// resolveOutputSchema returns the resolved output schema of a tool
+// declaration, or nil when it declares none or it cannot be used.
+func resolveOutputSchema(decl *genai.FunctionDeclaration) (*jsonschema.Resolved, error) {
+	if decl == nil || decl.ResponseJsonSchema == nil {
+		return nil, nil
+	}
+	schema, ok := decl.ResponseJsonSchema.(*jsonschema.Schema)
+	if !ok {
+		raw, err := json.Marshal(decl.ResponseJsonSchema)
+		if err != nil {
+			return nil, err
+		}
+		schema = &jsonschema.Schema{}
+		if err := json.Unmarshal(raw, schema); err != nil {
+			return nil, err
+		}
+	}
+	return schema.Resolve(nil)
+}
+
+// coerce returns value with the scalars whose JSON type the schema does not
+// allow converted to one it does, where that is lossless: numeric and
+// boolean strings to numbers and booleans, and numbers and booleans to
+// strings. Objects and arrays are walked through properties,
+// additionalProperties and items.
+func coerce(s *jsonschema.Schema, value any) any {
+	if s == nil {
+		return value
+	}
+	switch v := value.(type) {
+	case string:
+		if allows(s, "string") {
+			return v
+		}
+		trimmed := strings.TrimSpace(v)
+		if allows(s, "integer") {
+			if i, err := strconv.ParseInt(trimmed, 10, 64); err == nil {
+				return float64(i)
+			}
+		}
+		if allows(s, "number") {
+			if f, err := strconv.ParseFloat(trimmed, 64); err == nil {
+				return f
+			}
+		}
+		if allows(s, "boolean") {
+			switch strings.ToLower(trimmed) {
+			case "true":
+				return true
+			case "false":
+				return false
+			}
+		}
+	case float64:
+		if allows(s, "string") && !allows(s, "number") && !allows(s, "integer") {
+			return strconv.FormatFloat(v, 'f', -1, 64)
+		}
+	case bool:
+		if allows(s, "string") && !allows(s, "boolean") {
+			return strconv.FormatBool(v)
+		}
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for key, item := range v {
+			if prop, ok := s.Properties[key]; ok {
+				out[key] = coerce(prop, item)
+			} else {
+				out[key] = coerce(s.AdditionalProperties, item)
+			}
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			if i < len(s.PrefixItems) {
+				out[i] = coerce(s.PrefixItems[i], item)
+			} else {
+				out[i] = coerce(s.Items, item)
+			}
+		}
+		return out
+	}
+	return value
+}
+
+// allows reports whether the schema declares the JSON type t. A schema
+// without a type declares none, so nothing is coerced to match it.
+func allows(s *jsonschema.Schema, t string) bool {
+	return s.Type == t || slices.Contains(s.Types, t)
+}
@@ -0,0 +1,141 @@
+package toolschema
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/kagent-dev/kagent/go/api/adk"
+	"google.golang.org/genai"
+)
+
+var podSchema = &jsonschema.Schema{
+	Type: "object",
+	Properties: map[string]*jsonschema.Schema{
+		"name":     {Type: "string"},
+		"replicas": {Type: "integer"},
+		"ready":    {Type: "boolean"},
+		"cpu":      {Type: "number"},
+		"labels":   {Type: "object", AdditionalProperties: &jsonschema.Schema{Type: "string"}},
+		"ports":    {Type: "array", Items: &jsonschema.Schema{Type: "integer"}},
+	},
+	Required: []string{"name", "replicas"},
+}
+
+func resolved(t *testing.T) *jsonschema.Resolved {
+	t.Helper()
+	rs, err := resolveOutputSchema(&genai.FunctionDeclaration{ResponseJsonSchema: podSchema})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rs
+}
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name          string
+		coerce        bool
+		exclude       []string
+		result        map[string]any
+		want          map[string]any
+		wantViolation string
+	}{
+		{
+			name:   "valid structured result passes through",
+			coerce: true,
+			result: map[string]any{"output": map[string]any{"name": "web", "replicas": float64(3)}},
+		},
+		{
+			name:   "valid JSON text becomes structured",
+			result: map[string]any{"output": `{"name":"web","replicas":3}`},
+			want:   map[string]any{"output": map[string]any{"name": "web", "replicas": float64(3)}},
+		},
+		{
+			name:   "strings are coerced to the schema",
+			coerce: true,
+			result: map[string]any{"output": map[string]any{
+				"name": "web", "replicas": "3", "ready": "True", "cpu": "0.5",
+				"labels": map[string]any{"tier": float64(1)},
+				"ports":  []any{"80", float64(443)},
+			}},
+			want: map[string]any{"output": map[string]any{
+				"name": "web", "replicas": float64(3), "ready": true, "cpu": 0.5,
+				"labels": map[string]any{"tier": "1"},
+				"ports":  []any{float64(80), float64(443)},
+			}},
+		},
+		{
+			name:          "mismatch without coercion is rejected",
+			result:        map[string]any{"output": map[string]any{"name": "web", "replicas": "3"}},
+			wantViolation: "replicas",
+		},
+		{
+			name:          "uncoercible value is rejected",
+			coerce:        true,
+			result:        map[string]any{"output": map[string]any{"name": "web", "replicas": "three"}},
+			wantViolation: "replicas",
+		},
+		{
+			name:          "missing required property is rejected",
+			coerce:        true,
+			result:        map[string]any{"output": map[string]any{"name": "web"}},
+			wantViolation: "replicas",
+		},
+		{
+			name:          "text that is not JSON is rejected",
+			coerce:        true,
+			result:        map[string]any{"output": "deployment scaled"},
+			wantViolation: "not JSON",
+		},
+		{
+			name:    "excluded tool is not checked",
+			exclude: []string{"get_pod"},
+			result:  map[string]any{"output": "deployment scaled"},
+		},
+		{
+			name:   "tool errors are left alone",
+			result: map[string]any{"error": "boom"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := New(&adk.ToolResultValidationConfig{Coerce: tt.coerce, ExcludeTools: tt.exclude}, logr.Discard())
+			got := v.Apply("get_pod", resolved(t), tt.result)
+			if tt.wantViolation != "" {
+				violation, _ := got[ViolationKey].(string)
+				if !strings.Contains(violation, tt.wantViolation) {
+					t.Fatalf("violation = %q, want it to mention %q", violation, tt.wantViolation)
+				}
+				if msg, _ := got["error"].(string); !strings.Contains(msg, "get_pod") {
+					t.Fatalf("error = %q, want it to name the tool", msg)
+				}
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Apply() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyWithoutSchema(t *testing.T) {
+	v := New(&adk.ToolResultValidationConfig{Coerce: true}, logr.Discard())
+	if got := v.Apply("get_pod", nil, map[string]any{"output": "anything"}); got != nil {
+		t.Fatalf("Apply() = %#v, want nil", got)
+	}
+}
+
+func TestResolveOutputSchemaFromMap(t *testing.T) {
+	rs, err := resolveOutputSchema(&genai.FunctionDeclaration{ResponseJsonSchema: map[string]any{
+		"type":       "object",
+		"properties": map[string]any{"count": map[string]any{"type": "integer"}},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rs.Validate(map[string]any{"count": "1"}); err == nil {
+		t.Fatal("expected a string count to be invalid")
+	}
+}
@@ -0,0 +1,207 @@
+// Package toolstats counts the calls the agent makes to each tool server,
+// with their errors and latency, and reports them to the controller, which
+// shows operators which MCP servers are slow or flaky.
+package toolstats
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kagent-dev/kagent/go/adk/pkg/toolwrap"
+	"github.com/kagent-dev/kagent/go/api/adk"
+	"google.golang.org/adk/v2/agent"
+	"google.golang.org/adk/v2/tool"
+)
+
+const (
+	// reportInterval is how often the stats are reported.
+	reportInterval = 30 * time.Second
+	// reportTimeout bounds reporting the stats of one tool server.
+	reportTimeout = 10 * time.Second
+)
+
+// Default is the collector the MCP toolsets of the agent record their calls
+// in. It outlives config reloads, which rebuild the toolsets.
+var Default = NewCollector()
+
+// Collector counts tool calls per tool server.
+type Collector struct {
+	mu      sync.Mutex
+	servers map[string]*adk.ToolCallStatsReport
+}
+
+// NewCollector returns an empty Collector.
+func NewCollector() *Collector {
+	return &Collector{servers: map[string]*adk.ToolCallStatsReport{}}
+}
+
+// Record counts one call to the tool server serverRef.
+func (c *Collector) Record(serverRef string, latency time.Duration, failed bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := c.servers[serverRef]
+	if stats == nil {
+		stats = &adk.ToolCallStatsReport{LatencyBuckets: make([]int64, len(adk.ToolLatencyBucketsMs)+1)}
+		c.servers[serverRef] = stats
+	}
+	stats.Calls++
+	if failed {
+		stats.Errors++
+	}
+	stats.LatencyBuckets[bucket(latency)]++
+}
+
+// bucket returns the index of the latency bucket of latency.
+func bucket(latency time.Duration) int {
+	ms := latency.Milliseconds()
+	for i, bound := range adk.ToolLatencyBucketsMs {
+		if ms <= bound {
+			return i
+		}
+	}
+	return len(adk.ToolLatencyBucketsMs)
+}
+
+// take returns the stats counted since the previous take, by tool server,
+// and resets them.
+func (c *Collector) take() map[string]*adk.ToolCallStatsReport {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	taken := c.servers
+	c.servers = map[string]*adk.ToolCallStatsReport{}
+	return taken
+}
+
+// restore adds stats that could not be reported back, so the next report
+// includes them.
+func (c *Collector) restore(serverRef string, stats *adk.ToolCallStatsReport) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	current := c.servers[serverRef]
+	if current == nil {
+		c.servers[serverRef] = stats
+		return
+	}
+	current.Calls += stats.Calls
+	current.Errors += stats.Errors
+	for i, n := range stats.LatencyBuckets {
+		current.LatencyBuckets[i] += n
+	}
+}
+
+// Wrap returns a toolset whose tool calls are counted for the tool server
+// serverRef.
+func (c *Collector) Wrap(serverRef string, ts tool.Toolset) tool.Toolset {
+	return toolwrap.Toolset(ts, func(toolwrap.Tool) toolwrap.RunFunc {
+		return func(ctx agent.Context, inner toolwrap.Tool, args any) (map[string]any, error) {
+			start := time.Now()
+			result, err := inner.Run(ctx, args)
+			// Calls abandoned because the invocation was canceled say nothing
+			// about the tool server.
+			if err != nil && ctx.Err() != nil {
+				return result, err
+			}
+			c.Record(serverRef, time.Since(start), err != nil)
+			return result, err
+		}
+	})
+}
+
+// Reporter periodically reports the stats of a Collector through the Kagent
+// API.
+type Reporter struct {
+	collector *Collector
+	baseURL   string
+	client    *http.Client
+	agentID   string
+	log       logr.Logger
+}
+
+// NewReporter returns a Reporter that reports the stats of collector as those
+// of the agent agentID through the Kagent API at baseURL.
+func NewReporter(collector *Collector, baseURL string, httpClient *http.Client, agentID string, log logr.Logger) *Reporter {
+	return &Reporter{
+		collector: collector,
+		baseURL:   strings.TrimSuffix(baseURL, "/"),
+		client:    httpClient,
+		agentID:   agentID,
+		log:       log.WithName("tool-stats"),
+	}
+}
+
+// Run reports the stats every reportInterval until ctx is done.
+func (r *Reporter) Run(ctx context.Context) {
+	ticker := time.NewTicker(reportInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.Report(ctx)
+		}
+	}
+}
+
+// errRejected is returned for reports the controller rejects, which are not
+// retried.
+var errRejected = errors.New("report rejected")
+
+// Report reports the stats counted since the previous report. The stats of
+// a tool server that fail to be reported are kept for the next report,
+// unless the controller rejected them.
+func (r *Reporter) Report(ctx context.Context) {
+	for serverRef, stats := range r.collector.take() {
+		stats.AgentID = r.agentID
+		if err := r.report(ctx, serverRef, stats); err != nil {
+			r.log.Error(err, "Failed to report tool call stats", "toolServer", serverRef)
+			if !errors.Is(err, errRejected) {
+				r.collector.restore(serverRef, stats)
+			}
+		}
+	}
+}
+
+func (r *Reporter) report(ctx context.Context, serverRef string, stats *adk.ToolCallStatsReport) error {
+	namespace, name, ok := strings.Cut(serverRef, "/")
+	if !ok {
+		return fmt.Errorf("%w: invalid tool server reference %q", errRejected, serverRef)
+	}
+	data, err := json.Marshal(stats)
+	if err != nil {
+		return fmt.Errorf("failed to encode stats: %w", err)
+	}
+	reqCtx, cancel := context.WithTimeout(ctx, reportTimeout)
+	defer cancel()
+
+	u := fmt.Sprintf("%s/api/toolservers/%s/%s/stats", r.baseURL, url.PathEscape(namespace), url.PathEscape(name))
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodPost, u, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		if resp.StatusCode < http.StatusInternalServerError {
+			return fmt.Errorf("%w with status %d: %s", errRejected, resp.StatusCode, msg)
+		}
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, msg)
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package toolstats
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kagent-dev/kagent/go/adk/pkg/toolwrap"
+	"github.com/kagent-dev/kagent/go/api/adk"
+	"google.golang.org/adk/v2/agent"
+	"google.golang.org/adk/v2/model"
+	"google.golang.org/adk/v2/tool"
+	"google.golang.org/genai"
+)
+
+// fakeContext is the part of agent.Context a tool call uses.
+type fakeContext struct {
+	agent.Context
+	ctx context.Context
+}
+
+func (c *fakeContext) Deadline() (time.Time, bool) { return c.ctx.Deadline() }
+func (c *fakeContext) Done() <-chan struct{}       { return c.ctx.Done() }
+func (c *fakeContext) Err() error                  { return c.ctx.Err() }
+func (c *fakeContext) Value(key any) any           { return c.ctx.Value(key) }
+
+// fakeTool returns err, or a result when err is nil.
+type fakeTool struct {
+	err error
+}
+
+func (t *fakeTool) Name() string        { return "k8s_get_resources" }
+func (t *fakeTool) Description() string { return "" }
+func (t *fakeTool) IsLongRunning() bool { return false }
+func (t *fakeTool) Declaration() *genai.FunctionDeclaration {
+	return &genai.FunctionDeclaration{Name: t.Name()}
+}
+func (t *fakeTool) ProcessRequest(agent.Context, *model.LLMRequest) error { return nil }
+func (t *fakeTool) Run(agent.Context, any) (map[string]any, error) {
+	if t.err != nil {
+		return nil, t.err
+	}
+	return map[string]any{"output": "ok"}, nil
+}
+
+type fakeToolset struct {
+	tools []tool.Tool
+}
+
+func (f *fakeToolset) Name() string { return "fake" }
+
+func (f *fakeToolset) Tools(agent.ReadonlyContext) ([]tool.Tool, error) {
+	return f.tools, nil
+}
+
+func run(t *testing.T, c *Collector, ctx context.Context, toolErr error) {
+	t.Helper()
+	tools, err := c.Wrap("kagent/tools", &fakeToolset{tools: []tool.Tool{&fakeTool{err: toolErr}}}).Tools(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tools[0].(toolwrap.Tool).Run(&fakeContext{ctx: ctx}, nil) //nolint:errcheck
+}
+
+func TestWrapRecordsCalls(t *testing.T) {
+	c := NewCollector()
+	run(t, c, context.Background(), nil)
+	run(t, c, context.Background(), errors.New("connection refused"))
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	run(t, c, canceled, context.Canceled)
+
+	stats := c.take()["kagent/tools"]
+	if stats == nil || stats.Calls != 2 || stats.Errors != 1 {
+		t.Fatalf("stats = %+v, want 2 calls with 1 error", stats)
+	}
+	if stats.LatencyBuckets[0] != 2 {
+		t.Errorf("latency buckets = %v, want both calls in the first bucket", stats.LatencyBuckets)
+	}
+	if len(c.take()) != 0 {
+		t.Error("take did not reset the stats")
+	}
+}
+
+func TestBucket(t *testing.T) {
+	if got := bucket(10 * time.Millisecond); got != 0 {
+		t.Errorf("bucket(10ms) = %d, want 0", got)
+	}
+	if got := bucket(11 * time.Millisecond); got != 1 {
+		t.Errorf("bucket(11ms) = %d, want 1", got)
+	}
+	if got := bucket(time.Hour); got != len(adk.ToolLatencyBucketsMs) {
+		t.Errorf("bucket(1h) = %d, want the overflow bucket", got)
+	}
+}
+
+func TestReport(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		reports  = map[string]adk.ToolCallStatsReport{}
+		failures = 1
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.URL.Path == "/api/toolservers/kagent/flaky/stats" && failures > 0 {
+			failures--
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if r.URL.Path == "/api/toolservers/kagent/gone/stats" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var report adk.ToolCallStatsReport
+		if err := json.NewDecoder(r.Body).Decode(&report); err != nil {
+			t.Errorf("failed to decode report: %v", err)
+		}
+		reports[r.URL.Path] = report
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	c := NewCollector()
+	c.Record("kagent/tools", time.Millisecond, false)
+	c.Record("kagent/flaky", time.Millisecond, true)
+	c.Record("kagent/gone", time.Millisecond, false)
+	r := NewReporter(c, srv.URL, srv.Client(), "kagent__NS__k8s_agent", logr.Discard())
+
+	r.Report(context.Background())
+	if got := reports["/api/toolservers/kagent/tools/stats"]; got.AgentID != "kagent__NS__k8s_agent" || got.Calls != 1 {
+		t.Errorf("report = %+v, want 1 call of kagent__NS__k8s_agent", got)
+	}
+	pending := c.take()
+	if pending["kagent/flaky"] == nil || pending["kagent/gone"] != nil {
+		t.Fatalf("pending = %v, want only the stats the controller failed to store", pending)
+	}
+
+	c.restore("kagent/flaky", pending["kagent/flaky"])
+	c.Record("kagent/flaky", time.Millisecond, false)
+	r.Report(context.Background())
+	if got := reports["/api/toolservers/kagent/flaky/stats"]; got.Calls != 2 || got.Errors != 1 {
+		t.Errorf("report = %+v, want the failed report retried with the new call", got)
+	}
+}
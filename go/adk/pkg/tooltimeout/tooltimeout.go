@@ -0,0 +1,72 @@
+// Package tooltimeout bounds how long MCP tool calls may take. A call that
+// runs out of time is cancelled and fails with an error telling the model it
+// timed out, so a hung tool server does not stall the agent until the whole
+// task gives up.
+package tooltimeout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kagent-dev/kagent/go/adk/pkg/toolwrap"
+	"github.com/kagent-dev/kagent/go/api/adk"
+	"google.golang.org/adk/v2/agent"
+	"google.golang.org/adk/v2/tool"
+)
+
+// Limiter applies the configured timeouts to tool calls.
+type Limiter struct {
+	cfg *adk.TimeoutsConfig
+	log logr.Logger
+}
+
+// New builds a Limiter from the agent's timeouts.
+func New(cfg *adk.TimeoutsConfig, log logr.Logger) *Limiter {
+	return &Limiter{cfg: cfg, log: log.WithName("tool-timeout")}
+}
+
+// Enabled reports whether any tool call is bounded.
+func (l *Limiter) Enabled() bool {
+	return l.cfg != nil && (l.cfg.ToolCall != nil || len(l.cfg.Tools) > 0)
+}
+
+// Wrap returns a toolset whose tool calls are bounded by their timeout.
+func (l *Limiter) Wrap(ts tool.Toolset) tool.Toolset {
+	return toolwrap.Toolset(ts, func(t toolwrap.Tool) toolwrap.RunFunc {
+		timeout := l.cfg.ToolTimeout(t.Name())
+		if timeout <= 0 {
+			return nil
+		}
+		return func(ctx agent.Context, inner toolwrap.Tool, args any) (map[string]any, error) {
+			return l.run(ctx, inner, timeout, args)
+		}
+	})
+}
+
+func (l *Limiter) run(ctx agent.Context, inner toolwrap.Tool, timeout time.Duration, args any) (map[string]any, error) {
+	callCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	result, err := inner.Run(&boundedContext{Context: ctx, bound: callCtx}, args)
+	// Only report the timeout when it was this call's own deadline that
+	// expired, not the invocation's.
+	if ctx.Err() == nil && errors.Is(callCtx.Err(), context.DeadlineExceeded) {
+		l.log.Info("Tool call timed out", "tool", inner.Name(), "timeout", timeout, "functionCallID", ctx.FunctionCallID())
+		return nil, fmt.Errorf("tool %s timed out after %s; retry it with narrower arguments or continue without its result", inner.Name(), timeout)
+	}
+	return result, err
+}
+
+// boundedContext is an agent.Context whose cancellation and deadline are
+// those of bound, which derives from it.
+type boundedContext struct {
+	agent.Context
+	bound context.Context
+}
+
+func (c *boundedContext) Deadline() (time.Time, bool) { return c.bound.Deadline() }
+func (c *boundedContext) Done() <-chan struct{}       { return c.bound.Done() }
+func (c *boundedContext) Err() error                  { return c.bound.Err() }
+func (c *boundedContext) Value(key any) any           { return c.bound.Value(key) }
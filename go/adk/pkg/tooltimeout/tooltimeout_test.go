@@ -0,0 +1,121 @@
+package tooltimeout
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kagent-dev/kagent/go/adk/pkg/toolwrap"
+	"github.com/kagent-dev/kagent/go/api/adk"
+	"google.golang.org/adk/v2/agent"
+	"google.golang.org/adk/v2/model"
+	"google.golang.org/adk/v2/tool"
+	"google.golang.org/genai"
+)
+
+// fakeContext is the part of agent.Context a tool call uses.
+type fakeContext struct {
+	agent.Context
+	ctx context.Context
+}
+
+func (c *fakeContext) Deadline() (time.Time, bool) { return c.ctx.Deadline() }
+func (c *fakeContext) Done() <-chan struct{}       { return c.ctx.Done() }
+func (c *fakeContext) Err() error                  { return c.ctx.Err() }
+func (c *fakeContext) Value(key any) any           { return c.ctx.Value(key) }
+func (c *fakeContext) FunctionCallID() string      { return "call-1" }
+
+// slowTool returns after delay, or when its call is cancelled.
+type slowTool struct {
+	name  string
+	delay time.Duration
+}
+
+func (t *slowTool) Name() string        { return t.name }
+func (t *slowTool) Description() string { return "" }
+func (t *slowTool) IsLongRunning() bool { return false }
+func (t *slowTool) Declaration() *genai.FunctionDeclaration {
+	return &genai.FunctionDeclaration{Name: t.name}
+}
+func (t *slowTool) ProcessRequest(agent.Context, *model.LLMRequest) error { return nil }
+func (t *slowTool) Run(ctx agent.Context, _ any) (map[string]any, error) {
+	select {
+	case <-time.After(t.delay):
+		return map[string]any{"output": "done"}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+type fakeToolset struct {
+	tools []tool.Tool
+}
+
+func (f *fakeToolset) Name() string { return "fake" }
+
+func (f *fakeToolset) Tools(agent.ReadonlyContext) ([]tool.Tool, error) {
+	return f.tools, nil
+}
+
+func TestWrap(t *testing.T) {
+	limiter := New(&adk.TimeoutsConfig{
+		ToolCall: new(0.01),
+		Tools:    map[string]float64{"helm_upgrade": 60},
+	}, logr.Discard())
+	if !limiter.Enabled() {
+		t.Fatal("expected the limiter to be enabled")
+	}
+	ts := limiter.Wrap(&fakeToolset{tools: []tool.Tool{
+		&slowTool{name: "k8s_get_resources", delay: time.Minute},
+		&slowTool{name: "helm_upgrade", delay: 20 * time.Millisecond},
+	}})
+	tools, err := ts.Tools(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := &fakeContext{ctx: context.Background()}
+
+	_, err = tools[0].(toolwrap.Tool).Run(ctx, nil)
+	if err == nil || !strings.Contains(err.Error(), "tool k8s_get_resources timed out after 10ms") {
+		t.Fatalf("expected a timeout error, got %v", err)
+	}
+
+	result, err := tools[1].(toolwrap.Tool).Run(ctx, nil)
+	if err != nil {
+		t.Fatalf("expected the overridden timeout to allow the call, got %v", err)
+	}
+	if result["output"] != "done" {
+		t.Fatalf("unexpected result %v", result)
+	}
+}
+
+func TestWrapLeavesUnboundedToolsAlone(t *testing.T) {
+	limiter := New(&adk.TimeoutsConfig{Tools: map[string]float64{"helm_upgrade": 60}}, logr.Discard())
+	inner := &slowTool{name: "k8s_get_resources"}
+	tools, err := limiter.Wrap(&fakeToolset{tools: []tool.Tool{inner}}).Tools(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tools[0] != inner {
+		t.Fatalf("expected the tool without a timeout to be returned as is, got %T", tools[0])
+	}
+	if New(&adk.TimeoutsConfig{LLMRequest: new(30.0)}, logr.Discard()).Enabled() {
+		t.Fatal("expected the limiter to be disabled without tool timeouts")
+	}
+}
+
+func TestRunReportsInvocationCancellation(t *testing.T) {
+	limiter := New(&adk.TimeoutsConfig{ToolCall: new(60.0)}, logr.Discard())
+	tools, err := limiter.Wrap(&fakeToolset{tools: []tool.Tool{&slowTool{name: "k8s_get_resources", delay: time.Minute}}}).Tools(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = tools[0].(toolwrap.Tool).Run(&fakeContext{ctx: cancelled}, nil)
+	if err != context.Canceled {
+		t.Fatalf("expected the invocation's cancellation, got %v", err)
+	}
+}
@@ -0,0 +1,82 @@
+// Package toolwrap wraps the function tools of a toolset so their calls run
+// through a wrapper, which the ADK flow then runs in place of the tool. The
+// tool result limits, output schema validation, call timeouts and call stats
+// are all built on it.
+package toolwrap
+
+import (
+	"google.golang.org/adk/v2/agent"
+	"google.golang.org/adk/v2/model"
+	"google.golang.org/adk/v2/tool"
+	"google.golang.org/genai"
+)
+
+// Tool is the method set the ADK flow uses to declare and run a function
+// tool.
+type Tool interface {
+	tool.Tool
+	Declaration() *genai.FunctionDeclaration
+	ProcessRequest(ctx agent.Context, req *model.LLMRequest) error
+	Run(ctx agent.Context, args any) (map[string]any, error)
+}
+
+// RunFunc runs a call to the wrapped tool inner.
+type RunFunc func(ctx agent.Context, inner Tool, args any) (map[string]any, error)
+
+// Wrap returns a tool declared as inner whose calls run through run.
+func Wrap(inner Tool, run RunFunc) Tool {
+	return &wrappedTool{Tool: inner, run: run}
+}
+
+// Toolset returns a toolset whose function tools are wrapped with the RunFunc
+// wrap returns for them. Tools for which wrap returns nil, and tools that are
+// not function tools, are returned as is.
+func Toolset(ts tool.Toolset, wrap func(Tool) RunFunc) tool.Toolset {
+	return &wrappedToolset{inner: ts, wrap: wrap}
+}
+
+type wrappedToolset struct {
+	inner tool.Toolset
+	wrap  func(Tool) RunFunc
+}
+
+func (t *wrappedToolset) Name() string {
+	return t.inner.Name()
+}
+
+func (t *wrappedToolset) Tools(ctx agent.ReadonlyContext) ([]tool.Tool, error) {
+	tools, err := t.inner.Tools(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]tool.Tool, 0, len(tools))
+	for _, inner := range tools {
+		if ft, ok := inner.(Tool); ok {
+			if run := t.wrap(ft); run != nil {
+				out = append(out, Wrap(ft, run))
+				continue
+			}
+		}
+		out = append(out, inner)
+	}
+	return out, nil
+}
+
+type wrappedTool struct {
+	Tool
+	run RunFunc
+}
+
+// ProcessRequest declares the tool through the inner tool, then registers the
+// wrapper under its name so the flow runs the wrapper.
+func (t *wrappedTool) ProcessRequest(ctx agent.Context, req *model.LLMRequest) error {
+	if err := t.Tool.ProcessRequest(ctx, req); err != nil {
+		return err
+	}
+	req.Tools[t.Name()] = t
+	return nil
+}
+
+func (t *wrappedTool) Run(ctx agent.Context, args any) (map[string]any, error) {
+	return t.run(ctx, t.Tool, args)
+}
@@ -0,0 +1,75 @@
+package toolwrap
+
+import (
+	"testing"
+
+	"google.golang.org/adk/v2/agent"
+	"google.golang.org/adk/v2/model"
+	"google.golang.org/adk/v2/tool"
+	"google.golang.org/genai"
+)
+
+// fakeTool declares itself under its name and returns its name as output.
+type fakeTool struct {
+	name string
+}
+
+func (t *fakeTool) Name() string        { return t.name }
+func (t *fakeTool) Description() string { return "" }
+func (t *fakeTool) IsLongRunning() bool { return false }
+func (t *fakeTool) Declaration() *genai.FunctionDeclaration {
+	return &genai.FunctionDeclaration{Name: t.name}
+}
+func (t *fakeTool) ProcessRequest(_ agent.Context, req *model.LLMRequest) error {
+	req.Tools[t.name] = t
+	return nil
+}
+func (t *fakeTool) Run(agent.Context, any) (map[string]any, error) {
+	return map[string]any{"output": t.name}, nil
+}
+
+type fakeToolset struct {
+	tools []tool.Tool
+}
+
+func (f *fakeToolset) Name() string { return "fake" }
+
+func (f *fakeToolset) Tools(agent.ReadonlyContext) ([]tool.Tool, error) {
+	return f.tools, nil
+}
+
+func TestToolsetWrapsSelectedTools(t *testing.T) {
+	wrapped, skipped := &fakeTool{name: "k8s_get_resources"}, &fakeTool{name: "helm_list"}
+	ts := Toolset(&fakeToolset{tools: []tool.Tool{wrapped, skipped}}, func(t Tool) RunFunc {
+		if t.Name() != wrapped.name {
+			return nil
+		}
+		return func(ctx agent.Context, inner Tool, args any) (map[string]any, error) {
+			result, err := inner.Run(ctx, args)
+			result["wrapped"] = true
+			return result, err
+		}
+	})
+	tools, err := ts.Tools(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tools[1] != skipped {
+		t.Fatalf("expected the tool without a RunFunc to be returned as is, got %T", tools[1])
+	}
+
+	req := &model.LLMRequest{Tools: map[string]any{}}
+	if err := tools[0].(Tool).ProcessRequest(nil, req); err != nil {
+		t.Fatal(err)
+	}
+	if req.Tools[wrapped.name] != tools[0] {
+		t.Fatalf("expected the wrapper to be registered in place of the tool, got %T", req.Tools[wrapped.name])
+	}
+	result, err := tools[0].(Tool).Run(nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result["output"] != wrapped.name || result["wrapped"] != true {
+		t.Fatalf("expected the call to run through the wrapper, got %v", result)
+	}
+}
@@ -5,6 +5,9 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"fmt"
+	"path"
+	"slices"
+	"time"
 )
 
 type StreamableHTTPConnectionParams struct {
@@ -17,6 +20,18 @@ type StreamableHTTPConnectionParams struct {
 	TLSInsecureSkipVerify *bool   `json:"tls_insecure_skip_verify,omitempty"`
 	TLSCACertPath         *string `json:"tls_ca_cert_path,omitempty"`
 	TLSDisableSystemCAs   *bool   `json:"tls_disable_system_cas,omitempty"`
+	// OAuth2 authenticates requests with a client credentials access token
+	OAuth2 *OAuth2ClientCredentialsConfig `json:"oauth2,omitempty"`
+}
+
+// OAuth2ClientCredentialsConfig configures the OAuth2 client credentials
+// grant used to authenticate to an MCP server.
+type OAuth2ClientCredentialsConfig struct {
+	TokenURL     string   `json:"token_url"`
+	ClientID     string   `json:"client_id"`
+	ClientSecret string   `json:"client_secret"`
+	Scopes       []string `json:"scopes,omitempty"`
+	Audience     string   `json:"audience,omitempty"`
 }
 
 type HttpMcpServerConfig struct {
@@ -24,6 +39,9 @@ type HttpMcpServerConfig struct {
 	Tools           []string                       `json:"tools,omitempty"`
 	AllowedHeaders  []string                       `json:"allowed_headers,omitempty"`
 	RequireApproval []string                       `json:"require_approval,omitempty"`
+	// ServerRef is the namespace/name of the tool server the tools come
+	// from, which the go runtime reports tool call stats for.
+	ServerRef string `json:"server_ref,omitempty"`
 }
 
 type SseConnectionParams struct {
@@ -35,6 +53,8 @@ type SseConnectionParams struct {
 	TLSInsecureSkipVerify *bool   `json:"tls_insecure_skip_verify,omitempty"`
 	TLSCACertPath         *string `json:"tls_ca_cert_path,omitempty"`
 	TLSDisableSystemCAs   *bool   `json:"tls_disable_system_cas,omitempty"`
+	// OAuth2 authenticates requests with a client credentials access token
+	OAuth2 *OAuth2ClientCredentialsConfig `json:"oauth2,omitempty"`
 }
 
 type SseMcpServerConfig struct {
@@ -42,6 +62,9 @@ type SseMcpServerConfig struct {
 	Tools           []string            `json:"tools,omitempty"`
 	AllowedHeaders  []string            `json:"allowed_headers,omitempty"`
 	RequireApproval []string            `json:"require_approval,omitempty"`
+	// ServerRef is the namespace/name of the tool server the tools come
+	// from, which the go runtime reports tool call stats for.
+	ServerRef string `json:"server_ref,omitempty"`
 }
 
 type Model interface {
@@ -153,6 +176,13 @@ type Anthropic struct {
 	TopP        *float64 `json:"top_p,omitempty"`
 	TopK        *int     `json:"top_k,omitempty"`
 	Timeout     *int     `json:"timeout,omitempty"`
+	// CacheSystemInstruction and CacheTools add cache_control breakpoints to
+	// the system prompt and the last tool definition. See the
+	// v1alpha2.AnthropicConfig CRD doc for context.
+	CacheSystemInstruction bool `json:"cache_system_instruction,omitempty"`
+	CacheTools             bool `json:"cache_tools,omitempty"`
+	// CacheTTL selects the cache retention window: "5m" (default) or "1h".
+	CacheTTL string `json:"cache_ttl,omitempty"`
 }
 
 func (a *Anthropic) MarshalJSON() ([]byte, error) {
@@ -170,8 +200,31 @@ func (a *Anthropic) GetType() string {
 	return ModelTypeAnthropic
 }
 
+// GeminiOptions are the generation, function-calling and safety options of
+// the Gemini and GeminiVertexAI model types.
+type GeminiOptions struct {
+	Temperature      *float64 `json:"temperature,omitempty"`
+	TopP             *float64 `json:"top_p,omitempty"`
+	TopK             *float64 `json:"top_k,omitempty"`
+	MaxOutputTokens  *int     `json:"max_output_tokens,omitempty"`
+	CandidateCount   *int     `json:"candidate_count,omitempty"`
+	StopSequences    []string `json:"stop_sequences,omitempty"`
+	ResponseMimeType string   `json:"response_mime_type,omitempty"`
+	// ToolChoice is auto, any, none or validated.
+	ToolChoice           string                `json:"tool_choice,omitempty"`
+	AllowedFunctionNames []string              `json:"allowed_function_names,omitempty"`
+	ParallelToolCalls    *bool                 `json:"parallel_tool_calls,omitempty"`
+	SafetySettings       []GeminiSafetySetting `json:"safety_settings,omitempty"`
+}
+
+type GeminiSafetySetting struct {
+	Category  string `json:"category"`
+	Threshold string `json:"threshold"`
+}
+
 type GeminiVertexAI struct {
 	BaseModel
+	GeminiOptions
 }
 
 func (g *GeminiVertexAI) MarshalJSON() ([]byte, error) {
@@ -230,6 +283,7 @@ func (o *Ollama) GetType() string {
 
 type Gemini struct {
 	BaseModel
+	GeminiOptions
 }
 
 func (g *Gemini) MarshalJSON() ([]byte, error) {
@@ -251,6 +305,8 @@ type Bedrock struct {
 	BaseModel
 	// Region is the AWS region where the model is available
 	Region string `json:"region,omitempty"`
+	// Endpoint overrides the Bedrock runtime endpoint derived from Region
+	Endpoint string `json:"endpoint,omitempty"`
 	// AdditionalModelRequestFields passes model-specific parameters to Bedrock's
 	// additionalModelRequestFields in the Converse API. Use this for provider-specific
 	// options outside the standard InferenceConfiguration block.
@@ -382,6 +438,23 @@ type RemoteAgentConfig struct {
 	Description string            `json:"description,omitempty"`
 }
 
+// WorkflowPattern is how a workflow agent runs its remote agents.
+type WorkflowPattern string
+
+const (
+	WorkflowPatternSequential WorkflowPattern = "sequential"
+	WorkflowPatternParallel   WorkflowPattern = "parallel"
+	WorkflowPatternRouter     WorkflowPattern = "router"
+)
+
+// WorkflowConfig makes the agent an orchestrator of its RemoteAgents. With
+// the sequential and parallel patterns the model and tools are unused; with
+// the router pattern the model delegates each request to one remote agent.
+// Only the go runtime supports it.
+type WorkflowConfig struct {
+	Pattern WorkflowPattern `json:"pattern"`
+}
+
 // EmbeddingConfig is the embedding model config for memory tools.
 // JSON uses "provider" to match Python EmbeddingConfig; unmarshaling accepts "type" for backward compat.
 type EmbeddingConfig struct {
@@ -449,6 +522,15 @@ func ModelToEmbeddingConfig(m Model) *EmbeddingConfig {
 type MemoryConfig struct {
 	TTLDays   int              `json:"ttl_days,omitempty"`
 	Embedding *EmbeddingConfig `json:"embedding,omitempty"`
+	// Qdrant stores memories in a Qdrant collection instead of the kagent
+	// database. The API key, if any, is read from QDRANT_API_KEY.
+	Qdrant *QdrantMemoryConfig `json:"qdrant,omitempty"`
+}
+
+// QdrantMemoryConfig is the Qdrant collection an agent keeps its memories in.
+type QdrantMemoryConfig struct {
+	URL        string `json:"url"`
+	Collection string `json:"collection"`
 }
 
 type NetworkConfig struct {
@@ -512,6 +594,371 @@ type AgentConfig struct {
 	ContextConfig *AgentContextConfig   `json:"context_config,omitempty"`
 	ShareTools    *bool                 `json:"share_tools,omitempty"`
 	SessionDBURL  string                `json:"session_db_url,omitempty"`
+	Streaming     *StreamingConfig      `json:"streaming,omitempty"`
+	PromptCapture *PromptCaptureConfig  `json:"prompt_capture,omitempty"`
+	// LLMTrace records sampled model calls with their session for debugging.
+	LLMTrace *LLMTraceConfig `json:"llm_trace,omitempty"`
+	// PromptInjection enables scanning of tool results for prompt-injection attempts.
+	PromptInjection *PromptInjectionConfig `json:"prompt_injection,omitempty"`
+	// ToolPolicy filters the MCP tools exposed to the model by name.
+	ToolPolicy *ToolPolicy `json:"tool_policy,omitempty"`
+	// ModelFallbacks are tried in order when Model fails with a retryable error.
+	ModelFallbacks []ModelFallback `json:"model_fallbacks,omitempty"`
+	// ToolResultLimit caps the size of MCP tool results added to the conversation.
+	ToolResultLimit *ToolResultLimitConfig `json:"tool_result_limit,omitempty"`
+	// ToolResultValidation checks MCP tool results against their tool's output schema.
+	ToolResultValidation *ToolResultValidationConfig `json:"tool_result_validation,omitempty"`
+	// DryRunTools are glob patterns of the tools a dry-run invocation previews
+	// instead of running. Empty means DefaultDryRunTools.
+	DryRunTools []string `json:"dry_run_tools,omitempty"`
+	// Workflow runs RemoteAgents as a workflow instead of as tools.
+	Workflow *WorkflowConfig `json:"workflow,omitempty"`
+	// Timeouts bounds model requests, tool calls and tasks.
+	Timeouts *TimeoutsConfig `json:"timeouts,omitempty"`
+	// Moderation checks model responses, and optionally user messages,
+	// against content rules.
+	Moderation *ModerationConfig `json:"moderation,omitempty"`
+	// Budget bounds the tokens, tool calls and model turns of each task.
+	Budget *BudgetConfig `json:"budget,omitempty"`
+}
+
+// RestartSettings returns the part of the config the go runtime only reads
+// at startup. The go runtime reloads every other setting, such as the
+// instruction, model and tools, from its mounted config without a restart.
+func (a *AgentConfig) RestartSettings() AgentConfig {
+	return AgentConfig{
+		ExecuteCode:  a.ExecuteCode,
+		Stream:       a.Stream,
+		Memory:       a.Memory,
+		Network:      a.Network,
+		SessionDBURL: a.SessionDBURL,
+		Streaming:    a.Streaming,
+	}
+}
+
+// DefaultDryRunTools are the mutating tools of the kagent tool server.
+// See `python/packages/kagent-adk/src/kagent/adk/_dry_run.py` for the python version.
+var DefaultDryRunTools = []string{
+	"k8s_apply_manifest",
+	"k8s_create_resource*",
+	"k8s_patch_resource",
+	"k8s_delete_resource",
+	"k8s_scale*",
+	"k8s_rollout*",
+	"k8s_annotate_resource",
+	"k8s_label_resource",
+	"k8s_remove_annotation",
+	"k8s_remove_label",
+	"k8s_execute_command",
+	"helm_install",
+	"helm_upgrade",
+	"helm_uninstall",
+	"istio_install_istio",
+	"istio_apply_waypoint",
+	"istio_delete_waypoint",
+	"cilium_install_cilium",
+	"cilium_upgrade_cilium",
+}
+
+// DryRunToolPatterns returns the configured dry-run tool patterns, or
+// DefaultDryRunTools when none are configured.
+func (a *AgentConfig) DryRunToolPatterns() []string {
+	if len(a.DryRunTools) > 0 {
+		return a.DryRunTools
+	}
+	return DefaultDryRunTools
+}
+
+// Model error classes that trigger failover to a fallback model.
+const (
+	ModelFallbackOnServerError = "server_error"
+	ModelFallbackOnRateLimited = "rate_limited"
+	ModelFallbackOnTimeout     = "timeout"
+)
+
+// ModelFallback is a model to fail over to. Triggers lists the error classes
+// that fail over to it; empty means all of them.
+// See `python/packages/kagent-adk/src/kagent/adk/_model_fallback.py` for the python version.
+type ModelFallback struct {
+	Model    Model    `json:"model"`
+	Triggers []string `json:"triggers,omitempty"`
+}
+
+// AppliesTo reports whether an error of the given class fails over to f.
+func (f *ModelFallback) AppliesTo(class string) bool {
+	return len(f.Triggers) == 0 || slices.Contains(f.Triggers, class)
+}
+
+func (f *ModelFallback) UnmarshalJSON(data []byte) error {
+	var tmp struct {
+		Model    json.RawMessage `json:"model"`
+		Triggers []string        `json:"triggers,omitempty"`
+	}
+	if err := json.Unmarshal(data, &tmp); err != nil {
+		return err
+	}
+	model, err := ParseModel(tmp.Model)
+	if err != nil {
+		return err
+	}
+	f.Model = model
+	f.Triggers = tmp.Triggers
+	return nil
+}
+
+// PromptCaptureConfig enables writing sampled, redacted model prompt/response
+// pairs as JSONL under Path.
+type PromptCaptureConfig struct {
+	Path           string   `json:"path"`
+	SampleRate     float64  `json:"sample_rate"`
+	MaxFileBytes   int64    `json:"max_file_bytes"`
+	MaxTotalBytes  int64    `json:"max_total_bytes"`
+	RedactPatterns []string `json:"redact_patterns,omitempty"`
+}
+
+// LLMTraceConfig records the redacted request and response of a sampled
+// fraction of model calls with their session, through the Kagent API.
+type LLMTraceConfig struct {
+	SampleRate     float64  `json:"sample_rate"`
+	RedactPatterns []string `json:"redact_patterns,omitempty"`
+}
+
+// LLMTraceSampleRateStateKey is the session state key that overrides the
+// agent's LLM trace sample rate for one session. Its value is a number
+// between 0 and 1, or a boolean for all or none.
+const LLMTraceSampleRateStateKey = "kagent:llm_trace_sample_rate"
+
+// ToolLatencyBucketsMs are the upper bounds, in milliseconds, of the latency
+// buckets of ToolCallStatsReport.
+var ToolLatencyBucketsMs = []int64{10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000, 30000, 60000}
+
+// ToolCallStatsReport is what an agent reports about the calls it made to one
+// tool server since its previous report.
+type ToolCallStatsReport struct {
+	AgentID string `json:"agent_id"`
+	Calls   int64  `json:"calls"`
+	Errors  int64  `json:"errors"`
+	// LatencyBuckets counts the calls per ToolLatencyBucketsMs bucket, with
+	// one more bucket for calls slower than every bound.
+	LatencyBuckets []int64 `json:"latency_buckets"`
+}
+
+// Prompt-injection actions understood by both runtimes.
+const (
+	PromptInjectionActionFlag   = "flag"
+	PromptInjectionActionRedact = "redact"
+	PromptInjectionActionBlock  = "block"
+)
+
+// PromptInjectionConfig configures scanning of tool results for
+// prompt-injection attempts before they are sent to the model.
+type PromptInjectionConfig struct {
+	Action       string   `json:"action"`
+	Patterns     []string `json:"patterns,omitempty"`
+	ExcludeTools []string `json:"exclude_tools,omitempty"`
+}
+
+// Moderation actions understood by both runtimes.
+const (
+	ModerationActionBlock  = "block"
+	ModerationActionRedact = "redact"
+	ModerationActionFlag   = "flag"
+)
+
+// Moderation providers understood by both runtimes.
+const (
+	ModerationProviderOpenAI = "openai"
+	ModerationProviderHTTP   = "http"
+)
+
+// ModerationConfig configures moderation of model responses and, with
+// CheckInput, of user messages before they are sent to the model.
+// See `python/packages/kagent-adk/src/kagent/adk/_moderation.py` for the python version.
+type ModerationConfig struct {
+	Action     string                    `json:"action"`
+	Keywords   []string                  `json:"keywords,omitempty"`
+	Patterns   []string                  `json:"patterns,omitempty"`
+	Provider   *ModerationProviderConfig `json:"provider,omitempty"`
+	CheckInput bool                      `json:"check_input,omitempty"`
+}
+
+// ModerationProviderConfig is a moderation service. Its API key, if any, is
+// read from the KAGENT_MODERATION_API_KEY environment variable.
+type ModerationProviderConfig struct {
+	Type     string `json:"type"`
+	URL      string `json:"url,omitempty"`
+	Model    string `json:"model,omitempty"`
+	FailOpen bool   `json:"fail_open,omitempty"`
+}
+
+// Tool result truncation strategies understood by both runtimes.
+const (
+	ToolResultStrategyHead      = "head"
+	ToolResultStrategyTail      = "tail"
+	ToolResultStrategySummarize = "summarize"
+	ToolResultStrategyPaginate  = "paginate"
+)
+
+// ToolResultLimitConfig bounds the size of each MCP tool result. MaxBytes is
+// the resolved limit: the controller folds a token limit into it.
+// See `python/packages/kagent-adk/src/kagent/adk/_tool_result_limit.py` for the python version.
+type ToolResultLimitConfig struct {
+	MaxBytes     int      `json:"max_bytes"`
+	Strategy     string   `json:"strategy"`
+	ExcludeTools []string `json:"exclude_tools,omitempty"`
+}
+
+// ToolResultValidationConfig checks each MCP tool result against the output
+// schema its tool declares. Coerce converts values that only differ from the
+// schema in their JSON type before validating.
+// See `python/packages/kagent-adk/src/kagent/adk/_tool_result_validation.py` for the python version.
+type ToolResultValidationConfig struct {
+	Coerce       bool     `json:"coerce"`
+	ExcludeTools []string `json:"exclude_tools,omitempty"`
+}
+
+// ToolPolicy is an allowlist and denylist of tool-name glob patterns.
+// See `python/packages/kagent-adk/src/kagent/adk/_tool_policy.py` for the python version.
+type ToolPolicy struct {
+	Allow []string `json:"allow,omitempty"`
+	Deny  []string `json:"deny,omitempty"`
+}
+
+// Allows reports whether the policy permits the named tool: it must match an
+// allow pattern, when any are set, and no deny pattern. Malformed patterns
+// never match.
+func (p *ToolPolicy) Allows(name string) bool {
+	if p == nil {
+		return true
+	}
+	if len(p.Allow) > 0 && !matchesAny(p.Allow, name) {
+		return false
+	}
+	return !matchesAny(p.Deny, name)
+}
+
+// FilterNames returns the names the policy permits, preserving order.
+func (p *ToolPolicy) FilterNames(names []string) []string {
+	out := make([]string, 0, len(names))
+	for _, name := range names {
+		if p.Allows(name) {
+			out = append(out, name)
+		}
+	}
+	return out
+}
+
+func matchesAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// StreamingConfig tunes how the agent's A2A server writes SSE responses.
+// Durations are in seconds; zero or unset keeps the runtime default.
+type StreamingConfig struct {
+	FlushInterval *float64 `json:"flush_interval,omitempty"`
+	WriteTimeout  *float64 `json:"write_timeout,omitempty"`
+	MaxEventSize  *int     `json:"max_event_size,omitempty"`
+}
+
+// TimeoutsConfig bounds the work of the agent. Durations are in seconds;
+// unset keeps the runtime default, which is no timeout.
+type TimeoutsConfig struct {
+	LLMRequest *float64 `json:"llm_request,omitempty"`
+	ToolCall   *float64 `json:"tool_call,omitempty"`
+	// Tools overrides ToolCall by tool name.
+	Tools map[string]float64 `json:"tools,omitempty"`
+	Task  *float64           `json:"task,omitempty"`
+}
+
+// ToolTimeout returns how long a call of the named tool may take, or zero
+// when it is not bounded.
+func (t *TimeoutsConfig) ToolTimeout(name string) time.Duration {
+	if t == nil {
+		return 0
+	}
+	if seconds, ok := t.Tools[name]; ok {
+		return secondsToDuration(seconds)
+	}
+	if t.ToolCall != nil {
+		return secondsToDuration(*t.ToolCall)
+	}
+	return 0
+}
+
+// LLMRequestTimeout returns how long a model request may take, or zero when
+// it is not bounded.
+func (t *TimeoutsConfig) LLMRequestTimeout() time.Duration {
+	if t == nil || t.LLMRequest == nil {
+		return 0
+	}
+	return secondsToDuration(*t.LLMRequest)
+}
+
+// TaskTimeout returns how long a task may take, or zero when it is not
+// bounded.
+func (t *TimeoutsConfig) TaskTimeout() time.Duration {
+	if t == nil || t.Task == nil {
+		return 0
+	}
+	return secondsToDuration(*t.Task)
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// BudgetConfig bounds the work of the agent on a task. Unset limits are not
+// enforced. Invocations can tighten the limits with the budget A2A message
+// metadata key, which holds a BudgetConfig.
+type BudgetConfig struct {
+	// MaxTotalTokens bounds the model tokens, input and output, of a task.
+	MaxTotalTokens *int64 `json:"max_total_tokens,omitempty"`
+	// MaxSessionTokens bounds the model tokens of the whole session,
+	// including the tasks before this one.
+	MaxSessionTokens *int64 `json:"max_session_tokens,omitempty"`
+	MaxToolCalls     *int   `json:"max_tool_calls,omitempty"`
+	// MaxTurns bounds the model requests of a task.
+	MaxTurns *int `json:"max_turns,omitempty"`
+}
+
+// Enabled reports whether b sets any limit.
+func (b *BudgetConfig) Enabled() bool {
+	return b != nil && (b.MaxTotalTokens != nil || b.MaxSessionTokens != nil || b.MaxToolCalls != nil || b.MaxTurns != nil)
+}
+
+// Tighten returns the limits of b bounded by those of o, so an invocation
+// can lower the limits of the agent but not raise them. Either may be nil.
+func (b *BudgetConfig) Tighten(o *BudgetConfig) *BudgetConfig {
+	if b == nil && o == nil {
+		return nil
+	}
+	out := &BudgetConfig{}
+	if b != nil {
+		*out = *b
+	}
+	if o != nil {
+		out.MaxTotalTokens = minLimit(out.MaxTotalTokens, o.MaxTotalTokens)
+		out.MaxSessionTokens = minLimit(out.MaxSessionTokens, o.MaxSessionTokens)
+		out.MaxToolCalls = minLimit(out.MaxToolCalls, o.MaxToolCalls)
+		out.MaxTurns = minLimit(out.MaxTurns, o.MaxTurns)
+	}
+	return out
+}
+
+func minLimit[T int | int64](a, b *T) *T {
+	switch {
+	case a == nil:
+		return b
+	case b == nil:
+		return a
+	default:
+		return new(min(*a, *b))
+	}
 }
 
 // GetStream returns the stream value or default if not set
@@ -532,19 +979,32 @@ func (a *AgentConfig) GetExecuteCode() bool {
 
 func (a *AgentConfig) UnmarshalJSON(data []byte) error {
 	var tmp struct {
-		Model         json.RawMessage       `json:"model"`
-		Description   string                `json:"description"`
-		Instruction   string                `json:"instruction"`
-		HttpTools     []HttpMcpServerConfig `json:"http_tools,omitempty"`
-		SseTools      []SseMcpServerConfig  `json:"sse_tools,omitempty"`
-		RemoteAgents  []RemoteAgentConfig   `json:"remote_agents,omitempty"`
-		ExecuteCode   *bool                 `json:"execute_code,omitempty"`
-		Stream        *bool                 `json:"stream,omitempty"`
-		Memory        json.RawMessage       `json:"memory"`
-		Network       *NetworkConfig        `json:"network,omitempty"`
-		ContextConfig *AgentContextConfig   `json:"context_config,omitempty"`
-		ShareTools    *bool                 `json:"share_tools,omitempty"`
-		SessionDBURL  string                `json:"session_db_url,omitempty"`
+		Model                json.RawMessage             `json:"model"`
+		Description          string                      `json:"description"`
+		Instruction          string                      `json:"instruction"`
+		HttpTools            []HttpMcpServerConfig       `json:"http_tools,omitempty"`
+		SseTools             []SseMcpServerConfig        `json:"sse_tools,omitempty"`
+		RemoteAgents         []RemoteAgentConfig         `json:"remote_agents,omitempty"`
+		ExecuteCode          *bool                       `json:"execute_code,omitempty"`
+		Stream               *bool                       `json:"stream,omitempty"`
+		Memory               json.RawMessage             `json:"memory"`
+		Network              *NetworkConfig              `json:"network,omitempty"`
+		ContextConfig        *AgentContextConfig         `json:"context_config,omitempty"`
+		ShareTools           *bool                       `json:"share_tools,omitempty"`
+		SessionDBURL         string                      `json:"session_db_url,omitempty"`
+		Streaming            *StreamingConfig            `json:"streaming,omitempty"`
+		PromptCapture        *PromptCaptureConfig        `json:"prompt_capture,omitempty"`
+		LLMTrace             *LLMTraceConfig             `json:"llm_trace,omitempty"`
+		PromptInjection      *PromptInjectionConfig      `json:"prompt_injection,omitempty"`
+		ToolPolicy           *ToolPolicy                 `json:"tool_policy,omitempty"`
+		ModelFallbacks       []ModelFallback             `json:"model_fallbacks,omitempty"`
+		ToolResultLimit      *ToolResultLimitConfig      `json:"tool_result_limit,omitempty"`
+		DryRunTools          []string                    `json:"dry_run_tools,omitempty"`
+		ToolResultValidation *ToolResultValidationConfig `json:"tool_result_validation,omitempty"`
+		Workflow             *WorkflowConfig             `json:"workflow,omitempty"`
+		Timeouts             *TimeoutsConfig             `json:"timeouts,omitempty"`
+		Moderation           *ModerationConfig           `json:"moderation,omitempty"`
+		Budget               *BudgetConfig               `json:"budget,omitempty"`
 	}
 	if err := json.Unmarshal(data, &tmp); err != nil {
 		return err
@@ -582,6 +1042,19 @@ func (a *AgentConfig) UnmarshalJSON(data []byte) error {
 	a.ContextConfig = tmp.ContextConfig
 	a.ShareTools = tmp.ShareTools
 	a.SessionDBURL = tmp.SessionDBURL
+	a.Streaming = tmp.Streaming
+	a.PromptCapture = tmp.PromptCapture
+	a.LLMTrace = tmp.LLMTrace
+	a.PromptInjection = tmp.PromptInjection
+	a.ToolPolicy = tmp.ToolPolicy
+	a.ModelFallbacks = tmp.ModelFallbacks
+	a.ToolResultLimit = tmp.ToolResultLimit
+	a.ToolResultValidation = tmp.ToolResultValidation
+	a.DryRunTools = tmp.DryRunTools
+	a.Workflow = tmp.Workflow
+	a.Timeouts = tmp.Timeouts
+	a.Moderation = tmp.Moderation
+	a.Budget = tmp.Budget
 	return nil
 }
 
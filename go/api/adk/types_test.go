@@ -281,6 +281,38 @@ func TestAgentConfig_UnmarshalJSON_Network(t *testing.T) {
 	}
 }
 
+func TestAgentConfig_UnmarshalJSON_Workflow(t *testing.T) {
+	configJSON := `{
+		"model": {"type": "openai", "model": "gpt-4o"},
+		"description": "test agent",
+		"instruction": "you are helpful",
+		"workflow": {"pattern": "parallel"}
+	}`
+
+	var cfg AgentConfig
+	if err := json.Unmarshal([]byte(configJSON), &cfg); err != nil {
+		t.Fatalf("failed to unmarshal config: %v", err)
+	}
+	if cfg.Workflow == nil {
+		t.Fatal("workflow config is nil")
+	}
+	if cfg.Workflow.Pattern != WorkflowPatternParallel {
+		t.Errorf("workflow pattern = %q, want %q", cfg.Workflow.Pattern, WorkflowPatternParallel)
+	}
+
+	data, err := json.Marshal(&cfg)
+	if err != nil {
+		t.Fatalf("failed to marshal config: %v", err)
+	}
+	var roundtrip AgentConfig
+	if err := json.Unmarshal(data, &roundtrip); err != nil {
+		t.Fatalf("failed to unmarshal marshaled config: %v", err)
+	}
+	if roundtrip.Workflow == nil || roundtrip.Workflow.Pattern != WorkflowPatternParallel {
+		t.Errorf("workflow after round trip = %+v, want pattern %q", roundtrip.Workflow, WorkflowPatternParallel)
+	}
+}
+
 func TestParseModel_Roundtrip(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -904,3 +936,74 @@ func TestAgentConfig_ScanAndValue(t *testing.T) {
 		t.Errorf("after Scan: Description = %q, want %q", scanned.Description, "test")
 	}
 }
+
+func TestToolPolicy_Allows(t *testing.T) {
+	policy := &ToolPolicy{
+		Allow: []string{"kubectl_*", "helm_*"},
+		Deny:  []string{"*delete*", "helm_[ur]*"},
+	}
+	tests := []struct {
+		name   string
+		policy *ToolPolicy
+		tool   string
+		want   bool
+	}{
+		{name: "nil policy allows everything", tool: "anything", want: true},
+		{name: "matches allow", policy: policy, tool: "kubectl_get", want: true},
+		{name: "not in allow", policy: policy, tool: "istio_analyze", want: false},
+		{name: "deny wins over allow", policy: policy, tool: "kubectl_delete_pod", want: false},
+		{name: "character class", policy: policy, tool: "helm_uninstall", want: false},
+		{name: "deny only", policy: &ToolPolicy{Deny: []string{"*delete*"}}, tool: "istio_analyze", want: true},
+		{name: "exact name", policy: &ToolPolicy{Allow: []string{"get_pods"}}, tool: "get_pods_logs", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.Allows(tt.tool); got != tt.want {
+				t.Errorf("Allows(%q) = %v, want %v", tt.tool, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAgentConfig_UnmarshalJSON_ModelFallbacks(t *testing.T) {
+	data := []byte(`{
+		"model": {"type":"openai","model":"gpt-4o"},
+		"description": "d",
+		"instruction": "i",
+		"model_fallbacks": [
+			{"model": {"type":"azure_openai","model":"gpt-4o"}, "triggers": ["server_error"]},
+			{"model": {"type":"anthropic","model":"claude-sonnet-4"}}
+		]
+	}`)
+	var cfg AgentConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(cfg.ModelFallbacks) != 2 {
+		t.Fatalf("expected 2 fallbacks, got %d", len(cfg.ModelFallbacks))
+	}
+	if _, ok := cfg.ModelFallbacks[0].Model.(*AzureOpenAI); !ok {
+		t.Errorf("expected *AzureOpenAI, got %T", cfg.ModelFallbacks[0].Model)
+	}
+	if _, ok := cfg.ModelFallbacks[1].Model.(*Anthropic); !ok {
+		t.Errorf("expected *Anthropic, got %T", cfg.ModelFallbacks[1].Model)
+	}
+	if cfg.ModelFallbacks[0].AppliesTo(ModelFallbackOnRateLimited) {
+		t.Error("fallback restricted to server_error must not trigger on rate_limited")
+	}
+	if !cfg.ModelFallbacks[1].AppliesTo(ModelFallbackOnTimeout) {
+		t.Error("fallback without conditions must trigger on every class")
+	}
+
+	roundtrip, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var again AgentConfig
+	if err := json.Unmarshal(roundtrip, &again); err != nil {
+		t.Fatalf("unmarshal roundtrip: %v", err)
+	}
+	if len(again.ModelFallbacks) != 2 || again.ModelFallbacks[1].Model.GetType() != ModelTypeAnthropic {
+		t.Errorf("fallbacks did not round-trip: %s", roundtrip)
+	}
+}
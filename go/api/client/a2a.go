@@ -0,0 +1,470 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kagent-dev/kagent/go/api/adk"
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	a2aclient "trpc.group/trpc-go/trpc-a2a-go/client"
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
+)
+
+// A2AOption configures an A2AClient
+type A2AOption func(*a2aOptions)
+
+type a2aOptions struct {
+	sessionID  string
+	token      string
+	userID     string
+	timeout    time.Duration
+	httpClient *http.Client
+	webSocket  bool
+	dryRun     bool
+	budget     *adk.BudgetConfig
+}
+
+// WithA2ASessionID continues an existing session instead of starting a new one
+func WithA2ASessionID(sessionID string) A2AOption {
+	return func(o *a2aOptions) {
+		o.sessionID = sessionID
+	}
+}
+
+// WithA2ABearerToken sends an Authorization: Bearer header with every request,
+// e.g. for agents using API key passthrough
+func WithA2ABearerToken(token string) A2AOption {
+	return func(o *a2aOptions) {
+		o.token = token
+	}
+}
+
+// WithA2AUserID sets the user the agent runs tasks as
+func WithA2AUserID(userID string) A2AOption {
+	return func(o *a2aOptions) {
+		o.userID = userID
+	}
+}
+
+// WithA2ATimeout bounds each request, including the whole of a stream. The
+// default is no timeout; use the context to bound calls instead.
+func WithA2ATimeout(timeout time.Duration) A2AOption {
+	return func(o *a2aOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithA2AHTTPClient sets the HTTP client used for A2A requests. Its Timeout
+// applies to whole streams, so it should usually be zero.
+func WithA2AHTTPClient(httpClient *http.Client) A2AOption {
+	return func(o *a2aOptions) {
+		o.httpClient = httpClient
+	}
+}
+
+// WithA2ADryRun makes the agent preview mutating tool calls, such as applying
+// a manifest or upgrading a Helm release, instead of running them
+func WithA2ADryRun() A2AOption {
+	return func(o *a2aOptions) {
+		o.dryRun = true
+	}
+}
+
+// WithA2ABudget bounds the tokens, tool calls and model turns of each task.
+// It can only tighten the budget of the agent; a task that reaches it ends
+// with a summary of the work done and budget_exceeded metadata.
+func WithA2ABudget(budget *adk.BudgetConfig) A2AOption {
+	return func(o *a2aOptions) {
+		o.budget = budget
+	}
+}
+
+// A2AEvent is one event of an A2A stream. Exactly one field is set.
+type A2AEvent struct {
+	Message        *protocol.Message
+	Task           *protocol.Task
+	StatusUpdate   *protocol.TaskStatusUpdateEvent
+	ArtifactUpdate *protocol.TaskArtifactUpdateEvent
+}
+
+// Final reports whether the event is the last one of its task
+func (e A2AEvent) Final() bool {
+	return e.StatusUpdate != nil && e.StatusUpdate.Final
+}
+
+// ContextID returns the session the event belongs to
+func (e A2AEvent) ContextID() string {
+	switch {
+	case e.Message != nil && e.Message.ContextID != nil:
+		return *e.Message.ContextID
+	case e.Task != nil:
+		return e.Task.ContextID
+	case e.StatusUpdate != nil:
+		return e.StatusUpdate.ContextID
+	case e.ArtifactUpdate != nil:
+		return e.ArtifactUpdate.ContextID
+	}
+	return ""
+}
+
+// MarshalJSON encodes the event as the A2A object it holds
+func (e A2AEvent) MarshalJSON() ([]byte, error) {
+	switch {
+	case e.Message != nil:
+		return json.Marshal(e.Message)
+	case e.Task != nil:
+		return json.Marshal(e.Task)
+	case e.StatusUpdate != nil:
+		return json.Marshal(e.StatusUpdate)
+	case e.ArtifactUpdate != nil:
+		return json.Marshal(e.ArtifactUpdate)
+	}
+	return []byte("null"), nil
+}
+
+func newA2AEvent(result protocol.StreamingMessageResult) (A2AEvent, bool) {
+	switch r := result.(type) {
+	case *protocol.Message:
+		return A2AEvent{Message: r}, true
+	case *protocol.Task:
+		return A2AEvent{Task: r}, true
+	case *protocol.TaskStatusUpdateEvent:
+		return A2AEvent{StatusUpdate: r}, true
+	case *protocol.TaskArtifactUpdateEvent:
+		return A2AEvent{ArtifactUpdate: r}, true
+	}
+	return A2AEvent{}, false
+}
+
+// A2AClient invokes a single agent over A2A. The first call starts a session
+// and later calls continue it, so a client holds one conversation; use
+// NewSession to start another. An A2AClient is safe for concurrent use.
+type A2AClient struct {
+	URL string
+
+	client  *a2aclient.A2AClient
+	ws      *a2aWebSocket
+	timeout time.Duration
+	dryRun  bool
+	budget  *adk.BudgetConfig
+
+	mu        sync.Mutex
+	sessionID string
+}
+
+// NewA2AClient creates a client for the agent served at url
+func NewA2AClient(url string, options ...A2AOption) (*A2AClient, error) {
+	opts := &a2aOptions{}
+	for _, option := range options {
+		option(opts)
+	}
+
+	httpClient := opts.httpClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: opts.timeout}
+	}
+	if opts.token != "" || opts.userID != "" {
+		base := httpClient.Transport
+		if base == nil {
+			base = http.DefaultTransport
+		}
+		wrapped := *httpClient
+		wrapped.Transport = &a2aHeaderTransport{base: base, token: opts.token, userID: opts.userID}
+		httpClient = &wrapped
+	}
+
+	client, err := a2aclient.NewA2AClient(url, a2aclient.WithHTTPClient(httpClient))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create A2A client: %w", err)
+	}
+	a2aClient := &A2AClient{URL: url, client: client, timeout: opts.timeout, dryRun: opts.dryRun, budget: opts.budget, sessionID: opts.sessionID}
+	if opts.webSocket {
+		a2aClient.ws = newA2AWebSocket(url, opts)
+	}
+	return a2aClient, nil
+}
+
+// A2A creates an A2A client for an agent, given as namespace/name. Requests
+// are sent as the client set's user.
+func (c *ClientSet) A2A(ctx context.Context, agentRef string, options ...A2AOption) (*A2AClient, error) {
+	namespace, name, ok := strings.Cut(agentRef, "/")
+	if !ok || namespace == "" || name == "" {
+		return nil, fmt.Errorf("invalid agent reference %q: expected namespace/name", agentRef)
+	}
+	agent, err := c.Agent.GetAgent(ctx, agentRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get agent %s: %w", agentRef, err)
+	}
+
+	if c.baseClient.UserID != "" {
+		options = append([]A2AOption{WithA2AUserID(c.baseClient.UserID)}, options...)
+	}
+	baseURL := c.baseClient.BaseURL
+	if c.baseClient.A2AURL != "" {
+		baseURL = c.baseClient.A2AURL
+	}
+	return NewA2AClient(AgentA2AURL(baseURL, namespace, name, agent.Data), options...)
+}
+
+// AgentA2AURL returns the URL the kagent server at baseURL serves an agent's
+// A2A endpoint on. agent may be nil for regular (non-sandbox) agents.
+func AgentA2AURL(baseURL, namespace, name string, agent *api.AgentResponse) string {
+	a2aPath := "api/a2a"
+	if agent != nil && agent.WorkloadMode == v1alpha2.WorkloadModeSandbox {
+		a2aPath = "api/a2a-sandboxes"
+	}
+	return fmt.Sprintf("%s/%s/%s/%s", strings.TrimSuffix(baseURL, "/"), a2aPath, namespace, name)
+}
+
+// SessionID returns the session the next call continues, or "" before the
+// first call of a new session
+func (c *A2AClient) SessionID() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.sessionID
+}
+
+// NewSession makes the next call start a new session
+func (c *A2AClient) NewSession() {
+	c.setSessionID("")
+}
+
+func (c *A2AClient) setSessionID(sessionID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sessionID = sessionID
+}
+
+func (c *A2AClient) newMessage(text string, attachments ...api.Attachment) protocol.Message {
+	parts := []protocol.Part{protocol.NewTextPart(text)}
+	for _, a := range attachments {
+		parts = append(parts, protocol.NewFilePartWithBytes(a.Name, a.MediaType, base64.StdEncoding.EncodeToString(a.Data)))
+	}
+	message := protocol.NewMessage(protocol.MessageRoleUser, parts)
+	if sessionID := c.SessionID(); sessionID != "" {
+		message.ContextID = &sessionID
+	}
+	if c.dryRun || c.budget.Enabled() {
+		message.Metadata = map[string]any{}
+	}
+	if c.dryRun {
+		message.Metadata["kagent_dry_run"] = true
+	}
+	if c.budget.Enabled() {
+		message.Metadata["kagent_budget"] = c.budget
+	}
+	return message
+}
+
+// Send sends text and any attached files to the agent and waits for the
+// resulting task
+func (c *A2AClient) Send(ctx context.Context, text string, attachments ...api.Attachment) (*protocol.Task, error) {
+	result, err := c.client.SendMessage(ctx, protocol.SendMessageParams{Message: c.newMessage(text, attachments...)})
+	if err != nil {
+		return nil, err
+	}
+	return c.taskResult(result)
+}
+
+// taskResult returns the task of a message/send result, wrapping a direct
+// message reply in a completed task
+func (c *A2AClient) taskResult(result *protocol.MessageResult) (*protocol.Task, error) {
+	switch r := result.Result.(type) {
+	case *protocol.Task:
+		c.setSessionID(r.ContextID)
+		return r, nil
+	case *protocol.Message:
+		// Agents that answer without creating a task reply with a message.
+		task := &protocol.Task{
+			Kind:   protocol.KindTask,
+			Status: protocol.TaskStatus{State: protocol.TaskStateCompleted, Message: r},
+		}
+		if r.TaskID != nil {
+			task.ID = *r.TaskID
+		}
+		if r.ContextID != nil {
+			task.ContextID = *r.ContextID
+			c.setSessionID(*r.ContextID)
+		}
+		return task, nil
+	}
+	return nil, fmt.Errorf("unexpected A2A result %T", result.Result)
+}
+
+// ToolDecision is a user's decision on the tool calls a task holds for
+// approval
+type ToolDecision struct {
+	// Approve approves every held call, or rejects them all when false
+	Approve bool
+	// Calls decides individual calls by tool call ID and takes precedence
+	// over Approve. The agent approves calls it does not list.
+	Calls map[string]bool
+	// Reason is passed to the agent for rejected calls
+	Reason string
+}
+
+// decisionMessage builds the message that resumes an input-required task with
+// decision, in the form the kagent runtimes and UI use.
+func (d ToolDecision) decisionMessage(taskID, contextID string) protocol.Message {
+	data := map[string]any{}
+	summary := "Approved"
+	switch {
+	case len(d.Calls) > 0:
+		decisions := make(map[string]string, len(d.Calls))
+		reasons := map[string]string{}
+		approved := 0
+		for id, approve := range d.Calls {
+			if approve {
+				decisions[id] = "approve"
+				approved++
+				continue
+			}
+			decisions[id] = "reject"
+			if d.Reason != "" {
+				reasons[id] = d.Reason
+			}
+		}
+		data["decision_type"] = "batch"
+		data["decisions"] = decisions
+		if len(reasons) > 0 {
+			data["rejection_reasons"] = reasons
+		}
+		summary = fmt.Sprintf("Batch decision: %d approved, %d rejected", approved, len(d.Calls)-approved)
+	case d.Approve:
+		data["decision_type"] = "approve"
+	default:
+		data["decision_type"] = "reject"
+		if d.Reason != "" {
+			data["rejection_reason"] = d.Reason
+		}
+		summary = "Rejected"
+	}
+
+	message := protocol.NewMessage(protocol.MessageRoleUser, []protocol.Part{protocol.NewDataPart(data), protocol.NewTextPart(summary)})
+	message.TaskID = &taskID
+	message.ContextID = &contextID
+	return message
+}
+
+// Decide resumes a task waiting for tool approval with decision and waits for
+// the task to finish or to ask for approval again
+func (c *A2AClient) Decide(ctx context.Context, taskID, contextID string, decision ToolDecision) (*protocol.Task, error) {
+	result, err := c.client.SendMessage(ctx, protocol.SendMessageParams{Message: decision.decisionMessage(taskID, contextID)})
+	if err != nil {
+		return nil, err
+	}
+	return c.taskResult(result)
+}
+
+// Stream sends text and any attached files to the agent and returns its
+// events as they arrive. The channel is closed when the task finishes, the
+// stream breaks or ctx is done.
+func (c *A2AClient) Stream(ctx context.Context, text string, attachments ...api.Attachment) (<-chan A2AEvent, error) {
+	return c.streamMessage(ctx, c.newMessage(text, attachments...))
+}
+
+// StreamReply answers a task waiting for user input with text and returns the
+// task's events as they arrive, like Stream
+func (c *A2AClient) StreamReply(ctx context.Context, taskID, contextID, text string) (<-chan A2AEvent, error) {
+	message := c.newMessage(text)
+	message.TaskID = &taskID
+	message.ContextID = &contextID
+	return c.streamMessage(ctx, message)
+}
+
+// StreamDecide resumes a task waiting for tool approval with decision and
+// returns the task's events as they arrive, like Stream
+func (c *A2AClient) StreamDecide(ctx context.Context, taskID, contextID string, decision ToolDecision) (<-chan A2AEvent, error) {
+	return c.streamMessage(ctx, decision.decisionMessage(taskID, contextID))
+}
+
+func (c *A2AClient) streamMessage(ctx context.Context, message protocol.Message) (<-chan A2AEvent, error) {
+	if c.ws != nil {
+		return c.streamWebSocket(ctx, protocol.MethodMessageStream, protocol.SendMessageParams{Message: message})
+	}
+	events, err := c.client.StreamMessage(ctx, protocol.SendMessageParams{Message: message})
+	if err != nil {
+		return nil, err
+	}
+	return c.forward(ctx, events), nil
+}
+
+// Resubscribe reattaches to the event stream of a running task, e.g. after a
+// dropped connection
+func (c *A2AClient) Resubscribe(ctx context.Context, taskID string) (<-chan A2AEvent, error) {
+	if c.ws != nil {
+		return c.streamWebSocket(ctx, protocol.MethodTasksResubscribe, protocol.TaskIDParams{ID: taskID})
+	}
+	events, err := c.client.ResubscribeTask(ctx, protocol.TaskIDParams{ID: taskID})
+	if err != nil {
+		return nil, err
+	}
+	return c.forward(ctx, events), nil
+}
+
+// GetTask returns the current state of a task
+func (c *A2AClient) GetTask(ctx context.Context, taskID string) (*protocol.Task, error) {
+	return c.client.GetTasks(ctx, protocol.TaskQueryParams{ID: taskID})
+}
+
+// Cancel asks the agent to cancel a task and returns the task's state after
+// the request
+func (c *A2AClient) Cancel(ctx context.Context, taskID string) (*protocol.Task, error) {
+	return c.client.CancelTasks(ctx, protocol.TaskIDParams{ID: taskID})
+}
+
+// Close releases the WebSocket connection, ending its streams. It is a no-op
+// for clients without WithA2AWebSocket.
+func (c *A2AClient) Close() error {
+	if c.ws == nil {
+		return nil
+	}
+	return c.ws.Close()
+}
+
+func (c *A2AClient) forward(ctx context.Context, events <-chan protocol.StreamingMessageEvent) <-chan A2AEvent {
+	out := make(chan A2AEvent)
+	go func() {
+		defer close(out)
+		for raw := range events {
+			event, ok := newA2AEvent(raw.Result)
+			if !ok {
+				continue
+			}
+			if contextID := event.ContextID(); contextID != "" {
+				c.setSessionID(contextID)
+			}
+			select {
+			case out <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// a2aHeaderTransport adds the caller's identity to A2A requests
+type a2aHeaderTransport struct {
+	base   http.RoundTripper
+	token  string
+	userID string
+}
+
+func (t *a2aHeaderTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	if t.token != "" {
+		req.Header.Set("Authorization", "Bearer "+t.token)
+	}
+	if t.userID != "" {
+		req.Header.Set("X-User-Id", t.userID)
+	}
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,346 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
+)
+
+const (
+	// a2aWebSocketPath is appended to an agent's A2A URL to reach its
+	// WebSocket endpoint
+	a2aWebSocketPath = "/ws"
+	// a2aWebSocketStreamEnd is the notification the server sends after the
+	// last event of a stream
+	a2aWebSocketStreamEnd = "kagent/streamEnd"
+	// a2aWebSocketResumeAttempts bounds how often a stream cut by a dropped
+	// connection is resumed
+	a2aWebSocketResumeAttempts = 3
+)
+
+// WithA2AWebSocket streams over the agent's WebSocket endpoint instead of
+// SSE, for networks whose proxies buffer or cut event streams. Streams share
+// one connection, and a stream cut by a dropped connection is resumed on a new
+// one by task ID. Other calls still use plain HTTP. Call Close to release the
+// connection.
+func WithA2AWebSocket() A2AOption {
+	return func(o *a2aOptions) {
+		o.webSocket = true
+	}
+}
+
+// a2aWSFrame is a JSON-RPC message on the WebSocket connection
+type a2aWSFrame struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      string          `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *a2aWSError     `json:"error,omitempty"`
+}
+
+type a2aWSError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// a2aWebSocket dials the WebSocket endpoint on demand and keeps one
+// connection for all streams of a client
+type a2aWebSocket struct {
+	url    string
+	header http.Header
+	dialer *websocket.Dialer
+
+	mu     sync.Mutex
+	conn   *a2aWSConn
+	nextID int64
+}
+
+func newA2AWebSocket(agentURL string, opts *a2aOptions) *a2aWebSocket {
+	wsURL := strings.TrimSuffix(agentURL, "/") + a2aWebSocketPath
+	switch {
+	case strings.HasPrefix(wsURL, "https://"):
+		wsURL = "wss://" + strings.TrimPrefix(wsURL, "https://")
+	case strings.HasPrefix(wsURL, "http://"):
+		wsURL = "ws://" + strings.TrimPrefix(wsURL, "http://")
+	}
+	header := http.Header{}
+	if opts.token != "" {
+		header.Set("Authorization", "Bearer "+opts.token)
+	}
+	if opts.userID != "" {
+		header.Set("X-User-Id", opts.userID)
+	}
+	return &a2aWebSocket{
+		url:    wsURL,
+		header: header,
+		dialer: &websocket.Dialer{
+			Proxy:            http.ProxyFromEnvironment,
+			HandshakeTimeout: 45 * time.Second,
+		},
+	}
+}
+
+// start sends a streaming request and returns its frames. The channel ends
+// with the server's end-of-stream notification, or is closed without it when
+// the connection breaks.
+func (w *a2aWebSocket) start(ctx context.Context, method string, params any) (<-chan a2aWSFrame, error) {
+	rawParams, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s params: %w", method, err)
+	}
+
+	conn, id, err := w.connection(ctx)
+	if err != nil {
+		return nil, err
+	}
+	frames := conn.register(ctx, id)
+	request, _ := json.Marshal(a2aWSFrame{JSONRPC: "2.0", ID: id, Method: method, Params: rawParams})
+	if err := conn.write(request); err != nil {
+		// A failed write leaves the connection unusable; closing it ends the
+		// reader, which releases the stream.
+		conn.ws.Close()
+		return nil, fmt.Errorf("failed to send %s: %w", method, err)
+	}
+	return frames, nil
+}
+
+func (w *a2aWebSocket) connection(ctx context.Context) (*a2aWSConn, string, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.nextID++
+	id := strconv.FormatInt(w.nextID, 10)
+	if w.conn != nil && !w.conn.closed() {
+		return w.conn, id, nil
+	}
+	ws, resp, err := w.dialer.DialContext(ctx, w.url, w.header)
+	if resp != nil && resp.Body != nil {
+		resp.Body.Close()
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to connect to %s: %w", w.url, err)
+	}
+	w.conn = newA2AWSConn(ws)
+	return w.conn, id, nil
+}
+
+// Close closes the connection with a normal closure; running streams end.
+func (w *a2aWebSocket) Close() error {
+	w.mu.Lock()
+	conn := w.conn
+	w.conn = nil
+	w.mu.Unlock()
+	if conn == nil {
+		return nil
+	}
+	return conn.close()
+}
+
+type a2aWSStream struct {
+	ctx    context.Context
+	frames chan a2aWSFrame
+}
+
+// a2aWSConn demultiplexes the frames of one connection to its streams
+type a2aWSConn struct {
+	ws      *websocket.Conn
+	writeMu sync.Mutex
+
+	mu      sync.Mutex
+	streams map[string]*a2aWSStream
+	done    chan struct{}
+}
+
+func newA2AWSConn(ws *websocket.Conn) *a2aWSConn {
+	c := &a2aWSConn{ws: ws, streams: map[string]*a2aWSStream{}, done: make(chan struct{})}
+	go c.read()
+	return c
+}
+
+func (c *a2aWSConn) closed() bool {
+	select {
+	case <-c.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (c *a2aWSConn) register(ctx context.Context, id string) <-chan a2aWSFrame {
+	stream := &a2aWSStream{ctx: ctx, frames: make(chan a2aWSFrame, 16)}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.streams[id] = stream
+	return stream.frames
+}
+
+func (c *a2aWSConn) unregister(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if stream, ok := c.streams[id]; ok {
+		delete(c.streams, id)
+		close(stream.frames)
+	}
+}
+
+func (c *a2aWSConn) write(frame []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	return c.ws.WriteMessage(websocket.TextMessage, frame)
+}
+
+// read dispatches frames until the connection ends, then closes every stream
+// still open so its consumer can resume it elsewhere. Pings are answered by
+// the library's default handler while reading.
+func (c *a2aWSConn) read() {
+	defer func() {
+		close(c.done)
+		c.ws.Close()
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		for id, stream := range c.streams {
+			delete(c.streams, id)
+			close(stream.frames)
+		}
+	}()
+	for {
+		_, data, err := c.ws.ReadMessage()
+		if err != nil {
+			return
+		}
+		var frame a2aWSFrame
+		if err := json.Unmarshal(data, &frame); err != nil {
+			continue
+		}
+		id := frame.ID
+		if frame.Method == a2aWebSocketStreamEnd {
+			var params struct {
+				ID string `json:"id"`
+			}
+			if err := json.Unmarshal(frame.Params, &params); err != nil {
+				continue
+			}
+			id = params.ID
+		}
+		c.mu.Lock()
+		stream, ok := c.streams[id]
+		c.mu.Unlock()
+		if !ok {
+			continue
+		}
+		select {
+		case stream.frames <- frame:
+			if frame.Method == a2aWebSocketStreamEnd || frame.Error != nil {
+				c.unregister(id)
+			}
+		case <-stream.ctx.Done():
+			c.unregister(id)
+		}
+	}
+}
+
+func (c *a2aWSConn) close() error {
+	c.writeMu.Lock()
+	err := c.ws.WriteControl(websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(5*time.Second))
+	c.writeMu.Unlock()
+	if err != nil && !errors.Is(err, websocket.ErrCloseSent) {
+		return c.ws.Close()
+	}
+	// Wait for the server to echo the close so it can cancel the streams.
+	select {
+	case <-c.done:
+	case <-time.After(5 * time.Second):
+		return c.ws.Close()
+	}
+	return nil
+}
+
+// streamWebSocket runs a streaming call over the WebSocket connection,
+// resuming it by task ID when the connection drops before the task finishes
+func (c *A2AClient) streamWebSocket(ctx context.Context, method string, params any) (<-chan A2AEvent, error) {
+	streamCtx, cancel := ctx, context.CancelFunc(func() {})
+	if c.timeout > 0 {
+		streamCtx, cancel = context.WithTimeout(ctx, c.timeout)
+	}
+	frames, err := c.ws.start(streamCtx, method, params)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	out := make(chan A2AEvent)
+	go func() {
+		defer close(out)
+		defer cancel()
+		var taskID string
+		for attempt := 0; ; attempt++ {
+			ended := false
+			for frame := range frames {
+				if frame.Method == a2aWebSocketStreamEnd || frame.Error != nil {
+					ended = true
+					continue
+				}
+				var raw protocol.StreamingMessageEvent
+				if err := json.Unmarshal(frame.Result, &raw); err != nil {
+					continue
+				}
+				event, ok := newA2AEvent(raw.Result)
+				if !ok {
+					continue
+				}
+				if id := eventTaskID(event); id != "" {
+					taskID = id
+				}
+				if contextID := event.ContextID(); contextID != "" {
+					c.setSessionID(contextID)
+				}
+				select {
+				case out <- event:
+				case <-streamCtx.Done():
+					return
+				}
+				if event.Final() {
+					ended = true
+				}
+			}
+			if ended || taskID == "" || streamCtx.Err() != nil || attempt >= a2aWebSocketResumeAttempts {
+				return
+			}
+			select {
+			case <-time.After(time.Duration(attempt+1) * time.Second):
+			case <-streamCtx.Done():
+				return
+			}
+			resumed, err := c.ws.start(streamCtx, protocol.MethodTasksResubscribe, protocol.TaskIDParams{ID: taskID})
+			if err != nil {
+				continue
+			}
+			frames = resumed
+		}
+	}()
+	return out, nil
+}
+
+func eventTaskID(e A2AEvent) string {
+	switch {
+	case e.Message != nil && e.Message.TaskID != nil:
+		return *e.Message.TaskID
+	case e.Task != nil:
+		return e.Task.ID
+	case e.StatusUpdate != nil:
+		return e.StatusUpdate.TaskID
+	case e.ArtifactUpdate != nil:
+		return e.ArtifactUpdate.TaskID
+	}
+	return ""
+}
@@ -3,7 +3,6 @@ package client
 import (
 	"context"
 	"fmt"
-	"net/url"
 
 	api "github.com/kagent-dev/kagent/go/api/httpapi"
 	"github.com/kagent-dev/kagent/go/api/v1alpha2"
@@ -16,12 +15,13 @@ type Agent interface {
 	GetAgent(ctx context.Context, agentRef string) (*api.StandardResponse[*api.AgentResponse], error)
 	UpdateAgent(ctx context.Context, request *v1alpha2.Agent) (*api.StandardResponse[*v1alpha2.Agent], error)
 	DeleteAgent(ctx context.Context, agentRef string) error
+	ValidateAgent(ctx context.Context, request *v1alpha2.Agent) (*api.StandardResponse[api.ValidateAgentResponse], error)
+	InvokeAgentAsync(ctx context.Context, agentRef string, request *api.InvokeAsyncRequest) (*api.StandardResponse[api.InvokeAsyncResponse], error)
 }
 
-// ListAgentsOptions configures ListAgents requests.
-type ListAgentsOptions struct {
-	Namespace string
-}
+// ListAgentsOptions configures ListAgents requests. Agents can be sorted by
+// name, namespace or created_at.
+type ListAgentsOptions = ListOptions
 
 // agentClient handles agent-related requests
 type agentClient struct {
@@ -35,8 +35,9 @@ func NewAgentClient(client *BaseClient) Agent {
 
 // ListAgents lists all agents for a user. When Namespace is set, only agents in that namespace are returned.
 func (c *agentClient) ListAgents(ctx context.Context, opts ...ListAgentsOptions) (*api.StandardResponse[[]api.AgentResponse], error) {
-	if len(opts) > 1 {
-		return nil, fmt.Errorf("ListAgents accepts at most one options argument")
+	path, err := withListOptions("ListAgents", "/api/agents", opts)
+	if err != nil {
+		return nil, err
 	}
 
 	userID := c.client.GetUserIDOrDefault("")
@@ -44,11 +45,6 @@ func (c *agentClient) ListAgents(ctx context.Context, opts ...ListAgentsOptions)
 		return nil, fmt.Errorf("userID is required")
 	}
 
-	path := "/api/agents"
-	if len(opts) > 0 && opts[0].Namespace != "" {
-		path += "?namespace=" + url.QueryEscape(opts[0].Namespace)
-	}
-
 	resp, err := c.client.Get(ctx, path, userID)
 	if err != nil {
 		return nil, err
@@ -139,3 +135,40 @@ func (c *agentClient) DeleteAgent(ctx context.Context, agentRef string) error {
 	resp.Body.Close()
 	return nil
 }
+
+// ValidateAgent runs an agent through the server's translation pipeline and
+// lint rules without creating it
+func (c *agentClient) ValidateAgent(ctx context.Context, request *v1alpha2.Agent) (*api.StandardResponse[api.ValidateAgentResponse], error) {
+	resp, err := c.client.Post(ctx, "/api/agents/validate", request, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var response api.StandardResponse[api.ValidateAgentResponse]
+	if err := DecodeResponse(resp, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// InvokeAgentAsync starts a task on an agent and returns without waiting for
+// it to finish
+func (c *agentClient) InvokeAgentAsync(ctx context.Context, agentRef string, request *api.InvokeAsyncRequest) (*api.StandardResponse[api.InvokeAsyncResponse], error) {
+	userID := c.client.GetUserIDOrDefault("")
+	if userID == "" {
+		return nil, fmt.Errorf("userID is required")
+	}
+
+	resp, err := c.client.Post(ctx, fmt.Sprintf("/api/agents/%s/invoke-async", agentRef), request, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var response api.StandardResponse[api.InvokeAsyncResponse]
+	if err := DecodeResponse(resp, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
@@ -0,0 +1,38 @@
+package client
+
+import (
+	"context"
+
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
+)
+
+// Apply defines the bulk create-or-update operations
+type Apply interface {
+	Apply(ctx context.Context, request *api.ApplyRequest) (*api.StandardResponse[api.ApplyResponse], error)
+}
+
+// applyClient handles bulk apply requests
+type applyClient struct {
+	client *BaseClient
+}
+
+// NewApplyClient creates a new apply client
+func NewApplyClient(client *BaseClient) Apply {
+	return &applyClient{client: client}
+}
+
+// Apply creates or updates every item of the request. The response lists a
+// result per item; Error is set on it when any item was invalid or failed.
+func (c *applyClient) Apply(ctx context.Context, request *api.ApplyRequest) (*api.StandardResponse[api.ApplyResponse], error) {
+	resp, err := c.client.Post(ctx, "/api/apply", request, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var response api.StandardResponse[api.ApplyResponse]
+	if err := DecodeResponse(resp, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
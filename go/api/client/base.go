@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -17,13 +18,34 @@ import (
 type ClientError struct {
 	StatusCode int
 	Message    string
-	Body       string
+	// Code is the machine-readable code of the error; servers that predate
+	// error codes leave it to the generic code of the status.
+	Code api.ErrorCode
+	// Details holds the facts the server returned about the error.
+	Details map[string]any
+	Body    string
 }
 
 func (e *ClientError) Error() string {
 	return fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.Message)
 }
 
+// ErrorCode returns the code of err if it is, or wraps, a ClientError, and
+// an empty code otherwise.
+func ErrorCode(err error) api.ErrorCode {
+	var clientErr *ClientError
+	if errors.As(err, &clientErr) {
+		return clientErr.Code
+	}
+	return ""
+}
+
+// IsNotFound reports whether err is a ClientError for a missing resource.
+func IsNotFound(err error) bool {
+	var clientErr *ClientError
+	return errors.As(err, &clientErr) && clientErr.StatusCode == http.StatusNotFound
+}
+
 // ClientOption represents a configuration option for the client
 type ClientOption func(*BaseClient)
 
@@ -41,9 +63,18 @@ func WithUserID(userID string) ClientOption {
 	}
 }
 
+// WithA2AURL sets the base URL of the agents' A2A endpoints, for servers that
+// expose them apart from the API. Empty keeps the API base URL.
+func WithA2AURL(a2aURL string) ClientOption {
+	return func(c *BaseClient) {
+		c.A2AURL = strings.TrimSuffix(a2aURL, "/")
+	}
+}
+
 // BaseClient contains the shared HTTP functionality used by all sub-clients
 type BaseClient struct {
 	BaseURL    string
+	A2AURL     string // Base URL of the A2A endpoints; BaseURL when empty
 	HTTPClient *http.Client
 	UserID     string // Default user ID for requests that require it
 }
@@ -117,9 +148,15 @@ func (c *BaseClient) doRequest(ctx context.Context, method, path string, body an
 
 		var apiErr api.APIError
 		if json.Unmarshal(bodyBytes, &apiErr) == nil && apiErr.Error != "" {
+			code := apiErr.Code
+			if code == "" {
+				code = api.ErrorCodeForStatus(resp.StatusCode)
+			}
 			return nil, &ClientError{
 				StatusCode: resp.StatusCode,
 				Message:    apiErr.Error,
+				Code:       code,
+				Details:    apiErr.Details,
 				Body:       string(bodyBytes),
 			}
 		}
@@ -127,6 +164,7 @@ func (c *BaseClient) doRequest(ctx context.Context, method, path string, body an
 		return nil, &ClientError{
 			StatusCode: resp.StatusCode,
 			Message:    "Request failed",
+			Code:       api.ErrorCodeForStatus(resp.StatusCode),
 			Body:       string(bodyBytes),
 		}
 	}
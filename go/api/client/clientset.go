@@ -16,6 +16,13 @@ type ClientSet struct {
 	Model               Model
 	Namespace           Namespace
 	Feedback            Feedback
+	Debug               Debug
+	Lint                Lint
+	Apply               Apply
+	Template            Template
+	Retention           Retention
+	Stats               Stats
+	Task                Task
 }
 
 // New creates a new KAgent client set
@@ -36,5 +43,12 @@ func New(baseURL string, options ...ClientOption) *ClientSet {
 		Model:               NewModelClient(baseClient),
 		Namespace:           NewNamespaceClient(baseClient),
 		Feedback:            NewFeedbackClient(baseClient),
+		Debug:               NewDebugClient(baseClient),
+		Lint:                NewLintClient(baseClient),
+		Apply:               NewApplyClient(baseClient),
+		Template:            NewTemplateClient(baseClient),
+		Retention:           NewRetentionClient(baseClient),
+		Stats:               NewStatsClient(baseClient),
+		Task:                NewTaskClient(baseClient),
 	}
 }
@@ -0,0 +1,57 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
+)
+
+// Debug defines the debug capture operations
+type Debug interface {
+	StartAgentCapture(ctx context.Context, namespace, name, duration string) (*api.StandardResponse[api.DebugCaptureResponse], error)
+	GetAgentCapture(ctx context.Context, namespace, name string) (*api.StandardResponse[api.DebugCaptureResponse], error)
+}
+
+// debugClient handles debug capture requests
+type debugClient struct {
+	client *BaseClient
+}
+
+// NewDebugClient creates a new debug client
+func NewDebugClient(client *BaseClient) Debug {
+	return &debugClient{client: client}
+}
+
+// StartAgentCapture starts recording reconcile traces for an agent for the given duration
+func (c *debugClient) StartAgentCapture(ctx context.Context, namespace, name, duration string) (*api.StandardResponse[api.DebugCaptureResponse], error) {
+	path := fmt.Sprintf("/api/debug/agents/%s/%s/capture", url.PathEscape(namespace), url.PathEscape(name))
+	resp, err := c.client.Post(ctx, path, &api.StartDebugCaptureRequest{Duration: duration}, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var response api.StandardResponse[api.DebugCaptureResponse]
+	if err := DecodeResponse(resp, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// GetAgentCapture downloads the entries recorded for an agent's latest capture
+func (c *debugClient) GetAgentCapture(ctx context.Context, namespace, name string) (*api.StandardResponse[api.DebugCaptureResponse], error) {
+	path := fmt.Sprintf("/api/debug/agents/%s/%s/capture", url.PathEscape(namespace), url.PathEscape(name))
+	resp, err := c.client.Get(ctx, path, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var response api.StandardResponse[api.DebugCaptureResponse]
+	if err := DecodeResponse(resp, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
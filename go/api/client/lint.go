@@ -0,0 +1,38 @@
+package client
+
+import (
+	"context"
+
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+)
+
+// Lint defines the spec linting operations
+type Lint interface {
+	LintAgent(ctx context.Context, agent *v1alpha2.Agent) (*api.StandardResponse[api.LintAgentResponse], error)
+}
+
+// lintClient handles spec linting requests
+type lintClient struct {
+	client *BaseClient
+}
+
+// NewLintClient creates a new lint client
+func NewLintClient(client *BaseClient) Lint {
+	return &lintClient{client: client}
+}
+
+// LintAgent checks an agent spec against kagent's best-practice rules
+func (c *lintClient) LintAgent(ctx context.Context, agent *v1alpha2.Agent) (*api.StandardResponse[api.LintAgentResponse], error) {
+	resp, err := c.client.Post(ctx, "/api/lint/agent", agent, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var response api.StandardResponse[api.LintAgentResponse]
+	if err := DecodeResponse(resp, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
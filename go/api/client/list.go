@@ -0,0 +1,60 @@
+package client
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// ListOptions pages, filters and sorts list requests. Zero values are left
+// out of the request, so the server returns everything in its default order.
+// Each endpoint documents which filters and sort fields it supports.
+type ListOptions struct {
+	// Limit is the maximum number of items to return (1-1000).
+	Limit int
+	// Offset is the number of items to skip.
+	Offset int
+	// Sort is the field to sort by, prefixed with "-" for descending order.
+	Sort string
+	// Namespace restricts results to a single namespace.
+	Namespace string
+	// LabelSelector restricts results to items matching a Kubernetes label selector.
+	LabelSelector string
+	// CreatedAfter restricts results to items created after this time.
+	CreatedAfter time.Time
+}
+
+// withListOptions appends the query parameters for opts to path.
+func withListOptions(method, path string, opts []ListOptions) (string, error) {
+	if len(opts) > 1 {
+		return "", fmt.Errorf("%s accepts at most one options argument", method)
+	}
+	if len(opts) == 0 {
+		return path, nil
+	}
+	o := opts[0]
+	q := url.Values{}
+	if o.Limit > 0 {
+		q.Set("limit", strconv.Itoa(o.Limit))
+	}
+	if o.Offset > 0 {
+		q.Set("offset", strconv.Itoa(o.Offset))
+	}
+	if o.Sort != "" {
+		q.Set("sort", o.Sort)
+	}
+	if o.Namespace != "" {
+		q.Set("namespace", o.Namespace)
+	}
+	if o.LabelSelector != "" {
+		q.Set("label", o.LabelSelector)
+	}
+	if !o.CreatedAfter.IsZero() {
+		q.Set("created_after", o.CreatedAfter.UTC().Format(time.RFC3339))
+	}
+	if len(q) == 0 {
+		return path, nil
+	}
+	return path + "?" + q.Encode(), nil
+}
@@ -0,0 +1,38 @@
+package client
+
+import (
+	"context"
+
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
+)
+
+// Retention defines the history retention operations
+type Retention interface {
+	Prune(ctx context.Context, request *api.PruneRequest) (*api.StandardResponse[api.PruneResponse], error)
+}
+
+// retentionClient handles history retention requests
+type retentionClient struct {
+	client *BaseClient
+}
+
+// NewRetentionClient creates a new retention client
+func NewRetentionClient(client *BaseClient) Retention {
+	return &retentionClient{client: client}
+}
+
+// Prune deletes the session history past the retention policy, or reports
+// what it would delete when request.DryRun is set
+func (c *retentionClient) Prune(ctx context.Context, request *api.PruneRequest) (*api.StandardResponse[api.PruneResponse], error) {
+	resp, err := c.client.Post(ctx, "/api/retention/prune", request, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var response api.StandardResponse[api.PruneResponse]
+	if err := DecodeResponse(resp, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
@@ -2,19 +2,37 @@ package client
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/url"
 
+	"github.com/kagent-dev/kagent/go/api/database"
 	api "github.com/kagent-dev/kagent/go/api/httpapi"
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
 )
 
 // Session defines the session operations
 type Session interface {
-	ListSessions(ctx context.Context) (*api.StandardResponse[[]*api.Session], error)
+	ListSessions(ctx context.Context, opts ...ListOptions) (*api.StandardResponse[[]*api.Session], error)
 	CreateSession(ctx context.Context, request *api.SessionRequest) (*api.StandardResponse[*api.Session], error)
 	GetSession(ctx context.Context, sessionName string) (*api.StandardResponse[*api.Session], error)
 	UpdateSession(ctx context.Context, request *api.SessionRequest) (*api.StandardResponse[*api.Session], error)
 	DeleteSession(ctx context.Context, sessionName string) error
+	ForkSession(ctx context.Context, sessionID string, request *api.ForkSessionRequest) (*api.StandardResponse[api.ForkSessionResponse], error)
 	ListSessionRuns(ctx context.Context, sessionName string) (*api.StandardResponse[any], error)
+	ListSessionEvents(ctx context.Context, sessionID string) (*api.StandardResponse[api.SessionWithEvents], error)
+	ListSessionTasks(ctx context.Context, sessionID string, opts ...ListOptions) (*api.StandardResponse[[]protocol.Task], error)
+	ListSessionApprovals(ctx context.Context, sessionID string) (*api.StandardResponse[[]api.ToolApproval], error)
+	GetSessionCompaction(ctx context.Context, sessionID string) (*api.StandardResponse[api.SessionCompactionStatus], error)
+	CompactSession(ctx context.Context, sessionID string) (*api.StandardResponse[api.CompactSessionResponse], error)
+	ListSessionGrants(ctx context.Context, sessionID string) (*api.StandardResponse[[]api.SessionGrant], error)
+	GrantSessionAccess(ctx context.Context, sessionID string, kind database.SessionPrincipalKind, principal string, access database.SessionAccess) (*api.StandardResponse[*api.SessionGrant], error)
+	RevokeSessionAccess(ctx context.Context, sessionID string, kind database.SessionPrincipalKind, principal string) error
+	ListSessionState(ctx context.Context, sessionID string) (*api.StandardResponse[[]api.SessionStateEntry], error)
+	GetSessionState(ctx context.Context, sessionID, key string) (*api.StandardResponse[api.SessionStateEntry], error)
+	SetSessionState(ctx context.Context, sessionID, key string, value json.RawMessage) (*api.StandardResponse[api.SessionStateEntry], error)
+	DeleteSessionState(ctx context.Context, sessionID, key string) error
+	ListLLMTraces(ctx context.Context, sessionID string) (*api.StandardResponse[[]api.LLMTrace], error)
 }
 
 // sessionClient handles session-related requests
@@ -27,14 +45,20 @@ func NewSessionClient(client *BaseClient) Session {
 	return &sessionClient{client: client}
 }
 
-// ListSessions lists all sessions for a user
-func (c *sessionClient) ListSessions(ctx context.Context) (*api.StandardResponse[[]*api.Session], error) {
+// ListSessions lists all sessions for a user. Sessions can be sorted by name,
+// created_at or updated_at and filtered by CreatedAfter.
+func (c *sessionClient) ListSessions(ctx context.Context, opts ...ListOptions) (*api.StandardResponse[[]*api.Session], error) {
+	path, err := withListOptions("ListSessions", "/api/sessions", opts)
+	if err != nil {
+		return nil, err
+	}
+
 	userID := c.client.GetUserIDOrDefault("")
 	if userID == "" {
 		return nil, fmt.Errorf("userID is required")
 	}
 
-	resp, err := c.client.Get(ctx, "/api/sessions", userID)
+	resp, err := c.client.Get(ctx, path, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -107,6 +131,27 @@ func (c *sessionClient) UpdateSession(ctx context.Context, request *api.SessionR
 	return &response, nil
 }
 
+// ForkSession creates a new session from the history of an existing one
+func (c *sessionClient) ForkSession(ctx context.Context, sessionID string, request *api.ForkSessionRequest) (*api.StandardResponse[api.ForkSessionResponse], error) {
+	userID := c.client.GetUserIDOrDefault("")
+	if userID == "" {
+		return nil, fmt.Errorf("userID is required")
+	}
+
+	path := fmt.Sprintf("/api/sessions/%s/fork", sessionID)
+	resp, err := c.client.Post(ctx, path, request, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var response api.StandardResponse[api.ForkSessionResponse]
+	if err := DecodeResponse(resp, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
 // DeleteSession deletes a session
 func (c *sessionClient) DeleteSession(ctx context.Context, sessionName string) error {
 	userID := c.client.GetUserIDOrDefault("")
@@ -142,3 +187,269 @@ func (c *sessionClient) ListSessionRuns(ctx context.Context, sessionName string)
 
 	return &response, nil
 }
+
+// GetSessionCompaction retrieves the compaction status of a session
+func (c *sessionClient) GetSessionCompaction(ctx context.Context, sessionID string) (*api.StandardResponse[api.SessionCompactionStatus], error) {
+	userID := c.client.GetUserIDOrDefault("")
+	if userID == "" {
+		return nil, fmt.Errorf("userID is required")
+	}
+
+	path := fmt.Sprintf("/api/sessions/%s/compaction", sessionID)
+	resp, err := c.client.Get(ctx, path, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var response api.StandardResponse[api.SessionCompactionStatus]
+	if err := DecodeResponse(resp, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// CompactSession summarizes the older events of a session
+func (c *sessionClient) CompactSession(ctx context.Context, sessionID string) (*api.StandardResponse[api.CompactSessionResponse], error) {
+	userID := c.client.GetUserIDOrDefault("")
+	if userID == "" {
+		return nil, fmt.Errorf("userID is required")
+	}
+
+	path := fmt.Sprintf("/api/sessions/%s/compact", sessionID)
+	resp, err := c.client.Post(ctx, path, nil, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var response api.StandardResponse[api.CompactSessionResponse]
+	if err := DecodeResponse(resp, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// ListSessionEvents retrieves a session with all of its events, oldest first
+func (c *sessionClient) ListSessionEvents(ctx context.Context, sessionID string) (*api.StandardResponse[api.SessionWithEvents], error) {
+	userID := c.client.GetUserIDOrDefault("")
+	if userID == "" {
+		return nil, fmt.Errorf("userID is required")
+	}
+
+	path := fmt.Sprintf("/api/sessions/%s?order=asc", sessionID)
+	resp, err := c.client.Get(ctx, path, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var response api.StandardResponse[api.SessionWithEvents]
+	if err := DecodeResponse(resp, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// ListSessionTasks lists the A2A tasks of a session
+func (c *sessionClient) ListSessionTasks(ctx context.Context, sessionID string, opts ...ListOptions) (*api.StandardResponse[[]protocol.Task], error) {
+	path, err := withListOptions("ListSessionTasks", fmt.Sprintf("/api/sessions/%s/tasks", sessionID), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	userID := c.client.GetUserIDOrDefault("")
+	if userID == "" {
+		return nil, fmt.Errorf("userID is required")
+	}
+
+	resp, err := c.client.Get(ctx, path, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var response api.StandardResponse[[]protocol.Task]
+	if err := DecodeResponse(resp, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// ListSessionApprovals lists the tool calls of a session waiting for approval
+func (c *sessionClient) ListSessionApprovals(ctx context.Context, sessionID string) (*api.StandardResponse[[]api.ToolApproval], error) {
+	userID := c.client.GetUserIDOrDefault("")
+	if userID == "" {
+		return nil, fmt.Errorf("userID is required")
+	}
+
+	path := fmt.Sprintf("/api/sessions/%s/approvals", sessionID)
+	resp, err := c.client.Get(ctx, path, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var response api.StandardResponse[[]api.ToolApproval]
+	if err := DecodeResponse(resp, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// ListSessionGrants lists the users and groups a session is shared with
+func (c *sessionClient) ListSessionGrants(ctx context.Context, sessionID string) (*api.StandardResponse[[]api.SessionGrant], error) {
+	userID := c.client.GetUserIDOrDefault("")
+	if userID == "" {
+		return nil, fmt.Errorf("userID is required")
+	}
+
+	path := fmt.Sprintf("/api/sessions/%s/grants", sessionID)
+	resp, err := c.client.Get(ctx, path, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var response api.StandardResponse[[]api.SessionGrant]
+	if err := DecodeResponse(resp, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// GrantSessionAccess shares a session with a user or group, replacing any
+// access it already had
+func (c *sessionClient) GrantSessionAccess(ctx context.Context, sessionID string, kind database.SessionPrincipalKind, principal string, access database.SessionAccess) (*api.StandardResponse[*api.SessionGrant], error) {
+	userID := c.client.GetUserIDOrDefault("")
+	if userID == "" {
+		return nil, fmt.Errorf("userID is required")
+	}
+
+	path := fmt.Sprintf("/api/sessions/%s/grants/%s/%s", sessionID, kind, url.PathEscape(principal))
+	resp, err := c.client.Put(ctx, path, &api.SessionGrantRequest{Access: access}, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var response api.StandardResponse[*api.SessionGrant]
+	if err := DecodeResponse(resp, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// RevokeSessionAccess stops sharing a session with a user or group
+func (c *sessionClient) RevokeSessionAccess(ctx context.Context, sessionID string, kind database.SessionPrincipalKind, principal string) error {
+	userID := c.client.GetUserIDOrDefault("")
+	if userID == "" {
+		return fmt.Errorf("userID is required")
+	}
+
+	path := fmt.Sprintf("/api/sessions/%s/grants/%s/%s", sessionID, kind, url.PathEscape(principal))
+	resp, err := c.client.Delete(ctx, path, userID)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// ListSessionState lists the key/value state of a session
+func (c *sessionClient) ListSessionState(ctx context.Context, sessionID string) (*api.StandardResponse[[]api.SessionStateEntry], error) {
+	userID := c.client.GetUserIDOrDefault("")
+	if userID == "" {
+		return nil, fmt.Errorf("userID is required")
+	}
+
+	path := fmt.Sprintf("/api/sessions/%s/state", sessionID)
+	resp, err := c.client.Get(ctx, path, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var response api.StandardResponse[[]api.SessionStateEntry]
+	if err := DecodeResponse(resp, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// GetSessionState gets one key of a session's state
+func (c *sessionClient) GetSessionState(ctx context.Context, sessionID, key string) (*api.StandardResponse[api.SessionStateEntry], error) {
+	userID := c.client.GetUserIDOrDefault("")
+	if userID == "" {
+		return nil, fmt.Errorf("userID is required")
+	}
+
+	path := fmt.Sprintf("/api/sessions/%s/state/%s", sessionID, url.PathEscape(key))
+	resp, err := c.client.Get(ctx, path, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var response api.StandardResponse[api.SessionStateEntry]
+	if err := DecodeResponse(resp, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// SetSessionState sets one key of a session's state to a JSON value
+func (c *sessionClient) SetSessionState(ctx context.Context, sessionID, key string, value json.RawMessage) (*api.StandardResponse[api.SessionStateEntry], error) {
+	userID := c.client.GetUserIDOrDefault("")
+	if userID == "" {
+		return nil, fmt.Errorf("userID is required")
+	}
+
+	path := fmt.Sprintf("/api/sessions/%s/state/%s", sessionID, url.PathEscape(key))
+	resp, err := c.client.Put(ctx, path, &api.SessionStateRequest{Value: value}, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var response api.StandardResponse[api.SessionStateEntry]
+	if err := DecodeResponse(resp, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// DeleteSessionState deletes one key of a session's state
+func (c *sessionClient) DeleteSessionState(ctx context.Context, sessionID, key string) error {
+	userID := c.client.GetUserIDOrDefault("")
+	if userID == "" {
+		return fmt.Errorf("userID is required")
+	}
+
+	path := fmt.Sprintf("/api/sessions/%s/state/%s", sessionID, url.PathEscape(key))
+	resp, err := c.client.Delete(ctx, path, userID)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// ListLLMTraces lists the LLM traces recorded for a session
+func (c *sessionClient) ListLLMTraces(ctx context.Context, sessionID string) (*api.StandardResponse[[]api.LLMTrace], error) {
+	userID := c.client.GetUserIDOrDefault("")
+	if userID == "" {
+		return nil, fmt.Errorf("userID is required")
+	}
+
+	path := fmt.Sprintf("/api/sessions/%s/llm-traces", sessionID)
+	resp, err := c.client.Get(ctx, path, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var response api.StandardResponse[[]api.LLMTrace]
+	if err := DecodeResponse(resp, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
@@ -0,0 +1,43 @@
+package client
+
+import (
+	"context"
+	"net/url"
+
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
+)
+
+// Stats defines the live agent activity operations
+type Stats interface {
+	GetStats(ctx context.Context, window string) (*api.StandardResponse[api.StatsResponse], error)
+}
+
+// statsClient handles agent activity requests
+type statsClient struct {
+	client *BaseClient
+}
+
+// NewStatsClient creates a new stats client
+func NewStatsClient(client *BaseClient) Stats {
+	return &statsClient{client: client}
+}
+
+// GetStats returns the activity of each agent over the last window (Go
+// duration syntax), or the server's default window when empty
+func (c *statsClient) GetStats(ctx context.Context, window string) (*api.StandardResponse[api.StatsResponse], error) {
+	path := "/api/stats"
+	if window != "" {
+		path += "?window=" + url.QueryEscape(window)
+	}
+	resp, err := c.client.Get(ctx, path, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var response api.StandardResponse[api.StatsResponse]
+	if err := DecodeResponse(resp, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
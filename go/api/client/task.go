@@ -0,0 +1,45 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
+)
+
+// Task defines the task operations
+type Task interface {
+	GetTask(ctx context.Context, taskID string) (*api.StandardResponse[*protocol.Task], error)
+}
+
+// taskClient handles task-related requests
+type taskClient struct {
+	client *BaseClient
+}
+
+// NewTaskClient creates a new task client
+func NewTaskClient(client *BaseClient) Task {
+	return &taskClient{client: client}
+}
+
+// GetTask retrieves the stored state of a task
+func (c *taskClient) GetTask(ctx context.Context, taskID string) (*api.StandardResponse[*protocol.Task], error) {
+	userID := c.client.GetUserIDOrDefault("")
+	if userID == "" {
+		return nil, fmt.Errorf("userID is required")
+	}
+
+	resp, err := c.client.Get(ctx, fmt.Sprintf("/api/tasks/%s", url.PathEscape(taskID)), userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var response api.StandardResponse[*protocol.Task]
+	if err := DecodeResponse(resp, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
@@ -0,0 +1,78 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+)
+
+// Template defines the agent template catalog operations
+type Template interface {
+	ListTemplates(ctx context.Context, namespace string) (*api.StandardResponse[[]api.AgentTemplate], error)
+	GetTemplate(ctx context.Context, namespace, name string) (*api.StandardResponse[api.AgentTemplate], error)
+	CreateAgentFromTemplate(ctx context.Context, namespace, name string, request *api.CreateAgentFromTemplateRequest) (*api.StandardResponse[v1alpha2.Agent], error)
+}
+
+// templateClient handles agent template requests
+type templateClient struct {
+	client *BaseClient
+}
+
+// NewTemplateClient creates a new template client
+func NewTemplateClient(client *BaseClient) Template {
+	return &templateClient{client: client}
+}
+
+// ListTemplates lists the agent templates of a namespace, or of every
+// namespace when namespace is empty.
+func (c *templateClient) ListTemplates(ctx context.Context, namespace string) (*api.StandardResponse[[]api.AgentTemplate], error) {
+	path := "/api/templates"
+	if namespace != "" {
+		path += "?namespace=" + url.QueryEscape(namespace)
+	}
+	resp, err := c.client.Get(ctx, path, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var response api.StandardResponse[[]api.AgentTemplate]
+	if err := DecodeResponse(resp, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// GetTemplate retrieves an agent template, including its manifest
+func (c *templateClient) GetTemplate(ctx context.Context, namespace, name string) (*api.StandardResponse[api.AgentTemplate], error) {
+	resp, err := c.client.Get(ctx, fmt.Sprintf("/api/templates/%s/%s", namespace, name), "")
+	if err != nil {
+		return nil, err
+	}
+
+	var response api.StandardResponse[api.AgentTemplate]
+	if err := DecodeResponse(resp, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// CreateAgentFromTemplate creates an Agent from a template, or only renders
+// and validates it when request.DryRun is set
+func (c *templateClient) CreateAgentFromTemplate(ctx context.Context, namespace, name string, request *api.CreateAgentFromTemplateRequest) (*api.StandardResponse[v1alpha2.Agent], error) {
+	resp, err := c.client.Post(ctx, fmt.Sprintf("/api/templates/%s/%s/agents", namespace, name), request, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var response api.StandardResponse[v1alpha2.Agent]
+	if err := DecodeResponse(resp, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	api "github.com/kagent-dev/kagent/go/api/httpapi"
 	"github.com/kagent-dev/kagent/go/api/v1alpha1"
@@ -10,9 +11,10 @@ import (
 
 // ToolServer defines the tool server operations
 type ToolServer interface {
-	ListToolServers(ctx context.Context) ([]api.ToolServerResponse, error)
+	ListToolServers(ctx context.Context, opts ...ListOptions) ([]api.ToolServerResponse, error)
 	CreateToolServer(ctx context.Context, toolServer *v1alpha1.ToolServer) (*v1alpha1.ToolServer, error)
 	DeleteToolServer(ctx context.Context, namespace, toolServerName string) error
+	GetToolServerStats(ctx context.Context, namespace, toolServerName string, hours int) (*api.ToolServerStatsResponse, error)
 }
 
 // ToolServerClient handles tool server-related requests
@@ -25,19 +27,25 @@ func NewToolServerClient(client *BaseClient) ToolServer {
 	return &ToolServerClient{client: client}
 }
 
-// ListToolServers lists all tool servers
-func (c *ToolServerClient) ListToolServers(ctx context.Context) ([]api.ToolServerResponse, error) {
-	resp, err := c.client.Get(ctx, "/api/toolservers", "")
+// ListToolServers lists all tool servers. Tool servers can be sorted by name
+// or created_at and filtered by Namespace and CreatedAfter.
+func (c *ToolServerClient) ListToolServers(ctx context.Context, opts ...ListOptions) ([]api.ToolServerResponse, error) {
+	path, err := withListOptions("ListToolServers", "/api/toolservers", opts)
 	if err != nil {
 		return nil, err
 	}
 
-	var toolServers []api.ToolServerResponse
-	if err := DecodeResponse(resp, &toolServers); err != nil {
+	resp, err := c.client.Get(ctx, path, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var response api.StandardResponse[[]api.ToolServerResponse]
+	if err := DecodeResponse(resp, &response); err != nil {
 		return nil, err
 	}
 
-	return toolServers, nil
+	return response.Data, nil
 }
 
 // CreateToolServer creates a new tool server
@@ -64,3 +72,23 @@ func (c *ToolServerClient) DeleteToolServer(ctx context.Context, namespace, tool
 	}
 	return nil
 }
+
+// GetToolServerStats returns the calls agents made to a tool server over the
+// last hours hours, or the server's default window when hours is 0.
+func (c *ToolServerClient) GetToolServerStats(ctx context.Context, namespace, toolServerName string, hours int) (*api.ToolServerStatsResponse, error) {
+	path := fmt.Sprintf("/api/toolservers/%s/%s/stats", namespace, toolServerName)
+	if hours > 0 {
+		path += "?hours=" + strconv.Itoa(hours)
+	}
+	resp, err := c.client.Get(ctx, path, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var response api.StandardResponse[api.ToolServerStatsResponse]
+	if err := DecodeResponse(resp, &response); err != nil {
+		return nil, err
+	}
+
+	return &response.Data, nil
+}
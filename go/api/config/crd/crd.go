@@ -0,0 +1,9 @@
+// Package crd embeds the generated kagent CustomResourceDefinition manifests.
+package crd
+
+import "embed"
+
+// Bases holds the CRD manifests under bases/, one file per resource.
+//
+//go:embed bases/*.yaml
+var Bases embed.FS
@@ -14,11 +14,54 @@ import (
 // different user.
 var ErrTaskOwnedByAnotherUser = errors.New("task id owned by another user")
 
+// ErrEventNotInSession means the event a session is forked at does not exist
+// in the session being forked.
+var ErrEventNotInSession = errors.New("event not found in session")
+
 type QueryOptions struct {
 	Limit    int
 	After    time.Time
 	OrderAsc bool // When true, order results by created_at ASC (chronological). Default is DESC (newest first).
 }
+
+// SessionListOptions filters, sorts and pages ListSessionsWithOptions.
+// Zero values mean no filter, the default updated_at DESC order and no limit.
+type SessionListOptions struct {
+	Limit        int
+	Offset       int
+	CreatedAfter time.Time
+	SortBy       string // One of "created_at", "updated_at" or "name".
+	SortAsc      bool
+}
+
+// RetentionPolicy bounds how much session history is kept. A zero field
+// disables that rule.
+type RetentionPolicy struct {
+	// SessionMaxAge deletes sessions not updated for longer, along with their
+	// events, tasks and push notifications.
+	SessionMaxAge time.Duration
+	// MaxSessions keeps only the newest sessions of each user and agent.
+	MaxSessions int
+	// TaskMaxAge, EventMaxAge and PushNotificationMaxAge delete rows not
+	// updated (or, for events, created) for longer, whatever their session.
+	// EventMaxAge also deletes event archives whose newest event is older.
+	TaskMaxAge             time.Duration
+	EventMaxAge            time.Duration
+	PushNotificationMaxAge time.Duration
+	// StreamEventMaxAge deletes the stored events of A2A streams, which
+	// clients resume streams from, once they are older.
+	StreamEventMaxAge time.Duration
+}
+
+// PruneResult counts the rows deleted by PruneHistory.
+type PruneResult struct {
+	Sessions          int64 `json:"sessions"`
+	Events            int64 `json:"events"`
+	Tasks             int64 `json:"tasks"`
+	PushNotifications int64 `json:"pushNotifications"`
+	StreamEvents      int64 `json:"streamEvents"`
+}
+
 type LangGraphCheckpointTuple struct {
 	Checkpoint *LangGraphCheckpoint
 	Writes     []*LangGraphCheckpointWrite
@@ -33,6 +76,10 @@ type Client interface {
 	StorePushNotification(ctx context.Context, config *a2a.PushConfig) error
 	StoreToolServer(ctx context.Context, toolServer *ToolServer) (*ToolServer, error)
 	StoreEvents(ctx context.Context, messages ...*Event) error
+	// ForkSession stores fork as a new session holding a copy of the events
+	// of sourceSessionID up to and including untilEventID, or all of them
+	// when untilEventID is empty. It returns the number of events copied.
+	ForkSession(ctx context.Context, sourceSessionID, untilEventID string, fork *Session) (int, error)
 
 	// Delete methods
 	DeleteSession(ctx context.Context, sessionID string, userID string) error
@@ -40,6 +87,7 @@ type Client interface {
 	DeleteToolServer(ctx context.Context, serverName string, groupKind string) error
 	DeleteTask(ctx context.Context, taskID string, userID string) error
 	DeletePushNotification(ctx context.Context, taskID string) error
+	DeletePushNotificationConfig(ctx context.Context, taskID string, configID string) error
 	DeleteToolsForServer(ctx context.Context, serverName string, groupKind string) error
 
 	// Get methods
@@ -56,8 +104,12 @@ type Client interface {
 	ListFeedback(ctx context.Context, userID string) ([]Feedback, error)
 	ListTasksForSession(ctx context.Context, sessionID string, userID string) ([]*a2a.Task, error)
 	ListSessions(ctx context.Context, userID string) ([]Session, error)
+	ListSessionsWithOptions(ctx context.Context, userID string, opts SessionListOptions) ([]Session, error)
 	ListSessionsForAgent(ctx context.Context, agentID string, userID string) ([]SessionWithShareToken, error)
 	ListSessionsForAgentAllUsers(ctx context.Context, agentID string) ([]Session, error)
+	// ListSessionsUpdatedSince returns the live sessions of every user
+	// updated since the given time, oldest update first.
+	ListSessionsUpdatedSince(ctx context.Context, since time.Time) ([]Session, error)
 	ListAgents(ctx context.Context) ([]Agent, error)
 	ListToolServers(ctx context.Context) ([]ToolServer, error)
 	ListToolsForServer(ctx context.Context, serverName string, groupKind string) ([]Tool, error)
@@ -66,6 +118,10 @@ type Client interface {
 
 	// Helper methods
 	RefreshToolsForServer(ctx context.Context, serverName string, groupKind string, tools ...*v1alpha2.MCPTool) error
+	// WithLock runs fn while holding the lock named key, which every
+	// controller replica using the database shares, in a transaction: the
+	// writes fn makes through tx commit together when it returns nil.
+	WithLock(ctx context.Context, key string, fn func(tx Client) error) error
 
 	// LangGraph Checkpoint methods
 	StoreCheckpoint(ctx context.Context, checkpoint *LangGraphCheckpoint) error
@@ -87,6 +143,39 @@ type Client interface {
 	DeleteSessionShare(ctx context.Context, token, sessionID, userID string) error
 	RecordShareAccess(ctx context.Context, userID string, shareID int64) error
 
+	// Session grant methods
+	UpsertSessionGrant(ctx context.Context, grant *SessionGrant) (*SessionGrant, error)
+	ListSessionGrants(ctx context.Context, sessionID, ownerID string) ([]SessionGrant, error)
+	DeleteSessionGrant(ctx context.Context, sessionID, ownerID string, kind SessionPrincipalKind, principal string) error
+	// GetSessionGrantForPrincipal returns the strongest grant on a live session
+	// for the user or any of the groups.
+	GetSessionGrantForPrincipal(ctx context.Context, sessionID, userID string, groups []string) (*SessionGrant, error)
+
+	// Session state methods
+	ListSessionState(ctx context.Context, sessionID, userID string) ([]SessionStateEntry, error)
+	// GetSessionStateValue returns nil when the key is not set.
+	GetSessionStateValue(ctx context.Context, sessionID, userID, key string) (*SessionStateEntry, error)
+	SetSessionStateValue(ctx context.Context, entry *SessionStateEntry) (*SessionStateEntry, error)
+	// DeleteSessionStateValue reports whether the key was set.
+	DeleteSessionStateValue(ctx context.Context, sessionID, userID, key string) (bool, error)
+
+	// Event archive methods
+	// ListArchivableSessions returns up to limit live sessions not updated
+	// since updatedBefore that still have events in the database, least
+	// recently updated first.
+	ListArchivableSessions(ctx context.Context, updatedBefore time.Time, limit int) ([]Session, error)
+	// ArchiveEvents records archive and deletes the archived events from the
+	// database, atomically.
+	ArchiveEvents(ctx context.Context, archive *EventArchive, eventIDs []string) (*EventArchive, error)
+	// ListEventArchives returns the archives of a session, oldest first.
+	ListEventArchives(ctx context.Context, sessionID, userID string) ([]*EventArchive, error)
+	// ListEventArchiveKeys returns the object keys of every event archive.
+	ListEventArchiveKeys(ctx context.Context) ([]string, error)
+
+	// LLM trace methods
+	StoreLLMTrace(ctx context.Context, trace *LLMTrace) (*LLMTrace, error)
+	ListLLMTraces(ctx context.Context, sessionID, userID string) ([]LLMTrace, error)
+
 	// Agent memory (vector search) methods
 	StoreAgentMemory(ctx context.Context, memory *Memory) error
 	StoreAgentMemories(ctx context.Context, memories []*Memory) error
@@ -94,4 +183,45 @@ type Client interface {
 	ListAgentMemories(ctx context.Context, agentName, userID string) ([]Memory, error)
 	DeleteAgentMemory(ctx context.Context, agentName, userID string) error
 	PruneExpiredMemories(ctx context.Context) error
+
+	// Retention methods
+	// PruneHistory permanently deletes the history the policy no longer
+	// retains. A dry run reports what would be deleted and rolls back.
+	PruneHistory(ctx context.Context, policy RetentionPolicy, dryRun bool) (*PruneResult, error)
+
+	// Push notification delivery methods
+	RecordPushNotificationDelivery(ctx context.Context, delivery *PushNotificationDelivery) error
+	ListPushNotificationDeliveries(ctx context.Context, taskID string) ([]PushNotificationDelivery, error)
+
+	// Provider analytics methods
+	RecordProviderCall(ctx context.Context, call *ProviderCall) error
+	ListProviderDailyStats(ctx context.Context, since time.Time) ([]ProviderDailyStats, error)
+
+	// Tool call stats methods
+	// RecordToolCallStats adds stats to the hour they fall in.
+	RecordToolCallStats(ctx context.Context, stats *ToolCallStats) error
+	// ListToolCallStats returns the hourly stats of a tool server since the
+	// hour of since, oldest first.
+	ListToolCallStats(ctx context.Context, toolServer string, since time.Time) ([]ToolCallStats, error)
+
+	// Task stream event methods
+	// AppendTaskStreamEvent stores the next event of the streams of a task,
+	// given as its JSON-RPC response, and returns its sequence number.
+	AppendTaskStreamEvent(ctx context.Context, taskID, userID string, data []byte) (int64, error)
+	// ListTaskStreamEvents returns the events of the streams of a task after
+	// sequence number afterSeq, oldest first.
+	ListTaskStreamEvents(ctx context.Context, taskID, userID string, afterSeq int64) ([]*TaskStreamEvent, error)
+
+	// Agent activity methods
+	// CountActiveSessionsByAgent counts the live sessions of each agent
+	// updated since the given time, across every user.
+	CountActiveSessionsByAgent(ctx context.Context, since time.Time) ([]AgentSessionCount, error)
+	// ListAgentTasksUpdatedSince returns the live tasks of every user updated
+	// since the given time.
+	ListAgentTasksUpdatedSince(ctx context.Context, since time.Time) ([]AgentTask, error)
+
+	// Debug capture methods
+	StoreDebugCaptureEntry(ctx context.Context, entry *DebugCaptureEntry) error
+	ListDebugCaptureEntries(ctx context.Context, kind, namespace, name, captureID string) ([]DebugCaptureEntry, error)
+	DeleteDebugCaptureEntries(ctx context.Context, kind, namespace, name string) error
 }
@@ -125,6 +125,31 @@ type PushNotification struct {
 	ProtocolVersion *string    `json:"protocol_version,omitempty"`
 }
 
+// PushNotificationDeliveryStatus is the outcome of the latest webhook delivery
+// attempt for a push notification config.
+type PushNotificationDeliveryStatus string
+
+const (
+	PushNotificationDeliveryPending   PushNotificationDeliveryStatus = "pending"
+	PushNotificationDeliveryRetrying  PushNotificationDeliveryStatus = "retrying"
+	PushNotificationDeliveryDelivered PushNotificationDeliveryStatus = "delivered"
+	PushNotificationDeliveryFailed    PushNotificationDeliveryStatus = "failed"
+)
+
+// PushNotificationDelivery records the latest task state change sent to a push
+// notification config and how delivery went.
+type PushNotificationDelivery struct {
+	TaskID         string                         `json:"task_id"`
+	ConfigID       string                         `json:"config_id"`
+	TaskState      string                         `json:"task_state"`
+	Status         PushNotificationDeliveryStatus `json:"status"`
+	Attempts       int                            `json:"attempts"`
+	LastStatusCode int                            `json:"last_status_code,omitempty"`
+	LastError      string                         `json:"last_error,omitempty"`
+	DeliveredAt    *time.Time                     `json:"delivered_at,omitempty"`
+	UpdatedAt      time.Time                      `json:"updated_at"`
+}
+
 // FeedbackIssueType represents the category of feedback issue
 type FeedbackIssueType string
 
@@ -242,3 +267,142 @@ type SessionShare struct {
 	ReadOnly  bool      `json:"read_only"`
 	CreatedAt time.Time `json:"created_at"`
 }
+
+// SessionPrincipalKind says whether a SessionGrant names a user or a group.
+type SessionPrincipalKind string
+
+const (
+	SessionPrincipalUser  SessionPrincipalKind = "user"
+	SessionPrincipalGroup SessionPrincipalKind = "group"
+)
+
+// SessionAccess is the access a SessionGrant gives. Invoke access includes
+// read access.
+type SessionAccess string
+
+const (
+	SessionAccessRead   SessionAccess = "read"
+	SessionAccessInvoke SessionAccess = "invoke"
+)
+
+// SessionGrant gives a user, or every member of a group, access to a session
+// owned by another user.
+type SessionGrant struct {
+	SessionID     string               `json:"session_id"`
+	OwnerID       string               `json:"owner_id"`
+	PrincipalKind SessionPrincipalKind `json:"principal_kind"`
+	Principal     string               `json:"principal"`
+	Access        SessionAccess        `json:"access"`
+	CreatedAt     time.Time            `json:"created_at"`
+}
+
+// SessionStateEntry is one key of a session's key/value state, which agents
+// read and write as ADK session state. Value is any JSON value.
+type SessionStateEntry struct {
+	SessionID string          `json:"session_id"`
+	UserID    string          `json:"user_id"`
+	Key       string          `json:"key"`
+	Value     json.RawMessage `json:"value"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// EventArchive points at session events moved out of the database into the
+// artifact store, as gzip-compressed JSON Lines of Event at ObjectKey.
+type EventArchive struct {
+	ID           int64     `json:"id"`
+	SessionID    string    `json:"session_id"`
+	UserID       string    `json:"user_id"`
+	ObjectKey    string    `json:"object_key"`
+	EventCount   int       `json:"event_count"`
+	FirstEventAt time.Time `json:"first_event_at"`
+	LastEventAt  time.Time `json:"last_event_at"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// LLMTrace is the redacted request and response of one sampled model call,
+// recorded by an agent with LLM trace capture enabled. Request and Response
+// are JSON; Response is empty and Error set when the call failed.
+type LLMTrace struct {
+	ID        int64           `json:"id"`
+	SessionID string          `json:"session_id"`
+	UserID    string          `json:"user_id"`
+	AgentID   string          `json:"agent_id"`
+	Model     string          `json:"model"`
+	Request   json.RawMessage `json:"request"`
+	Response  json.RawMessage `json:"response,omitempty"`
+	Error     string          `json:"error,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// ProviderCall is the outcome of a single agent invocation attributed to the
+// model provider and model the agent is configured with.
+type ProviderCall struct {
+	Provider string
+	Model    string
+	Latency  time.Duration
+	Failed   bool
+	At       time.Time
+}
+
+// ProviderDailyStats aggregates ProviderCall outcomes per UTC day.
+type ProviderDailyStats struct {
+	Day            time.Time `json:"day"`
+	Provider       string    `json:"provider"`
+	Model          string    `json:"model"`
+	RequestCount   int64     `json:"request_count"`
+	ErrorCount     int64     `json:"error_count"`
+	TotalLatencyMs int64     `json:"total_latency_ms"`
+	MaxLatencyMs   int64     `json:"max_latency_ms"`
+}
+
+// ToolCallStats counts the calls one agent made to one tool server during one
+// UTC hour. LatencyBuckets counts the calls per adk.ToolLatencyBucketsMs
+// bucket, with one more bucket for calls slower than every bound.
+type ToolCallStats struct {
+	Hour           time.Time `json:"hour"`
+	ToolServer     string    `json:"tool_server"`
+	AgentID        string    `json:"agent_id"`
+	CallCount      int64     `json:"call_count"`
+	ErrorCount     int64     `json:"error_count"`
+	LatencyBuckets []int64   `json:"latency_buckets"`
+}
+
+// TaskStreamEvent is an event of an A2A stream of a task, kept so clients
+// can resume the stream. Seq numbers the events of a task from 1 across all
+// of its streams.
+type TaskStreamEvent struct {
+	TaskID    string    `json:"task_id"`
+	Seq       int64     `json:"seq"`
+	UserID    string    `json:"user_id"`
+	Data      string    `json:"data"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AgentSessionCount is the number of sessions of an agent.
+type AgentSessionCount struct {
+	AgentID  string
+	Sessions int64
+}
+
+// AgentTask is a task attributed to the agent of its session.
+type AgentTask struct {
+	AgentID   string
+	Task      *a2a.Task
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// DebugCaptureEntry is one step the controller recorded while an object was
+// under debug capture. CaptureID identifies the capture window; Data holds the
+// JSON-encoded (and redacted) payload for the step, if any.
+type DebugCaptureEntry struct {
+	ID         int64     `json:"id"`
+	CaptureID  string    `json:"capture_id"`
+	ObjectKind string    `json:"object_kind"`
+	Namespace  string    `json:"namespace"`
+	Name       string    `json:"name"`
+	Stage      string    `json:"stage"`
+	Message    string    `json:"message"`
+	Data       string    `json:"data,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
@@ -0,0 +1,75 @@
+package httpapi
+
+import "net/http"
+
+// ErrorCode is the machine-readable code of an APIError. Clients should act
+// on the code rather than on the message, which is meant for people and may
+// change.
+type ErrorCode string
+
+// Generic codes, set from the HTTP status of errors that have no more
+// specific code.
+const (
+	ErrorCodeBadRequest       ErrorCode = "BAD_REQUEST"
+	ErrorCodeUnauthenticated  ErrorCode = "UNAUTHENTICATED"
+	ErrorCodeForbidden        ErrorCode = "FORBIDDEN"
+	ErrorCodeNotFound         ErrorCode = "NOT_FOUND"
+	ErrorCodeConflict         ErrorCode = "CONFLICT"
+	ErrorCodeValidationFailed ErrorCode = "VALIDATION_FAILED"
+	ErrorCodeTooManyRequests  ErrorCode = "TOO_MANY_REQUESTS"
+	ErrorCodeRequestTooLarge  ErrorCode = "REQUEST_TOO_LARGE"
+	ErrorCodeInternal         ErrorCode = "INTERNAL"
+	ErrorCodeNotImplemented   ErrorCode = "NOT_IMPLEMENTED"
+	ErrorCodeUnavailable      ErrorCode = "UNAVAILABLE"
+)
+
+// Codes of specific errors.
+const (
+	ErrorCodeInvalidRequestBody     ErrorCode = "INVALID_REQUEST_BODY"
+	ErrorCodeAgentNotFound          ErrorCode = "AGENT_NOT_FOUND"
+	ErrorCodeAgentInvalid           ErrorCode = "AGENT_INVALID"
+	ErrorCodeAgentHarnessNotFound   ErrorCode = "AGENTHARNESS_NOT_FOUND"
+	ErrorCodeAgentTemplateNotFound  ErrorCode = "AGENT_TEMPLATE_NOT_FOUND"
+	ErrorCodeModelConfigNotFound    ErrorCode = "MODELCONFIG_NOT_FOUND"
+	ErrorCodeModelConfigInvalid     ErrorCode = "MODELCONFIG_INVALID"
+	ErrorCodeModelConfigExists      ErrorCode = "MODELCONFIG_EXISTS"
+	ErrorCodeToolServerNotFound     ErrorCode = "TOOLSERVER_NOT_FOUND"
+	ErrorCodeToolServerInvalid      ErrorCode = "TOOLSERVER_INVALID"
+	ErrorCodeToolServerUnreachable  ErrorCode = "TOOLSERVER_UNREACHABLE"
+	ErrorCodeToolNotFound           ErrorCode = "TOOL_NOT_FOUND"
+	ErrorCodeSessionNotFound        ErrorCode = "SESSION_NOT_FOUND"
+	ErrorCodeSessionExists          ErrorCode = "SESSION_EXISTS"
+	ErrorCodeTaskNotFound           ErrorCode = "TASK_NOT_FOUND"
+	ErrorCodeArtifactNotFound       ErrorCode = "ARTIFACT_NOT_FOUND"
+	ErrorCodePromptTemplateNotFound ErrorCode = "PROMPT_TEMPLATE_NOT_FOUND"
+)
+
+// ErrorCodeForStatus returns the generic code of an HTTP error status.
+func ErrorCodeForStatus(status int) ErrorCode {
+	switch status {
+	case http.StatusBadRequest:
+		return ErrorCodeBadRequest
+	case http.StatusUnauthorized:
+		return ErrorCodeUnauthenticated
+	case http.StatusForbidden:
+		return ErrorCodeForbidden
+	case http.StatusNotFound:
+		return ErrorCodeNotFound
+	case http.StatusConflict:
+		return ErrorCodeConflict
+	case http.StatusUnprocessableEntity:
+		return ErrorCodeValidationFailed
+	case http.StatusTooManyRequests:
+		return ErrorCodeTooManyRequests
+	case http.StatusRequestEntityTooLarge:
+		return ErrorCodeRequestTooLarge
+	case http.StatusNotImplemented:
+		return ErrorCodeNotImplemented
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return ErrorCodeUnavailable
+	}
+	if status >= 500 {
+		return ErrorCodeInternal
+	}
+	return ErrorCodeBadRequest
+}
@@ -1,6 +1,9 @@
 package httpapi
 
 import (
+	"encoding/json"
+	"time"
+
 	"github.com/kagent-dev/kagent/go/api/database"
 	"github.com/kagent-dev/kagent/go/api/v1alpha1"
 	"github.com/kagent-dev/kagent/go/api/v1alpha2"
@@ -11,7 +14,13 @@ import (
 
 // APIError represents an error response from the API
 type APIError struct {
+	// Error describes the error for people.
 	Error string `json:"error"`
+	// Code identifies the kind of error for programs.
+	Code ErrorCode `json:"code,omitempty"`
+	// Details holds facts about the error, such as the name of the resource
+	// that wasn't found.
+	Details map[string]any `json:"details,omitempty"`
 }
 
 func NewResponse[T any](data T, message string, error bool) StandardResponse[T] {
@@ -27,6 +36,17 @@ type StandardResponse[T any] struct {
 	Error   bool   `json:"error"`
 	Data    T      `json:"data,omitempty"`
 	Message string `json:"message,omitempty"`
+	// Pagination is set by list endpoints when the request asked for a page.
+	Pagination *Pagination `json:"pagination,omitempty"`
+}
+
+// Pagination describes the page of results returned by a list endpoint.
+type Pagination struct {
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+	// HasMore is true when more results exist after this page; request the
+	// next page with offset=Offset+Limit.
+	HasMore bool `json:"hasMore"`
 }
 
 // Provider represents a provider configuration
@@ -173,6 +193,23 @@ type SessionRequest struct {
 	Source   *database.SessionSource `json:"source,omitempty"`
 }
 
+// ForkSessionRequest represents a session fork request. The fork copies the
+// history of the source session up to and including EventID, or all of it
+// when EventID is unset.
+type ForkSessionRequest struct {
+	EventID *string `json:"event_id,omitempty"`
+	Name    *string `json:"name,omitempty"`
+	ID      *string `json:"id,omitempty"`
+}
+
+// ForkSessionResponse is the session created by a fork and the number of
+// events copied into it.
+type ForkSessionResponse struct {
+	Session      *database.Session `json:"session"`
+	CopiedEvents int               `json:"copied_events"`
+	ForkedFrom   string            `json:"forked_from"`
+}
+
 // Run types
 
 // RunRequest represents a run creation request
@@ -186,9 +223,94 @@ type Task = database.Task
 // Message represents a message from the database
 type Message = database.Event
 
+// InvokeAsyncRequest starts an agent task without waiting for it to finish.
+// CallbackURL, when set, receives the finished task once it completes, fails
+// or is canceled, with CallbackToken in the A2A-Notification-Token header.
+// Budget, when set, tightens the agent's budget for the task. Attachments
+// are sent to the agent with the task, as A2A file parts.
+type InvokeAsyncRequest struct {
+	Task          string                `json:"task"`
+	SessionID     string                `json:"sessionId,omitempty"`
+	CallbackURL   string                `json:"callbackUrl,omitempty"`
+	CallbackToken string                `json:"callbackToken,omitempty"`
+	Budget        *v1alpha2.AgentBudget `json:"budget,omitempty"`
+	Attachments   []Attachment          `json:"attachments,omitempty"`
+}
+
+// Attachment is a file sent to an agent with a task. Data is base64-encoded
+// in JSON.
+type Attachment struct {
+	Name      string `json:"name,omitempty"`
+	MediaType string `json:"mediaType"`
+	Data      []byte `json:"data"`
+}
+
+// InvokeAsyncResponse identifies the task started by an async invocation.
+// Its progress can be followed with GET /api/tasks/{taskId}. An agent that
+// answers without creating a task has nothing left to run: the response then
+// has no TaskID, carries the answer in Result, and no callback follows.
+type InvokeAsyncResponse struct {
+	TaskID    string `json:"taskId,omitempty"`
+	SessionID string `json:"sessionId"`
+	State     string `json:"state"`
+	Result    string `json:"result,omitempty"`
+}
+
 // Session represents a session from the database
 type Session = database.Session
 
+// SessionGrant gives a user or group access to another user's session
+type SessionGrant = database.SessionGrant
+
+// SessionGrantRequest is the body of PUT /api/sessions/{session_id}/grants/{kind}/{principal}
+type SessionGrantRequest struct {
+	Access database.SessionAccess `json:"access"`
+}
+
+// SessionStateEntry is one key of a session's key/value state
+type SessionStateEntry = database.SessionStateEntry
+
+// SessionStateRequest is the body of PUT /api/sessions/{session_id}/state/{key}
+type SessionStateRequest struct {
+	Value json.RawMessage `json:"value"`
+}
+
+// LLMTrace is the redacted request and response of one sampled model call
+type LLMTrace = database.LLMTrace
+
+// LLMTraceRequest is the body of POST /api/sessions/{session_id}/llm-traces,
+// sent by agents with LLM trace capture enabled
+type LLMTraceRequest struct {
+	Model    string          `json:"model"`
+	Request  json.RawMessage `json:"request"`
+	Response json.RawMessage `json:"response,omitempty"`
+	Error    string          `json:"error,omitempty"`
+}
+
+// ToolApproval is a tool call held for a user's decision because the agent
+// lists the tool under requireApproval. Its task is input-required until the
+// user approves or rejects the call by sending a decision message to the
+// task over A2A.
+type ToolApproval struct {
+	// Agent is the agent serving the session, as namespace/name.
+	Agent     string `json:"agent,omitempty"`
+	TaskID    string `json:"taskId"`
+	ContextID string `json:"contextId"`
+	// ToolCallID identifies the call in batch decisions.
+	ToolCallID string         `json:"toolCallId"`
+	ToolName   string         `json:"toolName"`
+	Args       map[string]any `json:"args,omitempty"`
+	// Hint is the message shown with the approval request.
+	Hint string `json:"hint,omitempty"`
+}
+
+// SessionWithEvents is a session together with its stored events, as returned
+// by GET /api/sessions/{session_id}
+type SessionWithEvents struct {
+	Session *Session   `json:"session"`
+	Events  []*Message `json:"events"`
+}
+
 // Agent represents an agent from the database
 type Agent = database.Agent
 
@@ -209,6 +331,33 @@ type ToolServerResponse struct {
 	DiscoveredTools []*v1alpha2.MCPTool `json:"discoveredTools"`
 }
 
+// ToolCallSummary summarizes tool calls. Latency percentiles are the upper
+// bound of the latency bucket the percentile falls in, capped at the largest
+// bound.
+type ToolCallSummary struct {
+	CallCount    int64   `json:"callCount"`
+	ErrorCount   int64   `json:"errorCount"`
+	ErrorRate    float64 `json:"errorRate"`
+	P50LatencyMs int64   `json:"p50LatencyMs"`
+	P95LatencyMs int64   `json:"p95LatencyMs"`
+}
+
+// ToolServerAgentStats summarizes the calls one agent made to a tool server.
+type ToolServerAgentStats struct {
+	AgentID string `json:"agentId"`
+	ToolCallSummary
+}
+
+// ToolServerStatsResponse summarizes the calls agents made to a tool server
+// over the requested window, with the breakdown per agent and hour.
+type ToolServerStatsResponse struct {
+	Ref   string `json:"ref"`
+	Hours int    `json:"hours"`
+	ToolCallSummary
+	Agents []ToolServerAgentStats   `json:"agents"`
+	Hourly []database.ToolCallStats `json:"hourly"`
+}
+
 // Memory types
 
 // MemoryResponse represents a memory response
@@ -288,3 +437,241 @@ type SessionRunsResponse struct {
 type SessionRunsData struct {
 	Runs []any `json:"runs"`
 }
+
+// Analytics types
+
+// ProviderStatsResponse summarizes agent traffic for a single model provider
+// and model over the requested window.
+type ProviderStatsResponse struct {
+	Provider     string                        `json:"provider"`
+	Model        string                        `json:"model"`
+	RequestCount int64                         `json:"requestCount"`
+	ErrorCount   int64                         `json:"errorCount"`
+	SuccessRate  float64                       `json:"successRate"`
+	AvgLatencyMs float64                       `json:"avgLatencyMs"`
+	MaxLatencyMs int64                         `json:"maxLatencyMs"`
+	Daily        []database.ProviderDailyStats `json:"daily"`
+}
+
+// Stats types
+
+// AgentStats is the recent activity of one agent. Rates and averages cover the
+// tasks updated within the window; an agent without any is still listed while
+// it has active sessions.
+type AgentStats struct {
+	// Agent is the agent's namespace/name.
+	Agent           string  `json:"agent"`
+	ActiveSessions  int64   `json:"activeSessions"`
+	InFlightTasks   int64   `json:"inFlightTasks"`
+	FinishedTasks   int64   `json:"finishedTasks"`
+	TokensPerMinute float64 `json:"tokensPerMinute"`
+	AvgLatencyMs    float64 `json:"avgLatencyMs"`
+	ErrorRate       float64 `json:"errorRate"`
+}
+
+// StatsResponse is the live agent activity over the requested window.
+type StatsResponse struct {
+	// Window is the Go duration the stats cover, ending now.
+	Window string       `json:"window"`
+	Agents []AgentStats `json:"agents"`
+}
+
+// Retention types
+
+// PruneRequest asks the controller to delete session history now. Limits use
+// Go duration syntax, e.g. "720h"; an omitted limit defaults to the
+// controller's retention policy and "0s" (or a MaxSessions of 0) disables it.
+type PruneRequest struct {
+	DryRun                 bool   `json:"dryRun,omitempty"`
+	SessionMaxAge          string `json:"sessionMaxAge,omitempty"`
+	MaxSessions            *int   `json:"maxSessions,omitempty"`
+	TaskMaxAge             string `json:"taskMaxAge,omitempty"`
+	EventMaxAge            string `json:"eventMaxAge,omitempty"`
+	PushNotificationMaxAge string `json:"pushNotificationMaxAge,omitempty"`
+	StreamEventMaxAge      string `json:"streamEventMaxAge,omitempty"`
+}
+
+// PruneResponse counts the rows a prune deleted, or would delete on a dry run.
+type PruneResponse struct {
+	database.PruneResult
+	DryRun bool `json:"dryRun"`
+}
+
+// Debug capture types
+
+// StartDebugCaptureRequest asks the controller to record reconcile traces for
+// an object for the given duration (Go duration syntax, e.g. "5m").
+type StartDebugCaptureRequest struct {
+	Duration string `json:"duration"`
+}
+
+// DebugCaptureEntry is one recorded reconcile step. Data is the redacted JSON
+// payload attached to the step, if any.
+type DebugCaptureEntry struct {
+	Stage     string          `json:"stage"`
+	Message   string          `json:"message"`
+	Data      json.RawMessage `json:"data,omitempty"`
+	CreatedAt time.Time       `json:"createdAt"`
+}
+
+// DebugCaptureResponse is the downloadable bundle for an object's latest
+// debug capture.
+type DebugCaptureResponse struct {
+	Kind      string              `json:"kind"`
+	Namespace string              `json:"namespace"`
+	Name      string              `json:"name"`
+	CaptureID string              `json:"captureId"`
+	Until     time.Time           `json:"until"`
+	Active    bool                `json:"active"`
+	Entries   []DebugCaptureEntry `json:"entries"`
+}
+
+// Lint types
+
+// LintFinding is a single best-practice violation in an agent spec. RuleID is
+// stable across releases; Field is the JSON path of the offending field.
+type LintFinding struct {
+	RuleID   string `json:"ruleId"`
+	Severity string `json:"severity"`
+	Field    string `json:"field"`
+	Message  string `json:"message"`
+}
+
+// LintAgentResponse lists the findings for an agent spec, most severe first.
+type LintAgentResponse struct {
+	Findings []LintFinding `json:"findings"`
+	Errors   int           `json:"errors"`
+	Warnings int           `json:"warnings"`
+}
+
+// Agent validation types
+
+// Stages of the agent validation pipeline an issue can come from.
+const (
+	// ValidationStageSpec covers checks of the agent spec itself.
+	ValidationStageSpec = "spec"
+	// ValidationStageCompile covers model config, tool server and subagent
+	// resolution.
+	ValidationStageCompile = "compile"
+	// ValidationStageManifest covers generating config.json and the
+	// workload manifests.
+	ValidationStageManifest = "manifest"
+	// ValidationStageLint covers best-practice rules, as reported by
+	// POST /api/lint/agent.
+	ValidationStageLint = "lint"
+)
+
+// ValidationIssue is a single problem found while validating an agent.
+// RuleID and Field are only set for lint issues.
+type ValidationIssue struct {
+	Stage   string `json:"stage"`
+	RuleID  string `json:"ruleId,omitempty"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// ValidateAgentResponse is the outcome of validating an agent manifest. The
+// agent is valid when there are no errors; warnings do not block an apply.
+type ValidateAgentResponse struct {
+	Valid    bool              `json:"valid"`
+	Errors   []ValidationIssue `json:"errors"`
+	Warnings []ValidationIssue `json:"warnings"`
+}
+
+// Apply types
+
+// ApplyRequest creates or updates a batch of resources in one call. Each item
+// is a manifest of kind Agent, ModelConfig, RemoteMCPServer or MCPServer.
+type ApplyRequest struct {
+	Items []json.RawMessage `json:"items"`
+	// DryRun validates the items and reports what would change without
+	// applying anything.
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// Apply actions reported for each item.
+const (
+	ApplyActionCreated   = "created"
+	ApplyActionUpdated   = "updated"
+	ApplyActionUnchanged = "unchanged"
+	// ApplyActionInvalid marks an item that failed validation; when any item
+	// is invalid nothing is applied.
+	ApplyActionInvalid = "invalid"
+	// ApplyActionSkipped marks a valid item that was not applied because
+	// another item was invalid.
+	ApplyActionSkipped = "skipped"
+	// ApplyActionFailed marks an item that passed validation but could not
+	// be written.
+	ApplyActionFailed = "failed"
+)
+
+// ApplyResult is the outcome for one item of an ApplyRequest.
+type ApplyResult struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Action    string `json:"action"`
+	Error     string `json:"error,omitempty"`
+}
+
+// ApplyResponse lists the results in request order.
+type ApplyResponse struct {
+	Results []ApplyResult `json:"results"`
+	DryRun  bool          `json:"dryRun"`
+}
+
+// Agent template types
+
+// AgentTemplateParameter is a value substituted into an agent template.
+type AgentTemplateParameter struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Default     string `json:"default,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+}
+
+// AgentTemplate is an agent blueprint kept in a ConfigMap labeled
+// kagent.dev/agent-template=true.
+type AgentTemplate struct {
+	Namespace   string                   `json:"namespace"`
+	Name        string                   `json:"name"`
+	Description string                   `json:"description,omitempty"`
+	Parameters  []AgentTemplateParameter `json:"parameters,omitempty"`
+	// Template is the Agent manifest, a Go text/template over the
+	// parameters. Only set when getting a single template.
+	Template string `json:"template,omitempty"`
+}
+
+// CreateAgentFromTemplateRequest creates an Agent from a template.
+type CreateAgentFromTemplateRequest struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	// Parameters are the template parameter values; parameters left out take
+	// their default.
+	Parameters map[string]string `json:"parameters,omitempty"`
+	// DryRun renders and validates the Agent without creating it.
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// Session compaction types
+
+// SessionCompactionStatus reports how much un-compacted history a session
+// carries. EstimatedTokens approximates the prompt size at four characters per
+// token; TokenThreshold is 0 when the agent does not set one.
+type SessionCompactionStatus struct {
+	SessionID       string     `json:"sessionId"`
+	EstimatedTokens int        `json:"estimatedTokens"`
+	TokenThreshold  int        `json:"tokenThreshold"`
+	PendingEvents   int        `json:"pendingEvents"`
+	Compactions     int        `json:"compactions"`
+	LastCompactedAt *time.Time `json:"lastCompactedAt,omitempty"`
+}
+
+// CompactSessionResponse is the outcome of a compaction run. Compacted is
+// false when every event was inside the agent's retention window.
+type CompactSessionResponse struct {
+	SessionCompactionStatus
+	Compacted       bool   `json:"compacted"`
+	CompactedEvents int    `json:"compactedEvents"`
+	Summary         string `json:"summary,omitempty"`
+}
@@ -0,0 +1,167 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrStreamEventTooLarge is returned by writes to a tuned event stream that
+// exceed StreamOptions.MaxEventSize. The stream is aborted rather than sending
+// a truncated event the client could not parse.
+var ErrStreamEventTooLarge = errors.New("stream event exceeds maximum size")
+
+// StreamOptions tunes how server-sent event (text/event-stream) responses are
+// written. The zero value keeps the default behavior: every event is flushed as
+// soon as it is written, with no write deadline and no size limit.
+type StreamOptions struct {
+	// FlushInterval coalesces flushes so that buffered events are sent to the
+	// client at most this often. Zero flushes after every event.
+	FlushInterval time.Duration
+	// WriteTimeout bounds how long a single write or flush may block on a slow
+	// client or intermediary. Zero means no deadline.
+	WriteTimeout time.Duration
+	// MaxEventSize is the largest single write, in bytes, allowed on the
+	// stream. Zero means no limit.
+	MaxEventSize int
+}
+
+// IsZero reports whether o keeps the default streaming behavior.
+func (o StreamOptions) IsZero() bool {
+	return o == StreamOptions{}
+}
+
+// Merge returns o with every non-zero field of override applied on top.
+func (o StreamOptions) Merge(override StreamOptions) StreamOptions {
+	if override.FlushInterval != 0 {
+		o.FlushInterval = override.FlushInterval
+	}
+	if override.WriteTimeout != 0 {
+		o.WriteTimeout = override.WriteTimeout
+	}
+	if override.MaxEventSize != 0 {
+		o.MaxEventSize = override.MaxEventSize
+	}
+	return o
+}
+
+// NewStreamHandler wraps next so that its event-stream responses honor opts.
+// Other responses are passed through untouched. When opts is zero, next is
+// returned as is.
+func NewStreamHandler(next http.Handler, opts StreamOptions) http.Handler {
+	if opts.IsZero() {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sw := &streamResponseWriter{ResponseWriter: w, rc: http.NewResponseController(w), opts: opts}
+		defer sw.finish()
+		next.ServeHTTP(sw, r)
+	})
+}
+
+// streamResponseWriter applies StreamOptions to a response once its headers
+// identify it as an event stream.
+type streamResponseWriter struct {
+	http.ResponseWriter
+	rc   *http.ResponseController
+	opts StreamOptions
+
+	mu          sync.Mutex
+	wroteHeader bool
+	streaming   bool
+	pending     bool
+	timer       *time.Timer
+	done        bool
+}
+
+var _ http.Flusher = &streamResponseWriter{}
+
+func (w *streamResponseWriter) WriteHeader(code int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.writeHeaderLocked(code)
+}
+
+func (w *streamResponseWriter) writeHeaderLocked(code int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	w.streaming = strings.HasPrefix(w.Header().Get("Content-Type"), "text/event-stream")
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *streamResponseWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.writeHeaderLocked(http.StatusOK)
+	if !w.streaming {
+		return w.ResponseWriter.Write(p)
+	}
+	if w.opts.MaxEventSize > 0 && len(p) > w.opts.MaxEventSize {
+		return 0, fmt.Errorf("%w: %d bytes, limit is %d", ErrStreamEventTooLarge, len(p), w.opts.MaxEventSize)
+	}
+	w.setWriteDeadline()
+	return w.ResponseWriter.Write(p)
+}
+
+// Flush sends buffered data to the client immediately, or schedules it for
+// the end of the current flush interval when one is configured.
+func (w *streamResponseWriter) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.streaming || w.opts.FlushInterval <= 0 {
+		w.flushLocked()
+		return
+	}
+	w.pending = true
+	if w.timer == nil {
+		w.timer = time.AfterFunc(w.opts.FlushInterval, w.flushPending)
+	}
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (w *streamResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *streamResponseWriter) flushPending() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.timer = nil
+	if w.pending && !w.done {
+		w.flushLocked()
+	}
+}
+
+func (w *streamResponseWriter) flushLocked() {
+	w.pending = false
+	w.setWriteDeadline()
+	_ = w.rc.Flush()
+}
+
+// finish stops any scheduled flush and sends what is still buffered. The
+// handler has returned by then, so the writer must not be touched afterwards.
+func (w *streamResponseWriter) finish() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.timer != nil {
+		w.timer.Stop()
+		w.timer = nil
+	}
+	if w.pending {
+		w.flushLocked()
+	}
+	w.done = true
+}
+
+func (w *streamResponseWriter) setWriteDeadline() {
+	if w.opts.WriteTimeout > 0 {
+		// Not every writer supports deadlines (e.g. in tests); the stream
+		// still works without one.
+		_ = w.rc.SetWriteDeadline(time.Now().Add(w.opts.WriteTimeout))
+	}
+}
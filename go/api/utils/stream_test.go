@@ -0,0 +1,110 @@
+package utils
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// flushRecorder counts flushes reaching the underlying writer.
+type flushRecorder struct {
+	*httptest.ResponseRecorder
+	flushes int
+}
+
+func (f *flushRecorder) Flush() {
+	f.flushes++
+	f.ResponseRecorder.Flush()
+}
+
+func sseHandler(events ...string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		for _, e := range events {
+			if _, err := w.Write([]byte("data: " + e + "\n\n")); err != nil {
+				return
+			}
+			w.(http.Flusher).Flush()
+		}
+	})
+}
+
+func TestStreamHandler(t *testing.T) {
+	tests := []struct {
+		name        string
+		handler     http.Handler
+		opts        StreamOptions
+		wantBody    string
+		wantFlushes int
+	}{
+		{
+			name:        "flushes every event by default",
+			handler:     sseHandler("a", "b", "c"),
+			opts:        StreamOptions{WriteTimeout: time.Second},
+			wantBody:    "data: a\n\ndata: b\n\ndata: c\n\n",
+			wantFlushes: 3,
+		},
+		{
+			name:        "coalesces flushes within the interval",
+			handler:     sseHandler("a", "b", "c"),
+			opts:        StreamOptions{FlushInterval: time.Hour},
+			wantBody:    "data: a\n\ndata: b\n\ndata: c\n\n",
+			wantFlushes: 1,
+		},
+		{
+			name:        "aborts on oversized events",
+			handler:     sseHandler("a", strings.Repeat("x", 64), "c"),
+			opts:        StreamOptions{MaxEventSize: 32},
+			wantBody:    "data: a\n\n",
+			wantFlushes: 1,
+		},
+		{
+			name: "leaves other responses alone",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(strings.Repeat("x", 64)))
+			}),
+			opts:     StreamOptions{MaxEventSize: 32},
+			wantBody: strings.Repeat("x", 64),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &flushRecorder{ResponseRecorder: httptest.NewRecorder()}
+			NewStreamHandler(tt.handler, tt.opts).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", nil))
+
+			if got := rec.Body.String(); got != tt.wantBody {
+				t.Errorf("body = %q, want %q", got, tt.wantBody)
+			}
+			if rec.flushes != tt.wantFlushes {
+				t.Errorf("flushes = %d, want %d", rec.flushes, tt.wantFlushes)
+			}
+		})
+	}
+}
+
+func TestStreamHandlerOversizedWriteError(t *testing.T) {
+	var writeErr error
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, writeErr = w.Write(make([]byte, 10))
+	})
+	NewStreamHandler(handler, StreamOptions{MaxEventSize: 5}).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/", nil))
+	if !errors.Is(writeErr, ErrStreamEventTooLarge) {
+		t.Errorf("write error = %v, want ErrStreamEventTooLarge", writeErr)
+	}
+}
+
+func TestStreamOptionsMerge(t *testing.T) {
+	base := StreamOptions{FlushInterval: time.Second, MaxEventSize: 1024}
+	got := base.Merge(StreamOptions{WriteTimeout: time.Minute, MaxEventSize: 2048})
+	want := StreamOptions{FlushInterval: time.Second, WriteTimeout: time.Minute, MaxEventSize: 2048}
+	if got != want {
+		t.Errorf("Merge() = %+v, want %+v", got, want)
+	}
+}
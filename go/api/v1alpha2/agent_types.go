@@ -20,7 +20,10 @@ import (
 	"context"
 	"fmt"
 
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -46,6 +49,18 @@ const (
 	DeclarativeRuntime_Go     DeclarativeRuntime = "go"
 )
 
+// AgentDebugCaptureUntilAnnotation turns on object-scoped debug capture for an
+// Agent. While the RFC3339 timestamp it holds is in the future, the controller
+// records reconcile traces, translator inputs/outputs and the writes it makes
+// for that agent, keyed by the annotation value. Set it through
+// `kagent debug agent <name> --capture <duration>`.
+const AgentDebugCaptureUntilAnnotation = "kagent.dev/debug-capture-until"
+
+// AgentLastVerifiedSpecAnnotation holds the JSON of the last Agent spec whose
+// rollout passed its smoke tests. The controller restores it when a later
+// rollout fails and spec.smokeTests.rollbackOnFailure is set.
+const AgentLastVerifiedSpecAnnotation = "kagent.dev/last-verified-spec"
+
 // AgentSpec defines the desired state of Agent.
 // +kubebuilder:validation:XValidation:message="type must be specified",rule="has(self.type)"
 // +kubebuilder:validation:XValidation:message="type must be either Declarative or BYO",rule="self.type == 'Declarative' || self.type == 'BYO'"
@@ -102,6 +117,18 @@ type AgentSpec struct {
 	// +optional
 	Sandbox *SandboxConfig `json:"sandbox,omitempty"`
 
+	// Streaming tunes how server-sent event responses for this agent are
+	// written, overriding the controller defaults. Declarative agents only
+	// support it with the go runtime; the controller rejects it for others.
+	// +optional
+	Streaming *StreamingConfig `json:"streaming,omitempty"`
+
+	// SmokeTests are prompts the controller sends to the agent after each
+	// completed rollout. Results are reported in the PostRolloutVerified
+	// condition and status.smokeTests. SandboxAgents are not verified.
+	// +optional
+	SmokeTests *SmokeTestSpec `json:"smokeTests,omitempty"`
+
 	// AllowedNamespaces defines which namespaces are allowed to reference this Agent as a tool.
 	// This follows the Gateway API pattern for cross-namespace route attachments.
 	// If not specified, only Agents in the same namespace can reference this Agent as a tool.
@@ -111,6 +138,49 @@ type AgentSpec struct {
 	AllowedNamespaces *AllowedNamespaces `json:"allowedNamespaces,omitempty"`
 }
 
+// SmokeTestSpec configures post-rollout verification of an agent.
+type SmokeTestSpec struct {
+	// Prompts are sent to the agent in order, each in a new session.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=10
+	Prompts []SmokeTestPrompt `json:"prompts"`
+	// Timeout bounds each prompt. Defaults to 2m.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// RollbackOnFailure restores the last spec that passed its smoke tests when
+	// a rollout fails them. Nothing is rolled back until one rollout has passed.
+	// +optional
+	RollbackOnFailure bool `json:"rollbackOnFailure,omitempty"`
+}
+
+// SmokeTestPrompt is a single prompt and the assertions on the agent's reply.
+type SmokeTestPrompt struct {
+	// Name identifies the prompt in status.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+	// Prompt is the user message sent to the agent.
+	// +kubebuilder:validation:MinLength=1
+	Prompt string `json:"prompt"`
+	// Expect holds the assertions on the reply. With no assertions the prompt
+	// passes when the agent replies without error.
+	// +optional
+	Expect SmokeTestExpectation `json:"expect,omitempty"`
+}
+
+// SmokeTestExpectation are assertions on an agent's reply. All must hold.
+type SmokeTestExpectation struct {
+	// Contains are substrings the reply must include, case-insensitively.
+	// +optional
+	Contains []string `json:"contains,omitempty"`
+	// NotContains are substrings the reply must not include, case-insensitively.
+	// +optional
+	NotContains []string `json:"notContains,omitempty"`
+	// Matches is a regular expression (RE2 syntax) the reply must match.
+	// +optional
+	Matches string `json:"matches,omitempty"`
+}
+
 // AgentProvider identifies the organization responsible for an agent on its A2A AgentCard.
 type AgentProvider struct {
 	// Organization is the name of the agent provider's organization.
@@ -131,7 +201,9 @@ type SkillForAgent struct {
 	// +optional
 	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
 
-	// The list of skill images to fetch.
+	// The list of skill images to fetch. The controller resolves each tag to
+	// the digest it points at and the agent's pods fetch that digest, so they
+	// all run the same skill contents; status.skills reports the digests.
 	// +kubebuilder:validation:MaxItems=20
 	// +kubebuilder:validation:MinItems=1
 	// +optional
@@ -200,6 +272,9 @@ type GitRepo struct {
 }
 
 // +kubebuilder:validation:XValidation:rule="!has(self.systemMessage) || !has(self.systemMessageFrom)",message="systemMessage and systemMessageFrom are mutually exclusive"
+// +kubebuilder:validation:XValidation:rule="!has(self.promptCapture) || !has(self.runtime) || self.runtime == 'go'",message="promptCapture is only supported by the go runtime"
+// +kubebuilder:validation:XValidation:rule="!has(self.llmTrace) || !has(self.runtime) || self.runtime == 'go'",message="llmTrace is only supported by the go runtime"
+// +kubebuilder:validation:XValidation:rule="!has(self.memory) || !has(self.memory.qdrant) || !has(self.runtime) || self.runtime == 'go'",message="memory.qdrant is only supported by the go runtime"
 type DeclarativeAgentSpec struct {
 	// Runtime specifies which ADK implementation to use for this agent.
 	// - "go": Uses the Go ADK (default, faster startup, most features supported)
@@ -268,6 +343,387 @@ type DeclarativeAgentSpec struct {
 	// This includes event compaction (compression) and context caching.
 	// +optional
 	Context *ContextConfig `json:"context,omitempty"`
+
+	// PromptCapture saves sampled, redacted model prompt/response pairs to a
+	// volume as JSONL, for curating fine-tuning or evaluation datasets.
+	// Only supported by the go runtime; the controller rejects it for others.
+	// +optional
+	PromptCapture *PromptCaptureSpec `json:"promptCapture,omitempty"`
+
+	// LLMTrace stores the redacted request and response of sampled model
+	// calls with their session, served at /api/sessions/{id}/llm-traces, for
+	// debugging prompts. A session can override the sample rate with its
+	// "kagent:llm_trace_sample_rate" state key, which works without this field.
+	// Only supported by the go runtime; the controller rejects it for others.
+	// +optional
+	LLMTrace *LLMTraceSpec `json:"llmTrace,omitempty"`
+
+	// PromptInjection scans tool results, including retrieved memories, for
+	// prompt-injection attempts (instructions to exfiltrate secrets, override
+	// the system prompt, impersonate other roles) before they reach the model.
+	// +optional
+	PromptInjection *PromptInjectionSpec `json:"promptInjection,omitempty"`
+
+	// ToolPolicy restricts which MCP tools the agent can use, by name. It is
+	// applied on top of each tool's toolNames and also to tools an MCP server
+	// adds after the agent starts.
+	// +optional
+	ToolPolicy *ToolPolicy `json:"toolPolicy,omitempty"`
+
+	// ToolResultLimit caps the size of each MCP tool result before it is added
+	// to the conversation, so large outputs such as `kubectl get -o json` do not
+	// overflow the model's context window.
+	// +optional
+	ToolResultLimit *ToolResultLimitSpec `json:"toolResultLimit,omitempty"`
+
+	// ToolResultValidation checks each MCP tool result against the output
+	// schema its tool declares. A result that does not match reaches the model
+	// as a tool error naming the violation instead of as data. Tools without
+	// an output schema are not checked.
+	// +optional
+	ToolResultValidation *ToolResultValidationSpec `json:"toolResultValidation,omitempty"`
+
+	// DryRunTools lists the tools that a dry-run invocation previews instead
+	// of running, as glob patterns like toolPolicy entries. A tool whose input
+	// schema has a `dry_run` parameter is called with it set to true, so the
+	// server can return a server-side dry-run or `helm --dry-run` diff; other
+	// matching tools are not called and the model is told what would have run.
+	// Defaults to the mutating tools of the kagent tool server, such as
+	// k8s_apply_manifest, k8s_delete_resource and helm_upgrade.
+	// +kubebuilder:validation:MaxItems=50
+	// +optional
+	DryRunTools []string `json:"dryRunTools,omitempty"`
+
+	// Timeouts bounds how long the agent waits on its model, its tools and
+	// each task. Unset timeouts keep the runtime defaults.
+	// +optional
+	Timeouts *AgentTimeouts `json:"timeouts,omitempty"`
+
+	// Moderation checks the model's responses, and optionally the user's
+	// messages, against content rules before they are shown to the user or
+	// sent to the model.
+	// +optional
+	Moderation *ModerationSpec `json:"moderation,omitempty"`
+
+	// Budget bounds the tokens, tool calls and model turns the agent spends on
+	// a task, so a task that loops is ended with a summary of the work done
+	// instead of running until it times out. Invocations can lower the limits
+	// but not raise them.
+	// +optional
+	Budget *AgentBudget `json:"budget,omitempty"`
+}
+
+// ToolResultTruncationStrategy is how a tool result over the limit is cut down.
+// +kubebuilder:validation:Enum=Head;Tail;Summarize;Paginate
+type ToolResultTruncationStrategy string
+
+const (
+	// ToolResultTruncationHead keeps the beginning of the result.
+	ToolResultTruncationHead ToolResultTruncationStrategy = "Head"
+	// ToolResultTruncationTail keeps the end of the result.
+	ToolResultTruncationTail ToolResultTruncationStrategy = "Tail"
+	// ToolResultTruncationSummarize replaces the result with a summary written
+	// by the agent's model, falling back to Head when summarization fails.
+	ToolResultTruncationSummarize ToolResultTruncationStrategy = "Summarize"
+	// ToolResultTruncationPaginate returns the first page of the result and
+	// gives the model a tool to read the following pages.
+	ToolResultTruncationPaginate ToolResultTruncationStrategy = "Paginate"
+)
+
+// ToolResultLimitSpec configures the size limit on tool results. At least one
+// of maxBytes and maxTokens must be set; when both are, the smaller applies.
+// Tokens are estimated at four bytes each.
+// +kubebuilder:validation:XValidation:rule="has(self.maxBytes) || has(self.maxTokens)",message="at least one of maxBytes or maxTokens must be set"
+type ToolResultLimitSpec struct {
+	// MaxBytes is the largest result, in bytes, passed to the model unchanged.
+	// +kubebuilder:validation:Minimum=1024
+	// +optional
+	MaxBytes *int32 `json:"maxBytes,omitempty"`
+	// MaxTokens is the largest result, in estimated tokens, passed to the
+	// model unchanged.
+	// +kubebuilder:validation:Minimum=256
+	// +optional
+	MaxTokens *int32 `json:"maxTokens,omitempty"`
+	// Strategy applied to results over the limit. Defaults to Head.
+	// +kubebuilder:default=Head
+	// +optional
+	Strategy ToolResultTruncationStrategy `json:"strategy,omitempty"`
+	// ExcludeTools lists tool names whose results are never limited.
+	// +kubebuilder:validation:MaxItems=50
+	// +optional
+	ExcludeTools []string `json:"excludeTools,omitempty"`
+}
+
+// ToolResultValidationSpec configures validation of tool results against
+// their tool's output schema.
+type ToolResultValidationSpec struct {
+	// Coerce converts values that only differ from the schema in their JSON
+	// type, such as numbers or booleans sent as strings, instead of rejecting
+	// the result. Defaults to true.
+	// +kubebuilder:default=true
+	// +optional
+	Coerce *bool `json:"coerce,omitempty"`
+	// ExcludeTools lists tool names whose results are never validated.
+	// +kubebuilder:validation:MaxItems=50
+	// +optional
+	ExcludeTools []string `json:"excludeTools,omitempty"`
+}
+
+// AgentTimeouts bounds the work of a declarative agent. A model request or
+// tool call that times out fails like any other; a task that times out is
+// cancelled and marked failed.
+type AgentTimeouts struct {
+	// LLMRequest bounds each request to the model, including the streaming of
+	// its response. A request that times out may fail over to the model
+	// fallbacks configured on the ModelConfig.
+	// +optional
+	LLMRequest *metav1.Duration `json:"llmRequest,omitempty"`
+	// ToolCall bounds each MCP tool call. The model is told the call timed out
+	// and can retry it or carry on without the result.
+	// +optional
+	ToolCall *metav1.Duration `json:"toolCall,omitempty"`
+	// Tools overrides toolCall for the named tools, e.g. to give a slow
+	// `helm_upgrade` more time than the rest.
+	// +kubebuilder:validation:MaxItems=50
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Tools []ToolTimeout `json:"tools,omitempty"`
+	// Task bounds how long the agent works on a task before it answers or
+	// asks for input. The bound restarts when the user replies, so time spent
+	// waiting for input or approval does not count.
+	// +optional
+	Task *metav1.Duration `json:"task,omitempty"`
+}
+
+// AgentBudget bounds the work of a declarative agent on a task. When a limit
+// is reached, the agent makes no further model requests or tool calls and the
+// task completes with a summary of the work done, marked with the
+// budget_exceeded task metadata key. Like the task timeout, the task limits
+// restart when the user replies to a request for input or approval.
+type AgentBudget struct {
+	// MaxTotalTokens bounds the model tokens, input and output, of a task.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxTotalTokens *int64 `json:"maxTotalTokens,omitempty"`
+	// MaxSessionTokens bounds the model tokens of the whole session, including
+	// its earlier tasks. Once reached, new tasks in the session end at once.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxSessionTokens *int64 `json:"maxSessionTokens,omitempty"`
+	// MaxToolCalls bounds the tool calls of a task.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxToolCalls *int32 `json:"maxToolCalls,omitempty"`
+	// MaxTurns bounds the model requests of a task.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxTurns *int32 `json:"maxTurns,omitempty"`
+}
+
+// ToolTimeout is the timeout of the calls of a single tool.
+type ToolTimeout struct {
+	// Name is the name of the tool as the model sees it.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Timeout bounds each call of the tool.
+	Timeout metav1.Duration `json:"timeout"`
+}
+
+// ToolPolicy is an allowlist and denylist of tool names. Entries are glob
+// patterns where `*` matches any run of characters, `?` a single character
+// and `[...]` a character class, e.g. `kubectl_*` or `*delete*`. A tool is
+// usable when it matches an allow entry (or allow is empty) and matches no
+// deny entry.
+type ToolPolicy struct {
+	// Allow lists the tool names the agent may use. Empty allows all tools.
+	// +kubebuilder:validation:MaxItems=50
+	// +optional
+	Allow []string `json:"allow,omitempty"`
+	// Deny lists tool names the agent may not use. Deny wins over allow.
+	// +kubebuilder:validation:MaxItems=50
+	// +optional
+	Deny []string `json:"deny,omitempty"`
+}
+
+// PromptInjectionAction is what happens to a tool result that matches a
+// prompt-injection pattern.
+// +kubebuilder:validation:Enum=Flag;Redact;Block
+type PromptInjectionAction string
+
+const (
+	// PromptInjectionActionFlag passes the result through with a warning that
+	// tells the model to treat it as data, not instructions.
+	PromptInjectionActionFlag PromptInjectionAction = "Flag"
+	// PromptInjectionActionRedact removes the matching text and adds the warning.
+	PromptInjectionActionRedact PromptInjectionAction = "Redact"
+	// PromptInjectionActionBlock replaces the whole result with an error.
+	PromptInjectionActionBlock PromptInjectionAction = "Block"
+)
+
+// PromptInjectionSpec configures prompt-injection detection on tool results.
+type PromptInjectionSpec struct {
+	// Action taken when a tool result matches. Defaults to Flag.
+	// +kubebuilder:default=Flag
+	// +optional
+	Action PromptInjectionAction `json:"action,omitempty"`
+	// Patterns are additional regular expressions treated as injection
+	// attempts, on top of the built-in ones.
+	// +kubebuilder:validation:MaxItems=20
+	// +optional
+	Patterns []string `json:"patterns,omitempty"`
+	// ExcludeTools lists tool names whose results are not scanned.
+	// +kubebuilder:validation:MaxItems=50
+	// +optional
+	ExcludeTools []string `json:"excludeTools,omitempty"`
+}
+
+// ModerationAction is what happens to a message that fails moderation.
+// +kubebuilder:validation:Enum=Block;Redact;Flag
+type ModerationAction string
+
+const (
+	// ModerationActionBlock replaces the whole message with a notice.
+	ModerationActionBlock ModerationAction = "Block"
+	// ModerationActionRedact removes the text that matched a keyword or
+	// pattern. A message flagged by the provider, which does not say which
+	// text it objects to, is blocked.
+	ModerationActionRedact ModerationAction = "Redact"
+	// ModerationActionFlag passes the message through and marks it in the
+	// metadata of its event.
+	ModerationActionFlag ModerationAction = "Flag"
+)
+
+// ModerationProviderType selects the service that classifies messages.
+// +kubebuilder:validation:Enum=OpenAI;HTTP
+type ModerationProviderType string
+
+const (
+	// ModerationProviderOpenAI uses the OpenAI moderations API.
+	ModerationProviderOpenAI ModerationProviderType = "OpenAI"
+	// ModerationProviderHTTP posts {"text": ..., "direction": "input" or
+	// "output"} to a custom endpoint, which answers {"flagged": bool,
+	// "categories": [...]}.
+	ModerationProviderHTTP ModerationProviderType = "HTTP"
+)
+
+// ModerationSpec configures content moderation of an agent's messages.
+// +kubebuilder:validation:XValidation:message="at least one of keywords, patterns and provider must be set",rule="has(self.keywords) || has(self.patterns) || has(self.provider)"
+type ModerationSpec struct {
+	// Action taken when a message fails moderation. Defaults to Block.
+	// +kubebuilder:default=Block
+	// +optional
+	Action ModerationAction `json:"action,omitempty"`
+	// Keywords are words and phrases that fail moderation, matched as whole
+	// words regardless of case.
+	// +kubebuilder:validation:MaxItems=200
+	// +optional
+	Keywords []string `json:"keywords,omitempty"`
+	// Patterns are regular expressions whose matches fail moderation.
+	// +kubebuilder:validation:MaxItems=50
+	// +optional
+	Patterns []string `json:"patterns,omitempty"`
+	// Provider is a moderation service that classifies each message after the
+	// keywords and patterns have passed it.
+	// +optional
+	Provider *ModerationProvider `json:"provider,omitempty"`
+	// CheckInput also moderates the user's messages before they are sent to
+	// the model. A blocked message is answered with a notice instead.
+	// +optional
+	CheckInput bool `json:"checkInput,omitempty"`
+}
+
+// ModerationProvider is a moderation service called by the agent.
+// +kubebuilder:validation:XValidation:message="url is required for the HTTP provider",rule="self.type != 'HTTP' || has(self.url)"
+// +kubebuilder:validation:XValidation:message="apiKeySecretKey is required with apiKeySecret",rule="!has(self.apiKeySecret) || has(self.apiKeySecretKey)"
+type ModerationProvider struct {
+	// +required
+	Type ModerationProviderType `json:"type"`
+	// URL of the service. For OpenAI it is the base URL of the API and
+	// defaults to https://api.openai.com/v1; for HTTP it is the endpoint
+	// messages are posted to.
+	// +kubebuilder:validation:Pattern=`^https?://`
+	// +optional
+	URL string `json:"url,omitempty"`
+	// Model is the OpenAI moderation model. Defaults to omni-moderation-latest.
+	// +optional
+	Model string `json:"model,omitempty"`
+	// APIKeySecret is the name of a Secret in the agent's namespace that
+	// holds the API key, sent as a bearer token.
+	// +optional
+	APIKeySecret string `json:"apiKeySecret,omitempty"`
+	// APIKeySecretKey is the key in APIKeySecret that holds the API key.
+	// +optional
+	APIKeySecretKey string `json:"apiKeySecretKey,omitempty"`
+	// FailOpen passes messages through when the service cannot be reached or
+	// answers with an error. By default such messages fail moderation.
+	// +optional
+	FailOpen bool `json:"failOpen,omitempty"`
+}
+
+// PromptCaptureSpec configures capture of model prompt/response pairs.
+type PromptCaptureSpec struct {
+	// ClaimName is the PersistentVolumeClaim, in the agent's namespace, that
+	// captures are written to. Each agent writes under its own directory, one
+	// file per pod, so a ReadWriteMany claim can be shared by several agents.
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	ClaimName string `json:"claimName"`
+	// SampleRate is the fraction of model calls to capture, between "0" and "1".
+	// Defaults to "1" (every call).
+	// +kubebuilder:validation:Pattern=`^(0(\.[0-9]+)?|1(\.0+)?)$`
+	// +optional
+	SampleRate string `json:"sampleRate,omitempty"`
+	// MaxFileSize rotates to a new capture file once the current one reaches
+	// this size. Defaults to 100Mi.
+	// +optional
+	MaxFileSize *resource.Quantity `json:"maxFileSize,omitempty"`
+	// MaxTotalSize stops capturing once this agent's files on the volume
+	// reach this size. Defaults to 1Gi.
+	// +optional
+	MaxTotalSize *resource.Quantity `json:"maxTotalSize,omitempty"`
+	// RedactPatterns are additional regular expressions whose matches are
+	// replaced before a capture is written. Common credentials (API keys,
+	// bearer tokens, JWTs, passwords in key=value form) are always redacted.
+	// +kubebuilder:validation:MaxItems=20
+	// +optional
+	RedactPatterns []string `json:"redactPatterns,omitempty"`
+}
+
+// LLMTraceSpec configures recording of model calls for debugging.
+type LLMTraceSpec struct {
+	// SampleRate is the fraction of model calls to record, between "0" and "1".
+	// Defaults to "1" (every call).
+	// +kubebuilder:validation:Pattern=`^(0(\.[0-9]+)?|1(\.0+)?)$`
+	// +optional
+	SampleRate string `json:"sampleRate,omitempty"`
+	// RedactPatterns are additional regular expressions whose matches are
+	// replaced before a trace is stored. Common credentials (API keys, bearer
+	// tokens, JWTs, passwords in key=value form) are always redacted.
+	// +kubebuilder:validation:MaxItems=20
+	// +optional
+	RedactPatterns []string `json:"redactPatterns,omitempty"`
+}
+
+// StreamingConfig tunes server-sent event (SSE) streams. It applies to the
+// controller's A2A proxy and, for the Go runtime, to the agent's own server.
+// Some ingress controllers and proxies hold back small writes, which makes a
+// stream look frozen; these settings let operators work around that.
+type StreamingConfig struct {
+	// FlushInterval batches stream writes and flushes them at most this often.
+	// When unset, every event is flushed as soon as it is written.
+	// +optional
+	FlushInterval *metav1.Duration `json:"flushInterval,omitempty"`
+	// WriteTimeout bounds how long a single write may block on a slow client
+	// before the stream is aborted. When unset, writes have no deadline.
+	// +optional
+	WriteTimeout *metav1.Duration `json:"writeTimeout,omitempty"`
+	// MaxEventSize is the largest single event, in bytes, that may be written
+	// to a stream. A larger event aborts the stream. When unset, events are not
+	// limited.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxEventSize *int32 `json:"maxEventSize,omitempty"`
 }
 
 // SandboxSubstrateSpec configures Agent Substrate for a SandboxAgent.
@@ -383,7 +839,19 @@ type PromptSource struct {
 	Alias string `json:"alias,omitempty"`
 }
 
+// MemoryProvider is the vector store that holds an agent's long-term memory.
+// +kubebuilder:validation:Enum=Postgres;Qdrant
+type MemoryProvider string
+
+const (
+	// MemoryProviderPostgres stores memories in the kagent database using pgvector.
+	MemoryProviderPostgres MemoryProvider = "Postgres"
+	// MemoryProviderQdrant stores memories in a Qdrant collection.
+	MemoryProviderQdrant MemoryProvider = "Qdrant"
+)
+
 // MemorySpec enables long-term memory for an agent.
+// +kubebuilder:validation:XValidation:message="qdrant must be set if and only if provider is Qdrant",rule="(has(self.provider) && self.provider == 'Qdrant') == has(self.qdrant)"
 type MemorySpec struct {
 	// ModelConfig is the name of the ModelConfig object whose embedding
 	// provider will be used to generate memory vectors.
@@ -395,6 +863,51 @@ type MemorySpec struct {
 	// +optional
 	// +kubebuilder:validation:Minimum=1
 	TTLDays int `json:"ttlDays,omitempty"`
+
+	// Provider is the vector store memories are kept in. Postgres uses the
+	// kagent database; Qdrant uses the collection configured in qdrant.
+	// +optional
+	// +kubebuilder:default=Postgres
+	Provider MemoryProvider `json:"provider,omitempty"`
+
+	// Qdrant configures the Qdrant memory provider.
+	// +optional
+	Qdrant *QdrantMemoryConfig `json:"qdrant,omitempty"`
+}
+
+// QdrantMemoryConfig points an agent's memory at a Qdrant collection. The
+// controller creates the collection and its payload indexes if they are
+// missing and prunes expired memories from it; it never deletes the
+// collection.
+type QdrantMemoryConfig struct {
+	// URL is the base URL of the Qdrant REST API, e.g. http://qdrant.qdrant:6333.
+	// +kubebuilder:validation:Pattern=`^https?://`
+	// +required
+	URL string `json:"url"`
+
+	// Collection is the name of the Qdrant collection. Defaults to
+	// kagent-<namespace>-<name> of the agent.
+	// +kubebuilder:validation:MaxLength=255
+	// +optional
+	Collection string `json:"collection,omitempty"`
+
+	// APIKeySecret is the name of a Secret in the agent's namespace that
+	// holds the Qdrant API key.
+	// +optional
+	APIKeySecret string `json:"apiKeySecret,omitempty"`
+
+	// APIKeySecretKey is the key in APIKeySecret that holds the API key.
+	// +optional
+	APIKeySecretKey string `json:"apiKeySecretKey,omitempty"`
+}
+
+// CollectionName returns the Qdrant collection used by the agent
+// namespace/name.
+func (q *QdrantMemoryConfig) CollectionName(namespace, name string) string {
+	if q.Collection != "" {
+		return q.Collection
+	}
+	return "kagent-" + namespace + "-" + name
 }
 
 type DeclarativeDeploymentSpec struct {
@@ -430,10 +943,28 @@ type ByoDeploymentSpec struct {
 }
 
 // +kubebuilder:validation:XValidation:message="serviceAccountName and serviceAccountConfig are mutually exclusive",rule="!(has(self.serviceAccountName) && has(self.serviceAccountConfig))"
+// +kubebuilder:validation:XValidation:message="replicas and autoscaling are mutually exclusive",rule="!(has(self.replicas) && has(self.autoscaling))"
+// +kubebuilder:validation:XValidation:message="scaleToZero and autoscaling are mutually exclusive",rule="!(has(self.scaleToZero) && has(self.autoscaling))"
 type SharedDeploymentSpec struct {
 	// Replicas is the number of desired agent pods. Defaults to 1.
 	// +optional
 	Replicas *int32 `json:"replicas,omitempty"`
+	// Autoscaling creates a HorizontalPodAutoscaler that scales the agent
+	// Deployment. This field is mutually exclusive with Replicas.
+	// +optional
+	Autoscaling *AutoscalingSpec `json:"autoscaling,omitempty"`
+	// ScaleToZero scales the agent Deployment to zero after a period without
+	// invocations. The next A2A request scales it back to Replicas and is held
+	// until a pod is ready. This field is mutually exclusive with Autoscaling
+	// and not supported for sandbox agents.
+	// +optional
+	ScaleToZero *ScaleToZeroSpec `json:"scaleToZero,omitempty"`
+	// NetworkPolicy creates a NetworkPolicy that restricts the egress of the
+	// agent pods to DNS, the kagent controller, and the model providers, MCP
+	// servers and agents the agent uses. It is not supported for sandbox
+	// agents.
+	// +optional
+	NetworkPolicy *AgentNetworkPolicySpec `json:"networkPolicy,omitempty"`
 	// ImagePullSecrets are references to secrets in the agent's namespace
 	// used for pulling the agent container image.
 	// +optional
@@ -455,6 +986,8 @@ type SharedDeploymentSpec struct {
 	Env []corev1.EnvVar `json:"env,omitempty"`
 	// +optional
 	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+	// Resources of the agent container. Extended resources such as
+	// nvidia.com/gpu are passed through; set them in limits.
 	// +optional
 	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
 	// Tolerations applied to the agent pods.
@@ -465,6 +998,10 @@ type SharedDeploymentSpec struct {
 	// NodeSelector restricts the nodes the agent pods can be scheduled on.
 	// +optional
 	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// RuntimeClassName is the RuntimeClass the agent pods run with, such as
+	// one for GPU workloads or a sandboxed runtime.
+	// +optional
+	RuntimeClassName *string `json:"runtimeClassName,omitempty"`
 	// +optional
 	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
 	// +optional
@@ -484,6 +1021,90 @@ type SharedDeploymentSpec struct {
 	// Useful for sidecars such as token proxies, log shippers, or security agents.
 	// +optional
 	ExtraContainers []corev1.Container `json:"extraContainers,omitempty"`
+	// InitContainers run to completion, in order, before the agent container
+	// starts. They can mount any of the pod's volumes, e.g. to render config
+	// or fetch credentials into a volume shared with the agent container.
+	// +optional
+	InitContainers []corev1.Container `json:"initContainers,omitempty"`
+	// Sidecars are containers started before the agent container and kept
+	// running alongside it, such as credential brokers or localhost proxies
+	// the agent needs from its first request. They are rendered as native
+	// sidecars (init containers with restartPolicy Always) and can mount any
+	// of the pod's volumes.
+	// +optional
+	Sidecars []corev1.Container `json:"sidecars,omitempty"`
+}
+
+// SharedDeployment returns the deployment settings of a declarative or BYO
+// agent, or nil when none are set.
+func (s *AgentSpec) SharedDeployment() *SharedDeploymentSpec {
+	switch {
+	case s.Declarative != nil && s.Declarative.Deployment != nil:
+		return &s.Declarative.Deployment.SharedDeploymentSpec
+	case s.BYO != nil && s.BYO.Deployment != nil:
+		return &s.BYO.Deployment.SharedDeploymentSpec
+	}
+	return nil
+}
+
+// AutoscalingSpec configures the HorizontalPodAutoscaler of an agent. When no
+// target is set, the agent scales on 80% average CPU utilization.
+// +kubebuilder:validation:XValidation:message="maxReplicas must be greater than or equal to minReplicas",rule="!has(self.minReplicas) || self.maxReplicas >= self.minReplicas"
+type AutoscalingSpec struct {
+	// MinReplicas is the lower limit of agent pods. Defaults to 1.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+	// MaxReplicas is the upper limit of agent pods.
+	// +kubebuilder:validation:Minimum=1
+	MaxReplicas int32 `json:"maxReplicas"`
+	// TargetCPUUtilizationPercentage is the average CPU utilization, as a
+	// percentage of the requested CPU, to scale on.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	TargetCPUUtilizationPercentage *int32 `json:"targetCPUUtilizationPercentage,omitempty"`
+	// TargetMemoryUtilizationPercentage is the average memory utilization, as
+	// a percentage of the requested memory, to scale on.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	TargetMemoryUtilizationPercentage *int32 `json:"targetMemoryUtilizationPercentage,omitempty"`
+	// RequestsPerSecond scales on the request rate per pod. It requires a
+	// custom metrics adapter, such as prometheus-adapter, serving the metric.
+	// +optional
+	RequestsPerSecond *RequestsPerSecondTarget `json:"requestsPerSecond,omitempty"`
+}
+
+// ScaleToZeroSpec configures idle scale-to-zero of an agent.
+type ScaleToZeroSpec struct {
+	// IdleTimeout is how long the agent must go without invocations before it
+	// is scaled to zero, e.g. "30m".
+	IdleTimeout metav1.Duration `json:"idleTimeout"`
+	// ColdStartTimeout bounds how long a request to a scaled-down agent is
+	// held while the agent starts. Defaults to 2m.
+	// +optional
+	ColdStartTimeout *metav1.Duration `json:"coldStartTimeout,omitempty"`
+}
+
+// AgentNetworkPolicySpec configures the egress NetworkPolicy of an agent.
+// Endpoints served by a Service in the cluster are allowed to the pods behind
+// it. Other hostnames are allowed by port only, as a NetworkPolicy matches IP
+// addresses rather than names.
+type AgentNetworkPolicySpec struct {
+	// AdditionalEgress are egress rules allowed on top of the generated ones,
+	// e.g. for endpoints called by extra containers or the agent's own code.
+	// +optional
+	AdditionalEgress []networkingv1.NetworkPolicyEgressRule `json:"additionalEgress,omitempty"`
+}
+
+// RequestsPerSecondTarget is a per-pod request rate target read from the
+// custom metrics API.
+type RequestsPerSecondTarget struct {
+	// MetricName is the name of the pods metric holding the request rate.
+	// +optional
+	// +kubebuilder:default=http_requests_per_second
+	MetricName string `json:"metricName,omitempty"`
+	// AverageValue is the target request rate per pod.
+	AverageValue resource.Quantity `json:"averageValue"`
 }
 
 type ServiceAccountConfig struct {
@@ -493,6 +1114,33 @@ type ServiceAccountConfig struct {
 	// Annotations are additional annotations added to the created ServiceAccount.
 	// +optional
 	Annotations map[string]string `json:"annotations,omitempty"`
+	// Rules are the Kubernetes permissions the agent needs in its namespace.
+	// They are granted to the created ServiceAccount, which is used even when
+	// a default ServiceAccount is configured for agents, through a Role and
+	// RoleBinding named after the agent. Each rule must be within the rules the
+	// kagent administrator allows agents to be granted.
+	// +kubebuilder:validation:MaxItems=32
+	// +optional
+	Rules []ServiceAccountRule `json:"rules,omitempty"`
+}
+
+// ServiceAccountRule grants verbs on resources, like a rule of a Role.
+type ServiceAccountRule struct {
+	// APIGroups of the resources. "" is the core group and "*" matches any
+	// group. Defaults to the core group.
+	// +optional
+	APIGroups []string `json:"apiGroups,omitempty"`
+	// Resources the rule applies to, such as pods, pods/log or
+	// deployments/scale. "*" matches any resource.
+	// +kubebuilder:validation:MinItems=1
+	Resources []string `json:"resources"`
+	// ResourceNames restricts the rule to the named objects.
+	// +optional
+	ResourceNames []string `json:"resourceNames,omitempty"`
+	// Verbs granted on the resources, such as get, list, watch, create,
+	// patch or delete. "*" matches any verb.
+	// +kubebuilder:validation:MinItems=1
+	Verbs []string `json:"verbs"`
 }
 
 // ToolProviderType represents the tool provider type
@@ -619,8 +1267,141 @@ type A2AConfig struct {
 	// +kubebuilder:validation:MinItems=1
 	// +optional
 	Skills []AgentSkill `json:"skills,omitempty"`
+
+	// DefaultInputModes are the input MIME types the agent accepts across all
+	// of its skills. Defaults to ["text"].
+	// +optional
+	// +kubebuilder:validation:MaxItems=20
+	DefaultInputModes []string `json:"defaultInputModes,omitempty"`
+
+	// DefaultOutputModes are the output MIME types the agent produces across
+	// all of its skills. Defaults to ["text"].
+	// +optional
+	// +kubebuilder:validation:MaxItems=20
+	DefaultOutputModes []string `json:"defaultOutputModes,omitempty"`
+
+	// SecuritySchemes are the ways clients can authenticate to the agent,
+	// published on the AgentCard. A client must satisfy one of them. They
+	// describe authentication enforced in front of the agent, e.g. by a
+	// gateway; kagent does not enforce them itself.
+	// +optional
+	// +kubebuilder:validation:MaxItems=10
+	// +listType=map
+	// +listMapKey=name
+	SecuritySchemes []A2ASecurityScheme `json:"securitySchemes,omitempty"`
 }
 
+// A2ASecurityScheme is a security scheme published on the AgentCard. Exactly
+// one of apiKey, http, oauth2, openIdConnect or mutualTLS must be set.
+// +kubebuilder:validation:XValidation:message="exactly one of apiKey, http, oauth2, openIdConnect or mutualTLS must be set",rule="[has(self.apiKey), has(self.http), has(self.oauth2), has(self.openIdConnect), has(self.mutualTLS)].filter(x, x).size() == 1"
+type A2ASecurityScheme struct {
+	// Name identifies the scheme on the AgentCard.
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Name string `json:"name"`
+
+	// Description is an optional description of the scheme.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Scopes are the OAuth2 or OpenID Connect scopes a client's credentials
+	// must cover.
+	// +optional
+	Scopes []string `json:"scopes,omitempty"`
+
+	// +optional
+	APIKey *A2AAPIKeySecurityScheme `json:"apiKey,omitempty"`
+
+	// +optional
+	HTTP *A2AHTTPSecurityScheme `json:"http,omitempty"`
+
+	// +optional
+	OAuth2 *A2AOAuth2SecurityScheme `json:"oauth2,omitempty"`
+
+	// +optional
+	OpenIDConnect *A2AOpenIDConnectSecurityScheme `json:"openIdConnect,omitempty"`
+
+	// MutualTLS requires clients to present a TLS client certificate.
+	// +optional
+	MutualTLS *A2AMutualTLSSecurityScheme `json:"mutualTLS,omitempty"`
+}
+
+// A2AAPIKeySecurityScheme authenticates clients with an API key.
+type A2AAPIKeySecurityScheme struct {
+	// Location is where the API key is passed.
+	// +kubebuilder:validation:Enum=header;query;cookie
+	// +required
+	Location string `json:"location"`
+
+	// ParameterName is the name of the header, query parameter or cookie
+	// that carries the API key.
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	ParameterName string `json:"parameterName"`
+}
+
+// A2AHTTPSecurityScheme authenticates clients with HTTP authentication.
+type A2AHTTPSecurityScheme struct {
+	// Scheme is the HTTP authentication scheme used in the Authorization
+	// header, e.g. Bearer or Basic.
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Scheme string `json:"scheme"`
+
+	// BearerFormat hints how bearer tokens are formatted, e.g. JWT.
+	// +optional
+	BearerFormat string `json:"bearerFormat,omitempty"`
+}
+
+// A2AOAuth2Flow is the OAuth 2.0 flow clients use to obtain tokens.
+// +kubebuilder:validation:Enum=ClientCredentials;AuthorizationCode
+type A2AOAuth2Flow string
+
+const (
+	A2AOAuth2FlowClientCredentials A2AOAuth2Flow = "ClientCredentials"
+	A2AOAuth2FlowAuthorizationCode A2AOAuth2Flow = "AuthorizationCode"
+)
+
+// A2AOAuth2SecurityScheme authenticates clients with OAuth 2.0 access tokens.
+// +kubebuilder:validation:XValidation:message="authorizationUrl is required for the AuthorizationCode flow",rule="self.flow != 'AuthorizationCode' || has(self.authorizationUrl)"
+type A2AOAuth2SecurityScheme struct {
+	// Flow is the OAuth 2.0 flow clients use to obtain tokens.
+	// +required
+	Flow A2AOAuth2Flow `json:"flow"`
+
+	// TokenURL is the token endpoint of the authorization server.
+	// +kubebuilder:validation:Format=uri
+	// +required
+	TokenURL string `json:"tokenUrl"`
+
+	// AuthorizationURL is the authorization endpoint of the authorization
+	// server. Required for the AuthorizationCode flow.
+	// +kubebuilder:validation:Format=uri
+	// +optional
+	AuthorizationURL string `json:"authorizationUrl,omitempty"`
+
+	// Scopes maps the scopes the agent supports to their descriptions.
+	// +optional
+	Scopes map[string]string `json:"scopes,omitempty"`
+
+	// MetadataURL is the URL of the authorization server's RFC 8414 metadata.
+	// +kubebuilder:validation:Format=uri
+	// +optional
+	MetadataURL string `json:"metadataUrl,omitempty"`
+}
+
+// A2AOpenIDConnectSecurityScheme authenticates clients with OpenID Connect.
+type A2AOpenIDConnectSecurityScheme struct {
+	// URL is the OpenID Connect discovery URL of the provider.
+	// +kubebuilder:validation:Format=uri
+	// +required
+	URL string `json:"url"`
+}
+
+// A2AMutualTLSSecurityScheme authenticates clients with TLS client
+// certificates. It has no settings.
+type A2AMutualTLSSecurityScheme struct{}
+
 // AgentSkill describes a specific capability or function of the agent.
 type AgentSkill struct {
 	// ID is the unique identifier for the skill.
@@ -650,24 +1431,103 @@ type AgentSkill struct {
 }
 
 const (
-	AgentConditionTypeAccepted            = "Accepted"
-	AgentConditionTypeReady               = "Ready"
+	AgentConditionTypeAccepted = "Accepted"
+	// AgentConditionTypeDeployed reports whether the agent's Deployment has
+	// rolled out its current pod template.
+	AgentConditionTypeDeployed = "Deployed"
+	// AgentConditionTypeReady reports whether the agent has available pods
+	// that answer its /health endpoint.
+	AgentConditionTypeReady = "Ready"
+	// AgentConditionTypeToolsConnected reports whether the MCP servers the
+	// agent uses are reachable.
+	AgentConditionTypeToolsConnected      = "ToolsConnected"
 	AgentConditionTypeUnsupportedFeatures = "UnsupportedFeatures"
+	AgentConditionTypePostRolloutVerified = "PostRolloutVerified"
 )
 
+// SmokeTestResult is the outcome of one smoke-test prompt.
+type SmokeTestResult struct {
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// SmokeTestStatus records the last smoke-test run.
+type SmokeTestStatus struct {
+	// DeploymentGeneration is the generation of the agent Deployment the
+	// smoke tests ran against.
+	DeploymentGeneration int64 `json:"deploymentGeneration"`
+	// +optional
+	LastRunTime *metav1.Time `json:"lastRunTime,omitempty"`
+	// +optional
+	Results []SmokeTestResult `json:"results,omitempty"`
+}
+
 // AgentStatus defines the observed state of Agent.
 type AgentStatus struct {
 	// +optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// SmokeTests holds the results of the last post-rollout smoke-test run.
+	// +optional
+	SmokeTests *SmokeTestStatus `json:"smokeTests,omitempty"`
+	// SecretHash is a hash of the Secret data referenced by the agent's
+	// ModelConfig and RemoteMCPServers. The agent's pods are restarted when
+	// it changes.
+	// +optional
+	SecretHash string `json:"secretHash,omitempty"`
+	// SecretsRotatedAt is when the agent was last rolled out because
+	// referenced Secret data changed.
+	// +optional
+	SecretsRotatedAt *metav1.Time `json:"secretsRotatedAt,omitempty"`
+	// Autoscaling is the observed state of the agent's HorizontalPodAutoscaler,
+	// set when autoscaling is configured.
+	// +optional
+	Autoscaling *AutoscalingStatus `json:"autoscaling,omitempty"`
+	// Skills are the OCI skill images in spec.skills.refs and the digests the
+	// agent's pods fetch them by.
+	// +optional
+	Skills []SkillStatus `json:"skills,omitempty"`
+}
+
+// SkillStatus is an OCI skill image of an agent and the digest it resolved to.
+type SkillStatus struct {
+	// Ref is the image reference from spec.skills.refs.
+	Ref string `json:"ref"`
+	// Digest is the manifest digest Ref resolved to when the agent was last
+	// reconciled. The agent's pods fetch the skill by this digest. It is empty
+	// when the controller could not resolve Ref, in which case the pods pull
+	// Ref as is.
+	// +optional
+	Digest string `json:"digest,omitempty"`
+}
+
+// AutoscalingStatus mirrors the status of an agent's HorizontalPodAutoscaler.
+type AutoscalingStatus struct {
+	// CurrentReplicas is the number of agent pods last seen by the autoscaler.
+	// +optional
+	CurrentReplicas int32 `json:"currentReplicas,omitempty"`
+	// DesiredReplicas is the number of agent pods the autoscaler wants.
+	// +optional
+	DesiredReplicas int32 `json:"desiredReplicas,omitempty"`
+	// LastScaleTime is when the autoscaler last changed the replica count.
+	// +optional
+	LastScaleTime *metav1.Time `json:"lastScaleTime,omitempty"`
+	// Conditions are the conditions of the HorizontalPodAutoscaler, such as
+	// AbleToScale and ScalingActive.
+	// +optional
+	Conditions []autoscalingv2.HorizontalPodAutoscalerCondition `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Type",type="string",JSONPath=".spec.type",description="The type of the agent."
 // +kubebuilder:printcolumn:name="Runtime",type="string",JSONPath=".spec.declarative.runtime",description="The runtime implementation for declarative agents."
+// +kubebuilder:printcolumn:name="Deployed",type="string",JSONPath=".status.conditions[?(@.type=='Deployed')].status",description="Whether the agent's Deployment has rolled out."
 // +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status",description="Whether or not the agent is ready to serve requests."
+// +kubebuilder:printcolumn:name="Tools",type="string",JSONPath=".status.conditions[?(@.type=='ToolsConnected')].status",description="Whether the agent's MCP servers are reachable."
 // +kubebuilder:printcolumn:name="Accepted",type="string",JSONPath=".status.conditions[?(@.type=='Accepted')].status",description="Whether or not the agent has been accepted by the system."
 // +kubebuilder:storageversion
 
@@ -0,0 +1,256 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+*/
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	// AgentEvalConditionTypeReady indicates whether the target agent exists.
+	AgentEvalConditionTypeReady = "Ready"
+	// AgentEvalConditionTypePassed reports the outcome of the most recent
+	// finished run.
+	AgentEvalConditionTypePassed = "Passed"
+
+	// AgentEvalRunAnnotation requests a run of an AgentEval: setting it to a
+	// new value, e.g. a timestamp, runs the suite against the current agent
+	// rollout once.
+	AgentEvalRunAnnotation = "kagent.dev/eval-run"
+)
+
+// AgentEvalRunPhase is the state of one run.
+type AgentEvalRunPhase string
+
+const (
+	AgentEvalRunRunning AgentEvalRunPhase = "Running"
+	AgentEvalRunPassed  AgentEvalRunPhase = "Passed"
+	AgentEvalRunFailed  AgentEvalRunPhase = "Failed"
+)
+
+// AgentEvalTrigger is what started a run.
+type AgentEvalTrigger string
+
+const (
+	// AgentEvalTriggerRollout runs after the agent finished rolling out a new
+	// version, or after the suite changed.
+	AgentEvalTriggerRollout AgentEvalTrigger = "Rollout"
+	// AgentEvalTriggerManual runs on request through AgentEvalRunAnnotation.
+	AgentEvalTriggerManual AgentEvalTrigger = "Manual"
+)
+
+// AgentEvalSpec defines the desired state of AgentEval.
+type AgentEvalSpec struct {
+	// AgentRef is the name of the Agent, in the AgentEval's namespace, that
+	// is evaluated.
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	AgentRef string `json:"agentRef"`
+	// Cases are the golden tasks of the suite. Each is sent to the agent in a
+	// new session.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=50
+	// +listType=map
+	// +listMapKey=name
+	Cases []AgentEvalCase `json:"cases"`
+	// JudgeModelConfig is the ModelConfig, in the AgentEval's namespace, that
+	// grades rubric assertions. Defaults to the agent's own ModelConfig. Its
+	// API key must be read from apiKeySecret.
+	// +optional
+	JudgeModelConfig string `json:"judgeModelConfig,omitempty"`
+	// PassThreshold is the percentage of cases that must pass for a run to
+	// pass. Defaults to 100.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	PassThreshold *int32 `json:"passThreshold,omitempty"`
+	// Timeout bounds each case. Defaults to 2m.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// Suspend stops new runs from being started. A run in progress finishes.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+	// HistoryLimit is how many finished runs are kept in status. Defaults
+	// to 10.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	HistoryLimit *int32 `json:"historyLimit,omitempty"`
+}
+
+// AgentEvalCase is one prompt of a suite and the assertions on the reply.
+type AgentEvalCase struct {
+	// Name identifies the case in status.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+	// Prompt is the user message sent to the agent.
+	// +kubebuilder:validation:MinLength=1
+	Prompt string `json:"prompt"`
+	// Expect holds the assertions on the reply. With no assertions the case
+	// passes when the agent replies without error.
+	// +optional
+	Expect AgentEvalExpectation `json:"expect,omitempty"`
+}
+
+// AgentEvalExpectation are assertions on an agent's reply. A case passes when
+// all of them hold; its score is the mean of the assertion scores.
+type AgentEvalExpectation struct {
+	// Contains are substrings the reply must include, case-insensitively.
+	// +optional
+	Contains []string `json:"contains,omitempty"`
+	// NotContains are substrings the reply must not include, case-insensitively.
+	// +optional
+	NotContains []string `json:"notContains,omitempty"`
+	// Matches is a regular expression (RE2 syntax) the reply must match.
+	// +optional
+	Matches string `json:"matches,omitempty"`
+	// JSONPath are assertions on a JSON reply. The JSON may be wrapped in a
+	// Markdown code block.
+	// +optional
+	JSONPath []AgentEvalJSONPathAssertion `json:"jsonPath,omitempty"`
+	// Rubric has the judge model grade the reply against criteria written in
+	// natural language.
+	// +optional
+	Rubric *AgentEvalRubric `json:"rubric,omitempty"`
+}
+
+// AgentEvalJSONPathAssertion checks one field of a JSON reply.
+type AgentEvalJSONPathAssertion struct {
+	// Path is a kubectl-style JSONPath expression, e.g. "{.status}" or
+	// ".items[0].name".
+	// +kubebuilder:validation:MinLength=1
+	Path string `json:"path"`
+	// Value is the expected value of the field, compared as text. Without
+	// it the field only has to exist.
+	// +optional
+	Value *string `json:"value,omitempty"`
+}
+
+// AgentEvalRubric is an LLM-as-judge assertion.
+type AgentEvalRubric struct {
+	// Criteria describe a good reply, e.g. "Names the crashing pod and
+	// suggests a concrete fix".
+	// +kubebuilder:validation:MinLength=1
+	Criteria string `json:"criteria"`
+	// MinScore is the lowest judge score, from 1 to 10, that passes.
+	// Defaults to 7.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=10
+	// +optional
+	MinScore *int32 `json:"minScore,omitempty"`
+}
+
+// AgentEvalCaseResult is the outcome of one case in a run.
+type AgentEvalCaseResult struct {
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+	// Score is the mean of the case's assertion scores, from 0 to 100.
+	Score int32 `json:"score"`
+	// Message describes the failed assertions, or why the case could not run.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// AgentEvalRun records one run of a suite against an agent version.
+type AgentEvalRun struct {
+	// StartTime is when the first case was sent.
+	StartTime metav1.Time `json:"startTime"`
+	// CompletionTime is when the run finished.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// Trigger is what started the run.
+	Trigger AgentEvalTrigger `json:"trigger"`
+	// Phase of the run.
+	Phase AgentEvalRunPhase `json:"phase"`
+	// AgentGeneration is the generation of the Agent that was evaluated.
+	AgentGeneration int64 `json:"agentGeneration"`
+	// DeploymentGeneration is the generation of the agent Deployment the
+	// suite ran against.
+	DeploymentGeneration int64 `json:"deploymentGeneration"`
+	// EvalGeneration is the generation of the AgentEval that ran.
+	EvalGeneration int64 `json:"evalGeneration"`
+	// Score is the mean of the case scores, from 0 to 100.
+	// +optional
+	Score int32 `json:"score,omitempty"`
+	// PassedCases is how many cases passed.
+	// +optional
+	PassedCases int32 `json:"passedCases,omitempty"`
+	// TotalCases is how many cases the suite has.
+	TotalCases int32 `json:"totalCases"`
+	// Message is why the run failed as a whole, e.g. the controller restarted.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// Results lists the outcome of each case.
+	// +optional
+	Results []AgentEvalCaseResult `json:"results,omitempty"`
+}
+
+// AgentEvalStatus defines the observed state of AgentEval.
+type AgentEvalStatus struct {
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// LastRunRequest is the value of the kagent.dev/eval-run annotation that
+	// was last run.
+	// +optional
+	LastRunRequest string `json:"lastRunRequest,omitempty"`
+	// Active is the run in progress.
+	// +optional
+	Active *AgentEvalRun `json:"active,omitempty"`
+	// History lists finished runs, newest first, trimmed to the history limit.
+	// +optional
+	History []AgentEvalRun `json:"history,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:categories=kagent,shortName=aev
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Agent",type="string",JSONPath=".spec.agentRef"
+// +kubebuilder:printcolumn:name="Score",type="integer",JSONPath=".status.history[0].score"
+// +kubebuilder:printcolumn:name="Passed",type="string",JSONPath=".status.conditions[?(@.type=='Passed')].status"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:storageversion
+
+// AgentEval is a suite of golden tasks for an agent. The controller runs the
+// suite after every completed rollout of the agent and on request, scores
+// the replies and keeps the pass/fail history, so prompt and model changes
+// can be regression-tested before they are rolled out further.
+type AgentEval struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +required
+	Spec AgentEvalSpec `json:"spec"`
+	// +optional
+	Status AgentEvalStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AgentEvalList contains a list of AgentEval.
+type AgentEvalList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AgentEval `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(func(s *runtime.Scheme) error {
+		s.AddKnownTypes(GroupVersion, &AgentEval{}, &AgentEvalList{})
+		return nil
+	})
+}
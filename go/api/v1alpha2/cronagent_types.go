@@ -0,0 +1,215 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+*/
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	// CronAgentConditionTypeReady indicates whether the schedule is valid and
+	// the target agent exists.
+	CronAgentConditionTypeReady = "Ready"
+)
+
+// CronAgentConcurrencyPolicy is what happens when a run is due while an
+// earlier run of the same CronAgent is still in progress.
+// +kubebuilder:validation:Enum=Allow;Forbid;Replace
+type CronAgentConcurrencyPolicy string
+
+const (
+	// CronAgentConcurrencyAllow starts the new run alongside the running ones.
+	CronAgentConcurrencyAllow CronAgentConcurrencyPolicy = "Allow"
+	// CronAgentConcurrencyForbid skips the new run.
+	CronAgentConcurrencyForbid CronAgentConcurrencyPolicy = "Forbid"
+	// CronAgentConcurrencyReplace cancels the running runs and starts the new one.
+	CronAgentConcurrencyReplace CronAgentConcurrencyPolicy = "Replace"
+)
+
+// CronAgentWebhookFormat is the body posted to a CronAgent's webhook.
+// +kubebuilder:validation:Enum=JSON;Slack
+type CronAgentWebhookFormat string
+
+const (
+	// CronAgentWebhookFormatJSON posts the run record, including the agent's reply.
+	CronAgentWebhookFormatJSON CronAgentWebhookFormat = "JSON"
+	// CronAgentWebhookFormatSlack posts {"text": ...} for Slack incoming webhooks.
+	CronAgentWebhookFormatSlack CronAgentWebhookFormat = "Slack"
+)
+
+// CronAgentRunPhase is the state of one run.
+type CronAgentRunPhase string
+
+const (
+	CronAgentRunRunning   CronAgentRunPhase = "Running"
+	CronAgentRunSucceeded CronAgentRunPhase = "Succeeded"
+	CronAgentRunFailed    CronAgentRunPhase = "Failed"
+)
+
+// CronAgentSpec defines the desired state of CronAgent.
+type CronAgentSpec struct {
+	// Schedule is a cron expression with five fields (minute, hour, day of
+	// month, month, day of week), e.g. "0 8 * * 1-5", or one of the macros
+	// @hourly, @daily, @weekly, @monthly and @yearly.
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Schedule string `json:"schedule"`
+	// TimeZone is the IANA time zone the schedule is interpreted in, e.g.
+	// "Europe/Berlin". Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+	// AgentRef is the name of the Agent, in the CronAgent's namespace, that
+	// runs the task.
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	AgentRef string `json:"agentRef"`
+	// Task is the message sent to the agent on each run. Every run starts a
+	// new session.
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Task string `json:"task"`
+	// ConcurrencyPolicy decides what happens when a run is due while an
+	// earlier one is still in progress. Defaults to Forbid.
+	// +kubebuilder:default=Forbid
+	// +optional
+	ConcurrencyPolicy CronAgentConcurrencyPolicy `json:"concurrencyPolicy,omitempty"`
+	// Suspend stops new runs from being scheduled. Runs in progress finish.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+	// StartingDeadlineSeconds skips a run that could not start within this
+	// many seconds of its scheduled time, e.g. because the controller was
+	// down. Without it, only the most recent missed run is started.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	StartingDeadlineSeconds *int64 `json:"startingDeadlineSeconds,omitempty"`
+	// Timeout bounds each run. Defaults to 10 minutes.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// SuccessfulRunsHistoryLimit is how many succeeded runs are kept in
+	// status. Defaults to 3.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	SuccessfulRunsHistoryLimit *int32 `json:"successfulRunsHistoryLimit,omitempty"`
+	// FailedRunsHistoryLimit is how many failed runs are kept in status.
+	// Defaults to 1.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	FailedRunsHistoryLimit *int32 `json:"failedRunsHistoryLimit,omitempty"`
+	// Webhook receives the result of every finished run.
+	// +optional
+	Webhook *CronAgentWebhook `json:"webhook,omitempty"`
+}
+
+// CronAgentWebhook is an HTTP endpoint that run results are posted to.
+// +kubebuilder:validation:XValidation:rule="(has(self.url) && !has(self.urlFrom)) || (!has(self.url) && has(self.urlFrom))",message="exactly one of url or urlFrom must be specified"
+type CronAgentWebhook struct {
+	// URL is the endpoint results are posted to.
+	// +kubebuilder:validation:Pattern=`^https?://.*`
+	// +optional
+	URL string `json:"url,omitempty"`
+	// URLFrom reads the URL from a Secret or ConfigMap, for URLs that embed a
+	// token such as Slack incoming webhooks.
+	// +optional
+	URLFrom *ValueSource `json:"urlFrom,omitempty"`
+	// Format of the posted body. Defaults to JSON.
+	// +kubebuilder:default=JSON
+	// +optional
+	Format CronAgentWebhookFormat `json:"format,omitempty"`
+}
+
+// CronAgentRun records one run of a CronAgent.
+type CronAgentRun struct {
+	// ScheduledTime is the schedule activation the run belongs to.
+	ScheduledTime metav1.Time `json:"scheduledTime"`
+	// StartTime is when the task was sent to the agent.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// CompletionTime is when the run finished.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// Phase of the run.
+	Phase CronAgentRunPhase `json:"phase"`
+	// SessionID is the A2A context ID of the session the run created.
+	// +optional
+	SessionID string `json:"sessionID,omitempty"`
+	// TaskID is the A2A task the agent created for the run, if any.
+	// +optional
+	TaskID string `json:"taskID,omitempty"`
+	// Message is the beginning of the agent's reply, or why the run failed.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// CronAgentStatus defines the observed state of CronAgent.
+type CronAgentStatus struct {
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// LastScheduleTime is the activation time of the most recently started run.
+	// +optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+	// LastSuccessfulTime is when the most recent successful run finished.
+	// +optional
+	LastSuccessfulTime *metav1.Time `json:"lastSuccessfulTime,omitempty"`
+	// NextScheduleTime is the next activation time, unset while suspended.
+	// +optional
+	NextScheduleTime *metav1.Time `json:"nextScheduleTime,omitempty"`
+	// Active lists the runs in progress.
+	// +optional
+	Active []CronAgentRun `json:"active,omitempty"`
+	// History lists finished runs, newest first, trimmed to the history limits.
+	// +optional
+	History []CronAgentRun `json:"history,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:categories=kagent,shortName=cra
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Schedule",type="string",JSONPath=".spec.schedule"
+// +kubebuilder:printcolumn:name="Agent",type="string",JSONPath=".spec.agentRef"
+// +kubebuilder:printcolumn:name="Suspend",type="boolean",JSONPath=".spec.suspend"
+// +kubebuilder:printcolumn:name="Last Schedule",type="date",JSONPath=".status.lastScheduleTime"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:storageversion
+
+// CronAgent runs an agent task on a cron schedule, e.g. summarizing cluster
+// events every morning and posting the summary to a Slack webhook.
+type CronAgent struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +required
+	Spec CronAgentSpec `json:"spec"`
+	// +optional
+	Status CronAgentStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CronAgentList contains a list of CronAgent.
+type CronAgentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CronAgent `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(func(s *runtime.Scheme) error {
+		s.AddKnownTypes(GroupVersion, &CronAgent{}, &CronAgentList{})
+		return nil
+	})
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+*/
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	// GitHubTriggerConditionTypeReady indicates whether the agents of every
+	// route exist.
+	GitHubTriggerConditionTypeReady = "Ready"
+)
+
+// GitHubTriggerEvent is a GitHub webhook event a route handles.
+// +kubebuilder:validation:Enum=PullRequestOpened;PullRequestLabeled;IssueOpened;IssueLabeled
+type GitHubTriggerEvent string
+
+const (
+	// GitHubTriggerPullRequestOpened is a pull request being opened or
+	// reopened.
+	GitHubTriggerPullRequestOpened GitHubTriggerEvent = "PullRequestOpened"
+	// GitHubTriggerPullRequestLabeled is a label being added to a pull request.
+	GitHubTriggerPullRequestLabeled GitHubTriggerEvent = "PullRequestLabeled"
+	// GitHubTriggerIssueOpened is an issue being opened or reopened.
+	GitHubTriggerIssueOpened GitHubTriggerEvent = "IssueOpened"
+	// GitHubTriggerIssueLabeled is a label being added to an issue.
+	GitHubTriggerIssueLabeled GitHubTriggerEvent = "IssueLabeled"
+)
+
+// GitHubTriggerDeliveryPhase is the outcome of a handled delivery.
+type GitHubTriggerDeliveryPhase string
+
+const (
+	GitHubTriggerDeliverySucceeded GitHubTriggerDeliveryPhase = "Succeeded"
+	GitHubTriggerDeliveryFailed    GitHubTriggerDeliveryPhase = "Failed"
+)
+
+// GitHubTriggerSpec defines the desired state of GitHubTrigger.
+type GitHubTriggerSpec struct {
+	// Repositories the trigger handles events of, as owner/name. owner/*
+	// matches every repository of owner. Events are delivered by the GitHub
+	// App configured on the controller, which must be installed on them.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=100
+	// +kubebuilder:validation:items:Pattern=`^[A-Za-z0-9_.-]+/([A-Za-z0-9_.-]+|\*)$`
+	// +required
+	Repositories []string `json:"repositories"`
+	// Routes map events to agents. An event is handled by the first route
+	// that matches it.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=20
+	// +listType=map
+	// +listMapKey=name
+	// +required
+	Routes []GitHubTriggerRoute `json:"routes"`
+	// Suspend stops new events from being handled. Events in progress
+	// finish.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+	// Timeout bounds each agent invocation. Defaults to 10 minutes.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// HistoryLimit is how many handled deliveries are kept in status.
+	// Defaults to 10.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	HistoryLimit *int32 `json:"historyLimit,omitempty"`
+}
+
+// GitHubTriggerRoute sends matching events to an agent and posts its reply
+// as a comment on the pull request or issue.
+type GitHubTriggerRoute struct {
+	// Name identifies the route in status.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=63
+	// +required
+	Name string `json:"name"`
+	// Events the route handles.
+	// +kubebuilder:validation:MinItems=1
+	// +required
+	Events []GitHubTriggerEvent `json:"events"`
+	// Labels restricts the route to pull requests and issues with one of
+	// these labels. For labeled events, the label added must be one of them.
+	// Empty matches any.
+	// +optional
+	Labels []string `json:"labels,omitempty"`
+	// AgentRef is the name of the Agent, in the trigger's namespace, that
+	// handles the events.
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	AgentRef string `json:"agentRef"`
+	// Instructions tell the agent what to do with the event, e.g. "Review
+	// this pull request for Kubernetes manifest mistakes". They are sent
+	// followed by the event as JSON.
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Instructions string `json:"instructions"`
+}
+
+// GitHubTriggerDelivery records one handled webhook delivery.
+type GitHubTriggerDelivery struct {
+	// DeliveryID is GitHub's X-GitHub-Delivery ID, for finding the delivery
+	// in the App's settings.
+	DeliveryID string `json:"deliveryID"`
+	// Event is the event that was delivered.
+	Event GitHubTriggerEvent `json:"event"`
+	// Repository is the owner/name of the repository.
+	Repository string `json:"repository"`
+	// Number of the pull request or issue.
+	Number int64 `json:"number"`
+	// Route is the route that handled the event.
+	Route string `json:"route"`
+	// ReceivedTime is when the delivery arrived.
+	ReceivedTime metav1.Time `json:"receivedTime"`
+	// CompletionTime is when the comment was posted or the delivery failed.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// Phase of the delivery.
+	Phase GitHubTriggerDeliveryPhase `json:"phase"`
+	// SessionID is the A2A context ID of the session. Events of the same
+	// pull request or issue continue the same session.
+	// +optional
+	SessionID string `json:"sessionID,omitempty"`
+	// CommentURL is the comment the agent's reply was posted as.
+	// +optional
+	CommentURL string `json:"commentURL,omitempty"`
+	// Message is why the delivery failed.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// GitHubTriggerStatus defines the observed state of GitHubTrigger.
+type GitHubTriggerStatus struct {
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Deliveries lists handled deliveries, newest first, trimmed to the
+	// history limit.
+	// +optional
+	Deliveries []GitHubTriggerDelivery `json:"deliveries,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:categories=kagent,shortName=ght
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Repositories",type="string",JSONPath=".spec.repositories",priority=1
+// +kubebuilder:printcolumn:name="Suspend",type="boolean",JSONPath=".spec.suspend"
+// +kubebuilder:printcolumn:name="Last Delivery",type="date",JSONPath=".status.deliveries[0].receivedTime"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:storageversion
+
+// GitHubTrigger routes GitHub pull request and issue events to agents, e.g.
+// reviewing every opened pull request or triaging issues labeled
+// "needs-triage", and posts the agents' replies as comments.
+type GitHubTrigger struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +required
+	Spec GitHubTriggerSpec `json:"spec"`
+	// +optional
+	Status GitHubTriggerStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GitHubTriggerList contains a list of GitHubTrigger.
+type GitHubTriggerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GitHubTrigger `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(func(s *runtime.Scheme) error {
+		s.AddKnownTypes(GroupVersion, &GitHubTrigger{}, &GitHubTriggerList{})
+		return nil
+	})
+}
@@ -24,10 +24,19 @@ import (
 
 const (
 	ModelConfigConditionTypeAccepted = "Accepted"
+	// ModelConfigConditionTypeModelAvailable reports whether the configured
+	// model was found on the provider's models endpoint. Only set for
+	// providers that support model discovery (OpenAICompatible, and Ollama
+	// with pullModel or warmUp set).
+	ModelConfigConditionTypeModelAvailable = "ModelAvailable"
+	// ModelConfigConditionTypeFallbacksResolved reports whether every
+	// ModelConfig listed in spec.fallbacks exists. Only set when fallbacks
+	// are configured.
+	ModelConfigConditionTypeFallbacksResolved = "FallbacksResolved"
 )
 
 // ModelProvider represents the model provider type
-// +kubebuilder:validation:Enum=Anthropic;OpenAI;AzureOpenAI;Ollama;Gemini;GeminiVertexAI;AnthropicVertexAI;Bedrock;SAPAICore
+// +kubebuilder:validation:Enum=Anthropic;OpenAI;AzureOpenAI;Ollama;Gemini;GeminiVertexAI;AnthropicVertexAI;Bedrock;SAPAICore;OpenAICompatible
 type ModelProvider string
 
 const (
@@ -40,6 +49,7 @@ const (
 	ModelProviderAnthropicVertexAI ModelProvider = "AnthropicVertexAI"
 	ModelProviderBedrock           ModelProvider = "Bedrock"
 	ModelProviderSAPAICore         ModelProvider = "SAPAICore"
+	ModelProviderOpenAICompatible  ModelProvider = "OpenAICompatible"
 )
 
 type BaseVertexAIConfig struct {
@@ -83,6 +93,52 @@ type GeminiVertexAIConfig struct {
 	// Response mime type
 	// +optional
 	ResponseMimeType string `json:"responseMimeType,omitempty"`
+
+	GeminiFunctionCallingConfig `json:",inline"`
+}
+
+// GeminiFunctionCallingConfig contains the function-calling and safety options
+// shared by the Gemini and GeminiVertexAI providers
+// +kubebuilder:validation:XValidation:message="allowedFunctionNames requires toolChoice any or validated",rule="!has(self.allowedFunctionNames) || (has(self.toolChoice) && self.toolChoice in ['any', 'validated'])"
+type GeminiFunctionCallingConfig struct {
+	// ToolChoice controls whether the model calls tools. auto lets the model
+	// decide, none disables tool calls, any forces a tool call and validated
+	// lets the model decide but constrains calls to the tool schemas. A forced
+	// tool choice applies to the first model call of each turn only, so the
+	// agent can answer once its tools have run.
+	// +optional
+	// +kubebuilder:validation:Enum=auto;any;none;validated
+	ToolChoice string `json:"toolChoice,omitempty"`
+
+	// AllowedFunctionNames limits the tools the model may call when ToolChoice
+	// is any or validated.
+	// +optional
+	// +kubebuilder:validation:MaxItems=100
+	AllowedFunctionNames []string `json:"allowedFunctionNames,omitempty"`
+
+	// ParallelToolCalls allows the model to return several tool calls in one
+	// response. When false only the first call of a response is run.
+	// +optional
+	ParallelToolCalls *bool `json:"parallelToolCalls,omitempty"`
+
+	// SafetySettings set the blocking threshold of the harm categories.
+	// Responses blocked by a safety filter fail with the SAFETY_BLOCKED error code.
+	// +optional
+	// +kubebuilder:validation:MaxItems=10
+	SafetySettings []GeminiSafetySetting `json:"safetySettings,omitempty"`
+}
+
+// GeminiSafetySetting sets the blocking threshold of one harm category
+type GeminiSafetySetting struct {
+	// Category is the harm category.
+	// +required
+	// +kubebuilder:validation:Enum=HARM_CATEGORY_HARASSMENT;HARM_CATEGORY_HATE_SPEECH;HARM_CATEGORY_SEXUALLY_EXPLICIT;HARM_CATEGORY_DANGEROUS_CONTENT;HARM_CATEGORY_CIVIC_INTEGRITY
+	Category string `json:"category"`
+
+	// Threshold is the probability at and above which content is blocked.
+	// +required
+	// +kubebuilder:validation:Enum=BLOCK_LOW_AND_ABOVE;BLOCK_MEDIUM_AND_ABOVE;BLOCK_ONLY_HIGH;BLOCK_NONE;OFF
+	Threshold string `json:"threshold"`
 }
 
 type AnthropicVertexAIConfig struct {
@@ -114,6 +170,32 @@ type AnthropicConfig struct {
 	// Top-k sampling parameter
 	// +optional
 	TopK int `json:"topK,omitempty"`
+
+	// CacheSystemInstruction adds a prompt caching breakpoint (cache_control)
+	// to the end of the system prompt. Anthropic caches the request prefix up to
+	// and including the breakpoint (tool definitions and the system prompt), so
+	// agents with large, stable system prompts pay the reduced cached-input rate
+	// on every call after the first. Prefixes shorter than the model's minimum
+	// cacheable length are processed normally.
+	//
+	// See https://docs.anthropic.com/en/docs/build-with-claude/prompt-caching
+	// +optional
+	CacheSystemInstruction bool `json:"cacheSystemInstruction,omitempty"`
+
+	// CacheTools adds a prompt caching breakpoint after the last tool
+	// definition, caching the tool set independently of the system prompt.
+	// +optional
+	CacheTools bool `json:"cacheTools,omitempty"`
+
+	// CacheTTL controls how long Anthropic retains cached prefixes when
+	// CacheSystemInstruction or CacheTools is enabled.
+	//
+	//   - "5m" (default): 5-minute cache, refreshed on each hit.
+	//   - "1h": extended cache for agents whose calls are spaced further apart.
+	//     Cache writes are billed at a higher rate than with "5m".
+	// +optional
+	// +kubebuilder:validation:Enum="5m";"1h"
+	CacheTTL string `json:"cacheTTL,omitempty"`
 }
 
 // TokenExchangeType identifies the token exchange mechanism
@@ -255,9 +337,40 @@ type OllamaConfig struct {
 	// Options for the Ollama API
 	// +optional
 	Options map[string]string `json:"options,omitempty"`
+
+	// PullModel makes the controller pull the model onto the Ollama server
+	// when the server does not have it. The progress is reported in
+	// status.modelPull and on the ModelAvailable condition.
+	// +optional
+	PullModel bool `json:"pullModel,omitempty"`
+
+	// WarmUp makes the controller load the model into the server's memory
+	// once it is available, so that the first request to an agent does not
+	// wait for it to load.
+	// +optional
+	WarmUp bool `json:"warmUp,omitempty"`
 }
 
-type GeminiConfig struct{}
+// GeminiConfig contains Gemini-specific configuration options
+type GeminiConfig struct {
+	// Temperature
+	// +optional
+	Temperature string `json:"temperature,omitempty"`
+
+	// Top-p sampling parameter
+	// +optional
+	TopP string `json:"topP,omitempty"`
+
+	// Top-k sampling parameter
+	// +optional
+	TopK string `json:"topK,omitempty"`
+
+	// Maximum output tokens
+	// +optional
+	MaxOutputTokens int `json:"maxOutputTokens,omitempty"`
+
+	GeminiFunctionCallingConfig `json:",inline"`
+}
 
 // BedrockConfig contains AWS Bedrock-specific configuration options.
 type BedrockConfig struct {
@@ -265,6 +378,14 @@ type BedrockConfig struct {
 	// +required
 	Region string `json:"region"`
 
+	// Endpoint overrides the Bedrock runtime endpoint derived from Region, for
+	// example a VPC interface endpoint or a FIPS endpoint
+	// (e.g., https://vpce-0123-abcd.bedrock-runtime.us-east-1.vpce.amazonaws.com).
+	// Requests are still signed with SigV4 for Region.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^https?://.*`
+	Endpoint string `json:"endpoint,omitempty"`
+
 	// AdditionalModelRequestFields passes model-specific parameters to Bedrock's
 	// additionalModelRequestFields in the Converse API. Use this for provider-specific
 	// options that are not part of the standard InferenceConfiguration block, such as
@@ -327,6 +448,40 @@ type SAPAICoreConfig struct {
 	AuthURL string `json:"authUrl,omitempty"`
 }
 
+// OpenAICompatibleConfig contains configuration for self-hosted servers that
+// expose the OpenAI API (vLLM, LM Studio, llama.cpp, etc.). The controller
+// probes {baseUrl}/v1/models to validate that the requested model is served
+// and reports the available models in the ModelConfig status.
+type OpenAICompatibleConfig struct {
+	// Base URL of the OpenAI-compatible server (e.g. http://vllm.models:8000/v1)
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	BaseURL string `json:"baseUrl"`
+
+	// Temperature for sampling
+	// +optional
+	Temperature string `json:"temperature,omitempty"`
+
+	// Maximum tokens to generate
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxTokens int `json:"maxTokens,omitempty"`
+
+	// Top-p sampling parameter
+	// +optional
+	TopP string `json:"topP,omitempty"`
+
+	// Timeout
+	// +optional
+	Timeout *int `json:"timeout,omitempty"`
+
+	// SkipModelValidation disables the check that spec.model is listed by the
+	// server's models endpoint. Discovery still runs and status.availableModels
+	// is populated when the endpoint is reachable.
+	// +optional
+	SkipModelValidation bool `json:"skipModelValidation,omitempty"`
+}
+
 // TLSConfig contains TLS/SSL configuration options for outbound HTTPS
 // connections from the agent (model provider, RemoteMCPServer). The
 // XValidation rules below apply at admission to every CRD field that
@@ -394,10 +549,15 @@ func (t *TLSConfig) IsEmpty() bool {
 // +kubebuilder:validation:XValidation:message="provider.anthropicVertexAI must be nil if the provider is not AnthropicVertexAI",rule="!(has(self.anthropicVertexAI) && self.provider != 'AnthropicVertexAI')"
 // +kubebuilder:validation:XValidation:message="provider.bedrock must be nil if the provider is not Bedrock",rule="!(has(self.bedrock) && self.provider != 'Bedrock')"
 // +kubebuilder:validation:XValidation:message="provider.sapAICore must be nil if the provider is not SAPAICore",rule="!(has(self.sapAICore) && self.provider != 'SAPAICore')"
+// +kubebuilder:validation:XValidation:message="provider.openAICompatible must be nil if the provider is not OpenAICompatible",rule="!(has(self.openAICompatible) && self.provider != 'OpenAICompatible')"
+// +kubebuilder:validation:XValidation:message="provider.openAICompatible must be set if the provider is OpenAICompatible",rule="!(self.provider == 'OpenAICompatible' && !has(self.openAICompatible))"
 // +kubebuilder:validation:XValidation:message="apiKeySecret must be set if apiKeySecretKey is set",rule="!(has(self.apiKeySecretKey) && !has(self.apiKeySecret))"
 // +kubebuilder:validation:XValidation:message="apiKeySecretKey must be set if apiKeySecret is set (except for Bedrock and SAPAICore providers)",rule="!(has(self.apiKeySecret) && !has(self.apiKeySecretKey) && self.provider != 'Bedrock' && self.provider != 'SAPAICore')"
 // +kubebuilder:validation:XValidation:message="apiKeyPassthrough and apiKeySecret are mutually exclusive",rule="!(has(self.apiKeyPassthrough) && self.apiKeyPassthrough && has(self.apiKeySecret) && size(self.apiKeySecret) > 0)"
 // +kubebuilder:validation:XValidation:message="apiKeyPassthrough must be false if provider is Gemini;GeminiVertexAI;AnthropicVertexAI",rule="!(has(self.apiKeyPassthrough) && self.apiKeyPassthrough && (self.provider == 'Gemini' || self.provider == 'GeminiVertexAI' || self.provider == 'AnthropicVertexAI'))"
+// +kubebuilder:validation:XValidation:message="apiKeyFrom is mutually exclusive with apiKeySecret and apiKeyPassthrough",rule="!(has(self.apiKeyFrom) && ((has(self.apiKeySecret) && size(self.apiKeySecret) > 0) || (has(self.apiKeyPassthrough) && self.apiKeyPassthrough)))"
+// +kubebuilder:validation:XValidation:message="apiKeyFrom is not supported for the Bedrock and SAPAICore providers",rule="!(has(self.apiKeyFrom) && (self.provider == 'Bedrock' || self.provider == 'SAPAICore'))"
+// +kubebuilder:validation:XValidation:message="apiKeyFrom.file must be used for the GeminiVertexAI and AnthropicVertexAI providers",rule="!(has(self.apiKeyFrom) && has(self.apiKeyFrom.envVar) && (self.provider == 'GeminiVertexAI' || self.provider == 'AnthropicVertexAI'))"
 // +kubebuilder:validation:XValidation:message="openAI.tokenExchange requires apiKeySecret (the service account secret)",rule="!(has(self.openAI) && has(self.openAI.tokenExchange) && (!has(self.apiKeySecret) || size(self.apiKeySecret) == 0))"
 // +kubebuilder:validation:XValidation:message="openAI.tokenExchange and apiKeyPassthrough are mutually exclusive",rule="!(has(self.openAI) && has(self.openAI.tokenExchange) && has(self.apiKeyPassthrough) && self.apiKeyPassthrough)"
 // +kubebuilder:validation:XValidation:message="openAI.tokenExchange type GDCHServiceAccount requires openAI.tokenExchange.gdchServiceAccount",rule="!(has(self.openAI) && has(self.openAI.tokenExchange) && self.openAI.tokenExchange.type == 'GDCHServiceAccount' && !has(self.openAI.tokenExchange.gdchServiceAccount))"
@@ -423,6 +583,14 @@ type ModelConfigSpec struct {
 	// +optional
 	APIKeyPassthrough bool `json:"apiKeyPassthrough,omitempty"`
 
+	// APIKeyFrom reads the API key from the agent pod instead of a Secret,
+	// for keys provided by a secrets operator or a CSI volume, e.g. Vault
+	// or the Secrets Store CSI driver. For the Vertex AI providers the file
+	// holds the service account credentials.
+	// Mutually exclusive with apiKeySecret and apiKeyPassthrough.
+	// +optional
+	APIKeyFrom *APIKeySource `json:"apiKeyFrom,omitempty"`
+
 	// +optional
 	DefaultHeaders map[string]string `json:"defaultHeaders,omitempty"`
 
@@ -467,11 +635,67 @@ type ModelConfigSpec struct {
 	// +optional
 	SAPAICore *SAPAICoreConfig `json:"sapAICore,omitempty"`
 
+	// OpenAI-compatible server configuration
+	// +optional
+	OpenAICompatible *OpenAICompatibleConfig `json:"openAICompatible,omitempty"`
+
 	// TLS configuration for provider connections.
 	// Enables agents to connect to internal LiteLLM gateways or other providers
 	// that use self-signed certificates or custom certificate authorities.
 	// +optional
 	TLS *TLSConfig `json:"tls,omitempty"`
+
+	// Fallbacks lists ModelConfigs, in the same namespace, that agents fail
+	// over to in order when this model returns server errors, rate limits
+	// or times out. Each request starts at this model; a fallback is only
+	// tried before any response has been streamed back. Fallbacks of the
+	// listed ModelConfigs are ignored.
+	// +optional
+	// +kubebuilder:validation:MaxItems=5
+	Fallbacks []ModelFallback `json:"fallbacks,omitempty"`
+}
+
+// APIKeySource locates an API key already present in the agent container.
+// +kubebuilder:validation:XValidation:message="exactly one of envVar and file must be set",rule="has(self.envVar) != has(self.file)"
+type APIKeySource struct {
+	// EnvVar is an environment variable of the agent container that holds
+	// the key. It must be defined in the agent's deployment env or envFrom.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	EnvVar string `json:"envVar,omitempty"`
+
+	// File is the absolute path of a file in the agent container that
+	// holds the key, typically in a volume mounted through the agent's
+	// deployment volumes. It is read when the agent starts.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^/`
+	File string `json:"file,omitempty"`
+}
+
+// ModelFallbackCondition is a class of model error that triggers failover.
+// +kubebuilder:validation:Enum=ServerError;RateLimited;Timeout
+type ModelFallbackCondition string
+
+const (
+	// ModelFallbackOnServerError fails over on 5xx responses.
+	ModelFallbackOnServerError ModelFallbackCondition = "ServerError"
+	// ModelFallbackOnRateLimited fails over on 429 responses.
+	ModelFallbackOnRateLimited ModelFallbackCondition = "RateLimited"
+	// ModelFallbackOnTimeout fails over when the request times out.
+	ModelFallbackOnTimeout ModelFallbackCondition = "Timeout"
+)
+
+// ModelFallback is one entry of a ModelConfig failover chain.
+type ModelFallback struct {
+	// ModelConfig is the name of a ModelConfig in the same namespace.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	ModelConfig string `json:"modelConfig"`
+
+	// Triggers limits the errors that fail over to this model. Defaults
+	// to all of them.
+	// +optional
+	Triggers []ModelFallbackCondition `json:"triggers,omitempty"`
 }
 
 // ModelConfigStatus defines the observed state of ModelConfig.
@@ -483,6 +707,27 @@ type ModelConfigStatus struct {
 	// The secret hash stores a hash of any secrets required by the model config (i.e. api key, tls cert) to ensure agents referencing this model config detect changes to these secrets and restart if necessary.
 	// +optional
 	SecretHash string `json:"secretHash,omitempty"`
+	// AvailableModels lists the models reported by the provider's models
+	// endpoint. Only populated for providers that support discovery
+	// (OpenAICompatible, and Ollama with pullModel or warmUp set).
+	// +optional
+	AvailableModels []string `json:"availableModels,omitempty"`
+	// ModelPull reports the progress of pulling the model onto the Ollama
+	// server while spec.ollama.pullModel is set and the pull is running.
+	// +optional
+	ModelPull *ModelPullStatus `json:"modelPull,omitempty"`
+}
+
+// ModelPullStatus is the progress of a model pull.
+type ModelPullStatus struct {
+	// Status is the last step reported by the server, e.g. "pulling manifest".
+	// +optional
+	Status string `json:"status,omitempty"`
+	// CompletedBytes and TotalBytes measure the layer being downloaded.
+	// +optional
+	CompletedBytes int64 `json:"completedBytes,omitempty"`
+	// +optional
+	TotalBytes int64 `json:"totalBytes,omitempty"`
 }
 
 // +kubebuilder:object:root=true
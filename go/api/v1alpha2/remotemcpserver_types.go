@@ -89,6 +89,45 @@ type RemoteMCPServerSpec struct {
 	// no equivalent rule, so a TLS block can sit alongside any baseUrl.
 	// +optional
 	TLS *TLSConfig `json:"tls,omitempty"`
+
+	// Auth configures how kagent authenticates to the MCP server, both when
+	// the controller discovers its tools and when agents call them. The
+	// Authorization header it produces takes precedence over headersFrom.
+	// +optional
+	Auth *RemoteMCPServerAuth `json:"auth,omitempty"`
+}
+
+// RemoteMCPServerAuth configures authentication to a RemoteMCPServer.
+// +kubebuilder:validation:XValidation:message="an authentication method must be specified",rule="has(self.oauth2ClientCredentials)"
+type RemoteMCPServerAuth struct {
+	// OAuth2ClientCredentials obtains an access token with the OAuth2 client
+	// credentials grant and sends it as a bearer token. Tokens are cached and
+	// refreshed shortly before they expire.
+	// +optional
+	OAuth2ClientCredentials *OAuth2ClientCredentials `json:"oauth2ClientCredentials,omitempty"`
+}
+
+// OAuth2ClientCredentials configures the OAuth2 client credentials grant.
+type OAuth2ClientCredentials struct {
+	// TokenURL is the authorization server's token endpoint.
+	// +kubebuilder:validation:Pattern=`^https?://.*`
+	// +required
+	TokenURL string `json:"tokenURL"`
+	// ClientID is the OAuth2 client ID.
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	ClientID string `json:"clientID"`
+	// ClientSecretFrom reads the client secret from a Secret or ConfigMap in
+	// the RemoteMCPServer's namespace.
+	// +required
+	ClientSecretFrom ValueSource `json:"clientSecretFrom"`
+	// Scopes requested with the token.
+	// +optional
+	Scopes []string `json:"scopes,omitempty"`
+	// Audience is sent as the audience parameter of the token request, for
+	// authorization servers that require it.
+	// +optional
+	Audience string `json:"audience,omitempty"`
 }
 
 var _ sql.Scanner = (*RemoteMCPServerSpec)(nil)
@@ -122,6 +161,21 @@ type RemoteMCPServerStatus struct {
 	// roll on the next reconcile. Empty when spec.tls.caCertSecretRef is unset.
 	// +optional
 	SecretHash string `json:"secretHash,omitempty"`
+	// ToolCallStats summarizes the calls agents on the go runtime made to the
+	// server since the start of the previous hour.
+	// +optional
+	ToolCallStats *ToolCallStats `json:"toolCallStats,omitempty"`
+}
+
+// ToolCallStats summarizes the calls agents made to a tool server.
+type ToolCallStats struct {
+	// Calls is the number of tool calls.
+	Calls int64 `json:"calls"`
+	// Errors is the number of tool calls that failed.
+	Errors int64 `json:"errors"`
+	// P95LatencyMs is the 95th percentile latency of the tool calls in
+	// milliseconds, rounded up to the bound of its latency bucket.
+	P95LatencyMs int64 `json:"p95LatencyMs"`
 }
 
 type MCPTool struct {
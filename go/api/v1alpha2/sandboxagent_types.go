@@ -38,6 +38,7 @@ type SandboxAgent struct {
 }
 
 // +kubebuilder:validation:XValidation:rule="!has(self.skills)",message="spec.skills is not supported for sandbox agents"
+// +kubebuilder:validation:XValidation:rule="(!has(self.declarative) || !has(self.declarative.deployment) || !has(self.declarative.deployment.scaleToZero)) && (!has(self.byo) || !has(self.byo.deployment) || !has(self.byo.deployment.scaleToZero))",message="scaleToZero is not supported for sandbox agents"
 type SandboxAgentSpec struct {
 	AgentSpec `json:",inline"`
 
@@ -0,0 +1,133 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+*/
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	// WorkflowConditionTypeReady indicates whether the Workflow's
+	// orchestrator agent is ready to serve requests.
+	WorkflowConditionTypeReady = "Ready"
+
+	// WorkflowPatternAnnotation is set on the Agent a Workflow creates to the
+	// Workflow's pattern. It makes the translator configure the agent as an
+	// orchestrator of its agent tools.
+	WorkflowPatternAnnotation = "kagent.dev/workflow-pattern"
+)
+
+// WorkflowPattern is how a Workflow runs its steps.
+// +kubebuilder:validation:Enum=Sequential;Parallel;Router
+type WorkflowPattern string
+
+const (
+	// WorkflowPatternSequential runs the steps one after another, each seeing
+	// the replies of the steps before it.
+	WorkflowPatternSequential WorkflowPattern = "Sequential"
+	// WorkflowPatternParallel runs all steps at once on the same request.
+	WorkflowPatternParallel WorkflowPattern = "Parallel"
+	// WorkflowPatternRouter has a model pick the step that handles each
+	// request.
+	WorkflowPatternRouter WorkflowPattern = "Router"
+)
+
+// WorkflowSpec defines the desired state of Workflow.
+// +kubebuilder:validation:XValidation:rule="!has(self.router) || self.pattern == 'Router'",message="router can only be set when pattern is Router"
+type WorkflowSpec struct {
+	// Description of the Workflow, shown in its agent card.
+	// +optional
+	Description string `json:"description,omitempty"`
+	// Pattern is how the steps run.
+	// +required
+	Pattern WorkflowPattern `json:"pattern"`
+	// Steps are the agents the Workflow is composed of, in order.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=20
+	// +required
+	Steps []WorkflowStep `json:"steps"`
+	// Router configures the model that picks a step with the Router pattern.
+	// +optional
+	Router *WorkflowRouter `json:"router,omitempty"`
+	// Deployment configures the orchestrator agent's deployment.
+	// +optional
+	Deployment *DeclarativeDeploymentSpec `json:"deployment,omitempty"`
+}
+
+// WorkflowStep is one agent of a Workflow.
+type WorkflowStep struct {
+	// AgentRef is the name of the Agent, in the Workflow's namespace, that
+	// runs the step.
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	AgentRef string `json:"agentRef"`
+}
+
+// WorkflowRouter configures the routing model of a Workflow.
+type WorkflowRouter struct {
+	// ModelConfig is the name of the ModelConfig, in the Workflow's
+	// namespace, used for routing. Defaults to the default model config.
+	// +optional
+	ModelConfig string `json:"modelConfig,omitempty"`
+	// Instruction tells the model how to pick a step. Defaults to routing
+	// each request to the agent best suited to it.
+	// +optional
+	Instruction string `json:"instruction,omitempty"`
+}
+
+// WorkflowStatus defines the observed state of Workflow.
+type WorkflowStatus struct {
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:categories=kagent,shortName=wf
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Pattern",type="string",JSONPath=".spec.pattern"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:storageversion
+
+// Workflow composes existing Agents into an orchestrator agent that runs
+// them sequentially, in parallel or behind a router. The orchestrator is an
+// Agent of the same name, owned by the Workflow, with its own A2A endpoint.
+type Workflow struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +required
+	Spec WorkflowSpec `json:"spec"`
+	// +optional
+	Status WorkflowStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// WorkflowList contains a list of Workflow.
+type WorkflowList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Workflow `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(func(s *runtime.Scheme) error {
+		s.AddKnownTypes(GroupVersion, &Workflow{}, &WorkflowList{})
+		return nil
+	})
+}
@@ -21,12 +21,29 @@ limitations under the License.
 package v1alpha2
 
 import (
-	"k8s.io/api/core/v1"
+	"k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *A2AAPIKeySecurityScheme) DeepCopyInto(out *A2AAPIKeySecurityScheme) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new A2AAPIKeySecurityScheme.
+func (in *A2AAPIKeySecurityScheme) DeepCopy() *A2AAPIKeySecurityScheme {
+	if in == nil {
+		return nil
+	}
+	out := new(A2AAPIKeySecurityScheme)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *A2AConfig) DeepCopyInto(out *A2AConfig) {
 	*out = *in
@@ -37,6 +54,23 @@ func (in *A2AConfig) DeepCopyInto(out *A2AConfig) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DefaultInputModes != nil {
+		in, out := &in.DefaultInputModes, &out.DefaultInputModes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DefaultOutputModes != nil {
+		in, out := &in.DefaultOutputModes, &out.DefaultOutputModes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SecuritySchemes != nil {
+		in, out := &in.SecuritySchemes, &out.SecuritySchemes
+		*out = make([]A2ASecurityScheme, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new A2AConfig.
@@ -50,170 +84,215 @@ func (in *A2AConfig) DeepCopy() *A2AConfig {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Agent) DeepCopyInto(out *Agent) {
+func (in *A2AHTTPSecurityScheme) DeepCopyInto(out *A2AHTTPSecurityScheme) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Agent.
-func (in *Agent) DeepCopy() *Agent {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new A2AHTTPSecurityScheme.
+func (in *A2AHTTPSecurityScheme) DeepCopy() *A2AHTTPSecurityScheme {
 	if in == nil {
 		return nil
 	}
-	out := new(Agent)
+	out := new(A2AHTTPSecurityScheme)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Agent) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *A2AMutualTLSSecurityScheme) DeepCopyInto(out *A2AMutualTLSSecurityScheme) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new A2AMutualTLSSecurityScheme.
+func (in *A2AMutualTLSSecurityScheme) DeepCopy() *A2AMutualTLSSecurityScheme {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(A2AMutualTLSSecurityScheme)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AgentHarness) DeepCopyInto(out *AgentHarness) {
+func (in *A2AOAuth2SecurityScheme) DeepCopyInto(out *A2AOAuth2SecurityScheme) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentHarness.
-func (in *AgentHarness) DeepCopy() *AgentHarness {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new A2AOAuth2SecurityScheme.
+func (in *A2AOAuth2SecurityScheme) DeepCopy() *A2AOAuth2SecurityScheme {
 	if in == nil {
 		return nil
 	}
-	out := new(AgentHarness)
+	out := new(A2AOAuth2SecurityScheme)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *AgentHarness) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *A2AOpenIDConnectSecurityScheme) DeepCopyInto(out *A2AOpenIDConnectSecurityScheme) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new A2AOpenIDConnectSecurityScheme.
+func (in *A2AOpenIDConnectSecurityScheme) DeepCopy() *A2AOpenIDConnectSecurityScheme {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(A2AOpenIDConnectSecurityScheme)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AgentHarnessChannel) DeepCopyInto(out *AgentHarnessChannel) {
+func (in *A2ASecurityScheme) DeepCopyInto(out *A2ASecurityScheme) {
 	*out = *in
-	if in.Telegram != nil {
-		in, out := &in.Telegram, &out.Telegram
-		*out = new(AgentHarnessTelegramChannelSpec)
-		(*in).DeepCopyInto(*out)
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.Slack != nil {
-		in, out := &in.Slack, &out.Slack
-		*out = new(AgentHarnessSlackChannelSpec)
+	if in.APIKey != nil {
+		in, out := &in.APIKey, &out.APIKey
+		*out = new(A2AAPIKeySecurityScheme)
+		**out = **in
+	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(A2AHTTPSecurityScheme)
+		**out = **in
+	}
+	if in.OAuth2 != nil {
+		in, out := &in.OAuth2, &out.OAuth2
+		*out = new(A2AOAuth2SecurityScheme)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.OpenIDConnect != nil {
+		in, out := &in.OpenIDConnect, &out.OpenIDConnect
+		*out = new(A2AOpenIDConnectSecurityScheme)
+		**out = **in
+	}
+	if in.MutualTLS != nil {
+		in, out := &in.MutualTLS, &out.MutualTLS
+		*out = new(A2AMutualTLSSecurityScheme)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentHarnessChannel.
-func (in *AgentHarnessChannel) DeepCopy() *AgentHarnessChannel {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new A2ASecurityScheme.
+func (in *A2ASecurityScheme) DeepCopy() *A2ASecurityScheme {
 	if in == nil {
 		return nil
 	}
-	out := new(AgentHarnessChannel)
+	out := new(A2ASecurityScheme)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AgentHarnessChannelCredential) DeepCopyInto(out *AgentHarnessChannelCredential) {
+func (in *APIKeySource) DeepCopyInto(out *APIKeySource) {
 	*out = *in
-	if in.ValueFrom != nil {
-		in, out := &in.ValueFrom, &out.ValueFrom
-		*out = new(ValueSource)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentHarnessChannelCredential.
-func (in *AgentHarnessChannelCredential) DeepCopy() *AgentHarnessChannelCredential {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIKeySource.
+func (in *APIKeySource) DeepCopy() *APIKeySource {
 	if in == nil {
 		return nil
 	}
-	out := new(AgentHarnessChannelCredential)
+	out := new(APIKeySource)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AgentHarnessConnection) DeepCopyInto(out *AgentHarnessConnection) {
+func (in *Agent) DeepCopyInto(out *Agent) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentHarnessConnection.
-func (in *AgentHarnessConnection) DeepCopy() *AgentHarnessConnection {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Agent.
+func (in *Agent) DeepCopy() *Agent {
 	if in == nil {
 		return nil
 	}
-	out := new(AgentHarnessConnection)
+	out := new(Agent)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Agent) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AgentHarnessHermesSlackOptions) DeepCopyInto(out *AgentHarnessHermesSlackOptions) {
+func (in *AgentBudget) DeepCopyInto(out *AgentBudget) {
 	*out = *in
-	if in.AllowedUserIDs != nil {
-		in, out := &in.AllowedUserIDs, &out.AllowedUserIDs
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.MaxTotalTokens != nil {
+		in, out := &in.MaxTotalTokens, &out.MaxTotalTokens
+		*out = new(int64)
+		**out = **in
 	}
-	if in.AllowedUserIDsFrom != nil {
-		in, out := &in.AllowedUserIDsFrom, &out.AllowedUserIDsFrom
-		*out = new(ValueSource)
+	if in.MaxSessionTokens != nil {
+		in, out := &in.MaxSessionTokens, &out.MaxSessionTokens
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxToolCalls != nil {
+		in, out := &in.MaxToolCalls, &out.MaxToolCalls
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxTurns != nil {
+		in, out := &in.MaxTurns, &out.MaxTurns
+		*out = new(int32)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentHarnessHermesSlackOptions.
-func (in *AgentHarnessHermesSlackOptions) DeepCopy() *AgentHarnessHermesSlackOptions {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentBudget.
+func (in *AgentBudget) DeepCopy() *AgentBudget {
 	if in == nil {
 		return nil
 	}
-	out := new(AgentHarnessHermesSlackOptions)
+	out := new(AgentBudget)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AgentHarnessList) DeepCopyInto(out *AgentHarnessList) {
+func (in *AgentEval) DeepCopyInto(out *AgentEval) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]AgentHarness, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentHarnessList.
-func (in *AgentHarnessList) DeepCopy() *AgentHarnessList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentEval.
+func (in *AgentEval) DeepCopy() *AgentEval {
 	if in == nil {
 		return nil
 	}
-	out := new(AgentHarnessList)
+	out := new(AgentEval)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *AgentHarnessList) DeepCopyObject() runtime.Object {
+func (in *AgentEval) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -221,739 +300,1744 @@ func (in *AgentHarnessList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AgentHarnessOpenClawSlackOptions) DeepCopyInto(out *AgentHarnessOpenClawSlackOptions) {
+func (in *AgentEvalCase) DeepCopyInto(out *AgentEvalCase) {
 	*out = *in
-	if in.AllowlistChannels != nil {
-		in, out := &in.AllowlistChannels, &out.AllowlistChannels
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.InteractiveReplies != nil {
-		in, out := &in.InteractiveReplies, &out.InteractiveReplies
-		*out = new(bool)
-		**out = **in
-	}
+	in.Expect.DeepCopyInto(&out.Expect)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentHarnessOpenClawSlackOptions.
-func (in *AgentHarnessOpenClawSlackOptions) DeepCopy() *AgentHarnessOpenClawSlackOptions {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentEvalCase.
+func (in *AgentEvalCase) DeepCopy() *AgentEvalCase {
 	if in == nil {
 		return nil
 	}
-	out := new(AgentHarnessOpenClawSlackOptions)
+	out := new(AgentEvalCase)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AgentHarnessSlackChannelSpec) DeepCopyInto(out *AgentHarnessSlackChannelSpec) {
+func (in *AgentEvalCaseResult) DeepCopyInto(out *AgentEvalCaseResult) {
 	*out = *in
-	in.BotToken.DeepCopyInto(&out.BotToken)
-	in.AppToken.DeepCopyInto(&out.AppToken)
-	if in.OpenClaw != nil {
-		in, out := &in.OpenClaw, &out.OpenClaw
-		*out = new(AgentHarnessOpenClawSlackOptions)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Hermes != nil {
-		in, out := &in.Hermes, &out.Hermes
-		*out = new(AgentHarnessHermesSlackOptions)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentHarnessSlackChannelSpec.
-func (in *AgentHarnessSlackChannelSpec) DeepCopy() *AgentHarnessSlackChannelSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentEvalCaseResult.
+func (in *AgentEvalCaseResult) DeepCopy() *AgentEvalCaseResult {
 	if in == nil {
 		return nil
 	}
-	out := new(AgentHarnessSlackChannelSpec)
+	out := new(AgentEvalCaseResult)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AgentHarnessSpec) DeepCopyInto(out *AgentHarnessSpec) {
+func (in *AgentEvalExpectation) DeepCopyInto(out *AgentEvalExpectation) {
 	*out = *in
-	if in.Substrate != nil {
-		in, out := &in.Substrate, &out.Substrate
-		*out = new(AgentHarnessSubstrateSpec)
-		(*in).DeepCopyInto(*out)
+	if in.Contains != nil {
+		in, out := &in.Contains, &out.Contains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.Env != nil {
-		in, out := &in.Env, &out.Env
-		*out = make([]v1.EnvVar, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.NotContains != nil {
+		in, out := &in.NotContains, &out.NotContains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.Channels != nil {
-		in, out := &in.Channels, &out.Channels
-		*out = make([]AgentHarnessChannel, len(*in))
+	if in.JSONPath != nil {
+		in, out := &in.JSONPath, &out.JSONPath
+		*out = make([]AgentEvalJSONPathAssertion, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Rubric != nil {
+		in, out := &in.Rubric, &out.Rubric
+		*out = new(AgentEvalRubric)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentHarnessSpec.
-func (in *AgentHarnessSpec) DeepCopy() *AgentHarnessSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentEvalExpectation.
+func (in *AgentEvalExpectation) DeepCopy() *AgentEvalExpectation {
 	if in == nil {
 		return nil
 	}
-	out := new(AgentHarnessSpec)
+	out := new(AgentEvalExpectation)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AgentHarnessStatus) DeepCopyInto(out *AgentHarnessStatus) {
+func (in *AgentEvalJSONPathAssertion) DeepCopyInto(out *AgentEvalJSONPathAssertion) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.BackendRef != nil {
-		in, out := &in.BackendRef, &out.BackendRef
-		*out = new(AgentHarnessStatusRef)
-		**out = **in
-	}
-	if in.Connection != nil {
-		in, out := &in.Connection, &out.Connection
-		*out = new(AgentHarnessConnection)
+	if in.Value != nil {
+		in, out := &in.Value, &out.Value
+		*out = new(string)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentHarnessStatus.
-func (in *AgentHarnessStatus) DeepCopy() *AgentHarnessStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentEvalJSONPathAssertion.
+func (in *AgentEvalJSONPathAssertion) DeepCopy() *AgentEvalJSONPathAssertion {
 	if in == nil {
 		return nil
 	}
-	out := new(AgentHarnessStatus)
+	out := new(AgentEvalJSONPathAssertion)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AgentHarnessStatusRef) DeepCopyInto(out *AgentHarnessStatusRef) {
+func (in *AgentEvalList) DeepCopyInto(out *AgentEvalList) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AgentEval, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentHarnessStatusRef.
-func (in *AgentHarnessStatusRef) DeepCopy() *AgentHarnessStatusRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentEvalList.
+func (in *AgentEvalList) DeepCopy() *AgentEvalList {
 	if in == nil {
 		return nil
 	}
-	out := new(AgentHarnessStatusRef)
+	out := new(AgentEvalList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AgentEvalList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AgentHarnessSubstrateSnapshotsConfig) DeepCopyInto(out *AgentHarnessSubstrateSnapshotsConfig) {
+func (in *AgentEvalRubric) DeepCopyInto(out *AgentEvalRubric) {
 	*out = *in
+	if in.MinScore != nil {
+		in, out := &in.MinScore, &out.MinScore
+		*out = new(int32)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentHarnessSubstrateSnapshotsConfig.
-func (in *AgentHarnessSubstrateSnapshotsConfig) DeepCopy() *AgentHarnessSubstrateSnapshotsConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentEvalRubric.
+func (in *AgentEvalRubric) DeepCopy() *AgentEvalRubric {
 	if in == nil {
 		return nil
 	}
-	out := new(AgentHarnessSubstrateSnapshotsConfig)
+	out := new(AgentEvalRubric)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AgentHarnessSubstrateSpec) DeepCopyInto(out *AgentHarnessSubstrateSpec) {
+func (in *AgentEvalRun) DeepCopyInto(out *AgentEvalRun) {
 	*out = *in
-	if in.WorkerPoolRef != nil {
-		in, out := &in.WorkerPoolRef, &out.WorkerPoolRef
-		*out = new(TypedLocalReference)
-		**out = **in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
 	}
-	if in.SnapshotsConfig != nil {
-		in, out := &in.SnapshotsConfig, &out.SnapshotsConfig
-		*out = new(AgentHarnessSubstrateSnapshotsConfig)
-		**out = **in
+	if in.Results != nil {
+		in, out := &in.Results, &out.Results
+		*out = make([]AgentEvalCaseResult, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentHarnessSubstrateSpec.
-func (in *AgentHarnessSubstrateSpec) DeepCopy() *AgentHarnessSubstrateSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentEvalRun.
+func (in *AgentEvalRun) DeepCopy() *AgentEvalRun {
 	if in == nil {
 		return nil
 	}
-	out := new(AgentHarnessSubstrateSpec)
+	out := new(AgentEvalRun)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AgentHarnessTelegramChannelSpec) DeepCopyInto(out *AgentHarnessTelegramChannelSpec) {
+func (in *AgentEvalSpec) DeepCopyInto(out *AgentEvalSpec) {
 	*out = *in
-	in.BotToken.DeepCopyInto(&out.BotToken)
-	if in.AllowedUserIDs != nil {
-		in, out := &in.AllowedUserIDs, &out.AllowedUserIDs
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.Cases != nil {
+		in, out := &in.Cases, &out.Cases
+		*out = make([]AgentEvalCase, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	if in.AllowedUserIDsFrom != nil {
-		in, out := &in.AllowedUserIDsFrom, &out.AllowedUserIDsFrom
-		*out = new(ValueSource)
+	if in.PassThreshold != nil {
+		in, out := &in.PassThreshold, &out.PassThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.HistoryLimit != nil {
+		in, out := &in.HistoryLimit, &out.HistoryLimit
+		*out = new(int32)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentHarnessTelegramChannelSpec.
-func (in *AgentHarnessTelegramChannelSpec) DeepCopy() *AgentHarnessTelegramChannelSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentEvalSpec.
+func (in *AgentEvalSpec) DeepCopy() *AgentEvalSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(AgentHarnessTelegramChannelSpec)
+	out := new(AgentEvalSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AgentList) DeepCopyInto(out *AgentList) {
+func (in *AgentEvalStatus) DeepCopyInto(out *AgentEvalStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]Agent, len(*in))
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Active != nil {
+		in, out := &in.Active, &out.Active
+		*out = new(AgentEvalRun)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]AgentEvalRun, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentList.
-func (in *AgentList) DeepCopy() *AgentList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentEvalStatus.
+func (in *AgentEvalStatus) DeepCopy() *AgentEvalStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(AgentList)
+	out := new(AgentEvalStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *AgentList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AgentProvider) DeepCopyInto(out *AgentProvider) {
+func (in *AgentHarness) DeepCopyInto(out *AgentHarness) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentProvider.
-func (in *AgentProvider) DeepCopy() *AgentProvider {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentHarness.
+func (in *AgentHarness) DeepCopy() *AgentHarness {
 	if in == nil {
 		return nil
 	}
-	out := new(AgentProvider)
+	out := new(AgentHarness)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AgentSkill) DeepCopyInto(out *AgentSkill) {
-	*out = *in
-	if in.Tags != nil {
-		in, out := &in.Tags, &out.Tags
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.Examples != nil {
-		in, out := &in.Examples, &out.Examples
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.InputModes != nil {
-		in, out := &in.InputModes, &out.InputModes
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.OutputModes != nil {
-		in, out := &in.OutputModes, &out.OutputModes
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentSkill.
-func (in *AgentSkill) DeepCopy() *AgentSkill {
-	if in == nil {
-		return nil
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AgentHarness) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
-	out := new(AgentSkill)
-	in.DeepCopyInto(out)
-	return out
+	return nil
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AgentSpec) DeepCopyInto(out *AgentSpec) {
+func (in *AgentHarnessChannel) DeepCopyInto(out *AgentHarnessChannel) {
 	*out = *in
-	if in.BYO != nil {
-		in, out := &in.BYO, &out.BYO
-		*out = new(BYOAgentSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Declarative != nil {
-		in, out := &in.Declarative, &out.Declarative
-		*out = new(DeclarativeAgentSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Provider != nil {
-		in, out := &in.Provider, &out.Provider
-		*out = new(AgentProvider)
-		**out = **in
-	}
-	if in.Skills != nil {
-		in, out := &in.Skills, &out.Skills
-		*out = new(SkillForAgent)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Sandbox != nil {
-		in, out := &in.Sandbox, &out.Sandbox
-		*out = new(SandboxConfig)
+	if in.Telegram != nil {
+		in, out := &in.Telegram, &out.Telegram
+		*out = new(AgentHarnessTelegramChannelSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.AllowedNamespaces != nil {
-		in, out := &in.AllowedNamespaces, &out.AllowedNamespaces
-		*out = new(AllowedNamespaces)
+	if in.Slack != nil {
+		in, out := &in.Slack, &out.Slack
+		*out = new(AgentHarnessSlackChannelSpec)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentSpec.
-func (in *AgentSpec) DeepCopy() *AgentSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentHarnessChannel.
+func (in *AgentHarnessChannel) DeepCopy() *AgentHarnessChannel {
 	if in == nil {
 		return nil
 	}
-	out := new(AgentSpec)
+	out := new(AgentHarnessChannel)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AgentStatus) DeepCopyInto(out *AgentStatus) {
+func (in *AgentHarnessChannelCredential) DeepCopyInto(out *AgentHarnessChannelCredential) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.ValueFrom != nil {
+		in, out := &in.ValueFrom, &out.ValueFrom
+		*out = new(ValueSource)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentStatus.
-func (in *AgentStatus) DeepCopy() *AgentStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentHarnessChannelCredential.
+func (in *AgentHarnessChannelCredential) DeepCopy() *AgentHarnessChannelCredential {
 	if in == nil {
 		return nil
 	}
-	out := new(AgentStatus)
+	out := new(AgentHarnessChannelCredential)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AllowedNamespaces) DeepCopyInto(out *AllowedNamespaces) {
+func (in *AgentHarnessConnection) DeepCopyInto(out *AgentHarnessConnection) {
 	*out = *in
-	if in.Selector != nil {
-		in, out := &in.Selector, &out.Selector
-		*out = new(metav1.LabelSelector)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AllowedNamespaces.
-func (in *AllowedNamespaces) DeepCopy() *AllowedNamespaces {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentHarnessConnection.
+func (in *AgentHarnessConnection) DeepCopy() *AgentHarnessConnection {
 	if in == nil {
 		return nil
 	}
-	out := new(AllowedNamespaces)
+	out := new(AgentHarnessConnection)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AnthropicConfig) DeepCopyInto(out *AnthropicConfig) {
+func (in *AgentHarnessHermesSlackOptions) DeepCopyInto(out *AgentHarnessHermesSlackOptions) {
 	*out = *in
+	if in.AllowedUserIDs != nil {
+		in, out := &in.AllowedUserIDs, &out.AllowedUserIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedUserIDsFrom != nil {
+		in, out := &in.AllowedUserIDsFrom, &out.AllowedUserIDsFrom
+		*out = new(ValueSource)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnthropicConfig.
-func (in *AnthropicConfig) DeepCopy() *AnthropicConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentHarnessHermesSlackOptions.
+func (in *AgentHarnessHermesSlackOptions) DeepCopy() *AgentHarnessHermesSlackOptions {
 	if in == nil {
 		return nil
 	}
-	out := new(AnthropicConfig)
+	out := new(AgentHarnessHermesSlackOptions)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AnthropicVertexAIConfig) DeepCopyInto(out *AnthropicVertexAIConfig) {
+func (in *AgentHarnessList) DeepCopyInto(out *AgentHarnessList) {
 	*out = *in
-	in.BaseVertexAIConfig.DeepCopyInto(&out.BaseVertexAIConfig)
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AgentHarness, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnthropicVertexAIConfig.
-func (in *AnthropicVertexAIConfig) DeepCopy() *AnthropicVertexAIConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentHarnessList.
+func (in *AgentHarnessList) DeepCopy() *AgentHarnessList {
 	if in == nil {
 		return nil
 	}
-	out := new(AnthropicVertexAIConfig)
+	out := new(AgentHarnessList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AgentHarnessList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AzureOpenAIConfig) DeepCopyInto(out *AzureOpenAIConfig) {
+func (in *AgentHarnessOpenClawSlackOptions) DeepCopyInto(out *AgentHarnessOpenClawSlackOptions) {
 	*out = *in
-	if in.MaxTokens != nil {
-		in, out := &in.MaxTokens, &out.MaxTokens
-		*out = new(int)
+	if in.AllowlistChannels != nil {
+		in, out := &in.AllowlistChannels, &out.AllowlistChannels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.InteractiveReplies != nil {
+		in, out := &in.InteractiveReplies, &out.InteractiveReplies
+		*out = new(bool)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureOpenAIConfig.
-func (in *AzureOpenAIConfig) DeepCopy() *AzureOpenAIConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentHarnessOpenClawSlackOptions.
+func (in *AgentHarnessOpenClawSlackOptions) DeepCopy() *AgentHarnessOpenClawSlackOptions {
 	if in == nil {
 		return nil
 	}
-	out := new(AzureOpenAIConfig)
+	out := new(AgentHarnessOpenClawSlackOptions)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BYOAgentSpec) DeepCopyInto(out *BYOAgentSpec) {
+func (in *AgentHarnessSlackChannelSpec) DeepCopyInto(out *AgentHarnessSlackChannelSpec) {
 	*out = *in
-	if in.Deployment != nil {
-		in, out := &in.Deployment, &out.Deployment
-		*out = new(ByoDeploymentSpec)
+	in.BotToken.DeepCopyInto(&out.BotToken)
+	in.AppToken.DeepCopyInto(&out.AppToken)
+	if in.OpenClaw != nil {
+		in, out := &in.OpenClaw, &out.OpenClaw
+		*out = new(AgentHarnessOpenClawSlackOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Hermes != nil {
+		in, out := &in.Hermes, &out.Hermes
+		*out = new(AgentHarnessHermesSlackOptions)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BYOAgentSpec.
-func (in *BYOAgentSpec) DeepCopy() *BYOAgentSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentHarnessSlackChannelSpec.
+func (in *AgentHarnessSlackChannelSpec) DeepCopy() *AgentHarnessSlackChannelSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(BYOAgentSpec)
+	out := new(AgentHarnessSlackChannelSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentHarnessSpec) DeepCopyInto(out *AgentHarnessSpec) {
+	*out = *in
+	if in.Substrate != nil {
+		in, out := &in.Substrate, &out.Substrate
+		*out = new(AgentHarnessSubstrateSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Channels != nil {
+		in, out := &in.Channels, &out.Channels
+		*out = make([]AgentHarnessChannel, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentHarnessSpec.
+func (in *AgentHarnessSpec) DeepCopy() *AgentHarnessSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentHarnessSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentHarnessStatus) DeepCopyInto(out *AgentHarnessStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BackendRef != nil {
+		in, out := &in.BackendRef, &out.BackendRef
+		*out = new(AgentHarnessStatusRef)
+		**out = **in
+	}
+	if in.Connection != nil {
+		in, out := &in.Connection, &out.Connection
+		*out = new(AgentHarnessConnection)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentHarnessStatus.
+func (in *AgentHarnessStatus) DeepCopy() *AgentHarnessStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentHarnessStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentHarnessStatusRef) DeepCopyInto(out *AgentHarnessStatusRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentHarnessStatusRef.
+func (in *AgentHarnessStatusRef) DeepCopy() *AgentHarnessStatusRef {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentHarnessStatusRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentHarnessSubstrateSnapshotsConfig) DeepCopyInto(out *AgentHarnessSubstrateSnapshotsConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentHarnessSubstrateSnapshotsConfig.
+func (in *AgentHarnessSubstrateSnapshotsConfig) DeepCopy() *AgentHarnessSubstrateSnapshotsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentHarnessSubstrateSnapshotsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentHarnessSubstrateSpec) DeepCopyInto(out *AgentHarnessSubstrateSpec) {
+	*out = *in
+	if in.WorkerPoolRef != nil {
+		in, out := &in.WorkerPoolRef, &out.WorkerPoolRef
+		*out = new(TypedLocalReference)
+		**out = **in
+	}
+	if in.SnapshotsConfig != nil {
+		in, out := &in.SnapshotsConfig, &out.SnapshotsConfig
+		*out = new(AgentHarnessSubstrateSnapshotsConfig)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentHarnessSubstrateSpec.
+func (in *AgentHarnessSubstrateSpec) DeepCopy() *AgentHarnessSubstrateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentHarnessSubstrateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentHarnessTelegramChannelSpec) DeepCopyInto(out *AgentHarnessTelegramChannelSpec) {
+	*out = *in
+	in.BotToken.DeepCopyInto(&out.BotToken)
+	if in.AllowedUserIDs != nil {
+		in, out := &in.AllowedUserIDs, &out.AllowedUserIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedUserIDsFrom != nil {
+		in, out := &in.AllowedUserIDsFrom, &out.AllowedUserIDsFrom
+		*out = new(ValueSource)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentHarnessTelegramChannelSpec.
+func (in *AgentHarnessTelegramChannelSpec) DeepCopy() *AgentHarnessTelegramChannelSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentHarnessTelegramChannelSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentList) DeepCopyInto(out *AgentList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Agent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentList.
+func (in *AgentList) DeepCopy() *AgentList {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AgentList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentNetworkPolicySpec) DeepCopyInto(out *AgentNetworkPolicySpec) {
+	*out = *in
+	if in.AdditionalEgress != nil {
+		in, out := &in.AdditionalEgress, &out.AdditionalEgress
+		*out = make([]networkingv1.NetworkPolicyEgressRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentNetworkPolicySpec.
+func (in *AgentNetworkPolicySpec) DeepCopy() *AgentNetworkPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentNetworkPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentProvider) DeepCopyInto(out *AgentProvider) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentProvider.
+func (in *AgentProvider) DeepCopy() *AgentProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentSkill) DeepCopyInto(out *AgentSkill) {
+	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Examples != nil {
+		in, out := &in.Examples, &out.Examples
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.InputModes != nil {
+		in, out := &in.InputModes, &out.InputModes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.OutputModes != nil {
+		in, out := &in.OutputModes, &out.OutputModes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentSkill.
+func (in *AgentSkill) DeepCopy() *AgentSkill {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentSkill)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentSpec) DeepCopyInto(out *AgentSpec) {
+	*out = *in
+	if in.BYO != nil {
+		in, out := &in.BYO, &out.BYO
+		*out = new(BYOAgentSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Declarative != nil {
+		in, out := &in.Declarative, &out.Declarative
+		*out = new(DeclarativeAgentSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Provider != nil {
+		in, out := &in.Provider, &out.Provider
+		*out = new(AgentProvider)
+		**out = **in
+	}
+	if in.Skills != nil {
+		in, out := &in.Skills, &out.Skills
+		*out = new(SkillForAgent)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Sandbox != nil {
+		in, out := &in.Sandbox, &out.Sandbox
+		*out = new(SandboxConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Streaming != nil {
+		in, out := &in.Streaming, &out.Streaming
+		*out = new(StreamingConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SmokeTests != nil {
+		in, out := &in.SmokeTests, &out.SmokeTests
+		*out = new(SmokeTestSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AllowedNamespaces != nil {
+		in, out := &in.AllowedNamespaces, &out.AllowedNamespaces
+		*out = new(AllowedNamespaces)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentSpec.
+func (in *AgentSpec) DeepCopy() *AgentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentStatus) DeepCopyInto(out *AgentStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SmokeTests != nil {
+		in, out := &in.SmokeTests, &out.SmokeTests
+		*out = new(SmokeTestStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretsRotatedAt != nil {
+		in, out := &in.SecretsRotatedAt, &out.SecretsRotatedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(AutoscalingStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Skills != nil {
+		in, out := &in.Skills, &out.Skills
+		*out = make([]SkillStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentStatus.
+func (in *AgentStatus) DeepCopy() *AgentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentTimeouts) DeepCopyInto(out *AgentTimeouts) {
+	*out = *in
+	if in.LLMRequest != nil {
+		in, out := &in.LLMRequest, &out.LLMRequest
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ToolCall != nil {
+		in, out := &in.ToolCall, &out.ToolCall
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Tools != nil {
+		in, out := &in.Tools, &out.Tools
+		*out = make([]ToolTimeout, len(*in))
+		copy(*out, *in)
+	}
+	if in.Task != nil {
+		in, out := &in.Task, &out.Task
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentTimeouts.
+func (in *AgentTimeouts) DeepCopy() *AgentTimeouts {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentTimeouts)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AllowedNamespaces) DeepCopyInto(out *AllowedNamespaces) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AllowedNamespaces.
+func (in *AllowedNamespaces) DeepCopy() *AllowedNamespaces {
+	if in == nil {
+		return nil
+	}
+	out := new(AllowedNamespaces)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AnthropicConfig) DeepCopyInto(out *AnthropicConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnthropicConfig.
+func (in *AnthropicConfig) DeepCopy() *AnthropicConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AnthropicConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AnthropicVertexAIConfig) DeepCopyInto(out *AnthropicVertexAIConfig) {
+	*out = *in
+	in.BaseVertexAIConfig.DeepCopyInto(&out.BaseVertexAIConfig)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnthropicVertexAIConfig.
+func (in *AnthropicVertexAIConfig) DeepCopy() *AnthropicVertexAIConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AnthropicVertexAIConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalingSpec) DeepCopyInto(out *AutoscalingSpec) {
+	*out = *in
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TargetCPUUtilizationPercentage != nil {
+		in, out := &in.TargetCPUUtilizationPercentage, &out.TargetCPUUtilizationPercentage
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TargetMemoryUtilizationPercentage != nil {
+		in, out := &in.TargetMemoryUtilizationPercentage, &out.TargetMemoryUtilizationPercentage
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RequestsPerSecond != nil {
+		in, out := &in.RequestsPerSecond, &out.RequestsPerSecond
+		*out = new(RequestsPerSecondTarget)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingSpec.
+func (in *AutoscalingSpec) DeepCopy() *AutoscalingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalingStatus) DeepCopyInto(out *AutoscalingStatus) {
+	*out = *in
+	if in.LastScaleTime != nil {
+		in, out := &in.LastScaleTime, &out.LastScaleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v2.HorizontalPodAutoscalerCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingStatus.
+func (in *AutoscalingStatus) DeepCopy() *AutoscalingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureOpenAIConfig) DeepCopyInto(out *AzureOpenAIConfig) {
+	*out = *in
+	if in.MaxTokens != nil {
+		in, out := &in.MaxTokens, &out.MaxTokens
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureOpenAIConfig.
+func (in *AzureOpenAIConfig) DeepCopy() *AzureOpenAIConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureOpenAIConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BYOAgentSpec) DeepCopyInto(out *BYOAgentSpec) {
+	*out = *in
+	if in.Deployment != nil {
+		in, out := &in.Deployment, &out.Deployment
+		*out = new(ByoDeploymentSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BYOAgentSpec.
+func (in *BYOAgentSpec) DeepCopy() *BYOAgentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BYOAgentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BaseVertexAIConfig) DeepCopyInto(out *BaseVertexAIConfig) {
+	*out = *in
+	if in.StopSequences != nil {
+		in, out := &in.StopSequences, &out.StopSequences
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BaseVertexAIConfig.
+func (in *BaseVertexAIConfig) DeepCopy() *BaseVertexAIConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(BaseVertexAIConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BedrockConfig) DeepCopyInto(out *BedrockConfig) {
+	*out = *in
+	if in.AdditionalModelRequestFields != nil {
+		in, out := &in.AdditionalModelRequestFields, &out.AdditionalModelRequestFields
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BedrockConfig.
+func (in *BedrockConfig) DeepCopy() *BedrockConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(BedrockConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ByoDeploymentSpec) DeepCopyInto(out *ByoDeploymentSpec) {
+	*out = *in
+	if in.Cmd != nil {
+		in, out := &in.Cmd, &out.Cmd
+		*out = new(string)
+		**out = **in
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.WorkingDir != nil {
+		in, out := &in.WorkingDir, &out.WorkingDir
+		*out = new(string)
+		**out = **in
+	}
+	in.SharedDeploymentSpec.DeepCopyInto(&out.SharedDeploymentSpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ByoDeploymentSpec.
+func (in *ByoDeploymentSpec) DeepCopy() *ByoDeploymentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ByoDeploymentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContextCompressionConfig) DeepCopyInto(out *ContextCompressionConfig) {
+	*out = *in
+	if in.CompactionInterval != nil {
+		in, out := &in.CompactionInterval, &out.CompactionInterval
+		*out = new(int)
+		**out = **in
+	}
+	if in.OverlapSize != nil {
+		in, out := &in.OverlapSize, &out.OverlapSize
+		*out = new(int)
+		**out = **in
+	}
+	if in.Summarizer != nil {
+		in, out := &in.Summarizer, &out.Summarizer
+		*out = new(ContextSummarizerConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TokenThreshold != nil {
+		in, out := &in.TokenThreshold, &out.TokenThreshold
+		*out = new(int)
+		**out = **in
+	}
+	if in.EventRetentionSize != nil {
+		in, out := &in.EventRetentionSize, &out.EventRetentionSize
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContextCompressionConfig.
+func (in *ContextCompressionConfig) DeepCopy() *ContextCompressionConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ContextCompressionConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContextConfig) DeepCopyInto(out *ContextConfig) {
+	*out = *in
+	if in.Compaction != nil {
+		in, out := &in.Compaction, &out.Compaction
+		*out = new(ContextCompressionConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContextConfig.
+func (in *ContextConfig) DeepCopy() *ContextConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ContextConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContextSummarizerConfig) DeepCopyInto(out *ContextSummarizerConfig) {
+	*out = *in
+	if in.ModelConfig != nil {
+		in, out := &in.ModelConfig, &out.ModelConfig
+		*out = new(string)
+		**out = **in
+	}
+	if in.PromptTemplate != nil {
+		in, out := &in.PromptTemplate, &out.PromptTemplate
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContextSummarizerConfig.
+func (in *ContextSummarizerConfig) DeepCopy() *ContextSummarizerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ContextSummarizerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CronAgent) DeepCopyInto(out *CronAgent) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CronAgent.
+func (in *CronAgent) DeepCopy() *CronAgent {
+	if in == nil {
+		return nil
+	}
+	out := new(CronAgent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CronAgent) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CronAgentList) DeepCopyInto(out *CronAgentList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CronAgent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CronAgentList.
+func (in *CronAgentList) DeepCopy() *CronAgentList {
+	if in == nil {
+		return nil
+	}
+	out := new(CronAgentList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CronAgentList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CronAgentRun) DeepCopyInto(out *CronAgentRun) {
+	*out = *in
+	in.ScheduledTime.DeepCopyInto(&out.ScheduledTime)
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CronAgentRun.
+func (in *CronAgentRun) DeepCopy() *CronAgentRun {
+	if in == nil {
+		return nil
+	}
+	out := new(CronAgentRun)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BaseVertexAIConfig) DeepCopyInto(out *BaseVertexAIConfig) {
+func (in *CronAgentSpec) DeepCopyInto(out *CronAgentSpec) {
 	*out = *in
-	if in.StopSequences != nil {
-		in, out := &in.StopSequences, &out.StopSequences
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.StartingDeadlineSeconds != nil {
+		in, out := &in.StartingDeadlineSeconds, &out.StartingDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.SuccessfulRunsHistoryLimit != nil {
+		in, out := &in.SuccessfulRunsHistoryLimit, &out.SuccessfulRunsHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailedRunsHistoryLimit != nil {
+		in, out := &in.FailedRunsHistoryLimit, &out.FailedRunsHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(CronAgentWebhook)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BaseVertexAIConfig.
-func (in *BaseVertexAIConfig) DeepCopy() *BaseVertexAIConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CronAgentSpec.
+func (in *CronAgentSpec) DeepCopy() *CronAgentSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(BaseVertexAIConfig)
+	out := new(CronAgentSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BedrockConfig) DeepCopyInto(out *BedrockConfig) {
+func (in *CronAgentStatus) DeepCopyInto(out *CronAgentStatus) {
 	*out = *in
-	if in.AdditionalModelRequestFields != nil {
-		in, out := &in.AdditionalModelRequestFields, &out.AdditionalModelRequestFields
-		*out = new(apiextensionsv1.JSON)
-		(*in).DeepCopyInto(*out)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSuccessfulTime != nil {
+		in, out := &in.LastSuccessfulTime, &out.LastSuccessfulTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextScheduleTime != nil {
+		in, out := &in.NextScheduleTime, &out.NextScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Active != nil {
+		in, out := &in.Active, &out.Active
+		*out = make([]CronAgentRun, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]CronAgentRun, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BedrockConfig.
-func (in *BedrockConfig) DeepCopy() *BedrockConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CronAgentStatus.
+func (in *CronAgentStatus) DeepCopy() *CronAgentStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(BedrockConfig)
+	out := new(CronAgentStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ByoDeploymentSpec) DeepCopyInto(out *ByoDeploymentSpec) {
+func (in *CronAgentWebhook) DeepCopyInto(out *CronAgentWebhook) {
 	*out = *in
-	if in.Cmd != nil {
-		in, out := &in.Cmd, &out.Cmd
-		*out = new(string)
+	if in.URLFrom != nil {
+		in, out := &in.URLFrom, &out.URLFrom
+		*out = new(ValueSource)
 		**out = **in
 	}
-	if in.Args != nil {
-		in, out := &in.Args, &out.Args
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CronAgentWebhook.
+func (in *CronAgentWebhook) DeepCopy() *CronAgentWebhook {
+	if in == nil {
+		return nil
+	}
+	out := new(CronAgentWebhook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeclarativeAgentSpec) DeepCopyInto(out *DeclarativeAgentSpec) {
+	*out = *in
+	if in.SystemMessageFrom != nil {
+		in, out := &in.SystemMessageFrom, &out.SystemMessageFrom
+		*out = new(ValueSource)
+		**out = **in
+	}
+	if in.PromptTemplate != nil {
+		in, out := &in.PromptTemplate, &out.PromptTemplate
+		*out = new(PromptTemplateSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tools != nil {
+		in, out := &in.Tools, &out.Tools
+		*out = make([]*Tool, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(Tool)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.A2AConfig != nil {
+		in, out := &in.A2AConfig, &out.A2AConfig
+		*out = new(A2AConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Deployment != nil {
+		in, out := &in.Deployment, &out.Deployment
+		*out = new(DeclarativeDeploymentSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExecuteCodeBlocks != nil {
+		in, out := &in.ExecuteCodeBlocks, &out.ExecuteCodeBlocks
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Memory != nil {
+		in, out := &in.Memory, &out.Memory
+		*out = new(MemorySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ShareTools != nil {
+		in, out := &in.ShareTools, &out.ShareTools
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Context != nil {
+		in, out := &in.Context, &out.Context
+		*out = new(ContextConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PromptCapture != nil {
+		in, out := &in.PromptCapture, &out.PromptCapture
+		*out = new(PromptCaptureSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LLMTrace != nil {
+		in, out := &in.LLMTrace, &out.LLMTrace
+		*out = new(LLMTraceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PromptInjection != nil {
+		in, out := &in.PromptInjection, &out.PromptInjection
+		*out = new(PromptInjectionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ToolPolicy != nil {
+		in, out := &in.ToolPolicy, &out.ToolPolicy
+		*out = new(ToolPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ToolResultLimit != nil {
+		in, out := &in.ToolResultLimit, &out.ToolResultLimit
+		*out = new(ToolResultLimitSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ToolResultValidation != nil {
+		in, out := &in.ToolResultValidation, &out.ToolResultValidation
+		*out = new(ToolResultValidationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DryRunTools != nil {
+		in, out := &in.DryRunTools, &out.DryRunTools
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.WorkingDir != nil {
-		in, out := &in.WorkingDir, &out.WorkingDir
-		*out = new(string)
-		**out = **in
+	if in.Timeouts != nil {
+		in, out := &in.Timeouts, &out.Timeouts
+		*out = new(AgentTimeouts)
+		(*in).DeepCopyInto(*out)
 	}
+	if in.Moderation != nil {
+		in, out := &in.Moderation, &out.Moderation
+		*out = new(ModerationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Budget != nil {
+		in, out := &in.Budget, &out.Budget
+		*out = new(AgentBudget)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeclarativeAgentSpec.
+func (in *DeclarativeAgentSpec) DeepCopy() *DeclarativeAgentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DeclarativeAgentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeclarativeDeploymentSpec) DeepCopyInto(out *DeclarativeDeploymentSpec) {
+	*out = *in
 	in.SharedDeploymentSpec.DeepCopyInto(&out.SharedDeploymentSpec)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ByoDeploymentSpec.
-func (in *ByoDeploymentSpec) DeepCopy() *ByoDeploymentSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeclarativeDeploymentSpec.
+func (in *DeclarativeDeploymentSpec) DeepCopy() *DeclarativeDeploymentSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ByoDeploymentSpec)
+	out := new(DeclarativeDeploymentSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ContextCompressionConfig) DeepCopyInto(out *ContextCompressionConfig) {
+func (in *GDCHServiceAccountConfig) DeepCopyInto(out *GDCHServiceAccountConfig) {
 	*out = *in
-	if in.CompactionInterval != nil {
-		in, out := &in.CompactionInterval, &out.CompactionInterval
-		*out = new(int)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GDCHServiceAccountConfig.
+func (in *GDCHServiceAccountConfig) DeepCopy() *GDCHServiceAccountConfig {
+	if in == nil {
+		return nil
 	}
-	if in.OverlapSize != nil {
-		in, out := &in.OverlapSize, &out.OverlapSize
-		*out = new(int)
-		**out = **in
+	out := new(GDCHServiceAccountConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GeminiConfig) DeepCopyInto(out *GeminiConfig) {
+	*out = *in
+	in.GeminiFunctionCallingConfig.DeepCopyInto(&out.GeminiFunctionCallingConfig)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GeminiConfig.
+func (in *GeminiConfig) DeepCopy() *GeminiConfig {
+	if in == nil {
+		return nil
 	}
-	if in.Summarizer != nil {
-		in, out := &in.Summarizer, &out.Summarizer
-		*out = new(ContextSummarizerConfig)
-		(*in).DeepCopyInto(*out)
+	out := new(GeminiConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GeminiFunctionCallingConfig) DeepCopyInto(out *GeminiFunctionCallingConfig) {
+	*out = *in
+	if in.AllowedFunctionNames != nil {
+		in, out := &in.AllowedFunctionNames, &out.AllowedFunctionNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.TokenThreshold != nil {
-		in, out := &in.TokenThreshold, &out.TokenThreshold
-		*out = new(int)
+	if in.ParallelToolCalls != nil {
+		in, out := &in.ParallelToolCalls, &out.ParallelToolCalls
+		*out = new(bool)
 		**out = **in
 	}
-	if in.EventRetentionSize != nil {
-		in, out := &in.EventRetentionSize, &out.EventRetentionSize
-		*out = new(int)
-		**out = **in
+	if in.SafetySettings != nil {
+		in, out := &in.SafetySettings, &out.SafetySettings
+		*out = make([]GeminiSafetySetting, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContextCompressionConfig.
-func (in *ContextCompressionConfig) DeepCopy() *ContextCompressionConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GeminiFunctionCallingConfig.
+func (in *GeminiFunctionCallingConfig) DeepCopy() *GeminiFunctionCallingConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(ContextCompressionConfig)
+	out := new(GeminiFunctionCallingConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ContextConfig) DeepCopyInto(out *ContextConfig) {
+func (in *GeminiSafetySetting) DeepCopyInto(out *GeminiSafetySetting) {
 	*out = *in
-	if in.Compaction != nil {
-		in, out := &in.Compaction, &out.Compaction
-		*out = new(ContextCompressionConfig)
-		(*in).DeepCopyInto(*out)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GeminiSafetySetting.
+func (in *GeminiSafetySetting) DeepCopy() *GeminiSafetySetting {
+	if in == nil {
+		return nil
+	}
+	out := new(GeminiSafetySetting)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GeminiVertexAIConfig) DeepCopyInto(out *GeminiVertexAIConfig) {
+	*out = *in
+	in.BaseVertexAIConfig.DeepCopyInto(&out.BaseVertexAIConfig)
+	in.GeminiFunctionCallingConfig.DeepCopyInto(&out.GeminiFunctionCallingConfig)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GeminiVertexAIConfig.
+func (in *GeminiVertexAIConfig) DeepCopy() *GeminiVertexAIConfig {
+	if in == nil {
+		return nil
 	}
+	out := new(GeminiVertexAIConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitHubTrigger) DeepCopyInto(out *GitHubTrigger) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContextConfig.
-func (in *ContextConfig) DeepCopy() *ContextConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitHubTrigger.
+func (in *GitHubTrigger) DeepCopy() *GitHubTrigger {
 	if in == nil {
 		return nil
 	}
-	out := new(ContextConfig)
+	out := new(GitHubTrigger)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GitHubTrigger) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ContextSummarizerConfig) DeepCopyInto(out *ContextSummarizerConfig) {
+func (in *GitHubTriggerDelivery) DeepCopyInto(out *GitHubTriggerDelivery) {
 	*out = *in
-	if in.ModelConfig != nil {
-		in, out := &in.ModelConfig, &out.ModelConfig
-		*out = new(string)
-		**out = **in
-	}
-	if in.PromptTemplate != nil {
-		in, out := &in.PromptTemplate, &out.PromptTemplate
-		*out = new(string)
-		**out = **in
+	in.ReceivedTime.DeepCopyInto(&out.ReceivedTime)
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContextSummarizerConfig.
-func (in *ContextSummarizerConfig) DeepCopy() *ContextSummarizerConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitHubTriggerDelivery.
+func (in *GitHubTriggerDelivery) DeepCopy() *GitHubTriggerDelivery {
 	if in == nil {
 		return nil
 	}
-	out := new(ContextSummarizerConfig)
+	out := new(GitHubTriggerDelivery)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DeclarativeAgentSpec) DeepCopyInto(out *DeclarativeAgentSpec) {
+func (in *GitHubTriggerList) DeepCopyInto(out *GitHubTriggerList) {
 	*out = *in
-	if in.SystemMessageFrom != nil {
-		in, out := &in.SystemMessageFrom, &out.SystemMessageFrom
-		*out = new(ValueSource)
-		**out = **in
-	}
-	if in.PromptTemplate != nil {
-		in, out := &in.PromptTemplate, &out.PromptTemplate
-		*out = new(PromptTemplateSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Tools != nil {
-		in, out := &in.Tools, &out.Tools
-		*out = make([]*Tool, len(*in))
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GitHubTrigger, len(*in))
 		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(Tool)
-				(*in).DeepCopyInto(*out)
-			}
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.A2AConfig != nil {
-		in, out := &in.A2AConfig, &out.A2AConfig
-		*out = new(A2AConfig)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Deployment != nil {
-		in, out := &in.Deployment, &out.Deployment
-		*out = new(DeclarativeDeploymentSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ExecuteCodeBlocks != nil {
-		in, out := &in.ExecuteCodeBlocks, &out.ExecuteCodeBlocks
-		*out = new(bool)
-		**out = **in
-	}
-	if in.Memory != nil {
-		in, out := &in.Memory, &out.Memory
-		*out = new(MemorySpec)
-		**out = **in
-	}
-	if in.ShareTools != nil {
-		in, out := &in.ShareTools, &out.ShareTools
-		*out = new(bool)
-		**out = **in
-	}
-	if in.Context != nil {
-		in, out := &in.Context, &out.Context
-		*out = new(ContextConfig)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeclarativeAgentSpec.
-func (in *DeclarativeAgentSpec) DeepCopy() *DeclarativeAgentSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitHubTriggerList.
+func (in *GitHubTriggerList) DeepCopy() *GitHubTriggerList {
 	if in == nil {
 		return nil
 	}
-	out := new(DeclarativeAgentSpec)
+	out := new(GitHubTriggerList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GitHubTriggerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DeclarativeDeploymentSpec) DeepCopyInto(out *DeclarativeDeploymentSpec) {
+func (in *GitHubTriggerRoute) DeepCopyInto(out *GitHubTriggerRoute) {
 	*out = *in
-	in.SharedDeploymentSpec.DeepCopyInto(&out.SharedDeploymentSpec)
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]GitHubTriggerEvent, len(*in))
+		copy(*out, *in)
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeclarativeDeploymentSpec.
-func (in *DeclarativeDeploymentSpec) DeepCopy() *DeclarativeDeploymentSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitHubTriggerRoute.
+func (in *GitHubTriggerRoute) DeepCopy() *GitHubTriggerRoute {
 	if in == nil {
 		return nil
 	}
-	out := new(DeclarativeDeploymentSpec)
+	out := new(GitHubTriggerRoute)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GDCHServiceAccountConfig) DeepCopyInto(out *GDCHServiceAccountConfig) {
+func (in *GitHubTriggerSpec) DeepCopyInto(out *GitHubTriggerSpec) {
 	*out = *in
+	if in.Repositories != nil {
+		in, out := &in.Repositories, &out.Repositories
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]GitHubTriggerRoute, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.HistoryLimit != nil {
+		in, out := &in.HistoryLimit, &out.HistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GDCHServiceAccountConfig.
-func (in *GDCHServiceAccountConfig) DeepCopy() *GDCHServiceAccountConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitHubTriggerSpec.
+func (in *GitHubTriggerSpec) DeepCopy() *GitHubTriggerSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(GDCHServiceAccountConfig)
+	out := new(GitHubTriggerSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GeminiConfig) DeepCopyInto(out *GeminiConfig) {
+func (in *GitHubTriggerStatus) DeepCopyInto(out *GitHubTriggerStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Deliveries != nil {
+		in, out := &in.Deliveries, &out.Deliveries
+		*out = make([]GitHubTriggerDelivery, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GeminiConfig.
-func (in *GeminiConfig) DeepCopy() *GeminiConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitHubTriggerStatus.
+func (in *GitHubTriggerStatus) DeepCopy() *GitHubTriggerStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(GeminiConfig)
+	out := new(GitHubTriggerStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GeminiVertexAIConfig) DeepCopyInto(out *GeminiVertexAIConfig) {
+func (in *GitRepo) DeepCopyInto(out *GitRepo) {
 	*out = *in
-	in.BaseVertexAIConfig.DeepCopyInto(&out.BaseVertexAIConfig)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GeminiVertexAIConfig.
-func (in *GeminiVertexAIConfig) DeepCopy() *GeminiVertexAIConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitRepo.
+func (in *GitRepo) DeepCopy() *GitRepo {
 	if in == nil {
 		return nil
 	}
-	out := new(GeminiVertexAIConfig)
+	out := new(GitRepo)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GitRepo) DeepCopyInto(out *GitRepo) {
+func (in *LLMTraceSpec) DeepCopyInto(out *LLMTraceSpec) {
 	*out = *in
+	if in.RedactPatterns != nil {
+		in, out := &in.RedactPatterns, &out.RedactPatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitRepo.
-func (in *GitRepo) DeepCopy() *GitRepo {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LLMTraceSpec.
+func (in *LLMTraceSpec) DeepCopy() *LLMTraceSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(GitRepo)
+	out := new(LLMTraceSpec)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1007,6 +2091,11 @@ func (in *McpServerTool) DeepCopy() *McpServerTool {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MemorySpec) DeepCopyInto(out *MemorySpec) {
 	*out = *in
+	if in.Qdrant != nil {
+		in, out := &in.Qdrant, &out.Qdrant
+		*out = new(QdrantMemoryConfig)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemorySpec.
@@ -1081,6 +2170,11 @@ func (in *ModelConfigList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ModelConfigSpec) DeepCopyInto(out *ModelConfigSpec) {
 	*out = *in
+	if in.APIKeyFrom != nil {
+		in, out := &in.APIKeyFrom, &out.APIKeyFrom
+		*out = new(APIKeySource)
+		**out = **in
+	}
 	if in.DefaultHeaders != nil {
 		in, out := &in.DefaultHeaders, &out.DefaultHeaders
 		*out = make(map[string]string, len(*in))
@@ -1111,7 +2205,7 @@ func (in *ModelConfigSpec) DeepCopyInto(out *ModelConfigSpec) {
 	if in.Gemini != nil {
 		in, out := &in.Gemini, &out.Gemini
 		*out = new(GeminiConfig)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.GeminiVertexAI != nil {
 		in, out := &in.GeminiVertexAI, &out.GeminiVertexAI
@@ -1133,11 +2227,23 @@ func (in *ModelConfigSpec) DeepCopyInto(out *ModelConfigSpec) {
 		*out = new(SAPAICoreConfig)
 		**out = **in
 	}
+	if in.OpenAICompatible != nil {
+		in, out := &in.OpenAICompatible, &out.OpenAICompatible
+		*out = new(OpenAICompatibleConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.TLS != nil {
 		in, out := &in.TLS, &out.TLS
 		*out = new(TLSConfig)
 		**out = **in
 	}
+	if in.Fallbacks != nil {
+		in, out := &in.Fallbacks, &out.Fallbacks
+		*out = make([]ModelFallback, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelConfigSpec.
@@ -1155,11 +2261,21 @@ func (in *ModelConfigStatus) DeepCopyInto(out *ModelConfigStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.AvailableModels != nil {
+		in, out := &in.AvailableModels, &out.AvailableModels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ModelPull != nil {
+		in, out := &in.ModelPull, &out.ModelPull
+		*out = new(ModelPullStatus)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelConfigStatus.
@@ -1172,6 +2288,26 @@ func (in *ModelConfigStatus) DeepCopy() *ModelConfigStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelFallback) DeepCopyInto(out *ModelFallback) {
+	*out = *in
+	if in.Triggers != nil {
+		in, out := &in.Triggers, &out.Triggers
+		*out = make([]ModelFallbackCondition, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelFallback.
+func (in *ModelFallback) DeepCopy() *ModelFallback {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelFallback)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ModelProviderConfig) DeepCopyInto(out *ModelProviderConfig) {
 	*out = *in
@@ -1256,7 +2392,7 @@ func (in *ModelProviderConfigStatus) DeepCopyInto(out *ModelProviderConfigStatus
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1282,6 +2418,66 @@ func (in *ModelProviderConfigStatus) DeepCopy() *ModelProviderConfigStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelPullStatus) DeepCopyInto(out *ModelPullStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelPullStatus.
+func (in *ModelPullStatus) DeepCopy() *ModelPullStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelPullStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModerationProvider) DeepCopyInto(out *ModerationProvider) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModerationProvider.
+func (in *ModerationProvider) DeepCopy() *ModerationProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(ModerationProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModerationSpec) DeepCopyInto(out *ModerationSpec) {
+	*out = *in
+	if in.Keywords != nil {
+		in, out := &in.Keywords, &out.Keywords
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Patterns != nil {
+		in, out := &in.Patterns, &out.Patterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Provider != nil {
+		in, out := &in.Provider, &out.Provider
+		*out = new(ModerationProvider)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModerationSpec.
+func (in *ModerationSpec) DeepCopy() *ModerationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ModerationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkConfig) DeepCopyInto(out *NetworkConfig) {
 	*out = *in
@@ -1302,6 +2498,27 @@ func (in *NetworkConfig) DeepCopy() *NetworkConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OAuth2ClientCredentials) DeepCopyInto(out *OAuth2ClientCredentials) {
+	*out = *in
+	out.ClientSecretFrom = in.ClientSecretFrom
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OAuth2ClientCredentials.
+func (in *OAuth2ClientCredentials) DeepCopy() *OAuth2ClientCredentials {
+	if in == nil {
+		return nil
+	}
+	out := new(OAuth2ClientCredentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OllamaConfig) DeepCopyInto(out *OllamaConfig) {
 	*out = *in
@@ -1314,12 +2531,32 @@ func (in *OllamaConfig) DeepCopyInto(out *OllamaConfig) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OllamaConfig.
-func (in *OllamaConfig) DeepCopy() *OllamaConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OllamaConfig.
+func (in *OllamaConfig) DeepCopy() *OllamaConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OllamaConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenAICompatibleConfig) DeepCopyInto(out *OpenAICompatibleConfig) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenAICompatibleConfig.
+func (in *OpenAICompatibleConfig) DeepCopy() *OpenAICompatibleConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(OllamaConfig)
+	out := new(OpenAICompatibleConfig)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1364,6 +2601,61 @@ func (in *OpenAIConfig) DeepCopy() *OpenAIConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PromptCaptureSpec) DeepCopyInto(out *PromptCaptureSpec) {
+	*out = *in
+	if in.MaxFileSize != nil {
+		in, out := &in.MaxFileSize, &out.MaxFileSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MaxTotalSize != nil {
+		in, out := &in.MaxTotalSize, &out.MaxTotalSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.RedactPatterns != nil {
+		in, out := &in.RedactPatterns, &out.RedactPatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PromptCaptureSpec.
+func (in *PromptCaptureSpec) DeepCopy() *PromptCaptureSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PromptCaptureSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PromptInjectionSpec) DeepCopyInto(out *PromptInjectionSpec) {
+	*out = *in
+	if in.Patterns != nil {
+		in, out := &in.Patterns, &out.Patterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeTools != nil {
+		in, out := &in.ExcludeTools, &out.ExcludeTools
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PromptInjectionSpec.
+func (in *PromptInjectionSpec) DeepCopy() *PromptInjectionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PromptInjectionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PromptSource) DeepCopyInto(out *PromptSource) {
 	*out = *in
@@ -1400,6 +2692,21 @@ func (in *PromptTemplateSpec) DeepCopy() *PromptTemplateSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QdrantMemoryConfig) DeepCopyInto(out *QdrantMemoryConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QdrantMemoryConfig.
+func (in *QdrantMemoryConfig) DeepCopy() *QdrantMemoryConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(QdrantMemoryConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RemoteMCPServer) DeepCopyInto(out *RemoteMCPServer) {
 	*out = *in
@@ -1427,6 +2734,26 @@ func (in *RemoteMCPServer) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteMCPServerAuth) DeepCopyInto(out *RemoteMCPServerAuth) {
+	*out = *in
+	if in.OAuth2ClientCredentials != nil {
+		in, out := &in.OAuth2ClientCredentials, &out.OAuth2ClientCredentials
+		*out = new(OAuth2ClientCredentials)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteMCPServerAuth.
+func (in *RemoteMCPServerAuth) DeepCopy() *RemoteMCPServerAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteMCPServerAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RemoteMCPServerList) DeepCopyInto(out *RemoteMCPServerList) {
 	*out = *in
@@ -1471,12 +2798,12 @@ func (in *RemoteMCPServerSpec) DeepCopyInto(out *RemoteMCPServerSpec) {
 	}
 	if in.Timeout != nil {
 		in, out := &in.Timeout, &out.Timeout
-		*out = new(metav1.Duration)
+		*out = new(v1.Duration)
 		**out = **in
 	}
 	if in.SseReadTimeout != nil {
 		in, out := &in.SseReadTimeout, &out.SseReadTimeout
-		*out = new(metav1.Duration)
+		*out = new(v1.Duration)
 		**out = **in
 	}
 	if in.TerminateOnClose != nil {
@@ -1494,6 +2821,11 @@ func (in *RemoteMCPServerSpec) DeepCopyInto(out *RemoteMCPServerSpec) {
 		*out = new(TLSConfig)
 		**out = **in
 	}
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(RemoteMCPServerAuth)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteMCPServerSpec.
@@ -1511,7 +2843,7 @@ func (in *RemoteMCPServerStatus) DeepCopyInto(out *RemoteMCPServerStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1527,6 +2859,11 @@ func (in *RemoteMCPServerStatus) DeepCopyInto(out *RemoteMCPServerStatus) {
 			}
 		}
 	}
+	if in.ToolCallStats != nil {
+		in, out := &in.ToolCallStats, &out.ToolCallStats
+		*out = new(ToolCallStats)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteMCPServerStatus.
@@ -1539,6 +2876,22 @@ func (in *RemoteMCPServerStatus) DeepCopy() *RemoteMCPServerStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RequestsPerSecondTarget) DeepCopyInto(out *RequestsPerSecondTarget) {
+	*out = *in
+	out.AverageValue = in.AverageValue.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequestsPerSecondTarget.
+func (in *RequestsPerSecondTarget) DeepCopy() *RequestsPerSecondTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(RequestsPerSecondTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SAPAICoreConfig) DeepCopyInto(out *SAPAICoreConfig) {
 	*out = *in
@@ -1679,6 +3032,27 @@ func (in *SandboxSubstrateSpec) DeepCopy() *SandboxSubstrateSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleToZeroSpec) DeepCopyInto(out *ScaleToZeroSpec) {
+	*out = *in
+	out.IdleTimeout = in.IdleTimeout
+	if in.ColdStartTimeout != nil {
+		in, out := &in.ColdStartTimeout, &out.ColdStartTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleToZeroSpec.
+func (in *ScaleToZeroSpec) DeepCopy() *ScaleToZeroSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleToZeroSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretReference) DeepCopyInto(out *SecretReference) {
 	*out = *in
@@ -1711,6 +3085,13 @@ func (in *ServiceAccountConfig) DeepCopyInto(out *ServiceAccountConfig) {
 			(*out)[key] = val
 		}
 	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]ServiceAccountRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountConfig.
@@ -1723,6 +3104,41 @@ func (in *ServiceAccountConfig) DeepCopy() *ServiceAccountConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceAccountRule) DeepCopyInto(out *ServiceAccountRule) {
+	*out = *in
+	if in.APIGroups != nil {
+		in, out := &in.APIGroups, &out.APIGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResourceNames != nil {
+		in, out := &in.ResourceNames, &out.ResourceNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Verbs != nil {
+		in, out := &in.Verbs, &out.Verbs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountRule.
+func (in *ServiceAccountRule) DeepCopy() *ServiceAccountRule {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceAccountRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SharedDeploymentSpec) DeepCopyInto(out *SharedDeploymentSpec) {
 	*out = *in
@@ -1731,21 +3147,36 @@ func (in *SharedDeploymentSpec) DeepCopyInto(out *SharedDeploymentSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(AutoscalingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ScaleToZero != nil {
+		in, out := &in.ScaleToZero, &out.ScaleToZero
+		*out = new(ScaleToZeroSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(AgentNetworkPolicySpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ImagePullSecrets != nil {
 		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
-		*out = make([]v1.LocalObjectReference, len(*in))
+		*out = make([]corev1.LocalObjectReference, len(*in))
 		copy(*out, *in)
 	}
 	if in.Volumes != nil {
 		in, out := &in.Volumes, &out.Volumes
-		*out = make([]v1.Volume, len(*in))
+		*out = make([]corev1.Volume, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 	if in.VolumeMounts != nil {
 		in, out := &in.VolumeMounts, &out.VolumeMounts
-		*out = make([]v1.VolumeMount, len(*in))
+		*out = make([]corev1.VolumeMount, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1766,26 +3197,26 @@ func (in *SharedDeploymentSpec) DeepCopyInto(out *SharedDeploymentSpec) {
 	}
 	if in.Env != nil {
 		in, out := &in.Env, &out.Env
-		*out = make([]v1.EnvVar, len(*in))
+		*out = make([]corev1.EnvVar, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
-		*out = new(v1.ResourceRequirements)
+		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Tolerations != nil {
 		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]v1.Toleration, len(*in))
+		*out = make([]corev1.Toleration, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 	if in.Affinity != nil {
 		in, out := &in.Affinity, &out.Affinity
-		*out = new(v1.Affinity)
+		*out = new(corev1.Affinity)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.NodeSelector != nil {
@@ -1795,14 +3226,19 @@ func (in *SharedDeploymentSpec) DeepCopyInto(out *SharedDeploymentSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.RuntimeClassName != nil {
+		in, out := &in.RuntimeClassName, &out.RuntimeClassName
+		*out = new(string)
+		**out = **in
+	}
 	if in.SecurityContext != nil {
 		in, out := &in.SecurityContext, &out.SecurityContext
-		*out = new(v1.SecurityContext)
+		*out = new(corev1.SecurityContext)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.PodSecurityContext != nil {
 		in, out := &in.PodSecurityContext, &out.PodSecurityContext
-		*out = new(v1.PodSecurityContext)
+		*out = new(corev1.PodSecurityContext)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.ServiceAccountName != nil {
@@ -1810,93 +3246,259 @@ func (in *SharedDeploymentSpec) DeepCopyInto(out *SharedDeploymentSpec) {
 		*out = new(string)
 		**out = **in
 	}
-	if in.ServiceAccountConfig != nil {
-		in, out := &in.ServiceAccountConfig, &out.ServiceAccountConfig
-		*out = new(ServiceAccountConfig)
-		(*in).DeepCopyInto(*out)
+	if in.ServiceAccountConfig != nil {
+		in, out := &in.ServiceAccountConfig, &out.ServiceAccountConfig
+		*out = new(ServiceAccountConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExtraContainers != nil {
+		in, out := &in.ExtraContainers, &out.ExtraContainers
+		*out = make([]corev1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.InitContainers != nil {
+		in, out := &in.InitContainers, &out.InitContainers
+		*out = make([]corev1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Sidecars != nil {
+		in, out := &in.Sidecars, &out.Sidecars
+		*out = make([]corev1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SharedDeploymentSpec.
+func (in *SharedDeploymentSpec) DeepCopy() *SharedDeploymentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SharedDeploymentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SkillForAgent) DeepCopyInto(out *SkillForAgent) {
+	*out = *in
+	if in.Refs != nil {
+		in, out := &in.Refs, &out.Refs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.GitAuthSecretRef != nil {
+		in, out := &in.GitAuthSecretRef, &out.GitAuthSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.GitRefs != nil {
+		in, out := &in.GitRefs, &out.GitRefs
+		*out = make([]GitRepo, len(*in))
+		copy(*out, *in)
+	}
+	if in.InitContainer != nil {
+		in, out := &in.InitContainer, &out.InitContainer
+		*out = new(SkillsInitContainer)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SkillForAgent.
+func (in *SkillForAgent) DeepCopy() *SkillForAgent {
+	if in == nil {
+		return nil
+	}
+	out := new(SkillForAgent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SkillStatus) DeepCopyInto(out *SkillStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SkillStatus.
+func (in *SkillStatus) DeepCopy() *SkillStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SkillStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SkillsInitContainer) DeepCopyInto(out *SkillsInitContainer) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SkillsInitContainer.
+func (in *SkillsInitContainer) DeepCopy() *SkillsInitContainer {
+	if in == nil {
+		return nil
+	}
+	out := new(SkillsInitContainer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SmokeTestExpectation) DeepCopyInto(out *SmokeTestExpectation) {
+	*out = *in
+	if in.Contains != nil {
+		in, out := &in.Contains, &out.Contains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NotContains != nil {
+		in, out := &in.NotContains, &out.NotContains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SmokeTestExpectation.
+func (in *SmokeTestExpectation) DeepCopy() *SmokeTestExpectation {
+	if in == nil {
+		return nil
+	}
+	out := new(SmokeTestExpectation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SmokeTestPrompt) DeepCopyInto(out *SmokeTestPrompt) {
+	*out = *in
+	in.Expect.DeepCopyInto(&out.Expect)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SmokeTestPrompt.
+func (in *SmokeTestPrompt) DeepCopy() *SmokeTestPrompt {
+	if in == nil {
+		return nil
+	}
+	out := new(SmokeTestPrompt)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SmokeTestResult) DeepCopyInto(out *SmokeTestResult) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SmokeTestResult.
+func (in *SmokeTestResult) DeepCopy() *SmokeTestResult {
+	if in == nil {
+		return nil
 	}
-	if in.ExtraContainers != nil {
-		in, out := &in.ExtraContainers, &out.ExtraContainers
-		*out = make([]v1.Container, len(*in))
+	out := new(SmokeTestResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SmokeTestSpec) DeepCopyInto(out *SmokeTestSpec) {
+	*out = *in
+	if in.Prompts != nil {
+		in, out := &in.Prompts, &out.Prompts
+		*out = make([]SmokeTestPrompt, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SharedDeploymentSpec.
-func (in *SharedDeploymentSpec) DeepCopy() *SharedDeploymentSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SmokeTestSpec.
+func (in *SmokeTestSpec) DeepCopy() *SmokeTestSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(SharedDeploymentSpec)
+	out := new(SmokeTestSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SkillForAgent) DeepCopyInto(out *SkillForAgent) {
+func (in *SmokeTestStatus) DeepCopyInto(out *SmokeTestStatus) {
 	*out = *in
-	if in.Refs != nil {
-		in, out := &in.Refs, &out.Refs
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.ImagePullSecrets != nil {
-		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
-		*out = make([]v1.LocalObjectReference, len(*in))
-		copy(*out, *in)
-	}
-	if in.GitAuthSecretRef != nil {
-		in, out := &in.GitAuthSecretRef, &out.GitAuthSecretRef
-		*out = new(v1.LocalObjectReference)
-		**out = **in
+	if in.LastRunTime != nil {
+		in, out := &in.LastRunTime, &out.LastRunTime
+		*out = (*in).DeepCopy()
 	}
-	if in.GitRefs != nil {
-		in, out := &in.GitRefs, &out.GitRefs
-		*out = make([]GitRepo, len(*in))
+	if in.Results != nil {
+		in, out := &in.Results, &out.Results
+		*out = make([]SmokeTestResult, len(*in))
 		copy(*out, *in)
 	}
-	if in.InitContainer != nil {
-		in, out := &in.InitContainer, &out.InitContainer
-		*out = new(SkillsInitContainer)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SkillForAgent.
-func (in *SkillForAgent) DeepCopy() *SkillForAgent {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SmokeTestStatus.
+func (in *SmokeTestStatus) DeepCopy() *SmokeTestStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(SkillForAgent)
+	out := new(SmokeTestStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SkillsInitContainer) DeepCopyInto(out *SkillsInitContainer) {
+func (in *StreamingConfig) DeepCopyInto(out *StreamingConfig) {
 	*out = *in
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(v1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
+	if in.FlushInterval != nil {
+		in, out := &in.FlushInterval, &out.FlushInterval
+		*out = new(v1.Duration)
+		**out = **in
 	}
-	if in.Env != nil {
-		in, out := &in.Env, &out.Env
-		*out = make([]v1.EnvVar, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.WriteTimeout != nil {
+		in, out := &in.WriteTimeout, &out.WriteTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxEventSize != nil {
+		in, out := &in.MaxEventSize, &out.MaxEventSize
+		*out = new(int32)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SkillsInitContainer.
-func (in *SkillsInitContainer) DeepCopy() *SkillsInitContainer {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StreamingConfig.
+func (in *StreamingConfig) DeepCopy() *StreamingConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(SkillsInitContainer)
+	out := new(StreamingConfig)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1968,6 +3570,117 @@ func (in *Tool) DeepCopy() *Tool {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ToolCallStats) DeepCopyInto(out *ToolCallStats) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ToolCallStats.
+func (in *ToolCallStats) DeepCopy() *ToolCallStats {
+	if in == nil {
+		return nil
+	}
+	out := new(ToolCallStats)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ToolPolicy) DeepCopyInto(out *ToolPolicy) {
+	*out = *in
+	if in.Allow != nil {
+		in, out := &in.Allow, &out.Allow
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Deny != nil {
+		in, out := &in.Deny, &out.Deny
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ToolPolicy.
+func (in *ToolPolicy) DeepCopy() *ToolPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ToolPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ToolResultLimitSpec) DeepCopyInto(out *ToolResultLimitSpec) {
+	*out = *in
+	if in.MaxBytes != nil {
+		in, out := &in.MaxBytes, &out.MaxBytes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxTokens != nil {
+		in, out := &in.MaxTokens, &out.MaxTokens
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ExcludeTools != nil {
+		in, out := &in.ExcludeTools, &out.ExcludeTools
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ToolResultLimitSpec.
+func (in *ToolResultLimitSpec) DeepCopy() *ToolResultLimitSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ToolResultLimitSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ToolResultValidationSpec) DeepCopyInto(out *ToolResultValidationSpec) {
+	*out = *in
+	if in.Coerce != nil {
+		in, out := &in.Coerce, &out.Coerce
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ExcludeTools != nil {
+		in, out := &in.ExcludeTools, &out.ExcludeTools
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ToolResultValidationSpec.
+func (in *ToolResultValidationSpec) DeepCopy() *ToolResultValidationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ToolResultValidationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ToolTimeout) DeepCopyInto(out *ToolTimeout) {
+	*out = *in
+	out.Timeout = in.Timeout
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ToolTimeout.
+func (in *ToolTimeout) DeepCopy() *ToolTimeout {
+	if in == nil {
+		return nil
+	}
+	out := new(ToolTimeout)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TypedLocalReference) DeepCopyInto(out *TypedLocalReference) {
 	*out = *in
@@ -2032,3 +3745,144 @@ func (in *ValueSource) DeepCopy() *ValueSource {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Workflow) DeepCopyInto(out *Workflow) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Workflow.
+func (in *Workflow) DeepCopy() *Workflow {
+	if in == nil {
+		return nil
+	}
+	out := new(Workflow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Workflow) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkflowList) DeepCopyInto(out *WorkflowList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Workflow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowList.
+func (in *WorkflowList) DeepCopy() *WorkflowList {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkflowList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WorkflowList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkflowRouter) DeepCopyInto(out *WorkflowRouter) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowRouter.
+func (in *WorkflowRouter) DeepCopy() *WorkflowRouter {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkflowRouter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkflowSpec) DeepCopyInto(out *WorkflowSpec) {
+	*out = *in
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]WorkflowStep, len(*in))
+		copy(*out, *in)
+	}
+	if in.Router != nil {
+		in, out := &in.Router, &out.Router
+		*out = new(WorkflowRouter)
+		**out = **in
+	}
+	if in.Deployment != nil {
+		in, out := &in.Deployment, &out.Deployment
+		*out = new(DeclarativeDeploymentSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowSpec.
+func (in *WorkflowSpec) DeepCopy() *WorkflowSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkflowSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkflowStatus) DeepCopyInto(out *WorkflowStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowStatus.
+func (in *WorkflowStatus) DeepCopy() *WorkflowStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkflowStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkflowStep) DeepCopyInto(out *WorkflowStep) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowStep.
+func (in *WorkflowStep) DeepCopy() *WorkflowStep {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkflowStep)
+	in.DeepCopyInto(out)
+	return out
+}
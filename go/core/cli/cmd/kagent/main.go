@@ -19,6 +19,7 @@ import (
 	dbmigrate "github.com/kagent-dev/kagent/go/core/pkg/cli/db/migrate"
 	"github.com/kagent-dev/kagent/go/core/pkg/migrations"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/tools/clientcmd"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -62,6 +63,9 @@ func loadConfig() (*config.Config, error) {
 }
 
 func newRootCommand(ctx context.Context, cfg *config.Config) *cobra.Command {
+	// columns selects and orders the table columns of get commands.
+	var columns []string
+
 	rootCmd := &cobra.Command{
 		Use:   "kagent",
 		Short: "kagent is a CLI and TUI for kagent",
@@ -69,14 +73,38 @@ func newRootCommand(ctx context.Context, cfg *config.Config) *cobra.Command {
 		Run: func(cmd *cobra.Command, args []string) {
 			runInteractive(cmd, args, cfg)
 		},
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			path, err := config.ContextsPath()
+			if err != nil {
+				return err
+			}
+			contexts, err := config.LoadContexts(path)
+			if err != nil {
+				return err
+			}
+			// A bad context is not a usage error.
+			cmd.SilenceUsage = true
+			if err := cfg.Apply(contexts, cfg.Context, cmd.Flags()); err != nil {
+				return err
+			}
+			// The output renderer reads the format from viper, which does not
+			// see the flags.
+			viper.Set("output_format", cfg.OutputFormat)
+			viper.Set("output_columns", columns)
+			return nil
+		},
 	}
 	rootCmd.SetContext(ctx)
 
 	rootCmd.PersistentFlags().StringVar(&cfg.KAgentURL, "kagent-url", cfg.KAgentURL, "KAgent URL")
 	rootCmd.PersistentFlags().StringVarP(&cfg.Namespace, "namespace", "n", cfg.Namespace, "Namespace")
-	rootCmd.PersistentFlags().StringVarP(&cfg.OutputFormat, "output-format", "o", cfg.OutputFormat, "Output format")
+	rootCmd.PersistentFlags().StringVarP(&cfg.OutputFormat, "output-format", "o", cfg.OutputFormat, "Output format: "+cli.OutputFormats)
 	rootCmd.PersistentFlags().BoolVarP(&cfg.Verbose, "verbose", "v", cfg.Verbose, "Verbose output")
 	rootCmd.PersistentFlags().DurationVar(&cfg.Timeout, "timeout", cfg.Timeout, "Timeout")
+	rootCmd.PersistentFlags().StringVar(&cfg.A2ATransport, "a2a-transport", cfg.A2ATransport, "Transport for streamed agent responses (sse|websocket)")
+	rootCmd.PersistentFlags().StringVar(&cfg.A2AURL, "a2a-url", cfg.A2AURL, "Base URL of the agents' A2A endpoints (default: <kagent-url>/api/a2a)")
+	rootCmd.PersistentFlags().StringVar(&cfg.UserID, "user-id", cfg.UserID, "User ID to send requests as")
+	rootCmd.PersistentFlags().StringVar(&cfg.Context, "context", "", "Context from ~/.config/kagent/config.yaml to use (default: the current context)")
 	installCfg := &cli.InstallCfg{
 		Config: cfg,
 	}
@@ -84,12 +112,26 @@ func newRootCommand(ctx context.Context, cfg *config.Config) *cobra.Command {
 	installCmd := &cobra.Command{
 		Use:   "install",
 		Short: "Install kagent",
-		Long:  `Install kagent`,
+		Long: `Install kagent
+
+By default kagent is installed from its Helm charts. With --manifests the CLI
+renders plain Kubernetes manifests (namespace, CRDs, controller and default
+ModelConfig) and applies them with kubectl, so Helm is not required. Add
+--dry-run to print the manifests instead; apply them twice, or the CRDs first,
+so the ModelConfig kind is known when it is created.`,
+		Example: `  kagent install --manifests --image-registry registry.internal
+  kagent install --manifests --dry-run -o yaml > kagent.yaml`,
 		Run: func(cmd *cobra.Command, args []string) {
 			cli.InstallCmd(cmd.Context(), installCfg)
 		},
 	}
 	installCmd.Flags().StringVar(&installCfg.Profile, "profile", "", "Installation profile (minimal|demo)")
+	installCmd.Flags().StringVar(&installCfg.ImageRegistry, "image-registry", "", "Registry to pull all kagent images from, e.g. a mirror for air-gapped clusters")
+	installCmd.Flags().StringSliceVar(&installCfg.ImagePullSecrets, "image-pull-secret", nil, "Image pull secret for kagent and agent pods (repeatable)")
+	installCmd.Flags().BoolVar(&installCfg.Manifests, "manifests", false, "Install from plain manifests applied with kubectl instead of Helm")
+	installCmd.Flags().BoolVar(&installCfg.DryRun, "dry-run", false, "Print the manifests instead of applying them (requires --manifests)")
+	installCmd.Flags().StringVar(&installCfg.DatabaseURL, "database-url", "", "External PostgreSQL connection string for --manifests; a bundled PostgreSQL for evaluation is installed when empty")
+	installCmd.Flags().StringToStringVar(&installCfg.ImageRegistryMirrors, "image-registry-mirror", nil, "Rewrite images on agent pods from a source registry to a mirror, in SOURCE=MIRROR format (repeatable)")
 	_ = installCmd.RegisterFlagCompletionFunc("profile", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		return profiles.Profiles, cobra.ShellCompDirectiveNoFileComp
 	})
@@ -114,7 +156,11 @@ func newRootCommand(ctx context.Context, cfg *config.Config) *cobra.Command {
 		Run: func(cmd *cobra.Command, args []string) {
 			cli.InvokeCmd(cmd.Context(), invokeCfg)
 		},
-		Example: `kagent invoke --agent "k8s-agent" --task "Get all the pods in the kagent namespace"`,
+		Example: `kagent invoke --agent "k8s-agent" --task "Get all the pods in the kagent namespace"
+kagent invoke --agent "k8s-agent" --task "Scale the frontend deployment to 3 replicas" --dry-run
+kagent invoke --agent "k8s-agent" --task "Audit the RBAC of the kagent namespace" --async --callback-url https://example.com/hooks/kagent
+kagent invoke --agent "k8s-agent" --task "Check the rollout of the frontend deployment" --kube-exec
+kagent invoke --agent "k8s-agent" --task "Why is this pod crashing?" --attach pod.log --attach dashboard.png`,
 	}
 
 	invokeCmd.Flags().StringVarP(&invokeCfg.Task, "task", "t", "", "Task")
@@ -122,9 +168,20 @@ func newRootCommand(ctx context.Context, cfg *config.Config) *cobra.Command {
 	invokeCmd.Flags().StringVarP(&invokeCfg.Agent, "agent", "a", "", "Agent")
 	invokeCmd.Flags().BoolVarP(&invokeCfg.Stream, "stream", "S", false, "Stream the response")
 	invokeCmd.Flags().StringVarP(&invokeCfg.File, "file", "f", "", "File to read the task from")
+	invokeCmd.Flags().StringArrayVar(&invokeCfg.Attachments, "attach", nil, "File or image to send to the agent with the task (may be repeated)")
 	invokeCmd.Flags().StringVarP(&invokeCfg.URLOverride, "url-override", "u", "", "URL override")
 	invokeCmd.Flags().MarkHidden("url-override") //nolint:errcheck
 	invokeCmd.Flags().StringVar(&invokeCfg.Token, "token", "", "Bearer token to include in A2A requests (for API key passthrough)")
+	invokeCmd.Flags().BoolVar(&invokeCfg.DryRun, "dry-run", false, "Preview mutating tool calls (apply, delete, scale, helm upgrade, ...) instead of running them")
+	invokeCmd.Flags().Int64Var(&invokeCfg.MaxTokens, "max-tokens", 0, "Stop the task once it used this many model tokens (can only tighten the agent's budget)")
+	invokeCmd.Flags().IntVar(&invokeCfg.MaxToolCalls, "max-tool-calls", 0, "Stop the task once it made this many tool calls (can only tighten the agent's budget)")
+	invokeCmd.Flags().IntVar(&invokeCfg.MaxTurns, "max-turns", 0, "Stop the task once it made this many model requests (can only tighten the agent's budget)")
+	invokeCmd.Flags().BoolVar(&invokeCfg.Async, "async", false, "Start the task and print its ID without waiting for it to finish")
+	invokeCmd.Flags().StringVar(&invokeCfg.CallbackURL, "callback-url", "", "URL that receives the finished task (with --async)")
+	invokeCmd.Flags().BoolVar(&invokeCfg.KubeExec, "kube-exec", false, "Run the invocation in a short-lived Job in the cluster and stream its output, for hosts that reach the Kubernetes API but not the controller")
+	invokeCmd.Flags().StringVar(&invokeCfg.KubeExecImage, "kube-exec-image", "", "Image of the --kube-exec Job (default: "+cli.DefaultKubeExecImage+":<CLI version>)")
+	_ = invokeCmd.RegisterFlagCompletionFunc("agent", completeAgentNames(cfg))
+	_ = invokeCmd.RegisterFlagCompletionFunc("session", completeSessionIDs(cfg))
 
 	bugReportCmd := &cobra.Command{
 		Use:   "bug-report",
@@ -143,6 +200,37 @@ func newRootCommand(ctx context.Context, cfg *config.Config) *cobra.Command {
 		},
 	}
 
+	doctorCfg := &cli.DoctorCfg{Config: cfg}
+	doctorCmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Diagnose a kagent installation",
+		Long: `Diagnose a kagent installation and print a pass/fail report.
+
+Checks that the controller is reachable, the kagent CRDs are installed at the
+expected versions, the default ModelConfig exists, ModelConfig API key secrets
+resolve, tool servers are healthy and agent deployments are ready. Failing
+checks print a hint on how to fix them, and the command exits non-zero when
+any check fails.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			// A controller that cannot be reached is reported by the controller check.
+			if err := cli.CheckServerConnection(cmd.Context(), cfg.Client()); err != nil {
+				if pf, err := cli.NewPortForward(cmd.Context(), cfg); err == nil {
+					defer pf.Stop()
+				}
+			}
+			k8sClient, err := cli.CreateKubernetesClient()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating Kubernetes client: %v\n", err)
+			}
+			if err := cli.DoctorCmd(cmd.Context(), doctorCfg, k8sClient); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+		Example: `kagent doctor
+kagent doctor -n my-namespace`,
+	}
+
 	versionCmd := &cobra.Command{
 		Use:   "version",
 		Short: "Print the kagent version",
@@ -180,10 +268,12 @@ func newRootCommand(ctx context.Context, cfg *config.Config) *cobra.Command {
 		},
 	}
 
+	getSessionCfg := &cli.GetCfg{Config: cfg}
 	getSessionCmd := &cobra.Command{
-		Use:   "session [session_id]",
-		Short: "Get a session or list all sessions",
-		Long:  `Get a session by ID or list all sessions`,
+		Use:               "session [session_id]",
+		Short:             "Get a session or list all sessions",
+		Long:              `Get a session by ID or list all sessions`,
+		ValidArgsFunction: firstArgCompletion(completeSessionIDs(cfg)),
 		Run: func(cmd *cobra.Command, args []string) {
 			if err := cli.CheckServerConnection(cmd.Context(), cfg.Client()); err != nil {
 				pf, err := cli.NewPortForward(cmd.Context(), cfg)
@@ -197,14 +287,17 @@ func newRootCommand(ctx context.Context, cfg *config.Config) *cobra.Command {
 			if len(args) > 0 {
 				resourceName = args[0]
 			}
-			cli.GetSessionCmd(cfg, resourceName)
+			cli.GetSessionCmd(getSessionCfg, resourceName)
 		},
 	}
+	addGetListFlags(getSessionCmd, getSessionCfg, "name, created_at or updated_at")
 
+	getAgentCfg := &cli.GetCfg{Config: cfg}
 	getAgentCmd := &cobra.Command{
-		Use:   "agent [agent_name]",
-		Short: "Get an agent or list all agents",
-		Long:  `Get an agent by name or list all agents`,
+		Use:               "agent [agent_name]",
+		Short:             "Get an agent or list all agents",
+		Long:              `Get an agent by name or list all agents`,
+		ValidArgsFunction: firstArgCompletion(completeAgentNames(cfg)),
 		Run: func(cmd *cobra.Command, args []string) {
 			if err := cli.CheckServerConnection(cmd.Context(), cfg.Client()); err != nil {
 				pf, err := cli.NewPortForward(cmd.Context(), cfg)
@@ -217,9 +310,11 @@ func newRootCommand(ctx context.Context, cfg *config.Config) *cobra.Command {
 			if len(args) > 0 {
 				resourceName = args[0]
 			}
-			cli.GetAgentCmd(cfg, resourceName)
+			cli.GetAgentCmd(getAgentCfg, resourceName)
 		},
 	}
+	addGetListFlags(getAgentCmd, getAgentCfg, "name, namespace or created_at")
+	getAgentCmd.Flags().StringVarP(&getAgentCfg.Selector, "selector", "l", "", "Only list agents matching this label selector, e.g. team=platform")
 
 	getToolCmd := &cobra.Command{
 		Use:   "tool",
@@ -238,7 +333,631 @@ func newRootCommand(ctx context.Context, cfg *config.Config) *cobra.Command {
 		},
 	}
 
-	getCmd.AddCommand(getSessionCmd, getAgentCmd, getToolCmd)
+	getTemplateCmd := &cobra.Command{
+		Use:   "template [[namespace/]name]",
+		Short: "Get an agent template or list all templates",
+		Long:  `Get an agent template with its parameters and manifest, or list the templates of every namespace`,
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := cli.CheckServerConnection(cmd.Context(), cfg.Client()); err != nil {
+				pf, err := cli.NewPortForward(cmd.Context(), cfg)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error starting port-forward: %v\n", err)
+					os.Exit(1)
+				}
+				defer pf.Stop()
+			}
+			ref := ""
+			if len(args) > 0 {
+				ref = args[0]
+			}
+			if err := cli.GetTemplateCmd(cmd.Context(), cfg, ref); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	getRunCfg := &cli.GetCfg{Config: cfg}
+	getRunCmd := &cobra.Command{
+		Use:               "run session_id",
+		Short:             "List the runs of a session",
+		Long:              `List the runs (A2A tasks) of a session`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: firstArgCompletion(completeSessionIDs(cfg)),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := cli.CheckServerConnection(cmd.Context(), cfg.Client()); err != nil {
+				pf, err := cli.NewPortForward(cmd.Context(), cfg)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error starting port-forward: %v\n", err)
+					return
+				}
+				defer pf.Stop()
+			}
+			cli.GetRunCmd(getRunCfg, args[0])
+		},
+	}
+	getRunCmd.Flags().IntVar(&getRunCfg.Limit, "limit", 0, "Maximum number of results to return (1-1000, 0 for all)")
+	getRunCmd.Flags().IntVar(&getRunCfg.Offset, "offset", 0, "Number of results to skip")
+
+	getToolServerCfg := &cli.GetCfg{Config: cfg}
+	getToolServerCmd := &cobra.Command{
+		Use:   "toolserver",
+		Short: "Get tool servers",
+		Long:  `List all tool servers and the tools they provide`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := cli.CheckServerConnection(cmd.Context(), cfg.Client()); err != nil {
+				pf, err := cli.NewPortForward(cmd.Context(), cfg)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error starting port-forward: %v\n", err)
+					return
+				}
+				defer pf.Stop()
+			}
+			cli.GetToolServerCmd(getToolServerCfg)
+		},
+	}
+	addGetListFlags(getToolServerCmd, getToolServerCfg, "name or created_at")
+
+	getCmd.PersistentFlags().StringSliceVar(&columns, "columns", nil, "Comma-separated table columns to show, in order, e.g. name,created")
+	getCmd.AddCommand(getSessionCmd, getAgentCmd, getToolCmd, getRunCmd, getToolServerCmd, getTemplateCmd)
+
+	createCmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a kagent resource",
+		Long:  `Create a kagent resource`,
+		Run: func(cmd *cobra.Command, args []string) {
+			fmt.Fprintf(os.Stderr, "No resource type provided\n\n")
+			cmd.Help() //nolint:errcheck
+			os.Exit(1)
+		},
+	}
+
+	createAgentCfg := &cli.CreateAgentCfg{Config: cfg}
+	createAgentCmd := &cobra.Command{
+		Use:   "agent [name]",
+		Short: "Create an agent from a template",
+		Long: `Create an agent in the namespace from an agent template.
+
+Templates are ConfigMaps labeled kagent.dev/agent-template=true that platform
+teams curate; list them with 'kagent get template'. A template given by name
+alone must be unique across namespaces.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			createAgentCfg.Name = args[0]
+			if err := cli.CheckServerConnection(cmd.Context(), cfg.Client()); err != nil {
+				pf, err := cli.NewPortForward(cmd.Context(), cfg)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error starting port-forward: %v\n", err)
+					os.Exit(1)
+				}
+				defer pf.Stop()
+			}
+			if err := cli.CreateAgentCmd(cmd.Context(), createAgentCfg); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+		Example: `kagent create agent payments-sre --from-template k8s-sre --set model=gpt-4o -n payments
+kagent create agent payments-sre --from-template kagent/k8s-sre --set model=gpt-4o --dry-run`,
+	}
+	createAgentCmd.Flags().StringVar(&createAgentCfg.Template, "from-template", "", "Template to create the agent from, as name or namespace/name")
+	createAgentCmd.Flags().StringArrayVar(&createAgentCfg.Set, "set", nil, "Template parameter as key=value (repeatable)")
+	createAgentCmd.Flags().BoolVar(&createAgentCfg.DryRun, "dry-run", false, "Print the rendered agent without creating it")
+	_ = createAgentCmd.MarkFlagRequired("from-template")
+	createCmd.AddCommand(createAgentCmd)
+
+	createModelConfigCfg := &cli.CreateModelConfigCfg{Config: cfg}
+	createModelConfigCmd := &cobra.Command{
+		Use:   "modelconfig [name]",
+		Short: "Create a ModelConfig for a model provider",
+		Long: `Create a ModelConfig for OpenAI, Anthropic, Azure OpenAI, Gemini, Ollama or
+Bedrock, with a Secret holding its API key, and print it as YAML.
+
+Without --provider, and in a terminal, a wizard asks for the provider, model
+and settings, offering the defaults of the Helm chart. The API key is read
+from the provider's usual environment variable (e.g. OPENAI_API_KEY, or the
+one --api-key-env names) or asked for without echoing it. Bedrock uses
+AWS_BEARER_TOKEN_BEDROCK or AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY when
+set, and the agent pod's AWS credentials otherwise.
+
+Before creating the ModelConfig the command lists the provider's models with
+the API key to check that it is accepted; Bedrock is not checked.`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) == 1 {
+				createModelConfigCfg.Name = args[0]
+			}
+			if !createModelConfigCfg.DryRun {
+				if err := cli.CheckServerConnection(cmd.Context(), cfg.Client()); err != nil {
+					pf, err := cli.NewPortForward(cmd.Context(), cfg)
+					if err != nil {
+						fmt.Fprintf(os.Stderr, "Error starting port-forward: %v\n", err)
+						os.Exit(1)
+					}
+					defer pf.Stop()
+				}
+			}
+			if err := cli.CreateModelConfigCmd(cmd.Context(), createModelConfigCfg); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+		Example: `kagent create modelconfig
+kagent create modelconfig claude --provider anthropic --model claude-sonnet-4-5
+kagent create modelconfig azure --provider azureOpenAI --azure-endpoint https://my-resource.openai.azure.com --azure-deployment gpt-4o
+kagent create modelconfig local --provider ollama --ollama-host ollama.ollama.svc:11434 --skip-validation`,
+	}
+	createModelConfigCmd.Flags().StringVar(&createModelConfigCfg.Provider, "provider", "", "Model provider: openAI, anthropic, azureOpenAI, gemini, ollama or bedrock")
+	createModelConfigCmd.Flags().StringVar(&createModelConfigCfg.Model, "model", "", "Model name, defaults to the provider's default model")
+	createModelConfigCmd.Flags().StringVar(&createModelConfigCfg.APIKeyEnv, "api-key-env", "", "Environment variable to read the API key from, defaults to the provider's, e.g. OPENAI_API_KEY")
+	createModelConfigCmd.Flags().StringVar(&createModelConfigCfg.BaseURL, "base-url", "", "Base URL of the OpenAI or Anthropic API, e.g. of a proxy")
+	createModelConfigCmd.Flags().StringVar(&createModelConfigCfg.AzureEndpoint, "azure-endpoint", "", "Azure OpenAI endpoint")
+	createModelConfigCmd.Flags().StringVar(&createModelConfigCfg.AzureDeployment, "azure-deployment", "", "Azure OpenAI deployment, defaults to the model")
+	createModelConfigCmd.Flags().StringVar(&createModelConfigCfg.AzureAPIVersion, "azure-api-version", "", "Azure OpenAI API version (default 2023-05-15)")
+	createModelConfigCmd.Flags().StringVar(&createModelConfigCfg.OllamaHost, "ollama-host", "", "Ollama host as reached from the cluster (default host.docker.internal:11434)")
+	createModelConfigCmd.Flags().StringVar(&createModelConfigCfg.BedrockRegion, "bedrock-region", "", "AWS region of the Bedrock model (default us-east-1)")
+	createModelConfigCmd.Flags().BoolVar(&createModelConfigCfg.SkipValidation, "skip-validation", false, "Create the ModelConfig without checking the API key with the provider")
+	createModelConfigCmd.Flags().BoolVar(&createModelConfigCfg.DryRun, "dry-run", false, "Print the ModelConfig without creating it")
+	createCmd.AddCommand(createModelConfigCmd)
+
+	debugCmd := &cobra.Command{
+		Use:   "debug",
+		Short: "Debug a kagent resource",
+		Long:  `Debug a kagent resource`,
+		Run: func(cmd *cobra.Command, args []string) {
+			fmt.Fprintf(os.Stderr, "No resource type provided\n\n")
+			cmd.Help() //nolint:errcheck
+			os.Exit(1)
+		},
+	}
+
+	debugAgentCfg := &cli.DebugAgentCfg{Config: cfg}
+	debugAgentCmd := &cobra.Command{
+		Use:   "agent [agent_name]",
+		Short: "Capture reconcile traces for an agent",
+		Long: `Capture reconcile traces for a single agent.
+
+The controller records every reconcile of the agent for the capture duration:
+translator inputs and outputs (with secrets redacted), the objects it creates,
+updates or prunes, and the agent config it stores. When the capture ends the
+recording is downloaded as a JSON bundle. The controller's log level is not
+changed.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: firstArgCompletion(completeAgentNames(cfg)),
+		Run: func(cmd *cobra.Command, args []string) {
+			debugAgentCfg.Name = args[0]
+			if err := cli.CheckServerConnection(cmd.Context(), cfg.Client()); err != nil {
+				pf, err := cli.NewPortForward(cmd.Context(), cfg)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error starting port-forward: %v\n", err)
+					os.Exit(1)
+				}
+				defer pf.Stop()
+			}
+			if err := cli.DebugAgentCmd(cmd.Context(), debugAgentCfg); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+		Example: `kagent debug agent k8s-agent --capture 5m`,
+	}
+	debugAgentCmd.Flags().DurationVar(&debugAgentCfg.Capture, "capture", 5*time.Minute, "How long to capture reconciles for (at most 30m)")
+	debugAgentCmd.Flags().StringVar(&debugAgentCfg.OutputFile, "output-file", "", "File to write the bundle to (defaults to kagent-debug-<namespace>-<name>-<timestamp>.json)")
+
+	debugCmd.AddCommand(debugAgentCmd)
+
+	lintCfg := &cli.LintCfg{Config: cfg}
+	lintCmd := &cobra.Command{
+		Use:   "lint",
+		Short: "Check an agent manifest against best practices",
+		Long: `Check an agent manifest against kagent best-practice rules.
+
+Each finding has a stable rule ID and a severity (error, warning or info).
+The command exits non-zero when any finding is at or above --fail-on, so it
+can gate CI pipelines. Nothing is applied to the cluster.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := cli.CheckServerConnection(cmd.Context(), cfg.Client()); err != nil {
+				pf, err := cli.NewPortForward(cmd.Context(), cfg)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error starting port-forward: %v\n", err)
+					os.Exit(1)
+				}
+				defer pf.Stop()
+			}
+			if err := cli.LintCmd(cmd.Context(), lintCfg); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+		Example: `kagent lint -f agent.yaml
+kagent lint -f agent.yaml --fail-on warning -o json`,
+	}
+	lintCmd.Flags().StringVarP(&lintCfg.File, "file", "f", "", "Agent manifest to lint (- for stdin)")
+	lintCmd.Flags().StringVar(&lintCfg.FailOn, "fail-on", "error", "Lowest severity that fails the command (error, warning, info)")
+
+	validateCfg := &cli.ValidateCfg{Config: cfg}
+	validateCmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Validate an agent manifest before applying it",
+		Long: `Validate an agent manifest against the kagent server.
+
+The agent goes through the same translation as when it is applied: its model
+config, tool servers and subagents are resolved and its config.json is
+generated. Best-practice lint errors and warnings are reported as well. The
+command exits non-zero when the agent is invalid. Nothing is applied to the
+cluster.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := cli.CheckServerConnection(cmd.Context(), cfg.Client()); err != nil {
+				pf, err := cli.NewPortForward(cmd.Context(), cfg)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error starting port-forward: %v\n", err)
+					os.Exit(1)
+				}
+				defer pf.Stop()
+			}
+			if err := cli.ValidateCmd(cmd.Context(), validateCfg); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+		Example: `kagent validate -f agent.yaml
+cat agent.yaml | kagent validate -f - -o json`,
+	}
+	validateCmd.Flags().StringVarP(&validateCfg.File, "file", "f", "", "Agent manifest to validate (- for stdin)")
+
+	applyCfg := &cli.ApplyCfg{Config: cfg}
+	applyCmd := &cobra.Command{
+		Use:   "apply",
+		Short: "Create or update agents, model configs and tool servers from manifests",
+		Long: `Create or update Agents, ModelConfigs, RemoteMCPServers and MCPServers from
+manifest files in one request.
+
+-f takes a file, a directory of .yaml, .yml and .json files, or - for stdin, and
+may be repeated. Every resource is validated before any is written, so an
+invalid manifest leaves the cluster unchanged. A table lists whether each
+resource was created, updated or unchanged.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := cli.CheckServerConnection(cmd.Context(), cfg.Client()); err != nil {
+				pf, err := cli.NewPortForward(cmd.Context(), cfg)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error starting port-forward: %v\n", err)
+					os.Exit(1)
+				}
+				defer pf.Stop()
+			}
+			if err := cli.ApplyCmd(cmd.Context(), applyCfg); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+		Example: `kagent apply -f agents/
+kagent apply -f model.yaml -f agent.yaml --dry-run`,
+	}
+	applyCmd.Flags().StringArrayVarP(&applyCfg.Files, "file", "f", nil, "Manifest file or directory to apply (- for stdin)")
+	applyCmd.Flags().BoolVar(&applyCfg.DryRun, "dry-run", false, "Validate the manifests and show what would change without applying them")
+
+	pruneCfg := &cli.PruneCfg{Config: cfg}
+	var pruneMaxSessions int
+	pruneCmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Delete session history past the retention policy",
+		Long: `Permanently delete the sessions, events, A2A tasks and push notifications of
+every user that are past the controller's retention policy, now rather than
+on the controller's next retention run.
+
+Each limit flag overrides the controller's limit for this run; "0s" (or
+--max-sessions 0) disables it. A session is deleted with its events, tasks
+and push notifications. Use --dry-run to see how much would be deleted.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if cmd.Flags().Changed("max-sessions") {
+				pruneCfg.MaxSessions = &pruneMaxSessions
+			}
+			if err := cli.CheckServerConnection(cmd.Context(), cfg.Client()); err != nil {
+				pf, err := cli.NewPortForward(cmd.Context(), cfg)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error starting port-forward: %v\n", err)
+					os.Exit(1)
+				}
+				defer pf.Stop()
+			}
+			if err := cli.PruneCmd(cmd.Context(), pruneCfg); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+		Example: `kagent prune --dry-run
+kagent prune --session-max-age 720h --max-sessions 100`,
+	}
+	pruneCmd.Flags().BoolVar(&pruneCfg.DryRun, "dry-run", false, "Show how much would be deleted without deleting anything")
+	pruneCmd.Flags().StringVar(&pruneCfg.SessionMaxAge, "session-max-age", "", "Delete sessions not updated for longer than this, e.g. 720h")
+	pruneCmd.Flags().IntVar(&pruneMaxSessions, "max-sessions", 0, "Keep only this many of the most recently updated sessions of each user and agent")
+	pruneCmd.Flags().StringVar(&pruneCfg.TaskMaxAge, "task-max-age", "", "Delete A2A tasks not updated for longer than this")
+	pruneCmd.Flags().StringVar(&pruneCfg.EventMaxAge, "event-max-age", "", "Delete session events older than this")
+	pruneCmd.Flags().StringVar(&pruneCfg.PushNotificationMaxAge, "push-notification-max-age", "", "Delete push notification configs not updated for longer than this")
+	pruneCmd.Flags().StringVar(&pruneCfg.StreamEventMaxAge, "stream-event-max-age", "", "Delete the stored events of A2A streams older than this")
+
+	waitCmd := &cobra.Command{
+		Use:   "wait",
+		Short: "Wait for a kagent resource",
+		Long:  `Wait for a kagent resource`,
+		Run: func(cmd *cobra.Command, args []string) {
+			fmt.Fprintf(os.Stderr, "No resource type provided\n\n")
+			cmd.Help() //nolint:errcheck
+			os.Exit(1)
+		},
+	}
+
+	waitTaskCfg := &cli.WaitTaskCfg{Config: cfg}
+	waitTaskCmd := &cobra.Command{
+		Use:   "task [task_id]",
+		Short: "Wait for an agent task to finish",
+		Long: `Wait for an agent task, such as one started with kagent invoke --async, to
+finish and print it.
+
+The command exits non-zero if the task failed, was canceled or rejected, stops
+for user input or authorization, or does not finish within --timeout.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			waitTaskCfg.TaskID = args[0]
+			if err := cli.CheckServerConnection(cmd.Context(), cfg.Client()); err != nil {
+				pf, err := cli.NewPortForward(cmd.Context(), cfg)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error starting port-forward: %v\n", err)
+					os.Exit(1)
+				}
+				defer pf.Stop()
+			}
+			if err := cli.WaitTaskCmd(cmd.Context(), waitTaskCfg); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+		Example: `kagent wait task 4b1c2f7e-9a3d-4f5e-8c6b-1d2e3f4a5b6c --timeout 10m`,
+	}
+	waitTaskCmd.Flags().DurationVar(&waitTaskCfg.Interval, "interval", 2*time.Second, "How often to check the task")
+	waitTaskCmd.Flags().DurationVar(&waitTaskCfg.Timeout, "timeout", 30*time.Minute, "How long to wait for the task (0 waits forever)")
+	waitCmd.AddCommand(waitTaskCmd)
+
+	attachCfg := &cli.AttachCfg{Config: cfg}
+	attachCmd := &cobra.Command{
+		Use:   "attach [task_id]",
+		Short: "Follow a running agent task",
+		Long: `Attach to an agent task, for example after kagent invoke --stream lost its
+connection, and print its events as they arrive.
+
+When the task waits for input, the command prompts for an answer: approve or
+reject the tool calls it holds for approval, or reply to the agent's question.
+End the input (Ctrl-D) to detach and leave the task waiting.
+
+The agent is looked up from the task's session unless --agent is set. The
+command exits non-zero unless the task completed.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			attachCfg.TaskID = args[0]
+			if err := cli.CheckServerConnection(cmd.Context(), cfg.Client()); err != nil {
+				pf, err := cli.NewPortForward(cmd.Context(), cfg)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error starting port-forward: %v\n", err)
+					os.Exit(1)
+				}
+				defer pf.Stop()
+			}
+			if err := cli.AttachCmd(cmd.Context(), attachCfg); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+		Example: `kagent attach 4b1c2f7e-9a3d-4f5e-8c6b-1d2e3f4a5b6c`,
+	}
+	attachCmd.Flags().StringVarP(&attachCfg.Agent, "agent", "a", "", "Agent serving the task (default: the agent of the task's session)")
+	attachCmd.Flags().StringVar(&attachCfg.Token, "token", "", "Bearer token to include in A2A requests (for API key passthrough)")
+	_ = attachCmd.RegisterFlagCompletionFunc("agent", completeAgentNames(cfg))
+
+	topCfg := &cli.TopCfg{Config: cfg}
+	topCmd := &cobra.Command{
+		Use:   "top",
+		Short: "Show live agent activity",
+		Long: `Show the activity of each agent over a recent window, like kubectl top for
+agents: active sessions, in-flight tasks, tokens per minute, and the average
+latency and error rate of the tasks that finished.
+
+The table refreshes every --interval until interrupted. Use --once, or an
+output format other than table, to print the stats a single time.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := cli.CheckServerConnection(cmd.Context(), cfg.Client()); err != nil {
+				pf, err := cli.NewPortForward(cmd.Context(), cfg)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error starting port-forward: %v\n", err)
+					os.Exit(1)
+				}
+				defer pf.Stop()
+			}
+			if err := cli.TopCmd(cmd.Context(), topCfg); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+		Example: `kagent top
+kagent top --window 1h --once -o wide`,
+	}
+	topCmd.Flags().StringVar(&topCfg.Window, "window", "", "How far back the stats reach, e.g. 15m (default 5m)")
+	topCmd.Flags().DurationVar(&topCfg.Interval, "interval", 2*time.Second, "How often to refresh the table")
+	topCmd.Flags().BoolVar(&topCfg.Once, "once", false, "Print the stats once instead of refreshing them")
+
+	replayCmd := &cobra.Command{
+		Use:   "replay",
+		Short: "Replay a kagent resource",
+		Long:  `Replay a kagent resource`,
+		Run: func(cmd *cobra.Command, args []string) {
+			fmt.Fprintf(os.Stderr, "No resource type provided\n\n")
+			cmd.Help() //nolint:errcheck
+			os.Exit(1)
+		},
+	}
+
+	replaySessionCfg := &cli.ReplaySessionCfg{Config: cfg}
+	replaySessionCmd := &cobra.Command{
+		Use:   "session [session_id]",
+		Short: "Replay the event timeline of a session",
+		Long: `Replay the event timeline of a session in the terminal.
+
+Messages, tool calls and tool results are printed with their original
+timestamps and offset from the start of the session. Entries are paced by the
+time that originally passed between them, scaled by --speed and capped at
+--max-delay; use --speed 0 to print the whole timeline at once. Use --task or
+--from to start the replay part way through the session.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: firstArgCompletion(completeSessionIDs(cfg)),
+		Run: func(cmd *cobra.Command, args []string) {
+			replaySessionCfg.SessionID = args[0]
+			if err := cli.CheckServerConnection(cmd.Context(), cfg.Client()); err != nil {
+				pf, err := cli.NewPortForward(cmd.Context(), cfg)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error starting port-forward: %v\n", err)
+					os.Exit(1)
+				}
+				defer pf.Stop()
+			}
+			if err := cli.ReplaySessionCmd(cmd.Context(), replaySessionCfg); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+		Example: `kagent replay session 3f2a9c --speed 4
+kagent replay session 3f2a9c --task 8d1e0b --speed 0
+kagent replay session 3f2a9c --from 2m30s`,
+	}
+	replaySessionCmd.Flags().Float64Var(&replaySessionCfg.Speed, "speed", 1, "Playback speed multiplier (0 prints without pausing)")
+	replaySessionCmd.Flags().DurationVar(&replaySessionCfg.MaxDelay, "max-delay", 5*time.Second, "Longest pause between two entries (0 for no limit)")
+	replaySessionCmd.Flags().StringVar(&replaySessionCfg.Task, "task", "", "Start at the first event of this task (task or invocation ID)")
+	replaySessionCmd.Flags().StringVar(&replaySessionCfg.From, "from", "", "Start at an RFC3339 timestamp or an offset from the session start (e.g. 90s)")
+	replaySessionCmd.MarkFlagsMutuallyExclusive("task", "from")
+	_ = replaySessionCmd.RegisterFlagCompletionFunc("task", cobra.NoFileCompletions)
+
+	replayCmd.AddCommand(replaySessionCmd)
+
+	sessionCmd := &cobra.Command{
+		Use:   "session",
+		Short: "Manage sessions",
+		Long:  `Manage sessions`,
+		Run: func(cmd *cobra.Command, args []string) {
+			fmt.Fprintf(os.Stderr, "No subcommand provided\n\n")
+			cmd.Help() //nolint:errcheck
+			os.Exit(1)
+		},
+	}
+
+	shareSessionCfg := &cli.ShareSessionCfg{Config: cfg}
+	shareSessionCmd := &cobra.Command{
+		Use:   "share [session_id]",
+		Short: "Share a session with other users and groups",
+		Long: `Share one of your sessions with other users and groups.
+
+Users and groups given read access can view the session and its tasks;
+invoke access also lets them continue the conversation with the agent. Granting
+again replaces the access a user or group had. Use --revoke to remove access.
+The users and groups the session is shared with are printed afterwards; with no
+--user or --group the command only lists them.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: firstArgCompletion(completeSessionIDs(cfg)),
+		Run: func(cmd *cobra.Command, args []string) {
+			shareSessionCfg.SessionID = args[0]
+			if err := cli.CheckServerConnection(cmd.Context(), cfg.Client()); err != nil {
+				pf, err := cli.NewPortForward(cmd.Context(), cfg)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error starting port-forward: %v\n", err)
+					os.Exit(1)
+				}
+				defer pf.Stop()
+			}
+			if err := cli.ShareSessionCmd(cmd.Context(), shareSessionCfg); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+		Example: `kagent session share 3f2a9c --user alice
+kagent session share 3f2a9c --group sre --access invoke
+kagent session share 3f2a9c --user alice --revoke`,
+	}
+	shareSessionCmd.Flags().StringSliceVar(&shareSessionCfg.Users, "user", nil, "User to share the session with (repeatable)")
+	shareSessionCmd.Flags().StringSliceVar(&shareSessionCfg.Groups, "group", nil, "Group to share the session with (repeatable)")
+	shareSessionCmd.Flags().StringVar(&shareSessionCfg.Access, "access", "read", "Access to grant (read, invoke)")
+	shareSessionCmd.Flags().BoolVar(&shareSessionCfg.Revoke, "revoke", false, "Revoke access instead of granting it")
+	_ = shareSessionCmd.RegisterFlagCompletionFunc("access", cobra.FixedCompletions([]string{"read", "invoke"}, cobra.ShellCompDirectiveNoFileComp))
+
+	sessionApprovalsCfg := &cli.SessionApprovalsCfg{Config: cfg}
+	sessionApprovalsCmd := &cobra.Command{
+		Use:   "approvals [session_id]",
+		Short: "List the tool calls of a session waiting for approval",
+		Long: `List the tool calls of a session waiting for approval.
+
+Agents hold calls to the tools listed under requireApproval until a user
+approves or rejects them. Use 'kagent session approve' or 'kagent session
+reject' to decide.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: firstArgCompletion(completeSessionIDs(cfg)),
+		Run: func(cmd *cobra.Command, args []string) {
+			sessionApprovalsCfg.SessionID = args[0]
+			if err := cli.CheckServerConnection(cmd.Context(), cfg.Client()); err != nil {
+				pf, err := cli.NewPortForward(cmd.Context(), cfg)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error starting port-forward: %v\n", err)
+					os.Exit(1)
+				}
+				defer pf.Stop()
+			}
+			if err := cli.SessionApprovalsCmd(cmd.Context(), sessionApprovalsCfg); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+		Example: `kagent session approvals 3f2a9c`,
+	}
+
+	newDecideApprovalsCmd := func(use string, approve bool) *cobra.Command {
+		decideCfg := &cli.DecideApprovalsCfg{Config: cfg, Approve: approve}
+		verb, opposite := "Approve", "rejected"
+		if !approve {
+			verb, opposite = "Reject", "approved"
+		}
+		decideCmd := &cobra.Command{
+			Use:   use + " [session_id]",
+			Short: verb + " the tool calls a session is waiting on",
+			Long: verb + ` the tool calls a session's task holds for approval and resume the task.
+
+Without --call every pending call of the task is decided the same way. With
+--call only the given calls are decided so; the task's other pending calls
+are ` + opposite + `. Use --task when several tasks of the session are waiting.`,
+			Args:              cobra.ExactArgs(1),
+			ValidArgsFunction: firstArgCompletion(completeSessionIDs(cfg)),
+			Run: func(cmd *cobra.Command, args []string) {
+				decideCfg.SessionID = args[0]
+				if err := cli.CheckServerConnection(cmd.Context(), cfg.Client()); err != nil {
+					pf, err := cli.NewPortForward(cmd.Context(), cfg)
+					if err != nil {
+						fmt.Fprintf(os.Stderr, "Error starting port-forward: %v\n", err)
+						os.Exit(1)
+					}
+					defer pf.Stop()
+				}
+				if err := cli.DecideApprovalsCmd(cmd.Context(), decideCfg); err != nil {
+					fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+					os.Exit(1)
+				}
+			},
+			Example: fmt.Sprintf(`kagent session %[1]s 3f2a9c
+kagent session %[1]s 3f2a9c --task 8d1e0b --call adk-6f1c`, use),
+		}
+		decideCmd.Flags().StringVar(&decideCfg.TaskID, "task", "", "Task to decide on when several are waiting for approval")
+		decideCmd.Flags().StringSliceVar(&decideCfg.Calls, "call", nil, "Tool call ID to decide on (repeatable)")
+		decideCmd.Flags().StringVar(&decideCfg.Reason, "reason", "", "Reason passed to the agent for rejected calls")
+		_ = decideCmd.RegisterFlagCompletionFunc("task", cobra.NoFileCompletions)
+		_ = decideCmd.RegisterFlagCompletionFunc("call", cobra.NoFileCompletions)
+		return decideCmd
+	}
+
+	sessionCmd.AddCommand(shareSessionCmd, sessionApprovalsCmd, newDecideApprovalsCmd("approve", true), newDecideApprovalsCmd("reject", false))
 
 	initCfg := &cli.InitCfg{
 		Config: cfg,
@@ -456,7 +1175,7 @@ Examples:
 	runCmd.Flags().StringVar(&runCfg.ProjectDir, "project-dir", "", "Project directory (default: current directory)")
 	runCmd.Flags().BoolVar(&runCfg.Build, "build", false, "Rebuild the Docker image before running")
 
-	rootCmd.AddCommand(installCmd, uninstallCmd, invokeCmd, bugReportCmd, versionCmd, dashboardCmd, getCmd, initCmd, buildCmd, deployCmd, addMcpCmd, runCmd, mcp.NewMCPCmd(), envdoc.NewEnvCmd(), dbcli.NewCommandFromFunc(migrationSources(cfg)))
+	rootCmd.AddCommand(installCmd, uninstallCmd, invokeCmd, bugReportCmd, doctorCmd, versionCmd, dashboardCmd, getCmd, createCmd, debugCmd, replayCmd, sessionCmd, lintCmd, validateCmd, applyCmd, pruneCmd, topCmd, waitCmd, attachCmd, initCmd, buildCmd, deployCmd, addMcpCmd, runCmd, mcp.NewMCPCmd(), envdoc.NewEnvCmd(), newConfigCmd(cfg), dbcli.NewCommandFromFunc(migrationSources(cfg)))
 
 	return rootCmd
 }
@@ -474,6 +1193,167 @@ const vectorEnabledKey = "DATABASE_VECTOR_ENABLED"
 // environment (explicit operator intent, works without a cluster), the
 // controller's configmap on the live cluster (the same value the server
 // reads), and finally the controller's default (enabled).
+// completeAgentNames completes agent names in --namespace by querying the
+// controller API. Completion stays silent when the API is unreachable; it does
+// not start a port-forward.
+// addGetListFlags registers the pagination, sorting and filtering flags of a
+// get command that lists resources.
+// newConfigCmd returns the commands managing the CLI contexts. They skip the
+// root's context resolution so that a missing or broken context can be fixed.
+func newConfigCmd(cfg *config.Config) *cobra.Command {
+	contextCfg := &cli.ContextCfg{}
+	run := func(fn func(*cli.ContextCfg) error) func(*cobra.Command, []string) {
+		return func(cmd *cobra.Command, args []string) {
+			if len(args) > 0 {
+				contextCfg.Name = args[0]
+			}
+			if err := fn(contextCfg); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+	}
+	completeContexts := func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		// Completion runs outside the config command, so resolve the path here.
+		path, err := config.ContextsPath()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		contexts, err := config.LoadContexts(path)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		return contexts.Names(), cobra.ShellCompDirectiveNoFileComp
+	}
+
+	configCmd := &cobra.Command{
+		Use:   "config",
+		Short: "Manage CLI contexts",
+		Long: `Manage the named contexts in ~/.config/kagent/config.yaml.
+
+A context holds the kagent URL, A2A URL, user ID and namespace to use, like a
+kubectl context. The current context applies to every command; --context or
+KAGENT_CLI_CONTEXT select another one. Flags override a context, and the
+KAGENT_CLI_URL, KAGENT_CLI_A2A_URL, KAGENT_CLI_USER_ID and KAGENT_CLI_NAMESPACE
+environment variables override it too when the flag is not set.`,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			path, err := config.ContextsPath()
+			if err != nil {
+				return err
+			}
+			contextCfg.Path = path
+			return nil
+		},
+	}
+
+	useContextCmd := &cobra.Command{
+		Use:               "use-context [name]",
+		Short:             "Set the current context",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeContexts,
+		Run:               run(cli.UseContextCmd),
+		Example:           `kagent config use-context prod`,
+	}
+
+	getContextsCmd := &cobra.Command{
+		Use:   "get-contexts",
+		Short: "List the contexts",
+		Args:  cobra.NoArgs,
+		Run:   run(cli.GetContextsCmd),
+	}
+
+	currentContextCmd := &cobra.Command{
+		Use:   "current-context",
+		Short: "Print the context in use",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			contextCfg.Name = cfg.Context
+			run(cli.CurrentContextCmd)(cmd, args)
+		},
+	}
+
+	setContextCmd := &cobra.Command{
+		Use:   "set-context [name]",
+		Short: "Create or update a context",
+		Long: `Create a context, or update an existing one.
+
+Only the settings given as flags are changed.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeContexts,
+		Run: func(cmd *cobra.Command, args []string) {
+			flags := cmd.Flags()
+			for _, f := range []struct {
+				flag       string
+				dst, value *string
+			}{
+				{"kagent-url", &contextCfg.Settings.KAgentURL, &cfg.KAgentURL},
+				{"a2a-url", &contextCfg.Settings.A2AURL, &cfg.A2AURL},
+				{"user-id", &contextCfg.Settings.UserID, &cfg.UserID},
+				{"namespace", &contextCfg.Settings.Namespace, &cfg.Namespace},
+			} {
+				if flags.Changed(f.flag) {
+					*f.dst = *f.value
+				}
+			}
+			run(cli.SetContextCmd)(cmd, args)
+		},
+		Example: `kagent config set-context prod --kagent-url https://kagent.example.com --user-id alice@example.com -n team-a`,
+	}
+
+	deleteContextCmd := &cobra.Command{
+		Use:               "delete-context [name]",
+		Short:             "Delete a context",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeContexts,
+		Run:               run(cli.DeleteContextCmd),
+	}
+
+	configCmd.AddCommand(useContextCmd, getContextsCmd, currentContextCmd, setContextCmd, deleteContextCmd)
+	return configCmd
+}
+
+func addGetListFlags(cmd *cobra.Command, getCfg *cli.GetCfg, sortFields string) {
+	cmd.Flags().IntVar(&getCfg.Limit, "limit", 0, "Maximum number of results to return (1-1000, 0 for all)")
+	cmd.Flags().IntVar(&getCfg.Offset, "offset", 0, "Number of results to skip")
+	cmd.Flags().StringVar(&getCfg.Sort, "sort", "", "Field to sort by ("+sortFields+"), prefixed with - for descending order")
+	cmd.Flags().StringVar(&getCfg.CreatedAfter, "created-after", "", "Only list results created after an RFC3339 timestamp or a duration ago, e.g. 24h")
+}
+
+func completeAgentNames(cfg *config.Config) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		names, err := cli.CompleteAgentNames(cmd.Context(), cfg, toComplete)
+		if err != nil {
+			cobra.CompDebugln(err.Error(), true)
+		}
+		return names, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeSessionIDs completes the current user's session IDs by querying the
+// controller API.
+func completeSessionIDs(cfg *config.Config) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		ids, err := cli.CompleteSessionIDs(cmd.Context(), cfg, toComplete)
+		if err != nil {
+			cobra.CompDebugln(err.Error(), true)
+		}
+		return ids, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// firstArgCompletion applies fn to a command's single positional argument.
+func firstArgCompletion(fn cobra.CompletionFunc) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return fn(cmd, args, toComplete)
+	}
+}
+
 func migrationSources(cfg *config.Config) dbmigrate.SourcesFunc {
 	return func(ctx context.Context) ([]migrations.Source, error) {
 		vectorEnabled := true
@@ -1,9 +1,13 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -90,6 +94,60 @@ func TestRootCommandFlagsOverrideConfigValues(t *testing.T) {
 	assert.Equal(t, 10*time.Second, cfg.Timeout)
 }
 
+func TestDynamicCompletion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/agents":
+			assert.Equal(t, "kagent", r.URL.Query().Get("namespace"))
+			_, _ = w.Write([]byte(`{"data":[{"agent":{"metadata":{"name":"k8s-agent","namespace":"kagent"},"spec":{"description":"Kubernetes expert"}}},{"agent":{"metadata":{"name":"helm-agent","namespace":"kagent"}}}]}`))
+		case "/api/sessions":
+			_, _ = w.Write([]byte(`{"data":[{"id":"s-123","name":"pod triage"},{"id":"t-456"}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{name: "invoke agent flag", args: []string{"invoke", "--agent", "k8s"}, want: []string{"k8s-agent\tKubernetes expert"}},
+		{name: "get session argument", args: []string{"get", "session", ""}, want: []string{"s-123\tpod triage", "t-456"}},
+		{name: "only the first argument", args: []string{"get", "agent", "k8s-agent", ""}, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{KAgentURL: server.URL, Namespace: "kagent", Timeout: time.Second}
+			rootCmd := newRootCommand(context.Background(), cfg)
+			var out bytes.Buffer
+			rootCmd.SetOut(&out)
+			rootCmd.SetArgs(append([]string{"__complete"}, tt.args...))
+			require.NoError(t, rootCmd.Execute())
+
+			// The last line is the shell directive, e.g. ":4".
+			lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+			assert.Equal(t, ":4", lines[len(lines)-1])
+			var got []string
+			got = append(got, lines[:len(lines)-1]...)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCompletionCommand(t *testing.T) {
+	for _, shell := range []string{"bash", "zsh", "fish"} {
+		rootCmd := newRootCommand(context.Background(), &config.Config{})
+		var out bytes.Buffer
+		rootCmd.SetOut(&out)
+		rootCmd.SetArgs([]string{"completion", shell})
+		require.NoError(t, rootCmd.Execute(), shell)
+		assert.Contains(t, out.String(), "kagent", shell)
+	}
+}
+
 func resetConfigState(t *testing.T) {
 	t.Helper()
 
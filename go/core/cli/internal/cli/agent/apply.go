@@ -0,0 +1,137 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
+	"github.com/kagent-dev/kagent/go/core/cli/internal/config"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+type ApplyCfg struct {
+	Config *config.Config
+	// Files are manifest files or directories of manifests, or "-" for stdin.
+	Files []string
+	// DryRun validates the manifests and reports what would change without
+	// applying anything.
+	DryRun bool
+}
+
+// manifestExtensions are the file extensions read from a directory.
+var manifestExtensions = []string{".yaml", ".yml", ".json"}
+
+// ApplyCmd creates or updates the Agents, ModelConfigs and tool servers in the
+// given manifests in one request and prints a result per resource. The server
+// validates every resource before writing any, so an invalid manifest leaves
+// the cluster unchanged.
+func ApplyCmd(ctx context.Context, cfg *ApplyCfg) error {
+	if len(cfg.Files) == 0 {
+		return fmt.Errorf("at least one manifest file or directory is required (-f)")
+	}
+	var items []json.RawMessage
+	for _, path := range cfg.Files {
+		fileItems, err := readManifests(path)
+		if err != nil {
+			return err
+		}
+		items = append(items, fileItems...)
+	}
+	if len(items) == 0 {
+		return fmt.Errorf("no resources found in %s", strings.Join(cfg.Files, ", "))
+	}
+
+	resp, err := cfg.Config.Client().Apply.Apply(ctx, &api.ApplyRequest{Items: items, DryRun: cfg.DryRun})
+	if err != nil {
+		return fmt.Errorf("failed to apply manifests: %w", err)
+	}
+
+	results := resp.Data.Results
+	rows := make([][]string, len(results))
+	for i, r := range results {
+		rows[i] = []string{r.Kind, r.Namespace, r.Name, r.Action, r.Error}
+	}
+	if err := printOutput(resp.Data, []string{"KIND", "NAMESPACE", "NAME", "ACTION", "ERROR"}, rows); err != nil {
+		return err
+	}
+	if resp.Error {
+		return errors.New(resp.Message)
+	}
+	if isTableOutput() {
+		fmt.Println(resp.Message)
+	}
+	return nil
+}
+
+// readManifests reads the resources of a manifest file, of every manifest file
+// in a directory (not recursively), or of stdin when path is "-". Files may
+// hold several YAML documents or JSON objects; each becomes one item.
+func readManifests(path string) ([]json.RawMessage, error) {
+	if path == "-" {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read stdin: %w", err)
+		}
+		return decodeManifests("stdin", data)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	files := []string{path}
+	if info.IsDir() {
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		files = nil
+		for _, e := range entries {
+			if !e.IsDir() && slices.Contains(manifestExtensions, filepath.Ext(e.Name())) {
+				files = append(files, filepath.Join(path, e.Name()))
+			}
+		}
+	}
+
+	var items []json.RawMessage
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		fileItems, err := decodeManifests(file, data)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, fileItems...)
+	}
+	return items, nil
+}
+
+// decodeManifests splits data into its resources, converted to JSON. Empty
+// documents are skipped.
+func decodeManifests(name string, data []byte) ([]json.RawMessage, error) {
+	decoder := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096)
+	var items []json.RawMessage
+	for {
+		var item json.RawMessage
+		if err := decoder.Decode(&item); err != nil {
+			if errors.Is(err, io.EOF) {
+				return items, nil
+			}
+			return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+		}
+		if len(item) == 0 || string(item) == "null" {
+			continue
+		}
+		items = append(items, item)
+	}
+}
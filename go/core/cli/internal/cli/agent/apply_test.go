@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadManifests(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"agents.yaml": `apiVersion: kagent.dev/v1alpha2
+kind: Agent
+metadata:
+  name: k8s-agent
+---
+# comment-only document
+---
+apiVersion: kagent.dev/v1alpha2
+kind: Agent
+metadata:
+  name: helm-agent
+`,
+		"model.json": `{"apiVersion": "kagent.dev/v1alpha2", "kind": "ModelConfig", "metadata": {"name": "default-model-config"}}`,
+		"README.md":  "not a manifest",
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0600))
+	}
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "nested"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "nested", "skipped.yaml"), []byte("kind: Agent\n"), 0600))
+
+	items, err := readManifests(dir)
+	require.NoError(t, err)
+	require.Len(t, items, 3)
+	require.JSONEq(t, `{"apiVersion":"kagent.dev/v1alpha2","kind":"Agent","metadata":{"name":"k8s-agent"}}`, string(items[0]))
+	require.JSONEq(t, `{"apiVersion":"kagent.dev/v1alpha2","kind":"Agent","metadata":{"name":"helm-agent"}}`, string(items[1]))
+	require.JSONEq(t, files["model.json"], string(items[2]))
+
+	items, err = readManifests(filepath.Join(dir, "model.json"))
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+
+	_, err = readManifests(filepath.Join(dir, "missing.yaml"))
+	require.ErrorContains(t, err, "failed to read")
+
+	bad := filepath.Join(t.TempDir(), "bad.yaml")
+	require.NoError(t, os.WriteFile(bad, []byte("kind: [Agent\n"), 0600))
+	_, err = readManifests(bad)
+	require.ErrorContains(t, err, "failed to parse")
+}
@@ -0,0 +1,300 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/kagent-dev/kagent/go/api/client"
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
+	"github.com/kagent-dev/kagent/go/api/utils"
+	"github.com/kagent-dev/kagent/go/core/cli/internal/config"
+	coreutils "github.com/kagent-dev/kagent/go/core/internal/utils"
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
+)
+
+type AttachCfg struct {
+	Config *config.Config
+	TaskID string
+	// Agent is the name of the agent serving the task in the config's
+	// namespace. It is looked up from the task's session when empty.
+	Agent string
+	Token string
+}
+
+// AttachCmd follows a task of an agent, printing its events as they arrive,
+// and prompts for the user's answer whenever the task waits for input. It
+// fails unless the task completed.
+func AttachCmd(ctx context.Context, cfg *AttachCfg) error {
+	clientSet := cfg.Config.Client()
+	resp, err := clientSet.Task.GetTask(ctx, cfg.TaskID)
+	if err != nil {
+		return fmt.Errorf("failed to get task %s: %w", cfg.TaskID, err)
+	}
+	task := resp.Data
+
+	agentRef, err := attachAgentRef(ctx, clientSet.Session, cfg, task)
+	if err != nil {
+		return err
+	}
+	a2aOpts, err := cfg.Config.A2AOptions()
+	if err != nil {
+		return fmt.Errorf("failed to create A2A client: %w", err)
+	}
+	if cfg.Token != "" {
+		a2aOpts = append(a2aOpts, client.WithA2ABearerToken(cfg.Token))
+	}
+	a2aClient, err := clientSet.A2A(ctx, agentRef, append(a2aOpts, client.WithA2ASessionID(task.ContextID))...)
+	if err != nil {
+		return fmt.Errorf("failed to create A2A client: %w", err)
+	}
+	defer a2aClient.Close()
+
+	a := &attachment{
+		client: a2aClient,
+		approvals: func(ctx context.Context, task *protocol.Task) ([]api.ToolApproval, error) {
+			resp, err := clientSet.Session.ListSessionApprovals(ctx, task.ContextID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list session approvals: %w", err)
+			}
+			var approvals []api.ToolApproval
+			for _, approval := range resp.Data {
+				if approval.TaskID == task.ID {
+					approvals = append(approvals, approval)
+				}
+			}
+			return approvals, nil
+		},
+		in:      bufio.NewReader(os.Stdin),
+		out:     os.Stdout,
+		verbose: cfg.Config.Verbose,
+	}
+	task, err = a.run(ctx, task)
+	if err != nil {
+		return err
+	}
+	if task.Status.State != protocol.TaskStateCompleted {
+		return fmt.Errorf("task %s is %s", task.ID, task.Status.State)
+	}
+	return nil
+}
+
+// attachAgentRef returns the agent serving task as namespace/name.
+func attachAgentRef(ctx context.Context, sessions client.Session, cfg *AttachCfg, task *protocol.Task) (string, error) {
+	if cfg.Agent != "" {
+		if strings.Contains(cfg.Agent, "/") {
+			return "", fmt.Errorf("invalid agent format: use --namespace to specify the namespace, got %q", cfg.Agent)
+		}
+		return fmt.Sprintf("%s/%s", cfg.Config.Namespace, cfg.Agent), nil
+	}
+	resp, err := sessions.GetSession(ctx, task.ContextID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get session %s of task %s: %w", task.ContextID, task.ID, err)
+	}
+	if resp.Data == nil || resp.Data.AgentID == nil {
+		return "", fmt.Errorf("session %s has no agent; select it with --agent", task.ContextID)
+	}
+	return coreutils.ConvertToKubernetesIdentifier(*resp.Data.AgentID), nil
+}
+
+// attachClient is the part of the A2A client an attachment uses.
+type attachClient interface {
+	Resubscribe(ctx context.Context, taskID string) (<-chan client.A2AEvent, error)
+	GetTask(ctx context.Context, taskID string) (*protocol.Task, error)
+	StreamReply(ctx context.Context, taskID, contextID, text string) (<-chan client.A2AEvent, error)
+	StreamDecide(ctx context.Context, taskID, contextID string, decision client.ToolDecision) (<-chan client.A2AEvent, error)
+}
+
+// attachment follows one task and answers it on the user's behalf.
+type attachment struct {
+	client attachClient
+	// approvals returns the tool calls task holds for approval.
+	approvals func(ctx context.Context, task *protocol.Task) ([]api.ToolApproval, error)
+	in        *bufio.Reader
+	out       io.Writer
+	verbose   bool
+}
+
+// run follows task until it settles and the user gives no further input, and
+// returns its last state.
+func (a *attachment) run(ctx context.Context, task *protocol.Task) (*protocol.Task, error) {
+	var events <-chan client.A2AEvent
+	if taskSettled(task.Status.State) {
+		a.renderTask(task)
+	} else {
+		var err error
+		events, err = a.client.Resubscribe(ctx, task.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resubscribe to task %s: %w", task.ID, err)
+		}
+	}
+
+	for {
+		if events != nil {
+			for event := range events {
+				a.render(event)
+			}
+			// The stream ends with the task's final event or when it breaks;
+			// the task's state tells which.
+			latest, err := a.client.GetTask(ctx, task.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get task %s: %w", task.ID, err)
+			}
+			task = latest
+			if !taskSettled(task.Status.State) {
+				return nil, fmt.Errorf("the stream of task %s ended while it is %s; run 'kagent attach %s' to follow it again", task.ID, task.Status.State, task.ID)
+			}
+		}
+		fmt.Fprintf(a.out, "Task %s is %s\n", task.ID, task.Status.State)
+		if task.Status.State != protocol.TaskStateInputRequired {
+			return task, nil
+		}
+
+		var err error
+		events, err = a.respond(ctx, task)
+		if err != nil {
+			return nil, err
+		}
+		if events == nil {
+			return task, nil
+		}
+	}
+}
+
+// respond asks the user to decide on the tool calls task holds for approval,
+// or to answer it, and sends the answer. It returns nil events when the user
+// gave no answer.
+func (a *attachment) respond(ctx context.Context, task *protocol.Task) (<-chan client.A2AEvent, error) {
+	approvals, err := a.approvals(ctx, task)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(approvals) > 0 {
+		fmt.Fprintf(a.out, "The agent is waiting for approval of %d tool call(s):\n", len(approvals))
+		for _, approval := range approvals {
+			args, _ := json.Marshal(approval.Args)
+			fmt.Fprintf(a.out, "  %s %s\n", approval.ToolName, args)
+			if approval.Hint != "" {
+				fmt.Fprintf(a.out, "    %s\n", approval.Hint)
+			}
+		}
+		fmt.Fprint(a.out, "Approve? [y/n, or a reason to reject]: ")
+		line, ok := a.readLine()
+		if !ok {
+			return nil, nil
+		}
+		decision := client.ToolDecision{}
+		switch strings.ToLower(line) {
+		case "y", "yes":
+			decision.Approve = true
+		case "n", "no":
+		default:
+			decision.Reason = line
+		}
+		events, err := a.client.StreamDecide(ctx, task.ID, task.ContextID, decision)
+		if err != nil {
+			return nil, fmt.Errorf("failed to send decision: %w", err)
+		}
+		return events, nil
+	}
+
+	fmt.Fprint(a.out, "> ")
+	line, ok := a.readLine()
+	if !ok {
+		return nil, nil
+	}
+	events, err := a.client.StreamReply(ctx, task.ID, task.ContextID, line)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send reply: %w", err)
+	}
+	return events, nil
+}
+
+// readLine reads the user's next non-empty line. It reports false at the end
+// of the input.
+func (a *attachment) readLine() (string, bool) {
+	for {
+		line, err := a.in.ReadString('\n')
+		if line = strings.TrimSpace(line); line != "" {
+			return line, true
+		}
+		if err != nil {
+			fmt.Fprintln(a.out)
+			return "", false
+		}
+	}
+}
+
+// render prints an event of the task's stream. Agent text is printed once
+// complete: from final status updates, the last chunk of artifacts and
+// messages. Tool calls and results are printed as they happen.
+func (a *attachment) render(event client.A2AEvent) {
+	if a.verbose {
+		data, err := json.Marshal(event)
+		if err == nil {
+			fmt.Fprintln(a.out, string(data))
+		}
+		return
+	}
+	switch {
+	case event.StatusUpdate != nil:
+		if event.StatusUpdate.Status.Message != nil {
+			a.renderMessage(*event.StatusUpdate.Status.Message, event.StatusUpdate.Final)
+		}
+	case event.ArtifactUpdate != nil:
+		if event.ArtifactUpdate.LastChunk != nil && *event.ArtifactUpdate.LastChunk {
+			a.renderText(protocol.MessageRoleAgent, event.ArtifactUpdate.Artifact.Parts)
+		}
+	case event.Message != nil:
+		a.renderMessage(*event.Message, true)
+	case event.Task != nil:
+		a.renderTask(event.Task)
+	}
+}
+
+// renderTask prints the status message of task, e.g. the question of a task
+// waiting for input.
+func (a *attachment) renderTask(task *protocol.Task) {
+	if task.Status.Message != nil {
+		a.renderMessage(*task.Status.Message, true)
+	}
+}
+
+func (a *attachment) renderMessage(message protocol.Message, withText bool) {
+	for _, part := range message.Parts {
+		dp, ok := part.(*protocol.DataPart)
+		if !ok {
+			continue
+		}
+		kind, _ := utils.GetMetadataValue(dp.Metadata, "type")
+		data, _ := dp.Data.(map[string]any)
+		name, _ := data["name"].(string)
+		switch kind {
+		case "function_call":
+			args, _ := json.Marshal(data["args"])
+			fmt.Fprintf(a.out, "tool call: %s %s\n", name, args)
+		case "function_response":
+			fmt.Fprintf(a.out, "tool result: %s\n", name)
+		}
+	}
+	if withText {
+		a.renderText(message.Role, message.Parts)
+	}
+}
+
+func (a *attachment) renderText(role protocol.MessageRole, parts []protocol.Part) {
+	var text strings.Builder
+	for _, part := range parts {
+		if tp, ok := part.(*protocol.TextPart); ok {
+			text.WriteString(tp.Text)
+		}
+	}
+	if strings.TrimSpace(text.String()) != "" {
+		fmt.Fprintf(a.out, "%s: %s\n", role, text.String())
+	}
+}
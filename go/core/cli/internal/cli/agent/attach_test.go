@@ -0,0 +1,139 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
+
+	"github.com/kagent-dev/kagent/go/api/client"
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
+)
+
+type fakeAttachClient struct {
+	states    []protocol.TaskState
+	streams   [][]client.A2AEvent
+	decisions []client.ToolDecision
+	replies   []string
+}
+
+func (f *fakeAttachClient) next() <-chan client.A2AEvent {
+	ch := make(chan client.A2AEvent, len(f.streams[0]))
+	for _, event := range f.streams[0] {
+		ch <- event
+	}
+	close(ch)
+	f.streams = f.streams[1:]
+	return ch
+}
+
+func (f *fakeAttachClient) Resubscribe(context.Context, string) (<-chan client.A2AEvent, error) {
+	return f.next(), nil
+}
+
+func (f *fakeAttachClient) GetTask(_ context.Context, taskID string) (*protocol.Task, error) {
+	state := f.states[0]
+	f.states = f.states[1:]
+	return &protocol.Task{ID: taskID, ContextID: "session-1", Status: protocol.TaskStatus{State: state}}, nil
+}
+
+func (f *fakeAttachClient) StreamReply(_ context.Context, _, _, text string) (<-chan client.A2AEvent, error) {
+	f.replies = append(f.replies, text)
+	return f.next(), nil
+}
+
+func (f *fakeAttachClient) StreamDecide(_ context.Context, _, _ string, decision client.ToolDecision) (<-chan client.A2AEvent, error) {
+	f.decisions = append(f.decisions, decision)
+	return f.next(), nil
+}
+
+func statusEvent(state protocol.TaskState, final bool, parts ...protocol.Part) client.A2AEvent {
+	message := protocol.NewMessage(protocol.MessageRoleAgent, parts)
+	return client.A2AEvent{StatusUpdate: &protocol.TaskStatusUpdateEvent{
+		TaskID: "task-1",
+		Status: protocol.TaskStatus{State: state, Message: &message},
+		Final:  final,
+	}}
+}
+
+// textPart returns a text part as decoded from the wire.
+func textPart(text string) *protocol.TextPart {
+	part := protocol.NewTextPart(text)
+	return &part
+}
+
+func TestAttachmentRun(t *testing.T) {
+	toolCall := protocol.NewDataPart(map[string]any{"name": "k8s_delete_resource", "args": map[string]any{"name": "web"}})
+	toolCall.Metadata = map[string]any{"kagent_type": "function_call"}
+	lastChunk := true
+
+	fake := &fakeAttachClient{
+		states: []protocol.TaskState{protocol.TaskStateInputRequired, protocol.TaskStateInputRequired, protocol.TaskStateCompleted},
+		streams: [][]client.A2AEvent{
+			{
+				statusEvent(protocol.TaskStateWorking, false, &toolCall),
+				statusEvent(protocol.TaskStateInputRequired, true, textPart("Delete pod web?")),
+			},
+			{statusEvent(protocol.TaskStateInputRequired, true, textPart("Which namespace?"))},
+			{
+				{ArtifactUpdate: &protocol.TaskArtifactUpdateEvent{
+					Artifact:  protocol.Artifact{Parts: []protocol.Part{textPart("Deleted pod web.")}},
+					LastChunk: &lastChunk,
+				}},
+				statusEvent(protocol.TaskStateCompleted, true),
+			},
+		},
+	}
+	approvalsCalls := 0
+	var out strings.Builder
+	a := &attachment{
+		client: fake,
+		approvals: func(context.Context, *protocol.Task) ([]api.ToolApproval, error) {
+			approvalsCalls++
+			if approvalsCalls > 1 {
+				return nil, nil
+			}
+			return []api.ToolApproval{{TaskID: "task-1", ToolName: "k8s_delete_resource", Args: map[string]any{"name": "web"}}}, nil
+		},
+		in:  bufio.NewReader(strings.NewReader("y\n\ndefault\n")),
+		out: &out,
+	}
+
+	task, err := a.run(context.Background(), &protocol.Task{ID: "task-1", ContextID: "session-1", Status: protocol.TaskStatus{State: protocol.TaskStateWorking}})
+	require.NoError(t, err)
+	assert.Equal(t, protocol.TaskStateCompleted, task.Status.State)
+	assert.Equal(t, []client.ToolDecision{{Approve: true}}, fake.decisions)
+	assert.Equal(t, []string{"default"}, fake.replies)
+
+	for _, want := range []string{
+		`tool call: k8s_delete_resource {"name":"web"}`,
+		"agent: Delete pod web?",
+		"Approve? [y/n, or a reason to reject]",
+		"agent: Which namespace?",
+		"agent: Deleted pod web.",
+		"Task task-1 is completed",
+	} {
+		assert.Contains(t, out.String(), want)
+	}
+}
+
+func TestAttachmentRunDetachesAtEndOfInput(t *testing.T) {
+	fake := &fakeAttachClient{}
+	var out strings.Builder
+	a := &attachment{
+		client:    fake,
+		approvals: func(context.Context, *protocol.Task) ([]api.ToolApproval, error) { return nil, nil },
+		in:        bufio.NewReader(strings.NewReader("")),
+		out:       &out,
+	}
+	message := protocol.NewMessage(protocol.MessageRoleAgent, []protocol.Part{textPart("Which cluster?")})
+	task, err := a.run(context.Background(), &protocol.Task{ID: "task-1", Status: protocol.TaskStatus{State: protocol.TaskStateInputRequired, Message: &message}})
+	require.NoError(t, err)
+	assert.Equal(t, protocol.TaskStateInputRequired, task.Status.State)
+	assert.Contains(t, out.String(), "agent: Which cluster?")
+	assert.Empty(t, fake.replies)
+}
@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kagent-dev/kagent/go/api/client"
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
+	"github.com/kagent-dev/kagent/go/core/cli/internal/config"
+)
+
+// completionTimeout bounds API calls made while completing on the command
+// line, so a missing or slow controller never hangs the shell.
+const completionTimeout = 3 * time.Second
+
+// CompleteAgentNames returns the names of agents in the configured namespace
+// that start with toComplete. Each entry carries the agent's description in
+// the "value\tdescription" form shells display next to candidates.
+func CompleteAgentNames(ctx context.Context, cfg *config.Config, toComplete string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, completionTimeout)
+	defer cancel()
+
+	resp, err := cfg.Client().Agent.ListAgents(ctx, client.ListAgentsOptions{Namespace: cfg.Namespace})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list agents: %w", err)
+	}
+	return agentCompletions(resp.Data, toComplete), nil
+}
+
+// CompleteSessionIDs returns the IDs of the current user's sessions that start
+// with toComplete, described by the session name.
+func CompleteSessionIDs(ctx context.Context, cfg *config.Config, toComplete string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, completionTimeout)
+	defer cancel()
+
+	resp, err := cfg.Client().Session.ListSessions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	return sessionCompletions(resp.Data, toComplete), nil
+}
+
+func agentCompletions(agents []api.AgentResponse, toComplete string) []string {
+	var out []string
+	for _, a := range agents {
+		if a.Agent == nil || !strings.HasPrefix(a.Agent.Metadata.Name, toComplete) {
+			continue
+		}
+		out = append(out, completionEntry(a.Agent.Metadata.Name, a.Agent.Spec.Description))
+	}
+	return out
+}
+
+func sessionCompletions(sessions []*api.Session, toComplete string) []string {
+	var out []string
+	for _, s := range sessions {
+		if s == nil || !strings.HasPrefix(s.ID, toComplete) {
+			continue
+		}
+		description := ""
+		if s.Name != nil {
+			description = *s.Name
+		}
+		out = append(out, completionEntry(s.ID, description))
+	}
+	return out
+}
+
+// completionEntry formats a candidate with an optional one-line description.
+func completionEntry(value, description string) string {
+	description = strings.Join(strings.Fields(description), " ")
+	if description == "" {
+		return value
+	}
+	return value + "\t" + description
+}
@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"slices"
+	"testing"
+
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAgentCompletions(t *testing.T) {
+	agent := func(name, description string) api.AgentResponse {
+		spec := v1alpha2.SandboxAgentSpec{}
+		spec.Description = description
+		return api.AgentResponse{Agent: &api.AgentResource{Metadata: metav1.ObjectMeta{Name: name}, Spec: spec}}
+	}
+	agents := []api.AgentResponse{
+		agent("k8s-agent", "Kubernetes expert\nfor clusters"),
+		agent("helm-agent", ""),
+		agent("kgateway-agent", "Gateway helper"),
+		{},
+	}
+
+	tests := []struct {
+		name       string
+		toComplete string
+		want       []string
+	}{
+		{name: "all", toComplete: "", want: []string{"k8s-agent\tKubernetes expert for clusters", "helm-agent", "kgateway-agent\tGateway helper"}},
+		{name: "prefix", toComplete: "k", want: []string{"k8s-agent\tKubernetes expert for clusters", "kgateway-agent\tGateway helper"}},
+		{name: "no match", toComplete: "x", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := agentCompletions(agents, tt.toComplete); !slices.Equal(got, tt.want) {
+				t.Errorf("agentCompletions() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSessionCompletions(t *testing.T) {
+	name := "Debug crashing pod"
+	sessions := []*api.Session{
+		{ID: "a1b2", Name: &name},
+		{ID: "a9z8"},
+		{ID: "c3d4"},
+		nil,
+	}
+	got := sessionCompletions(sessions, "a")
+	want := []string{"a1b2\tDebug crashing pod", "a9z8"}
+	if !slices.Equal(got, want) {
+		t.Errorf("sessionCompletions() = %q, want %q", got, want)
+	}
+}
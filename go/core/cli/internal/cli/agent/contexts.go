@@ -0,0 +1,121 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/kagent-dev/kagent/go/core/cli/internal/config"
+)
+
+type ContextCfg struct {
+	// Path is the contexts file.
+	Path string
+	Name string
+	// Settings are the context fields set on the command line.
+	Settings config.Context
+}
+
+// UseContextCmd makes a context the current one.
+func UseContextCmd(cfg *ContextCfg) error {
+	contexts, err := config.LoadContexts(cfg.Path)
+	if err != nil {
+		return err
+	}
+	if _, ok := contexts.Contexts[cfg.Name]; !ok {
+		return fmt.Errorf("context %q not found", cfg.Name)
+	}
+	contexts.CurrentContext = cfg.Name
+	if err := contexts.Save(cfg.Path); err != nil {
+		return err
+	}
+	fmt.Printf("Switched to context %q.\n", cfg.Name)
+	return nil
+}
+
+// SetContextCmd creates a context, or updates the fields of an existing one
+// that are set in cfg.Settings.
+func SetContextCmd(cfg *ContextCfg) error {
+	contexts, err := config.LoadContexts(cfg.Path)
+	if err != nil {
+		return err
+	}
+	if contexts.Contexts == nil {
+		contexts.Contexts = map[string]config.Context{}
+	}
+	ctx, exists := contexts.Contexts[cfg.Name]
+	for _, f := range []struct{ dst, src *string }{
+		{&ctx.KAgentURL, &cfg.Settings.KAgentURL},
+		{&ctx.A2AURL, &cfg.Settings.A2AURL},
+		{&ctx.UserID, &cfg.Settings.UserID},
+		{&ctx.Namespace, &cfg.Settings.Namespace},
+	} {
+		if *f.src != "" {
+			*f.dst = *f.src
+		}
+	}
+	contexts.Contexts[cfg.Name] = ctx
+	if err := contexts.Save(cfg.Path); err != nil {
+		return err
+	}
+	if exists {
+		fmt.Printf("Context %q modified.\n", cfg.Name)
+	} else {
+		fmt.Printf("Context %q created.\n", cfg.Name)
+	}
+	return nil
+}
+
+// DeleteContextCmd removes a context, unsetting the current context if it was
+// the one removed.
+func DeleteContextCmd(cfg *ContextCfg) error {
+	contexts, err := config.LoadContexts(cfg.Path)
+	if err != nil {
+		return err
+	}
+	if _, ok := contexts.Contexts[cfg.Name]; !ok {
+		return fmt.Errorf("context %q not found", cfg.Name)
+	}
+	delete(contexts.Contexts, cfg.Name)
+	if contexts.CurrentContext == cfg.Name {
+		contexts.CurrentContext = ""
+	}
+	if err := contexts.Save(cfg.Path); err != nil {
+		return err
+	}
+	fmt.Printf("Deleted context %q.\n", cfg.Name)
+	return nil
+}
+
+// GetContextsCmd prints the contexts, marking the current one.
+func GetContextsCmd(cfg *ContextCfg) error {
+	contexts, err := config.LoadContexts(cfg.Path)
+	if err != nil {
+		return err
+	}
+	headers := []string{"CURRENT", "NAME", "KAGENT URL", "A2A URL", "USER ID", "NAMESPACE"}
+	names := contexts.Names()
+	rows := make([][]string, len(names))
+	for i, name := range names {
+		ctx := contexts.Contexts[name]
+		current := ""
+		if name == contexts.CurrentContext {
+			current = "*"
+		}
+		rows[i] = []string{current, name, ctx.KAgentURL, ctx.A2AURL, ctx.UserID, ctx.Namespace}
+	}
+	return printOutput(contexts, headers, rows)
+}
+
+// CurrentContextCmd prints the context in use, which --context or
+// KAGENT_CLI_CONTEXT may select instead of the current context.
+func CurrentContextCmd(cfg *ContextCfg) error {
+	contexts, err := config.LoadContexts(cfg.Path)
+	if err != nil {
+		return err
+	}
+	name := contexts.Resolve(cfg.Name)
+	if name == "" {
+		return fmt.Errorf("current context is not set")
+	}
+	fmt.Println(name)
+	return nil
+}
@@ -0,0 +1,399 @@
+package cli
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	"github.com/kagent-dev/kagent/go/core/cli/internal/common/prompt"
+	"github.com/kagent-dev/kagent/go/core/cli/internal/config"
+	"github.com/kagent-dev/kagent/go/core/pkg/env"
+	"golang.org/x/term"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// modelConfigPreset holds the defaults the modelconfig wizard offers for a
+// provider. The default models match the Helm chart's providers.
+type modelConfigPreset struct {
+	provider v1alpha2.ModelProvider
+	model    string
+	// apiKeyEnv is the environment variable the API key is read from, and
+	// its key in the generated Secret. Empty for providers without one.
+	apiKeyEnv string
+}
+
+var modelConfigPresets = []modelConfigPreset{
+	{provider: v1alpha2.ModelProviderOpenAI, model: "gpt-4.1-mini", apiKeyEnv: env.OpenAIAPIKey.Name()},
+	{provider: v1alpha2.ModelProviderAnthropic, model: "claude-haiku-4-5", apiKeyEnv: env.AnthropicAPIKey.Name()},
+	{provider: v1alpha2.ModelProviderAzureOpenAI, model: "gpt-4.1-mini", apiKeyEnv: env.AzureOpenAIAPIKey.Name()},
+	{provider: v1alpha2.ModelProviderGemini, model: "gemini-2.0-flash-lite", apiKeyEnv: env.GoogleAPIKey.Name()},
+	{provider: v1alpha2.ModelProviderOllama, model: "llama3.2"},
+	{provider: v1alpha2.ModelProviderBedrock, model: "anthropic.claude-3-5-haiku-20241022-v1:0"},
+}
+
+const (
+	defaultAzureAPIVersion = "2023-05-15"
+	defaultOllamaHost      = "host.docker.internal:11434"
+	defaultBedrockRegion   = "us-east-1"
+)
+
+// modelConfigPresetFor returns the preset of a provider given by its CRD
+// name or Helm values key, in any case.
+func modelConfigPresetFor(provider string) (modelConfigPreset, error) {
+	for _, p := range modelConfigPresets {
+		if strings.EqualFold(provider, string(p.provider)) {
+			return p, nil
+		}
+	}
+	names := make([]string, len(modelConfigPresets))
+	for i, p := range modelConfigPresets {
+		names[i] = GetModelProviderHelmValuesKey(p.provider)
+	}
+	return modelConfigPreset{}, fmt.Errorf("unsupported provider %q, must be one of %s", provider, strings.Join(names, ", "))
+}
+
+type CreateModelConfigCfg struct {
+	Config *config.Config
+	Name   string
+	// Provider is the model provider, e.g. openAI or anthropic. The wizard
+	// asks for everything unset when Provider is empty and stdin is a
+	// terminal.
+	Provider string
+	Model    string
+	// APIKeyEnv is the environment variable to read the API key from. It
+	// defaults to the provider's usual variable, e.g. OPENAI_API_KEY.
+	APIKeyEnv string
+	// BaseURL overrides the OpenAI or Anthropic API URL.
+	BaseURL         string
+	AzureEndpoint   string
+	AzureDeployment string
+	AzureAPIVersion string
+	OllamaHost      string
+	BedrockRegion   string
+	// SkipValidation creates the ModelConfig without checking that the
+	// provider accepts its credentials.
+	SkipValidation bool
+	// DryRun prints the ModelConfig without creating it.
+	DryRun bool
+}
+
+// CreateModelConfigCmd creates a ModelConfig, and a Secret holding its
+// credentials, after checking that the provider accepts them.
+func CreateModelConfigCmd(ctx context.Context, cfg *CreateModelConfigCfg) error {
+	interactive := cfg.Provider == "" && term.IsTerminal(int(os.Stdin.Fd()))
+	if interactive {
+		if err := runModelConfigWizard(cfg); err != nil {
+			return err
+		}
+	}
+	if cfg.Provider == "" {
+		return errors.New("a provider is required (--provider)")
+	}
+	preset, err := modelConfigPresetFor(cfg.Provider)
+	if err != nil {
+		return err
+	}
+	if cfg.Name == "" {
+		cfg.Name = strings.ToLower(string(preset.provider)) + "-model-config"
+	}
+	credentials, err := modelConfigCredentials(preset, cfg, interactive)
+	if err != nil {
+		return err
+	}
+	modelConfig, err := buildModelConfig(preset, cfg, cfg.Config.Namespace, credentials)
+	if err != nil {
+		return err
+	}
+
+	if !cfg.SkipValidation {
+		if err := validateModelConfig(ctx, http.DefaultClient, &modelConfig.Spec, credentials, os.Stderr); err != nil {
+			if !interactive {
+				return fmt.Errorf("%w\nUse --skip-validation to create the ModelConfig anyway", err)
+			}
+			fmt.Fprintf(os.Stderr, "Validation failed: %v\n", err)
+			ok, promptErr := prompt.PromptForConfirmation("Create the ModelConfig anyway?")
+			if promptErr != nil {
+				return promptErr
+			}
+			if !ok {
+				return errors.New("aborted")
+			}
+		}
+	}
+
+	out, err := yaml.Marshal(modelConfig)
+	if err != nil {
+		return fmt.Errorf("failed to encode ModelConfig: %w", err)
+	}
+	if cfg.DryRun {
+		fmt.Print(string(out))
+		return nil
+	}
+
+	secrets := make([]api.SecretMaterial, 0, len(credentials))
+	for _, key := range slices.Sorted(maps.Keys(credentials)) {
+		secrets = append(secrets, api.SecretMaterial{Name: modelConfig.Spec.APIKeySecret, Key: key, Value: credentials[key]})
+	}
+	resp, err := cfg.Config.Client().ModelConfig.CreateModelConfig(ctx, &api.CreateModelConfigRequest{
+		Ref:     modelConfig.Namespace + "/" + modelConfig.Name,
+		Secrets: secrets,
+		Spec:    modelConfig.Spec,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create ModelConfig: %w", err)
+	}
+	fmt.Print(string(out))
+	fmt.Printf("ModelConfig %s created\n", resp.Data.Ref)
+	if len(secrets) > 0 {
+		fmt.Printf("Secret %s/%s created with its credentials\n", modelConfig.Namespace, modelConfig.Spec.APIKeySecret)
+	}
+	return nil
+}
+
+// runModelConfigWizard asks for the settings cfg leaves unset.
+func runModelConfigWizard(cfg *CreateModelConfigCfg) error {
+	options := make([]string, len(modelConfigPresets))
+	for i, p := range modelConfigPresets {
+		options[i] = string(p.provider)
+	}
+	i, err := prompt.PromptForSelection("Select a model provider:", options)
+	if err != nil {
+		return err
+	}
+	preset := modelConfigPresets[i]
+	cfg.Provider = string(preset.provider)
+
+	ask := func(target *string, text, defaultValue string) error {
+		if *target != "" {
+			return nil
+		}
+		v, err := prompt.PromptWithDefault(text, defaultValue)
+		*target = v
+		return err
+	}
+	if err := ask(&cfg.Name, "ModelConfig name", strings.ToLower(cfg.Provider)+"-model-config"); err != nil {
+		return err
+	}
+	if err := ask(&cfg.Model, "Model", preset.model); err != nil {
+		return err
+	}
+	switch preset.provider {
+	case v1alpha2.ModelProviderAzureOpenAI:
+		if err := ask(&cfg.AzureEndpoint, "Azure OpenAI endpoint, e.g. https://my-resource.openai.azure.com", ""); err != nil {
+			return err
+		}
+		if err := ask(&cfg.AzureDeployment, "Azure OpenAI deployment", cfg.Model); err != nil {
+			return err
+		}
+		return ask(&cfg.AzureAPIVersion, "Azure OpenAI API version", defaultAzureAPIVersion)
+	case v1alpha2.ModelProviderOllama:
+		return ask(&cfg.OllamaHost, "Ollama host, as reached from the cluster", defaultOllamaHost)
+	case v1alpha2.ModelProviderBedrock:
+		return ask(&cfg.BedrockRegion, "AWS region", defaultBedrockRegion)
+	}
+	return nil
+}
+
+// modelConfigCredentials returns the Secret keys and values holding the
+// credentials of the ModelConfig, read from the environment or, in the
+// wizard, asked for.
+func modelConfigCredentials(preset modelConfigPreset, cfg *CreateModelConfigCfg, interactive bool) (map[string]string, error) {
+	switch preset.provider {
+	case v1alpha2.ModelProviderOllama:
+		return nil, nil
+	case v1alpha2.ModelProviderBedrock:
+		// A bearer token, or IAM credentials; without either the agent uses
+		// the AWS credentials of its pod, e.g. from IRSA.
+		credentials := map[string]string{}
+		for _, v := range []env.StringVar{env.AWSBearerTokenBedrock, env.AWSAccessKeyID, env.AWSSecretAccessKey, env.AWSSessionToken} {
+			if value := os.Getenv(v.Name()); value != "" {
+				credentials[v.Name()] = value
+			}
+		}
+		if _, ok := credentials[env.AWSBearerTokenBedrock.Name()]; ok {
+			return map[string]string{env.AWSBearerTokenBedrock.Name(): credentials[env.AWSBearerTokenBedrock.Name()]}, nil
+		}
+		if len(credentials) == 0 && interactive {
+			token, err := prompt.PromptForSecret(fmt.Sprintf("%s (empty to use the pod's AWS credentials): ", env.AWSBearerTokenBedrock.Name()))
+			if err != nil {
+				return nil, err
+			}
+			if token != "" {
+				credentials[env.AWSBearerTokenBedrock.Name()] = token
+			}
+		}
+		return credentials, nil
+	}
+
+	apiKeyEnv := cfg.APIKeyEnv
+	if apiKeyEnv == "" {
+		apiKeyEnv = preset.apiKeyEnv
+		if preset.provider == v1alpha2.ModelProviderGemini {
+			apiKeyEnv = GetProviderAPIKey(preset.provider)
+		}
+	}
+	apiKey := os.Getenv(apiKeyEnv)
+	if apiKey == "" && interactive {
+		var err error
+		if apiKey, err = prompt.PromptForSecret(fmt.Sprintf("%s API key: ", preset.provider)); err != nil {
+			return nil, err
+		}
+	}
+	if apiKey == "" {
+		return nil, fmt.Errorf("no API key for %s: set %s or run the command in a terminal to enter it", preset.provider, apiKeyEnv)
+	}
+	return map[string]string{preset.apiKeyEnv: apiKey}, nil
+}
+
+// buildModelConfig returns the ModelConfig cfg describes, referencing a
+// Secret named after it when there are credentials.
+func buildModelConfig(preset modelConfigPreset, cfg *CreateModelConfigCfg, namespace string, credentials map[string]string) (*v1alpha2.ModelConfig, error) {
+	spec := v1alpha2.ModelConfigSpec{Provider: preset.provider, Model: cfg.Model}
+	if spec.Model == "" {
+		spec.Model = preset.model
+	}
+	if len(credentials) > 0 {
+		spec.APIKeySecret = cfg.Name
+		spec.APIKeySecretKey = preset.apiKeyEnv
+	}
+	switch preset.provider {
+	case v1alpha2.ModelProviderOpenAI:
+		if cfg.BaseURL != "" {
+			spec.OpenAI = &v1alpha2.OpenAIConfig{BaseURL: cfg.BaseURL}
+		}
+	case v1alpha2.ModelProviderAnthropic:
+		if cfg.BaseURL != "" {
+			spec.Anthropic = &v1alpha2.AnthropicConfig{BaseURL: cfg.BaseURL}
+		}
+	case v1alpha2.ModelProviderAzureOpenAI:
+		if cfg.AzureEndpoint == "" {
+			return nil, errors.New("an Azure OpenAI endpoint is required (--azure-endpoint)")
+		}
+		spec.AzureOpenAI = &v1alpha2.AzureOpenAIConfig{
+			Endpoint:       cfg.AzureEndpoint,
+			APIVersion:     cmp.Or(cfg.AzureAPIVersion, defaultAzureAPIVersion),
+			DeploymentName: cmp.Or(cfg.AzureDeployment, spec.Model),
+		}
+	case v1alpha2.ModelProviderOllama:
+		spec.Ollama = &v1alpha2.OllamaConfig{Host: cmp.Or(cfg.OllamaHost, defaultOllamaHost)}
+	case v1alpha2.ModelProviderBedrock:
+		spec.Bedrock = &v1alpha2.BedrockConfig{Region: cmp.Or(cfg.BedrockRegion, defaultBedrockRegion)}
+	}
+	return &v1alpha2.ModelConfig{
+		TypeMeta:   metav1.TypeMeta{APIVersion: v1alpha2.GroupVersion.String(), Kind: "ModelConfig"},
+		ObjectMeta: metav1.ObjectMeta{Name: cfg.Name, Namespace: namespace},
+		Spec:       spec,
+	}, nil
+}
+
+// validateModelConfig lists the models of the provider with the ModelConfig's
+// credentials, and warns on w when its model is not among them. Bedrock
+// requests need AWS signatures and are not checked.
+func validateModelConfig(ctx context.Context, httpClient *http.Client, spec *v1alpha2.ModelConfigSpec, credentials map[string]string, w io.Writer) error {
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+
+	apiKey := credentials[spec.APIKeySecretKey]
+	var (
+		modelsURL string
+		header    = http.Header{}
+	)
+	switch spec.Provider {
+	case v1alpha2.ModelProviderOpenAI:
+		base := "https://api.openai.com/v1"
+		if spec.OpenAI != nil && spec.OpenAI.BaseURL != "" {
+			base = spec.OpenAI.BaseURL
+		}
+		modelsURL = strings.TrimSuffix(base, "/") + "/models"
+		header.Set("Authorization", "Bearer "+apiKey)
+	case v1alpha2.ModelProviderAnthropic:
+		base := "https://api.anthropic.com"
+		if spec.Anthropic != nil && spec.Anthropic.BaseURL != "" {
+			base = spec.Anthropic.BaseURL
+		}
+		modelsURL = strings.TrimSuffix(strings.TrimSuffix(base, "/"), "/v1") + "/v1/models"
+		header.Set("x-api-key", apiKey)
+		header.Set("anthropic-version", "2023-06-01")
+	case v1alpha2.ModelProviderAzureOpenAI:
+		modelsURL = strings.TrimSuffix(spec.AzureOpenAI.Endpoint, "/") + "/openai/models?api-version=" + url.QueryEscape(spec.AzureOpenAI.APIVersion)
+		header.Set("api-key", apiKey)
+	case v1alpha2.ModelProviderGemini:
+		modelsURL = "https://generativelanguage.googleapis.com/v1beta/models"
+		header.Set("x-goog-api-key", apiKey)
+	case v1alpha2.ModelProviderOllama:
+		host := spec.Ollama.Host
+		if !strings.Contains(host, "://") {
+			host = "http://" + host
+		}
+		modelsURL = strings.TrimSuffix(host, "/") + "/api/tags"
+	default:
+		fmt.Fprintf(w, "Skipping validation: %s credentials cannot be checked from the CLI\n", spec.Provider)
+		return nil
+	}
+	return checkProviderModels(ctx, httpClient, spec, modelsURL, header, w)
+}
+
+// providerModels is the union of the model list responses of the providers:
+// data[].id (OpenAI, Anthropic, Azure), models[].name (Gemini, Ollama).
+type providerModels struct {
+	Data []struct {
+		ID string `json:"id"`
+	} `json:"data"`
+	Models []struct {
+		Name string `json:"name"`
+	} `json:"models"`
+}
+
+func checkProviderModels(ctx context.Context, httpClient *http.Client, spec *v1alpha2.ModelConfigSpec, modelsURL string, header http.Header, w io.Writer) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, modelsURL, nil)
+	if err != nil {
+		return fmt.Errorf("invalid %s URL: %w", spec.Provider, err)
+	}
+	req.Header = header
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach %s: %w", spec.Provider, err)
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("%s rejected the API key (%s)", spec.Provider, resp.Status)
+	default:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s returned %s: %s", spec.Provider, resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var models providerModels
+	if err := json.NewDecoder(resp.Body).Decode(&models); err != nil {
+		return fmt.Errorf("failed to read the models of %s: %w", spec.Provider, err)
+	}
+	names := make([]string, 0, len(models.Data)+len(models.Models))
+	for _, m := range models.Data {
+		names = append(names, m.ID)
+	}
+	for _, m := range models.Models {
+		// Gemini names models "models/<id>", Ollama "<name>:<tag>".
+		names = append(names, strings.TrimPrefix(m.Name, "models/"), strings.TrimSuffix(m.Name, ":latest"))
+	}
+	// Azure lists the base models of the resource, not its deployments.
+	if spec.Provider == v1alpha2.ModelProviderAzureOpenAI || slices.Contains(names, spec.Model) {
+		fmt.Fprintf(w, "Validated the credentials with %s\n", spec.Provider)
+		return nil
+	}
+	fmt.Fprintf(w, "Warning: %s accepted the credentials but does not list model %q\n", spec.Provider, spec.Model)
+	return nil
+}
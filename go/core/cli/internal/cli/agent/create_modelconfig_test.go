@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+)
+
+func TestModelConfigPresetFor(t *testing.T) {
+	for _, name := range []string{"openAI", "OpenAI", "azureOpenAI", "bedrock"} {
+		_, err := modelConfigPresetFor(name)
+		assert.NoError(t, err, name)
+	}
+	_, err := modelConfigPresetFor("mistral")
+	assert.ErrorContains(t, err, "must be one of openAI, anthropic, azureOpenAI, gemini, ollama, bedrock")
+}
+
+func TestBuildModelConfig(t *testing.T) {
+	preset, _ := modelConfigPresetFor("azureOpenAI")
+	_, err := buildModelConfig(preset, &CreateModelConfigCfg{Name: "azure"}, "kagent", nil)
+	assert.ErrorContains(t, err, "--azure-endpoint")
+
+	mc, err := buildModelConfig(preset, &CreateModelConfigCfg{Name: "azure", AzureEndpoint: "https://example.openai.azure.com"}, "kagent",
+		map[string]string{"AZURE_OPENAI_API_KEY": "key"})
+	require.NoError(t, err)
+	assert.Equal(t, "kagent", mc.Namespace)
+	assert.Equal(t, "azure", mc.Spec.APIKeySecret)
+	assert.Equal(t, "AZURE_OPENAI_API_KEY", mc.Spec.APIKeySecretKey)
+	assert.Equal(t, "gpt-4.1-mini", mc.Spec.Model)
+	assert.Equal(t, &v1alpha2.AzureOpenAIConfig{Endpoint: "https://example.openai.azure.com", APIVersion: defaultAzureAPIVersion, DeploymentName: "gpt-4.1-mini"}, mc.Spec.AzureOpenAI)
+
+	preset, _ = modelConfigPresetFor("ollama")
+	mc, err = buildModelConfig(preset, &CreateModelConfigCfg{Name: "local", Model: "qwen3"}, "kagent", nil)
+	require.NoError(t, err)
+	assert.Empty(t, mc.Spec.APIKeySecret)
+	assert.Equal(t, "qwen3", mc.Spec.Model)
+	assert.Equal(t, defaultOllamaHost, mc.Spec.Ollama.Host)
+}
+
+func TestValidateModelConfig(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/models":
+			if r.Header.Get("Authorization") != "Bearer good" && r.Header.Get("x-api-key") != "good" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte(`{"data":[{"id":"gpt-4.1-mini"},{"id":"claude-haiku-4-5"}]}`))
+		case "/api/tags":
+			_, _ = w.Write([]byte(`{"models":[{"name":"llama3.2:latest"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name        string
+		spec        v1alpha2.ModelConfigSpec
+		apiKey      string
+		wantErr     string
+		wantMessage string
+	}{
+		{
+			name:        "openai",
+			spec:        v1alpha2.ModelConfigSpec{Provider: v1alpha2.ModelProviderOpenAI, Model: "gpt-4.1-mini", OpenAI: &v1alpha2.OpenAIConfig{BaseURL: server.URL + "/v1"}},
+			apiKey:      "good",
+			wantMessage: "Validated the credentials with OpenAI",
+		},
+		{
+			name:    "rejected key",
+			spec:    v1alpha2.ModelConfigSpec{Provider: v1alpha2.ModelProviderOpenAI, Model: "gpt-4.1-mini", OpenAI: &v1alpha2.OpenAIConfig{BaseURL: server.URL + "/v1"}},
+			apiKey:  "bad",
+			wantErr: "OpenAI rejected the API key",
+		},
+		{
+			name:        "anthropic unknown model",
+			spec:        v1alpha2.ModelConfigSpec{Provider: v1alpha2.ModelProviderAnthropic, Model: "claude-9", Anthropic: &v1alpha2.AnthropicConfig{BaseURL: server.URL}},
+			apiKey:      "good",
+			wantMessage: `does not list model "claude-9"`,
+		},
+		{
+			name:        "ollama",
+			spec:        v1alpha2.ModelConfigSpec{Provider: v1alpha2.ModelProviderOllama, Model: "llama3.2", Ollama: &v1alpha2.OllamaConfig{Host: server.URL}},
+			wantMessage: "Validated the credentials with Ollama",
+		},
+		{
+			name:        "bedrock",
+			spec:        v1alpha2.ModelConfigSpec{Provider: v1alpha2.ModelProviderBedrock, Model: "anthropic.claude", Bedrock: &v1alpha2.BedrockConfig{Region: "us-east-1"}},
+			wantMessage: "Skipping validation",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.spec.APIKeySecretKey = "API_KEY"
+			var out bytes.Buffer
+			err := validateModelConfig(context.Background(), server.Client(), &tt.spec, map[string]string{"API_KEY": tt.apiKey}, &out)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Contains(t, out.String(), tt.wantMessage)
+		})
+	}
+}
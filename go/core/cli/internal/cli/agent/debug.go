@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/kagent-dev/kagent/go/core/cli/internal/config"
+)
+
+type DebugAgentCfg struct {
+	Config     *config.Config
+	Name       string
+	Capture    time.Duration
+	OutputFile string
+}
+
+// DebugAgentCmd asks the controller to record reconcile traces for a single
+// agent, waits for the capture window to close (or for the user to interrupt),
+// then downloads the recorded bundle to a JSON file.
+func DebugAgentCmd(ctx context.Context, cfg *DebugAgentCfg) error {
+	client := cfg.Config.Client()
+	namespace := cfg.Config.Namespace
+
+	started, err := client.Debug.StartAgentCapture(ctx, namespace, cfg.Name, cfg.Capture.String())
+	if err != nil {
+		return fmt.Errorf("failed to start debug capture for agent %s/%s: %w", namespace, cfg.Name, err)
+	}
+	fmt.Fprintf(os.Stderr, "Capturing reconciles of agent %s/%s until %s (Ctrl+C to stop early)...\n",
+		namespace, cfg.Name, started.Data.Until.Local().Format(time.Kitchen))
+
+	timer := time.NewTimer(time.Until(started.Data.Until))
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+		fmt.Fprintln(os.Stderr, "Capture interrupted, downloading what was recorded so far...")
+	}
+
+	// The wait may have been cut short by ctx; the download must still run.
+	bundle, err := client.Debug.GetAgentCapture(context.WithoutCancel(ctx), namespace, cfg.Name)
+	if err != nil {
+		return fmt.Errorf("failed to download debug capture for agent %s/%s: %w", namespace, cfg.Name, err)
+	}
+
+	out := cfg.OutputFile
+	if out == "" {
+		out = fmt.Sprintf("kagent-debug-%s-%s-%s.json", namespace, cfg.Name, time.Now().Format("20060102-150405"))
+	}
+	byt, err := json.MarshalIndent(bundle.Data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode debug capture: %w", err)
+	}
+	if err := os.WriteFile(out, byt, 0600); err != nil {
+		return fmt.Errorf("failed to write debug capture: %w", err)
+	}
+
+	fmt.Printf("Wrote %d entries to %s\n", len(bundle.Data.Entries), out)
+	return nil
+}
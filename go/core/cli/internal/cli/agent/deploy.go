@@ -19,6 +19,7 @@ import (
 	"github.com/kagent-dev/kmcp/api/v1alpha1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -565,6 +566,9 @@ func CreateKubernetesClient() (client.Client, error) {
 	if err := v1alpha2.AddToScheme(schemes); err != nil {
 		return nil, fmt.Errorf("failed to add kagent v1alpha2 scheme: %v", err)
 	}
+	if err := apiextensionsv1.AddToScheme(schemes); err != nil {
+		return nil, fmt.Errorf("failed to add apiextensions scheme: %v", err)
+	}
 
 	k8sClient, err := client.New(config, client.Options{Scheme: schemes})
 	if err != nil {
@@ -0,0 +1,352 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	"github.com/kagent-dev/kagent/go/core/cli/internal/config"
+	kmcpv1alpha1 "github.com/kagent-dev/kmcp/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// defaultModelConfigName is the controller's default for
+// --default-model-config-name, used when the controller configmap does not
+// set DEFAULT_MODEL_CONFIG_NAME.
+const defaultModelConfigName = "default-model-config"
+
+// doctorCRDs are the CRDs the CLI needs, with the API version it talks to.
+var doctorCRDs = []struct {
+	name    string
+	version string
+}{
+	{name: "agents.kagent.dev", version: "v1alpha2"},
+	{name: "modelconfigs.kagent.dev", version: "v1alpha2"},
+	{name: "modelproviderconfigs.kagent.dev", version: "v1alpha2"},
+	{name: "remotemcpservers.kagent.dev", version: "v1alpha2"},
+	{name: "memories.kagent.dev", version: "v1alpha1"},
+}
+
+type DoctorCfg struct {
+	Config *config.Config
+}
+
+type doctorStatus string
+
+const (
+	doctorPass doctorStatus = "PASS"
+	doctorWarn doctorStatus = "WARN"
+	doctorFail doctorStatus = "FAIL"
+)
+
+// doctorResult is the outcome of one diagnostic check. Hint tells the user
+// how to fix a failing or warning check.
+type doctorResult struct {
+	Check   string
+	Status  doctorStatus
+	Message string
+	Hint    string
+}
+
+// DoctorCmd diagnoses a kagent installation and prints a pass/fail report. It
+// returns an error when any check fails so scripts can act on the exit code.
+// k8sClient may be nil when no cluster is reachable, in which case only the
+// controller check runs.
+func DoctorCmd(ctx context.Context, cfg *DoctorCfg, k8sClient client.Client) error {
+	results := []doctorResult{checkController(ctx, cfg.Config)}
+	if k8sClient == nil {
+		results = append(results, doctorResult{
+			Check:   "Kubernetes",
+			Status:  doctorFail,
+			Message: "could not create a Kubernetes client",
+			Hint:    "Check that your kubeconfig points at the cluster kagent is installed in",
+		})
+	} else {
+		results = append(results, runClusterChecks(ctx, k8sClient, cfg.Config.Namespace)...)
+	}
+
+	failed := printDoctorReport(results)
+	if failed > 0 {
+		return fmt.Errorf("%d check(s) failed", failed)
+	}
+	return nil
+}
+
+func runClusterChecks(ctx context.Context, k8sClient client.Client, namespace string) []doctorResult {
+	var results []doctorResult
+	results = append(results, checkCRDs(ctx, k8sClient)...)
+	results = append(results, checkDefaultModelConfig(ctx, k8sClient, namespace))
+	results = append(results, checkModelConfigSecrets(ctx, k8sClient, namespace)...)
+	results = append(results, checkToolServers(ctx, k8sClient, namespace)...)
+	results = append(results, checkAgents(ctx, k8sClient, namespace)...)
+	return results
+}
+
+func checkController(ctx context.Context, cfg *config.Config) doctorResult {
+	result := doctorResult{Check: "Controller"}
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	version, err := cfg.Client().Version.GetVersion(ctx)
+	if err != nil {
+		result.Status = doctorFail
+		result.Message = fmt.Sprintf("%s is not reachable: %v", cfg.KAgentURL, err)
+		result.Hint = fmt.Sprintf("Check the controller pods with 'kubectl -n %s get pods -l app.kubernetes.io/component=controller', or run 'kagent install'", cfg.Namespace)
+		return result
+	}
+	result.Status = doctorPass
+	result.Message = fmt.Sprintf("reachable at %s (version %s)", cfg.KAgentURL, version.KAgentVersion)
+	return result
+}
+
+func checkCRDs(ctx context.Context, k8sClient client.Client) []doctorResult {
+	results := make([]doctorResult, 0, len(doctorCRDs))
+	for _, want := range doctorCRDs {
+		result := doctorResult{Check: "CRD " + want.name}
+		crd := &apiextensionsv1.CustomResourceDefinition{}
+		if err := k8sClient.Get(ctx, client.ObjectKey{Name: want.name}, crd); err != nil {
+			result.Status = doctorFail
+			if apierrors.IsNotFound(err) {
+				result.Message = "not installed"
+			} else {
+				result.Message = fmt.Sprintf("failed to get CRD: %v", err)
+			}
+			result.Hint = "Install the CRDs with 'kagent install' or 'helm upgrade --install kagent-crds'"
+			results = append(results, result)
+			continue
+		}
+
+		var served []string
+		for _, v := range crd.Spec.Versions {
+			if v.Served {
+				served = append(served, v.Name)
+			}
+		}
+		if !slices.Contains(served, want.version) {
+			result.Status = doctorFail
+			result.Message = fmt.Sprintf("serves %s, expected %s", strings.Join(served, ", "), want.version)
+			result.Hint = "Upgrade the kagent-crds chart to match the CLI version"
+		} else {
+			result.Status = doctorPass
+			result.Message = "serves " + strings.Join(served, ", ")
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// checkDefaultModelConfig verifies that the ModelConfig the controller falls
+// back to exists and was accepted.
+func checkDefaultModelConfig(ctx context.Context, k8sClient client.Client, namespace string) doctorResult {
+	name := defaultModelConfigName
+	var cm corev1.ConfigMap
+	if err := k8sClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: "kagent-controller"}, &cm); err == nil && cm.Data["DEFAULT_MODEL_CONFIG_NAME"] != "" {
+		name = cm.Data["DEFAULT_MODEL_CONFIG_NAME"]
+	}
+
+	result := doctorResult{Check: "Default ModelConfig"}
+	modelConfig := &v1alpha2.ModelConfig{}
+	if err := k8sClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, modelConfig); err != nil {
+		result.Status = doctorFail
+		result.Message = fmt.Sprintf("%s/%s: %v", namespace, name, err)
+		result.Hint = "Create it, or set providers.default when installing kagent so the chart creates it"
+		return result
+	}
+	if cond := meta.FindStatusCondition(modelConfig.Status.Conditions, v1alpha2.ModelConfigConditionTypeAccepted); cond != nil && cond.Status == metav1.ConditionFalse {
+		result.Status = doctorFail
+		result.Message = fmt.Sprintf("%s/%s is not accepted: %s", namespace, name, cond.Message)
+		result.Hint = fmt.Sprintf("Inspect it with 'kubectl -n %s describe modelconfig %s'", namespace, name)
+		return result
+	}
+	result.Status = doctorPass
+	result.Message = fmt.Sprintf("%s/%s (%s %s)", namespace, name, modelConfig.Spec.Provider, modelConfig.Spec.Model)
+	return result
+}
+
+// checkModelConfigSecrets verifies that the API key secret of every
+// ModelConfig exists and holds the configured key.
+func checkModelConfigSecrets(ctx context.Context, k8sClient client.Client, namespace string) []doctorResult {
+	var modelConfigs v1alpha2.ModelConfigList
+	if err := k8sClient.List(ctx, &modelConfigs, client.InNamespace(namespace)); err != nil {
+		return []doctorResult{{
+			Check:   "ModelConfig secrets",
+			Status:  doctorFail,
+			Message: fmt.Sprintf("failed to list ModelConfigs: %v", err),
+		}}
+	}
+
+	var results []doctorResult
+	for _, modelConfig := range modelConfigs.Items {
+		if from := modelConfig.Spec.APIKeyFrom; from != nil {
+			// The key only exists in the agent pods, so it cannot be checked here.
+			source := "env var " + from.EnvVar
+			if from.File != "" {
+				source = "file " + from.File
+			}
+			results = append(results, doctorResult{
+				Check:   "ModelConfig " + modelConfig.Name,
+				Status:  doctorPass,
+				Message: fmt.Sprintf("API key read from %s in the agent pod", source),
+			})
+			continue
+		}
+		if modelConfig.Spec.APIKeySecret == "" {
+			continue
+		}
+		result := doctorResult{Check: "ModelConfig " + modelConfig.Name}
+		secret := &corev1.Secret{}
+		err := k8sClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: modelConfig.Spec.APIKeySecret}, secret)
+		switch {
+		case err != nil:
+			result.Status = doctorFail
+			result.Message = fmt.Sprintf("API key secret %s: %v", modelConfig.Spec.APIKeySecret, err)
+			result.Hint = fmt.Sprintf("Create it with 'kubectl -n %s create secret generic %s --from-literal=%s=<api key>'",
+				namespace, modelConfig.Spec.APIKeySecret, modelConfig.Spec.APIKeySecretKey)
+		case len(secret.Data[modelConfig.Spec.APIKeySecretKey]) == 0:
+			result.Status = doctorFail
+			result.Message = fmt.Sprintf("API key secret %s has no value for key %q", modelConfig.Spec.APIKeySecret, modelConfig.Spec.APIKeySecretKey)
+			result.Hint = "Fix spec.apiKeySecretKey or add the key to the secret"
+		default:
+			result.Status = doctorPass
+			result.Message = fmt.Sprintf("API key resolved from %s", modelConfig.Spec.APIKeySecret)
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// checkToolServers reports RemoteMCPServers that were not accepted or expose
+// no tools, and MCPServers that are not ready.
+func checkToolServers(ctx context.Context, k8sClient client.Client, namespace string) []doctorResult {
+	var results []doctorResult
+
+	var remoteServers v1alpha2.RemoteMCPServerList
+	if err := k8sClient.List(ctx, &remoteServers, client.InNamespace(namespace)); err != nil {
+		results = append(results, doctorResult{
+			Check:   "RemoteMCPServers",
+			Status:  doctorFail,
+			Message: fmt.Sprintf("failed to list RemoteMCPServers: %v", err),
+		})
+	}
+	for _, server := range remoteServers.Items {
+		result := doctorResult{Check: "RemoteMCPServer " + server.Name}
+		cond := meta.FindStatusCondition(server.Status.Conditions, v1alpha2.AgentConditionTypeAccepted)
+		switch {
+		case cond == nil:
+			result.Status = doctorWarn
+			result.Message = "not reconciled yet"
+			result.Hint = "Check the controller logs if this persists"
+		case cond.Status != metav1.ConditionTrue:
+			result.Status = doctorFail
+			result.Message = cond.Message
+			result.Hint = fmt.Sprintf("Check that %s is reachable from the controller", server.Spec.URL)
+		case len(server.Status.DiscoveredTools) == 0:
+			result.Status = doctorWarn
+			result.Message = "accepted but no tools were discovered"
+			result.Hint = "Check that the server implements tools/list"
+		default:
+			result.Status = doctorPass
+			result.Message = fmt.Sprintf("%d tools discovered", len(server.Status.DiscoveredTools))
+		}
+		results = append(results, result)
+	}
+
+	// MCPServers are optional; skip them when the kmcp CRD is not installed.
+	var mcpServers kmcpv1alpha1.MCPServerList
+	if err := k8sClient.List(ctx, &mcpServers, client.InNamespace(namespace)); err != nil {
+		if !meta.IsNoMatchError(err) {
+			results = append(results, doctorResult{
+				Check:   "MCPServers",
+				Status:  doctorFail,
+				Message: fmt.Sprintf("failed to list MCPServers: %v", err),
+			})
+		}
+		return results
+	}
+	for _, server := range mcpServers.Items {
+		result := doctorResult{Check: "MCPServer " + server.Name}
+		cond := meta.FindStatusCondition(server.Status.Conditions, string(kmcpv1alpha1.MCPServerConditionReady))
+		if cond == nil || cond.Status != metav1.ConditionTrue {
+			result.Status = doctorFail
+			result.Message = "not ready"
+			if cond != nil && cond.Message != "" {
+				result.Message += ": " + cond.Message
+			}
+			result.Hint = fmt.Sprintf("Inspect the deployment with 'kubectl -n %s describe deployment %s'", namespace, server.Name)
+		} else {
+			result.Status = doctorPass
+			result.Message = "ready"
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// checkAgents reports agents that were not accepted or whose deployment is
+// not ready.
+func checkAgents(ctx context.Context, k8sClient client.Client, namespace string) []doctorResult {
+	var agents v1alpha2.AgentList
+	if err := k8sClient.List(ctx, &agents, client.InNamespace(namespace)); err != nil {
+		return []doctorResult{{
+			Check:   "Agents",
+			Status:  doctorFail,
+			Message: fmt.Sprintf("failed to list agents: %v", err),
+		}}
+	}
+
+	var results []doctorResult
+	for _, agent := range agents.Items {
+		result := doctorResult{Check: "Agent " + agent.Name}
+		accepted := meta.FindStatusCondition(agent.Status.Conditions, v1alpha2.AgentConditionTypeAccepted)
+		ready := meta.FindStatusCondition(agent.Status.Conditions, v1alpha2.AgentConditionTypeReady)
+		switch {
+		case accepted != nil && accepted.Status == metav1.ConditionFalse:
+			result.Status = doctorFail
+			result.Message = "not accepted: " + accepted.Message
+			result.Hint = fmt.Sprintf("Fix the agent spec; 'kagent lint' or 'kubectl -n %s describe agent %s' shows details", namespace, agent.Name)
+		case ready == nil:
+			result.Status = doctorWarn
+			result.Message = "not reconciled yet"
+			result.Hint = "Check the controller logs if this persists"
+		case ready.Status != metav1.ConditionTrue:
+			result.Status = doctorFail
+			result.Message = "deployment not ready: " + ready.Message
+			result.Hint = fmt.Sprintf("Check the pods with 'kubectl -n %s get pods -l app.kubernetes.io/name=%s'", namespace, agent.Name)
+		default:
+			result.Status = doctorPass
+			result.Message = "ready"
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// printDoctorReport prints results and returns how many failed.
+func printDoctorReport(results []doctorResult) int {
+	counts := map[doctorStatus]int{}
+	for _, r := range results {
+		counts[r.Status]++
+		var status string
+		switch r.Status {
+		case doctorPass:
+			status = config.BoldGreen(string(r.Status))
+		case doctorWarn:
+			status = config.BoldYellow(string(r.Status))
+		default:
+			status = config.BoldRed(string(r.Status))
+		}
+		fmt.Printf("[%s] %s: %s\n", status, r.Check, r.Message)
+		if r.Status != doctorPass && r.Hint != "" {
+			fmt.Printf("       %s\n", r.Hint)
+		}
+	}
+	fmt.Printf("\n%d passed, %d warnings, %d failed\n", counts[doctorPass], counts[doctorWarn], counts[doctorFail])
+	return counts[doctorFail]
+}
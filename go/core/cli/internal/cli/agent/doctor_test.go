@@ -0,0 +1,160 @@
+package cli
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	kmcpv1alpha1 "github.com/kagent-dev/kmcp/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newDoctorClient(t *testing.T, objects ...client.Object) client.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, apiextensionsv1.AddToScheme(scheme))
+	require.NoError(t, v1alpha2.AddToScheme(scheme))
+	require.NoError(t, kmcpv1alpha1.AddToScheme(scheme))
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+}
+
+func doctorCRD(name string, versions ...string) *apiextensionsv1.CustomResourceDefinition {
+	obj := &apiextensionsv1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	for _, v := range versions {
+		obj.Spec.Versions = append(obj.Spec.Versions, apiextensionsv1.CustomResourceDefinitionVersion{Name: v, Served: true})
+	}
+	return obj
+}
+
+func doctorResultsByCheck(results []doctorResult) map[string]doctorResult {
+	byCheck := map[string]doctorResult{}
+	for _, r := range results {
+		byCheck[r.Check] = r
+	}
+	return byCheck
+}
+
+func TestRunClusterChecks(t *testing.T) {
+	condition := func(conditionType string, status metav1.ConditionStatus, message string) []metav1.Condition {
+		return []metav1.Condition{{Type: conditionType, Status: status, Message: message}}
+	}
+
+	k8sClient := newDoctorClient(t,
+		doctorCRD("agents.kagent.dev", "v1alpha1", "v1alpha2"),
+		doctorCRD("modelconfigs.kagent.dev", "v1alpha1"),
+		doctorCRD("modelproviderconfigs.kagent.dev", "v1alpha2"),
+		doctorCRD("remotemcpservers.kagent.dev", "v1alpha2"),
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "kagent-controller", Namespace: "kagent"},
+			Data:       map[string]string{"DEFAULT_MODEL_CONFIG_NAME": "primary"},
+		},
+		&v1alpha2.ModelConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "primary", Namespace: "kagent"},
+			Spec: v1alpha2.ModelConfigSpec{
+				Provider:        v1alpha2.ModelProviderOpenAI,
+				Model:           "gpt-4o",
+				APIKeySecret:    "openai",
+				APIKeySecretKey: "OPENAI_API_KEY",
+			},
+		},
+		&v1alpha2.ModelConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "wrong-key", Namespace: "kagent"},
+			Spec: v1alpha2.ModelConfigSpec{
+				Provider:        v1alpha2.ModelProviderOpenAI,
+				APIKeySecret:    "openai",
+				APIKeySecretKey: "MISSING",
+			},
+		},
+		&v1alpha2.ModelConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "ollama", Namespace: "kagent"},
+			Spec:       v1alpha2.ModelConfigSpec{Provider: v1alpha2.ModelProviderOllama},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "openai", Namespace: "kagent"},
+			Data:       map[string][]byte{"OPENAI_API_KEY": []byte("sk-test")},
+		},
+		&v1alpha2.RemoteMCPServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "tools", Namespace: "kagent"},
+			Spec:       v1alpha2.RemoteMCPServerSpec{URL: "http://tools:8084/mcp"},
+			Status: v1alpha2.RemoteMCPServerStatus{
+				Conditions:      condition(v1alpha2.AgentConditionTypeAccepted, metav1.ConditionTrue, ""),
+				DiscoveredTools: []*v1alpha2.MCPTool{{Name: "get_pods"}},
+			},
+		},
+		&v1alpha2.RemoteMCPServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "unreachable", Namespace: "kagent"},
+			Spec:       v1alpha2.RemoteMCPServerSpec{URL: "http://unreachable/mcp"},
+			Status: v1alpha2.RemoteMCPServerStatus{
+				Conditions: condition(v1alpha2.AgentConditionTypeAccepted, metav1.ConditionFalse, "connection refused"),
+			},
+		},
+		&kmcpv1alpha1.MCPServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "github", Namespace: "kagent"},
+			Status: kmcpv1alpha1.MCPServerStatus{
+				Conditions: condition(string(kmcpv1alpha1.MCPServerConditionReady), metav1.ConditionTrue, ""),
+			},
+		},
+		&v1alpha2.Agent{
+			ObjectMeta: metav1.ObjectMeta{Name: "k8s-agent", Namespace: "kagent"},
+			Status: v1alpha2.AgentStatus{
+				Conditions: condition(v1alpha2.AgentConditionTypeReady, metav1.ConditionTrue, ""),
+			},
+		},
+		&v1alpha2.Agent{
+			ObjectMeta: metav1.ObjectMeta{Name: "crashing", Namespace: "kagent"},
+			Status: v1alpha2.AgentStatus{
+				Conditions: condition(v1alpha2.AgentConditionTypeReady, metav1.ConditionFalse, "0/1 replicas ready"),
+			},
+		},
+		&v1alpha2.Agent{
+			ObjectMeta: metav1.ObjectMeta{Name: "new", Namespace: "kagent"},
+		},
+	)
+
+	results := doctorResultsByCheck(runClusterChecks(context.Background(), k8sClient, "kagent"))
+
+	tests := []struct {
+		check  string
+		status doctorStatus
+	}{
+		{check: "CRD agents.kagent.dev", status: doctorPass},
+		{check: "CRD modelconfigs.kagent.dev", status: doctorFail},
+		{check: "CRD memories.kagent.dev", status: doctorFail},
+		{check: "Default ModelConfig", status: doctorPass},
+		{check: "ModelConfig primary", status: doctorPass},
+		{check: "ModelConfig wrong-key", status: doctorFail},
+		{check: "RemoteMCPServer tools", status: doctorPass},
+		{check: "RemoteMCPServer unreachable", status: doctorFail},
+		{check: "MCPServer github", status: doctorPass},
+		{check: "Agent k8s-agent", status: doctorPass},
+		{check: "Agent crashing", status: doctorFail},
+		{check: "Agent new", status: doctorWarn},
+	}
+	for _, tt := range tests {
+		t.Run(tt.check, func(t *testing.T) {
+			result, ok := results[tt.check]
+			require.True(t, ok, "check %q did not run", tt.check)
+			assert.Equal(t, tt.status, result.Status, result.Message)
+			if tt.status != doctorPass {
+				assert.NotEmpty(t, result.Hint)
+			}
+		})
+	}
+
+	_, ok := results["ModelConfig ollama"]
+	assert.False(t, ok, "ModelConfigs without an API key secret are skipped")
+}
+
+func TestCheckDefaultModelConfig_Missing(t *testing.T) {
+	result := checkDefaultModelConfig(context.Background(), newDoctorClient(t), "kagent")
+	assert.Equal(t, doctorFail, result.Status)
+	assert.Contains(t, result.Message, "kagent/default-model-config")
+}
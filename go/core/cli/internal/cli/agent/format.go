@@ -3,51 +3,156 @@ package cli
 import (
 	"encoding/json"
 	"fmt"
+	"io"
+	"os"
 	"slices"
+	"strings"
 
 	"github.com/jedib0t/go-pretty/v6/table"
 	"github.com/kagent-dev/kagent/go/core/internal/utils"
 	"github.com/spf13/viper"
+	"k8s.io/client-go/util/jsonpath"
+	"sigs.k8s.io/yaml"
 )
 
 type OutputFormat string
 
 const (
 	OutputFormatJSON  OutputFormat = "json"
+	OutputFormatYAML  OutputFormat = "yaml"
 	OutputFormatTable OutputFormat = "table"
+	// OutputFormatWide is a table that also shows the wide columns.
+	OutputFormatWide OutputFormat = "wide"
+	// OutputFormatJSONPath prefixes a JSONPath template evaluated against the
+	// JSON form of the output, e.g. jsonpath={[*].id}.
+	OutputFormatJSONPath OutputFormat = "jsonpath="
 )
 
+// OutputFormats lists the accepted values of the output format flag.
+const OutputFormats = "table, wide, json, yaml or jsonpath=<template>"
+
 func printOutput(data any, tableHeaders []string, tableRows [][]string) error {
+	return printWideOutput(data, tableHeaders, tableRows, len(tableHeaders))
+}
+
+// printWideOutput prints data in the configured output format. Table output
+// shows the first defaultColumns columns; the rest are wide columns, shown
+// with -o wide. --columns selects and orders columns by header instead.
+func printWideOutput(data any, tableHeaders []string, tableRows [][]string, defaultColumns int) error {
 	format := OutputFormat(viper.GetString("output_format"))
+	return renderOutput(os.Stdout, format, viper.GetStringSlice("output_columns"), data, tableHeaders, tableRows, defaultColumns)
+}
 
-	tw := table.NewWriter()
-	headers := slices.Collect(utils.Map(slices.Values(tableHeaders), func(header string) any {
-		return header
-	}))
-	tw.AppendHeader(headers)
-	rows := slices.Collect(utils.Map(slices.Values(tableRows), func(row []string) table.Row {
-		return slices.Collect(utils.Map(slices.Values(row), func(cell string) any {
-			return cell
+func renderOutput(w io.Writer, format OutputFormat, columns []string, data any, tableHeaders []string, tableRows [][]string, defaultColumns int) error {
+	switch {
+	case format == OutputFormatJSON:
+		return writeJSON(w, data)
+	case format == OutputFormatYAML:
+		output, err := yaml.Marshal(data)
+		if err != nil {
+			return fmt.Errorf("error formatting YAML: %w", err)
+		}
+		_, err = w.Write(output)
+		return err
+	case strings.HasPrefix(string(format), string(OutputFormatJSONPath)):
+		return writeJSONPath(w, strings.TrimPrefix(string(format), string(OutputFormatJSONPath)), data)
+	case format == OutputFormatTable, format == OutputFormatWide:
+		var selected []int
+		switch {
+		case len(columns) > 0:
+			for _, column := range columns {
+				i := slices.IndexFunc(tableHeaders, func(header string) bool {
+					return strings.EqualFold(header, strings.TrimSpace(column))
+				})
+				if i < 0 {
+					return fmt.Errorf("unknown column %q, available columns: %s", column, strings.Join(tableHeaders, ", "))
+				}
+				selected = append(selected, i)
+			}
+		case format == OutputFormatWide:
+			selected = indexes(len(tableHeaders))
+		default:
+			selected = indexes(min(defaultColumns, len(tableHeaders)))
+		}
+
+		tw := table.NewWriter()
+		tw.AppendHeader(pick(tableHeaders, selected))
+		rows := slices.Collect(utils.Map(slices.Values(tableRows), func(row []string) table.Row {
+			return pick(row, selected)
 		}))
-	}))
-	tw.AppendRows(rows)
-
-	switch format {
-	case OutputFormatJSON:
-		return printJSON(data)
-	case OutputFormatTable:
-		fmt.Println(tw.Render())
-		return nil
+		tw.AppendRows(rows)
+		_, err := fmt.Fprintln(w, tw.Render())
+		return err
 	default:
-		return fmt.Errorf("unknown output format: %s", format)
+		return fmt.Errorf("unknown output format %q, expected %s", format, OutputFormats)
 	}
 }
 
+// isTableOutput reports whether output is a table, to which human-readable
+// notes can be added without breaking structured output.
+func isTableOutput() bool {
+	format := OutputFormat(viper.GetString("output_format"))
+	return format == OutputFormatTable || format == OutputFormatWide
+}
+
 func printJSON(data any) error {
+	return writeJSON(os.Stdout, data)
+}
+
+func writeJSON(w io.Writer, data any) error {
 	output, err := json.MarshalIndent(data, "", "  ")
 	if err != nil {
 		return fmt.Errorf("error formatting JSON: %w", err)
 	}
-	fmt.Println(string(output))
-	return nil
+	_, err = fmt.Fprintln(w, string(output))
+	return err
+}
+
+// writeJSONPath evaluates a kubectl-style JSONPath template. The braces may be
+// omitted for a single expression, e.g. jsonpath=[*].id.
+func writeJSONPath(w io.Writer, template string, data any) error {
+	if !strings.Contains(template, "{") {
+		template = "{" + template + "}"
+	}
+	jp := jsonpath.New("output")
+	if err := jp.Parse(template); err != nil {
+		return fmt.Errorf("invalid JSONPath template %q: %w", template, err)
+	}
+	// Evaluate against the JSON form so that paths use the JSON field names.
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("error formatting JSON: %w", err)
+	}
+	var obj any
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return fmt.Errorf("error formatting JSON: %w", err)
+	}
+	var out strings.Builder
+	if err := jp.Execute(&out, obj); err != nil {
+		return fmt.Errorf("error executing JSONPath template %q: %w", template, err)
+	}
+	result := out.String()
+	if !strings.HasSuffix(result, "\n") {
+		result += "\n"
+	}
+	_, err = io.WriteString(w, result)
+	return err
+}
+
+func indexes(n int) []int {
+	out := make([]int, n)
+	for i := range out {
+		out[i] = i
+	}
+	return out
+}
+
+func pick(row []string, selected []int) []any {
+	out := make([]any, len(selected))
+	for i, col := range selected {
+		if col < len(row) {
+			out[i] = row[col]
+		}
+	}
+	return out
 }
@@ -2,23 +2,61 @@ package cli
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/kagent-dev/kagent/go/api/client"
 	"github.com/kagent-dev/kagent/go/api/database"
 	api "github.com/kagent-dev/kagent/go/api/httpapi"
 	"github.com/kagent-dev/kagent/go/core/cli/internal/config"
 	"github.com/kagent-dev/kagent/go/core/internal/utils"
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
 )
 
-func GetAgentCmd(cfg *config.Config, resourceName string) {
+// GetCfg holds the pagination, filtering and sorting flags of the get commands.
+type GetCfg struct {
+	Config   *config.Config
+	Limit    int
+	Offset   int
+	Sort     string
+	Selector string
+	// CreatedAfter is an RFC3339 timestamp or a duration relative to now, e.g. 24h.
+	CreatedAfter string
+}
+
+func (c *GetCfg) listOptions() (client.ListOptions, error) {
+	opts := client.ListOptions{
+		Limit:         c.Limit,
+		Offset:        c.Offset,
+		Sort:          c.Sort,
+		LabelSelector: c.Selector,
+	}
+	if c.CreatedAfter != "" {
+		if d, err := time.ParseDuration(c.CreatedAfter); err == nil {
+			opts.CreatedAfter = time.Now().Add(-d)
+		} else if t, err := time.Parse(time.RFC3339, c.CreatedAfter); err == nil {
+			opts.CreatedAfter = t
+		} else {
+			return opts, fmt.Errorf("invalid --created-after %q: must be an RFC3339 timestamp or a duration such as 24h", c.CreatedAfter)
+		}
+	}
+	return opts, nil
+}
+
+func GetAgentCmd(getCfg *GetCfg, resourceName string) {
+	cfg := getCfg.Config
 	client := cfg.Client()
 
 	if resourceName == "" {
-		agentList, err := client.Agent.ListAgents(context.Background())
+		opts, err := getCfg.listOptions()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return
+		}
+		agentList, err := client.Agent.ListAgents(context.Background(), opts)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Failed to get agents: %v\n", err)
 			return
@@ -29,25 +67,33 @@ func GetAgentCmd(cfg *config.Config, resourceName string) {
 			return
 		}
 
-		if err := printAgents(agentList.Data); err != nil {
+		if err := printAgents(agentList.Data, agentList.Data); err != nil {
 			fmt.Fprintf(os.Stderr, "Failed to print agents: %v\n", err)
 			return
 		}
+		printNextPageHint(agentList.Pagination)
 	} else {
 		agent, err := client.Agent.GetAgent(context.Background(), resourceName)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Failed to get agent %s: %v\n", resourceName, err)
 			return
 		}
-		byt, _ := json.MarshalIndent(agent, "", "  ")
-		fmt.Fprintln(os.Stdout, string(byt))
+		if err := printAgents(agent.Data, []api.AgentResponse{*agent.Data}); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to print agent: %v\n", err)
+		}
 	}
 }
 
-func GetSessionCmd(cfg *config.Config, resourceName string) {
+func GetSessionCmd(getCfg *GetCfg, resourceName string) {
+	cfg := getCfg.Config
 	client := cfg.Client()
 	if resourceName == "" {
-		sessionList, err := client.Session.ListSessions(context.Background())
+		opts, err := getCfg.listOptions()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return
+		}
+		sessionList, err := client.Session.ListSessions(context.Background(), opts)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Failed to get sessions: %v\n", err)
 			return
@@ -58,18 +104,20 @@ func GetSessionCmd(cfg *config.Config, resourceName string) {
 			return
 		}
 
-		if err := printSessions(sessionList.Data); err != nil {
+		if err := printSessions(sessionList.Data, sessionList.Data); err != nil {
 			fmt.Fprintf(os.Stderr, "Failed to print sessions: %v\n", err)
 			return
 		}
+		printNextPageHint(sessionList.Pagination)
 	} else {
 		session, err := client.Session.GetSession(context.Background(), resourceName)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Failed to get session %s: %v\n", resourceName, err)
 			return
 		}
-		byt, _ := json.MarshalIndent(session, "", "  ")
-		fmt.Fprintln(os.Stdout, string(byt))
+		if err := printSessions(session.Data, []*database.Session{session.Data}); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to print session: %v\n", err)
+		}
 	}
 }
 
@@ -86,8 +134,67 @@ func GetToolCmd(cfg *config.Config) {
 	}
 }
 
+// GetRunCmd lists the runs (A2A tasks) of a session.
+func GetRunCmd(getCfg *GetCfg, sessionID string) {
+	client := getCfg.Config.Client()
+	opts, err := getCfg.listOptions()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	taskList, err := client.Session.ListSessionTasks(context.Background(), sessionID, opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to get runs of session %s: %v\n", sessionID, err)
+		return
+	}
+
+	if len(taskList.Data) == 0 {
+		fmt.Println("No runs found")
+		return
+	}
+
+	if err := printRuns(taskList.Data); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to print runs: %v\n", err)
+		return
+	}
+	printNextPageHint(taskList.Pagination)
+}
+
+func GetToolServerCmd(getCfg *GetCfg) {
+	client := getCfg.Config.Client()
+	opts, err := getCfg.listOptions()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	toolServers, err := client.ToolServer.ListToolServers(context.Background(), opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to get tool servers: %v\n", err)
+		return
+	}
+
+	if len(toolServers) == 0 {
+		fmt.Println("No tool servers found")
+		return
+	}
+
+	if err := printToolServers(toolServers); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to print tool servers: %v\n", err)
+		return
+	}
+}
+
+// printNextPageHint tells the user how to fetch the next page, on stderr so
+// that structured output stays parseable.
+func printNextPageHint(p *api.Pagination) {
+	if p == nil || !p.HasMore {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "More results available, use --offset %d to see the next page\n", p.Offset+p.Limit)
+}
+
 func printTools(tools []database.Tool) error {
-	headers := []string{"#", "NAME", "SERVER_NAME", "DESCRIPTION", "CREATED"}
+	headers := []string{"#", "NAME", "SERVER_NAME", "DESCRIPTION", "CREATED", "GROUP_KIND", "UPDATED"}
 	rows := make([][]string, len(tools))
 	for i, tool := range tools {
 		rows[i] = []string{
@@ -96,15 +203,18 @@ func printTools(tools []database.Tool) error {
 			tool.ServerName,
 			tool.Description,
 			tool.CreatedAt.Format(time.RFC3339),
+			tool.GroupKind,
+			tool.UpdatedAt.Format(time.RFC3339),
 		}
 	}
 
-	return printOutput(tools, headers, rows)
+	return printWideOutput(tools, headers, rows, 5)
 }
 
-func printAgents(agents []api.AgentResponse) error {
+// printAgents prints agents, data is what structured output formats print.
+func printAgents(data any, agents []api.AgentResponse) error {
 	// Prepare table data
-	headers := []string{"#", "NAME", "CREATED", "DEPLOYMENT_READY", "ACCEPTED"}
+	headers := []string{"#", "NAME", "CREATED", "DEPLOYMENT_READY", "ACCEPTED", "MODEL_PROVIDER", "MODEL", "TOOLS", "WORKLOAD"}
 	rows := make([][]string, len(agents))
 	for i, agent := range agents {
 		rows[i] = []string{
@@ -113,14 +223,19 @@ func printAgents(agents []api.AgentResponse) error {
 			agent.Agent.Metadata.CreationTimestamp.Format(time.RFC3339),
 			strconv.FormatBool(agent.DeploymentReady),
 			strconv.FormatBool(agent.Accepted),
+			string(agent.ModelProvider),
+			agent.Model,
+			strconv.Itoa(len(agent.Tools)),
+			string(agent.WorkloadMode),
 		}
 	}
 
-	return printOutput(agents, headers, rows)
+	return printWideOutput(data, headers, rows, 5)
 }
 
-func printSessions(sessions []*database.Session) error {
-	headers := []string{"#", "ID", "NAME", "AGENT", "CREATED"}
+// printSessions prints sessions, data is what structured output formats print.
+func printSessions(data any, sessions []*database.Session) error {
+	headers := []string{"#", "ID", "NAME", "AGENT", "CREATED", "UPDATED", "SOURCE"}
 	rows := make([][]string, len(sessions))
 	for i, session := range sessions {
 		agentID := ""
@@ -131,14 +246,57 @@ func printSessions(sessions []*database.Session) error {
 		if session.Name != nil {
 			sessionName = *session.Name
 		}
+		source := ""
+		if session.Source != nil {
+			source = string(*session.Source)
+		}
 		rows[i] = []string{
 			strconv.Itoa(i + 1),
 			session.ID,
 			sessionName,
 			agentID,
 			session.CreatedAt.Format(time.RFC3339),
+			session.UpdatedAt.Format(time.RFC3339),
+			source,
+		}
+	}
+
+	return printWideOutput(data, headers, rows, 5)
+}
+
+func printRuns(tasks []protocol.Task) error {
+	headers := []string{"#", "ID", "STATE", "UPDATED", "MESSAGES", "ARTIFACTS"}
+	rows := make([][]string, len(tasks))
+	for i, task := range tasks {
+		rows[i] = []string{
+			strconv.Itoa(i + 1),
+			task.ID,
+			string(task.Status.State),
+			task.Status.Timestamp,
+			strconv.Itoa(len(task.History)),
+			strconv.Itoa(len(task.Artifacts)),
+		}
+	}
+
+	return printWideOutput(tasks, headers, rows, 4)
+}
+
+func printToolServers(toolServers []api.ToolServerResponse) error {
+	headers := []string{"#", "NAME", "KIND", "TOOLS", "TOOL_NAMES"}
+	rows := make([][]string, len(toolServers))
+	for i, toolServer := range toolServers {
+		names := make([]string, 0, len(toolServer.DiscoveredTools))
+		for _, tool := range toolServer.DiscoveredTools {
+			names = append(names, tool.Name)
+		}
+		rows[i] = []string{
+			strconv.Itoa(i + 1),
+			toolServer.Ref,
+			toolServer.GroupKind,
+			strconv.Itoa(len(toolServer.DiscoveredTools)),
+			strings.Join(names, ","),
 		}
 	}
 
-	return printOutput(sessions, headers, rows)
+	return printWideOutput(toolServers, headers, rows, 4)
 }
@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kagent-dev/kagent/go/api/client"
+)
+
+func TestGetCfgListOptions(t *testing.T) {
+	t.Run("passes flags through", func(t *testing.T) {
+		cfg := &GetCfg{Limit: 20, Offset: 40, Sort: "-created_at", Selector: "team=platform", CreatedAfter: "2026-01-02T03:04:05Z"}
+		opts, err := cfg.listOptions()
+		require.NoError(t, err)
+		assert.Equal(t, client.ListOptions{
+			Limit:         20,
+			Offset:        40,
+			Sort:          "-created_at",
+			LabelSelector: "team=platform",
+			CreatedAfter:  time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		}, opts)
+	})
+
+	t.Run("created-after accepts a duration", func(t *testing.T) {
+		opts, err := (&GetCfg{CreatedAfter: "24h"}).listOptions()
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now().Add(-24*time.Hour), opts.CreatedAfter, time.Minute)
+	})
+
+	t.Run("rejects invalid created-after", func(t *testing.T) {
+		_, err := (&GetCfg{CreatedAfter: "yesterday"}).listOptions()
+		assert.ErrorContains(t, err, "invalid --created-after")
+	})
+}
+
+func TestRenderOutput(t *testing.T) {
+	type item struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}
+	data := []item{{ID: "s1", Name: "first"}, {ID: "s2", Name: "second"}}
+	headers := []string{"#", "ID", "NAME"}
+	rows := [][]string{{"1", "s1", "first"}, {"2", "s2", "second"}}
+
+	render := func(format OutputFormat, columns ...string) (string, error) {
+		var buf bytes.Buffer
+		err := renderOutput(&buf, format, columns, data, headers, rows, 2)
+		return buf.String(), err
+	}
+
+	t.Run("table hides wide columns", func(t *testing.T) {
+		out, err := render(OutputFormatTable)
+		require.NoError(t, err)
+		assert.Contains(t, out, "s1")
+		assert.NotContains(t, out, "NAME")
+	})
+
+	t.Run("wide shows all columns", func(t *testing.T) {
+		out, err := render(OutputFormatWide)
+		require.NoError(t, err)
+		assert.Contains(t, out, "NAME")
+		assert.Contains(t, out, "second")
+	})
+
+	t.Run("columns select and order", func(t *testing.T) {
+		out, err := render(OutputFormatTable, "name", "id")
+		require.NoError(t, err)
+		assert.Contains(t, out, "NAME")
+		assert.NotContains(t, out, "#")
+		assert.Less(t, strings.Index(out, "first"), strings.Index(out, "s1"))
+	})
+
+	t.Run("rejects unknown column", func(t *testing.T) {
+		_, err := render(OutputFormatTable, "owner")
+		assert.ErrorContains(t, err, `unknown column "owner"`)
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		out, err := render(OutputFormatYAML)
+		require.NoError(t, err)
+		assert.Equal(t, "- id: s1\n  name: first\n- id: s2\n  name: second\n", out)
+	})
+
+	t.Run("jsonpath", func(t *testing.T) {
+		out, err := render("jsonpath={range [*]}{.id}{\"\\n\"}{end}")
+		require.NoError(t, err)
+		assert.Equal(t, "s1\ns2\n", out)
+
+		out, err = render("jsonpath=[1].name")
+		require.NoError(t, err)
+		assert.Equal(t, "second\n", out)
+	})
+
+	t.Run("rejects unknown format", func(t *testing.T) {
+		_, err := render("xml")
+		assert.ErrorContains(t, err, `unknown output format "xml"`)
+	})
+}
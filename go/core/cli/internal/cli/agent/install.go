@@ -3,6 +3,7 @@ package cli
 import (
 	"context"
 	"fmt"
+	"maps"
 	"os"
 	"os/exec"
 	"slices"
@@ -22,6 +23,58 @@ import (
 type InstallCfg struct {
 	Config  *config.Config
 	Profile string
+
+	// ImageRegistry points every kagent image, including agent runtime images,
+	// at a different registry (e.g. a mirror reachable from an air-gapped cluster).
+	ImageRegistry string
+	// ImagePullSecrets are added to kagent pods and to the agent pods the
+	// controller creates.
+	ImagePullSecrets []string
+	// ImageRegistryMirrors maps source registries to mirrors for images
+	// referenced by agent pods, such as user-supplied sidecars.
+	ImageRegistryMirrors map[string]string
+
+	// Manifests installs kagent from plain manifests applied with kubectl
+	// instead of the Helm charts.
+	Manifests bool
+	// DryRun prints the manifests instead of applying them. Requires Manifests.
+	DryRun bool
+	// DatabaseURL is the external PostgreSQL connection string used with
+	// Manifests. A bundled PostgreSQL for evaluation is installed when empty.
+	DatabaseURL string
+}
+
+// imageHelmValues translates the image flags into --set values for the kagent chart.
+func imageHelmValues(cfg *InstallCfg) []string {
+	var values []string
+	if cfg.ImageRegistry != "" {
+		values = append(values,
+			"registry="+helmEscape(cfg.ImageRegistry),
+			"querydoc.image.registry="+helmEscape(cfg.ImageRegistry),
+		)
+	}
+	for i, name := range cfg.ImagePullSecrets {
+		values = append(values,
+			fmt.Sprintf("imagePullSecrets[%d].name=%s", i, helmEscape(name)),
+			fmt.Sprintf("global.imagePullSecrets[%d].name=%s", i, helmEscape(name)),
+		)
+	}
+	sources := slices.Sorted(maps.Keys(cfg.ImageRegistryMirrors))
+	for _, source := range sources {
+		values = append(values, fmt.Sprintf("imageRegistryMirrors.%s=%s", helmEscapeKey(source), helmEscape(cfg.ImageRegistryMirrors[source])))
+	}
+	return values
+}
+
+// helmEscape escapes a --set value so commas are not read as separators.
+func helmEscape(value string) string {
+	return strings.ReplaceAll(value, ",", `\,`)
+}
+
+// helmEscapeKey escapes a --set key segment so dots (as in registry host
+// names) are not read as nesting.
+func helmEscapeKey(key string) string {
+	return strings.ReplaceAll(helmEscape(key), ".", `\.`)
 }
 
 // installChart installs or upgrades a Helm chart with the given parameters
@@ -71,11 +124,18 @@ func InstallCmd(ctx context.Context, cfg *InstallCfg) *PortForward {
 		return nil
 	}
 
-	if err := checkHelmAvailable(); err != nil {
-		fmt.Fprintln(os.Stderr, err)
+	if cfg.DryRun && !cfg.Manifests {
+		fmt.Fprintln(os.Stderr, "--dry-run requires --manifests")
 		return nil
 	}
 
+	if !cfg.Manifests {
+		if err := checkHelmAvailable(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return nil
+		}
+	}
+
 	// get model provider from KAGENT_DEFAULT_MODEL_PROVIDER environment variable or use DefaultModelProvider
 	modelProvider := GetModelProvider()
 
@@ -90,7 +150,15 @@ func InstallCmd(ctx context.Context, cfg *InstallCfg) *PortForward {
 		return nil
 	}
 
+	if cfg.Manifests {
+		if cfg.Profile != "" {
+			fmt.Fprintln(os.Stderr, "Warning: --profile only applies to the Helm install and is ignored with --manifests")
+		}
+		return installManifests(ctx, cfg, modelProvider, apiKeyName, apiKeyValue)
+	}
+
 	helmConfig := setupHelmConfig(modelProvider, apiKeyValue)
+	helmConfig.values = append(helmConfig.values, imageHelmValues(cfg)...)
 
 	// setup profile if provided
 	if cfg.Profile = strings.TrimSpace(cfg.Profile); cfg.Profile != "" {
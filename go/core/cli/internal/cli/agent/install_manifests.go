@@ -0,0 +1,542 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/briandowns/spinner"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kagent-dev/kagent/go/api/config/crd"
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	"github.com/kagent-dev/kagent/go/core/internal/version"
+)
+
+const (
+	manifestsControllerName  = "kagent-controller"
+	manifestsPostgresName    = "kagent-postgresql"
+	manifestsModelConfig     = "default-model-config"
+	manifestsControllerPort  = 8083
+	manifestsDefaultRegistry = "ghcr.io"
+	manifestsPostgresImage   = "docker.io/library/postgres:18.3-alpine"
+	manifestsManagedBy       = "kagent-cli"
+)
+
+// manifestDefaultModels mirrors providers.<name>.model in the kagent chart.
+var manifestDefaultModels = map[v1alpha2.ModelProvider]string{
+	v1alpha2.ModelProviderOpenAI:    "gpt-4.1-mini",
+	v1alpha2.ModelProviderAnthropic: "claude-haiku-4-5",
+	v1alpha2.ModelProviderGemini:    "gemini-2.0-flash-lite",
+	v1alpha2.ModelProviderOllama:    "llama3.2",
+}
+
+// manifestOptions configures the plain manifests rendered by `kagent install --manifests`.
+type manifestOptions struct {
+	Namespace            string
+	Version              string
+	ImageRegistry        string
+	ImagePullSecrets     []string
+	ImageRegistryMirrors map[string]string
+	Provider             v1alpha2.ModelProvider
+	// APIKeyEnv is the key of the provider API key in its Secret, e.g. OPENAI_API_KEY.
+	APIKeyEnv string
+	APIKey    string
+	// DatabaseURL points the controller at an external PostgreSQL. When empty
+	// a single-replica PostgreSQL for evaluation is included.
+	DatabaseURL string
+}
+
+// renderedManifests splits the install into CRDs, which must be established
+// before custom resources can be applied, and everything else.
+type renderedManifests struct {
+	CRDs      []byte
+	Resources []byte
+}
+
+// installManifests renders kagent as plain Kubernetes manifests and either
+// prints them (dry run) or applies them with kubectl, without using Helm.
+func installManifests(ctx context.Context, cfg *InstallCfg, modelProvider v1alpha2.ModelProvider, apiKeyName, apiKeyValue string) *PortForward {
+	manifests, err := renderManifests(manifestOptions{
+		Namespace:            cfg.Config.Namespace,
+		Version:              version.Version,
+		ImageRegistry:        cfg.ImageRegistry,
+		ImagePullSecrets:     cfg.ImagePullSecrets,
+		ImageRegistryMirrors: cfg.ImageRegistryMirrors,
+		Provider:             modelProvider,
+		APIKeyEnv:            apiKeyName,
+		APIKey:               apiKeyValue,
+		DatabaseURL:          cfg.DatabaseURL,
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error rendering manifests:", err)
+		return nil
+	}
+
+	if cfg.DryRun {
+		if format := cfg.Config.OutputFormat; format != "" && format != "table" && format != "yaml" {
+			fmt.Fprintf(os.Stderr, "Warning: --dry-run only supports YAML output, ignoring -o %s\n", format)
+		}
+		os.Stdout.Write(manifests.CRDs)      //nolint:errcheck
+		os.Stdout.Write(manifests.Resources) //nolint:errcheck
+		return nil
+	}
+
+	if _, err := exec.LookPath("kubectl"); err != nil {
+		fmt.Fprintln(os.Stderr, "kubectl not found in PATH. Please install kubectl first: https://kubernetes.io/docs/tasks/tools/")
+		return nil
+	}
+
+	s := spinner.New(spinner.CharSets[35], 100*time.Millisecond)
+	defer s.Stop()
+	s.Suffix = " Installing kagent CRDs"
+	s.Start()
+	if err := applyManifests(ctx, manifests.CRDs); err != nil {
+		s.Stop()
+		fmt.Fprintln(os.Stderr, "Error installing kagent CRDs:", err)
+		return nil
+	}
+	if output, err := exec.CommandContext(ctx, "kubectl", "wait", "--for=condition=Established", "--timeout=60s", "crd", "-l", "app.kubernetes.io/managed-by="+manifestsManagedBy).CombinedOutput(); err != nil {
+		s.Stop()
+		fmt.Fprintln(os.Stderr, "Error waiting for kagent CRDs:", string(output))
+		return nil
+	}
+
+	s.Suffix = fmt.Sprintf(" Installing kagent [%s] %s", modelProvider, cfg.Config.Namespace)
+	if err := applyManifests(ctx, manifests.Resources); err != nil {
+		s.Stop()
+		fmt.Fprintln(os.Stderr, "Error installing kagent:", err)
+		return nil
+	}
+	if output, err := exec.CommandContext(ctx, "kubectl", "rollout", "status", "-n", cfg.Config.Namespace, "deployment/"+manifestsControllerName, "--timeout=5m").CombinedOutput(); err != nil {
+		s.Stop()
+		fmt.Fprintln(os.Stderr, "Error waiting for the kagent controller:", string(output))
+		return nil
+	}
+
+	s.Stop()
+	fmt.Fprintln(os.Stdout, "kagent installed successfully")
+
+	pf, err := NewPortForward(ctx, cfg.Config)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error starting port-forward: %v\n", err)
+		return nil
+	}
+	return pf
+}
+
+// applyManifests server-side applies a YAML stream. Server-side apply avoids
+// the last-applied annotation, which the larger CRDs would not fit in.
+func applyManifests(ctx context.Context, manifests []byte) error {
+	cmd := exec.CommandContext(ctx, "kubectl", "apply", "--server-side", "--force-conflicts", "--field-manager="+manifestsManagedBy, "-f", "-")
+	cmd.Stdin = bytes.NewReader(manifests)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%w: %s", err, output)
+	}
+	return nil
+}
+
+// renderManifests renders the namespace, CRDs, controller and default
+// ModelConfig of a kagent install.
+func renderManifests(opts manifestOptions) (*renderedManifests, error) {
+	crds, err := renderCRDs()
+	if err != nil {
+		return nil, err
+	}
+
+	modelConfig, secret, err := manifestModelConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	objects := []any{
+		&corev1.Namespace{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Namespace"},
+			ObjectMeta: metav1.ObjectMeta{Name: opts.Namespace, Labels: manifestLabels("")},
+		},
+	}
+	if opts.DatabaseURL == "" {
+		objects = append(objects, manifestPostgres(opts)...)
+	}
+	objects = append(objects, manifestController(opts)...)
+	if secret != nil {
+		objects = append(objects, secret)
+	}
+	objects = append(objects, modelConfig)
+
+	var resources bytes.Buffer
+	for _, obj := range objects {
+		out, err := yaml.Marshal(obj)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal %T: %w", obj, err)
+		}
+		resources.WriteString("---\n")
+		resources.Write(out)
+	}
+	return &renderedManifests{CRDs: crds, Resources: resources.Bytes()}, nil
+}
+
+// renderCRDs returns the embedded CRDs, labelled so the install can wait for them.
+func renderCRDs() ([]byte, error) {
+	files, err := fs.Glob(crd.Bases, "bases/*.yaml")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list CRDs: %w", err)
+	}
+	var out bytes.Buffer
+	for _, name := range files {
+		raw, err := fs.ReadFile(crd.Bases, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CRD %s: %w", name, err)
+		}
+		var obj map[string]any
+		if err := yaml.Unmarshal(raw, &obj); err != nil {
+			return nil, fmt.Errorf("failed to parse CRD %s: %w", name, err)
+		}
+		metadata, _ := obj["metadata"].(map[string]any)
+		if metadata == nil {
+			return nil, fmt.Errorf("CRD %s has no metadata", name)
+		}
+		metadata["labels"] = map[string]any{"app.kubernetes.io/managed-by": manifestsManagedBy}
+		labelled, err := yaml.Marshal(obj)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal CRD %s: %w", name, err)
+		}
+		out.WriteString("---\n")
+		out.Write(labelled)
+	}
+	return out.Bytes(), nil
+}
+
+func manifestLabels(component string) map[string]string {
+	labels := map[string]string{
+		"app.kubernetes.io/name":       "kagent",
+		"app.kubernetes.io/instance":   "kagent",
+		"app.kubernetes.io/managed-by": manifestsManagedBy,
+	}
+	if component != "" {
+		labels["app.kubernetes.io/component"] = component
+	}
+	return labels
+}
+
+func manifestSelector(component string) map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":      "kagent",
+		"app.kubernetes.io/instance":  "kagent",
+		"app.kubernetes.io/component": component,
+	}
+}
+
+func manifestMeta(name, namespace, component string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: manifestLabels(component)}
+}
+
+func (o manifestOptions) registry() string {
+	if o.ImageRegistry != "" {
+		return o.ImageRegistry
+	}
+	return manifestsDefaultRegistry
+}
+
+func (o manifestOptions) image(repository string) string {
+	return fmt.Sprintf("%s/%s:%s", o.registry(), repository, o.Version)
+}
+
+func (o manifestOptions) pullSecrets() []corev1.LocalObjectReference {
+	var refs []corev1.LocalObjectReference
+	for _, name := range o.ImagePullSecrets {
+		refs = append(refs, corev1.LocalObjectReference{Name: name})
+	}
+	return refs
+}
+
+func manifestController(opts manifestOptions) []any {
+	ns := opts.Namespace
+	config := map[string]string{
+		"A2A_BASE_URL":                 fmt.Sprintf("http://%s.%s.svc:%d", manifestsControllerName, ns, manifestsControllerPort),
+		"DEFAULT_MODEL_CONFIG_NAME":    manifestsModelConfig,
+		"KAGENT_CONTROLLER_NAME":       manifestsControllerName,
+		"IMAGE_REGISTRY":               opts.registry(),
+		"IMAGE_REPOSITORY":             "kagent-dev/kagent/app",
+		"IMAGE_TAG":                    opts.Version,
+		"GO_IMAGE_REGISTRY":            opts.registry(),
+		"GO_IMAGE_REPOSITORY":          "kagent-dev/kagent/golang-adk",
+		"GO_IMAGE_TAG":                 opts.Version,
+		"SKILLS_INIT_IMAGE_REGISTRY":   opts.registry(),
+		"SKILLS_INIT_IMAGE_REPOSITORY": "kagent-dev/kagent/skills-init",
+		"SKILLS_INIT_IMAGE_TAG":        opts.Version,
+		"LEADER_ELECT":                 "false",
+		"WATCH_NAMESPACES":             "",
+		"DATABASE_VECTOR_ENABLED":      "false",
+		"ZAP_LOG_LEVEL":                "info",
+	}
+	if len(opts.ImagePullSecrets) > 0 {
+		config["IMAGE_PULL_SECRET"] = strings.Join(opts.ImagePullSecrets, ",")
+	}
+	if len(opts.ImageRegistryMirrors) > 0 {
+		var mirrors []string
+		for _, source := range slices.Sorted(maps.Keys(opts.ImageRegistryMirrors)) {
+			mirrors = append(mirrors, source+"="+opts.ImageRegistryMirrors[source])
+		}
+		config["IMAGE_REGISTRY_MIRRORS"] = strings.Join(mirrors, ",")
+	}
+
+	env := []corev1.EnvVar{
+		{Name: "KAGENT_NAMESPACE", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.namespace"}}},
+		{Name: "K8S_POD_NAME", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"}}},
+		{Name: "K8S_NODE_NAME", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "spec.nodeName"}}},
+		{Name: "AUTH_MODE", Value: "unsecure"},
+	}
+	if opts.DatabaseURL != "" {
+		env = append(env, corev1.EnvVar{Name: "POSTGRES_DATABASE_URL", Value: opts.DatabaseURL})
+	} else {
+		env = append(env,
+			corev1.EnvVar{Name: "POSTGRES_PASSWORD", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: manifestsPostgresName},
+				Key:                  "POSTGRES_PASSWORD",
+			}}},
+			corev1.EnvVar{Name: "POSTGRES_DATABASE_URL", Value: fmt.Sprintf("postgres://kagent:$(POSTGRES_PASSWORD)@%s.%s.svc:5432/kagent?sslmode=disable", manifestsPostgresName, ns)},
+		)
+	}
+
+	healthProbe := func(period int32) *corev1.Probe {
+		return &corev1.Probe{
+			ProbeHandler:  corev1.ProbeHandler{HTTPGet: &corev1.HTTPGetAction{Path: "/health", Port: intstr.FromString("http")}},
+			PeriodSeconds: period,
+		}
+	}
+	startupProbe := healthProbe(15)
+	startupProbe.InitialDelaySeconds = 15
+
+	return []any{
+		&corev1.ServiceAccount{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ServiceAccount"},
+			ObjectMeta: manifestMeta(manifestsControllerName, ns, "controller"),
+		},
+		&rbacv1.ClusterRole{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "ClusterRole"},
+			ObjectMeta: manifestMeta(manifestsControllerName, "", "controller"),
+			Rules:      manifestControllerRules(),
+		},
+		&rbacv1.ClusterRoleBinding{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "ClusterRoleBinding"},
+			ObjectMeta: manifestMeta(manifestsControllerName, "", "controller"),
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: manifestsControllerName},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: manifestsControllerName, Namespace: ns}},
+		},
+		&corev1.ConfigMap{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+			ObjectMeta: manifestMeta(manifestsControllerName, ns, "controller"),
+			Data:       config,
+		},
+		&appsv1.Deployment{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+			ObjectMeta: manifestMeta(manifestsControllerName, ns, "controller"),
+			Spec: appsv1.DeploymentSpec{
+				Replicas: new(int32(1)),
+				Selector: &metav1.LabelSelector{MatchLabels: manifestSelector("controller")},
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: manifestSelector("controller")},
+					Spec: corev1.PodSpec{
+						ServiceAccountName: manifestsControllerName,
+						ImagePullSecrets:   opts.pullSecrets(),
+						Containers: []corev1.Container{{
+							Name:            "controller",
+							Image:           opts.image("kagent-dev/kagent/controller"),
+							ImagePullPolicy: corev1.PullIfNotPresent,
+							Env:             env,
+							EnvFrom: []corev1.EnvFromSource{{
+								ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: manifestsControllerName}},
+							}},
+							Ports:          []corev1.ContainerPort{{Name: "http", ContainerPort: manifestsControllerPort, Protocol: corev1.ProtocolTCP}},
+							StartupProbe:   startupProbe,
+							ReadinessProbe: healthProbe(30),
+						}},
+					},
+				},
+			},
+		},
+		&corev1.Service{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+			ObjectMeta: manifestMeta(manifestsControllerName, ns, "controller"),
+			Spec: corev1.ServiceSpec{
+				Type:     corev1.ServiceTypeClusterIP,
+				Selector: manifestSelector("controller"),
+				Ports: []corev1.ServicePort{{
+					Name:       "controller",
+					Port:       manifestsControllerPort,
+					TargetPort: intstr.FromInt32(manifestsControllerPort),
+					Protocol:   corev1.ProtocolTCP,
+				}},
+			},
+		},
+	}
+}
+
+// manifestControllerRules combines the getter, writer and leader election
+// roles of the kagent chart.
+func manifestControllerRules() []rbacv1.PolicyRule {
+	kagentResources := []string{
+		"agents", "sandboxagents", "agentharnesses", "cronagents", "agentevals", "githubtriggers", "workflows", "modelconfigs", "modelproviderconfigs",
+		"toolservers", "memories", "remotemcpservers", "mcpservers",
+	}
+	var finalizers, status []string
+	for _, r := range kagentResources {
+		finalizers = append(finalizers, r+"/finalizers")
+		status = append(status, r+"/status")
+	}
+	readWrite := []string{"get", "list", "watch", "create", "update", "patch", "delete"}
+	return []rbacv1.PolicyRule{
+		{APIGroups: []string{"kagent.dev"}, Resources: kagentResources, Verbs: readWrite},
+		{APIGroups: []string{"kagent.dev"}, Resources: finalizers, Verbs: []string{"update"}},
+		{APIGroups: []string{"kagent.dev"}, Resources: status, Verbs: []string{"get", "patch", "update"}},
+		{APIGroups: []string{"", "apps", "batch", "gateway.networking.k8s.io"}, Resources: []string{"*"}, Verbs: readWrite},
+		{APIGroups: []string{"rbac.authorization.k8s.io"}, Resources: []string{"*"}, Verbs: []string{"get", "list", "watch"}},
+		{APIGroups: []string{"agents.x-k8s.io"}, Resources: []string{"sandboxes"}, Verbs: readWrite},
+		{APIGroups: []string{"ate.dev"}, Resources: []string{"workerpools"}, Verbs: []string{"get", "list", "watch"}},
+		{APIGroups: []string{"ate.dev"}, Resources: []string{"actortemplates"}, Verbs: readWrite},
+		{APIGroups: []string{"ate.dev"}, Resources: []string{"actortemplates/status"}, Verbs: []string{"get"}},
+		{APIGroups: []string{"coordination.k8s.io"}, Resources: []string{"leases"}, Verbs: []string{"get", "list", "watch", "create", "update", "patch"}},
+	}
+}
+
+// manifestPostgres returns the bundled single-replica PostgreSQL, for
+// evaluation only, matching database.postgres.bundled in the kagent chart.
+func manifestPostgres(opts manifestOptions) []any {
+	ns := opts.Namespace
+	image := manifestsPostgresImage
+	if opts.ImageRegistry != "" {
+		image = opts.ImageRegistry + "/library/postgres:18.3-alpine"
+	}
+	readyProbe := func(delay, period, timeout, failures int32) *corev1.Probe {
+		return &corev1.Probe{
+			ProbeHandler:        corev1.ProbeHandler{Exec: &corev1.ExecAction{Command: []string{"pg_isready", "-U", "kagent", "-d", "kagent"}}},
+			InitialDelaySeconds: delay,
+			PeriodSeconds:       period,
+			TimeoutSeconds:      timeout,
+			FailureThreshold:    failures,
+		}
+	}
+	return []any{
+		&corev1.Secret{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+			ObjectMeta: manifestMeta(manifestsPostgresName, ns, "database"),
+			Type:       corev1.SecretTypeOpaque,
+			StringData: map[string]string{"POSTGRES_PASSWORD": "kagent"},
+		},
+		&corev1.PersistentVolumeClaim{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "PersistentVolumeClaim"},
+			ObjectMeta: manifestMeta(manifestsPostgresName, ns, "database"),
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+				Resources: corev1.VolumeResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("500Mi")},
+				},
+			},
+		},
+		&appsv1.Deployment{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+			ObjectMeta: manifestMeta(manifestsPostgresName, ns, "database"),
+			Spec: appsv1.DeploymentSpec{
+				Replicas: new(int32(1)),
+				Strategy: appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType},
+				Selector: &metav1.LabelSelector{MatchLabels: manifestSelector("database")},
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: manifestSelector("database")},
+					Spec: corev1.PodSpec{
+						ImagePullSecrets: opts.pullSecrets(),
+						SecurityContext: &corev1.PodSecurityContext{
+							FSGroup:      new(int64(999)),
+							RunAsUser:    new(int64(999)),
+							RunAsGroup:   new(int64(999)),
+							RunAsNonRoot: new(true),
+						},
+						Containers: []corev1.Container{{
+							Name:            "postgresql",
+							Image:           image,
+							ImagePullPolicy: corev1.PullIfNotPresent,
+							Ports:           []corev1.ContainerPort{{Name: "postgresql", ContainerPort: 5432, Protocol: corev1.ProtocolTCP}},
+							Env: []corev1.EnvVar{
+								{Name: "POSTGRES_DB", Value: "kagent"},
+								{Name: "POSTGRES_USER", Value: "kagent"},
+								{Name: "POSTGRES_PASSWORD", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+									LocalObjectReference: corev1.LocalObjectReference{Name: manifestsPostgresName},
+									Key:                  "POSTGRES_PASSWORD",
+								}}},
+								{Name: "PGDATA", Value: "/var/lib/postgresql/data/pgdata"},
+							},
+							LivenessProbe:  readyProbe(20, 10, 5, 6),
+							ReadinessProbe: readyProbe(5, 5, 3, 3),
+							VolumeMounts:   []corev1.VolumeMount{{Name: "data", MountPath: "/var/lib/postgresql/data"}},
+						}},
+						Volumes: []corev1.Volume{{
+							Name: "data",
+							VolumeSource: corev1.VolumeSource{
+								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: manifestsPostgresName},
+							},
+						}},
+					},
+				},
+			},
+		},
+		&corev1.Service{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+			ObjectMeta: manifestMeta(manifestsPostgresName, ns, "database"),
+			Spec: corev1.ServiceSpec{
+				Type:     corev1.ServiceTypeClusterIP,
+				Selector: manifestSelector("database"),
+				Ports: []corev1.ServicePort{{
+					Name:       "postgresql",
+					Port:       5432,
+					TargetPort: intstr.FromString("postgresql"),
+					Protocol:   corev1.ProtocolTCP,
+				}},
+			},
+		},
+	}
+}
+
+// manifestModelConfig returns the default ModelConfig and, for providers that
+// use an API key, the Secret holding it.
+func manifestModelConfig(opts manifestOptions) (*v1alpha2.ModelConfig, *corev1.Secret, error) {
+	model, ok := manifestDefaultModels[opts.Provider]
+	if !ok {
+		return nil, nil, fmt.Errorf("provider %s is not supported with --manifests, use the Helm install instead", opts.Provider)
+	}
+	modelConfig := &v1alpha2.ModelConfig{
+		TypeMeta:   metav1.TypeMeta{APIVersion: v1alpha2.GroupVersion.String(), Kind: "ModelConfig"},
+		ObjectMeta: manifestMeta(manifestsModelConfig, opts.Namespace, ""),
+		Spec: v1alpha2.ModelConfigSpec{
+			Provider: opts.Provider,
+			Model:    model,
+		},
+	}
+	if opts.Provider == v1alpha2.ModelProviderOllama {
+		modelConfig.Spec.Ollama = &v1alpha2.OllamaConfig{Host: "host.docker.internal:11434"}
+		return modelConfig, nil, nil
+	}
+
+	secretName := "kagent-" + strings.ToLower(string(opts.Provider))
+	modelConfig.Spec.APIKeySecret = secretName
+	modelConfig.Spec.APIKeySecretKey = opts.APIKeyEnv
+	secret := &corev1.Secret{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: manifestMeta(secretName, opts.Namespace, ""),
+		Type:       corev1.SecretTypeOpaque,
+		StringData: map[string]string{opts.APIKeyEnv: opts.APIKey},
+	}
+	return modelConfig, secret, nil
+}
@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestImageHelmValues(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  *InstallCfg
+		want []string
+	}{
+		{
+			name: "no image flags",
+			cfg:  &InstallCfg{},
+			want: nil,
+		},
+		{
+			name: "registry and pull secrets",
+			cfg: &InstallCfg{
+				ImageRegistry:    "registry.local:5000/kagent",
+				ImagePullSecrets: []string{"mirror-creds", "extra"},
+			},
+			want: []string{
+				"registry=registry.local:5000/kagent",
+				"querydoc.image.registry=registry.local:5000/kagent",
+				"imagePullSecrets[0].name=mirror-creds",
+				"global.imagePullSecrets[0].name=mirror-creds",
+				"imagePullSecrets[1].name=extra",
+				"global.imagePullSecrets[1].name=extra",
+			},
+		},
+		{
+			name: "mirrors escape dotted registry hosts",
+			cfg: &InstallCfg{
+				ImageRegistryMirrors: map[string]string{
+					"ghcr.io":   "registry.local/ghcr",
+					"docker.io": "registry.local/dockerhub",
+				},
+			},
+			want: []string{
+				`imageRegistryMirrors.docker\.io=registry.local/dockerhub`,
+				`imageRegistryMirrors.ghcr\.io=registry.local/ghcr`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := imageHelmValues(tt.cfg); !slices.Equal(got, tt.want) {
+				t.Errorf("imageHelmValues() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
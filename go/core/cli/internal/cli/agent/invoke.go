@@ -2,18 +2,21 @@ package cli
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
+	"github.com/kagent-dev/kagent/go/api/adk"
+	"github.com/kagent-dev/kagent/go/api/client"
 	api "github.com/kagent-dev/kagent/go/api/httpapi"
 	"github.com/kagent-dev/kagent/go/api/v1alpha2"
 	"github.com/kagent-dev/kagent/go/core/cli/internal/config"
-	a2aclient "trpc.group/trpc-go/trpc-a2a-go/client"
-	"trpc.group/trpc-go/trpc-a2a-go/protocol"
 )
 
 type InvokeCfg struct {
@@ -25,33 +28,23 @@ type InvokeCfg struct {
 	Stream      bool
 	URLOverride string
 	Token       string
-}
-
-// bearerTokenTransport is an http.RoundTripper that injects an Authorization: Bearer header.
-type bearerTokenTransport struct {
-	base  http.RoundTripper
-	token string
-}
-
-func (t *bearerTokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
-	req = req.Clone(req.Context())
-	req.Header.Set("Authorization", "Bearer "+t.token)
-	return t.base.RoundTrip(req)
+	DryRun      bool
+	Async       bool
+	CallbackURL string
+	// MaxTokens, MaxToolCalls and MaxTurns tighten the agent's budget for
+	// the task; zero keeps the agent's limit.
+	MaxTokens    int64
+	MaxToolCalls int
+	MaxTurns     int
+	// KubeExec runs the invocation in a Job in the cluster instead of
+	// calling the controller API from the CLI.
+	KubeExec      bool
+	KubeExecImage string
+	// Attachments are paths of files sent to the agent with the task.
+	Attachments []string
 }
 
 func InvokeCmd(ctx context.Context, cfg *InvokeCfg) {
-	clientSet := cfg.Config.Client()
-
-	if err := CheckServerConnection(ctx, clientSet); err != nil {
-		// If a connection does not exist, start a short-lived port-forward.
-		pf, err := NewPortForward(ctx, cfg.Config)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error starting port-forward: %v\n", err)
-			return
-		}
-		defer pf.Stop()
-	}
-
 	var task string
 	// If task is set, use it. Otherwise, read from file or stdin.
 	if cfg.Task != "" {
@@ -80,22 +73,59 @@ func InvokeCmd(ctx context.Context, cfg *InvokeCfg) {
 		return
 	}
 
-	var a2aClientOpts []a2aclient.Option
-	a2aClientOpts = append(a2aClientOpts, a2aclient.WithTimeout(cfg.Config.Timeout))
+	attachments, err := readAttachments(cfg.Attachments)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading attachment: %v\n", err)
+		return
+	}
+
+	if cfg.KubeExec {
+		if len(attachments) > 0 {
+			fmt.Fprintln(os.Stderr, "--attach cannot be used with --kube-exec")
+			return
+		}
+		invokeKubeExec(ctx, cfg, task)
+		return
+	}
+
+	clientSet := cfg.Config.Client()
+
+	if err := CheckServerConnection(ctx, clientSet); err != nil {
+		// If a connection does not exist, start a short-lived port-forward.
+		pf, err := NewPortForward(ctx, cfg.Config)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error starting port-forward: %v\n", err)
+			return
+		}
+		defer pf.Stop()
+	}
+
+	if cfg.Async {
+		invokeAsync(ctx, clientSet, cfg, task, attachments)
+		return
+	}
 
+	a2aOpts, err := cfg.Config.A2AOptions()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error creating A2A client: %v\n", err)
+		return
+	}
 	if cfg.Token != "" {
-		a2aClientOpts = append(a2aClientOpts, a2aclient.WithHTTPClient(&http.Client{
-			Transport: &bearerTokenTransport{
-				base:  http.DefaultTransport,
-				token: cfg.Token,
-			},
-		}))
+		a2aOpts = append(a2aOpts, client.WithA2ABearerToken(cfg.Token))
+	}
+	if cfg.Session != "" {
+		a2aOpts = append(a2aOpts, client.WithA2ASessionID(cfg.Session))
+	}
+	if cfg.DryRun {
+		a2aOpts = append(a2aOpts, client.WithA2ADryRun())
+	}
+	if budget := cfg.budget(); budget != nil {
+		a2aOpts = append(a2aOpts, client.WithA2ABudget(budget))
 	}
 
-	var a2aClient *a2aclient.A2AClient
-	var err error
+	var a2aClient *client.A2AClient
 	if cfg.URLOverride != "" {
-		a2aClient, err = a2aclient.NewA2AClient(cfg.URLOverride, a2aClientOpts...)
+		a2aClient, err = client.NewA2AClient(cfg.URLOverride, a2aOpts...)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error creating A2A client: %v\n", err)
 			return
@@ -112,61 +142,34 @@ func InvokeCmd(ctx context.Context, cfg *InvokeCfg) {
 			return
 		}
 
-		agentResponse, err := clientSet.Agent.GetAgent(ctx, fmt.Sprintf("%s/%s", cfg.Config.Namespace, cfg.Agent))
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error getting agent metadata: %v\n", err)
-			return
-		}
-
-		a2aURL := buildA2AURL(cfg.Config.KAgentURL, cfg.Config.Namespace, cfg.Agent, agentResponse.Data)
-		a2aClient, err = a2aclient.NewA2AClient(a2aURL, a2aClientOpts...)
+		a2aClient, err = clientSet.A2A(ctx, fmt.Sprintf("%s/%s", cfg.Config.Namespace, cfg.Agent), a2aOpts...)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error creating A2A client: %v\n", err)
 			return
 		}
 	}
 
-	var sessionID *string
-	if cfg.Session != "" {
-		sessionID = &cfg.Session
-	}
+	defer a2aClient.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, 300*time.Second)
+	defer cancel()
 
 	// Use A2A client to send message
 	if cfg.Stream {
-		ctx, cancel := context.WithTimeout(ctx, 300*time.Second)
-		defer cancel()
-
-		result, err := a2aClient.StreamMessage(ctx, protocol.SendMessageParams{
-			Message: protocol.Message{
-				Kind:      protocol.KindMessage,
-				Role:      protocol.MessageRoleUser,
-				ContextID: sessionID,
-				Parts:     []protocol.Part{protocol.NewTextPart(task)},
-			},
-		})
+		events, err := a2aClient.Stream(ctx, task, attachments...)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error invoking session: %v\n", err)
 			return
 		}
-		StreamA2AEvents(result, cfg.Config.Verbose)
+		StreamA2AEvents(events, cfg.Config.Verbose)
 	} else {
-		ctx, cancel := context.WithTimeout(ctx, 300*time.Second)
-		defer cancel()
-
-		result, err := a2aClient.SendMessage(ctx, protocol.SendMessageParams{
-			Message: protocol.Message{
-				Kind:      protocol.KindMessage,
-				Role:      protocol.MessageRoleUser,
-				ContextID: sessionID,
-				Parts:     []protocol.Part{protocol.NewTextPart(task)},
-			},
-		})
+		result, err := a2aClient.Send(ctx, task, attachments...)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error invoking session: %v\n", err)
 			return
 		}
 
-		jsn, err := result.MarshalJSON()
+		jsn, err := json.Marshal(result)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error marshaling result: %v\n", err)
 			return
@@ -176,10 +179,89 @@ func InvokeCmd(ctx context.Context, cfg *InvokeCfg) {
 	}
 }
 
-func buildA2AURL(baseURL, namespace, agent string, agentResponse *api.AgentResponse) string {
-	a2aPath := "api/a2a"
-	if agentResponse != nil && agentResponse.WorkloadMode == v1alpha2.WorkloadModeSandbox {
-		a2aPath = "api/a2a-sandboxes"
+// budget returns the budget set by the flags, or nil when they keep the
+// agent's budget.
+func (cfg *InvokeCfg) budget() *adk.BudgetConfig {
+	b := &adk.BudgetConfig{}
+	if cfg.MaxTokens > 0 {
+		b.MaxTotalTokens = new(cfg.MaxTokens)
+	}
+	if cfg.MaxToolCalls > 0 {
+		b.MaxToolCalls = new(cfg.MaxToolCalls)
+	}
+	if cfg.MaxTurns > 0 {
+		b.MaxTurns = new(cfg.MaxTurns)
+	}
+	if !b.Enabled() {
+		return nil
+	}
+	return b
+}
+
+// asyncBudget returns the budget set by the flags in the format of the
+// invoke-async API.
+func (cfg *InvokeCfg) asyncBudget() *v1alpha2.AgentBudget {
+	b := cfg.budget()
+	if b == nil {
+		return nil
+	}
+	async := &v1alpha2.AgentBudget{MaxTotalTokens: b.MaxTotalTokens}
+	if b.MaxToolCalls != nil {
+		async.MaxToolCalls = new(int32(*b.MaxToolCalls))
+	}
+	if b.MaxTurns != nil {
+		async.MaxTurns = new(int32(*b.MaxTurns))
+	}
+	return async
+}
+
+// invokeAsync starts the task without waiting for it and prints the task ID,
+// which "kagent wait task" follows.
+func invokeAsync(ctx context.Context, clientSet *client.ClientSet, cfg *InvokeCfg, task string, attachments []api.Attachment) {
+	if cfg.Agent == "" {
+		fmt.Fprintln(os.Stderr, "Agent is required")
+		return
+	}
+	if strings.Contains(cfg.Agent, "/") {
+		fmt.Fprintf(os.Stderr, "Invalid agent format: use --namespace to specify the namespace. Got'%s'\n", cfg.Agent)
+		return
+	}
+	resp, err := clientSet.Agent.InvokeAgentAsync(ctx, fmt.Sprintf("%s/%s", cfg.Config.Namespace, cfg.Agent), &api.InvokeAsyncRequest{
+		Task:        task,
+		SessionID:   cfg.Session,
+		CallbackURL: cfg.CallbackURL,
+		Budget:      cfg.asyncBudget(),
+		Attachments: attachments,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error invoking agent: %v\n", err)
+		return
+	}
+	jsn, err := json.Marshal(resp.Data)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error marshaling result: %v\n", err)
+		return
+	}
+	fmt.Fprintln(os.Stdout, string(jsn))
+	if resp.Data.TaskID != "" {
+		fmt.Fprintf(os.Stderr, "Wait for the task with: kagent wait task %s\n", resp.Data.TaskID)
+	}
+}
+
+// readAttachments reads the files at paths for sending to an agent. Their
+// media type is guessed from the extension, then from the content.
+func readAttachments(paths []string) ([]api.Attachment, error) {
+	var attachments []api.Attachment
+	for _, p := range paths {
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return nil, err
+		}
+		mediaType := mime.TypeByExtension(filepath.Ext(p))
+		if mediaType == "" {
+			mediaType = http.DetectContentType(data)
+		}
+		attachments = append(attachments, api.Attachment{Name: filepath.Base(p), MediaType: mediaType, Data: data})
 	}
-	return fmt.Sprintf("%s/%s/%s/%s", baseURL, a2aPath, namespace, agent)
+	return attachments, nil
 }
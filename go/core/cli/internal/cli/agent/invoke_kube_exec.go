@@ -0,0 +1,224 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/kagent-dev/kagent/go/core/internal/version"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+)
+
+const (
+	// DefaultKubeExecImage is the image of the Job that runs the invocation
+	// with --kube-exec, tagged with the version of the CLI.
+	DefaultKubeExecImage = "ghcr.io/kagent-dev/kagent/cli"
+
+	kubeExecContainerName = "invoke"
+	// kubeExecTTL is how long finished Jobs are kept when the CLI could not
+	// delete them, e.g. because it was killed.
+	kubeExecTTL = 10 * time.Minute
+	// kubeExecStartTimeout bounds pulling the image and scheduling the pod.
+	kubeExecStartTimeout = 5 * time.Minute
+)
+
+// invokeKubeExec runs the invocation in a short-lived Job in the cluster and
+// streams its output, so the controller API does not have to be reachable
+// from where the CLI runs, only the Kubernetes API server.
+func invokeKubeExec(ctx context.Context, cfg *InvokeCfg, task string) {
+	if cfg.Agent == "" {
+		fmt.Fprintln(os.Stderr, "Agent is required")
+		return
+	}
+	// The Job spec is readable by anyone who can read Jobs in the namespace.
+	if cfg.Token != "" {
+		fmt.Fprintln(os.Stderr, "--token is not supported with --kube-exec")
+		return
+	}
+
+	restConfig, err := config.GetConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error getting kubernetes config: %v\n", err)
+		return
+	}
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error creating kubernetes clientset: %v\n", err)
+		return
+	}
+
+	job, err := clientset.BatchV1().Jobs(cfg.Config.Namespace).Create(ctx, kubeExecJob(cfg, task), metav1.CreateOptions{})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error creating invocation job: %v\n", err)
+		return
+	}
+	defer func() {
+		// Clean up even when the invocation was interrupted.
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 30*time.Second)
+		defer cancel()
+		err := clientset.BatchV1().Jobs(job.Namespace).Delete(ctx, job.Name, metav1.DeleteOptions{
+			PropagationPolicy: ptr.To(metav1.DeletePropagationBackground),
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error deleting invocation job %s: %v\n", job.Name, err)
+		}
+	}()
+	if cfg.Config.Verbose {
+		fmt.Fprintf(os.Stderr, "Created invocation job %s/%s\n", job.Namespace, job.Name)
+	}
+
+	pod, err := waitForKubeExecPod(ctx, clientset, job)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error starting invocation job: %v\n", err)
+		return
+	}
+	logs, err := clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+		Container: kubeExecContainerName,
+		Follow:    true,
+	}).Stream(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error streaming invocation logs: %v\n", err)
+		return
+	}
+	defer logs.Close()
+	if _, err := io.Copy(os.Stdout, logs); err != nil {
+		fmt.Fprintf(os.Stderr, "Error streaming invocation logs: %v\n", err)
+	}
+}
+
+// kubeExecJob returns the Job that runs the invocation of cfg with the CLI
+// image against the controller service of the namespace.
+func kubeExecJob(cfg *InvokeCfg, task string) *batchv1.Job {
+	namespace := cfg.Config.Namespace
+	args := []string{
+		"invoke",
+		"--agent", cfg.Agent,
+		"--task", task,
+		"--namespace", namespace,
+		"--kagent-url", fmt.Sprintf("http://kagent-controller.%s.svc:8083", namespace),
+		"--user-id", cfg.Config.GetUserID(),
+	}
+	if cfg.Config.Timeout > 0 {
+		args = append(args, "--timeout", cfg.Config.Timeout.String())
+	}
+	if cfg.Config.A2ATransport != "" {
+		args = append(args, "--a2a-transport", cfg.Config.A2ATransport)
+	}
+	if cfg.Config.Verbose {
+		args = append(args, "--verbose")
+	}
+	if cfg.Session != "" {
+		args = append(args, "--session", cfg.Session)
+	}
+	if cfg.Stream {
+		args = append(args, "--stream")
+	}
+	if cfg.DryRun {
+		args = append(args, "--dry-run")
+	}
+	if cfg.MaxTokens > 0 {
+		args = append(args, "--max-tokens", strconv.FormatInt(cfg.MaxTokens, 10))
+	}
+	if cfg.MaxToolCalls > 0 {
+		args = append(args, "--max-tool-calls", strconv.Itoa(cfg.MaxToolCalls))
+	}
+	if cfg.MaxTurns > 0 {
+		args = append(args, "--max-turns", strconv.Itoa(cfg.MaxTurns))
+	}
+	if cfg.Async {
+		args = append(args, "--async")
+	}
+	if cfg.CallbackURL != "" {
+		args = append(args, "--callback-url", cfg.CallbackURL)
+	}
+
+	image := cfg.KubeExecImage
+	if image == "" {
+		image = DefaultKubeExecImage + ":" + version.Version
+	}
+	labels := map[string]string{
+		"app.kubernetes.io/name":       "kagent-invoke",
+		"app.kubernetes.io/managed-by": "kagent-cli",
+		"kagent.dev/agent":             cfg.Agent,
+	}
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kagent-invoke-" + rand.String(5),
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:            ptr.To[int32](0),
+			TTLSecondsAfterFinished: ptr.To(int32(kubeExecTTL.Seconds())),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					// The invocation only talks to the controller.
+					AutomountServiceAccountToken: ptr.To(false),
+					SecurityContext: &corev1.PodSecurityContext{
+						RunAsNonRoot: ptr.To(true),
+						SeccompProfile: &corev1.SeccompProfile{
+							Type: corev1.SeccompProfileTypeRuntimeDefault,
+						},
+					},
+					Containers: []corev1.Container{{
+						Name:  kubeExecContainerName,
+						Image: image,
+						Args:  args,
+						SecurityContext: &corev1.SecurityContext{
+							AllowPrivilegeEscalation: ptr.To(false),
+							ReadOnlyRootFilesystem:   ptr.To(true),
+							Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+						},
+					}},
+				},
+			},
+		},
+	}
+}
+
+// waitForKubeExecPod waits until the pod of job has started, so its logs can
+// be followed. Pods that cannot start, e.g. because their image cannot be
+// pulled, fail the wait instead of leaving it to time out.
+func waitForKubeExecPod(ctx context.Context, clientset kubernetes.Interface, job *batchv1.Job) (*corev1.Pod, error) {
+	var started *corev1.Pod
+	err := wait.PollUntilContextTimeout(ctx, time.Second, kubeExecStartTimeout, true, func(ctx context.Context) (bool, error) {
+		pods, err := clientset.CoreV1().Pods(job.Namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: batchv1.JobNameLabel + "=" + job.Name,
+		})
+		if err != nil {
+			return false, err
+		}
+		for i := range pods.Items {
+			pod := &pods.Items[i]
+			if pod.Status.Phase != corev1.PodPending {
+				started = pod
+				return true, nil
+			}
+			for _, status := range pod.Status.ContainerStatuses {
+				if waiting := status.State.Waiting; waiting != nil {
+					switch waiting.Reason {
+					case "ErrImagePull", "ImagePullBackOff", "InvalidImageName", "CreateContainerConfigError":
+						return false, fmt.Errorf("pod %s cannot start: %s: %s", pod.Name, waiting.Reason, waiting.Message)
+					}
+				}
+			}
+		}
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return started, nil
+}
@@ -1,7 +1,22 @@
 package cli
 
 import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/kagent-dev/kagent/go/core/cli/internal/config"
+	"github.com/kagent-dev/kagent/go/core/internal/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
 )
 
 // Note: Most InvokeCmd tests require K8s port-forwarding mock which is complex.
@@ -15,3 +30,94 @@ func TestInvokeCmd_ServerError(t *testing.T) {
 	// real kubectl port-forward, which is not appropriate for unit tests.
 	t.Skip("Skipping InvokeCmd server error test in unit suite; covered by integration tests without requiring kubectl/port-forwarding")
 }
+
+func TestKubeExecJob(t *testing.T) {
+	cfg := &InvokeCfg{
+		Config:   &config.Config{Namespace: "team-a", UserID: "ci@example.com", Timeout: 5 * time.Minute},
+		Agent:    "k8s-agent",
+		Session:  "session-1",
+		Stream:   true,
+		MaxTurns: 10,
+	}
+	job := kubeExecJob(cfg, "Get all the pods")
+
+	if job.Namespace != "team-a" || !strings.HasPrefix(job.Name, "kagent-invoke-") {
+		t.Errorf("job = %s/%s, want a kagent-invoke job in team-a", job.Namespace, job.Name)
+	}
+	if *job.Spec.BackoffLimit != 0 {
+		t.Errorf("backoffLimit = %d, want 0 so failed invocations are not retried", *job.Spec.BackoffLimit)
+	}
+	container := job.Spec.Template.Spec.Containers[0]
+	if container.Image != DefaultKubeExecImage+":"+version.Version {
+		t.Errorf("image = %q, want the CLI image of this version", container.Image)
+	}
+	want := []string{
+		"invoke",
+		"--agent", "k8s-agent",
+		"--task", "Get all the pods",
+		"--namespace", "team-a",
+		"--kagent-url", "http://kagent-controller.team-a.svc:8083",
+		"--user-id", "ci@example.com",
+		"--timeout", "5m0s",
+		"--session", "session-1",
+		"--stream",
+		"--max-turns", "10",
+	}
+	if !slices.Equal(container.Args, want) {
+		t.Errorf("args = %q, want %q", container.Args, want)
+	}
+
+	cfg.KubeExecImage = "registry.internal/kagent/cli:v1"
+	if got := kubeExecJob(cfg, "task").Spec.Template.Spec.Containers[0].Image; got != cfg.KubeExecImage {
+		t.Errorf("image = %q, want the --kube-exec-image override", got)
+	}
+}
+
+func TestWaitForKubeExecPod(t *testing.T) {
+	job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Namespace: "kagent", Name: "kagent-invoke-abcde"}}
+	pod := func(phase corev1.PodPhase, waitingReason string) *corev1.Pod {
+		p := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "kagent",
+				Name:      "kagent-invoke-abcde-xyz",
+				Labels:    map[string]string{batchv1.JobNameLabel: job.Name},
+			},
+			Status: corev1.PodStatus{Phase: phase},
+		}
+		if waitingReason != "" {
+			p.Status.ContainerStatuses = []corev1.ContainerStatus{{
+				State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: waitingReason}},
+			}}
+		}
+		return p
+	}
+
+	got, err := waitForKubeExecPod(context.Background(), fake.NewClientset(pod(corev1.PodSucceeded, "")), job)
+	if err != nil || got.Name != "kagent-invoke-abcde-xyz" {
+		t.Errorf("waitForKubeExecPod() = %v, %v, want the finished pod", got, err)
+	}
+
+	_, err = waitForKubeExecPod(context.Background(), fake.NewClientset(pod(corev1.PodPending, "ImagePullBackOff")), job)
+	if err == nil || !strings.Contains(err.Error(), "ImagePullBackOff") {
+		t.Errorf("waitForKubeExecPod() error = %v, want the image pull failure", err)
+	}
+}
+
+func TestReadAttachments(t *testing.T) {
+	dir := t.TempDir()
+	logPath := filepath.Join(dir, "pod.log")
+	require.NoError(t, os.WriteFile(logPath, []byte("OOMKilled"), 0o600))
+	pngPath := filepath.Join(dir, "dashboard")
+	require.NoError(t, os.WriteFile(pngPath, []byte("\x89PNG\r\n\x1a\n"), 0o600))
+
+	attachments, err := readAttachments([]string{logPath, pngPath})
+	require.NoError(t, err)
+	require.Len(t, attachments, 2)
+	assert.Equal(t, "pod.log", attachments[0].Name)
+	assert.Equal(t, "OOMKilled", string(attachments[0].Data))
+	assert.Equal(t, "dashboard", attachments[1].Name)
+	assert.Equal(t, "image/png", attachments[1].MediaType)
+
+	_, err = readAttachments([]string{filepath.Join(dir, "missing.png")})
+	assert.Error(t, err)
+}
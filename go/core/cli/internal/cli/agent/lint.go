@@ -0,0 +1,92 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	"github.com/kagent-dev/kagent/go/core/cli/internal/config"
+	"github.com/kagent-dev/kagent/go/core/internal/agentlint"
+	"sigs.k8s.io/yaml"
+)
+
+type LintCfg struct {
+	Config *config.Config
+	// File is the Agent manifest to lint, or "-" for stdin.
+	File string
+	// FailOn is the lowest severity that makes the command fail.
+	FailOn string
+}
+
+// LintCmd sends an Agent manifest to the kagent server for linting, prints the
+// findings and returns an error when any finding is at or above FailOn so CI
+// jobs can gate on the exit code.
+func LintCmd(ctx context.Context, cfg *LintCfg) error {
+	failOn := agentlint.Severity(cfg.FailOn)
+	if failOn.Rank() > agentlint.SeverityInfo.Rank() {
+		return fmt.Errorf("invalid --fail-on %q: must be one of error, warning, info", cfg.FailOn)
+	}
+
+	agent, err := readAgentManifest(cfg.File)
+	if err != nil {
+		return err
+	}
+
+	resp, err := cfg.Config.Client().Lint.LintAgent(ctx, agent)
+	if err != nil {
+		return fmt.Errorf("failed to lint agent: %w", err)
+	}
+
+	findings := resp.Data.Findings
+	if len(findings) == 0 && isTableOutput() {
+		fmt.Println("No findings")
+	} else {
+		rows := make([][]string, len(findings))
+		for i, f := range findings {
+			rows[i] = []string{f.Severity, f.RuleID, f.Field, f.Message}
+		}
+		if err := printOutput(resp.Data, []string{"SEVERITY", "RULE", "FIELD", "MESSAGE"}, rows); err != nil {
+			return err
+		}
+	}
+
+	failing := 0
+	for _, f := range findings {
+		if agentlint.Severity(f.Severity).Rank() <= failOn.Rank() {
+			failing++
+		}
+	}
+	if failing > 0 {
+		return fmt.Errorf("%d finding(s) at or above severity %s", failing, failOn)
+	}
+	return nil
+}
+
+func readAgentManifest(path string) (*v1alpha2.Agent, error) {
+	if path == "" {
+		return nil, fmt.Errorf("an agent manifest is required (-f)")
+	}
+	var (
+		data []byte
+		err  error
+	)
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	agent := &v1alpha2.Agent{}
+	if err := yaml.UnmarshalStrict(data, agent); err != nil {
+		return nil, fmt.Errorf("failed to parse agent manifest %s: %w", path, err)
+	}
+	if agent.Kind != "" && agent.Kind != "Agent" {
+		return nil, fmt.Errorf("%s is a %s, not an Agent", path, agent.Kind)
+	}
+	return agent, nil
+}
@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadAgentManifest(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		wantErr  string
+	}{
+		{
+			name: "agent manifest",
+			manifest: `apiVersion: kagent.dev/v1alpha2
+kind: Agent
+metadata:
+  name: k8s-agent
+spec:
+  type: Declarative
+  description: Answers cluster questions
+  declarative:
+    modelConfig: default-model-config
+    systemMessage: You are a helpful agent.
+`,
+		},
+		{
+			name: "wrong kind",
+			manifest: `apiVersion: kagent.dev/v1alpha2
+kind: ModelConfig
+metadata:
+  name: default-model-config
+`,
+			wantErr: "not an Agent",
+		},
+		{
+			name: "unknown field",
+			manifest: `kind: Agent
+spec:
+  declaritive: {}
+`,
+			wantErr: "failed to parse",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "agent.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.manifest), 0600))
+
+			agent, err := readAgentManifest(path)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "k8s-agent", agent.Name)
+			require.Equal(t, "Answers cluster questions", agent.Spec.Description)
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
+	"github.com/kagent-dev/kagent/go/core/cli/internal/config"
+)
+
+type PruneCfg struct {
+	Config *config.Config
+	// DryRun reports what would be deleted without deleting anything.
+	DryRun bool
+	// The limits override the controller's retention policy when set, in Go
+	// duration syntax. Empty keeps the controller's limit.
+	SessionMaxAge          string
+	TaskMaxAge             string
+	EventMaxAge            string
+	PushNotificationMaxAge string
+	StreamEventMaxAge      string
+	// MaxSessions overrides the controller's per user and agent session
+	// limit when set.
+	MaxSessions *int
+}
+
+// PruneCmd asks the kagent server to permanently delete the session history
+// past its retention policy and prints how many rows of each kind went.
+func PruneCmd(ctx context.Context, cfg *PruneCfg) error {
+	resp, err := cfg.Config.Client().Retention.Prune(ctx, &api.PruneRequest{
+		DryRun:                 cfg.DryRun,
+		SessionMaxAge:          cfg.SessionMaxAge,
+		MaxSessions:            cfg.MaxSessions,
+		TaskMaxAge:             cfg.TaskMaxAge,
+		EventMaxAge:            cfg.EventMaxAge,
+		PushNotificationMaxAge: cfg.PushNotificationMaxAge,
+		StreamEventMaxAge:      cfg.StreamEventMaxAge,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to prune history: %w", err)
+	}
+
+	result := resp.Data
+	rows := [][]string{
+		{"sessions", strconv.FormatInt(result.Sessions, 10)},
+		{"events", strconv.FormatInt(result.Events, 10)},
+		{"tasks", strconv.FormatInt(result.Tasks, 10)},
+		{"push notifications", strconv.FormatInt(result.PushNotifications, 10)},
+		{"stream events", strconv.FormatInt(result.StreamEvents, 10)},
+	}
+	if err := printOutput(result, []string{"KIND", "DELETED"}, rows); err != nil {
+		return err
+	}
+	if resp.Error {
+		return errors.New(resp.Message)
+	}
+	if isTableOutput() {
+		fmt.Println(resp.Message)
+	}
+	return nil
+}
@@ -0,0 +1,298 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/kagent-dev/kagent/go/api/database"
+	"github.com/kagent-dev/kagent/go/api/utils"
+	"github.com/kagent-dev/kagent/go/core/cli/internal/config"
+)
+
+// replayMaxFieldLength bounds how much of a message, tool argument or tool
+// result is printed per timeline entry.
+const replayMaxFieldLength = 2000
+
+type ReplaySessionCfg struct {
+	Config    *config.Config
+	SessionID string
+	Speed     float64
+	MaxDelay  time.Duration
+	Task      string
+	From      string
+}
+
+type replayEntryKind string
+
+const (
+	replayMessage    replayEntryKind = "message"
+	replayThought    replayEntryKind = "thought"
+	replayToolCall   replayEntryKind = "tool call"
+	replayToolResult replayEntryKind = "tool result"
+)
+
+// replayEntry is a single line of the replayed timeline.
+type replayEntry struct {
+	At           time.Time
+	InvocationID string
+	Author       string
+	Kind         replayEntryKind
+	Name         string
+	Text         string
+}
+
+// replayEvent decodes the fields of a stored session event needed for replay.
+// The Python runtime stores ADK events with snake_case keys while the Go
+// runtime stores untagged structs with genai camelCase parts, so both spellings
+// are accepted.
+type replayEvent struct {
+	Author         string         `json:"author"`
+	InvocationID   string         `json:"invocation_id"`
+	GoInvocationID string         `json:"InvocationID"`
+	Partial        bool           `json:"partial"`
+	Content        *replayContent `json:"content"`
+}
+
+type replayContent struct {
+	Role  string       `json:"role"`
+	Parts []replayPart `json:"parts"`
+}
+
+type replayPart struct {
+	Text               string              `json:"text"`
+	Thought            bool                `json:"thought"`
+	FunctionCall       *replayFunctionCall `json:"function_call"`
+	GoFunctionCall     *replayFunctionCall `json:"functionCall"`
+	FunctionResponse   *replayFunctionResp `json:"function_response"`
+	GoFunctionResponse *replayFunctionResp `json:"functionResponse"`
+}
+
+type replayFunctionCall struct {
+	Name string          `json:"name"`
+	Args json.RawMessage `json:"args"`
+}
+
+type replayFunctionResp struct {
+	Name     string          `json:"name"`
+	Response json.RawMessage `json:"response"`
+}
+
+// ReplaySessionCmd prints the event timeline of a session, pausing between
+// entries in proportion to the time that originally passed between them.
+func ReplaySessionCmd(ctx context.Context, cfg *ReplaySessionCfg) error {
+	client := cfg.Config.Client()
+
+	resp, err := client.Session.ListSessionEvents(ctx, cfg.SessionID)
+	if err != nil {
+		return fmt.Errorf("failed to get events for session %s: %w", cfg.SessionID, err)
+	}
+	entries, skipped := buildReplayTimeline(resp.Data.Events)
+	if skipped > 0 {
+		fmt.Fprintf(os.Stderr, "Skipped %d events that could not be decoded\n", skipped)
+	}
+	if len(entries) == 0 {
+		fmt.Println("No events to replay")
+		return nil
+	}
+
+	start := 0
+	switch {
+	case cfg.Task != "":
+		invocationID, err := resolveReplayTask(ctx, cfg)
+		if err != nil {
+			return err
+		}
+		if start = replayInvocationIndex(entries, invocationID); start < 0 {
+			return fmt.Errorf("no events found for task %s", cfg.Task)
+		}
+	case cfg.From != "":
+		from, err := parseReplayFrom(cfg.From, entries[0].At)
+		if err != nil {
+			return err
+		}
+		if start = replayTimeIndex(entries, from); start < 0 {
+			return fmt.Errorf("no events at or after %s", from.Format(time.RFC3339))
+		}
+	}
+	if start > 0 {
+		fmt.Fprintf(os.Stderr, "Skipping %d earlier entries\n", start)
+	}
+
+	origin := entries[0].At
+	for i := start; i < len(entries); i++ {
+		if i > start {
+			delay := replayDelay(entries[i].At.Sub(entries[i-1].At), cfg.Speed, cfg.MaxDelay)
+			if err := sleepContext(ctx, delay); err != nil {
+				return nil
+			}
+		}
+		writeReplayEntry(os.Stdout, entries[i], origin)
+	}
+	return nil
+}
+
+// resolveReplayTask maps an A2A task ID to the ADK invocation that produced
+// it. A value that does not match any task is treated as an invocation ID.
+func resolveReplayTask(ctx context.Context, cfg *ReplaySessionCfg) (string, error) {
+	tasks, err := cfg.Config.Client().Session.ListSessionTasks(ctx, cfg.SessionID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get tasks for session %s: %w", cfg.SessionID, err)
+	}
+	for _, task := range tasks.Data {
+		if task.ID != cfg.Task {
+			continue
+		}
+		if v, ok := utils.GetMetadataValue(task.Metadata, "invocation_id"); ok {
+			if id, ok := v.(string); ok && id != "" {
+				return id, nil
+			}
+		}
+		return "", fmt.Errorf("task %s has no invocation ID recorded", cfg.Task)
+	}
+	return cfg.Task, nil
+}
+
+// buildReplayTimeline flattens stored events into timeline entries. Partial
+// (streaming) events are dropped since the final event repeats their content.
+// It also returns the number of events that could not be decoded.
+func buildReplayTimeline(events []*database.Event) ([]replayEntry, int) {
+	var entries []replayEntry
+	skipped := 0
+	for _, event := range events {
+		var data replayEvent
+		if err := json.Unmarshal([]byte(event.Data), &data); err != nil {
+			skipped++
+			continue
+		}
+		if data.Partial || data.Content == nil {
+			continue
+		}
+		invocationID := data.InvocationID
+		if invocationID == "" {
+			invocationID = data.GoInvocationID
+		}
+		author := data.Author
+		if author == "" {
+			author = data.Content.Role
+		}
+		for _, part := range data.Content.Parts {
+			entry := replayEntry{At: event.CreatedAt, InvocationID: invocationID, Author: author}
+			switch {
+			case part.FunctionCall != nil || part.GoFunctionCall != nil:
+				call := part.FunctionCall
+				if call == nil {
+					call = part.GoFunctionCall
+				}
+				entry.Kind, entry.Name, entry.Text = replayToolCall, call.Name, string(call.Args)
+			case part.FunctionResponse != nil || part.GoFunctionResponse != nil:
+				result := part.FunctionResponse
+				if result == nil {
+					result = part.GoFunctionResponse
+				}
+				entry.Kind, entry.Name, entry.Text = replayToolResult, result.Name, string(result.Response)
+			case part.Text != "" && part.Thought:
+				entry.Kind, entry.Text = replayThought, part.Text
+			case part.Text != "":
+				entry.Kind, entry.Text = replayMessage, part.Text
+			default:
+				continue
+			}
+			entries = append(entries, entry)
+		}
+	}
+	return entries, skipped
+}
+
+// parseReplayFrom accepts either an RFC3339 timestamp or a duration offset
+// from the start of the session, e.g. "90s" or "2m30s".
+func parseReplayFrom(from string, sessionStart time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, from); err == nil {
+		return t, nil
+	}
+	offset, err := time.ParseDuration(strings.TrimPrefix(from, "+"))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --from %q: expected an RFC3339 timestamp or a duration offset such as 90s", from)
+	}
+	return sessionStart.Add(offset), nil
+}
+
+// replayInvocationIndex returns the index of the first entry for the given
+// invocation, or -1.
+func replayInvocationIndex(entries []replayEntry, invocationID string) int {
+	for i, e := range entries {
+		if e.InvocationID == invocationID {
+			return i
+		}
+	}
+	return -1
+}
+
+// replayTimeIndex returns the index of the first entry at or after t, or -1.
+func replayTimeIndex(entries []replayEntry, t time.Time) int {
+	for i, e := range entries {
+		if !e.At.Before(t) {
+			return i
+		}
+	}
+	return -1
+}
+
+// replayDelay scales the original gap between two entries by speed and caps
+// it at maxDelay. A speed of 0 or less replays without pausing.
+func replayDelay(gap time.Duration, speed float64, maxDelay time.Duration) time.Duration {
+	if speed <= 0 || gap <= 0 {
+		return 0
+	}
+	delay := time.Duration(float64(gap) / speed)
+	if maxDelay > 0 && delay > maxDelay {
+		delay = maxDelay
+	}
+	return delay
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func writeReplayEntry(w io.Writer, e replayEntry, origin time.Time) {
+	prefix := fmt.Sprintf("[%s +%s] %s", e.At.Local().Format("15:04:05"), formatReplayOffset(e.At.Sub(origin)), e.Author)
+	text := truncateReplayText(e.Text)
+	switch e.Kind {
+	case replayToolCall:
+		fmt.Fprintf(w, "%s: tool call %s(%s)\n", prefix, e.Name, text)
+	case replayToolResult:
+		fmt.Fprintf(w, "%s: tool result %s: %s\n", prefix, e.Name, text)
+	case replayThought:
+		fmt.Fprintf(w, "%s (thinking): %s\n", prefix, text)
+	default:
+		fmt.Fprintf(w, "%s: %s\n", prefix, text)
+	}
+}
+
+func formatReplayOffset(d time.Duration) string {
+	d = d.Round(time.Second)
+	return fmt.Sprintf("%02d:%02d:%02d", int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60)
+}
+
+func truncateReplayText(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) <= replayMaxFieldLength {
+		return s
+	}
+	return s[:replayMaxFieldLength] + fmt.Sprintf("... (%d more bytes)", len(s)-replayMaxFieldLength)
+}
@@ -0,0 +1,121 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kagent-dev/kagent/go/api/database"
+)
+
+func TestBuildReplayTimeline(t *testing.T) {
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	events := []*database.Event{
+		{CreatedAt: base, Data: `{"author":"user","invocation_id":"inv-1","content":{"role":"user","parts":[{"text":"why is my pod crashing?"}]}}`},
+		{CreatedAt: base.Add(time.Second), Data: `{"author":"k8s_agent","invocation_id":"inv-1","partial":true,"content":{"parts":[{"text":"Let me"}]}}`},
+		{CreatedAt: base.Add(2 * time.Second), Data: `{"author":"k8s_agent","invocation_id":"inv-1","content":{"parts":[{"text":"checking","thought":true},{"function_call":{"name":"get_pods","args":{"ns":"default"}}}]}}`},
+		{CreatedAt: base.Add(3 * time.Second), Data: `{"author":"k8s_agent","invocation_id":"inv-1","content":{"parts":[{"function_response":{"name":"get_pods","response":{"result":"ok"}}}]}}`},
+		{CreatedAt: base.Add(4 * time.Second), Data: `{"Author":"go_agent","InvocationID":"inv-2","Content":{"role":"model","parts":[{"functionCall":{"name":"get_logs","args":{}}}]}}`},
+		{CreatedAt: base.Add(5 * time.Second), Data: `not json`},
+	}
+
+	entries, skipped := buildReplayTimeline(events)
+	if skipped != 1 {
+		t.Errorf("skipped = %d, want 1", skipped)
+	}
+
+	want := []replayEntry{
+		{At: base, InvocationID: "inv-1", Author: "user", Kind: replayMessage, Text: "why is my pod crashing?"},
+		{At: base.Add(2 * time.Second), InvocationID: "inv-1", Author: "k8s_agent", Kind: replayThought, Text: "checking"},
+		{At: base.Add(2 * time.Second), InvocationID: "inv-1", Author: "k8s_agent", Kind: replayToolCall, Name: "get_pods", Text: `{"ns":"default"}`},
+		{At: base.Add(3 * time.Second), InvocationID: "inv-1", Author: "k8s_agent", Kind: replayToolResult, Name: "get_pods", Text: `{"result":"ok"}`},
+		{At: base.Add(4 * time.Second), InvocationID: "inv-2", Author: "go_agent", Kind: replayToolCall, Name: "get_logs", Text: `{}`},
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("got %d entries, want %d: %+v", len(entries), len(want), entries)
+	}
+	for i := range want {
+		if entries[i] != want[i] {
+			t.Errorf("entry %d = %+v, want %+v", i, entries[i], want[i])
+		}
+	}
+
+	if got := replayInvocationIndex(entries, "inv-2"); got != 4 {
+		t.Errorf("replayInvocationIndex(inv-2) = %d, want 4", got)
+	}
+	if got := replayInvocationIndex(entries, "missing"); got != -1 {
+		t.Errorf("replayInvocationIndex(missing) = %d, want -1", got)
+	}
+	if got := replayTimeIndex(entries, base.Add(2500*time.Millisecond)); got != 3 {
+		t.Errorf("replayTimeIndex = %d, want 3", got)
+	}
+}
+
+func TestParseReplayFrom(t *testing.T) {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		from    string
+		want    time.Time
+		wantErr bool
+	}{
+		{name: "timestamp", from: "2025-01-01T12:05:00Z", want: start.Add(5 * time.Minute)},
+		{name: "offset", from: "90s", want: start.Add(90 * time.Second)},
+		{name: "offset with plus", from: "+2m", want: start.Add(2 * time.Minute)},
+		{name: "invalid", from: "yesterday", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseReplayFrom(tt.from, start)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseReplayFrom() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !got.Equal(tt.want) {
+				t.Errorf("parseReplayFrom() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplayDelay(t *testing.T) {
+	tests := []struct {
+		name     string
+		gap      time.Duration
+		speed    float64
+		maxDelay time.Duration
+		want     time.Duration
+	}{
+		{name: "real time", gap: 2 * time.Second, speed: 1, want: 2 * time.Second},
+		{name: "double speed", gap: 2 * time.Second, speed: 2, want: time.Second},
+		{name: "capped", gap: time.Minute, speed: 1, maxDelay: 5 * time.Second, want: 5 * time.Second},
+		{name: "instant", gap: time.Minute, speed: 0, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := replayDelay(tt.gap, tt.speed, tt.maxDelay); got != tt.want {
+				t.Errorf("replayDelay() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteReplayEntry(t *testing.T) {
+	origin := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	var buf bytes.Buffer
+	writeReplayEntry(&buf, replayEntry{
+		At:     origin.Add(time.Hour + 2*time.Minute + 3*time.Second),
+		Author: "k8s_agent",
+		Kind:   replayToolCall,
+		Name:   "get_pods",
+		Text:   strings.Repeat("x", replayMaxFieldLength+10),
+	}, origin)
+
+	got := buf.String()
+	if !strings.Contains(got, "+01:02:03] k8s_agent: tool call get_pods(") {
+		t.Errorf("unexpected line prefix: %q", got)
+	}
+	if !strings.HasSuffix(got, "... (10 more bytes))\n") {
+		t.Errorf("expected truncated text, got suffix %q", got[len(got)-30:])
+	}
+}
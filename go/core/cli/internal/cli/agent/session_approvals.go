@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/kagent-dev/kagent/go/api/client"
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
+	"github.com/kagent-dev/kagent/go/core/cli/internal/config"
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
+)
+
+type SessionApprovalsCfg struct {
+	Config    *config.Config
+	SessionID string
+}
+
+// SessionApprovalsCmd prints the tool calls of a session waiting for approval.
+func SessionApprovalsCmd(ctx context.Context, cfg *SessionApprovalsCfg) error {
+	resp, err := cfg.Config.Client().Session.ListSessionApprovals(ctx, cfg.SessionID)
+	if err != nil {
+		return fmt.Errorf("failed to list session approvals: %w", err)
+	}
+	return printApprovals(resp.Data)
+}
+
+func printApprovals(approvals []api.ToolApproval) error {
+	headers := []string{"#", "TASK", "TOOL CALL", "TOOL", "ARGS"}
+	rows := make([][]string, len(approvals))
+	for i, a := range approvals {
+		args, _ := json.Marshal(a.Args)
+		rows[i] = []string{strconv.Itoa(i + 1), a.TaskID, a.ToolCallID, a.ToolName, string(args)}
+	}
+	return printOutput(approvals, headers, rows)
+}
+
+type DecideApprovalsCfg struct {
+	Config    *config.Config
+	SessionID string
+	// Approve approves the pending calls, or rejects them when false.
+	Approve bool
+	// TaskID selects the task to decide on when several are waiting.
+	TaskID string
+	// Calls limits the decision to these tool call IDs; the task's other
+	// pending calls get the opposite decision.
+	Calls  []string
+	Reason string
+}
+
+// DecideApprovalsCmd approves or rejects the tool calls a session's task holds
+// for approval, resuming the task, and prints the task's state afterwards.
+func DecideApprovalsCmd(ctx context.Context, cfg *DecideApprovalsCfg) error {
+	clientSet := cfg.Config.Client()
+	resp, err := clientSet.Session.ListSessionApprovals(ctx, cfg.SessionID)
+	if err != nil {
+		return fmt.Errorf("failed to list session approvals: %w", err)
+	}
+	pending, decision, err := cfg.decision(resp.Data)
+	if err != nil {
+		return err
+	}
+	if pending[0].Agent == "" {
+		return fmt.Errorf("session %s has no agent", cfg.SessionID)
+	}
+
+	a2aOpts, err := cfg.Config.A2AOptions()
+	if err != nil {
+		return fmt.Errorf("failed to create A2A client: %w", err)
+	}
+	a2aClient, err := clientSet.A2A(ctx, pending[0].Agent, append(a2aOpts, client.WithA2ASessionID(cfg.SessionID))...)
+	if err != nil {
+		return fmt.Errorf("failed to create A2A client: %w", err)
+	}
+	defer a2aClient.Close()
+
+	task, err := a2aClient.Decide(ctx, pending[0].TaskID, pending[0].ContextID, decision)
+	if err != nil {
+		return fmt.Errorf("failed to send decision: %w", err)
+	}
+	fmt.Printf("Task %s is %s\n", task.ID, task.Status.State)
+	if task.Status.State == protocol.TaskStateInputRequired {
+		fmt.Printf("The agent is waiting for approval again; run 'kagent session approvals %s' to list the calls.\n", cfg.SessionID)
+	}
+	return nil
+}
+
+// decision picks the task to decide on from the session's pending approvals
+// and returns its approvals together with the decision to send.
+func (c *DecideApprovalsCfg) decision(approvals []api.ToolApproval) ([]api.ToolApproval, client.ToolDecision, error) {
+	var pending []api.ToolApproval
+	for _, a := range approvals {
+		if c.TaskID == "" || a.TaskID == c.TaskID {
+			pending = append(pending, a)
+		}
+	}
+	if len(pending) == 0 {
+		if c.TaskID != "" {
+			return nil, client.ToolDecision{}, fmt.Errorf("task %s has no tool calls waiting for approval", c.TaskID)
+		}
+		return nil, client.ToolDecision{}, fmt.Errorf("session %s has no tool calls waiting for approval", c.SessionID)
+	}
+	for _, a := range pending {
+		if a.TaskID != pending[0].TaskID {
+			return nil, client.ToolDecision{}, fmt.Errorf("several tasks are waiting for approval; select one with --task")
+		}
+	}
+
+	decision := client.ToolDecision{Approve: c.Approve, Reason: c.Reason}
+	if len(c.Calls) == 0 {
+		return pending, decision, nil
+	}
+	decision.Calls = make(map[string]bool, len(pending))
+	for _, a := range pending {
+		decision.Calls[a.ToolCallID] = !c.Approve
+	}
+	for _, id := range c.Calls {
+		if !slices.ContainsFunc(pending, func(a api.ToolApproval) bool { return a.ToolCallID == id }) {
+			return nil, client.ToolDecision{}, fmt.Errorf("tool call %s is not waiting for approval", id)
+		}
+		decision.Calls[id] = c.Approve
+	}
+	return pending, decision, nil
+}
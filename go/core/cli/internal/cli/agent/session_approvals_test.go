@@ -0,0 +1,37 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kagent-dev/kagent/go/api/client"
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
+)
+
+func TestDecideApprovalsCfgDecision(t *testing.T) {
+	approvals := []api.ToolApproval{
+		{TaskID: "task-1", ToolCallID: "call-1"},
+		{TaskID: "task-1", ToolCallID: "call-2"},
+		{TaskID: "task-2", ToolCallID: "call-3"},
+	}
+
+	_, _, err := (&DecideApprovalsCfg{Approve: true}).decision(approvals)
+	assert.ErrorContains(t, err, "select one with --task")
+
+	pending, decision, err := (&DecideApprovalsCfg{Approve: true, TaskID: "task-1"}).decision(approvals)
+	require.NoError(t, err)
+	assert.Len(t, pending, 2)
+	assert.Equal(t, client.ToolDecision{Approve: true}, decision)
+
+	_, decision, err = (&DecideApprovalsCfg{TaskID: "task-1", Calls: []string{"call-2"}, Reason: "too risky"}).decision(approvals)
+	require.NoError(t, err)
+	assert.Equal(t, client.ToolDecision{Calls: map[string]bool{"call-1": true, "call-2": false}, Reason: "too risky"}, decision)
+
+	_, _, err = (&DecideApprovalsCfg{TaskID: "task-1", Calls: []string{"call-3"}}).decision(approvals)
+	assert.ErrorContains(t, err, "call-3 is not waiting for approval")
+
+	_, _, err = (&DecideApprovalsCfg{SessionID: "s1"}).decision(nil)
+	assert.ErrorContains(t, err, "session s1 has no tool calls waiting")
+}
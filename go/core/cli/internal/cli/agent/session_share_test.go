@@ -0,0 +1,32 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kagent-dev/kagent/go/api/database"
+)
+
+func TestShareSessionCfgTargets(t *testing.T) {
+	targets, err := (&ShareSessionCfg{Users: []string{"alice"}, Groups: []string{"sre"}, Access: "invoke"}).targets()
+	require.NoError(t, err)
+	assert.Equal(t, []sessionShareTarget{
+		{Kind: database.SessionPrincipalUser, Principal: "alice"},
+		{Kind: database.SessionPrincipalGroup, Principal: "sre"},
+	}, targets)
+
+	targets, err = (&ShareSessionCfg{Access: "read"}).targets()
+	require.NoError(t, err, "no targets only lists the grants")
+	assert.Empty(t, targets)
+
+	_, err = (&ShareSessionCfg{Revoke: true, Access: "read"}).targets()
+	assert.ErrorContains(t, err, "--revoke requires")
+
+	_, err = (&ShareSessionCfg{Users: []string{"alice"}, Access: "write"}).targets()
+	assert.ErrorContains(t, err, "invalid --access")
+
+	_, err = (&ShareSessionCfg{Groups: []string{""}, Access: "read"}).targets()
+	assert.ErrorContains(t, err, "empty group name")
+}
@@ -0,0 +1,144 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kagent-dev/kagent/go/api/client"
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
+	"github.com/kagent-dev/kagent/go/core/cli/internal/config"
+	"sigs.k8s.io/yaml"
+)
+
+// GetTemplateCmd lists the agent templates of every namespace, or prints one
+// template with its parameters and manifest when ref is set.
+func GetTemplateCmd(ctx context.Context, cfg *config.Config, ref string) error {
+	templates := cfg.Client().Template
+	if ref == "" {
+		resp, err := templates.ListTemplates(ctx, "")
+		if err != nil {
+			return fmt.Errorf("failed to list templates: %w", err)
+		}
+		if len(resp.Data) == 0 {
+			fmt.Println("No templates found")
+			return nil
+		}
+		rows := make([][]string, len(resp.Data))
+		for i, t := range resp.Data {
+			params := make([]string, len(t.Parameters))
+			for j, p := range t.Parameters {
+				params[j] = p.Name
+			}
+			rows[i] = []string{t.Namespace, t.Name, strings.Join(params, ", "), t.Description}
+		}
+		return printOutput(resp.Data, []string{"NAMESPACE", "NAME", "PARAMETERS", "DESCRIPTION"}, rows)
+	}
+
+	namespace, name, err := resolveTemplate(ctx, templates, ref)
+	if err != nil {
+		return err
+	}
+	resp, err := templates.GetTemplate(ctx, namespace, name)
+	if err != nil {
+		return fmt.Errorf("failed to get template %s/%s: %w", namespace, name, err)
+	}
+	rows := make([][]string, len(resp.Data.Parameters))
+	for i, p := range resp.Data.Parameters {
+		rows[i] = []string{p.Name, fmt.Sprint(p.Required), p.Default, p.Description}
+	}
+	if err := printOutput(resp.Data, []string{"PARAMETER", "REQUIRED", "DEFAULT", "DESCRIPTION"}, rows); err != nil {
+		return err
+	}
+	if isTableOutput() {
+		fmt.Println(resp.Data.Template)
+	}
+	return nil
+}
+
+type CreateAgentCfg struct {
+	Config *config.Config
+	Name   string
+	// Template is the template to create the agent from, as namespace/name or
+	// as a name unique across namespaces.
+	Template string
+	// Set holds the template parameters as key=value.
+	Set []string
+	// DryRun prints the rendered agent without creating it.
+	DryRun bool
+}
+
+// CreateAgentCmd creates an agent in the configured namespace from a template.
+func CreateAgentCmd(ctx context.Context, cfg *CreateAgentCfg) error {
+	if cfg.Template == "" {
+		return fmt.Errorf("a template is required (--from-template)")
+	}
+	params, err := parseSetValues(cfg.Set)
+	if err != nil {
+		return err
+	}
+	templates := cfg.Config.Client().Template
+	namespace, name, err := resolveTemplate(ctx, templates, cfg.Template)
+	if err != nil {
+		return err
+	}
+
+	resp, err := templates.CreateAgentFromTemplate(ctx, namespace, name, &api.CreateAgentFromTemplateRequest{
+		Name:       cfg.Name,
+		Namespace:  cfg.Config.Namespace,
+		Parameters: params,
+		DryRun:     cfg.DryRun,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create agent from template %s/%s: %w", namespace, name, err)
+	}
+	if cfg.DryRun {
+		out, err := yaml.Marshal(resp.Data)
+		if err != nil {
+			return fmt.Errorf("failed to encode agent: %w", err)
+		}
+		fmt.Print(string(out))
+		return nil
+	}
+	fmt.Printf("Agent %s/%s created from template %s/%s\n", resp.Data.Namespace, resp.Data.Name, namespace, name)
+	return nil
+}
+
+// resolveTemplate splits a namespace/name reference. A bare name is looked up
+// across namespaces and must match exactly one template.
+func resolveTemplate(ctx context.Context, templates client.Template, ref string) (string, string, error) {
+	if namespace, name, ok := strings.Cut(ref, "/"); ok {
+		return namespace, name, nil
+	}
+	resp, err := templates.ListTemplates(ctx, "")
+	if err != nil {
+		return "", "", fmt.Errorf("failed to list templates: %w", err)
+	}
+	var matches []string
+	for _, t := range resp.Data {
+		if t.Name == ref {
+			matches = append(matches, t.Namespace)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", "", fmt.Errorf("template %s not found", ref)
+	case 1:
+		return matches[0], ref, nil
+	default:
+		return "", "", fmt.Errorf("template %s exists in namespaces %s; use namespace/name", ref, strings.Join(matches, ", "))
+	}
+}
+
+// parseSetValues parses key=value pairs as given to --set.
+func parseSetValues(set []string) (map[string]string, error) {
+	values := make(map[string]string, len(set))
+	for _, kv := range set {
+		key, value, ok := strings.Cut(kv, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid --set %q: must be key=value", kv)
+		}
+		values[key] = value
+	}
+	return values, nil
+}
@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kagent-dev/kagent/go/api/client"
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
+)
+
+func TestParseSetValues(t *testing.T) {
+	values, err := parseSetValues([]string{"model=gpt-4o", "prompt=a=b", "empty="})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"model": "gpt-4o", "prompt": "a=b", "empty": ""}, values)
+
+	_, err = parseSetValues([]string{"model"})
+	assert.ErrorContains(t, err, `invalid --set "model"`)
+}
+
+func TestResolveTemplate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/templates", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(api.NewResponse([]api.AgentTemplate{
+			{Namespace: "kagent", Name: "k8s-sre"},
+			{Namespace: "kagent", Name: "helm"},
+			{Namespace: "team-a", Name: "helm"},
+		}, "", false))
+	}))
+	defer server.Close()
+	templates := client.New(server.URL).Template
+
+	tests := []struct {
+		ref           string
+		wantNamespace string
+		wantName      string
+		wantErr       string
+	}{
+		{ref: "team-b/custom", wantNamespace: "team-b", wantName: "custom"},
+		{ref: "k8s-sre", wantNamespace: "kagent", wantName: "k8s-sre"},
+		{ref: "helm", wantErr: "template helm exists in namespaces kagent, team-a"},
+		{ref: "missing", wantErr: "template missing not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			namespace, name, err := resolveTemplate(context.Background(), templates, tt.ref)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantNamespace, namespace)
+			assert.Equal(t, tt.wantName, name)
+		})
+	}
+}
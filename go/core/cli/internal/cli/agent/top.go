@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
+	"github.com/kagent-dev/kagent/go/core/cli/internal/config"
+	"github.com/spf13/viper"
+)
+
+// clearScreen moves the cursor home and clears the terminal before each
+// refresh of the table.
+const clearScreen = "\033[H\033[2J"
+
+type TopCfg struct {
+	Config *config.Config
+	// Window is how far back the stats reach, in Go duration syntax. Empty
+	// uses the server's default of 5m.
+	Window string
+	// Interval is how often the table is refreshed.
+	Interval time.Duration
+	// Once prints the stats a single time instead of refreshing them.
+	Once bool
+}
+
+var topHeaders = []string{"AGENT", "SESSIONS", "IN-FLIGHT", "TOKENS/MIN", "AVG LATENCY", "ERROR RATE", "FINISHED"}
+
+// TopCmd shows the live activity of each agent. Table output is refreshed
+// every Interval until ctx is done; other output formats, and --once, print
+// the stats once.
+func TopCmd(ctx context.Context, cfg *TopCfg) error {
+	if cfg.Once || !isTableOutput() || cfg.Interval <= 0 {
+		stats, err := getTopStats(ctx, cfg)
+		if err != nil {
+			return err
+		}
+		return printTop(os.Stdout, stats)
+	}
+
+	ticker := time.NewTicker(cfg.Interval)
+	defer ticker.Stop()
+	for {
+		// Render into a buffer first, so the screen is only cleared once the
+		// next frame is ready.
+		var frame bytes.Buffer
+		stats, err := getTopStats(ctx, cfg)
+		if err != nil {
+			// Keep refreshing: the server may only be restarting.
+			fmt.Fprintf(&frame, "Error: %v\n", err)
+		} else {
+			fmt.Fprintf(&frame, "Agent activity over the last %s, refreshed %s (Ctrl-C to quit)\n\n", stats.Window, time.Now().Format(time.TimeOnly))
+			if err := printTop(&frame, stats); err != nil {
+				return err
+			}
+		}
+		fmt.Print(clearScreen + frame.String())
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func getTopStats(ctx context.Context, cfg *TopCfg) (*api.StatsResponse, error) {
+	resp, err := cfg.Config.Client().Stats.GetStats(ctx, cfg.Window)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stats: %w", err)
+	}
+	return &resp.Data, nil
+}
+
+func printTop(w io.Writer, stats *api.StatsResponse) error {
+	format := OutputFormat(viper.GetString("output_format"))
+	return renderOutput(w, format, viper.GetStringSlice("output_columns"), stats, topHeaders, topRows(stats), len(topHeaders)-1)
+}
+
+// topRows formats stats as table rows. Latency and error rate are "-" for
+// agents without finished tasks.
+func topRows(stats *api.StatsResponse) [][]string {
+	rows := make([][]string, 0, len(stats.Agents))
+	for _, a := range stats.Agents {
+		latency, errorRate := "-", "-"
+		if a.FinishedTasks > 0 {
+			latency = (time.Duration(a.AvgLatencyMs) * time.Millisecond).String()
+			errorRate = fmt.Sprintf("%.1f%%", a.ErrorRate*100)
+		}
+		rows = append(rows, []string{
+			a.Agent,
+			strconv.FormatInt(a.ActiveSessions, 10),
+			strconv.FormatInt(a.InFlightTasks, 10),
+			fmt.Sprintf("%.0f", a.TokensPerMinute),
+			latency,
+			errorRate,
+			strconv.FormatInt(a.FinishedTasks, 10),
+		})
+	}
+	return rows
+}
@@ -0,0 +1,28 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
+)
+
+func TestTopRows(t *testing.T) {
+	rows := topRows(&api.StatsResponse{Window: "5m0s", Agents: []api.AgentStats{
+		{Agent: "kagent/idle-agent", ActiveSessions: 1},
+		{
+			Agent:           "kagent/k8s-agent",
+			ActiveSessions:  3,
+			InFlightTasks:   1,
+			FinishedTasks:   4,
+			TokensPerMinute: 1234.4,
+			AvgLatencyMs:    2500,
+			ErrorRate:       0.25,
+		},
+	}})
+	assert.Equal(t, [][]string{
+		{"kagent/idle-agent", "1", "0", "0", "-", "-", "0"},
+		{"kagent/k8s-agent", "3", "1", "1234", "2.5s", "25.0%", "4"},
+	}, rows)
+}
@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kagent-dev/kagent/go/core/cli/internal/config"
+)
+
+type ValidateCfg struct {
+	Config *config.Config
+	// File is the Agent manifest to validate, or "-" for stdin.
+	File string
+}
+
+// ValidateCmd sends an Agent manifest to the kagent server for pre-flight
+// validation, prints the errors and warnings and returns an error when the
+// agent is invalid so CI jobs can gate on the exit code.
+func ValidateCmd(ctx context.Context, cfg *ValidateCfg) error {
+	agent, err := readAgentManifest(cfg.File)
+	if err != nil {
+		return err
+	}
+
+	resp, err := cfg.Config.Client().Agent.ValidateAgent(ctx, agent)
+	if err != nil {
+		return fmt.Errorf("failed to validate agent: %w", err)
+	}
+
+	result := resp.Data
+	if len(result.Errors) == 0 && len(result.Warnings) == 0 && isTableOutput() {
+		fmt.Println("Agent configuration is valid")
+	} else {
+		var rows [][]string
+		for _, issue := range result.Errors {
+			rows = append(rows, []string{"error", issue.Stage, issue.RuleID, issue.Field, issue.Message})
+		}
+		for _, issue := range result.Warnings {
+			rows = append(rows, []string{"warning", issue.Stage, issue.RuleID, issue.Field, issue.Message})
+		}
+		if err := printOutput(result, []string{"SEVERITY", "STAGE", "RULE", "FIELD", "MESSAGE"}, rows); err != nil {
+			return err
+		}
+	}
+
+	if !result.Valid {
+		return fmt.Errorf("agent configuration is invalid: %d error(s)", len(result.Errors))
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/kagent-dev/kagent/go/api/client"
+	"github.com/kagent-dev/kagent/go/core/cli/internal/config"
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
+)
+
+type WaitTaskCfg struct {
+	Config   *config.Config
+	TaskID   string
+	Interval time.Duration
+	Timeout  time.Duration
+}
+
+// WaitTaskCmd waits for a task to finish or stop for user input, then prints
+// it. It fails unless the task completed.
+func WaitTaskCmd(ctx context.Context, cfg *WaitTaskCfg) error {
+	if cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.Timeout)
+		defer cancel()
+	}
+
+	task, err := waitForTask(ctx, cfg.Config.Client().Task, cfg.TaskID, cfg.Interval)
+	if err != nil {
+		return err
+	}
+	jsn, err := json.Marshal(task)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task: %w", err)
+	}
+	fmt.Fprintln(os.Stdout, string(jsn))
+	if task.Status.State != protocol.TaskStateCompleted {
+		return fmt.Errorf("task %s is %s", cfg.TaskID, task.Status.State)
+	}
+	return nil
+}
+
+// waitForTask polls a task every interval until it settles or ctx is done.
+func waitForTask(ctx context.Context, tasks client.Task, taskID string, interval time.Duration) (*protocol.Task, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		resp, err := tasks.GetTask(ctx, taskID)
+		switch {
+		case client.IsNotFound(err):
+			// The agent persists the task shortly after an async invocation
+			// returns its ID.
+		case err != nil:
+			// A request cut short by ctx is reported as a timeout below.
+			if ctx.Err() == nil {
+				return nil, fmt.Errorf("failed to get task %s: %w", taskID, err)
+			}
+		case taskSettled(resp.Data.Status.State):
+			return resp.Data, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("timed out waiting for task %s: %w", taskID, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// taskSettled reports whether a task in state will not progress without the
+// user: it finished, or it waits for input or authorization.
+func taskSettled(state protocol.TaskState) bool {
+	switch state {
+	case protocol.TaskStateCompleted, protocol.TaskStateFailed, protocol.TaskStateCanceled, protocol.TaskStateRejected,
+		protocol.TaskStateInputRequired, protocol.TaskStateAuthRequired:
+		return true
+	}
+	return false
+}
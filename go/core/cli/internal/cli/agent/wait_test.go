@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
+
+	"github.com/kagent-dev/kagent/go/api/client"
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
+)
+
+func TestWaitForTask(t *testing.T) {
+	states := []protocol.TaskState{"", protocol.TaskStateSubmitted, protocol.TaskStateWorking, protocol.TaskStateCompleted}
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/tasks/task-1", r.URL.Path)
+		state := states[min(int(calls.Add(1))-1, len(states)-1)]
+		if state == "" {
+			// Not persisted by the agent yet.
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(api.NewResponse[any](nil, "Task not found", true))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(api.NewResponse(&protocol.Task{ID: "task-1", Status: protocol.TaskStatus{State: state}}, "", false))
+	}))
+	defer server.Close()
+	tasks := client.New(server.URL, client.WithUserID("test-user")).Task
+
+	task, err := waitForTask(context.Background(), tasks, "task-1", time.Millisecond)
+	require.NoError(t, err)
+	assert.Equal(t, protocol.TaskStateCompleted, task.Status.State)
+	assert.Equal(t, int32(len(states)), calls.Load())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	calls.Store(1)
+	states = []protocol.TaskState{protocol.TaskStateWorking}
+	_, err = waitForTask(ctx, tasks, "task-1", time.Millisecond)
+	assert.ErrorContains(t, err, "timed out waiting for task task-1")
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"os"
 	"strings"
+
+	"golang.org/x/term"
 )
 
 // PromptForInput displays a prompt and reads user input from stdin.
@@ -76,3 +78,19 @@ func PromptForSelection(promptText string, options []string) (int, error) {
 		return selection - 1, nil
 	}
 }
+
+// PromptForSecret displays a prompt and reads a secret from the terminal
+// without echoing it. Input that is not a terminal is read as a line.
+func PromptForSecret(promptText string) (string, error) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return PromptForInput(promptText)
+	}
+	fmt.Print(promptText)
+	secret, err := term.ReadPassword(fd)
+	fmt.Println()
+	if err != nil {
+		return "", fmt.Errorf("failed to read input: %w", err)
+	}
+	return strings.TrimSpace(string(secret)), nil
+}
@@ -12,16 +12,64 @@ import (
 	"github.com/spf13/viper"
 )
 
+// DefaultUserID is the user the CLI acts as when no user ID is configured.
+const DefaultUserID = "admin@kagent.dev"
+
+// A2A transports for streaming agent invocations
+const (
+	A2ATransportSSE       = "sse"
+	A2ATransportWebSocket = "websocket"
+)
+
 type Config struct {
 	KAgentURL    string        `mapstructure:"kagent_url"`
 	Namespace    string        `mapstructure:"namespace"`
 	OutputFormat string        `mapstructure:"output_format"`
 	Verbose      bool          `mapstructure:"verbose"`
 	Timeout      time.Duration `mapstructure:"timeout"`
+	// A2ATransport selects how streamed agent responses are received: "sse"
+	// (default) or "websocket" for networks whose proxies break SSE.
+	A2ATransport string `mapstructure:"a2a_transport"`
+	// A2AURL is the base URL of the agents' A2A endpoints. Defaults to
+	// KAgentURL.
+	A2AURL string `mapstructure:"a2a_url"`
+	UserID string `mapstructure:"user_id"`
+	// Context is the name of the CLI context in use, if any.
+	Context string `mapstructure:"-"`
 }
 
 func (c *Config) Client() *kagentclient.ClientSet {
-	return kagentclient.New(c.KAgentURL, kagentclient.WithUserID("admin@kagent.dev"))
+	return kagentclient.New(c.KAgentURL, kagentclient.WithUserID(c.GetUserID()), kagentclient.WithA2AURL(c.A2AURL))
+}
+
+// GetUserID returns the user the CLI acts as.
+func (c *Config) GetUserID() string {
+	if c.UserID == "" {
+		return DefaultUserID
+	}
+	return c.UserID
+}
+
+// A2ABaseURL returns the base URL of the agents' A2A endpoints.
+func (c *Config) A2ABaseURL() string {
+	if c.A2AURL != "" {
+		return c.A2AURL
+	}
+	return c.KAgentURL
+}
+
+// A2AOptions returns the A2A client options for the configured timeout and
+// transport
+func (c *Config) A2AOptions() ([]kagentclient.A2AOption, error) {
+	opts := []kagentclient.A2AOption{kagentclient.WithA2ATimeout(c.Timeout)}
+	switch c.A2ATransport {
+	case "", A2ATransportSSE:
+	case A2ATransportWebSocket:
+		opts = append(opts, kagentclient.WithA2AWebSocket())
+	default:
+		return nil, fmt.Errorf("unknown a2a transport %q: expected %s or %s", c.A2ATransport, A2ATransportSSE, A2ATransportWebSocket)
+	}
+	return opts, nil
 }
 
 func Init() error {
@@ -47,6 +95,8 @@ func Init() error {
 	viper.SetDefault("output_format", "table")
 	viper.SetDefault("namespace", "kagent")
 	viper.SetDefault("timeout", 300*time.Second)
+	viper.SetDefault("a2a_transport", A2ATransportSSE)
+	viper.SetDefault("user_id", DefaultUserID)
 	viper.MustBindEnv("USER_ID")
 
 	if err := viper.ReadInConfig(); err != nil {
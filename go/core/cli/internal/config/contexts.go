@@ -0,0 +1,134 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/kagent-dev/kagent/go/core/pkg/env"
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/yaml"
+)
+
+// Context is a named set of connection settings, selected like a kubectl
+// context. Empty fields fall back to the config file and defaults.
+type Context struct {
+	KAgentURL string `json:"kagent-url,omitempty"`
+	// A2AURL is the base URL of the agents' A2A endpoints, for installs that
+	// expose them apart from the API server.
+	A2AURL    string `json:"a2a-url,omitempty"`
+	UserID    string `json:"user-id,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// Contexts is the CLI contexts file, ~/.config/kagent/config.yaml.
+type Contexts struct {
+	CurrentContext string             `json:"current-context,omitempty"`
+	Contexts       map[string]Context `json:"contexts,omitempty"`
+}
+
+// ContextsPath returns the path of the contexts file, creating its directory
+// if needed.
+func ContextsPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("error getting user home directory: %w", err)
+	}
+	configDir, err := GetConfigDir(home)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "config.yaml"), nil
+}
+
+// LoadContexts reads the contexts file at path. A missing file holds no
+// contexts.
+func LoadContexts(path string) (*Contexts, error) {
+	contexts := &Contexts{}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return contexts, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading contexts file: %w", err)
+	}
+	if err := yaml.Unmarshal(data, contexts); err != nil {
+		return nil, fmt.Errorf("error parsing contexts file %s: %w", path, err)
+	}
+	return contexts, nil
+}
+
+// Save writes the contexts file to path.
+func (c *Contexts) Save(path string) error {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("error encoding contexts: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("error writing contexts file: %w", err)
+	}
+	return nil
+}
+
+// Names returns the context names, sorted.
+func (c *Contexts) Names() []string {
+	names := make([]string, 0, len(c.Contexts))
+	for name := range c.Contexts {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Resolve returns the name of the context to use: name, or
+// KAGENT_CLI_CONTEXT, or the current context.
+func (c *Contexts) Resolve(name string) string {
+	if name == "" {
+		name = env.KagentCLIContext.Get()
+	}
+	if name == "" {
+		name = c.CurrentContext
+	}
+	return name
+}
+
+// Apply layers a context and the KAGENT_CLI_* environment variables over the
+// settings read from the config file. Settings whose flag is set on the
+// command line are kept. The context is chosen by Resolve; none is used when
+// it resolves to an empty name.
+func (c *Config) Apply(contexts *Contexts, name string, flags *pflag.FlagSet) error {
+	name = contexts.Resolve(name)
+	var selected Context
+	if name != "" {
+		var ok bool
+		if selected, ok = contexts.Contexts[name]; !ok {
+			return fmt.Errorf("context %q not found; available contexts: %s", name, strings.Join(contexts.Names(), ", "))
+		}
+	}
+	c.Context = name
+
+	settings := []struct {
+		flag    string
+		field   *string
+		context string
+		env     env.StringVar
+	}{
+		{"kagent-url", &c.KAgentURL, selected.KAgentURL, env.KagentCLIURL},
+		{"a2a-url", &c.A2AURL, selected.A2AURL, env.KagentCLIA2AURL},
+		{"user-id", &c.UserID, selected.UserID, env.KagentCLIUserID},
+		{"namespace", &c.Namespace, selected.Namespace, env.KagentCLINamespace},
+	}
+	for _, s := range settings {
+		if flags != nil && flags.Changed(s.flag) {
+			continue
+		}
+		if value, ok := s.env.Lookup(); ok && value != "" {
+			*s.field = value
+		} else if s.context != "" {
+			*s.field = s.context
+		}
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package config
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/pflag"
+)
+
+func TestContextsSaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+
+	empty, err := LoadContexts(path)
+	if err != nil {
+		t.Fatalf("LoadContexts() on a missing file error = %v", err)
+	}
+	if empty.CurrentContext != "" || len(empty.Contexts) != 0 {
+		t.Fatalf("LoadContexts() on a missing file = %+v, want empty", empty)
+	}
+
+	want := &Contexts{
+		CurrentContext: "prod",
+		Contexts: map[string]Context{
+			"prod":  {KAgentURL: "https://kagent.example.com", UserID: "alice@example.com"},
+			"local": {Namespace: "dev"},
+		},
+	}
+	if err := want.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	got, err := LoadContexts(path)
+	if err != nil {
+		t.Fatalf("LoadContexts() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LoadContexts() = %+v, want %+v", got, want)
+	}
+	if names := got.Names(); !reflect.DeepEqual(names, []string{"local", "prod"}) {
+		t.Errorf("Names() = %v", names)
+	}
+}
+
+func TestConfigApply(t *testing.T) {
+	contexts := &Contexts{
+		CurrentContext: "prod",
+		Contexts: map[string]Context{
+			"prod":    {KAgentURL: "https://prod.example.com", UserID: "alice@example.com", Namespace: "team-a"},
+			"staging": {KAgentURL: "https://staging.example.com", A2AURL: "https://a2a.staging.example.com"},
+		},
+	}
+	fileConfig := func() *Config {
+		return &Config{KAgentURL: "http://localhost:8083", UserID: DefaultUserID, Namespace: "kagent"}
+	}
+
+	tests := []struct {
+		name    string
+		context string
+		env     map[string]string
+		flags   []string
+		want    Config
+		wantErr string
+	}{
+		{
+			name: "current context over the config file",
+			want: Config{Context: "prod", KAgentURL: "https://prod.example.com", UserID: "alice@example.com", Namespace: "team-a"},
+		},
+		{
+			name:    "selected context",
+			context: "staging",
+			want:    Config{Context: "staging", KAgentURL: "https://staging.example.com", A2AURL: "https://a2a.staging.example.com", UserID: DefaultUserID, Namespace: "kagent"},
+		},
+		{
+			name: "context from the environment",
+			env:  map[string]string{"KAGENT_CLI_CONTEXT": "staging"},
+			want: Config{Context: "staging", KAgentURL: "https://staging.example.com", A2AURL: "https://a2a.staging.example.com", UserID: DefaultUserID, Namespace: "kagent"},
+		},
+		{
+			name:  "environment over context, flags over environment",
+			env:   map[string]string{"KAGENT_CLI_USER_ID": "bob@example.com", "KAGENT_CLI_NAMESPACE": "env-ns"},
+			flags: []string{"--namespace", "flag-ns"},
+			want:  Config{Context: "prod", KAgentURL: "https://prod.example.com", UserID: "bob@example.com", Namespace: "flag-ns"},
+		},
+		{
+			name:    "unknown context",
+			context: "missing",
+			wantErr: `context "missing" not found; available contexts: prod, staging`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{"KAGENT_CLI_CONTEXT", "KAGENT_CLI_URL", "KAGENT_CLI_A2A_URL", "KAGENT_CLI_USER_ID", "KAGENT_CLI_NAMESPACE"} {
+				t.Setenv(name, tt.env[name])
+			}
+			cfg := fileConfig()
+			flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			flags.StringVar(&cfg.Namespace, "namespace", cfg.Namespace, "")
+			if err := flags.Parse(tt.flags); err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			err := cfg.Apply(contexts, tt.context, flags)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Apply() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+			if *cfg != tt.want {
+				t.Errorf("Apply() = %+v, want %+v", *cfg, tt.want)
+			}
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
 	"github.com/kagent-dev/kagent/go/api/utils"
 	"github.com/kagent-dev/kagent/go/core/cli/internal/tui/theme"
 	"github.com/muesli/reflow/wordwrap"
@@ -21,6 +22,10 @@ import (
 // SendMessageFn abstracts the A2A client's StreamMessage method for easier testing.
 type SendMessageFn func(ctx context.Context, params protocol.SendMessageParams) (<-chan protocol.StreamingMessageEvent, error)
 
+// ForkSessionFn forks a session at eventID, or after its latest event when
+// eventID is empty, and returns the new session.
+type ForkSessionFn func(ctx context.Context, sessionID, eventID string) (*api.ForkSessionResponse, error)
+
 // RunChat starts the TUI chat, blocking until the user exits.
 func RunChat(agentRef string, sessionID string, sendFn SendMessageFn, verbose bool) error {
 	model := newChatModel(agentRef, sessionID, sendFn, verbose)
@@ -35,6 +40,11 @@ type a2aEventMsg struct {
 
 type streamDoneMsg struct{}
 
+type sessionForkedMsg struct {
+	fork *api.ForkSessionResponse
+	err  error
+}
+
 type toolCall struct {
 	Name string `json:"name"`
 	ID   string `json:"id"`
@@ -63,6 +73,7 @@ type chatModel struct {
 	spin spinner.Model
 
 	send      SendMessageFn
+	fork      ForkSessionFn
 	streamCh  <-chan protocol.StreamingMessageEvent
 	cancel    context.CancelFunc
 	streaming bool
@@ -72,7 +83,7 @@ type chatModel struct {
 
 func newChatModel(agentRef string, sessionID string, send SendMessageFn, verbose bool) *chatModel {
 	input := textarea.New()
-	input.Placeholder = "Type a message (Enter to send)"
+	input.Placeholder = "Type a message (Enter to send, /fork to branch the session)"
 	input.FocusedStyle.CursorLine = lipgloss.NewStyle()
 	input.Prompt = "> "
 	input.ShowLineNumbers = false
@@ -167,6 +178,10 @@ func (m *chatModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if text == "" {
 				return m, nil
 			}
+			if cmd, ok := m.directive(text); ok {
+				m.input.Reset()
+				return m, cmd
+			}
 			m.appendUser(text)
 			m.input.Reset()
 			return m, m.submit(text)
@@ -179,6 +194,14 @@ func (m *chatModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.working = false
 		m.updateStatus()
 		return m, nil
+	case sessionForkedMsg:
+		if msg.err != nil {
+			m.appendError(fmt.Errorf("failed to fork session: %w", msg.err))
+			return m, nil
+		}
+		m.appendLine(theme.DimStyle().Render(fmt.Sprintf("Forked session %s into %s (%d events copied)", msg.fork.ForkedFrom, msg.fork.Session.ID, msg.fork.CopiedEvents)))
+		m.sessionID = msg.fork.Session.ID
+		return m, nil
 	}
 
 	m.input, cmd = m.input.Update(msg)
@@ -215,6 +238,25 @@ func (m *chatModel) View() string {
 	)
 }
 
+// directive runs a chat directive such as "/fork [event-id]", reporting false
+// when text is a regular message.
+func (m *chatModel) directive(text string) (tea.Cmd, bool) {
+	name, arg, _ := strings.Cut(text, " ")
+	switch name {
+	case "/fork":
+		if m.fork == nil {
+			m.appendError(fmt.Errorf("/fork is not supported in this chat"))
+			return nil, true
+		}
+		fork, sessionID, eventID := m.fork, m.sessionID, strings.TrimSpace(arg)
+		return func() tea.Msg {
+			res, err := fork(context.Background(), sessionID, eventID)
+			return sessionForkedMsg{fork: res, err: err}
+		}, true
+	}
+	return nil, false
+}
+
 func (m *chatModel) submit(text string) tea.Cmd {
 	m.streaming = true
 	m.working = true
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"slices"
 	"strings"
 
@@ -186,6 +187,19 @@ func (m *workspaceModel) createSession(name string) tea.Cmd {
 	}
 }
 
+// forkSession forks a session through the controller API.
+func (m *workspaceModel) forkSession(ctx context.Context, sessionID, eventID string) (*api.ForkSessionResponse, error) {
+	req := &api.ForkSessionRequest{}
+	if eventID != "" {
+		req.EventID = &eventID
+	}
+	res, err := m.client.Session.ForkSession(ctx, sessionID, req)
+	if err != nil {
+		return nil, err
+	}
+	return &res.Data, nil
+}
+
 func (m *workspaceModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// Forward to dialog manager first; if a dialog is open, it captures input
 	dcmd := m.dlg.Handle(msg)
@@ -273,6 +287,20 @@ func (m *workspaceModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.sessionInput.SetValue("")
 		m.sessionInput.Focus()
 		return m, nil
+	case sessionForkedMsg:
+		if msg.err == nil {
+			// Continue chatting in the fork; the transcript so far stays on screen.
+			items := append([]list.Item{sessionListItem{s: msg.fork.Session}}, m.sessions.Items()...)
+			m.sessions.SetItems(items)
+			m.sessions.Select(0)
+			m.current = msg.fork.Session
+		}
+		if m.chat != nil {
+			mod, cmd := m.chat.Update(msg)
+			m.chat = mod.(*chatModel)
+			return m, cmd
+		}
+		return m, nil
 	case sessionSelectedMsg:
 		// Clear chat, size chat, and show input
 		m.current = msg.session
@@ -462,11 +490,8 @@ func (m *workspaceModel) startChat(loadHistory bool) tea.Cmd {
 	if m.agent == nil || m.current == nil {
 		return nil
 	}
-	a2aPath := "api/a2a"
-	if m.agent != nil && m.agent.WorkloadMode == v1alpha2.WorkloadModeSandbox {
-		a2aPath = "api/a2a-sandboxes"
-	}
-	a2aURL := fmt.Sprintf("%s/%s/%s", m.cfg.KAgentURL, a2aPath, m.agentRef)
+	namespace, name, _ := strings.Cut(m.agentRef, "/")
+	a2aURL := client.AgentA2AURL(m.cfg.A2ABaseURL(), namespace, name, m.agent)
 	client, err := a2aclient.NewA2AClient(a2aURL,
 		a2aclient.WithTimeout(m.cfg.Timeout),
 	)
@@ -483,6 +508,7 @@ func (m *workspaceModel) startChat(loadHistory bool) tea.Cmd {
 	} else {
 		*m.chat = *newChatModel(m.agentRef, m.current.ID, sendFn, m.verbose)
 	}
+	m.chat.fork = m.forkSession
 	// Set header and clear transcript
 	title := theme.HeadingStyle().Render(fmt.Sprintf("Chat with %s (session %s)", m.agentRef, m.current.ID))
 	m.chat.ResetTranscript(title)
@@ -495,7 +521,7 @@ func (m *workspaceModel) startChat(loadHistory bool) tea.Cmd {
 
 func (m *workspaceModel) fetchSessionHistoryCmd(sessionID string) tea.Cmd {
 	return func() tea.Msg {
-		tasksURL := fmt.Sprintf("%s/api/sessions/%s/tasks?user_id=%s", m.cfg.KAgentURL, sessionID, "admin@kagent.dev")
+		tasksURL := fmt.Sprintf("%s/api/sessions/%s/tasks?user_id=%s", m.cfg.KAgentURL, sessionID, url.QueryEscape(m.cfg.GetUserID()))
 		resp, err := http.Get(tasksURL) //nolint:gosec
 		if err != nil {
 			return sessionHistoryLoadedMsg{items: nil, err: err}
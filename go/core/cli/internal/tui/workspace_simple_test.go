@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"io"
 	"net/http"
 	"testing"
 
@@ -218,3 +219,35 @@ func TestWorkspaceModel_InitCommand(t *testing.T) {
 	cmd := m.Init()
 	assert.NotNil(t, cmd, "Init should return a command")
 }
+
+func TestWorkspaceModel_ForkDirective(t *testing.T) {
+	var gotPath, gotBody string
+	mockServer := testutil.NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		gotPath, gotBody = r.URL.Path, string(body)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"data": {"session": {"id": "sess-fork"}, "copied_events": 4, "forked_from": "sess-1"}}`))
+	})
+
+	cfg := &config.Config{KAgentURL: mockServer.URL, UserID: "test-user"}
+	m := newWorkspaceModel(cfg, cfg.Client(), false)
+	m.current = &api.Session{ID: "sess-1"}
+	m.chat = newChatModel("default/agent", "sess-1", nil, false)
+	m.chat.fork = m.forkSession
+
+	cmd, ok := m.chat.directive("/fork evt-2")
+	require.True(t, ok)
+	require.NotNil(t, cmd)
+	forked := cmd()
+	assert.Equal(t, "/api/sessions/sess-1/fork", gotPath)
+	assert.JSONEq(t, `{"event_id": "evt-2"}`, gotBody)
+
+	m.Update(forked)
+	assert.Equal(t, "sess-fork", m.current.ID)
+	assert.Equal(t, "sess-fork", m.chat.sessionID, "the chat continues in the fork")
+	assert.Contains(t, m.chat.history, "Forked session sess-1 into sess-fork (4 events copied)")
+
+	_, ok = m.chat.directive("hello /fork")
+	assert.False(t, ok, "only leading directives are handled")
+}
@@ -0,0 +1,76 @@
+// Command chat-gateway connects Slack and Microsoft Teams to kagent agents.
+//
+// It serves the Slack Events API at /slack/events and the Bot Framework
+// messaging endpoint at /teams/messages. Mentions of the bot, and direct
+// messages to it, are sent to an agent through the kagent controller's A2A
+// API; every thread maps to one agent session, and the agent's reply is
+// streamed back into the thread with a summary of the tools it called.
+//
+// Credentials are read from the environment (SLACK_BOT_TOKEN,
+// SLACK_SIGNING_SECRET, TEAMS_APP_ID, TEAMS_APP_PASSWORD, TEAMS_TENANT_ID and
+// KAGENT_API_TOKEN) so they can come from Secrets.
+//
+// Usage:
+//
+//	chat-gateway --kagent-url http://kagent-controller.kagent:8083 --agent kagent/k8s-agent \
+//	  --channel-agent C0123ABCD=kagent/helm-agent
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/kagent-dev/kagent/go/core/pkg/chatgateway"
+)
+
+func main() {
+	log.SetFlags(log.LstdFlags | log.Lmicroseconds)
+
+	cfg := &chatgateway.Config{ChannelAgents: map[string]string{}}
+	flag.StringVar(&cfg.ListenAddr, "listen", ":8080", "address to serve the webhooks on")
+	flag.StringVar(&cfg.KagentURL, "kagent-url", "http://kagent-controller.kagent:8083", "base URL of the kagent controller API")
+	flag.StringVar(&cfg.Agent, "agent", "", "agent, as namespace/name, that answers in channels without a --channel-agent")
+	flag.Func("channel-agent", "channel=namespace/name mapping a Slack channel or Teams conversation ID to its agent (repeatable)", func(v string) error {
+		channel, agent, ok := strings.Cut(v, "=")
+		if !ok || channel == "" {
+			return fmt.Errorf("expected channel=namespace/name, got %q", v)
+		}
+		cfg.ChannelAgents[channel] = agent
+		return nil
+	})
+	flag.DurationVar(&cfg.ReplyTimeout, "reply-timeout", 10*time.Minute, "how long an agent may take to reply")
+	flag.DurationVar(&cfg.UpdateInterval, "update-interval", time.Second, "how often a streaming reply is edited")
+	flag.StringVar(&cfg.Teams.TenantID, "teams-tenant-id", "", "tenant of a single-tenant Teams bot registration")
+	flag.Parse()
+
+	chatgateway.LoadConfig(cfg)
+
+	if err := cfg.Validate(); err != nil {
+		log.Fatalf("chat-gateway: %v", err)
+	}
+
+	srv := chatgateway.NewServer(cfg)
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		sig := <-sigCh
+		log.Printf("chat-gateway: received %s, shutting down", sig)
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(ctx); err != nil {
+			log.Printf("chat-gateway: shutdown error: %v", err)
+		}
+	}()
+
+	if err := srv.ListenAndServe(); err != nil {
+		log.Fatalf("chat-gateway: %v", err)
+	}
+}
@@ -1,39 +1,53 @@
 package main
 
 import (
+	"context"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	authimpl "github.com/kagent-dev/kagent/go/core/internal/httpserver/auth"
+	"github.com/kagent-dev/kagent/go/core/pkg/app"
 	"github.com/kagent-dev/kagent/go/core/pkg/auth"
 )
 
 func TestGetAuthenticator(t *testing.T) {
 	tests := []struct {
 		name     string
-		authCfg  struct{ Mode, UserIDClaim string }
+		authCfg  app.AuthConfig
 		wantType string
 	}{
 		{
 			name:     "unsecure mode uses UnsecureAuthenticator",
-			authCfg:  struct{ Mode, UserIDClaim string }{"unsecure", ""},
+			authCfg:  app.AuthConfig{Mode: "unsecure"},
 			wantType: "*auth.UnsecureAuthenticator",
 		},
 		{
 			name:     "trusted-proxy mode uses ProxyAuthenticator",
-			authCfg:  struct{ Mode, UserIDClaim string }{"trusted-proxy", ""},
+			authCfg:  app.AuthConfig{Mode: "trusted-proxy"},
 			wantType: "*auth.ProxyAuthenticator",
 		},
 		{
 			name:     "trusted-proxy mode with custom claim",
-			authCfg:  struct{ Mode, UserIDClaim string }{"trusted-proxy", "user_id"},
+			authCfg:  app.AuthConfig{Mode: "trusted-proxy", UserIDClaim: "user_id"},
 			wantType: "*auth.ProxyAuthenticator",
 		},
+		{
+			name:     "token-review mode uses TokenReviewAuthenticator",
+			authCfg:  app.AuthConfig{Mode: "token-review", TokenReviewAudiences: "kagent-api, https://kubernetes.default.svc"},
+			wantType: "*auth.TokenReviewAuthenticator",
+		},
+		{
+			name:     "api-key mode uses APIKeyAuthenticator",
+			authCfg:  app.AuthConfig{Mode: "api-key", APIKeysFile: writeAPIKeys(t, "- user: ci\n  key: secret\n")},
+			wantType: "*auth.APIKeyAuthenticator",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			authenticator, err := getAuthenticator(tt.authCfg)
+			authenticator, err := getAuthenticator(context.Background(), tt.authCfg, nil)
 			if err != nil {
 				t.Fatalf("getAuthenticator() unexpected error: %v", err)
 			}
@@ -47,7 +61,7 @@ func TestGetAuthenticator(t *testing.T) {
 
 func TestGetAuthenticatorErrorsOnUnknownMode(t *testing.T) {
 	const invalidMode = "proxy"
-	authenticator, err := getAuthenticator(struct{ Mode, UserIDClaim string }{invalidMode, ""})
+	authenticator, err := getAuthenticator(context.Background(), app.AuthConfig{Mode: invalidMode}, nil)
 	if err == nil {
 		t.Fatal("expected error for unknown auth mode, got nil")
 	}
@@ -61,19 +75,44 @@ func TestGetAuthenticatorErrorsOnUnknownMode(t *testing.T) {
 	if !strings.Contains(msg, invalidMode) {
 		t.Errorf("error message %q does not include the invalid mode %q", msg, invalidMode)
 	}
-	for _, valid := range []string{"unsecure", "trusted-proxy"} {
+	for _, valid := range []string{"unsecure", "trusted-proxy", "oidc", "token-review", "api-key"} {
 		if !strings.Contains(msg, valid) {
 			t.Errorf("error message %q does not list supported mode %q", msg, valid)
 		}
 	}
 }
 
+func TestGetAuthenticatorErrorsOnMissingConfig(t *testing.T) {
+	for _, authCfg := range []app.AuthConfig{
+		{Mode: "oidc"},
+		{Mode: "api-key"},
+		{Mode: "api-key", APIKeysFile: writeAPIKeys(t, "- user: ci\n")},
+	} {
+		if _, err := getAuthenticator(context.Background(), authCfg, nil); err == nil {
+			t.Errorf("expected error for %+v, got nil", authCfg)
+		}
+	}
+}
+
+func writeAPIKeys(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "api-keys.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
 func getTypeName(v auth.AuthProvider) string {
 	switch v.(type) {
 	case *authimpl.UnsecureAuthenticator:
 		return "*auth.UnsecureAuthenticator"
 	case *authimpl.ProxyAuthenticator:
 		return "*auth.ProxyAuthenticator"
+	case *authimpl.TokenReviewAuthenticator:
+		return "*auth.TokenReviewAuthenticator"
+	case *authimpl.APIKeyAuthenticator:
+		return "*auth.APIKeyAuthenticator"
 	default:
 		return "unknown"
 	}
@@ -17,11 +17,14 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"fmt"
+	"strings"
 
 	"github.com/kagent-dev/kagent/go/core/internal/httpserver/auth"
 	"github.com/kagent-dev/kagent/go/core/pkg/app"
 	pkgauth "github.com/kagent-dev/kagent/go/core/pkg/auth"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -32,7 +35,7 @@ import (
 func main() {
 	authorizer := &auth.NoopAuthorizer{}
 	app.Start(func(bootstrap app.BootstrapConfig) (*app.ExtensionConfig, error) {
-		authenticator, err := getAuthenticator(bootstrap.Config.Auth)
+		authenticator, err := getAuthenticator(bootstrap.Ctx, bootstrap.Config.Auth, bootstrap.Manager.GetClient())
 		if err != nil {
 			return nil, err
 		}
@@ -44,13 +47,43 @@ func main() {
 	}, nil)
 }
 
-func getAuthenticator(authCfg struct{ Mode, UserIDClaim string }) (pkgauth.AuthProvider, error) {
+func getAuthenticator(ctx context.Context, authCfg app.AuthConfig, kube client.Client) (pkgauth.AuthProvider, error) {
+	// Agents authenticate with their projected service account token in
+	// every mode that validates credentials.
+	newTokenReview := func() *auth.TokenReviewAuthenticator {
+		var audiences []string
+		for aud := range strings.SplitSeq(authCfg.TokenReviewAudiences, ",") {
+			if aud = strings.TrimSpace(aud); aud != "" {
+				audiences = append(audiences, aud)
+			}
+		}
+		return auth.NewTokenReviewAuthenticator(kube, audiences)
+	}
+
 	switch authCfg.Mode {
 	case "trusted-proxy":
 		return auth.NewProxyAuthenticator(authCfg.UserIDClaim), nil
 	case "unsecure":
 		return &auth.UnsecureAuthenticator{}, nil
+	case "oidc":
+		return auth.NewOIDCAuthenticator(ctx, auth.OIDCConfig{
+			IssuerURL:   authCfg.OIDC.IssuerURL,
+			Audience:    authCfg.OIDC.Audience,
+			JWKSURL:     authCfg.OIDC.JWKSURL,
+			UserIDClaim: authCfg.UserIDClaim,
+		}, newTokenReview())
+	case "token-review":
+		return newTokenReview(), nil
+	case "api-key":
+		if authCfg.APIKeysFile == "" {
+			return nil, fmt.Errorf("api-key auth mode requires --auth-api-keys-file")
+		}
+		keys, err := auth.LoadAPIKeys(authCfg.APIKeysFile)
+		if err != nil {
+			return nil, err
+		}
+		return auth.NewAPIKeyAuthenticator(keys, newTokenReview())
 	default:
-		return nil, fmt.Errorf("unknown auth mode %q (valid modes: unsecure, trusted-proxy)", authCfg.Mode)
+		return nil, fmt.Errorf("unknown auth mode %q (valid modes: unsecure, trusted-proxy, oidc, token-review, api-key)", authCfg.Mode)
 	}
 }
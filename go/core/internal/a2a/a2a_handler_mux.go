@@ -1,9 +1,9 @@
 package a2a
 
 import (
+	"context"
 	"fmt"
 	"net/http"
-	"slices"
 	"strings"
 	"sync"
 
@@ -12,6 +12,7 @@ import (
 	"github.com/a2aproject/a2a-go/v2/a2acompat/a2av0"
 	"github.com/a2aproject/a2a-go/v2/a2asrv"
 	"github.com/gorilla/mux"
+	apiutils "github.com/kagent-dev/kagent/go/api/utils"
 	authimpl "github.com/kagent-dev/kagent/go/core/internal/httpserver/auth"
 	common "github.com/kagent-dev/kagent/go/core/internal/utils"
 	"github.com/kagent-dev/kagent/go/core/pkg/auth"
@@ -24,20 +25,34 @@ type A2AHandlerMux interface {
 		client *a2aclient.Client,
 		card a2atype.AgentCard,
 		tracing middleware,
+		stats providerStatsLabels,
+		streaming apiutils.StreamOptions,
 	) error
 	RemoveAgentHandler(
 		agentRef string,
 	)
+	// SendMessage sends a message to an agent through the same request
+	// handlers that serve its A2A route, without an HTTP round trip.
+	SendMessage(ctx context.Context, agentRef string, req *a2atype.SendMessageRequest) (a2atype.SendMessageResult, error)
+	// ValidatePushURL returns an error when push notifications may not be
+	// delivered to url.
+	ValidatePushURL(url string) error
 	http.Handler
 }
 
 type handlerMux struct {
 	handlers          map[string]http.Handler
+	requestHandlers   map[string]a2asrv.RequestHandler
 	lock              sync.RWMutex
 	agentPathPrefix   string
 	sandboxPathPrefix string
 	authenticator     auth.AuthProvider
 	taskStore         TaskStore
+	statsRecorder     ProviderStatsRecorder
+	pushDispatcher    *PushNotificationDispatcher
+	streamStore       StreamEventStore
+	attachmentLimits  AttachmentLimits
+	wsAllowedOrigins  []string
 }
 
 var _ A2AHandlerMux = &handlerMux{}
@@ -46,16 +61,37 @@ type middleware interface {
 	Wrap(next http.Handler) http.Handler
 }
 
-func NewA2AHttpMux(agentPathPrefix, sandboxPathPrefix string, authenticator auth.AuthProvider, taskStore TaskStore) *handlerMux {
+func NewA2AHttpMux(agentPathPrefix, sandboxPathPrefix string, authenticator auth.AuthProvider, taskStore TaskStore, statsRecorder ProviderStatsRecorder, pushDispatcher *PushNotificationDispatcher, streamStore StreamEventStore) *handlerMux {
 	return &handlerMux{
 		handlers:          make(map[string]http.Handler),
+		requestHandlers:   make(map[string]a2asrv.RequestHandler),
 		agentPathPrefix:   agentPathPrefix,
 		sandboxPathPrefix: sandboxPathPrefix,
 		authenticator:     authenticator,
 		taskStore:         taskStore,
+		statsRecorder:     statsRecorder,
+		pushDispatcher:    pushDispatcher,
+		streamStore:       streamStore,
+		attachmentLimits:  DefaultAttachmentLimits(),
 	}
 }
 
+// SetWebSocketAllowedOrigins sets the origins, besides the API's own, whose
+// pages may open WebSockets to agents registered afterwards.
+func (a *handlerMux) SetWebSocketAllowedOrigins(origins []string) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.wsAllowedOrigins = origins
+}
+
+// SetAttachmentLimits sets the limits on files attached to the messages of
+// agents registered afterwards.
+func (a *handlerMux) SetAttachmentLimits(limits AttachmentLimits) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.attachmentLimits = limits
+}
+
 // newTaskQueryHandlers builds the request handler and the legacy (v0) JSON-RPC
 // handler for one agent. kagent persists tasks and is their source of truth,
 // so with a store ListTasks is served from it instead of proxying to the agent
@@ -78,8 +114,20 @@ func (a *handlerMux) SetAgentHandler(
 	client *a2aclient.Client,
 	card a2atype.AgentCard,
 	tracing middleware,
+	stats providerStatsLabels,
+	streaming apiutils.StreamOptions,
 ) error {
-	requestHandler := NewPassthroughRequestHandler(client, &card)
+	if a.pushDispatcher != nil {
+		// Push notifications are delivered by the controller, not the agent
+		// runtime, so advertise them regardless of the runtime's own card.
+		card.Capabilities.PushNotifications = true
+	}
+	requestHandler := newPushConfigHandler(NewPassthroughRequestHandler(client, &card), a.pushDispatcher)
+	requestHandler = newProviderStatsHandler(requestHandler, a.statsRecorder, stats)
+	a.lock.RLock()
+	requestHandler = newAttachmentLimitHandler(requestHandler, a.attachmentLimits)
+	wsAllowedOrigins := a.wsAllowedOrigins
+	a.lock.RUnlock()
 
 	taskHandler, legacyJSONRPCHandler := newTaskQueryHandlers(requestHandler, a.taskStore)
 	v1JSONRPCHandler := a2asrv.NewJSONRPCHandler(taskHandler)
@@ -105,18 +153,20 @@ func (a *handlerMux) SetAgentHandler(
 			http.Error(w, fmt.Sprintf("unknown negotiated A2A wire version %q", wireVersion), http.StatusBadRequest)
 		}
 	})
-	middlewares := []middleware{authimpl.NewA2AAuthenticator(a.authenticator)}
+	handler = apiutils.NewStreamHandler(newResumableStreamHandler(handler, a.streamStore), streaming)
 	if tracing != nil {
-		middlewares = append(middlewares, tracing)
-	}
-	for _, middleware := range slices.Backward(middlewares) {
-		handler = middleware.Wrap(handler)
+		handler = tracing.Wrap(handler)
 	}
+	// WebSocket frames are tunneled through the handlers above, so each
+	// request gets its own span, after the upgrade itself is authenticated.
+	handler = newWebSocketHandler(handler, wsAllowedOrigins)
+	handler = authimpl.NewA2AAuthenticator(a.authenticator).Wrap(handler)
 
 	a.lock.Lock()
 	defer a.lock.Unlock()
 
 	a.handlers[agentRef] = handler
+	a.requestHandlers[agentRef] = taskHandler
 
 	return nil
 }
@@ -127,6 +177,26 @@ func (a *handlerMux) RemoveAgentHandler(
 	a.lock.Lock()
 	defer a.lock.Unlock()
 	delete(a.handlers, agentRef)
+	delete(a.requestHandlers, agentRef)
+}
+
+func (a *handlerMux) SendMessage(ctx context.Context, agentRef string, req *a2atype.SendMessageRequest) (a2atype.SendMessageResult, error) {
+	a.lock.RLock()
+	requestHandler, ok := a.requestHandlers[agentRef]
+	a.lock.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("agent %s not found or not ready", agentRef)
+	}
+	return requestHandler.SendMessage(ctx, req)
+}
+
+// ValidatePushURL returns an error when url is not a URL push notifications
+// may be delivered to.
+func (a *handlerMux) ValidatePushURL(url string) error {
+	if a.pushDispatcher == nil {
+		return webhookGuard{}.checkURL(url)
+	}
+	return a.pushDispatcher.ValidateURL(url)
 }
 
 func (a *handlerMux) getHandler(name string) (http.Handler, bool) {
@@ -12,9 +12,11 @@ import (
 	"github.com/a2aproject/a2a-go/v2/a2acompat/a2av0"
 	"github.com/go-logr/logr"
 	"github.com/kagent-dev/kagent/go/api/database"
+	apiutils "github.com/kagent-dev/kagent/go/api/utils"
 	"github.com/kagent-dev/kagent/go/api/v1alpha2"
 	"github.com/kagent-dev/kagent/go/core/internal/controller/reconciler"
 	agent_translator "github.com/kagent-dev/kagent/go/core/internal/controller/translator/agent"
+	"github.com/kagent-dev/kagent/go/core/internal/scaletozero"
 	common "github.com/kagent-dev/kagent/go/core/internal/utils"
 	"github.com/kagent-dev/kagent/go/core/pkg/auth"
 	"github.com/kagent-dev/kagent/go/core/pkg/env"
@@ -38,6 +40,7 @@ type A2ARegistrar struct {
 	agentObserver                AgentObserver
 	substrateSandboxActorBackend *substrate.SandboxAgentActorBackend
 	dbService                    database.Client
+	waker                        *scaletozero.Waker
 }
 
 type AgentObserver interface {
@@ -57,6 +60,7 @@ func NewA2ARegistrar(
 	agentObserver AgentObserver,
 	substrateSandboxActorBackend *substrate.SandboxAgentActorBackend,
 	dbService database.Client,
+	waker *scaletozero.Waker,
 ) (*A2ARegistrar, error) {
 	if clientRegistry == nil {
 		return nil, fmt.Errorf("clientRegistry must not be nil")
@@ -72,6 +76,7 @@ func NewA2ARegistrar(
 		substrateSandboxActorBackend: substrateSandboxActorBackend,
 		agentObserver:                agentObserver,
 		dbService:                    dbService,
+		waker:                        waker,
 	}
 
 	return reg, nil
@@ -174,7 +179,7 @@ func isAgentReady(agent v1alpha2.AgentObject) bool {
 	for _, c := range status.Conditions {
 		if c.Type == v1alpha2.AgentConditionTypeReady && c.Status == metav1.ConditionTrue {
 			switch c.Reason {
-			case reconciler.AgentReadyReasonDeploymentReady, reconciler.AgentReadyReasonWorkloadReady:
+			case reconciler.AgentReadyReasonDeploymentReady, reconciler.AgentReadyReasonWorkloadReady, reconciler.AgentReadyReasonScaledToZero:
 				workloadReady = true
 			}
 		}
@@ -218,7 +223,9 @@ func (a *A2ARegistrar) upsertAgentHandler(ctx context.Context, agent v1alpha2.Ag
 	agentRef := types.NamespacedName{Namespace: agent.GetNamespace(), Name: agent.GetName()}
 	card := agent_translator.GetA2AAgentCard(agent)
 
-	provider := resolveProviderName(ctx, a.cache, agent)
+	modelConfig := resolveModelConfig(ctx, a.cache, agent)
+	provider := providerNameAttribute(modelConfig)
+	stats := providerStatsLabelsFor(modelConfig)
 
 	httpClient := a2aHTTPClient()
 	if sa, ok := agent.(*v1alpha2.SandboxAgent); ok &&
@@ -236,6 +243,15 @@ func (a *A2ARegistrar) upsertAgentHandler(ctx context.Context, agent v1alpha2.Ag
 			return fmt.Errorf("substrate sandbox A2A transport for %s: %w", agentRef, err)
 		}
 		httpClient = &http.Client{Transport: transport}
+	} else if deployment := agent.GetAgentSpec().SharedDeployment(); a.waker != nil &&
+		agent.GetWorkloadMode() == v1alpha2.WorkloadModeDeployment &&
+		deployment != nil && deployment.ScaleToZero != nil {
+		// Scale-to-zero agents are scaled up by their first request, which
+		// is held until a pod is available.
+		httpClient = &http.Client{
+			Timeout:   httpClient.Timeout,
+			Transport: a.waker.RoundTripper(agentRef, deployment, httpClient.Transport),
+		}
 	}
 
 	client, err := a2aclient.NewFromEndpoints(
@@ -268,7 +284,7 @@ func (a *A2ARegistrar) upsertAgentHandler(ctx context.Context, agent v1alpha2.Ag
 	cardCopy.SupportedInterfaces = cloneInterfacesWithURL(card.SupportedInterfaces, a.a2aRouteURL(agent))
 
 	routeRef := a2aRouteKey(agent)
-	if err := a.handlerMux.SetAgentHandler(routeRef, client, cardCopy, newA2ATracingMiddleware(agentRef, provider)); err != nil {
+	if err := a.handlerMux.SetAgentHandler(routeRef, client, cardCopy, newA2ATracingMiddleware(agentRef, provider), stats, streamOptions(agent)); err != nil {
 		return fmt.Errorf("set handler for %s: %w", agentRef, err)
 	}
 
@@ -296,6 +312,31 @@ func a2aHTTPClient() *http.Client {
 	return client
 }
 
+// streamOptions returns the SSE tuning for an agent's proxied streams: the
+// controller-wide KAGENT_SSE_* defaults overridden by spec.streaming.
+func streamOptions(agent v1alpha2.AgentObject) apiutils.StreamOptions {
+	opts := apiutils.StreamOptions{
+		FlushInterval: env.KagentSSEFlushInterval.Get(),
+		WriteTimeout:  env.KagentSSEWriteTimeout.Get(),
+		MaxEventSize:  env.KagentSSEMaxEventSize.Get(),
+	}
+	streaming := agent.GetAgentSpec().Streaming
+	if streaming == nil {
+		return opts
+	}
+	var override apiutils.StreamOptions
+	if streaming.FlushInterval != nil {
+		override.FlushInterval = streaming.FlushInterval.Duration
+	}
+	if streaming.WriteTimeout != nil {
+		override.WriteTimeout = streaming.WriteTimeout.Duration
+	}
+	if streaming.MaxEventSize != nil {
+		override.MaxEventSize = int(*streaming.MaxEventSize)
+	}
+	return opts.Merge(override)
+}
+
 func (a *A2ARegistrar) a2aRouteURL(agent v1alpha2.AgentObject) string {
 	baseURL := a.a2aBaseURL
 	if agent.GetWorkloadMode() == v1alpha2.WorkloadModeSandbox {
@@ -3,6 +3,7 @@ package a2a
 import (
 	"context"
 	"fmt"
+	"iter"
 	"sync"
 
 	a2atype "github.com/a2aproject/a2a-go/v2/a2a"
@@ -38,14 +39,33 @@ func (r *AgentClientRegistry) Register(namespace, name string, c *a2aclient.Clie
 	r.set(namespace+"/"+name, c)
 }
 
-// SendMessage invokes an agent directly via its cached A2A client.
-func (r *AgentClientRegistry) SendMessage(ctx context.Context, namespace, name string, req *a2atype.SendMessageRequest) (a2atype.SendMessageResult, error) {
-	key := namespace + "/" + name
+func (r *AgentClientRegistry) get(namespace, name string) (*a2aclient.Client, error) {
 	r.mu.RLock()
-	c, ok := r.clients[key]
-	r.mu.RUnlock()
+	defer r.mu.RUnlock()
+	c, ok := r.clients[namespace+"/"+name]
 	if !ok {
 		return nil, fmt.Errorf("agent %s/%s not found or not ready", namespace, name)
 	}
+	return c, nil
+}
+
+// SendMessage invokes an agent directly via its cached A2A client.
+func (r *AgentClientRegistry) SendMessage(ctx context.Context, namespace, name string, req *a2atype.SendMessageRequest) (a2atype.SendMessageResult, error) {
+	c, err := r.get(namespace, name)
+	if err != nil {
+		return nil, err
+	}
 	return c.SendMessage(ctx, req)
 }
+
+// SendStreamingMessage invokes an agent like SendMessage, streaming the
+// events of the task as the agent works on it.
+func (r *AgentClientRegistry) SendStreamingMessage(ctx context.Context, namespace, name string, req *a2atype.SendMessageRequest) iter.Seq2[a2atype.Event, error] {
+	c, err := r.get(namespace, name)
+	if err != nil {
+		return func(yield func(a2atype.Event, error) bool) {
+			yield(nil, err)
+		}
+	}
+	return c.SendStreamingMessage(ctx, req)
+}
@@ -0,0 +1,114 @@
+package a2a
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"mime"
+	"path"
+	"strings"
+
+	a2atype "github.com/a2aproject/a2a-go/v2/a2a"
+	"github.com/a2aproject/a2a-go/v2/a2asrv"
+)
+
+// AttachmentLimits bounds the files sent to agents in A2A messages.
+type AttachmentLimits struct {
+	// MaxBytes caps the size of each inline file. Zero disables the limit.
+	MaxBytes int64
+	// AllowedTypes lists the media types files may have, e.g. "image/png".
+	// A "type/*" entry allows every subtype. Empty allows any type.
+	AllowedTypes []string
+}
+
+// DefaultAttachmentLimits returns the limits the controller proxies A2A
+// messages with: images, PDFs and plain text, Markdown, CSV, JSON and YAML
+// files of up to 5 MiB, which fit the request size limit of the HTTP server
+// once base64-encoded. Types browsers render as active content, such as
+// text/html, are left out.
+func DefaultAttachmentLimits() AttachmentLimits {
+	return AttachmentLimits{
+		MaxBytes:     5 << 20,
+		AllowedTypes: []string{"image/png", "image/jpeg", "image/gif", "image/webp", "application/pdf", "text/plain", "text/markdown", "text/csv", "application/json", "application/yaml"},
+	}
+}
+
+// Check returns an error when a file attached to msg breaks the limits.
+func (l AttachmentLimits) Check(msg *a2atype.Message) error {
+	if msg == nil {
+		return nil
+	}
+	for i, part := range msg.Parts {
+		if part == nil {
+			continue
+		}
+		switch content := part.Content.(type) {
+		case a2atype.Raw:
+			if l.MaxBytes > 0 && int64(len(content)) > l.MaxBytes {
+				return fmt.Errorf("attachment %s is %d bytes, more than the limit of %d", attachmentName(part, i), len(content), l.MaxBytes)
+			}
+		case a2atype.URL:
+		default:
+			continue
+		}
+		if !l.allows(part.MediaType) {
+			return fmt.Errorf("attachment %s has media type %q, which is not one of %s", attachmentName(part, i), part.MediaType, strings.Join(l.AllowedTypes, ", "))
+		}
+	}
+	return nil
+}
+
+// allows reports whether files of mediaType may be attached.
+func (l AttachmentLimits) allows(mediaType string) bool {
+	if len(l.AllowedTypes) == 0 {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(mediaType)
+	if err != nil {
+		return false
+	}
+	for _, allowed := range l.AllowedTypes {
+		if ok, _ := path.Match(allowed, mediaType); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func attachmentName(part *a2atype.Part, index int) string {
+	if part.Filename != "" {
+		return fmt.Sprintf("%q", part.Filename)
+	}
+	return fmt.Sprintf("in part %d", index)
+}
+
+// attachmentLimitHandler rejects message/send and message/stream requests
+// whose message attaches files that break the limits, before they reach the
+// agent.
+type attachmentLimitHandler struct {
+	a2asrv.RequestHandler
+	limits AttachmentLimits
+}
+
+func newAttachmentLimitHandler(delegate a2asrv.RequestHandler, limits AttachmentLimits) a2asrv.RequestHandler {
+	if limits.MaxBytes <= 0 && len(limits.AllowedTypes) == 0 {
+		return delegate
+	}
+	return &attachmentLimitHandler{RequestHandler: delegate, limits: limits}
+}
+
+func (h *attachmentLimitHandler) SendMessage(ctx context.Context, req *a2atype.SendMessageRequest) (a2atype.SendMessageResult, error) {
+	if err := h.limits.Check(req.Message); err != nil {
+		return nil, a2atype.NewError(a2atype.ErrInvalidParams, err.Error())
+	}
+	return h.RequestHandler.SendMessage(ctx, req)
+}
+
+func (h *attachmentLimitHandler) SendStreamingMessage(ctx context.Context, req *a2atype.SendMessageRequest) iter.Seq2[a2atype.Event, error] {
+	if err := h.limits.Check(req.Message); err != nil {
+		return func(yield func(a2atype.Event, error) bool) {
+			yield(nil, a2atype.NewError(a2atype.ErrInvalidParams, err.Error()))
+		}
+	}
+	return h.RequestHandler.SendStreamingMessage(ctx, req)
+}
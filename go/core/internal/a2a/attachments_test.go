@@ -0,0 +1,86 @@
+package a2a
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	a2atype "github.com/a2aproject/a2a-go/v2/a2a"
+	"github.com/a2aproject/a2a-go/v2/a2asrv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func filePart(name, mediaType string, size int) *a2atype.Part {
+	part := a2atype.NewRawPart(make([]byte, size))
+	part.Filename, part.MediaType = name, mediaType
+	return part
+}
+
+func TestAttachmentLimitsCheck(t *testing.T) {
+	limits := AttachmentLimits{MaxBytes: 1024, AllowedTypes: []string{"image/png", "text/*"}}
+	tests := []struct {
+		name    string
+		part    *a2atype.Part
+		wantErr string
+	}{
+		{name: "text", part: a2atype.NewTextPart("hello")},
+		{name: "allowed image", part: filePart("graph.png", "image/png", 1024)},
+		{name: "wildcard subtype with parameters", part: filePart("pod.log", "text/plain; charset=utf-8", 10)},
+		{name: "too large", part: filePart("graph.png", "image/png", 1025), wantErr: `attachment "graph.png" is 1025 bytes`},
+		{name: "disallowed type", part: filePart("core.bin", "application/octet-stream", 10), wantErr: `media type "application/octet-stream"`},
+		{name: "missing type", part: filePart("", "", 10), wantErr: "attachment in part 1"},
+		{name: "disallowed URL type", part: a2atype.NewFileURLPart("https://example.com/a.zip", "application/zip"), wantErr: `"application/zip"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := limits.Check(a2atype.NewMessage(a2atype.MessageRoleUser, a2atype.NewTextPart("look"), tt.part))
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+
+	assert.NoError(t, AttachmentLimits{}.Check(a2atype.NewMessage(a2atype.MessageRoleUser, filePart("core.bin", "", 1<<20))))
+
+	// The defaults leave out types browsers render as active content.
+	defaults := DefaultAttachmentLimits()
+	assert.NoError(t, defaults.Check(a2atype.NewMessage(a2atype.MessageRoleUser, filePart("pod.log", "text/plain; charset=utf-8", 10))))
+	assert.ErrorContains(t, defaults.Check(a2atype.NewMessage(a2atype.MessageRoleUser, filePart("page.html", "text/html", 10))), `media type "text/html"`)
+}
+
+type recordingRequestHandler struct {
+	a2asrv.RequestHandler
+	called bool
+}
+
+func (h *recordingRequestHandler) SendMessage(context.Context, *a2atype.SendMessageRequest) (a2atype.SendMessageResult, error) {
+	h.called = true
+	return &a2atype.Task{}, nil
+}
+
+func TestAttachmentLimitHandler(t *testing.T) {
+	delegate := &recordingRequestHandler{}
+	handler := newAttachmentLimitHandler(delegate, AttachmentLimits{MaxBytes: 10})
+
+	_, err := handler.SendMessage(context.Background(), &a2atype.SendMessageRequest{
+		Message: a2atype.NewMessage(a2atype.MessageRoleUser, filePart("big.txt", "text/plain", 11)),
+	})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, a2atype.ErrInvalidParams))
+	assert.False(t, delegate.called)
+
+	for _, err := range handler.SendStreamingMessage(context.Background(), &a2atype.SendMessageRequest{
+		Message: a2atype.NewMessage(a2atype.MessageRoleUser, filePart("big.txt", "text/plain", 11)),
+	}) {
+		assert.True(t, errors.Is(err, a2atype.ErrInvalidParams))
+	}
+
+	_, err = handler.SendMessage(context.Background(), &a2atype.SendMessageRequest{
+		Message: a2atype.NewMessage(a2atype.MessageRoleUser, filePart("small.txt", "text/plain", 10)),
+	})
+	require.NoError(t, err)
+	assert.True(t, delegate.called)
+}
@@ -65,6 +65,7 @@ func (u *upstreamAuthInterceptor) Before(ctx context.Context, req *a2aclient.Req
 			return ctx, nil, err
 		}
 	}
+	setInvokeSpanAttributes(ctx, req.Payload)
 	propagation.TraceContext{}.Inject(ctx, propagation.HeaderCarrier(httpReq.Header))
 	for k, values := range httpReq.Header {
 		for _, value := range values {
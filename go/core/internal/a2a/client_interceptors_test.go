@@ -4,8 +4,11 @@ import (
 	"context"
 	"testing"
 
+	a2atype "github.com/a2aproject/a2a-go/v2/a2a"
 	a2aclient "github.com/a2aproject/a2a-go/v2/a2aclient"
 	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"go.opentelemetry.io/otel/trace"
 	"k8s.io/apimachinery/pkg/types"
 )
@@ -54,3 +57,36 @@ func TestUpstreamAuthInterceptor_NoTraceContext(t *testing.T) {
 		t.Errorf("expected no traceparent service param, got %q", got)
 	}
 }
+
+func TestUpstreamAuthInterceptor_AnnotatesInvokeSpan(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	ctx, span := tp.Tracer("test").Start(context.Background(), "invoke_agent")
+
+	msg := a2atype.NewMessage(a2atype.MessageRoleUser, a2atype.NewTextPart("hi"))
+	msg.ContextID = "session-1"
+	req := &a2aclient.Request{
+		BaseURL:       "http://agent.default:8080",
+		ServiceParams: a2aclient.ServiceParams{},
+		Payload:       &a2atype.SendMessageRequest{Message: msg},
+	}
+	interceptor := NewUpstreamAuthInterceptor(nil, types.NamespacedName{})
+	if _, _, err := interceptor.Before(ctx, req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	span.End()
+
+	ended := recorder.Ended()
+	if len(ended) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(ended))
+	}
+	var got string
+	for _, attr := range ended[0].Attributes() {
+		if attr.Key == "gen_ai.conversation.id" {
+			got = attr.Value.AsString()
+		}
+	}
+	if got != "session-1" {
+		t.Errorf("gen_ai.conversation.id: want session-1, got %q", got)
+	}
+}
@@ -0,0 +1,100 @@
+package a2a
+
+import (
+	"context"
+	"iter"
+	"time"
+
+	a2atype "github.com/a2aproject/a2a-go/v2/a2a"
+	"github.com/a2aproject/a2a-go/v2/a2asrv"
+	dbpkg "github.com/kagent-dev/kagent/go/api/database"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// ProviderStatsRecorder persists per-provider invocation outcomes.
+// *database.Client satisfies it.
+type ProviderStatsRecorder interface {
+	RecordProviderCall(ctx context.Context, call *dbpkg.ProviderCall) error
+}
+
+// providerStatsLabels identifies the model provider and model an agent is
+// configured with. An empty provider disables recording (BYO agents).
+type providerStatsLabels struct {
+	provider string
+	model    string
+}
+
+// providerStatsHandler records the latency and outcome of every message/send
+// and message/stream call proxied to an agent, attributed to the agent's
+// model provider. A call counts as failed when the proxy returns an error or
+// the agent reports the task as failed. Recording errors are logged and never
+// affect the proxied call.
+type providerStatsHandler struct {
+	a2asrv.RequestHandler
+	recorder ProviderStatsRecorder
+	labels   providerStatsLabels
+	now      func() time.Time
+}
+
+func newProviderStatsHandler(delegate a2asrv.RequestHandler, recorder ProviderStatsRecorder, labels providerStatsLabels) a2asrv.RequestHandler {
+	if recorder == nil || labels.provider == "" {
+		return delegate
+	}
+	return &providerStatsHandler{
+		RequestHandler: delegate,
+		recorder:       recorder,
+		labels:         labels,
+		now:            time.Now,
+	}
+}
+
+func (h *providerStatsHandler) SendMessage(ctx context.Context, req *a2atype.SendMessageRequest) (a2atype.SendMessageResult, error) {
+	start := h.now()
+	result, err := h.RequestHandler.SendMessage(ctx, req)
+	failed := err != nil
+	if task, ok := result.(*a2atype.Task); ok && task.Status.State == a2atype.TaskStateFailed {
+		failed = true
+	}
+	h.record(ctx, start, failed)
+	return result, err
+}
+
+func (h *providerStatsHandler) SendStreamingMessage(ctx context.Context, req *a2atype.SendMessageRequest) iter.Seq2[a2atype.Event, error] {
+	events := h.RequestHandler.SendStreamingMessage(ctx, req)
+	return func(yield func(a2atype.Event, error) bool) {
+		start := h.now()
+		failed := false
+		defer func() { h.record(ctx, start, failed) }()
+
+		for event, err := range events {
+			if err != nil {
+				failed = true
+			}
+			switch e := event.(type) {
+			case *a2atype.Task:
+				failed = failed || e.Status.State == a2atype.TaskStateFailed
+			case *a2atype.TaskStatusUpdateEvent:
+				failed = failed || e.Status.State == a2atype.TaskStateFailed
+			}
+			if !yield(event, err) {
+				return
+			}
+		}
+	}
+}
+
+func (h *providerStatsHandler) record(ctx context.Context, start time.Time, failed bool) {
+	end := h.now()
+	call := &dbpkg.ProviderCall{
+		Provider: h.labels.provider,
+		Model:    h.labels.model,
+		Latency:  end.Sub(start),
+		Failed:   failed,
+		At:       end,
+	}
+	// The caller's context may already be canceled (client went away mid
+	// stream); the outcome is still worth recording.
+	if err := h.recorder.RecordProviderCall(context.WithoutCancel(ctx), call); err != nil {
+		ctrllog.FromContext(ctx).Error(err, "failed to record provider call", "provider", call.Provider, "model", call.Model)
+	}
+}
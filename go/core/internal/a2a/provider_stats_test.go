@@ -0,0 +1,157 @@
+package a2a
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"testing"
+	"time"
+
+	a2atype "github.com/a2aproject/a2a-go/v2/a2a"
+	"github.com/a2aproject/a2a-go/v2/a2asrv"
+	dbpkg "github.com/kagent-dev/kagent/go/api/database"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeProviderStatsRecorder struct {
+	calls []*dbpkg.ProviderCall
+}
+
+func (f *fakeProviderStatsRecorder) RecordProviderCall(_ context.Context, call *dbpkg.ProviderCall) error {
+	f.calls = append(f.calls, call)
+	return nil
+}
+
+// scriptedRequestHandler returns canned results for message/send and
+// message/stream; every other method falls through to the passthrough handler.
+type scriptedRequestHandler struct {
+	a2asrv.RequestHandler
+	result a2atype.SendMessageResult
+	events []a2atype.Event
+	err    error
+}
+
+func (s *scriptedRequestHandler) SendMessage(context.Context, *a2atype.SendMessageRequest) (a2atype.SendMessageResult, error) {
+	return s.result, s.err
+}
+
+func (s *scriptedRequestHandler) SendStreamingMessage(context.Context, *a2atype.SendMessageRequest) iter.Seq2[a2atype.Event, error] {
+	return func(yield func(a2atype.Event, error) bool) {
+		for _, e := range s.events {
+			if !yield(e, nil) {
+				return
+			}
+		}
+		if s.err != nil {
+			yield(nil, s.err)
+		}
+	}
+}
+
+func newTestProviderStatsHandler(delegate a2asrv.RequestHandler, recorder ProviderStatsRecorder) *providerStatsHandler {
+	h := newProviderStatsHandler(delegate, recorder, providerStatsLabels{provider: "OpenAI", model: "gpt-4o"}).(*providerStatsHandler)
+	tick := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	h.now = func() time.Time {
+		tick = tick.Add(250 * time.Millisecond)
+		return tick
+	}
+	return h
+}
+
+func TestProviderStatsHandlerSendMessage(t *testing.T) {
+	tests := []struct {
+		name       string
+		result     a2atype.SendMessageResult
+		err        error
+		wantFailed bool
+	}{
+		{
+			name:   "completed task",
+			result: &a2atype.Task{Status: a2atype.TaskStatus{State: a2atype.TaskStateCompleted}},
+		},
+		{
+			name:       "failed task",
+			result:     &a2atype.Task{Status: a2atype.TaskStatus{State: a2atype.TaskStateFailed}},
+			wantFailed: true,
+		},
+		{
+			name:       "proxy error",
+			err:        errors.New("connection refused"),
+			wantFailed: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := &fakeProviderStatsRecorder{}
+			h := newTestProviderStatsHandler(&scriptedRequestHandler{
+				RequestHandler: &PassthroughRequestHandler{},
+				result:         tt.result,
+				err:            tt.err,
+			}, recorder)
+
+			_, err := h.SendMessage(context.Background(), &a2atype.SendMessageRequest{})
+			require.Equal(t, tt.err, err)
+
+			require.Len(t, recorder.calls, 1)
+			call := recorder.calls[0]
+			require.Equal(t, "OpenAI", call.Provider)
+			require.Equal(t, "gpt-4o", call.Model)
+			require.Equal(t, 250*time.Millisecond, call.Latency)
+			require.Equal(t, tt.wantFailed, call.Failed)
+		})
+	}
+}
+
+func TestProviderStatsHandlerSendStreamingMessage(t *testing.T) {
+	tests := []struct {
+		name       string
+		events     []a2atype.Event
+		err        error
+		wantFailed bool
+	}{
+		{
+			name: "completed stream",
+			events: []a2atype.Event{
+				&a2atype.TaskStatusUpdateEvent{Status: a2atype.TaskStatus{State: a2atype.TaskStateWorking}},
+				&a2atype.TaskStatusUpdateEvent{Status: a2atype.TaskStatus{State: a2atype.TaskStateCompleted}},
+			},
+		},
+		{
+			name: "failed status update",
+			events: []a2atype.Event{
+				&a2atype.TaskStatusUpdateEvent{Status: a2atype.TaskStatus{State: a2atype.TaskStateFailed}},
+			},
+			wantFailed: true,
+		},
+		{
+			name:       "stream error",
+			events:     []a2atype.Event{&a2atype.TaskStatusUpdateEvent{Status: a2atype.TaskStatus{State: a2atype.TaskStateWorking}}},
+			err:        errors.New("stream reset"),
+			wantFailed: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := &fakeProviderStatsRecorder{}
+			h := newTestProviderStatsHandler(&scriptedRequestHandler{
+				RequestHandler: &PassthroughRequestHandler{},
+				events:         tt.events,
+				err:            tt.err,
+			}, recorder)
+
+			for range h.SendStreamingMessage(context.Background(), &a2atype.SendMessageRequest{}) {
+			}
+
+			require.Len(t, recorder.calls, 1)
+			require.Equal(t, tt.wantFailed, recorder.calls[0].Failed)
+		})
+	}
+}
+
+func TestNewProviderStatsHandlerDisabled(t *testing.T) {
+	delegate := &PassthroughRequestHandler{}
+	require.Same(t, delegate, newProviderStatsHandler(delegate, nil, providerStatsLabels{provider: "OpenAI"}))
+	require.Same(t, delegate, newProviderStatsHandler(delegate, &fakeProviderStatsRecorder{}, providerStatsLabels{}))
+}
@@ -0,0 +1,180 @@
+package a2a
+
+import (
+	"context"
+	"fmt"
+	"iter"
+
+	a2atype "github.com/a2aproject/a2a-go/v2/a2a"
+	"github.com/a2aproject/a2a-go/v2/a2asrv"
+	"github.com/google/uuid"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// pushConfigHandler serves the tasks/pushNotificationConfig methods from
+// kagent's store instead of proxying them to the agent runtime: the controller
+// owns webhook delivery (see PushNotificationDispatcher), so configs must live
+// where task writes are observed. Push configs sent inline with message/send
+// and message/stream are stripped before proxying and saved once the task id
+// is known. Every config operation first resolves the task through the
+// delegate, so callers can only register webhooks on tasks they can read.
+type pushConfigHandler struct {
+	a2asrv.RequestHandler
+	dispatcher *PushNotificationDispatcher
+}
+
+func newPushConfigHandler(delegate a2asrv.RequestHandler, dispatcher *PushNotificationDispatcher) a2asrv.RequestHandler {
+	if dispatcher == nil {
+		return delegate
+	}
+	return &pushConfigHandler{RequestHandler: delegate, dispatcher: dispatcher}
+}
+
+func (h *pushConfigHandler) CreateTaskPushConfig(ctx context.Context, req *a2atype.PushConfig) (*a2atype.PushConfig, error) {
+	if err := requireWritableShare(ctx); err != nil {
+		return nil, err
+	}
+	task, err := h.RequestHandler.GetTask(ctx, &a2atype.GetTaskRequest{ID: req.TaskID})
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := h.savePushConfig(ctx, req.TaskID, req)
+	if err != nil {
+		return nil, err
+	}
+	h.notify(ctx, task)
+	return cfg, nil
+}
+
+func (h *pushConfigHandler) GetTaskPushConfig(ctx context.Context, req *a2atype.GetTaskPushConfigRequest) (*a2atype.PushConfig, error) {
+	if _, err := h.RequestHandler.GetTask(ctx, &a2atype.GetTaskRequest{ID: req.TaskID}); err != nil {
+		return nil, err
+	}
+	cfg, err := h.dispatcher.store.GetPushNotification(ctx, string(req.TaskID), req.ID)
+	if err != nil {
+		return nil, a2atype.NewError(a2atype.ErrInvalidParams, fmt.Sprintf("push notification config %q not found for task %q", req.ID, req.TaskID))
+	}
+	return cfg, nil
+}
+
+func (h *pushConfigHandler) ListTaskPushConfigs(ctx context.Context, req *a2atype.ListTaskPushConfigRequest) (*a2atype.ListTaskPushConfigResponse, error) {
+	if _, err := h.RequestHandler.GetTask(ctx, &a2atype.GetTaskRequest{ID: req.TaskID}); err != nil {
+		return nil, err
+	}
+	configs, err := h.dispatcher.store.ListPushNotifications(ctx, string(req.TaskID))
+	if err != nil {
+		return nil, err
+	}
+	return &a2atype.ListTaskPushConfigResponse{Configs: configs}, nil
+}
+
+func (h *pushConfigHandler) DeleteTaskPushConfig(ctx context.Context, req *a2atype.DeleteTaskPushConfigRequest) error {
+	if err := requireWritableShare(ctx); err != nil {
+		return err
+	}
+	if _, err := h.RequestHandler.GetTask(ctx, &a2atype.GetTaskRequest{ID: req.TaskID}); err != nil {
+		return err
+	}
+	return h.dispatcher.store.DeletePushNotificationConfig(ctx, string(req.TaskID), req.ID)
+}
+
+func (h *pushConfigHandler) SendMessage(ctx context.Context, req *a2atype.SendMessageRequest) (a2atype.SendMessageResult, error) {
+	req, inline, err := h.stripInlinePushConfig(req)
+	if err != nil {
+		return nil, err
+	}
+	result, err := h.RequestHandler.SendMessage(ctx, req)
+	if err != nil || inline == nil {
+		return result, err
+	}
+	if task, ok := result.(*a2atype.Task); ok {
+		h.saveInline(ctx, task, inline)
+	}
+	return result, nil
+}
+
+func (h *pushConfigHandler) SendStreamingMessage(ctx context.Context, req *a2atype.SendMessageRequest) iter.Seq2[a2atype.Event, error] {
+	req, inline, err := h.stripInlinePushConfig(req)
+	if err != nil {
+		return func(yield func(a2atype.Event, error) bool) {
+			yield(nil, err)
+		}
+	}
+	events := h.RequestHandler.SendStreamingMessage(ctx, req)
+	if inline == nil {
+		return events
+	}
+	return func(yield func(a2atype.Event, error) bool) {
+		saved := false
+		for event, err := range events {
+			if task, ok := event.(*a2atype.Task); ok && !saved {
+				h.saveInline(ctx, task, inline)
+				saved = true
+			} else if e, ok := event.(*a2atype.TaskStatusUpdateEvent); ok && !saved {
+				h.saveInline(ctx, &a2atype.Task{ID: e.TaskID, ContextID: e.ContextID, Status: e.Status}, inline)
+				saved = true
+			}
+			if !yield(event, err) {
+				return
+			}
+		}
+	}
+}
+
+// stripInlinePushConfig returns a copy of req without its push config, which
+// the agent runtime does not support, along with the validated config.
+func (h *pushConfigHandler) stripInlinePushConfig(req *a2atype.SendMessageRequest) (*a2atype.SendMessageRequest, *a2atype.PushConfig, error) {
+	if req.Config == nil || req.Config.PushConfig == nil {
+		return req, nil, nil
+	}
+	inline := req.Config.PushConfig
+	if err := h.validatePushConfig(inline); err != nil {
+		return nil, nil, err
+	}
+	stripped := *req
+	cfg := *req.Config
+	cfg.PushConfig = nil
+	stripped.Config = &cfg
+	return &stripped, inline, nil
+}
+
+func (h *pushConfigHandler) saveInline(ctx context.Context, task *a2atype.Task, inline *a2atype.PushConfig) {
+	if _, err := h.savePushConfig(ctx, task.ID, inline); err != nil {
+		ctrllog.FromContext(ctx).Error(err, "failed to save inline push notification config", "taskID", task.ID)
+		return
+	}
+	h.notify(ctx, task)
+}
+
+func (h *pushConfigHandler) savePushConfig(ctx context.Context, taskID a2atype.TaskID, req *a2atype.PushConfig) (*a2atype.PushConfig, error) {
+	if err := h.validatePushConfig(req); err != nil {
+		return nil, err
+	}
+	cfg := *req
+	cfg.TaskID = taskID
+	if cfg.ID == "" {
+		cfg.ID = uuid.NewString()
+	}
+	if err := h.dispatcher.store.StorePushNotification(ctx, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to store push notification config: %w", err)
+	}
+	return &cfg, nil
+}
+
+// notify delivers the task's current state to newly registered configs, so a
+// webhook registered after the task finished still hears about it.
+func (h *pushConfigHandler) notify(ctx context.Context, task *a2atype.Task) {
+	if task == nil || task.Status.State == a2atype.TaskStateUnspecified {
+		return
+	}
+	if err := h.dispatcher.NotifyTaskUpdate(ctx, task); err != nil {
+		ctrllog.FromContext(ctx).Error(err, "failed to queue push notification", "taskID", task.ID)
+	}
+}
+
+func (h *pushConfigHandler) validatePushConfig(cfg *a2atype.PushConfig) error {
+	if err := h.dispatcher.ValidateURL(cfg.URL); err != nil {
+		return a2atype.NewError(a2atype.ErrInvalidParams, err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package a2a
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	a2atype "github.com/a2aproject/a2a-go/v2/a2a"
+	"github.com/stretchr/testify/require"
+)
+
+// taskLookupHandler answers GetTask from a fixed set of tasks and records the
+// message/send request it receives.
+type taskLookupHandler struct {
+	scriptedRequestHandler
+	tasks map[a2atype.TaskID]*a2atype.Task
+	sent  *a2atype.SendMessageRequest
+}
+
+func (h *taskLookupHandler) GetTask(_ context.Context, req *a2atype.GetTaskRequest) (*a2atype.Task, error) {
+	if task, ok := h.tasks[req.ID]; ok {
+		return task, nil
+	}
+	return nil, a2atype.ErrTaskNotFound
+}
+
+func (h *taskLookupHandler) SendMessage(ctx context.Context, req *a2atype.SendMessageRequest) (a2atype.SendMessageResult, error) {
+	h.sent = req
+	return h.scriptedRequestHandler.SendMessage(ctx, req)
+}
+
+func TestPushConfigHandlerCreate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *a2atype.PushConfig
+		wantErr error
+	}{
+		{
+			name:   "stores config with generated id",
+			config: &a2atype.PushConfig{TaskID: "task-1", URL: "https://hooks.example.com/a2a"},
+		},
+		{
+			name:    "rejects relative url",
+			config:  &a2atype.PushConfig{TaskID: "task-1", URL: "/hooks"},
+			wantErr: a2atype.ErrInvalidParams,
+		},
+		{
+			name:    "rejects unknown task",
+			config:  &a2atype.PushConfig{TaskID: "someone-elses", URL: "https://hooks.example.com/a2a"},
+			wantErr: a2atype.ErrTaskNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newFakePushStore()
+			delegate := &taskLookupHandler{tasks: map[a2atype.TaskID]*a2atype.Task{"task-1": {ID: "task-1"}}}
+			h := newPushConfigHandler(delegate, NewPushNotificationDispatcher(store, PushDispatcherConfig{}))
+
+			cfg, err := h.CreateTaskPushConfig(context.Background(), tt.config)
+			if tt.wantErr != nil {
+				require.True(t, errors.Is(err, tt.wantErr), "got %v", err)
+				require.Empty(t, store.configs)
+				return
+			}
+			require.NoError(t, err)
+			require.NotEmpty(t, cfg.ID)
+
+			stored, err := h.GetTaskPushConfig(context.Background(), &a2atype.GetTaskPushConfigRequest{TaskID: "task-1", ID: cfg.ID})
+			require.NoError(t, err)
+			require.Equal(t, tt.config.URL, stored.URL)
+		})
+	}
+}
+
+func TestPushConfigHandlerSendMessageStoresInlineConfig(t *testing.T) {
+	store := newFakePushStore()
+	delegate := &taskLookupHandler{
+		scriptedRequestHandler: scriptedRequestHandler{
+			result: &a2atype.Task{ID: "task-1", Status: a2atype.TaskStatus{State: a2atype.TaskStateSubmitted}},
+		},
+	}
+	h := newPushConfigHandler(delegate, NewPushNotificationDispatcher(store, PushDispatcherConfig{}))
+
+	req := &a2atype.SendMessageRequest{
+		Config: &a2atype.SendMessageConfig{
+			ReturnImmediately: true,
+			PushConfig:        &a2atype.PushConfig{ID: "cfg-1", URL: "https://hooks.example.com/a2a"},
+		},
+	}
+	_, err := h.SendMessage(context.Background(), req)
+	require.NoError(t, err)
+
+	require.Nil(t, delegate.sent.Config.PushConfig, "push config must not be forwarded to the agent runtime")
+	require.True(t, delegate.sent.Config.ReturnImmediately)
+	require.NotNil(t, req.Config.PushConfig, "caller's request must not be mutated")
+
+	configs, err := store.ListPushNotifications(context.Background(), "task-1")
+	require.NoError(t, err)
+	require.Len(t, configs, 1)
+	require.Equal(t, "cfg-1", configs[0].ID)
+	require.Equal(t, a2atype.TaskID("task-1"), configs[0].TaskID)
+}
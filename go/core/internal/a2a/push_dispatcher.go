@@ -0,0 +1,338 @@
+package a2a
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	a2atype "github.com/a2aproject/a2a-go/v2/a2a"
+	dbpkg "github.com/kagent-dev/kagent/go/api/database"
+	"k8s.io/client-go/util/workqueue"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	// PushNotificationTokenHeader carries the client-supplied PushConfig token
+	// so the receiver can match the notification to its registration.
+	PushNotificationTokenHeader = "A2A-Notification-Token"
+	// PushNotificationSignatureHeader carries "sha256=<hex>", the HMAC-SHA256
+	// of "<timestamp>.<body>" keyed with the controller's signing key.
+	PushNotificationSignatureHeader = "X-Kagent-Signature"
+	// PushNotificationTimestampHeader carries the unix timestamp covered by
+	// the signature, letting receivers reject replayed notifications.
+	PushNotificationTimestampHeader = "X-Kagent-Signature-Timestamp"
+
+	// CompletionCallbackIDPrefix marks push configs that only want the
+	// task's terminal state, such as the callbacks of async invocations.
+	CompletionCallbackIDPrefix = "completion-"
+
+	defaultPushMaxAttempts = 5
+	defaultPushWorkers     = 4
+	defaultPushTimeout     = 10 * time.Second
+)
+
+// PushNotificationStore is the subset of the persistent store the push
+// notification subsystem needs. *database.Client satisfies it.
+type PushNotificationStore interface {
+	StorePushNotification(ctx context.Context, config *a2atype.PushConfig) error
+	GetPushNotification(ctx context.Context, taskID, configID string) (*a2atype.PushConfig, error)
+	ListPushNotifications(ctx context.Context, taskID string) ([]*a2atype.PushConfig, error)
+	DeletePushNotificationConfig(ctx context.Context, taskID, configID string) error
+	RecordPushNotificationDelivery(ctx context.Context, delivery *dbpkg.PushNotificationDelivery) error
+	ListPushNotificationDeliveries(ctx context.Context, taskID string) ([]dbpkg.PushNotificationDelivery, error)
+}
+
+// PushDispatcherConfig tunes webhook delivery. Zero values pick defaults.
+type PushDispatcherConfig struct {
+	// SigningKey enables HMAC signing of every notification when non-empty.
+	SigningKey []byte
+	// MaxAttempts bounds delivery attempts per task state change.
+	MaxAttempts int
+	// Timeout bounds a single webhook request.
+	Timeout time.Duration
+	// Workers is the number of concurrent deliveries.
+	Workers int
+	// AllowedNetworks lists the loopback, private or link-local networks
+	// webhooks may be delivered to. Other addresses in those ranges are
+	// refused, so webhooks cannot reach the cluster's internal services.
+	AllowedNetworks []netip.Prefix
+}
+
+type pushDeliveryKey struct {
+	taskID   string
+	configID string
+}
+
+// pendingPush is the newest undelivered notification for a push config. A
+// later task state change replaces it, so receivers always converge on the
+// latest state even if intermediate ones are coalesced while retrying.
+type pendingPush struct {
+	config   *a2atype.PushConfig
+	task     *a2atype.Task
+	attempts int
+}
+
+// PushNotificationDispatcher delivers A2A task state changes to the webhooks
+// registered for the task. Deliveries are queued per (task, config) so at most
+// one request per config is in flight, failed attempts are retried with
+// exponential backoff, and the outcome of every attempt is recorded in the
+// store.
+type PushNotificationDispatcher struct {
+	store       PushNotificationStore
+	client      *http.Client
+	guard       webhookGuard
+	signingKey  []byte
+	maxAttempts int
+	workers     int
+	now         func() time.Time
+
+	queue   workqueue.TypedRateLimitingInterface[pushDeliveryKey]
+	mu      sync.Mutex
+	pending map[pushDeliveryKey]*pendingPush
+}
+
+var _ manager.Runnable = (*PushNotificationDispatcher)(nil)
+
+func NewPushNotificationDispatcher(store PushNotificationStore, cfg PushDispatcherConfig) *PushNotificationDispatcher {
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = defaultPushMaxAttempts
+	}
+	if cfg.Workers <= 0 {
+		cfg.Workers = defaultPushWorkers
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultPushTimeout
+	}
+	guard := webhookGuard{allowed: cfg.AllowedNetworks}
+	// Webhooks are dialed directly, without the environment's proxy, so the
+	// guard sees the address of every connection, including redirects.
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second, Control: guard.control}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &PushNotificationDispatcher{
+		store:       store,
+		client:      &http.Client{Timeout: cfg.Timeout, Transport: transport},
+		guard:       guard,
+		signingKey:  cfg.SigningKey,
+		maxAttempts: cfg.MaxAttempts,
+		workers:     cfg.Workers,
+		now:         time.Now,
+		queue: workqueue.NewTypedRateLimitingQueueWithConfig(
+			workqueue.NewTypedItemExponentialFailureRateLimiter[pushDeliveryKey](time.Second, 5*time.Minute),
+			workqueue.TypedRateLimitingQueueConfig[pushDeliveryKey]{Name: "push-notifications"},
+		),
+		pending: map[pushDeliveryKey]*pendingPush{},
+	}
+}
+
+// ValidateURL returns an error when url is not an absolute http(s) URL that
+// push notifications may be delivered to.
+func (d *PushNotificationDispatcher) ValidateURL(url string) error {
+	return d.guard.checkURL(url)
+}
+
+// NotifyTaskUpdate queues a notification for every push config registered on
+// the task whose last recorded delivery was for a different state. Persisting
+// the same task state twice therefore does not notify twice. Completion
+// callbacks are only notified of terminal states.
+func (d *PushNotificationDispatcher) NotifyTaskUpdate(ctx context.Context, task *a2atype.Task) error {
+	configs, err := d.store.ListPushNotifications(ctx, string(task.ID))
+	if err != nil {
+		return fmt.Errorf("failed to list push notification configs: %w", err)
+	}
+	if len(configs) == 0 {
+		return nil
+	}
+	deliveries, err := d.store.ListPushNotificationDeliveries(ctx, string(task.ID))
+	if err != nil {
+		return fmt.Errorf("failed to list push notification deliveries: %w", err)
+	}
+	lastState := make(map[string]string, len(deliveries))
+	for _, delivery := range deliveries {
+		lastState[delivery.ConfigID] = delivery.TaskState
+	}
+
+	state := string(task.Status.State)
+	for _, cfg := range configs {
+		if lastState[cfg.ID] == state {
+			continue
+		}
+		if strings.HasPrefix(cfg.ID, CompletionCallbackIDPrefix) && !task.Status.State.Terminal() {
+			continue
+		}
+		key := pushDeliveryKey{taskID: string(task.ID), configID: cfg.ID}
+		d.mu.Lock()
+		d.pending[key] = &pendingPush{config: cfg, task: task}
+		d.mu.Unlock()
+		// Record before queueing so a fast worker's outcome is never
+		// overwritten by this pending row.
+		d.record(ctx, key, state, dbpkg.PushNotificationDeliveryPending, 0, 0, "")
+		d.queue.Forget(key)
+		d.queue.Add(key)
+	}
+	return nil
+}
+
+// Start runs the delivery workers until ctx is cancelled.
+func (d *PushNotificationDispatcher) Start(ctx context.Context) error {
+	log := ctrllog.FromContext(ctx).WithName("push-notifications")
+	log.Info("Starting push notification dispatcher", "workers", d.workers, "signed", len(d.signingKey) > 0)
+
+	var wg sync.WaitGroup
+	for range d.workers {
+		wg.Go(func() {
+			for d.processNext(ctx) {
+			}
+		})
+	}
+	<-ctx.Done()
+	d.queue.ShutDown()
+	wg.Wait()
+	return nil
+}
+
+// NeedLeaderElection returns false: every replica proxies A2A traffic and
+// accepts task writes, so every replica dispatches the notifications it sees.
+func (d *PushNotificationDispatcher) NeedLeaderElection() bool {
+	return false
+}
+
+func (d *PushNotificationDispatcher) processNext(ctx context.Context) bool {
+	key, shutdown := d.queue.Get()
+	if shutdown {
+		return false
+	}
+	defer d.queue.Done(key)
+
+	d.mu.Lock()
+	p, ok := d.pending[key]
+	if ok {
+		p.attempts++
+	}
+	d.mu.Unlock()
+	if !ok {
+		d.queue.Forget(key)
+		return true
+	}
+
+	state := string(p.task.Status.State)
+	statusCode, err := d.send(ctx, p.config, p.task)
+
+	d.mu.Lock()
+	superseded := d.pending[key] != p
+	if !superseded && (err == nil || !retryable(statusCode) || p.attempts >= d.maxAttempts) {
+		delete(d.pending, key)
+	}
+	d.mu.Unlock()
+
+	switch {
+	case superseded:
+		// A newer state was queued while this one was in flight; it has
+		// already been re-added and will be delivered instead.
+	case err == nil:
+		d.queue.Forget(key)
+		d.record(ctx, key, state, dbpkg.PushNotificationDeliveryDelivered, p.attempts, statusCode, "")
+	case !retryable(statusCode) || p.attempts >= d.maxAttempts:
+		d.queue.Forget(key)
+		d.record(ctx, key, state, dbpkg.PushNotificationDeliveryFailed, p.attempts, statusCode, err.Error())
+	default:
+		d.queue.AddRateLimited(key)
+		d.record(ctx, key, state, dbpkg.PushNotificationDeliveryRetrying, p.attempts, statusCode, err.Error())
+	}
+	return true
+}
+
+// send posts the task to the config's URL and returns the HTTP status code
+// (0 when no response was received).
+func (d *PushNotificationDispatcher) send(ctx context.Context, cfg *a2atype.PushConfig, task *a2atype.Task) (int, error) {
+	body, err := json.Marshal(a2atype.StreamResponse{Event: task})
+	if err != nil {
+		return 0, fmt.Errorf("failed to serialize task: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create push notification request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if cfg.Token != "" {
+		req.Header.Set(PushNotificationTokenHeader, cfg.Token)
+	}
+	if cfg.Auth != nil && cfg.Auth.Credentials != "" {
+		switch strings.ToLower(cfg.Auth.Scheme) {
+		case "bearer":
+			req.Header.Set("Authorization", "Bearer "+cfg.Auth.Credentials)
+		case "basic":
+			req.Header.Set("Authorization", "Basic "+cfg.Auth.Credentials)
+		}
+	}
+	if len(d.signingKey) > 0 {
+		timestamp := strconv.FormatInt(d.now().Unix(), 10)
+		req.Header.Set(PushNotificationTimestampHeader, timestamp)
+		req.Header.Set(PushNotificationSignatureHeader, "sha256="+SignPushNotification(d.signingKey, timestamp, body))
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to send push notification: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("push notification endpoint returned %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+func (d *PushNotificationDispatcher) record(ctx context.Context, key pushDeliveryKey, state string, status dbpkg.PushNotificationDeliveryStatus, attempts, statusCode int, lastErr string) {
+	delivery := &dbpkg.PushNotificationDelivery{
+		TaskID:         key.taskID,
+		ConfigID:       key.configID,
+		TaskState:      state,
+		Status:         status,
+		Attempts:       attempts,
+		LastStatusCode: statusCode,
+		LastError:      lastErr,
+	}
+	if status == dbpkg.PushNotificationDeliveryDelivered {
+		at := d.now()
+		delivery.DeliveredAt = &at
+	}
+	if err := d.store.RecordPushNotificationDelivery(context.WithoutCancel(ctx), delivery); err != nil {
+		ctrllog.FromContext(ctx).Error(err, "failed to record push notification delivery", "taskID", key.taskID, "configID", key.configID)
+	}
+}
+
+// retryable reports whether a failed delivery is worth retrying. Client errors
+// other than timeouts and rate limiting will not succeed on a later attempt.
+func retryable(statusCode int) bool {
+	if statusCode < 400 || statusCode >= 500 {
+		return true
+	}
+	return statusCode == http.StatusRequestTimeout || statusCode == http.StatusTooManyRequests
+}
+
+// SignPushNotification returns the hex HMAC-SHA256 of "<timestamp>.<body>".
+// Receivers recompute it with the shared key to authenticate a notification.
+func SignPushNotification(key []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,279 @@
+package a2a
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	a2atype "github.com/a2aproject/a2a-go/v2/a2a"
+	dbpkg "github.com/kagent-dev/kagent/go/api/database"
+	"github.com/stretchr/testify/require"
+)
+
+// loopback lets the dispatcher deliver to httptest servers.
+var loopback = []netip.Prefix{netip.MustParsePrefix("127.0.0.0/8"), netip.MustParsePrefix("::1/128")}
+
+// fakePushStore keeps configs and the latest delivery per (task, config) in
+// memory, mirroring the upsert semantics of the real store.
+type fakePushStore struct {
+	mu         sync.Mutex
+	configs    map[string][]*a2atype.PushConfig
+	deliveries map[pushDeliveryKey]dbpkg.PushNotificationDelivery
+	history    []dbpkg.PushNotificationDelivery
+}
+
+func newFakePushStore() *fakePushStore {
+	return &fakePushStore{
+		configs:    map[string][]*a2atype.PushConfig{},
+		deliveries: map[pushDeliveryKey]dbpkg.PushNotificationDelivery{},
+	}
+}
+
+func (f *fakePushStore) StorePushNotification(_ context.Context, cfg *a2atype.PushConfig) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.configs[string(cfg.TaskID)] = append(f.configs[string(cfg.TaskID)], cfg)
+	return nil
+}
+
+func (f *fakePushStore) GetPushNotification(_ context.Context, taskID, configID string) (*a2atype.PushConfig, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, cfg := range f.configs[taskID] {
+		if cfg.ID == configID {
+			return cfg, nil
+		}
+	}
+	return nil, io.EOF
+}
+
+func (f *fakePushStore) ListPushNotifications(_ context.Context, taskID string) ([]*a2atype.PushConfig, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.configs[taskID], nil
+}
+
+func (f *fakePushStore) DeletePushNotificationConfig(_ context.Context, taskID, configID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var kept []*a2atype.PushConfig
+	for _, cfg := range f.configs[taskID] {
+		if cfg.ID != configID {
+			kept = append(kept, cfg)
+		}
+	}
+	f.configs[taskID] = kept
+	return nil
+}
+
+func (f *fakePushStore) RecordPushNotificationDelivery(_ context.Context, d *dbpkg.PushNotificationDelivery) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.deliveries[pushDeliveryKey{taskID: d.TaskID, configID: d.ConfigID}] = *d
+	f.history = append(f.history, *d)
+	return nil
+}
+
+func (f *fakePushStore) ListPushNotificationDeliveries(_ context.Context, taskID string) ([]dbpkg.PushNotificationDelivery, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var out []dbpkg.PushNotificationDelivery
+	for key, d := range f.deliveries {
+		if key.taskID == taskID {
+			out = append(out, d)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakePushStore) delivery(taskID, configID string) dbpkg.PushNotificationDelivery {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.deliveries[pushDeliveryKey{taskID: taskID, configID: configID}]
+}
+
+func runDispatcher(t *testing.T, d *PushNotificationDispatcher) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = d.Start(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+}
+
+func waitForDeliveryStatus(t *testing.T, store *fakePushStore, taskID, configID string, want dbpkg.PushNotificationDeliveryStatus) dbpkg.PushNotificationDelivery {
+	t.Helper()
+	var got dbpkg.PushNotificationDelivery
+	require.Eventually(t, func() bool {
+		got = store.delivery(taskID, configID)
+		return got.Status == want
+	}, 5*time.Second, 10*time.Millisecond, "delivery status never reached %q", want)
+	return got
+}
+
+func TestPushNotificationDispatcherSignsAndDelivers(t *testing.T) {
+	key := []byte("s3cret")
+	var (
+		gotBody      []byte
+		gotSignature string
+		gotTimestamp string
+		gotToken     string
+		gotAuthz     string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotBody, _ = io.ReadAll(r.Body)
+		gotSignature = r.Header.Get(PushNotificationSignatureHeader)
+		gotTimestamp = r.Header.Get(PushNotificationTimestampHeader)
+		gotToken = r.Header.Get(PushNotificationTokenHeader)
+		gotAuthz = r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	store := newFakePushStore()
+	require.NoError(t, store.StorePushNotification(context.Background(), &a2atype.PushConfig{
+		TaskID: "task-1",
+		ID:     "cfg-1",
+		URL:    srv.URL,
+		Token:  "client-token",
+		Auth:   &a2atype.PushAuthInfo{Scheme: "Bearer", Credentials: "abc"},
+	}))
+
+	d := NewPushNotificationDispatcher(store, PushDispatcherConfig{SigningKey: key, AllowedNetworks: loopback})
+	runDispatcher(t, d)
+
+	task := &a2atype.Task{ID: "task-1", Status: a2atype.TaskStatus{State: a2atype.TaskStateCompleted}}
+	require.NoError(t, d.NotifyTaskUpdate(context.Background(), task))
+
+	delivery := waitForDeliveryStatus(t, store, "task-1", "cfg-1", dbpkg.PushNotificationDeliveryDelivered)
+	require.Equal(t, 1, delivery.Attempts)
+	require.Equal(t, http.StatusNoContent, delivery.LastStatusCode)
+	require.Equal(t, string(a2atype.TaskStateCompleted), delivery.TaskState)
+	require.NotNil(t, delivery.DeliveredAt)
+
+	require.Equal(t, "sha256="+SignPushNotification(key, gotTimestamp, gotBody), gotSignature)
+	require.Equal(t, "client-token", gotToken)
+	require.Equal(t, "Bearer abc", gotAuthz)
+	require.Contains(t, string(gotBody), `"task-1"`)
+}
+
+func TestPushNotificationDispatcherRetries(t *testing.T) {
+	tests := []struct {
+		name         string
+		statuses     []int
+		maxAttempts  int
+		wantStatus   dbpkg.PushNotificationDeliveryStatus
+		wantAttempts int
+	}{
+		{
+			name:         "transient failure then success",
+			statuses:     []int{http.StatusServiceUnavailable, http.StatusOK},
+			maxAttempts:  3,
+			wantStatus:   dbpkg.PushNotificationDeliveryDelivered,
+			wantAttempts: 2,
+		},
+		{
+			name:         "gives up after max attempts",
+			statuses:     []int{http.StatusBadGateway, http.StatusBadGateway},
+			maxAttempts:  2,
+			wantStatus:   dbpkg.PushNotificationDeliveryFailed,
+			wantAttempts: 2,
+		},
+		{
+			name:         "client error is not retried",
+			statuses:     []int{http.StatusUnauthorized},
+			maxAttempts:  5,
+			wantStatus:   dbpkg.PushNotificationDeliveryFailed,
+			wantAttempts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := int(calls.Add(1)) - 1
+				w.WriteHeader(tt.statuses[min(n, len(tt.statuses)-1)])
+			}))
+			defer srv.Close()
+
+			store := newFakePushStore()
+			require.NoError(t, store.StorePushNotification(context.Background(), &a2atype.PushConfig{TaskID: "task-1", ID: "cfg-1", URL: srv.URL}))
+
+			d := NewPushNotificationDispatcher(store, PushDispatcherConfig{MaxAttempts: tt.maxAttempts, AllowedNetworks: loopback})
+			runDispatcher(t, d)
+
+			task := &a2atype.Task{ID: "task-1", Status: a2atype.TaskStatus{State: a2atype.TaskStateWorking}}
+			require.NoError(t, d.NotifyTaskUpdate(context.Background(), task))
+
+			delivery := waitForDeliveryStatus(t, store, "task-1", "cfg-1", tt.wantStatus)
+			require.Equal(t, tt.wantAttempts, delivery.Attempts)
+			require.Equal(t, int32(tt.wantAttempts), calls.Load())
+		})
+	}
+}
+
+func TestPushNotificationDispatcherSkipsUnchangedState(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+	}))
+	defer srv.Close()
+
+	store := newFakePushStore()
+	require.NoError(t, store.StorePushNotification(context.Background(), &a2atype.PushConfig{TaskID: "task-1", ID: "cfg-1", URL: srv.URL}))
+
+	d := NewPushNotificationDispatcher(store, PushDispatcherConfig{AllowedNetworks: loopback})
+	runDispatcher(t, d)
+
+	working := &a2atype.Task{ID: "task-1", Status: a2atype.TaskStatus{State: a2atype.TaskStateWorking}}
+	require.NoError(t, d.NotifyTaskUpdate(context.Background(), working))
+	waitForDeliveryStatus(t, store, "task-1", "cfg-1", dbpkg.PushNotificationDeliveryDelivered)
+
+	require.NoError(t, d.NotifyTaskUpdate(context.Background(), working))
+	require.Equal(t, int32(1), calls.Load())
+
+	completed := &a2atype.Task{ID: "task-1", Status: a2atype.TaskStatus{State: a2atype.TaskStateCompleted}}
+	require.NoError(t, d.NotifyTaskUpdate(context.Background(), completed))
+	require.Eventually(t, func() bool {
+		d := store.delivery("task-1", "cfg-1")
+		return d.TaskState == string(a2atype.TaskStateCompleted) && d.Status == dbpkg.PushNotificationDeliveryDelivered
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, int32(2), calls.Load())
+}
+
+func TestPushNotificationDispatcherCompletionCallback(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+	}))
+	defer srv.Close()
+
+	configID := CompletionCallbackIDPrefix + "cfg-1"
+	store := newFakePushStore()
+	require.NoError(t, store.StorePushNotification(context.Background(), &a2atype.PushConfig{TaskID: "task-1", ID: configID, URL: srv.URL}))
+
+	d := NewPushNotificationDispatcher(store, PushDispatcherConfig{AllowedNetworks: loopback})
+	runDispatcher(t, d)
+
+	working := &a2atype.Task{ID: "task-1", Status: a2atype.TaskStatus{State: a2atype.TaskStateWorking}}
+	require.NoError(t, d.NotifyTaskUpdate(context.Background(), working))
+	require.Empty(t, store.delivery("task-1", configID).Status)
+
+	failed := &a2atype.Task{ID: "task-1", Status: a2atype.TaskStatus{State: a2atype.TaskStateFailed}}
+	require.NoError(t, d.NotifyTaskUpdate(context.Background(), failed))
+	delivery := waitForDeliveryStatus(t, store, "task-1", configID, dbpkg.PushNotificationDeliveryDelivered)
+	require.Equal(t, string(a2atype.TaskStateFailed), delivery.TaskState)
+	require.Equal(t, int32(1), calls.Load())
+}
@@ -0,0 +1,381 @@
+package a2a
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	dbpkg "github.com/kagent-dev/kagent/go/api/database"
+	common "github.com/kagent-dev/kagent/go/core/internal/utils"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Streaming JSON-RPC methods whose events are stored for resumption, and the
+// methods a resumed stream subscribes with.
+const (
+	v0MethodMessageStream  = "message/stream"
+	v0MethodTasksSubscribe = "tasks/resubscribe"
+	v1MethodMessageStream  = "SendStreamingMessage"
+	v1MethodTasksSubscribe = "SubscribeToTask"
+)
+
+// StreamEventStore is the subset of the persistent store the events of A2A
+// streams are kept in so clients can resume them. *database.Client satisfies
+// it.
+type StreamEventStore interface {
+	AppendTaskStreamEvent(ctx context.Context, taskID, userID string, data []byte) (int64, error)
+	ListTaskStreamEvents(ctx context.Context, taskID, userID string, afterSeq int64) ([]*dbpkg.TaskStreamEvent, error)
+}
+
+// resumableStreamHandler makes the A2A streams of an agent resumable with
+// the Last-Event-ID header. Every streamed event of a task is stored and sent
+// with the id "<taskId>:<seq>". A client that lost its connection sends the
+// streaming request again with the id of the last event it received: the
+// events it missed are replayed from the store and, unless the task already
+// finished or stopped for input, the stream continues by subscribing to the
+// task, so the work is never run twice. Replayed events keep the wire
+// encoding of the stream they were stored from.
+type resumableStreamHandler struct {
+	next  http.Handler
+	store StreamEventStore
+}
+
+func newResumableStreamHandler(next http.Handler, store StreamEventStore) http.Handler {
+	if store == nil {
+		return next
+	}
+	return &resumableStreamHandler{next: next, store: store}
+}
+
+func (h *resumableStreamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	sw := &storingResponseWriter{ResponseWriter: w, store: h.store, ctx: context.WithoutCancel(r.Context()), userID: callerUserID(r.Context())}
+	defer sw.finish()
+
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if r.Method != http.MethodPost || lastEventID == "" {
+		h.next.ServeHTTP(sw, r)
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "failed to read request body", http.StatusBadRequest)
+		return
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	var rpcReq v0RPCRequest
+	if err := json.Unmarshal(body, &rpcReq); err != nil || !isStreamingMethod(rpcReq.Method) {
+		h.next.ServeHTTP(sw, r)
+		return
+	}
+	taskID, seq, ok := parseStreamEventID(lastEventID)
+	if !ok {
+		http.Error(w, fmt.Sprintf("invalid Last-Event-ID %q", lastEventID), http.StatusBadRequest)
+		return
+	}
+	h.resume(sw, r, &rpcReq, taskID, seq)
+}
+
+// resume replays the events of taskID after seq and then subscribes to the
+// task for the events still to come.
+func (h *resumableStreamHandler) resume(w *storingResponseWriter, r *http.Request, rpcReq *v0RPCRequest, taskID string, seq int64) {
+	// The last event the client received is read too, to tell whether the
+	// stream had already ended.
+	events, err := h.store.ListTaskStreamEvents(r.Context(), taskID, w.userID, max(seq-1, 0))
+	if err != nil {
+		http.Error(w, "failed to read stream events", http.StatusInternalServerError)
+		return
+	}
+	header := w.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	header.Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	done := false
+	for _, event := range events {
+		done = streamEventDone([]byte(event.Data))
+		if event.Seq <= seq {
+			continue
+		}
+		data, err := withRPCID([]byte(event.Data), rpcReq.ID)
+		if err != nil {
+			continue
+		}
+		if err := w.writeEvent(fmt.Sprintf("%s:%d", event.TaskID, event.Seq), data); err != nil {
+			return
+		}
+	}
+	w.Flush()
+	if done {
+		return
+	}
+
+	method := v0MethodTasksSubscribe
+	if wire, err := common.NegotiateA2AWireVersion(r); err == nil && wire == common.A2AWireVersionV1 {
+		method = v1MethodTasksSubscribe
+	}
+	params, _ := json.Marshal(map[string]string{"id": taskID})
+	body, _ := json.Marshal(v0RPCRequest{JSONRPC: "2.0", Method: method, Params: params, ID: rpcReq.ID})
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	r.ContentLength = int64(len(body))
+	h.next.ServeHTTP(w, r)
+}
+
+func isStreamingMethod(method string) bool {
+	switch method {
+	case v0MethodMessageStream, v0MethodTasksSubscribe, v1MethodMessageStream, v1MethodTasksSubscribe:
+		return true
+	}
+	return false
+}
+
+// parseStreamEventID splits an event id "<taskId>:<seq>".
+func parseStreamEventID(id string) (string, int64, bool) {
+	i := strings.LastIndex(id, ":")
+	if i <= 0 {
+		return "", 0, false
+	}
+	seq, err := strconv.ParseInt(id[i+1:], 10, 64)
+	if err != nil || seq < 0 {
+		return "", 0, false
+	}
+	return id[:i], seq, true
+}
+
+// withRPCID returns the JSON-RPC response data with its id replaced by id,
+// the id of the request the response is replayed to.
+func withRPCID(data []byte, id json.RawMessage) ([]byte, error) {
+	var resp map[string]json.RawMessage
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, err
+	}
+	if len(id) > 0 {
+		resp["id"] = id
+	} else {
+		delete(resp, "id")
+	}
+	return json.Marshal(resp)
+}
+
+// streamEvent holds the fields of a streamed JSON-RPC result, in either wire
+// encoding, that identify its task and tell whether it ends the stream.
+type streamEvent struct {
+	Result *struct {
+		// v0 results carry the event fields directly.
+		streamEventFields
+		Kind string `json:"kind"`
+		// v1 results wrap them by event kind.
+		Task           *streamEventFields `json:"task"`
+		StatusUpdate   *streamEventFields `json:"statusUpdate"`
+		ArtifactUpdate *streamEventFields `json:"artifactUpdate"`
+		Message        *streamEventFields `json:"message"`
+	} `json:"result"`
+}
+
+type streamEventFields struct {
+	ID     string `json:"id"`
+	TaskID string `json:"taskId"`
+	Final  bool   `json:"final"`
+	Status *struct {
+		State string `json:"state"`
+	} `json:"status"`
+}
+
+func parseStreamEvent(data []byte) (*streamEventFields, bool) {
+	var event streamEvent
+	if err := json.Unmarshal(data, &event); err != nil || event.Result == nil {
+		return nil, false
+	}
+	res := event.Result
+	for _, fields := range []*streamEventFields{res.Task, res.StatusUpdate, res.ArtifactUpdate, res.Message} {
+		if fields != nil {
+			if fields == res.Task {
+				fields.TaskID = fields.ID
+			}
+			return fields, true
+		}
+	}
+	fields := res.streamEventFields
+	if res.Kind == "task" {
+		fields.TaskID = fields.ID
+	}
+	return &fields, true
+}
+
+// streamEventTaskID returns the id of the task a streamed event belongs to,
+// or "" for events of no task.
+func streamEventTaskID(data []byte) string {
+	fields, ok := parseStreamEvent(data)
+	if !ok {
+		return ""
+	}
+	return fields.TaskID
+}
+
+// streamEventDone reports whether the event is the last of its stream: a
+// final status update, or a task that finished or waits for input.
+func streamEventDone(data []byte) bool {
+	fields, ok := parseStreamEvent(data)
+	if !ok {
+		return false
+	}
+	if fields.Final {
+		return true
+	}
+	if fields.Status == nil {
+		return false
+	}
+	switch strings.TrimPrefix(strings.ToLower(fields.Status.State), "task_state_") {
+	case "completed", "failed", "canceled", "rejected", "input-required", "input_required", "auth-required", "auth_required":
+		return true
+	}
+	return false
+}
+
+// storingResponseWriter stores the events of an event-stream response and
+// sends each with an id it can be resumed from. Other responses are passed
+// through untouched.
+type storingResponseWriter struct {
+	http.ResponseWriter
+	store  StreamEventStore
+	ctx    context.Context
+	userID string
+
+	mu          sync.Mutex
+	wroteHeader bool
+	streaming   bool
+	// wrapBody is set when a response that is not an event stream follows
+	// replayed events, so it is sent as one more event instead.
+	wrapBody bool
+	buf      []byte
+}
+
+var _ http.Flusher = &storingResponseWriter{}
+
+func (w *storingResponseWriter) WriteHeader(code int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.writeHeaderLocked(code)
+}
+
+func (w *storingResponseWriter) writeHeaderLocked(code int) {
+	isStream := strings.HasPrefix(w.Header().Get("Content-Type"), "text/event-stream")
+	if w.wroteHeader {
+		// The stream continues after replayed events.
+		if !isStream {
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.wrapBody = true
+		}
+		return
+	}
+	w.wroteHeader = true
+	w.streaming = isStream
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *storingResponseWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.wroteHeader {
+		w.writeHeaderLocked(http.StatusOK)
+	}
+	if !w.streaming && !w.wrapBody {
+		return w.ResponseWriter.Write(p)
+	}
+	w.buf = append(w.buf, p...)
+	if w.wrapBody {
+		return len(p), nil
+	}
+	for {
+		i := bytes.Index(w.buf, []byte("\n\n"))
+		if i < 0 {
+			break
+		}
+		block := w.buf[:i+2]
+		if err := w.writeBlockLocked(block); err != nil {
+			return 0, err
+		}
+		w.buf = w.buf[i+2:]
+	}
+	return len(p), nil
+}
+
+// writeBlockLocked sends one event of the stream, stored and with its
+// resumable id when it belongs to a task.
+func (w *storingResponseWriter) writeBlockLocked(block []byte) error {
+	var data [][]byte
+	for line := range bytes.SplitSeq(bytes.TrimSuffix(block, []byte("\n\n")), []byte("\n")) {
+		if rest, ok := bytes.CutPrefix(line, []byte("data:")); ok {
+			data = append(data, bytes.TrimPrefix(rest, []byte(" ")))
+		}
+	}
+	if len(data) == 0 {
+		// Comments such as keep-alives.
+		_, err := w.ResponseWriter.Write(block)
+		return err
+	}
+	payload := bytes.Join(data, []byte("\n"))
+	taskID := streamEventTaskID(payload)
+	if taskID == "" {
+		_, err := w.ResponseWriter.Write(block)
+		return err
+	}
+	seq, err := w.store.AppendTaskStreamEvent(w.ctx, taskID, w.userID, payload)
+	if err != nil {
+		// The stream goes on, it just cannot be resumed from this event.
+		ctrllog.FromContext(w.ctx).WithName("a2a-stream").Error(err, "Failed to store stream event", "taskID", taskID)
+		_, err := w.ResponseWriter.Write(block)
+		return err
+	}
+	return w.writeEventLocked(fmt.Sprintf("%s:%d", taskID, seq), payload)
+}
+
+func (w *storingResponseWriter) writeEvent(id string, data []byte) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.writeEventLocked(id, data)
+}
+
+// writeEventLocked sends data as one event, with id unless it is empty.
+func (w *storingResponseWriter) writeEventLocked(id string, data []byte) error {
+	if id != "" {
+		if _, err := fmt.Fprintf(w.ResponseWriter, "id: %s\n", id); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w.ResponseWriter, "data: %s\n\n", data)
+	return err
+}
+
+// Flush sends the events written so far to the client.
+func (w *storingResponseWriter) Flush() {
+	_ = http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (w *storingResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// finish sends what is left of the response once the handler returned.
+func (w *storingResponseWriter) finish() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.buf) == 0 {
+		return
+	}
+	if w.wrapBody {
+		_ = w.writeEventLocked("", bytes.TrimSpace(w.buf))
+	} else {
+		_, _ = w.ResponseWriter.Write(w.buf)
+	}
+	w.buf = nil
+	_ = http.NewResponseController(w.ResponseWriter).Flush()
+}
@@ -0,0 +1,199 @@
+package a2a
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	dbpkg "github.com/kagent-dev/kagent/go/api/database"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeStreamEventStore struct {
+	mu     sync.Mutex
+	events []*dbpkg.TaskStreamEvent
+}
+
+func (s *fakeStreamEventStore) AppendTaskStreamEvent(_ context.Context, taskID, userID string, data []byte) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var seq int64
+	for _, e := range s.events {
+		if e.TaskID == taskID {
+			seq = max(seq, e.Seq)
+		}
+	}
+	s.events = append(s.events, &dbpkg.TaskStreamEvent{TaskID: taskID, Seq: seq + 1, UserID: userID, Data: string(data)})
+	return seq + 1, nil
+}
+
+func (s *fakeStreamEventStore) ListTaskStreamEvents(_ context.Context, taskID, userID string, afterSeq int64) ([]*dbpkg.TaskStreamEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []*dbpkg.TaskStreamEvent
+	for _, e := range s.events {
+		if e.TaskID == taskID && e.UserID == userID && e.Seq > afterSeq {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+// sseAgent writes its results as a v0 event stream the way a2a-go does, and
+// records the JSON-RPC methods it is called with.
+type sseAgent struct {
+	results []string
+	methods []string
+}
+
+func (a *sseAgent) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	var req v0RPCRequest
+	_ = json.Unmarshal(body, &req)
+	a.methods = append(a.methods, req.Method)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(": keep-alive\n\n"))
+	for i, result := range a.results {
+		fmt.Fprintf(w, "id: uuid-%d\n", i)
+		fmt.Fprintf(w, "data: {\"jsonrpc\":\"2.0\",\"id\":%s,\"result\":%s}\n\n", req.ID, result)
+		w.(http.Flusher).Flush()
+	}
+}
+
+const (
+	resultTask    = `{"kind":"task","id":"t1","contextId":"c1","status":{"state":"submitted"}}`
+	resultWorking = `{"kind":"status-update","taskId":"t1","contextId":"c1","final":false,"status":{"state":"working"}}`
+	resultDone    = `{"kind":"status-update","taskId":"t1","contextId":"c1","final":true,"status":{"state":"completed"}}`
+)
+
+type sseEvent struct {
+	id   string
+	data string
+}
+
+func readEvents(t *testing.T, body string) []sseEvent {
+	t.Helper()
+	var events []sseEvent
+	for block := range strings.SplitSeq(strings.TrimSpace(body), "\n\n") {
+		var e sseEvent
+		for line := range strings.SplitSeq(block, "\n") {
+			if id, ok := strings.CutPrefix(line, "id: "); ok {
+				e.id = id
+			}
+			if data, ok := strings.CutPrefix(line, "data: "); ok {
+				e.data = data
+			}
+		}
+		if e.data != "" {
+			events = append(events, e)
+		}
+	}
+	return events
+}
+
+func serveStream(h http.Handler, user, lastEventID, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/a2a/kagent/agent", strings.NewReader(body)).WithContext(userCtx(user))
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+const streamRequest = `{"jsonrpc":"2.0","id":1,"method":"message/stream","params":{"message":{"kind":"message","messageId":"m1","role":"user","parts":[{"kind":"text","text":"hi"}]}}}`
+
+func TestResumableStreamStoresEvents(t *testing.T) {
+	store := &fakeStreamEventStore{}
+	agent := &sseAgent{results: []string{resultTask, resultWorking, resultDone}}
+	rec := serveStream(newResumableStreamHandler(agent, store), "alice", "", streamRequest)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), ": keep-alive\n\n")
+	events := readEvents(t, rec.Body.String())
+	require.Len(t, events, 3)
+	for i, e := range events {
+		require.Equal(t, fmt.Sprintf("t1:%d", i+1), e.id)
+	}
+	require.Len(t, store.events, 3)
+	require.Equal(t, "alice", store.events[0].UserID)
+	require.JSONEq(t, events[2].data, store.events[2].Data)
+}
+
+func TestResumableStreamResume(t *testing.T) {
+	t.Run("replays missed events of a finished task", func(t *testing.T) {
+		store := &fakeStreamEventStore{}
+		serveStream(newResumableStreamHandler(&sseAgent{results: []string{resultTask, resultWorking, resultDone}}, store), "alice", "", streamRequest)
+
+		agent := &sseAgent{}
+		rec := serveStream(newResumableStreamHandler(agent, store), "alice", "t1:1", strings.Replace(streamRequest, `"id":1`, `"id":2`, 1))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, "text/event-stream", rec.Header().Get("Content-Type"))
+		require.Empty(t, agent.methods, "a finished task is not run or subscribed to again")
+		events := readEvents(t, rec.Body.String())
+		require.Len(t, events, 2)
+		require.Equal(t, "t1:2", events[0].id)
+		require.Equal(t, "t1:3", events[1].id)
+		require.Contains(t, events[1].data, `"id":2`)
+		require.Contains(t, events[1].data, `"final":true`)
+	})
+
+	t.Run("subscribes to a running task", func(t *testing.T) {
+		store := &fakeStreamEventStore{}
+		serveStream(newResumableStreamHandler(&sseAgent{results: []string{resultTask, resultWorking}}, store), "alice", "", streamRequest)
+
+		agent := &sseAgent{results: []string{resultDone}}
+		rec := serveStream(newResumableStreamHandler(agent, store), "alice", "t1:1", streamRequest)
+
+		require.Equal(t, []string{v0MethodTasksSubscribe}, agent.methods)
+		events := readEvents(t, rec.Body.String())
+		require.Len(t, events, 2)
+		require.Equal(t, "t1:2", events[0].id)
+		require.Equal(t, "t1:3", events[1].id)
+		require.Len(t, store.events, 3)
+	})
+
+	t.Run("does not replay other users' events", func(t *testing.T) {
+		store := &fakeStreamEventStore{}
+		serveStream(newResumableStreamHandler(&sseAgent{results: []string{resultTask, resultWorking}}, store), "alice", "", streamRequest)
+
+		agent := &sseAgent{}
+		rec := serveStream(newResumableStreamHandler(agent, store), "bob", "t1:1", streamRequest)
+		require.Empty(t, readEvents(t, rec.Body.String()))
+	})
+
+	t.Run("rejects an invalid event id", func(t *testing.T) {
+		rec := serveStream(newResumableStreamHandler(&sseAgent{}, &fakeStreamEventStore{}), "alice", "not-an-id", streamRequest)
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestStreamEventDone(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want bool
+	}{
+		{"v0 working", `{"result":` + resultWorking + `}`, false},
+		{"v0 final", `{"result":` + resultDone + `}`, true},
+		{"v0 input required task", `{"result":{"kind":"task","id":"t1","status":{"state":"input-required"}}}`, true},
+		{"v1 working", `{"result":{"statusUpdate":{"taskId":"t1","status":{"state":"TASK_STATE_WORKING"}}}}`, false},
+		{"v1 completed task", `{"result":{"task":{"id":"t1","status":{"state":"TASK_STATE_COMPLETED"}}}}`, true},
+		{"error", `{"error":{"code":-32603,"message":"boom"}}`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, streamEventDone([]byte(tt.data)))
+		})
+	}
+	require.Equal(t, "t1", streamEventTaskID([]byte(`{"result":{"task":{"id":"t1"}}}`)))
+	require.Equal(t, "t1", streamEventTaskID([]byte(`{"result":{"artifactUpdate":{"taskId":"t1"}}}`)))
+}
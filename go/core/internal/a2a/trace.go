@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 
+	a2atype "github.com/a2aproject/a2a-go/v2/a2a"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
@@ -12,6 +13,7 @@ import (
 	crcache "sigs.k8s.io/controller-runtime/pkg/cache"
 
 	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	"github.com/kagent-dev/kagent/go/core/pkg/auth"
 )
 
 // a2aTracingMiddleware is an A2A server middleware that creates an invoke_agent
@@ -43,13 +45,55 @@ func (m *a2aTracingMiddleware) Wrap(next http.Handler) http.Handler {
 	})
 }
 
-// resolveProviderName looks up the ModelConfig for a declarative agent and
-// returns the corresponding gen_ai.provider.name attribute. Falls back to "kagent"
+// setInvokeSpanAttributes annotates the invoke_agent span with the session,
+// task and user of a proxied message so a single invocation can be found by
+// session ID and followed into the agent's own spans.
+func setInvokeSpanAttributes(ctx context.Context, payload any) {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+	// Streaming and non-streaming sends share the request type.
+	if req, ok := payload.(*a2atype.SendMessageRequest); ok && req.Message != nil {
+		msg := req.Message
+		if msg.ContextID != "" {
+			span.SetAttributes(semconv.GenAIConversationID(msg.ContextID))
+		}
+		if msg.TaskID != "" {
+			span.SetAttributes(attribute.String("gen_ai.task.id", string(msg.TaskID)))
+		}
+	}
+	if session, ok := auth.AuthSessionFrom(ctx); ok && session.Principal().User.ID != "" {
+		span.SetAttributes(attribute.String("kagent.user_id", session.Principal().User.ID))
+	}
+}
+
+// providerNameAttribute returns the gen_ai.provider.name attribute for an
+// agent's ModelConfig. Falls back to "kagent" for BYO agents or if the
+// ModelConfig cannot be fetched (mc is nil).
+func providerNameAttribute(mc *v1alpha2.ModelConfig) attribute.KeyValue {
+	if mc == nil {
+		return semconv.GenAIProviderNameKey.String("kagent")
+	}
+	return genAIProviderName(mc.Spec.Provider)
+}
+
+// providerStatsLabelsFor returns the provider/model labels used to attribute
+// proxied calls in provider analytics. A nil ModelConfig yields empty labels,
+// which disables recording.
+func providerStatsLabelsFor(mc *v1alpha2.ModelConfig) providerStatsLabels {
+	if mc == nil {
+		return providerStatsLabels{}
+	}
+	return providerStatsLabels{provider: string(mc.Spec.Provider), model: mc.Spec.Model}
+}
+
+// resolveModelConfig returns the ModelConfig a declarative agent uses, or nil
 // for BYO agents or if the ModelConfig cannot be fetched.
-func resolveProviderName(ctx context.Context, cache crcache.Cache, agent v1alpha2.AgentObject) attribute.KeyValue {
+func resolveModelConfig(ctx context.Context, cache crcache.Cache, agent v1alpha2.AgentObject) *v1alpha2.ModelConfig {
 	spec := agent.GetAgentSpec()
 	if spec.Declarative == nil {
-		return semconv.GenAIProviderNameKey.String("kagent")
+		return nil
 	}
 	mcName := spec.Declarative.ModelConfig
 	if mcName == "" {
@@ -57,9 +101,9 @@ func resolveProviderName(ctx context.Context, cache crcache.Cache, agent v1alpha
 	}
 	mc := &v1alpha2.ModelConfig{}
 	if err := cache.Get(ctx, types.NamespacedName{Namespace: agent.GetNamespace(), Name: mcName}, mc); err != nil {
-		return semconv.GenAIProviderNameKey.String("kagent")
+		return nil
 	}
-	return genAIProviderName(mc.Spec.Provider)
+	return mc
 }
 
 // genAIProviderName maps kagent's ModelProvider values to the standard
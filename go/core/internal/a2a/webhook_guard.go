@@ -0,0 +1,75 @@
+package a2a
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+)
+
+// webhookGuard keeps push notification webhooks from reaching the cluster's
+// internal network: URLs are checked when a config is registered, and every
+// connection is checked when it is dialed, which also covers redirects and
+// host names that resolve to a different address later. Loopback, private
+// and link-local addresses are refused unless they are in allowed. Private
+// includes the 100.64.0.0/10 shared address space, which clusters and cloud
+// networks use for internal addresses too.
+type webhookGuard struct {
+	allowed []netip.Prefix
+}
+
+// checkURL returns an error when raw is not an absolute http(s) URL or names
+// a refused address. Host names are resolved when the webhook is dialed.
+func (g webhookGuard) checkURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("push notification url %q must be an absolute http(s) URL", raw)
+	}
+	host := u.Hostname()
+	if strings.EqualFold(host, "localhost") || strings.HasSuffix(strings.ToLower(host), ".localhost") {
+		return fmt.Errorf("push notification url %q must not point to the loopback interface", raw)
+	}
+	if addr, err := netip.ParseAddr(host); err == nil {
+		if err := g.checkAddr(addr); err != nil {
+			return fmt.Errorf("push notification url %q is not allowed: %w", raw, err)
+		}
+	}
+	return nil
+}
+
+// sharedAddressSpace is the carrier-grade NAT range of RFC 6598, which
+// netip.Addr.IsPrivate does not cover.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+func (g webhookGuard) checkAddr(addr netip.Addr) error {
+	addr = addr.Unmap()
+	if !addr.IsLoopback() && !addr.IsPrivate() && !sharedAddressSpace.Contains(addr) &&
+		!addr.IsLinkLocalUnicast() && !addr.IsLinkLocalMulticast() && !addr.IsUnspecified() {
+		return nil
+	}
+	for _, prefix := range g.allowed {
+		if prefix.Contains(addr) {
+			return nil
+		}
+	}
+	return fmt.Errorf("address %s is in a loopback, private or link-local network", addr)
+}
+
+// control is a net.Dialer Control function that refuses connections to
+// addresses checkAddr refuses.
+func (g webhookGuard) control(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	if err := g.checkAddr(addr); err != nil {
+		return fmt.Errorf("refusing to deliver push notification: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package a2a
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	a2atype "github.com/a2aproject/a2a-go/v2/a2a"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookGuardCheckURL(t *testing.T) {
+	tests := []struct {
+		url     string
+		allowed []netip.Prefix
+		wantErr string
+	}{
+		{url: "https://hooks.example.com/kagent"},
+		{url: "http://203.0.113.10:8080/hook"},
+		{url: "/hook", wantErr: "must be an absolute http(s) URL"},
+		{url: "ftp://example.com/hook", wantErr: "must be an absolute http(s) URL"},
+		{url: "http://localhost:8083/api", wantErr: "loopback"},
+		{url: "http://127.0.0.1/hook", wantErr: "loopback, private or link-local"},
+		{url: "http://169.254.169.254/latest/meta-data", wantErr: "loopback, private or link-local"},
+		{url: "http://10.0.0.12/hook", wantErr: "loopback, private or link-local"},
+		{url: "http://100.100.100.200/latest/meta-data", wantErr: "loopback, private or link-local"},
+		{url: "http://100.128.0.1/hook"},
+		{url: "http://[::ffff:192.168.1.1]/hook", wantErr: "loopback, private or link-local"},
+		{url: "http://[fe80::1]/hook", wantErr: "loopback, private or link-local"},
+		{url: "http://0.0.0.0/hook", wantErr: "loopback, private or link-local"},
+		{url: "http://10.0.0.12/hook", allowed: []netip.Prefix{netip.MustParsePrefix("10.0.0.0/24")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			err := webhookGuard{allowed: tt.allowed}.checkURL(tt.url)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestPushNotificationDispatcherRefusesInternalAddresses(t *testing.T) {
+	delivered := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		delivered = true
+	}))
+	defer srv.Close()
+
+	// The connection is checked when dialed, whatever the URL passed when
+	// the config was registered.
+	d := NewPushNotificationDispatcher(newFakePushStore(), PushDispatcherConfig{})
+	_, err := d.send(context.Background(), &a2atype.PushConfig{URL: srv.URL}, &a2atype.Task{ID: "task-1"})
+	require.ErrorContains(t, err, "refusing to deliver push notification")
+	require.False(t, delivered)
+}
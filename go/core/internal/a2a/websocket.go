@@ -0,0 +1,339 @@
+package a2a
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// WebSocketPathSuffix is appended to an agent's A2A route to reach its
+// WebSocket endpoint, e.g. /api/a2a/{namespace}/{name}/ws.
+//
+// The endpoint tunnels JSON-RPC over one connection for UIs whose proxies
+// buffer or cut SSE: every text frame from the client is one JSON-RPC request,
+// answered exactly as the HTTP endpoint would answer it. Each event of a
+// streaming method (message/stream, tasks/resubscribe) arrives as its own
+// frame, followed by a WebSocketStreamEndMethod notification carrying the
+// request ID. Requests are independent, so many streams can share a
+// connection, and a stream cut by a dropped connection is resumed on a new
+// one with tasks/resubscribe and the task ID. Closing the connection cancels
+// the streams still running on it; their tasks keep running on the agent.
+const WebSocketPathSuffix = "/ws"
+
+// WebSocketStreamEndMethod is the JSON-RPC notification sent after the last
+// event of a streaming request. Its params are {"id": <request id>}.
+const WebSocketStreamEndMethod = "kagent/streamEnd"
+
+const (
+	// wsPingInterval is how often the server pings an idle client.
+	wsPingInterval = 30 * time.Second
+	// wsPongWait is how long the server waits for any frame, pongs included,
+	// before treating the connection as dead.
+	wsPongWait = 2 * wsPingInterval
+	// wsWriteWait bounds a single frame write.
+	wsWriteWait = 10 * time.Second
+	// wsCloseGrace bounds how long the server waits for running requests to
+	// wind down after the connection is closing.
+	wsCloseGrace = 5 * time.Second
+	// wsMaxMessageSize bounds one request frame; it matches the SSE line
+	// limit of the A2A library.
+	wsMaxMessageSize = 10 << 20
+)
+
+// newWebSocketHandler serves WebSocket upgrades on WebSocketPathSuffix by
+// tunneling each request frame through next, and passes every other request
+// to next unchanged. Upgrades from browsers on other origins than the API's
+// own and allowedOrigins are refused, see checkWebSocketOrigin.
+func newWebSocketHandler(next http.Handler, allowedOrigins []string) http.Handler {
+	wsUpgrader := websocket.Upgrader{
+		ReadBufferSize:  64 * 1024,
+		WriteBufferSize: 64 * 1024,
+		CheckOrigin:     checkWebSocketOrigin(allowedOrigins),
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, WebSocketPathSuffix) || !websocket.IsWebSocketUpgrade(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			// The upgrader has already written an HTTP error response.
+			return
+		}
+		newWSSession(conn, r, next).serve()
+	})
+}
+
+// checkWebSocketOrigin returns the origin check of WebSocket upgrades.
+// Browsers authenticate an upgrade with cookies, or with credentials a proxy
+// in front of kagent adds on their behalf, so a page on any other site could
+// open an authenticated socket. Upgrades are therefore only accepted from
+// the API's own origin and allowedOrigins ("*" allows any), unless the
+// client presents its credential itself in access_token, which other sites
+// do not know. Clients sending no Origin are not browsers and are accepted.
+func checkWebSocketOrigin(allowedOrigins []string) func(*http.Request) bool {
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			return true
+		}
+		if token := strings.TrimSpace(r.URL.Query().Get("access_token")); token != "" && r.Header.Get("Authorization") == "Bearer "+token {
+			return true
+		}
+		if u, err := url.Parse(origin); err == nil && strings.EqualFold(u.Host, r.Host) {
+			return true
+		}
+		return slices.ContainsFunc(allowedOrigins, func(allowed string) bool {
+			return allowed == "*" || strings.EqualFold(strings.TrimSuffix(allowed, "/"), origin)
+		})
+	}
+}
+
+type wsSession struct {
+	conn    *websocket.Conn
+	upgrade *http.Request
+	next    http.Handler
+
+	writeMu sync.Mutex
+}
+
+func newWSSession(conn *websocket.Conn, upgrade *http.Request, next http.Handler) *wsSession {
+	return &wsSession{conn: conn, upgrade: upgrade, next: next}
+}
+
+func (s *wsSession) serve() {
+	log := ctrllog.FromContext(s.upgrade.Context()).WithName("a2a-websocket")
+	ctx, cancel := context.WithCancel(s.upgrade.Context())
+	defer cancel()
+	defer s.conn.Close()
+
+	s.conn.SetReadLimit(wsMaxMessageSize)
+	_ = s.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	s.conn.SetPongHandler(func(string) error {
+		return s.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	})
+
+	var requests sync.WaitGroup
+	pingDone := make(chan struct{})
+	go s.ping(ctx, pingDone)
+
+	closeCode, closeText := websocket.CloseNormalClosure, ""
+	for {
+		messageType, frame, err := s.conn.ReadMessage()
+		if err != nil {
+			var closeErr *websocket.CloseError
+			if !errors.As(err, &closeErr) {
+				// Timeouts and broken connections: nothing more can be read,
+				// so tell the client why if it is still listening.
+				closeCode, closeText = websocket.CloseGoingAway, "connection idle or broken"
+				log.V(1).Info("websocket read failed", "error", err)
+			}
+			break
+		}
+		_ = s.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+		if messageType != websocket.TextMessage {
+			closeCode, closeText = websocket.CloseUnsupportedData, "only text frames carrying JSON-RPC requests are supported"
+			break
+		}
+		requests.Add(1)
+		go func() {
+			defer requests.Done()
+			s.handle(ctx, frame)
+		}()
+	}
+
+	// Requests stop writing once ctx is done; give them a moment so their
+	// handlers unwind before the connection goes away.
+	cancel()
+	waitTimeout(&requests, wsCloseGrace)
+	<-pingDone
+
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	_ = s.conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(closeCode, closeText), time.Now().Add(wsWriteWait))
+}
+
+func (s *wsSession) ping(ctx context.Context, done chan<- struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(wsPingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteWait)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (s *wsSession) write(frame []byte) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	_ = s.conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+	return s.conn.WriteMessage(websocket.TextMessage, frame)
+}
+
+// handle runs one JSON-RPC request frame through the HTTP handler chain and
+// forwards its response, event by event for streams.
+func (s *wsSession) handle(ctx context.Context, frame []byte) {
+	var envelope struct {
+		ID json.RawMessage `json:"id"`
+	}
+	if err := json.Unmarshal(frame, &envelope); err != nil {
+		_ = s.write(wsJSONRPCError(nil, -32700, "parse error: "+err.Error()))
+		return
+	}
+
+	req := s.upgrade.Clone(ctx)
+	req.Method = http.MethodPost
+	req.URL.Path = strings.TrimSuffix(req.URL.Path, WebSocketPathSuffix)
+	req.URL.RawPath = ""
+	req.Body = io.NopCloser(bytes.NewReader(frame))
+	req.ContentLength = int64(len(frame))
+	for _, h := range []string{"Connection", "Upgrade", "Sec-Websocket-Key", "Sec-Websocket-Version", "Sec-Websocket-Extensions", "Sec-Websocket-Protocol"} {
+		req.Header.Del(h)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json, text/event-stream")
+
+	rw := &wsResponseWriter{header: http.Header{}, send: s.write}
+	s.next.ServeHTTP(rw, req)
+	if err := rw.finish(envelope.ID); err != nil && ctx.Err() == nil {
+		ctrllog.FromContext(ctx).V(1).Info("failed to write websocket response", "error", err)
+	}
+}
+
+// wsResponseWriter turns the HTTP response of one tunneled request into
+// frames: every SSE data block of a stream, or the whole body otherwise.
+type wsResponseWriter struct {
+	header      http.Header
+	status      int
+	wroteHeader bool
+	streaming   bool
+	buf         bytes.Buffer
+	send        func([]byte) error
+	err         error
+}
+
+var _ http.Flusher = &wsResponseWriter{}
+
+func (w *wsResponseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *wsResponseWriter) WriteHeader(code int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	w.status = code
+	w.streaming = strings.HasPrefix(w.header.Get("Content-Type"), "text/event-stream")
+}
+
+func (w *wsResponseWriter) Write(p []byte) (int, error) {
+	w.WriteHeader(http.StatusOK)
+	if w.err != nil {
+		return 0, w.err
+	}
+	w.buf.Write(p)
+	if w.streaming {
+		w.drainEvents()
+	}
+	return len(p), w.err
+}
+
+func (w *wsResponseWriter) Flush() {
+	if w.streaming {
+		w.drainEvents()
+	}
+}
+
+// drainEvents sends the data of every complete SSE event in the buffer;
+// comments such as keep-alives are dropped, WebSocket pings replace them.
+func (w *wsResponseWriter) drainEvents() {
+	for w.err == nil {
+		event, rest, ok := bytes.Cut(w.buf.Bytes(), []byte("\n\n"))
+		if !ok {
+			return
+		}
+		var data [][]byte
+		for _, line := range bytes.Split(event, []byte("\n")) {
+			if value, ok := bytes.CutPrefix(line, []byte("data:")); ok {
+				data = append(data, bytes.TrimPrefix(value, []byte(" ")))
+			}
+		}
+		if len(data) > 0 {
+			w.err = w.send(bytes.Join(data, []byte("\n")))
+		}
+		remaining := bytes.Clone(rest)
+		w.buf.Reset()
+		w.buf.Write(remaining)
+	}
+}
+
+func (w *wsResponseWriter) finish(id json.RawMessage) error {
+	if w.err != nil {
+		return w.err
+	}
+	if w.streaming {
+		w.drainEvents()
+		if w.err != nil {
+			return w.err
+		}
+		end, _ := json.Marshal(map[string]any{
+			"jsonrpc": "2.0",
+			"method":  WebSocketStreamEndMethod,
+			"params":  map[string]json.RawMessage{"id": id},
+		})
+		return w.send(end)
+	}
+	body := bytes.TrimSpace(w.buf.Bytes())
+	if w.status >= http.StatusBadRequest || !json.Valid(body) {
+		// Errors outside JSON-RPC (bad wire version, auth, unknown agent) are
+		// plain HTTP responses; wrap them so the client can match them up.
+		message := string(body)
+		if message == "" {
+			message = http.StatusText(w.status)
+		}
+		return w.send(wsJSONRPCError(id, -32603, message))
+	}
+	return w.send(body)
+}
+
+func wsJSONRPCError(id json.RawMessage, code int, message string) []byte {
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+	frame, _ := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      id,
+		"error":   map[string]any{"code": code, "message": message},
+	})
+	return frame
+}
+
+func waitTimeout(wg *sync.WaitGroup, timeout time.Duration) {
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(timeout):
+	}
+}
@@ -0,0 +1,169 @@
+package a2a
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeJSONRPCHandler answers message/stream with two SSE events, unknown
+// agents with a plain HTTP error, and everything else with a JSON body.
+func fakeJSONRPCHandler(t *testing.T) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/a2a/kagent/agent", r.URL.Path)
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		require.NoError(t, json.Unmarshal(body, &req))
+
+		switch req.Method {
+		case "message/stream":
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.WriteHeader(http.StatusOK)
+			for i := range 2 {
+				fmt.Fprintf(w, "id: %d\ndata: {\"jsonrpc\":\"2.0\",\"id\":%s,\"result\":{\"n\":%d}}\n\n", i, req.ID, i)
+				w.(http.Flusher).Flush()
+			}
+			fmt.Fprint(w, ": keep-alive\n\n")
+		case "tasks/missing":
+			http.Error(w, "Agent kagent/agent not found", http.StatusNotFound)
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, "{\"jsonrpc\":\"2.0\",\"id\":%s,\"result\":{\"method\":%q}}\n", req.ID, req.Method)
+		}
+	})
+}
+
+func dialA2AWebSocket(t *testing.T, handler http.Handler) *websocket.Conn {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/api/a2a/kagent/agent" + WebSocketPathSuffix
+	conn, resp, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+type wsTestFrame struct {
+	ID     string          `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func readWSFrame(t *testing.T, conn *websocket.Conn) wsTestFrame {
+	t.Helper()
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	var frame wsTestFrame
+	require.NoError(t, conn.ReadJSON(&frame))
+	return frame
+}
+
+func TestWebSocketHandler_StreamsEventsThenEnd(t *testing.T) {
+	conn := dialA2AWebSocket(t, newWebSocketHandler(fakeJSONRPCHandler(t), nil))
+
+	require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(`{"jsonrpc":"2.0","id":"s1","method":"message/stream","params":{}}`)))
+
+	for i := range 2 {
+		frame := readWSFrame(t, conn)
+		assert.Equal(t, "s1", frame.ID)
+		assert.JSONEq(t, fmt.Sprintf(`{"n":%d}`, i), string(frame.Result))
+	}
+	end := readWSFrame(t, conn)
+	assert.Equal(t, WebSocketStreamEndMethod, end.Method)
+	assert.JSONEq(t, `{"id":"s1"}`, string(end.Params))
+}
+
+func TestWebSocketHandler_NonStreamingAndErrors(t *testing.T) {
+	conn := dialA2AWebSocket(t, newWebSocketHandler(fakeJSONRPCHandler(t), nil))
+
+	require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(`{"jsonrpc":"2.0","id":"g1","method":"tasks/get","params":{}}`)))
+	frame := readWSFrame(t, conn)
+	assert.Equal(t, "g1", frame.ID)
+	assert.JSONEq(t, `{"method":"tasks/get"}`, string(frame.Result))
+
+	require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(`{"jsonrpc":"2.0","id":"m1","method":"tasks/missing"}`)))
+	frame = readWSFrame(t, conn)
+	assert.Equal(t, "m1", frame.ID)
+	require.NotNil(t, frame.Error)
+	assert.Contains(t, frame.Error.Message, "not found")
+
+	require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(`not json`)))
+	frame = readWSFrame(t, conn)
+	require.NotNil(t, frame.Error)
+	assert.Contains(t, frame.Error.Message, "parse error")
+}
+
+func TestWebSocketHandler_ClosesGracefully(t *testing.T) {
+	conn := dialA2AWebSocket(t, newWebSocketHandler(fakeJSONRPCHandler(t), nil))
+
+	require.NoError(t, conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")))
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	_, _, err := conn.ReadMessage()
+	assert.True(t, websocket.IsCloseError(err, websocket.CloseNormalClosure), "server echoes a normal closure, got %v", err)
+}
+
+func TestWebSocketHandler_PassesThroughPlainRequests(t *testing.T) {
+	handler := newWebSocketHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}), nil)
+
+	for _, path := range []string{"/api/a2a/kagent/agent", "/api/a2a/kagent/agent/ws"} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, path, strings.NewReader("{}")))
+		assert.Equal(t, http.StatusTeapot, rec.Code, path)
+	}
+}
+
+func TestCheckWebSocketOrigin(t *testing.T) {
+	check := checkWebSocketOrigin([]string{"https://ui.example.com/"})
+	tests := []struct {
+		name          string
+		origin        string
+		query         string
+		authorization string
+		want          bool
+	}{
+		{name: "no origin", want: true},
+		{name: "same origin", origin: "https://kagent.example.com", want: true},
+		{name: "allowed origin", origin: "https://ui.example.com", want: true},
+		{name: "cross-site with cookies", origin: "https://evil.example.net"},
+		{name: "cross-site with proxy credentials", origin: "https://evil.example.net", authorization: "Bearer from-proxy"},
+		{name: "cross-site with a guessed access_token", origin: "https://evil.example.net", query: "?access_token=guess", authorization: "Bearer from-proxy"},
+		{name: "cross-site with its own access_token", origin: "https://evil.example.net", query: "?access_token=tok", authorization: "Bearer tok", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "http://kagent.example.com/api/a2a/kagent/agent/ws"+tt.query, nil)
+			if tt.origin != "" {
+				r.Header.Set("Origin", tt.origin)
+			}
+			if tt.authorization != "" {
+				r.Header.Set("Authorization", tt.authorization)
+			}
+			assert.Equal(t, tt.want, check(r))
+		})
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "http://kagent.example.com/api/a2a/kagent/agent/ws", nil)
+	r.Header.Set("Origin", "https://evil.example.net")
+	assert.True(t, checkWebSocketOrigin([]string{"*"})(r), "* allows any origin")
+}
@@ -0,0 +1,301 @@
+// Package agentlint checks Agent specs against kagent best practices. Each
+// rule has a stable ID and severity so CI pipelines can gate on findings
+// (e.g. fail on any error) independently of the message wording.
+package agentlint
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+)
+
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+// Rank orders severities from most (0) to least severe. Unknown severities
+// rank last.
+func (s Severity) Rank() int {
+	switch s {
+	case SeverityError:
+		return 0
+	case SeverityWarning:
+		return 1
+	case SeverityInfo:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// Finding is a single rule violation. Field is the JSON path of the offending
+// spec field.
+type Finding struct {
+	RuleID   string
+	Severity Severity
+	Field    string
+	Message  string
+}
+
+// Input is the agent to lint along with values the linter cannot derive from
+// the spec on its own.
+type Input struct {
+	Spec v1alpha2.AgentSpec
+	// SystemMessage is the resolved system prompt: spec.declarative.systemMessage,
+	// or the value spec.declarative.systemMessageFrom points at.
+	SystemMessage string
+	// Model is the model name of the agent's ModelConfig, when known.
+	Model string
+}
+
+// Rule is a named check over an agent spec.
+type Rule struct {
+	ID          string
+	Description string
+	check       func(in *Input) []Finding
+}
+
+// Rules lists every rule Lint runs, in ID order.
+var Rules = []Rule{
+	{
+		ID:          "broad-tool-access",
+		Description: "MCP server tools should list the tool names the agent needs instead of exposing every tool on the server.",
+		check:       checkBroadToolAccess,
+	},
+	{
+		ID:          "inline-secret-header",
+		Description: "Credential headers should be read from a Secret with valueFrom rather than written inline.",
+		check:       checkInlineSecretHeaders,
+	},
+	{
+		ID:          "missing-description",
+		Description: "Agents should have a description so A2A clients can discover what they do.",
+		check:       checkMissingDescription,
+	},
+	{
+		ID:          "missing-resource-limits",
+		Description: "Agent deployments that override resources should set CPU and memory limits.",
+		check:       checkResourceLimits,
+	},
+	{
+		ID:          "prompt-exceeds-context",
+		Description: "The system prompt should fit comfortably in the model's context window.",
+		check:       checkPromptSize,
+	},
+}
+
+// Lint runs every rule against in and returns the findings ordered by
+// severity, then rule ID, then field.
+func Lint(in Input) []Finding {
+	findings := []Finding{}
+	for _, rule := range Rules {
+		findings = append(findings, rule.check(&in)...)
+	}
+	slices.SortStableFunc(findings, func(a, b Finding) int {
+		return cmp.Or(
+			cmp.Compare(a.Severity.Rank(), b.Severity.Rank()),
+			cmp.Compare(a.RuleID, b.RuleID),
+			cmp.Compare(a.Field, b.Field),
+		)
+	})
+	return findings
+}
+
+func checkBroadToolAccess(in *Input) []Finding {
+	if in.Spec.Declarative == nil {
+		return nil
+	}
+	var findings []Finding
+	for i, tool := range in.Spec.Declarative.Tools {
+		if tool == nil || tool.McpServer == nil || len(tool.McpServer.ToolNames) > 0 {
+			continue
+		}
+		findings = append(findings, Finding{
+			RuleID:   "broad-tool-access",
+			Severity: SeverityWarning,
+			Field:    fmt.Sprintf("spec.declarative.tools[%d].mcpServer.toolNames", i),
+			Message:  fmt.Sprintf("agent can call every tool exposed by %s %q; list the tools it needs in toolNames", cmp.Or(tool.McpServer.Kind, "MCP server"), tool.McpServer.Name),
+		})
+	}
+	return findings
+}
+
+func checkInlineSecretHeaders(in *Input) []Finding {
+	if in.Spec.Declarative == nil {
+		return nil
+	}
+	var findings []Finding
+	for i, tool := range in.Spec.Declarative.Tools {
+		if tool == nil {
+			continue
+		}
+		for j, header := range tool.HeadersFrom {
+			if header.Value == "" || !isCredentialHeader(header.Name) {
+				continue
+			}
+			findings = append(findings, Finding{
+				RuleID:   "inline-secret-header",
+				Severity: SeverityError,
+				Field:    fmt.Sprintf("spec.declarative.tools[%d].headersFrom[%d].value", i, j),
+				Message:  fmt.Sprintf("header %q carries a credential inline; reference a Secret with valueFrom instead", header.Name),
+			})
+		}
+	}
+	return findings
+}
+
+func isCredentialHeader(name string) bool {
+	n := strings.ToLower(name)
+	if n == "authorization" || n == "proxy-authorization" || n == "cookie" {
+		return true
+	}
+	for _, marker := range []string{"token", "secret", "api-key", "apikey", "api_key", "password", "credential"} {
+		if strings.Contains(n, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+func checkMissingDescription(in *Input) []Finding {
+	if strings.TrimSpace(in.Spec.Description) != "" {
+		return nil
+	}
+	return []Finding{{
+		RuleID:   "missing-description",
+		Severity: SeverityWarning,
+		Field:    "spec.description",
+		Message:  "agent has no description; A2A clients and other agents rely on it to decide when to call this agent",
+	}}
+}
+
+func checkResourceLimits(in *Input) []Finding {
+	var resources *corev1.ResourceRequirements
+	field := ""
+	switch {
+	case in.Spec.Declarative != nil && in.Spec.Declarative.Deployment != nil:
+		resources = in.Spec.Declarative.Deployment.Resources
+		field = "spec.declarative.deployment.resources.limits"
+	case in.Spec.BYO != nil && in.Spec.BYO.Deployment != nil:
+		resources = in.Spec.BYO.Deployment.Resources
+		field = "spec.byo.deployment.resources.limits"
+	}
+	// Without a resources override the controller applies default requests
+	// and limits, so only a partial override can leave the pod unbounded.
+	if resources == nil {
+		return nil
+	}
+	var missing []string
+	for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+		if _, ok := resources.Limits[name]; !ok {
+			missing = append(missing, string(name))
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return []Finding{{
+		RuleID:   "missing-resource-limits",
+		Severity: SeverityWarning,
+		Field:    field,
+		Message:  fmt.Sprintf("resources override sets no %s limit; the agent pod can consume unbounded %s", strings.Join(missing, " or "), strings.Join(missing, " and ")),
+	}}
+}
+
+// contextWindows maps model name prefixes to context window sizes in tokens.
+// The longest matching prefix wins.
+var contextWindows = map[string]int{
+	"gpt-3.5-turbo":  16_385,
+	"gpt-4":          8_192,
+	"gpt-4-turbo":    128_000,
+	"gpt-4o":         128_000,
+	"gpt-4.1":        1_047_576,
+	"gpt-5":          400_000,
+	"o1":             200_000,
+	"o3":             200_000,
+	"o4-mini":        200_000,
+	"claude-":        200_000,
+	"gemini-1.5":     1_048_576,
+	"gemini-2":       1_048_576,
+	"llama3":         8_192,
+	"llama3.1":       128_000,
+	"llama3.2":       128_000,
+	"llama-3.1":      128_000,
+	"llama-3.2":      128_000,
+	"llama-3.3":      128_000,
+	"mistral":        32_768,
+	"qwen2.5":        32_768,
+	"deepseek-chat":  64_000,
+	"deepseek-coder": 128_000,
+	"command-r":      128_000,
+}
+
+// ContextWindow returns the context window of model in tokens, and false when
+// the model is not known. Provider prefixes such as "openai/" or Bedrock's
+// "us.anthropic." are ignored.
+func ContextWindow(model string) (int, bool) {
+	model = strings.ToLower(model)
+	if i := strings.LastIndex(model, "/"); i >= 0 {
+		model = model[i+1:]
+	}
+	candidates := []string{model}
+	for i, r := range model {
+		if r == '.' {
+			candidates = append(candidates, model[i+1:])
+		}
+	}
+	best := ""
+	for _, candidate := range candidates {
+		for prefix := range contextWindows {
+			if strings.HasPrefix(candidate, prefix) && len(prefix) > len(best) {
+				best = prefix
+			}
+		}
+	}
+	if best == "" {
+		return 0, false
+	}
+	return contextWindows[best], true
+}
+
+// estimateTokens approximates the token count of text at four characters per
+// token, which is close enough for English prose across common tokenizers.
+func estimateTokens(text string) int {
+	return (len(text) + 3) / 4
+}
+
+func checkPromptSize(in *Input) []Finding {
+	if in.SystemMessage == "" || in.Model == "" {
+		return nil
+	}
+	window, ok := ContextWindow(in.Model)
+	if !ok {
+		return nil
+	}
+	tokens := estimateTokens(in.SystemMessage)
+	switch {
+	case tokens > window:
+		return []Finding{{
+			RuleID:   "prompt-exceeds-context",
+			Severity: SeverityError,
+			Field:    "spec.declarative.systemMessage",
+			Message:  fmt.Sprintf("system prompt is ~%d tokens, more than the %d token context window of %s", tokens, window, in.Model),
+		}}
+	case tokens > window/2:
+		return []Finding{{
+			RuleID:   "prompt-exceeds-context",
+			Severity: SeverityWarning,
+			Field:    "spec.declarative.systemMessage",
+			Message:  fmt.Sprintf("system prompt is ~%d tokens, over half the %d token context window of %s, leaving little room for conversation and tool output", tokens, window, in.Model),
+		}}
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package agentlint
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func cleanSpec() v1alpha2.AgentSpec {
+	return v1alpha2.AgentSpec{
+		Type:        v1alpha2.AgentType_Declarative,
+		Description: "Answers questions about the cluster",
+		Declarative: &v1alpha2.DeclarativeAgentSpec{
+			SystemMessage: "You are a helpful agent.",
+			Tools: []*v1alpha2.Tool{{
+				Type: v1alpha2.ToolProviderType_McpServer,
+				McpServer: &v1alpha2.McpServerTool{
+					TypedReference: v1alpha2.TypedReference{Kind: "RemoteMCPServer", Name: "k8s"},
+					ToolNames:      []string{"get_pods"},
+				},
+			}},
+		},
+	}
+}
+
+func TestLint(t *testing.T) {
+	tests := []struct {
+		name      string
+		mutate    func(in *Input)
+		wantRules []string
+		wantSev   []Severity
+	}{
+		{
+			name:   "clean spec",
+			mutate: func(in *Input) {},
+		},
+		{
+			name: "all tools exposed",
+			mutate: func(in *Input) {
+				in.Spec.Declarative.Tools[0].McpServer.ToolNames = nil
+			},
+			wantRules: []string{"broad-tool-access"},
+			wantSev:   []Severity{SeverityWarning},
+		},
+		{
+			name: "inline credential header",
+			mutate: func(in *Input) {
+				in.Spec.Declarative.Tools[0].HeadersFrom = []v1alpha2.ValueRef{
+					{Name: "Authorization", Value: "Bearer abc"},
+					{Name: "X-Tenant", Value: "acme"},
+					{Name: "X-Api-Key", ValueFrom: &v1alpha2.ValueSource{Type: v1alpha2.SecretValueSource, Name: "s", Key: "k"}},
+				}
+			},
+			wantRules: []string{"inline-secret-header"},
+			wantSev:   []Severity{SeverityError},
+		},
+		{
+			name: "missing description",
+			mutate: func(in *Input) {
+				in.Spec.Description = ""
+			},
+			wantRules: []string{"missing-description"},
+			wantSev:   []Severity{SeverityWarning},
+		},
+		{
+			name: "resources override without limits",
+			mutate: func(in *Input) {
+				in.Spec.Declarative.Deployment = &v1alpha2.DeclarativeDeploymentSpec{
+					SharedDeploymentSpec: v1alpha2.SharedDeploymentSpec{
+						Resources: &corev1.ResourceRequirements{
+							Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+						},
+					},
+				}
+			},
+			wantRules: []string{"missing-resource-limits"},
+			wantSev:   []Severity{SeverityWarning},
+		},
+		{
+			name: "prompt larger than context window",
+			mutate: func(in *Input) {
+				in.Model = "gpt-4"
+				in.SystemMessage = strings.Repeat("word ", 8000)
+			},
+			wantRules: []string{"prompt-exceeds-context"},
+			wantSev:   []Severity{SeverityError},
+		},
+		{
+			name: "findings ordered by severity",
+			mutate: func(in *Input) {
+				in.Spec.Description = ""
+				in.Spec.Declarative.Tools[0].HeadersFrom = []v1alpha2.ValueRef{{Name: "Authorization", Value: "Bearer abc"}}
+			},
+			wantRules: []string{"inline-secret-header", "missing-description"},
+			wantSev:   []Severity{SeverityError, SeverityWarning},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := Input{Spec: cleanSpec(), Model: "gpt-4o"}
+			in.SystemMessage = in.Spec.Declarative.SystemMessage
+			tt.mutate(&in)
+
+			findings := Lint(in)
+			var rules []string
+			var severities []Severity
+			for _, f := range findings {
+				rules = append(rules, f.RuleID)
+				severities = append(severities, f.Severity)
+			}
+			require.Equal(t, tt.wantRules, rules)
+			require.Equal(t, tt.wantSev, severities)
+		})
+	}
+}
+
+func TestContextWindow(t *testing.T) {
+	tests := []struct {
+		model  string
+		want   int
+		wantOK bool
+	}{
+		{model: "gpt-4o-mini", want: 128_000, wantOK: true},
+		{model: "gpt-4", want: 8_192, wantOK: true},
+		{model: "openai/gpt-4.1", want: 1_047_576, wantOK: true},
+		{model: "us.anthropic.claude-sonnet-4-20250514-v1:0", want: 200_000, wantOK: true},
+		{model: "my-finetune"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.model, func(t *testing.T) {
+			got, ok := ContextWindow(tt.model)
+			require.Equal(t, tt.wantOK, ok)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
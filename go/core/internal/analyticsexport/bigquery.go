@@ -0,0 +1,134 @@
+package analyticsexport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+const (
+	defaultBigQueryEndpoint = "https://bigquery.googleapis.com"
+	bigQueryScope           = "https://www.googleapis.com/auth/bigquery.insertdata"
+
+	// bigQueryBatchSize is the number of rows sent per insertAll request,
+	// the batch size BigQuery recommends for streaming inserts.
+	bigQueryBatchSize = 500
+)
+
+// BigQuerySink streams rows into BigQuery tables through the tabledata
+// insertAll API, authenticating with Application Default Credentials
+// (Workload Identity, a mounted service account key, ...). Row IDs are sent
+// as insert IDs, so BigQuery drops rows exported twice on a best-effort
+// basis.
+type BigQuerySink struct {
+	client   *http.Client
+	endpoint string
+	project  string
+	dataset  string
+}
+
+var _ Sink = (*BigQuerySink)(nil)
+
+// NewBigQuerySink returns a BigQuerySink for the cfg.Database dataset of
+// cfg.Project.
+func NewBigQuerySink(ctx context.Context, cfg Config) (*BigQuerySink, error) {
+	if cfg.Project == "" || cfg.Database == "" {
+		return nil, fmt.Errorf("bigquery analytics export requires a project and a dataset")
+	}
+	tokens, err := google.DefaultTokenSource(ctx, bigQueryScope)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Google credentials: %w", err)
+	}
+	client := oauth2.NewClient(context.Background(), tokens)
+	client.Timeout = time.Minute
+	return newBigQuerySink(client, cfg), nil
+}
+
+func newBigQuerySink(client *http.Client, cfg Config) *BigQuerySink {
+	endpoint := cfg.Endpoint
+	if endpoint == "" {
+		endpoint = defaultBigQueryEndpoint
+	}
+	return &BigQuerySink{
+		client:   client,
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		project:  cfg.Project,
+		dataset:  cfg.Database,
+	}
+}
+
+type bigQueryInsertRequest struct {
+	Rows []bigQueryRow `json:"rows"`
+}
+
+type bigQueryRow struct {
+	InsertID string `json:"insertId,omitempty"`
+	JSON     any    `json:"json"`
+}
+
+type bigQueryInsertResponse struct {
+	InsertErrors []struct {
+		Index  int `json:"index"`
+		Errors []struct {
+			Reason  string `json:"reason"`
+			Message string `json:"message"`
+		} `json:"errors"`
+	} `json:"insertErrors"`
+}
+
+func (s *BigQuerySink) Insert(ctx context.Context, table string, rows []Row) error {
+	for start := 0; start < len(rows); start += bigQueryBatchSize {
+		if err := s.insertBatch(ctx, table, rows[start:min(start+bigQueryBatchSize, len(rows))]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *BigQuerySink) insertBatch(ctx context.Context, table string, rows []Row) error {
+	var body bigQueryInsertRequest
+	for _, row := range rows {
+		body.Rows = append(body.Rows, bigQueryRow{InsertID: row.ID, JSON: row.Data})
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s rows: %w", table, err)
+	}
+	rawURL := fmt.Sprintf("%s/bigquery/v2/projects/%s/datasets/%s/tables/%s/insertAll",
+		s.endpoint, url.PathEscape(s.project), url.PathEscape(s.dataset), url.PathEscape(table))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, rawURL, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to insert into %s: %w", table, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to insert into %s: %w", table, responseError(resp))
+	}
+	var result bigQueryInsertResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("failed to read insertAll response for %s: %w", table, err)
+	}
+	if len(result.InsertErrors) > 0 {
+		first := result.InsertErrors[0]
+		var reason string
+		if len(first.Errors) > 0 {
+			reason = first.Errors[0].Reason + ": " + first.Errors[0].Message
+		}
+		return fmt.Errorf("failed to insert %d of %d rows into %s, first at index %d: %s",
+			len(result.InsertErrors), len(rows), table, first.Index, reason)
+	}
+	return nil
+}
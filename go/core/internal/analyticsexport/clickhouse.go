@@ -0,0 +1,91 @@
+package analyticsexport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+const defaultClickHouseDatabase = "default"
+
+// ClickHouseSink inserts rows through the ClickHouse HTTP interface in the
+// JSONEachRow format.
+type ClickHouseSink struct {
+	client   *http.Client
+	endpoint string
+	database string
+	username string
+	password string
+}
+
+var _ Sink = (*ClickHouseSink)(nil)
+
+// NewClickHouseSink returns a ClickHouseSink for cfg.Endpoint.
+func NewClickHouseSink(cfg Config) (*ClickHouseSink, error) {
+	if cfg.Endpoint == "" {
+		return nil, fmt.Errorf("clickhouse analytics export requires an endpoint")
+	}
+	var password string
+	if cfg.PasswordFile != "" {
+		data, err := os.ReadFile(cfg.PasswordFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read ClickHouse password: %w", err)
+		}
+		password = strings.TrimSpace(string(data))
+	}
+	database := cfg.Database
+	if database == "" {
+		database = defaultClickHouseDatabase
+	}
+	return &ClickHouseSink{
+		client:   &http.Client{Timeout: time.Minute},
+		endpoint: strings.TrimSuffix(cfg.Endpoint, "/"),
+		database: database,
+		username: cfg.Username,
+		password: password,
+	}, nil
+}
+
+func (s *ClickHouseSink) Insert(ctx context.Context, table string, rows []Row) error {
+	var body bytes.Buffer
+	enc := json.NewEncoder(&body)
+	for _, row := range rows {
+		if err := enc.Encode(row.Data); err != nil {
+			return fmt.Errorf("failed to encode %s row %s: %w", table, row.ID, err)
+		}
+	}
+	q := url.Values{
+		"query": {fmt.Sprintf("INSERT INTO %s.%s FORMAT JSONEachRow", quoteIdentifier(s.database), quoteIdentifier(table))},
+		// Accept the RFC 3339 timestamps the records are encoded with.
+		"date_time_input_format": {"best_effort"},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint+"/?"+q.Encode(), &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	if s.username != "" {
+		req.Header.Set("X-ClickHouse-User", s.username)
+		req.Header.Set("X-ClickHouse-Key", s.password)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to insert into %s: %w", table, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to insert into %s: %w", table, responseError(resp))
+	}
+	return nil
+}
+
+// quoteIdentifier quotes a ClickHouse database or table name.
+func quoteIdentifier(name string) string {
+	return "`" + strings.ReplaceAll(strings.ReplaceAll(name, `\`, `\\`), "`", "\\`") + "`"
+}
@@ -0,0 +1,296 @@
+package analyticsexport
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/a2aproject/a2a-go/v2/a2a"
+	"github.com/prometheus/client_golang/prometheus"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kagent-dev/kagent/go/api/database"
+	"github.com/kagent-dev/kagent/go/core/internal/utils"
+)
+
+// Tables the Exporter inserts into.
+const (
+	TableSessions   = "kagent_sessions"
+	TableTasks      = "kagent_tasks"
+	TableTokenUsage = "kagent_token_usage"
+	TableToolCalls  = "kagent_tool_calls"
+)
+
+var (
+	exportedRows = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kagent_analytics_export_rows_total",
+		Help: "Rows inserted into the analytics warehouse, by table.",
+	}, []string{"table"})
+	exportFailures = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "kagent_analytics_export_failures_total",
+		Help: "Analytics export runs that failed and are retried on the next run.",
+	})
+)
+
+// Collectors returns the analytics export metrics, for registration with
+// the controller's metrics registry.
+func Collectors() []prometheus.Collector {
+	return []prometheus.Collector{exportedRows, exportFailures}
+}
+
+// usageMetadataKeys are the message metadata keys the Python and Go agent
+// runtimes report an LLM call's token usage under.
+var usageMetadataKeys = []string{"kagent_usage_metadata", "adk_usage_metadata"}
+
+// SessionRecord is a row of kagent_sessions.
+type SessionRecord struct {
+	SessionID string    `json:"session_id"`
+	UserID    string    `json:"user_id"`
+	Agent     string    `json:"agent"`
+	Name      string    `json:"name"`
+	Source    string    `json:"source"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TaskRecord is a row of kagent_tasks. DurationMs is the time from the
+// task's creation to its last update.
+type TaskRecord struct {
+	TaskID     string    `json:"task_id"`
+	SessionID  string    `json:"session_id"`
+	Agent      string    `json:"agent"`
+	State      string    `json:"state"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+	DurationMs int64     `json:"duration_ms"`
+}
+
+// TokenUsageRecord is a row of kagent_token_usage: the tokens the agent
+// reported for the LLM calls of a task.
+type TokenUsageRecord struct {
+	TaskID           string    `json:"task_id"`
+	SessionID        string    `json:"session_id"`
+	Agent            string    `json:"agent"`
+	LLMCalls         int64     `json:"llm_calls"`
+	PromptTokens     int64     `json:"prompt_tokens"`
+	CompletionTokens int64     `json:"completion_tokens"`
+	TotalTokens      int64     `json:"total_tokens"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// ToolCallRecord is a row of kagent_tool_calls: the calls one agent made to
+// one tool server during one hour.
+type ToolCallRecord struct {
+	Hour           time.Time `json:"hour"`
+	ToolServer     string    `json:"tool_server"`
+	Agent          string    `json:"agent"`
+	CallCount      int64     `json:"call_count"`
+	ErrorCount     int64     `json:"error_count"`
+	LatencyBuckets []int64   `json:"latency_buckets"`
+}
+
+// Exporter periodically inserts the records that changed since its previous
+// run into Sink.
+type Exporter struct {
+	DB       database.Client
+	Sink     Sink
+	Interval time.Duration
+
+	now func() time.Time
+	// since is where the next run starts. A failed run leaves it in place so
+	// the next run retries the same records.
+	since time.Time
+}
+
+// NeedLeaderElection ensures only one replica exports records.
+func (e *Exporter) NeedLeaderElection() bool { return true }
+
+// NewExporter returns an Exporter that runs every interval; pass 0 to use
+// the default of 1 hour.
+func NewExporter(db database.Client, sink Sink, interval time.Duration) *Exporter {
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	return &Exporter{DB: db, Sink: sink, Interval: interval, now: time.Now}
+}
+
+// Start runs the export loop until ctx is cancelled. The first run covers
+// the interval before the exporter started, which a previous leader may not
+// have exported yet.
+func (e *Exporter) Start(ctx context.Context) error {
+	log := ctrllog.FromContext(ctx).WithName("analytics-export")
+	log.Info("Starting analytics export loop", "interval", e.Interval)
+	e.since = e.now().Add(-e.Interval)
+	ticker := time.NewTicker(e.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			e.runOnce(ctx)
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (e *Exporter) runOnce(ctx context.Context) {
+	log := ctrllog.FromContext(ctx).WithName("analytics-export")
+	until := e.now()
+	if err := e.Export(ctx, e.since, until); err != nil {
+		exportFailures.Inc()
+		log.Error(err, "Failed to export analytics records", "since", e.since)
+		return
+	}
+	e.since = until
+}
+
+// Export inserts the sessions and tasks updated since since, and the tool
+// calls of the hours between since and until that have ended.
+func (e *Exporter) Export(ctx context.Context, since, until time.Time) error {
+	sessions, err := e.DB.ListSessionsUpdatedSince(ctx, since)
+	if err != nil {
+		return err
+	}
+	tasks, err := e.DB.ListAgentTasksUpdatedSince(ctx, since)
+	if err != nil {
+		return err
+	}
+	toolCalls, err := e.toolCallRows(ctx, since, until)
+	if err != nil {
+		return err
+	}
+	taskRows, usageRows := taskRows(tasks)
+
+	for _, batch := range []struct {
+		table string
+		rows  []Row
+	}{
+		{TableSessions, sessionRows(sessions)},
+		{TableTasks, taskRows},
+		{TableTokenUsage, usageRows},
+		{TableToolCalls, toolCalls},
+	} {
+		if len(batch.rows) == 0 {
+			continue
+		}
+		if err := e.Sink.Insert(ctx, batch.table, batch.rows); err != nil {
+			return err
+		}
+		exportedRows.WithLabelValues(batch.table).Add(float64(len(batch.rows)))
+	}
+	return nil
+}
+
+func sessionRows(sessions []database.Session) []Row {
+	rows := make([]Row, 0, len(sessions))
+	for _, s := range sessions {
+		record := SessionRecord{
+			SessionID: s.ID,
+			UserID:    s.UserID,
+			CreatedAt: s.CreatedAt,
+			UpdatedAt: s.UpdatedAt,
+		}
+		if s.AgentID != nil {
+			record.Agent = utils.ConvertToKubernetesIdentifier(*s.AgentID)
+		}
+		if s.Name != nil {
+			record.Name = *s.Name
+		}
+		if s.Source != nil {
+			record.Source = string(*s.Source)
+		}
+		rows = append(rows, Row{ID: versionID(s.ID, s.UpdatedAt), Data: record})
+	}
+	return rows
+}
+
+// taskRows returns the task and token usage rows of tasks. Tasks without
+// reported usage have no token usage row.
+func taskRows(tasks []database.AgentTask) (taskRows, usageRows []Row) {
+	for _, t := range tasks {
+		agent := utils.ConvertToKubernetesIdentifier(t.AgentID)
+		id := versionID(string(t.Task.ID), t.UpdatedAt)
+		taskRows = append(taskRows, Row{ID: id, Data: TaskRecord{
+			TaskID:     string(t.Task.ID),
+			SessionID:  t.Task.ContextID,
+			Agent:      agent,
+			State:      string(t.Task.Status.State),
+			CreatedAt:  t.CreatedAt,
+			UpdatedAt:  t.UpdatedAt,
+			DurationMs: t.UpdatedAt.Sub(t.CreatedAt).Milliseconds(),
+		}})
+		if usage, ok := taskUsage(t.Task); ok {
+			usage.TaskID, usage.SessionID, usage.Agent, usage.UpdatedAt = string(t.Task.ID), t.Task.ContextID, agent, t.UpdatedAt
+			usageRows = append(usageRows, Row{ID: id, Data: usage})
+		}
+	}
+	return taskRows, usageRows
+}
+
+// taskUsage sums the token counts the agent reported on the messages of
+// task.
+func taskUsage(task *a2a.Task) (TokenUsageRecord, bool) {
+	var usage TokenUsageRecord
+	for _, msg := range task.History {
+		if msg == nil || msg.Role == a2a.MessageRoleUser {
+			continue
+		}
+		for _, key := range usageMetadataKeys {
+			counts, ok := msg.Metadata[key].(map[string]any)
+			if !ok {
+				continue
+			}
+			usage.LLMCalls++
+			usage.PromptTokens += tokenCount(counts, "promptTokenCount")
+			usage.CompletionTokens += tokenCount(counts, "candidatesTokenCount")
+			usage.TotalTokens += tokenCount(counts, "totalTokenCount")
+			break
+		}
+	}
+	return usage, usage.LLMCalls > 0
+}
+
+func tokenCount(counts map[string]any, key string) int64 {
+	n, _ := counts[key].(float64)
+	return int64(n)
+}
+
+// toolCallRows returns the tool call stats of every tool server for the
+// hours from the one since falls in up to the one until falls in, which is
+// still being recorded.
+func (e *Exporter) toolCallRows(ctx context.Context, since, until time.Time) ([]Row, error) {
+	servers, err := e.DB.ListToolServers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	current := until.Truncate(time.Hour)
+	var rows []Row
+	for _, server := range servers {
+		stats, err := e.DB.ListToolCallStats(ctx, server.Name, since)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range stats {
+			if !s.Hour.Before(current) {
+				continue
+			}
+			rows = append(rows, Row{
+				ID: fmt.Sprintf("%s/%s/%d", s.ToolServer, s.AgentID, s.Hour.Unix()),
+				Data: ToolCallRecord{
+					Hour:           s.Hour,
+					ToolServer:     s.ToolServer,
+					Agent:          utils.ConvertToKubernetesIdentifier(s.AgentID),
+					CallCount:      s.CallCount,
+					ErrorCount:     s.ErrorCount,
+					LatencyBuckets: s.LatencyBuckets,
+				},
+			})
+		}
+	}
+	return rows, nil
+}
+
+// versionID identifies the version of a record last updated at updatedAt.
+func versionID(id string, updatedAt time.Time) string {
+	return fmt.Sprintf("%s/%d", id, updatedAt.UnixNano())
+}
@@ -0,0 +1,170 @@
+package analyticsexport
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/v2/a2a"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kagent-dev/kagent/go/api/database"
+)
+
+// fakeDB implements the session, task and tool call stats methods of
+// database.Client.
+type fakeDB struct {
+	database.Client
+	sessions  []database.Session
+	tasks     []database.AgentTask
+	toolStats map[string][]database.ToolCallStats
+}
+
+func (f *fakeDB) ListSessionsUpdatedSince(_ context.Context, since time.Time) ([]database.Session, error) {
+	var sessions []database.Session
+	for _, s := range f.sessions {
+		if !s.UpdatedAt.Before(since) {
+			sessions = append(sessions, s)
+		}
+	}
+	return sessions, nil
+}
+
+func (f *fakeDB) ListAgentTasksUpdatedSince(_ context.Context, since time.Time) ([]database.AgentTask, error) {
+	var tasks []database.AgentTask
+	for _, t := range f.tasks {
+		if !t.UpdatedAt.Before(since) {
+			tasks = append(tasks, t)
+		}
+	}
+	return tasks, nil
+}
+
+func (f *fakeDB) ListToolServers(_ context.Context) ([]database.ToolServer, error) {
+	var servers []database.ToolServer
+	for name := range f.toolStats {
+		servers = append(servers, database.ToolServer{Name: name})
+	}
+	return servers, nil
+}
+
+func (f *fakeDB) ListToolCallStats(_ context.Context, toolServer string, since time.Time) ([]database.ToolCallStats, error) {
+	var stats []database.ToolCallStats
+	for _, s := range f.toolStats[toolServer] {
+		if !s.Hour.Before(since.Truncate(time.Hour)) {
+			stats = append(stats, s)
+		}
+	}
+	return stats, nil
+}
+
+// recordingSink keeps the rows inserted into each table, failing while err
+// is set.
+type recordingSink struct {
+	rows map[string][]Row
+	err  error
+}
+
+func (s *recordingSink) Insert(_ context.Context, table string, rows []Row) error {
+	if s.err != nil {
+		return s.err
+	}
+	if s.rows == nil {
+		s.rows = map[string][]Row{}
+	}
+	s.rows[table] = append(s.rows[table], rows...)
+	return nil
+}
+
+func TestExport(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 30, 0, 0, time.UTC)
+	agent, name := "kagent__NS__triage_agent", "Crashloop"
+	db := &fakeDB{
+		sessions: []database.Session{
+			{ID: "s1", UserID: "alice", AgentID: &agent, Name: &name, CreatedAt: now.Add(-2 * time.Hour), UpdatedAt: now.Add(-10 * time.Minute)},
+			{ID: "old", UserID: "bob", UpdatedAt: now.Add(-3 * time.Hour)},
+		},
+		tasks: []database.AgentTask{{
+			AgentID: agent,
+			Task: &a2a.Task{
+				ID:        "t1",
+				ContextID: "s1",
+				Status:    a2a.TaskStatus{State: a2a.TaskStateCompleted},
+				History: []*a2a.Message{
+					{Role: a2a.MessageRoleUser},
+					{Role: a2a.MessageRoleAgent, Metadata: map[string]any{"kagent_usage_metadata": map[string]any{"promptTokenCount": 100.0, "candidatesTokenCount": 20.0, "totalTokenCount": 120.0}}},
+					{Role: a2a.MessageRoleAgent, Metadata: map[string]any{"adk_usage_metadata": map[string]any{"promptTokenCount": 150.0, "candidatesTokenCount": 30.0, "totalTokenCount": 180.0}}},
+				},
+			},
+			CreatedAt: now.Add(-20 * time.Minute),
+			UpdatedAt: now.Add(-15 * time.Minute),
+		}},
+		toolStats: map[string][]database.ToolCallStats{
+			"kagent/tools": {
+				{Hour: now.Add(-90 * time.Minute).Truncate(time.Hour), ToolServer: "kagent/tools", AgentID: agent, CallCount: 4, ErrorCount: 1, LatencyBuckets: []int64{3, 1}},
+				{Hour: now.Truncate(time.Hour), ToolServer: "kagent/tools", AgentID: agent, CallCount: 2},
+			},
+		},
+	}
+	sink := &recordingSink{}
+	exporter := NewExporter(db, sink, 0)
+	require.NoError(t, exporter.Export(context.Background(), now.Add(-time.Hour), now))
+
+	require.Len(t, sink.rows[TableSessions], 1)
+	assert.Equal(t, SessionRecord{
+		SessionID: "s1",
+		UserID:    "alice",
+		Agent:     "kagent/triage-agent",
+		Name:      "Crashloop",
+		CreatedAt: now.Add(-2 * time.Hour),
+		UpdatedAt: now.Add(-10 * time.Minute),
+	}, sink.rows[TableSessions][0].Data)
+
+	require.Len(t, sink.rows[TableTasks], 1)
+	assert.Equal(t, TaskRecord{
+		TaskID:     "t1",
+		SessionID:  "s1",
+		Agent:      "kagent/triage-agent",
+		State:      string(a2a.TaskStateCompleted),
+		CreatedAt:  now.Add(-20 * time.Minute),
+		UpdatedAt:  now.Add(-15 * time.Minute),
+		DurationMs: (5 * time.Minute).Milliseconds(),
+	}, sink.rows[TableTasks][0].Data)
+
+	require.Len(t, sink.rows[TableTokenUsage], 1)
+	assert.Equal(t, TokenUsageRecord{
+		TaskID:           "t1",
+		SessionID:        "s1",
+		Agent:            "kagent/triage-agent",
+		LLMCalls:         2,
+		PromptTokens:     250,
+		CompletionTokens: 50,
+		TotalTokens:      300,
+		UpdatedAt:        now.Add(-15 * time.Minute),
+	}, sink.rows[TableTokenUsage][0].Data)
+
+	require.Len(t, sink.rows[TableToolCalls], 1, "the current hour is still being recorded")
+	assert.Equal(t, int64(4), sink.rows[TableToolCalls][0].Data.(ToolCallRecord).CallCount)
+}
+
+func TestExporterRetriesFailedRuns(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	db := &fakeDB{sessions: []database.Session{{ID: "s1", UserID: "alice", UpdatedAt: now.Add(-30 * time.Minute)}}}
+	sink := &recordingSink{err: errors.New("warehouse unavailable")}
+	exporter := NewExporter(db, sink, time.Hour)
+	exporter.now = func() time.Time { return now }
+	exporter.since = now.Add(-time.Hour)
+
+	exporter.runOnce(context.Background())
+	assert.Empty(t, sink.rows)
+
+	sink.err = nil
+	now = now.Add(time.Hour)
+	exporter.runOnce(context.Background())
+	assert.Len(t, sink.rows[TableSessions], 1, "the failed run's records are exported by the next run")
+
+	exporter.runOnce(context.Background())
+	assert.Len(t, sink.rows[TableSessions], 1, "records are exported once")
+}
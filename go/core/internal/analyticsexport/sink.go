@@ -0,0 +1,78 @@
+// Package analyticsexport ships session, task, token usage and tool call
+// records from the operational database to an analytics warehouse, where
+// they are kept for long-term product analytics after retention has pruned
+// them from the database.
+//
+// The Exporter runs on the leader every interval and inserts the records
+// that changed since its previous run into four tables of a Sink:
+// kagent_sessions, kagent_tasks, kagent_token_usage and kagent_tool_calls.
+// Sessions and tasks are exported again each time they are updated, so
+// analyses should keep the row with the latest updated_at per id, e.g. with
+// a ClickHouse ReplacingMergeTree. Tool calls are exported once per
+// completed hour. The tables must exist; their columns are the JSON names
+// of the record types.
+package analyticsexport
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	BackendClickHouse = "clickhouse"
+	BackendBigQuery   = "bigquery"
+)
+
+// Row is a record inserted into a warehouse table.
+type Row struct {
+	// ID identifies this version of the record, for sinks that drop
+	// duplicate inserts.
+	ID string
+	// Data is encoded as one JSON object.
+	Data any
+}
+
+// Sink inserts rows into the tables of an analytics warehouse.
+type Sink interface {
+	Insert(ctx context.Context, table string, rows []Row) error
+}
+
+// Config selects and configures a Sink.
+type Config struct {
+	// Backend is one of clickhouse or bigquery. An empty backend disables
+	// the export.
+	Backend string
+	// Endpoint is the ClickHouse HTTP interface URL, or overrides the
+	// BigQuery API endpoint.
+	Endpoint string
+	// Database is the ClickHouse database or the BigQuery dataset the
+	// tables are in.
+	Database string
+	// Project is the Google Cloud project of the BigQuery dataset.
+	Project string
+	// Username and PasswordFile authenticate with ClickHouse.
+	Username     string
+	PasswordFile string
+}
+
+// NewSink returns the Sink selected by cfg, or nil when cfg.Backend is empty.
+func NewSink(ctx context.Context, cfg Config) (Sink, error) {
+	switch cfg.Backend {
+	case "":
+		return nil, nil
+	case BackendClickHouse:
+		return NewClickHouseSink(cfg)
+	case BackendBigQuery:
+		return NewBigQuerySink(ctx, cfg)
+	default:
+		return nil, fmt.Errorf("unknown analytics export backend %q: must be one of %s, %s", cfg.Backend, BackendClickHouse, BackendBigQuery)
+	}
+}
+
+func responseError(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("unexpected status %s: %s", resp.Status, strings.TrimSpace(string(body)))
+}
@@ -0,0 +1,90 @@
+package analyticsexport
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClickHouseSink(t *testing.T) {
+	var query, user, key string
+	var lines []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query().Get("query")
+		user, key = r.Header.Get("X-ClickHouse-User"), r.Header.Get("X-ClickHouse-Key")
+		body, _ := io.ReadAll(r.Body)
+		lines = strings.Split(strings.TrimSpace(string(body)), "\n")
+		if strings.Contains(query, "missing") {
+			http.Error(w, "Code: 60. DB::Exception: Table analytics.missing does not exist", http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	passwordFile := filepath.Join(t.TempDir(), "password")
+	require.NoError(t, os.WriteFile(passwordFile, []byte("s3cret\n"), 0o600))
+	sink, err := NewClickHouseSink(Config{Endpoint: server.URL, Database: "analytics", Username: "kagent", PasswordFile: passwordFile})
+	require.NoError(t, err)
+
+	rows := []Row{{ID: "s1", Data: SessionRecord{SessionID: "s1"}}, {ID: "s2", Data: SessionRecord{SessionID: "s2"}}}
+	require.NoError(t, sink.Insert(context.Background(), TableSessions, rows))
+	assert.Equal(t, "INSERT INTO `analytics`.`kagent_sessions` FORMAT JSONEachRow", query)
+	assert.Equal(t, "kagent", user)
+	assert.Equal(t, "s3cret", key)
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[1], `"session_id":"s2"`)
+
+	err = sink.Insert(context.Background(), "missing", rows)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not exist")
+}
+
+func TestBigQuerySink(t *testing.T) {
+	var paths []string
+	var batches []bigQueryInsertRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		var body bigQueryInsertRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		batches = append(batches, body)
+		if strings.Contains(r.URL.Path, TableTasks) {
+			_, _ = io.WriteString(w, `{"insertErrors":[{"index":3,"errors":[{"reason":"invalid","message":"no such field: extra"}]}]}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{}`)
+	}))
+	defer server.Close()
+
+	sink := newBigQuerySink(server.Client(), Config{Endpoint: server.URL, Project: "acme", Database: "kagent"})
+	rows := make([]Row, bigQueryBatchSize+1)
+	for i := range rows {
+		rows[i] = Row{ID: "s", Data: SessionRecord{SessionID: "s"}}
+	}
+	require.NoError(t, sink.Insert(context.Background(), TableSessions, rows))
+	require.Len(t, batches, 2)
+	assert.Equal(t, "/bigquery/v2/projects/acme/datasets/kagent/tables/kagent_sessions/insertAll", paths[0])
+	assert.Len(t, batches[0].Rows, bigQueryBatchSize)
+	assert.Len(t, batches[1].Rows, 1)
+	assert.Equal(t, "s", batches[1].Rows[0].InsertID)
+
+	err := sink.Insert(context.Background(), TableTasks, rows[:5])
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no such field: extra")
+}
+
+func TestNewSinkRejectsUnknownBackends(t *testing.T) {
+	sink, err := NewSink(context.Background(), Config{})
+	require.NoError(t, err)
+	assert.Nil(t, sink)
+
+	_, err = NewSink(context.Background(), Config{Backend: "snowflake"})
+	assert.ErrorContains(t, err, "unknown analytics export backend")
+}
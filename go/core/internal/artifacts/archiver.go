@@ -0,0 +1,291 @@
+package artifacts
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+
+	a2a "github.com/a2aproject/a2a-go/v2/a2a"
+	"github.com/hashicorp/go-multierror"
+)
+
+const (
+	// ContentTypeMetadataKey records, on an archived artifact, the content
+	// type its stored object is served with.
+	ContentTypeMetadataKey = "kagent_artifact_content_type"
+	// SizeMetadataKey records, on an archived artifact, the size in bytes of
+	// its stored object.
+	SizeMetadataKey = "kagent_artifact_size"
+
+	// DefaultInlineLimit is the largest encoded artifact kept inline in the
+	// persisted task after it has been archived.
+	DefaultInlineLimit = 64 * 1024
+
+	keyPrefix           = "tasks/"
+	attachmentKeyPrefix = "attachments/"
+)
+
+// keyPrefixes are the prefixes of every object the archiver stores.
+var keyPrefixes = []string{keyPrefix, attachmentKeyPrefix}
+
+// Archiver uploads the artifacts of finished tasks to a Store.
+type Archiver struct {
+	store       Store
+	inlineLimit int
+}
+
+// NewArchiver returns an Archiver writing to store. Archived artifacts whose
+// encoded size exceeds inlineLimit bytes have their parts replaced by a link
+// to the stored copy; pass 0 to use DefaultInlineLimit, or a negative value to
+// always keep artifacts inline.
+func NewArchiver(store Store, inlineLimit int) *Archiver {
+	if inlineLimit == 0 {
+		inlineLimit = DefaultInlineLimit
+	}
+	return &Archiver{store: store, inlineLimit: inlineLimit}
+}
+
+// ArchiveTask uploads the artifacts of task, and the files users attached to
+// its messages, once it has reached a terminal state. Oversized artifacts
+// and attachments are rewritten in place so the caller persists a link
+// instead of their content. Those archived by an earlier call are skipped,
+// so a task may be stored repeatedly.
+func (a *Archiver) ArchiveTask(ctx context.Context, task *a2a.Task) error {
+	if !task.Status.State.Terminal() {
+		return nil
+	}
+	var errs *multierror.Error
+	for _, artifact := range task.Artifacts {
+		if artifact == nil {
+			continue
+		}
+		if _, archived := artifact.Metadata[ContentTypeMetadataKey]; archived {
+			continue
+		}
+		body, contentType, ok := EncodeArtifact(artifact)
+		if !ok {
+			continue
+		}
+		key, err := objectKey(task.ID, artifact.ID)
+		if err != nil {
+			errs = multierror.Append(errs, err)
+			continue
+		}
+		if err := a.store.Put(ctx, key, body, contentType); err != nil {
+			errs = multierror.Append(errs, err)
+			continue
+		}
+		if artifact.Metadata == nil {
+			artifact.Metadata = map[string]any{}
+		}
+		artifact.Metadata[ContentTypeMetadataKey] = contentType
+		artifact.Metadata[SizeMetadataKey] = len(body)
+		if a.inlineLimit >= 0 && len(body) > a.inlineLimit {
+			artifact.Parts = a2a.ContentParts{
+				a2a.NewFileURLPart(a2a.URL(ArtifactPath(task.ID, artifact)), contentType),
+			}
+		}
+	}
+	for _, msg := range task.History {
+		if msg == nil || msg.Role != a2a.MessageRoleUser {
+			continue
+		}
+		for i, part := range msg.Parts {
+			if err := a.archiveAttachment(ctx, task.ID, msg.ID, i, part); err != nil {
+				errs = multierror.Append(errs, err)
+			}
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
+// archiveAttachment uploads part when it is a file attached inline to a
+// user message, and replaces its content by a link when it is oversized.
+func (a *Archiver) archiveAttachment(ctx context.Context, taskID a2a.TaskID, messageID string, index int, part *a2a.Part) error {
+	if part == nil {
+		return nil
+	}
+	raw, ok := part.Content.(a2a.Raw)
+	if !ok {
+		return nil
+	}
+	if _, archived := part.Metadata[ContentTypeMetadataKey]; archived {
+		return nil
+	}
+	key, err := attachmentKey(taskID, messageID, index)
+	if err != nil {
+		return err
+	}
+	contentType := mediaTypeOr(part, "application/octet-stream")
+	if err := a.store.Put(ctx, key, raw, contentType); err != nil {
+		return err
+	}
+	part.SetMeta(ContentTypeMetadataKey, contentType)
+	part.SetMeta(SizeMetadataKey, len(raw))
+	if a.inlineLimit >= 0 && len(raw) > a.inlineLimit {
+		part.Content = a2a.URL(AttachmentPath(taskID, messageID, index))
+		part.MediaType = contentType
+	}
+	return nil
+}
+
+// Open returns the stored copy of an archived artifact.
+func (a *Archiver) Open(ctx context.Context, taskID a2a.TaskID, artifactID a2a.ArtifactID) (io.ReadCloser, *Object, error) {
+	key, err := objectKey(taskID, artifactID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return a.store.Get(ctx, key)
+}
+
+// OpenAttachment returns the stored copy of the file attached in part index
+// of a user message of a task.
+func (a *Archiver) OpenAttachment(ctx context.Context, taskID a2a.TaskID, messageID string, index int) (io.ReadCloser, *Object, error) {
+	key, err := attachmentKey(taskID, messageID, index)
+	if err != nil {
+		return nil, nil, err
+	}
+	return a.store.Get(ctx, key)
+}
+
+// DeleteTask removes every stored artifact and attachment of a task.
+func (a *Archiver) DeleteTask(ctx context.Context, taskID a2a.TaskID) error {
+	segment, err := keySegment(string(taskID))
+	if err != nil {
+		return fmt.Errorf("invalid task id: %w", err)
+	}
+	var errs *multierror.Error
+	for _, prefix := range keyPrefixes {
+		objects, err := a.store.List(ctx, prefix+segment+"/")
+		if err != nil {
+			errs = multierror.Append(errs, err)
+			continue
+		}
+		for _, obj := range objects {
+			if err := a.store.Delete(ctx, obj.Key); err != nil {
+				errs = multierror.Append(errs, err)
+			}
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
+// ArtifactPath is the HTTP API path an artifact is served from. Artifacts are
+// addressed by name, falling back to their id when unnamed.
+func ArtifactPath(taskID a2a.TaskID, artifact *a2a.Artifact) string {
+	name := artifact.Name
+	if name == "" {
+		name = string(artifact.ID)
+	}
+	return "/api/tasks/" + url.PathEscape(string(taskID)) + "/artifacts/" + url.PathEscape(name)
+}
+
+// AttachmentPath is the HTTP API path the file attached in part index of a
+// user message of a task is served from.
+func AttachmentPath(taskID a2a.TaskID, messageID string, index int) string {
+	return "/api/tasks/" + url.PathEscape(string(taskID)) + "/attachments/" + url.PathEscape(messageID) + "/" + strconv.Itoa(index)
+}
+
+// EncodeArtifact returns the content an artifact is stored and served as:
+// the bytes of a single raw part, the concatenated text of text-only parts,
+// or the JSON-encoded parts otherwise. It reports false for artifacts with no
+// content of their own, e.g. ones that only link to files.
+func EncodeArtifact(artifact *a2a.Artifact) ([]byte, string, bool) {
+	parts := artifact.Parts
+	if len(parts) == 0 {
+		return nil, "", false
+	}
+	if len(parts) == 1 {
+		if raw, ok := parts[0].Content.(a2a.Raw); ok {
+			return raw, mediaTypeOr(parts[0], "application/octet-stream"), true
+		}
+	}
+
+	allText, allURL := true, true
+	for _, part := range parts {
+		switch part.Content.(type) {
+		case a2a.Text:
+			allURL = false
+		case a2a.URL:
+			allText = false
+		default:
+			allText, allURL = false, false
+		}
+	}
+	switch {
+	case allURL:
+		return nil, "", false
+	case allText:
+		var b strings.Builder
+		for _, part := range parts {
+			b.WriteString(part.Text())
+		}
+		contentType := "text/plain; charset=utf-8"
+		if len(parts) == 1 {
+			contentType = mediaTypeOr(parts[0], contentType)
+		}
+		return []byte(b.String()), contentType, true
+	}
+	body, err := json.Marshal(parts)
+	if err != nil {
+		return nil, "", false
+	}
+	return body, "application/json", true
+}
+
+func mediaTypeOr(part *a2a.Part, fallback string) string {
+	if part.MediaType != "" {
+		return part.MediaType
+	}
+	return fallback
+}
+
+// taskPrefix is the key prefix under which a task's artifacts are stored.
+func taskPrefix(taskID a2a.TaskID) (string, error) {
+	segment, err := keySegment(string(taskID))
+	if err != nil {
+		return "", fmt.Errorf("invalid task id: %w", err)
+	}
+	return keyPrefix + segment + "/", nil
+}
+
+func objectKey(taskID a2a.TaskID, artifactID a2a.ArtifactID) (string, error) {
+	prefix, err := taskPrefix(taskID)
+	if err != nil {
+		return "", err
+	}
+	segment, err := keySegment(string(artifactID))
+	if err != nil {
+		return "", fmt.Errorf("invalid artifact id: %w", err)
+	}
+	return prefix + segment, nil
+}
+
+func attachmentKey(taskID a2a.TaskID, messageID string, index int) (string, error) {
+	task, err := keySegment(string(taskID))
+	if err != nil {
+		return "", fmt.Errorf("invalid task id: %w", err)
+	}
+	message, err := keySegment(messageID)
+	if err != nil {
+		return "", fmt.Errorf("invalid message id: %w", err)
+	}
+	return attachmentKeyPrefix + task + "/" + message + "/" + strconv.Itoa(index), nil
+}
+
+// keySegment escapes an id for use as a single key segment.
+func keySegment(id string) (string, error) {
+	if id == "" {
+		return "", errors.New("must not be empty")
+	}
+	segment := url.PathEscape(id)
+	if segment == "." || segment == ".." {
+		return "", fmt.Errorf("%q is not a valid key segment", id)
+	}
+	return segment, nil
+}
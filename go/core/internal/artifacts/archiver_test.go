@@ -0,0 +1,202 @@
+package artifacts
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	a2a "github.com/a2aproject/a2a-go/v2/a2a"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeArtifact(t *testing.T) {
+	tests := []struct {
+		name            string
+		parts           a2a.ContentParts
+		wantBody        string
+		wantContentType string
+		wantOK          bool
+	}{
+		{name: "no parts"},
+		{
+			name:            "text parts are concatenated",
+			parts:           a2a.ContentParts{a2a.NewTextPart("hello "), a2a.NewTextPart("world")},
+			wantBody:        "hello world",
+			wantContentType: "text/plain; charset=utf-8",
+			wantOK:          true,
+		},
+		{
+			name:            "single text part keeps its media type",
+			parts:           a2a.ContentParts{{Content: a2a.Text("kind: Pod"), MediaType: "application/yaml"}},
+			wantBody:        "kind: Pod",
+			wantContentType: "application/yaml",
+			wantOK:          true,
+		},
+		{
+			name:            "raw part",
+			parts:           a2a.ContentParts{{Content: a2a.Raw("\x89PNG"), MediaType: "image/png"}},
+			wantBody:        "\x89PNG",
+			wantContentType: "image/png",
+			wantOK:          true,
+		},
+		{
+			name:            "raw part without media type",
+			parts:           a2a.ContentParts{a2a.NewRawPart([]byte("bytes"))},
+			wantBody:        "bytes",
+			wantContentType: "application/octet-stream",
+			wantOK:          true,
+		},
+		{
+			name:            "mixed parts are JSON encoded",
+			parts:           a2a.ContentParts{a2a.NewTextPart("summary"), a2a.NewDataPart(map[string]any{"ok": true})},
+			wantBody:        `[{"text":"summary"},{"data":{"ok":true}}]`,
+			wantContentType: "application/json",
+			wantOK:          true,
+		},
+		{
+			name:  "links only",
+			parts: a2a.ContentParts{a2a.NewFileURLPart("https://example.com/report.pdf", "application/pdf")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, contentType, ok := EncodeArtifact(&a2a.Artifact{ID: "a1", Parts: tt.parts})
+			assert.Equal(t, tt.wantOK, ok)
+			if !tt.wantOK {
+				return
+			}
+			if tt.wantContentType == "application/json" {
+				assert.JSONEq(t, tt.wantBody, string(body))
+			} else {
+				assert.Equal(t, tt.wantBody, string(body))
+			}
+			assert.Equal(t, tt.wantContentType, contentType)
+		})
+	}
+}
+
+func TestArchiveTask(t *testing.T) {
+	ctx := context.Background()
+	newTask := func(state a2a.TaskState) *a2a.Task {
+		return &a2a.Task{
+			ID:     "task-1",
+			Status: a2a.TaskStatus{State: state},
+			Artifacts: []*a2a.Artifact{
+				{ID: "small", Name: "summary", Parts: a2a.ContentParts{a2a.NewTextPart("done")}},
+				{ID: "large", Name: "build log", Parts: a2a.ContentParts{a2a.NewTextPart(strings.Repeat("x", 100))}},
+			},
+		}
+	}
+
+	t.Run("running task is not archived", func(t *testing.T) {
+		store, err := NewLocalStore(t.TempDir())
+		require.NoError(t, err)
+		task := newTask(a2a.TaskStateWorking)
+		require.NoError(t, NewArchiver(store, 10).ArchiveTask(ctx, task))
+
+		objects, err := store.List(ctx, "")
+		require.NoError(t, err)
+		assert.Empty(t, objects)
+		assert.Nil(t, task.Artifacts[0].Metadata)
+	})
+
+	t.Run("completed task", func(t *testing.T) {
+		store, err := NewLocalStore(t.TempDir())
+		require.NoError(t, err)
+		archiver := NewArchiver(store, 10)
+		task := newTask(a2a.TaskStateCompleted)
+		require.NoError(t, archiver.ArchiveTask(ctx, task))
+
+		small, large := task.Artifacts[0], task.Artifacts[1]
+		assert.Equal(t, "done", small.Parts[0].Text(), "artifacts under the inline limit keep their content")
+		assert.Equal(t, "text/plain; charset=utf-8", small.Metadata[ContentTypeMetadataKey])
+		assert.Equal(t, 4, small.Metadata[SizeMetadataKey])
+
+		require.Len(t, large.Parts, 1)
+		assert.Equal(t, a2a.URL("/api/tasks/task-1/artifacts/build%20log"), large.Parts[0].URL())
+		assert.Equal(t, 100, large.Metadata[SizeMetadataKey])
+
+		body, obj, err := archiver.Open(ctx, task.ID, large.ID)
+		require.NoError(t, err)
+		defer body.Close()
+		content, err := io.ReadAll(body)
+		require.NoError(t, err)
+		assert.Equal(t, strings.Repeat("x", 100), string(content))
+		assert.Equal(t, int64(100), obj.Size)
+
+		// Storing the task again must not overwrite the archived copy with
+		// the link that replaced its content.
+		require.NoError(t, archiver.ArchiveTask(ctx, task))
+		objects, err := store.List(ctx, "")
+		require.NoError(t, err)
+		assert.Len(t, objects, 2)
+
+		require.NoError(t, archiver.DeleteTask(ctx, task.ID))
+		objects, err = store.List(ctx, "")
+		require.NoError(t, err)
+		assert.Empty(t, objects)
+		_, _, err = archiver.Open(ctx, task.ID, large.ID)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("negative inline limit keeps content", func(t *testing.T) {
+		store, err := NewLocalStore(t.TempDir())
+		require.NoError(t, err)
+		task := newTask(a2a.TaskStateFailed)
+		require.NoError(t, NewArchiver(store, -1).ArchiveTask(ctx, task))
+		assert.Equal(t, strings.Repeat("x", 100), task.Artifacts[1].Parts[0].Text())
+	})
+}
+
+func TestArchiveTaskAttachments(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+	archiver := NewArchiver(store, 10)
+
+	screenshot := &a2a.Part{Content: a2a.Raw(strings.Repeat("p", 100)), MediaType: "image/png", Filename: "dashboard.png"}
+	config := &a2a.Part{Content: a2a.Raw("a: 1"), MediaType: "application/yaml"}
+	question := a2a.NewMessage(a2a.MessageRoleUser, a2a.NewTextPart("why is this red?"), screenshot, config)
+	question.ID = "m1"
+	answer := a2a.NewMessage(a2a.MessageRoleAgent, &a2a.Part{Content: a2a.Raw(strings.Repeat("a", 100))})
+	task := &a2a.Task{ID: "task-1", Status: a2a.TaskStatus{State: a2a.TaskStateCompleted}, History: []*a2a.Message{question, answer}}
+	require.NoError(t, archiver.ArchiveTask(ctx, task))
+
+	assert.Equal(t, a2a.URL("/api/tasks/task-1/attachments/m1/1"), screenshot.URL(), "oversized attachments are replaced by a link")
+	assert.Equal(t, "image/png", screenshot.MediaType)
+	assert.Equal(t, "dashboard.png", screenshot.Filename)
+	assert.Equal(t, "a: 1", string(config.Raw()), "attachments under the inline limit keep their content")
+	assert.Equal(t, "application/yaml", config.Metadata[ContentTypeMetadataKey])
+	assert.Nil(t, answer.Parts[0].Metadata, "only files users attached are archived")
+
+	body, obj, err := archiver.OpenAttachment(ctx, task.ID, "m1", 1)
+	require.NoError(t, err)
+	content, err := io.ReadAll(body)
+	body.Close()
+	require.NoError(t, err)
+	assert.Equal(t, strings.Repeat("p", 100), string(content))
+	assert.Equal(t, int64(100), obj.Size)
+
+	require.NoError(t, archiver.ArchiveTask(ctx, task))
+	objects, err := store.List(ctx, attachmentKeyPrefix)
+	require.NoError(t, err)
+	assert.Len(t, objects, 2)
+
+	require.NoError(t, archiver.DeleteTask(ctx, task.ID))
+	objects, err = store.List(ctx, "")
+	require.NoError(t, err)
+	assert.Empty(t, objects)
+}
+
+func TestObjectKey(t *testing.T) {
+	key, err := objectKey("task/1", "../artifact")
+	require.NoError(t, err)
+	assert.Equal(t, "tasks/task%2F1/..%2Fartifact", key)
+
+	_, err = objectKey("..", "a")
+	assert.Error(t, err)
+	_, err = objectKey("task", "")
+	assert.Error(t, err)
+}
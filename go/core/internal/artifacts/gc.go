@@ -0,0 +1,96 @@
+package artifacts
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Collector periodically deletes stored artifacts older than MaxAge, then the
+// oldest remaining artifacts until their total size is at most MaxTotalBytes.
+// A zero limit disables that rule.
+type Collector struct {
+	Store         Store
+	Interval      time.Duration
+	MaxAge        time.Duration
+	MaxTotalBytes int64
+
+	now func() time.Time
+}
+
+// NeedLeaderElection ensures only one replica deletes artifacts.
+func (c *Collector) NeedLeaderElection() bool { return true }
+
+// NewCollector returns a Collector that runs every interval; pass 0 to use
+// the default of 1 hour.
+func NewCollector(store Store, interval, maxAge time.Duration, maxTotalBytes int64) *Collector {
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	return &Collector{Store: store, Interval: interval, MaxAge: maxAge, MaxTotalBytes: maxTotalBytes, now: time.Now}
+}
+
+// Start runs the collection loop until ctx is cancelled.
+func (c *Collector) Start(ctx context.Context) error {
+	log := ctrllog.FromContext(ctx).WithName("artifact-gc")
+	log.Info("Starting artifact garbage collection loop", "interval", c.Interval, "maxAge", c.MaxAge, "maxTotalBytes", c.MaxTotalBytes)
+	ticker := time.NewTicker(c.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.runOnce(ctx)
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (c *Collector) runOnce(ctx context.Context) {
+	log := ctrllog.FromContext(ctx).WithName("artifact-gc")
+	var objects []Object
+	for _, prefix := range keyPrefixes {
+		listed, err := c.Store.List(ctx, prefix)
+		if err != nil {
+			log.Error(err, "Failed to list artifacts")
+			return
+		}
+		objects = append(objects, listed...)
+	}
+	deleted := 0
+	for _, obj := range c.expired(objects) {
+		if err := c.Store.Delete(ctx, obj.Key); err != nil {
+			log.Error(err, "Failed to delete artifact", "key", obj.Key)
+			continue
+		}
+		deleted++
+	}
+	if deleted > 0 {
+		log.Info("Deleted artifacts", "count", deleted)
+	}
+}
+
+// expired returns the objects the age and size limits select for deletion.
+func (c *Collector) expired(objects []Object) []Object {
+	// Oldest first, so the size limit evicts the oldest artifacts.
+	slices.SortFunc(objects, func(a, b Object) int { return a.ModTime.Compare(b.ModTime) })
+
+	var total int64
+	for _, obj := range objects {
+		total += obj.Size
+	}
+	var expired []Object
+	cutoff := c.now().Add(-c.MaxAge)
+	for _, obj := range objects {
+		tooOld := c.MaxAge > 0 && obj.ModTime.Before(cutoff)
+		tooBig := c.MaxTotalBytes > 0 && total > c.MaxTotalBytes
+		if !tooOld && !tooBig {
+			break
+		}
+		expired = append(expired, obj)
+		total -= obj.Size
+	}
+	return expired
+}
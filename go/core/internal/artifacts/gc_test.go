@@ -0,0 +1,43 @@
+package artifacts
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollectorExpired(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	objects := func() []Object {
+		return []Object{
+			{Key: "tasks/t1/new", Size: 40, ModTime: now.Add(-time.Hour)},
+			{Key: "tasks/t1/old", Size: 10, ModTime: now.Add(-48 * time.Hour)},
+			{Key: "tasks/t2/mid", Size: 30, ModTime: now.Add(-12 * time.Hour)},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		maxAge        time.Duration
+		maxTotalBytes int64
+		want          []string
+	}{
+		{name: "no limits"},
+		{name: "age", maxAge: 24 * time.Hour, want: []string{"tasks/t1/old"}},
+		{name: "size evicts oldest first", maxTotalBytes: 45, want: []string{"tasks/t1/old", "tasks/t2/mid"}},
+		{name: "size already within limit", maxTotalBytes: 80},
+		{name: "age and size", maxAge: 24 * time.Hour, maxTotalBytes: 50, want: []string{"tasks/t1/old", "tasks/t2/mid"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCollector(nil, 0, tt.maxAge, tt.maxTotalBytes)
+			c.now = func() time.Time { return now }
+			var got []string
+			for _, obj := range c.expired(objects()) {
+				got = append(got, obj.Key)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package artifacts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+const (
+	defaultGCSEndpoint = "https://storage.googleapis.com"
+	gcsScope           = "https://www.googleapis.com/auth/devstorage.read_write"
+)
+
+// GCSStore keeps artifacts in a Google Cloud Storage bucket through the JSON
+// API, authenticating with Application Default Credentials (Workload
+// Identity, a mounted service account key, ...).
+type GCSStore struct {
+	client   *http.Client
+	endpoint string
+	bucket   string
+	prefix   string
+}
+
+var _ Store = (*GCSStore)(nil)
+
+// NewGCSStore returns a GCSStore for cfg.Bucket.
+func NewGCSStore(ctx context.Context, cfg Config) (*GCSStore, error) {
+	if cfg.Bucket == "" {
+		return nil, fmt.Errorf("gcs artifact store requires a bucket")
+	}
+	tokens, err := google.DefaultTokenSource(ctx, gcsScope)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Google credentials: %w", err)
+	}
+	client := oauth2.NewClient(context.Background(), tokens)
+	client.Timeout = time.Minute
+	return newGCSStore(client, cfg), nil
+}
+
+func newGCSStore(client *http.Client, cfg Config) *GCSStore {
+	endpoint := cfg.Endpoint
+	if endpoint == "" {
+		endpoint = defaultGCSEndpoint
+	}
+	return &GCSStore{
+		client:   client,
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		bucket:   cfg.Bucket,
+		prefix:   cfg.Prefix,
+	}
+}
+
+func (s *GCSStore) objectURL(key string) string {
+	return s.endpoint + "/storage/v1/b/" + url.PathEscape(s.bucket) + "/o/" + url.PathEscape(s.prefix+key)
+}
+
+func (s *GCSStore) do(ctx context.Context, method, rawURL string, body []byte, contentType string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	return s.client.Do(req)
+}
+
+func (s *GCSStore) Put(ctx context.Context, key string, body []byte, contentType string) error {
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	q := url.Values{"uploadType": {"media"}, "name": {s.prefix + key}}
+	rawURL := s.endpoint + "/upload/storage/v1/b/" + url.PathEscape(s.bucket) + "/o?" + q.Encode()
+	resp, err := s.do(ctx, http.MethodPost, rawURL, body, contentType)
+	if err != nil {
+		return fmt.Errorf("failed to upload artifact %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to upload artifact %s: %w", key, responseError(resp))
+	}
+	return nil
+}
+
+func (s *GCSStore) Get(ctx context.Context, key string) (io.ReadCloser, *Object, error) {
+	resp, err := s.do(ctx, http.MethodGet, s.objectURL(key)+"?alt=media", nil, "")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to download artifact %s: %w", key, err)
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, nil, ErrNotFound
+	default:
+		defer resp.Body.Close()
+		return nil, nil, fmt.Errorf("failed to download artifact %s: %w", key, responseError(resp))
+	}
+	modTime, _ := http.ParseTime(resp.Header.Get("Last-Modified"))
+	return resp.Body, &Object{Key: key, Size: resp.ContentLength, ModTime: modTime}, nil
+}
+
+type gcsListResult struct {
+	Items []struct {
+		Name    string    `json:"name"`
+		Size    string    `json:"size"`
+		Updated time.Time `json:"updated"`
+	} `json:"items"`
+	NextPageToken string `json:"nextPageToken"`
+}
+
+func (s *GCSStore) List(ctx context.Context, prefix string) ([]Object, error) {
+	var objects []Object
+	token := ""
+	for {
+		q := url.Values{"prefix": {s.prefix + prefix}, "fields": {"items(name,size,updated),nextPageToken"}}
+		if token != "" {
+			q.Set("pageToken", token)
+		}
+		resp, err := s.do(ctx, http.MethodGet, s.endpoint+"/storage/v1/b/"+url.PathEscape(s.bucket)+"/o?"+q.Encode(), nil, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to list artifacts: %w", err)
+		}
+		var result gcsListResult
+		if resp.StatusCode != http.StatusOK {
+			err = responseError(resp)
+		} else {
+			err = json.NewDecoder(resp.Body).Decode(&result)
+		}
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list artifacts: %w", err)
+		}
+		for _, item := range result.Items {
+			// The JSON API encodes the 64-bit size as a string.
+			size, _ := strconv.ParseInt(item.Size, 10, 64)
+			objects = append(objects, Object{Key: strings.TrimPrefix(item.Name, s.prefix), Size: size, ModTime: item.Updated})
+		}
+		if result.NextPageToken == "" {
+			return objects, nil
+		}
+		token = result.NextPageToken
+	}
+}
+
+func (s *GCSStore) Delete(ctx context.Context, key string) error {
+	resp, err := s.do(ctx, http.MethodDelete, s.objectURL(key), nil, "")
+	if err != nil {
+		return fmt.Errorf("failed to delete artifact %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("failed to delete artifact %s: %w", key, responseError(resp))
+	}
+	return nil
+}
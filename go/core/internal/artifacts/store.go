@@ -0,0 +1,83 @@
+// Package artifacts persists A2A task artifacts outside the task store so
+// large outputs (files, logs, generated manifests) survive after the event
+// stream that produced them has ended.
+//
+// When a task reaches a terminal state the Archiver uploads each of its
+// artifacts to a Store (local disk, S3 or GCS) and, for artifacts above the
+// inline limit, replaces their parts in the persisted task with a link to
+// /api/tasks/{id}/artifacts/{name}. The Collector removes stored artifacts by
+// age and total size.
+package artifacts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// ErrNotFound is returned by Store.Get when no object exists for a key.
+var ErrNotFound = errors.New("artifact not found")
+
+// Object describes a stored artifact.
+type Object struct {
+	Key     string
+	Size    int64
+	ModTime time.Time
+}
+
+// Store is a flat key/value blob store. Keys are slash-separated paths.
+type Store interface {
+	// Put stores body under key, replacing any existing object.
+	Put(ctx context.Context, key string, body []byte, contentType string) error
+	// Get opens the object stored under key. It returns ErrNotFound when the
+	// key does not exist.
+	Get(ctx context.Context, key string) (io.ReadCloser, *Object, error)
+	// List returns every object whose key starts with prefix.
+	List(ctx context.Context, prefix string) ([]Object, error)
+	// Delete removes the object stored under key. Deleting a missing key is
+	// not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+const (
+	BackendLocal = "local"
+	BackendS3    = "s3"
+	BackendGCS   = "gcs"
+)
+
+// Config selects and configures a Store backend.
+type Config struct {
+	// Backend is one of local, s3 or gcs. An empty backend disables
+	// artifact storage.
+	Backend string
+	// Path is the root directory of the local backend.
+	Path string
+	// Bucket is the S3 or GCS bucket artifacts are written to.
+	Bucket string
+	// Prefix is prepended to every key in the bucket.
+	Prefix string
+	// Endpoint overrides the S3 or GCS API endpoint, e.g. for MinIO or a
+	// private service endpoint.
+	Endpoint string
+	// Region is the S3 region. Defaults to the AWS SDK's region resolution.
+	Region string
+}
+
+// NewStore returns the Store selected by cfg, or nil when cfg.Backend is
+// empty.
+func NewStore(ctx context.Context, cfg Config) (Store, error) {
+	switch cfg.Backend {
+	case "":
+		return nil, nil
+	case BackendLocal:
+		return NewLocalStore(cfg.Path)
+	case BackendS3:
+		return NewS3Store(ctx, cfg)
+	case BackendGCS:
+		return NewGCSStore(ctx, cfg)
+	default:
+		return nil, fmt.Errorf("unknown artifact store backend %q: must be one of %s, %s, %s", cfg.Backend, BackendLocal, BackendS3, BackendGCS)
+	}
+}
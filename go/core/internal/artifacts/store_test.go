@@ -0,0 +1,234 @@
+package artifacts
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBucket is an in-memory object store shared by the S3 and GCS fakes.
+type fakeBucket struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	types   map[string]string
+}
+
+func newFakeBucket() *fakeBucket {
+	return &fakeBucket{objects: map[string][]byte{}, types: map[string]string{}}
+}
+
+func (b *fakeBucket) put(key string, body []byte, contentType string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.objects[key] = body
+	b.types[key] = contentType
+}
+
+func (b *fakeBucket) get(key string) ([]byte, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	body, ok := b.objects[key]
+	return body, ok
+}
+
+func (b *fakeBucket) remove(key string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.objects, key)
+}
+
+func (b *fakeBucket) list(prefix string) []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var keys []string
+	for key := range b.objects {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// fakeS3 serves path-style requests for a single bucket, rejecting unsigned
+// requests.
+func fakeS3(t *testing.T, bucket *fakeBucket) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		key, isObject := strings.CutPrefix(r.URL.Path, "/artifacts/")
+		switch {
+		case r.Method == http.MethodPut && isObject:
+			body, _ := io.ReadAll(r.Body)
+			bucket.put(key, body, r.Header.Get("Content-Type"))
+		case r.Method == http.MethodGet && isObject:
+			body, ok := bucket.get(key)
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Write(body) //nolint:errcheck
+		case r.Method == http.MethodDelete && isObject:
+			bucket.remove(key)
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodGet && r.URL.Path == "/artifacts":
+			// Return one key per page to exercise continuation.
+			keys := bucket.list(r.URL.Query().Get("prefix"))
+			start, _ := strconv.Atoi(r.URL.Query().Get("continuation-token"))
+			var result s3ListResult
+			if start < len(keys) {
+				body, _ := bucket.get(keys[start])
+				result.Contents = append(result.Contents, struct {
+					Key          string    `xml:"Key"`
+					Size         int64     `xml:"Size"`
+					LastModified time.Time `xml:"LastModified"`
+				}{Key: keys[start], Size: int64(len(body))})
+			}
+			if start+1 < len(keys) {
+				result.IsTruncated = true
+				result.NextContinuationToken = strconv.Itoa(start + 1)
+			}
+			xml.NewEncoder(w).Encode(result) //nolint:errcheck
+		default:
+			t.Errorf("unexpected S3 request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+}
+
+// fakeGCS serves the JSON API for a single bucket.
+func fakeGCS(t *testing.T, bucket *fakeBucket) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key, isObject := strings.CutPrefix(r.URL.Path, "/storage/v1/b/artifacts/o/")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/upload/storage/v1/b/artifacts/o":
+			body, _ := io.ReadAll(r.Body)
+			bucket.put(r.URL.Query().Get("name"), body, r.Header.Get("Content-Type"))
+			w.Write([]byte(`{}`)) //nolint:errcheck
+		case r.Method == http.MethodGet && isObject:
+			body, ok := bucket.get(key)
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Write(body) //nolint:errcheck
+		case r.Method == http.MethodDelete && isObject:
+			bucket.remove(key)
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodGet && r.URL.Path == "/storage/v1/b/artifacts/o":
+			var result gcsListResult
+			for _, key := range bucket.list(r.URL.Query().Get("prefix")) {
+				body, _ := bucket.get(key)
+				result.Items = append(result.Items, struct {
+					Name    string    `json:"name"`
+					Size    string    `json:"size"`
+					Updated time.Time `json:"updated"`
+				}{Name: key, Size: strconv.Itoa(len(body))})
+			}
+			json.NewEncoder(w).Encode(result) //nolint:errcheck
+		default:
+			t.Errorf("unexpected GCS request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+}
+
+func TestStores(t *testing.T) {
+	tests := []struct {
+		name     string
+		newStore func(t *testing.T, bucket *fakeBucket) Store
+	}{
+		{
+			name: "local",
+			newStore: func(t *testing.T, _ *fakeBucket) Store {
+				store, err := NewLocalStore(t.TempDir())
+				require.NoError(t, err)
+				return store
+			},
+		},
+		{
+			name: "s3",
+			newStore: func(t *testing.T, bucket *fakeBucket) Store {
+				server := fakeS3(t, bucket)
+				t.Cleanup(server.Close)
+				return &S3Store{
+					client: server.Client(),
+					credentials: aws.CredentialsProviderFunc(func(context.Context) (aws.Credentials, error) {
+						return aws.Credentials{AccessKeyID: "AKID", SecretAccessKey: "secret"}, nil
+					}),
+					signer:   v4.NewSigner(),
+					endpoint: server.URL,
+					region:   "us-east-1",
+					bucket:   "artifacts",
+					prefix:   "kagent/",
+					now:      time.Now,
+				}
+			},
+		},
+		{
+			name: "gcs",
+			newStore: func(t *testing.T, bucket *fakeBucket) Store {
+				server := fakeGCS(t, bucket)
+				t.Cleanup(server.Close)
+				return newGCSStore(server.Client(), Config{Endpoint: server.URL, Bucket: "artifacts", Prefix: "kagent/"})
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			bucket := newFakeBucket()
+			store := tt.newStore(t, bucket)
+
+			require.NoError(t, store.Put(ctx, "tasks/t1/report", []byte("report"), "text/plain"))
+			require.NoError(t, store.Put(ctx, "tasks/t1/log", []byte("log lines"), "text/plain"))
+			require.NoError(t, store.Put(ctx, "tasks/t2/report", []byte("other"), "text/plain"))
+
+			body, obj, err := store.Get(ctx, "tasks/t1/report")
+			require.NoError(t, err)
+			content, err := io.ReadAll(body)
+			body.Close()
+			require.NoError(t, err)
+			assert.Equal(t, "report", string(content))
+			assert.Equal(t, "tasks/t1/report", obj.Key)
+
+			_, _, err = store.Get(ctx, "tasks/t1/missing")
+			assert.ErrorIs(t, err, ErrNotFound)
+
+			objects, err := store.List(ctx, "tasks/t1/")
+			require.NoError(t, err)
+			keys := map[string]int64{}
+			for _, obj := range objects {
+				keys[obj.Key] = obj.Size
+			}
+			assert.Equal(t, map[string]int64{"tasks/t1/report": 6, "tasks/t1/log": 9}, keys)
+
+			require.NoError(t, store.Delete(ctx, "tasks/t1/report"))
+			require.NoError(t, store.Delete(ctx, "tasks/t1/report"), "deleting a missing key is not an error")
+			_, _, err = store.Get(ctx, "tasks/t1/report")
+			assert.ErrorIs(t, err, ErrNotFound)
+		})
+	}
+}
+
+func TestLocalStoreRejectsEscapingKeys(t *testing.T) {
+	store, err := NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+	assert.Error(t, store.Put(context.Background(), "../outside", []byte("x"), ""))
+	_, _, err = store.Get(context.Background(), "/etc/passwd")
+	assert.Error(t, err)
+}
@@ -0,0 +1,289 @@
+// Package compaction summarizes long agent sessions from the controller.
+//
+// The Python ADK runtime already compacts event history in-process after an
+// invocation. This package runs the same compaction out-of-band: it reads a
+// session's events from the database, summarizes the older ones with the
+// agent's configured summarizer model and appends an ADK compaction event.
+// When the runtime next loads the session it replaces the compacted range with
+// the summary, exactly as it would for a compaction it produced itself.
+package compaction
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kagent-dev/kagent/go/api/database"
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	"github.com/kagent-dev/kagent/go/core/internal/controller/provider"
+	"github.com/kagent-dev/kagent/go/core/internal/utils"
+)
+
+const (
+	// DefaultEventRetentionSize is the number of most recent events kept
+	// verbatim when the agent does not set eventRetentionSize.
+	DefaultEventRetentionSize = 10
+
+	// conversationHistoryPlaceholder is substituted with the rendered events.
+	// It matches the placeholder used by the ADK LlmEventSummarizer so custom
+	// prompt templates work for both in-runtime and controller compaction.
+	conversationHistoryPlaceholder = "{conversation_history}"
+
+	defaultPromptTemplate = `The following is a conversation history between a user and an AI agent. Please summarize the conversation, focusing on key information and decisions made, as well as any unresolved questions or tasks. The summary should be concise and capture the essence of the interaction.
+
+` + conversationHistoryPlaceholder
+
+	// maxPartChars truncates individual tool calls/results when rendering
+	// history for the summarizer so a single large payload cannot dominate it.
+	maxPartChars = 2000
+)
+
+var (
+	// ErrNotConfigured is returned when the session's agent has no
+	// spec.declarative.context.compaction settings.
+	ErrNotConfigured = errors.New("agent does not have context compaction configured")
+	// ErrUnsupportedRuntime is returned for agents whose runtime does not
+	// apply compaction events.
+	ErrUnsupportedRuntime = errors.New("context compaction is only supported for the python runtime")
+	// ErrNoAgent is returned for sessions that are not bound to an agent.
+	ErrNoAgent = errors.New("session is not associated with an agent")
+)
+
+// Summarizer generates the summary text for a compaction.
+type Summarizer interface {
+	Summarize(ctx context.Context, req provider.SummaryRequest) (string, error)
+}
+
+// Status describes how much un-compacted history a session carries.
+type Status struct {
+	SessionID string
+	// EstimatedTokens approximates the prompt size of the session: the latest
+	// summary plus every event after the compacted range.
+	EstimatedTokens int
+	// PendingEvents is the number of events after the last compacted range.
+	PendingEvents int
+	// Compactions is the number of compaction events in the session.
+	Compactions int
+	// LastCompactedAt is when the most recent compaction event was written.
+	LastCompactedAt *time.Time
+	// TokenThreshold is the agent's tokenThreshold, or 0 when unset.
+	TokenThreshold int
+}
+
+// Result is the outcome of a compaction run.
+type Result struct {
+	Status
+	// Compacted reports whether a compaction event was written. It is false
+	// when there were no events outside the retention window.
+	Compacted       bool
+	CompactedEvents int
+	Summary         string
+}
+
+// Service computes compaction status and runs compactions for sessions.
+type Service struct {
+	kube               client.Client
+	db                 database.Client
+	summarizer         Summarizer
+	defaultModelConfig types.NamespacedName
+	now                func() time.Time
+}
+
+// NewService creates a new compaction Service.
+func NewService(kube client.Client, db database.Client, summarizer Summarizer, defaultModelConfig types.NamespacedName) *Service {
+	return &Service{
+		kube:               kube,
+		db:                 db,
+		summarizer:         summarizer,
+		defaultModelConfig: defaultModelConfig,
+		now:                time.Now,
+	}
+}
+
+// Status reports the compaction state of a session.
+func (s *Service) Status(ctx context.Context, session *database.Session) (*Status, error) {
+	agent, err := s.agentForSession(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+	h, err := s.loadHistory(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+	status := h.status(session.ID)
+	if cfg := compactionConfig(agent); cfg != nil && cfg.TokenThreshold != nil {
+		status.TokenThreshold = *cfg.TokenThreshold
+	}
+	return &status, nil
+}
+
+// Compact summarizes the session's events outside the retention window and
+// appends the resulting compaction event to the session.
+func (s *Service) Compact(ctx context.Context, session *database.Session) (*Result, error) {
+	agent, err := s.agentForSession(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+	if v1alpha2.EffectiveDeclarativeRuntime(&agent.Spec) != v1alpha2.DeclarativeRuntime_Python {
+		return nil, ErrUnsupportedRuntime
+	}
+	cfg := compactionConfig(agent)
+	if cfg == nil {
+		return nil, ErrNotConfigured
+	}
+
+	h, err := s.loadHistory(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+	result := &Result{Status: h.status(session.ID)}
+	if cfg.TokenThreshold != nil {
+		result.TokenThreshold = *cfg.TokenThreshold
+	}
+
+	retain := DefaultEventRetentionSize
+	if cfg.EventRetentionSize != nil {
+		retain = *cfg.EventRetentionSize
+	}
+	if len(h.pending) <= retain {
+		return result, nil
+	}
+	toCompact := h.pending[:len(h.pending)-retain]
+
+	req, err := s.summaryRequest(ctx, agent, cfg)
+	if err != nil {
+		return nil, err
+	}
+	req.Prompt = buildPrompt(cfg, h.lastSummary, toCompact)
+	summary, err := s.summarizer.Summarize(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize session %s: %w", session.ID, err)
+	}
+
+	// A new compaction subsumes the previous one, so its range starts where
+	// the previous range started.
+	start := toCompact[0].Timestamp
+	if h.last != nil {
+		start = h.last.StartTimestamp
+	}
+	end := toCompact[len(toCompact)-1].Timestamp
+	now := s.now()
+	compactionEvent := adkEvent{
+		ID:           uuid.New().String(),
+		InvocationID: uuid.New().String(),
+		Author:       "user",
+		Timestamp:    float64(now.UnixNano()) / float64(time.Second),
+		Actions: &adkEventActions{Compaction: &adkEventCompaction{
+			StartTimestamp: start,
+			EndTimestamp:   end,
+			CompactedContent: adkContent{
+				Role:  "model",
+				Parts: []adkPart{{Text: summary}},
+			},
+		}},
+	}
+	data, err := json.Marshal(compactionEvent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal compaction event: %w", err)
+	}
+	if err := s.db.StoreEvents(ctx, &database.Event{
+		ID:        compactionEvent.ID,
+		SessionID: session.ID,
+		UserID:    session.UserID,
+		Data:      string(data),
+	}); err != nil {
+		return nil, fmt.Errorf("failed to store compaction event: %w", err)
+	}
+
+	remaining := h.pending[len(toCompact):]
+	result.Compacted = true
+	result.CompactedEvents = len(toCompact)
+	result.Summary = summary
+	result.Compactions++
+	result.LastCompactedAt = &now
+	result.PendingEvents = len(remaining)
+	result.EstimatedTokens = estimateTokens(summary) + eventTokens(remaining)
+	return result, nil
+}
+
+// agentForSession looks up the Agent a session belongs to.
+func (s *Service) agentForSession(ctx context.Context, session *database.Session) (*v1alpha2.Agent, error) {
+	if session.AgentID == nil || *session.AgentID == "" {
+		return nil, ErrNoAgent
+	}
+	ref, err := utils.ParseRefString(utils.ConvertToKubernetesIdentifier(*session.AgentID), "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse agent reference %q: %w", *session.AgentID, err)
+	}
+	agent := &v1alpha2.Agent{}
+	if err := s.kube.Get(ctx, ref, agent); err != nil {
+		return nil, fmt.Errorf("failed to get agent %s: %w", ref, err)
+	}
+	return agent, nil
+}
+
+// summaryRequest resolves the summarizer ModelConfig and its API key. The
+// summarizer's modelConfig takes precedence over the agent's own model.
+func (s *Service) summaryRequest(ctx context.Context, agent *v1alpha2.Agent, cfg *v1alpha2.ContextCompressionConfig) (provider.SummaryRequest, error) {
+	ref := types.NamespacedName{Namespace: agent.Namespace, Name: agent.Spec.Declarative.ModelConfig}
+	if cfg.Summarizer != nil && cfg.Summarizer.ModelConfig != nil && *cfg.Summarizer.ModelConfig != "" {
+		ref.Name = *cfg.Summarizer.ModelConfig
+	} else if ref.Name == "" {
+		ref = s.defaultModelConfig
+	}
+
+	req, err := provider.SummaryRequestFor(ctx, s.kube, ref)
+	if err != nil {
+		return req, fmt.Errorf("summarizer: %w", err)
+	}
+	return req, nil
+}
+
+func compactionConfig(agent *v1alpha2.Agent) *v1alpha2.ContextCompressionConfig {
+	if agent.Spec.Declarative == nil || agent.Spec.Declarative.Context == nil {
+		return nil
+	}
+	return agent.Spec.Declarative.Context.Compaction
+}
+
+// buildPrompt renders the events into the summarizer prompt template.
+func buildPrompt(cfg *v1alpha2.ContextCompressionConfig, previousSummary string, events []adkEvent) string {
+	var b strings.Builder
+	if previousSummary != "" {
+		fmt.Fprintf(&b, "summary of earlier conversation: %s\n", previousSummary)
+	}
+	for _, e := range events {
+		if text := e.text(); text != "" {
+			fmt.Fprintf(&b, "%s: %s\n", e.Author, text)
+		}
+	}
+
+	tmpl := defaultPromptTemplate
+	if cfg.Summarizer != nil && cfg.Summarizer.PromptTemplate != nil && *cfg.Summarizer.PromptTemplate != "" {
+		tmpl = *cfg.Summarizer.PromptTemplate
+	}
+	if !strings.Contains(tmpl, conversationHistoryPlaceholder) {
+		return tmpl + "\n\n" + b.String()
+	}
+	return strings.ReplaceAll(tmpl, conversationHistoryPlaceholder, b.String())
+}
+
+// estimateTokens approximates token count at four characters per token.
+func estimateTokens(s string) int {
+	return len(s) / 4
+}
+
+func eventTokens(events []adkEvent) int {
+	n := 0
+	for _, e := range events {
+		n += estimateTokens(e.text())
+	}
+	return n
+}
@@ -0,0 +1,285 @@
+package compaction
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kagent-dev/kagent/go/api/database"
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	"github.com/kagent-dev/kagent/go/core/internal/controller/provider"
+)
+
+// fakeDB implements the event methods of database.Client used by compaction.
+type fakeDB struct {
+	database.Client
+	events []*database.Event
+}
+
+func (f *fakeDB) ListEventsForSession(_ context.Context, sessionID, _ string, _ database.QueryOptions) ([]*database.Event, error) {
+	var out []*database.Event
+	for _, e := range f.events {
+		if e.SessionID == sessionID {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeDB) StoreEvents(_ context.Context, events ...*database.Event) error {
+	f.events = append(f.events, events...)
+	return nil
+}
+
+type fakeSummarizer struct {
+	req provider.SummaryRequest
+}
+
+func (f *fakeSummarizer) Summarize(_ context.Context, req provider.SummaryRequest) (string, error) {
+	f.req = req
+	return "the summary", nil
+}
+
+func textEvent(t *testing.T, ts float64, author, text string) *database.Event {
+	t.Helper()
+	data, err := json.Marshal(adkEvent{
+		ID:        fmt.Sprintf("e-%v", ts),
+		Author:    author,
+		Timestamp: ts,
+		Content:   &adkContent{Role: "user", Parts: []adkPart{{Text: text}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &database.Event{ID: fmt.Sprintf("e-%v", ts), SessionID: "s1", UserID: "u1", Data: string(data)}
+}
+
+func newScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := v1alpha2.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	return scheme
+}
+
+func testAgent(runtime v1alpha2.DeclarativeRuntime, compaction *v1alpha2.ContextCompressionConfig) *v1alpha2.Agent {
+	agent := &v1alpha2.Agent{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-agent", Namespace: "ns"},
+		Spec: v1alpha2.AgentSpec{
+			Type: v1alpha2.AgentType_Declarative,
+			Declarative: &v1alpha2.DeclarativeAgentSpec{
+				Runtime:     runtime,
+				ModelConfig: "agent-model",
+			},
+		},
+	}
+	if compaction != nil {
+		agent.Spec.Declarative.Context = &v1alpha2.ContextConfig{Compaction: compaction}
+	}
+	return agent
+}
+
+func TestParseHistory(t *testing.T) {
+	compactionData, _ := json.Marshal(adkEvent{
+		ID:     "c1",
+		Author: "user",
+		Actions: &adkEventActions{Compaction: &adkEventCompaction{
+			StartTimestamp:   1,
+			EndTimestamp:     2,
+			CompactedContent: adkContent{Role: "model", Parts: []adkPart{{Text: "earlier summary"}}},
+		}},
+	})
+	rows := []*database.Event{
+		textEvent(t, 1, "user", "first"),
+		textEvent(t, 2, "agent", "second"),
+		{ID: "c1", SessionID: "s1", Data: string(compactionData), CreatedAt: time.Unix(100, 0)},
+		textEvent(t, 3, "user", "third message"),
+		{ID: "bad", SessionID: "s1", Data: "not json"},
+	}
+
+	h := parseHistory(rows)
+	if h.compactions != 1 {
+		t.Errorf("compactions = %d, want 1", h.compactions)
+	}
+	if h.lastSummary != "earlier summary" {
+		t.Errorf("lastSummary = %q", h.lastSummary)
+	}
+	if len(h.pending) != 1 || h.pending[0].text() != "third message" {
+		t.Errorf("pending = %+v, want only the third message", h.pending)
+	}
+	st := h.status("s1")
+	if st.LastCompactedAt == nil || !st.LastCompactedAt.Equal(time.Unix(100, 0)) {
+		t.Errorf("LastCompactedAt = %v", st.LastCompactedAt)
+	}
+	if want := estimateTokens("earlier summary") + estimateTokens("third message"); st.EstimatedTokens != want {
+		t.Errorf("EstimatedTokens = %d, want %d", st.EstimatedTokens, want)
+	}
+}
+
+func TestBuildPrompt(t *testing.T) {
+	events := []adkEvent{
+		{Author: "user", Content: &adkContent{Parts: []adkPart{{Text: "hello"}}}},
+		{Author: "agent", Content: &adkContent{Parts: []adkPart{{FunctionCall: &adkFunctionCall{Name: "get_pods", Args: json.RawMessage(`{"ns":"default"}`)}}}}},
+	}
+
+	tests := []struct {
+		name     string
+		cfg      *v1alpha2.ContextCompressionConfig
+		previous string
+		want     []string
+	}{
+		{
+			name: "default template",
+			cfg:  &v1alpha2.ContextCompressionConfig{},
+			want: []string{"Please summarize the conversation", "user: hello", `agent: called tool get_pods with {"ns":"default"}`},
+		},
+		{
+			name: "custom template with previous summary",
+			cfg: &v1alpha2.ContextCompressionConfig{Summarizer: &v1alpha2.ContextSummarizerConfig{
+				PromptTemplate: new("Summarize tersely:\n{conversation_history}"),
+			}},
+			previous: "they asked about pods",
+			want:     []string{"Summarize tersely:\nsummary of earlier conversation: they asked about pods", "user: hello"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildPrompt(tt.cfg, tt.previous, events)
+			for _, w := range tt.want {
+				if !strings.Contains(got, w) {
+					t.Errorf("prompt missing %q:\n%s", w, got)
+				}
+			}
+		})
+	}
+}
+
+func TestCompact(t *testing.T) {
+	agentID := "ns__NS__my_agent"
+	session := &database.Session{ID: "s1", UserID: "u1", AgentID: &agentID}
+
+	tests := []struct {
+		name          string
+		agent         *v1alpha2.Agent
+		events        int
+		wantErr       error
+		wantCompacted int
+	}{
+		{
+			name: "compacts events outside retention window",
+			agent: testAgent(v1alpha2.DeclarativeRuntime_Python, &v1alpha2.ContextCompressionConfig{
+				EventRetentionSize: new(2),
+				Summarizer:         &v1alpha2.ContextSummarizerConfig{ModelConfig: new("summarizer")},
+			}),
+			events:        5,
+			wantCompacted: 3,
+		},
+		{
+			name: "nothing to compact within retention window",
+			agent: testAgent(v1alpha2.DeclarativeRuntime_Python, &v1alpha2.ContextCompressionConfig{
+				EventRetentionSize: new(10),
+			}),
+			events: 5,
+		},
+		{
+			name:    "compaction not configured",
+			agent:   testAgent(v1alpha2.DeclarativeRuntime_Python, nil),
+			events:  5,
+			wantErr: ErrNotConfigured,
+		},
+		{
+			name:    "go runtime unsupported",
+			agent:   testAgent(v1alpha2.DeclarativeRuntime_Go, &v1alpha2.ContextCompressionConfig{}),
+			events:  5,
+			wantErr: ErrUnsupportedRuntime,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summarizerConfig := &v1alpha2.ModelConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "summarizer", Namespace: "ns"},
+				Spec: v1alpha2.ModelConfigSpec{
+					Provider:        v1alpha2.ModelProviderOpenAI,
+					Model:           "gpt-4o-mini",
+					APIKeySecret:    "openai",
+					APIKeySecretKey: "key",
+				},
+			}
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "openai", Namespace: "ns"},
+				Data:       map[string][]byte{"key": []byte("sk-test")},
+			}
+			kube := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(tt.agent, summarizerConfig, secret).Build()
+
+			db := &fakeDB{}
+			for i := range tt.events {
+				db.events = append(db.events, textEvent(t, float64(i+1), "user", fmt.Sprintf("message %d", i+1)))
+			}
+			summarizer := &fakeSummarizer{}
+			svc := NewService(kube, db, summarizer, types.NamespacedName{Namespace: "kagent", Name: "default-model-config"})
+
+			result, err := svc.Compact(context.Background(), session)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Compact() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Compact() error = %v", err)
+			}
+			if result.CompactedEvents != tt.wantCompacted {
+				t.Errorf("CompactedEvents = %d, want %d", result.CompactedEvents, tt.wantCompacted)
+			}
+			if tt.wantCompacted == 0 {
+				if result.Compacted || len(db.events) != tt.events {
+					t.Errorf("expected no compaction event to be stored")
+				}
+				return
+			}
+
+			if summarizer.req.Model != "gpt-4o-mini" || summarizer.req.APIKey != "sk-test" {
+				t.Errorf("unexpected summary request: %+v", summarizer.req)
+			}
+			if len(db.events) != tt.events+1 {
+				t.Fatalf("stored events = %d, want %d", len(db.events), tt.events+1)
+			}
+			var stored adkEvent
+			if err := json.Unmarshal([]byte(db.events[len(db.events)-1].Data), &stored); err != nil {
+				t.Fatal(err)
+			}
+			c := stored.compaction()
+			if c == nil || c.StartTimestamp != 1 || c.EndTimestamp != float64(tt.wantCompacted) {
+				t.Fatalf("unexpected compaction: %+v", c)
+			}
+			if c.CompactedContent.Parts[0].Text != "the summary" {
+				t.Errorf("summary = %q", c.CompactedContent.Parts[0].Text)
+			}
+
+			// The stored compaction is reflected in the session status.
+			st, err := svc.Status(context.Background(), session)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if st.Compactions != 1 || st.PendingEvents != tt.events-tt.wantCompacted {
+				t.Errorf("status = %+v", st)
+			}
+		})
+	}
+}
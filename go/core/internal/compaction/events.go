@@ -0,0 +1,156 @@
+package compaction
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/kagent-dev/kagent/go/api/database"
+)
+
+// adkEvent is the subset of the Python ADK Event model (as written by
+// Event.model_dump_json) that compaction reads and writes.
+type adkEvent struct {
+	ID           string           `json:"id"`
+	InvocationID string           `json:"invocation_id"`
+	Author       string           `json:"author"`
+	Timestamp    float64          `json:"timestamp"`
+	Content      *adkContent      `json:"content,omitempty"`
+	Actions      *adkEventActions `json:"actions,omitempty"`
+}
+
+type adkEventActions struct {
+	Compaction *adkEventCompaction `json:"compaction,omitempty"`
+}
+
+// adkEventCompaction mirrors google.adk.events.EventCompaction.
+type adkEventCompaction struct {
+	StartTimestamp   float64    `json:"start_timestamp"`
+	EndTimestamp     float64    `json:"end_timestamp"`
+	CompactedContent adkContent `json:"compacted_content"`
+}
+
+type adkContent struct {
+	Role  string    `json:"role,omitempty"`
+	Parts []adkPart `json:"parts,omitempty"`
+}
+
+type adkPart struct {
+	Text             string           `json:"text,omitempty"`
+	FunctionCall     *adkFunctionCall `json:"function_call,omitempty"`
+	FunctionResponse *adkFunctionCall `json:"function_response,omitempty"`
+}
+
+type adkFunctionCall struct {
+	Name     string          `json:"name"`
+	Args     json.RawMessage `json:"args,omitempty"`
+	Response json.RawMessage `json:"response,omitempty"`
+}
+
+func (e *adkEvent) compaction() *adkEventCompaction {
+	if e.Actions == nil {
+		return nil
+	}
+	return e.Actions.Compaction
+}
+
+// text renders the event content as plain text for summarization and token
+// estimation. Tool payloads are truncated.
+func (e *adkEvent) text() string {
+	if e.Content == nil {
+		return ""
+	}
+	var parts []string
+	for _, p := range e.Content.Parts {
+		switch {
+		case p.Text != "":
+			parts = append(parts, p.Text)
+		case p.FunctionCall != nil:
+			parts = append(parts, truncate(fmt.Sprintf("called tool %s with %s", p.FunctionCall.Name, p.FunctionCall.Args)))
+		case p.FunctionResponse != nil:
+			parts = append(parts, truncate(fmt.Sprintf("tool %s returned %s", p.FunctionResponse.Name, p.FunctionResponse.Response)))
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+func truncate(s string) string {
+	if len(s) <= maxPartChars {
+		return s
+	}
+	return s[:maxPartChars] + "...(truncated)"
+}
+
+// history is a session's events split around the most recent compaction.
+type history struct {
+	// last is the compaction covering the latest range, nil if none.
+	last        *adkEventCompaction
+	lastAt      time.Time
+	lastSummary string
+	compactions int
+	// pending are the non-compaction events after the compacted range, in order.
+	pending []adkEvent
+}
+
+func (s *Service) loadHistory(ctx context.Context, session *database.Session) (*history, error) {
+	rows, err := s.db.ListEventsForSession(ctx, session.ID, session.UserID, database.QueryOptions{OrderAsc: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events for session %s: %w", session.ID, err)
+	}
+	return parseHistory(rows), nil
+}
+
+// parseHistory decodes stored events. Events that are not ADK events are
+// ignored; they are not sent to the model by the runtime either.
+func parseHistory(rows []*database.Event) *history {
+	h := &history{}
+	var events []adkEvent
+	for _, row := range rows {
+		var e adkEvent
+		if err := json.Unmarshal([]byte(row.Data), &e); err != nil {
+			continue
+		}
+		if c := e.compaction(); c != nil {
+			h.compactions++
+			if h.last == nil || c.EndTimestamp >= h.last.EndTimestamp {
+				h.last = c
+				h.lastAt = row.CreatedAt
+				var summary []string
+				for _, p := range c.CompactedContent.Parts {
+					summary = append(summary, p.Text)
+				}
+				h.lastSummary = strings.Join(summary, "\n")
+			}
+			continue
+		}
+		events = append(events, e)
+	}
+
+	end := math.Inf(-1)
+	if h.last != nil {
+		end = h.last.EndTimestamp
+	}
+	for _, e := range events {
+		if e.Timestamp > end {
+			h.pending = append(h.pending, e)
+		}
+	}
+	return h
+}
+
+func (h *history) status(sessionID string) Status {
+	st := Status{
+		SessionID:       sessionID,
+		PendingEvents:   len(h.pending),
+		Compactions:     h.compactions,
+		EstimatedTokens: estimateTokens(h.lastSummary) + eventTokens(h.pending),
+	}
+	if h.last != nil {
+		at := h.lastAt
+		st.LastCompactedAt = &at
+	}
+	return st
+}
@@ -0,0 +1,93 @@
+package compaction
+
+import (
+	"context"
+	"time"
+
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	"github.com/kagent-dev/kagent/go/core/internal/utils"
+)
+
+// Runner periodically compacts sessions whose estimated size exceeds their
+// agent's context.compaction.tokenThreshold. Agents without a tokenThreshold
+// are only compacted on demand through the API.
+type Runner struct {
+	Service  *Service
+	Interval time.Duration
+}
+
+// NeedLeaderElection ensures only one replica compacts sessions.
+func (r *Runner) NeedLeaderElection() bool { return true }
+
+// NewRunner returns a Runner that scans sessions every interval; pass 0 to
+// use the default of 10 minutes.
+func NewRunner(service *Service, interval time.Duration) *Runner {
+	if interval <= 0 {
+		interval = 10 * time.Minute
+	}
+	return &Runner{Service: service, Interval: interval}
+}
+
+// Start runs the periodic compaction loop until ctx is cancelled.
+func (r *Runner) Start(ctx context.Context) error {
+	log := ctrllog.FromContext(ctx).WithName("session-compaction")
+	log.Info("Starting session compaction loop", "interval", r.Interval)
+	ticker := time.NewTicker(r.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			r.runOnce(ctx)
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (r *Runner) runOnce(ctx context.Context) {
+	log := ctrllog.FromContext(ctx).WithName("session-compaction")
+
+	agents := &v1alpha2.AgentList{}
+	if err := r.Service.kube.List(ctx, agents); err != nil {
+		log.Error(err, "Failed to list agents")
+		return
+	}
+	for i := range agents.Items {
+		agent := &agents.Items[i]
+		cfg := compactionConfig(agent)
+		if cfg == nil || cfg.TokenThreshold == nil ||
+			v1alpha2.EffectiveDeclarativeRuntime(&agent.Spec) != v1alpha2.DeclarativeRuntime_Python {
+			continue
+		}
+		threshold := *cfg.TokenThreshold
+		agentLog := log.WithValues("agent", utils.GetObjectRef(agent))
+
+		sessions, err := r.Service.db.ListSessionsForAgentAllUsers(ctx, utils.ConvertToPythonIdentifier(utils.GetObjectRef(agent)))
+		if err != nil {
+			agentLog.Error(err, "Failed to list sessions")
+			continue
+		}
+		for j := range sessions {
+			session := &sessions[j]
+			h, err := r.Service.loadHistory(ctx, session)
+			if err != nil {
+				agentLog.Error(err, "Failed to load session history", "session", session.ID)
+				continue
+			}
+			if st := h.status(session.ID); st.EstimatedTokens < threshold {
+				continue
+			}
+			result, err := r.Service.Compact(ctx, session)
+			if err != nil {
+				agentLog.Error(err, "Failed to compact session", "session", session.ID)
+				continue
+			}
+			if result.Compacted {
+				agentLog.Info("Compacted session", "session", session.ID,
+					"compactedEvents", result.CompactedEvents, "estimatedTokens", result.EstimatedTokens)
+			}
+		}
+	}
+}
@@ -31,6 +31,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	"github.com/kagent-dev/kagent/go/core/internal/controller/predicates"
 	"github.com/kagent-dev/kagent/go/core/internal/controller/reconciler"
 	agent_translator "github.com/kagent-dev/kagent/go/core/internal/controller/translator/agent"
 )
@@ -53,9 +54,12 @@ type AgentController struct {
 // +kubebuilder:rbac:groups=core,resources=serviceaccounts,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=agents.x-k8s.io,resources=sandboxes,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=agents.x-k8s.io,resources=sandboxes/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=agents.x-k8s.io,resources=sandboxes/finalizers,verbs=update
+// +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;update;patch
 
 func (r *AgentController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	_ = log.FromContext(ctx)
@@ -64,11 +68,19 @@ func (r *AgentController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *AgentController) SetupWithManager(mgr ctrl.Manager) error {
+	if err := indexAgentDependencies(context.Background(), mgr.GetFieldIndexer(), &v1alpha2.Agent{}); err != nil {
+		return err
+	}
+
 	build := ctrl.NewControllerManagedBy(mgr).
 		WithOptions(controller.Options{
 			NeedLeaderElection: new(true),
 		}).
-		For(&v1alpha2.Agent{}, builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, predicate.LabelChangedPredicate{})))
+		For(&v1alpha2.Agent{}, builder.WithPredicates(predicate.Or(
+			predicate.GenerationChangedPredicate{},
+			predicate.LabelChangedPredicate{},
+			predicates.AnnotationChangedPredicate{Key: v1alpha2.AgentDebugCaptureUntilAnnotation},
+		)))
 
 	var err error
 	build, err = addOwnedResourceWatches(build, mgr, r.AdkTranslator.GetOwnedResourceTypes())
@@ -76,11 +88,11 @@ func (r *AgentController) SetupWithManager(mgr ctrl.Manager) error {
 		return err
 	}
 	build, err = addCommonAgentWatches(build, mgr, agentWatchFinders{
-		modelConfig:     r.agentDependencyFinder("failed to list Agents in order to reconcile ModelConfig update", usesModelConfig),
-		remoteMCPServer: r.agentDependencyFinder("failed to list Agents in order to reconcile ToolServer update", usesRemoteMCPServer),
-		mcpService:      r.agentDependencyFinder("failed to list agents in order to reconcile MCPService update", usesMCPService),
-		configMap:       r.agentDependencyFinder("failed to list agents in order to reconcile ConfigMap update", referencesConfigMap),
-		mcpServer:       r.agentDependencyFinder("failed to list agents in order to reconcile MCPServer update", usesMCPServer),
+		modelConfig:     r.agentDependencyFinder("failed to list Agents in order to reconcile ModelConfig update", agentModelConfigIndex, usesModelConfig),
+		remoteMCPServer: r.agentDependencyFinder("failed to list Agents in order to reconcile ToolServer update", agentToolServerIndex, usesRemoteMCPServer),
+		mcpService:      r.agentDependencyFinder("failed to list agents in order to reconcile MCPService update", agentToolServerIndex, usesMCPService),
+		configMap:       r.agentDependencyFinder("failed to list agents in order to reconcile ConfigMap update", agentConfigMapIndex, referencesConfigMap),
+		mcpServer:       r.agentDependencyFinder("failed to list agents in order to reconcile MCPServer update", agentToolServerIndex, usesMCPServer),
 	})
 	if err != nil {
 		return err
@@ -89,10 +101,12 @@ func (r *AgentController) SetupWithManager(mgr ctrl.Manager) error {
 	return build.Named("agent").Complete(r)
 }
 
-func (r *AgentController) agentDependencyFinder(errMsg string, pred agentDependencyPredicate) dependentRefFinder {
+// agentDependencyFinder finds the Agents referencing obj through the index
+// field. pred narrows the indexed matches, e.g. to references of one kind.
+func (r *AgentController) agentDependencyFinder(errMsg, field string, pred agentDependencyPredicate) dependentRefFinder {
 	return func(ctx context.Context, cl client.Client, obj types.NamespacedName) []types.NamespacedName {
 		var agentsList v1alpha2.AgentList
-		if err := cl.List(ctx, &agentsList); err != nil {
+		if err := cl.List(ctx, &agentsList, client.MatchingFields{field: obj.String()}); err != nil {
 			agentControllerLog.Error(err, errMsg)
 			return nil
 		}
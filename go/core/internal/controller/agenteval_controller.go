@@ -0,0 +1,428 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	"github.com/kagent-dev/kagent/go/core/internal/controller/provider"
+	"github.com/kagent-dev/kagent/go/core/internal/verification"
+)
+
+const (
+	agentEvalReasonAgentFound    = "AgentFound"
+	agentEvalReasonAgentNotFound = "AgentNotFound"
+	agentEvalReasonSuspended     = "Suspended"
+	agentEvalReasonPassed        = "EvalPassed"
+	agentEvalReasonFailed        = "EvalFailed"
+
+	defaultAgentEvalCaseTimeout        = 2 * time.Minute
+	defaultAgentEvalHistoryLimit       = 10
+	defaultAgentEvalPassThreshold      = 100
+	agentEvalAgentNotFoundRequeueDelay = time.Minute
+	// agentEvalActiveRequeueDelay re-checks an AgentEval while a run is in
+	// progress, so a rollout or suite change made during the run is
+	// evaluated once it finishes.
+	agentEvalActiveRequeueDelay = 30 * time.Second
+)
+
+var errAgentEvalDeleted = errors.New("AgentEval was deleted")
+
+// EvalJudge sends a single prompt to a model. It grades rubric assertions
+// and is satisfied by provider.Summarizer.
+type EvalJudge interface {
+	Summarize(ctx context.Context, req provider.SummaryRequest) (string, error)
+}
+
+// AgentEvalController reconciles an AgentEval object. It runs the suite
+// once the agent's Deployment has rolled out a version, or the suite a
+// generation, that has not been evaluated yet, and when a run is requested
+// through the kagent.dev/eval-run annotation. Runs execute in goroutines
+// owned by the controller, so a run in progress when the controller
+// restarts is recorded as failed.
+type AgentEvalController struct {
+	Client client.Client
+	Sender MessageSender
+	Judge  EvalJudge
+
+	now func() time.Time
+
+	mu sync.Mutex
+	// running holds the cancel functions of runs in progress, by AgentEval.
+	running map[types.NamespacedName]context.CancelCauseFunc
+	// wg tracks run goroutines, for tests.
+	wg sync.WaitGroup
+}
+
+// +kubebuilder:rbac:groups=kagent.dev,resources=agentevals,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=kagent.dev,resources=agentevals/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=kagent.dev,resources=agentevals/finalizers,verbs=update
+// +kubebuilder:rbac:groups=kagent.dev,resources=agents,verbs=get;list;watch
+// +kubebuilder:rbac:groups=kagent.dev,resources=modelconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch
+
+func (r *AgentEvalController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	eval := &v1alpha2.AgentEval{}
+	if err := r.Client.Get(ctx, req.NamespacedName, eval); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.untrack(req.NamespacedName, errAgentEvalDeleted)
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	status := eval.Status.DeepCopy()
+	status.ObservedGeneration = eval.Generation
+	if status.Active != nil {
+		if r.tracked(req.NamespacedName) {
+			return ctrl.Result{RequeueAfter: agentEvalActiveRequeueDelay}, r.updateStatus(ctx, eval, status)
+		}
+		run := *status.Active
+		run.Phase = v1alpha2.AgentEvalRunFailed
+		run.CompletionTime = &metav1.Time{Time: r.clock()}
+		run.Message = "controller restarted before the run finished"
+		status.Active = nil
+		recordAgentEvalRun(status, eval.Spec, run)
+	}
+
+	if eval.Spec.Suspend {
+		setAgentEvalCondition(status, v1alpha2.AgentEvalConditionTypeReady, eval.Generation, metav1.ConditionTrue, agentEvalReasonSuspended, "AgentEval is suspended")
+		return ctrl.Result{}, r.updateStatus(ctx, eval, status)
+	}
+
+	agent := &v1alpha2.Agent{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Namespace: eval.Namespace, Name: eval.Spec.AgentRef}, agent); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+		setAgentEvalCondition(status, v1alpha2.AgentEvalConditionTypeReady, eval.Generation, metav1.ConditionFalse, agentEvalReasonAgentNotFound,
+			fmt.Sprintf("Agent %s not found in namespace %s", eval.Spec.AgentRef, eval.Namespace))
+		if err := r.updateStatus(ctx, eval, status); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: agentEvalAgentNotFoundRequeueDelay}, nil
+	}
+	setAgentEvalCondition(status, v1alpha2.AgentEvalConditionTypeReady, eval.Generation, metav1.ConditionTrue, agentEvalReasonAgentFound,
+		fmt.Sprintf("Evaluating Agent %s", agent.Name))
+
+	trigger, deployment, err := r.dueRun(ctx, eval, agent)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if trigger == "" {
+		return ctrl.Result{}, r.updateStatus(ctx, eval, status)
+	}
+
+	run := v1alpha2.AgentEvalRun{
+		StartTime:            metav1.Time{Time: r.clock()},
+		Trigger:              trigger,
+		Phase:                v1alpha2.AgentEvalRunRunning,
+		AgentGeneration:      agent.Generation,
+		DeploymentGeneration: deployment.Generation,
+		EvalGeneration:       eval.Generation,
+		TotalCases:           int32(len(eval.Spec.Cases)),
+	}
+	status.Active = &run
+	if trigger == v1alpha2.AgentEvalTriggerManual {
+		status.LastRunRequest = eval.Annotations[v1alpha2.AgentEvalRunAnnotation]
+	}
+	r.track(req.NamespacedName)
+	if err := r.updateStatus(ctx, eval, status); err != nil {
+		r.untrack(req.NamespacedName, errors.New("failed to record run"))
+		return ctrl.Result{}, err
+	}
+
+	logger.Info("Starting AgentEval run", "trigger", trigger, "agentGeneration", agent.Generation, "deploymentGeneration", deployment.Generation)
+	judge := agentEvalJudgeModelConfig(eval, agent)
+	r.wg.Go(func() {
+		r.run(req.NamespacedName, eval.Spec, judge, run)
+	})
+	return ctrl.Result{RequeueAfter: agentEvalActiveRequeueDelay}, nil
+}
+
+// dueRun returns what triggers a run of the suite, or "" when none is due.
+// Runs only start once the agent's Deployment has finished rolling out the
+// agent's current spec.
+func (r *AgentEvalController) dueRun(ctx context.Context, eval *v1alpha2.AgentEval, agent *v1alpha2.Agent) (v1alpha2.AgentEvalTrigger, *appsv1.Deployment, error) {
+	if agent.Status.ObservedGeneration != agent.Generation {
+		return "", nil, nil
+	}
+	deployment := &appsv1.Deployment{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Namespace: agent.Namespace, Name: agent.Name}, deployment); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil, nil
+		}
+		return "", nil, fmt.Errorf("failed to get deployment: %w", err)
+	}
+	if !verification.RolloutComplete(deployment) {
+		return "", nil, nil
+	}
+
+	if request := eval.Annotations[v1alpha2.AgentEvalRunAnnotation]; request != "" && request != eval.Status.LastRunRequest {
+		return v1alpha2.AgentEvalTriggerManual, deployment, nil
+	}
+	history := eval.Status.History
+	if len(history) == 0 || history[0].DeploymentGeneration != deployment.Generation || history[0].EvalGeneration != eval.Generation {
+		return v1alpha2.AgentEvalTriggerRollout, deployment, nil
+	}
+	return "", nil, nil
+}
+
+// run sends each case to the agent in a new session, grades the replies and
+// records the result.
+func (r *AgentEvalController) run(key types.NamespacedName, spec v1alpha2.AgentEvalSpec, judgeModelConfig string, run v1alpha2.AgentEvalRun) {
+	logger := ctrl.Log.WithName("agenteval-controller").WithValues("agentEval", key.String())
+
+	ctx := r.runContext(key)
+	timeout := defaultAgentEvalCaseTimeout
+	if spec.Timeout != nil && spec.Timeout.Duration > 0 {
+		timeout = spec.Timeout.Duration
+	}
+	grader := &evalGrader{judge: r.Judge}
+	grader.judgeRequest = sync.OnceValues(func() (provider.SummaryRequest, error) {
+		if judgeModelConfig == "" {
+			return provider.SummaryRequest{}, errors.New("no judge model: set spec.judgeModelConfig")
+		}
+		return provider.SummaryRequestFor(ctx, r.Client, types.NamespacedName{Namespace: key.Namespace, Name: judgeModelConfig})
+	})
+
+	for _, c := range spec.Cases {
+		run.Results = append(run.Results, r.runCase(ctx, key.Namespace, spec.AgentRef, c, timeout, grader))
+	}
+	scoreAgentEvalRun(&run, spec)
+	run.CompletionTime = &metav1.Time{Time: r.clock()}
+	logger.Info("AgentEval run finished", "phase", run.Phase, "score", run.Score, "passedCases", run.PassedCases, "totalCases", run.TotalCases)
+
+	if err := r.recordRun(context.Background(), key, run); err != nil {
+		logger.Error(err, "Failed to record AgentEval run")
+	}
+	r.untrack(key, nil)
+}
+
+func (r *AgentEvalController) runCase(ctx context.Context, namespace, agentRef string, c v1alpha2.AgentEvalCase, timeout time.Duration, grader *evalGrader) v1alpha2.AgentEvalCaseResult {
+	sendCtx, cancel := context.WithTimeout(ctx, timeout)
+	reply, _, err := sendAgentMessage(sendCtx, r.Sender, namespace, agentRef, c.Prompt, uuid.New().String())
+	if err != nil && errors.Is(context.Cause(sendCtx), context.DeadlineExceeded) {
+		err = fmt.Errorf("timed out after %s", timeout)
+	}
+	cancel()
+	if err != nil {
+		return v1alpha2.AgentEvalCaseResult{Name: c.Name, Message: truncateRunMessage(err.Error())}
+	}
+	return grader.grade(ctx, c, reply)
+}
+
+// recordRun moves the finished run from active to the history and sets the
+// Passed condition.
+func (r *AgentEvalController) recordRun(ctx context.Context, key types.NamespacedName, run v1alpha2.AgentEvalRun) error {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest := &v1alpha2.AgentEval{}
+		if err := r.Client.Get(ctx, key, latest); err != nil {
+			return err
+		}
+		latest.Status.Active = nil
+		recordAgentEvalRun(&latest.Status, latest.Spec, run)
+		return r.Client.Status().Update(ctx, latest)
+	})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update AgentEval status: %w", err)
+	}
+	return nil
+}
+
+func (r *AgentEvalController) updateStatus(ctx context.Context, eval *v1alpha2.AgentEval, status *v1alpha2.AgentEvalStatus) error {
+	eval.Status = *status
+	if err := r.Client.Status().Update(ctx, eval); err != nil {
+		return fmt.Errorf("failed to update AgentEval status: %w", err)
+	}
+	return nil
+}
+
+func (r *AgentEvalController) clock() time.Time {
+	if r.now != nil {
+		return r.now()
+	}
+	return time.Now()
+}
+
+func (r *AgentEvalController) track(key types.NamespacedName) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.running == nil {
+		r.running = map[types.NamespacedName]context.CancelCauseFunc{}
+	}
+	// Replaced by the run's own cancel function once it starts.
+	r.running[key] = func(error) {}
+}
+
+// runContext returns the context of a tracked run. Runs outlive the
+// reconcile that starts them, so they are not derived from its context.
+func (r *AgentEvalController) runContext(key types.NamespacedName) context.Context {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.running[key]; !ok {
+		cancel(errAgentEvalDeleted)
+		return ctx
+	}
+	r.running[key] = cancel
+	return ctx
+}
+
+func (r *AgentEvalController) tracked(key types.NamespacedName) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, ok := r.running[key]
+	return ok
+}
+
+func (r *AgentEvalController) untrack(key types.NamespacedName, cause error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if cancel, ok := r.running[key]; ok {
+		cancel(cause)
+		delete(r.running, key)
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *AgentEvalController) SetupWithManager(mgr ctrl.Manager) error {
+	// Agent Deployments share the Agent's name, so both map to the
+	// AgentEvals that reference that name.
+	evalsForAgent := handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		return r.findAgentEvalsForAgent(ctx, types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()})
+	})
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(controller.Options{
+			NeedLeaderElection: new(true),
+		}).
+		For(&v1alpha2.AgentEval{}, builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{}))).
+		Watches(&v1alpha2.Agent{}, evalsForAgent, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&appsv1.Deployment{}, evalsForAgent, builder.WithPredicates(predicate.ResourceVersionChangedPredicate{})).
+		Named("agenteval").
+		Complete(r)
+}
+
+func (r *AgentEvalController) findAgentEvalsForAgent(ctx context.Context, agent types.NamespacedName) []reconcile.Request {
+	evals := &v1alpha2.AgentEvalList{}
+	if err := r.Client.List(ctx, evals, client.InNamespace(agent.Namespace)); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list AgentEvals", "agent", agent.String())
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, eval := range evals.Items {
+		if eval.Spec.AgentRef == agent.Name {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: eval.Namespace, Name: eval.Name}})
+		}
+	}
+	return requests
+}
+
+// agentEvalJudgeModelConfig returns the name of the ModelConfig that grades
+// rubrics, or "" if neither the AgentEval nor the agent names one.
+func agentEvalJudgeModelConfig(eval *v1alpha2.AgentEval, agent *v1alpha2.Agent) string {
+	if eval.Spec.JudgeModelConfig != "" {
+		return eval.Spec.JudgeModelConfig
+	}
+	if agent.Spec.Declarative != nil {
+		return agent.Spec.Declarative.ModelConfig
+	}
+	return ""
+}
+
+// scoreAgentEvalRun sets the score, passed cases and phase of a run from its
+// case results.
+func scoreAgentEvalRun(run *v1alpha2.AgentEvalRun, spec v1alpha2.AgentEvalSpec) {
+	var total int32
+	run.PassedCases = 0
+	for _, res := range run.Results {
+		total += res.Score
+		if res.Passed {
+			run.PassedCases++
+		}
+	}
+	if len(run.Results) > 0 {
+		run.Score = total / int32(len(run.Results))
+	}
+	threshold := int32(defaultAgentEvalPassThreshold)
+	if spec.PassThreshold != nil {
+		threshold = *spec.PassThreshold
+	}
+	run.Phase = v1alpha2.AgentEvalRunFailed
+	if run.PassedCases*100 >= threshold*run.TotalCases {
+		run.Phase = v1alpha2.AgentEvalRunPassed
+	}
+}
+
+// recordAgentEvalRun prepends a finished run to the history, trims the
+// history to the spec's limit and reports the run in the Passed condition.
+func recordAgentEvalRun(status *v1alpha2.AgentEvalStatus, spec v1alpha2.AgentEvalSpec, run v1alpha2.AgentEvalRun) {
+	limit := defaultAgentEvalHistoryLimit
+	if spec.HistoryLimit != nil {
+		limit = int(*spec.HistoryLimit)
+	}
+	status.History = append([]v1alpha2.AgentEvalRun{run}, status.History...)
+	if len(status.History) > limit {
+		status.History = status.History[:limit]
+	}
+
+	conditionStatus, reason := metav1.ConditionTrue, agentEvalReasonPassed
+	if run.Phase != v1alpha2.AgentEvalRunPassed {
+		conditionStatus, reason = metav1.ConditionFalse, agentEvalReasonFailed
+	}
+	message := fmt.Sprintf("%d/%d cases passed with score %d against Agent generation %d", run.PassedCases, run.TotalCases, run.Score, run.AgentGeneration)
+	if run.Message != "" {
+		message = run.Message
+	}
+	setAgentEvalCondition(status, v1alpha2.AgentEvalConditionTypePassed, run.EvalGeneration, conditionStatus, reason, message)
+}
+
+func setAgentEvalCondition(status *v1alpha2.AgentEvalStatus, conditionType string, generation int64, conditionStatus metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             conditionStatus,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: generation,
+	})
+}
@@ -0,0 +1,247 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	a2atype "github.com/a2aproject/a2a-go/v2/a2a"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	"github.com/kagent-dev/kagent/go/core/internal/a2a"
+	"github.com/kagent-dev/kagent/go/core/internal/controller/provider"
+)
+
+// fakeEvalSender replies to each prompt with the configured reply.
+type fakeEvalSender struct {
+	replies map[string]string
+	prompts []string
+}
+
+func (f *fakeEvalSender) SendMessage(_ context.Context, _, _ string, req *a2atype.SendMessageRequest) (a2atype.SendMessageResult, error) {
+	prompt := a2a.ExtractText(req.Message)
+	f.prompts = append(f.prompts, prompt)
+	reply, ok := f.replies[prompt]
+	if !ok {
+		return nil, errors.New("connection refused")
+	}
+	return a2atype.NewMessage(a2atype.MessageRoleAgent, a2atype.NewTextPart(reply)), nil
+}
+
+type fakeJudge struct {
+	verdict string
+	req     provider.SummaryRequest
+}
+
+func (f *fakeJudge) Summarize(_ context.Context, req provider.SummaryRequest) (string, error) {
+	f.req = req
+	return f.verdict, nil
+}
+
+func testAgentEval(spec v1alpha2.AgentEvalSpec) *v1alpha2.AgentEval {
+	return &v1alpha2.AgentEval{
+		ObjectMeta: metav1.ObjectMeta{Name: "k8s-agent-golden", Namespace: "kagent", Generation: 1},
+		Spec:       spec,
+	}
+}
+
+func testEvalDeployment(generation int64, rolledOut bool) *appsv1.Deployment {
+	d := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "k8s-agent", Namespace: "kagent", Generation: generation},
+		Spec:       appsv1.DeploymentSpec{Replicas: new(int32(1))},
+		Status:     appsv1.DeploymentStatus{ObservedGeneration: generation, Replicas: 1, UpdatedReplicas: 1, AvailableReplicas: 1},
+	}
+	if !rolledOut {
+		d.Status.UpdatedReplicas = 0
+	}
+	return d
+}
+
+func newAgentEvalController(t *testing.T, sender MessageSender, judge EvalJudge, objs ...client.Object) *AgentEvalController {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha2.AddToScheme(scheme))
+	require.NoError(t, appsv1.AddToScheme(scheme))
+	agent := &v1alpha2.Agent{
+		ObjectMeta: metav1.ObjectMeta{Name: "k8s-agent", Namespace: "kagent", Generation: 3},
+		Spec: v1alpha2.AgentSpec{
+			Type:        v1alpha2.AgentType_Declarative,
+			Declarative: &v1alpha2.DeclarativeAgentSpec{ModelConfig: "default-model-config"},
+		},
+		Status: v1alpha2.AgentStatus{ObservedGeneration: 3},
+	}
+	modelConfig := &v1alpha2.ModelConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "default-model-config", Namespace: "kagent"},
+		Spec:       v1alpha2.ModelConfigSpec{Provider: v1alpha2.ModelProviderOpenAI, Model: "gpt-4.1-mini"},
+	}
+	kube := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(append(objs, agent, modelConfig)...).
+		WithStatusSubresource(&v1alpha2.AgentEval{}).
+		Build()
+	return &AgentEvalController{Client: kube, Sender: sender, Judge: judge}
+}
+
+func reconcileAgentEval(t *testing.T, r *AgentEvalController) *v1alpha2.AgentEval {
+	t.Helper()
+	ctx := context.Background()
+	key := client.ObjectKey{Namespace: "kagent", Name: "k8s-agent-golden"}
+	_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	require.NoError(t, err)
+	r.wg.Wait()
+	latest := &v1alpha2.AgentEval{}
+	require.NoError(t, r.Client.Get(ctx, key, latest))
+	return latest
+}
+
+func TestAgentEvalReconcile(t *testing.T) {
+	spec := v1alpha2.AgentEvalSpec{
+		AgentRef:      "k8s-agent",
+		PassThreshold: new(int32(50)),
+		Cases: []v1alpha2.AgentEvalCase{
+			{
+				Name:   "crashloop",
+				Prompt: "Why is my pod crashing?",
+				Expect: v1alpha2.AgentEvalExpectation{
+					Contains: []string{"OOMKilled"},
+					Rubric:   &v1alpha2.AgentEvalRubric{Criteria: "Suggests raising the memory limit"},
+				},
+			},
+			{
+				Name:   "json",
+				Prompt: "List pods as JSON",
+				Expect: v1alpha2.AgentEvalExpectation{
+					JSONPath: []v1alpha2.AgentEvalJSONPathAssertion{{Path: ".pods[0].phase", Value: new("Running")}},
+				},
+			},
+		},
+	}
+	replies := map[string]string{
+		"Why is my pod crashing?": "The container was OOMKilled; raise its memory limit.",
+		"List pods as JSON":       "```json\n{\"pods\": [{\"name\": \"web\", \"phase\": \"Pending\"}]}\n```",
+	}
+
+	t.Run("runs the suite after a rollout and records the scores", func(t *testing.T) {
+		sender := &fakeEvalSender{replies: replies}
+		judge := &fakeJudge{verdict: `{"score": 8, "reason": "Suggests a higher limit"}`}
+		r := newAgentEvalController(t, sender, judge, testAgentEval(spec), testEvalDeployment(2, true))
+
+		eval := reconcileAgentEval(t, r)
+		assert.Nil(t, eval.Status.Active)
+		require.Len(t, eval.Status.History, 1)
+		run := eval.Status.History[0]
+		assert.Equal(t, v1alpha2.AgentEvalRunPassed, run.Phase)
+		assert.Equal(t, v1alpha2.AgentEvalTriggerRollout, run.Trigger)
+		assert.Equal(t, int64(3), run.AgentGeneration)
+		assert.Equal(t, int64(2), run.DeploymentGeneration)
+		assert.Equal(t, int32(1), run.PassedCases)
+		assert.Equal(t, int32(2), run.TotalCases)
+		assert.Equal(t, []v1alpha2.AgentEvalCaseResult{
+			{Name: "crashloop", Passed: true, Score: 90},
+			{Name: "json", Score: 0, Message: `.pods[0].phase is Pending, not "Running"`},
+		}, run.Results)
+		assert.Equal(t, int32(45), run.Score)
+		assert.Equal(t, "gpt-4.1-mini", judge.req.Model)
+		assert.Contains(t, judge.req.Prompt, "Suggests raising the memory limit")
+		assert.True(t, meta.IsStatusConditionTrue(eval.Status.Conditions, v1alpha2.AgentEvalConditionTypeReady))
+		assert.True(t, meta.IsStatusConditionTrue(eval.Status.Conditions, v1alpha2.AgentEvalConditionTypePassed))
+
+		// The rollout has been evaluated; nothing runs until something changes.
+		eval = reconcileAgentEval(t, r)
+		assert.Len(t, eval.Status.History, 1)
+
+		eval.Annotations = map[string]string{v1alpha2.AgentEvalRunAnnotation: "2026-10-16T10:00:00Z"}
+		require.NoError(t, r.Client.Update(context.Background(), eval))
+		eval = reconcileAgentEval(t, r)
+		require.Len(t, eval.Status.History, 2)
+		assert.Equal(t, v1alpha2.AgentEvalTriggerManual, eval.Status.History[0].Trigger)
+		assert.Equal(t, "2026-10-16T10:00:00Z", eval.Status.LastRunRequest)
+		assert.Len(t, sender.prompts, 4)
+	})
+
+	t.Run("fails below the pass threshold", func(t *testing.T) {
+		strict := *spec.DeepCopy()
+		strict.PassThreshold = nil
+		r := newAgentEvalController(t, &fakeEvalSender{replies: replies}, &fakeJudge{verdict: "Score: 3"}, testAgentEval(strict), testEvalDeployment(2, true))
+
+		eval := reconcileAgentEval(t, r)
+		require.Len(t, eval.Status.History, 1)
+		run := eval.Status.History[0]
+		assert.Equal(t, v1alpha2.AgentEvalRunFailed, run.Phase)
+		assert.Equal(t, int32(0), run.PassedCases)
+		assert.Contains(t, run.Results[0].Message, "rubric not graded: judge did not reply with JSON")
+		assert.Equal(t, int32(50), run.Results[0].Score)
+		assert.True(t, meta.IsStatusConditionFalse(eval.Status.Conditions, v1alpha2.AgentEvalConditionTypePassed))
+	})
+
+	t.Run("waits for the rollout to complete", func(t *testing.T) {
+		sender := &fakeEvalSender{replies: replies}
+		r := newAgentEvalController(t, sender, &fakeJudge{}, testAgentEval(spec), testEvalDeployment(2, false))
+
+		eval := reconcileAgentEval(t, r)
+		assert.Empty(t, eval.Status.History)
+		assert.Empty(t, sender.prompts)
+	})
+
+	t.Run("records a run orphaned by a restart as failed", func(t *testing.T) {
+		eval := testAgentEval(spec)
+		eval.Spec.Suspend = true
+		eval.Status.Active = &v1alpha2.AgentEvalRun{Phase: v1alpha2.AgentEvalRunRunning, TotalCases: 2, EvalGeneration: 1}
+		r := newAgentEvalController(t, &fakeEvalSender{}, &fakeJudge{}, eval, testEvalDeployment(2, true))
+
+		eval = reconcileAgentEval(t, r)
+		assert.Nil(t, eval.Status.Active)
+		require.Len(t, eval.Status.History, 1)
+		assert.Equal(t, v1alpha2.AgentEvalRunFailed, eval.Status.History[0].Phase)
+		assert.Equal(t, "controller restarted before the run finished", meta.FindStatusCondition(eval.Status.Conditions, v1alpha2.AgentEvalConditionTypePassed).Message)
+	})
+
+	t.Run("reports a missing agent", func(t *testing.T) {
+		missing := *spec.DeepCopy()
+		missing.AgentRef = "missing"
+		r := newAgentEvalController(t, &fakeEvalSender{}, &fakeJudge{}, testAgentEval(missing))
+
+		eval := reconcileAgentEval(t, r)
+		assert.True(t, meta.IsStatusConditionFalse(eval.Status.Conditions, v1alpha2.AgentEvalConditionTypeReady))
+	})
+}
+
+func TestCheckJSONPath(t *testing.T) {
+	tests := []struct {
+		name  string
+		reply string
+		path  string
+		value *string
+		want  string
+	}{
+		{"field exists", `{"status": "ok"}`, "{.status}", nil, ""},
+		{"value matches", `Here you go: {"count": 3}`, ".count", new("3"), ""},
+		{"any array element", `{"items": [{"n": "a"}, {"n": "b"}]}`, ".items[*].n", new("b"), ""},
+		{"missing field", `{"status": "ok"}`, ".reason", nil, "reply has no .reason"},
+		{"not json", "all good", ".status", nil, "reply is not JSON"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, checkJSONPath(tt.reply, v1alpha2.AgentEvalJSONPathAssertion{Path: tt.path, Value: tt.value}))
+		})
+	}
+}
+
+func TestRecordAgentEvalRunTrimsHistory(t *testing.T) {
+	status := &v1alpha2.AgentEvalStatus{}
+	spec := v1alpha2.AgentEvalSpec{HistoryLimit: new(int32(2))}
+	for i := range 3 {
+		recordAgentEvalRun(status, spec, v1alpha2.AgentEvalRun{Phase: v1alpha2.AgentEvalRunPassed, AgentGeneration: int64(i)})
+	}
+	require.Len(t, status.History, 2)
+	assert.Equal(t, int64(2), status.History[0].AgentGeneration)
+	assert.Equal(t, int64(1), status.History[1].AgentGeneration)
+}
@@ -0,0 +1,233 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"k8s.io/client-go/util/jsonpath"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	"github.com/kagent-dev/kagent/go/core/internal/controller/provider"
+)
+
+const (
+	defaultAgentEvalRubricMinScore = 7
+	// agentEvalJudgeMaxTokens bounds the judge's reply, which is a short
+	// JSON object.
+	agentEvalJudgeMaxTokens = 256
+)
+
+const agentEvalJudgePrompt = `You are grading the reply of an AI agent against a rubric.
+
+Rubric:
+%s
+
+Prompt sent to the agent:
+%s
+
+Agent reply:
+%s
+
+Respond with only a JSON object of the form {"score": <integer from 1 to 10>, "reason": "<one sentence>"}, where 10 means the reply fully satisfies the rubric and 1 means it does not satisfy it at all.`
+
+// evalGrader checks an agent's replies against the assertions of a case.
+type evalGrader struct {
+	judge EvalJudge
+	// judgeRequest resolves the judge ModelConfig once per run, on the first
+	// rubric assertion.
+	judgeRequest func() (provider.SummaryRequest, error)
+}
+
+// grade scores a reply. Each assertion scores 100 when it holds and 0 when
+// it does not, except rubrics, which score ten times the judge's grade. The
+// case passes when every assertion holds.
+func (g *evalGrader) grade(ctx context.Context, c v1alpha2.AgentEvalCase, reply string) v1alpha2.AgentEvalCaseResult {
+	var scores []int32
+	var failures []string
+	record := func(score int32, failure string) {
+		scores = append(scores, score)
+		if failure != "" {
+			failures = append(failures, failure)
+		}
+	}
+	assert := func(failure string) {
+		if failure == "" {
+			record(100, "")
+		} else {
+			record(0, failure)
+		}
+	}
+
+	expect := c.Expect
+	lower := strings.ToLower(reply)
+	for _, s := range expect.Contains {
+		if strings.Contains(lower, strings.ToLower(s)) {
+			assert("")
+		} else {
+			assert(fmt.Sprintf("reply does not contain %q", s))
+		}
+	}
+	for _, s := range expect.NotContains {
+		if strings.Contains(lower, strings.ToLower(s)) {
+			assert(fmt.Sprintf("reply contains %q", s))
+		} else {
+			assert("")
+		}
+	}
+	if expect.Matches != "" {
+		re, err := regexp.Compile(expect.Matches)
+		switch {
+		case err != nil:
+			assert(fmt.Sprintf("invalid matches expression: %v", err))
+		case !re.MatchString(reply):
+			assert(fmt.Sprintf("reply does not match %q", expect.Matches))
+		default:
+			assert("")
+		}
+	}
+	for _, a := range expect.JSONPath {
+		assert(checkJSONPath(reply, a))
+	}
+	if expect.Rubric != nil {
+		record(g.gradeRubric(ctx, c, reply))
+	}
+
+	result := v1alpha2.AgentEvalCaseResult{Name: c.Name, Passed: len(failures) == 0, Score: 100}
+	if len(scores) > 0 {
+		var total int32
+		for _, s := range scores {
+			total += s
+		}
+		result.Score = total / int32(len(scores))
+	}
+	result.Message = truncateRunMessage(strings.Join(failures, "; "))
+	return result
+}
+
+// gradeRubric asks the judge model to grade the reply and returns the
+// assertion score and, if the grade is below the minimum, why.
+func (g *evalGrader) gradeRubric(ctx context.Context, c v1alpha2.AgentEvalCase, reply string) (int32, string) {
+	req, err := g.judgeRequest()
+	if err != nil {
+		return 0, fmt.Sprintf("rubric not graded: %v", err)
+	}
+	req.Prompt = fmt.Sprintf(agentEvalJudgePrompt, c.Expect.Rubric.Criteria, c.Prompt, reply)
+	req.MaxTokens = agentEvalJudgeMaxTokens
+	out, err := g.judge.Summarize(ctx, req)
+	if err != nil {
+		return 0, fmt.Sprintf("rubric not graded: %v", err)
+	}
+	score, reason, err := parseJudgeVerdict(out)
+	if err != nil {
+		return 0, fmt.Sprintf("rubric not graded: %v", err)
+	}
+
+	minScore := int32(defaultAgentEvalRubricMinScore)
+	if c.Expect.Rubric.MinScore != nil {
+		minScore = *c.Expect.Rubric.MinScore
+	}
+	if score < minScore {
+		return score * 10, fmt.Sprintf("judge scored %d/10, below %d: %s", score, minScore, reason)
+	}
+	return score * 10, ""
+}
+
+// parseJudgeVerdict reads the score and reason from the judge's reply.
+func parseJudgeVerdict(out string) (int32, string, error) {
+	var verdict struct {
+		Score  int32  `json:"score"`
+		Reason string `json:"reason"`
+	}
+	start, end := strings.Index(out, "{"), strings.LastIndex(out, "}")
+	if start < 0 || end < start {
+		return 0, "", fmt.Errorf("judge did not reply with JSON: %q", out)
+	}
+	if err := json.Unmarshal([]byte(out[start:end+1]), &verdict); err != nil {
+		return 0, "", fmt.Errorf("invalid judge reply: %w", err)
+	}
+	if verdict.Score < 1 || verdict.Score > 10 {
+		return 0, "", fmt.Errorf("judge score %d is not between 1 and 10", verdict.Score)
+	}
+	return verdict.Score, verdict.Reason, nil
+}
+
+// checkJSONPath returns why the JSON in reply does not satisfy the
+// assertion, or "" when it does.
+func checkJSONPath(reply string, a v1alpha2.AgentEvalJSONPathAssertion) string {
+	data, err := replyJSON(reply)
+	if err != nil {
+		return err.Error()
+	}
+	path := a.Path
+	if !strings.HasPrefix(path, "{") {
+		path = "{" + path + "}"
+	}
+	jp := jsonpath.New("assertion")
+	if err := jp.Parse(path); err != nil {
+		return fmt.Sprintf("invalid JSONPath %q: %v", a.Path, err)
+	}
+	results, err := jp.FindResults(data)
+	if err != nil || len(results) == 0 || len(results[0]) == 0 {
+		return fmt.Sprintf("reply has no %s", a.Path)
+	}
+	if a.Value == nil {
+		return ""
+	}
+	var found []string
+	for _, v := range results[0] {
+		text := fmt.Sprint(v.Interface())
+		if _, ok := v.Interface().(string); !ok {
+			if b, err := json.Marshal(v.Interface()); err == nil {
+				text = string(b)
+			}
+		}
+		if text == *a.Value {
+			return ""
+		}
+		found = append(found, text)
+	}
+	return fmt.Sprintf("%s is %s, not %q", a.Path, strings.Join(found, ", "), *a.Value)
+}
+
+// replyJSON decodes the JSON in a reply: the whole reply, the first Markdown
+// code block, or the outermost object in the text.
+func replyJSON(reply string) (any, error) {
+	candidates := []string{strings.TrimSpace(reply)}
+	if _, rest, ok := strings.Cut(reply, "```"); ok {
+		if _, body, ok := strings.Cut(rest, "\n"); ok {
+			if block, _, ok := strings.Cut(body, "```"); ok {
+				candidates = append(candidates, block)
+			}
+		}
+	}
+	if start, end := strings.Index(reply, "{"), strings.LastIndex(reply, "}"); start >= 0 && end > start {
+		candidates = append(candidates, reply[start:end+1])
+	}
+	for _, c := range candidates {
+		var data any
+		if json.Unmarshal([]byte(c), &data) == nil {
+			return data, nil
+		}
+	}
+	return nil, errors.New("reply is not JSON")
+}
@@ -0,0 +1,549 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	a2atype "github.com/a2aproject/a2a-go/v2/a2a"
+	"github.com/google/uuid"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	"github.com/kagent-dev/kagent/go/core/internal/a2a"
+	"github.com/kagent-dev/kagent/go/core/internal/cron"
+)
+
+const (
+	cronAgentReasonScheduled       = "Scheduled"
+	cronAgentReasonSuspended       = "Suspended"
+	cronAgentReasonInvalidSchedule = "InvalidSchedule"
+	cronAgentReasonAgentNotFound   = "AgentNotFound"
+
+	defaultCronAgentRunTimeout         = 10 * time.Minute
+	defaultCronAgentSuccessfulHistory  = 3
+	defaultCronAgentFailedHistory      = 1
+	cronAgentAgentNotFoundRequeueDelay = time.Minute
+	// maxCronAgentRunMessageBytes bounds the reply kept in status; the
+	// webhook receives the full reply.
+	maxCronAgentRunMessageBytes = 1024
+)
+
+var (
+	errCronAgentRunReplaced = errors.New("replaced by a newer run")
+	errCronAgentDeleted     = errors.New("CronAgent was deleted")
+)
+
+// MessageSender sends an A2A message to an agent. It is satisfied by
+// a2a.AgentClientRegistry.
+type MessageSender interface {
+	SendMessage(ctx context.Context, namespace, name string, req *a2atype.SendMessageRequest) (a2atype.SendMessageResult, error)
+}
+
+// CronAgentController reconciles a CronAgent object. Runs execute in
+// goroutines owned by the controller, so a run in progress when the
+// controller restarts is recorded as failed.
+type CronAgentController struct {
+	Client     client.Client
+	Sender     MessageSender
+	HTTPClient *http.Client
+
+	now func() time.Time
+
+	mu sync.Mutex
+	// running holds the cancel functions of runs in progress, by CronAgent
+	// and session ID.
+	running map[types.NamespacedName]map[string]context.CancelCauseFunc
+	// wg tracks run goroutines, for tests.
+	wg sync.WaitGroup
+}
+
+// +kubebuilder:rbac:groups=kagent.dev,resources=cronagents,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=kagent.dev,resources=cronagents/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=kagent.dev,resources=cronagents/finalizers,verbs=update
+// +kubebuilder:rbac:groups=kagent.dev,resources=agents,verbs=get;list;watch
+
+func (r *CronAgentController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	cronAgent := &v1alpha2.CronAgent{}
+	if err := r.Client.Get(ctx, req.NamespacedName, cronAgent); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.cancelRuns(req.NamespacedName, errCronAgentDeleted)
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	status := cronAgent.Status.DeepCopy()
+	status.ObservedGeneration = cronAgent.Generation
+	r.failOrphanedRuns(cronAgent, status)
+
+	schedule, loc, err := parseCronAgentSchedule(cronAgent.Spec)
+	if err != nil {
+		status.NextScheduleTime = nil
+		setCronAgentReady(status, cronAgent.Generation, metav1.ConditionFalse, cronAgentReasonInvalidSchedule, err.Error())
+		return ctrl.Result{}, r.updateStatus(ctx, cronAgent, status)
+	}
+
+	now := r.clock().In(loc)
+	if cronAgent.Spec.Suspend {
+		status.NextScheduleTime = nil
+		setCronAgentReady(status, cronAgent.Generation, metav1.ConditionTrue, cronAgentReasonSuspended, "CronAgent is suspended")
+		return ctrl.Result{}, r.updateStatus(ctx, cronAgent, status)
+	}
+
+	agent := &v1alpha2.Agent{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Namespace: cronAgent.Namespace, Name: cronAgent.Spec.AgentRef}, agent); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+		setCronAgentReady(status, cronAgent.Generation, metav1.ConditionFalse, cronAgentReasonAgentNotFound,
+			fmt.Sprintf("Agent %s not found in namespace %s", cronAgent.Spec.AgentRef, cronAgent.Namespace))
+		if err := r.updateStatus(ctx, cronAgent, status); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: cronAgentAgentNotFoundRequeueDelay}, nil
+	}
+
+	var started *v1alpha2.CronAgentRun
+	if due := mostRecentActivation(schedule, cronAgent, now); !due.IsZero() {
+		started = r.startRunIfAllowed(ctx, cronAgent, status, due, now)
+	}
+
+	next := schedule.Next(now)
+	status.NextScheduleTime = &metav1.Time{Time: next}
+	setCronAgentReady(status, cronAgent.Generation, metav1.ConditionTrue, cronAgentReasonScheduled,
+		fmt.Sprintf("Next run at %s", next.Format(time.RFC3339)))
+
+	if err := r.updateStatus(ctx, cronAgent, status); err != nil {
+		if started != nil {
+			r.untrack(req.NamespacedName, started.SessionID, errors.New("failed to record run"))
+		}
+		return ctrl.Result{}, err
+	}
+	if started != nil {
+		logger.Info("Starting CronAgent run", "scheduledTime", started.ScheduledTime.Time, "sessionID", started.SessionID)
+		r.wg.Go(func() {
+			r.run(req.NamespacedName, cronAgent.Spec, *started)
+		})
+	}
+	return ctrl.Result{RequeueAfter: next.Sub(now)}, nil
+}
+
+// startRunIfAllowed records a run for the activation at due in status and
+// returns it, or returns nil when the concurrency policy says not to run.
+// The caller starts the run once status is saved.
+func (r *CronAgentController) startRunIfAllowed(ctx context.Context, cronAgent *v1alpha2.CronAgent, status *v1alpha2.CronAgentStatus, due, now time.Time) *v1alpha2.CronAgentRun {
+	logger := log.FromContext(ctx)
+	key := client.ObjectKeyFromObject(cronAgent)
+
+	if len(status.Active) > 0 {
+		switch cronAgent.Spec.ConcurrencyPolicy {
+		case v1alpha2.CronAgentConcurrencyAllow:
+		case v1alpha2.CronAgentConcurrencyReplace:
+			r.cancelRuns(key, errCronAgentRunReplaced)
+		default:
+			logger.Info("Previous run still in progress, skipping run", "scheduledTime", due)
+			return nil
+		}
+	}
+
+	run := v1alpha2.CronAgentRun{
+		ScheduledTime: metav1.Time{Time: due},
+		StartTime:     &metav1.Time{Time: now},
+		Phase:         v1alpha2.CronAgentRunRunning,
+		SessionID:     uuid.New().String(),
+	}
+	status.Active = append(status.Active, run)
+	status.LastScheduleTime = &run.ScheduledTime
+	r.track(key, run.SessionID)
+	return &run
+}
+
+// failOrphanedRuns moves active runs this controller is not running, left
+// behind by a restart, to the history as failed.
+func (r *CronAgentController) failOrphanedRuns(cronAgent *v1alpha2.CronAgent, status *v1alpha2.CronAgentStatus) {
+	key := client.ObjectKeyFromObject(cronAgent)
+	active := status.Active[:0]
+	for _, run := range status.Active {
+		if r.tracked(key, run.SessionID) {
+			active = append(active, run)
+			continue
+		}
+		run.Phase = v1alpha2.CronAgentRunFailed
+		run.CompletionTime = &metav1.Time{Time: r.clock()}
+		run.Message = "controller restarted before the run finished"
+		recordCronAgentRun(status, cronAgent.Spec, run)
+	}
+	status.Active = active
+}
+
+// run sends the task to the agent in a new session and records the result.
+func (r *CronAgentController) run(key types.NamespacedName, spec v1alpha2.CronAgentSpec, run v1alpha2.CronAgentRun) {
+	logger := ctrl.Log.WithName("cronagent-controller").WithValues("cronAgent", key.String(), "sessionID", run.SessionID)
+
+	ctx := r.runContext(key, run.SessionID)
+	timeout := defaultCronAgentRunTimeout
+	if spec.Timeout != nil && spec.Timeout.Duration > 0 {
+		timeout = spec.Timeout.Duration
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	reply, taskID, err := r.send(ctx, key.Namespace, spec, run.SessionID)
+	if cause := context.Cause(ctx); err != nil && cause != nil {
+		switch {
+		case errors.Is(cause, context.DeadlineExceeded):
+			err = fmt.Errorf("timed out after %s", timeout)
+		case !errors.Is(cause, context.Canceled):
+			err = cause
+		}
+	}
+	cancel()
+
+	run.TaskID = taskID
+	run.CompletionTime = &metav1.Time{Time: r.clock()}
+	if err != nil {
+		run.Phase = v1alpha2.CronAgentRunFailed
+		run.Message = err.Error()
+		logger.Info("CronAgent run failed", "error", err.Error())
+	} else {
+		run.Phase = v1alpha2.CronAgentRunSucceeded
+		run.Message = truncateRunMessage(reply)
+		logger.Info("CronAgent run succeeded")
+	}
+
+	bg := context.Background()
+	cronAgent, recErr := r.recordRun(bg, key, run)
+	r.untrack(key, run.SessionID, nil)
+	if recErr != nil {
+		logger.Error(recErr, "Failed to record CronAgent run")
+	}
+	if cronAgent != nil && cronAgent.Spec.Webhook != nil {
+		if err := r.postWebhook(bg, cronAgent, run, reply); err != nil {
+			logger.Error(err, "Failed to post CronAgent run to webhook")
+		}
+	}
+}
+
+func (r *CronAgentController) send(ctx context.Context, namespace string, spec v1alpha2.CronAgentSpec, sessionID string) (string, string, error) {
+	return sendAgentMessage(ctx, r.Sender, namespace, spec.AgentRef, spec.Task, sessionID)
+}
+
+// sendAgentMessage sends prompt to an agent in the session contextID and
+// returns the reply and the ID of the task the agent created, if any.
+func sendAgentMessage(ctx context.Context, sender MessageSender, namespace, name, prompt, contextID string) (string, string, error) {
+	message := a2atype.NewMessage(a2atype.MessageRoleUser, a2atype.NewTextPart(prompt))
+	message.ContextID = contextID
+	result, err := sender.SendMessage(ctx, namespace, name, &a2atype.SendMessageRequest{Message: message})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to send message: %w", err)
+	}
+	switch res := result.(type) {
+	case *a2atype.Message:
+		return a2a.ExtractText(res), string(res.TaskID), nil
+	case *a2atype.Task:
+		if res.Status.State == a2atype.TaskStateFailed {
+			return "", string(res.ID), fmt.Errorf("task failed: %s", a2a.ExtractText(res.Status.Message))
+		}
+		var text strings.Builder
+		for _, artifact := range res.Artifacts {
+			text.WriteString(a2a.ExtractText(&a2atype.Message{Parts: artifact.Parts}))
+		}
+		if text.Len() == 0 {
+			text.WriteString(a2a.ExtractText(res.Status.Message))
+		}
+		return text.String(), string(res.ID), nil
+	default:
+		return "", "", fmt.Errorf("unexpected A2A result %T", result)
+	}
+}
+
+// recordRun moves a finished run from the active list to the history and
+// returns the updated CronAgent, or nil if it was deleted.
+func (r *CronAgentController) recordRun(ctx context.Context, key types.NamespacedName, run v1alpha2.CronAgentRun) (*v1alpha2.CronAgent, error) {
+	var latest *v1alpha2.CronAgent
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest = &v1alpha2.CronAgent{}
+		if err := r.Client.Get(ctx, key, latest); err != nil {
+			return err
+		}
+		active := latest.Status.Active[:0]
+		for _, a := range latest.Status.Active {
+			if a.SessionID != run.SessionID {
+				active = append(active, a)
+			}
+		}
+		latest.Status.Active = active
+		recordCronAgentRun(&latest.Status, latest.Spec, run)
+		return r.Client.Status().Update(ctx, latest)
+	})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return latest, fmt.Errorf("failed to update CronAgent status: %w", err)
+	}
+	return latest, nil
+}
+
+// cronAgentWebhookPayload is the body posted by the JSON webhook format.
+type cronAgentWebhookPayload struct {
+	CronAgent string                `json:"cronAgent"`
+	Namespace string                `json:"namespace"`
+	Agent     string                `json:"agent"`
+	Run       v1alpha2.CronAgentRun `json:"run"`
+	Output    string                `json:"output,omitempty"`
+}
+
+func (r *CronAgentController) postWebhook(ctx context.Context, cronAgent *v1alpha2.CronAgent, run v1alpha2.CronAgentRun, reply string) error {
+	webhook := cronAgent.Spec.Webhook
+	url := webhook.URL
+	if webhook.URLFrom != nil {
+		resolved, err := webhook.URLFrom.Resolve(ctx, r.Client, cronAgent.Namespace)
+		if err != nil {
+			return fmt.Errorf("failed to resolve webhook URL: %w", err)
+		}
+		url = strings.TrimSpace(resolved)
+	}
+
+	var body any
+	switch webhook.Format {
+	case v1alpha2.CronAgentWebhookFormatSlack:
+		text := reply
+		if run.Phase == v1alpha2.CronAgentRunFailed {
+			text = fmt.Sprintf("CronAgent %s/%s run failed: %s", cronAgent.Namespace, cronAgent.Name, run.Message)
+		}
+		body = map[string]string{"text": text}
+	default:
+		body = cronAgentWebhookPayload{
+			CronAgent: cronAgent.Name,
+			Namespace: cronAgent.Namespace,
+			Agent:     cronAgent.Spec.AgentRef,
+			Run:       run,
+			Output:    reply,
+		}
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	httpClient := r.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+func (r *CronAgentController) updateStatus(ctx context.Context, cronAgent *v1alpha2.CronAgent, status *v1alpha2.CronAgentStatus) error {
+	cronAgent.Status = *status
+	if err := r.Client.Status().Update(ctx, cronAgent); err != nil {
+		return fmt.Errorf("failed to update CronAgent status: %w", err)
+	}
+	return nil
+}
+
+func (r *CronAgentController) clock() time.Time {
+	if r.now != nil {
+		return r.now()
+	}
+	return time.Now()
+}
+
+func (r *CronAgentController) track(key types.NamespacedName, sessionID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.running == nil {
+		r.running = map[types.NamespacedName]map[string]context.CancelCauseFunc{}
+	}
+	if r.running[key] == nil {
+		r.running[key] = map[string]context.CancelCauseFunc{}
+	}
+	// Replaced by the run's own cancel function once it starts.
+	r.running[key][sessionID] = func(error) {}
+}
+
+// runContext returns the context of a tracked run. Runs outlive the
+// reconcile that starts them, so they are not derived from its context.
+func (r *CronAgentController) runContext(key types.NamespacedName, sessionID string) context.Context {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.running[key][sessionID]; !ok {
+		cancel(errCronAgentDeleted)
+		return ctx
+	}
+	r.running[key][sessionID] = cancel
+	return ctx
+}
+
+func (r *CronAgentController) tracked(key types.NamespacedName, sessionID string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, ok := r.running[key][sessionID]
+	return ok
+}
+
+func (r *CronAgentController) untrack(key types.NamespacedName, sessionID string, cause error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if cancel, ok := r.running[key][sessionID]; ok {
+		cancel(cause)
+		delete(r.running[key], sessionID)
+	}
+	if len(r.running[key]) == 0 {
+		delete(r.running, key)
+	}
+}
+
+func (r *CronAgentController) cancelRuns(key types.NamespacedName, cause error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, cancel := range r.running[key] {
+		cancel(cause)
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *CronAgentController) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(controller.Options{
+			NeedLeaderElection: new(true),
+		}).
+		For(&v1alpha2.CronAgent{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Named("cronagent").
+		Complete(r)
+}
+
+func parseCronAgentSchedule(spec v1alpha2.CronAgentSpec) (*cron.Schedule, *time.Location, error) {
+	loc := time.UTC
+	if spec.TimeZone != "" {
+		var err error
+		if loc, err = time.LoadLocation(spec.TimeZone); err != nil {
+			return nil, nil, fmt.Errorf("invalid time zone %q: %w", spec.TimeZone, err)
+		}
+	}
+	schedule, err := cron.Parse(spec.Schedule)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid schedule %q: %w", spec.Schedule, err)
+	}
+	if schedule.Next(time.Now().In(loc)).IsZero() {
+		return nil, nil, fmt.Errorf("schedule %q never fires", spec.Schedule)
+	}
+	return schedule, loc, nil
+}
+
+// mostRecentActivation returns the latest activation time at or before now
+// that has not been scheduled yet and is within the starting deadline, or
+// the zero time if there is none. Only the most recent of several missed
+// activations is run.
+func mostRecentActivation(schedule *cron.Schedule, cronAgent *v1alpha2.CronAgent, now time.Time) time.Time {
+	earliest := cronAgent.CreationTimestamp.Time
+	if last := cronAgent.Status.LastScheduleTime; last != nil {
+		earliest = last.Time
+	}
+	if d := cronAgent.Spec.StartingDeadlineSeconds; d != nil {
+		if deadline := now.Add(-time.Duration(*d) * time.Second); deadline.After(earliest) {
+			earliest = deadline
+		}
+	}
+
+	var due time.Time
+	for t := schedule.Next(earliest.In(now.Location())); !t.IsZero() && !t.After(now); t = schedule.Next(t) {
+		due = t
+	}
+	return due
+}
+
+// recordCronAgentRun prepends a finished run to the history and trims the
+// history to the spec's limits.
+func recordCronAgentRun(status *v1alpha2.CronAgentStatus, spec v1alpha2.CronAgentSpec, run v1alpha2.CronAgentRun) {
+	if run.Phase == v1alpha2.CronAgentRunSucceeded {
+		status.LastSuccessfulTime = run.CompletionTime
+	}
+	successLimit, failedLimit := int32(defaultCronAgentSuccessfulHistory), int32(defaultCronAgentFailedHistory)
+	if spec.SuccessfulRunsHistoryLimit != nil {
+		successLimit = *spec.SuccessfulRunsHistoryLimit
+	}
+	if spec.FailedRunsHistoryLimit != nil {
+		failedLimit = *spec.FailedRunsHistoryLimit
+	}
+
+	history := make([]v1alpha2.CronAgentRun, 0, len(status.History)+1)
+	var succeeded, failed int32
+	for _, h := range append([]v1alpha2.CronAgentRun{run}, status.History...) {
+		if h.Phase == v1alpha2.CronAgentRunSucceeded {
+			if succeeded++; succeeded > successLimit {
+				continue
+			}
+		} else if failed++; failed > failedLimit {
+			continue
+		}
+		history = append(history, h)
+	}
+	status.History = history
+}
+
+func setCronAgentReady(status *v1alpha2.CronAgentStatus, generation int64, conditionStatus metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               v1alpha2.CronAgentConditionTypeReady,
+		Status:             conditionStatus,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: generation,
+	})
+}
+
+func truncateRunMessage(s string) string {
+	if len(s) <= maxCronAgentRunMessageBytes {
+		return s
+	}
+	return strings.ToValidUTF8(s[:maxCronAgentRunMessageBytes], "") + "..."
+}
@@ -0,0 +1,226 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	a2atype "github.com/a2aproject/a2a-go/v2/a2a"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	"github.com/kagent-dev/kagent/go/core/internal/cron"
+)
+
+type fakeCronSender struct {
+	reply     string
+	err       error
+	contextID string
+}
+
+func (f *fakeCronSender) SendMessage(_ context.Context, _, _ string, req *a2atype.SendMessageRequest) (a2atype.SendMessageResult, error) {
+	f.contextID = req.Message.ContextID
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &a2atype.Task{
+		ID:        "task-1",
+		ContextID: req.Message.ContextID,
+		Status:    a2atype.TaskStatus{State: a2atype.TaskStateCompleted},
+		Artifacts: []*a2atype.Artifact{{Parts: a2atype.ContentParts{a2atype.NewTextPart(f.reply)}}},
+	}, nil
+}
+
+func testCronAgent(spec v1alpha2.CronAgentSpec) *v1alpha2.CronAgent {
+	return &v1alpha2.CronAgent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "morning-report",
+			Namespace:         "kagent",
+			Generation:        1,
+			CreationTimestamp: metav1.NewTime(time.Date(2026, 3, 10, 7, 0, 0, 0, time.UTC)),
+		},
+		Spec: spec,
+	}
+}
+
+func newCronAgentController(t *testing.T, sender MessageSender, now time.Time, objs ...client.Object) *CronAgentController {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha2.AddToScheme(scheme))
+	kube := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(append(objs, &v1alpha2.Agent{ObjectMeta: metav1.ObjectMeta{Name: "k8s-agent", Namespace: "kagent"}})...).
+		WithStatusSubresource(&v1alpha2.CronAgent{}).
+		Build()
+	return &CronAgentController{Client: kube, Sender: sender, now: func() time.Time { return now }}
+}
+
+func reconcileCronAgent(t *testing.T, r *CronAgentController) (ctrl.Result, *v1alpha2.CronAgent) {
+	t.Helper()
+	ctx := context.Background()
+	key := client.ObjectKey{Namespace: "kagent", Name: "morning-report"}
+	result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	require.NoError(t, err)
+	r.wg.Wait()
+	latest := &v1alpha2.CronAgent{}
+	require.NoError(t, r.Client.Get(ctx, key, latest))
+	return result, latest
+}
+
+func TestCronAgentReconcile(t *testing.T) {
+	now := time.Date(2026, 3, 10, 8, 0, 30, 0, time.UTC)
+
+	t.Run("due run is sent in a new session and posted to the webhook", func(t *testing.T) {
+		var posted map[string]string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			assert.NoError(t, json.NewDecoder(req.Body).Decode(&posted))
+		}))
+		defer server.Close()
+
+		sender := &fakeCronSender{reply: "3 warning events in kube-system"}
+		r := newCronAgentController(t, sender, now, testCronAgent(v1alpha2.CronAgentSpec{
+			Schedule: "0 8 * * *",
+			AgentRef: "k8s-agent",
+			Task:     "Summarize cluster events",
+			Webhook:  &v1alpha2.CronAgentWebhook{URL: server.URL, Format: v1alpha2.CronAgentWebhookFormatSlack},
+		}))
+
+		result, cronAgent := reconcileCronAgent(t, r)
+		assert.Equal(t, 23*time.Hour+59*time.Minute+30*time.Second, result.RequeueAfter)
+		assert.Empty(t, cronAgent.Status.Active)
+		require.Len(t, cronAgent.Status.History, 1)
+		run := cronAgent.Status.History[0]
+		assert.Equal(t, v1alpha2.CronAgentRunSucceeded, run.Phase)
+		assert.Equal(t, sender.contextID, run.SessionID)
+		assert.Equal(t, "task-1", run.TaskID)
+		assert.Equal(t, "3 warning events in kube-system", run.Message)
+		assert.True(t, cronAgent.Status.LastScheduleTime.Equal(&metav1.Time{Time: time.Date(2026, 3, 10, 8, 0, 0, 0, time.UTC)}))
+		assert.NotNil(t, cronAgent.Status.LastSuccessfulTime)
+		assert.True(t, meta.IsStatusConditionTrue(cronAgent.Status.Conditions, v1alpha2.CronAgentConditionTypeReady))
+		assert.Equal(t, map[string]string{"text": "3 warning events in kube-system"}, posted)
+
+		// The activation has been run; the next reconcile waits for tomorrow.
+		_, cronAgent = reconcileCronAgent(t, r)
+		assert.Len(t, cronAgent.Status.History, 1)
+	})
+
+	t.Run("failed run is recorded", func(t *testing.T) {
+		r := newCronAgentController(t, &fakeCronSender{err: errors.New("connection refused")}, now, testCronAgent(v1alpha2.CronAgentSpec{
+			Schedule: "@hourly",
+			AgentRef: "k8s-agent",
+			Task:     "Summarize cluster events",
+		}))
+		_, cronAgent := reconcileCronAgent(t, r)
+		require.Len(t, cronAgent.Status.History, 1)
+		assert.Equal(t, v1alpha2.CronAgentRunFailed, cronAgent.Status.History[0].Phase)
+		assert.Contains(t, cronAgent.Status.History[0].Message, "connection refused")
+		assert.Nil(t, cronAgent.Status.LastSuccessfulTime)
+	})
+
+	t.Run("missed starting deadline skips the run", func(t *testing.T) {
+		r := newCronAgentController(t, &fakeCronSender{}, now.Add(5*time.Minute), testCronAgent(v1alpha2.CronAgentSpec{
+			Schedule:                "0 8 * * *",
+			AgentRef:                "k8s-agent",
+			Task:                    "Summarize cluster events",
+			StartingDeadlineSeconds: new(int64(60)),
+		}))
+		_, cronAgent := reconcileCronAgent(t, r)
+		assert.Empty(t, cronAgent.Status.History)
+		assert.Nil(t, cronAgent.Status.LastScheduleTime)
+	})
+
+	t.Run("orphaned active run is failed and forbid no longer blocks", func(t *testing.T) {
+		cronAgent := testCronAgent(v1alpha2.CronAgentSpec{
+			Schedule: "0 8 * * *",
+			AgentRef: "k8s-agent",
+			Task:     "Summarize cluster events",
+		})
+		cronAgent.Status.Active = []v1alpha2.CronAgentRun{{
+			ScheduledTime: metav1.NewTime(time.Date(2026, 3, 9, 8, 0, 0, 0, time.UTC)),
+			Phase:         v1alpha2.CronAgentRunRunning,
+			SessionID:     "lost",
+		}}
+		cronAgent.Status.LastScheduleTime = &cronAgent.Status.Active[0].ScheduledTime
+		r := newCronAgentController(t, &fakeCronSender{reply: "ok"}, now, cronAgent)
+
+		_, cronAgent = reconcileCronAgent(t, r)
+		require.Len(t, cronAgent.Status.History, 2)
+		assert.Equal(t, v1alpha2.CronAgentRunSucceeded, cronAgent.Status.History[0].Phase)
+		assert.Equal(t, "lost", cronAgent.Status.History[1].SessionID)
+		assert.Equal(t, v1alpha2.CronAgentRunFailed, cronAgent.Status.History[1].Phase)
+	})
+
+	t.Run("invalid schedule", func(t *testing.T) {
+		r := newCronAgentController(t, &fakeCronSender{}, now, testCronAgent(v1alpha2.CronAgentSpec{
+			Schedule: "0 25 * * *",
+			AgentRef: "k8s-agent",
+			Task:     "Summarize cluster events",
+		}))
+		result, cronAgent := reconcileCronAgent(t, r)
+		assert.Zero(t, result.RequeueAfter)
+		cond := meta.FindStatusCondition(cronAgent.Status.Conditions, v1alpha2.CronAgentConditionTypeReady)
+		require.NotNil(t, cond)
+		assert.Equal(t, cronAgentReasonInvalidSchedule, cond.Reason)
+	})
+
+	t.Run("missing agent", func(t *testing.T) {
+		r := newCronAgentController(t, &fakeCronSender{}, now, testCronAgent(v1alpha2.CronAgentSpec{
+			Schedule: "0 8 * * *",
+			AgentRef: "missing",
+			Task:     "Summarize cluster events",
+		}))
+		_, cronAgent := reconcileCronAgent(t, r)
+		assert.Empty(t, cronAgent.Status.History)
+		cond := meta.FindStatusCondition(cronAgent.Status.Conditions, v1alpha2.CronAgentConditionTypeReady)
+		require.NotNil(t, cond)
+		assert.Equal(t, cronAgentReasonAgentNotFound, cond.Reason)
+	})
+}
+
+func TestMostRecentActivation(t *testing.T) {
+	schedule, err := cron.Parse("*/10 * * * *")
+	require.NoError(t, err)
+	cronAgent := testCronAgent(v1alpha2.CronAgentSpec{})
+	now := time.Date(2026, 3, 10, 9, 5, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC), mostRecentActivation(schedule, cronAgent, now))
+
+	cronAgent.Status.LastScheduleTime = &metav1.Time{Time: time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)}
+	assert.True(t, mostRecentActivation(schedule, cronAgent, now).IsZero())
+}
+
+func TestRecordCronAgentRun(t *testing.T) {
+	run := func(phase v1alpha2.CronAgentRunPhase, id string) v1alpha2.CronAgentRun {
+		return v1alpha2.CronAgentRun{Phase: phase, SessionID: id}
+	}
+	status := &v1alpha2.CronAgentStatus{History: []v1alpha2.CronAgentRun{
+		run(v1alpha2.CronAgentRunSucceeded, "s2"),
+		run(v1alpha2.CronAgentRunFailed, "f1"),
+		run(v1alpha2.CronAgentRunSucceeded, "s1"),
+	}}
+	spec := v1alpha2.CronAgentSpec{SuccessfulRunsHistoryLimit: new(int32(2))}
+
+	recordCronAgentRun(status, spec, run(v1alpha2.CronAgentRunSucceeded, "s3"))
+	var ids []string
+	for _, h := range status.History {
+		ids = append(ids, h.SessionID)
+	}
+	assert.Equal(t, []string{"s3", "s2", "f1"}, ids)
+
+	recordCronAgentRun(status, spec, run(v1alpha2.CronAgentRunFailed, "f2"))
+	ids = ids[:0]
+	for _, h := range status.History {
+		ids = append(ids, h.SessionID)
+	}
+	assert.Equal(t, []string{"f2", "s3", "s2"}, ids)
+}
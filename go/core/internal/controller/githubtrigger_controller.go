@@ -0,0 +1,523 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	"github.com/kagent-dev/kagent/go/core/internal/githubapp"
+)
+
+const (
+	gitHubTriggerReasonAgentsFound     = "AgentsFound"
+	gitHubTriggerReasonAgentNotFound   = "AgentNotFound"
+	gitHubTriggerReasonSuspended       = "Suspended"
+	gitHubTriggerReasonWebhookDisabled = "WebhookDisabled"
+
+	defaultGitHubTriggerTimeout         = 10 * time.Minute
+	defaultGitHubTriggerHistoryLimit    = 10
+	gitHubTriggerAgentNotFoundRequeue   = time.Minute
+	maxGitHubWebhookBodyBytes           = 25 << 20
+	maxGitHubCommentBytes               = 60000
+	gitHubWebhookServerShutdownDeadline = 10 * time.Second
+)
+
+// GitHubCommenter comments on pull requests and issues. It is satisfied by
+// githubapp.Client.
+type GitHubCommenter interface {
+	CreateComment(ctx context.Context, installationID int64, repo string, number int64, body string) (string, error)
+}
+
+// GitHubTriggerController reconciles GitHubTrigger objects and serves the
+// GitHub App webhook. Each delivery is sent to the agent of the first
+// matching route of every GitHubTrigger covering the repository, and the
+// agent's reply is posted as a comment. Deliveries run in goroutines owned
+// by the controller and are handled by whichever replica receives them.
+type GitHubTriggerController struct {
+	Client client.Client
+	Sender MessageSender
+	// GitHub posts replies. The webhook is not served when it is nil.
+	GitHub GitHubCommenter
+	// WebhookSecret verifies the signature of deliveries.
+	WebhookSecret []byte
+	// WebhookAddr is the address the webhook is served on.
+	WebhookAddr string
+
+	now func() time.Time
+
+	mu sync.Mutex
+	// ctx is the context of the webhook server. Deliveries are cancelled
+	// when it is.
+	ctx context.Context
+	// inFlight holds the deliveries being handled, by trigger and delivery
+	// ID, so a redelivery is not handled twice.
+	inFlight map[string]bool
+	// sessions serializes deliveries of the same pull request or issue.
+	sessions map[string]*sessionLock
+	// wg tracks delivery goroutines, for tests.
+	wg sync.WaitGroup
+}
+
+type sessionLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// +kubebuilder:rbac:groups=kagent.dev,resources=githubtriggers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=kagent.dev,resources=githubtriggers/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=kagent.dev,resources=githubtriggers/finalizers,verbs=update
+// +kubebuilder:rbac:groups=kagent.dev,resources=agents,verbs=get;list;watch
+
+func (r *GitHubTriggerController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	trigger := &v1alpha2.GitHubTrigger{}
+	if err := r.Client.Get(ctx, req.NamespacedName, trigger); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	status := trigger.Status.DeepCopy()
+	status.ObservedGeneration = trigger.Generation
+	if r.GitHub == nil {
+		setGitHubTriggerCondition(status, trigger.Generation, metav1.ConditionFalse, gitHubTriggerReasonWebhookDisabled,
+			"The controller has no GitHub App configured to receive webhooks")
+		return ctrl.Result{}, r.updateStatus(ctx, trigger, status)
+	}
+	if trigger.Spec.Suspend {
+		setGitHubTriggerCondition(status, trigger.Generation, metav1.ConditionTrue, gitHubTriggerReasonSuspended, "GitHubTrigger is suspended")
+		return ctrl.Result{}, r.updateStatus(ctx, trigger, status)
+	}
+
+	var missing []string
+	for _, route := range trigger.Spec.Routes {
+		if slices.Contains(missing, route.AgentRef) {
+			continue
+		}
+		err := r.Client.Get(ctx, types.NamespacedName{Namespace: trigger.Namespace, Name: route.AgentRef}, &v1alpha2.Agent{})
+		if apierrors.IsNotFound(err) {
+			missing = append(missing, route.AgentRef)
+		} else if err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	if len(missing) > 0 {
+		setGitHubTriggerCondition(status, trigger.Generation, metav1.ConditionFalse, gitHubTriggerReasonAgentNotFound,
+			fmt.Sprintf("Agents %s not found in namespace %s", strings.Join(missing, ", "), trigger.Namespace))
+		if err := r.updateStatus(ctx, trigger, status); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: gitHubTriggerAgentNotFoundRequeue}, nil
+	}
+	setGitHubTriggerCondition(status, trigger.Generation, metav1.ConditionTrue, gitHubTriggerReasonAgentsFound,
+		fmt.Sprintf("Routing events of %s", strings.Join(trigger.Spec.Repositories, ", ")))
+	return ctrl.Result{}, r.updateStatus(ctx, trigger, status)
+}
+
+// ServeHTTP handles a GitHub App webhook delivery. Deliveries are
+// acknowledged once dispatched, as GitHub gives up on them after 10
+// seconds.
+func (r *GitHubTriggerController) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	logger := log.FromContext(req.Context()).WithName("github-webhook")
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, req.Body, maxGitHubWebhookBodyBytes))
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+	if err := githubapp.VerifySignature(r.WebhookSecret, body, req.Header.Get("X-Hub-Signature-256")); err != nil {
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	kind := req.Header.Get("X-GitHub-Event")
+	ev, err := githubapp.ParseEvent(kind, body)
+	if errors.Is(err, githubapp.ErrUnsupportedEvent) {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	event, ok := gitHubTriggerEventOf(ev)
+	if !ok {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	triggers := &v1alpha2.GitHubTriggerList{}
+	if err := r.Client.List(req.Context(), triggers); err != nil {
+		logger.Error(err, "Failed to list GitHubTriggers")
+		http.Error(w, "failed to list GitHubTriggers", http.StatusInternalServerError)
+		return
+	}
+	deliveryID := req.Header.Get("X-GitHub-Delivery")
+	dispatched := 0
+	for i := range triggers.Items {
+		trigger := &triggers.Items[i]
+		if trigger.Spec.Suspend || !gitHubTriggerCoversRepository(trigger.Spec.Repositories, ev.Repository) {
+			continue
+		}
+		route, ok := gitHubTriggerRouteFor(trigger.Spec.Routes, event, ev)
+		if !ok || gitHubTriggerDelivered(trigger.Status, deliveryID) || !r.claim(trigger, deliveryID) {
+			continue
+		}
+		delivery := v1alpha2.GitHubTriggerDelivery{
+			DeliveryID:   deliveryID,
+			Event:        event,
+			Repository:   ev.Repository,
+			Number:       ev.Number,
+			Route:        route.Name,
+			ReceivedTime: metav1.Time{Time: r.clock()},
+			SessionID:    gitHubTriggerSessionID(trigger, ev),
+		}
+		key := types.NamespacedName{Namespace: trigger.Namespace, Name: trigger.Name}
+		timeout := defaultGitHubTriggerTimeout
+		if trigger.Spec.Timeout != nil && trigger.Spec.Timeout.Duration > 0 {
+			timeout = trigger.Spec.Timeout.Duration
+		}
+		logger.Info("Dispatching GitHub event", "gitHubTrigger", key.String(), "route", route.Name, "event", event,
+			"repository", ev.Repository, "number", ev.Number, "delivery", deliveryID)
+		r.wg.Go(func() {
+			r.deliver(key, route, ev, timeout, delivery)
+		})
+		dispatched++
+	}
+	if dispatched == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// deliver sends the event to the route's agent, posts the reply and
+// records the delivery.
+func (r *GitHubTriggerController) deliver(key types.NamespacedName, route v1alpha2.GitHubTriggerRoute, ev *githubapp.Event, timeout time.Duration, delivery v1alpha2.GitHubTriggerDelivery) {
+	logger := ctrl.Log.WithName("githubtrigger-controller").WithValues("gitHubTrigger", key.String(), "delivery", delivery.DeliveryID)
+	defer r.release(key, delivery.DeliveryID)
+
+	unlock := r.lockSession(delivery.SessionID)
+	ctx, cancel := context.WithTimeout(r.baseContext(), timeout)
+	commentURL, err := r.reply(ctx, key, route, ev, delivery.SessionID, timeout)
+	cancel()
+	unlock()
+
+	delivery.CompletionTime = &metav1.Time{Time: r.clock()}
+	delivery.Phase = v1alpha2.GitHubTriggerDeliverySucceeded
+	delivery.CommentURL = commentURL
+	if err != nil {
+		delivery.Phase = v1alpha2.GitHubTriggerDeliveryFailed
+		delivery.Message = truncateRunMessage(err.Error())
+	}
+	logger.Info("GitHub event handled", "phase", delivery.Phase, "comment", commentURL)
+
+	if err := r.recordDelivery(context.Background(), key, delivery); err != nil {
+		logger.Error(err, "Failed to record GitHubTrigger delivery")
+	}
+}
+
+func (r *GitHubTriggerController) reply(ctx context.Context, key types.NamespacedName, route v1alpha2.GitHubTriggerRoute, ev *githubapp.Event, sessionID string, timeout time.Duration) (string, error) {
+	prompt, err := gitHubTriggerPrompt(route, ev)
+	if err != nil {
+		return "", err
+	}
+	reply, _, err := sendAgentMessage(ctx, r.Sender, key.Namespace, route.AgentRef, prompt, sessionID)
+	if err != nil {
+		if errors.Is(context.Cause(ctx), context.DeadlineExceeded) {
+			return "", fmt.Errorf("agent timed out after %s", timeout)
+		}
+		return "", err
+	}
+	reply = strings.TrimSpace(reply)
+	if reply == "" {
+		return "", errors.New("agent returned an empty reply")
+	}
+	if len(reply) > maxGitHubCommentBytes {
+		reply = strings.ToValidUTF8(reply[:maxGitHubCommentBytes], "") + "\n\n_(reply truncated)_"
+	}
+	body := fmt.Sprintf("%s\n\n<sub>Posted by agent `%s/%s` for GitHubTrigger `%s`.</sub>", reply, key.Namespace, route.AgentRef, key.String())
+	return r.GitHub.CreateComment(ctx, ev.InstallationID, ev.Repository, ev.Number, body)
+}
+
+// recordDelivery prepends a handled delivery to the trigger's status and
+// trims the list to the history limit.
+func (r *GitHubTriggerController) recordDelivery(ctx context.Context, key types.NamespacedName, delivery v1alpha2.GitHubTriggerDelivery) error {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest := &v1alpha2.GitHubTrigger{}
+		if err := r.Client.Get(ctx, key, latest); err != nil {
+			return err
+		}
+		recordGitHubTriggerDelivery(&latest.Status, latest.Spec, delivery)
+		return r.Client.Status().Update(ctx, latest)
+	})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update GitHubTrigger status: %w", err)
+	}
+	return nil
+}
+
+func (r *GitHubTriggerController) updateStatus(ctx context.Context, trigger *v1alpha2.GitHubTrigger, status *v1alpha2.GitHubTriggerStatus) error {
+	trigger.Status = *status
+	if err := r.Client.Status().Update(ctx, trigger); err != nil {
+		return fmt.Errorf("failed to update GitHubTrigger status: %w", err)
+	}
+	return nil
+}
+
+func (r *GitHubTriggerController) clock() time.Time {
+	if r.now != nil {
+		return r.now()
+	}
+	return time.Now()
+}
+
+func (r *GitHubTriggerController) baseContext() context.Context {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.ctx != nil {
+		return r.ctx
+	}
+	return context.Background()
+}
+
+// claim marks a delivery of the trigger in flight, returning false if it
+// already is.
+func (r *GitHubTriggerController) claim(trigger *v1alpha2.GitHubTrigger, deliveryID string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.inFlight == nil {
+		r.inFlight = map[string]bool{}
+	}
+	id := trigger.Namespace + "/" + trigger.Name + "/" + deliveryID
+	if r.inFlight[id] {
+		return false
+	}
+	r.inFlight[id] = true
+	return true
+}
+
+func (r *GitHubTriggerController) release(key types.NamespacedName, deliveryID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.inFlight, key.String()+"/"+deliveryID)
+}
+
+// lockSession waits for other deliveries of the session to finish and
+// returns the function releasing it.
+func (r *GitHubTriggerController) lockSession(sessionID string) func() {
+	r.mu.Lock()
+	if r.sessions == nil {
+		r.sessions = map[string]*sessionLock{}
+	}
+	l, ok := r.sessions[sessionID]
+	if !ok {
+		l = &sessionLock{}
+		r.sessions[sessionID] = l
+	}
+	l.refs++
+	r.mu.Unlock()
+
+	l.mu.Lock()
+	return func() {
+		l.mu.Unlock()
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if l.refs--; l.refs == 0 {
+			delete(r.sessions, sessionID)
+		}
+	}
+}
+
+// Start serves the webhook until ctx is cancelled, then waits for the
+// deliveries in progress, which are cancelled with it.
+func (r *GitHubTriggerController) Start(ctx context.Context) error {
+	r.mu.Lock()
+	r.ctx = ctx
+	r.mu.Unlock()
+
+	mux := http.NewServeMux()
+	mux.Handle("POST /", r)
+	srv := &http.Server{Addr: r.WebhookAddr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- srv.ListenAndServe()
+	}()
+	ctrl.Log.WithName("githubtrigger-controller").Info("Serving GitHub webhook", "address", r.WebhookAddr)
+
+	select {
+	case err := <-errCh:
+		return fmt.Errorf("GitHub webhook server failed: %w", err)
+	case <-ctx.Done():
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), gitHubWebhookServerShutdownDeadline)
+	defer cancel()
+	err := srv.Shutdown(shutdownCtx)
+	r.wg.Wait()
+	return err
+}
+
+// NeedLeaderElection lets every replica handle the deliveries it receives.
+func (r *GitHubTriggerController) NeedLeaderElection() bool { return false }
+
+// SetupWithManager sets up the controller with the Manager and, when a
+// GitHub App is configured, the webhook server.
+func (r *GitHubTriggerController) SetupWithManager(mgr ctrl.Manager) error {
+	if r.GitHub != nil {
+		if err := mgr.Add(r); err != nil {
+			return err
+		}
+	}
+	triggersForAgent := handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		return r.findGitHubTriggersForAgent(ctx, types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()})
+	})
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha2.GitHubTrigger{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&v1alpha2.Agent{}, triggersForAgent, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Named("githubtrigger").
+		Complete(r)
+}
+
+func (r *GitHubTriggerController) findGitHubTriggersForAgent(ctx context.Context, agent types.NamespacedName) []reconcile.Request {
+	triggers := &v1alpha2.GitHubTriggerList{}
+	if err := r.Client.List(ctx, triggers, client.InNamespace(agent.Namespace)); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list GitHubTriggers", "agent", agent.String())
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, trigger := range triggers.Items {
+		if slices.ContainsFunc(trigger.Spec.Routes, func(route v1alpha2.GitHubTriggerRoute) bool { return route.AgentRef == agent.Name }) {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: trigger.Namespace, Name: trigger.Name}})
+		}
+	}
+	return requests
+}
+
+// gitHubTriggerEventOf maps a webhook event to the route event it is, if
+// any. Reopening counts as opening.
+func gitHubTriggerEventOf(ev *githubapp.Event) (v1alpha2.GitHubTriggerEvent, bool) {
+	opened := ev.Action == "opened" || ev.Action == "reopened"
+	switch {
+	case ev.Kind == githubapp.KindPullRequest && opened:
+		return v1alpha2.GitHubTriggerPullRequestOpened, true
+	case ev.Kind == githubapp.KindPullRequest && ev.Action == "labeled":
+		return v1alpha2.GitHubTriggerPullRequestLabeled, true
+	case ev.Kind == githubapp.KindIssues && opened:
+		return v1alpha2.GitHubTriggerIssueOpened, true
+	case ev.Kind == githubapp.KindIssues && ev.Action == "labeled":
+		return v1alpha2.GitHubTriggerIssueLabeled, true
+	}
+	return "", false
+}
+
+// gitHubTriggerCoversRepository reports whether repo matches one of the
+// trigger's repositories. GitHub names are case-insensitive.
+func gitHubTriggerCoversRepository(repositories []string, repo string) bool {
+	owner, _, _ := strings.Cut(repo, "/")
+	for _, r := range repositories {
+		if strings.EqualFold(r, repo) || strings.EqualFold(r, owner+"/*") {
+			return true
+		}
+	}
+	return false
+}
+
+// gitHubTriggerRouteFor returns the first route handling the event.
+func gitHubTriggerRouteFor(routes []v1alpha2.GitHubTriggerRoute, event v1alpha2.GitHubTriggerEvent, ev *githubapp.Event) (v1alpha2.GitHubTriggerRoute, bool) {
+	for _, route := range routes {
+		if !slices.Contains(route.Events, event) {
+			continue
+		}
+		if len(route.Labels) == 0 {
+			return route, true
+		}
+		labeled := event == v1alpha2.GitHubTriggerPullRequestLabeled || event == v1alpha2.GitHubTriggerIssueLabeled
+		if labeled && slices.Contains(route.Labels, ev.Label) {
+			return route, true
+		}
+		if !labeled && slices.ContainsFunc(ev.Labels, func(l string) bool { return slices.Contains(route.Labels, l) }) {
+			return route, true
+		}
+	}
+	return v1alpha2.GitHubTriggerRoute{}, false
+}
+
+func gitHubTriggerDelivered(status v1alpha2.GitHubTriggerStatus, deliveryID string) bool {
+	return slices.ContainsFunc(status.Deliveries, func(d v1alpha2.GitHubTriggerDelivery) bool { return d.DeliveryID == deliveryID })
+}
+
+// gitHubTriggerSessionID returns the A2A context ID of a pull request or
+// issue, so later events of it continue the same session.
+func gitHubTriggerSessionID(trigger *v1alpha2.GitHubTrigger, ev *githubapp.Event) string {
+	name := fmt.Sprintf("githubtrigger/%s/%s/%s#%d", trigger.Namespace, trigger.Name, strings.ToLower(ev.Repository), ev.Number)
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte(name)).String()
+}
+
+func gitHubTriggerPrompt(route v1alpha2.GitHubTriggerRoute, ev *githubapp.Event) (string, error) {
+	b, err := json.MarshalIndent(ev, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode event: %w", err)
+	}
+	return fmt.Sprintf("%s\n\nGitHub event:\n```json\n%s\n```", route.Instructions, b), nil
+}
+
+// recordGitHubTriggerDelivery prepends a delivery to the status and trims
+// the list to the spec's history limit.
+func recordGitHubTriggerDelivery(status *v1alpha2.GitHubTriggerStatus, spec v1alpha2.GitHubTriggerSpec, delivery v1alpha2.GitHubTriggerDelivery) {
+	limit := defaultGitHubTriggerHistoryLimit
+	if spec.HistoryLimit != nil {
+		limit = int(*spec.HistoryLimit)
+	}
+	status.Deliveries = append([]v1alpha2.GitHubTriggerDelivery{delivery}, status.Deliveries...)
+	if len(status.Deliveries) > limit {
+		status.Deliveries = status.Deliveries[:limit]
+	}
+}
+
+func setGitHubTriggerCondition(status *v1alpha2.GitHubTriggerStatus, generation int64, conditionStatus metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               v1alpha2.GitHubTriggerConditionTypeReady,
+		Status:             conditionStatus,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: generation,
+	})
+}
@@ -0,0 +1,244 @@
+package controller
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	a2atype "github.com/a2aproject/a2a-go/v2/a2a"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	"github.com/kagent-dev/kagent/go/core/internal/a2a"
+	"github.com/kagent-dev/kagent/go/core/internal/githubapp"
+)
+
+const testGitHubWebhookSecret = "s3cret"
+
+// fakeGitHubSender replies to every prompt and records the prompts and
+// context IDs it was sent.
+type fakeGitHubSender struct {
+	mu       sync.Mutex
+	reply    string
+	err      error
+	agents   []string
+	prompts  []string
+	contexts []string
+}
+
+func (f *fakeGitHubSender) SendMessage(_ context.Context, _, name string, req *a2atype.SendMessageRequest) (a2atype.SendMessageResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.agents = append(f.agents, name)
+	f.prompts = append(f.prompts, a2a.ExtractText(req.Message))
+	f.contexts = append(f.contexts, req.Message.ContextID)
+	if f.err != nil {
+		return nil, f.err
+	}
+	return a2atype.NewMessage(a2atype.MessageRoleAgent, a2atype.NewTextPart(f.reply)), nil
+}
+
+type fakeGitHubCommenter struct {
+	mu       sync.Mutex
+	comments []string
+}
+
+func (f *fakeGitHubCommenter) CreateComment(_ context.Context, installationID int64, repo string, number int64, body string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.comments = append(f.comments, body)
+	return "https://github.com/" + repo + "/pull/7#issuecomment-1", nil
+}
+
+func testGitHubTrigger() *v1alpha2.GitHubTrigger {
+	return &v1alpha2.GitHubTrigger{
+		ObjectMeta: metav1.ObjectMeta{Name: "platform", Namespace: "kagent", Generation: 1},
+		Spec: v1alpha2.GitHubTriggerSpec{
+			Repositories: []string{"acme/*"},
+			Routes: []v1alpha2.GitHubTriggerRoute{
+				{
+					Name:         "helm-review",
+					Events:       []v1alpha2.GitHubTriggerEvent{v1alpha2.GitHubTriggerPullRequestOpened},
+					Labels:       []string{"helm"},
+					AgentRef:     "helm-agent",
+					Instructions: "Review the chart changes.",
+				},
+				{
+					Name:         "review",
+					Events:       []v1alpha2.GitHubTriggerEvent{v1alpha2.GitHubTriggerPullRequestOpened, v1alpha2.GitHubTriggerPullRequestLabeled},
+					AgentRef:     "k8s-agent",
+					Instructions: "Review this pull request for Kubernetes manifest mistakes.",
+				},
+			},
+		},
+	}
+}
+
+func newGitHubTriggerController(t *testing.T, sender MessageSender, objs ...client.Object) (*GitHubTriggerController, *fakeGitHubCommenter) {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha2.AddToScheme(scheme))
+	agent := &v1alpha2.Agent{ObjectMeta: metav1.ObjectMeta{Name: "k8s-agent", Namespace: "kagent"}}
+	kube := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(append(objs, agent)...).
+		WithStatusSubresource(&v1alpha2.GitHubTrigger{}).
+		Build()
+	commenter := &fakeGitHubCommenter{}
+	return &GitHubTriggerController{
+		Client:        kube,
+		Sender:        sender,
+		GitHub:        commenter,
+		WebhookSecret: []byte(testGitHubWebhookSecret),
+	}, commenter
+}
+
+func postGitHubWebhook(t *testing.T, r *GitHubTriggerController, kind, deliveryID, body string) int {
+	t.Helper()
+	mac := hmac.New(sha256.New, []byte(testGitHubWebhookSecret))
+	mac.Write([]byte(body))
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.Header.Set("X-GitHub-Event", kind)
+	req.Header.Set("X-GitHub-Delivery", deliveryID)
+	req.Header.Set("X-Hub-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	r.wg.Wait()
+	return rec.Code
+}
+
+func getGitHubTrigger(t *testing.T, r *GitHubTriggerController) *v1alpha2.GitHubTrigger {
+	t.Helper()
+	trigger := &v1alpha2.GitHubTrigger{}
+	require.NoError(t, r.Client.Get(context.Background(), client.ObjectKey{Namespace: "kagent", Name: "platform"}, trigger))
+	return trigger
+}
+
+const testPullRequestOpened = `{"action":"opened","repository":{"full_name":"acme/platform"},"installation":{"id":42},
+	"pull_request":{"number":7,"title":"Add redis","user":{"login":"octocat"},"html_url":"https://github.com/acme/platform/pull/7"}}`
+
+func TestGitHubTriggerWebhook(t *testing.T) {
+	t.Run("posts the agent's review and records the delivery", func(t *testing.T) {
+		sender := &fakeGitHubSender{reply: "The Service selects no pods."}
+		r, commenter := newGitHubTriggerController(t, sender, testGitHubTrigger())
+
+		require.Equal(t, http.StatusAccepted, postGitHubWebhook(t, r, githubapp.KindPullRequest, "d-1", testPullRequestOpened))
+
+		require.Len(t, sender.prompts, 1)
+		assert.Equal(t, []string{"k8s-agent"}, sender.agents)
+		assert.True(t, strings.HasPrefix(sender.prompts[0], "Review this pull request for Kubernetes manifest mistakes.\n\nGitHub event:\n```json\n"))
+		assert.Contains(t, sender.prompts[0], `"title": "Add redis"`)
+		require.Len(t, commenter.comments, 1)
+		assert.Equal(t, "The Service selects no pods.\n\n<sub>Posted by agent `kagent/k8s-agent` for GitHubTrigger `kagent/platform`.</sub>", commenter.comments[0])
+
+		trigger := getGitHubTrigger(t, r)
+		require.Len(t, trigger.Status.Deliveries, 1)
+		d := trigger.Status.Deliveries[0]
+		assert.Equal(t, "d-1", d.DeliveryID)
+		assert.Equal(t, v1alpha2.GitHubTriggerPullRequestOpened, d.Event)
+		assert.Equal(t, "review", d.Route)
+		assert.Equal(t, v1alpha2.GitHubTriggerDeliverySucceeded, d.Phase)
+		assert.Equal(t, "https://github.com/acme/platform/pull/7#issuecomment-1", d.CommentURL)
+		assert.Equal(t, sender.contexts[0], d.SessionID)
+
+		// A redelivery is not handled again; a later event of the pull
+		// request continues its session.
+		assert.Equal(t, http.StatusNoContent, postGitHubWebhook(t, r, githubapp.KindPullRequest, "d-1", testPullRequestOpened))
+		labeled := strings.Replace(testPullRequestOpened, `"action":"opened"`, `"action":"labeled","label":{"name":"urgent"}`, 1)
+		assert.Equal(t, http.StatusAccepted, postGitHubWebhook(t, r, githubapp.KindPullRequest, "d-2", labeled))
+		require.Len(t, sender.contexts, 2)
+		assert.Equal(t, sender.contexts[0], sender.contexts[1])
+		assert.Len(t, getGitHubTrigger(t, r).Status.Deliveries, 2)
+	})
+
+	t.Run("records agent failures without commenting", func(t *testing.T) {
+		sender := &fakeGitHubSender{err: errors.New("connection refused")}
+		r, commenter := newGitHubTriggerController(t, sender, testGitHubTrigger())
+
+		require.Equal(t, http.StatusAccepted, postGitHubWebhook(t, r, githubapp.KindPullRequest, "d-1", testPullRequestOpened))
+		assert.Empty(t, commenter.comments)
+		d := getGitHubTrigger(t, r).Status.Deliveries[0]
+		assert.Equal(t, v1alpha2.GitHubTriggerDeliveryFailed, d.Phase)
+		assert.Contains(t, d.Message, "connection refused")
+	})
+
+	t.Run("ignores unmatched and unsigned deliveries", func(t *testing.T) {
+		sender := &fakeGitHubSender{reply: "ok"}
+		trigger := testGitHubTrigger()
+		r, _ := newGitHubTriggerController(t, sender, trigger)
+
+		otherOwner := strings.Replace(testPullRequestOpened, "acme/platform", "globex/platform", 1)
+		assert.Equal(t, http.StatusNoContent, postGitHubWebhook(t, r, githubapp.KindPullRequest, "d-1", otherOwner))
+		closed := strings.Replace(testPullRequestOpened, `"action":"opened"`, `"action":"closed"`, 1)
+		assert.Equal(t, http.StatusNoContent, postGitHubWebhook(t, r, githubapp.KindPullRequest, "d-2", closed))
+		assert.Equal(t, http.StatusNoContent, postGitHubWebhook(t, r, "ping", "d-3", `{"zen":"Keep it logically awesome."}`))
+		assert.Empty(t, sender.prompts)
+
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(testPullRequestOpened))
+		req.Header.Set("X-GitHub-Event", githubapp.KindPullRequest)
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+}
+
+func TestGitHubTriggerRouteFor(t *testing.T) {
+	routes := testGitHubTrigger().Spec.Routes
+	tests := []struct {
+		name      string
+		event     v1alpha2.GitHubTriggerEvent
+		ev        githubapp.Event
+		wantRoute string
+	}{
+		{name: "labeled pull request opened", event: v1alpha2.GitHubTriggerPullRequestOpened, ev: githubapp.Event{Labels: []string{"helm"}}, wantRoute: "helm-review"},
+		{name: "pull request opened", event: v1alpha2.GitHubTriggerPullRequestOpened, wantRoute: "review"},
+		{name: "label added", event: v1alpha2.GitHubTriggerPullRequestLabeled, ev: githubapp.Event{Label: "helm", Labels: []string{"helm"}}, wantRoute: "review"},
+		{name: "issue opened", event: v1alpha2.GitHubTriggerIssueOpened},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			route, ok := gitHubTriggerRouteFor(routes, tt.event, &tt.ev)
+			assert.Equal(t, tt.wantRoute != "", ok)
+			assert.Equal(t, tt.wantRoute, route.Name)
+		})
+	}
+}
+
+func TestGitHubTriggerReconcile(t *testing.T) {
+	trigger := testGitHubTrigger()
+	r, _ := newGitHubTriggerController(t, &fakeGitHubSender{}, trigger)
+	key := client.ObjectKeyFromObject(trigger)
+
+	res, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+	require.NoError(t, err)
+	assert.Equal(t, gitHubTriggerAgentNotFoundRequeue, res.RequeueAfter)
+	cond := meta.FindStatusCondition(getGitHubTrigger(t, r).Status.Conditions, v1alpha2.GitHubTriggerConditionTypeReady)
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionFalse, cond.Status)
+	assert.Equal(t, "Agents helm-agent not found in namespace kagent", cond.Message)
+
+	require.NoError(t, r.Client.Create(context.Background(), &v1alpha2.Agent{ObjectMeta: metav1.ObjectMeta{Name: "helm-agent", Namespace: "kagent"}}))
+	_, err = r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+	require.NoError(t, err)
+	cond = meta.FindStatusCondition(getGitHubTrigger(t, r).Status.Conditions, v1alpha2.GitHubTriggerConditionTypeReady)
+	assert.Equal(t, metav1.ConditionTrue, cond.Status)
+
+	r.GitHub = nil
+	_, err = r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+	require.NoError(t, err)
+	cond = meta.FindStatusCondition(getGitHubTrigger(t, r).Status.Conditions, v1alpha2.GitHubTriggerConditionTypeReady)
+	assert.Equal(t, gitHubTriggerReasonWebhookDisabled, cond.Reason)
+}
@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Cache field indexes over Agents and SandboxAgents. Each maps an agent to the
+// namespace/name of the objects it references, so the watch handlers can find
+// the agents depending on a changed object without listing every agent.
+const (
+	agentModelConfigIndex = "spec.declarative.modelConfig"
+	agentToolServerIndex  = "spec.declarative.tools.mcpServer"
+	agentConfigMapIndex   = "spec.declarative.configMaps"
+)
+
+var agentIndexers = map[string]func(v1alpha2.AgentObject) []string{
+	agentModelConfigIndex: func(agent v1alpha2.AgentObject) []string {
+		decl := declarativeSpec(agent)
+		if decl == nil || decl.ModelConfig == "" {
+			return nil
+		}
+		return []string{types.NamespacedName{Namespace: agent.GetNamespace(), Name: decl.ModelConfig}.String()}
+	},
+	agentToolServerIndex: func(agent v1alpha2.AgentObject) []string {
+		decl := declarativeSpec(agent)
+		if decl == nil {
+			return nil
+		}
+		var refs []string
+		for _, tool := range decl.Tools {
+			if tool != nil && tool.McpServer != nil {
+				refs = append(refs, tool.McpServer.NamespacedName(agent.GetNamespace()).String())
+			}
+		}
+		return refs
+	},
+	agentConfigMapIndex: func(agent v1alpha2.AgentObject) []string {
+		decl := declarativeSpec(agent)
+		if decl == nil {
+			return nil
+		}
+		ref := func(name string) string {
+			return types.NamespacedName{Namespace: agent.GetNamespace(), Name: name}.String()
+		}
+		var refs []string
+		if src := decl.SystemMessageFrom; src != nil && src.Type == v1alpha2.ConfigMapValueSource {
+			refs = append(refs, ref(src.Name))
+		}
+		if pt := decl.PromptTemplate; pt != nil {
+			for _, ds := range pt.DataSources {
+				refs = append(refs, ref(ds.Name))
+			}
+		}
+		return refs
+	},
+}
+
+func declarativeSpec(agent v1alpha2.AgentObject) *v1alpha2.DeclarativeAgentSpec {
+	spec := agent.GetAgentSpec()
+	if spec.Type != v1alpha2.AgentType_Declarative {
+		return nil
+	}
+	return spec.Declarative
+}
+
+// indexAgentDependencies registers the agent dependency indexes for obj, an
+// *Agent or *SandboxAgent.
+func indexAgentDependencies(ctx context.Context, indexer client.FieldIndexer, obj client.Object) error {
+	for field, extract := range agentIndexers {
+		if err := indexer.IndexField(ctx, obj, field, func(o client.Object) []string {
+			agent, ok := o.(v1alpha2.AgentObject)
+			if !ok {
+				return nil
+			}
+			return extract(agent)
+		}); err != nil {
+			return fmt.Errorf("index %T by %s: %w", obj, field, err)
+		}
+	}
+	return nil
+}
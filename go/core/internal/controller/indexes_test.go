@@ -0,0 +1,119 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// builderIndexer registers field indexes on a fake client builder.
+type builderIndexer struct {
+	builder *fake.ClientBuilder
+}
+
+func (b builderIndexer) IndexField(_ context.Context, obj client.Object, field string, extract client.IndexerFunc) error {
+	b.builder.WithIndex(obj, field, extract)
+	return nil
+}
+
+func indexedAgent(name string, decl *v1alpha2.DeclarativeAgentSpec) *v1alpha2.Agent {
+	return &v1alpha2.Agent{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: v1alpha2.AgentSpec{
+			Type:        v1alpha2.AgentType_Declarative,
+			Declarative: decl,
+		},
+	}
+}
+
+func mcpTool(kind, apiGroup, name string) *v1alpha2.Tool {
+	return &v1alpha2.Tool{
+		Type: v1alpha2.ToolProviderType_McpServer,
+		McpServer: &v1alpha2.McpServerTool{
+			TypedReference: v1alpha2.TypedReference{Kind: kind, ApiGroup: apiGroup, Name: name},
+		},
+	}
+}
+
+func TestAgentDependencyFinder(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha2.AddToScheme(scheme))
+	builder := fake.NewClientBuilder().WithScheme(scheme)
+	require.NoError(t, indexAgentDependencies(context.Background(), builderIndexer{builder}, &v1alpha2.Agent{}))
+
+	kube := builder.WithObjects(
+		indexedAgent("uses-model", &v1alpha2.DeclarativeAgentSpec{ModelConfig: "model"}),
+		indexedAgent("uses-other-model", &v1alpha2.DeclarativeAgentSpec{ModelConfig: "other"}),
+		indexedAgent("uses-tools", &v1alpha2.DeclarativeAgentSpec{
+			ModelConfig: "other",
+			Tools: []*v1alpha2.Tool{
+				mcpTool("MCPServer", "kagent.dev", "tools"),
+				mcpTool("Service", "", "svc"),
+			},
+		}),
+		indexedAgent("uses-configmap", &v1alpha2.DeclarativeAgentSpec{
+			SystemMessageFrom: &v1alpha2.ValueSource{Type: v1alpha2.ConfigMapValueSource, Name: "prompts", Key: "system"},
+		}),
+	).Build()
+
+	r := &AgentController{}
+	ctx := context.Background()
+	ref := func(name string) types.NamespacedName {
+		return types.NamespacedName{Namespace: "default", Name: name}
+	}
+
+	tests := []struct {
+		name   string
+		finder dependentRefFinder
+		obj    types.NamespacedName
+		want   []types.NamespacedName
+	}{
+		{
+			name:   "model config",
+			finder: r.agentDependencyFinder("", agentModelConfigIndex, usesModelConfig),
+			obj:    ref("model"),
+			want:   []types.NamespacedName{ref("uses-model")},
+		},
+		{
+			name:   "mcp server",
+			finder: r.agentDependencyFinder("", agentToolServerIndex, usesMCPServer),
+			obj:    ref("tools"),
+			want:   []types.NamespacedName{ref("uses-tools")},
+		},
+		{
+			name:   "service is not an mcp server",
+			finder: r.agentDependencyFinder("", agentToolServerIndex, usesMCPServer),
+			obj:    ref("svc"),
+		},
+		{
+			name:   "mcp service",
+			finder: r.agentDependencyFinder("", agentToolServerIndex, usesMCPService),
+			obj:    ref("svc"),
+			want:   []types.NamespacedName{ref("uses-tools")},
+		},
+		{
+			name:   "config map",
+			finder: r.agentDependencyFinder("", agentConfigMapIndex, referencesConfigMap),
+			obj:    ref("prompts"),
+			want:   []types.NamespacedName{ref("uses-configmap")},
+		},
+		{
+			name:   "other namespace",
+			finder: r.agentDependencyFinder("", agentModelConfigIndex, usesModelConfig),
+			obj:    types.NamespacedName{Namespace: "other", Name: "model"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ElementsMatch(t, tt.want, tt.finder(ctx, kube, tt.obj))
+		})
+	}
+}
@@ -18,6 +18,9 @@ package controller
 
 import (
 	"context"
+	"errors"
+	"slices"
+	"time"
 
 	"github.com/kagent-dev/kagent/go/core/internal/controller/reconciler"
 
@@ -28,6 +31,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
@@ -40,6 +44,10 @@ var (
 	modelConfigControllerLog = ctrl.Log.WithName("modelconfig-controller")
 )
 
+// modelPullPollInterval is how often the progress of an Ollama model pull or
+// warm-up is reported on the ModelConfig status.
+const modelPullPollInterval = 10 * time.Second
+
 // ModelConfigController reconciles a ModelConfig object
 type ModelConfigController struct {
 	Scheme     *runtime.Scheme
@@ -53,7 +61,13 @@ type ModelConfigController struct {
 
 func (r *ModelConfigController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	_ = log.FromContext(ctx)
-	return ctrl.Result{}, r.Reconciler.ReconcileKagentModelConfig(ctx, req)
+	err := r.Reconciler.ReconcileKagentModelConfig(ctx, req)
+	var pendingErr *reconciler.ModelPendingError
+	if errors.As(err, &pendingErr) {
+		// The model is being pulled or loaded; report its progress again soon.
+		return ctrl.Result{RequeueAfter: modelPullPollInterval}, nil
+	}
+	return ctrl.Result{}, err
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -84,6 +98,20 @@ func (r *ModelConfigController) SetupWithManager(mgr ctrl.Manager) error {
 			}),
 			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
 		).
+		// Re-resolve the fallbacks of ModelConfigs that list a created or
+		// deleted ModelConfig.
+		Watches(
+			&v1alpha2.ModelConfig{},
+			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+				return reconcileRequestsForRefs(findModelsFallingBackTo(ctx, mgr.GetClient(), types.NamespacedName{
+					Name:      obj.GetName(),
+					Namespace: obj.GetNamespace(),
+				}))
+			}),
+			builder.WithPredicates(predicate.Funcs{
+				UpdateFunc: func(event.UpdateEvent) bool { return false },
+			}),
+		).
 		Named("modelconfig").
 		Complete(r)
 }
@@ -111,6 +139,26 @@ func (r *ModelConfigController) findModelsUsingSecret(ctx context.Context, cl cl
 	return models
 }
 
+// findModelsFallingBackTo returns the ModelConfigs that list obj as a fallback.
+func findModelsFallingBackTo(ctx context.Context, cl client.Client, obj types.NamespacedName) []types.NamespacedName {
+	var modelsList v1alpha2.ModelConfigList
+	if err := cl.List(ctx, &modelsList, client.InNamespace(obj.Namespace)); err != nil {
+		modelConfigControllerLog.Error(err, "failed to list ModelConfigs in order to reconcile fallback update")
+		return nil
+	}
+
+	var refs []types.NamespacedName
+	for _, model := range modelsList.Items {
+		if model.Name == obj.Name {
+			continue
+		}
+		if slices.ContainsFunc(model.Spec.Fallbacks, func(f v1alpha2.ModelFallback) bool { return f.ModelConfig == obj.Name }) {
+			refs = append(refs, types.NamespacedName{Name: model.Name, Namespace: model.Namespace})
+		}
+	}
+	return refs
+}
+
 func modelReferencesSecret(model *v1alpha2.ModelConfig, secretObj types.NamespacedName) bool {
 	// secrets must be in the same namespace as the model
 	if model.Namespace != secretObj.Namespace {
@@ -0,0 +1,50 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package predicates
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// AnnotationChangedPredicate passes update events where the value of a single
+// annotation changed. Unlike predicate.AnnotationChangedPredicate it ignores
+// every other annotation, so it can be OR'd with GenerationChangedPredicate
+// without reconciling on unrelated metadata churn.
+type AnnotationChangedPredicate struct {
+	predicate.Funcs
+	Key string
+}
+
+func (p AnnotationChangedPredicate) Create(event.CreateEvent) bool {
+	return false
+}
+
+func (p AnnotationChangedPredicate) Update(e event.UpdateEvent) bool {
+	if e.ObjectOld == nil || e.ObjectNew == nil {
+		return false
+	}
+	return e.ObjectOld.GetAnnotations()[p.Key] != e.ObjectNew.GetAnnotations()[p.Key]
+}
+
+func (p AnnotationChangedPredicate) Delete(event.DeleteEvent) bool {
+	return false
+}
+
+func (p AnnotationChangedPredicate) Generic(event.GenericEvent) bool {
+	return false
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package predicates
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func TestAnnotationChangedPredicate(t *testing.T) {
+	p := AnnotationChangedPredicate{Key: "kagent.dev/debug-capture-until"}
+
+	tests := []struct {
+		name     string
+		old      map[string]string
+		new      map[string]string
+		expected bool
+	}{
+		{
+			name:     "annotation added",
+			old:      nil,
+			new:      map[string]string{"kagent.dev/debug-capture-until": "2026-01-01T00:00:00Z"},
+			expected: true,
+		},
+		{
+			name:     "annotation changed",
+			old:      map[string]string{"kagent.dev/debug-capture-until": "2026-01-01T00:00:00Z"},
+			new:      map[string]string{"kagent.dev/debug-capture-until": "2026-01-01T00:05:00Z"},
+			expected: true,
+		},
+		{
+			name:     "annotation removed",
+			old:      map[string]string{"kagent.dev/debug-capture-until": "2026-01-01T00:00:00Z"},
+			new:      nil,
+			expected: true,
+		},
+		{
+			name:     "other annotation changed",
+			old:      map[string]string{"kagent.dev/debug-capture-until": "2026-01-01T00:00:00Z", "foo": "a"},
+			new:      map[string]string{"kagent.dev/debug-capture-until": "2026-01-01T00:00:00Z", "foo": "b"},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldObj := &unstructured.Unstructured{}
+			oldObj.SetAnnotations(tt.old)
+			newObj := &unstructured.Unstructured{}
+			newObj.SetAnnotations(tt.new)
+
+			assert.Equal(t, tt.expected, p.Update(event.UpdateEvent{ObjectOld: oldObj, ObjectNew: newObj}))
+			assert.False(t, p.Create(event.CreateEvent{Object: newObj}))
+		})
+	}
+}
@@ -112,6 +112,11 @@ func (d *ModelDiscoverer) setAuthHeaders(req *http.Request, providerType v1alpha
 	case v1alpha2.ModelProviderGemini, v1alpha2.ModelProviderGeminiVertexAI:
 		// Google uses query parameter for API key (handled in URL) or Bearer token
 		req.Header.Set("Authorization", "Bearer "+apiKey)
+	case v1alpha2.ModelProviderOpenAICompatible:
+		// Self-hosted servers (vLLM, LM Studio, llama.cpp) often run without auth
+		if apiKey != "" {
+			req.Header.Set("Authorization", "Bearer "+apiKey)
+		}
 	default:
 		// OpenAI and compatible providers use Bearer token
 		req.Header.Set("Authorization", "Bearer "+apiKey)
@@ -166,7 +171,7 @@ func buildModelsURL(endpoint string, providerType v1alpha2.ModelProvider) string
 		return endpoint + "/v1/models"
 
 	default:
-		// OpenAI and compatible (Azure OpenAI, LiteLLM, vLLM, etc.)
+		// OpenAI and compatible (Azure OpenAI, LiteLLM, vLLM, OpenAICompatible, etc.)
 		if strings.HasSuffix(endpoint, "/v1") {
 			return endpoint + "/models"
 		}
@@ -455,6 +455,7 @@ func TestSetAuthHeaders(t *testing.T) {
 		wantAuthz    string
 		wantAPIKey   string
 		wantAnthVer  string
+		wantNoAuthz  bool
 	}{
 		{
 			name:         "OpenAI",
@@ -481,6 +482,17 @@ func TestSetAuthHeaders(t *testing.T) {
 			apiKey:       "gemini-key",
 			wantAuthz:    "Bearer gemini-key",
 		},
+		{
+			name:         "OpenAICompatible with key",
+			providerType: v1alpha2.ModelProviderOpenAICompatible,
+			apiKey:       "local-key",
+			wantAuthz:    "Bearer local-key",
+		},
+		{
+			name:         "OpenAICompatible without key",
+			providerType: v1alpha2.ModelProviderOpenAICompatible,
+			wantNoAuthz:  true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -495,6 +507,12 @@ func TestSetAuthHeaders(t *testing.T) {
 				}
 			}
 
+			if tt.wantNoAuthz {
+				if got := req.Header.Get("Authorization"); got != "" {
+					t.Errorf("Authorization = %v, want unset", got)
+				}
+			}
+
 			if tt.wantAPIKey != "" {
 				got := req.Header.Get("x-api-key")
 				if got != tt.wantAPIKey {
@@ -0,0 +1,137 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ollama/ollama/api"
+)
+
+// ollamaJobTimeout bounds a pull or warm-up, which for large models can take
+// a long time.
+const ollamaJobTimeout = 2 * time.Hour
+
+// OllamaProgress is the state of a pull or warm-up run by OllamaPuller.
+type OllamaProgress struct {
+	// Status is the last step reported by the server, e.g. "pulling manifest".
+	Status string
+	// Completed and Total measure the layer being downloaded, in bytes.
+	Completed int64
+	Total     int64
+	// Done is set once the job finished, with Err set when it failed.
+	Done bool
+	Err  error
+}
+
+// OllamaPuller pulls models onto Ollama servers and loads them into memory in
+// the background, so that reconciles poll the progress of downloads that can
+// take many minutes instead of waiting for them.
+type OllamaPuller struct {
+	httpClient *http.Client
+
+	mu   sync.Mutex
+	jobs map[string]*OllamaProgress
+}
+
+// NewOllamaPuller creates a new OllamaPuller instance.
+func NewOllamaPuller() *OllamaPuller {
+	return &OllamaPuller{httpClient: &http.Client{}, jobs: map[string]*OllamaProgress{}}
+}
+
+// Pull starts pulling model onto the Ollama server at host, unless a pull of
+// it is already running, and returns the pull's progress. A finished pull is
+// forgotten once its result has been returned, so the next call starts a new
+// one.
+func (p *OllamaPuller) Pull(host, model string) OllamaProgress {
+	return p.run("pull", host, model, func(ctx context.Context, client *api.Client, update func(api.ProgressResponse)) error {
+		return client.Pull(ctx, &api.PullRequest{Model: model}, func(resp api.ProgressResponse) error {
+			update(resp)
+			return nil
+		})
+	})
+}
+
+// WarmUp starts loading model into the memory of the Ollama server at host,
+// unless it is already being loaded, and returns the load's progress like
+// Pull.
+func (p *OllamaPuller) WarmUp(host, model string) OllamaProgress {
+	return p.run("warm-up", host, model, func(ctx context.Context, client *api.Client, update func(api.ProgressResponse)) error {
+		update(api.ProgressResponse{Status: "loading model"})
+		// A generate request without a prompt only loads the model.
+		return client.Generate(ctx, &api.GenerateRequest{Model: model}, func(api.GenerateResponse) error {
+			return nil
+		})
+	})
+}
+
+func (p *OllamaPuller) run(kind, host, model string, job func(context.Context, *api.Client, func(api.ProgressResponse)) error) OllamaProgress {
+	key := kind + "\x00" + host + "\x00" + model
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if progress, ok := p.jobs[key]; ok {
+		if progress.Done {
+			delete(p.jobs, key)
+		}
+		return *progress
+	}
+
+	base, err := url.Parse(OllamaBaseURL(host))
+	if err != nil {
+		return OllamaProgress{Done: true, Err: fmt.Errorf("invalid Ollama host %q: %w", host, err)}
+	}
+	progress := &OllamaProgress{Status: "starting " + kind}
+	p.jobs[key] = progress
+	client := api.NewClient(base, p.httpClient)
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), ollamaJobTimeout)
+		defer cancel()
+		err := job(ctx, client, func(resp api.ProgressResponse) {
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			progress.Status, progress.Completed, progress.Total = resp.Status, resp.Completed, resp.Total
+		})
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		progress.Done = true
+		if err != nil {
+			progress.Err = fmt.Errorf("%s of model %s failed: %w", kind, model, err)
+		}
+	}()
+	return *progress
+}
+
+// OllamaBaseURL returns the URL of the Ollama server at host, adding the http
+// scheme to hosts given without one, such as "ollama.ollama.svc:11434". An
+// empty host is the local server.
+func OllamaBaseURL(host string) string {
+	if host == "" {
+		return "http://localhost:11434"
+	}
+	if !strings.HasPrefix(host, "http://") && !strings.HasPrefix(host, "https://") {
+		return "http://" + host
+	}
+	return host
+}
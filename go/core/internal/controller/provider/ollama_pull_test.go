@@ -0,0 +1,111 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOllamaPullerPull(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/pull" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		_, _ = w.Write([]byte(`{"status":"pulling manifest"}` + "\n"))
+		_, _ = w.Write([]byte(`{"status":"pulling abc","completed":50,"total":100}` + "\n"))
+		w.(http.Flusher).Flush()
+		<-release
+		_, _ = w.Write([]byte(`{"status":"success"}` + "\n"))
+	}))
+	defer server.Close()
+
+	p := NewOllamaPuller()
+	if progress := p.Pull(server.URL, "llama3.2"); progress.Done {
+		t.Fatalf("expected the pull to be running, got %+v", progress)
+	}
+	waitFor(t, func() bool { return p.Pull(server.URL, "llama3.2").Completed == 50 })
+
+	close(release)
+	var progress OllamaProgress
+	waitFor(t, func() bool {
+		progress = p.Pull(server.URL, "llama3.2")
+		return progress.Done
+	})
+	if progress.Err != nil {
+		t.Fatalf("unexpected error: %v", progress.Err)
+	}
+	if progress.Status != "success" {
+		t.Errorf("expected status success, got %q", progress.Status)
+	}
+}
+
+func TestOllamaPullerWarmUpFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/generate" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"error":"model requires more system memory"}`))
+	}))
+	defer server.Close()
+
+	p := NewOllamaPuller()
+	var progress OllamaProgress
+	waitFor(t, func() bool {
+		progress = p.WarmUp(server.URL, "llama3.2")
+		return progress.Done
+	})
+	if progress.Err == nil || !strings.Contains(progress.Err.Error(), "more system memory") {
+		t.Fatalf("expected the warm-up to fail, got %+v", progress)
+	}
+
+	// The failure is reported once; the next call starts a new warm-up.
+	if progress := p.WarmUp(server.URL, "llama3.2"); progress.Done {
+		t.Errorf("expected a new warm-up to start, got %+v", progress)
+	}
+}
+
+func TestOllamaBaseURL(t *testing.T) {
+	tests := map[string]string{
+		"":                           "http://localhost:11434",
+		"ollama.ollama.svc:11434":    "http://ollama.ollama.svc:11434",
+		"https://ollama.example.com": "https://ollama.example.com",
+	}
+	for host, want := range tests {
+		if got := OllamaBaseURL(host); got != want {
+			t.Errorf("OllamaBaseURL(%q) = %q, want %q", host, got, want)
+		}
+	}
+}
+
+func waitFor(t *testing.T, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met in time")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,255 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1alpha2 "github.com/kagent-dev/kagent/go/api/v1alpha2"
+)
+
+const (
+	// DefaultSummaryTimeout is the default HTTP timeout for summarization requests.
+	// Summaries of long sessions can take a while to generate.
+	DefaultSummaryTimeout = 2 * time.Minute
+
+	// DefaultSummaryMaxTokens caps the length of a generated summary.
+	DefaultSummaryMaxTokens = 2048
+)
+
+// SummaryRequest describes a single summarization call.
+type SummaryRequest struct {
+	// Provider selects the wire protocol and auth headers.
+	Provider v1alpha2.ModelProvider
+	// Endpoint is the provider base URL, see ChatEndpoint.
+	Endpoint string
+	APIKey   string
+	Model    string
+	// Headers are extra headers sent with the request (ModelConfig defaultHeaders).
+	Headers map[string]string
+	// Prompt is the full summarization prompt, sent as a single user message.
+	Prompt    string
+	MaxTokens int
+}
+
+// SummaryRequestFor resolves a ModelConfig and its API key into a
+// SummaryRequest without a prompt. ModelConfigs that read their API key in
+// the agent pod (apiKeyFrom) cannot be called by the controller.
+func SummaryRequestFor(ctx context.Context, kube client.Reader, ref types.NamespacedName) (SummaryRequest, error) {
+	modelConfig := &v1alpha2.ModelConfig{}
+	if err := kube.Get(ctx, ref, modelConfig); err != nil {
+		return SummaryRequest{}, fmt.Errorf("failed to get ModelConfig %s: %w", ref, err)
+	}
+	endpoint, err := ChatEndpoint(&modelConfig.Spec)
+	if err != nil {
+		return SummaryRequest{}, fmt.Errorf("failed to resolve endpoint for ModelConfig %s: %w", ref, err)
+	}
+
+	if modelConfig.Spec.APIKeyFrom != nil {
+		return SummaryRequest{}, fmt.Errorf("ModelConfig %s reads its API key in the agent pod (apiKeyFrom), so the controller cannot call it", ref)
+	}
+	var apiKey string
+	if modelConfig.Spec.APIKeySecret != "" {
+		secret := &corev1.Secret{}
+		secretRef := types.NamespacedName{Namespace: modelConfig.Namespace, Name: modelConfig.Spec.APIKeySecret}
+		if err := kube.Get(ctx, secretRef, secret); err != nil {
+			return SummaryRequest{}, fmt.Errorf("failed to get API key secret %s: %w", secretRef, err)
+		}
+		apiKey = string(secret.Data[modelConfig.Spec.APIKeySecretKey])
+	}
+
+	return SummaryRequest{
+		Provider: modelConfig.Spec.Provider,
+		Endpoint: endpoint,
+		APIKey:   apiKey,
+		Model:    modelConfig.Spec.Model,
+		Headers:  modelConfig.Spec.DefaultHeaders,
+	}, nil
+}
+
+// Summarizer calls a model provider's chat API to summarize conversation history.
+// Only providers with an OpenAI-compatible chat completions API or the
+// Anthropic messages API are supported.
+type Summarizer struct {
+	httpClient *http.Client
+	discoverer *ModelDiscoverer
+}
+
+// NewSummarizer creates a new Summarizer instance.
+func NewSummarizer() *Summarizer {
+	return &Summarizer{
+		httpClient: &http.Client{
+			Timeout: DefaultSummaryTimeout,
+		},
+		discoverer: NewModelDiscoverer(),
+	}
+}
+
+// ChatEndpoint returns the base URL used to reach the chat API of a ModelConfig,
+// or an error if the provider is not supported for summarization.
+func ChatEndpoint(spec *v1alpha2.ModelConfigSpec) (string, error) {
+	switch spec.Provider {
+	case v1alpha2.ModelProviderOpenAI, "":
+		if spec.OpenAI != nil && spec.OpenAI.BaseURL != "" {
+			return spec.OpenAI.BaseURL, nil
+		}
+		return "https://api.openai.com/v1", nil
+	case v1alpha2.ModelProviderOpenAICompatible:
+		if spec.OpenAICompatible == nil || spec.OpenAICompatible.BaseURL == "" {
+			return "", fmt.Errorf("openAICompatible.baseUrl is required")
+		}
+		return spec.OpenAICompatible.BaseURL, nil
+	case v1alpha2.ModelProviderAnthropic:
+		if spec.Anthropic != nil && spec.Anthropic.BaseURL != "" {
+			return spec.Anthropic.BaseURL, nil
+		}
+		return "https://api.anthropic.com", nil
+	case v1alpha2.ModelProviderOllama:
+		if spec.Ollama != nil && spec.Ollama.Host != "" {
+			return spec.Ollama.Host, nil
+		}
+		return "http://localhost:11434", nil
+	default:
+		return "", fmt.Errorf("provider %s is not supported for summarization", spec.Provider)
+	}
+}
+
+type chatCompletionRequest struct {
+	Model     string        `json:"model"`
+	Messages  []chatMessage `json:"messages"`
+	MaxTokens int           `json:"max_tokens,omitempty"`
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type chatCompletionResponse struct {
+	Choices []struct {
+		Message chatMessage `json:"message"`
+	} `json:"choices"`
+}
+
+type anthropicMessagesResponse struct {
+	Content []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"content"`
+}
+
+// Summarize sends the prompt to the provider and returns the generated text.
+func (s *Summarizer) Summarize(ctx context.Context, req SummaryRequest) (string, error) {
+	maxTokens := req.MaxTokens
+	if maxTokens <= 0 {
+		maxTokens = DefaultSummaryMaxTokens
+	}
+	body, err := json.Marshal(chatCompletionRequest{
+		Model:     req.Model,
+		Messages:  []chatMessage{{Role: "user", Content: req.Prompt}},
+		MaxTokens: maxTokens,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	url := buildChatURL(req.Endpoint, req.Provider)
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	for k, v := range req.Headers {
+		httpReq.Header.Set(k, v)
+	}
+	if req.Provider != v1alpha2.ModelProviderOllama {
+		s.discoverer.setAuthHeaders(httpReq, req.Provider, req.APIKey)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.httpClient.Do(httpReq)
+	if err != nil {
+		return "", fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response body: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("API returned status %d for provider %s: %s", resp.StatusCode, req.Provider, string(respBody))
+	}
+
+	var text string
+	if req.Provider == v1alpha2.ModelProviderAnthropic {
+		var result anthropicMessagesResponse
+		if err := json.Unmarshal(respBody, &result); err != nil {
+			return "", fmt.Errorf("failed to parse messages response: %w", err)
+		}
+		var parts []string
+		for _, c := range result.Content {
+			if c.Type == "text" {
+				parts = append(parts, c.Text)
+			}
+		}
+		text = strings.Join(parts, "")
+	} else {
+		var result chatCompletionResponse
+		if err := json.Unmarshal(respBody, &result); err != nil {
+			return "", fmt.Errorf("failed to parse chat completion response: %w", err)
+		}
+		if len(result.Choices) > 0 {
+			text = result.Choices[0].Message.Content
+		}
+	}
+
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return "", fmt.Errorf("provider %s returned an empty summary", req.Provider)
+	}
+	return text, nil
+}
+
+// buildChatURL constructs the chat endpoint URL based on provider type.
+func buildChatURL(endpoint string, providerType v1alpha2.ModelProvider) string {
+	endpoint = strings.TrimSuffix(endpoint, "/")
+
+	if providerType == v1alpha2.ModelProviderAnthropic {
+		// Anthropic: https://api.anthropic.com/v1/messages
+		if strings.HasSuffix(endpoint, "/v1") {
+			return endpoint + "/messages"
+		}
+		return endpoint + "/v1/messages"
+	}
+
+	// OpenAI and compatible (LiteLLM, vLLM, Ollama's /v1 API, etc.)
+	if strings.HasSuffix(endpoint, "/v1") {
+		return endpoint + "/chat/completions"
+	}
+	return endpoint + "/v1/chat/completions"
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v1alpha2 "github.com/kagent-dev/kagent/go/api/v1alpha2"
+)
+
+func TestSummarize(t *testing.T) {
+	tests := []struct {
+		name         string
+		providerType v1alpha2.ModelProvider
+		apiKey       string
+		wantPath     string
+		wantHeader   string
+		wantValue    string
+		response     string
+		want         string
+		wantErr      bool
+	}{
+		{
+			name:         "OpenAI chat completions",
+			providerType: v1alpha2.ModelProviderOpenAI,
+			apiKey:       "sk-test",
+			wantPath:     "/v1/chat/completions",
+			wantHeader:   "Authorization",
+			wantValue:    "Bearer sk-test",
+			response:     `{"choices":[{"message":{"role":"assistant","content":" the summary "}}]}`,
+			want:         "the summary",
+		},
+		{
+			name:         "Anthropic messages",
+			providerType: v1alpha2.ModelProviderAnthropic,
+			apiKey:       "ant-key",
+			wantPath:     "/v1/messages",
+			wantHeader:   "x-api-key",
+			wantValue:    "ant-key",
+			response:     `{"content":[{"type":"text","text":"part one, "},{"type":"text","text":"part two"}]}`,
+			want:         "part one, part two",
+		},
+		{
+			name:         "Ollama sends no auth",
+			providerType: v1alpha2.ModelProviderOllama,
+			wantPath:     "/v1/chat/completions",
+			wantHeader:   "Authorization",
+			wantValue:    "",
+			response:     `{"choices":[{"message":{"role":"assistant","content":"ok"}}]}`,
+			want:         "ok",
+		},
+		{
+			name:         "empty summary is an error",
+			providerType: v1alpha2.ModelProviderOpenAICompatible,
+			wantPath:     "/v1/chat/completions",
+			wantHeader:   "Authorization",
+			response:     `{"choices":[]}`,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != tt.wantPath {
+					t.Errorf("path = %q, want %q", r.URL.Path, tt.wantPath)
+				}
+				if got := r.Header.Get(tt.wantHeader); got != tt.wantValue {
+					t.Errorf("header %s = %q, want %q", tt.wantHeader, got, tt.wantValue)
+				}
+				if got := r.Header.Get("X-Team"); got != "platform" {
+					t.Errorf("default header X-Team = %q, want %q", got, "platform")
+				}
+				var body chatCompletionRequest
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					t.Fatalf("failed to decode request: %v", err)
+				}
+				if body.Model != "test-model" || len(body.Messages) != 1 || body.Messages[0].Content != "summarize this" {
+					t.Errorf("unexpected request body: %+v", body)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			got, err := NewSummarizer().Summarize(context.Background(), SummaryRequest{
+				Provider: tt.providerType,
+				Endpoint: server.URL,
+				APIKey:   tt.apiKey,
+				Model:    "test-model",
+				Headers:  map[string]string{"X-Team": "platform"},
+				Prompt:   "summarize this",
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Summarize() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Summarize() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChatEndpoint(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    v1alpha2.ModelConfigSpec
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "OpenAI default",
+			spec: v1alpha2.ModelConfigSpec{Provider: v1alpha2.ModelProviderOpenAI},
+			want: "https://api.openai.com/v1",
+		},
+		{
+			name: "OpenAI custom base URL",
+			spec: v1alpha2.ModelConfigSpec{Provider: v1alpha2.ModelProviderOpenAI, OpenAI: &v1alpha2.OpenAIConfig{BaseURL: "http://litellm:4000"}},
+			want: "http://litellm:4000",
+		},
+		{
+			name: "Anthropic default",
+			spec: v1alpha2.ModelConfigSpec{Provider: v1alpha2.ModelProviderAnthropic},
+			want: "https://api.anthropic.com",
+		},
+		{
+			name: "Ollama host",
+			spec: v1alpha2.ModelConfigSpec{Provider: v1alpha2.ModelProviderOllama, Ollama: &v1alpha2.OllamaConfig{Host: "http://ollama:11434"}},
+			want: "http://ollama:11434",
+		},
+		{
+			name:    "Bedrock unsupported",
+			spec:    v1alpha2.ModelConfigSpec{Provider: v1alpha2.ModelProviderBedrock},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ChatEndpoint(&tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ChatEndpoint() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ChatEndpoint() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,235 @@
+package reconciler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/kagent-dev/kmcp/api/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+)
+
+// Reasons for Agent status condition types Deployed, Ready and ToolsConnected.
+const (
+	AgentDeployedReasonRolloutComplete   = "RolloutComplete"
+	AgentDeployedReasonRolloutInProgress = "RolloutInProgress"
+	AgentReadyReasonHealthCheckFailed    = "HealthCheckFailed"
+	AgentToolsReasonConnected            = "ToolServersReachable"
+	AgentToolsReasonNoToolServers        = "NoToolServers"
+	AgentToolsReasonUnreachable          = "ToolServersUnreachable"
+
+	agentHealthTimeout = 5 * time.Second
+)
+
+// AgentHealthProbe checks that an agent answers requests. It returns nil
+// when the agent is healthy.
+type AgentHealthProbe func(ctx context.Context, agent *v1alpha2.Agent) error
+
+// probeAgentHealth calls the /health endpoint of the agent's Service.
+func probeAgentHealth(ctx context.Context, agent *v1alpha2.Agent) error {
+	ctx, cancel := context.WithTimeout(ctx, agentHealthTimeout)
+	defer cancel()
+
+	url := fmt.Sprintf("http://%s.%s:8080/health", agent.Name, agent.Namespace)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("health check failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("health check returned %s", resp.Status)
+	}
+	return nil
+}
+
+// ReconcileKagentAgentStatus refreshes the Deployed, Ready and ToolsConnected
+// conditions of an Agent without reconciling its resources.
+func (a *kagentReconciler) ReconcileKagentAgentStatus(ctx context.Context, req ctrl.Request) error {
+	agent := &v1alpha2.Agent{}
+	if err := a.kube.Get(ctx, req.NamespacedName, agent); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	conditions := a.agentConditions(ctx, agent)
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest := &v1alpha2.Agent{}
+		if err := a.kube.Get(ctx, req.NamespacedName, latest); err != nil {
+			return err
+		}
+		changed := false
+		for _, condition := range conditions {
+			changed = meta.SetStatusCondition(&latest.Status.Conditions, condition) || changed
+		}
+		if !changed {
+			return nil
+		}
+		return a.kube.Status().Update(ctx, latest)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update agent status: %w", client.IgnoreNotFound(err))
+	}
+	return nil
+}
+
+// agentConditions computes the operational conditions of an Agent from its
+// Deployment, its health endpoint and the MCP servers it uses.
+func (a *kagentReconciler) agentConditions(ctx context.Context, agent *v1alpha2.Agent) []metav1.Condition {
+	deployed := metav1.Condition{
+		Type:               v1alpha2.AgentConditionTypeDeployed,
+		Status:             metav1.ConditionUnknown,
+		ObservedGeneration: agent.Generation,
+	}
+	ready := metav1.Condition{
+		Type:               v1alpha2.AgentConditionTypeReady,
+		Status:             metav1.ConditionUnknown,
+		ObservedGeneration: agent.Generation,
+	}
+
+	deployment := &appsv1.Deployment{}
+	if err := a.kube.Get(ctx, types.NamespacedName{Namespace: agent.Namespace, Name: agent.Name}, deployment); err != nil {
+		deployed.Reason = "DeploymentNotFound"
+		deployed.Message = err.Error()
+		ready.Reason = "DeploymentNotFound"
+		ready.Message = err.Error()
+	} else {
+		replicas := int32(1)
+		if deployment.Spec.Replicas != nil {
+			replicas = *deployment.Spec.Replicas
+		}
+
+		if deployment.Status.ObservedGeneration >= deployment.Generation && deployment.Status.UpdatedReplicas >= replicas {
+			deployed.Status = metav1.ConditionTrue
+			deployed.Reason = AgentDeployedReasonRolloutComplete
+			deployed.Message = fmt.Sprintf("Deployment has rolled out %d/%d pods", deployment.Status.UpdatedReplicas, replicas)
+		} else {
+			deployed.Status = metav1.ConditionFalse
+			deployed.Reason = AgentDeployedReasonRolloutInProgress
+			deployed.Message = fmt.Sprintf("Deployment is rolling out, %d/%d pods are updated", deployment.Status.UpdatedReplicas, replicas)
+		}
+
+		switch {
+		case replicas == 0 && scalesToZero(agent):
+			ready.Status = metav1.ConditionTrue
+			ready.Reason = AgentReadyReasonScaledToZero
+			ready.Message = "Agent is scaled to zero and starts on its next request"
+		case deployment.Status.AvailableReplicas == 0:
+			ready.Status = metav1.ConditionFalse
+			ready.Reason = "DeploymentNotReady"
+			ready.Message = fmt.Sprintf("Deployment is not ready, %d/%d pods are ready", deployment.Status.AvailableReplicas, replicas)
+		case a.healthProbe != nil && agent.Spec.Type == v1alpha2.AgentType_Declarative:
+			if err := a.healthProbe(ctx, agent); err != nil {
+				ready.Status = metav1.ConditionFalse
+				ready.Reason = AgentReadyReasonHealthCheckFailed
+				ready.Message = err.Error()
+				break
+			}
+			fallthrough
+		default:
+			ready.Status = metav1.ConditionTrue
+			ready.Reason = AgentReadyReasonDeploymentReady
+			ready.Message = "Deployment is ready"
+		}
+	}
+
+	return []metav1.Condition{deployed, ready, a.toolsConnectedCondition(ctx, agent)}
+}
+
+func scalesToZero(agent *v1alpha2.Agent) bool {
+	deployment := agent.Spec.SharedDeployment()
+	return deployment != nil && deployment.ScaleToZero != nil
+}
+
+// toolsConnectedCondition checks the MCP servers referenced by the agent's
+// tools: RemoteMCPServers must have been accepted, which requires tool
+// discovery to succeed, MCPServers must be ready and Services must exist.
+func (a *kagentReconciler) toolsConnectedCondition(ctx context.Context, agent *v1alpha2.Agent) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               v1alpha2.AgentConditionTypeToolsConnected,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: agent.Generation,
+	}
+
+	var servers int
+	var unreachable []string
+	if agent.Spec.Type == v1alpha2.AgentType_Declarative && agent.Spec.Declarative != nil {
+		for _, tool := range agent.Spec.Declarative.Tools {
+			if tool == nil || tool.McpServer == nil {
+				continue
+			}
+			servers++
+			ref := tool.McpServer.NamespacedName(agent.Namespace)
+			if err := a.checkToolServer(ctx, tool.McpServer.GroupKind(), ref); err != nil {
+				unreachable = append(unreachable, fmt.Sprintf("%s: %v", ref, err))
+			}
+		}
+	}
+
+	switch {
+	case servers == 0:
+		condition.Reason = AgentToolsReasonNoToolServers
+		condition.Message = "Agent uses no MCP servers"
+	case len(unreachable) > 0:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = AgentToolsReasonUnreachable
+		condition.Message = fmt.Sprintf("%d/%d MCP servers are unreachable: %s", len(unreachable), servers, strings.Join(unreachable, "; "))
+	default:
+		condition.Reason = AgentToolsReasonConnected
+		condition.Message = fmt.Sprintf("%d/%d MCP servers are reachable", servers, servers)
+	}
+	return condition
+}
+
+func (a *kagentReconciler) checkToolServer(ctx context.Context, gk schema.GroupKind, ref types.NamespacedName) error {
+	switch gk {
+	case schema.GroupKind{Group: "kagent.dev", Kind: "MCPServer"}:
+		server := &v1alpha1.MCPServer{}
+		if err := a.kube.Get(ctx, ref, server); err != nil {
+			return notFoundOr(err, "MCPServer not found")
+		}
+		if !meta.IsStatusConditionTrue(server.Status.Conditions, string(v1alpha1.MCPServerConditionReady)) {
+			return errors.New("MCPServer is not ready")
+		}
+	case schema.GroupKind{Group: "", Kind: "Service"}:
+		if err := a.kube.Get(ctx, ref, &corev1.Service{}); err != nil {
+			return notFoundOr(err, "Service not found")
+		}
+	default:
+		server := &v1alpha2.RemoteMCPServer{}
+		if err := a.kube.Get(ctx, ref, server); err != nil {
+			return notFoundOr(err, "RemoteMCPServer not found")
+		}
+		accepted := meta.FindStatusCondition(server.Status.Conditions, v1alpha2.AgentConditionTypeAccepted)
+		if accepted == nil {
+			return errors.New("RemoteMCPServer has not been reconciled yet")
+		}
+		if accepted.Status != metav1.ConditionTrue {
+			return errors.New(accepted.Message)
+		}
+	}
+	return nil
+}
+
+func notFoundOr(err error, notFound string) error {
+	if apierrors.IsNotFound(err) {
+		return errors.New(notFound)
+	}
+	return err
+}
@@ -0,0 +1,177 @@
+package reconciler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+)
+
+func TestReconcileKagentAgentStatus(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1alpha2.AddToScheme(scheme))
+
+	mcpTool := func(kind, name string) *v1alpha2.Tool {
+		apiGroup := "kagent.dev"
+		if kind == "Service" {
+			apiGroup = ""
+		}
+		return &v1alpha2.Tool{
+			Type: v1alpha2.ToolProviderType_McpServer,
+			McpServer: &v1alpha2.McpServerTool{
+				TypedReference: v1alpha2.TypedReference{ApiGroup: apiGroup, Kind: kind, Name: name},
+			},
+		}
+	}
+	remoteServer := func(name string, status metav1.ConditionStatus, message string) *v1alpha2.RemoteMCPServer {
+		return &v1alpha2.RemoteMCPServer{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Status: v1alpha2.RemoteMCPServerStatus{Conditions: []metav1.Condition{{
+				Type:    v1alpha2.AgentConditionTypeAccepted,
+				Status:  status,
+				Reason:  "Reconciled",
+				Message: message,
+			}}},
+		}
+	}
+
+	replicas, scaledToZero := int32(2), int32(0)
+	tests := []struct {
+		name         string
+		replicas     *int32
+		scaleToZero  *v1alpha2.ScaleToZeroSpec
+		deployment   appsv1.DeploymentStatus
+		tools        []*v1alpha2.Tool
+		healthErr    error
+		wantDeployed string
+		wantReady    string
+		wantTools    string
+		wantToolsMsg string
+	}{
+		{
+			name:         "rolled out, healthy, tools reachable",
+			deployment:   appsv1.DeploymentStatus{UpdatedReplicas: 2, AvailableReplicas: 2},
+			tools:        []*v1alpha2.Tool{mcpTool("RemoteMCPServer", "k8s-tools"), mcpTool("Service", "mcp-svc")},
+			wantDeployed: AgentDeployedReasonRolloutComplete,
+			wantReady:    AgentReadyReasonDeploymentReady,
+			wantTools:    AgentToolsReasonConnected,
+			wantToolsMsg: "2/2 MCP servers are reachable",
+		},
+		{
+			name:         "rollout in progress without tools",
+			deployment:   appsv1.DeploymentStatus{UpdatedReplicas: 1, AvailableReplicas: 2},
+			wantDeployed: AgentDeployedReasonRolloutInProgress,
+			wantReady:    AgentReadyReasonDeploymentReady,
+			wantTools:    AgentToolsReasonNoToolServers,
+		},
+		{
+			name:         "health check fails",
+			deployment:   appsv1.DeploymentStatus{UpdatedReplicas: 2, AvailableReplicas: 1},
+			healthErr:    errors.New("health check returned 503 Service Unavailable"),
+			wantDeployed: AgentDeployedReasonRolloutComplete,
+			wantReady:    AgentReadyReasonHealthCheckFailed,
+			wantTools:    AgentToolsReasonNoToolServers,
+		},
+		{
+			name:         "tool servers unreachable",
+			deployment:   appsv1.DeploymentStatus{UpdatedReplicas: 2, AvailableReplicas: 2},
+			tools:        []*v1alpha2.Tool{mcpTool("RemoteMCPServer", "broken"), mcpTool("RemoteMCPServer", "missing")},
+			wantDeployed: AgentDeployedReasonRolloutComplete,
+			wantReady:    AgentReadyReasonDeploymentReady,
+			wantTools:    AgentToolsReasonUnreachable,
+			wantToolsMsg: "2/2 MCP servers are unreachable: default/broken: connection refused; default/missing: RemoteMCPServer not found",
+		},
+		{
+			name:         "scaled to zero while idle",
+			replicas:     &scaledToZero,
+			scaleToZero:  &v1alpha2.ScaleToZeroSpec{IdleTimeout: metav1.Duration{Duration: time.Hour}},
+			wantDeployed: AgentDeployedReasonRolloutComplete,
+			wantReady:    AgentReadyReasonScaledToZero,
+			wantTools:    AgentToolsReasonNoToolServers,
+		},
+		{
+			name:         "scaled to zero without scale-to-zero",
+			replicas:     &scaledToZero,
+			wantDeployed: AgentDeployedReasonRolloutComplete,
+			wantReady:    "DeploymentNotReady",
+			wantTools:    AgentToolsReasonNoToolServers,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agent := &v1alpha2.Agent{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-agent", Namespace: "default"},
+				Spec: v1alpha2.AgentSpec{
+					Type: v1alpha2.AgentType_Declarative,
+					Declarative: &v1alpha2.DeclarativeAgentSpec{
+						Tools: tt.tools,
+						Deployment: &v1alpha2.DeclarativeDeploymentSpec{
+							SharedDeploymentSpec: v1alpha2.SharedDeploymentSpec{ScaleToZero: tt.scaleToZero},
+						},
+					},
+				},
+			}
+			deploymentReplicas := &replicas
+			if tt.replicas != nil {
+				deploymentReplicas = tt.replicas
+			}
+			deployment := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: agent.Name, Namespace: agent.Namespace},
+				Spec:       appsv1.DeploymentSpec{Replicas: deploymentReplicas},
+				Status:     tt.deployment,
+			}
+			kube := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithStatusSubresource(agent).
+				WithObjects(
+					agent,
+					deployment,
+					remoteServer("k8s-tools", metav1.ConditionTrue, "Remote MCP server configuration accepted"),
+					remoteServer("broken", metav1.ConditionFalse, "connection refused"),
+					&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "mcp-svc", Namespace: "default"}},
+				).
+				Build()
+			reconciler := &kagentReconciler{
+				kube: kube,
+				healthProbe: func(context.Context, *v1alpha2.Agent) error {
+					return tt.healthErr
+				},
+			}
+
+			require.NoError(t, reconciler.ReconcileKagentAgentStatus(context.Background(), reconcile.Request{NamespacedName: client.ObjectKeyFromObject(agent)}))
+
+			updated := &v1alpha2.Agent{}
+			require.NoError(t, kube.Get(context.Background(), client.ObjectKeyFromObject(agent), updated))
+			for condType, want := range map[string]string{
+				v1alpha2.AgentConditionTypeDeployed:       tt.wantDeployed,
+				v1alpha2.AgentConditionTypeReady:          tt.wantReady,
+				v1alpha2.AgentConditionTypeToolsConnected: tt.wantTools,
+			} {
+				cond := meta.FindStatusCondition(updated.Status.Conditions, condType)
+				require.NotNil(t, cond, condType)
+				assert.Equal(t, want, cond.Reason, condType)
+			}
+			if tt.wantToolsMsg != "" {
+				tools := meta.FindStatusCondition(updated.Status.Conditions, v1alpha2.AgentConditionTypeToolsConnected)
+				assert.Equal(t, tt.wantToolsMsg, tools.Message)
+			}
+			assert.Nil(t, meta.FindStatusCondition(updated.Status.Conditions, v1alpha2.AgentConditionTypeAccepted), "Accepted is left to the agent reconciler")
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package reconciler
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Reasons for the Events recorded on reconciled objects, so that
+// `kubectl describe` shows what the controller did without its logs.
+const (
+	EventReasonTranslationFailed     = "TranslationFailed"
+	EventReasonResourceCreated       = "ResourceCreated"
+	EventReasonResourceUpdated       = "ResourceUpdated"
+	EventReasonResourceApplyFailed   = "ResourceApplyFailed"
+	EventReasonToolDiscoveryFailed   = "ToolDiscoveryFailed"
+	EventReasonToolsDiscovered       = "ToolsDiscovered"
+	EventReasonToolServerProbeFailed = "ToolServerProbeFailed"
+	EventReasonModelConfigInvalid    = "ModelConfigInvalid"
+	EventReasonModelDiscoveryFailed  = "ModelDiscoveryFailed"
+	EventReasonModelPulled           = "ModelPulled"
+	EventReasonModelPullFailed       = "ModelPullFailed"
+	EventReasonModelWarmUpFailed     = "ModelWarmUpFailed"
+	EventReasonMemoryStoreFailed     = "MemoryStoreFailed"
+	EventReasonSkillResolutionFailed = "SkillResolutionFailed"
+)
+
+// Actions of the Events recorded on reconciled objects.
+const (
+	eventActionTranslate     = "Translate"
+	eventActionApply         = "Apply"
+	eventActionDiscoverTools = "DiscoverTools"
+	eventActionProbe         = "Probe"
+	eventActionValidate      = "Validate"
+	eventActionDiscoverModel = "DiscoverModels"
+	eventActionPullModel     = "PullModel"
+	eventActionWarmUpModel   = "WarmUpModel"
+	eventActionEnsureMemory  = "EnsureMemoryStore"
+	eventActionResolveSkills = "ResolveSkills"
+)
+
+// event records a Normal Event on obj.
+func (a *kagentReconciler) event(obj runtime.Object, reason, action, note string, args ...any) {
+	if a.recorder != nil {
+		a.recorder.Eventf(obj, nil, corev1.EventTypeNormal, reason, action, note, args...)
+	}
+}
+
+// warningEvent records a Warning Event on obj.
+func (a *kagentReconciler) warningEvent(obj runtime.Object, reason, action, note string, args ...any) {
+	if a.recorder != nil {
+		a.recorder.Eventf(obj, nil, corev1.EventTypeWarning, reason, action, note, args...)
+	}
+}
@@ -0,0 +1,100 @@
+package reconciler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func drainEvents(recorder *events.FakeRecorder) []string {
+	var out []string
+	for {
+		select {
+		case e := <-recorder.Events:
+			out = append(out, e)
+		default:
+			return out
+		}
+	}
+}
+
+func eventsScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1alpha2.AddToScheme(scheme))
+	return scheme
+}
+
+func TestReconcileKagentModelConfig_Events(t *testing.T) {
+	modelConfig := &v1alpha2.ModelConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "primary", Namespace: "default"},
+		Spec: v1alpha2.ModelConfigSpec{
+			Model:        "gpt-4o",
+			Provider:     v1alpha2.ModelProviderOpenAI,
+			APIKeySecret: "missing",
+			Fallbacks:    []v1alpha2.ModelFallback{{ModelConfig: "backup"}},
+		},
+	}
+	kube := fake.NewClientBuilder().
+		WithScheme(eventsScheme(t)).
+		WithStatusSubresource(modelConfig).
+		WithObjects(modelConfig).
+		Build()
+	recorder := events.NewFakeRecorder(10)
+	r := &kagentReconciler{kube: kube, recorder: recorder}
+
+	require.NoError(t, r.ReconcileKagentModelConfig(context.Background(), reconcile.Request{NamespacedName: client.ObjectKeyFromObject(modelConfig)}))
+
+	got := drainEvents(recorder)
+	require.Len(t, got, 2)
+	assert.Contains(t, got[0], "Warning ModelConfigInvalid failed to get secret missing")
+	assert.Contains(t, got[1], "Warning ModelConfigInvalid")
+	assert.Contains(t, got[1], "backup")
+}
+
+func TestReconcileDesiredObjects_Events(t *testing.T) {
+	owner := &v1alpha2.Agent{
+		ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "default", UID: types.UID("agent-uid")},
+	}
+	kube := fake.NewClientBuilder().WithScheme(eventsScheme(t)).WithObjects(owner).Build()
+	recorder := events.NewFakeRecorder(10)
+	r := &kagentReconciler{kube: kube, recorder: recorder}
+
+	desired := func(value string) []client.Object {
+		return []client.Object{&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "default"},
+			Data:       map[string]string{"key": value},
+		}}
+	}
+	ctx := context.Background()
+
+	require.NoError(t, r.reconcileDesiredObjects(ctx, owner, desired("a"), nil))
+	assert.Equal(t, []string{"Normal ResourceCreated Created ConfigMap agent"}, drainEvents(recorder))
+
+	require.NoError(t, r.reconcileDesiredObjects(ctx, owner, desired("b"), nil))
+	assert.Equal(t, []string{"Normal ResourceUpdated Updated ConfigMap agent"}, drainEvents(recorder))
+
+	require.NoError(t, r.reconcileDesiredObjects(ctx, owner, desired("b"), nil))
+	assert.Empty(t, drainEvents(recorder), "unchanged objects record no events")
+}
+
+func TestEventsWithoutRecorder(t *testing.T) {
+	r := &kagentReconciler{}
+	assert.NotPanics(t, func() {
+		r.event(&v1alpha2.Agent{}, EventReasonResourceCreated, eventActionApply, "Created")
+		r.warningEvent(&v1alpha2.Agent{}, EventReasonTranslationFailed, eventActionTranslate, "failed")
+	})
+}
@@ -0,0 +1,46 @@
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	"github.com/kagent-dev/kagent/go/core/internal/qdrant"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// ensureQdrantMemory makes sure the Qdrant collection of an agent using the
+// Qdrant memory provider exists with the payload indexes the agent runtime
+// filters on, and prunes the memories in it that have expired. It is a no-op
+// for agents without Qdrant memory. The collection is left in place when the
+// agent is deleted, since it may be shared and holds users' memories.
+func (a *kagentReconciler) ensureQdrantMemory(ctx context.Context, agent v1alpha2.AgentObject) error {
+	spec := agent.GetAgentSpec()
+	if spec.Declarative == nil || spec.Declarative.Memory == nil ||
+		spec.Declarative.Memory.Provider != v1alpha2.MemoryProviderQdrant || spec.Declarative.Memory.Qdrant == nil {
+		return nil
+	}
+	cfg := spec.Declarative.Memory.Qdrant
+
+	var apiKey string
+	if cfg.APIKeySecret != "" {
+		secret := &corev1.Secret{}
+		if err := a.kube.Get(ctx, types.NamespacedName{Namespace: agent.GetNamespace(), Name: cfg.APIKeySecret}, secret); err != nil {
+			return fmt.Errorf("failed to get Qdrant API key secret %s: %w", cfg.APIKeySecret, err)
+		}
+		key, ok := secret.Data[cfg.APIKeySecretKey]
+		if !ok {
+			return fmt.Errorf("key %q not found in Qdrant API key secret %s", cfg.APIKeySecretKey, cfg.APIKeySecret)
+		}
+		apiKey = string(key)
+	}
+
+	collection := cfg.CollectionName(agent.GetNamespace(), agent.GetName())
+	client := qdrant.NewClient(cfg.URL, apiKey, nil)
+	if err := client.EnsureCollection(ctx, collection); err != nil {
+		return err
+	}
+	return client.DeleteExpired(ctx, collection, time.Now())
+}
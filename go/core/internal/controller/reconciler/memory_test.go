@@ -0,0 +1,97 @@
+package reconciler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestEnsureQdrantMemory(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		requests []string
+		apiKeys  []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		apiKeys = append(apiKeys, r.Header.Get("api-key"))
+		if r.Method == http.MethodGet {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"result":true,"status":"ok"}`))
+	}))
+	defer server.Close()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1alpha2.AddToScheme(scheme))
+	kube := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "qdrant", Namespace: "default"},
+		Data:       map[string][]byte{"api-key": []byte("secret")},
+	}).Build()
+	r := &kagentReconciler{kube: kube}
+
+	agent := func(memory *v1alpha2.MemorySpec) *v1alpha2.Agent {
+		return &v1alpha2.Agent{
+			ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "default"},
+			Spec: v1alpha2.AgentSpec{
+				Type:        v1alpha2.AgentType_Declarative,
+				Declarative: &v1alpha2.DeclarativeAgentSpec{Memory: memory},
+			},
+		}
+	}
+
+	t.Run("postgres memory is left alone", func(t *testing.T) {
+		require.NoError(t, r.ensureQdrantMemory(context.Background(), agent(&v1alpha2.MemorySpec{
+			ModelConfig: "embedding",
+			Provider:    v1alpha2.MemoryProviderPostgres,
+		})))
+		assert.Empty(t, requests)
+	})
+
+	t.Run("qdrant collection is created and pruned", func(t *testing.T) {
+		require.NoError(t, r.ensureQdrantMemory(context.Background(), agent(&v1alpha2.MemorySpec{
+			ModelConfig: "embedding",
+			Provider:    v1alpha2.MemoryProviderQdrant,
+			Qdrant: &v1alpha2.QdrantMemoryConfig{
+				URL:             server.URL,
+				APIKeySecret:    "qdrant",
+				APIKeySecretKey: "api-key",
+			},
+		})))
+		require.NotEmpty(t, requests)
+		assert.Equal(t, "GET /collections/kagent-default-agent", requests[0])
+		assert.Equal(t, "POST /collections/kagent-default-agent/points/delete", requests[len(requests)-1])
+		for _, key := range apiKeys {
+			assert.Equal(t, "secret", key)
+		}
+	})
+
+	t.Run("missing secret key is an error", func(t *testing.T) {
+		err := r.ensureQdrantMemory(context.Background(), agent(&v1alpha2.MemorySpec{
+			ModelConfig: "embedding",
+			Provider:    v1alpha2.MemoryProviderQdrant,
+			Qdrant: &v1alpha2.QdrantMemoryConfig{
+				URL:             server.URL,
+				APIKeySecret:    "qdrant",
+				APIKeySecretKey: "token",
+			},
+		}))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `key "token" not found`)
+	})
+}
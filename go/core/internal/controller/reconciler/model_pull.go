@@ -0,0 +1,111 @@
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	"github.com/kagent-dev/kagent/go/core/internal/controller/provider"
+	"github.com/kagent-dev/kagent/go/core/internal/utils"
+)
+
+// prepareOllamaModel makes sure the model of an Ollama ModelConfig is served:
+// it lists the models on the server, pulls the model when it is missing and
+// spec.ollama.pullModel is set, and loads it into memory when
+// spec.ollama.warmUp is set. Pulls and warm-ups run in the background; the
+// result reports their progress until they finish.
+func (a *kagentReconciler) prepareOllamaModel(ctx context.Context, modelConfig *v1alpha2.ModelConfig) *modelConfigDiscovery {
+	ollama := modelConfig.Spec.Ollama
+	model := modelConfig.Spec.Model
+
+	discoverer := provider.NewModelDiscoverer()
+	models, err := discoverer.DiscoverModels(ctx, v1alpha2.ModelProviderOllama, provider.OllamaBaseURL(ollama.Host), "")
+	if err != nil {
+		return &modelConfigDiscovery{endpointErr: err}
+	}
+
+	result := &modelConfigDiscovery{models: models}
+	if !slices.ContainsFunc(models, func(name string) bool { return sameOllamaModel(name, model) }) {
+		if !ollama.PullModel {
+			result.modelErr = fmt.Errorf("model %q is not on the Ollama server %s (available: %s); pull it or set spec.ollama.pullModel",
+				model, provider.OllamaBaseURL(ollama.Host), strings.Join(models, ", "))
+			return result
+		}
+		progress := a.ollamaPuller.Pull(ollama.Host, model)
+		switch {
+		case progress.Err != nil:
+			result.pullErr = progress.Err
+			return result
+		case !progress.Done:
+			result.pull = &progress
+			return result
+		}
+		result.pulled = true
+		result.models = append(result.models, model)
+	}
+
+	if ollama.WarmUp {
+		progress := a.ollamaPuller.WarmUp(ollama.Host, model)
+		switch {
+		case progress.Err != nil:
+			result.loadErr = progress.Err
+		case !progress.Done:
+			result.loading = true
+		}
+	}
+	return result
+}
+
+// sameOllamaModel reports whether two Ollama model names refer to the same
+// model, where a name without a tag stands for its "latest" tag.
+func sameOllamaModel(a, b string) bool {
+	withTag := func(name string) string {
+		if strings.Contains(name, ":") {
+			return name
+		}
+		return name + ":latest"
+	}
+	return withTag(a) == withTag(b)
+}
+
+// pullMessage describes the progress of a model pull for the ModelAvailable
+// condition.
+func pullMessage(model string, progress *provider.OllamaProgress) string {
+	if progress.Total > 0 {
+		return fmt.Sprintf("Pulling model %s: %s (%d%%)", model, progress.Status, progress.Completed*100/progress.Total)
+	}
+	return fmt.Sprintf("Pulling model %s: %s", model, progress.Status)
+}
+
+// preparationErr returns the error ReconcileKagentModelConfig returns for the
+// pull and warm-up of the model: a ModelPendingError while they run, and the
+// error of a failed one so that it is retried with backoff.
+func (d *modelConfigDiscovery) preparationErr(modelConfig *v1alpha2.ModelConfig) error {
+	switch {
+	case d == nil:
+		return nil
+	case d.pullErr != nil:
+		return fmt.Errorf("failed to pull model for model config %s: %w", utils.GetObjectRef(modelConfig), d.pullErr)
+	case d.loadErr != nil:
+		return fmt.Errorf("failed to warm up model for model config %s: %w", utils.GetObjectRef(modelConfig), d.loadErr)
+	case d.pull != nil:
+		return &ModelPendingError{Model: modelConfig.Spec.Model, Reason: "pulling"}
+	case d.loading:
+		return &ModelPendingError{Model: modelConfig.Spec.Model, Reason: "loading"}
+	}
+	return nil
+}
+
+// ModelPendingError is returned by ReconcileKagentModelConfig while the model
+// of an Ollama ModelConfig is being pulled or loaded. The ModelConfig is
+// reconciled again after a short delay to report the progress.
+type ModelPendingError struct {
+	Model  string
+	Reason string
+}
+
+func (e *ModelPendingError) Error() string {
+	return fmt.Sprintf("model %s is still %s", e.Model, e.Reason)
+}
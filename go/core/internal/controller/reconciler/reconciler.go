@@ -18,24 +18,31 @@ import (
 	"github.com/hashicorp/go-multierror"
 	reconcilerutils "github.com/kagent-dev/kagent/go/core/internal/controller/reconciler/utils"
 	"github.com/kagent-dev/kagent/go/core/internal/controller/translator"
+	"github.com/kagent-dev/kagent/go/core/internal/mcpauth"
+	"github.com/kagent-dev/kagent/go/core/internal/toolstats"
 	"github.com/kagent-dev/kagent/go/core/pkg/egress"
 	"github.com/kagent-dev/kagent/go/core/pkg/sandboxbackend"
 	"github.com/kagent-dev/kagent/go/core/pkg/sandboxbackend/substrate"
 	"github.com/kagent-dev/kmcp/api/v1alpha1"
-	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/events"
 	"k8s.io/client-go/util/retry"
 
 	"github.com/kagent-dev/kagent/go/api/database"
 	"github.com/kagent-dev/kagent/go/api/v1alpha2"
 	"github.com/kagent-dev/kagent/go/core/internal/controller/provider"
 	agent_translator "github.com/kagent-dev/kagent/go/core/internal/controller/translator/agent"
+	"github.com/kagent-dev/kagent/go/core/internal/debugcapture"
+	"github.com/kagent-dev/kagent/go/core/internal/imagepolicy"
 	"github.com/kagent-dev/kagent/go/core/internal/utils"
 	"github.com/kagent-dev/kagent/go/core/internal/version"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/oauth2"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -51,6 +58,9 @@ var (
 const (
 	AgentReadyReasonDeploymentReady = "DeploymentReady"
 	AgentReadyReasonWorkloadReady   = "WorkloadReady"
+	// AgentReadyReasonScaledToZero marks an idle scale-to-zero agent, which
+	// is started by its next request.
+	AgentReadyReasonScaledToZero = "ScaledToZero"
 
 	// mcpRegistrationTimeout is the default deadline applied to a RemoteMCPServer
 	// registration attempt (header resolution + MCP connect + tool listing) when
@@ -72,6 +82,7 @@ func remoteMCPRegistrationTimeout(s *v1alpha2.RemoteMCPServer) time.Duration {
 
 type KagentReconciler interface {
 	ReconcileKagentAgent(ctx context.Context, req ctrl.Request) error
+	ReconcileKagentAgentStatus(ctx context.Context, req ctrl.Request) error
 	ReconcileKagentSandboxAgent(ctx context.Context, req ctrl.Request) error
 	ReconcileKagentModelConfig(ctx context.Context, req ctrl.Request) error
 	ReconcileKagentRemoteMCPServer(ctx context.Context, req ctrl.Request) error
@@ -88,6 +99,9 @@ type kagentReconciler struct {
 	kube     client.Client
 	dbClient database.Client
 
+	// recorder records Events on reconciled objects. It may be nil.
+	recorder events.EventRecorder
+
 	defaultModelConfig types.NamespacedName
 
 	// watchedNamespaces is the list of namespaces the controller watches.
@@ -103,6 +117,18 @@ type kagentReconciler struct {
 	// uses s.Spec.URL verbatim. Mirrors the agent translator's config-phase
 	// egress rewrite.
 	mcpEgressPlaintext bool
+
+	// mcpTokens caches the OAuth2 tokens of RemoteMCPServers with spec.auth
+	// across tool discovery runs.
+	mcpTokens mcpauth.TokenSources
+
+	// healthProbe checks the health endpoint of declarative agents for the
+	// Ready condition. When nil, Ready only reflects the Deployment.
+	healthProbe AgentHealthProbe
+
+	// ollamaPuller pulls and loads the models of Ollama ModelConfigs with
+	// spec.ollama.pullModel or warmUp set.
+	ollamaPuller *provider.OllamaPuller
 }
 
 func NewKagentReconciler(
@@ -113,15 +139,19 @@ func NewKagentReconciler(
 	watchedNamespaces []string,
 	sandboxBackend sandboxbackend.Backend,
 	mcpEgressPlaintext bool,
+	recorder events.EventRecorder,
 ) KagentReconciler {
 	return &kagentReconciler{
 		adkTranslator:      adkTranslator,
 		kube:               kube,
 		dbClient:           dbClient,
+		recorder:           recorder,
 		defaultModelConfig: defaultModelConfig,
 		watchedNamespaces:  watchedNamespaces,
 		sandboxBackend:     sandboxBackend,
 		mcpEgressPlaintext: mcpEgressPlaintext,
+		healthProbe:        probeAgentHealth,
+		ollamaPuller:       provider.NewOllamaPuller(),
 	}
 }
 
@@ -134,9 +164,18 @@ func (a *kagentReconciler) ReconcileKagentAgent(ctx context.Context, req ctrl.Re
 		return fmt.Errorf("failed to get agent %s: %w", req.NamespacedName, err)
 	}
 
+	ctx = debugcapture.Start(ctx, a.dbClient, "Agent", agent, time.Now())
+	debugcapture.Record(ctx, "reconcile", "reconcile started", map[string]any{
+		"generation":      agent.Generation,
+		"resourceVersion": agent.ResourceVersion,
+	})
+
 	err := a.reconcileAgent(ctx, agent)
 	if err != nil {
 		reconcileLog.Error(err, "failed to reconcile agent", "agent", req.NamespacedName)
+		debugcapture.Record(ctx, "reconcile", "reconcile failed", map[string]any{"error": err.Error()})
+	} else {
+		debugcapture.Record(ctx, "reconcile", "reconcile succeeded", nil)
 	}
 
 	return a.reconcileAgentStatus(ctx, agent, err)
@@ -194,18 +233,26 @@ func (a *kagentReconciler) reconcileTranslatedAgent(
 	mutateManifest func([]client.Object) error,
 ) error {
 	if err := a.validateCrossNamespaceReferences(ctx, agent); err != nil {
+		a.warningEvent(agent, EventReasonTranslationFailed, eventActionTranslate, "%s", err.Error())
 		return err
 	}
 
 	inputs, err := a.adkTranslator.CompileAgent(ctx, agent)
 	if err != nil {
+		a.warningEvent(agent, EventReasonTranslationFailed, eventActionTranslate, "Failed to compile %s: %s", resourceName, err.Error())
 		return fmt.Errorf("failed to compile %s %s/%s: %w", resourceName, agent.GetNamespace(), agent.GetName(), err)
 	}
+	debugcapture.Record(ctx, "translate", "compiled translator inputs", map[string]any{
+		"spec":   agent.GetAgentSpec(),
+		"inputs": inputs,
+	})
 
 	agentOutputs, err := a.adkTranslator.BuildManifest(ctx, agent, inputs)
 	if err != nil {
+		a.warningEvent(agent, EventReasonTranslationFailed, eventActionTranslate, "Failed to build manifest: %s", err.Error())
 		return fmt.Errorf("failed to build manifest for %s %s/%s: %w", resourceName, agent.GetNamespace(), agent.GetName(), err)
 	}
+	debugcapture.Record(ctx, "translate", "built manifest", agentOutputs)
 
 	if mutateManifest != nil {
 		if err := mutateManifest(agentOutputs.Manifest); err != nil {
@@ -228,13 +275,75 @@ func (a *kagentReconciler) reconcileTranslatedAgent(
 		return fmt.Errorf("failed to reconcile owned objects: %w", err)
 	}
 
+	if inputs.SkillDigestsErr != nil {
+		a.warningEvent(agent, EventReasonSkillResolutionFailed, eventActionResolveSkills, "Skills are not pinned to a digest: %s", inputs.SkillDigestsErr.Error())
+	}
+
+	// The secret hash is folded into the pod template's config hash, so a
+	// changed hash means the workload above is rolling out with the rotated
+	// Secret data.
+	previousRotation := agent.GetAgentStatus().SecretsRotatedAt
+	secretHashChanged := recordAgentSecretHash(agent.GetAgentStatus(), inputs.SecretHashBytes, time.Now())
+	if secretHashChanged && agent.GetAgentStatus().SecretsRotatedAt != previousRotation {
+		reconcileLog.Info("referenced secrets rotated, rolling out", "kind", agentKind(agent), "namespace", agent.GetNamespace(), "name", agent.GetName())
+	}
+	skillsChanged := recordAgentSkills(agent.GetAgentStatus(), agent.GetAgentSpec().Skills, inputs.SkillDigests)
+	if secretHashChanged || skillsChanged {
+		if err := a.kube.Status().Update(ctx, agent); err != nil {
+			return fmt.Errorf("failed to update %s status: %w", strings.ToLower(agentKind(agent)), err)
+		}
+	}
+
 	if err := a.upsertAgent(ctx, agent, agentOutputs); err != nil {
 		return fmt.Errorf("failed to upsert %s %s/%s: %w", resourceName, agent.GetNamespace(), agent.GetName(), err)
 	}
 
+	if err := a.ensureQdrantMemory(ctx, agent); err != nil {
+		a.warningEvent(agent, EventReasonMemoryStoreFailed, eventActionEnsureMemory, "%s", err.Error())
+		return err
+	}
+
 	return nil
 }
 
+// recordAgentSecretHash stores a hash of the agent's referenced Secret data
+// in its status and stamps SecretsRotatedAt when a previously recorded hash
+// changes. Returns whether the status changed.
+func recordAgentSecretHash(status *v1alpha2.AgentStatus, secretHashBytes []byte, now time.Time) bool {
+	var secretHash string
+	if len(secretHashBytes) > 0 {
+		sum := sha256.Sum256(secretHashBytes)
+		secretHash = hex.EncodeToString(sum[:])
+	}
+	if status.SecretHash == secretHash {
+		return false
+	}
+	// Secrets being referenced for the first time, or no longer referenced,
+	// is a spec change rather than a rotation.
+	if status.SecretHash != "" && secretHash != "" {
+		rotatedAt := metav1.NewTime(now)
+		status.SecretsRotatedAt = &rotatedAt
+	}
+	status.SecretHash = secretHash
+	return true
+}
+
+// recordAgentSkills stores the agent's OCI skills and the digests they
+// resolved to in its status. Returns whether the status changed.
+func recordAgentSkills(status *v1alpha2.AgentStatus, skills *v1alpha2.SkillForAgent, digests map[string]string) bool {
+	var resolved []v1alpha2.SkillStatus
+	if skills != nil {
+		for _, ref := range skills.Refs {
+			resolved = append(resolved, v1alpha2.SkillStatus{Ref: ref, Digest: digests[ref]})
+		}
+	}
+	if equality.Semantic.DeepEqual(status.Skills, resolved) {
+		return false
+	}
+	status.Skills = resolved
+	return true
+}
+
 func (a *kagentReconciler) reconcileSandboxAgent(ctx context.Context, sa *v1alpha2.SandboxAgent) error {
 	if err := v1alpha2.ValidateSubstrateSandboxAgentSpec(sa); err != nil {
 		return err
@@ -274,45 +383,50 @@ func (a *kagentReconciler) reconcileSandboxAgentStatus(ctx context.Context, sa *
 		}
 	}
 
-	return a.updateAgentObjectStatus(ctx, sa, reconcileErr, deployedCondition)
+	return a.updateAgentObjectStatus(ctx, sa, reconcileErr, false, deployedCondition)
 }
 
 func (a *kagentReconciler) reconcileAgentStatus(ctx context.Context, agent *v1alpha2.Agent, err error) error {
-	deployedCondition := metav1.Condition{
-		Type:               v1alpha2.AgentConditionTypeReady,
-		Status:             metav1.ConditionUnknown,
-		ObservedGeneration: agent.Generation,
+	conditions := a.agentConditions(ctx, agent)
+
+	autoscalingChanged, hpaErr := a.setAutoscalingStatus(ctx, agent)
+	if hpaErr != nil {
+		reconcileLog.Error(hpaErr, "failed to get HorizontalPodAutoscaler", "agent", client.ObjectKeyFromObject(agent))
 	}
 
-	switch agent.Spec.Type {
-	default:
-		// Check if the deployment exists
-		deployment := &appsv1.Deployment{}
-		if err := a.kube.Get(ctx, types.NamespacedName{Namespace: agent.Namespace, Name: agent.Name}, deployment); err != nil {
-			deployedCondition.Status = metav1.ConditionUnknown
-			deployedCondition.Reason = "DeploymentNotFound"
-			deployedCondition.Message = err.Error()
-		} else {
-			replicas := int32(1)
-			if deployment.Spec.Replicas != nil {
-				replicas = *deployment.Spec.Replicas
-			}
-			if deployment.Status.AvailableReplicas > 0 {
-				deployedCondition.Status = metav1.ConditionTrue
-				deployedCondition.Reason = AgentReadyReasonDeploymentReady
-				deployedCondition.Message = "Deployment is ready"
-			} else {
-				deployedCondition.Status = metav1.ConditionFalse
-				deployedCondition.Reason = "DeploymentNotReady"
-				deployedCondition.Message = fmt.Sprintf("Deployment is not ready, %d/%d pods are ready", deployment.Status.AvailableReplicas, replicas)
-			}
+	return a.updateAgentObjectStatus(ctx, agent, err, autoscalingChanged, conditions...)
+}
+
+// setAutoscalingStatus copies the status of the agent's HorizontalPodAutoscaler
+// into the agent status, clearing it when the agent has none. It reports
+// whether the agent status changed.
+func (a *kagentReconciler) setAutoscalingStatus(ctx context.Context, agent *v1alpha2.Agent) (bool, error) {
+	var autoscaling *v1alpha2.AutoscalingStatus
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+	if err := a.kube.Get(ctx, types.NamespacedName{Namespace: agent.Namespace, Name: agent.Name}, hpa); err != nil {
+		if !apierrors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+			return false, err
+		}
+	} else {
+		autoscaling = &v1alpha2.AutoscalingStatus{
+			CurrentReplicas: hpa.Status.CurrentReplicas,
+			DesiredReplicas: hpa.Status.DesiredReplicas,
+			LastScaleTime:   hpa.Status.LastScaleTime,
+			Conditions:      hpa.Status.Conditions,
 		}
 	}
 
-	return a.updateAgentObjectStatus(ctx, agent, err, deployedCondition)
+	if equality.Semantic.DeepEqual(agent.Status.Autoscaling, autoscaling) {
+		return false, nil
+	}
+	agent.Status.Autoscaling = autoscaling
+	return true, nil
 }
 
-func (a *kagentReconciler) updateAgentObjectStatus(ctx context.Context, agent v1alpha2.AgentObject, reconcileErr error, readyCondition metav1.Condition) error {
+// updateAgentObjectStatus sets the Accepted condition and the given conditions
+// and writes the status when it changed. statusChanged reports changes the
+// caller already made to other status fields.
+func (a *kagentReconciler) updateAgentObjectStatus(ctx context.Context, agent v1alpha2.AgentObject, reconcileErr error, statusChanged bool, conditions ...metav1.Condition) error {
 	statusRef := agent.GetAgentStatus()
 	var (
 		status  metav1.ConditionStatus
@@ -323,6 +437,9 @@ func (a *kagentReconciler) updateAgentObjectStatus(ctx context.Context, agent v1
 		status = metav1.ConditionFalse
 		message = reconcileErr.Error()
 		reason = "ReconcileFailed"
+		if violation := (*imagepolicy.Violation)(nil); errors.As(reconcileErr, &violation) {
+			reason = "ImageVerificationFailed"
+		}
 	} else {
 		status = metav1.ConditionTrue
 		reason = "Reconciled"
@@ -358,10 +475,12 @@ func (a *kagentReconciler) updateAgentObjectStatus(ctx context.Context, agent v1
 		}
 	}
 
-	conditionChanged = conditionChanged || meta.SetStatusCondition(&statusRef.Conditions, readyCondition)
+	for _, condition := range conditions {
+		conditionChanged = meta.SetStatusCondition(&statusRef.Conditions, condition) || conditionChanged
+	}
 
 	// update the status if it has changed or the generation has changed
-	if conditionChanged || statusRef.ObservedGeneration != agent.GetGeneration() {
+	if statusChanged || conditionChanged || statusRef.ObservedGeneration != agent.GetGeneration() {
 		statusRef.ObservedGeneration = agent.GetGeneration()
 		if err := a.kube.Status().Update(ctx, agent); err != nil {
 			return fmt.Errorf("failed to update %s status: %w", strings.ToLower(agentKind(agent)), err)
@@ -380,13 +499,10 @@ func (a *kagentReconciler) ReconcileKagentMCPService(ctx context.Context, req ct
 				Name:      req.String(),
 				GroupKind: schema.GroupKind{Group: "", Kind: "Service"}.String(),
 			}
-			if err := a.dbClient.DeleteToolServer(ctx, dbService.Name, dbService.GroupKind); err != nil {
+			if err := a.deleteToolServer(ctx, dbService); err != nil {
 				reconcileLog.Error(err, "failed to delete tool server for mcp service", "service", req.String())
 			}
 			reconcileLog.Info("mcp service was deleted", "service", req.String())
-			if err := a.dbClient.DeleteToolsForServer(ctx, dbService.Name, dbService.GroupKind); err != nil {
-				reconcileLog.Error(err, "failed to delete tools for mcp service", "service", req.String())
-			}
 			return nil
 		}
 		return fmt.Errorf("failed to get service %s: %w", req.Name, err)
@@ -406,15 +522,64 @@ func (a *kagentReconciler) ReconcileKagentMCPService(ctx context.Context, req ct
 		return fmt.Errorf("failed to convert service %s: %w", utils.GetObjectRef(service), err)
 	}
 
-	// Upsert tool server and fetch tools
-	if _, err := a.upsertToolServerForRemoteMCPServer(ctx, dbService, remoteService); err != nil {
-		reconcileLog.Error(err, "failed to upsert tool server for service", "service", utils.GetObjectRef(service))
-		return fmt.Errorf("failed to upsert tool server for mcp service %s: %w", utils.GetObjectRef(service), err)
+	// The Service is registered only once its endpoint answers initialize and
+	// tools/list, so agents and the UI never see a tool server that is not
+	// serving. A registered Service that later fails its probe keeps the tools
+	// of its last successful probe.
+	tools, err := a.probeToolServer(ctx, dbService, remoteService)
+	if err != nil {
+		reconcileLog.Error(err, "mcp service failed its probe", "service", utils.GetObjectRef(service))
+		a.warningEvent(service, EventReasonToolServerProbeFailed, eventActionProbe, "MCP endpoint %s did not answer: %s", remoteService.Spec.URL, err.Error())
+		return &ProbeError{Err: fmt.Errorf("probe of mcp service %s failed: %w", utils.GetObjectRef(service), err)}
+	}
+
+	previous, err := a.dbClient.ListToolsForServer(ctx, dbService.Name, dbService.GroupKind)
+	if err != nil {
+		return fmt.Errorf("failed to list tools of mcp service %s: %w", utils.GetObjectRef(service), err)
+	}
+	if err := a.registerToolServer(ctx, dbService, tools); err != nil {
+		reconcileLog.Error(err, "failed to register tool server for service", "service", utils.GetObjectRef(service))
+		a.warningEvent(service, EventReasonToolDiscoveryFailed, eventActionDiscoverTools, "%s", err.Error())
+		return fmt.Errorf("failed to register tool server for mcp service %s: %w", utils.GetObjectRef(service), err)
+	}
+	if !sameToolNames(previous, tools) {
+		a.event(service, EventReasonToolsDiscovered, eventActionDiscoverTools, "Discovered %d tools", len(tools))
 	}
 
 	return nil
 }
 
+// ProbeError is returned by ReconcileKagentMCPService when the MCP endpoint of
+// a Service did not answer. The Service is probed again with backoff.
+type ProbeError struct {
+	Err error
+}
+
+func (e *ProbeError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ProbeError) Unwrap() error {
+	return e.Err
+}
+
+// sameToolNames reports whether stored and discovered name the same tools.
+func sameToolNames(stored []database.Tool, discovered []*v1alpha2.MCPTool) bool {
+	if len(stored) != len(discovered) {
+		return false
+	}
+	names := make(map[string]bool, len(stored))
+	for _, tool := range stored {
+		names[tool.ID] = true
+	}
+	for _, tool := range discovered {
+		if !names[tool.Name] {
+			return false
+		}
+	}
+	return true
+}
+
 type secretRef struct {
 	NamespacedName types.NamespacedName
 	Secret         *corev1.Secret
@@ -478,12 +643,135 @@ func (a *kagentReconciler) ReconcileKagentModelConfig(ctx context.Context, req c
 	// compute the hash for the status
 	secretHash := computeStatusSecretHash(secrets)
 
-	return a.reconcileModelConfigStatus(
+	var discovery *modelConfigDiscovery
+	switch {
+	case modelConfig.Spec.Provider == v1alpha2.ModelProviderOpenAICompatible && modelConfig.Spec.OpenAICompatible != nil:
+		discovery = a.discoverModelConfigModels(ctx, modelConfig, secrets)
+	case modelConfig.Spec.Provider == v1alpha2.ModelProviderOllama && modelConfig.Spec.Ollama != nil &&
+		(modelConfig.Spec.Ollama.PullModel || modelConfig.Spec.Ollama.WarmUp):
+		discovery = a.prepareOllamaModel(ctx, modelConfig)
+	}
+
+	fallbacksErr := a.checkModelConfigFallbacks(ctx, modelConfig)
+
+	if statusErr := a.reconcileModelConfigStatus(
 		ctx,
 		modelConfig,
 		err,
 		secretHash,
-	)
+		discovery,
+		fallbacksErr,
+	); statusErr != nil {
+		return statusErr
+	}
+
+	a.recordModelConfigEvents(modelConfig, err, discovery, fallbacksErr)
+
+	// An unreachable models endpoint is usually transient (the server is
+	// still starting or loading weights), so return the error to requeue
+	// with backoff. A missing model is reported on the condition only, and a
+	// model being pulled or loaded is polled until it is ready.
+	if discovery != nil && discovery.endpointErr != nil {
+		return fmt.Errorf("failed to discover models for model config %s: %w", utils.GetObjectRef(modelConfig), discovery.endpointErr)
+	}
+	return discovery.preparationErr(modelConfig)
+}
+
+// recordModelConfigEvents records Warning Events for the problems found while
+// reconciling a ModelConfig.
+func (a *kagentReconciler) recordModelConfigEvents(modelConfig *v1alpha2.ModelConfig, err error, discovery *modelConfigDiscovery, fallbacksErr error) {
+	// err collects every missing or invalid Secret; record each on its own.
+	var merr *multierror.Error
+	if errors.As(err, &merr) {
+		for _, e := range merr.Errors {
+			a.warningEvent(modelConfig, EventReasonModelConfigInvalid, eventActionValidate, "%s", e.Error())
+		}
+	} else if err != nil {
+		a.warningEvent(modelConfig, EventReasonModelConfigInvalid, eventActionValidate, "%s", err.Error())
+	}
+	if fallbacksErr != nil {
+		a.warningEvent(modelConfig, EventReasonModelConfigInvalid, eventActionValidate, "%s", fallbacksErr.Error())
+	}
+	if discovery == nil {
+		return
+	}
+	switch {
+	case discovery.endpointErr != nil:
+		a.warningEvent(modelConfig, EventReasonModelDiscoveryFailed, eventActionDiscoverModel, "%s", discovery.endpointErr.Error())
+	case discovery.modelErr != nil:
+		a.warningEvent(modelConfig, EventReasonModelConfigInvalid, eventActionDiscoverModel, "%s", discovery.modelErr.Error())
+	case discovery.pullErr != nil:
+		a.warningEvent(modelConfig, EventReasonModelPullFailed, eventActionPullModel, "%s", discovery.pullErr.Error())
+	case discovery.loadErr != nil:
+		a.warningEvent(modelConfig, EventReasonModelWarmUpFailed, eventActionWarmUpModel, "%s", discovery.loadErr.Error())
+	case discovery.pulled:
+		a.event(modelConfig, EventReasonModelPulled, eventActionPullModel, "Pulled model %s", modelConfig.Spec.Model)
+	}
+}
+
+// checkModelConfigFallbacks verifies that every fallback of a ModelConfig
+// exists. Agents using the ModelConfig fail to translate until they do.
+func (a *kagentReconciler) checkModelConfigFallbacks(ctx context.Context, modelConfig *v1alpha2.ModelConfig) error {
+	var errs *multierror.Error
+	for _, fallback := range modelConfig.Spec.Fallbacks {
+		if fallback.ModelConfig == modelConfig.Name {
+			errs = multierror.Append(errs, errors.New("model config lists itself as a fallback"))
+			continue
+		}
+		namespacedName := types.NamespacedName{Namespace: modelConfig.Namespace, Name: fallback.ModelConfig}
+		if kubeErr := a.kube.Get(ctx, namespacedName, &v1alpha2.ModelConfig{}); kubeErr != nil {
+			errs = multierror.Append(errs, fmt.Errorf("failed to get fallback model config %s: %w", fallback.ModelConfig, kubeErr))
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
+// modelConfigDiscovery holds the result of probing a ModelConfig's models endpoint.
+type modelConfigDiscovery struct {
+	models []string
+	// endpointErr is set when the models endpoint could not be queried.
+	endpointErr error
+	// modelErr is set when the endpoint responded but the configured model
+	// is not among the listed models.
+	modelErr error
+
+	// The remaining fields report the pull and warm-up of Ollama models.
+	// pull is the progress of the pull of the model while it runs, and
+	// pulled is set once it finished.
+	pull   *provider.OllamaProgress
+	pulled bool
+	// loading is set while the model is being loaded into memory.
+	loading bool
+	// pullErr and loadErr are set when the pull or the warm-up failed.
+	pullErr error
+	loadErr error
+}
+
+// discoverModelConfigModels lists the models served by an OpenAICompatible
+// ModelConfig's base URL and checks that spec.model is among them.
+func (a *kagentReconciler) discoverModelConfigModels(ctx context.Context, modelConfig *v1alpha2.ModelConfig, secrets []secretRef) *modelConfigDiscovery {
+	var apiKey string
+	if modelConfig.Spec.APIKeySecret != "" {
+		for _, s := range secrets {
+			if s.NamespacedName.Name == modelConfig.Spec.APIKeySecret {
+				apiKey = string(s.Secret.Data[modelConfig.Spec.APIKeySecretKey])
+				break
+			}
+		}
+	}
+
+	discoverer := provider.NewModelDiscoverer()
+	models, err := discoverer.DiscoverModels(ctx, modelConfig.Spec.Provider, modelConfig.Spec.OpenAICompatible.BaseURL, apiKey)
+	if err != nil {
+		return &modelConfigDiscovery{endpointErr: err}
+	}
+
+	result := &modelConfigDiscovery{models: models}
+	if !modelConfig.Spec.OpenAICompatible.SkipModelValidation && !slices.Contains(models, modelConfig.Spec.Model) {
+		result.modelErr = fmt.Errorf("model %q is not served by %s (available: %s)",
+			modelConfig.Spec.Model, modelConfig.Spec.OpenAICompatible.BaseURL, strings.Join(models, ", "))
+	}
+	return result
 }
 
 // computeStatusSecretHash computes a deterministic singular hash of the secrets the model config references for the status
@@ -515,7 +803,7 @@ func computeStatusSecretHash(secrets []secretRef) string {
 	return hex.EncodeToString(hash.Sum(nil))
 }
 
-func (a *kagentReconciler) reconcileModelConfigStatus(ctx context.Context, modelConfig *v1alpha2.ModelConfig, err error, secretHash string) error {
+func (a *kagentReconciler) reconcileModelConfigStatus(ctx context.Context, modelConfig *v1alpha2.ModelConfig, err error, secretHash string, discovery *modelConfigDiscovery, fallbacksErr error) error {
 	var (
 		status  metav1.ConditionStatus
 		message string
@@ -546,8 +834,72 @@ func (a *kagentReconciler) reconcileModelConfigStatus(ctx context.Context, model
 		modelConfig.Status.SecretHash = secretHash
 	}
 
+	modelsChanged := false
+	var modelPull *v1alpha2.ModelPullStatus
+	if discovery != nil {
+		reason, message := "ModelAvailable", fmt.Sprintf("Model %s is available", modelConfig.Spec.Model)
+		switch {
+		case discovery.endpointErr != nil:
+			reason, message = "DiscoveryFailed", discovery.endpointErr.Error()
+		case discovery.modelErr != nil:
+			reason, message = "ModelNotFound", discovery.modelErr.Error()
+		case discovery.pullErr != nil:
+			reason, message = "PullFailed", discovery.pullErr.Error()
+		case discovery.loadErr != nil:
+			reason, message = "WarmUpFailed", discovery.loadErr.Error()
+		case discovery.pull != nil:
+			reason, message = "ModelPulling", pullMessage(modelConfig.Spec.Model, discovery.pull)
+			modelPull = &v1alpha2.ModelPullStatus{
+				Status:         discovery.pull.Status,
+				CompletedBytes: discovery.pull.Completed,
+				TotalBytes:     discovery.pull.Total,
+			}
+		case discovery.loading:
+			reason, message = "ModelLoading", fmt.Sprintf("Loading model %s into memory", modelConfig.Spec.Model)
+		}
+		if meta.SetStatusCondition(&modelConfig.Status.Conditions, metav1.Condition{
+			Type:    v1alpha2.ModelConfigConditionTypeModelAvailable,
+			Status:  conditionStatus(reason == "ModelAvailable"),
+			Reason:  reason,
+			Message: message,
+		}) {
+			conditionChanged = true
+		}
+
+		// Keep the last known model list when the endpoint is unreachable.
+		if discovery.endpointErr == nil && !slices.Equal(modelConfig.Status.AvailableModels, discovery.models) {
+			modelConfig.Status.AvailableModels = discovery.models
+			modelsChanged = true
+		}
+	} else {
+		if meta.RemoveStatusCondition(&modelConfig.Status.Conditions, v1alpha2.ModelConfigConditionTypeModelAvailable) {
+			conditionChanged = true
+		}
+		if modelConfig.Status.AvailableModels != nil {
+			modelConfig.Status.AvailableModels = nil
+			modelsChanged = true
+		}
+	}
+	if !reflect.DeepEqual(modelConfig.Status.ModelPull, modelPull) {
+		modelConfig.Status.ModelPull = modelPull
+		modelsChanged = true
+	}
+
+	if len(modelConfig.Spec.Fallbacks) > 0 {
+		if meta.SetStatusCondition(&modelConfig.Status.Conditions, metav1.Condition{
+			Type:    v1alpha2.ModelConfigConditionTypeFallbacksResolved,
+			Status:  conditionStatus(fallbacksErr == nil),
+			Reason:  conditionReason(fallbacksErr, "FallbacksResolved", "FallbackNotFound"),
+			Message: conditionMessage(fallbacksErr, fmt.Sprintf("All %d fallbacks resolved", len(modelConfig.Spec.Fallbacks))),
+		}) {
+			conditionChanged = true
+		}
+	} else if meta.RemoveStatusCondition(&modelConfig.Status.Conditions, v1alpha2.ModelConfigConditionTypeFallbacksResolved) {
+		conditionChanged = true
+	}
+
 	// update the status if it has changed or the generation has changed
-	if conditionChanged || modelConfig.Status.ObservedGeneration != modelConfig.Generation || secretHashChanged {
+	if conditionChanged || modelConfig.Status.ObservedGeneration != modelConfig.Generation || secretHashChanged || modelsChanged {
 		modelConfig.Status.ObservedGeneration = modelConfig.Generation
 		if err := a.kube.Status().Update(ctx, modelConfig); err != nil {
 			return fmt.Errorf("failed to update model config status: %w", err)
@@ -565,13 +917,10 @@ func (a *kagentReconciler) ReconcileKagentMCPServer(ctx context.Context, req ctr
 				Name:      req.String(),
 				GroupKind: schema.GroupKind{Group: "kagent.dev", Kind: "MCPServer"}.String(),
 			}
-			if err := a.dbClient.DeleteToolServer(ctx, dbServer.Name, dbServer.GroupKind); err != nil {
+			if err := a.deleteToolServer(ctx, dbServer); err != nil {
 				reconcileLog.Error(err, "failed to delete tool server for mcp server", "mcpServer", req.String())
 			}
 			reconcileLog.Info("mcp server was deleted", "mcpServer", req.String())
-			if err := a.dbClient.DeleteToolsForServer(ctx, dbServer.Name, dbServer.GroupKind); err != nil {
-				reconcileLog.Error(err, "failed to delete tools for mcp server", "mcpServer", req.String())
-			}
 			return nil
 		}
 		return fmt.Errorf("failed to get mcp server %s: %w", req.Name, err)
@@ -594,6 +943,7 @@ func (a *kagentReconciler) ReconcileKagentMCPServer(ctx context.Context, req ctr
 	// Upsert tool server and fetch tools
 	if _, err := a.upsertToolServerForRemoteMCPServer(ctx, dbServer, remoteSpec); err != nil {
 		reconcileLog.Error(err, "failed to upsert tool server for mcp server", "mcpServer", utils.GetObjectRef(mcpServer))
+		a.warningEvent(mcpServer, EventReasonToolDiscoveryFailed, eventActionDiscoverTools, "%s", err.Error())
 		return fmt.Errorf("failed to upsert tool server for remote mcp server %s: %w", utils.GetObjectRef(mcpServer), err)
 	}
 
@@ -615,14 +965,10 @@ func (a *kagentReconciler) ReconcileKagentRemoteMCPServer(ctx context.Context, r
 				GroupKind: schema.GroupKind{Group: "kagent.dev", Kind: "RemoteMCPServer"}.String(),
 			}
 
-			if err := a.dbClient.DeleteToolServer(ctx, dbServer.Name, dbServer.GroupKind); err != nil {
+			if err := a.deleteToolServer(ctx, dbServer); err != nil {
 				l.Error(err, "failed to delete tool server for remote mcp server")
 			}
 
-			if err := a.dbClient.DeleteToolsForServer(ctx, dbServer.Name, dbServer.GroupKind); err != nil {
-				l.Error(err, "failed to delete tools for remote mcp server")
-			}
-
 			return nil
 		}
 
@@ -649,6 +995,7 @@ func (a *kagentReconciler) ReconcileKagentRemoteMCPServer(ctx context.Context, r
 	tools, err := a.upsertToolServerForRemoteMCPServer(ctx, dbServer, server)
 	if err != nil {
 		l.Error(err, "failed to upsert tool server for remote mcp server", "duration", time.Since(start))
+		a.warningEvent(server, EventReasonToolDiscoveryFailed, eventActionDiscoverTools, "%s", err.Error())
 
 		// Fetch previously discovered tools from database if possible
 		var discoveryErr error
@@ -658,6 +1005,9 @@ func (a *kagentReconciler) ReconcileKagentRemoteMCPServer(ctx context.Context, r
 		}
 	} else {
 		l.Info("successfully registered remote MCP server", "url", server.Spec.URL, "toolCount", len(tools), "duration", time.Since(start))
+		if !reflect.DeepEqual(server.Status.DiscoveredTools, tools) {
+			a.event(server, EventReasonToolsDiscovered, eventActionDiscoverTools, "Discovered %d tools", len(tools))
+		}
 	}
 	// secretErr is folded in here, not where it occurs, so a bad Secret ref
 	// doesn't skip tool discovery (and its previously-discovered-tools
@@ -667,12 +1017,21 @@ func (a *kagentReconciler) ReconcileKagentRemoteMCPServer(ctx context.Context, r
 		err = multierror.Append(err, secretErr)
 	}
 
+	// Stats are informational; failing to read them doesn't fail the
+	// reconcile, and the previous ones are kept.
+	callStats, statsErr := a.getToolCallStats(ctx, serverRef)
+	if statsErr != nil {
+		l.Error(statsErr, "failed to get tool call stats")
+		callStats = server.Status.ToolCallStats
+	}
+
 	// update the tool server status as the agents depend on it
 	if err := a.reconcileRemoteMCPServerStatus(
 		ctx,
 		server,
 		tools,
 		secretHash,
+		callStats,
 		err,
 	); err != nil {
 		return fmt.Errorf("failed to reconcile remote mcp server status %s: %w", req.NamespacedName, err)
@@ -682,29 +1041,86 @@ func (a *kagentReconciler) ReconcileKagentRemoteMCPServer(ctx context.Context, r
 }
 
 // computeRemoteMCPServerSecretHash returns a hash over the TLS Secret
-// referenced by spec.tls.caCertSecretRef, matching the shape used by
-// ModelConfig's secret hash so agents can detect cert rotation. Returns
-// the empty string (no error) when no TLS Secret is referenced. Also
-// validates that the named key exists in the Secret so the operator
-// gets a clear Accepted=false on the RMS rather than a startup crash
-// (FileNotFoundError from the Python ADK) on every consuming agent —
-// mirrors the equivalent check in ReconcileKagentModelConfig.
+// referenced by spec.tls.caCertSecretRef and the Secrets referenced by
+// spec.headersFrom, matching the shape used by ModelConfig's secret hash so
+// agents can detect cert and header credential rotation. Returns the empty
+// string (no error) when no Secret is referenced. Also validates that the
+// named TLS key exists in the Secret so the operator gets a clear
+// Accepted=false on the RMS rather than a startup crash (FileNotFoundError
+// from the Python ADK) on every consuming agent — mirrors the equivalent
+// check in ReconcileKagentModelConfig.
 func (a *kagentReconciler) computeRemoteMCPServerSecretHash(ctx context.Context, server *v1alpha2.RemoteMCPServer) (string, error) {
-	tlsSpec := server.Spec.TLS
-	if tlsSpec == nil || tlsSpec.CACertSecretRef == "" {
-		return "", nil
+	var secrets []secretRef
+	seen := map[string]bool{}
+	getSecret := func(name string) (*corev1.Secret, error) {
+		secret := &corev1.Secret{}
+		nn := types.NamespacedName{Namespace: server.Namespace, Name: name}
+		if err := a.kube.Get(ctx, nn, secret); err != nil {
+			return nil, err
+		}
+		if !seen[name] {
+			seen[name] = true
+			secrets = append(secrets, secretRef{NamespacedName: nn, Secret: secret})
+		}
+		return secret, nil
 	}
-	secret := &corev1.Secret{}
-	nn := types.NamespacedName{Namespace: server.Namespace, Name: tlsSpec.CACertSecretRef}
-	if err := a.kube.Get(ctx, nn, secret); err != nil {
-		return "", fmt.Errorf("failed to get TLS secret %s: %w", tlsSpec.CACertSecretRef, err)
+
+	if tlsSpec := server.Spec.TLS; tlsSpec != nil && tlsSpec.CACertSecretRef != "" {
+		secret, err := getSecret(tlsSpec.CACertSecretRef)
+		if err != nil {
+			return "", fmt.Errorf("failed to get TLS secret %s: %w", tlsSpec.CACertSecretRef, err)
+		}
+		if tlsSpec.CACertSecretKey != "" {
+			if _, ok := secret.Data[tlsSpec.CACertSecretKey]; !ok {
+				return "", fmt.Errorf("tls secret %s does not contain key %q", tlsSpec.CACertSecretRef, tlsSpec.CACertSecretKey)
+			}
+		}
 	}
-	if tlsSpec.CACertSecretKey != "" {
-		if _, ok := secret.Data[tlsSpec.CACertSecretKey]; !ok {
-			return "", fmt.Errorf("tls secret %s does not contain key %q", tlsSpec.CACertSecretRef, tlsSpec.CACertSecretKey)
+
+	// Header values are resolved into the agent config at translate time,
+	// so a rotated header Secret only reaches running agents via a rollout.
+	for _, header := range server.Spec.HeadersFrom {
+		if header.ValueFrom == nil || header.ValueFrom.Type != v1alpha2.SecretValueSource || seen[header.ValueFrom.Name] {
+			continue
+		}
+		if _, err := getSecret(header.ValueFrom.Name); err != nil {
+			return "", fmt.Errorf("failed to get header secret %s: %w", header.ValueFrom.Name, err)
+		}
+	}
+
+	// The OAuth2 client secret is resolved into the agent config the same way.
+	if auth := server.Spec.Auth; auth != nil && auth.OAuth2ClientCredentials != nil {
+		from := auth.OAuth2ClientCredentials.ClientSecretFrom
+		if from.Type == v1alpha2.SecretValueSource && !seen[from.Name] {
+			if _, err := getSecret(from.Name); err != nil {
+				return "", fmt.Errorf("failed to get OAuth2 client secret %s: %w", from.Name, err)
+			}
 		}
 	}
-	return computeStatusSecretHash([]secretRef{{NamespacedName: nn, Secret: secret}}), nil
+
+	if len(secrets) == 0 {
+		return "", nil
+	}
+	return computeStatusSecretHash(secrets), nil
+}
+
+// getToolCallStats summarizes the calls agents reported making to the tool
+// server since the start of the previous hour, or returns nil when there are
+// none.
+func (a *kagentReconciler) getToolCallStats(ctx context.Context, serverRef string) (*v1alpha2.ToolCallStats, error) {
+	rows, err := a.dbClient.ListToolCallStats(ctx, serverRef, time.Now().Add(-time.Hour))
+	if err != nil {
+		return nil, err
+	}
+	summary := toolstats.Summarize(rows)
+	if summary.CallCount == 0 {
+		return nil, nil
+	}
+	return &v1alpha2.ToolCallStats{
+		Calls:        summary.CallCount,
+		Errors:       summary.ErrorCount,
+		P95LatencyMs: summary.P95LatencyMs,
+	}, nil
 }
 
 func (a *kagentReconciler) reconcileRemoteMCPServerStatus(
@@ -712,6 +1128,7 @@ func (a *kagentReconciler) reconcileRemoteMCPServerStatus(
 	server *v1alpha2.RemoteMCPServer,
 	discoveredTools []*v1alpha2.MCPTool,
 	secretHash string,
+	callStats *v1alpha2.ToolCallStats,
 	err error,
 ) error {
 	var (
@@ -740,13 +1157,15 @@ func (a *kagentReconciler) reconcileRemoteMCPServerStatus(
 	if !conditionChanged &&
 		server.Status.ObservedGeneration == server.Generation &&
 		server.Status.SecretHash == secretHash &&
-		reflect.DeepEqual(server.Status.DiscoveredTools, discoveredTools) {
+		reflect.DeepEqual(server.Status.DiscoveredTools, discoveredTools) &&
+		reflect.DeepEqual(server.Status.ToolCallStats, callStats) {
 		return nil
 	}
 
 	server.Status.ObservedGeneration = server.Generation
 	server.Status.DiscoveredTools = discoveredTools
 	server.Status.SecretHash = secretHash
+	server.Status.ToolCallStats = callStats
 
 	if err := a.kube.Status().Update(ctx, server); err != nil {
 		return fmt.Errorf("failed to update remote mcp server status: %w", err)
@@ -936,7 +1355,7 @@ func (r *kagentReconciler) GetOwnedResourceTypes() []client.Object {
 }
 
 // Function initially copied from https://github.com/open-telemetry/opentelemetry-operator/blob/e6d96f006f05cff0bc3808da1af69b6b636fbe88/internal/controllers/common.go#L141-L192
-func (a *kagentReconciler) reconcileDesiredObjects(ctx context.Context, owner metav1.Object, desiredObjects []client.Object, ownedObjects map[types.UID]client.Object) error {
+func (a *kagentReconciler) reconcileDesiredObjects(ctx context.Context, owner client.Object, desiredObjects []client.Object, ownedObjects map[types.UID]client.Object) error {
 	var errs []error
 	actorTemplatePending := false
 	for _, desired := range desiredObjects {
@@ -968,14 +1387,25 @@ func (a *kagentReconciler) reconcileDesiredObjects(ctx context.Context, owner me
 		existing := desired.DeepCopyObject().(client.Object)
 		mutateFn := translator.MutateFuncFor(existing, desired)
 
+		var result controllerutil.OperationResult
 		if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
-			_, createOrUpdateErr := createOrUpdate(ctx, a.kube, existing, mutateFn)
+			var createOrUpdateErr error
+			result, createOrUpdateErr = createOrUpdate(ctx, a.kube, existing, mutateFn)
 			return createOrUpdateErr
 		}); err != nil {
 			l.Error(err, "failed to configure desired")
+			a.warningEvent(owner, EventReasonResourceApplyFailed, eventActionApply, "Failed to apply %s %s: %s", objectKind(desired), desired.GetName(), err.Error())
+			debugcapture.Record(ctx, "apply", "failed to apply "+debugObjectRef(desired), map[string]any{"error": err.Error()})
 			errs = append(errs, err)
 			continue
 		}
+		debugcapture.Record(ctx, "apply", fmt.Sprintf("%s %s", debugObjectRef(desired), result), nil)
+		switch result {
+		case controllerutil.OperationResultCreated:
+			a.event(owner, EventReasonResourceCreated, eventActionApply, "Created %s %s", objectKind(desired), desired.GetName())
+		case controllerutil.OperationResultUpdated:
+			a.event(owner, EventReasonResourceUpdated, eventActionApply, "Updated %s %s", objectKind(desired), desired.GetName())
+		}
 
 		// This object is still managed by the controller, remove it from the list of objects to prune
 		delete(ownedObjects, existing.GetUID())
@@ -1079,8 +1509,11 @@ func (a *kagentReconciler) deleteObjects(ctx context.Context, objects map[types.
 		err := a.kube.Delete(ctx, obj)
 		if err != nil {
 			l.Error(err, "failed to delete resource")
+			debugcapture.Record(ctx, "prune", "failed to prune "+debugObjectRef(obj), map[string]any{"error": err.Error()})
 			pruneErrs = append(pruneErrs, err)
+			continue
 		}
+		debugcapture.Record(ctx, "prune", "pruned "+debugObjectRef(obj), nil)
 	}
 
 	return errors.Join(pruneErrs...)
@@ -1102,10 +1535,27 @@ func (a *kagentReconciler) upsertAgent(ctx context.Context, agent v1alpha2.Agent
 	if err := a.dbClient.StoreAgent(ctx, dbAgent); err != nil {
 		return fmt.Errorf("failed to store agent %s: %w", id, err)
 	}
+	debugcapture.Record(ctx, "database", "stored agent "+id, map[string]any{"type": dbType, "workloadType": dbAgent.WorkloadType})
 
 	return nil
 }
 
+// debugObjectRef names obj for debug capture entries. Typed objects built by
+// the translator do not always carry their GVK, so fall back to the Go type.
+func debugObjectRef(obj client.Object) string {
+	return fmt.Sprintf("%s %s/%s", objectKind(obj), obj.GetNamespace(), obj.GetName())
+}
+
+// objectKind returns the kind of obj, falling back to its Go type name when
+// the TypeMeta is not set.
+func objectKind(obj client.Object) string {
+	if kind := obj.GetObjectKind().GroupVersionKind().Kind; kind != "" {
+		return kind
+	}
+	t := fmt.Sprintf("%T", obj)
+	return t[strings.LastIndex(t, ".")+1:]
+}
+
 func agentKind(agent v1alpha2.AgentObject) string {
 	if agent.GetWorkloadMode() == v1alpha2.WorkloadModeSandbox {
 		return "SandboxAgent"
@@ -1114,10 +1564,61 @@ func agentKind(agent v1alpha2.AgentObject) string {
 }
 
 func (a *kagentReconciler) upsertToolServerForRemoteMCPServer(ctx context.Context, toolServer *database.ToolServer, remoteMcpServer *v1alpha2.RemoteMCPServer) ([]*v1alpha2.MCPTool, error) {
-	if _, err := a.dbClient.StoreToolServer(ctx, toolServer); err != nil {
+	if err := a.dbClient.WithLock(ctx, toolServerLockKey(toolServer), func(tx database.Client) error {
+		_, err := tx.StoreToolServer(ctx, toolServer)
+		return err
+	}); err != nil {
 		return nil, fmt.Errorf("failed to store toolServer %s: %w", toolServer.Name, err)
 	}
 
+	tools, err := a.probeToolServer(ctx, toolServer, remoteMcpServer)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.registerToolServer(ctx, toolServer, tools); err != nil {
+		return nil, err
+	}
+	return tools, nil
+}
+
+// toolServerLockKey names the database lock held while writing toolServer
+// and its tools, so that controller replicas reconciling it at once, e.g.
+// while leadership fails over, cannot interleave their writes.
+func toolServerLockKey(toolServer *database.ToolServer) string {
+	return "kagent/toolserver/" + toolServer.GroupKind + "/" + toolServer.Name
+}
+
+// registerToolServer stores toolServer with the tools it serves, in one
+// transaction.
+func (a *kagentReconciler) registerToolServer(ctx context.Context, toolServer *database.ToolServer, tools []*v1alpha2.MCPTool) error {
+	return a.dbClient.WithLock(ctx, toolServerLockKey(toolServer), func(tx database.Client) error {
+		if _, err := tx.StoreToolServer(ctx, toolServer); err != nil {
+			return fmt.Errorf("failed to store toolServer %s: %w", toolServer.Name, err)
+		}
+		if err := tx.RefreshToolsForServer(ctx, toolServer.Name, toolServer.GroupKind, tools...); err != nil {
+			return fmt.Errorf("failed to refresh tools for toolServer %s: %w", toolServer.Name, err)
+		}
+		return nil
+	})
+}
+
+// deleteToolServer deletes toolServer and its tools, in one transaction.
+func (a *kagentReconciler) deleteToolServer(ctx context.Context, toolServer *database.ToolServer) error {
+	return a.dbClient.WithLock(ctx, toolServerLockKey(toolServer), func(tx database.Client) error {
+		if err := tx.DeleteToolServer(ctx, toolServer.Name, toolServer.GroupKind); err != nil {
+			return fmt.Errorf("failed to delete toolServer %s: %w", toolServer.Name, err)
+		}
+		if err := tx.DeleteToolsForServer(ctx, toolServer.Name, toolServer.GroupKind); err != nil {
+			return fmt.Errorf("failed to delete tools for toolServer %s: %w", toolServer.Name, err)
+		}
+		return nil
+	})
+}
+
+// probeToolServer connects to the MCP endpoint of remoteMcpServer and returns
+// the tools it lists.
+func (a *kagentReconciler) probeToolServer(ctx context.Context, toolServer *database.ToolServer, remoteMcpServer *v1alpha2.RemoteMCPServer) ([]*v1alpha2.MCPTool, error) {
 	// Bound the entire registration sequence (header resolution + MCP connect +
 	// tool listing) to the effective per-resource timeout so that a hung or
 	// unreachable endpoint cannot block this goroutine — and therefore all
@@ -1134,12 +1635,6 @@ func (a *kagentReconciler) upsertToolServerForRemoteMCPServer(ctx context.Contex
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch tools for toolServer %s: %w", toolServer.Name, err)
 	}
-
-	// Refresh tools in database - uses transaction for atomicity
-	if err := a.dbClient.RefreshToolsForServer(ctx, toolServer.Name, toolServer.GroupKind, tools...); err != nil {
-		return nil, fmt.Errorf("failed to refresh tools for toolServer %s: %w", toolServer.Name, err)
-	}
-
 	return tools, nil
 }
 
@@ -1174,7 +1669,16 @@ func (a *kagentReconciler) createMcpTransport(ctx context.Context, s *v1alpha2.R
 		return nil, fmt.Errorf("failed to build TLS config for %s/%s: %w", s.Namespace, s.Name, err)
 	}
 
-	httpClient := newHTTPClient(headers, remoteMCPRegistrationTimeout(s), tlsConfig)
+	auth, err := mcpauth.Resolve(ctx, a.kube, s)
+	if err != nil {
+		return nil, err
+	}
+	var tokens oauth2.TokenSource
+	if auth != nil {
+		tokens = a.mcpTokens.Get(s.Namespace+"/"+s.Name, auth)
+	}
+
+	httpClient := newHTTPClient(headers, remoteMCPRegistrationTimeout(s), tlsConfig, tokens)
 
 	switch s.Spec.Protocol {
 	case v1alpha2.RemoteMCPServerProtocolSse:
@@ -1249,6 +1753,7 @@ func newHTTPClient(
 	headers map[string]string,
 	timeout time.Duration,
 	tlsConfig *tls.Config,
+	tokens oauth2.TokenSource,
 ) *http.Client {
 	var base = http.DefaultTransport
 	if tlsConfig != nil {
@@ -1260,6 +1765,11 @@ func newHTTPClient(
 		clone.TLSClientConfig = tlsConfig
 		base = clone
 	}
+	if tokens != nil {
+		// Below the header transport, so the bearer token replaces any
+		// Authorization header from spec.headersFrom.
+		base = &oauth2.Transport{Source: tokens, Base: base}
+	}
 
 	if len(headers) == 0 {
 		return &http.Client{
@@ -8,17 +8,22 @@ import (
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/pem"
+	"errors"
 	"math/big"
 	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	"github.com/kagent-dev/kagent/go/core/internal/controller/provider"
 	"github.com/kagent-dev/kagent/go/core/internal/utils"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -293,6 +298,339 @@ func TestReconcileAgentStatus_AvailableReplicas(t *testing.T) {
 	}
 }
 
+func TestReconcileAgentStatus_Autoscaling(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1alpha2.AddToScheme(scheme))
+
+	agent := &v1alpha2.Agent{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-agent", Namespace: "default"},
+		Status: v1alpha2.AgentStatus{
+			Autoscaling: &v1alpha2.AutoscalingStatus{CurrentReplicas: 1, DesiredReplicas: 1},
+		},
+	}
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: agent.Name, Namespace: agent.Namespace},
+		Status:     appsv1.DeploymentStatus{AvailableReplicas: 3},
+	}
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{Name: agent.Name, Namespace: agent.Namespace},
+		Status: autoscalingv2.HorizontalPodAutoscalerStatus{
+			CurrentReplicas: 3,
+			DesiredReplicas: 4,
+			Conditions: []autoscalingv2.HorizontalPodAutoscalerCondition{
+				{Type: autoscalingv2.ScalingActive, Status: corev1.ConditionTrue, Reason: "ValidMetricFound"},
+			},
+		},
+	}
+	kube := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(agent).
+		WithObjects(agent, deployment, hpa).
+		Build()
+	reconciler := &kagentReconciler{kube: kube}
+
+	require.NoError(t, reconciler.reconcileAgentStatus(context.Background(), agent, nil))
+	updated := &v1alpha2.Agent{}
+	require.NoError(t, kube.Get(context.Background(), client.ObjectKeyFromObject(agent), updated))
+	require.NotNil(t, updated.Status.Autoscaling)
+	assert.Equal(t, int32(3), updated.Status.Autoscaling.CurrentReplicas)
+	assert.Equal(t, int32(4), updated.Status.Autoscaling.DesiredReplicas)
+	require.Len(t, updated.Status.Autoscaling.Conditions, 1)
+	assert.Equal(t, autoscalingv2.ScalingActive, updated.Status.Autoscaling.Conditions[0].Type)
+
+	// Removing the autoscaler clears the status.
+	require.NoError(t, kube.Delete(context.Background(), hpa))
+	require.NoError(t, reconciler.reconcileAgentStatus(context.Background(), updated, nil))
+	require.NoError(t, kube.Get(context.Background(), client.ObjectKeyFromObject(agent), updated))
+	assert.Nil(t, updated.Status.Autoscaling)
+}
+
+func TestReconcileKagentModelConfig_OpenAICompatibleDiscovery(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/models" {
+			http.NotFound(w, r)
+			return
+		}
+		if got := r.Header.Get("Authorization"); got != "" && got != "Bearer local-key" {
+			t.Errorf("unexpected Authorization header: %s", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":[{"id":"llama-3.1-8b"},{"id":"qwen2.5-7b"}]}`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name          string
+		model         string
+		baseURL       string
+		apiKeySecret  string
+		skipValidate  bool
+		wantErr       bool
+		wantStatus    metav1.ConditionStatus
+		wantReason    string
+		wantAvailable []string
+	}{
+		{
+			name:          "model served",
+			model:         "qwen2.5-7b",
+			baseURL:       server.URL + "/v1",
+			wantStatus:    metav1.ConditionTrue,
+			wantReason:    "ModelAvailable",
+			wantAvailable: []string{"llama-3.1-8b", "qwen2.5-7b"},
+		},
+		{
+			name:          "model served with api key",
+			model:         "llama-3.1-8b",
+			baseURL:       server.URL,
+			apiKeySecret:  "local-key",
+			wantStatus:    metav1.ConditionTrue,
+			wantReason:    "ModelAvailable",
+			wantAvailable: []string{"llama-3.1-8b", "qwen2.5-7b"},
+		},
+		{
+			name:          "model not served",
+			model:         "mistral-7b",
+			baseURL:       server.URL,
+			wantStatus:    metav1.ConditionFalse,
+			wantReason:    "ModelNotFound",
+			wantAvailable: []string{"llama-3.1-8b", "qwen2.5-7b"},
+		},
+		{
+			name:          "model not served but validation skipped",
+			model:         "mistral-7b",
+			baseURL:       server.URL,
+			skipValidate:  true,
+			wantStatus:    metav1.ConditionTrue,
+			wantReason:    "ModelAvailable",
+			wantAvailable: []string{"llama-3.1-8b", "qwen2.5-7b"},
+		},
+		{
+			name:       "endpoint unreachable",
+			model:      "qwen2.5-7b",
+			baseURL:    server.URL + "/missing",
+			wantErr:    true,
+			wantStatus: metav1.ConditionFalse,
+			wantReason: "DiscoveryFailed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			require.NoError(t, clientgoscheme.AddToScheme(scheme))
+			require.NoError(t, v1alpha2.AddToScheme(scheme))
+
+			modelConfig := &v1alpha2.ModelConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "local", Namespace: "default"},
+				Spec: v1alpha2.ModelConfigSpec{
+					Model:    tt.model,
+					Provider: v1alpha2.ModelProviderOpenAICompatible,
+					OpenAICompatible: &v1alpha2.OpenAICompatibleConfig{
+						BaseURL:             tt.baseURL,
+						SkipModelValidation: tt.skipValidate,
+					},
+				},
+			}
+			objs := []client.Object{modelConfig}
+			if tt.apiKeySecret != "" {
+				modelConfig.Spec.APIKeySecret = "local-key"
+				modelConfig.Spec.APIKeySecretKey = "key"
+				objs = append(objs, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "local-key", Namespace: "default"},
+					Data:       map[string][]byte{"key": []byte(tt.apiKeySecret)},
+				})
+			}
+
+			kube := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithStatusSubresource(modelConfig).
+				WithObjects(objs...).
+				Build()
+			r := &kagentReconciler{kube: kube}
+
+			err := r.ReconcileKagentModelConfig(context.Background(), reconcile.Request{NamespacedName: client.ObjectKeyFromObject(modelConfig)})
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			updated := &v1alpha2.ModelConfig{}
+			require.NoError(t, kube.Get(context.Background(), client.ObjectKeyFromObject(modelConfig), updated))
+			cond := meta.FindStatusCondition(updated.Status.Conditions, v1alpha2.ModelConfigConditionTypeModelAvailable)
+			require.NotNil(t, cond)
+			assert.Equal(t, tt.wantStatus, cond.Status)
+			assert.Equal(t, tt.wantReason, cond.Reason)
+			assert.Equal(t, tt.wantAvailable, updated.Status.AvailableModels)
+		})
+	}
+}
+
+func TestReconcileKagentModelConfig_OllamaPull(t *testing.T) {
+	var pulled atomic.Bool
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/tags":
+			w.Header().Set("Content-Type", "application/json")
+			if pulled.Load() {
+				_, _ = w.Write([]byte(`{"models":[{"name":"llama3.2:latest"}]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"models":[]}`))
+		case "/api/pull":
+			w.Header().Set("Content-Type", "application/x-ndjson")
+			_, _ = w.Write([]byte(`{"status":"pulling abc","completed":25,"total":100}` + "\n"))
+			w.(http.Flusher).Flush()
+			<-release
+			pulled.Store(true)
+			_, _ = w.Write([]byte(`{"status":"success"}` + "\n"))
+		case "/api/generate":
+			w.Header().Set("Content-Type", "application/x-ndjson")
+			_, _ = w.Write([]byte(`{"model":"llama3.2","done":true,"done_reason":"load"}` + "\n"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1alpha2.AddToScheme(scheme))
+
+	modelConfig := &v1alpha2.ModelConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "local", Namespace: "default"},
+		Spec: v1alpha2.ModelConfigSpec{
+			Model:    "llama3.2",
+			Provider: v1alpha2.ModelProviderOllama,
+			Ollama:   &v1alpha2.OllamaConfig{Host: server.URL, WarmUp: true},
+		},
+	}
+	kube := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(modelConfig).
+		WithObjects(modelConfig).
+		Build()
+	r := &kagentReconciler{kube: kube, ollamaPuller: provider.NewOllamaPuller()}
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(modelConfig)}
+
+	reconcileModelConfig := func() (*v1alpha2.ModelConfig, *metav1.Condition, error) {
+		err := r.ReconcileKagentModelConfig(context.Background(), req)
+		updated := &v1alpha2.ModelConfig{}
+		require.NoError(t, kube.Get(context.Background(), req.NamespacedName, updated))
+		cond := meta.FindStatusCondition(updated.Status.Conditions, v1alpha2.ModelConfigConditionTypeModelAvailable)
+		require.NotNil(t, cond)
+		return updated, cond, err
+	}
+
+	// Without pullModel, a missing model is only reported.
+	_, cond, err := reconcileModelConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "ModelNotFound", cond.Reason)
+	assert.Contains(t, cond.Message, "spec.ollama.pullModel")
+
+	modelConfig = &v1alpha2.ModelConfig{}
+	require.NoError(t, kube.Get(context.Background(), req.NamespacedName, modelConfig))
+	modelConfig.Spec.Ollama.PullModel = true
+	require.NoError(t, kube.Update(context.Background(), modelConfig))
+
+	// The pull runs in the background and its progress is reported.
+	var pendingErr *ModelPendingError
+	require.Eventually(t, func() bool {
+		updated, cond, err := reconcileModelConfig()
+		return errors.As(err, &pendingErr) && cond.Reason == "ModelPulling" &&
+			updated.Status.ModelPull != nil && updated.Status.ModelPull.CompletedBytes == 25
+	}, 5*time.Second, 10*time.Millisecond)
+	_, cond, _ = reconcileModelConfig()
+	assert.Equal(t, metav1.ConditionFalse, cond.Status)
+	assert.Contains(t, cond.Message, "(25%)")
+
+	// Once pulled, the model is warmed up and becomes available.
+	close(release)
+	var updated *v1alpha2.ModelConfig
+	require.Eventually(t, func() bool {
+		updated, cond, err = reconcileModelConfig()
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, metav1.ConditionTrue, cond.Status)
+	assert.Equal(t, "ModelAvailable", cond.Reason)
+	assert.Nil(t, updated.Status.ModelPull)
+	assert.Equal(t, []string{"llama3.2:latest"}, updated.Status.AvailableModels)
+}
+
+func TestReconcileKagentModelConfig_Fallbacks(t *testing.T) {
+	tests := []struct {
+		name       string
+		fallbacks  []string
+		wantStatus metav1.ConditionStatus
+		wantReason string
+	}{
+		{name: "no fallbacks"},
+		{
+			name:       "fallbacks exist",
+			fallbacks:  []string{"backup"},
+			wantStatus: metav1.ConditionTrue,
+			wantReason: "FallbacksResolved",
+		},
+		{
+			name:       "missing fallback",
+			fallbacks:  []string{"backup", "missing"},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: "FallbackNotFound",
+		},
+		{
+			name:       "self reference",
+			fallbacks:  []string{"primary"},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: "FallbackNotFound",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			require.NoError(t, clientgoscheme.AddToScheme(scheme))
+			require.NoError(t, v1alpha2.AddToScheme(scheme))
+
+			primary := &v1alpha2.ModelConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "primary", Namespace: "default"},
+				Spec:       v1alpha2.ModelConfigSpec{Model: "gpt-4o", Provider: v1alpha2.ModelProviderOpenAI},
+			}
+			for _, name := range tt.fallbacks {
+				primary.Spec.Fallbacks = append(primary.Spec.Fallbacks, v1alpha2.ModelFallback{ModelConfig: name})
+			}
+			backup := &v1alpha2.ModelConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "default"},
+				Spec:       v1alpha2.ModelConfigSpec{Model: "claude-sonnet-4", Provider: v1alpha2.ModelProviderAnthropic},
+			}
+
+			kube := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithStatusSubresource(primary).
+				WithObjects(primary, backup).
+				Build()
+			r := &kagentReconciler{kube: kube}
+
+			require.NoError(t, r.ReconcileKagentModelConfig(context.Background(), reconcile.Request{NamespacedName: client.ObjectKeyFromObject(primary)}))
+
+			updated := &v1alpha2.ModelConfig{}
+			require.NoError(t, kube.Get(context.Background(), client.ObjectKeyFromObject(primary), updated))
+			cond := meta.FindStatusCondition(updated.Status.Conditions, v1alpha2.ModelConfigConditionTypeFallbacksResolved)
+			if tt.wantReason == "" {
+				assert.Nil(t, cond)
+				return
+			}
+			require.NotNil(t, cond)
+			assert.Equal(t, tt.wantStatus, cond.Status)
+			assert.Equal(t, tt.wantReason, cond.Reason)
+			accepted := meta.FindStatusCondition(updated.Status.Conditions, v1alpha2.ModelConfigConditionTypeAccepted)
+			require.NotNil(t, accepted)
+			assert.Equal(t, metav1.ConditionTrue, accepted.Status, "fallback problems do not affect the primary model")
+		})
+	}
+}
+
 func TestValidateCrossNamespaceReferences(t *testing.T) {
 	scheme := runtime.NewScheme()
 	require.NoError(t, clientgoscheme.AddToScheme(scheme))
@@ -833,6 +1171,79 @@ func TestComputeRemoteMCPServerSecretHash(t *testing.T) {
 		require.NoError(t, err)
 		assert.NotEqual(t, h1, h2, "rotating the Secret content must change the hash")
 	})
+
+	t.Run("header secret rotation → different hex", func(t *testing.T) {
+		rmsWithHeaders := &v1alpha2.RemoteMCPServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "x", Namespace: "ns"},
+			Spec: v1alpha2.RemoteMCPServerSpec{
+				URL: "https://x/y",
+				HeadersFrom: []v1alpha2.ValueRef{{
+					Name:      "Authorization",
+					ValueFrom: &v1alpha2.ValueSource{Type: v1alpha2.SecretValueSource, Name: "token", Key: "value"},
+				}},
+			},
+		}
+		token := func(value string) *corev1.Secret {
+			return &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "token", Namespace: "ns"},
+				Data:       map[string][]byte{"value": []byte(value)},
+			}
+		}
+
+		_, err := (&kagentReconciler{kube: fake.NewClientBuilder().WithScheme(scheme).Build()}).
+			computeRemoteMCPServerSecretHash(context.Background(), rmsWithHeaders)
+		require.Error(t, err)
+
+		h1, err := (&kagentReconciler{kube: fake.NewClientBuilder().WithScheme(scheme).WithObjects(token("v1")).Build()}).
+			computeRemoteMCPServerSecretHash(context.Background(), rmsWithHeaders)
+		require.NoError(t, err)
+		require.NotEmpty(t, h1)
+
+		h2, err := (&kagentReconciler{kube: fake.NewClientBuilder().WithScheme(scheme).WithObjects(token("v2")).Build()}).
+			computeRemoteMCPServerSecretHash(context.Background(), rmsWithHeaders)
+		require.NoError(t, err)
+		assert.NotEqual(t, h1, h2, "rotating a header Secret must change the hash")
+	})
+}
+
+func TestRecordAgentSecretHash(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	status := &v1alpha2.AgentStatus{}
+
+	assert.False(t, recordAgentSecretHash(status, nil, now), "no referenced secrets leaves the status untouched")
+
+	require.True(t, recordAgentSecretHash(status, []byte("v1"), now))
+	assert.NotEmpty(t, status.SecretHash)
+	assert.Nil(t, status.SecretsRotatedAt, "first observed hash is not a rotation")
+
+	assert.False(t, recordAgentSecretHash(status, []byte("v1"), now.Add(time.Minute)))
+	assert.Nil(t, status.SecretsRotatedAt)
+
+	first := status.SecretHash
+	require.True(t, recordAgentSecretHash(status, []byte("v2"), now.Add(time.Hour)))
+	assert.NotEqual(t, first, status.SecretHash)
+	require.NotNil(t, status.SecretsRotatedAt)
+	assert.True(t, status.SecretsRotatedAt.Time.Equal(now.Add(time.Hour)))
+
+	require.True(t, recordAgentSecretHash(status, nil, now.Add(2*time.Hour)))
+	assert.Empty(t, status.SecretHash)
+	assert.True(t, status.SecretsRotatedAt.Time.Equal(now.Add(time.Hour)), "dropping secret references is not a rotation")
+}
+
+func TestRecordAgentSkills(t *testing.T) {
+	status := &v1alpha2.AgentStatus{}
+	assert.False(t, recordAgentSkills(status, nil, nil), "no skills leaves the status untouched")
+
+	skills := &v1alpha2.SkillForAgent{Refs: []string{"ghcr.io/org/kubernetes:v1", "ghcr.io/org/helm:v1"}}
+	require.True(t, recordAgentSkills(status, skills, map[string]string{"ghcr.io/org/kubernetes:v1": "sha256:abc"}))
+	assert.Equal(t, []v1alpha2.SkillStatus{
+		{Ref: "ghcr.io/org/kubernetes:v1", Digest: "sha256:abc"},
+		{Ref: "ghcr.io/org/helm:v1"},
+	}, status.Skills)
+	assert.False(t, recordAgentSkills(status, skills, map[string]string{"ghcr.io/org/kubernetes:v1": "sha256:abc"}))
+
+	require.True(t, recordAgentSkills(status, &v1alpha2.SkillForAgent{}, nil))
+	assert.Nil(t, status.Skills)
 }
 
 // TestRemoteMCPRegistrationTimeout verifies that remoteMCPRegistrationTimeout
@@ -878,17 +1289,17 @@ func TestNewHTTPClient(t *testing.T) {
 	timeout := 5 * time.Second
 
 	t.Run("no headers", func(t *testing.T) {
-		c := newHTTPClient(nil, timeout, nil)
+		c := newHTTPClient(nil, timeout, nil, nil)
 		assert.Equal(t, timeout, c.Timeout)
 	})
 
 	t.Run("empty headers", func(t *testing.T) {
-		c := newHTTPClient(map[string]string{}, timeout, nil)
+		c := newHTTPClient(map[string]string{}, timeout, nil, nil)
 		assert.Equal(t, timeout, c.Timeout)
 	})
 
 	t.Run("with headers sets timeout and custom transport", func(t *testing.T) {
-		c := newHTTPClient(map[string]string{"X-Key": "val"}, timeout, nil)
+		c := newHTTPClient(map[string]string{"X-Key": "val"}, timeout, nil, nil)
 		assert.Equal(t, timeout, c.Timeout)
 		_, ok := c.Transport.(*headerTransport)
 		assert.True(t, ok, "expected headerTransport")
@@ -896,7 +1307,7 @@ func TestNewHTTPClient(t *testing.T) {
 
 	t.Run("with tls config installs cloned transport", func(t *testing.T) {
 		tlsCfg := &tls.Config{InsecureSkipVerify: true} //nolint:gosec // test only
-		c := newHTTPClient(nil, timeout, tlsCfg)
+		c := newHTTPClient(nil, timeout, tlsCfg, nil)
 		require.Equal(t, timeout, c.Timeout)
 		// No headers → transport is the cloned *http.Transport directly.
 		tr, ok := c.Transport.(*http.Transport)
@@ -906,7 +1317,7 @@ func TestNewHTTPClient(t *testing.T) {
 
 	t.Run("with headers and tls config wraps headerTransport over cloned transport", func(t *testing.T) {
 		tlsCfg := &tls.Config{InsecureSkipVerify: true} //nolint:gosec // test only
-		c := newHTTPClient(map[string]string{"X-Key": "val"}, timeout, tlsCfg)
+		c := newHTTPClient(map[string]string{"X-Key": "val"}, timeout, tlsCfg, nil)
 		ht, ok := c.Transport.(*headerTransport)
 		require.True(t, ok, "expected headerTransport")
 		tr, ok := ht.base.(*http.Transport)
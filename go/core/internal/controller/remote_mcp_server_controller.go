@@ -131,5 +131,20 @@ func remoteMCPServerReferencesSecret(server *v1alpha2.RemoteMCPServer, secretObj
 		return true
 	}
 
+	// check if secret is referenced as a header value
+	for _, header := range server.Spec.HeadersFrom {
+		if header.ValueFrom != nil && header.ValueFrom.Type == v1alpha2.SecretValueSource && header.ValueFrom.Name == secretObj.Name {
+			return true
+		}
+	}
+
+	// check if secret holds the OAuth2 client secret
+	if auth := server.Spec.Auth; auth != nil && auth.OAuth2ClientCredentials != nil {
+		from := auth.OAuth2ClientCredentials.ClientSecretFrom
+		if from.Type == v1alpha2.SecretValueSource && from.Name == secretObj.Name {
+			return true
+		}
+	}
+
 	return false
 }
@@ -0,0 +1,85 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestRemoteMCPServerReferencesSecret(t *testing.T) {
+	server := func(namespace string, spec v1alpha2.RemoteMCPServerSpec) *v1alpha2.RemoteMCPServer {
+		return &v1alpha2.RemoteMCPServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "tools", Namespace: namespace},
+			Spec:       spec,
+		}
+	}
+	headerFrom := func(sourceType v1alpha2.ValueSourceType, name string) []v1alpha2.ValueRef {
+		return []v1alpha2.ValueRef{{
+			Name:      "Authorization",
+			ValueFrom: &v1alpha2.ValueSource{Type: sourceType, Name: name, Key: "token"},
+		}}
+	}
+
+	tests := []struct {
+		name      string
+		server    *v1alpha2.RemoteMCPServer
+		secretObj types.NamespacedName
+		want      bool
+	}{
+		{
+			name: "matching TLS secret",
+			server: server("kagent", v1alpha2.RemoteMCPServerSpec{
+				TLS: &v1alpha2.TLSConfig{CACertSecretRef: "corp-ca"},
+			}),
+			secretObj: types.NamespacedName{Name: "corp-ca", Namespace: "kagent"},
+			want:      true,
+		},
+		{
+			name:      "matching header secret",
+			server:    server("kagent", v1alpha2.RemoteMCPServerSpec{HeadersFrom: headerFrom(v1alpha2.SecretValueSource, "github-token")}),
+			secretObj: types.NamespacedName{Name: "github-token", Namespace: "kagent"},
+			want:      true,
+		},
+		{
+			name:      "header from configmap with the same name",
+			server:    server("kagent", v1alpha2.RemoteMCPServerSpec{HeadersFrom: headerFrom(v1alpha2.ConfigMapValueSource, "github-token")}),
+			secretObj: types.NamespacedName{Name: "github-token", Namespace: "kagent"},
+			want:      false,
+		},
+		{
+			name:      "header secret in a different namespace",
+			server:    server("kagent", v1alpha2.RemoteMCPServerSpec{HeadersFrom: headerFrom(v1alpha2.SecretValueSource, "github-token")}),
+			secretObj: types.NamespacedName{Name: "github-token", Namespace: "other"},
+			want:      false,
+		},
+		{
+			name: "matching OAuth2 client secret",
+			server: server("kagent", v1alpha2.RemoteMCPServerSpec{
+				Auth: &v1alpha2.RemoteMCPServerAuth{OAuth2ClientCredentials: &v1alpha2.OAuth2ClientCredentials{
+					TokenURL:         "https://idp.example.com/token",
+					ClientID:         "kagent",
+					ClientSecretFrom: v1alpha2.ValueSource{Type: v1alpha2.SecretValueSource, Name: "idp-client", Key: "secret"},
+				}},
+			}),
+			secretObj: types.NamespacedName{Name: "idp-client", Namespace: "kagent"},
+			want:      true,
+		},
+		{
+			name:      "no secret references",
+			server:    server("kagent", v1alpha2.RemoteMCPServerSpec{URL: "http://tools:8084/mcp"}),
+			secretObj: types.NamespacedName{Name: "github-token", Namespace: "kagent"},
+			want:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := remoteMCPServerReferencesSecret(tt.server, tt.secretObj)
+			if got != tt.want {
+				t.Errorf("remoteMCPServerReferencesSecret() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -100,6 +100,9 @@ func (r *SandboxAgentController) SetupWithManager(mgr ctrl.Manager) error {
 	if r.Client == nil {
 		r.Client = mgr.GetClient()
 	}
+	if err := indexAgentDependencies(context.Background(), mgr.GetFieldIndexer(), &v1alpha2.SandboxAgent{}); err != nil {
+		return err
+	}
 
 	build := ctrl.NewControllerManagedBy(mgr).
 		WithOptions(controller.Options{
@@ -119,11 +122,11 @@ func (r *SandboxAgentController) SetupWithManager(mgr ctrl.Manager) error {
 		)
 	}
 	build, err = addCommonAgentWatches(build, mgr, agentWatchFinders{
-		modelConfig:     r.sandboxAgentDependencyFinder("failed to list sandboxagents for ModelConfig watch", usesModelConfig),
-		remoteMCPServer: r.sandboxAgentDependencyFinder("failed to list sandboxagents for RemoteMCPServer watch", usesRemoteMCPServer),
-		mcpService:      r.sandboxAgentDependencyFinder("failed to list sandboxagents for Service watch", usesMCPService),
-		configMap:       r.sandboxAgentDependencyFinder("failed to list sandboxagents for ConfigMap watch", referencesConfigMap),
-		mcpServer:       r.sandboxAgentDependencyFinder("failed to list sandboxagents for MCPServer watch", usesMCPServer),
+		modelConfig:     r.sandboxAgentDependencyFinder("failed to list sandboxagents for ModelConfig watch", agentModelConfigIndex, usesModelConfig),
+		remoteMCPServer: r.sandboxAgentDependencyFinder("failed to list sandboxagents for RemoteMCPServer watch", agentToolServerIndex, usesRemoteMCPServer),
+		mcpService:      r.sandboxAgentDependencyFinder("failed to list sandboxagents for Service watch", agentToolServerIndex, usesMCPService),
+		configMap:       r.sandboxAgentDependencyFinder("failed to list sandboxagents for ConfigMap watch", agentConfigMapIndex, referencesConfigMap),
+		mcpServer:       r.sandboxAgentDependencyFinder("failed to list sandboxagents for MCPServer watch", agentToolServerIndex, usesMCPServer),
 	})
 	if err != nil {
 		return err
@@ -151,10 +154,12 @@ func sandboxAgentPrimaryPredicate() predicate.Predicate {
 	}
 }
 
-func (r *SandboxAgentController) sandboxAgentDependencyFinder(errMsg string, pred agentDependencyPredicate) dependentRefFinder {
+// sandboxAgentDependencyFinder finds the SandboxAgents referencing obj through
+// the index field. pred narrows the indexed matches.
+func (r *SandboxAgentController) sandboxAgentDependencyFinder(errMsg, field string, pred agentDependencyPredicate) dependentRefFinder {
 	return func(ctx context.Context, cl client.Client, obj types.NamespacedName) []types.NamespacedName {
 		var list v1alpha2.SandboxAgentList
-		if err := cl.List(ctx, &list); err != nil {
+		if err := cl.List(ctx, &list, client.MatchingFields{field: obj.String()}); err != nil {
 			sandboxAgentControllerLog.Error(err, errMsg)
 			return nil
 		}
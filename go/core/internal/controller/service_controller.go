@@ -19,6 +19,7 @@ package controller
 import (
 	"context"
 	"errors"
+	"sync"
 	"time"
 
 	"github.com/kagent-dev/kagent/go/core/internal/controller/reconciler"
@@ -26,6 +27,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -33,10 +35,25 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 )
 
+const (
+	// serviceRefreshInterval is how often the tools of a healthy MCP Service
+	// are refreshed.
+	serviceRefreshInterval = 60 * time.Second
+	// serviceProbeBackoff and serviceProbeMaxBackoff bound the wait before a
+	// Service whose MCP endpoint did not answer is probed again. The wait
+	// doubles with each consecutive failure.
+	serviceProbeBackoff    = 5 * time.Second
+	serviceProbeMaxBackoff = 5 * time.Minute
+)
+
 // ServiceController reconciles a Service object
 type ServiceController struct {
 	Scheme     *runtime.Scheme
 	Reconciler reconciler.KagentReconciler
+
+	mu sync.Mutex
+	// probeFailures counts the consecutive failed probes of each Service.
+	probeFailures map[types.NamespacedName]int
 }
 
 // +kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch
@@ -45,6 +62,12 @@ func (r *ServiceController) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	_ = log.FromContext(ctx)
 
 	err := r.Reconciler.ReconcileKagentMCPService(ctx, req)
+	var probeErr *reconciler.ProbeError
+	if errors.As(err, &probeErr) {
+		// The endpoint did not answer; probe it again once the backoff elapsed.
+		return ctrl.Result{RequeueAfter: r.probeFailed(req.NamespacedName)}, nil
+	}
+	r.resetProbeFailures(req.NamespacedName)
 	if err != nil {
 		// Check if this is a validation error that requires user action
 		var validationErr *agent_translator.ValidationError
@@ -57,7 +80,34 @@ func (r *ServiceController) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		return ctrl.Result{}, err
 	}
 
-	return ctrl.Result{RequeueAfter: 60 * time.Second}, nil
+	return ctrl.Result{RequeueAfter: serviceRefreshInterval}, nil
+}
+
+// probeFailed counts a failed probe of the Service and returns how long to
+// wait before probing it again.
+func (r *ServiceController) probeFailed(service types.NamespacedName) time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.probeFailures == nil {
+		r.probeFailures = map[types.NamespacedName]int{}
+	}
+	failures := r.probeFailures[service]
+	r.probeFailures[service] = failures + 1
+
+	backoff := serviceProbeBackoff
+	for range failures {
+		backoff *= 2
+		if backoff >= serviceProbeMaxBackoff {
+			return serviceProbeMaxBackoff
+		}
+	}
+	return backoff
+}
+
+func (r *ServiceController) resetProbeFailures(service types.NamespacedName) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.probeFailures, service)
 }
 
 // SetupWithManager sets up the controller with the Manager.
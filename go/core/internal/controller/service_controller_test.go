@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -11,6 +12,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/kagent-dev/kagent/go/core/internal/controller/reconciler"
 	agenttranslator "github.com/kagent-dev/kagent/go/core/internal/controller/translator/agent"
 )
 
@@ -31,6 +33,10 @@ func (f *fakeServiceReconciler) ReconcileKagentAgent(ctx context.Context, req ct
 	return nil
 }
 
+func (f *fakeServiceReconciler) ReconcileKagentAgentStatus(ctx context.Context, req ctrl.Request) error {
+	return nil
+}
+
 func (f *fakeServiceReconciler) ReconcileKagentSandboxAgent(ctx context.Context, req ctrl.Request) error {
 	return nil
 }
@@ -65,6 +71,7 @@ func TestServiceController_ErrorTypeDetection(t *testing.T) {
 		reconcilerError       error
 		expectControllerError bool
 		expectRequeue         bool
+		expectRequeueAfter    time.Duration
 	}{
 		{
 			name:                  "no ports - validation error",
@@ -90,11 +97,19 @@ func TestServiceController_ErrorTypeDetection(t *testing.T) {
 			expectControllerError: true,
 			expectRequeue:         false,
 		},
+		{
+			name:                  "probe failed - backoff",
+			reconcilerError:       &reconciler.ProbeError{Err: errors.New("connection refused")},
+			expectControllerError: false,
+			expectRequeue:         true,
+			expectRequeueAfter:    serviceProbeBackoff,
+		},
 		{
 			name:                  "success - periodic refresh",
 			reconcilerError:       nil,
 			expectControllerError: false,
 			expectRequeue:         true, // Services now requeue after 60s like MCPServers
+			expectRequeueAfter:    60 * time.Second,
 		},
 	}
 
@@ -125,10 +140,49 @@ func TestServiceController_ErrorTypeDetection(t *testing.T) {
 
 			if tc.expectRequeue {
 				assert.NotEqual(t, ctrl.Result{}, result, "Should have requeue result")
-				assert.Equal(t, float64(60), result.RequeueAfter.Seconds(), "Should requeue after 60 seconds")
+				assert.Equal(t, tc.expectRequeueAfter, result.RequeueAfter)
 			} else {
 				assert.Equal(t, ctrl.Result{}, result, "Should have empty result")
 			}
 		})
 	}
 }
+
+// TestServiceController_ProbeBackoff tests that Services whose MCP endpoint
+// does not answer are probed again with exponential backoff, which resets
+// once a probe succeeds.
+func TestServiceController_ProbeBackoff(t *testing.T) {
+	ctx := context.Background()
+	fakeReconciler := &fakeServiceReconciler{
+		reconcileServiceError: &reconciler.ProbeError{Err: errors.New("connection refused")},
+	}
+	controller := &ServiceController{Reconciler: fakeReconciler}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "test", Name: "test-service"}}
+	other := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "test", Name: "other-service"}}
+
+	var got []time.Duration
+	for range 9 {
+		result, err := controller.Reconcile(ctx, req)
+		require.NoError(t, err)
+		got = append(got, result.RequeueAfter)
+	}
+	want := []time.Duration{
+		5 * time.Second, 10 * time.Second, 20 * time.Second, 40 * time.Second, 80 * time.Second,
+		160 * time.Second, serviceProbeMaxBackoff, serviceProbeMaxBackoff, serviceProbeMaxBackoff,
+	}
+	assert.Equal(t, want, got)
+
+	result, err := controller.Reconcile(ctx, other)
+	require.NoError(t, err)
+	assert.Equal(t, serviceProbeBackoff, result.RequeueAfter, "backoff is per Service")
+
+	fakeReconciler.reconcileServiceError = nil
+	result, err = controller.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, serviceRefreshInterval, result.RequeueAfter)
+
+	fakeReconciler.reconcileServiceError = &reconciler.ProbeError{Err: errors.New("connection refused")}
+	result, err = controller.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, serviceProbeBackoff, result.RequeueAfter, "backoff resets after a successful probe")
+}
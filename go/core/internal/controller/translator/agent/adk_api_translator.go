@@ -13,6 +13,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"reflect"
 	"regexp"
 	"slices"
 	"strings"
@@ -20,6 +21,7 @@ import (
 
 	"github.com/kagent-dev/kagent/go/api/adk"
 	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	"github.com/kagent-dev/kagent/go/core/internal/mcpauth"
 	"github.com/kagent-dev/kagent/go/core/internal/skillsinit"
 	"github.com/kagent-dev/kagent/go/core/internal/utils"
 	"github.com/kagent-dev/kagent/go/core/internal/version"
@@ -29,7 +31,10 @@ import (
 	"github.com/kagent-dev/kagent/go/core/pkg/translator"
 	"github.com/kagent-dev/kmcp/api/v1alpha1"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -147,6 +152,16 @@ var DefaultSkillsInitImageConfig = ImageConfig{
 	Repository: "kagent-dev/kagent/skills-init",
 }
 
+// ImageRegistryMirrors maps a source registry (e.g. "ghcr.io", "docker.io") to
+// the registry, optionally with a path prefix, that serves its images. Every
+// container image on generated agent pods is rewritten through it, so
+// air-gapped clusters can run agents from a local mirror.
+var ImageRegistryMirrors map[string]string
+
+// PinRuntimeImageDigests makes regular (non-sandbox) agents reference the
+// runtime image by the configured digest instead of by tag.
+var PinRuntimeImageDigests bool
+
 // DefaultServiceAccountName is the global default ServiceAccount name for agent pods.
 // When set, agent pods that don't specify an explicit serviceAccountName will use this
 // instead of auto-creating a per-agent ServiceAccount.
@@ -215,10 +230,10 @@ func getRuntimeProbeConfig(runtime v1alpha2.DeclarativeRuntime) probeConfig {
 type TranslatorPlugin = translator.TranslatorPlugin
 
 func NewAdkApiTranslator(kube client.Client, defaultModelConfig types.NamespacedName, plugins []TranslatorPlugin, globalProxyURL string, sandboxBackend sandboxbackend.Backend) AdkApiTranslator {
-	return NewAdkApiTranslatorWithWatchedNamespaces(kube, nil, defaultModelConfig, plugins, globalProxyURL, sandboxBackend, false)
+	return NewAdkApiTranslatorWithWatchedNamespaces(kube, nil, defaultModelConfig, plugins, globalProxyURL, sandboxBackend, false, nil)
 }
 
-func NewAdkApiTranslatorWithWatchedNamespaces(kube client.Client, watchedNamespaces []string, defaultModelConfig types.NamespacedName, plugins []TranslatorPlugin, globalProxyURL string, sandboxBackend sandboxbackend.Backend, mcpEgressPlaintext bool) AdkApiTranslator {
+func NewAdkApiTranslatorWithWatchedNamespaces(kube client.Client, watchedNamespaces []string, defaultModelConfig types.NamespacedName, plugins []TranslatorPlugin, globalProxyURL string, sandboxBackend sandboxbackend.Backend, mcpEgressPlaintext bool, skillDigests SkillDigestResolver) AdkApiTranslator {
 	return &adkApiTranslator{
 		kube:               kube,
 		watchedNamespaces:  watchedNamespaces,
@@ -227,9 +242,17 @@ func NewAdkApiTranslatorWithWatchedNamespaces(kube client.Client, watchedNamespa
 		globalProxyURL:     globalProxyURL,
 		sandboxBackend:     sandboxBackend,
 		mcpEgressPlaintext: mcpEgressPlaintext,
+		skillDigests:       skillDigests,
 	}
 }
 
+// SkillDigestResolver resolves the OCI skill images of an agent to the
+// digests they point at. It returns the digests it could resolve along with
+// an error for the others.
+type SkillDigestResolver interface {
+	ResolveSkillDigests(ctx context.Context, namespace string, refs []string, pullSecrets []corev1.LocalObjectReference, insecure bool) (map[string]string, error)
+}
+
 type adkApiTranslator struct {
 	kube               client.Client
 	watchedNamespaces  []string
@@ -243,6 +266,9 @@ type adkApiTranslator struct {
 	// egress.RewriteURL on the same RMS, so the agent and the controller probe
 	// the same endpoint when the egress feature is on.
 	mcpEgressPlaintext bool
+	// skillDigests, when set, pins the agent's OCI skills to digests; nil
+	// leaves the skills-init container pulling the refs as written.
+	skillDigests SkillDigestResolver
 }
 
 // GetOwnedResourceTypes returns all the resource types that may be created for an agent.
@@ -255,6 +281,10 @@ func (r *adkApiTranslator) GetOwnedResourceTypes() []client.Object {
 		&corev1.Secret{},
 		&corev1.Service{},
 		&corev1.ServiceAccount{},
+		&autoscalingv2.HorizontalPodAutoscaler{},
+		&networkingv1.NetworkPolicy{},
+		&rbacv1.Role{},
+		&rbacv1.RoleBinding{},
 	}
 
 	for _, plugin := range r.plugins {
@@ -277,6 +307,12 @@ const (
 	maxDNS1123LabelLen    = 63
 	gdchCredsVolumeName   = "gdch-creds"
 	gdchCredsMountPath    = "/gdch-creds"
+	promptCaptureVolume   = "prompt-capture"
+	promptCaptureMount    = "/var/kagent/prompt-capture"
+
+	// openAICompatiblePlaceholderAPIKey is injected as OPENAI_API_KEY for
+	// OpenAICompatible models that don't reference an API key Secret.
+	openAICompatiblePlaceholderAPIKey = "not-needed"
 )
 
 // dns1123LabelRE matches RFC 1123 labels (lowercase alphanumeric + dashes,
@@ -340,6 +376,41 @@ func populateTLSFields(baseModel *adk.BaseModel, tlsConfig *v1alpha2.TLSConfig)
 	baseModel.TLSInsecureSkipVerify, baseModel.TLSCACertPath, baseModel.TLSDisableSystemCAs = deriveTLSFields(tlsConfig)
 }
 
+// geminiOptions copies the function-calling and safety options shared by the
+// Gemini providers into the agent config.
+func geminiOptions(spec v1alpha2.GeminiFunctionCallingConfig) adk.GeminiOptions {
+	opts := adk.GeminiOptions{
+		ToolChoice:           spec.ToolChoice,
+		AllowedFunctionNames: spec.AllowedFunctionNames,
+		ParallelToolCalls:    spec.ParallelToolCalls,
+	}
+	for _, s := range spec.SafetySettings {
+		opts.SafetySettings = append(opts.SafetySettings, adk.GeminiSafetySetting{Category: s.Category, Threshold: s.Threshold})
+	}
+	return opts
+}
+
+// apiKeyFileEnvSuffix marks an env var holding the path of a file with the
+// value of the env var it is appended to. The runtimes load such files into
+// the environment at startup.
+const apiKeyFileEnvSuffix = "_FILE"
+
+// apiKeyFromEnvVars returns the env vars that make the API key located by
+// from available to the runtime as the env var name. A key in another env
+// var is referenced through dependent env var expansion, which requires that
+// var to be defined before the model's env vars, i.e. in the deployment env
+// or envFrom.
+func apiKeyFromEnvVars(name string, from *v1alpha2.APIKeySource) []corev1.EnvVar {
+	switch {
+	case from == nil:
+		return nil
+	case from.EnvVar != "":
+		return []corev1.EnvVar{{Name: name, Value: fmt.Sprintf("$(%s)", from.EnvVar)}}
+	default:
+		return []corev1.EnvVar{{Name: name + apiKeyFileEnvSuffix, Value: from.File}}
+	}
+}
+
 // addTLSConfiguration mounts a CA Secret as a per-Secret read-only volume on
 // modelDeploymentData. Safe to call multiple times for the same agent with
 // the same OR different TLSConfigs:
@@ -447,6 +518,52 @@ func (a *adkApiTranslator) translateEmbeddingConfig(ctx context.Context, namespa
 	return adk.ModelToEmbeddingConfig(embModel), embMdd, embHash, nil
 }
 
+var modelFallbackConditions = map[v1alpha2.ModelFallbackCondition]string{
+	v1alpha2.ModelFallbackOnServerError: adk.ModelFallbackOnServerError,
+	v1alpha2.ModelFallbackOnRateLimited: adk.ModelFallbackOnRateLimited,
+	v1alpha2.ModelFallbackOnTimeout:     adk.ModelFallbackOnTimeout,
+}
+
+// translateModelFallbacks resolves the fallbacks of the named ModelConfig and
+// merges their deployment data into mdd. Provider credentials are injected
+// under fixed env var names, so a fallback whose env vars would clash with
+// ones already on the agent (e.g. two OpenAI ModelConfigs with different API
+// key secrets) is rejected rather than silently sharing the first key.
+func (a *adkApiTranslator) translateModelFallbacks(ctx context.Context, namespace, modelConfig string, mdd *modelDeploymentData) ([]adk.ModelFallback, []byte, error) {
+	primary := &v1alpha2.ModelConfig{}
+	if err := a.kube.Get(ctx, types.NamespacedName{Namespace: namespace, Name: modelConfig}, primary); err != nil {
+		return nil, nil, err
+	}
+
+	var fallbacks []adk.ModelFallback
+	var secretHashBytes []byte
+	for _, fallback := range primary.Spec.Fallbacks {
+		if fallback.ModelConfig == modelConfig {
+			return nil, nil, fmt.Errorf("model config %q lists itself as a fallback", modelConfig)
+		}
+		model, fallbackMdd, hash, err := a.translateModel(ctx, namespace, fallback.ModelConfig)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to translate fallback model config %q: %w", fallback.ModelConfig, err)
+		}
+		for _, fe := range fallbackMdd.EnvVars {
+			for _, e := range mdd.EnvVars {
+				if e.Name == fe.Name && !reflect.DeepEqual(e, fe) {
+					return nil, nil, fmt.Errorf("fallback model config %q sets env var %s differently from a model already used by the agent", fallback.ModelConfig, fe.Name)
+				}
+			}
+		}
+		mergeDeploymentData(mdd, fallbackMdd)
+		secretHashBytes = append(secretHashBytes, hash...)
+
+		var triggers []string
+		for _, condition := range fallback.Triggers {
+			triggers = append(triggers, modelFallbackConditions[condition])
+		}
+		fallbacks = append(fallbacks, adk.ModelFallback{Model: model, Triggers: triggers})
+	}
+	return fallbacks, secretHashBytes, nil
+}
+
 func (a *adkApiTranslator) translateModel(ctx context.Context, namespace, modelConfig string) (adk.Model, *modelDeploymentData, []byte, error) {
 	model := &v1alpha2.ModelConfig{}
 	err := a.kube.Get(ctx, types.NamespacedName{Namespace: namespace, Name: modelConfig}, model)
@@ -464,7 +581,7 @@ func (a *adkApiTranslator) translateModel(ctx context.Context, namespace, modelC
 		secretHashBytes = decoded
 	}
 
-	modelDeploymentData := &modelDeploymentData{}
+	modelDeploymentData := &modelDeploymentData{ModelEndpoints: modelEndpoints(&model.Spec)}
 
 	// Add TLS configuration if present
 	addTLSConfiguration(modelDeploymentData, model.Spec.TLS)
@@ -485,6 +602,7 @@ func (a *adkApiTranslator) translateModel(ctx context.Context, namespace, modelC
 				},
 			})
 		}
+		modelDeploymentData.EnvVars = append(modelDeploymentData.EnvVars, apiKeyFromEnvVars(env.OpenAIAPIKey.Name(), model.Spec.APIKeyFrom)...)
 		openai := &adk.OpenAI{
 			BaseModel: adk.BaseModel{
 				Model:   model.Spec.Model,
@@ -546,6 +664,7 @@ func (a *adkApiTranslator) translateModel(ctx context.Context, namespace, modelC
 				},
 			})
 		}
+		modelDeploymentData.EnvVars = append(modelDeploymentData.EnvVars, apiKeyFromEnvVars(env.AnthropicAPIKey.Name(), model.Spec.APIKeyFrom)...)
 		anthropic := &adk.Anthropic{
 			BaseModel: adk.BaseModel{
 				Model:   model.Spec.Model,
@@ -567,6 +686,9 @@ func (a *adkApiTranslator) translateModel(ctx context.Context, namespace, modelC
 			if spec.TopK > 0 {
 				anthropic.TopK = &spec.TopK
 			}
+			anthropic.CacheSystemInstruction = spec.CacheSystemInstruction
+			anthropic.CacheTools = spec.CacheTools
+			anthropic.CacheTTL = spec.CacheTTL
 		}
 		return anthropic, modelDeploymentData, secretHashBytes, nil
 	case v1alpha2.ModelProviderAzureOpenAI:
@@ -586,6 +708,7 @@ func (a *adkApiTranslator) translateModel(ctx context.Context, namespace, modelC
 				},
 			})
 		}
+		modelDeploymentData.EnvVars = append(modelDeploymentData.EnvVars, apiKeyFromEnvVars(env.AzureOpenAIAPIKey.Name(), model.Spec.APIKeyFrom)...)
 		if model.Spec.AzureOpenAI.AzureADToken != "" {
 			modelDeploymentData.EnvVars = append(modelDeploymentData.EnvVars, corev1.EnvVar{
 				Name:  env.AzureADToken.Name(),
@@ -652,11 +775,30 @@ func (a *adkApiTranslator) translateModel(ctx context.Context, namespace, modelC
 				MountPath: "/creds",
 			})
 		}
+		if from := model.Spec.APIKeyFrom; from != nil && from.File != "" {
+			modelDeploymentData.EnvVars = append(modelDeploymentData.EnvVars, corev1.EnvVar{
+				Name:  env.GoogleApplicationCredentials.Name(),
+				Value: from.File,
+			})
+		}
+		spec := model.Spec.GeminiVertexAI
 		gemini := &adk.GeminiVertexAI{
 			BaseModel: adk.BaseModel{
 				Model:   model.Spec.Model,
 				Headers: model.Spec.DefaultHeaders,
 			},
+			GeminiOptions: geminiOptions(spec.GeminiFunctionCallingConfig),
+		}
+		gemini.Temperature = utils.ParseStringToFloat64(spec.Temperature)
+		gemini.TopP = utils.ParseStringToFloat64(spec.TopP)
+		gemini.TopK = utils.ParseStringToFloat64(spec.TopK)
+		gemini.StopSequences = spec.StopSequences
+		gemini.ResponseMimeType = spec.ResponseMimeType
+		if spec.MaxOutputTokens > 0 {
+			gemini.MaxOutputTokens = &spec.MaxOutputTokens
+		}
+		if spec.CandidateCount > 0 {
+			gemini.CandidateCount = &spec.CandidateCount
 		}
 		// Populate TLS fields in BaseModel
 		populateTLSFields(&gemini.BaseModel, model.Spec.TLS)
@@ -693,6 +835,12 @@ func (a *adkApiTranslator) translateModel(ctx context.Context, namespace, modelC
 				MountPath: "/creds",
 			})
 		}
+		if from := model.Spec.APIKeyFrom; from != nil && from.File != "" {
+			modelDeploymentData.EnvVars = append(modelDeploymentData.EnvVars, corev1.EnvVar{
+				Name:  env.GoogleApplicationCredentials.Name(),
+				Value: from.File,
+			})
+		}
 		anthropic := &adk.GeminiAnthropic{
 			BaseModel: adk.BaseModel{
 				Model:   model.Spec.Model,
@@ -708,13 +856,9 @@ func (a *adkApiTranslator) translateModel(ctx context.Context, namespace, modelC
 		if model.Spec.Ollama == nil {
 			return nil, nil, nil, fmt.Errorf("ollama model config is required")
 		}
-		host := model.Spec.Ollama.Host
-		if !strings.HasPrefix(host, "http://") && !strings.HasPrefix(host, "https://") {
-			host = "http://" + host
-		}
 		modelDeploymentData.EnvVars = append(modelDeploymentData.EnvVars, corev1.EnvVar{
 			Name:  env.OllamaAPIBase.Name(),
-			Value: host,
+			Value: ollamaBaseURL(model.Spec.Ollama.Host),
 		})
 		ollama := &adk.Ollama{
 			BaseModel: adk.BaseModel{
@@ -729,17 +873,21 @@ func (a *adkApiTranslator) translateModel(ctx context.Context, namespace, modelC
 
 		return ollama, modelDeploymentData, secretHashBytes, nil
 	case v1alpha2.ModelProviderGemini:
-		modelDeploymentData.EnvVars = append(modelDeploymentData.EnvVars, corev1.EnvVar{
-			Name: env.GoogleAPIKey.Name(),
-			ValueFrom: &corev1.EnvVarSource{
-				SecretKeyRef: &corev1.SecretKeySelector{
-					LocalObjectReference: corev1.LocalObjectReference{
-						Name: model.Spec.APIKeySecret,
+		if model.Spec.APIKeyFrom != nil {
+			modelDeploymentData.EnvVars = append(modelDeploymentData.EnvVars, apiKeyFromEnvVars(env.GoogleAPIKey.Name(), model.Spec.APIKeyFrom)...)
+		} else {
+			modelDeploymentData.EnvVars = append(modelDeploymentData.EnvVars, corev1.EnvVar{
+				Name: env.GoogleAPIKey.Name(),
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: model.Spec.APIKeySecret,
+						},
+						Key: model.Spec.APIKeySecretKey,
 					},
-					Key: model.Spec.APIKeySecretKey,
 				},
-			},
-		})
+			})
+		}
 		gemini := &adk.Gemini{
 			BaseModel: adk.BaseModel{
 				Model:   model.Spec.Model,
@@ -748,6 +896,15 @@ func (a *adkApiTranslator) translateModel(ctx context.Context, namespace, modelC
 		}
 		// Populate TLS fields in BaseModel
 		populateTLSFields(&gemini.BaseModel, model.Spec.TLS)
+		if spec := model.Spec.Gemini; spec != nil {
+			gemini.GeminiOptions = geminiOptions(spec.GeminiFunctionCallingConfig)
+			gemini.Temperature = utils.ParseStringToFloat64(spec.Temperature)
+			gemini.TopP = utils.ParseStringToFloat64(spec.TopP)
+			gemini.TopK = utils.ParseStringToFloat64(spec.TopK)
+			if spec.MaxOutputTokens > 0 {
+				gemini.MaxOutputTokens = &spec.MaxOutputTokens
+			}
+		}
 		return gemini, modelDeploymentData, secretHashBytes, nil
 	case v1alpha2.ModelProviderBedrock:
 		if model.Spec.Bedrock == nil {
@@ -831,6 +988,7 @@ func (a *adkApiTranslator) translateModel(ctx context.Context, namespace, modelC
 				Headers: model.Spec.DefaultHeaders,
 			},
 			Region:                       model.Spec.Bedrock.Region,
+			Endpoint:                     model.Spec.Bedrock.Endpoint,
 			AdditionalModelRequestFields: additionalFields,
 			PromptCaching:                model.Spec.Bedrock.PromptCaching,
 			CacheTTL:                     model.Spec.Bedrock.CacheTTL,
@@ -890,6 +1048,53 @@ func (a *adkApiTranslator) translateModel(ctx context.Context, namespace, modelC
 		sapAICore.APIKeyPassthrough = model.Spec.APIKeyPassthrough
 
 		return sapAICore, modelDeploymentData, secretHashBytes, nil
+	case v1alpha2.ModelProviderOpenAICompatible:
+		if model.Spec.OpenAICompatible == nil {
+			return nil, nil, nil, fmt.Errorf("openAICompatible model config is required")
+		}
+		switch {
+		case model.Spec.APIKeyPassthrough:
+		case model.Spec.APIKeySecret != "":
+			modelDeploymentData.EnvVars = append(modelDeploymentData.EnvVars, corev1.EnvVar{
+				Name: env.OpenAIAPIKey.Name(),
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: model.Spec.APIKeySecret,
+						},
+						Key: model.Spec.APIKeySecretKey,
+					},
+				},
+			})
+		case model.Spec.APIKeyFrom != nil:
+			modelDeploymentData.EnvVars = append(modelDeploymentData.EnvVars, apiKeyFromEnvVars(env.OpenAIAPIKey.Name(), model.Spec.APIKeyFrom)...)
+		default:
+			// Local servers usually don't authenticate, but both runtimes'
+			// OpenAI clients refuse to start without a key.
+			modelDeploymentData.EnvVars = append(modelDeploymentData.EnvVars, corev1.EnvVar{
+				Name:  env.OpenAIAPIKey.Name(),
+				Value: openAICompatiblePlaceholderAPIKey,
+			})
+		}
+
+		spec := model.Spec.OpenAICompatible
+		openai := &adk.OpenAI{
+			BaseModel: adk.BaseModel{
+				Model:   model.Spec.Model,
+				Headers: model.Spec.DefaultHeaders,
+			},
+			BaseUrl:     spec.BaseURL,
+			Temperature: utils.ParseStringToFloat64(spec.Temperature),
+			TopP:        utils.ParseStringToFloat64(spec.TopP),
+			Timeout:     spec.Timeout,
+		}
+		if spec.MaxTokens > 0 {
+			openai.MaxTokens = &spec.MaxTokens
+		}
+		populateTLSFields(&openai.BaseModel, model.Spec.TLS)
+		openai.APIKeyPassthrough = model.Spec.APIKeyPassthrough
+
+		return openai, modelDeploymentData, secretHashBytes, nil
 	default:
 		return nil, nil, nil, fmt.Errorf("unsupported model provider: %s", model.Spec.Provider)
 	}
@@ -930,6 +1135,9 @@ func (a *adkApiTranslator) translateStreamableHttpTool(ctx context.Context, serv
 		params.TerminateOnClose = server.Spec.TerminateOnClose
 	}
 	params.TLSInsecureSkipVerify, params.TLSCACertPath, params.TLSDisableSystemCAs = deriveTLSFields(server.Spec.TLS)
+	if params.OAuth2, err = mcpauth.Resolve(ctx, a.kube, server); err != nil {
+		return nil, err
+	}
 
 	return params, nil
 }
@@ -966,6 +1174,9 @@ func (a *adkApiTranslator) translateSseHttpTool(ctx context.Context, server *v1a
 		params.SseReadTimeout = new(server.Spec.SseReadTimeout.Seconds())
 	}
 	params.TLSInsecureSkipVerify, params.TLSCACertPath, params.TLSDisableSystemCAs = deriveTLSFields(server.Spec.TLS)
+	if params.OAuth2, err = mcpauth.Resolve(ctx, a.kube, server); err != nil {
+		return nil, err
+	}
 	return params, nil
 }
 
@@ -1062,6 +1273,8 @@ func (a *adkApiTranslator) translateMCPServerTarget(ctx context.Context, agent *
 }
 
 func (a *adkApiTranslator) translateRemoteMCPServerTarget(ctx context.Context, agent *adk.AgentConfig, mdd *modelDeploymentData, remoteMcpServer *v1alpha2.RemoteMCPServer, mcpServerTool *v1alpha2.McpServerTool, agentHeaders map[string]string, proxyURL string, egressRewrite bool) ([]byte, error) {
+	// Converted MCPServers and Services keep the name of the tool server.
+	serverRef := types.NamespacedName{Namespace: remoteMcpServer.Namespace, Name: remoteMcpServer.Name}.String()
 	switch remoteMcpServer.Spec.Protocol {
 	case v1alpha2.RemoteMCPServerProtocolSse:
 		tool, err := a.translateSseHttpTool(ctx, remoteMcpServer, agentHeaders, proxyURL, egressRewrite)
@@ -1073,6 +1286,7 @@ func (a *adkApiTranslator) translateRemoteMCPServerTarget(ctx context.Context, a
 			Tools:           mcpServerTool.ToolNames,
 			AllowedHeaders:  mcpServerTool.AllowedHeaders,
 			RequireApproval: mcpServerTool.RequireApproval,
+			ServerRef:       serverRef,
 		})
 	default:
 		tool, err := a.translateStreamableHttpTool(ctx, remoteMcpServer, agentHeaders, proxyURL, egressRewrite)
@@ -1084,6 +1298,7 @@ func (a *adkApiTranslator) translateRemoteMCPServerTarget(ctx context.Context, a
 			Tools:           mcpServerTool.ToolNames,
 			AllowedHeaders:  mcpServerTool.AllowedHeaders,
 			RequireApproval: mcpServerTool.RequireApproval,
+			ServerRef:       serverRef,
 		})
 	}
 	// Mount the CA Secret on the agent pod when the RemoteMCPServer pins a TLS bundle.
@@ -1194,6 +1409,14 @@ func computeConfigHash(agentCfg, agentCard, secretData, skillsInitCfg []byte) ui
 
 // mergeDeploymentData adds env vars, volumes, and volume mounts from src into dst,
 // skipping any that already exist in dst (by name for env/volumes, by mount path for mounts).
+// ollamaBaseURL returns the URL of an Ollama host, which may omit the scheme.
+func ollamaBaseURL(host string) string {
+	if !strings.HasPrefix(host, "http://") && !strings.HasPrefix(host, "https://") {
+		return "http://" + host
+	}
+	return host
+}
+
 func mergeDeploymentData(dst, src *modelDeploymentData) {
 	for _, se := range src.EnvVars {
 		found := false
@@ -1235,6 +1458,11 @@ func mergeDeploymentData(dst, src *modelDeploymentData) {
 			dst.VolumeMounts = append(dst.VolumeMounts, sm)
 		}
 	}
+	for _, endpoint := range src.ModelEndpoints {
+		if !slices.Contains(dst.ModelEndpoints, endpoint) {
+			dst.ModelEndpoints = append(dst.ModelEndpoints, endpoint)
+		}
+	}
 }
 
 func collectOtelEnvFromProcess() []corev1.EnvVar {
@@ -1483,6 +1711,24 @@ func prepareSkillsInitConfig(
 	return cfg, nil
 }
 
+// pinOCISkills points each OCI ref at the digest its image resolved to.
+// The skill keeps the directory name derived from the ref as written, so
+// pinning doesn't change the paths the agent sees.
+func pinOCISkills(refs []skillsinit.OCIRef, digests map[string]string) {
+	for i := range refs {
+		digest := digests[refs[i].Image]
+		if digest == "" {
+			continue
+		}
+		repository, _, _ := strings.Cut(refs[i].Image, "@")
+		if j := strings.LastIndex(repository, ":"); j > strings.LastIndex(repository, "/") {
+			repository = repository[:j]
+		}
+		refs[i].Image = repository + "@" + digest
+		refs[i].Digest = digest
+	}
+}
+
 // SkillsInitConfigMapSuffix is appended to the Agent name to form the
 // ConfigMap that carries the skills-init container's JSON config.
 const SkillsInitConfigMapSuffix = "-skills-init"
@@ -1514,11 +1760,13 @@ func validateSkillsInitConfigMapName(name string) error {
 // If imagePullSecrets is non-empty, each kubernetes.io/dockerconfigjson secret
 // is mounted under DockerSecretsDir/<name>; the binary merges them into a
 // single config.json and sets DOCKER_CONFIG for the OCI client library.
+// OCI refs with an entry in skillDigests are fetched by that digest.
 func buildSkillsInitContainer(
 	agentName, agentNamespace string,
 	gitRefs []v1alpha2.GitRepo,
 	authSecretRef *corev1.LocalObjectReference,
 	ociRefs []string,
+	skillDigests map[string]string,
 	insecureOCI bool,
 	securityContext *corev1.SecurityContext,
 	envVars []corev1.EnvVar,
@@ -1534,6 +1782,7 @@ func buildSkillsInitContainer(
 	if err != nil {
 		return nil, nil, nil, err
 	}
+	pinOCISkills(cfg.OCIRefs, skillDigests)
 	cfgJSON, err := json.Marshal(cfg)
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("marshal skills-init config: %w", err)
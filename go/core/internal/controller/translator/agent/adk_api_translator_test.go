@@ -564,6 +564,101 @@ func Test_AdkApiTranslator_OpenAIMaxCompletionTokens(t *testing.T) {
 	assert.Nil(t, m.MaxTokens)
 }
 
+func Test_AdkApiTranslator_OpenAICompatible(t *testing.T) {
+	scheme := schemev1.Scheme
+	require.NoError(t, v1alpha2.AddToScheme(scheme))
+
+	maxTokens := 2048
+	tests := []struct {
+		name       string
+		spec       v1alpha2.ModelConfigSpec
+		wantKeyRef bool
+	}{
+		{
+			name: "no api key secret injects placeholder key",
+			spec: v1alpha2.ModelConfigSpec{
+				Model:    "qwen2.5-7b-instruct",
+				Provider: v1alpha2.ModelProviderOpenAICompatible,
+				OpenAICompatible: &v1alpha2.OpenAICompatibleConfig{
+					BaseURL:   "http://vllm.models:8000/v1",
+					MaxTokens: maxTokens,
+				},
+			},
+		},
+		{
+			name: "api key secret is referenced",
+			spec: v1alpha2.ModelConfigSpec{
+				Model:           "qwen2.5-7b-instruct",
+				Provider:        v1alpha2.ModelProviderOpenAICompatible,
+				APIKeySecret:    "vllm-key",
+				APIKeySecretKey: "token",
+				OpenAICompatible: &v1alpha2.OpenAICompatibleConfig{
+					BaseURL:   "http://vllm.models:8000/v1",
+					MaxTokens: maxTokens,
+				},
+			},
+			wantKeyRef: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			modelConfig := &v1alpha2.ModelConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "m", Namespace: "ns"},
+				Spec:       tt.spec,
+			}
+			agent := &v1alpha2.Agent{
+				ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "ns"},
+				Spec: v1alpha2.AgentSpec{
+					Type: v1alpha2.AgentType_Declarative,
+					Declarative: &v1alpha2.DeclarativeAgentSpec{
+						SystemMessage: "x",
+						ModelConfig:   "m",
+					},
+				},
+			}
+
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns"}}
+			kubeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ns, modelConfig, agent).Build()
+			trans := translator.NewAdkApiTranslator(kubeClient, types.NamespacedName{Namespace: "ns", Name: "m"}, nil, "", nil)
+
+			outputs, err := translator.TranslateAgent(context.Background(), trans, agent)
+			require.NoError(t, err)
+
+			m, ok := outputs.Config.Model.(*adk.OpenAI)
+			require.True(t, ok, "OpenAICompatible should translate to the OpenAI runtime model")
+			assert.Equal(t, "qwen2.5-7b-instruct", m.Model)
+			assert.Equal(t, "http://vllm.models:8000/v1", m.BaseUrl)
+			assert.Equal(t, &maxTokens, m.MaxTokens)
+
+			var dep *appsv1.Deployment
+			for _, obj := range outputs.Manifest {
+				if d, ok := obj.(*appsv1.Deployment); ok {
+					dep = d
+					break
+				}
+			}
+			require.NotNil(t, dep, "Deployment not found in manifest")
+
+			var keyEnv *corev1.EnvVar
+			for i, e := range dep.Spec.Template.Spec.Containers[0].Env {
+				if e.Name == "OPENAI_API_KEY" {
+					keyEnv = &dep.Spec.Template.Spec.Containers[0].Env[i]
+				}
+			}
+			require.NotNil(t, keyEnv, "OPENAI_API_KEY must always be set for OpenAICompatible models")
+			if tt.wantKeyRef {
+				require.NotNil(t, keyEnv.ValueFrom)
+				assert.Equal(t, "vllm-key", keyEnv.ValueFrom.SecretKeyRef.Name)
+				assert.Equal(t, "token", keyEnv.ValueFrom.SecretKeyRef.Key)
+			} else {
+				assert.Nil(t, keyEnv.ValueFrom)
+				assert.NotEmpty(t, keyEnv.Value)
+			}
+		})
+	}
+}
+
 func Test_AdkApiTranslator_ServiceAccountNameOverride(t *testing.T) {
 	scheme := schemev1.Scheme
 	require.NoError(t, v1alpha2.AddToScheme(scheme))
@@ -1262,6 +1357,113 @@ func Test_AdkApiTranslator_MergeDeploymentData(t *testing.T) {
 	}
 }
 
+func Test_AdkApiTranslator_ModelFallbacks(t *testing.T) {
+	scheme := schemev1.Scheme
+	require.NoError(t, v1alpha2.AddToScheme(scheme))
+
+	modelConfig := func(name string, provider v1alpha2.ModelProvider, secretName string, fallbacks ...v1alpha2.ModelFallback) *v1alpha2.ModelConfig {
+		return &v1alpha2.ModelConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: v1alpha2.ModelConfigSpec{
+				Model:           "model-" + name,
+				Provider:        provider,
+				APIKeySecret:    secretName,
+				APIKeySecretKey: "api-key",
+				Fallbacks:       fallbacks,
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		objects []client.Object
+		wantErr string
+		assert  func(t *testing.T, cfg *adk.AgentConfig)
+	}{
+		{
+			name: "fallbacks are translated in order",
+			objects: []client.Object{
+				modelConfig("primary", v1alpha2.ModelProviderOpenAI, "openai-secret",
+					v1alpha2.ModelFallback{ModelConfig: "azure", Triggers: []v1alpha2.ModelFallbackCondition{v1alpha2.ModelFallbackOnRateLimited}},
+					v1alpha2.ModelFallback{ModelConfig: "anthropic"},
+				),
+				func() *v1alpha2.ModelConfig {
+					mc := modelConfig("azure", v1alpha2.ModelProviderAzureOpenAI, "azure-secret")
+					mc.Spec.AzureOpenAI = &v1alpha2.AzureOpenAIConfig{Endpoint: "https://example.openai.azure.com", APIVersion: "2024-06-01"}
+					return mc
+				}(),
+				modelConfig("anthropic", v1alpha2.ModelProviderAnthropic, "anthropic-secret",
+					v1alpha2.ModelFallback{ModelConfig: "primary"},
+				),
+			},
+			assert: func(t *testing.T, cfg *adk.AgentConfig) {
+				require.Len(t, cfg.ModelFallbacks, 2, "fallbacks of fallbacks are ignored")
+				assert.Equal(t, adk.ModelTypeAzureOpenAI, cfg.ModelFallbacks[0].Model.GetType())
+				assert.Equal(t, []string{adk.ModelFallbackOnRateLimited}, cfg.ModelFallbacks[0].Triggers)
+				assert.Equal(t, adk.ModelTypeAnthropic, cfg.ModelFallbacks[1].Model.GetType())
+				assert.Empty(t, cfg.ModelFallbacks[1].Triggers)
+			},
+		},
+		{
+			name: "same provider sharing a secret",
+			objects: []client.Object{
+				modelConfig("primary", v1alpha2.ModelProviderOpenAI, "openai-secret", v1alpha2.ModelFallback{ModelConfig: "backup"}),
+				modelConfig("backup", v1alpha2.ModelProviderOpenAI, "openai-secret"),
+			},
+			assert: func(t *testing.T, cfg *adk.AgentConfig) {
+				require.Len(t, cfg.ModelFallbacks, 1)
+			},
+		},
+		{
+			name: "same provider with a different secret",
+			objects: []client.Object{
+				modelConfig("primary", v1alpha2.ModelProviderOpenAI, "openai-secret", v1alpha2.ModelFallback{ModelConfig: "backup"}),
+				modelConfig("backup", v1alpha2.ModelProviderOpenAI, "other-secret"),
+			},
+			wantErr: "sets env var OPENAI_API_KEY differently",
+		},
+		{
+			name: "missing fallback",
+			objects: []client.Object{
+				modelConfig("primary", v1alpha2.ModelProviderOpenAI, "openai-secret", v1alpha2.ModelFallback{ModelConfig: "missing"}),
+			},
+			wantErr: `failed to translate fallback model config "missing"`,
+		},
+		{
+			name: "self reference",
+			objects: []client.Object{
+				modelConfig("primary", v1alpha2.ModelProviderOpenAI, "openai-secret", v1alpha2.ModelFallback{ModelConfig: "primary"}),
+			},
+			wantErr: "lists itself as a fallback",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kubeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.objects...).Build()
+			agent := &v1alpha2.Agent{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-agent", Namespace: "default"},
+				Spec: v1alpha2.AgentSpec{
+					Type: v1alpha2.AgentType_Declarative,
+					Declarative: &v1alpha2.DeclarativeAgentSpec{
+						SystemMessage: "You are a test agent",
+						ModelConfig:   "primary",
+					},
+				},
+			}
+			defaultModel := types.NamespacedName{Namespace: "default", Name: "primary"}
+			trans := translator.NewAdkApiTranslator(kubeClient, defaultModel, nil, "", nil)
+			outputs, err := translator.TranslateAgent(context.Background(), trans, agent)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			tt.assert(t, outputs.Config)
+		})
+	}
+}
+
 func Test_AdkApiTranslator_ContextConfig(t *testing.T) {
 	scheme := schemev1.Scheme
 	require.NoError(t, v1alpha2.AddToScheme(scheme))
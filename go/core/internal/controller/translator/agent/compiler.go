@@ -3,12 +3,17 @@ package agent
 import (
 	"context"
 	"fmt"
+	"path"
+	"regexp"
 	"slices"
+	"strconv"
 
 	a2a "github.com/a2aproject/a2a-go/v2/a2a"
 	"github.com/kagent-dev/kagent/go/api/adk"
 	"github.com/kagent-dev/kagent/go/api/v1alpha2"
 	"github.com/kagent-dev/kagent/go/core/internal/utils"
+	"github.com/kagent-dev/kagent/go/core/pkg/env"
+	corev1 "k8s.io/api/core/v1"
 )
 
 // AgentManifestInputs holds the translated data needed to emit Kubernetes resources.
@@ -18,6 +23,12 @@ type AgentManifestInputs struct {
 	Deployment      *resolvedDeployment
 	AgentCard       *a2a.AgentCard
 	SecretHashBytes []byte
+	// SkillDigests maps the agent's OCI skill refs to the digests the
+	// skills-init container fetches them by. Refs that could not be resolved
+	// are missing and SkillDigestsErr says why; their pods pull the refs as
+	// written.
+	SkillDigests    map[string]string
+	SkillDigestsErr error
 }
 
 const MAX_DEPTH = 10
@@ -151,6 +162,9 @@ func (a *adkApiTranslator) CompileAgent(
 	default:
 		return nil, fmt.Errorf("unknown agent type: %s", spec.Type)
 	}
+	if err := validateServiceAccountRules(dep.ServiceAccountConfig); err != nil {
+		return nil, err
+	}
 
 	runInSandbox := agent.GetWorkloadMode() == v1alpha2.WorkloadModeSandbox
 	if runInSandbox && a.sandboxBackend == nil {
@@ -159,6 +173,10 @@ func (a *adkApiTranslator) CompileAgent(
 	if runInSandbox {
 		cfg.SessionDBURL = a.sandboxBackend.SessionDBURL(agent)
 	}
+	if spec.Streaming != nil && spec.Type == v1alpha2.AgentType_Declarative && v1alpha2.EffectiveDeclarativeRuntime(spec) != v1alpha2.DeclarativeRuntime_Go {
+		return nil, NewValidationError("streaming is only supported by the go runtime")
+	}
+	cfg.Streaming = translateStreamingConfig(spec.Streaming)
 	if sa, ok := agent.(*v1alpha2.SandboxAgent); ok {
 		if err := v1alpha2.ValidateSubstrateSandboxAgentSpec(sa); err != nil {
 			return nil, NewValidationError("%s", err.Error())
@@ -167,12 +185,32 @@ func (a *adkApiTranslator) CompileAgent(
 
 	card := GetA2AAgentCard(agent)
 
+	// Pinning is best effort: a registry the controller can't reach, like
+	// one only the nodes are configured for, must not block the agent. A ref
+	// that fails to resolve keeps the digest recorded in the status, so a
+	// registry outage doesn't roll the pods back to the tag.
+	var skillDigests map[string]string
+	var skillDigestsErr error
+	if skills := spec.Skills; skills != nil && len(skills.Refs) > 0 && a.skillDigests != nil {
+		skillDigests, skillDigestsErr = a.skillDigests.ResolveSkillDigests(ctx, agent.GetNamespace(), skills.Refs, skills.ImagePullSecrets, skills.InsecureSkipVerify)
+		if skillDigests == nil {
+			skillDigests = map[string]string{}
+		}
+		for _, recorded := range agent.GetAgentStatus().Skills {
+			if _, ok := skillDigests[recorded.Ref]; !ok && recorded.Digest != "" && slices.Contains(skills.Refs, recorded.Ref) {
+				skillDigests[recorded.Ref] = recorded.Digest
+			}
+		}
+	}
+
 	return &AgentManifestInputs{
 		Config:          cfg,
 		Sandbox:         spec.Sandbox,
 		Deployment:      dep,
 		AgentCard:       card,
 		SecretHashBytes: secretHashBytes,
+		SkillDigests:    skillDigests,
+		SkillDigestsErr: skillDigestsErr,
 	}, nil
 }
 
@@ -225,6 +263,11 @@ func (a *adkApiTranslator) translateInlineAgent(ctx context.Context, agent v1alp
 	if err != nil {
 		return nil, nil, nil, err
 	}
+	modelFallbacks, fallbackSecretHash, err := a.translateModelFallbacks(ctx, agent.GetNamespace(), spec.Declarative.ModelConfig, mdd)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	secretHashBytes = append(secretHashBytes, fallbackSecretHash...)
 
 	// Resolve the raw system message (template processing happens after tools are translated).
 	rawSystemMessage, err := a.resolveRawSystemMessage(ctx, agent)
@@ -233,11 +276,12 @@ func (a *adkApiTranslator) translateInlineAgent(ctx context.Context, agent v1alp
 	}
 
 	cfg := &adk.AgentConfig{
-		Description: spec.Description,
-		Instruction: rawSystemMessage,
-		Model:       model,
-		ExecuteCode: spec.Declarative.ExecuteCodeBlocks,
-		Stream:      new(spec.Declarative.Stream),
+		Description:    spec.Description,
+		Instruction:    rawSystemMessage,
+		Model:          model,
+		ModelFallbacks: modelFallbacks,
+		ExecuteCode:    spec.Declarative.ExecuteCodeBlocks,
+		Stream:         new(spec.Declarative.Stream),
 	}
 
 	if spec.Sandbox != nil && spec.Sandbox.Network != nil {
@@ -296,6 +340,88 @@ func (a *adkApiTranslator) translateInlineAgent(ctx context.Context, agent v1alp
 		cfg.ShareTools = &t
 	}
 
+	goRuntime := v1alpha2.EffectiveDeclarativeRuntime(spec) == v1alpha2.DeclarativeRuntime_Go
+	if spec.Declarative.PromptCapture != nil {
+		if !goRuntime {
+			return nil, nil, nil, NewValidationError("promptCapture is only supported by the go runtime")
+		}
+		captureCfg, err := translatePromptCapture(agent.GetName(), spec.Declarative.PromptCapture, mdd)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		cfg.PromptCapture = captureCfg
+	}
+
+	if spec.Declarative.LLMTrace != nil {
+		if !goRuntime {
+			return nil, nil, nil, NewValidationError("llmTrace is only supported by the go runtime")
+		}
+		traceCfg, err := translateLLMTrace(spec.Declarative.LLMTrace)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		cfg.LLMTrace = traceCfg
+	}
+
+	if spec.Declarative.PromptInjection != nil {
+		injectionCfg, err := translatePromptInjection(spec.Declarative.PromptInjection)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		cfg.PromptInjection = injectionCfg
+	}
+
+	if moderation := spec.Declarative.Moderation; moderation != nil {
+		moderationCfg, err := translateModeration(moderation)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		cfg.Moderation = moderationCfg
+		if p := moderation.Provider; p != nil && p.APIKeySecret != "" {
+			mdd.EnvVars = append(mdd.EnvVars, corev1.EnvVar{
+				Name: env.ModerationAPIKey.Name(),
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: p.APIKeySecret},
+						Key:                  p.APIKeySecretKey,
+					},
+				},
+			})
+		}
+	}
+
+	if spec.Declarative.ToolPolicy != nil {
+		policy, err := translateToolPolicy(spec.Declarative.ToolPolicy)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		cfg.ToolPolicy = policy
+	}
+
+	if spec.Declarative.ToolResultLimit != nil {
+		limitCfg, err := translateToolResultLimit(spec.Declarative.ToolResultLimit)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		cfg.ToolResultLimit = limitCfg
+	}
+
+	if tv := spec.Declarative.ToolResultValidation; tv != nil {
+		cfg.ToolResultValidation = &adk.ToolResultValidationConfig{
+			Coerce:       tv.Coerce == nil || *tv.Coerce,
+			ExcludeTools: tv.ExcludeTools,
+		}
+	}
+
+	for _, pattern := range spec.Declarative.DryRunTools {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, nil, nil, NewValidationError("dryRunTools: invalid pattern %q: %v", pattern, err)
+		}
+	}
+	cfg.DryRunTools = spec.Declarative.DryRunTools
+	cfg.Timeouts = translateTimeouts(spec.Declarative.Timeouts)
+	cfg.Budget = translateBudget(spec.Declarative.Budget)
+
 	// Handle Memory Configuration: presence of Memory field enables it.
 	if spec.Declarative.Memory != nil {
 		embCfg, embMdd, embHash, err := a.translateEmbeddingConfig(ctx, agent.GetNamespace(), spec.Declarative.Memory.ModelConfig)
@@ -307,6 +433,23 @@ func (a *adkApiTranslator) translateInlineAgent(ctx context.Context, agent v1alp
 			TTLDays:   spec.Declarative.Memory.TTLDays,
 			Embedding: embCfg,
 		}
+		if q := spec.Declarative.Memory.Qdrant; q != nil && spec.Declarative.Memory.Provider == v1alpha2.MemoryProviderQdrant {
+			cfg.Memory.Qdrant = &adk.QdrantMemoryConfig{
+				URL:        q.URL,
+				Collection: q.CollectionName(agent.GetNamespace(), agent.GetName()),
+			}
+			if q.APIKeySecret != "" {
+				mdd.EnvVars = append(mdd.EnvVars, corev1.EnvVar{
+					Name: env.QdrantAPIKey.Name(),
+					ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: q.APIKeySecret},
+							Key:                  q.APIKeySecretKey,
+						},
+					},
+				})
+			}
+		}
 
 		mergeDeploymentData(mdd, embMdd)
 		if spec.Declarative.Memory.ModelConfig != spec.Declarative.ModelConfig {
@@ -373,6 +516,16 @@ func (a *adkApiTranslator) translateInlineAgent(ctx context.Context, agent v1alp
 		}
 	}
 
+	applyToolPolicy(cfg)
+
+	if pattern, ok := agent.GetAnnotations()[v1alpha2.WorkflowPatternAnnotation]; ok {
+		workflowCfg, err := translateWorkflowPattern(v1alpha2.WorkflowPattern(pattern))
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		cfg.Workflow = workflowCfg
+	}
+
 	if spec.Declarative.PromptTemplate != nil && len(spec.Declarative.PromptTemplate.DataSources) > 0 {
 		lookup, err := resolvePromptSources(ctx, a.kube, agent.GetNamespace(), spec.Declarative.PromptTemplate.DataSources)
 		if err != nil {
@@ -403,3 +556,307 @@ func (a *adkApiTranslator) resolveRawSystemMessage(ctx context.Context, agent v1
 	}
 	return "", fmt.Errorf("at least one system message source (SystemMessage or SystemMessageFrom) must be specified")
 }
+
+// translateStreamingConfig converts the agent's SSE tuning to the runtime
+// config format, which expresses durations in seconds.
+func translateStreamingConfig(streaming *v1alpha2.StreamingConfig) *adk.StreamingConfig {
+	if streaming == nil {
+		return nil
+	}
+	out := &adk.StreamingConfig{}
+	if streaming.FlushInterval != nil {
+		out.FlushInterval = new(streaming.FlushInterval.Seconds())
+	}
+	if streaming.WriteTimeout != nil {
+		out.WriteTimeout = new(streaming.WriteTimeout.Seconds())
+	}
+	if streaming.MaxEventSize != nil {
+		out.MaxEventSize = new(int(*streaming.MaxEventSize))
+	}
+	return out
+}
+
+// translateBudget converts the agent's budget to the runtime config format.
+func translateBudget(budget *v1alpha2.AgentBudget) *adk.BudgetConfig {
+	if budget == nil {
+		return nil
+	}
+	out := &adk.BudgetConfig{
+		MaxTotalTokens:   budget.MaxTotalTokens,
+		MaxSessionTokens: budget.MaxSessionTokens,
+	}
+	if budget.MaxToolCalls != nil {
+		out.MaxToolCalls = new(int(*budget.MaxToolCalls))
+	}
+	if budget.MaxTurns != nil {
+		out.MaxTurns = new(int(*budget.MaxTurns))
+	}
+	return out
+}
+
+// translateTimeouts converts the agent's timeouts to the runtime config
+// format, which expresses durations in seconds.
+func translateTimeouts(timeouts *v1alpha2.AgentTimeouts) *adk.TimeoutsConfig {
+	if timeouts == nil {
+		return nil
+	}
+	out := &adk.TimeoutsConfig{}
+	if timeouts.LLMRequest != nil {
+		out.LLMRequest = new(timeouts.LLMRequest.Seconds())
+	}
+	if timeouts.ToolCall != nil {
+		out.ToolCall = new(timeouts.ToolCall.Seconds())
+	}
+	if len(timeouts.Tools) > 0 {
+		out.Tools = make(map[string]float64, len(timeouts.Tools))
+		for _, tool := range timeouts.Tools {
+			out.Tools[tool.Name] = tool.Timeout.Seconds()
+		}
+	}
+	if timeouts.Task != nil {
+		out.Task = new(timeouts.Task.Seconds())
+	}
+	return out
+}
+
+// translatePromptInjection validates the custom patterns and converts the
+// action to the runtime's lowercase form.
+func translatePromptInjection(pi *v1alpha2.PromptInjectionSpec) (*adk.PromptInjectionConfig, error) {
+	cfg := &adk.PromptInjectionConfig{
+		Action:       adk.PromptInjectionActionFlag,
+		Patterns:     pi.Patterns,
+		ExcludeTools: pi.ExcludeTools,
+	}
+	switch pi.Action {
+	case "", v1alpha2.PromptInjectionActionFlag:
+	case v1alpha2.PromptInjectionActionRedact:
+		cfg.Action = adk.PromptInjectionActionRedact
+	case v1alpha2.PromptInjectionActionBlock:
+		cfg.Action = adk.PromptInjectionActionBlock
+	default:
+		return nil, NewValidationError("promptInjection.action %q must be one of Flag, Redact or Block", pi.Action)
+	}
+	for _, pattern := range pi.Patterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return nil, NewValidationError("promptInjection.patterns: invalid pattern %q: %v", pattern, err)
+		}
+	}
+	return cfg, nil
+}
+
+// openAIModerationURL is the base URL of the OpenAI API used for moderation
+// when the provider doesn't set one.
+const openAIModerationURL = "https://api.openai.com/v1"
+
+// translateModeration validates the patterns, converts the action and
+// provider type to the runtime's lowercase form and resolves the URL of the
+// OpenAI provider.
+func translateModeration(m *v1alpha2.ModerationSpec) (*adk.ModerationConfig, error) {
+	cfg := &adk.ModerationConfig{
+		Action:     adk.ModerationActionBlock,
+		Keywords:   m.Keywords,
+		Patterns:   m.Patterns,
+		CheckInput: m.CheckInput,
+	}
+	switch m.Action {
+	case "", v1alpha2.ModerationActionBlock:
+	case v1alpha2.ModerationActionRedact:
+		cfg.Action = adk.ModerationActionRedact
+	case v1alpha2.ModerationActionFlag:
+		cfg.Action = adk.ModerationActionFlag
+	default:
+		return nil, NewValidationError("moderation.action %q must be one of Block, Redact or Flag", m.Action)
+	}
+	for _, pattern := range m.Patterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return nil, NewValidationError("moderation.patterns: invalid pattern %q: %v", pattern, err)
+		}
+	}
+	if p := m.Provider; p != nil {
+		cfg.Provider = &adk.ModerationProviderConfig{
+			URL:      p.URL,
+			Model:    p.Model,
+			FailOpen: p.FailOpen,
+		}
+		switch p.Type {
+		case v1alpha2.ModerationProviderOpenAI:
+			cfg.Provider.Type = adk.ModerationProviderOpenAI
+			if cfg.Provider.URL == "" {
+				cfg.Provider.URL = openAIModerationURL
+			}
+		case v1alpha2.ModerationProviderHTTP:
+			cfg.Provider.Type = adk.ModerationProviderHTTP
+			if p.URL == "" {
+				return nil, NewValidationError("moderation.provider.url is required for the HTTP provider")
+			}
+		default:
+			return nil, NewValidationError("moderation.provider.type %q must be one of OpenAI or HTTP", p.Type)
+		}
+	}
+	return cfg, nil
+}
+
+// toolResultBytesPerToken estimates the size of a token when a tool result
+// limit is given in tokens.
+const toolResultBytesPerToken = 4
+
+// translateToolResultLimit resolves the byte and token limits to a single
+// byte limit, the smaller of the two, and converts the strategy to the
+// runtime's lowercase form.
+func translateToolResultLimit(tl *v1alpha2.ToolResultLimitSpec) (*adk.ToolResultLimitConfig, error) {
+	maxBytes := 0
+	if tl.MaxBytes != nil {
+		maxBytes = int(*tl.MaxBytes)
+	}
+	if tl.MaxTokens != nil {
+		if tokenBytes := int(*tl.MaxTokens) * toolResultBytesPerToken; maxBytes == 0 || tokenBytes < maxBytes {
+			maxBytes = tokenBytes
+		}
+	}
+	if maxBytes <= 0 {
+		return nil, NewValidationError("toolResultLimit: one of maxBytes or maxTokens must be set to a positive value")
+	}
+	cfg := &adk.ToolResultLimitConfig{
+		MaxBytes:     maxBytes,
+		Strategy:     adk.ToolResultStrategyHead,
+		ExcludeTools: tl.ExcludeTools,
+	}
+	switch tl.Strategy {
+	case "", v1alpha2.ToolResultTruncationHead:
+	case v1alpha2.ToolResultTruncationTail:
+		cfg.Strategy = adk.ToolResultStrategyTail
+	case v1alpha2.ToolResultTruncationSummarize:
+		cfg.Strategy = adk.ToolResultStrategySummarize
+	case v1alpha2.ToolResultTruncationPaginate:
+		cfg.Strategy = adk.ToolResultStrategyPaginate
+	default:
+		return nil, NewValidationError("toolResultLimit.strategy %q must be one of Head, Tail, Summarize or Paginate", tl.Strategy)
+	}
+	return cfg, nil
+}
+
+// translateToolPolicy validates the glob patterns of a tool policy.
+func translateToolPolicy(tp *v1alpha2.ToolPolicy) (*adk.ToolPolicy, error) {
+	for _, list := range []struct {
+		field    string
+		patterns []string
+	}{{"allow", tp.Allow}, {"deny", tp.Deny}} {
+		for _, pattern := range list.patterns {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, NewValidationError("toolPolicy.%s: invalid pattern %q: %v", list.field, pattern, err)
+			}
+		}
+	}
+	return &adk.ToolPolicy{Allow: tp.Allow, Deny: tp.Deny}, nil
+}
+
+// applyToolPolicy removes the tools the policy denies from each MCP server's
+// explicit tool list. A server whose list becomes empty is dropped, since an
+// empty list would expose all of its tools. Servers without a list keep it
+// empty; the runtime filters their tools as they are discovered.
+func applyToolPolicy(cfg *adk.AgentConfig) {
+	if cfg.ToolPolicy == nil {
+		return
+	}
+	httpTools := cfg.HttpTools[:0]
+	for _, t := range cfg.HttpTools {
+		if len(t.Tools) > 0 {
+			if t.Tools = cfg.ToolPolicy.FilterNames(t.Tools); len(t.Tools) == 0 {
+				continue
+			}
+		}
+		httpTools = append(httpTools, t)
+	}
+	cfg.HttpTools = httpTools
+
+	sseTools := cfg.SseTools[:0]
+	for _, t := range cfg.SseTools {
+		if len(t.Tools) > 0 {
+			if t.Tools = cfg.ToolPolicy.FilterNames(t.Tools); len(t.Tools) == 0 {
+				continue
+			}
+		}
+		sseTools = append(sseTools, t)
+	}
+	cfg.SseTools = sseTools
+}
+
+// translateWorkflowPattern configures an agent created by a Workflow as the
+// orchestrator of its agent tools.
+func translateWorkflowPattern(pattern v1alpha2.WorkflowPattern) (*adk.WorkflowConfig, error) {
+	switch pattern {
+	case v1alpha2.WorkflowPatternSequential:
+		return &adk.WorkflowConfig{Pattern: adk.WorkflowPatternSequential}, nil
+	case v1alpha2.WorkflowPatternParallel:
+		return &adk.WorkflowConfig{Pattern: adk.WorkflowPatternParallel}, nil
+	case v1alpha2.WorkflowPatternRouter:
+		return &adk.WorkflowConfig{Pattern: adk.WorkflowPatternRouter}, nil
+	default:
+		return nil, NewValidationError("annotation %s: unknown workflow pattern %q", v1alpha2.WorkflowPatternAnnotation, pattern)
+	}
+}
+
+// translateLLMTrace returns the runtime config for recording sampled model
+// calls with their session.
+func translateLLMTrace(lt *v1alpha2.LLMTraceSpec) (*adk.LLMTraceConfig, error) {
+	cfg := &adk.LLMTraceConfig{
+		SampleRate:     1,
+		RedactPatterns: lt.RedactPatterns,
+	}
+	if lt.SampleRate != "" {
+		rate, err := strconv.ParseFloat(lt.SampleRate, 64)
+		if err != nil || rate < 0 || rate > 1 {
+			return nil, NewValidationError("llmTrace.sampleRate %q must be a number between 0 and 1", lt.SampleRate)
+		}
+		cfg.SampleRate = rate
+	}
+	for _, pattern := range lt.RedactPatterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return nil, NewValidationError("llmTrace.redactPatterns: invalid pattern %q: %v", pattern, err)
+		}
+	}
+	return cfg, nil
+}
+
+// translatePromptCapture mounts the capture claim into the agent pod and
+// returns the runtime config. Each agent writes under its own directory so a
+// claim can be shared.
+func translatePromptCapture(agentName string, pc *v1alpha2.PromptCaptureSpec, mdd *modelDeploymentData) (*adk.PromptCaptureConfig, error) {
+	cfg := &adk.PromptCaptureConfig{
+		Path:           path.Join(promptCaptureMount, agentName),
+		SampleRate:     1,
+		MaxFileBytes:   100 * 1024 * 1024,
+		MaxTotalBytes:  1024 * 1024 * 1024,
+		RedactPatterns: pc.RedactPatterns,
+	}
+	if pc.SampleRate != "" {
+		rate, err := strconv.ParseFloat(pc.SampleRate, 64)
+		if err != nil || rate < 0 || rate > 1 {
+			return nil, NewValidationError("promptCapture.sampleRate %q must be a number between 0 and 1", pc.SampleRate)
+		}
+		cfg.SampleRate = rate
+	}
+	if pc.MaxFileSize != nil {
+		cfg.MaxFileBytes = pc.MaxFileSize.Value()
+	}
+	if pc.MaxTotalSize != nil {
+		cfg.MaxTotalBytes = pc.MaxTotalSize.Value()
+	}
+	for _, pattern := range pc.RedactPatterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return nil, NewValidationError("promptCapture.redactPatterns: invalid pattern %q: %v", pattern, err)
+		}
+	}
+
+	mdd.Volumes = append(mdd.Volumes, corev1.Volume{
+		Name: promptCaptureVolume,
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: pc.ClaimName},
+		},
+	})
+	mdd.VolumeMounts = append(mdd.VolumeMounts, corev1.VolumeMount{
+		Name:      promptCaptureVolume,
+		MountPath: promptCaptureMount,
+	})
+	return cfg, nil
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"maps"
 	"slices"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -18,6 +19,9 @@ type modelDeploymentData struct {
 	EnvVars      []corev1.EnvVar
 	Volumes      []corev1.Volume
 	VolumeMounts []corev1.VolumeMount
+	// ModelEndpoints are the URLs of the model providers the agent calls,
+	// allowed by its egress NetworkPolicy.
+	ModelEndpoints []string
 }
 
 // Internal to translator – a unified deployment spec for any agent.
@@ -32,6 +36,9 @@ type resolvedDeployment struct {
 
 	// SharedDeploymentSpec merged
 	Replicas             *int32
+	Autoscaling          *v1alpha2.AutoscalingSpec
+	NetworkPolicy        *v1alpha2.AgentNetworkPolicySpec
+	ModelEndpoints       []string
 	ImagePullSecrets     []corev1.LocalObjectReference
 	Volumes              []corev1.Volume
 	VolumeMounts         []corev1.VolumeMount
@@ -42,11 +49,24 @@ type resolvedDeployment struct {
 	Tolerations          []corev1.Toleration
 	Affinity             *corev1.Affinity
 	NodeSelector         map[string]string
+	RuntimeClassName     *string
 	SecurityContext      *corev1.SecurityContext
 	PodSecurityContext   *corev1.PodSecurityContext
 	ServiceAccountName   *string
 	ServiceAccountConfig *v1alpha2.ServiceAccountConfig
 	ExtraContainers      []corev1.Container
+	InitContainers       []corev1.Container
+	Sidecars             []corev1.Container
+}
+
+// deploymentReplicas returns the replicas set on the agent Deployment. They
+// are left unset for scale-to-zero agents, whose replicas are managed by the
+// idle scaler and the A2A proxy, so reconciling does not wake idle agents.
+func deploymentReplicas(spec v1alpha2.SharedDeploymentSpec) *int32 {
+	if spec.ScaleToZero != nil {
+		return nil
+	}
+	return spec.Replicas
 }
 
 // getDefaultResources sets default resource requirements if not specified
@@ -101,22 +121,54 @@ func getRuntimeImageRepository(runtime v1alpha2.DeclarativeRuntime) string {
 	return DefaultImageConfig.Repository
 }
 
+// reservedContainerNames are the names of the containers kagent adds to agent
+// pods itself.
+var reservedContainerNames = []string{"kagent", "skills-init"}
+
 // validateExtraContainers checks that none of the extra containers use the
 // reserved name "kagent" and that no two containers share the same name.
 func validateExtraContainers(containers []corev1.Container) error {
+	return validateContainerNames("extraContainers", containers, map[string]bool{})
+}
+
+// validateUserContainers checks the names of the extra, init and sidecar
+// containers, which share the pod's container namespace.
+func validateUserContainers(spec v1alpha2.SharedDeploymentSpec) error {
 	seen := make(map[string]bool)
+	if err := validateContainerNames("extraContainers", spec.ExtraContainers, seen); err != nil {
+		return err
+	}
+	if err := validateContainerNames("initContainers", spec.InitContainers, seen); err != nil {
+		return err
+	}
+	return validateContainerNames("sidecars", spec.Sidecars, seen)
+}
+
+func validateContainerNames(field string, containers []corev1.Container, seen map[string]bool) error {
 	for _, c := range containers {
-		if c.Name == "kagent" {
-			return fmt.Errorf("extraContainers: %q is a reserved container name", c.Name)
+		if slices.Contains(reservedContainerNames, c.Name) {
+			return fmt.Errorf("%s: %q is a reserved container name", field, c.Name)
 		}
 		if seen[c.Name] {
-			return fmt.Errorf("extraContainers: duplicate container name %q", c.Name)
+			return fmt.Errorf("%s: duplicate container name %q", field, c.Name)
 		}
 		seen[c.Name] = true
 	}
 	return nil
 }
 
+// nativeSidecars returns the sidecars as init containers that keep running
+// for the lifetime of the pod.
+func nativeSidecars(sidecars []corev1.Container) []corev1.Container {
+	out := make([]corev1.Container, 0, len(sidecars))
+	for _, c := range sidecars {
+		c := *c.DeepCopy()
+		c.RestartPolicy = new(corev1.ContainerRestartPolicyAlways)
+		out = append(out, c)
+	}
+	return out
+}
+
 func resolvePythonRuntimeImage(registry string, full, pinDigest bool) (string, error) {
 	repo := DefaultImageConfig.Repository
 	digest := PythonADKImageDigest
@@ -179,9 +231,6 @@ func resolveInlineDeployment(agent v1alpha2.AgentObject, mdd *modelDeploymentDat
 		"/config",
 	}
 
-	serviceAccountName := new(string)
-	*serviceAccountName = agent.GetName()
-
 	// Start with spec deployment spec
 	spec := v1alpha2.DeclarativeDeploymentSpec{}
 	if specRef.Declarative.Deployment != nil {
@@ -209,7 +258,7 @@ func resolveInlineDeployment(agent v1alpha2.AgentObject, mdd *modelDeploymentDat
 	full := needsSRTSettings(agent, specRef.Sandbox)
 	// Substrate ActorTemplates reject tag refs, so sandbox agents pin by digest;
 	// everything else references by tag (resolvable in mirrored registries).
-	pinDigest := agent.GetWorkloadMode() == v1alpha2.WorkloadModeSandbox
+	pinDigest := agent.GetWorkloadMode() == v1alpha2.WorkloadModeSandbox || PinRuntimeImageDigests
 	switch runtime {
 	case v1alpha2.DeclarativeRuntime_Go:
 		var err error
@@ -230,15 +279,14 @@ func resolveInlineDeployment(agent v1alpha2.AgentObject, mdd *modelDeploymentDat
 		imagePullPolicy = corev1.PullPolicy(spec.ImagePullPolicy)
 	}
 
-	if DefaultImageConfig.PullSecret != "" {
+	for _, name := range defaultImagePullSecrets() {
 		// Only append if not already present
-		alreadyPresent := checkPullSecretAlreadyPresent(spec)
-		if !alreadyPresent {
-			spec.ImagePullSecrets = append(spec.ImagePullSecrets, corev1.LocalObjectReference{Name: DefaultImageConfig.PullSecret})
+		if !checkPullSecretAlreadyPresent(spec, name) {
+			spec.ImagePullSecrets = append(spec.ImagePullSecrets, corev1.LocalObjectReference{Name: name})
 		}
 	}
 
-	if err := validateExtraContainers(spec.ExtraContainers); err != nil {
+	if err := validateUserContainers(spec.SharedDeploymentSpec); err != nil {
 		return nil, err
 	}
 
@@ -247,7 +295,10 @@ func resolveInlineDeployment(agent v1alpha2.AgentObject, mdd *modelDeploymentDat
 		Args:                 args,
 		Port:                 port,
 		ImagePullPolicy:      imagePullPolicy,
-		Replicas:             spec.Replicas,
+		Replicas:             deploymentReplicas(spec.SharedDeploymentSpec),
+		Autoscaling:          spec.Autoscaling,
+		NetworkPolicy:        spec.NetworkPolicy,
+		ModelEndpoints:       slices.Clone(mdd.ModelEndpoints),
 		ImagePullSecrets:     slices.Clone(spec.ImagePullSecrets),
 		Volumes:              append(slices.Clone(spec.Volumes), mdd.Volumes...),
 		VolumeMounts:         append(slices.Clone(spec.VolumeMounts), mdd.VolumeMounts...),
@@ -258,34 +309,98 @@ func resolveInlineDeployment(agent v1alpha2.AgentObject, mdd *modelDeploymentDat
 		Tolerations:          slices.Clone(spec.Tolerations),
 		Affinity:             spec.Affinity,
 		NodeSelector:         getDefaultNodeSelector(spec.NodeSelector),
+		RuntimeClassName:     spec.RuntimeClassName,
 		SecurityContext:      spec.SecurityContext,
 		PodSecurityContext:   spec.PodSecurityContext,
 		ServiceAccountName:   spec.ServiceAccountName,
 		ServiceAccountConfig: spec.ServiceAccountConfig,
 		ExtraContainers:      slices.Clone(spec.ExtraContainers),
+		InitContainers:       slices.Clone(spec.InitContainers),
+		Sidecars:             nativeSidecars(spec.Sidecars),
 	}
 
-	// Precedence: agent-level serviceAccountName > global default > auto-created SA (agent name)
-	if dep.ServiceAccountName == nil {
-		if DefaultServiceAccountName != "" {
-			dep.ServiceAccountName = new(DefaultServiceAccountName)
-		} else {
-			dep.ServiceAccountName = serviceAccountName
-		}
-	}
+	dep.ServiceAccountName = new(serviceAccountNameFor(agent.GetName(), spec.SharedDeploymentSpec))
 
 	return dep, nil
 }
 
-func checkPullSecretAlreadyPresent(spec v1alpha2.DeclarativeDeploymentSpec) bool {
-	alreadyPresent := false
-	for _, secret := range spec.ImagePullSecrets {
-		if secret.Name == DefaultImageConfig.PullSecret {
-			alreadyPresent = true
-			break
+func checkPullSecretAlreadyPresent(spec v1alpha2.DeclarativeDeploymentSpec, name string) bool {
+	return slices.ContainsFunc(spec.ImagePullSecrets, func(secret corev1.LocalObjectReference) bool {
+		return secret.Name == name
+	})
+}
+
+// defaultImagePullSecrets returns the pull secrets added to every declarative
+// agent pod. DefaultImageConfig.PullSecret accepts a comma-separated list.
+func defaultImagePullSecrets() []string {
+	var names []string
+	for name := range strings.SplitSeq(DefaultImageConfig.PullSecret, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
 		}
 	}
-	return alreadyPresent
+	return names
+}
+
+// mirrorImageRef rewrites ref to pull from the mirror configured for its
+// registry in ImageRegistryMirrors. References without a registry host are
+// Docker Hub images, matched under "docker.io".
+func mirrorImageRef(ref string) string {
+	if len(ImageRegistryMirrors) == 0 || ref == "" {
+		return ref
+	}
+	registry, rest := "docker.io", ref
+	if first, remainder, ok := strings.Cut(ref, "/"); ok && (strings.ContainsAny(first, ".:") || first == "localhost") {
+		registry, rest = first, remainder
+	} else if !ok {
+		rest = "library/" + ref
+	}
+	if registry == "index.docker.io" {
+		registry = "docker.io"
+	}
+	mirror, ok := ImageRegistryMirrors[registry]
+	if !ok || mirror == "" {
+		return ref
+	}
+	return strings.TrimSuffix(mirror, "/") + "/" + rest
+}
+
+// applyImageRegistryMirrors rewrites every container image in the pod spec
+// through ImageRegistryMirrors.
+func applyImageRegistryMirrors(spec *corev1.PodSpec) {
+	for i := range spec.InitContainers {
+		spec.InitContainers[i].Image = mirrorImageRef(spec.InitContainers[i].Image)
+	}
+	for i := range spec.Containers {
+		spec.Containers[i].Image = mirrorImageRef(spec.Containers[i].Image)
+	}
+}
+
+// ServiceAccountName returns the name of the ServiceAccount the pods of
+// agent run as.
+func ServiceAccountName(agent v1alpha2.AgentObject) string {
+	var spec v1alpha2.SharedDeploymentSpec
+	agentSpec := agent.GetAgentSpec()
+	switch {
+	case agentSpec.Type == v1alpha2.AgentType_Declarative && agentSpec.Declarative != nil && agentSpec.Declarative.Deployment != nil:
+		spec = agentSpec.Declarative.Deployment.SharedDeploymentSpec
+	case agentSpec.Type == v1alpha2.AgentType_BYO && agentSpec.BYO != nil && agentSpec.BYO.Deployment != nil:
+		spec = agentSpec.BYO.Deployment.SharedDeploymentSpec
+	}
+	return serviceAccountNameFor(agent.GetName(), spec)
+}
+
+// serviceAccountNameFor returns the ServiceAccount of an agent's pods.
+// Precedence: agent-level serviceAccountName > global default > auto-created SA (agent name).
+// Agents declaring permissions always get their own SA, so the permissions aren't shared.
+func serviceAccountNameFor(agentName string, spec v1alpha2.SharedDeploymentSpec) string {
+	switch {
+	case spec.ServiceAccountName != nil:
+		return *spec.ServiceAccountName
+	case DefaultServiceAccountName != "" && len(serviceAccountRules(spec.ServiceAccountConfig)) == 0:
+		return DefaultServiceAccountName
+	}
+	return agentName
 }
 
 func resolveByoDeployment(agent v1alpha2.AgentObject) (*resolvedDeployment, error) {
@@ -318,9 +433,9 @@ func resolveByoDeployment(agent v1alpha2.AgentObject) (*resolvedDeployment, erro
 		imagePullPolicy = corev1.PullPolicy(spec.ImagePullPolicy)
 	}
 
-	replicas := spec.Replicas
+	replicas := deploymentReplicas(spec.SharedDeploymentSpec)
 
-	if err := validateExtraContainers(spec.ExtraContainers); err != nil {
+	if err := validateUserContainers(spec.SharedDeploymentSpec); err != nil {
 		return nil, err
 	}
 
@@ -332,6 +447,8 @@ func resolveByoDeployment(agent v1alpha2.AgentObject) (*resolvedDeployment, erro
 		Port:                 port,
 		ImagePullPolicy:      imagePullPolicy,
 		Replicas:             replicas,
+		Autoscaling:          spec.Autoscaling,
+		NetworkPolicy:        spec.NetworkPolicy,
 		ImagePullSecrets:     slices.Clone(spec.ImagePullSecrets),
 		Volumes:              slices.Clone(spec.Volumes),
 		VolumeMounts:         slices.Clone(spec.VolumeMounts),
@@ -342,23 +459,17 @@ func resolveByoDeployment(agent v1alpha2.AgentObject) (*resolvedDeployment, erro
 		Tolerations:          slices.Clone(spec.Tolerations),
 		Affinity:             spec.Affinity,
 		NodeSelector:         getDefaultNodeSelector(spec.NodeSelector),
+		RuntimeClassName:     spec.RuntimeClassName,
 		SecurityContext:      spec.SecurityContext,
 		PodSecurityContext:   spec.PodSecurityContext,
 		ServiceAccountName:   spec.ServiceAccountName,
 		ServiceAccountConfig: spec.ServiceAccountConfig,
 		ExtraContainers:      slices.Clone(spec.ExtraContainers),
+		InitContainers:       slices.Clone(spec.InitContainers),
+		Sidecars:             nativeSidecars(spec.Sidecars),
 	}
 
-	// Precedence: agent-level serviceAccountName > global default > auto-created SA (agent name)
-	if dep.ServiceAccountName == nil {
-		if DefaultServiceAccountName != "" {
-			dep.ServiceAccountName = new(string)
-			*dep.ServiceAccountName = DefaultServiceAccountName
-		} else {
-			dep.ServiceAccountName = new(string)
-			*dep.ServiceAccountName = agent.GetName()
-		}
-	}
+	dep.ServiceAccountName = new(serviceAccountNameFor(agent.GetName(), spec.SharedDeploymentSpec))
 
 	return dep, nil
 }
@@ -86,6 +86,69 @@ func TestValidateExtraContainers(t *testing.T) {
 	}
 }
 
+func TestValidateUserContainers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		spec    v1alpha2.SharedDeploymentSpec
+		wantErr bool
+	}{
+		{
+			name: "distinct names are fine",
+			spec: v1alpha2.SharedDeploymentSpec{
+				ExtraContainers: []corev1.Container{{Name: "log-shipper"}},
+				InitContainers:  []corev1.Container{{Name: "render-config"}},
+				Sidecars:        []corev1.Container{{Name: "credential-broker"}},
+			},
+		},
+		{
+			name: "init container named like an extra container is rejected",
+			spec: v1alpha2.SharedDeploymentSpec{
+				ExtraContainers: []corev1.Container{{Name: "proxy"}},
+				InitContainers:  []corev1.Container{{Name: "proxy"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "sidecar named like an init container is rejected",
+			spec: v1alpha2.SharedDeploymentSpec{
+				InitContainers: []corev1.Container{{Name: "proxy"}},
+				Sidecars:       []corev1.Container{{Name: "proxy"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "reserved skills-init name is rejected",
+			spec: v1alpha2.SharedDeploymentSpec{
+				InitContainers: []corev1.Container{{Name: "skills-init"}},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := validateUserContainers(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateUserContainers() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNativeSidecars(t *testing.T) {
+	sidecars := []corev1.Container{{Name: "credential-broker"}}
+	got := nativeSidecars(sidecars)
+	if len(got) != 1 || got[0].RestartPolicy == nil || *got[0].RestartPolicy != corev1.ContainerRestartPolicyAlways {
+		t.Fatalf("nativeSidecars() = %v, want restartPolicy Always", got)
+	}
+	if sidecars[0].RestartPolicy != nil {
+		t.Errorf("nativeSidecars() modified its input")
+	}
+}
+
 func TestGetDefaultNodeSelector(t *testing.T) {
 	setGlobal := func(t *testing.T, v map[string]string) {
 		t.Helper()
@@ -69,6 +69,7 @@ func egressTranslator(t *testing.T, mcpEgressPlaintext bool, proxyURL string, ob
 		proxyURL,
 		nil,
 		mcpEgressPlaintext,
+		nil,
 	)
 }
 
@@ -3,6 +3,7 @@ package agent_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -950,3 +951,71 @@ func Test_AdkApiTranslator_SkillsInitContainer(t *testing.T) {
 		})
 	}
 }
+
+type fakeSkillDigestResolver map[string]string
+
+func (f fakeSkillDigestResolver) ResolveSkillDigests(_ context.Context, _ string, refs []string, _ []corev1.LocalObjectReference, _ bool) (map[string]string, error) {
+	digests := map[string]string{}
+	var err error
+	for _, ref := range refs {
+		if digest, ok := f[ref]; ok {
+			digests[ref] = digest
+		} else {
+			err = errors.New("failed to resolve skill image " + ref)
+		}
+	}
+	return digests, err
+}
+
+func Test_AdkApiTranslator_SkillsPinnedDigests(t *testing.T) {
+	scheme := schemev1.Scheme
+	require.NoError(t, v1alpha2.AddToScheme(scheme))
+
+	const (
+		namespace = "default"
+		modelName = "test-model"
+		resolved  = "sha256:1111111111111111111111111111111111111111111111111111111111111111"
+		recorded  = "sha256:2222222222222222222222222222222222222222222222222222222222222222"
+	)
+	modelConfig := &v1alpha2.ModelConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: modelName, Namespace: namespace},
+		Spec:       v1alpha2.ModelConfigSpec{Model: "gpt-4", Provider: v1alpha2.ModelProviderOpenAI},
+	}
+	agent := &v1alpha2.Agent{
+		ObjectMeta: metav1.ObjectMeta{Name: "agent-pinned", Namespace: namespace},
+		Spec: v1alpha2.AgentSpec{
+			Type: v1alpha2.AgentType_Declarative,
+			Declarative: &v1alpha2.DeclarativeAgentSpec{
+				SystemMessage: "test",
+				ModelConfig:   modelName,
+			},
+			Skills: &v1alpha2.SkillForAgent{
+				Refs: []string{"ghcr.io/org/kubernetes:v1", "localhost:5000/helm:latest", "ghcr.io/org/istio"},
+			},
+		},
+		Status: v1alpha2.AgentStatus{
+			Skills: []v1alpha2.SkillStatus{{Ref: "localhost:5000/helm:latest", Digest: recorded}},
+		},
+	}
+
+	kubeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(modelConfig, agent).Build()
+	trans := translator.NewAdkApiTranslatorWithWatchedNamespaces(kubeClient, nil, types.NamespacedName{Namespace: namespace, Name: modelName}, nil, "", nil, false,
+		fakeSkillDigestResolver{"ghcr.io/org/kubernetes:v1": resolved})
+
+	inputs, err := trans.CompileAgent(context.Background(), agent)
+	require.NoError(t, err)
+	require.Error(t, inputs.SkillDigestsErr)
+	assert.Equal(t, map[string]string{
+		"ghcr.io/org/kubernetes:v1":  resolved,
+		"localhost:5000/helm:latest": recorded,
+	}, inputs.SkillDigests, "a ref that fails to resolve keeps its recorded digest")
+
+	outputs, err := trans.BuildManifest(context.Background(), agent, inputs)
+	require.NoError(t, err)
+	cfg := findSkillsInitConfig(t, outputs.Manifest, agent.Name)
+	assert.Equal(t, []skillsinit.OCIRef{
+		{Image: "ghcr.io/org/kubernetes@" + resolved, Dest: "/skills/kubernetes", Digest: resolved},
+		{Image: "localhost:5000/helm@" + recorded, Dest: "/skills/helm", Digest: recorded},
+		{Image: "ghcr.io/org/istio", Dest: "/skills/istio"},
+	}, cfg.OCIRefs)
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 
 	"github.com/kagent-dev/kagent/go/api/v1alpha2"
 )
@@ -186,3 +187,88 @@ func TestResolveInlineDeploymentImagePinning(t *testing.T) {
 	require.NoError(t, err)
 	require.Contains(t, sdep.Image, "@sha256:pin-test", "sandbox agents require digest-pinned images (Substrate rejects tag refs)")
 }
+
+func TestResolveInlineDeploymentPinRuntimeImageDigests(t *testing.T) {
+	original := PythonADKImageDigest
+	t.Cleanup(func() {
+		PythonADKImageDigest = original
+		PinRuntimeImageDigests = false
+	})
+	PythonADKImageDigest = "sha256:pin-test"
+	PinRuntimeImageDigests = true
+
+	agent := &v1alpha2.Agent{Spec: v1alpha2.AgentSpec{
+		Type:        v1alpha2.AgentType_Declarative,
+		Declarative: &v1alpha2.DeclarativeAgentSpec{SystemMessage: "test", ModelConfig: "test-model"},
+	}}
+	dep, err := resolveInlineDeployment(agent, &modelDeploymentData{})
+	require.NoError(t, err)
+	require.Contains(t, dep.Image, "@sha256:pin-test")
+}
+
+func TestResolveInlineDeploymentDefaultPullSecrets(t *testing.T) {
+	original := DefaultImageConfig.PullSecret
+	t.Cleanup(func() { DefaultImageConfig.PullSecret = original })
+	DefaultImageConfig.PullSecret = "mirror-creds, extra-creds"
+
+	agent := &v1alpha2.Agent{Spec: v1alpha2.AgentSpec{
+		Type: v1alpha2.AgentType_Declarative,
+		Declarative: &v1alpha2.DeclarativeAgentSpec{
+			SystemMessage: "test",
+			ModelConfig:   "test-model",
+			Deployment: &v1alpha2.DeclarativeDeploymentSpec{SharedDeploymentSpec: v1alpha2.SharedDeploymentSpec{
+				ImagePullSecrets: []corev1.LocalObjectReference{{Name: "extra-creds"}},
+			}},
+		},
+	}}
+	dep, err := resolveInlineDeployment(agent, &modelDeploymentData{})
+	require.NoError(t, err)
+	require.Equal(t, []corev1.LocalObjectReference{{Name: "extra-creds"}, {Name: "mirror-creds"}}, dep.ImagePullSecrets)
+}
+
+func TestMirrorImageRef(t *testing.T) {
+	original := ImageRegistryMirrors
+	t.Cleanup(func() { ImageRegistryMirrors = original })
+	ImageRegistryMirrors = map[string]string{
+		"ghcr.io":        "registry.local/ghcr",
+		"docker.io":      "registry.local/dockerhub/",
+		"localhost:5001": "registry.local",
+	}
+
+	tests := []struct {
+		ref  string
+		want string
+	}{
+		{ref: "ghcr.io/kagent-dev/kagent/app:v1.0.0", want: "registry.local/ghcr/kagent-dev/kagent/app:v1.0.0"},
+		{ref: "ghcr.io/kagent-dev/kagent/app@sha256:abc", want: "registry.local/ghcr/kagent-dev/kagent/app@sha256:abc"},
+		{ref: "localhost:5001/app:dev", want: "registry.local/app:dev"},
+		{ref: "busybox:1.36", want: "registry.local/dockerhub/library/busybox:1.36"},
+		{ref: "bitnami/kubectl", want: "registry.local/dockerhub/bitnami/kubectl"},
+		{ref: "index.docker.io/library/nginx", want: "registry.local/dockerhub/library/nginx"},
+		{ref: "quay.io/prometheus/node-exporter", want: "quay.io/prometheus/node-exporter"},
+		{ref: "", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			require.Equal(t, tt.want, mirrorImageRef(tt.ref))
+		})
+	}
+}
+
+func TestApplyImageRegistryMirrors(t *testing.T) {
+	original := ImageRegistryMirrors
+	t.Cleanup(func() { ImageRegistryMirrors = original })
+	ImageRegistryMirrors = map[string]string{"ghcr.io": "mirror.example.com"}
+
+	spec := corev1.PodSpec{
+		InitContainers: []corev1.Container{{Name: "skills-init", Image: "ghcr.io/kagent-dev/kagent/skills-init:v1"}},
+		Containers: []corev1.Container{
+			{Name: "kagent", Image: "ghcr.io/kagent-dev/kagent/app:v1"},
+			{Name: "sidecar", Image: "quay.io/example/sidecar:v2"},
+		},
+	}
+	applyImageRegistryMirrors(&spec)
+	require.Equal(t, "mirror.example.com/kagent-dev/kagent/skills-init:v1", spec.InitContainers[0].Image)
+	require.Equal(t, "mirror.example.com/kagent-dev/kagent/app:v1", spec.Containers[0].Image)
+	require.Equal(t, "quay.io/example/sidecar:v2", spec.Containers[1].Image)
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"maps"
+	"slices"
 
 	a2a "github.com/a2aproject/a2a-go/v2/a2a"
 	"github.com/a2aproject/a2a-go/v2/a2acompat/a2av0"
@@ -18,6 +19,7 @@ import (
 	"github.com/kagent-dev/kagent/go/core/pkg/env"
 	"github.com/kagent-dev/kagent/go/core/pkg/sandboxbackend"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -36,6 +38,7 @@ type manifestContext struct {
 	agent          v1alpha2.AgentObject
 	deployment     *resolvedDeployment
 	selectorLabels map[string]string
+	skillDigests   map[string]string
 }
 
 type configSecretInputs struct {
@@ -82,6 +85,7 @@ func (a *adkApiTranslator) BuildManifest(
 
 	outputs := &AgentOutputs{}
 	manifestCtx := newManifestContext(agent, inputs.Deployment)
+	manifestCtx.skillDigests = inputs.SkillDigests
 
 	configSecret, err := a.buildConfigSecret(ctx, manifestCtx, inputs.Config, inputs.Sandbox, inputs.AgentCard, inputs.SecretHashBytes)
 	if err != nil {
@@ -95,6 +99,9 @@ func (a *adkApiTranslator) BuildManifest(
 	if sa := buildServiceAccount(manifestCtx); sa != nil {
 		outputs.Manifest = append(outputs.Manifest, sa)
 	}
+	if role := buildRole(manifestCtx); role != nil {
+		outputs.Manifest = append(outputs.Manifest, role, buildRoleBinding(manifestCtx, role))
+	}
 
 	podRuntime, err := buildPodRuntime(manifestCtx, inputs.Config, inputs.Sandbox, configSecret.volumes, configSecret.mounts)
 	if err != nil {
@@ -115,8 +122,9 @@ func (a *adkApiTranslator) BuildManifest(
 	}
 
 	podTemplate := buildPodTemplate(manifestCtx, podRuntime, configHash)
+	applyImageRegistryMirrors(&podTemplate.Spec)
 
-	workloadObjects, err := a.buildWorkloadObjects(ctx, manifestCtx, podTemplate)
+	workloadObjects, err := a.buildWorkloadObjects(ctx, manifestCtx, podTemplate, inputs.Config)
 	if err != nil {
 		return nil, err
 	}
@@ -158,10 +166,17 @@ func (m manifestContext) podLabels() map[string]string {
 }
 
 func (m manifestContext) objectMeta() metav1.ObjectMeta {
+	annotations := m.agent.GetAnnotations()
+	if _, ok := annotations[v1alpha2.AgentLastVerifiedSpecAnnotation]; ok {
+		// The last verified spec is controller bookkeeping, not metadata for
+		// the generated objects.
+		annotations = maps.Clone(annotations)
+		delete(annotations, v1alpha2.AgentLastVerifiedSpecAnnotation)
+	}
 	return metav1.ObjectMeta{
 		Name:        m.agent.GetName(),
 		Namespace:   m.agent.GetNamespace(),
-		Annotations: m.agent.GetAnnotations(),
+		Annotations: annotations,
 		Labels:      m.podLabels(),
 	}
 }
@@ -217,8 +232,18 @@ func (a *adkApiTranslator) buildConfigSecret(
 		hashData := make([]byte, 0, len(secretData)+len(srtSettingsJSON))
 		hashData = append(hashData, secretData...)
 		hashData = append(hashData, srtSettingsJSON...)
+		hashCfg := []byte(cfgJSON)
+		if cfg != nil && reloadsConfig(manifestCtx.agent) {
+			// The go runtime applies the rest of its config in place, so
+			// only the settings it reads at startup roll the pod.
+			bRestart, err := json.Marshal(cfg.RestartSettings())
+			if err != nil {
+				return nil, err
+			}
+			hashCfg = bRestart
+		}
 		hashInput = configHashInput{
-			agentCfg:   []byte(cfgJSON),
+			agentCfg:   hashCfg,
 			agentCard:  []byte(agentCard),
 			secretData: hashData,
 		}
@@ -243,6 +268,13 @@ func (a *adkApiTranslator) buildConfigSecret(
 	}, nil
 }
 
+// reloadsConfig reports whether the agent runtime watches its mounted config
+// and reloads it without a restart.
+func reloadsConfig(agent v1alpha2.AgentObject) bool {
+	return agent.GetWorkloadMode() == v1alpha2.WorkloadModeDeployment &&
+		v1alpha2.EffectiveDeclarativeRuntime(agent.GetAgentSpec()) == v1alpha2.DeclarativeRuntime_Go
+}
+
 func buildConfigSecretData(cfgJSON, agentCard, srtSettingsJSON string) map[string]string {
 	data := map[string]string{
 		"config.json":     cfgJSON,
@@ -366,6 +398,11 @@ func buildSRTSettingsJSON(sandboxCfg *v1alpha2.SandboxConfig) ([]byte, error) {
 	})
 }
 
+// kagentControllerURL is the URL agents reach the kagent controller at.
+func kagentControllerURL() string {
+	return fmt.Sprintf("http://%s.%s:8083", utils.GetControllerName(), utils.GetResourceNamespace())
+}
+
 func collectSharedEnv(agent v1alpha2.AgentObject) []corev1.EnvVar {
 	sharedEnv := make([]corev1.EnvVar, 0, 8)
 	sharedEnv = append(sharedEnv, collectOtelEnvFromProcess()...)
@@ -382,7 +419,7 @@ func collectSharedEnv(agent v1alpha2.AgentObject) []corev1.EnvVar {
 		},
 		corev1.EnvVar{
 			Name:  env.KagentURL.Name(),
-			Value: fmt.Sprintf("http://%s.%s:8083", utils.GetControllerName(), utils.GetResourceNamespace()),
+			Value: kagentControllerURL(),
 		},
 	)
 	if uiURL := env.KagentUIURL.Get(); uiURL != "" {
@@ -444,6 +481,7 @@ func buildSkillsRuntime(
 		gitRefs,
 		spec.Skills.GitAuthSecretRef,
 		skills,
+		manifestCtx.skillDigests,
 		spec.Skills.InsecureSkipVerify,
 		manifestCtx.deployment.SecurityContext,
 		initEnv,
@@ -518,6 +556,8 @@ func buildPodTemplate(
 		workingDir = *dep.WorkingDir
 	}
 
+	// Sidecars start first so that the init containers and the agent can use
+	// them, e.g. a credential broker needed to fetch skills.
 	return corev1.PodTemplateSpec{
 		ObjectMeta: metav1.ObjectMeta{
 			Labels:      manifestCtx.podLabels(),
@@ -527,7 +567,7 @@ func buildPodTemplate(
 			ServiceAccountName: *dep.ServiceAccountName,
 			ImagePullSecrets:   dep.ImagePullSecrets,
 			SecurityContext:    dep.PodSecurityContext,
-			InitContainers:     runtimeInputs.initContainers,
+			InitContainers:     slices.Concat(dep.Sidecars, dep.InitContainers, runtimeInputs.initContainers),
 			Containers: append([]corev1.Container{{
 				Name:            "kagent",
 				Image:           dep.Image,
@@ -552,10 +592,11 @@ func buildPodTemplate(
 				SecurityContext: runtimeInputs.securityContext,
 				VolumeMounts:    runtimeInputs.volumeMounts,
 			}}, dep.ExtraContainers...),
-			Volumes:      runtimeInputs.volumes,
-			Tolerations:  dep.Tolerations,
-			Affinity:     dep.Affinity,
-			NodeSelector: dep.NodeSelector,
+			Volumes:          runtimeInputs.volumes,
+			Tolerations:      dep.Tolerations,
+			Affinity:         dep.Affinity,
+			NodeSelector:     dep.NodeSelector,
+			RuntimeClassName: dep.RuntimeClassName,
 		},
 	}
 }
@@ -568,8 +609,15 @@ func (a *adkApiTranslator) buildWorkloadObjects(
 	ctx context.Context,
 	manifestCtx manifestContext,
 	podTemplate corev1.PodTemplateSpec,
+	cfg *adk.AgentConfig,
 ) ([]client.Object, error) {
 	if manifestCtx.runInSandbox() {
+		if manifestCtx.deployment.Autoscaling != nil {
+			return nil, fmt.Errorf("autoscaling is not supported for sandboxed agents")
+		}
+		if manifestCtx.deployment.NetworkPolicy != nil {
+			return nil, fmt.Errorf("networkPolicy is not supported for sandboxed agents")
+		}
 		sbObjs, err := a.sandboxBackend.BuildSandbox(ctx, sandboxbackend.BuildInput{
 			Agent:       manifestCtx.agent,
 			PodTemplate: podTemplate,
@@ -593,7 +641,7 @@ func (a *adkApiTranslator) buildWorkloadObjects(
 		svcPort.AppProtocol = &proto
 	}
 
-	return []client.Object{
+	objs := []client.Object{
 		&appsv1.Deployment{
 			TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
 			ObjectMeta: manifestCtx.objectMeta(),
@@ -619,7 +667,81 @@ func (a *adkApiTranslator) buildWorkloadObjects(
 				Type:     corev1.ServiceTypeClusterIP,
 			},
 		},
-	}, nil
+	}
+	if autoscaling := manifestCtx.deployment.Autoscaling; autoscaling != nil {
+		objs = append(objs, buildHorizontalPodAutoscaler(manifestCtx, autoscaling))
+	}
+	if manifestCtx.deployment.NetworkPolicy != nil {
+		networkPolicy, err := a.buildNetworkPolicy(ctx, manifestCtx, cfg)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, networkPolicy)
+	}
+	return objs, nil
+}
+
+// defaultTargetCPUUtilization is the CPU target of an autoscaled agent that
+// sets no target of its own.
+const defaultTargetCPUUtilization int32 = 80
+
+// buildHorizontalPodAutoscaler builds the HPA that scales the agent
+// Deployment. The Deployment leaves replicas unset so the two don't fight.
+func buildHorizontalPodAutoscaler(manifestCtx manifestContext, spec *v1alpha2.AutoscalingSpec) *autoscalingv2.HorizontalPodAutoscaler {
+	var metrics []autoscalingv2.MetricSpec
+	resourceMetric := func(name corev1.ResourceName, utilization int32) autoscalingv2.MetricSpec {
+		return autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricSource{
+				Name: name,
+				Target: autoscalingv2.MetricTarget{
+					Type:               autoscalingv2.UtilizationMetricType,
+					AverageUtilization: &utilization,
+				},
+			},
+		}
+	}
+	if spec.TargetCPUUtilizationPercentage != nil {
+		metrics = append(metrics, resourceMetric(corev1.ResourceCPU, *spec.TargetCPUUtilizationPercentage))
+	}
+	if spec.TargetMemoryUtilizationPercentage != nil {
+		metrics = append(metrics, resourceMetric(corev1.ResourceMemory, *spec.TargetMemoryUtilizationPercentage))
+	}
+	if rps := spec.RequestsPerSecond; rps != nil {
+		metricName := rps.MetricName
+		if metricName == "" {
+			metricName = "http_requests_per_second"
+		}
+		averageValue := rps.AverageValue.DeepCopy()
+		metrics = append(metrics, autoscalingv2.MetricSpec{
+			Type: autoscalingv2.PodsMetricSourceType,
+			Pods: &autoscalingv2.PodsMetricSource{
+				Metric: autoscalingv2.MetricIdentifier{Name: metricName},
+				Target: autoscalingv2.MetricTarget{
+					Type:         autoscalingv2.AverageValueMetricType,
+					AverageValue: &averageValue,
+				},
+			},
+		})
+	}
+	if len(metrics) == 0 {
+		metrics = append(metrics, resourceMetric(corev1.ResourceCPU, defaultTargetCPUUtilization))
+	}
+
+	return &autoscalingv2.HorizontalPodAutoscaler{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "autoscaling/v2", Kind: "HorizontalPodAutoscaler"},
+		ObjectMeta: manifestCtx.objectMeta(),
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       manifestCtx.agent.GetName(),
+			},
+			MinReplicas: spec.MinReplicas,
+			MaxReplicas: spec.MaxReplicas,
+			Metrics:     metrics,
+		},
+	}
 }
 
 func (a *adkApiTranslator) setManifestOwnerReferences(
@@ -0,0 +1,209 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/kagent-dev/kagent/go/api/adk"
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// Public API endpoints of the model providers that don't set one in their
+// ModelConfig.
+const (
+	openAIEndpoint    = "https://api.openai.com"
+	anthropicEndpoint = "https://api.anthropic.com"
+	geminiEndpoint    = "https://generativelanguage.googleapis.com"
+	vertexAIEndpoint  = "https://aiplatform.googleapis.com"
+	bedrockEndpoint   = "https://bedrock-runtime.amazonaws.com"
+)
+
+// modelEndpoints returns the URLs the agent calls a model provider at. Its
+// public API is used when the ModelConfig doesn't set an endpoint.
+func modelEndpoints(spec *v1alpha2.ModelConfigSpec) []string {
+	var configured []string
+	public := ""
+	switch spec.Provider {
+	case v1alpha2.ModelProviderOpenAI:
+		public = openAIEndpoint
+		if spec.OpenAI != nil {
+			configured = append(configured, spec.OpenAI.BaseURL)
+		}
+	case v1alpha2.ModelProviderAnthropic:
+		public = anthropicEndpoint
+		if spec.Anthropic != nil {
+			configured = append(configured, spec.Anthropic.BaseURL)
+		}
+	case v1alpha2.ModelProviderAzureOpenAI:
+		if spec.AzureOpenAI != nil {
+			configured = append(configured, spec.AzureOpenAI.Endpoint)
+		}
+	case v1alpha2.ModelProviderOllama:
+		if spec.Ollama != nil && spec.Ollama.Host != "" {
+			configured = append(configured, ollamaBaseURL(spec.Ollama.Host))
+		}
+	case v1alpha2.ModelProviderGemini:
+		public = geminiEndpoint
+	case v1alpha2.ModelProviderGeminiVertexAI, v1alpha2.ModelProviderAnthropicVertexAI:
+		public = vertexAIEndpoint
+	case v1alpha2.ModelProviderBedrock:
+		public = bedrockEndpoint
+		if spec.Bedrock != nil {
+			configured = append(configured, spec.Bedrock.Endpoint)
+		}
+	case v1alpha2.ModelProviderSAPAICore:
+		if spec.SAPAICore != nil {
+			configured = append(configured, spec.SAPAICore.BaseURL, spec.SAPAICore.AuthURL)
+		}
+	case v1alpha2.ModelProviderOpenAICompatible:
+		if spec.OpenAICompatible != nil {
+			configured = append(configured, spec.OpenAICompatible.BaseURL)
+		}
+	}
+	configured = slices.DeleteFunc(configured, func(endpoint string) bool { return endpoint == "" })
+	if len(configured) == 0 && public != "" {
+		return []string{public}
+	}
+	return configured
+}
+
+// buildNetworkPolicy builds the NetworkPolicy restricting the egress of the
+// agent pods to DNS, the kagent controller, the agent's model endpoints and
+// the MCP servers, agents, memory store and moderation service in cfg. cfg is nil for BYO agents.
+func (a *adkApiTranslator) buildNetworkPolicy(ctx context.Context, manifestCtx manifestContext, cfg *adk.AgentConfig) (*networkingv1.NetworkPolicy, error) {
+	udp, tcp := corev1.ProtocolUDP, corev1.ProtocolTCP
+	dnsPort := intstr.FromInt32(53)
+	egress := []networkingv1.NetworkPolicyEgressRule{{
+		Ports: []networkingv1.NetworkPolicyPort{
+			{Protocol: &udp, Port: &dnsPort},
+			{Protocol: &tcp, Port: &dnsPort},
+		},
+	}}
+
+	endpoints := append([]string{kagentControllerURL()}, manifestCtx.deployment.ModelEndpoints...)
+	if cfg != nil {
+		for _, tool := range cfg.HttpTools {
+			endpoints = append(endpoints, tool.Params.Url)
+		}
+		for _, tool := range cfg.SseTools {
+			endpoints = append(endpoints, tool.Params.Url)
+		}
+		for _, remoteAgent := range cfg.RemoteAgents {
+			endpoints = append(endpoints, remoteAgent.Url)
+		}
+		if cfg.Memory != nil && cfg.Memory.Qdrant != nil {
+			endpoints = append(endpoints, cfg.Memory.Qdrant.URL)
+		}
+		if cfg.Moderation != nil && cfg.Moderation.Provider != nil {
+			endpoints = append(endpoints, cfg.Moderation.Provider.URL)
+		}
+	}
+	for _, endpoint := range endpoints {
+		rule, err := a.egressRule(ctx, manifestCtx.agent.GetNamespace(), endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve egress to %q: %w", endpoint, err)
+		}
+		if !slices.ContainsFunc(egress, func(r networkingv1.NetworkPolicyEgressRule) bool { return reflect.DeepEqual(r, rule) }) {
+			egress = append(egress, rule)
+		}
+	}
+	egress = append(egress, manifestCtx.deployment.NetworkPolicy.AdditionalEgress...)
+
+	return &networkingv1.NetworkPolicy{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "networking.k8s.io/v1", Kind: "NetworkPolicy"},
+		ObjectMeta: manifestCtx.objectMeta(),
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{MatchLabels: manifestCtx.selectorLabels},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+			Egress:      egress,
+		},
+	}, nil
+}
+
+// egressRule allows TCP traffic to endpoint. An endpoint served by a Service
+// is allowed to the pods behind it, on the target port; an IP address to that
+// address. Any other hostname can only be matched by its port.
+func (a *adkApiTranslator) egressRule(ctx context.Context, namespace, endpoint string) (networkingv1.NetworkPolicyEgressRule, error) {
+	var rule networkingv1.NetworkPolicyEgressRule
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return rule, err
+	}
+	port := 80
+	if u.Scheme == "https" {
+		port = 443
+	}
+	if u.Port() != "" {
+		if port, err = strconv.Atoi(u.Port()); err != nil {
+			return rule, fmt.Errorf("invalid port: %w", err)
+		}
+	}
+	tcp := corev1.ProtocolTCP
+	targetPort := intstr.FromInt(port)
+
+	if addr, err := netip.ParseAddr(u.Hostname()); err == nil {
+		rule.To = []networkingv1.NetworkPolicyPeer{{
+			IPBlock: &networkingv1.IPBlock{CIDR: netip.PrefixFrom(addr, addr.BitLen()).String()},
+		}}
+	} else {
+		svc, err := a.lookupService(ctx, namespace, u.Hostname())
+		if err != nil {
+			return rule, err
+		}
+		if svc != nil && len(svc.Spec.Selector) > 0 {
+			for _, p := range svc.Spec.Ports {
+				if int(p.Port) == port && p.TargetPort != (intstr.IntOrString{}) {
+					targetPort = p.TargetPort
+				}
+			}
+			rule.To = []networkingv1.NetworkPolicyPeer{{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{corev1.LabelMetadataName: svc.Namespace}},
+				PodSelector:       &metav1.LabelSelector{MatchLabels: svc.Spec.Selector},
+			}}
+		}
+	}
+	rule.Ports = []networkingv1.NetworkPolicyPort{{Protocol: &tcp, Port: &targetPort}}
+	return rule, nil
+}
+
+// lookupService returns the Service that host resolves to from the agent's
+// namespace: name, name.namespace, or name.namespace.svc[.<cluster domain>].
+// It returns nil if host is not a Service the controller can see.
+func (a *adkApiTranslator) lookupService(ctx context.Context, namespace, host string) (*corev1.Service, error) {
+	if host == "" {
+		return nil, nil
+	}
+	parts := strings.Split(strings.TrimSuffix(host, "."), ".")
+	name := parts[0]
+	switch {
+	case len(parts) == 1:
+	case len(parts) == 2 || parts[2] == "svc":
+		namespace = parts[1]
+	default:
+		return nil, nil
+	}
+	if len(a.watchedNamespaces) > 0 && !slices.Contains(a.watchedNamespaces, namespace) {
+		return nil, nil
+	}
+
+	svc := &corev1.Service{}
+	if err := a.kube.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, svc); err != nil {
+		if apierrors.IsNotFound(err) || apierrors.IsForbidden(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return svc, nil
+}
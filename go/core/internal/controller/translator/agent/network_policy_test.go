@@ -0,0 +1,98 @@
+package agent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+)
+
+func TestModelEndpoints(t *testing.T) {
+	tests := []struct {
+		name string
+		spec v1alpha2.ModelConfigSpec
+		want []string
+	}{
+		{
+			name: "public OpenAI",
+			spec: v1alpha2.ModelConfigSpec{Provider: v1alpha2.ModelProviderOpenAI},
+			want: []string{openAIEndpoint},
+		},
+		{
+			name: "OpenAI base URL",
+			spec: v1alpha2.ModelConfigSpec{Provider: v1alpha2.ModelProviderOpenAI, OpenAI: &v1alpha2.OpenAIConfig{BaseURL: "http://litellm:4000"}},
+			want: []string{"http://litellm:4000"},
+		},
+		{
+			name: "Ollama host without scheme",
+			spec: v1alpha2.ModelConfigSpec{Provider: v1alpha2.ModelProviderOllama, Ollama: &v1alpha2.OllamaConfig{Host: "ollama.ollama:11434"}},
+			want: []string{"http://ollama.ollama:11434"},
+		},
+		{
+			name: "SAP AI Core with auth URL",
+			spec: v1alpha2.ModelConfigSpec{Provider: v1alpha2.ModelProviderSAPAICore, SAPAICore: &v1alpha2.SAPAICoreConfig{BaseURL: "https://api.ai.sap", AuthURL: "https://auth.sap"}},
+			want: []string{"https://api.ai.sap", "https://auth.sap"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, modelEndpoints(&tt.spec))
+		})
+	}
+}
+
+func TestEgressRule(t *testing.T) {
+	kube := fake.NewClientBuilder().WithObjects(
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "tools", Namespace: "test"},
+			Spec: corev1.ServiceSpec{
+				Selector: map[string]string{"app": "tools"},
+				Ports:    []corev1.ServicePort{{Port: 80, TargetPort: intstr.FromInt32(8084)}},
+			},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "external", Namespace: "test"},
+			Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeExternalName, ExternalName: "example.com"},
+		},
+	).Build()
+	a := &adkApiTranslator{kube: kube}
+
+	tcp := corev1.ProtocolTCP
+	port := func(p intstr.IntOrString) []networkingv1.NetworkPolicyPort {
+		return []networkingv1.NetworkPolicyPort{{Protocol: &tcp, Port: &p}}
+	}
+	toolsPeer := []networkingv1.NetworkPolicyPeer{{
+		NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{corev1.LabelMetadataName: "test"}},
+		PodSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"app": "tools"}},
+	}}
+
+	tests := []struct {
+		endpoint string
+		want     networkingv1.NetworkPolicyEgressRule
+	}{
+		{"http://tools/mcp", networkingv1.NetworkPolicyEgressRule{Ports: port(intstr.FromInt32(8084)), To: toolsPeer}},
+		{"http://tools.test.svc.cluster.local:80/mcp", networkingv1.NetworkPolicyEgressRule{Ports: port(intstr.FromInt32(8084)), To: toolsPeer}},
+		{"http://external:8080", networkingv1.NetworkPolicyEgressRule{Ports: port(intstr.FromInt(8080))}},
+		{"https://api.openai.com", networkingv1.NetworkPolicyEgressRule{Ports: port(intstr.FromInt(443))}},
+		{"http://example.com", networkingv1.NetworkPolicyEgressRule{Ports: port(intstr.FromInt(80))}},
+		{"http://10.1.2.3:6333", networkingv1.NetworkPolicyEgressRule{
+			Ports: port(intstr.FromInt(6333)),
+			To:    []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: "10.1.2.3/32"}}},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.endpoint, func(t *testing.T) {
+			got, err := a.egressRule(context.Background(), "test", tt.endpoint)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
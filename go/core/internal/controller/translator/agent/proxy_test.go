@@ -257,6 +257,7 @@ func TestProxyConfiguration_RemoteMCPServer_FallsBackToWatchedNamespacesWhenName
 		"http://proxy.kagent.svc.cluster.local:8080",
 		nil,
 		false,
+		nil,
 	)
 
 	result, err := agenttranslator.TranslateAgent(ctx, translator, agent)
@@ -0,0 +1,128 @@
+package agent
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// serviceAccountRules returns the permissions declared for the ServiceAccount
+// of a deployment spec.
+func serviceAccountRules(config *v1alpha2.ServiceAccountConfig) []v1alpha2.ServiceAccountRule {
+	if config == nil {
+		return nil
+	}
+	return config.Rules
+}
+
+// ruleAPIGroups returns the API groups of rule, defaulting to the core group.
+func ruleAPIGroups(rule v1alpha2.ServiceAccountRule) []string {
+	if len(rule.APIGroups) == 0 {
+		return []string{""}
+	}
+	return slices.Clone(rule.APIGroups)
+}
+
+// buildRole builds the Role granting the agent's declared permissions, or nil
+// when there are none or the agent runs as a ServiceAccount it doesn't own.
+func buildRole(manifestCtx manifestContext) *rbacv1.Role {
+	rules := serviceAccountRules(manifestCtx.deployment.ServiceAccountConfig)
+	serviceAccountName := manifestCtx.deployment.ServiceAccountName
+	if len(rules) == 0 || serviceAccountName == nil || *serviceAccountName != manifestCtx.agent.GetName() {
+		return nil
+	}
+	role := &rbacv1.Role{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "Role"},
+		ObjectMeta: manifestCtx.objectMeta(),
+	}
+	for _, rule := range rules {
+		role.Rules = append(role.Rules, rbacv1.PolicyRule{
+			APIGroups:     ruleAPIGroups(rule),
+			Resources:     slices.Clone(rule.Resources),
+			ResourceNames: slices.Clone(rule.ResourceNames),
+			Verbs:         slices.Clone(rule.Verbs),
+		})
+	}
+	return role
+}
+
+// buildRoleBinding binds role to the agent's ServiceAccount.
+func buildRoleBinding(manifestCtx manifestContext, role *rbacv1.Role) *rbacv1.RoleBinding {
+	return &rbacv1.RoleBinding{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "RoleBinding"},
+		ObjectMeta: manifestCtx.objectMeta(),
+		RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: role.Name},
+		Subjects: []rbacv1.Subject{{
+			Kind:      rbacv1.ServiceAccountKind,
+			Name:      *manifestCtx.deployment.ServiceAccountName,
+			Namespace: manifestCtx.agent.GetNamespace(),
+		}},
+	}
+}
+
+// AllowedServiceAccountRules are the rules agents may be granted through
+// serviceAccountConfig.rules, set by the kagent administrator. Every rule an
+// agent declares must be within one of them; with none, agents cannot be
+// granted any permissions.
+var AllowedServiceAccountRules []v1alpha2.ServiceAccountRule
+
+// validateServiceAccountRules rejects declared rules that are not within the
+// allowed rules, so that creating an agent cannot grant its ServiceAccount
+// more than the administrator allows.
+func validateServiceAccountRules(config *v1alpha2.ServiceAccountConfig) error {
+	for _, rule := range serviceAccountRules(config) {
+		if !slices.ContainsFunc(AllowedServiceAccountRules, func(allowed v1alpha2.ServiceAccountRule) bool {
+			return ruleWithin(rule, allowed)
+		}) {
+			return NewValidationError("serviceAccountConfig.rules: %s is not allowed for agents", describeRule(rule))
+		}
+	}
+	return nil
+}
+
+// ruleWithin reports whether allowed grants everything rule grants. A "*" in
+// rule is only within a "*" in allowed.
+func ruleWithin(rule, allowed v1alpha2.ServiceAccountRule) bool {
+	for _, group := range ruleAPIGroups(rule) {
+		if !matchesAny(ruleAPIGroups(allowed), group) {
+			return false
+		}
+	}
+	for _, resource := range rule.Resources {
+		if !matchesAny(allowed.Resources, resource) {
+			return false
+		}
+	}
+	for _, verb := range rule.Verbs {
+		if !matchesAny(allowed.Verbs, verb) {
+			return false
+		}
+	}
+	if len(allowed.ResourceNames) == 0 {
+		return true
+	}
+	return len(rule.ResourceNames) > 0 && !slices.ContainsFunc(rule.ResourceNames, func(name string) bool {
+		return !slices.Contains(allowed.ResourceNames, name)
+	})
+}
+
+// describeRule describes rule for validation errors.
+func describeRule(rule v1alpha2.ServiceAccountRule) string {
+	description := fmt.Sprintf("%s on %s", strings.Join(rule.Verbs, ", "), strings.Join(rule.Resources, ", "))
+	if groups := ruleAPIGroups(rule); !slices.Equal(groups, []string{""}) {
+		description += " in API groups " + strings.Join(groups, ", ")
+	}
+	if len(rule.ResourceNames) > 0 {
+		description += " named " + strings.Join(rule.ResourceNames, ", ")
+	}
+	return description
+}
+
+// matchesAny reports whether values contain value or the "*" wildcard.
+func matchesAny(values []string, value string) bool {
+	return slices.Contains(values, value) || slices.Contains(values, "*")
+}
@@ -0,0 +1,184 @@
+package agent_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	translator "github.com/kagent-dev/kagent/go/core/internal/controller/translator/agent"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	schemev1 "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func Test_AdkApiTranslator_ServiceAccountRules(t *testing.T) {
+	scheme := schemev1.Scheme
+	require.NoError(t, v1alpha2.AddToScheme(scheme))
+
+	// Agents declaring permissions get their own ServiceAccount even when a
+	// default one is configured.
+	origSA := translator.DefaultServiceAccountName
+	translator.DefaultServiceAccountName = "shared-agent-sa"
+	defer func() { translator.DefaultServiceAccountName = origSA }()
+
+	origRules := translator.AllowedServiceAccountRules
+	translator.AllowedServiceAccountRules = []v1alpha2.ServiceAccountRule{
+		{Resources: []string{"pods", "pods/log"}, Verbs: []string{"get", "list", "watch"}},
+		{APIGroups: []string{"apps"}, Resources: []string{"deployments"}, Verbs: []string{"*"}},
+	}
+	defer func() { translator.AllowedServiceAccountRules = origRules }()
+
+	agent := &v1alpha2.Agent{
+		ObjectMeta: metav1.ObjectMeta{Name: "k8s-agent", Namespace: "default"},
+		Spec: v1alpha2.AgentSpec{
+			Type: v1alpha2.AgentType_Declarative,
+			Declarative: &v1alpha2.DeclarativeAgentSpec{
+				SystemMessage: "System message",
+				ModelConfig:   "test-model",
+				Deployment: &v1alpha2.DeclarativeDeploymentSpec{
+					SharedDeploymentSpec: v1alpha2.SharedDeploymentSpec{
+						ServiceAccountConfig: &v1alpha2.ServiceAccountConfig{
+							Rules: []v1alpha2.ServiceAccountRule{
+								{Resources: []string{"pods", "pods/log"}, Verbs: []string{"get", "list"}},
+								{APIGroups: []string{"apps"}, Resources: []string{"deployments"}, ResourceNames: []string{"web"}, Verbs: []string{"patch"}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	modelConfig := &v1alpha2.ModelConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-model", Namespace: "default"},
+		Spec:       v1alpha2.ModelConfigSpec{Model: "gpt-4", Provider: v1alpha2.ModelProviderOpenAI},
+	}
+	kubeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(modelConfig, agent).Build()
+	trans := translator.NewAdkApiTranslator(kubeClient, types.NamespacedName{Namespace: "default", Name: "test-model"}, nil, "", nil)
+
+	outputs, err := translator.TranslateAgent(context.Background(), trans, agent)
+	require.NoError(t, err)
+
+	var (
+		deployment     *appsv1.Deployment
+		serviceAccount *corev1.ServiceAccount
+		role           *rbacv1.Role
+		roleBinding    *rbacv1.RoleBinding
+	)
+	for _, obj := range outputs.Manifest {
+		switch o := obj.(type) {
+		case *appsv1.Deployment:
+			deployment = o
+		case *corev1.ServiceAccount:
+			serviceAccount = o
+		case *rbacv1.Role:
+			role = o
+		case *rbacv1.RoleBinding:
+			roleBinding = o
+		}
+	}
+	require.NotNil(t, deployment)
+	assert.Equal(t, "k8s-agent", deployment.Spec.Template.Spec.ServiceAccountName)
+	require.NotNil(t, serviceAccount)
+
+	require.NotNil(t, role)
+	assert.Equal(t, "k8s-agent", role.Name)
+	assert.Equal(t, "default", role.Namespace)
+	assert.Equal(t, []rbacv1.PolicyRule{
+		{APIGroups: []string{""}, Resources: []string{"pods", "pods/log"}, Verbs: []string{"get", "list"}},
+		{APIGroups: []string{"apps"}, Resources: []string{"deployments"}, ResourceNames: []string{"web"}, Verbs: []string{"patch"}},
+	}, role.Rules)
+	require.Len(t, role.OwnerReferences, 1)
+	assert.Equal(t, "k8s-agent", role.OwnerReferences[0].Name)
+
+	require.NotNil(t, roleBinding)
+	assert.Equal(t, rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: "k8s-agent"}, roleBinding.RoleRef)
+	assert.Equal(t, []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: "k8s-agent", Namespace: "default"}}, roleBinding.Subjects)
+}
+
+func Test_AdkApiTranslator_ServiceAccountRulesAllowlist(t *testing.T) {
+	scheme := schemev1.Scheme
+	require.NoError(t, v1alpha2.AddToScheme(scheme))
+
+	origRules := translator.AllowedServiceAccountRules
+	translator.AllowedServiceAccountRules = []v1alpha2.ServiceAccountRule{
+		{Resources: []string{"pods", "pods/log"}, Verbs: []string{"get", "list", "watch"}},
+		{APIGroups: []string{"apps"}, Resources: []string{"deployments"}, ResourceNames: []string{"web", "api"}, Verbs: []string{"get", "patch"}},
+	}
+	defer func() { translator.AllowedServiceAccountRules = origRules }()
+
+	tests := []struct {
+		name    string
+		rules   []v1alpha2.ServiceAccountRule
+		wantErr string
+	}{
+		{
+			name:  "rules within the allowed rules",
+			rules: []v1alpha2.ServiceAccountRule{{Resources: []string{"pods/log"}, Verbs: []string{"get"}}},
+		},
+		{
+			name:  "resource names within the allowed names",
+			rules: []v1alpha2.ServiceAccountRule{{APIGroups: []string{"apps"}, Resources: []string{"deployments"}, ResourceNames: []string{"web"}, Verbs: []string{"patch"}}},
+		},
+		{
+			name:    "verb beyond the allowed rules",
+			rules:   []v1alpha2.ServiceAccountRule{{Resources: []string{"pods"}, Verbs: []string{"get", "delete"}}},
+			wantErr: "get, delete on pods is not allowed",
+		},
+		{
+			name:    "wildcards",
+			rules:   []v1alpha2.ServiceAccountRule{{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"*"}}},
+			wantErr: "* on * in API groups * is not allowed",
+		},
+		{
+			name:    "secrets",
+			rules:   []v1alpha2.ServiceAccountRule{{Resources: []string{"secrets"}, Verbs: []string{"get"}}},
+			wantErr: "get on secrets is not allowed",
+		},
+		{
+			name:    "all objects where only named ones are allowed",
+			rules:   []v1alpha2.ServiceAccountRule{{APIGroups: []string{"apps"}, Resources: []string{"deployments"}, Verbs: []string{"patch"}}},
+			wantErr: "patch on deployments in API groups apps is not allowed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agent := &v1alpha2.Agent{
+				ObjectMeta: metav1.ObjectMeta{Name: "k8s-agent", Namespace: "default"},
+				Spec: v1alpha2.AgentSpec{
+					Type: v1alpha2.AgentType_Declarative,
+					Declarative: &v1alpha2.DeclarativeAgentSpec{
+						SystemMessage: "System message",
+						ModelConfig:   "test-model",
+						Deployment: &v1alpha2.DeclarativeDeploymentSpec{
+							SharedDeploymentSpec: v1alpha2.SharedDeploymentSpec{
+								ServiceAccountConfig: &v1alpha2.ServiceAccountConfig{Rules: tt.rules},
+							},
+						},
+					},
+				},
+			}
+			modelConfig := &v1alpha2.ModelConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-model", Namespace: "default"},
+				Spec:       v1alpha2.ModelConfigSpec{Model: "gpt-4", Provider: v1alpha2.ModelProviderOpenAI},
+			}
+			kubeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(modelConfig, agent).Build()
+			trans := translator.NewAdkApiTranslator(kubeClient, types.NamespacedName{Namespace: "default", Name: "test-model"}, nil, "", nil)
+
+			_, err := translator.TranslateAgent(context.Background(), trans, agent)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			var validationErr *translator.ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/kagent-dev/kagent/go/api/v1alpha2"
 	translator "github.com/kagent-dev/kagent/go/core/internal/controller/translator/agent"
+	"github.com/kagent-dev/kagent/go/core/pkg/consts"
 )
 
 func withGoRuntimeDigests(t *testing.T) {
@@ -560,3 +561,99 @@ func TestRuntime_GoImageConfig_ExplicitPullPolicy(t *testing.T) {
 	assert.Equal(t, corev1.PullAlways, goPullPolicy, "Go runtime should use the explicit pull policy")
 	assert.NotEqual(t, corev1.PullAlways, pythonPullPolicy, "Python runtime must not inherit the Go-specific pull policy")
 }
+
+// TestRuntime_GoRuntimeConfigHashIgnoresReloadableSettings checks that only
+// config the go runtime reads at startup rolls its pod: it reloads the rest,
+// such as the system message, in place. The python runtime rolls on any change.
+func TestRuntime_GoRuntimeConfigHashIgnoresReloadableSettings(t *testing.T) {
+	withGoRuntimeDigests(t)
+	scheme := schemev1.Scheme
+	require.NoError(t, v1alpha2.AddToScheme(scheme))
+
+	configHash := func(runtime v1alpha2.DeclarativeRuntime, systemMessage string, stream bool) string {
+		agent := &v1alpha2.Agent{
+			ObjectMeta: metav1.ObjectMeta{Name: "reload-agent", Namespace: "test"},
+			Spec: v1alpha2.AgentSpec{
+				Type: v1alpha2.AgentType_Declarative,
+				Declarative: &v1alpha2.DeclarativeAgentSpec{
+					Runtime:       runtime,
+					SystemMessage: systemMessage,
+					ModelConfig:   "test-model",
+					Stream:        stream,
+				},
+			},
+		}
+		modelConfig := &v1alpha2.ModelConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-model", Namespace: "test"},
+			Spec:       v1alpha2.ModelConfigSpec{Provider: "OpenAI", Model: "gpt-4o"},
+		}
+		kubeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(agent, modelConfig).Build()
+		trans := translator.NewAdkApiTranslator(kubeClient, types.NamespacedName{Namespace: "test", Name: "test-model"}, nil, "", nil)
+		result, err := translator.TranslateAgent(context.Background(), trans, agent)
+		require.NoError(t, err)
+		return findDeployment(t, result).Spec.Template.Annotations[consts.ConfigHashAnnotation]
+	}
+
+	goHash := configHash(v1alpha2.DeclarativeRuntime_Go, "You are helpful.", true)
+	assert.NotEmpty(t, goHash)
+	assert.Equal(t, goHash, configHash(v1alpha2.DeclarativeRuntime_Go, "You are very helpful.", true),
+		"a system message change must not roll a go runtime agent")
+	assert.NotEqual(t, goHash, configHash(v1alpha2.DeclarativeRuntime_Go, "You are helpful.", false),
+		"a stream change must roll a go runtime agent")
+
+	pythonHash := configHash(v1alpha2.DeclarativeRuntime_Python, "You are helpful.", true)
+	assert.NotEqual(t, pythonHash, configHash(v1alpha2.DeclarativeRuntime_Python, "You are very helpful.", true),
+		"a system message change must roll a python runtime agent")
+}
+
+func TestRuntime_GoOnlyFieldsRejectedForPython(t *testing.T) {
+	withPythonRuntimeDigest(t)
+	ctx := context.Background()
+
+	scheme := schemev1.Scheme
+	require.NoError(t, v1alpha2.AddToScheme(scheme))
+	modelConfig := &v1alpha2.ModelConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-model", Namespace: "test"},
+		Spec:       v1alpha2.ModelConfigSpec{Provider: "OpenAI", Model: "gpt-4o"},
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(spec *v1alpha2.AgentSpec)
+	}{
+		{name: "streaming", mutate: func(spec *v1alpha2.AgentSpec) {
+			spec.Streaming = &v1alpha2.StreamingConfig{MaxEventSize: new(int32(1024))}
+		}},
+		{name: "promptCapture", mutate: func(spec *v1alpha2.AgentSpec) {
+			spec.Declarative.PromptCapture = &v1alpha2.PromptCaptureSpec{ClaimName: "captures"}
+		}},
+		{name: "llmTrace", mutate: func(spec *v1alpha2.AgentSpec) {
+			spec.Declarative.LLMTrace = &v1alpha2.LLMTraceSpec{SampleRate: "0.1"}
+		}},
+	}
+	for _, tt := range tests {
+		for _, runtime := range []v1alpha2.DeclarativeRuntime{v1alpha2.DeclarativeRuntime_Python, ""} {
+			t.Run(tt.name+"/"+string(runtime), func(t *testing.T) {
+				agent := &v1alpha2.Agent{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-agent", Namespace: "test"},
+					Spec: v1alpha2.AgentSpec{
+						Type: v1alpha2.AgentType_Declarative,
+						Declarative: &v1alpha2.DeclarativeAgentSpec{
+							Runtime:       runtime,
+							SystemMessage: "Test agent",
+							ModelConfig:   "test-model",
+						},
+					},
+				}
+				tt.mutate(&agent.Spec)
+				kubeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(agent, modelConfig).Build()
+				translatorInstance := translator.NewAdkApiTranslator(kubeClient, types.NamespacedName{Namespace: "test", Name: "test-model"}, nil, "", nil)
+
+				_, err := translator.TranslateAgent(ctx, translatorInstance, agent)
+				var validationErr *translator.ValidationError
+				require.ErrorAs(t, err, &validationErr)
+				assert.Contains(t, err.Error(), tt.name+" is only supported by the go runtime")
+			})
+		}
+	}
+}
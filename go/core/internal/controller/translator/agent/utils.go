@@ -44,8 +44,16 @@ func GetA2AAgentCard(agent v1alpha2.AgentObject) *a2atype.AgentCard {
 		}
 	}
 	if spec.Type == v1alpha2.AgentType_Declarative && spec.Declarative != nil && spec.Declarative.A2AConfig != nil {
-		card.Skills = make([]a2atype.AgentSkill, 0, len(spec.Declarative.A2AConfig.Skills))
-		for _, skill := range spec.Declarative.A2AConfig.Skills {
+		a2aConfig := spec.Declarative.A2AConfig
+		if len(a2aConfig.DefaultInputModes) > 0 {
+			card.DefaultInputModes = a2aConfig.DefaultInputModes
+		}
+		if len(a2aConfig.DefaultOutputModes) > 0 {
+			card.DefaultOutputModes = a2aConfig.DefaultOutputModes
+		}
+		card.SecuritySchemes, card.SecurityRequirements = a2aSecuritySchemes(a2aConfig.SecuritySchemes)
+		card.Skills = make([]a2atype.AgentSkill, 0, len(a2aConfig.Skills))
+		for _, skill := range a2aConfig.Skills {
 			card.Skills = append(card.Skills, a2atype.AgentSkill{
 				ID:          skill.ID,
 				Name:        skill.Name,
@@ -59,3 +67,71 @@ func GetA2AAgentCard(agent v1alpha2.AgentObject) *a2atype.AgentCard {
 	}
 	return &card
 }
+
+// a2aSecuritySchemes converts the security schemes of an agent's A2AConfig
+// for its AgentCard. Each scheme is a requirement on its own, so a client
+// may satisfy any one of them.
+func a2aSecuritySchemes(schemes []v1alpha2.A2ASecurityScheme) (a2atype.NamedSecuritySchemes, a2atype.SecurityRequirementsOptions) {
+	if len(schemes) == 0 {
+		return nil, nil
+	}
+	named := make(a2atype.NamedSecuritySchemes, len(schemes))
+	requirements := make(a2atype.SecurityRequirementsOptions, 0, len(schemes))
+	for _, s := range schemes {
+		var scheme a2atype.SecurityScheme
+		switch {
+		case s.APIKey != nil:
+			scheme = a2atype.APIKeySecurityScheme{
+				Description: s.Description,
+				Location:    a2atype.APIKeySecuritySchemeLocation(s.APIKey.Location),
+				Name:        s.APIKey.ParameterName,
+			}
+		case s.HTTP != nil:
+			scheme = a2atype.HTTPAuthSecurityScheme{
+				Description:  s.Description,
+				Scheme:       s.HTTP.Scheme,
+				BearerFormat: s.HTTP.BearerFormat,
+			}
+		case s.OAuth2 != nil:
+			scopes := s.OAuth2.Scopes
+			if scopes == nil {
+				// Scopes can't be null on the AgentCard.
+				scopes = map[string]string{}
+			}
+			oauth2 := a2atype.OAuth2SecurityScheme{
+				Description:       s.Description,
+				Oauth2MetadataURL: s.OAuth2.MetadataURL,
+			}
+			if s.OAuth2.Flow == v1alpha2.A2AOAuth2FlowAuthorizationCode {
+				oauth2.Flows = a2atype.AuthorizationCodeOAuthFlow{
+					AuthorizationURL: s.OAuth2.AuthorizationURL,
+					TokenURL:         s.OAuth2.TokenURL,
+					Scopes:           scopes,
+				}
+			} else {
+				oauth2.Flows = a2atype.ClientCredentialsOAuthFlow{
+					TokenURL: s.OAuth2.TokenURL,
+					Scopes:   scopes,
+				}
+			}
+			scheme = oauth2
+		case s.OpenIDConnect != nil:
+			scheme = a2atype.OpenIDConnectSecurityScheme{
+				Description:      s.Description,
+				OpenIDConnectURL: s.OpenIDConnect.URL,
+			}
+		case s.MutualTLS != nil:
+			scheme = a2atype.MutualTLSSecurityScheme{Description: s.Description}
+		default:
+			continue
+		}
+		name := a2atype.SecuritySchemeName(s.Name)
+		named[name] = scheme
+		scopes := a2atype.SecuritySchemeScopes(s.Scopes)
+		if scopes == nil {
+			scopes = a2atype.SecuritySchemeScopes{}
+		}
+		requirements = append(requirements, a2atype.SecurityRequirements{name: scopes})
+	}
+	return named, requirements
+}
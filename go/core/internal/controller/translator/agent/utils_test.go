@@ -156,3 +156,49 @@ func TestGetA2AAgentCard(t *testing.T) {
 		})
 	}
 }
+
+func TestGetA2AAgentCard_ModesAndSecuritySchemes(t *testing.T) {
+	agent := &v1alpha2.Agent{
+		ObjectMeta: metav1.ObjectMeta{Name: "secure-agent", Namespace: "default"},
+		Spec: v1alpha2.AgentSpec{
+			Type: v1alpha2.AgentType_Declarative,
+			Declarative: &v1alpha2.DeclarativeAgentSpec{
+				A2AConfig: &v1alpha2.A2AConfig{
+					DefaultInputModes:  []string{"text/plain", "image/png"},
+					DefaultOutputModes: []string{"application/json"},
+					SecuritySchemes: []v1alpha2.A2ASecurityScheme{
+						{Name: "key", APIKey: &v1alpha2.A2AAPIKeySecurityScheme{Location: "header", ParameterName: "X-API-Key"}},
+						{Name: "oidc", Scopes: []string{"openid"}, OpenIDConnect: &v1alpha2.A2AOpenIDConnectSecurityScheme{URL: "https://idp.example.com/.well-known/openid-configuration"}},
+						{Name: "mtls", Description: "Client certificates", MutualTLS: &v1alpha2.A2AMutualTLSSecurityScheme{}},
+						{Name: "login", OAuth2: &v1alpha2.A2AOAuth2SecurityScheme{
+							Flow:             v1alpha2.A2AOAuth2FlowAuthorizationCode,
+							AuthorizationURL: "https://idp.example.com/authorize",
+							TokenURL:         "https://idp.example.com/token",
+						}},
+					},
+				},
+			},
+		},
+	}
+
+	card := translator.GetA2AAgentCard(agent)
+
+	assert.Equal(t, []string{"text/plain", "image/png"}, card.DefaultInputModes)
+	assert.Equal(t, []string{"application/json"}, card.DefaultOutputModes)
+	assert.Equal(t, a2atype.NamedSecuritySchemes{
+		"key":  a2atype.APIKeySecurityScheme{Location: a2atype.APIKeySecuritySchemeLocationHeader, Name: "X-API-Key"},
+		"oidc": a2atype.OpenIDConnectSecurityScheme{OpenIDConnectURL: "https://idp.example.com/.well-known/openid-configuration"},
+		"mtls": a2atype.MutualTLSSecurityScheme{Description: "Client certificates"},
+		"login": a2atype.OAuth2SecurityScheme{Flows: a2atype.AuthorizationCodeOAuthFlow{
+			AuthorizationURL: "https://idp.example.com/authorize",
+			TokenURL:         "https://idp.example.com/token",
+			Scopes:           map[string]string{},
+		}},
+	}, card.SecuritySchemes)
+	assert.Equal(t, a2atype.SecurityRequirementsOptions{
+		{"key": {}},
+		{"oidc": {"openid"}},
+		{"mtls": {}},
+		{"login": {}},
+	}, card.SecurityRequirements)
+}
@@ -5,7 +5,9 @@ package translator
 import (
 	"dario.cat/mergo"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -59,6 +61,21 @@ func MutateFuncFor(existing, desired client.Object) controllerutil.MutateFn {
 			wantDpl := desired.(*appsv1.Deployment)
 			return mutateDeployment(dpl, wantDpl)
 
+		case *autoscalingv2.HorizontalPodAutoscaler:
+			hpa := existing.(*autoscalingv2.HorizontalPodAutoscaler)
+			wantHpa := desired.(*autoscalingv2.HorizontalPodAutoscaler)
+			mutateHorizontalPodAutoscaler(hpa, wantHpa)
+
+		case *rbacv1.Role:
+			role := existing.(*rbacv1.Role)
+			wantRole := desired.(*rbacv1.Role)
+			mutateRole(role, wantRole)
+
+		case *rbacv1.RoleBinding:
+			rb := existing.(*rbacv1.RoleBinding)
+			wantRb := desired.(*rbacv1.RoleBinding)
+			mutateRoleBinding(rb, wantRb)
+
 		default:
 			return mergeWithOverride(existing, desired)
 		}
@@ -84,11 +101,24 @@ func mutateServiceAccount(existing, desired *corev1.ServiceAccount) {
 	// Nothing to do here for the time being - we don't really care about anything but the existence of the ServiceAccount
 }
 
+func mutateRole(existing, desired *rbacv1.Role) {
+	existing.Rules = desired.Rules
+}
+
+func mutateRoleBinding(existing, desired *rbacv1.RoleBinding) {
+	// The role reference is immutable, and always names the agent's Role.
+	existing.Subjects = desired.Subjects
+}
+
 func mutateService(existing, desired *corev1.Service) {
 	existing.Spec.Ports = desired.Spec.Ports
 	existing.Spec.Selector = desired.Spec.Selector
 }
 
+func mutateHorizontalPodAutoscaler(existing, desired *autoscalingv2.HorizontalPodAutoscaler) {
+	existing.Spec = desired.Spec
+}
+
 func mutateDeployment(existing, desired *appsv1.Deployment) error {
 	existing.Spec.MinReadySeconds = desired.Spec.MinReadySeconds
 	existing.Spec.Paused = desired.Spec.Paused
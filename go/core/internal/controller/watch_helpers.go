@@ -49,10 +49,16 @@ func addCommonAgentWatches(build *builder.Builder, mgr ctrl.Manager, finders age
 	build = build.Watches(
 		&v1alpha2.ModelConfig{},
 		handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
-			return reconcileRequestsForRefs(finders.modelConfig(ctx, mgr.GetClient(), types.NamespacedName{
+			modelConfig := types.NamespacedName{
 				Name:      obj.GetName(),
 				Namespace: obj.GetNamespace(),
-			}))
+			}
+			refs := finders.modelConfig(ctx, mgr.GetClient(), modelConfig)
+			// Agents also embed the ModelConfigs their model falls back to.
+			for _, primary := range findModelsFallingBackTo(ctx, mgr.GetClient(), modelConfig) {
+				refs = append(refs, finders.modelConfig(ctx, mgr.GetClient(), primary)...)
+			}
+			return reconcileRequestsForRefs(refs)
 		}),
 		builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
 	).Watches(
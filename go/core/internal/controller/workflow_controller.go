@@ -0,0 +1,206 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+)
+
+const (
+	workflowReasonAgentReady    = "AgentReady"
+	workflowReasonAgentNotReady = "AgentNotReady"
+	workflowReasonAgentNotFound = "AgentNotFound"
+	workflowReasonAgentConflict = "AgentConflict"
+	workflowReasonInvalidStep   = "InvalidStep"
+
+	workflowAgentNotFoundRequeueDelay = time.Minute
+
+	defaultWorkflowRouterInstruction = "Delegate each request to the one agent best suited to handle it and reply with its answer."
+)
+
+// WorkflowController reconciles a Workflow object into an Agent of the same
+// name that orchestrates the Workflow's steps. The Agent controller deploys
+// it like any other agent, so the Workflow gets its own A2A endpoint.
+type WorkflowController struct {
+	Client client.Client
+}
+
+// +kubebuilder:rbac:groups=kagent.dev,resources=workflows,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=kagent.dev,resources=workflows/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=kagent.dev,resources=workflows/finalizers,verbs=update
+// +kubebuilder:rbac:groups=kagent.dev,resources=agents,verbs=get;list;watch;create;update;patch;delete
+
+func (r *WorkflowController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	workflow := &v1alpha2.Workflow{}
+	if err := r.Client.Get(ctx, req.NamespacedName, workflow); err != nil {
+		// The orchestrator Agent is garbage collected with the Workflow.
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	status := workflow.Status.DeepCopy()
+	status.ObservedGeneration = workflow.Generation
+
+	for _, step := range workflow.Spec.Steps {
+		if step.AgentRef == workflow.Name {
+			setWorkflowReady(status, workflow.Generation, metav1.ConditionFalse, workflowReasonInvalidStep,
+				fmt.Sprintf("Step %s is the Workflow's own agent", step.AgentRef))
+			return ctrl.Result{}, r.updateStatus(ctx, workflow, status)
+		}
+		if err := r.Client.Get(ctx, types.NamespacedName{Namespace: workflow.Namespace, Name: step.AgentRef}, &v1alpha2.Agent{}); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return ctrl.Result{}, err
+			}
+			setWorkflowReady(status, workflow.Generation, metav1.ConditionFalse, workflowReasonAgentNotFound,
+				fmt.Sprintf("Agent %s not found in namespace %s", step.AgentRef, workflow.Namespace))
+			if err := r.updateStatus(ctx, workflow, status); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{RequeueAfter: workflowAgentNotFoundRequeueDelay}, nil
+		}
+	}
+
+	agent := &v1alpha2.Agent{}
+	err := r.Client.Get(ctx, client.ObjectKeyFromObject(workflow), agent)
+	switch {
+	case err == nil && !metav1.IsControlledBy(agent, workflow):
+		setWorkflowReady(status, workflow.Generation, metav1.ConditionFalse, workflowReasonAgentConflict,
+			fmt.Sprintf("Agent %s already exists and is not owned by this Workflow", workflow.Name))
+		return ctrl.Result{}, r.updateStatus(ctx, workflow, status)
+	case err != nil && !apierrors.IsNotFound(err):
+		return ctrl.Result{}, err
+	}
+
+	agent = &v1alpha2.Agent{ObjectMeta: metav1.ObjectMeta{Name: workflow.Name, Namespace: workflow.Namespace}}
+	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, agent, func() error {
+		if agent.Annotations == nil {
+			agent.Annotations = map[string]string{}
+		}
+		agent.Annotations[v1alpha2.WorkflowPatternAnnotation] = string(workflow.Spec.Pattern)
+		agent.Spec = workflowAgentSpec(workflow)
+		return controllerutil.SetControllerReference(workflow, agent, r.Client.Scheme())
+	})
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to reconcile Workflow agent: %w", err)
+	}
+	if op != controllerutil.OperationResultNone {
+		logger.Info("Reconciled Workflow agent", "agent", agent.Name, "operation", op)
+	}
+
+	if ready := meta.FindStatusCondition(agent.Status.Conditions, v1alpha2.AgentConditionTypeReady); ready != nil && ready.Status == metav1.ConditionTrue {
+		setWorkflowReady(status, workflow.Generation, metav1.ConditionTrue, workflowReasonAgentReady, "Workflow agent is ready")
+	} else {
+		message := "Waiting for the Workflow agent to become ready"
+		if ready != nil && ready.Message != "" {
+			message = ready.Message
+		}
+		setWorkflowReady(status, workflow.Generation, metav1.ConditionFalse, workflowReasonAgentNotReady, message)
+	}
+	return ctrl.Result{}, r.updateStatus(ctx, workflow, status)
+}
+
+// workflowAgentSpec is the spec of the Agent orchestrating workflow: a go
+// runtime agent with the steps as agent tools. The pattern annotation makes
+// the translator run them as a workflow rather than as tools.
+func workflowAgentSpec(workflow *v1alpha2.Workflow) v1alpha2.AgentSpec {
+	tools := make([]*v1alpha2.Tool, 0, len(workflow.Spec.Steps))
+	for _, step := range workflow.Spec.Steps {
+		tools = append(tools, &v1alpha2.Tool{
+			Type:  v1alpha2.ToolProviderType_Agent,
+			Agent: &v1alpha2.TypedReference{Name: step.AgentRef},
+		})
+	}
+	declarative := &v1alpha2.DeclarativeAgentSpec{
+		Runtime:       v1alpha2.DeclarativeRuntime_Go,
+		SystemMessage: workflowInstruction(workflow),
+		Tools:         tools,
+		Deployment:    workflow.Spec.Deployment.DeepCopy(),
+	}
+	if workflow.Spec.Router != nil {
+		declarative.ModelConfig = workflow.Spec.Router.ModelConfig
+	}
+	return v1alpha2.AgentSpec{
+		Type:        v1alpha2.AgentType_Declarative,
+		Description: workflow.Spec.Description,
+		Declarative: declarative,
+	}
+}
+
+// workflowInstruction is the routing model's instruction for the Router
+// pattern. The other patterns do not use a model; their instruction only
+// describes the workflow.
+func workflowInstruction(workflow *v1alpha2.Workflow) string {
+	agents := make([]string, 0, len(workflow.Spec.Steps))
+	for _, step := range workflow.Spec.Steps {
+		agents = append(agents, step.AgentRef)
+	}
+	switch workflow.Spec.Pattern {
+	case v1alpha2.WorkflowPatternRouter:
+		if workflow.Spec.Router != nil && workflow.Spec.Router.Instruction != "" {
+			return workflow.Spec.Router.Instruction
+		}
+		return defaultWorkflowRouterInstruction
+	case v1alpha2.WorkflowPatternParallel:
+		return fmt.Sprintf("Runs the agents %s at the same time.", strings.Join(agents, ", "))
+	default:
+		return fmt.Sprintf("Runs the agents %s one after another.", strings.Join(agents, ", "))
+	}
+}
+
+func (r *WorkflowController) updateStatus(ctx context.Context, workflow *v1alpha2.Workflow, status *v1alpha2.WorkflowStatus) error {
+	workflow.Status = *status
+	if err := r.Client.Status().Update(ctx, workflow); err != nil {
+		return fmt.Errorf("failed to update Workflow status: %w", err)
+	}
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *WorkflowController) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha2.Workflow{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Owns(&v1alpha2.Agent{}).
+		Named("workflow").
+		Complete(r)
+}
+
+func setWorkflowReady(status *v1alpha2.WorkflowStatus, generation int64, conditionStatus metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               v1alpha2.WorkflowConditionTypeReady,
+		Status:             conditionStatus,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: generation,
+	})
+}
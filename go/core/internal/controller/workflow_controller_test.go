@@ -0,0 +1,154 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+)
+
+func testWorkflow(spec v1alpha2.WorkflowSpec) *v1alpha2.Workflow {
+	return &v1alpha2.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Name: "incident-workflow", Namespace: "kagent", Generation: 1, UID: "wf-uid"},
+		Spec:       spec,
+	}
+}
+
+func reconcileWorkflow(t *testing.T, objs ...client.Object) (ctrl.Result, *v1alpha2.Workflow, client.Client) {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha2.AddToScheme(scheme))
+	kube := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(append(objs,
+			&v1alpha2.Agent{ObjectMeta: metav1.ObjectMeta{Name: "triage-agent", Namespace: "kagent"}},
+			&v1alpha2.Agent{ObjectMeta: metav1.ObjectMeta{Name: "fix-agent", Namespace: "kagent"}},
+		)...).
+		WithStatusSubresource(&v1alpha2.Workflow{}, &v1alpha2.Agent{}).
+		Build()
+	r := &WorkflowController{Client: kube}
+
+	ctx := context.Background()
+	key := client.ObjectKey{Namespace: "kagent", Name: "incident-workflow"}
+	result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	require.NoError(t, err)
+	latest := &v1alpha2.Workflow{}
+	require.NoError(t, kube.Get(ctx, key, latest))
+	return result, latest, kube
+}
+
+func TestWorkflowReconcile(t *testing.T) {
+	steps := []v1alpha2.WorkflowStep{{AgentRef: "triage-agent"}, {AgentRef: "fix-agent"}}
+
+	t.Run("creates the orchestrator agent", func(t *testing.T) {
+		_, workflow, kube := reconcileWorkflow(t, testWorkflow(v1alpha2.WorkflowSpec{
+			Description: "Triage then fix",
+			Pattern:     v1alpha2.WorkflowPatternSequential,
+			Steps:       steps,
+		}))
+
+		agent := &v1alpha2.Agent{}
+		require.NoError(t, kube.Get(context.Background(), client.ObjectKeyFromObject(workflow), agent))
+		assert.Equal(t, "Sequential", agent.Annotations[v1alpha2.WorkflowPatternAnnotation])
+		assert.True(t, metav1.IsControlledBy(agent, workflow))
+		assert.Equal(t, "Triage then fix", agent.Spec.Description)
+		require.NotNil(t, agent.Spec.Declarative)
+		assert.Equal(t, v1alpha2.DeclarativeRuntime_Go, agent.Spec.Declarative.Runtime)
+		assert.Equal(t, "Runs the agents triage-agent, fix-agent one after another.", agent.Spec.Declarative.SystemMessage)
+		require.Len(t, agent.Spec.Declarative.Tools, 2)
+		assert.Equal(t, "triage-agent", agent.Spec.Declarative.Tools[0].Agent.Name)
+		assert.Equal(t, "fix-agent", agent.Spec.Declarative.Tools[1].Agent.Name)
+
+		ready := meta.FindStatusCondition(workflow.Status.Conditions, v1alpha2.WorkflowConditionTypeReady)
+		require.NotNil(t, ready)
+		assert.Equal(t, metav1.ConditionFalse, ready.Status)
+		assert.Equal(t, workflowReasonAgentNotReady, ready.Reason)
+	})
+
+	t.Run("configures the router model", func(t *testing.T) {
+		_, workflow, kube := reconcileWorkflow(t, testWorkflow(v1alpha2.WorkflowSpec{
+			Pattern: v1alpha2.WorkflowPatternRouter,
+			Steps:   steps,
+			Router:  &v1alpha2.WorkflowRouter{ModelConfig: "router-model"},
+		}))
+
+		agent := &v1alpha2.Agent{}
+		require.NoError(t, kube.Get(context.Background(), client.ObjectKeyFromObject(workflow), agent))
+		assert.Equal(t, "router-model", agent.Spec.Declarative.ModelConfig)
+		assert.Equal(t, defaultWorkflowRouterInstruction, agent.Spec.Declarative.SystemMessage)
+	})
+
+	t.Run("reports ready when the agent is ready", func(t *testing.T) {
+		workflow := testWorkflow(v1alpha2.WorkflowSpec{Pattern: v1alpha2.WorkflowPatternParallel, Steps: steps})
+		agent := &v1alpha2.Agent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "incident-workflow",
+				Namespace:       "kagent",
+				OwnerReferences: []metav1.OwnerReference{{APIVersion: "kagent.dev/v1alpha2", Kind: "Workflow", Name: "incident-workflow", UID: "wf-uid", Controller: new(true)}},
+			},
+			Status: v1alpha2.AgentStatus{Conditions: []metav1.Condition{{
+				Type:               v1alpha2.AgentConditionTypeReady,
+				Status:             metav1.ConditionTrue,
+				Reason:             "DeploymentReady",
+				LastTransitionTime: metav1.NewTime(time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)),
+			}}},
+		}
+		_, workflow, _ = reconcileWorkflow(t, workflow, agent)
+
+		ready := meta.FindStatusCondition(workflow.Status.Conditions, v1alpha2.WorkflowConditionTypeReady)
+		require.NotNil(t, ready)
+		assert.Equal(t, metav1.ConditionTrue, ready.Status)
+		assert.Equal(t, workflowReasonAgentReady, ready.Reason)
+	})
+
+	t.Run("waits for missing step agents", func(t *testing.T) {
+		result, workflow, kube := reconcileWorkflow(t, testWorkflow(v1alpha2.WorkflowSpec{
+			Pattern: v1alpha2.WorkflowPatternSequential,
+			Steps:   []v1alpha2.WorkflowStep{{AgentRef: "triage-agent"}, {AgentRef: "missing-agent"}},
+		}))
+
+		assert.Equal(t, workflowAgentNotFoundRequeueDelay, result.RequeueAfter)
+		ready := meta.FindStatusCondition(workflow.Status.Conditions, v1alpha2.WorkflowConditionTypeReady)
+		require.NotNil(t, ready)
+		assert.Equal(t, workflowReasonAgentNotFound, ready.Reason)
+		err := kube.Get(context.Background(), client.ObjectKeyFromObject(workflow), &v1alpha2.Agent{})
+		assert.True(t, apierrors.IsNotFound(err), "orchestrator agent should not be created, got %v", err)
+	})
+
+	t.Run("does not take over an existing agent", func(t *testing.T) {
+		existing := &v1alpha2.Agent{
+			ObjectMeta: metav1.ObjectMeta{Name: "incident-workflow", Namespace: "kagent"},
+			Spec:       v1alpha2.AgentSpec{Description: "hand-written"},
+		}
+		_, workflow, kube := reconcileWorkflow(t, testWorkflow(v1alpha2.WorkflowSpec{Pattern: v1alpha2.WorkflowPatternSequential, Steps: steps}), existing)
+
+		ready := meta.FindStatusCondition(workflow.Status.Conditions, v1alpha2.WorkflowConditionTypeReady)
+		require.NotNil(t, ready)
+		assert.Equal(t, workflowReasonAgentConflict, ready.Reason)
+		agent := &v1alpha2.Agent{}
+		require.NoError(t, kube.Get(context.Background(), client.ObjectKeyFromObject(existing), agent))
+		assert.Equal(t, "hand-written", agent.Spec.Description)
+		assert.Empty(t, agent.Annotations)
+	})
+
+	t.Run("rejects the workflow's own agent as a step", func(t *testing.T) {
+		_, workflow, _ := reconcileWorkflow(t, testWorkflow(v1alpha2.WorkflowSpec{
+			Pattern: v1alpha2.WorkflowPatternSequential,
+			Steps:   []v1alpha2.WorkflowStep{{AgentRef: "incident-workflow"}},
+		}))
+
+		ready := meta.FindStatusCondition(workflow.Status.Conditions, v1alpha2.WorkflowConditionTypeReady)
+		require.NotNil(t, ready)
+		assert.Equal(t, workflowReasonInvalidStep, ready.Reason)
+	})
+}
@@ -0,0 +1,96 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseErrors(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"10-5 * * * *",
+		"* * * foo *",
+		"@every 5m",
+	} {
+		if _, err := Parse(spec); err == nil {
+			t.Errorf("Parse(%q) succeeded, want an error", spec)
+		}
+	}
+}
+
+func TestNext(t *testing.T) {
+	utc := func(s string) time.Time {
+		t.Helper()
+		v, err := time.Parse("2006-01-02 15:04", s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+
+	tests := []struct {
+		spec string
+		from string
+		want string
+	}{
+		{spec: "0 8 * * *", from: "2026-03-10 07:59", want: "2026-03-10 08:00"},
+		{spec: "0 8 * * *", from: "2026-03-10 08:00", want: "2026-03-11 08:00"},
+		{spec: "*/15 * * * *", from: "2026-03-10 08:01", want: "2026-03-10 08:15"},
+		{spec: "5/20 * * * *", from: "2026-03-10 08:30", want: "2026-03-10 08:45"},
+		{spec: "0 9 * * mon-fri", from: "2026-03-13 10:00", want: "2026-03-16 09:00"},
+		{spec: "0 0 * * 7", from: "2026-03-10 00:00", want: "2026-03-15 00:00"},
+		{spec: "30 6 1,15 * *", from: "2026-03-02 00:00", want: "2026-03-15 06:30"},
+		{spec: "0 0 1 jan *", from: "2026-03-10 00:00", want: "2027-01-01 00:00"},
+		{spec: "@hourly", from: "2026-03-10 08:30", want: "2026-03-10 09:00"},
+		{spec: "@weekly", from: "2026-03-10 08:30", want: "2026-03-15 00:00"},
+		{spec: "0 0 29 2 *", from: "2026-03-10 00:00", want: "2028-02-29 00:00"},
+		// Both day fields restricted: either matches.
+		{spec: "0 0 13 * fri", from: "2026-03-10 00:00", want: "2026-03-13 00:00"},
+		{spec: "0 0 13 * fri", from: "2026-03-13 00:00", want: "2026-03-20 00:00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec+" from "+tt.from, func(t *testing.T) {
+			s, err := Parse(tt.spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := s.Next(utc(tt.from)); !got.Equal(utc(tt.want)) {
+				t.Errorf("Next() = %s, want %s", got.Format(time.DateTime), tt.want)
+			}
+		})
+	}
+}
+
+func TestNextNever(t *testing.T) {
+	s, err := Parse("0 0 30 2 *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := s.Next(time.Now()); !got.IsZero() {
+		t.Errorf("Next() = %s, want the zero time for February 30th", got)
+	}
+}
+
+func TestNextInLocation(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("time zone database not available")
+	}
+	s, err := Parse("0 8 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := s.Next(time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC).In(loc))
+	want := time.Date(2026, 3, 11, 8, 0, 0, 0, loc)
+	if !got.Equal(want) {
+		t.Errorf("Next() = %s, want %s", got, want)
+	}
+}
@@ -6,11 +6,13 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"math"
 	"strings"
 	"time"
 
 	a2a "github.com/a2aproject/a2a-go/v2/a2a"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 	dbpkg "github.com/kagent-dev/kagent/go/api/database"
@@ -22,8 +24,12 @@ import (
 )
 
 type postgresClient struct {
-	q  *dbgen.Queries
-	db *pgxpool.Pool
+	q *dbgen.Queries
+	// db is the connection pool, or the transaction of a client passed to
+	// WithLock, in which transactions nest as savepoints.
+	db interface {
+		Begin(ctx context.Context) (pgx.Tx, error)
+	}
 }
 
 func NewClient(db *pgxpool.Pool) dbpkg.Client {
@@ -45,6 +51,22 @@ func (c *postgresClient) withTx(ctx context.Context, fn func(*dbgen.Queries) err
 	return tx.Commit(ctx)
 }
 
+func (c *postgresClient) WithLock(ctx context.Context, key string, fn func(tx dbpkg.Client) error) error {
+	tx, err := c.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+	q := c.q.WithTx(tx)
+	if err := q.AcquireTransactionLock(ctx, key); err != nil {
+		return fmt.Errorf("failed to acquire lock %s: %w", key, err)
+	}
+	if err := fn(&postgresClient{q: q, db: tx}); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
 // ── Agents ────────────────────────────────────────────────────────────────────
 
 func (c *postgresClient) StoreAgent(ctx context.Context, agent *dbpkg.Agent) error {
@@ -118,6 +140,29 @@ func (c *postgresClient) ListSessions(ctx context.Context, userID string) ([]dbp
 	return sessions, nil
 }
 
+func (c *postgresClient) ListSessionsWithOptions(ctx context.Context, userID string, opts dbpkg.SessionListOptions) ([]dbpkg.Session, error) {
+	limit := int32(math.MaxInt32)
+	if opts.Limit > 0 && opts.Limit < math.MaxInt32 {
+		limit = int32(opts.Limit)
+	}
+	rows, err := c.q.ListSessionsWithOptions(ctx, dbgen.ListSessionsWithOptionsParams{
+		UserID:  userID,
+		Column2: opts.CreatedAfter,
+		Column3: opts.SortBy,
+		Column4: opts.SortAsc,
+		Limit:   limit,
+		Offset:  int32(min(max(opts.Offset, 0), math.MaxInt32)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	sessions := make([]dbpkg.Session, len(rows))
+	for i, r := range rows {
+		sessions[i] = *toSession(r)
+	}
+	return sessions, nil
+}
+
 func (c *postgresClient) ListSessionsForAgent(ctx context.Context, agentID, userID string) ([]dbpkg.SessionWithShareToken, error) {
 	rows, err := c.q.ListSessionsForAgent(ctx, dbgen.ListSessionsForAgentParams{
 		AgentID: &agentID,
@@ -145,6 +190,18 @@ func (c *postgresClient) ListSessionsForAgentAllUsers(ctx context.Context, agent
 	return sessions, nil
 }
 
+func (c *postgresClient) ListSessionsUpdatedSince(ctx context.Context, since time.Time) ([]dbpkg.Session, error) {
+	rows, err := c.q.ListSessionsUpdatedSince(ctx, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions updated since %s: %w", since, err)
+	}
+	sessions := make([]dbpkg.Session, len(rows))
+	for i, r := range rows {
+		sessions[i] = *toSession(r)
+	}
+	return sessions, nil
+}
+
 func (c *postgresClient) DeleteSession(ctx context.Context, sessionID, userID string) error {
 	return c.q.SoftDeleteSession(ctx, dbgen.SoftDeleteSessionParams{ID: sessionID, UserID: userID})
 }
@@ -218,6 +275,181 @@ func (c *postgresClient) RecordShareAccess(ctx context.Context, userID string, s
 	return nil
 }
 
+// ── Session Grants ─────────────────────────────────────────────────────────────
+
+func toSessionGrant(row dbgen.SessionGrant) dbpkg.SessionGrant {
+	return dbpkg.SessionGrant{
+		SessionID:     row.SessionID,
+		OwnerID:       row.OwnerID,
+		PrincipalKind: dbpkg.SessionPrincipalKind(row.PrincipalKind),
+		Principal:     row.Principal,
+		Access:        dbpkg.SessionAccess(row.Access),
+		CreatedAt:     row.CreatedAt,
+	}
+}
+
+func (c *postgresClient) UpsertSessionGrant(ctx context.Context, grant *dbpkg.SessionGrant) (*dbpkg.SessionGrant, error) {
+	row, err := c.q.UpsertSessionGrant(ctx, dbgen.UpsertSessionGrantParams{
+		SessionID:     grant.SessionID,
+		OwnerID:       grant.OwnerID,
+		PrincipalKind: string(grant.PrincipalKind),
+		Principal:     grant.Principal,
+		Access:        string(grant.Access),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("upsert session grant: %w", err)
+	}
+	result := toSessionGrant(row)
+	return &result, nil
+}
+
+func (c *postgresClient) ListSessionGrants(ctx context.Context, sessionID, ownerID string) ([]dbpkg.SessionGrant, error) {
+	rows, err := c.q.ListSessionGrants(ctx, dbgen.ListSessionGrantsParams{SessionID: sessionID, OwnerID: ownerID})
+	if err != nil {
+		return nil, fmt.Errorf("list session grants: %w", err)
+	}
+	grants := make([]dbpkg.SessionGrant, 0, len(rows))
+	for _, row := range rows {
+		grants = append(grants, toSessionGrant(row))
+	}
+	return grants, nil
+}
+
+func (c *postgresClient) DeleteSessionGrant(ctx context.Context, sessionID, ownerID string, kind dbpkg.SessionPrincipalKind, principal string) error {
+	if err := c.q.DeleteSessionGrant(ctx, dbgen.DeleteSessionGrantParams{
+		SessionID:     sessionID,
+		OwnerID:       ownerID,
+		PrincipalKind: string(kind),
+		Principal:     principal,
+	}); err != nil {
+		return fmt.Errorf("delete session grant: %w", err)
+	}
+	return nil
+}
+
+func (c *postgresClient) GetSessionGrantForPrincipal(ctx context.Context, sessionID, userID string, groups []string) (*dbpkg.SessionGrant, error) {
+	if groups == nil {
+		groups = []string{}
+	}
+	row, err := c.q.GetSessionGrantForPrincipal(ctx, dbgen.GetSessionGrantForPrincipalParams{
+		SessionID: sessionID,
+		UserID:    userID,
+		Groups:    groups,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get session grant for principal: %w", err)
+	}
+	result := toSessionGrant(row)
+	return &result, nil
+}
+
+// ── Session State ─────────────────────────────────────────────────────────────
+
+func toSessionStateEntry(row dbgen.SessionState) dbpkg.SessionStateEntry {
+	return dbpkg.SessionStateEntry{
+		SessionID: row.SessionID,
+		UserID:    row.UserID,
+		Key:       row.Key,
+		Value:     row.Value,
+		UpdatedAt: row.UpdatedAt,
+	}
+}
+
+func (c *postgresClient) ListSessionState(ctx context.Context, sessionID, userID string) ([]dbpkg.SessionStateEntry, error) {
+	rows, err := c.q.ListSessionState(ctx, dbgen.ListSessionStateParams{SessionID: sessionID, UserID: userID})
+	if err != nil {
+		return nil, fmt.Errorf("list session state: %w", err)
+	}
+	entries := make([]dbpkg.SessionStateEntry, 0, len(rows))
+	for _, row := range rows {
+		entries = append(entries, toSessionStateEntry(row))
+	}
+	return entries, nil
+}
+
+func (c *postgresClient) GetSessionStateValue(ctx context.Context, sessionID, userID, key string) (*dbpkg.SessionStateEntry, error) {
+	row, err := c.q.GetSessionStateValue(ctx, dbgen.GetSessionStateValueParams{SessionID: sessionID, UserID: userID, Key: key})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get session state value: %w", err)
+	}
+	result := toSessionStateEntry(row)
+	return &result, nil
+}
+
+func (c *postgresClient) SetSessionStateValue(ctx context.Context, entry *dbpkg.SessionStateEntry) (*dbpkg.SessionStateEntry, error) {
+	row, err := c.q.UpsertSessionStateValue(ctx, dbgen.UpsertSessionStateValueParams{
+		SessionID: entry.SessionID,
+		UserID:    entry.UserID,
+		Key:       entry.Key,
+		Value:     entry.Value,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("set session state value: %w", err)
+	}
+	result := toSessionStateEntry(row)
+	return &result, nil
+}
+
+func (c *postgresClient) DeleteSessionStateValue(ctx context.Context, sessionID, userID, key string) (bool, error) {
+	n, err := c.q.DeleteSessionStateValue(ctx, dbgen.DeleteSessionStateValueParams{SessionID: sessionID, UserID: userID, Key: key})
+	if err != nil {
+		return false, fmt.Errorf("delete session state value: %w", err)
+	}
+	return n > 0, nil
+}
+
+// ── LLM Traces ────────────────────────────────────────────────────────────────
+
+func toLLMTrace(row dbgen.LlmTrace) dbpkg.LLMTrace {
+	return dbpkg.LLMTrace{
+		ID:        row.ID,
+		SessionID: row.SessionID,
+		UserID:    row.UserID,
+		AgentID:   row.AgentID,
+		Model:     row.Model,
+		Request:   row.Request,
+		Response:  row.Response,
+		Error:     derefStr(row.Error),
+		CreatedAt: row.CreatedAt,
+	}
+}
+
+func (c *postgresClient) StoreLLMTrace(ctx context.Context, trace *dbpkg.LLMTrace) (*dbpkg.LLMTrace, error) {
+	var response []byte
+	if len(trace.Response) > 0 {
+		response = trace.Response
+	}
+	row, err := c.q.InsertLLMTrace(ctx, dbgen.InsertLLMTraceParams{
+		SessionID: trace.SessionID,
+		UserID:    trace.UserID,
+		AgentID:   trace.AgentID,
+		Model:     trace.Model,
+		Request:   trace.Request,
+		Response:  response,
+		Error:     strPtrIfNotEmpty(trace.Error),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("store llm trace: %w", err)
+	}
+	result := toLLMTrace(row)
+	return &result, nil
+}
+
+func (c *postgresClient) ListLLMTraces(ctx context.Context, sessionID, userID string) ([]dbpkg.LLMTrace, error) {
+	rows, err := c.q.ListLLMTraces(ctx, dbgen.ListLLMTracesParams{SessionID: sessionID, UserID: userID})
+	if err != nil {
+		return nil, fmt.Errorf("list llm traces: %w", err)
+	}
+	traces := make([]dbpkg.LLMTrace, 0, len(rows))
+	for _, row := range rows {
+		traces = append(traces, toLLMTrace(row))
+	}
+	return traces, nil
+}
+
 // ── Events ────────────────────────────────────────────────────────────────────
 
 func (c *postgresClient) StoreEvents(ctx context.Context, events ...*dbpkg.Event) error {
@@ -234,6 +466,49 @@ func (c *postgresClient) StoreEvents(ctx context.Context, events ...*dbpkg.Event
 	return nil
 }
 
+func (c *postgresClient) ForkSession(ctx context.Context, sourceSessionID, untilEventID string, fork *dbpkg.Session) (int, error) {
+	var copied int64
+	err := c.withTx(ctx, func(q *dbgen.Queries) error {
+		params := dbgen.CopySessionEventsParams{
+			TargetSessionID: fork.ID,
+			SourceSessionID: &sourceSessionID,
+			UserID:          fork.UserID,
+		}
+		if untilEventID != "" {
+			event, err := q.GetEvent(ctx, dbgen.GetEventParams{ID: untilEventID, UserID: fork.UserID})
+			if errors.Is(err, pgx.ErrNoRows) || (err == nil && derefStr(event.SessionID) != sourceSessionID) {
+				return dbpkg.ErrEventNotInSession
+			}
+			if err != nil {
+				return fmt.Errorf("failed to get event %s: %w", untilEventID, err)
+			}
+			params.Until = event.CreatedAt
+		}
+
+		session := dbgen.UpsertSessionParams{
+			ID:      fork.ID,
+			UserID:  fork.UserID,
+			Name:    fork.Name,
+			AgentID: fork.AgentID,
+		}
+		if fork.Source != nil {
+			src := string(*fork.Source)
+			session.Source = &src
+		}
+		if err := q.UpsertSession(ctx, session); err != nil {
+			return fmt.Errorf("failed to store session %s: %w", fork.ID, err)
+		}
+
+		var err error
+		copied, err = q.CopySessionEvents(ctx, params)
+		if err != nil {
+			return fmt.Errorf("failed to copy events of session %s: %w", sourceSessionID, err)
+		}
+		return nil
+	})
+	return int(copied), err
+}
+
 func (c *postgresClient) ListEventsForSession(ctx context.Context, sessionID, userID string, opts dbpkg.QueryOptions) ([]*dbpkg.Event, error) {
 	var rows []dbgen.Event
 	var err error
@@ -268,6 +543,76 @@ func (c *postgresClient) ListEventsForSession(ctx context.Context, sessionID, us
 	return events, nil
 }
 
+// ── Event archives ────────────────────────────────────────────────────────────
+
+func toEventArchive(row dbgen.EventArchive) *dbpkg.EventArchive {
+	return &dbpkg.EventArchive{
+		ID:           row.ID,
+		SessionID:    row.SessionID,
+		UserID:       row.UserID,
+		ObjectKey:    row.ObjectKey,
+		EventCount:   int(row.EventCount),
+		FirstEventAt: row.FirstEventAt,
+		LastEventAt:  row.LastEventAt,
+		CreatedAt:    row.CreatedAt,
+	}
+}
+
+func (c *postgresClient) ListArchivableSessions(ctx context.Context, updatedBefore time.Time, limit int) ([]dbpkg.Session, error) {
+	rows, err := c.q.ListArchivableSessions(ctx, dbgen.ListArchivableSessionsParams{UpdatedBefore: updatedBefore, MaxSessions: int32(limit)})
+	if err != nil {
+		return nil, fmt.Errorf("list archivable sessions: %w", err)
+	}
+	sessions := make([]dbpkg.Session, 0, len(rows))
+	for _, row := range rows {
+		sessions = append(sessions, *toSession(row))
+	}
+	return sessions, nil
+}
+
+func (c *postgresClient) ArchiveEvents(ctx context.Context, archive *dbpkg.EventArchive, eventIDs []string) (*dbpkg.EventArchive, error) {
+	var result *dbpkg.EventArchive
+	err := c.withTx(ctx, func(q *dbgen.Queries) error {
+		row, err := q.InsertEventArchive(ctx, dbgen.InsertEventArchiveParams{
+			SessionID:    archive.SessionID,
+			UserID:       archive.UserID,
+			ObjectKey:    archive.ObjectKey,
+			EventCount:   int32(archive.EventCount),
+			FirstEventAt: archive.FirstEventAt,
+			LastEventAt:  archive.LastEventAt,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to store event archive: %w", err)
+		}
+		if _, err := q.DeleteArchivedEvents(ctx, dbgen.DeleteArchivedEventsParams{UserID: archive.UserID, Ids: eventIDs}); err != nil {
+			return fmt.Errorf("failed to delete archived events: %w", err)
+		}
+		result = toEventArchive(row)
+		return nil
+	})
+	return result, err
+}
+
+func (c *postgresClient) ListEventArchives(ctx context.Context, sessionID, userID string) ([]*dbpkg.EventArchive, error) {
+	rows, err := c.q.ListEventArchives(ctx, dbgen.ListEventArchivesParams{SessionID: sessionID, UserID: userID})
+	if err != nil {
+		return nil, fmt.Errorf("list event archives: %w", err)
+	}
+	archives := make([]*dbpkg.EventArchive, 0, len(rows))
+	for _, row := range rows {
+		archives = append(archives, toEventArchive(row))
+	}
+	return archives, nil
+}
+
+func (c *postgresClient) ListEventArchiveKeys(ctx context.Context) ([]string, error) {
+	keys, err := c.q.ListEventArchiveKeys(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list event archive keys: %w", err)
+	}
+	return keys, nil
+}
+
 // ── Tasks ─────────────────────────────────────────────────────────────────────
 
 // TODO(0.11.0): Switch task writes to v1 storage format and remove legacy conversion from this write path.
@@ -397,6 +742,59 @@ func (c *postgresClient) DeletePushNotification(ctx context.Context, taskID stri
 	return c.q.SoftDeletePushNotification(ctx, taskID)
 }
 
+func (c *postgresClient) DeletePushNotificationConfig(ctx context.Context, taskID, configID string) error {
+	return c.q.SoftDeletePushNotificationConfig(ctx, dbgen.SoftDeletePushNotificationConfigParams{TaskID: taskID, ID: configID})
+}
+
+func (c *postgresClient) RecordPushNotificationDelivery(ctx context.Context, delivery *dbpkg.PushNotificationDelivery) error {
+	params := dbgen.UpsertPushNotificationDeliveryParams{
+		TaskID:      delivery.TaskID,
+		ConfigID:    delivery.ConfigID,
+		TaskState:   delivery.TaskState,
+		Status:      string(delivery.Status),
+		Attempts:    int32(delivery.Attempts),
+		DeliveredAt: delivery.DeliveredAt,
+	}
+	if delivery.LastStatusCode != 0 {
+		code := int32(delivery.LastStatusCode)
+		params.LastStatusCode = &code
+	}
+	if delivery.LastError != "" {
+		params.LastError = &delivery.LastError
+	}
+	if err := c.q.UpsertPushNotificationDelivery(ctx, params); err != nil {
+		return fmt.Errorf("failed to record push notification delivery: %w", err)
+	}
+	return nil
+}
+
+func (c *postgresClient) ListPushNotificationDeliveries(ctx context.Context, taskID string) ([]dbpkg.PushNotificationDelivery, error) {
+	rows, err := c.q.ListPushNotificationDeliveries(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list push notification deliveries: %w", err)
+	}
+	result := make([]dbpkg.PushNotificationDelivery, 0, len(rows))
+	for _, row := range rows {
+		delivery := dbpkg.PushNotificationDelivery{
+			TaskID:      row.TaskID,
+			ConfigID:    row.ConfigID,
+			TaskState:   row.TaskState,
+			Status:      dbpkg.PushNotificationDeliveryStatus(row.Status),
+			Attempts:    int(row.Attempts),
+			DeliveredAt: row.DeliveredAt,
+			UpdatedAt:   row.UpdatedAt,
+		}
+		if row.LastStatusCode != nil {
+			delivery.LastStatusCode = int(*row.LastStatusCode)
+		}
+		if row.LastError != nil {
+			delivery.LastError = *row.LastError
+		}
+		result = append(result, delivery)
+	}
+	return result, nil
+}
+
 // ── Feedback ──────────────────────────────────────────────────────────────────
 
 func (c *postgresClient) StoreFeedback(ctx context.Context, feedback *dbpkg.Feedback) error {
@@ -811,6 +1209,275 @@ func (c *postgresClient) PruneExpiredMemories(ctx context.Context) error {
 	})
 }
 
+// ── Provider analytics ────────────────────────────────────────────────────────
+
+func (c *postgresClient) RecordProviderCall(ctx context.Context, call *dbpkg.ProviderCall) error {
+	var errorCount int64
+	if call.Failed {
+		errorCount = 1
+	}
+	at := call.At
+	if at.IsZero() {
+		at = time.Now()
+	}
+	if err := c.q.UpsertProviderDailyStats(ctx, dbgen.UpsertProviderDailyStatsParams{
+		Day:            at.UTC().Truncate(24 * time.Hour),
+		Provider:       call.Provider,
+		Model:          call.Model,
+		ErrorCount:     errorCount,
+		TotalLatencyMs: call.Latency.Milliseconds(),
+	}); err != nil {
+		return fmt.Errorf("failed to record provider call for %s: %w", call.Provider, err)
+	}
+	return nil
+}
+
+func (c *postgresClient) ListProviderDailyStats(ctx context.Context, since time.Time) ([]dbpkg.ProviderDailyStats, error) {
+	rows, err := c.q.ListProviderDailyStats(ctx, since.UTC().Truncate(24*time.Hour))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list provider stats: %w", err)
+	}
+	stats := make([]dbpkg.ProviderDailyStats, len(rows))
+	for i, r := range rows {
+		stats[i] = *toProviderDailyStats(r)
+	}
+	return stats, nil
+}
+
+// ── Tool call stats ───────────────────────────────────────────────────────────
+
+func (c *postgresClient) RecordToolCallStats(ctx context.Context, stats *dbpkg.ToolCallStats) error {
+	hour := stats.Hour
+	if hour.IsZero() {
+		hour = time.Now()
+	}
+	if err := c.q.UpsertToolCallHourlyStats(ctx, dbgen.UpsertToolCallHourlyStatsParams{
+		Hour:           hour.UTC().Truncate(time.Hour),
+		ToolServer:     stats.ToolServer,
+		AgentID:        stats.AgentID,
+		CallCount:      stats.CallCount,
+		ErrorCount:     stats.ErrorCount,
+		LatencyBuckets: stats.LatencyBuckets,
+	}); err != nil {
+		return fmt.Errorf("failed to record tool call stats for %s: %w", stats.ToolServer, err)
+	}
+	return nil
+}
+
+func (c *postgresClient) ListToolCallStats(ctx context.Context, toolServer string, since time.Time) ([]dbpkg.ToolCallStats, error) {
+	rows, err := c.q.ListToolCallHourlyStats(ctx, dbgen.ListToolCallHourlyStatsParams{
+		ToolServer: toolServer,
+		Hour:       since.UTC().Truncate(time.Hour),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tool call stats for %s: %w", toolServer, err)
+	}
+	stats := make([]dbpkg.ToolCallStats, len(rows))
+	for i, r := range rows {
+		stats[i] = dbpkg.ToolCallStats{
+			Hour:           r.Hour,
+			ToolServer:     r.ToolServer,
+			AgentID:        r.AgentID,
+			CallCount:      r.CallCount,
+			ErrorCount:     r.ErrorCount,
+			LatencyBuckets: r.LatencyBuckets,
+		}
+	}
+	return stats, nil
+}
+
+// ── Task stream events ────────────────────────────────────────────────────────
+
+// isUniqueViolation reports whether err is a unique constraint violation.
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
+
+func (c *postgresClient) AppendTaskStreamEvent(ctx context.Context, taskID, userID string, data []byte) (int64, error) {
+	params := dbgen.AppendTaskStreamEventParams{TaskID: taskID, UserID: userID, Data: string(data)}
+	seq, err := c.q.AppendTaskStreamEvent(ctx, params)
+	if isUniqueViolation(err) {
+		// Another stream of the task took the sequence number; take the next.
+		seq, err = c.q.AppendTaskStreamEvent(ctx, params)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to append stream event of task %s: %w", taskID, err)
+	}
+	return seq, nil
+}
+
+func (c *postgresClient) ListTaskStreamEvents(ctx context.Context, taskID, userID string, afterSeq int64) ([]*dbpkg.TaskStreamEvent, error) {
+	rows, err := c.q.ListTaskStreamEvents(ctx, dbgen.ListTaskStreamEventsParams{TaskID: taskID, UserID: userID, Seq: afterSeq})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stream events of task %s: %w", taskID, err)
+	}
+	events := make([]*dbpkg.TaskStreamEvent, len(rows))
+	for i, r := range rows {
+		events[i] = &dbpkg.TaskStreamEvent{
+			TaskID:    r.TaskID,
+			Seq:       r.Seq,
+			UserID:    r.UserID,
+			Data:      r.Data,
+			CreatedAt: r.CreatedAt,
+		}
+	}
+	return events, nil
+}
+
+// ── Agent activity ────────────────────────────────────────────────────────────
+
+func (c *postgresClient) CountActiveSessionsByAgent(ctx context.Context, since time.Time) ([]dbpkg.AgentSessionCount, error) {
+	rows, err := c.q.CountActiveSessionsByAgent(ctx, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count active sessions: %w", err)
+	}
+	counts := make([]dbpkg.AgentSessionCount, len(rows))
+	for i, r := range rows {
+		counts[i] = dbpkg.AgentSessionCount{AgentID: derefStr(r.AgentID), Sessions: r.Sessions}
+	}
+	return counts, nil
+}
+
+func (c *postgresClient) ListAgentTasksUpdatedSince(ctx context.Context, since time.Time) ([]dbpkg.AgentTask, error) {
+	rows, err := c.q.ListAgentTasksUpdatedSince(ctx, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list agent tasks: %w", err)
+	}
+	tasks := make([]dbpkg.AgentTask, 0, len(rows))
+	for i, r := range rows {
+		task, err := parseVersionedTask(r.Data, r.ProtocolVersion)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse task row %d: %w", i, err)
+		}
+		tasks = append(tasks, dbpkg.AgentTask{
+			AgentID:   derefStr(r.AgentID),
+			Task:      task,
+			CreatedAt: derefTime(r.CreatedAt),
+			UpdatedAt: derefTime(r.UpdatedAt),
+		})
+	}
+	return tasks, nil
+}
+
+// ── Debug capture ─────────────────────────────────────────────────────────────
+
+func (c *postgresClient) StoreDebugCaptureEntry(ctx context.Context, entry *dbpkg.DebugCaptureEntry) error {
+	if err := c.q.InsertDebugCaptureEntry(ctx, dbgen.InsertDebugCaptureEntryParams{
+		CaptureID:  entry.CaptureID,
+		ObjectKind: entry.ObjectKind,
+		Namespace:  entry.Namespace,
+		Name:       entry.Name,
+		Stage:      entry.Stage,
+		Message:    entry.Message,
+		Data:       strPtrIfNotEmpty(entry.Data),
+	}); err != nil {
+		return fmt.Errorf("failed to store debug capture entry: %w", err)
+	}
+	return nil
+}
+
+func (c *postgresClient) ListDebugCaptureEntries(ctx context.Context, kind, namespace, name, captureID string) ([]dbpkg.DebugCaptureEntry, error) {
+	rows, err := c.q.ListDebugCaptureEntries(ctx, dbgen.ListDebugCaptureEntriesParams{
+		ObjectKind: kind,
+		Namespace:  namespace,
+		Name:       name,
+		CaptureID:  captureID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list debug capture entries: %w", err)
+	}
+	entries := make([]dbpkg.DebugCaptureEntry, len(rows))
+	for i, r := range rows {
+		entries[i] = *toDebugCaptureEntry(r)
+	}
+	return entries, nil
+}
+
+func (c *postgresClient) DeleteDebugCaptureEntries(ctx context.Context, kind, namespace, name string) error {
+	if err := c.q.DeleteDebugCaptureEntries(ctx, dbgen.DeleteDebugCaptureEntriesParams{
+		ObjectKind: kind,
+		Namespace:  namespace,
+		Name:       name,
+	}); err != nil {
+		return fmt.Errorf("failed to delete debug capture entries: %w", err)
+	}
+	return nil
+}
+
+// ── Retention ─────────────────────────────────────────────────────────────────
+
+// errDryRun rolls back a dry-run prune.
+var errDryRun = errors.New("dry run")
+
+func (c *postgresClient) PruneHistory(ctx context.Context, policy dbpkg.RetentionPolicy, dryRun bool) (*dbpkg.PruneResult, error) {
+	now := time.Now()
+	result := &dbpkg.PruneResult{}
+	err := c.withTx(ctx, func(q *dbgen.Queries) error {
+		if policy.SessionMaxAge > 0 || policy.MaxSessions > 0 {
+			var params dbgen.PruneSessionsParams
+			if policy.SessionMaxAge > 0 {
+				updatedBefore := now.Add(-policy.SessionMaxAge)
+				params.UpdatedBefore = &updatedBefore
+			}
+			if policy.MaxSessions > 0 {
+				maxSessions := int64(policy.MaxSessions)
+				params.MaxSessions = &maxSessions
+			}
+			row, err := q.PruneSessions(ctx, params)
+			if err != nil {
+				return fmt.Errorf("failed to prune sessions: %w", err)
+			}
+			result.Sessions += row.Sessions
+			result.Events += row.Events
+			result.Tasks += row.Tasks
+			result.PushNotifications += row.PushNotifications
+		}
+		if policy.TaskMaxAge > 0 {
+			row, err := q.PruneTasks(ctx, now.Add(-policy.TaskMaxAge))
+			if err != nil {
+				return fmt.Errorf("failed to prune tasks: %w", err)
+			}
+			result.Tasks += row.Tasks
+			result.PushNotifications += row.PushNotifications
+		}
+		if policy.EventMaxAge > 0 {
+			n, err := q.PruneEvents(ctx, now.Add(-policy.EventMaxAge))
+			if err != nil {
+				return fmt.Errorf("failed to prune events: %w", err)
+			}
+			result.Events += n
+			n, err = q.PruneEventArchives(ctx, now.Add(-policy.EventMaxAge))
+			if err != nil {
+				return fmt.Errorf("failed to prune event archives: %w", err)
+			}
+			result.Events += n
+		}
+		if policy.PushNotificationMaxAge > 0 {
+			n, err := q.PrunePushNotifications(ctx, now.Add(-policy.PushNotificationMaxAge))
+			if err != nil {
+				return fmt.Errorf("failed to prune push notifications: %w", err)
+			}
+			result.PushNotifications += n
+		}
+		if policy.StreamEventMaxAge > 0 {
+			n, err := q.PruneTaskStreamEvents(ctx, now.Add(-policy.StreamEventMaxAge))
+			if err != nil {
+				return fmt.Errorf("failed to prune stream events: %w", err)
+			}
+			result.StreamEvents += n
+		}
+		if dryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		return nil, err
+	}
+	return result, nil
+}
+
 // ── Conversion helpers ────────────────────────────────────────────────────────
 
 func toAgent(r dbgen.Agent) *dbpkg.Agent {
@@ -899,6 +1566,32 @@ func toTask(r dbgen.Task) *dbpkg.Task {
 	}
 }
 
+func toProviderDailyStats(r dbgen.ProviderDailyStat) *dbpkg.ProviderDailyStats {
+	return &dbpkg.ProviderDailyStats{
+		Day:            r.Day,
+		Provider:       r.Provider,
+		Model:          r.Model,
+		RequestCount:   r.RequestCount,
+		ErrorCount:     r.ErrorCount,
+		TotalLatencyMs: r.TotalLatencyMs,
+		MaxLatencyMs:   r.MaxLatencyMs,
+	}
+}
+
+func toDebugCaptureEntry(r dbgen.DebugCaptureEntry) *dbpkg.DebugCaptureEntry {
+	return &dbpkg.DebugCaptureEntry{
+		ID:         r.ID,
+		CaptureID:  r.CaptureID,
+		ObjectKind: r.ObjectKind,
+		Namespace:  r.Namespace,
+		Name:       r.Name,
+		Stage:      r.Stage,
+		Message:    r.Message,
+		Data:       derefStr(r.Data),
+		CreatedAt:  r.CreatedAt,
+	}
+}
+
 func toFeedback(r dbgen.Feedback) *dbpkg.Feedback {
 	return &dbpkg.Feedback{
 		ID:           r.ID,
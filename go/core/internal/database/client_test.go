@@ -2,6 +2,7 @@ package database
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"testing"
@@ -146,6 +147,56 @@ func TestConcurrentRefreshToolsForServer(t *testing.T) {
 	}
 }
 
+// TestWithLockSerializesToolServerWrites verifies that writers holding the
+// lock of a tool server run one at a time, so concurrent refreshes leave
+// exactly the tools of one of them, and that a failed writer rolls back.
+func TestWithLockSerializesToolServerWrites(t *testing.T) {
+	db := setupTestDB(t)
+	client := NewClient(db)
+	ctx := context.Background()
+
+	serverName := "locked-server"
+	groupKind := "RemoteMCPServer"
+	lockKey := "kagent/toolserver/" + groupKind + "/" + serverName
+
+	const numGoroutines = 10
+
+	var wg sync.WaitGroup
+	wg.Add(numGoroutines)
+
+	for i := range numGoroutines {
+		go func(goroutineID int) {
+			defer wg.Done()
+			err := client.WithLock(ctx, lockKey, func(tx dbpkg.Client) error {
+				if _, err := tx.StoreToolServer(ctx, &dbpkg.ToolServer{Name: serverName, GroupKind: groupKind, Description: "Test server"}); err != nil {
+					return err
+				}
+				return tx.RefreshToolsForServer(ctx, serverName, groupKind,
+					&v1alpha2.MCPTool{Name: fmt.Sprintf("tool-a-%d", goroutineID), Description: "Tool A"},
+					&v1alpha2.MCPTool{Name: fmt.Sprintf("tool-b-%d", goroutineID), Description: "Tool B"},
+				)
+			})
+			assert.NoError(t, err, "WithLock should not fail")
+		}(i)
+	}
+
+	wg.Wait()
+
+	tools, err := client.ListToolsForServer(ctx, serverName, groupKind)
+	require.NoError(t, err)
+	assert.Len(t, tools, 2, "Serialized refreshes should leave the tools of the last one")
+
+	errRollback := errors.New("rollback")
+	err = client.WithLock(ctx, lockKey, func(tx dbpkg.Client) error {
+		require.NoError(t, tx.DeleteToolsForServer(ctx, serverName, groupKind))
+		return errRollback
+	})
+	assert.ErrorIs(t, err, errRollback)
+	tools, err = client.ListToolsForServer(ctx, serverName, groupKind)
+	require.NoError(t, err)
+	assert.Len(t, tools, 2, "A failed writer should roll back")
+}
+
 // TestConcurrentSessionUpserts verifies that concurrent StoreSession calls
 // don't corrupt data and that a session is always visible via GetSession
 // immediately after StoreSession returns. This validates that StoreSession
@@ -284,6 +335,48 @@ func TestListSessionsOrdersByRecentActivity(t *testing.T) {
 	})
 }
 
+func TestListSessionsWithOptions(t *testing.T) {
+	db := setupTestDB(t)
+	client := NewClient(db)
+	ctx := context.Background()
+
+	userID := "test-user"
+	for _, sessionID := range []string{"c", "a", "b"} {
+		err := client.StoreSession(ctx, &dbpkg.Session{ID: sessionID, UserID: userID, Name: new(sessionID)})
+		require.NoError(t, err)
+		time.Sleep(10 * time.Millisecond)
+	}
+	first, err := client.GetSession(ctx, "c", userID)
+	require.NoError(t, err)
+
+	ids := func(sessions []dbpkg.Session) []string {
+		out := make([]string, len(sessions))
+		for i, s := range sessions {
+			out[i] = s.ID
+		}
+		return out
+	}
+
+	tests := []struct {
+		name string
+		opts dbpkg.SessionListOptions
+		want []string
+	}{
+		{name: "defaults to most recent activity", want: []string{"b", "a", "c"}},
+		{name: "sorts by name ascending", opts: dbpkg.SessionListOptions{SortBy: "name", SortAsc: true}, want: []string{"a", "b", "c"}},
+		{name: "sorts by creation ascending", opts: dbpkg.SessionListOptions{SortBy: "created_at", SortAsc: true}, want: []string{"c", "a", "b"}},
+		{name: "pages results", opts: dbpkg.SessionListOptions{SortBy: "name", SortAsc: true, Limit: 1, Offset: 1}, want: []string{"b"}},
+		{name: "filters by creation time", opts: dbpkg.SessionListOptions{CreatedAfter: first.CreatedAt}, want: []string{"b", "a"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sessions, err := client.ListSessionsWithOptions(ctx, userID, tt.opts)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, ids(sessions))
+		})
+	}
+}
+
 func TestStoreEventTouchesSessionActivity(t *testing.T) {
 	db := setupTestDB(t)
 	client := NewClient(db)
@@ -974,3 +1067,326 @@ func TestSearchAgentMemoryConcurrentAccessCount(t *testing.T) {
 		require.NoError(t, err, "concurrent memory search must not fail")
 	}
 }
+
+// TestRecordProviderCallAggregatesDaily verifies provider calls on the same
+// UTC day fold into one row per provider/model and days stay separate.
+func TestRecordProviderCallAggregatesDaily(t *testing.T) {
+	db := setupTestDB(t)
+	client := NewClient(db)
+	ctx := context.Background()
+
+	today := time.Now().UTC().Truncate(24 * time.Hour).Add(2 * time.Hour)
+	yesterday := today.Add(-24 * time.Hour)
+
+	calls := []*dbpkg.ProviderCall{
+		{Provider: "OpenAI", Model: "gpt-4.1", Latency: 100 * time.Millisecond, At: today},
+		{Provider: "OpenAI", Model: "gpt-4.1", Latency: 300 * time.Millisecond, Failed: true, At: today},
+		{Provider: "OpenAI", Model: "gpt-4.1", Latency: 50 * time.Millisecond, At: yesterday},
+		{Provider: "Anthropic", Model: "claude-sonnet-4-6", Latency: 200 * time.Millisecond, At: today},
+	}
+	for _, c := range calls {
+		require.NoError(t, client.RecordProviderCall(ctx, c))
+	}
+
+	stats, err := client.ListProviderDailyStats(ctx, today)
+	require.NoError(t, err)
+	require.Len(t, stats, 2)
+
+	assert.Equal(t, "Anthropic", stats[0].Provider)
+	assert.Equal(t, int64(1), stats[0].RequestCount)
+
+	assert.Equal(t, "OpenAI", stats[1].Provider)
+	assert.Equal(t, int64(2), stats[1].RequestCount)
+	assert.Equal(t, int64(1), stats[1].ErrorCount)
+	assert.Equal(t, int64(400), stats[1].TotalLatencyMs)
+	assert.Equal(t, int64(300), stats[1].MaxLatencyMs)
+
+	stats, err = client.ListProviderDailyStats(ctx, yesterday)
+	require.NoError(t, err)
+	assert.Len(t, stats, 3)
+}
+
+// TestRecordToolCallStatsAggregatesHourly verifies reports of an agent in the
+// same UTC hour fold into one row, with latency buckets added element-wise.
+func TestRecordToolCallStatsAggregatesHourly(t *testing.T) {
+	db := setupTestDB(t)
+	client := NewClient(db)
+	ctx := context.Background()
+
+	hour := time.Now().UTC().Truncate(time.Hour)
+	reports := []*dbpkg.ToolCallStats{
+		{Hour: hour.Add(time.Minute), ToolServer: "kagent/tools", AgentID: "kagent__NS__k8s_agent", CallCount: 3, ErrorCount: 1, LatencyBuckets: []int64{1, 2, 0}},
+		{Hour: hour.Add(2 * time.Minute), ToolServer: "kagent/tools", AgentID: "kagent__NS__k8s_agent", CallCount: 2, LatencyBuckets: []int64{0, 1, 1}},
+		{Hour: hour.Add(-time.Minute), ToolServer: "kagent/tools", AgentID: "kagent__NS__k8s_agent", CallCount: 1, LatencyBuckets: []int64{1, 0, 0}},
+		{Hour: hour, ToolServer: "kagent/other", AgentID: "kagent__NS__k8s_agent", CallCount: 1, LatencyBuckets: []int64{1, 0, 0}},
+	}
+	for _, r := range reports {
+		require.NoError(t, client.RecordToolCallStats(ctx, r))
+	}
+
+	stats, err := client.ListToolCallStats(ctx, "kagent/tools", hour)
+	require.NoError(t, err)
+	require.Len(t, stats, 1)
+	assert.Equal(t, int64(5), stats[0].CallCount)
+	assert.Equal(t, int64(1), stats[0].ErrorCount)
+	assert.Equal(t, []int64{1, 3, 1}, stats[0].LatencyBuckets)
+
+	stats, err = client.ListToolCallStats(ctx, "kagent/tools", hour.Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Len(t, stats, 2)
+}
+
+func TestAgentActivity(t *testing.T) {
+	db := setupTestDB(t)
+	client := NewClient(db)
+	ctx := context.Background()
+
+	triage, fix := "kagent__NS__triage_agent", "kagent__NS__fix_agent"
+	since := time.Now().Add(-time.Minute)
+	require.NoError(t, client.StoreSession(ctx, &dbpkg.Session{ID: "s1", UserID: "user-a", AgentID: &triage}))
+	require.NoError(t, client.StoreSession(ctx, &dbpkg.Session{ID: "s2", UserID: "user-b", AgentID: &triage}))
+	require.NoError(t, client.StoreSession(ctx, &dbpkg.Session{ID: "s3", UserID: "user-a", AgentID: &fix}))
+	require.NoError(t, client.StoreSession(ctx, &dbpkg.Session{ID: "no-agent", UserID: "user-a"}))
+
+	require.NoError(t, client.StoreTask(ctx, &a2a.Task{ID: "t1", ContextID: "s1", Status: a2a.TaskStatus{State: a2a.TaskStateWorking}}, "user-a"))
+	require.NoError(t, client.StoreTask(ctx, &a2a.Task{ID: "t2", ContextID: "s3", Status: a2a.TaskStatus{State: a2a.TaskStateCompleted}}, "user-a"))
+	require.NoError(t, client.StoreTask(ctx, &a2a.Task{ID: "t3", ContextID: "no-agent"}, "user-a"))
+
+	counts, err := client.CountActiveSessionsByAgent(ctx, since)
+	require.NoError(t, err)
+	assert.Equal(t, []dbpkg.AgentSessionCount{{AgentID: fix, Sessions: 1}, {AgentID: triage, Sessions: 2}}, counts)
+
+	tasks, err := client.ListAgentTasksUpdatedSince(ctx, since)
+	require.NoError(t, err)
+	require.Len(t, tasks, 2)
+	byID := map[a2a.TaskID]dbpkg.AgentTask{}
+	for _, task := range tasks {
+		byID[task.Task.ID] = task
+	}
+	assert.Equal(t, triage, byID["t1"].AgentID)
+	assert.Equal(t, a2a.TaskStateWorking, byID["t1"].Task.Status.State)
+	assert.Equal(t, fix, byID["t2"].AgentID)
+	assert.False(t, byID["t2"].UpdatedAt.Before(byID["t2"].CreatedAt))
+
+	sessions, err := client.ListSessionsUpdatedSince(ctx, since)
+	require.NoError(t, err)
+	assert.Len(t, sessions, 4, "sessions of every user, with or without an agent")
+
+	counts, err = client.CountActiveSessionsByAgent(ctx, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.Empty(t, counts)
+	sessions, err = client.ListSessionsUpdatedSince(ctx, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.Empty(t, sessions)
+}
+
+func TestRecordPushNotificationDeliveryKeepsLatestAttempt(t *testing.T) {
+	db := setupTestDB(t)
+	client := NewClient(db)
+	ctx := context.Background()
+
+	require.NoError(t, client.RecordPushNotificationDelivery(ctx, &dbpkg.PushNotificationDelivery{
+		TaskID:         "task-1",
+		ConfigID:       "cfg-1",
+		TaskState:      "TASK_STATE_WORKING",
+		Status:         dbpkg.PushNotificationDeliveryRetrying,
+		Attempts:       1,
+		LastStatusCode: 503,
+		LastError:      "endpoint returned 503 Service Unavailable",
+	}))
+
+	deliveredAt := time.Now().UTC().Truncate(time.Second)
+	require.NoError(t, client.RecordPushNotificationDelivery(ctx, &dbpkg.PushNotificationDelivery{
+		TaskID:         "task-1",
+		ConfigID:       "cfg-1",
+		TaskState:      "TASK_STATE_WORKING",
+		Status:         dbpkg.PushNotificationDeliveryDelivered,
+		Attempts:       2,
+		LastStatusCode: 200,
+		DeliveredAt:    &deliveredAt,
+	}))
+
+	deliveries, err := client.ListPushNotificationDeliveries(ctx, "task-1")
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, dbpkg.PushNotificationDeliveryDelivered, deliveries[0].Status)
+	assert.Equal(t, 2, deliveries[0].Attempts)
+	assert.Equal(t, 200, deliveries[0].LastStatusCode)
+	assert.Empty(t, deliveries[0].LastError)
+	require.NotNil(t, deliveries[0].DeliveredAt)
+	assert.True(t, deliveredAt.Equal(*deliveries[0].DeliveredAt))
+}
+
+func TestTaskStreamEventsNumberedPerTask(t *testing.T) {
+	db := setupTestDB(t)
+	client := NewClient(db)
+	ctx := context.Background()
+
+	for _, e := range []struct{ task, data string }{{"t1", "a"}, {"t2", "b"}, {"t1", "c"}, {"t1", "d"}} {
+		_, err := client.AppendTaskStreamEvent(ctx, e.task, "user-a", []byte(e.data))
+		require.NoError(t, err)
+	}
+
+	events, err := client.ListTaskStreamEvents(ctx, "t1", "user-a", 1)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, int64(2), events[0].Seq)
+	assert.Equal(t, "c", events[0].Data)
+	assert.Equal(t, int64(3), events[1].Seq)
+
+	events, err = client.ListTaskStreamEvents(ctx, "t1", "user-b", 0)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+}
+
+func TestDebugCaptureEntriesScopedToCapture(t *testing.T) {
+	db := setupTestDB(t)
+	client := NewClient(db)
+	ctx := context.Background()
+
+	for _, e := range []dbpkg.DebugCaptureEntry{
+		{CaptureID: "old", ObjectKind: "Agent", Namespace: "kagent", Name: "a", Stage: "reconcile", Message: "start"},
+		{CaptureID: "new", ObjectKind: "Agent", Namespace: "kagent", Name: "a", Stage: "reconcile", Message: "start"},
+		{CaptureID: "new", ObjectKind: "Agent", Namespace: "kagent", Name: "a", Stage: "translate", Message: "output", Data: `{"replicas":1}`},
+		{CaptureID: "new", ObjectKind: "Agent", Namespace: "kagent", Name: "b", Stage: "reconcile", Message: "start"},
+	} {
+		require.NoError(t, client.StoreDebugCaptureEntry(ctx, &e))
+	}
+
+	entries, err := client.ListDebugCaptureEntries(ctx, "Agent", "kagent", "a", "new")
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "start", entries[0].Message)
+	assert.Equal(t, `{"replicas":1}`, entries[1].Data)
+
+	require.NoError(t, client.DeleteDebugCaptureEntries(ctx, "Agent", "kagent", "a"))
+	entries, err = client.ListDebugCaptureEntries(ctx, "Agent", "kagent", "a", "new")
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	entries, err = client.ListDebugCaptureEntries(ctx, "Agent", "kagent", "b", "new")
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestSessionGrantForPrincipal(t *testing.T) {
+	db := setupTestDB(t)
+	client := NewClient(db)
+	ctx := context.Background()
+
+	require.NoError(t, client.StoreSession(ctx, &dbpkg.Session{ID: "sess-1", UserID: "owner"}))
+	for _, g := range []dbpkg.SessionGrant{
+		{SessionID: "sess-1", OwnerID: "owner", PrincipalKind: dbpkg.SessionPrincipalUser, Principal: "alice", Access: dbpkg.SessionAccessRead},
+		{SessionID: "sess-1", OwnerID: "owner", PrincipalKind: dbpkg.SessionPrincipalGroup, Principal: "sre", Access: dbpkg.SessionAccessInvoke},
+	} {
+		_, err := client.UpsertSessionGrant(ctx, &g)
+		require.NoError(t, err)
+	}
+
+	grant, err := client.GetSessionGrantForPrincipal(ctx, "sess-1", "alice", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "owner", grant.OwnerID)
+	assert.Equal(t, dbpkg.SessionAccessRead, grant.Access)
+
+	grant, err = client.GetSessionGrantForPrincipal(ctx, "sess-1", "alice", []string{"sre"})
+	require.NoError(t, err)
+	assert.Equal(t, dbpkg.SessionAccessInvoke, grant.Access, "the strongest grant wins")
+
+	_, err = client.GetSessionGrantForPrincipal(ctx, "sess-1", "bob", []string{"dev"})
+	require.Error(t, err)
+
+	// Re-granting updates the access in place.
+	_, err = client.UpsertSessionGrant(ctx, &dbpkg.SessionGrant{SessionID: "sess-1", OwnerID: "owner", PrincipalKind: dbpkg.SessionPrincipalUser, Principal: "alice", Access: dbpkg.SessionAccessInvoke})
+	require.NoError(t, err)
+	grants, err := client.ListSessionGrants(ctx, "sess-1", "owner")
+	require.NoError(t, err)
+	require.Len(t, grants, 2)
+	assert.Equal(t, dbpkg.SessionAccessInvoke, grants[1].Access)
+
+	require.NoError(t, client.DeleteSessionGrant(ctx, "sess-1", "owner", dbpkg.SessionPrincipalGroup, "sre"))
+	grants, err = client.ListSessionGrants(ctx, "sess-1", "owner")
+	require.NoError(t, err)
+	assert.Len(t, grants, 1)
+
+	// Grants on a deleted session give no access.
+	require.NoError(t, client.DeleteSession(ctx, "sess-1", "owner"))
+	_, err = client.GetSessionGrantForPrincipal(ctx, "sess-1", "alice", nil)
+	require.Error(t, err)
+}
+
+func TestSessionState(t *testing.T) {
+	db := setupTestDB(t)
+	client := NewClient(db)
+	ctx := context.Background()
+
+	require.NoError(t, client.StoreSession(ctx, &dbpkg.Session{ID: "sess-1", UserID: "owner"}))
+
+	entry, err := client.GetSessionStateValue(ctx, "sess-1", "owner", "city")
+	require.NoError(t, err)
+	assert.Nil(t, entry)
+
+	_, err = client.SetSessionStateValue(ctx, &dbpkg.SessionStateEntry{SessionID: "sess-1", UserID: "owner", Key: "city", Value: []byte(`"Paris"`)})
+	require.NoError(t, err)
+	entry, err = client.SetSessionStateValue(ctx, &dbpkg.SessionStateEntry{SessionID: "sess-1", UserID: "owner", Key: "city", Value: []byte(`"Rome"`)})
+	require.NoError(t, err)
+	assert.JSONEq(t, `"Rome"`, string(entry.Value))
+	_, err = client.SetSessionStateValue(ctx, &dbpkg.SessionStateEntry{SessionID: "sess-1", UserID: "owner", Key: "a", Value: []byte(`{"n":1}`)})
+	require.NoError(t, err)
+
+	entries, err := client.ListSessionState(ctx, "sess-1", "owner")
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "a", entries[0].Key)
+	assert.Equal(t, "city", entries[1].Key)
+
+	entries, err = client.ListSessionState(ctx, "sess-1", "alice")
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	deleted, err := client.DeleteSessionStateValue(ctx, "sess-1", "owner", "city")
+	require.NoError(t, err)
+	assert.True(t, deleted)
+	deleted, err = client.DeleteSessionStateValue(ctx, "sess-1", "owner", "city")
+	require.NoError(t, err)
+	assert.False(t, deleted)
+}
+
+func TestLLMTraces(t *testing.T) {
+	db := setupTestDB(t)
+	client := NewClient(db)
+	ctx := context.Background()
+
+	require.NoError(t, client.StoreSession(ctx, &dbpkg.Session{ID: "sess-1", UserID: "owner"}))
+
+	stored, err := client.StoreLLMTrace(ctx, &dbpkg.LLMTrace{
+		SessionID: "sess-1",
+		UserID:    "owner",
+		AgentID:   "agent_1",
+		Model:     "gpt-4o",
+		Request:   []byte(`{"contents":[]}`),
+		Response:  []byte(`{"content":{"role":"model"}}`),
+	})
+	require.NoError(t, err)
+	assert.NotZero(t, stored.ID)
+	_, err = client.StoreLLMTrace(ctx, &dbpkg.LLMTrace{
+		SessionID: "sess-1",
+		UserID:    "owner",
+		AgentID:   "agent_1",
+		Model:     "gpt-4o",
+		Request:   []byte(`{"contents":[]}`),
+		Error:     "rate limited",
+	})
+	require.NoError(t, err)
+
+	traces, err := client.ListLLMTraces(ctx, "sess-1", "owner")
+	require.NoError(t, err)
+	require.Len(t, traces, 2)
+	assert.JSONEq(t, `{"content":{"role":"model"}}`, string(traces[0].Response))
+	assert.Empty(t, traces[0].Error)
+	assert.Nil(t, traces[1].Response)
+	assert.Equal(t, "rate limited", traces[1].Error)
+
+	traces, err = client.ListLLMTraces(ctx, "sess-1", "alice")
+	require.NoError(t, err)
+	assert.Empty(t, traces)
+}
@@ -10,6 +10,34 @@ import (
 	"time"
 )
 
+const copySessionEvents = `-- name: CopySessionEvents :execrows
+INSERT INTO event (id, user_id, session_id, data, created_at, updated_at)
+SELECT gen_random_uuid()::text, e.user_id, $1::text, e.data, e.created_at, NOW()
+FROM event e
+WHERE e.session_id = $2 AND e.user_id = $3 AND e.deleted_at IS NULL
+  AND ($4::timestamptz IS NULL OR e.created_at <= $4)
+`
+
+type CopySessionEventsParams struct {
+	TargetSessionID string
+	SourceSessionID *string
+	UserID          string
+	Until           *time.Time
+}
+
+func (q *Queries) CopySessionEvents(ctx context.Context, arg CopySessionEventsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, copySessionEvents,
+		arg.TargetSessionID,
+		arg.SourceSessionID,
+		arg.UserID,
+		arg.Until,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const getEvent = `-- name: GetEvent :one
 SELECT id, user_id, session_id, created_at, updated_at, deleted_at, data FROM event
 WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL
@@ -42,6 +42,18 @@ type CrewaiFlowState struct {
 	StateData  string
 }
 
+type DebugCaptureEntry struct {
+	ID         int64
+	CaptureID  string
+	ObjectKind string
+	Namespace  string
+	Name       string
+	Stage      string
+	Message    string
+	Data       *string
+	CreatedAt  time.Time
+}
+
 type Event struct {
 	ID        string
 	UserID    string
@@ -52,6 +64,17 @@ type Event struct {
 	Data      string
 }
 
+type EventArchive struct {
+	ID           int64
+	SessionID    string
+	UserID       string
+	ObjectKey    string
+	EventCount   int32
+	FirstEventAt time.Time
+	LastEventAt  time.Time
+	CreatedAt    time.Time
+}
+
 type Feedback struct {
 	ID           int64
 	CreatedAt    *time.Time
@@ -94,6 +117,18 @@ type LgCheckpointWrite struct {
 	DeletedAt    *time.Time
 }
 
+type LlmTrace struct {
+	ID        int64
+	SessionID string
+	UserID    string
+	AgentID   string
+	Model     string
+	Request   []byte
+	Response  []byte
+	Error     *string
+	CreatedAt time.Time
+}
+
 type Memory struct {
 	ID          string
 	AgentName   *string
@@ -106,6 +141,17 @@ type Memory struct {
 	AccessCount *int64
 }
 
+type ProviderDailyStat struct {
+	Day            time.Time
+	Provider       string
+	Model          string
+	RequestCount   int64
+	ErrorCount     int64
+	TotalLatencyMs int64
+	MaxLatencyMs   int64
+	UpdatedAt      time.Time
+}
+
 type PushNotification struct {
 	ID              string
 	TaskID          string
@@ -116,6 +162,18 @@ type PushNotification struct {
 	ProtocolVersion *string
 }
 
+type PushNotificationDelivery struct {
+	TaskID         string
+	ConfigID       string
+	TaskState      string
+	Status         string
+	Attempts       int32
+	LastStatusCode *int32
+	LastError      *string
+	DeliveredAt    *time.Time
+	UpdatedAt      time.Time
+}
+
 type Session struct {
 	ID        string
 	UserID    string
@@ -127,6 +185,15 @@ type Session struct {
 	Source    *string
 }
 
+type SessionGrant struct {
+	SessionID     string
+	OwnerID       string
+	PrincipalKind string
+	Principal     string
+	Access        string
+	CreatedAt     time.Time
+}
+
 type SessionShare struct {
 	ID        int64
 	Token     string
@@ -142,6 +209,14 @@ type SessionShareAccess struct {
 	AccessedAt pgtype.Timestamp
 }
 
+type SessionState struct {
+	SessionID string
+	UserID    string
+	Key       string
+	Value     []byte
+	UpdatedAt time.Time
+}
+
 type Task struct {
 	ID              string
 	CreatedAt       *time.Time
@@ -153,6 +228,14 @@ type Task struct {
 	UserID          *string
 }
 
+type TaskStreamEvent struct {
+	TaskID    string
+	Seq       int64
+	UserID    string
+	Data      string
+	CreatedAt time.Time
+}
+
 type Tool struct {
 	ID          string
 	ServerName  string
@@ -163,6 +246,16 @@ type Tool struct {
 	Description *string
 }
 
+type ToolCallHourlyStat struct {
+	Hour           time.Time
+	ToolServer     string
+	AgentID        string
+	CallCount      int64
+	ErrorCount     int64
+	LatencyBuckets []int64
+	UpdatedAt      time.Time
+}
+
 type Toolserver struct {
 	Name          string
 	GroupKind     string
@@ -7,6 +7,7 @@ package dbgen
 
 import (
 	"context"
+	"time"
 )
 
 const getPushNotification = `-- name: GetPushNotification :one
@@ -35,6 +36,42 @@ func (q *Queries) GetPushNotification(ctx context.Context, arg GetPushNotificati
 	return i, err
 }
 
+const listPushNotificationDeliveries = `-- name: ListPushNotificationDeliveries :many
+SELECT task_id, config_id, task_state, status, attempts, last_status_code, last_error, delivered_at, updated_at FROM push_notification_delivery
+WHERE task_id = $1
+ORDER BY config_id ASC
+`
+
+func (q *Queries) ListPushNotificationDeliveries(ctx context.Context, taskID string) ([]PushNotificationDelivery, error) {
+	rows, err := q.db.Query(ctx, listPushNotificationDeliveries, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PushNotificationDelivery
+	for rows.Next() {
+		var i PushNotificationDelivery
+		if err := rows.Scan(
+			&i.TaskID,
+			&i.ConfigID,
+			&i.TaskState,
+			&i.Status,
+			&i.Attempts,
+			&i.LastStatusCode,
+			&i.LastError,
+			&i.DeliveredAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listPushNotifications = `-- name: ListPushNotifications :many
 SELECT id, task_id, created_at, updated_at, deleted_at, data, protocol_version FROM push_notification
 WHERE task_id = $1 AND deleted_at IS NULL
@@ -79,6 +116,21 @@ func (q *Queries) SoftDeletePushNotification(ctx context.Context, taskID string)
 	return err
 }
 
+const softDeletePushNotificationConfig = `-- name: SoftDeletePushNotificationConfig :exec
+UPDATE push_notification SET deleted_at = NOW()
+WHERE task_id = $1 AND id = $2 AND deleted_at IS NULL
+`
+
+type SoftDeletePushNotificationConfigParams struct {
+	TaskID string
+	ID     string
+}
+
+func (q *Queries) SoftDeletePushNotificationConfig(ctx context.Context, arg SoftDeletePushNotificationConfigParams) error {
+	_, err := q.db.Exec(ctx, softDeletePushNotificationConfig, arg.TaskID, arg.ID)
+	return err
+}
+
 const upsertPushNotification = `-- name: UpsertPushNotification :exec
 INSERT INTO push_notification (id, task_id, data, protocol_version, created_at, updated_at)
 VALUES ($1, $2, $3, $4, NOW(), NOW())
@@ -104,3 +156,41 @@ func (q *Queries) UpsertPushNotification(ctx context.Context, arg UpsertPushNoti
 	)
 	return err
 }
+
+const upsertPushNotificationDelivery = `-- name: UpsertPushNotificationDelivery :exec
+INSERT INTO push_notification_delivery (task_id, config_id, task_state, status, attempts, last_status_code, last_error, delivered_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW())
+ON CONFLICT (task_id, config_id) DO UPDATE SET
+    task_state       = EXCLUDED.task_state,
+    status           = EXCLUDED.status,
+    attempts         = EXCLUDED.attempts,
+    last_status_code = EXCLUDED.last_status_code,
+    last_error       = EXCLUDED.last_error,
+    delivered_at     = COALESCE(EXCLUDED.delivered_at, push_notification_delivery.delivered_at),
+    updated_at       = NOW()
+`
+
+type UpsertPushNotificationDeliveryParams struct {
+	TaskID         string
+	ConfigID       string
+	TaskState      string
+	Status         string
+	Attempts       int32
+	LastStatusCode *int32
+	LastError      *string
+	DeliveredAt    *time.Time
+}
+
+func (q *Queries) UpsertPushNotificationDelivery(ctx context.Context, arg UpsertPushNotificationDeliveryParams) error {
+	_, err := q.db.Exec(ctx, upsertPushNotificationDelivery,
+		arg.TaskID,
+		arg.ConfigID,
+		arg.TaskState,
+		arg.Status,
+		arg.Attempts,
+		arg.LastStatusCode,
+		arg.LastError,
+		arg.DeliveredAt,
+	)
+	return err
+}
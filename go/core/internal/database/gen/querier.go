@@ -6,13 +6,26 @@ package dbgen
 
 import (
 	"context"
+	"time"
 )
 
 type Querier interface {
+	// AcquireTransactionLock waits for the advisory lock named key, which is
+	// released when the transaction ends.
+	AcquireTransactionLock(ctx context.Context, key string) error
+	// AppendTaskStreamEvent stores the next event of a task's streams and
+	// returns its sequence number.
+	AppendTaskStreamEvent(ctx context.Context, arg AppendTaskStreamEventParams) (int64, error)
+	CopySessionEvents(ctx context.Context, arg CopySessionEventsParams) (int64, error)
+	CountActiveSessionsByAgent(ctx context.Context, updatedSince time.Time) ([]CountActiveSessionsByAgentRow, error)
 	CreateSessionShare(ctx context.Context, arg CreateSessionShareParams) (SessionShare, error)
 	DeleteAgentMemory(ctx context.Context, arg DeleteAgentMemoryParams) error
+	DeleteArchivedEvents(ctx context.Context, arg DeleteArchivedEventsParams) (int64, error)
+	DeleteDebugCaptureEntries(ctx context.Context, arg DeleteDebugCaptureEntriesParams) error
 	DeleteExpiredMemories(ctx context.Context) error
+	DeleteSessionGrant(ctx context.Context, arg DeleteSessionGrantParams) error
 	DeleteSessionShare(ctx context.Context, arg DeleteSessionShareParams) error
+	DeleteSessionStateValue(ctx context.Context, arg DeleteSessionStateValueParams) (int64, error)
 	ExtendMemoryTTL(ctx context.Context) error
 	GetAgent(ctx context.Context, id string) (Agent, error)
 	GetCheckpoint(ctx context.Context, arg GetCheckpointParams) (LgCheckpoint, error)
@@ -20,7 +33,9 @@ type Querier interface {
 	GetLatestCrewAIFlowState(ctx context.Context, arg GetLatestCrewAIFlowStateParams) (CrewaiFlowState, error)
 	GetPushNotification(ctx context.Context, arg GetPushNotificationParams) (PushNotification, error)
 	GetSession(ctx context.Context, arg GetSessionParams) (Session, error)
+	GetSessionGrantForPrincipal(ctx context.Context, arg GetSessionGrantForPrincipalParams) (SessionGrant, error)
 	GetSessionShareByToken(ctx context.Context, token string) (SessionShare, error)
+	GetSessionStateValue(ctx context.Context, arg GetSessionStateValueParams) (SessionState, error)
 	// Task ownership: a task belongs to task.user_id. A NULL user_id (row written
 	// before the owner column existed, or by a pre-upgrade pod during a rolling
 	// upgrade) is only visible to, and claimable by, a caller whose session id
@@ -41,14 +56,26 @@ type Querier interface {
 	HardDeleteCrewAIMemory(ctx context.Context, arg HardDeleteCrewAIMemoryParams) error
 	// Lock rows in id order to avoid deadlocks between concurrent overlapping increments.
 	IncrementMemoryAccessCount(ctx context.Context, dollar_1 []string) error
+	InsertDebugCaptureEntry(ctx context.Context, arg InsertDebugCaptureEntryParams) error
 	InsertEvent(ctx context.Context, arg InsertEventParams) error
+	InsertEventArchive(ctx context.Context, arg InsertEventArchiveParams) (EventArchive, error)
 	InsertFeedback(ctx context.Context, arg InsertFeedbackParams) error
+	InsertLLMTrace(ctx context.Context, arg InsertLLMTraceParams) (LlmTrace, error)
 	InsertMemory(ctx context.Context, arg InsertMemoryParams) (string, error)
 	ListAgentMemories(ctx context.Context, arg ListAgentMemoriesParams) ([]Memory, error)
+	// A task is attributed to the agent of its owner's session.
+	ListAgentTasksUpdatedSince(ctx context.Context, updatedSince time.Time) ([]ListAgentTasksUpdatedSinceRow, error)
 	ListAgents(ctx context.Context) ([]Agent, error)
+	// ListArchivableSessions returns the live sessions not updated since
+	// updated_before that still have events in the event table, least recently
+	// updated first.
+	ListArchivableSessions(ctx context.Context, arg ListArchivableSessionsParams) ([]Session, error)
 	ListCheckpointWrites(ctx context.Context, arg ListCheckpointWritesParams) ([]LgCheckpointWrite, error)
 	ListCheckpoints(ctx context.Context, arg ListCheckpointsParams) ([]LgCheckpoint, error)
 	ListCheckpointsLimit(ctx context.Context, arg ListCheckpointsLimitParams) ([]LgCheckpoint, error)
+	ListDebugCaptureEntries(ctx context.Context, arg ListDebugCaptureEntriesParams) ([]DebugCaptureEntry, error)
+	ListEventArchiveKeys(ctx context.Context) ([]string, error)
+	ListEventArchives(ctx context.Context, arg ListEventArchivesParams) ([]EventArchive, error)
 	ListEventsByContextID(ctx context.Context, sessionID *string) ([]Event, error)
 	ListEventsByContextIDLimit(ctx context.Context, arg ListEventsByContextIDLimitParams) ([]Event, error)
 	ListEventsForSessionAsc(ctx context.Context, arg ListEventsForSessionAscParams) ([]Event, error)
@@ -56,15 +83,37 @@ type Querier interface {
 	ListEventsForSessionDesc(ctx context.Context, arg ListEventsForSessionDescParams) ([]Event, error)
 	ListEventsForSessionDescLimit(ctx context.Context, arg ListEventsForSessionDescLimitParams) ([]Event, error)
 	ListFeedback(ctx context.Context, userID string) ([]Feedback, error)
+	ListLLMTraces(ctx context.Context, arg ListLLMTracesParams) ([]LlmTrace, error)
+	ListProviderDailyStats(ctx context.Context, day time.Time) ([]ProviderDailyStat, error)
+	ListPushNotificationDeliveries(ctx context.Context, taskID string) ([]PushNotificationDelivery, error)
 	ListPushNotifications(ctx context.Context, taskID string) ([]PushNotification, error)
+	ListSessionGrants(ctx context.Context, arg ListSessionGrantsParams) ([]SessionGrant, error)
 	ListSessionSharesBySession(ctx context.Context, sessionID string) ([]SessionShare, error)
+	ListSessionState(ctx context.Context, arg ListSessionStateParams) ([]SessionState, error)
 	ListSessions(ctx context.Context, userID string) ([]Session, error)
 	ListSessionsForAgent(ctx context.Context, arg ListSessionsForAgentParams) ([]ListSessionsForAgentRow, error)
 	ListSessionsForAgentAllUsers(ctx context.Context, agentID *string) ([]Session, error)
+	ListSessionsUpdatedSince(ctx context.Context, updatedSince time.Time) ([]Session, error)
+	ListSessionsWithOptions(ctx context.Context, arg ListSessionsWithOptionsParams) ([]Session, error)
+	ListTaskStreamEvents(ctx context.Context, arg ListTaskStreamEventsParams) ([]TaskStreamEvent, error)
 	ListTasksForSession(ctx context.Context, arg ListTasksForSessionParams) ([]Task, error)
+	ListToolCallHourlyStats(ctx context.Context, arg ListToolCallHourlyStatsParams) ([]ToolCallHourlyStat, error)
 	ListToolServers(ctx context.Context) ([]Toolserver, error)
 	ListTools(ctx context.Context) ([]Tool, error)
 	ListToolsForServer(ctx context.Context, arg ListToolsForServerParams) ([]Tool, error)
+	// PruneEventArchives deletes the archives whose newest event was created
+	// before created_before, and returns the number of events they held.
+	PruneEventArchives(ctx context.Context, createdBefore time.Time) (int64, error)
+	PruneEvents(ctx context.Context, createdBefore time.Time) (int64, error)
+	PrunePushNotifications(ctx context.Context, updatedBefore time.Time) (int64, error)
+	// PruneSessions deletes, for good, sessions not updated since updated_before
+	// and all but the newest max_sessions sessions of each user and agent, with
+	// their events (archived ones included), tasks, push notifications, shares,
+	// grants, state and LLM traces. Soft-deleted sessions rank after live ones,
+	// so the count limit removes them first. A NULL argument disables that rule.
+	PruneSessions(ctx context.Context, arg PruneSessionsParams) (PruneSessionsRow, error)
+	PruneTaskStreamEvents(ctx context.Context, createdBefore time.Time) (int64, error)
+	PruneTasks(ctx context.Context, updatedBefore time.Time) (PruneTasksRow, error)
 	// Memory uses hard DELETE (not soft deletes), so no deleted_at filter is needed.
 	// COALESCE guards against NULL embeddings (score=0 rather than NULL); rows are still ordered last by the ORDER BY clause.
 	SearchAgentMemory(ctx context.Context, arg SearchAgentMemoryParams) ([]SearchAgentMemoryRow, error)
@@ -75,6 +124,7 @@ type Querier interface {
 	SoftDeleteCheckpoints(ctx context.Context, arg SoftDeleteCheckpointsParams) error
 	SoftDeleteEvent(ctx context.Context, id string) error
 	SoftDeletePushNotification(ctx context.Context, taskID string) error
+	SoftDeletePushNotificationConfig(ctx context.Context, arg SoftDeletePushNotificationConfigParams) error
 	SoftDeleteSession(ctx context.Context, arg SoftDeleteSessionParams) error
 	SoftDeleteTask(ctx context.Context, arg SoftDeleteTaskParams) error
 	SoftDeleteToolServer(ctx context.Context, arg SoftDeleteToolServerParams) error
@@ -85,8 +135,12 @@ type Querier interface {
 	UpsertCheckpointWrite(ctx context.Context, arg UpsertCheckpointWriteParams) error
 	UpsertCrewAIFlowState(ctx context.Context, arg UpsertCrewAIFlowStateParams) error
 	UpsertCrewAIMemory(ctx context.Context, arg UpsertCrewAIMemoryParams) error
+	UpsertProviderDailyStats(ctx context.Context, arg UpsertProviderDailyStatsParams) error
 	UpsertPushNotification(ctx context.Context, arg UpsertPushNotificationParams) error
+	UpsertPushNotificationDelivery(ctx context.Context, arg UpsertPushNotificationDeliveryParams) error
 	UpsertSession(ctx context.Context, arg UpsertSessionParams) error
+	UpsertSessionGrant(ctx context.Context, arg UpsertSessionGrantParams) (SessionGrant, error)
+	UpsertSessionStateValue(ctx context.Context, arg UpsertSessionStateValueParams) (SessionState, error)
 	UpsertShareAccess(ctx context.Context, arg UpsertShareAccessParams) error
 	// UpsertTask returns the upserted id, or no rows when the write was rejected:
 	// the id belongs to another user, or it belongs to a soft-deleted task (a
@@ -94,6 +148,7 @@ type Querier interface {
 	// "no rows" to a conflict error.
 	UpsertTask(ctx context.Context, arg UpsertTaskParams) (string, error)
 	UpsertTool(ctx context.Context, arg UpsertToolParams) error
+	UpsertToolCallHourlyStats(ctx context.Context, arg UpsertToolCallHourlyStatsParams) error
 	UpsertToolServer(ctx context.Context, arg UpsertToolServerParams) (Toolserver, error)
 }
 
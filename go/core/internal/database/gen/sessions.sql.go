@@ -176,6 +176,100 @@ func (q *Queries) ListSessionsForAgentAllUsers(ctx context.Context, agentID *str
 	return items, nil
 }
 
+const listSessionsUpdatedSince = `-- name: ListSessionsUpdatedSince :many
+SELECT id, user_id, name, created_at, updated_at, deleted_at, agent_id, source FROM session
+WHERE deleted_at IS NULL AND updated_at >= $1::timestamptz
+ORDER BY updated_at ASC
+`
+
+func (q *Queries) ListSessionsUpdatedSince(ctx context.Context, updatedSince time.Time) ([]Session, error) {
+	rows, err := q.db.Query(ctx, listSessionsUpdatedSince, updatedSince)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Session
+	for rows.Next() {
+		var i Session
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Name,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.AgentID,
+			&i.Source,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSessionsWithOptions = `-- name: ListSessionsWithOptions :many
+SELECT id, user_id, name, created_at, updated_at, deleted_at, agent_id, source FROM session
+WHERE user_id = $1 AND deleted_at IS NULL
+  AND ($2::timestamptz IS NULL OR created_at > $2)
+ORDER BY
+    CASE WHEN $3::text = 'created_at' AND $4::boolean     THEN created_at END ASC,
+    CASE WHEN $3::text = 'created_at' AND NOT $4::boolean THEN created_at END DESC,
+    CASE WHEN $3::text = 'name'       AND $4::boolean     THEN name       END ASC,
+    CASE WHEN $3::text = 'name'       AND NOT $4::boolean THEN name       END DESC,
+    CASE WHEN $3::text = 'updated_at' AND $4::boolean     THEN updated_at END ASC,
+    updated_at DESC, created_at DESC, id ASC
+LIMIT $5 OFFSET $6
+`
+
+type ListSessionsWithOptionsParams struct {
+	UserID  string
+	Column2 time.Time
+	Column3 string
+	Column4 bool
+	Limit   int32
+	Offset  int32
+}
+
+func (q *Queries) ListSessionsWithOptions(ctx context.Context, arg ListSessionsWithOptionsParams) ([]Session, error) {
+	rows, err := q.db.Query(ctx, listSessionsWithOptions,
+		arg.UserID,
+		arg.Column2,
+		arg.Column3,
+		arg.Column4,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Session
+	for rows.Next() {
+		var i Session
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Name,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.AgentID,
+			&i.Source,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const softDeleteSession = `-- name: SoftDeleteSession :exec
 UPDATE session SET deleted_at = NOW()
 WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL
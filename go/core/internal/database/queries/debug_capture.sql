@@ -0,0 +1,12 @@
+-- name: InsertDebugCaptureEntry :exec
+INSERT INTO debug_capture_entry (capture_id, object_kind, namespace, name, stage, message, data, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, NOW());
+
+-- name: ListDebugCaptureEntries :many
+SELECT * FROM debug_capture_entry
+WHERE object_kind = $1 AND namespace = $2 AND name = $3 AND capture_id = $4
+ORDER BY id ASC;
+
+-- name: DeleteDebugCaptureEntries :exec
+DELETE FROM debug_capture_entry
+WHERE object_kind = $1 AND namespace = $2 AND name = $3;
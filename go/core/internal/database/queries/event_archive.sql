@@ -0,0 +1,30 @@
+-- ListArchivableSessions returns the live sessions not updated since
+-- updated_before that still have events in the event table, least recently
+-- updated first.
+-- name: ListArchivableSessions :many
+SELECT * FROM session s
+WHERE s.deleted_at IS NULL
+  AND s.updated_at < sqlc.arg(updated_before)::timestamptz
+  AND EXISTS (
+    SELECT 1 FROM event e
+    WHERE e.session_id = s.id AND e.user_id = s.user_id AND e.deleted_at IS NULL
+  )
+ORDER BY s.updated_at ASC
+LIMIT sqlc.arg(max_sessions);
+
+-- name: InsertEventArchive :one
+INSERT INTO event_archive (session_id, user_id, object_key, event_count, first_event_at, last_event_at, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, NOW())
+RETURNING *;
+
+-- name: DeleteArchivedEvents :execrows
+DELETE FROM event
+WHERE user_id = sqlc.arg(user_id) AND id = ANY(sqlc.arg(ids)::text[]);
+
+-- name: ListEventArchives :many
+SELECT * FROM event_archive
+WHERE session_id = $1 AND user_id = $2
+ORDER BY first_event_at ASC, id ASC;
+
+-- name: ListEventArchiveKeys :many
+SELECT object_key FROM event_archive;
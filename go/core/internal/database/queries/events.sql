@@ -54,6 +54,13 @@ WHERE session_id = $1 AND deleted_at IS NULL
 ORDER BY created_at DESC
 LIMIT $2;
 
+-- name: CopySessionEvents :execrows
+INSERT INTO event (id, user_id, session_id, data, created_at, updated_at)
+SELECT gen_random_uuid()::text, e.user_id, sqlc.arg(target_session_id)::text, e.data, e.created_at, NOW()
+FROM event e
+WHERE e.session_id = sqlc.arg(source_session_id) AND e.user_id = sqlc.arg(user_id) AND e.deleted_at IS NULL
+  AND (sqlc.narg(until)::timestamptz IS NULL OR e.created_at <= sqlc.narg(until));
+
 -- name: SoftDeleteEvent :exec
 UPDATE event SET deleted_at = NOW()
 WHERE id = $1 AND deleted_at IS NULL;
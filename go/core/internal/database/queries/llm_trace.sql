@@ -0,0 +1,9 @@
+-- name: InsertLLMTrace :one
+INSERT INTO llm_trace (session_id, user_id, agent_id, model, request, response, error, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+RETURNING *;
+
+-- name: ListLLMTraces :many
+SELECT * FROM llm_trace
+WHERE session_id = $1 AND user_id = $2
+ORDER BY id ASC;
@@ -0,0 +1,4 @@
+-- AcquireTransactionLock waits for the advisory lock named key, which is
+-- released when the transaction ends.
+-- name: AcquireTransactionLock :exec
+SELECT pg_advisory_xact_lock(hashtextextended(sqlc.arg(key)::text, 0));
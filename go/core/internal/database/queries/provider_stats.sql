@@ -0,0 +1,14 @@
+-- name: UpsertProviderDailyStats :exec
+INSERT INTO provider_daily_stats (day, provider, model, request_count, error_count, total_latency_ms, max_latency_ms, updated_at)
+VALUES ($1, $2, $3, 1, $4, $5, $5, NOW())
+ON CONFLICT (day, provider, model) DO UPDATE SET
+    request_count    = provider_daily_stats.request_count + 1,
+    error_count      = provider_daily_stats.error_count + EXCLUDED.error_count,
+    total_latency_ms = provider_daily_stats.total_latency_ms + EXCLUDED.total_latency_ms,
+    max_latency_ms   = GREATEST(provider_daily_stats.max_latency_ms, EXCLUDED.max_latency_ms),
+    updated_at       = NOW();
+
+-- name: ListProviderDailyStats :many
+SELECT * FROM provider_daily_stats
+WHERE day >= $1
+ORDER BY day ASC, provider ASC, model ASC;
@@ -19,3 +19,24 @@ ON CONFLICT (id) DO UPDATE SET
 -- name: SoftDeletePushNotification :exec
 UPDATE push_notification SET deleted_at = NOW()
 WHERE task_id = $1 AND deleted_at IS NULL;
+
+-- name: SoftDeletePushNotificationConfig :exec
+UPDATE push_notification SET deleted_at = NOW()
+WHERE task_id = $1 AND id = $2 AND deleted_at IS NULL;
+
+-- name: UpsertPushNotificationDelivery :exec
+INSERT INTO push_notification_delivery (task_id, config_id, task_state, status, attempts, last_status_code, last_error, delivered_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW())
+ON CONFLICT (task_id, config_id) DO UPDATE SET
+    task_state       = EXCLUDED.task_state,
+    status           = EXCLUDED.status,
+    attempts         = EXCLUDED.attempts,
+    last_status_code = EXCLUDED.last_status_code,
+    last_error       = EXCLUDED.last_error,
+    delivered_at     = COALESCE(EXCLUDED.delivered_at, push_notification_delivery.delivered_at),
+    updated_at       = NOW();
+
+-- name: ListPushNotificationDeliveries :many
+SELECT * FROM push_notification_delivery
+WHERE task_id = $1
+ORDER BY config_id ASC;
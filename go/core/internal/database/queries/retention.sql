@@ -0,0 +1,124 @@
+-- PruneSessions deletes, for good, sessions not updated since updated_before
+-- and all but the newest max_sessions sessions of each user and agent, with
+-- their events (archived ones included), tasks, push notifications, shares,
+-- grants, state and LLM traces. Soft-deleted sessions rank after live ones,
+-- so the count limit removes them first. A NULL argument disables that rule.
+-- name: PruneSessions :one
+WITH ranked_session AS (
+    SELECT id, user_id, updated_at,
+           ROW_NUMBER() OVER (
+               PARTITION BY user_id, agent_id
+               ORDER BY (deleted_at IS NULL) DESC, updated_at DESC, created_at DESC
+           ) AS session_rank
+    FROM session
+),
+pruned_session AS (
+    DELETE FROM session s
+    USING ranked_session r
+    WHERE s.id = r.id AND s.user_id = r.user_id
+      AND ((sqlc.narg(updated_before)::timestamptz IS NOT NULL AND r.updated_at < sqlc.narg(updated_before))
+        OR (sqlc.narg(max_sessions)::bigint IS NOT NULL AND r.session_rank > sqlc.narg(max_sessions)))
+    RETURNING s.id, s.user_id
+),
+pruned_event AS (
+    DELETE FROM event e
+    USING pruned_session s
+    WHERE e.session_id = s.id AND e.user_id = s.user_id
+    RETURNING e.id
+),
+pruned_task AS (
+    DELETE FROM task t
+    USING pruned_session s
+    WHERE t.session_id = s.id AND t.user_id = s.user_id
+    RETURNING t.id
+),
+pruned_push_notification AS (
+    DELETE FROM push_notification p
+    USING pruned_task t
+    WHERE p.task_id = t.id
+    RETURNING p.id
+),
+pruned_push_notification_delivery AS (
+    DELETE FROM push_notification_delivery d
+    USING pruned_task t
+    WHERE d.task_id = t.id
+),
+pruned_session_share AS (
+    DELETE FROM session_share sh
+    USING pruned_session s
+    WHERE sh.session_id = s.id AND sh.user_id = s.user_id
+),
+pruned_session_grant AS (
+    DELETE FROM session_grant g
+    USING pruned_session s
+    WHERE g.session_id = s.id AND g.owner_id = s.user_id
+),
+pruned_session_state AS (
+    DELETE FROM session_state st
+    USING pruned_session s
+    WHERE st.session_id = s.id AND st.user_id = s.user_id
+),
+pruned_llm_trace AS (
+    DELETE FROM llm_trace lt
+    USING pruned_session s
+    WHERE lt.session_id = s.id AND lt.user_id = s.user_id
+),
+pruned_event_archive AS (
+    DELETE FROM event_archive ea
+    USING pruned_session s
+    WHERE ea.session_id = s.id AND ea.user_id = s.user_id
+    RETURNING ea.event_count
+)
+SELECT
+    (SELECT COUNT(*) FROM pruned_session)           AS sessions,
+    ((SELECT COUNT(*) FROM pruned_event)
+      + (SELECT COALESCE(SUM(event_count), 0) FROM pruned_event_archive))::bigint AS events,
+    (SELECT COUNT(*) FROM pruned_task)              AS tasks,
+    (SELECT COUNT(*) FROM pruned_push_notification) AS push_notifications;
+
+-- name: PruneTasks :one
+WITH pruned_task AS (
+    DELETE FROM task
+    WHERE updated_at < sqlc.arg(updated_before)::timestamptz
+    RETURNING id
+),
+pruned_push_notification AS (
+    DELETE FROM push_notification p
+    USING pruned_task t
+    WHERE p.task_id = t.id
+    RETURNING p.id
+),
+pruned_push_notification_delivery AS (
+    DELETE FROM push_notification_delivery d
+    USING pruned_task t
+    WHERE d.task_id = t.id
+)
+SELECT
+    (SELECT COUNT(*) FROM pruned_task)              AS tasks,
+    (SELECT COUNT(*) FROM pruned_push_notification) AS push_notifications;
+
+-- name: PruneEvents :execrows
+DELETE FROM event
+WHERE created_at < sqlc.arg(created_before)::timestamptz;
+
+-- PruneEventArchives deletes the archives whose newest event was created
+-- before created_before, and returns the number of events they held.
+-- name: PruneEventArchives :one
+WITH pruned_event_archive AS (
+    DELETE FROM event_archive
+    WHERE last_event_at < sqlc.arg(created_before)::timestamptz
+    RETURNING event_count
+)
+SELECT COALESCE(SUM(event_count), 0)::bigint AS events FROM pruned_event_archive;
+
+-- name: PrunePushNotifications :execrows
+WITH pruned_push_notification_delivery AS (
+    DELETE FROM push_notification_delivery
+    WHERE updated_at < sqlc.arg(updated_before)::timestamptz
+)
+DELETE FROM push_notification
+WHERE updated_at < sqlc.arg(updated_before)::timestamptz;
+
+-- name: PruneTaskStreamEvents :execrows
+DELETE FROM task_stream_event
+WHERE created_at < sqlc.arg(created_before)::timestamptz;
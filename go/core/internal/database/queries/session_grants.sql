@@ -0,0 +1,24 @@
+-- name: UpsertSessionGrant :one
+INSERT INTO session_grant (session_id, owner_id, principal_kind, principal, access)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (session_id, owner_id, principal_kind, principal) DO UPDATE SET access = EXCLUDED.access
+RETURNING session_id, owner_id, principal_kind, principal, access, created_at;
+
+-- name: ListSessionGrants :many
+SELECT session_id, owner_id, principal_kind, principal, access, created_at FROM session_grant
+WHERE session_id = $1 AND owner_id = $2
+ORDER BY principal_kind, principal;
+
+-- name: DeleteSessionGrant :exec
+DELETE FROM session_grant
+WHERE session_id = $1 AND owner_id = $2 AND principal_kind = $3 AND principal = $4;
+
+-- name: GetSessionGrantForPrincipal :one
+SELECT g.session_id, g.owner_id, g.principal_kind, g.principal, g.access, g.created_at
+FROM session_grant g
+JOIN session s ON s.id = g.session_id AND s.user_id = g.owner_id AND s.deleted_at IS NULL
+WHERE g.session_id = sqlc.arg(session_id)
+  AND ((g.principal_kind = 'user' AND g.principal = sqlc.arg(user_id))
+    OR (g.principal_kind = 'group' AND g.principal = ANY(sqlc.arg(groups)::text[])))
+ORDER BY (g.access = 'invoke') DESC, g.created_at
+LIMIT 1;
@@ -0,0 +1,18 @@
+-- name: ListSessionState :many
+SELECT session_id, user_id, key, value, updated_at FROM session_state
+WHERE session_id = $1 AND user_id = $2
+ORDER BY key;
+
+-- name: GetSessionStateValue :one
+SELECT session_id, user_id, key, value, updated_at FROM session_state
+WHERE session_id = $1 AND user_id = $2 AND key = $3;
+
+-- name: UpsertSessionStateValue :one
+INSERT INTO session_state (session_id, user_id, key, value, updated_at)
+VALUES ($1, $2, $3, $4, NOW())
+ON CONFLICT (session_id, user_id, key) DO UPDATE SET value = EXCLUDED.value, updated_at = NOW()
+RETURNING session_id, user_id, key, value, updated_at;
+
+-- name: DeleteSessionStateValue :execrows
+DELETE FROM session_state
+WHERE session_id = $1 AND user_id = $2 AND key = $3;
@@ -32,6 +32,24 @@ WHERE agent_id = $1 AND deleted_at IS NULL
   AND (source IS NULL OR source != 'agent')
 ORDER BY updated_at DESC, created_at DESC;
 
+-- name: ListSessionsUpdatedSince :many
+SELECT * FROM session
+WHERE deleted_at IS NULL AND updated_at >= sqlc.arg(updated_since)::timestamptz
+ORDER BY updated_at ASC;
+
+-- name: ListSessionsWithOptions :many
+SELECT * FROM session
+WHERE user_id = $1 AND deleted_at IS NULL
+  AND ($2::timestamptz IS NULL OR created_at > $2)
+ORDER BY
+    CASE WHEN $3::text = 'created_at' AND $4::boolean     THEN created_at END ASC,
+    CASE WHEN $3::text = 'created_at' AND NOT $4::boolean THEN created_at END DESC,
+    CASE WHEN $3::text = 'name'       AND $4::boolean     THEN name       END ASC,
+    CASE WHEN $3::text = 'name'       AND NOT $4::boolean THEN name       END DESC,
+    CASE WHEN $3::text = 'updated_at' AND $4::boolean     THEN updated_at END ASC,
+    updated_at DESC, created_at DESC, id ASC
+LIMIT $5 OFFSET $6;
+
 -- name: UpsertSession :exec
 INSERT INTO session (id, user_id, name, agent_id, source, created_at, updated_at)
 VALUES ($1, $2, $3, $4, $5, NOW(), NOW())
@@ -0,0 +1,16 @@
+-- Agent activity for the stats endpoint (kagent top), across every user.
+
+-- name: CountActiveSessionsByAgent :many
+SELECT agent_id, COUNT(*) AS sessions FROM session
+WHERE deleted_at IS NULL AND agent_id IS NOT NULL
+  AND updated_at >= sqlc.arg(updated_since)::timestamptz
+GROUP BY agent_id
+ORDER BY agent_id ASC;
+
+-- A task is attributed to the agent of its owner's session.
+-- name: ListAgentTasksUpdatedSince :many
+SELECT s.agent_id, t.data, t.protocol_version, t.created_at, t.updated_at FROM task t
+JOIN session s ON s.id = t.session_id AND s.user_id = t.user_id
+WHERE t.deleted_at IS NULL AND s.deleted_at IS NULL AND s.agent_id IS NOT NULL
+  AND t.updated_at >= sqlc.arg(updated_since)::timestamptz
+ORDER BY t.updated_at ASC;
@@ -0,0 +1,13 @@
+-- AppendTaskStreamEvent stores the next event of a task's streams and
+-- returns its sequence number.
+-- name: AppendTaskStreamEvent :one
+INSERT INTO task_stream_event (task_id, seq, user_id, data)
+SELECT sqlc.arg(task_id), COALESCE(MAX(seq), 0) + 1, sqlc.arg(user_id), sqlc.arg(data)
+FROM task_stream_event
+WHERE task_id = sqlc.arg(task_id)
+RETURNING seq;
+
+-- name: ListTaskStreamEvents :many
+SELECT * FROM task_stream_event
+WHERE task_id = $1 AND user_id = $2 AND seq > $3
+ORDER BY seq ASC;
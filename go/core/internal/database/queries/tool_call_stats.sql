@@ -0,0 +1,17 @@
+-- name: UpsertToolCallHourlyStats :exec
+INSERT INTO tool_call_hourly_stats (hour, tool_server, agent_id, call_count, error_count, latency_buckets, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, NOW())
+ON CONFLICT (hour, tool_server, agent_id) DO UPDATE SET
+    call_count      = tool_call_hourly_stats.call_count + EXCLUDED.call_count,
+    error_count     = tool_call_hourly_stats.error_count + EXCLUDED.error_count,
+    latency_buckets = ARRAY(
+        SELECT COALESCE(t.a, 0) + COALESCE(t.b, 0)
+        FROM unnest(tool_call_hourly_stats.latency_buckets, EXCLUDED.latency_buckets) WITH ORDINALITY AS t(a, b, i)
+        ORDER BY t.i
+    ),
+    updated_at      = NOW();
+
+-- name: ListToolCallHourlyStats :many
+SELECT * FROM tool_call_hourly_stats
+WHERE tool_server = $1 AND hour >= $2
+ORDER BY hour ASC, agent_id ASC;
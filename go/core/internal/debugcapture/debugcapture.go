@@ -0,0 +1,184 @@
+// Package debugcapture records verbose reconcile traces for a single object
+// while it carries the kagent.dev/debug-capture-until annotation, so one
+// misbehaving agent can be debugged without raising the controller's log level
+// cluster-wide.
+//
+// The reconciler calls Start at the top of a reconcile; every later Record on
+// the returned context is stored against the capture, and is a no-op when no
+// capture is active. Payloads are JSON-encoded after secret material has been
+// redacted.
+package debugcapture
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/kagent-dev/kagent/go/api/database"
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// MaxDuration bounds how long a single capture may run.
+const MaxDuration = 30 * time.Minute
+
+const redacted = "<redacted>"
+
+var log = ctrl.Log.WithName("debug-capture")
+
+// Sink persists captured entries. database.Client satisfies it.
+type Sink interface {
+	StoreDebugCaptureEntry(ctx context.Context, entry *database.DebugCaptureEntry) error
+}
+
+type capture struct {
+	sink      Sink
+	id        string
+	kind      string
+	namespace string
+	name      string
+}
+
+type contextKey struct{}
+
+// Until returns the end of the capture window requested on obj, and false when
+// the annotation is missing or malformed.
+func Until(obj metav1.Object) (time.Time, bool) {
+	value, ok := obj.GetAnnotations()[v1alpha2.AgentDebugCaptureUntilAnnotation]
+	if !ok {
+		return time.Time{}, false
+	}
+	until, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return until, true
+}
+
+// Start returns a context that records entries for obj when its capture window
+// is still open at now. Otherwise ctx is returned unchanged.
+func Start(ctx context.Context, sink Sink, kind string, obj metav1.Object, now time.Time) context.Context {
+	if sink == nil {
+		return ctx
+	}
+	until, ok := Until(obj)
+	if !ok || !now.Before(until) {
+		return ctx
+	}
+	return context.WithValue(ctx, contextKey{}, &capture{
+		sink:      sink,
+		id:        obj.GetAnnotations()[v1alpha2.AgentDebugCaptureUntilAnnotation],
+		kind:      kind,
+		namespace: obj.GetNamespace(),
+		name:      obj.GetName(),
+	})
+}
+
+// Active reports whether ctx carries a capture. Callers use it to skip
+// building expensive payloads.
+func Active(ctx context.Context) bool {
+	_, ok := ctx.Value(contextKey{}).(*capture)
+	return ok
+}
+
+// Record stores one entry for the capture on ctx. data, when non-nil, is
+// redacted and JSON-encoded. Failures are logged and never affect the
+// reconcile being traced.
+func Record(ctx context.Context, stage, message string, data any) {
+	c, ok := ctx.Value(contextKey{}).(*capture)
+	if !ok {
+		return
+	}
+	entry := &database.DebugCaptureEntry{
+		CaptureID:  c.id,
+		ObjectKind: c.kind,
+		Namespace:  c.namespace,
+		Name:       c.name,
+		Stage:      stage,
+		Message:    message,
+	}
+	if data != nil {
+		encoded, err := encode(data)
+		if err != nil {
+			entry.Data = `{"error":"failed to encode payload"}`
+			log.Error(err, "failed to encode debug capture payload", "stage", stage)
+		} else {
+			entry.Data = encoded
+		}
+	}
+	if err := c.sink.StoreDebugCaptureEntry(context.WithoutCancel(ctx), entry); err != nil {
+		log.Error(err, "failed to store debug capture entry", "kind", c.kind, "namespace", c.namespace, "name", c.name)
+	}
+}
+
+func encode(data any) (string, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+	var generic any
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		return "", err
+	}
+	out, err := json.Marshal(Redact(generic))
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// Redact walks a decoded JSON value and masks secret material: every value of
+// a "headers" map (tool and model headers routinely carry credentials), the
+// data and stringData of Secret objects, and any string under a key that
+// names a credential.
+func Redact(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		isSecret := t["kind"] == "Secret"
+		for key, value := range t {
+			switch {
+			case isSecret && (key == "data" || key == "stringData"):
+				t[key] = redactValues(value)
+			case strings.EqualFold(key, "headers"):
+				t[key] = redactValues(value)
+			case isCredentialKey(key):
+				if _, ok := value.(string); ok {
+					t[key] = redacted
+				} else {
+					t[key] = Redact(value)
+				}
+			default:
+				t[key] = Redact(value)
+			}
+		}
+		return t
+	case []any:
+		for i := range t {
+			t[i] = Redact(t[i])
+		}
+		return t
+	default:
+		return v
+	}
+}
+
+func redactValues(v any) any {
+	m, ok := v.(map[string]any)
+	if !ok {
+		if v == nil {
+			return nil
+		}
+		return redacted
+	}
+	for key := range m {
+		m[key] = redacted
+	}
+	return m
+}
+
+func isCredentialKey(key string) bool {
+	k := strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(key))
+	return k == "apikey" || k == "password" || k == "token" || k == "secret" || strings.HasSuffix(k, "apikey")
+}
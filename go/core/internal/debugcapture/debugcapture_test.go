@@ -0,0 +1,100 @@
+package debugcapture
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/kagent-dev/kagent/go/api/database"
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type fakeSink struct {
+	entries []*database.DebugCaptureEntry
+}
+
+func (f *fakeSink) StoreDebugCaptureEntry(_ context.Context, entry *database.DebugCaptureEntry) error {
+	f.entries = append(f.entries, entry)
+	return nil
+}
+
+func TestStart(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		annotation string
+		wantActive bool
+	}{
+		{name: "no annotation"},
+		{name: "malformed annotation", annotation: "5m"},
+		{name: "expired window", annotation: now.Add(-time.Second).Format(time.RFC3339)},
+		{name: "open window", annotation: now.Add(time.Minute).Format(time.RFC3339), wantActive: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agent := &v1alpha2.Agent{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "kagent"}}
+			if tt.annotation != "" {
+				agent.Annotations = map[string]string{v1alpha2.AgentDebugCaptureUntilAnnotation: tt.annotation}
+			}
+			sink := &fakeSink{}
+			ctx := Start(context.Background(), sink, "Agent", agent, now)
+			require.Equal(t, tt.wantActive, Active(ctx))
+
+			Record(ctx, "reconcile", "start", nil)
+			if !tt.wantActive {
+				require.Empty(t, sink.entries)
+				return
+			}
+			require.Len(t, sink.entries, 1)
+			require.Equal(t, tt.annotation, sink.entries[0].CaptureID)
+			require.Equal(t, "Agent", sink.entries[0].ObjectKind)
+			require.Equal(t, "kagent", sink.entries[0].Namespace)
+			require.Equal(t, "a", sink.entries[0].Name)
+		})
+	}
+}
+
+func TestRecordRedactsSecrets(t *testing.T) {
+	until := time.Now().Add(time.Minute).Format(time.RFC3339)
+	agent := &v1alpha2.Agent{ObjectMeta: metav1.ObjectMeta{
+		Name:        "a",
+		Namespace:   "kagent",
+		Annotations: map[string]string{v1alpha2.AgentDebugCaptureUntilAnnotation: until},
+	}}
+	sink := &fakeSink{}
+	ctx := Start(context.Background(), sink, "Agent", agent, time.Now())
+
+	Record(ctx, "translate", "output", map[string]any{
+		"model": map[string]any{
+			"model":   "gpt-4o",
+			"api_key": "sk-123",
+			"headers": map[string]string{"Authorization": "Bearer abc"},
+		},
+		"manifest": []any{
+			&corev1.Secret{
+				TypeMeta:   metav1.TypeMeta{Kind: "Secret", APIVersion: "v1"},
+				ObjectMeta: metav1.ObjectMeta{Name: "a"},
+				StringData: map[string]string{"config.json": `{"api_key":"sk-123"}`},
+			},
+		},
+	})
+
+	require.Len(t, sink.entries, 1)
+	require.NotContains(t, sink.entries[0].Data, "sk-123")
+	require.NotContains(t, sink.entries[0].Data, "Bearer abc")
+
+	var got map[string]any
+	require.NoError(t, json.Unmarshal([]byte(sink.entries[0].Data), &got))
+	model := got["model"].(map[string]any)
+	require.Equal(t, "gpt-4o", model["model"])
+	require.Equal(t, redacted, model["api_key"])
+	require.Equal(t, map[string]any{"Authorization": redacted}, model["headers"])
+	secret := got["manifest"].([]any)[0].(map[string]any)
+	require.Equal(t, map[string]any{"config.json": redacted}, secret["stringData"])
+}
@@ -0,0 +1,243 @@
+// Package eventarchive moves the events of inactive sessions out of the
+// database into the artifact store, and reads them back when the session is
+// opened.
+//
+// The Archiver writes the events of each session not updated for MaxAge as
+// one gzip-compressed JSON Lines object, then replaces them in the event
+// table with a single event_archive row pointing at that object.
+// LoadEvents returns the archived events of a session, so the sessions API
+// serves them as if they had never left the database.
+package eventarchive
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kagent-dev/kagent/go/api/database"
+	"github.com/kagent-dev/kagent/go/core/internal/artifacts"
+)
+
+const (
+	// ContentType is the content type event archives are stored with.
+	ContentType = "application/gzip"
+
+	// DefaultBatchSize is the number of sessions archived per run.
+	DefaultBatchSize = 100
+
+	keyPrefix = "events/"
+)
+
+// Archiver periodically archives the events of sessions not updated for
+// longer than MaxAge, BatchSize sessions per run.
+type Archiver struct {
+	DB        database.Client
+	Store     artifacts.Store
+	MaxAge    time.Duration
+	Interval  time.Duration
+	BatchSize int
+
+	now func() time.Time
+}
+
+// NeedLeaderElection ensures only one replica archives events.
+func (a *Archiver) NeedLeaderElection() bool { return true }
+
+// NewArchiver returns an Archiver that runs every interval; pass 0 to use the
+// default of 1 hour.
+func NewArchiver(db database.Client, store artifacts.Store, maxAge, interval time.Duration) *Archiver {
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	return &Archiver{DB: db, Store: store, MaxAge: maxAge, Interval: interval, BatchSize: DefaultBatchSize, now: time.Now}
+}
+
+// Start runs the archiving loop until ctx is cancelled.
+func (a *Archiver) Start(ctx context.Context) error {
+	log := ctrllog.FromContext(ctx).WithName("event-archive")
+	log.Info("Starting event archiving loop", "interval", a.Interval, "maxAge", a.MaxAge)
+	ticker := time.NewTicker(a.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			a.runOnce(ctx)
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (a *Archiver) runOnce(ctx context.Context) {
+	log := ctrllog.FromContext(ctx).WithName("event-archive")
+	cutoff := a.now().Add(-a.MaxAge)
+	sessions, err := a.DB.ListArchivableSessions(ctx, cutoff, a.BatchSize)
+	if err != nil {
+		log.Error(err, "Failed to list archivable sessions")
+		return
+	}
+	archived := 0
+	for i := range sessions {
+		session := &sessions[i]
+		n, err := a.ArchiveSession(ctx, session, cutoff)
+		if err != nil {
+			log.Error(err, "Failed to archive session events", "session", session.ID, "user", session.UserID)
+			continue
+		}
+		archived += n
+	}
+	if archived > 0 {
+		log.Info("Archived session events", "sessions", len(sessions), "events", archived)
+	}
+	if err := a.deleteOrphans(ctx); err != nil {
+		log.Error(err, "Failed to delete orphaned event archives")
+	}
+}
+
+// ArchiveSession moves the events of session created before cutoff to the
+// store and returns how many were archived.
+func (a *Archiver) ArchiveSession(ctx context.Context, session *database.Session, cutoff time.Time) (int, error) {
+	events, err := a.DB.ListEventsForSession(ctx, session.ID, session.UserID, database.QueryOptions{OrderAsc: true})
+	if err != nil {
+		return 0, err
+	}
+	var ids []string
+	var archived []*database.Event
+	for _, event := range events {
+		if !event.CreatedAt.Before(cutoff) {
+			break
+		}
+		ids = append(ids, event.ID)
+		archived = append(archived, event)
+	}
+	if len(archived) == 0 {
+		return 0, nil
+	}
+
+	body, err := Encode(archived)
+	if err != nil {
+		return 0, err
+	}
+	first, last := archived[0].CreatedAt, archived[len(archived)-1].CreatedAt
+	key := objectKey(session.UserID, session.ID, a.now())
+	if err := a.Store.Put(ctx, key, body, ContentType); err != nil {
+		return 0, fmt.Errorf("failed to store event archive: %w", err)
+	}
+	_, err = a.DB.ArchiveEvents(ctx, &database.EventArchive{
+		SessionID:    session.ID,
+		UserID:       session.UserID,
+		ObjectKey:    key,
+		EventCount:   len(archived),
+		FirstEventAt: first,
+		LastEventAt:  last,
+	}, ids)
+	if err != nil {
+		// Nothing points at the object yet; drop it rather than leave it for
+		// the orphan sweep.
+		_ = a.Store.Delete(ctx, key)
+		return 0, err
+	}
+	return len(archived), nil
+}
+
+// LoadEvents returns the archived events of a session, oldest first.
+func (a *Archiver) LoadEvents(ctx context.Context, sessionID, userID string) ([]*database.Event, error) {
+	archives, err := a.DB.ListEventArchives(ctx, sessionID, userID)
+	if err != nil {
+		return nil, err
+	}
+	var events []*database.Event
+	for _, archive := range archives {
+		body, _, err := a.Store.Get(ctx, archive.ObjectKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open event archive %s: %w", archive.ObjectKey, err)
+		}
+		decoded, err := Decode(body)
+		body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read event archive %s: %w", archive.ObjectKey, err)
+		}
+		events = append(events, decoded...)
+	}
+	return events, nil
+}
+
+// deleteOrphans removes stored archives no event_archive row points at, e.g.
+// those of sessions the retention policy has since deleted.
+func (a *Archiver) deleteOrphans(ctx context.Context) error {
+	objects, err := a.Store.List(ctx, keyPrefix)
+	if err != nil {
+		return err
+	}
+	if len(objects) == 0 {
+		return nil
+	}
+	keys, err := a.DB.ListEventArchiveKeys(ctx)
+	if err != nil {
+		return err
+	}
+	referenced := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		referenced[key] = true
+	}
+	var errs *multierror.Error
+	for _, obj := range objects {
+		if referenced[obj.Key] {
+			continue
+		}
+		if err := a.Store.Delete(ctx, obj.Key); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
+// Encode returns events as gzip-compressed JSON Lines.
+func Encode(events []*database.Event) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	enc := json.NewEncoder(zw)
+	for _, event := range events {
+		if err := enc.Encode(event); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Decode reads events written by Encode.
+func Decode(r io.Reader) ([]*database.Event, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	var events []*database.Event
+	dec := json.NewDecoder(bufio.NewReader(zr))
+	for {
+		var event database.Event
+		if err := dec.Decode(&event); err == io.EOF {
+			return events, nil
+		} else if err != nil {
+			return nil, err
+		}
+		events = append(events, &event)
+	}
+}
+
+// objectKey is the key a session's events archived at t are stored under.
+func objectKey(userID, sessionID string, t time.Time) string {
+	return fmt.Sprintf("%s%s/%s/%d.jsonl.gz", keyPrefix, url.PathEscape(userID), url.PathEscape(sessionID), t.UnixNano())
+}
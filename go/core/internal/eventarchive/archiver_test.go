@@ -0,0 +1,123 @@
+package eventarchive
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kagent-dev/kagent/go/api/database"
+	"github.com/kagent-dev/kagent/go/core/internal/artifacts"
+)
+
+// fakeDB implements the event and event archive methods of database.Client.
+type fakeDB struct {
+	database.Client
+	sessions []database.Session
+	events   []*database.Event
+	archives []*database.EventArchive
+}
+
+func (f *fakeDB) ListArchivableSessions(_ context.Context, _ time.Time, _ int) ([]database.Session, error) {
+	return f.sessions, nil
+}
+
+func (f *fakeDB) ListEventsForSession(_ context.Context, sessionID, userID string, _ database.QueryOptions) ([]*database.Event, error) {
+	var events []*database.Event
+	for _, e := range f.events {
+		if e.SessionID == sessionID && e.UserID == userID {
+			events = append(events, e)
+		}
+	}
+	return events, nil
+}
+
+func (f *fakeDB) ArchiveEvents(_ context.Context, archive *database.EventArchive, eventIDs []string) (*database.EventArchive, error) {
+	f.archives = append(f.archives, archive)
+	f.events = slices.DeleteFunc(f.events, func(e *database.Event) bool {
+		return e.UserID == archive.UserID && slices.Contains(eventIDs, e.ID)
+	})
+	return archive, nil
+}
+
+func (f *fakeDB) ListEventArchives(_ context.Context, sessionID, userID string) ([]*database.EventArchive, error) {
+	var archives []*database.EventArchive
+	for _, a := range f.archives {
+		if a.SessionID == sessionID && a.UserID == userID {
+			archives = append(archives, a)
+		}
+	}
+	return archives, nil
+}
+
+func (f *fakeDB) ListEventArchiveKeys(_ context.Context) ([]string, error) {
+	var keys []string
+	for _, a := range f.archives {
+		keys = append(keys, a.ObjectKey)
+	}
+	return keys, nil
+}
+
+func TestArchiverArchivesAndLoadsEvents(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	store, err := artifacts.NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+
+	event := func(id string, age time.Duration) *database.Event {
+		return &database.Event{ID: id, SessionID: "s1", UserID: "alice", CreatedAt: now.Add(-age), Data: `{"messageId":"` + id + `"}`}
+	}
+	db := &fakeDB{
+		sessions: []database.Session{{ID: "s1", UserID: "alice"}},
+		events:   []*database.Event{event("e1", 72*time.Hour), event("e2", 48*time.Hour), event("e3", time.Hour)},
+	}
+	archiver := NewArchiver(db, store, 24*time.Hour, 0)
+	archiver.now = func() time.Time { return now }
+
+	require.NoError(t, store.Put(ctx, "events/alice/gone/1.jsonl.gz", []byte("orphan"), ContentType))
+	require.NoError(t, store.Put(ctx, "tasks/t1/a1", []byte("artifact"), "text/plain"))
+
+	archiver.runOnce(ctx)
+
+	require.Len(t, db.archives, 1)
+	archive := db.archives[0]
+	assert.Equal(t, 2, archive.EventCount)
+	assert.Equal(t, now.Add(-72*time.Hour), archive.FirstEventAt)
+	assert.Equal(t, now.Add(-48*time.Hour), archive.LastEventAt)
+	require.Len(t, db.events, 1, "only the recent event stays in the database")
+	assert.Equal(t, "e3", db.events[0].ID)
+
+	loaded, err := archiver.LoadEvents(ctx, "s1", "alice")
+	require.NoError(t, err)
+	require.Len(t, loaded, 2)
+	assert.Equal(t, "e1", loaded[0].ID)
+	assert.Equal(t, "e2", loaded[1].ID)
+	assert.Equal(t, `{"messageId":"e1"}`, loaded[0].Data)
+	assert.True(t, loaded[0].CreatedAt.Equal(now.Add(-72*time.Hour)))
+
+	objects, err := store.List(ctx, "")
+	require.NoError(t, err)
+	var keys []string
+	for _, obj := range objects {
+		keys = append(keys, obj.Key)
+	}
+	assert.ElementsMatch(t, []string{archive.ObjectKey, "tasks/t1/a1"}, keys, "orphaned archives are deleted, other artifacts kept")
+}
+
+func TestEncodeDecodeRoundTrip(t *testing.T) {
+	created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	events := []*database.Event{
+		{ID: "a", SessionID: "s", UserID: "u", CreatedAt: created, UpdatedAt: created, Data: "{}"},
+		{ID: "b", SessionID: "s", UserID: "u", CreatedAt: created.Add(time.Second), Data: `{"parts":[{"text":"hi\nthere"}]}`},
+	}
+	body, err := Encode(events)
+	require.NoError(t, err)
+
+	decoded, err := Decode(bytes.NewReader(body))
+	require.NoError(t, err)
+	assert.Equal(t, events, decoded)
+}
@@ -0,0 +1,164 @@
+// Package githubapp verifies and parses GitHub App webhook deliveries and
+// comments on pull requests and issues as the App.
+package githubapp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// DefaultAPIURL is the GitHub REST API of github.com.
+const DefaultAPIURL = "https://api.github.com"
+
+const (
+	// appTokenLifetime is how long App JWTs are valid. GitHub accepts at
+	// most 10 minutes.
+	appTokenLifetime = 9 * time.Minute
+	// installationTokenMargin is how long before expiry a cached
+	// installation token is replaced.
+	installationTokenMargin = time.Minute
+)
+
+// Client calls the GitHub REST API as a GitHub App, authenticating each
+// request with a token of the installation it acts on.
+type Client struct {
+	appID      string
+	apiURL     string
+	key        any
+	httpClient *http.Client
+	now        func() time.Time
+
+	mu     sync.Mutex
+	tokens map[int64]installationToken
+}
+
+type installationToken struct {
+	token     string
+	expiresAt time.Time
+}
+
+// NewClient returns a Client for the App with the given ID and PEM-encoded
+// RSA private key. apiURL defaults to DefaultAPIURL.
+func NewClient(appID string, privateKeyPEM []byte, apiURL string) (*Client, error) {
+	if appID == "" {
+		return nil, fmt.Errorf("GitHub App ID is required")
+	}
+	key, err := jwt.ParseRSAPrivateKeyFromPEM(privateKeyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse GitHub App private key: %w", err)
+	}
+	if apiURL == "" {
+		apiURL = DefaultAPIURL
+	}
+	return &Client{
+		appID:      appID,
+		apiURL:     strings.TrimSuffix(apiURL, "/"),
+		key:        key,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		now:        time.Now,
+		tokens:     map[int64]installationToken{},
+	}, nil
+}
+
+// CreateComment comments on a pull request or issue of repo (owner/name)
+// and returns the comment's URL.
+func (c *Client) CreateComment(ctx context.Context, installationID int64, repo string, number int64, body string) (string, error) {
+	token, err := c.installationToken(ctx, installationID)
+	if err != nil {
+		return "", err
+	}
+	var comment struct {
+		HTMLURL string `json:"html_url"`
+	}
+	path := fmt.Sprintf("/repos/%s/issues/%d/comments", repo, number)
+	if err := c.do(ctx, "token "+token, path, map[string]string{"body": body}, &comment); err != nil {
+		return "", fmt.Errorf("failed to comment on %s#%d: %w", repo, number, err)
+	}
+	return comment.HTMLURL, nil
+}
+
+// installationToken returns a cached token of the installation, creating
+// one when none is cached or it is about to expire.
+func (c *Client) installationToken(ctx context.Context, installationID int64) (string, error) {
+	c.mu.Lock()
+	cached, ok := c.tokens[installationID]
+	c.mu.Unlock()
+	if ok && c.now().Add(installationTokenMargin).Before(cached.expiresAt) {
+		return cached.token, nil
+	}
+
+	appToken, err := c.appToken()
+	if err != nil {
+		return "", err
+	}
+	var res struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+	path := fmt.Sprintf("/app/installations/%d/access_tokens", installationID)
+	if err := c.do(ctx, "Bearer "+appToken, path, nil, &res); err != nil {
+		return "", fmt.Errorf("failed to create token for installation %d: %w", installationID, err)
+	}
+
+	c.mu.Lock()
+	c.tokens[installationID] = installationToken{token: res.Token, expiresAt: res.ExpiresAt}
+	c.mu.Unlock()
+	return res.Token, nil
+}
+
+// appToken signs a JWT authenticating as the App itself. It is backdated
+// a minute to allow for clock drift.
+func (c *Client) appToken() (string, error) {
+	now := c.now()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.RegisteredClaims{
+		Issuer:    c.appID,
+		IssuedAt:  jwt.NewNumericDate(now.Add(-time.Minute)),
+		ExpiresAt: jwt.NewNumericDate(now.Add(appTokenLifetime)),
+	})
+	signed, err := token.SignedString(c.key)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign GitHub App token: %w", err)
+	}
+	return signed, nil
+}
+
+// do POSTs body as JSON to path and decodes the response into out.
+func (c *Client) do(ctx context.Context, authorization, path string, body, out any) error {
+	var reqBody io.Reader = http.NoBody
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.apiURL+path, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", authorization)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("GitHub API returned %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,149 @@
+package githubapp
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Kinds of events ParseEvent understands, as sent in X-GitHub-Event.
+const (
+	KindPullRequest = "pull_request"
+	KindIssues      = "issues"
+)
+
+// ErrUnsupportedEvent is returned by ParseEvent for events other than pull
+// requests and issues.
+var ErrUnsupportedEvent = errors.New("unsupported GitHub event")
+
+// VerifySignature checks the X-Hub-Signature-256 header of a delivery
+// against the App's webhook secret.
+func VerifySignature(secret, body []byte, signature string) error {
+	hexSig, ok := strings.CutPrefix(signature, "sha256=")
+	if !ok {
+		return errors.New("missing sha256 signature")
+	}
+	sig, err := hex.DecodeString(hexSig)
+	if err != nil {
+		return fmt.Errorf("malformed signature: %w", err)
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	if !hmac.Equal(sig, mac.Sum(nil)) {
+		return errors.New("signature mismatch")
+	}
+	return nil
+}
+
+// Event is a pull request or issue event, trimmed to what an agent needs
+// to act on it. It is sent to agents as JSON.
+type Event struct {
+	Kind           string   `json:"kind"`
+	Action         string   `json:"action"`
+	Repository     string   `json:"repository"`
+	Number         int64    `json:"number"`
+	Title          string   `json:"title"`
+	Body           string   `json:"body,omitempty"`
+	Author         string   `json:"author"`
+	URL            string   `json:"url"`
+	Labels         []string `json:"labels,omitempty"`
+	Label          string   `json:"label,omitempty"`
+	InstallationID int64    `json:"-"`
+
+	// Pull request only.
+	BaseRef string `json:"baseRef,omitempty"`
+	HeadRef string `json:"headRef,omitempty"`
+	HeadSHA string `json:"headSHA,omitempty"`
+	Draft   bool   `json:"draft,omitempty"`
+	DiffURL string `json:"diffURL,omitempty"`
+}
+
+type webhookPayload struct {
+	Action string `json:"action"`
+	Label  *struct {
+		Name string `json:"name"`
+	} `json:"label"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+	Installation *struct {
+		ID int64 `json:"id"`
+	} `json:"installation"`
+	PullRequest *webhookIssue `json:"pull_request"`
+	Issue       *webhookIssue `json:"issue"`
+}
+
+// webhookIssue holds the fields shared by pull requests and issues, and
+// those of pull requests only.
+type webhookIssue struct {
+	Number  int64  `json:"number"`
+	Title   string `json:"title"`
+	Body    string `json:"body"`
+	HTMLURL string `json:"html_url"`
+	User    struct {
+		Login string `json:"login"`
+	} `json:"user"`
+	Labels []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+	Draft   bool   `json:"draft"`
+	DiffURL string `json:"diff_url"`
+	Base    struct {
+		Ref string `json:"ref"`
+	} `json:"base"`
+	Head struct {
+		Ref string `json:"ref"`
+		SHA string `json:"sha"`
+	} `json:"head"`
+}
+
+// ParseEvent parses the body of a pull_request or issues delivery.
+func ParseEvent(kind string, body []byte) (*Event, error) {
+	if kind != KindPullRequest && kind != KindIssues {
+		return nil, fmt.Errorf("%w %q", ErrUnsupportedEvent, kind)
+	}
+	var p webhookPayload
+	if err := json.Unmarshal(body, &p); err != nil {
+		return nil, fmt.Errorf("failed to parse %s event: %w", kind, err)
+	}
+	item := p.Issue
+	if kind == KindPullRequest {
+		item = p.PullRequest
+	}
+	if item == nil {
+		return nil, fmt.Errorf("%s event has no %s", kind, strings.TrimSuffix(kind, "s"))
+	}
+	if p.Installation == nil {
+		return nil, fmt.Errorf("%s event was not delivered by a GitHub App installation", kind)
+	}
+
+	ev := &Event{
+		Kind:           kind,
+		Action:         p.Action,
+		Repository:     p.Repository.FullName,
+		Number:         item.Number,
+		Title:          item.Title,
+		Body:           item.Body,
+		Author:         item.User.Login,
+		URL:            item.HTMLURL,
+		InstallationID: p.Installation.ID,
+	}
+	for _, l := range item.Labels {
+		ev.Labels = append(ev.Labels, l.Name)
+	}
+	if p.Label != nil {
+		ev.Label = p.Label.Name
+	}
+	if kind == KindPullRequest {
+		ev.BaseRef = item.Base.Ref
+		ev.HeadRef = item.Head.Ref
+		ev.HeadSHA = item.Head.SHA
+		ev.Draft = item.Draft
+		ev.DiffURL = item.DiffURL
+	}
+	return ev, nil
+}
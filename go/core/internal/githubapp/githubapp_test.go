@@ -0,0 +1,143 @@
+package githubapp
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sign(secret, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifySignature(t *testing.T) {
+	body := `{"action":"opened"}`
+	tests := []struct {
+		name      string
+		signature string
+		wantErr   bool
+	}{
+		{name: "valid", signature: sign("s3cret", body)},
+		{name: "wrong secret", signature: sign("other", body), wantErr: true},
+		{name: "sha1 only", signature: "sha1=0123", wantErr: true},
+		{name: "malformed", signature: "sha256=zz", wantErr: true},
+		{name: "missing", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifySignature([]byte("s3cret"), []byte(body), tt.signature)
+			assert.Equal(t, tt.wantErr, err != nil, "VerifySignature() error = %v", err)
+		})
+	}
+}
+
+func TestParseEvent(t *testing.T) {
+	pr := `{
+		"action": "labeled",
+		"label": {"name": "needs-review"},
+		"repository": {"full_name": "acme/platform"},
+		"installation": {"id": 42},
+		"pull_request": {
+			"number": 7, "title": "Bump redis", "body": "Bumps the chart.", "html_url": "https://github.com/acme/platform/pull/7",
+			"user": {"login": "octocat"}, "labels": [{"name": "needs-review"}, {"name": "helm"}],
+			"draft": true, "diff_url": "https://github.com/acme/platform/pull/7.diff",
+			"base": {"ref": "main"}, "head": {"ref": "bump-redis", "sha": "abc123"}
+		}
+	}`
+	ev, err := ParseEvent(KindPullRequest, []byte(pr))
+	require.NoError(t, err)
+	assert.Equal(t, &Event{
+		Kind:           KindPullRequest,
+		Action:         "labeled",
+		Repository:     "acme/platform",
+		Number:         7,
+		Title:          "Bump redis",
+		Body:           "Bumps the chart.",
+		Author:         "octocat",
+		URL:            "https://github.com/acme/platform/pull/7",
+		Labels:         []string{"needs-review", "helm"},
+		Label:          "needs-review",
+		InstallationID: 42,
+		BaseRef:        "main",
+		HeadRef:        "bump-redis",
+		HeadSHA:        "abc123",
+		Draft:          true,
+		DiffURL:        "https://github.com/acme/platform/pull/7.diff",
+	}, ev)
+
+	issue := `{"action":"opened","repository":{"full_name":"acme/platform"},"installation":{"id":42},
+		"issue":{"number":9,"title":"web is crashing","user":{"login":"octocat"},"html_url":"https://github.com/acme/platform/issues/9"}}`
+	ev, err = ParseEvent(KindIssues, []byte(issue))
+	require.NoError(t, err)
+	assert.Equal(t, int64(9), ev.Number)
+	assert.Empty(t, ev.HeadSHA)
+
+	_, err = ParseEvent("push", []byte(`{}`))
+	assert.ErrorIs(t, err, ErrUnsupportedEvent)
+	_, err = ParseEvent(KindIssues, []byte(`{"action":"opened","issue":{"number":9}}`))
+	assert.Error(t, err, "events without an installation are rejected")
+}
+
+func TestCreateComment(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	tokenRequests := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /app/installations/42/access_tokens", func(w http.ResponseWriter, r *http.Request) {
+		tokenRequests++
+		claims := &jwt.RegisteredClaims{}
+		_, err := jwt.ParseWithClaims(r.Header.Get("Authorization")[len("Bearer "):], claims, func(*jwt.Token) (any, error) {
+			return &key.PublicKey, nil
+		}, jwt.WithValidMethods([]string{"RS256"}))
+		if err != nil || claims.Issuer != "1234" {
+			http.Error(w, "bad app token", http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"token": "ghs_test", "expires_at": time.Now().Add(time.Hour)})
+	})
+	var comments []string
+	mux.HandleFunc("POST /repos/acme/platform/issues/7/comments", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "token ghs_test" {
+			http.Error(w, "bad installation token", http.StatusUnauthorized)
+			return
+		}
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		comments = append(comments, body["body"])
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"html_url":"https://github.com/acme/platform/pull/7#issuecomment-1"}`))
+	})
+	api := httptest.NewServer(mux)
+	defer api.Close()
+
+	c, err := NewClient("1234", keyPEM, api.URL)
+	require.NoError(t, err)
+	for range 2 {
+		url, err := c.CreateComment(context.Background(), 42, "acme/platform", 7, "LGTM")
+		require.NoError(t, err)
+		assert.Equal(t, "https://github.com/acme/platform/pull/7#issuecomment-1", url)
+	}
+	assert.Equal(t, []string{"LGTM", "LGTM"}, comments)
+	assert.Equal(t, 1, tokenRequests, "installation tokens are cached")
+
+	_, err = c.CreateComment(context.Background(), 42, "acme/other", 1, "LGTM")
+	assert.ErrorContains(t, err, "404")
+}
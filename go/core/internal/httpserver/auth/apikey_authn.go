@@ -0,0 +1,92 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/kagent-dev/kagent/go/core/pkg/auth"
+)
+
+// APIKey is a static API key and the user it authenticates.
+type APIKey struct {
+	User   string   `json:"user"`
+	Key    string   `json:"key"`
+	Groups []string `json:"groups,omitempty"`
+}
+
+// APIKeyAuthenticator authenticates static API keys, sent as a bearer token
+// or in the X-API-Key header. Agent requests, marked by X-Agent-Name, carry
+// a service account token instead and are delegated to agents when it is
+// set.
+type APIKeyAuthenticator struct {
+	// keys maps the SHA-256 digest of each key to its entry, so keys are not
+	// compared byte by byte.
+	keys   map[[sha256.Size]byte]APIKey
+	agents auth.AuthProvider
+}
+
+func NewAPIKeyAuthenticator(keys []APIKey, agents auth.AuthProvider) (*APIKeyAuthenticator, error) {
+	a := &APIKeyAuthenticator{keys: make(map[[sha256.Size]byte]APIKey, len(keys)), agents: agents}
+	for i, k := range keys {
+		if k.User == "" || k.Key == "" {
+			return nil, fmt.Errorf("API key %d: user and key are required", i)
+		}
+		digest := sha256.Sum256([]byte(k.Key))
+		if _, ok := a.keys[digest]; ok {
+			return nil, fmt.Errorf("API key of user %s is not unique", k.User)
+		}
+		a.keys[digest] = k
+	}
+	return a, nil
+}
+
+// LoadAPIKeys reads API keys from a YAML or JSON file holding a list of
+// {user, key, groups} entries, typically mounted from a Secret.
+func LoadAPIKeys(path string) ([]APIKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read API keys: %w", err)
+	}
+	var keys []APIKey
+	if err := yaml.UnmarshalStrict(data, &keys); err != nil {
+		return nil, fmt.Errorf("invalid API keys file %s: %w", path, err)
+	}
+	return keys, nil
+}
+
+func (a *APIKeyAuthenticator) Authenticate(ctx context.Context, reqHeaders http.Header, query url.Values) (auth.Session, error) {
+	if reqHeaders.Get("X-Agent-Name") != "" && a.agents != nil {
+		return a.agents.Authenticate(ctx, reqHeaders, query)
+	}
+
+	key := reqHeaders.Get("X-API-Key")
+	if key == "" {
+		key, _ = strings.CutPrefix(reqHeaders.Get("Authorization"), "Bearer ")
+	}
+	if key == "" {
+		return nil, ErrUnauthenticated
+	}
+	entry, ok := a.keys[sha256.Sum256([]byte(key))]
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+	// The key itself is not kept in the session so that it is never
+	// forwarded to agents.
+	return &SimpleSession{
+		P: auth.Principal{
+			User:   auth.User{ID: entry.User},
+			Claims: map[string]any{"sub": entry.User, "groups": entry.Groups},
+		},
+	}, nil
+}
+
+func (a *APIKeyAuthenticator) UpstreamAuth(r *http.Request, session auth.Session, upstreamPrincipal auth.Principal) error {
+	return forwardIdentity(r, session)
+}
@@ -0,0 +1,66 @@
+package auth_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+
+	authimpl "github.com/kagent-dev/kagent/go/core/internal/httpserver/auth"
+)
+
+func TestAPIKeyAuthenticator(t *testing.T) {
+	var reviews int
+	agents := authimpl.NewTokenReviewAuthenticator(newTokenReviewClient(map[string]authenticationv1.UserInfo{
+		"agent-token": {Username: "system:serviceaccount:kagent:k8s-agent"},
+	}, &reviews), nil)
+	a, err := authimpl.NewAPIKeyAuthenticator([]authimpl.APIKey{
+		{User: "ci-bot", Key: "kag_ci", Groups: []string{"ci"}},
+		{User: "alice", Key: "kag_alice"},
+	}, agents)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, headers := range []http.Header{
+		{"Authorization": {"Bearer kag_ci"}},
+		{"X-Api-Key": {"kag_ci"}},
+	} {
+		session, err := a.Authenticate(context.Background(), headers, url.Values{"user_id": {"alice"}})
+		if err != nil {
+			t.Fatalf("Authenticate(%v) error = %v", headers, err)
+		}
+		if p := session.Principal(); p.User.ID != "ci-bot" || len(p.Groups()) != 1 {
+			t.Errorf("Principal() = %+v, want ci-bot in group ci", p)
+		}
+
+		r, _ := http.NewRequest(http.MethodPost, "http://agent", nil)
+		if err := a.UpstreamAuth(r, session, session.Principal()); err != nil {
+			t.Fatal(err)
+		}
+		if r.Header.Get("Authorization") != "" || r.Header.Get("X-User-Id") != "ci-bot" {
+			t.Errorf("UpstreamAuth forwarded %v, want only X-User-Id", r.Header)
+		}
+	}
+
+	session, err := a.Authenticate(context.Background(), http.Header{
+		"Authorization": {"Bearer agent-token"},
+		"X-Agent-Name":  {"kagent/k8s-agent"},
+		"X-User-Id":     {"alice"},
+	}, url.Values{})
+	if err != nil {
+		t.Fatalf("Authenticate() agent error = %v", err)
+	}
+	if p := session.Principal(); p.User.ID != "alice" || p.Agent.ID != "kagent/k8s-agent" {
+		t.Errorf("Principal() = %+v, want alice through kagent/k8s-agent", p)
+	}
+
+	if _, err := a.Authenticate(context.Background(), http.Header{"Authorization": {"Bearer kag_other"}}, url.Values{}); err == nil {
+		t.Error("expected error for unknown key")
+	}
+	if _, err := authimpl.NewAPIKeyAuthenticator([]authimpl.APIKey{{User: "a", Key: "k"}, {User: "b", Key: "k"}}, nil); err == nil {
+		t.Error("expected error for duplicate key")
+	}
+}
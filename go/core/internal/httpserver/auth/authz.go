@@ -2,7 +2,9 @@ package auth
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/kagent-dev/kagent/go/api/database"
 	"github.com/kagent-dev/kagent/go/core/pkg/auth"
 )
 
@@ -13,3 +15,46 @@ func (a *NoopAuthorizer) Check(ctx context.Context, principal auth.Principal, ve
 }
 
 var _ auth.Authorizer = (*NoopAuthorizer)(nil)
+
+// SessionGrantStore is the part of the database client SessionGrantAuthorizer
+// reads.
+type SessionGrantStore interface {
+	GetSession(ctx context.Context, sessionID string, userID string) (*database.Session, error)
+	GetSessionGrantForPrincipal(ctx context.Context, sessionID, userID string, groups []string) (*database.SessionGrant, error)
+}
+
+// SessionGrantAuthorizer enforces session grants on "Session" resources, named
+// by session ID, after Next allows a check. The owner may do anything with a session; a user the
+// session is shared with, directly or through one of their groups, may get it
+// with read access and also invoke it with invoke access.
+type SessionGrantAuthorizer struct {
+	Next  auth.Authorizer
+	Store SessionGrantStore
+}
+
+func (a *SessionGrantAuthorizer) Check(ctx context.Context, principal auth.Principal, verb auth.Verb, resource auth.Resource) error {
+	if a.Next != nil {
+		if err := a.Next.Check(ctx, principal, verb, resource); err != nil {
+			return err
+		}
+	}
+	if resource.Type != "Session" {
+		return nil
+	}
+	if _, err := a.Store.GetSession(ctx, resource.Name, principal.User.ID); err == nil {
+		return nil
+	}
+	grant, err := a.Store.GetSessionGrantForPrincipal(ctx, resource.Name, principal.User.ID, principal.Groups())
+	if err != nil {
+		return fmt.Errorf("session %s is not shared with %s", resource.Name, principal.User.ID)
+	}
+	switch {
+	case verb == auth.VerbGet:
+		return nil
+	case verb == auth.VerbInvoke && grant.Access == database.SessionAccessInvoke:
+		return nil
+	}
+	return fmt.Errorf("session %s is shared with %s for %s access, which does not allow %s", resource.Name, principal.User.ID, grant.Access, verb)
+}
+
+var _ auth.Authorizer = (*SessionGrantAuthorizer)(nil)
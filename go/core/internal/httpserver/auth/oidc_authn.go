@@ -0,0 +1,138 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+
+	"github.com/kagent-dev/kagent/go/core/pkg/auth"
+)
+
+// OIDCConfig configures an OIDCAuthenticator.
+type OIDCConfig struct {
+	// IssuerURL is the issuer tokens must be issued by. The JWKS URL is
+	// discovered from its /.well-known/openid-configuration unless JWKSURL
+	// is set.
+	IssuerURL string
+	// Audience, when set, must be one of the token's audiences.
+	Audience string
+	JWKSURL  string
+	// UserIDClaim is the claim holding the user ID. Defaults to "sub".
+	UserIDClaim string
+}
+
+// OIDCAuthenticator validates JWT bearer tokens against the signing keys of
+// an OIDC issuer. Agent requests, marked by X-Agent-Name, carry a service
+// account token instead and are delegated to agents when it is set.
+type OIDCAuthenticator struct {
+	cfg    OIDCConfig
+	keys   jwk.Set
+	agents auth.AuthProvider
+}
+
+// NewOIDCAuthenticator discovers the issuer's JWKS and starts refreshing it
+// in the background until ctx is done.
+func NewOIDCAuthenticator(ctx context.Context, cfg OIDCConfig, agents auth.AuthProvider) (*OIDCAuthenticator, error) {
+	if cfg.IssuerURL == "" {
+		return nil, fmt.Errorf("an OIDC issuer URL is required")
+	}
+	if cfg.UserIDClaim == "" {
+		cfg.UserIDClaim = "sub"
+	}
+	if cfg.JWKSURL == "" {
+		jwksURL, err := discoverJWKSURL(ctx, cfg.IssuerURL)
+		if err != nil {
+			return nil, err
+		}
+		cfg.JWKSURL = jwksURL
+	}
+
+	cache := jwk.NewCache(ctx)
+	if err := cache.Register(cfg.JWKSURL, jwk.WithMinRefreshInterval(15*time.Minute)); err != nil {
+		return nil, fmt.Errorf("failed to register JWKS %s: %w", cfg.JWKSURL, err)
+	}
+	if _, err := cache.Refresh(ctx, cfg.JWKSURL); err != nil {
+		return nil, fmt.Errorf("failed to fetch JWKS %s: %w", cfg.JWKSURL, err)
+	}
+	return &OIDCAuthenticator{cfg: cfg, keys: jwk.NewCachedSet(cache, cfg.JWKSURL), agents: agents}, nil
+}
+
+func (a *OIDCAuthenticator) Authenticate(ctx context.Context, reqHeaders http.Header, query url.Values) (auth.Session, error) {
+	if reqHeaders.Get("X-Agent-Name") != "" && a.agents != nil {
+		return a.agents.Authenticate(ctx, reqHeaders, query)
+	}
+
+	authHeader := reqHeaders.Get("Authorization")
+	tokenString, ok := strings.CutPrefix(authHeader, "Bearer ")
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+	opts := []jwt.ParseOption{
+		jwt.WithKeySet(a.keys),
+		jwt.WithIssuer(a.cfg.IssuerURL),
+		jwt.WithAcceptableSkew(time.Minute),
+	}
+	if a.cfg.Audience != "" {
+		opts = append(opts, jwt.WithAudience(a.cfg.Audience))
+	}
+	token, err := jwt.Parse([]byte(tokenString), opts...)
+	if err != nil {
+		return nil, ErrUnauthenticated
+	}
+	claims, err := token.AsMap(ctx)
+	if err != nil {
+		return nil, ErrUnauthenticated
+	}
+	userID, _ := claims[a.cfg.UserIDClaim].(string)
+	if userID == "" {
+		return nil, ErrUnauthenticated
+	}
+	return &SimpleSession{
+		P: auth.Principal{
+			User:   auth.User{ID: userID},
+			Claims: claims,
+		},
+		authHeader: authHeader,
+	}, nil
+}
+
+func (a *OIDCAuthenticator) UpstreamAuth(r *http.Request, session auth.Session, upstreamPrincipal auth.Principal) error {
+	return forwardIdentity(r, session)
+}
+
+func discoverJWKSURL(ctx context.Context, issuer string) (string, error) {
+	discoveryURL := strings.TrimSuffix(issuer, "/") + "/.well-known/openid-configuration"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, discoveryURL, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to discover OIDC configuration of %s: %w", issuer, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to discover OIDC configuration of %s: %s", issuer, resp.Status)
+	}
+	var discovery struct {
+		Issuer  string `json:"issuer"`
+		JWKSURI string `json:"jwks_uri"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&discovery); err != nil {
+		return "", fmt.Errorf("invalid OIDC configuration of %s: %w", issuer, err)
+	}
+	if discovery.Issuer != issuer {
+		return "", fmt.Errorf("OIDC configuration of %s is for issuer %s", issuer, discovery.Issuer)
+	}
+	if discovery.JWKSURI == "" {
+		return "", fmt.Errorf("OIDC configuration of %s has no jwks_uri", issuer)
+	}
+	return discovery.JWKSURI, nil
+}
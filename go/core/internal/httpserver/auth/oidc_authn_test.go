@@ -0,0 +1,114 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+
+	authimpl "github.com/kagent-dev/kagent/go/core/internal/httpserver/auth"
+)
+
+func TestOIDCAuthenticator(t *testing.T) {
+	rawKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := jwk.FromRaw(rawKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = key.Set(jwk.KeyIDKey, "test-key")
+	_ = key.Set(jwk.AlgorithmKey, jwa.RS256)
+	publicKeys, err := jwk.PublicSetOf(func() jwk.Set { s := jwk.NewSet(); _ = s.AddKey(key); return s }())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mux := http.NewServeMux()
+	issuer := httptest.NewServer(mux)
+	defer issuer.Close()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{"issuer": issuer.URL, "jwks_uri": issuer.URL + "/keys"})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(publicKeys)
+	})
+
+	sign := func(t *testing.T, signingKey jwk.Key, claims map[string]any) string {
+		t.Helper()
+		tok := jwt.New()
+		for k, v := range claims {
+			if err := tok.Set(k, v); err != nil {
+				t.Fatal(err)
+			}
+		}
+		signed, err := jwt.Sign(tok, jwt.WithKey(jwa.RS256, signingKey))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(signed)
+	}
+	valid := func() map[string]any {
+		return map[string]any{
+			"iss":    issuer.URL,
+			"aud":    "kagent",
+			"sub":    "alice",
+			"email":  "alice@example.com",
+			"groups": []string{"sre"},
+			"exp":    time.Now().Add(time.Hour).Unix(),
+		}
+	}
+
+	a, err := authimpl.NewOIDCAuthenticator(context.Background(), authimpl.OIDCConfig{
+		IssuerURL:   issuer.URL,
+		Audience:    "kagent",
+		UserIDClaim: "email",
+	}, nil)
+	if err != nil {
+		t.Fatalf("NewOIDCAuthenticator() error = %v", err)
+	}
+
+	session, err := a.Authenticate(context.Background(), http.Header{"Authorization": {"Bearer " + sign(t, key, valid())}}, url.Values{"user_id": {"mallory"}})
+	if err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+	if p := session.Principal(); p.User.ID != "alice@example.com" || len(p.Groups()) != 1 || p.Groups()[0] != "sre" {
+		t.Errorf("Principal() = %+v, want alice@example.com in group sre", p)
+	}
+
+	otherRaw, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, _ := jwk.FromRaw(otherRaw)
+	_ = otherKey.Set(jwk.KeyIDKey, "test-key")
+
+	expired := valid()
+	expired["exp"] = time.Now().Add(-time.Hour).Unix()
+	wrongAudience := valid()
+	wrongAudience["aud"] = "other"
+	wrongIssuer := valid()
+	wrongIssuer["iss"] = "https://evil.example.com"
+
+	for name, token := range map[string]string{
+		"expired":        sign(t, key, expired),
+		"wrong audience": sign(t, key, wrongAudience),
+		"wrong issuer":   sign(t, key, wrongIssuer),
+		"wrong key":      sign(t, otherKey, valid()),
+		"unsigned":       createTestJWT(valid()),
+	} {
+		if _, err := a.Authenticate(context.Background(), http.Header{"Authorization": {"Bearer " + token}}, url.Values{}); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
@@ -88,15 +88,7 @@ func userIDFromRequest(headers http.Header, query url.Values) string {
 }
 
 func (a *ProxyAuthenticator) UpstreamAuth(r *http.Request, session auth.Session, upstreamPrincipal auth.Principal) error {
-	if simpleSession, ok := session.(*SimpleSession); ok {
-		if simpleSession.authHeader != "" {
-			r.Header.Set("Authorization", simpleSession.authHeader)
-		}
-		if userID := simpleSession.P.User.ID; userID != "" {
-			r.Header.Set("X-User-Id", userID)
-		}
-	}
-	return nil
+	return forwardIdentity(r, session)
 }
 
 // parseJWTPayload decodes JWT payload without signature verification
@@ -0,0 +1,196 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	agent_translator "github.com/kagent-dev/kagent/go/core/internal/controller/translator/agent"
+	"github.com/kagent-dev/kagent/go/core/internal/utils"
+	"github.com/kagent-dev/kagent/go/core/pkg/auth"
+)
+
+const (
+	// AgentTokenAudience is the audience of the projected service account
+	// token agents present when calling the controller.
+	AgentTokenAudience = "kagent"
+
+	tokenReviewCacheTTL = 30 * time.Second
+
+	serviceAccountUserPrefix = "system:serviceaccount:"
+)
+
+// TokenReviewAuthenticator authenticates bearer tokens with the Kubernetes
+// TokenReview API. Users present any token the API server accepts, such as
+// a service account token; the user ID is the token's username and its
+// groups are exposed to the Authorizer. Agents present their projected
+// service account token and act on behalf of the user in X-User-Id; the
+// token must belong to the ServiceAccount of the agent in X-Agent-Name.
+type TokenReviewAuthenticator struct {
+	kube client.Client
+	// audiences are the audiences user tokens must be issued for. Empty
+	// means the API server's audiences.
+	audiences []string
+
+	mu    sync.Mutex
+	cache map[string]tokenReviewResult
+}
+
+type tokenReviewResult struct {
+	user    authenticationv1.UserInfo
+	expires time.Time
+}
+
+func NewTokenReviewAuthenticator(kube client.Client, audiences []string) *TokenReviewAuthenticator {
+	return &TokenReviewAuthenticator{
+		kube:      kube,
+		audiences: audiences,
+		cache:     map[string]tokenReviewResult{},
+	}
+}
+
+func (a *TokenReviewAuthenticator) Authenticate(ctx context.Context, reqHeaders http.Header, query url.Values) (auth.Session, error) {
+	authHeader := reqHeaders.Get("Authorization")
+	token, ok := strings.CutPrefix(authHeader, "Bearer ")
+	if !ok || token == "" {
+		return nil, ErrUnauthenticated
+	}
+
+	if agentName := reqHeaders.Get("X-Agent-Name"); agentName != "" {
+		user, err := a.review(ctx, token, []string{AgentTokenAudience})
+		if err != nil {
+			return nil, ErrUnauthenticated
+		}
+		agentRef, err := a.agentOf(ctx, user.Username, agentName)
+		if err != nil {
+			ctrllog.FromContext(ctx).V(1).Info("Rejected agent token", "username", user.Username, "agent", agentName, "error", err)
+			return nil, ErrUnauthenticated
+		}
+		// Only the ServiceAccount of an agent may act on behalf of users.
+		userID := userIDFromRequest(reqHeaders, query)
+		if userID == "" {
+			userID = user.Username
+		}
+		return &SimpleSession{
+			P: auth.Principal{
+				User:  auth.User{ID: userID},
+				Agent: auth.Agent{ID: agentRef.String()},
+			},
+		}, nil
+	}
+
+	user, err := a.review(ctx, token, a.audiences)
+	if err != nil {
+		return nil, ErrUnauthenticated
+	}
+	// The token is not kept in the session so that a user's Kubernetes
+	// credential is never forwarded to agents.
+	return &SimpleSession{
+		P: auth.Principal{
+			User:   auth.User{ID: user.Username},
+			Claims: map[string]any{"sub": user.Username, "uid": user.UID, "groups": user.Groups},
+		},
+	}, nil
+}
+
+// agentOf returns the agent a ServiceAccount token was presented for. The
+// agent named by X-Agent-Name must be in the ServiceAccount's namespace and
+// its pods must run as that ServiceAccount.
+func (a *TokenReviewAuthenticator) agentOf(ctx context.Context, username, agentName string) (types.NamespacedName, error) {
+	rest, isServiceAccount := strings.CutPrefix(username, serviceAccountUserPrefix)
+	namespace, serviceAccount, ok := strings.Cut(rest, ":")
+	if !isServiceAccount || !ok {
+		return types.NamespacedName{}, fmt.Errorf("%s is not a service account", username)
+	}
+	agentNamespace, name, ok := strings.Cut(utils.ConvertToKubernetesIdentifier(agentName), "/")
+	if !ok || agentNamespace != namespace {
+		return types.NamespacedName{}, fmt.Errorf("agent %s is not in namespace %s", agentName, namespace)
+	}
+	ref := types.NamespacedName{Namespace: namespace, Name: name}
+	var agent v1alpha2.AgentObject = &v1alpha2.Agent{}
+	err := a.kube.Get(ctx, ref, agent)
+	if apierrors.IsNotFound(err) {
+		agent = &v1alpha2.SandboxAgent{}
+		err = a.kube.Get(ctx, ref, agent)
+	}
+	if err != nil {
+		return types.NamespacedName{}, fmt.Errorf("failed to get agent %s: %w", ref, err)
+	}
+	if got := agent_translator.ServiceAccountName(agent); got != serviceAccount {
+		return types.NamespacedName{}, fmt.Errorf("agent %s runs as service account %s", ref, got)
+	}
+	return ref, nil
+}
+
+func (a *TokenReviewAuthenticator) UpstreamAuth(r *http.Request, session auth.Session, upstreamPrincipal auth.Principal) error {
+	return forwardIdentity(r, session)
+}
+
+// review returns the user a token belongs to. Results are cached briefly so
+// that chatty clients do not cost a TokenReview per request.
+func (a *TokenReviewAuthenticator) review(ctx context.Context, token string, audiences []string) (authenticationv1.UserInfo, error) {
+	sum := sha256.Sum256([]byte(token + "\x00" + strings.Join(audiences, ",")))
+	key := hex.EncodeToString(sum[:])
+	now := time.Now()
+
+	a.mu.Lock()
+	if cached, ok := a.cache[key]; ok && now.Before(cached.expires) {
+		a.mu.Unlock()
+		return cached.user, nil
+	}
+	a.mu.Unlock()
+
+	review := &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: token, Audiences: audiences},
+	}
+	if err := a.kube.Create(ctx, review); err != nil {
+		ctrllog.FromContext(ctx).Error(err, "TokenReview failed")
+		return authenticationv1.UserInfo{}, fmt.Errorf("token review failed: %w", err)
+	}
+	if !review.Status.Authenticated {
+		return authenticationv1.UserInfo{}, fmt.Errorf("token not authenticated: %s", review.Status.Error)
+	}
+	if len(audiences) > 0 && !slices.ContainsFunc(review.Status.Audiences, func(aud string) bool {
+		return slices.Contains(audiences, aud)
+	}) {
+		return authenticationv1.UserInfo{}, fmt.Errorf("token not issued for audiences %v", audiences)
+	}
+
+	a.mu.Lock()
+	for k, cached := range a.cache {
+		if now.After(cached.expires) {
+			delete(a.cache, k)
+		}
+	}
+	a.cache[key] = tokenReviewResult{user: review.Status.User, expires: now.Add(tokenReviewCacheTTL)}
+	a.mu.Unlock()
+	return review.Status.User, nil
+}
+
+// forwardIdentity passes the caller's user ID, and its credentials when the
+// session keeps them, on to an upstream agent.
+func forwardIdentity(r *http.Request, session auth.Session) error {
+	if simpleSession, ok := session.(*SimpleSession); ok {
+		if simpleSession.authHeader != "" {
+			r.Header.Set("Authorization", simpleSession.authHeader)
+		}
+		if userID := simpleSession.P.User.ID; userID != "" {
+			r.Header.Set("X-User-Id", userID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package auth_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	authimpl "github.com/kagent-dev/kagent/go/core/internal/httpserver/auth"
+	"github.com/kagent-dev/kagent/go/core/pkg/auth"
+)
+
+// newTokenReviewClient returns a client whose TokenReviews authenticate the
+// given tokens and counts the reviews it performs. It holds the agent
+// kagent/k8s-agent, running as its own ServiceAccount, and kagent/shared-sa,
+// running as the ServiceAccount "shared".
+func newTokenReviewClient(users map[string]authenticationv1.UserInfo, reviews *int) client.Client {
+	scheme := runtime.NewScheme()
+	if err := v1alpha2.AddToScheme(scheme); err != nil {
+		panic(err)
+	}
+	agents := []client.Object{
+		&v1alpha2.Agent{
+			ObjectMeta: metav1.ObjectMeta{Name: "k8s-agent", Namespace: "kagent"},
+			Spec:       v1alpha2.AgentSpec{Type: v1alpha2.AgentType_Declarative, Declarative: &v1alpha2.DeclarativeAgentSpec{}},
+		},
+		&v1alpha2.Agent{
+			ObjectMeta: metav1.ObjectMeta{Name: "shared-sa", Namespace: "kagent"},
+			Spec: v1alpha2.AgentSpec{Type: v1alpha2.AgentType_Declarative, Declarative: &v1alpha2.DeclarativeAgentSpec{
+				Deployment: &v1alpha2.DeclarativeDeploymentSpec{SharedDeploymentSpec: v1alpha2.SharedDeploymentSpec{ServiceAccountName: new("shared")}},
+			}},
+		},
+	}
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(agents...).WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			review := obj.(*authenticationv1.TokenReview)
+			*reviews++
+			if user, ok := users[review.Spec.Token]; ok {
+				review.Status = authenticationv1.TokenReviewStatus{Authenticated: true, User: user, Audiences: review.Spec.Audiences}
+			}
+			return nil
+		},
+	}).Build()
+}
+
+func TestTokenReviewAuthenticator(t *testing.T) {
+	var reviews int
+	kube := newTokenReviewClient(map[string]authenticationv1.UserInfo{
+		"user-token":   {Username: "alice", Groups: []string{"sre"}},
+		"agent-token":  {Username: "system:serviceaccount:kagent:k8s-agent"},
+		"shared-token": {Username: "system:serviceaccount:kagent:shared"},
+		"pod-token":    {Username: "system:serviceaccount:kagent:default"},
+	}, &reviews)
+	a := authimpl.NewTokenReviewAuthenticator(kube, nil)
+
+	session, err := a.Authenticate(context.Background(), http.Header{"Authorization": {"Bearer user-token"}}, url.Values{})
+	if err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+	if got := session.Principal().User.ID; got != "alice" {
+		t.Errorf("User.ID = %q, want alice", got)
+	}
+	if got := session.Principal().Groups(); len(got) != 1 || got[0] != "sre" {
+		t.Errorf("Groups() = %v, want [sre]", got)
+	}
+
+	if _, err := a.Authenticate(context.Background(), http.Header{"Authorization": {"Bearer user-token"}}, url.Values{}); err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+	if reviews != 1 {
+		t.Errorf("reviews = %d, want 1 (cached)", reviews)
+	}
+
+	agentHeaders := http.Header{"Authorization": {"Bearer agent-token"}, "X-Agent-Name": {"kagent__NS__k8s_agent"}, "X-User-Id": {"alice"}}
+	session, err = a.Authenticate(context.Background(), agentHeaders, url.Values{})
+	if err != nil {
+		t.Fatalf("Authenticate() agent error = %v", err)
+	}
+	if p := session.Principal(); p.User.ID != "alice" || p.Agent.ID != "kagent/k8s-agent" {
+		t.Errorf("Principal() = %+v, want user alice acting through kagent/k8s-agent", p)
+	}
+
+	session, err = a.Authenticate(context.Background(), http.Header{"Authorization": {"Bearer shared-token"}, "X-Agent-Name": {"kagent/shared-sa"}}, url.Values{})
+	if err != nil {
+		t.Fatalf("Authenticate() shared service account error = %v", err)
+	}
+	if got := session.Principal().Agent.ID; got != "kagent/shared-sa" {
+		t.Errorf("Agent.ID = %q, want kagent/shared-sa", got)
+	}
+
+	for name, headers := range map[string]http.Header{
+		"no token":                   {},
+		"unknown token":              {"Authorization": {"Bearer other"}},
+		"user token claiming agency": {"Authorization": {"Bearer user-token"}, "X-Agent-Name": {"kagent/k8s-agent"}},
+		"another agent":              {"Authorization": {"Bearer agent-token"}, "X-Agent-Name": {"kagent/shared-sa"}, "X-User-Id": {"alice"}},
+		"another namespace":          {"Authorization": {"Bearer agent-token"}, "X-Agent-Name": {"other/k8s-agent"}, "X-User-Id": {"alice"}},
+		"unknown agent":              {"Authorization": {"Bearer agent-token"}, "X-Agent-Name": {"kagent/unknown"}, "X-User-Id": {"alice"}},
+		"non-agent service account":  {"Authorization": {"Bearer pod-token"}, "X-Agent-Name": {"kagent/k8s-agent"}, "X-User-Id": {"alice"}},
+	} {
+		if _, err := a.Authenticate(context.Background(), headers, url.Values{}); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+// TestTokenReviewAuthenticator_DoesNotForwardTokens verifies that neither a
+// user's Kubernetes token nor an agent's token is forwarded to agents.
+func TestTokenReviewAuthenticator_DoesNotForwardTokens(t *testing.T) {
+	var reviews int
+	kube := newTokenReviewClient(map[string]authenticationv1.UserInfo{
+		"user-token":  {Username: "alice"},
+		"agent-token": {Username: "system:serviceaccount:kagent:k8s-agent"},
+	}, &reviews)
+	a := authimpl.NewTokenReviewAuthenticator(kube, nil)
+
+	for name, headers := range map[string]http.Header{
+		"user":  {"Authorization": {"Bearer user-token"}},
+		"agent": {"Authorization": {"Bearer agent-token"}, "X-Agent-Name": {"kagent/k8s-agent"}, "X-User-Id": {"alice"}},
+	} {
+		session, err := a.Authenticate(context.Background(), headers, url.Values{})
+		if err != nil {
+			t.Fatalf("%s: Authenticate() error = %v", name, err)
+		}
+		r, _ := http.NewRequest(http.MethodPost, "http://agent", nil)
+		if err := a.UpstreamAuth(r, session, auth.Principal{}); err != nil {
+			t.Fatalf("%s: UpstreamAuth() error = %v", name, err)
+		}
+		if got := r.Header.Get("Authorization"); got != "" {
+			t.Errorf("%s: forwarded Authorization %q", name, got)
+		}
+		if got := r.Header.Get("X-User-Id"); got != "alice" {
+			t.Errorf("%s: X-User-Id = %q, want alice", name, got)
+		}
+	}
+}
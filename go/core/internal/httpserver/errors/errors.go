@@ -3,6 +3,8 @@ package errors
 import (
 	"fmt"
 	"net/http"
+
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
 )
 
 // APIError represents an API error with HTTP status code and message
@@ -10,6 +12,11 @@ type APIError struct {
 	Code    int
 	Message string
 	Err     error
+	// ErrorCode is the machine-readable code of the error; when empty, the
+	// code of the HTTP status is used.
+	ErrorCode api.ErrorCode
+	// Details is returned to the client along with the code.
+	Details map[string]any
 }
 
 // Error implements the error interface
@@ -30,6 +37,30 @@ func (e *APIError) StatusCode() int {
 	return e.Code
 }
 
+// WithCode sets the machine-readable code of the error and returns it.
+func (e *APIError) WithCode(code api.ErrorCode) *APIError {
+	e.ErrorCode = code
+	return e
+}
+
+// WithDetail adds a detail to the error and returns it.
+func (e *APIError) WithDetail(key string, value any) *APIError {
+	if e.Details == nil {
+		e.Details = map[string]any{}
+	}
+	e.Details[key] = value
+	return e
+}
+
+// Response returns the body returned to the client for the error.
+func (e *APIError) Response() api.APIError {
+	code := e.ErrorCode
+	if code == "" {
+		code = api.ErrorCodeForStatus(e.Code)
+	}
+	return api.APIError{Error: e.Error(), Code: code, Details: e.Details}
+}
+
 // NewBadRequestError creates a new bad request error
 func NewBadRequestError(message string, err error) *APIError {
 	return &APIError{
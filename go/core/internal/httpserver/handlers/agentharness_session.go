@@ -129,7 +129,7 @@ func (h *Handlers) loadAgentHarnessSession(r *http.Request) (*v1alpha2.AgentHarn
 	var ah v1alpha2.AgentHarness
 	if err := h.KubeClient.Get(r.Context(), types.NamespacedName{Namespace: namespace, Name: name}, &ah); err != nil {
 		if apierrors.IsNotFound(err) {
-			return nil, "", errors.NewNotFoundError("AgentHarness not found", err)
+			return nil, "", errors.NewNotFoundError("AgentHarness not found", err).WithCode(api.ErrorCodeAgentHarnessNotFound)
 		}
 		return nil, "", errors.NewInternalServerError("Failed to load AgentHarness", err)
 	}
@@ -1,14 +1,17 @@
 package handlers
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"net/http"
+	"slices"
 	"strings"
 
 	"github.com/go-logr/logr"
 	api "github.com/kagent-dev/kagent/go/api/httpapi"
 	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	"github.com/kagent-dev/kagent/go/core/internal/agentlint"
 	"github.com/kagent-dev/kagent/go/core/internal/controller/reconciler"
 	agent_translator "github.com/kagent-dev/kagent/go/core/internal/controller/translator/agent"
 	"github.com/kagent-dev/kagent/go/core/internal/httpserver/errors"
@@ -17,7 +20,6 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
-	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -33,36 +35,26 @@ func NewAgentsHandler(base *Base) *AgentsHandler {
 }
 
 // HandleListAgents handles GET /api/agents requests using database.
-// Optional query param: namespace=<ns>.
+// Optional query params: namespace=<ns>, label=<selector>, created_after=<ts>,
+// sort=name|namespace|created_at, limit=<n> and offset=<n>.
 func (h *AgentsHandler) HandleListAgents(w ErrorResponseWriter, r *http.Request) {
 	log := ctrllog.FromContext(r.Context()).WithName("agents-handler").WithValues("operation", "list-db")
 
-	namespace := r.URL.Query().Get("namespace")
-	if namespace == "" {
-		h.handleListAgents(w, r, log)
+	params, err := listParamsFromRequest(r, "name", "namespace", "created_at")
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError(err.Error(), nil))
 		return
 	}
 
-	if strings.TrimSpace(namespace) != namespace {
-		w.RespondWithError(errors.NewBadRequestError(
-			fmt.Sprintf("invalid namespace %q: must not contain leading or trailing whitespace", namespace),
-			nil,
-		))
-		return
+	var opts []client.ListOption
+	if params.Namespace != "" {
+		log = log.WithValues("namespace", params.Namespace)
+		opts = append(opts, client.InNamespace(params.Namespace))
 	}
-
-	if errs := utilvalidation.IsDNS1123Label(namespace); len(errs) > 0 {
-		w.RespondWithError(errors.NewBadRequestError(
-			fmt.Sprintf("invalid namespace %q: %s", namespace, strings.Join(errs, "; ")),
-			nil,
-		))
-		return
+	if params.Selector != nil {
+		opts = append(opts, client.MatchingLabelsSelector{Selector: params.Selector})
 	}
 
-	h.handleListAgents(w, r, log.WithValues("namespace", namespace), client.InNamespace(namespace))
-}
-
-func (h *AgentsHandler) handleListAgents(w ErrorResponseWriter, r *http.Request, log logr.Logger, opts ...client.ListOption) {
 	if err := Check(h.Authorizer, r, auth.Resource{Type: "Agent"}); err != nil {
 		w.RespondWithError(err)
 		return
@@ -74,11 +66,40 @@ func (h *AgentsHandler) handleListAgents(w ErrorResponseWriter, r *http.Request,
 		return
 	}
 
-	log.Info("Successfully listed agents", "count", len(agentsWithID))
-	data := api.NewResponse(agentsWithID, "Successfully listed agents", false)
+	if !params.CreatedAfter.IsZero() {
+		agentsWithID = slices.DeleteFunc(agentsWithID, func(a api.AgentResponse) bool {
+			return !agentMetadata(a).CreationTimestamp.After(params.CreatedAfter)
+		})
+	}
+	sortItems(agentsWithID, params, agentComparators)
+	page, pagination := paginate(agentsWithID, params)
+
+	log.Info("Successfully listed agents", "count", len(page))
+	data := api.NewResponse(page, "Successfully listed agents", false)
+	data.Pagination = pagination
 	RespondWithJSON(w, http.StatusOK, data)
 }
 
+var agentComparators = map[string]func(a, b api.AgentResponse) int{
+	"name": func(a, b api.AgentResponse) int {
+		return cmp.Compare(agentMetadata(a).Name, agentMetadata(b).Name)
+	},
+	"namespace": func(a, b api.AgentResponse) int {
+		am, bm := agentMetadata(a), agentMetadata(b)
+		return cmp.Or(cmp.Compare(am.Namespace, bm.Namespace), cmp.Compare(am.Name, bm.Name))
+	},
+	"created_at": func(a, b api.AgentResponse) int {
+		return compareTime(agentMetadata(a).CreationTimestamp.Time, agentMetadata(b).CreationTimestamp.Time)
+	},
+}
+
+func agentMetadata(a api.AgentResponse) metav1.ObjectMeta {
+	if a.Agent == nil {
+		return metav1.ObjectMeta{}
+	}
+	return a.Agent.Metadata
+}
+
 // HandleListSandboxAgents handles GET /api/sandboxagents requests using database.
 func (h *AgentsHandler) HandleListSandboxAgents(w ErrorResponseWriter, r *http.Request) {
 	log := ctrllog.FromContext(r.Context()).WithName("agents-handler").WithValues("operation", "list-sandboxagents")
@@ -245,9 +266,9 @@ func (h *AgentsHandler) getAgentResponse(ctx context.Context, log logr.Logger, a
 	deploymentReady := false
 	for _, condition := range status.Conditions {
 		if condition.Type == "Ready" && condition.Status == "True" {
-			if condition.Reason == reconciler.AgentReadyReasonDeploymentReady || condition.Reason == reconciler.AgentReadyReasonWorkloadReady {
+			switch condition.Reason {
+			case reconciler.AgentReadyReasonDeploymentReady, reconciler.AgentReadyReasonWorkloadReady, reconciler.AgentReadyReasonScaledToZero:
 				deploymentReady = true
-				break
 			}
 		}
 	}
@@ -306,31 +327,104 @@ func (h *AgentsHandler) buildTranslator(kubeClient client.Client) agent_translat
 		h.ProxyURL,
 		h.SandboxBackend,
 		h.MCPEgressPlaintext,
+		nil,
 	)
 }
 
-func (h *AgentsHandler) validateAgentObject(ctx context.Context, agent v1alpha2.AgentObject) error {
+// validateAgentObject compiles the agent to check its configuration. related
+// are objects the agent may reference that are not in the cluster yet, such
+// as a ModelConfig created in the same apply request.
+func (h *AgentsHandler) validateAgentObject(ctx context.Context, agent v1alpha2.AgentObject, related ...client.Object) error {
+	_, invalid, err := h.compileAgentObject(ctx, agent, related...)
+	if err != nil {
+		return err
+	}
+	if invalid != nil {
+		return errors.NewBadRequestError("Invalid agent configuration", invalid).WithCode(api.ErrorCodeAgentInvalid)
+	}
+	return nil
+}
+
+// compileAgentObject runs the agent through the translator pipeline without
+// writing anything. invalid is set, with the validation stage that rejected
+// it, when the agent configuration is invalid; err is set when the check
+// itself could not run.
+func (h *AgentsHandler) compileAgentObject(ctx context.Context, agent v1alpha2.AgentObject, related ...client.Object) (stage string, invalid error, err error) {
 	if sa, ok := agent.(*v1alpha2.SandboxAgent); ok {
 		if err := v1alpha2.ValidateSubstrateSandboxAgentSpec(sa); err != nil {
-			return errors.NewBadRequestError(err.Error(), err)
+			return api.ValidationStageSpec, err, nil
 		}
 	}
 
 	kubeClientWrapper := utils.NewKubeClientWrapper(h.KubeClient)
+	for _, obj := range related {
+		if err := kubeClientWrapper.AddInMemory(obj); err != nil {
+			return "", nil, errors.NewInternalServerError("Failed to add related object to Kubernetes wrapper", err)
+		}
+	}
 	if err := kubeClientWrapper.AddInMemory(agent); err != nil {
-		return errors.NewInternalServerError("Failed to add Agent to Kubernetes wrapper", err)
+		return "", nil, errors.NewInternalServerError("Failed to add Agent to Kubernetes wrapper", err)
 	}
 
 	apiTranslator := h.buildTranslator(kubeClientWrapper)
 	inputs, err := apiTranslator.CompileAgent(ctx, agent)
 	if err != nil {
-		return errors.NewBadRequestError("Invalid agent configuration", err)
+		return api.ValidationStageCompile, err, nil
 	}
 	if _, err := apiTranslator.BuildManifest(ctx, agent, inputs); err != nil {
-		return errors.NewBadRequestError("Invalid agent configuration", err)
+		return api.ValidationStageManifest, err, nil
 	}
 
-	return nil
+	return "", nil, nil
+}
+
+// HandleValidateAgent handles POST /api/agents/validate. The body is an Agent
+// manifest, which goes through the same translation as a reconcile (model
+// config and tool server resolution, config.json and workload generation) and
+// the lint rules. Nothing is written to the cluster.
+func (h *AgentsHandler) HandleValidateAgent(w ErrorResponseWriter, r *http.Request) {
+	log := ctrllog.FromContext(r.Context()).WithName("agents-handler").WithValues("operation", "validate")
+
+	agent := &v1alpha2.Agent{}
+	if err := DecodeJSONBody(r, agent); err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Invalid request body", err).WithCode(api.ErrorCodeInvalidRequestBody))
+		return
+	}
+	log, agentRef, err := h.parseAgentRef(log, agent, "Invalid agent metadata")
+	if err != nil {
+		w.RespondWithError(err)
+		return
+	}
+	if !h.authorizeAgentRequest(w, r, agentRef) {
+		return
+	}
+
+	resp := api.ValidateAgentResponse{Errors: []api.ValidationIssue{}, Warnings: []api.ValidationIssue{}}
+	stage, invalid, err := h.compileAgentObject(r.Context(), agent)
+	if err != nil {
+		w.RespondWithError(err)
+		return
+	}
+	if invalid != nil {
+		resp.Errors = append(resp.Errors, api.ValidationIssue{Stage: stage, Message: invalid.Error()})
+	}
+	for _, f := range agentlint.Lint(h.agentLintInput(r.Context(), log, agent, agent.Namespace)) {
+		issue := api.ValidationIssue{Stage: api.ValidationStageLint, RuleID: f.RuleID, Field: f.Field, Message: f.Message}
+		switch f.Severity {
+		case agentlint.SeverityError:
+			resp.Errors = append(resp.Errors, issue)
+		case agentlint.SeverityWarning:
+			resp.Warnings = append(resp.Warnings, issue)
+		}
+	}
+	resp.Valid = len(resp.Errors) == 0
+
+	message := "Agent configuration is valid"
+	if !resp.Valid {
+		message = "Agent configuration is invalid"
+	}
+	log.Info(message, "errors", len(resp.Errors), "warnings", len(resp.Warnings))
+	RespondWithJSON(w, http.StatusOK, api.NewResponse(resp, message, false))
 }
 
 func (h *AgentsHandler) parseAgentRef(log logr.Logger, agent client.Object, invalidMsg string) (logr.Logger, types.NamespacedName, error) {
@@ -479,7 +573,7 @@ func (h *AgentsHandler) handleCreateAgentObject(
 	responseData func(context.Context, logr.Logger, v1alpha2.AgentObject) (any, error),
 ) {
 	if err := DecodeJSONBody(r, agent); err != nil {
-		w.RespondWithError(errors.NewBadRequestError("Invalid request body", err))
+		w.RespondWithError(errors.NewBadRequestError("Invalid request body", err).WithCode(api.ErrorCodeInvalidRequestBody))
 		return
 	}
 	if normalize != nil {
@@ -531,7 +625,7 @@ func (h *AgentsHandler) handleUpdateAgentObject(
 	responseData func(context.Context, logr.Logger, v1alpha2.AgentObject) (any, error),
 ) {
 	if err := DecodeJSONBody(r, incoming); err != nil {
-		w.RespondWithError(errors.NewBadRequestError("Invalid request body", err))
+		w.RespondWithError(errors.NewBadRequestError("Invalid request body", err).WithCode(api.ErrorCodeInvalidRequestBody))
 		return
 	}
 	if normalize != nil {
@@ -636,14 +730,14 @@ func (h *AgentsHandler) HandleGetAgentHarness(w ErrorResponseWriter, r *http.Req
 	sb := &v1alpha2.AgentHarness{}
 	if err := h.KubeClient.Get(ctx, objKey, sb); err != nil {
 		if apierrors.IsNotFound(err) {
-			w.RespondWithError(errors.NewNotFoundError("AgentHarness not found", nil))
+			w.RespondWithError(errors.NewNotFoundError("AgentHarness not found", nil).WithCode(api.ErrorCodeAgentHarnessNotFound))
 			return
 		}
 		w.RespondWithError(errors.NewInternalServerError("Failed to get AgentHarness", err))
 		return
 	}
 	if !v1alpha2.IsKnownAgentHarnessBackend(sb.Spec.Backend) {
-		w.RespondWithError(errors.NewNotFoundError("AgentHarness not found", nil))
+		w.RespondWithError(errors.NewNotFoundError("AgentHarness not found", nil).WithCode(api.ErrorCodeAgentHarnessNotFound))
 		return
 	}
 	resp := h.agentHarnessAgentResponse(ctx, log, sb)
@@ -722,7 +816,7 @@ func (h *AgentsHandler) HandleDeleteAgent(w ErrorResponseWriter, r *http.Request
 	err = h.KubeClient.Get(ctx, objKey, agent)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
-			w.RespondWithError(errors.NewNotFoundError("Agent not found", nil))
+			w.RespondWithError(errors.NewNotFoundError("Agent not found", nil).WithCode(api.ErrorCodeAgentNotFound).WithDetail("agent", objKey.String()))
 			return
 		}
 		w.RespondWithError(errors.NewInternalServerError("Failed to get Agent", err))
@@ -761,14 +855,14 @@ func (h *AgentsHandler) HandleDeleteAgentHarness(w ErrorResponseWriter, r *http.
 	sb := &v1alpha2.AgentHarness{}
 	if err := h.KubeClient.Get(ctx, objKey, sb); err != nil {
 		if apierrors.IsNotFound(err) {
-			w.RespondWithError(errors.NewNotFoundError("AgentHarness not found", nil))
+			w.RespondWithError(errors.NewNotFoundError("AgentHarness not found", nil).WithCode(api.ErrorCodeAgentHarnessNotFound))
 			return
 		}
 		w.RespondWithError(errors.NewInternalServerError("Failed to get AgentHarness", err))
 		return
 	}
 	if !v1alpha2.IsKnownAgentHarnessBackend(sb.Spec.Backend) {
-		w.RespondWithError(errors.NewNotFoundError("AgentHarness not found", nil))
+		w.RespondWithError(errors.NewNotFoundError("AgentHarness not found", nil).WithCode(api.ErrorCodeAgentHarnessNotFound))
 		return
 	}
 	if err := h.KubeClient.Delete(ctx, sb); err != nil {
@@ -793,7 +887,7 @@ func (h *AgentsHandler) HandleCreateAgentHarness(w ErrorResponseWriter, r *http.
 	log := ctrllog.FromContext(r.Context()).WithName("agents-handler").WithValues("operation", "create-agentharness")
 	sb := &v1alpha2.AgentHarness{}
 	if err := DecodeJSONBody(r, sb); err != nil {
-		w.RespondWithError(errors.NewBadRequestError("Invalid request body", err))
+		w.RespondWithError(errors.NewBadRequestError("Invalid request body", err).WithCode(api.ErrorCodeInvalidRequestBody))
 		return
 	}
 	if sb.APIVersion == "" {
@@ -1112,3 +1112,94 @@ func TestHandleCreateAgentHarness(t *testing.T) {
 		require.Equal(t, v1alpha2.AgentHarnessBackendHermes, created.Spec.Backend)
 	})
 }
+
+func TestHandleValidateAgent(t *testing.T) {
+	withRuntimeImageDigests(t)
+	modelConfig := &v1alpha2.ModelConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-model-config", Namespace: "default"},
+		Spec: v1alpha2.ModelConfigSpec{
+			Model:    "test",
+			Provider: "Ollama",
+			Ollama:   &v1alpha2.OllamaConfig{Host: "http://test-host"},
+		},
+	}
+
+	newAgent := func(name, description string) *v1alpha2.Agent {
+		a := createTestAgent(name, modelConfig)
+		a.Spec.Description = description
+		a.Spec.Declarative.SystemMessage = "You are a helpful agent"
+		return a
+	}
+
+	tests := []struct {
+		name         string
+		agent        *v1alpha2.Agent
+		wantValid    bool
+		wantErrors   []string
+		wantWarnings []string
+	}{
+		{
+			name:         "valid agent",
+			agent:        newAgent("valid", "Answers cluster questions"),
+			wantValid:    true,
+			wantErrors:   []string{},
+			wantWarnings: []string{},
+		},
+		{
+			name: "missing model config",
+			agent: func() *v1alpha2.Agent {
+				a := newAgent("no-model", "Answers cluster questions")
+				a.Spec.Declarative.ModelConfig = "missing"
+				return a
+			}(),
+			wantErrors:   []string{api.ValidationStageCompile},
+			wantWarnings: []string{},
+		},
+		{
+			name:         "lint warnings do not invalidate",
+			agent:        newAgent("no-description", ""),
+			wantValid:    true,
+			wantErrors:   []string{},
+			wantWarnings: []string{api.ValidationStageLint},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kubeClient := fake.NewClientBuilder().
+				WithScheme(setupScheme()).
+				WithObjects(modelConfig).
+				Build()
+			handler := handlers.NewAgentsHandler(&handlers.Base{
+				KubeClient:         kubeClient,
+				DefaultModelConfig: types.NamespacedName{Name: modelConfig.Name, Namespace: modelConfig.Namespace},
+				Authorizer:         &auth.NoopAuthorizer{},
+			})
+
+			body, _ := json.Marshal(tt.agent)
+			req := httptest.NewRequest(http.MethodPost, "/api/agents/validate", bytes.NewReader(body))
+			req = setUser(req, "test-user")
+			w := httptest.NewRecorder()
+
+			handler.HandleValidateAgent(&testErrorResponseWriter{w}, req)
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+			var response api.StandardResponse[api.ValidateAgentResponse]
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			stages := func(issues []api.ValidationIssue) []string {
+				out := []string{}
+				for _, issue := range issues {
+					out = append(out, issue.Stage)
+				}
+				return out
+			}
+			require.Equal(t, tt.wantValid, response.Data.Valid, w.Body.String())
+			require.Equal(t, tt.wantErrors, stages(response.Data.Errors))
+			require.Equal(t, tt.wantWarnings, stages(response.Data.Warnings))
+
+			// Validation never writes the agent.
+			err := kubeClient.Get(req.Context(), client.ObjectKeyFromObject(tt.agent), &v1alpha2.Agent{})
+			require.True(t, apierrors.IsNotFound(err))
+		})
+	}
+}
@@ -0,0 +1,273 @@
+package handlers
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"text/template"
+
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	"github.com/kagent-dev/kagent/go/core/internal/httpserver/errors"
+	"github.com/kagent-dev/kagent/go/core/pkg/auth"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// kagent.dev/agent-template=true marks a ConfigMap as an agent template.
+	// Agents created from a template carry the annotation of the same key,
+	// set to the template's namespace/name.
+	agentTemplateKey      = "kagent.dev/agent-template"
+	agentTemplateLabelVal = "true"
+
+	// Keys of an agent template ConfigMap.
+	agentTemplateDescriptionKey = "description"
+	agentTemplateParametersKey  = "parameters"
+	agentTemplateManifestKey    = "agent.yaml"
+)
+
+// AgentTemplatesHandler serves the catalog of agent templates and creates
+// Agents from them.
+type AgentTemplatesHandler struct {
+	*Base
+}
+
+// NewAgentTemplatesHandler creates an AgentTemplatesHandler.
+func NewAgentTemplatesHandler(base *Base) *AgentTemplatesHandler {
+	return &AgentTemplatesHandler{Base: base}
+}
+
+// HandleListAgentTemplates handles GET /api/templates?namespace=… Templates
+// of every namespace are listed when namespace is unset.
+func (h *AgentTemplatesHandler) HandleListAgentTemplates(w ErrorResponseWriter, r *http.Request) {
+	log := ctrllog.FromContext(r.Context()).WithName("agenttemplates-handler").WithValues("operation", "list")
+	if err := Check(h.Authorizer, r, auth.Resource{Type: "AgentTemplate"}); err != nil {
+		w.RespondWithError(err)
+		return
+	}
+
+	opts := []client.ListOption{client.MatchingLabels{agentTemplateKey: agentTemplateLabelVal}}
+	if ns := r.URL.Query().Get("namespace"); ns != "" {
+		opts = append(opts, client.InNamespace(ns))
+	}
+	list := &corev1.ConfigMapList{}
+	if err := h.KubeClient.List(r.Context(), list, opts...); err != nil {
+		w.RespondWithError(errors.NewInternalServerError("Failed to list agent templates", err))
+		return
+	}
+
+	out := make([]api.AgentTemplate, 0, len(list.Items))
+	for i := range list.Items {
+		tmpl, err := agentTemplateFromConfigMap(&list.Items[i])
+		if err != nil {
+			log.Error(err, "Skipping invalid agent template", "namespace", list.Items[i].Namespace, "name", list.Items[i].Name)
+			continue
+		}
+		tmpl.Template = ""
+		out = append(out, tmpl)
+	}
+	slices.SortFunc(out, func(a, b api.AgentTemplate) int {
+		return cmp.Or(cmp.Compare(a.Namespace, b.Namespace), cmp.Compare(a.Name, b.Name))
+	})
+
+	log.Info("Listed agent templates", "count", len(out))
+	RespondWithJSON(w, http.StatusOK, api.NewResponse(out, "Successfully listed agent templates", false))
+}
+
+// HandleGetAgentTemplate handles GET /api/templates/{namespace}/{name}
+func (h *AgentTemplatesHandler) HandleGetAgentTemplate(w ErrorResponseWriter, r *http.Request) {
+	ref, apiErr := agentTemplateRef(r)
+	if apiErr != nil {
+		w.RespondWithError(apiErr)
+		return
+	}
+	log := ctrllog.FromContext(r.Context()).WithName("agenttemplates-handler").WithValues("operation", "get", "template", ref)
+
+	if err := Check(h.Authorizer, r, auth.Resource{Type: "AgentTemplate", Name: ref.String()}); err != nil {
+		w.RespondWithError(err)
+		return
+	}
+	tmpl, apiErr := h.getAgentTemplate(r, ref)
+	if apiErr != nil {
+		w.RespondWithError(apiErr)
+		return
+	}
+
+	log.Info("Retrieved agent template")
+	RespondWithJSON(w, http.StatusOK, api.NewResponse(tmpl, "Successfully retrieved agent template", false))
+}
+
+// HandleCreateAgentFromTemplate handles POST
+// /api/templates/{namespace}/{name}/agents. It renders the template with the
+// request's parameters and creates the resulting Agent, or only validates it
+// on a dry run.
+func (h *AgentTemplatesHandler) HandleCreateAgentFromTemplate(w ErrorResponseWriter, r *http.Request) {
+	ref, apiErr := agentTemplateRef(r)
+	if apiErr != nil {
+		w.RespondWithError(apiErr)
+		return
+	}
+	log := ctrllog.FromContext(r.Context()).WithName("agenttemplates-handler").WithValues("operation", "create-agent", "template", ref)
+
+	var req api.CreateAgentFromTemplateRequest
+	if err := DecodeJSONBody(r, &req); err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Invalid request body", err).WithCode(api.ErrorCodeInvalidRequestBody))
+		return
+	}
+	if req.Name == "" || req.Namespace == "" {
+		w.RespondWithError(errors.NewBadRequestError("Agent name and namespace are required", nil))
+		return
+	}
+	agentRef := types.NamespacedName{Namespace: req.Namespace, Name: req.Name}
+	log = log.WithValues("agentRef", agentRef)
+	if err := Check(h.Authorizer, r, auth.Resource{Type: "Agent", Name: agentRef.String()}); err != nil {
+		w.RespondWithError(err)
+		return
+	}
+
+	tmpl, apiErr := h.getAgentTemplate(r, ref)
+	if apiErr != nil {
+		w.RespondWithError(apiErr)
+		return
+	}
+	agent, err := renderAgentTemplate(tmpl, req)
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError(err.Error(), err))
+		return
+	}
+	agents := &AgentsHandler{Base: h.Base}
+	if err := agents.validateAgentObject(r.Context(), agent); err != nil {
+		w.RespondWithError(err)
+		return
+	}
+
+	if req.DryRun {
+		log.Info("Rendered agent from template")
+		RespondWithJSON(w, http.StatusOK, api.NewResponse(agent, "Successfully rendered agent from template", false))
+		return
+	}
+	if err := h.KubeClient.Create(r.Context(), agent); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			w.RespondWithError(errors.NewConflictError("Agent already exists", err))
+			return
+		}
+		w.RespondWithError(errors.NewInternalServerError("Failed to create Agent in Kubernetes", err))
+		return
+	}
+
+	log.Info("Created agent from template")
+	RespondWithJSON(w, http.StatusCreated, api.NewResponse(agent, "Successfully created agent from template", false))
+}
+
+func agentTemplateRef(r *http.Request) (types.NamespacedName, *errors.APIError) {
+	namespace, err := GetPathParam(r, "namespace")
+	if err != nil {
+		return types.NamespacedName{}, errors.NewBadRequestError("Failed to get namespace from path", err)
+	}
+	name, err := GetPathParam(r, "name")
+	if err != nil {
+		return types.NamespacedName{}, errors.NewBadRequestError("Failed to get name from path", err)
+	}
+	return types.NamespacedName{Namespace: namespace, Name: name}, nil
+}
+
+func (h *AgentTemplatesHandler) getAgentTemplate(r *http.Request, ref types.NamespacedName) (api.AgentTemplate, *errors.APIError) {
+	cm := &corev1.ConfigMap{}
+	if err := h.KubeClient.Get(r.Context(), ref, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return api.AgentTemplate{}, errors.NewNotFoundError("Agent template not found", err).WithCode(api.ErrorCodeAgentTemplateNotFound)
+		}
+		return api.AgentTemplate{}, errors.NewInternalServerError("Failed to get agent template", err)
+	}
+	if cm.Labels[agentTemplateKey] != agentTemplateLabelVal {
+		return api.AgentTemplate{}, errors.NewNotFoundError("Agent template not found", fmt.Errorf("ConfigMap %s is not labeled %s=%s", ref, agentTemplateKey, agentTemplateLabelVal)).WithCode(api.ErrorCodeAgentTemplateNotFound)
+	}
+	tmpl, err := agentTemplateFromConfigMap(cm)
+	if err != nil {
+		return api.AgentTemplate{}, errors.NewInternalServerError("Invalid agent template", err)
+	}
+	return tmpl, nil
+}
+
+func agentTemplateFromConfigMap(cm *corev1.ConfigMap) (api.AgentTemplate, error) {
+	tmpl := api.AgentTemplate{
+		Namespace:   cm.Namespace,
+		Name:        cm.Name,
+		Description: cm.Data[agentTemplateDescriptionKey],
+		Template:    cm.Data[agentTemplateManifestKey],
+	}
+	if tmpl.Template == "" {
+		return tmpl, fmt.Errorf("missing %s key", agentTemplateManifestKey)
+	}
+	if params := cm.Data[agentTemplateParametersKey]; params != "" {
+		if err := yaml.UnmarshalStrict([]byte(params), &tmpl.Parameters); err != nil {
+			return tmpl, fmt.Errorf("invalid %s: %w", agentTemplateParametersKey, err)
+		}
+	}
+	for _, p := range tmpl.Parameters {
+		if p.Name == "" {
+			return tmpl, fmt.Errorf("invalid %s: parameter without a name", agentTemplateParametersKey)
+		}
+	}
+	return tmpl, nil
+}
+
+// renderAgentTemplate executes the template with the declared parameters,
+// plus name and namespace set to the new Agent's, and decodes the Agent.
+func renderAgentTemplate(tmpl api.AgentTemplate, req api.CreateAgentFromTemplateRequest) (*v1alpha2.Agent, error) {
+	values := make(map[string]string, len(tmpl.Parameters)+2)
+	for _, p := range tmpl.Parameters {
+		value, ok := req.Parameters[p.Name]
+		if !ok || value == "" {
+			value = p.Default
+		}
+		if value == "" && p.Required {
+			return nil, fmt.Errorf("parameter %s is required", p.Name)
+		}
+		values[p.Name] = value
+	}
+	for name := range req.Parameters {
+		if _, ok := values[name]; !ok {
+			return nil, fmt.Errorf("unknown parameter %s for template %s/%s", name, tmpl.Namespace, tmpl.Name)
+		}
+	}
+	values["name"] = req.Name
+	values["namespace"] = req.Namespace
+
+	t, err := template.New(tmpl.Name).
+		Option("missingkey=error").
+		Funcs(template.FuncMap{"quote": strconv.Quote}).
+		Parse(tmpl.Template)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template %s/%s: %w", tmpl.Namespace, tmpl.Name, err)
+	}
+	var manifest bytes.Buffer
+	if err := t.Execute(&manifest, values); err != nil {
+		return nil, fmt.Errorf("failed to render template %s/%s: %w", tmpl.Namespace, tmpl.Name, err)
+	}
+
+	agent := &v1alpha2.Agent{}
+	if err := yaml.UnmarshalStrict(manifest.Bytes(), agent); err != nil {
+		return nil, fmt.Errorf("template %s/%s does not render a valid Agent: %w", tmpl.Namespace, tmpl.Name, err)
+	}
+	if agent.Kind != "" && agent.Kind != "Agent" {
+		return nil, fmt.Errorf("template %s/%s renders a %s, not an Agent", tmpl.Namespace, tmpl.Name, agent.Kind)
+	}
+	agent.APIVersion = v1alpha2.GroupVersion.String()
+	agent.Kind = "Agent"
+	agent.Name = req.Name
+	agent.Namespace = req.Namespace
+	if agent.Annotations == nil {
+		agent.Annotations = map[string]string{}
+	}
+	agent.Annotations[agentTemplateKey] = tmpl.Namespace + "/" + tmpl.Name
+	return agent, nil
+}
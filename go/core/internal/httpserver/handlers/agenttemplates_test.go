@@ -0,0 +1,183 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	"github.com/kagent-dev/kagent/go/core/internal/httpserver/auth"
+	"github.com/kagent-dev/kagent/go/core/internal/httpserver/handlers"
+)
+
+func TestAgentTemplatesHandler(t *testing.T) {
+	withRuntimeImageDigests(t)
+
+	sreTemplate := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "kagent",
+			Name:      "k8s-sre",
+			Labels:    map[string]string{"kagent.dev/agent-template": "true"},
+		},
+		Data: map[string]string{
+			"description": "Kubernetes SRE agent",
+			"parameters": `
+- name: model
+  description: ModelConfig of the agent
+  required: true
+- name: tone
+  default: concise
+`,
+			"agent.yaml": `apiVersion: kagent.dev/v1alpha2
+kind: Agent
+metadata:
+  name: ignored
+spec:
+  type: Declarative
+  description: SRE for {{ .namespace }}
+  declarative:
+    modelConfig: {{ .model }}
+    systemMessage: {{ quote (print "You are " .name ", a " .tone " Kubernetes SRE.") }}
+`,
+		},
+	}
+	promptLibrary := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "kagent",
+			Name:      "team-prompts",
+			Labels:    map[string]string{"kagent.dev/prompt-library": "true"},
+		},
+		Data: map[string]string{"intro": "hello"},
+	}
+	broken := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "team-a",
+			Name:      "broken",
+			Labels:    map[string]string{"kagent.dev/agent-template": "true"},
+		},
+		Data: map[string]string{"description": "no manifest"},
+	}
+
+	newHandler := func() (*handlers.AgentTemplatesHandler, client.Client) {
+		kubeClient := fake.NewClientBuilder().WithScheme(setupScheme()).
+			WithObjects(sreTemplate.DeepCopy(), promptLibrary.DeepCopy(), broken.DeepCopy(), createTestModelConfig()).
+			Build()
+		return handlers.NewAgentTemplatesHandler(&handlers.Base{
+			KubeClient:         kubeClient,
+			DefaultModelConfig: types.NamespacedName{Name: "test-model-config", Namespace: "default"},
+			Authorizer:         &auth.NoopAuthorizer{},
+		}), kubeClient
+	}
+
+	createAgent := func(t *testing.T, h *handlers.AgentTemplatesHandler, name string, req api.CreateAgentFromTemplateRequest) *mockErrorResponseWriter {
+		t.Helper()
+		body, err := json.Marshal(req)
+		require.NoError(t, err)
+		r := httptest.NewRequest(http.MethodPost, "/api/templates/kagent/"+name+"/agents", bytes.NewReader(body))
+		r = mux.SetURLVars(setUser(r, "u1"), map[string]string{"namespace": "kagent", "name": name})
+		w := newMockErrorResponseWriter()
+		h.HandleCreateAgentFromTemplate(w, r)
+		return w
+	}
+
+	t.Run("lists valid templates only", func(t *testing.T) {
+		h, _ := newHandler()
+		w := newMockErrorResponseWriter()
+		h.HandleListAgentTemplates(w, setUser(httptest.NewRequest(http.MethodGet, "/api/templates", nil), "u1"))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var resp api.StandardResponse[[]api.AgentTemplate]
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		require.Len(t, resp.Data, 1)
+		assert.Equal(t, "k8s-sre", resp.Data[0].Name)
+		assert.Equal(t, "Kubernetes SRE agent", resp.Data[0].Description)
+		assert.Len(t, resp.Data[0].Parameters, 2)
+		assert.Empty(t, resp.Data[0].Template, "list leaves out the manifest")
+	})
+
+	t.Run("gets a template with its manifest", func(t *testing.T) {
+		h, _ := newHandler()
+		w := newMockErrorResponseWriter()
+		r := httptest.NewRequest(http.MethodGet, "/api/templates/kagent/k8s-sre", nil)
+		h.HandleGetAgentTemplate(w, mux.SetURLVars(setUser(r, "u1"), map[string]string{"namespace": "kagent", "name": "k8s-sre"}))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var resp api.StandardResponse[api.AgentTemplate]
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Contains(t, resp.Data.Template, "modelConfig: {{ .model }}")
+
+		w = newMockErrorResponseWriter()
+		r = httptest.NewRequest(http.MethodGet, "/api/templates/kagent/team-prompts", nil)
+		h.HandleGetAgentTemplate(w, mux.SetURLVars(setUser(r, "u1"), map[string]string{"namespace": "kagent", "name": "team-prompts"}))
+		assert.Equal(t, http.StatusNotFound, w.Code, "ConfigMaps without the label are not templates")
+	})
+
+	t.Run("creates an agent from a template", func(t *testing.T) {
+		h, kubeClient := newHandler()
+		w := createAgent(t, h, "k8s-sre", api.CreateAgentFromTemplateRequest{
+			Name:       "payments-sre",
+			Namespace:  "default",
+			Parameters: map[string]string{"model": "test-model-config"},
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		agent := &v1alpha2.Agent{}
+		require.NoError(t, kubeClient.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "payments-sre"}, agent))
+		assert.Equal(t, "test-model-config", agent.Spec.Declarative.ModelConfig)
+		assert.Equal(t, "You are payments-sre, a concise Kubernetes SRE.", agent.Spec.Declarative.SystemMessage)
+		assert.Equal(t, "SRE for default", agent.Spec.Description)
+		assert.Equal(t, "kagent/k8s-sre", agent.Annotations["kagent.dev/agent-template"])
+	})
+
+	t.Run("dry run creates nothing", func(t *testing.T) {
+		h, kubeClient := newHandler()
+		w := createAgent(t, h, "k8s-sre", api.CreateAgentFromTemplateRequest{
+			Name:       "payments-sre",
+			Namespace:  "default",
+			Parameters: map[string]string{"model": "test-model-config", "tone": "friendly"},
+			DryRun:     true,
+		})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var resp api.StandardResponse[v1alpha2.Agent]
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, "You are payments-sre, a friendly Kubernetes SRE.", resp.Data.Spec.Declarative.SystemMessage)
+		err := kubeClient.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "payments-sre"}, &v1alpha2.Agent{})
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects bad parameters", func(t *testing.T) {
+		h, _ := newHandler()
+		for name, params := range map[string]map[string]string{
+			"parameter model is required":   {"tone": "friendly"},
+			"unknown parameter temperature": {"model": "test-model-config", "temperature": "0.2"},
+		} {
+			w := createAgent(t, h, "k8s-sre", api.CreateAgentFromTemplateRequest{Name: "a", Namespace: "default", Parameters: params})
+			assert.Equal(t, http.StatusBadRequest, w.Code, name)
+			assert.Contains(t, w.Body.String(), name)
+		}
+	})
+
+	t.Run("rejects an agent that does not compile", func(t *testing.T) {
+		h, _ := newHandler()
+		w := createAgent(t, h, "k8s-sre", api.CreateAgentFromTemplateRequest{
+			Name:       "a",
+			Namespace:  "default",
+			Parameters: map[string]string{"model": "missing-model-config"},
+		})
+		assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+	})
+}
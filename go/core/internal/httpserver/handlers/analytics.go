@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/kagent-dev/kagent/go/api/database"
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
+	"github.com/kagent-dev/kagent/go/core/internal/httpserver/errors"
+	"github.com/kagent-dev/kagent/go/core/pkg/auth"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	defaultProviderStatsDays = 7
+	maxProviderStatsDays     = 90
+)
+
+// AnalyticsHandler serves aggregated usage data collected from agent traffic
+type AnalyticsHandler struct {
+	*Base
+}
+
+// NewAnalyticsHandler creates a new analytics handler
+func NewAnalyticsHandler(base *Base) *AnalyticsHandler {
+	return &AnalyticsHandler{Base: base}
+}
+
+// HandleListProviderStats returns success rate and latency per model provider
+// and model over the last `days` days (default 7), with the daily breakdown.
+// The stats aggregate the traffic of all agents, so the caller must be allowed
+// to list agents.
+func (h *AnalyticsHandler) HandleListProviderStats(w ErrorResponseWriter, r *http.Request) {
+	log := ctrllog.FromContext(r.Context()).WithName("analytics-handler").WithValues("operation", "list-provider-stats")
+
+	if err := Check(h.Authorizer, r, auth.Resource{Type: "Agent"}); err != nil {
+		w.RespondWithError(err)
+		return
+	}
+
+	days, err := parseProviderStatsDays(r)
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Invalid days parameter", err))
+		return
+	}
+
+	since := time.Now().UTC().AddDate(0, 0, -days)
+	daily, err := h.DatabaseService.ListProviderDailyStats(r.Context(), since)
+	if err != nil {
+		log.Error(err, "Failed to list provider stats")
+		w.RespondWithError(errors.NewInternalServerError("Failed to list provider stats", err))
+		return
+	}
+
+	log.Info("Successfully listed provider stats", "days", days, "rows", len(daily))
+	data := api.NewResponse(summarizeProviderStats(daily), "Successfully listed provider stats", false)
+	RespondWithJSON(w, http.StatusOK, data)
+}
+
+func parseProviderStatsDays(r *http.Request) (int, error) {
+	raw := r.URL.Query().Get("days")
+	if raw == "" {
+		return defaultProviderStatsDays, nil
+	}
+	days, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse days: %w", err)
+	}
+	if days < 1 || days > maxProviderStatsDays {
+		return 0, fmt.Errorf("days must be between 1 and %d", maxProviderStatsDays)
+	}
+	return days, nil
+}
+
+// summarizeProviderStats rolls daily rows up into one entry per provider and
+// model. Rows arrive ordered by day, so each Daily series stays chronological.
+func summarizeProviderStats(daily []database.ProviderDailyStats) []api.ProviderStatsResponse {
+	result := []api.ProviderStatsResponse{}
+	index := map[string]int{}
+	totalLatency := map[string]int64{}
+
+	for _, row := range daily {
+		key := row.Provider + "/" + row.Model
+		i, ok := index[key]
+		if !ok {
+			i = len(result)
+			index[key] = i
+			result = append(result, api.ProviderStatsResponse{Provider: row.Provider, Model: row.Model})
+		}
+		s := &result[i]
+		s.RequestCount += row.RequestCount
+		s.ErrorCount += row.ErrorCount
+		s.MaxLatencyMs = max(s.MaxLatencyMs, row.MaxLatencyMs)
+		s.Daily = append(s.Daily, row)
+		totalLatency[key] += row.TotalLatencyMs
+	}
+
+	for i := range result {
+		s := &result[i]
+		if s.RequestCount == 0 {
+			continue
+		}
+		s.SuccessRate = float64(s.RequestCount-s.ErrorCount) / float64(s.RequestCount)
+		s.AvgLatencyMs = float64(totalLatency[s.Provider+"/"+s.Model]) / float64(s.RequestCount)
+	}
+	return result
+}
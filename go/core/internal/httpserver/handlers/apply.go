@@ -0,0 +1,339 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	"github.com/kagent-dev/kagent/go/core/internal/httpserver/errors"
+	common "github.com/kagent-dev/kagent/go/core/internal/utils"
+	"github.com/kagent-dev/kagent/go/core/pkg/auth"
+	"github.com/kagent-dev/kmcp/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// maxApplyItems bounds the size of one apply request.
+const maxApplyItems = 500
+
+// ApplyHandler creates or updates batches of Agents, ModelConfigs and tool
+// servers in one request
+type ApplyHandler struct {
+	*Base
+}
+
+// NewApplyHandler creates a new ApplyHandler
+func NewApplyHandler(base *Base) *ApplyHandler {
+	return &ApplyHandler{Base: base}
+}
+
+// applyKind is a resource kind the apply endpoint accepts.
+type applyKind struct {
+	gvk schema.GroupVersionKind
+	// resource is the authorization resource type.
+	resource  string
+	newObject func() client.Object
+	// rank orders the writes so that referenced resources are written before
+	// the resources that reference them.
+	rank int
+}
+
+var applyKinds = map[string]applyKind{
+	"ModelConfig": {
+		gvk:       modelConfigGVK,
+		resource:  "ModelConfig",
+		newObject: func() client.Object { return &v1alpha2.ModelConfig{} },
+	},
+	"RemoteMCPServer": {
+		gvk:       remoteMCPServerGVK,
+		resource:  "ToolServer",
+		newObject: func() client.Object { return &v1alpha2.RemoteMCPServer{} },
+		rank:      1,
+	},
+	"MCPServer": {
+		gvk:       mcpServerGVK,
+		resource:  "ToolServer",
+		newObject: func() client.Object { return &v1alpha1.MCPServer{} },
+		rank:      1,
+	},
+	"Agent": {
+		gvk:       v1alpha2.GroupVersion.WithKind("Agent"),
+		resource:  "Agent",
+		newObject: func() client.Object { return &v1alpha2.Agent{} },
+		rank:      2,
+	},
+}
+
+// applyItem is one decoded item of an apply request.
+type applyItem struct {
+	kind applyKind
+	// obj is the desired state: the manifest, or the existing object with the
+	// manifest's spec, labels and annotations when it already exists.
+	obj    client.Object
+	result *api.ApplyResult
+}
+
+// HandleApply handles POST /api/apply requests. Every item is validated before
+// anything is written: its kind and metadata, the caller's permission, the
+// kind-specific checks of the single-resource endpoints and a server-side dry
+// run. Agents are validated together with the other items, so an Agent may
+// reference a ModelConfig or tool server created in the same request. When any
+// item is invalid nothing is applied. Items are then written ModelConfigs
+// first, then tool servers, then Agents; a write failure is reported on its
+// item and does not stop the others. The response is 207 Multi-Status when
+// any item is invalid or failed.
+func (h *ApplyHandler) HandleApply(w ErrorResponseWriter, r *http.Request) {
+	log := ctrllog.FromContext(r.Context()).WithName("apply-handler").WithValues("operation", "apply")
+
+	var req api.ApplyRequest
+	if err := DecodeJSONBody(r, &req); err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Invalid request body", err).WithCode(api.ErrorCodeInvalidRequestBody))
+		return
+	}
+	if len(req.Items) == 0 {
+		w.RespondWithError(errors.NewBadRequestError("items is required", nil))
+		return
+	}
+	if len(req.Items) > maxApplyItems {
+		w.RespondWithError(errors.NewBadRequestError(fmt.Sprintf("at most %d items can be applied at once", maxApplyItems), nil))
+		return
+	}
+	principal, err := GetPrincipal(r)
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get user ID", err))
+		return
+	}
+	ctx := r.Context()
+
+	resp := api.ApplyResponse{Results: make([]api.ApplyResult, len(req.Items)), DryRun: req.DryRun}
+	items := make([]*applyItem, len(req.Items))
+	seen := make(map[api.ApplyResult]int)
+	for i, raw := range req.Items {
+		item, err := decodeApplyItem(raw, &resp.Results[i])
+		if err == nil {
+			key := api.ApplyResult{Kind: resp.Results[i].Kind, Namespace: resp.Results[i].Namespace, Name: resp.Results[i].Name}
+			if j, ok := seen[key]; ok {
+				err = fmt.Errorf("duplicate of item %d", j)
+			} else {
+				seen[key] = i
+			}
+		}
+		if err != nil {
+			resp.Results[i].Action = api.ApplyActionInvalid
+			resp.Results[i].Error = err.Error()
+			continue
+		}
+		items[i] = item
+	}
+
+	// Objects of this request that Agents may reference.
+	var related []client.Object
+	for _, item := range items {
+		if item != nil && item.kind.resource != "Agent" {
+			related = append(related, item.obj)
+		}
+	}
+
+	invalid := 0
+	for i, item := range items {
+		if item == nil {
+			invalid++
+			continue
+		}
+		if err := h.validateApplyItem(ctx, principal, item, related); err != nil {
+			item.result.Action = api.ApplyActionInvalid
+			item.result.Error = err.Error()
+			items[i] = nil
+			invalid++
+		}
+	}
+	if invalid > 0 {
+		for _, item := range items {
+			if item != nil {
+				item.result.Action = api.ApplyActionSkipped
+			}
+		}
+		log.Info("Rejected apply request with invalid items", "items", len(items), "invalid", invalid)
+		RespondWithJSON(w, http.StatusMultiStatus, api.NewResponse(resp, fmt.Sprintf("%d of %d items are invalid; nothing was applied", invalid, len(items)), true))
+		return
+	}
+	if req.DryRun {
+		RespondWithJSON(w, http.StatusOK, api.NewResponse(resp, fmt.Sprintf("Validated %d items; nothing was applied (dry run)", len(items)), false))
+		return
+	}
+
+	ordered := slices.Clone(items)
+	slices.SortStableFunc(ordered, func(a, b *applyItem) int { return a.kind.rank - b.kind.rank })
+	failed := 0
+	for _, item := range ordered {
+		var err error
+		switch item.result.Action {
+		case api.ApplyActionCreated:
+			err = h.KubeClient.Create(ctx, item.obj)
+		case api.ApplyActionUpdated:
+			err = h.KubeClient.Update(ctx, item.obj)
+		}
+		if err != nil {
+			item.result.Action = api.ApplyActionFailed
+			item.result.Error = err.Error()
+			failed++
+			log.Error(err, "Failed to apply item", "kind", item.result.Kind, "namespace", item.result.Namespace, "name", item.result.Name)
+		}
+	}
+
+	log.Info("Applied items", "items", len(items), "failed", failed)
+	if failed > 0 {
+		RespondWithJSON(w, http.StatusMultiStatus, api.NewResponse(resp, fmt.Sprintf("%d of %d items failed to apply", failed, len(items)), true))
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, api.NewResponse(resp, fmt.Sprintf("Applied %d items", len(items)), false))
+}
+
+// decodeApplyItem decodes a manifest and fills in result's kind and object
+// reference. The namespace defaults to the controller's namespace.
+func decodeApplyItem(raw json.RawMessage, result *api.ApplyResult) (*applyItem, error) {
+	var header struct {
+		APIVersion string `json:"apiVersion"`
+		Kind       string `json:"kind"`
+	}
+	if err := json.Unmarshal(raw, &header); err != nil {
+		return nil, fmt.Errorf("invalid manifest: %w", err)
+	}
+	result.Kind = header.Kind
+	kind, ok := applyKinds[header.Kind]
+	if !ok {
+		return nil, fmt.Errorf("unsupported kind %q: must be one of Agent, ModelConfig, RemoteMCPServer or MCPServer", header.Kind)
+	}
+	if header.APIVersion != "" && header.APIVersion != kind.gvk.GroupVersion().String() {
+		return nil, fmt.Errorf("unsupported apiVersion %q for %s: must be %s", header.APIVersion, header.Kind, kind.gvk.GroupVersion())
+	}
+
+	obj := kind.newObject()
+	if err := json.Unmarshal(raw, obj); err != nil {
+		return nil, fmt.Errorf("invalid %s manifest: %w", header.Kind, err)
+	}
+	obj.GetObjectKind().SetGroupVersionKind(kind.gvk)
+	if obj.GetNamespace() == "" {
+		obj.SetNamespace(common.GetResourceNamespace())
+	}
+	result.Namespace = obj.GetNamespace()
+	result.Name = obj.GetName()
+	if _, err := common.ParseRefString(obj.GetName(), obj.GetNamespace()); err != nil {
+		return nil, fmt.Errorf("invalid metadata: %w", err)
+	}
+	// Server-managed fields are taken from the existing object, if any.
+	obj.SetResourceVersion("")
+	obj.SetUID("")
+	return &applyItem{kind: kind, obj: obj, result: result}, nil
+}
+
+// validateApplyItem checks that principal may write the item and that the
+// item is valid, and sets its action to created, updated or unchanged.
+func (h *ApplyHandler) validateApplyItem(ctx context.Context, principal auth.Principal, item *applyItem, related []client.Object) error {
+	existing := item.kind.newObject()
+	err := h.KubeClient.Get(ctx, client.ObjectKeyFromObject(item.obj), existing)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get existing %s: %w", item.result.Kind, err)
+	}
+	exists := err == nil
+
+	verb := auth.VerbCreate
+	if exists {
+		verb = auth.VerbUpdate
+	}
+	resource := auth.Resource{Type: item.kind.resource, Name: client.ObjectKeyFromObject(item.obj).String()}
+	if err := h.Authorizer.Check(ctx, principal, verb, resource); err != nil {
+		return fmt.Errorf("not authorized: %w", err)
+	}
+
+	switch obj := item.obj.(type) {
+	case *v1alpha2.ModelConfig:
+		if err := validateAPIKeySecretRef(obj.Spec.APIKeySecret, obj.Spec.APIKeySecretKey, obj.Spec.Provider); err != nil {
+			return err
+		}
+	case *v1alpha2.Agent:
+		agents := &AgentsHandler{Base: h.Base}
+		if err := agents.validateAgentObject(ctx, obj, related...); err != nil {
+			return err
+		}
+	}
+
+	if !exists {
+		item.result.Action = api.ApplyActionCreated
+		return h.KubeClient.Create(ctx, item.obj.DeepCopyObject().(client.Object), client.DryRunAll)
+	}
+
+	desired := existing.DeepCopyObject().(client.Object)
+	desired.GetObjectKind().SetGroupVersionKind(item.kind.gvk)
+	copyApplySpec(desired, item.obj)
+	desired.SetLabels(mergeStringMaps(existing.GetLabels(), item.obj.GetLabels()))
+	desired.SetAnnotations(mergeStringMaps(existing.GetAnnotations(), item.obj.GetAnnotations()))
+	item.obj = desired
+
+	// The dry run applies server-side defaults, so a manifest that leaves
+	// defaulted fields out still compares equal to the existing object.
+	preview := desired.DeepCopyObject().(client.Object)
+	if err := h.KubeClient.Update(ctx, preview, client.DryRunAll); err != nil {
+		return err
+	}
+	if applyEqual(existing, preview) {
+		item.result.Action = api.ApplyActionUnchanged
+	} else {
+		item.result.Action = api.ApplyActionUpdated
+	}
+	return nil
+}
+
+// copyApplySpec sets dst's spec to src's. Both are of the same applyKinds type.
+func copyApplySpec(dst, src client.Object) {
+	switch dst := dst.(type) {
+	case *v1alpha2.Agent:
+		dst.Spec = *src.(*v1alpha2.Agent).Spec.DeepCopy()
+	case *v1alpha2.ModelConfig:
+		dst.Spec = *src.(*v1alpha2.ModelConfig).Spec.DeepCopy()
+	case *v1alpha2.RemoteMCPServer:
+		dst.Spec = *src.(*v1alpha2.RemoteMCPServer).Spec.DeepCopy()
+	case *v1alpha1.MCPServer:
+		dst.Spec = *src.(*v1alpha1.MCPServer).Spec.DeepCopy()
+	}
+}
+
+// applyEqual reports whether a and b have the same spec, labels and annotations.
+func applyEqual(a, b client.Object) bool {
+	if !equality.Semantic.DeepEqual(a.GetLabels(), b.GetLabels()) || !equality.Semantic.DeepEqual(a.GetAnnotations(), b.GetAnnotations()) {
+		return false
+	}
+	switch a := a.(type) {
+	case *v1alpha2.Agent:
+		return equality.Semantic.DeepEqual(a.Spec, b.(*v1alpha2.Agent).Spec)
+	case *v1alpha2.ModelConfig:
+		return equality.Semantic.DeepEqual(a.Spec, b.(*v1alpha2.ModelConfig).Spec)
+	case *v1alpha2.RemoteMCPServer:
+		return equality.Semantic.DeepEqual(a.Spec, b.(*v1alpha2.RemoteMCPServer).Spec)
+	case *v1alpha1.MCPServer:
+		return equality.Semantic.DeepEqual(a.Spec, b.(*v1alpha1.MCPServer).Spec)
+	}
+	return false
+}
+
+// mergeStringMaps returns base with the entries of override added, or nil
+// when both are empty.
+func mergeStringMaps(base, override map[string]string) map[string]string {
+	if len(base) == 0 && len(override) == 0 {
+		return nil
+	}
+	merged := maps.Clone(base)
+	if merged == nil {
+		merged = make(map[string]string, len(override))
+	}
+	maps.Copy(merged, override)
+	return merged
+}
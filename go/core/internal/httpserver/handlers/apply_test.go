@@ -0,0 +1,143 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	"github.com/kagent-dev/kagent/go/core/internal/httpserver/auth"
+	"github.com/kagent-dev/kagent/go/core/internal/httpserver/handlers"
+)
+
+func TestHandleApply(t *testing.T) {
+	withRuntimeImageDigests(t)
+
+	manifest := func(t *testing.T, kind string, obj client.Object) json.RawMessage {
+		t.Helper()
+		obj.GetObjectKind().SetGroupVersionKind(v1alpha2.GroupVersion.WithKind(kind))
+		raw, err := json.Marshal(obj)
+		require.NoError(t, err)
+		return raw
+	}
+
+	apply := func(t *testing.T, kubeClient client.Client, req api.ApplyRequest) (int, api.StandardResponse[api.ApplyResponse]) {
+		t.Helper()
+		handler := handlers.NewApplyHandler(&handlers.Base{
+			KubeClient:         kubeClient,
+			DefaultModelConfig: types.NamespacedName{Name: "test-model-config", Namespace: "default"},
+			Authorizer:         &auth.NoopAuthorizer{},
+		})
+		body, _ := json.Marshal(req)
+		r := setUser(httptest.NewRequest(http.MethodPost, "/api/apply", bytes.NewReader(body)), "test-user")
+		w := httptest.NewRecorder()
+		handler.HandleApply(&testErrorResponseWriter{w}, r)
+
+		var response api.StandardResponse[api.ApplyResponse]
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response), w.Body.String())
+		return w.Code, response
+	}
+
+	actions := func(resp api.ApplyResponse) []string {
+		var got []string
+		for _, r := range resp.Results {
+			got = append(got, r.Kind+"/"+r.Name+"="+r.Action)
+		}
+		return got
+	}
+
+	newAgent := func(name string, modelConfig *v1alpha2.ModelConfig) *v1alpha2.Agent {
+		agent := createTestAgent(name, modelConfig)
+		agent.Spec.Declarative.SystemMessage = "You are a helpful agent."
+		return agent
+	}
+
+	exists := func(t *testing.T, kubeClient client.Client, obj client.Object, name string) bool {
+		t.Helper()
+		err := kubeClient.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: name}, obj)
+		if apierrors.IsNotFound(err) {
+			return false
+		}
+		require.NoError(t, err)
+		return true
+	}
+
+	t.Run("creates an agent together with its model config", func(t *testing.T) {
+		modelConfig := createTestModelConfig()
+		agent := newAgent("new-agent", modelConfig)
+		kubeClient := fake.NewClientBuilder().WithScheme(setupScheme()).Build()
+
+		code, resp := apply(t, kubeClient, api.ApplyRequest{Items: []json.RawMessage{
+			manifest(t, "Agent", agent),
+			manifest(t, "ModelConfig", modelConfig),
+		}})
+		require.Equal(t, http.StatusOK, code, resp.Data.Results)
+		require.Equal(t, []string{"Agent/new-agent=created", "ModelConfig/test-model-config=created"}, actions(resp.Data))
+		require.True(t, exists(t, kubeClient, &v1alpha2.Agent{}, "new-agent"))
+		require.True(t, exists(t, kubeClient, &v1alpha2.ModelConfig{}, "test-model-config"))
+	})
+
+	t.Run("dry run applies nothing", func(t *testing.T) {
+		modelConfig := createTestModelConfig()
+		kubeClient := fake.NewClientBuilder().WithScheme(setupScheme()).Build()
+
+		code, resp := apply(t, kubeClient, api.ApplyRequest{DryRun: true, Items: []json.RawMessage{manifest(t, "ModelConfig", modelConfig)}})
+		require.Equal(t, http.StatusOK, code, resp.Data.Results)
+		require.True(t, resp.Data.DryRun)
+		require.Equal(t, []string{"ModelConfig/test-model-config=created"}, actions(resp.Data))
+		require.False(t, exists(t, kubeClient, &v1alpha2.ModelConfig{}, "test-model-config"))
+	})
+
+	t.Run("reports unchanged and updated items", func(t *testing.T) {
+		modelConfig := createTestModelConfig()
+		agent := newAgent("existing-agent", modelConfig)
+		kubeClient := fake.NewClientBuilder().WithScheme(setupScheme()).WithObjects(modelConfig, agent).Build()
+
+		changed := newAgent("existing-agent", modelConfig)
+		changed.Spec.Description = "Updated"
+		code, resp := apply(t, kubeClient, api.ApplyRequest{Items: []json.RawMessage{
+			manifest(t, "ModelConfig", createTestModelConfig()),
+			manifest(t, "Agent", changed),
+		}})
+		require.Equal(t, http.StatusOK, code, resp.Data.Results)
+		require.Equal(t, []string{"ModelConfig/test-model-config=unchanged", "Agent/existing-agent=updated"}, actions(resp.Data))
+
+		updated := &v1alpha2.Agent{}
+		require.True(t, exists(t, kubeClient, updated, "existing-agent"))
+		require.Equal(t, "Updated", updated.Spec.Description)
+	})
+
+	t.Run("invalid item rejects the whole batch", func(t *testing.T) {
+		modelConfig := createTestModelConfig()
+		agent := newAgent("orphan-agent", &v1alpha2.ModelConfig{})
+		agent.Spec.Declarative.ModelConfig = "missing-model-config"
+		kubeClient := fake.NewClientBuilder().WithScheme(setupScheme()).Build()
+
+		code, resp := apply(t, kubeClient, api.ApplyRequest{Items: []json.RawMessage{
+			manifest(t, "ModelConfig", modelConfig),
+			manifest(t, "Agent", agent),
+			json.RawMessage(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm"}}`),
+			manifest(t, "ModelConfig", createTestModelConfig()),
+		}})
+		require.Equal(t, http.StatusMultiStatus, code)
+		require.True(t, resp.Error)
+		require.Equal(t, []string{
+			"ModelConfig/test-model-config=skipped",
+			"Agent/orphan-agent=invalid",
+			"ConfigMap/=invalid",
+			"ModelConfig/test-model-config=invalid",
+		}, actions(resp.Data))
+		require.Contains(t, resp.Data.Results[3].Error, "duplicate of item 0")
+		require.False(t, exists(t, kubeClient, &v1alpha2.ModelConfig{}, "test-model-config"))
+	})
+}
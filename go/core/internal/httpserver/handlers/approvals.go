@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"net/http"
+
+	a2a "github.com/a2aproject/a2a-go/v2/a2a"
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
+	"github.com/kagent-dev/kagent/go/core/internal/httpserver/errors"
+	"github.com/kagent-dev/kagent/go/core/internal/utils"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// requestConfirmationName is the function call name of the ADK confirmation
+// request that holds a tool call for approval.
+const requestConfirmationName = "adk_request_confirmation"
+
+// HandleListSessionApprovals handles GET /api/sessions/{session_id}/approvals,
+// listing the tool calls of the session's input-required tasks that are
+// waiting for approval.
+func (h *SessionsHandler) HandleListSessionApprovals(w ErrorResponseWriter, r *http.Request) {
+	log := ctrllog.FromContext(r.Context()).WithName("sessions-handler").WithValues("operation", "list-approvals")
+
+	sessionID, err := GetPathParam(r, "session_id")
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get session ID from path", err))
+		return
+	}
+	log = log.WithValues("session_id", sessionID)
+
+	userID, _, apiErr := h.sessionReader(r, sessionID)
+	if apiErr != nil {
+		w.RespondWithError(apiErr)
+		return
+	}
+
+	session, err := h.DatabaseService.GetSession(r.Context(), sessionID, userID)
+	if err != nil {
+		w.RespondWithError(errors.NewNotFoundError("Session not found for given ID", err).WithCode(api.ErrorCodeSessionNotFound))
+		return
+	}
+	tasks, err := h.DatabaseService.ListTasksForSession(r.Context(), sessionID, userID)
+	if err != nil {
+		w.RespondWithError(errors.NewInternalServerError("Failed to get session tasks", err))
+		return
+	}
+
+	var agentRef string
+	if session.AgentID != nil {
+		agentRef = utils.ConvertToKubernetesIdentifier(*session.AgentID)
+	}
+	approvals := []api.ToolApproval{}
+	for _, task := range tasks {
+		for _, approval := range pendingToolApprovals(task) {
+			approval.Agent = agentRef
+			approvals = append(approvals, approval)
+		}
+	}
+
+	log.Info("Successfully listed session approvals", "count", len(approvals))
+	RespondWithJSON(w, http.StatusOK, api.NewResponse(approvals, "Successfully listed session approvals", false))
+}
+
+// pendingToolApprovals returns the confirmation requests in the status
+// message of an input-required task.
+func pendingToolApprovals(task *a2a.Task) []api.ToolApproval {
+	if task == nil || task.Status.State != a2a.TaskStateInputRequired || task.Status.Message == nil {
+		return nil
+	}
+	var approvals []api.ToolApproval
+	for _, part := range task.Status.Message.Parts {
+		data, ok := part.Data().(map[string]any)
+		if !ok || !isLongRunningFunctionCall(part.Metadata) {
+			continue
+		}
+		if name, _ := data["name"].(string); name != requestConfirmationName {
+			continue
+		}
+		args, _ := data["args"].(map[string]any)
+		call, _ := args["originalFunctionCall"].(map[string]any)
+		if call == nil {
+			continue
+		}
+		approval := api.ToolApproval{
+			TaskID:    string(task.ID),
+			ContextID: task.ContextID,
+		}
+		approval.ToolCallID, _ = call["id"].(string)
+		approval.ToolName, _ = call["name"].(string)
+		approval.Args, _ = call["args"].(map[string]any)
+		if confirmation, ok := args["toolConfirmation"].(map[string]any); ok {
+			approval.Hint, _ = confirmation["hint"].(string)
+		}
+		approvals = append(approvals, approval)
+	}
+	return approvals
+}
+
+// isLongRunningFunctionCall reports whether part metadata marks a long-running
+// function call. The runtimes write the keys with an adk_ or kagent_ prefix.
+func isLongRunningFunctionCall(metadata map[string]any) bool {
+	value := func(key string) any {
+		if v, ok := metadata["adk_"+key]; ok {
+			return v
+		}
+		return metadata["kagent_"+key]
+	}
+	return value("type") == "function_call" && value("is_long_running") == true
+}
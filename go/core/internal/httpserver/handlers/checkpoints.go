@@ -81,7 +81,7 @@ func (h *CheckpointsHandler) HandlePutCheckpoint(w ErrorResponseWriter, r *http.
 
 	var req KAgentCheckpointPayload
 	if err := DecodeJSONBody(r, &req); err != nil {
-		w.RespondWithError(errors.NewBadRequestError("Invalid request body", err))
+		w.RespondWithError(errors.NewBadRequestError("Invalid request body", err).WithCode(api.ErrorCodeInvalidRequestBody))
 		return
 	}
 
@@ -214,7 +214,7 @@ func (h *CheckpointsHandler) HandlePutWrites(w ErrorResponseWriter, r *http.Requ
 
 	var req KAgentCheckpointWritePayload
 	if err := DecodeJSONBody(r, &req); err != nil {
-		w.RespondWithError(errors.NewBadRequestError("Invalid request body", err))
+		w.RespondWithError(errors.NewBadRequestError("Invalid request body", err).WithCode(api.ErrorCodeInvalidRequestBody))
 		return
 	}
 
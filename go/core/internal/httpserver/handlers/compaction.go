@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+
+	"github.com/kagent-dev/kagent/go/api/database"
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
+	"github.com/kagent-dev/kagent/go/core/internal/compaction"
+	"github.com/kagent-dev/kagent/go/core/internal/httpserver/errors"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// SessionCompactor summarizes long session histories.
+type SessionCompactor interface {
+	Status(ctx context.Context, session *database.Session) (*compaction.Status, error)
+	Compact(ctx context.Context, session *database.Session) (*compaction.Result, error)
+}
+
+// CompactionHandler triggers and reports on session history compaction
+type CompactionHandler struct {
+	*Base
+	compactor SessionCompactor
+}
+
+// NewCompactionHandler creates a new CompactionHandler
+func NewCompactionHandler(base *Base, compactor SessionCompactor) *CompactionHandler {
+	return &CompactionHandler{Base: base, compactor: compactor}
+}
+
+// HandleGetSessionCompaction handles GET /api/sessions/{session_id}/compaction
+func (h *CompactionHandler) HandleGetSessionCompaction(w ErrorResponseWriter, r *http.Request) {
+	log := ctrllog.FromContext(r.Context()).WithName("compaction-handler").WithValues("operation", "get-status")
+
+	session, ok := h.getSession(w, r)
+	if !ok {
+		return
+	}
+	log = log.WithValues("session_id", session.ID)
+
+	status, err := h.compactor.Status(r.Context(), session)
+	if err != nil {
+		w.RespondWithError(compactionError("Failed to get session compaction status", err))
+		return
+	}
+
+	log.V(1).Info("Retrieved session compaction status", "estimatedTokens", status.EstimatedTokens)
+	RespondWithJSON(w, http.StatusOK, api.NewResponse(toSessionCompactionStatus(status), "Successfully retrieved session compaction status", false))
+}
+
+// HandleCompactSession handles POST /api/sessions/{session_id}/compact. The
+// summary is generated synchronously with the agent's summarizer model.
+func (h *CompactionHandler) HandleCompactSession(w ErrorResponseWriter, r *http.Request) {
+	log := ctrllog.FromContext(r.Context()).WithName("compaction-handler").WithValues("operation", "compact")
+
+	session, ok := h.getSession(w, r)
+	if !ok {
+		return
+	}
+	log = log.WithValues("session_id", session.ID)
+
+	result, err := h.compactor.Compact(r.Context(), session)
+	if err != nil {
+		w.RespondWithError(compactionError("Failed to compact session", err))
+		return
+	}
+
+	msg := "Session compacted successfully"
+	if !result.Compacted {
+		msg = "No events outside the retention window, nothing to compact"
+	}
+	log.Info(msg, "compactedEvents", result.CompactedEvents)
+	RespondWithJSON(w, http.StatusOK, api.NewResponse(api.CompactSessionResponse{
+		SessionCompactionStatus: toSessionCompactionStatus(&result.Status),
+		Compacted:               result.Compacted,
+		CompactedEvents:         result.CompactedEvents,
+		Summary:                 result.Summary,
+	}, msg, false))
+}
+
+// getSession loads the session named in the path for the requesting user.
+func (h *CompactionHandler) getSession(w ErrorResponseWriter, r *http.Request) (*database.Session, bool) {
+	sessionID, err := GetPathParam(r, "session_id")
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get session ID from path", err))
+		return nil, false
+	}
+	userID, err := getEffectiveUserIDForSession(r, sessionID)
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get user ID", err))
+		return nil, false
+	}
+	session, err := h.DatabaseService.GetSession(r.Context(), sessionID, userID)
+	if err != nil {
+		w.RespondWithError(errors.NewNotFoundError("Session not found", err).WithCode(api.ErrorCodeSessionNotFound))
+		return nil, false
+	}
+	return session, true
+}
+
+func compactionError(message string, err error) error {
+	switch {
+	case stderrors.Is(err, compaction.ErrNoAgent),
+		stderrors.Is(err, compaction.ErrNotConfigured),
+		stderrors.Is(err, compaction.ErrUnsupportedRuntime):
+		return errors.NewBadRequestError(err.Error(), err)
+	default:
+		return errors.NewInternalServerError(message, err)
+	}
+}
+
+func toSessionCompactionStatus(s *compaction.Status) api.SessionCompactionStatus {
+	return api.SessionCompactionStatus{
+		SessionID:       s.SessionID,
+		EstimatedTokens: s.EstimatedTokens,
+		TokenThreshold:  s.TokenThreshold,
+		PendingEvents:   s.PendingEvents,
+		Compactions:     s.Compactions,
+		LastCompactedAt: s.LastCompactedAt,
+	}
+}
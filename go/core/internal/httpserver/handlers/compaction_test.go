@@ -0,0 +1,104 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kagent-dev/kagent/go/api/database"
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	"github.com/kagent-dev/kagent/go/core/internal/compaction"
+	"github.com/kagent-dev/kagent/go/core/internal/httpserver/auth"
+	"github.com/kagent-dev/kagent/go/core/internal/httpserver/handlers"
+)
+
+type fakeCompactor struct {
+	err error
+}
+
+func (f *fakeCompactor) Status(_ context.Context, session *database.Session) (*compaction.Status, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &compaction.Status{SessionID: session.ID, EstimatedTokens: 1200, PendingEvents: 12, TokenThreshold: 1000}, nil
+}
+
+func (f *fakeCompactor) Compact(_ context.Context, session *database.Session) (*compaction.Result, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &compaction.Result{
+		Status:          compaction.Status{SessionID: session.ID, EstimatedTokens: 300, PendingEvents: 10, Compactions: 1},
+		Compacted:       true,
+		CompactedEvents: 2,
+		Summary:         "the summary",
+	}, nil
+}
+
+func TestCompactionHandler(t *testing.T) {
+	setup := func(t *testing.T, compactor handlers.SessionCompactor) *handlers.CompactionHandler {
+		t.Helper()
+		dbClient := setupTestDBClient(t)
+		agentID := "default__NS__test_agent"
+		require.NoError(t, dbClient.StoreAgent(context.Background(), &database.Agent{ID: agentID, WorkloadType: v1alpha2.WorkloadModeDeployment}))
+		require.NoError(t, dbClient.StoreSession(context.Background(), &database.Session{ID: "s1", UserID: "test-user", AgentID: &agentID}))
+		return handlers.NewCompactionHandler(&handlers.Base{
+			KubeClient:      fake.NewClientBuilder().WithScheme(setupScheme()).Build(),
+			DatabaseService: dbClient,
+			Authorizer:      &auth.NoopAuthorizer{},
+		}, compactor)
+	}
+
+	newRequest := func(method, path, sessionID string) *http.Request {
+		req := httptest.NewRequest(method, path, nil)
+		req = mux.SetURLVars(req, map[string]string{"session_id": sessionID})
+		return setUser(req, "test-user")
+	}
+
+	t.Run("compact", func(t *testing.T) {
+		handler := setup(t, &fakeCompactor{})
+		w := httptest.NewRecorder()
+		handler.HandleCompactSession(&testErrorResponseWriter{w}, newRequest(http.MethodPost, "/api/sessions/s1/compact", "s1"))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var response api.StandardResponse[api.CompactSessionResponse]
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.True(t, response.Data.Compacted)
+		require.Equal(t, 2, response.Data.CompactedEvents)
+		require.Equal(t, 1, response.Data.Compactions)
+		require.Equal(t, "s1", response.Data.SessionID)
+	})
+
+	t.Run("status", func(t *testing.T) {
+		handler := setup(t, &fakeCompactor{})
+		w := httptest.NewRecorder()
+		handler.HandleGetSessionCompaction(&testErrorResponseWriter{w}, newRequest(http.MethodGet, "/api/sessions/s1/compaction", "s1"))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var response api.StandardResponse[api.SessionCompactionStatus]
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Equal(t, 1200, response.Data.EstimatedTokens)
+		require.Equal(t, 1000, response.Data.TokenThreshold)
+	})
+
+	t.Run("not configured is a bad request", func(t *testing.T) {
+		handler := setup(t, &fakeCompactor{err: compaction.ErrNotConfigured})
+		w := httptest.NewRecorder()
+		handler.HandleCompactSession(&testErrorResponseWriter{w}, newRequest(http.MethodPost, "/api/sessions/s1/compact", "s1"))
+		require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+	})
+
+	t.Run("unknown session", func(t *testing.T) {
+		handler := setup(t, &fakeCompactor{})
+		w := httptest.NewRecorder()
+		handler.HandleCompactSession(&testErrorResponseWriter{w}, newRequest(http.MethodPost, "/api/sessions/missing/compact", "missing"))
+		require.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+	})
+}
@@ -60,7 +60,7 @@ func (h *CrewAIHandler) HandleStoreMemory(w ErrorResponseWriter, r *http.Request
 
 	var req KagentMemoryPayload
 	if err := DecodeJSONBody(r, &req); err != nil {
-		w.RespondWithError(errors.NewBadRequestError("Invalid request body", err))
+		w.RespondWithError(errors.NewBadRequestError("Invalid request body", err).WithCode(api.ErrorCodeInvalidRequestBody))
 		return
 	}
 
@@ -206,7 +206,7 @@ func (h *CrewAIHandler) HandleStoreFlowState(w ErrorResponseWriter, r *http.Requ
 
 	var req KagentFlowStatePayload
 	if err := DecodeJSONBody(r, &req); err != nil {
-		w.RespondWithError(errors.NewBadRequestError("Invalid request body", err))
+		w.RespondWithError(errors.NewBadRequestError("Invalid request body", err).WithCode(api.ErrorCodeInvalidRequestBody))
 		return
 	}
 
@@ -0,0 +1,187 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	"github.com/kagent-dev/kagent/go/core/internal/debugcapture"
+	"github.com/kagent-dev/kagent/go/core/internal/httpserver/errors"
+	"github.com/kagent-dev/kagent/go/core/pkg/auth"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const debugCaptureAgentKind = "Agent"
+
+// DebugHandler starts and downloads object-scoped debug captures
+type DebugHandler struct {
+	*Base
+}
+
+// NewDebugHandler creates a new DebugHandler
+func NewDebugHandler(base *Base) *DebugHandler {
+	return &DebugHandler{Base: base}
+}
+
+// HandleStartAgentCapture handles POST /api/debug/agents/{namespace}/{name}/capture.
+// It clears the agent's previous capture and sets the capture annotation, which
+// triggers a reconcile that is recorded along with every later one until the
+// window closes.
+func (h *DebugHandler) HandleStartAgentCapture(w ErrorResponseWriter, r *http.Request) {
+	log := ctrllog.FromContext(r.Context()).WithName("debug-handler").WithValues("operation", "start-agent-capture")
+
+	agentRef, ok := h.agentRef(w, r)
+	if !ok {
+		return
+	}
+	log = log.WithValues("agent", agentRef.String())
+
+	var req api.StartDebugCaptureRequest
+	if err := DecodeJSONBody(r, &req); err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Invalid request body", err).WithCode(api.ErrorCodeInvalidRequestBody))
+		return
+	}
+	duration, err := parseDebugCaptureDuration(req.Duration)
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Invalid capture duration", err))
+		return
+	}
+
+	agent, ok := h.getAgent(w, r, log, agentRef)
+	if !ok {
+		return
+	}
+
+	if err := h.DatabaseService.DeleteDebugCaptureEntries(r.Context(), debugCaptureAgentKind, agentRef.Namespace, agentRef.Name); err != nil {
+		log.Error(err, "Failed to clear previous debug capture")
+		w.RespondWithError(errors.NewInternalServerError("Failed to clear previous debug capture", err))
+		return
+	}
+
+	until := time.Now().UTC().Add(duration).Truncate(time.Second)
+	patch := client.MergeFrom(agent.DeepCopy())
+	if agent.Annotations == nil {
+		agent.Annotations = map[string]string{}
+	}
+	agent.Annotations[v1alpha2.AgentDebugCaptureUntilAnnotation] = until.Format(time.RFC3339)
+	if err := h.KubeClient.Patch(r.Context(), agent, patch); err != nil {
+		log.Error(err, "Failed to set debug capture annotation")
+		w.RespondWithError(errors.NewInternalServerError("Failed to start debug capture", err))
+		return
+	}
+
+	log.Info("Started debug capture", "until", until)
+	data := api.NewResponse(h.captureResponse(agentRef, agent, nil), "Successfully started debug capture", false)
+	RespondWithJSON(w, http.StatusAccepted, data)
+}
+
+// HandleGetAgentCapture handles GET /api/debug/agents/{namespace}/{name}/capture
+// and returns the entries recorded for the agent's current or most recent
+// capture.
+func (h *DebugHandler) HandleGetAgentCapture(w ErrorResponseWriter, r *http.Request) {
+	log := ctrllog.FromContext(r.Context()).WithName("debug-handler").WithValues("operation", "get-agent-capture")
+
+	agentRef, ok := h.agentRef(w, r)
+	if !ok {
+		return
+	}
+	log = log.WithValues("agent", agentRef.String())
+
+	agent, ok := h.getAgent(w, r, log, agentRef)
+	if !ok {
+		return
+	}
+	captureID := agent.Annotations[v1alpha2.AgentDebugCaptureUntilAnnotation]
+	if captureID == "" {
+		w.RespondWithError(errors.NewNotFoundError("No debug capture has been started for this agent", nil))
+		return
+	}
+
+	entries, err := h.DatabaseService.ListDebugCaptureEntries(r.Context(), debugCaptureAgentKind, agentRef.Namespace, agentRef.Name, captureID)
+	if err != nil {
+		log.Error(err, "Failed to list debug capture entries")
+		w.RespondWithError(errors.NewInternalServerError("Failed to list debug capture entries", err))
+		return
+	}
+
+	resp := h.captureResponse(agentRef, agent, make([]api.DebugCaptureEntry, 0, len(entries)))
+	for _, e := range entries {
+		entry := api.DebugCaptureEntry{Stage: e.Stage, Message: e.Message, CreatedAt: e.CreatedAt}
+		if e.Data != "" {
+			entry.Data = json.RawMessage(e.Data)
+		}
+		resp.Entries = append(resp.Entries, entry)
+	}
+
+	log.Info("Successfully retrieved debug capture", "entries", len(entries))
+	RespondWithJSON(w, http.StatusOK, api.NewResponse(resp, "Successfully retrieved debug capture", false))
+}
+
+func (h *DebugHandler) agentRef(w ErrorResponseWriter, r *http.Request) (types.NamespacedName, bool) {
+	name, err := GetPathParam(r, "name")
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get name from path", err))
+		return types.NamespacedName{}, false
+	}
+	namespace, err := GetPathParam(r, "namespace")
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get namespace from path", err))
+		return types.NamespacedName{}, false
+	}
+	ref := types.NamespacedName{Namespace: namespace, Name: name}
+	if err := Check(h.Authorizer, r, auth.Resource{Type: "Agent", Name: ref.String()}); err != nil {
+		w.RespondWithError(err)
+		return types.NamespacedName{}, false
+	}
+	return ref, true
+}
+
+func (h *DebugHandler) getAgent(w ErrorResponseWriter, r *http.Request, log logr.Logger, ref types.NamespacedName) (*v1alpha2.Agent, bool) {
+	agent := &v1alpha2.Agent{}
+	if err := h.KubeClient.Get(r.Context(), ref, agent); err != nil {
+		if apierrors.IsNotFound(err) {
+			w.RespondWithError(errors.NewNotFoundError("Agent not found", nil).WithCode(api.ErrorCodeAgentNotFound).WithDetail("agent", ref.String()))
+			return nil, false
+		}
+		log.Error(err, "Failed to get agent")
+		w.RespondWithError(errors.NewInternalServerError("Failed to get Agent", err))
+		return nil, false
+	}
+	return agent, true
+}
+
+func (h *DebugHandler) captureResponse(ref types.NamespacedName, agent *v1alpha2.Agent, entries []api.DebugCaptureEntry) api.DebugCaptureResponse {
+	resp := api.DebugCaptureResponse{
+		Kind:      debugCaptureAgentKind,
+		Namespace: ref.Namespace,
+		Name:      ref.Name,
+		CaptureID: agent.Annotations[v1alpha2.AgentDebugCaptureUntilAnnotation],
+		Entries:   entries,
+	}
+	if until, ok := debugcapture.Until(agent); ok {
+		resp.Until = until
+		resp.Active = time.Now().Before(until)
+	}
+	return resp
+}
+
+func parseDebugCaptureDuration(raw string) (time.Duration, error) {
+	if raw == "" {
+		return 0, fmt.Errorf("duration is required")
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse duration: %w", err)
+	}
+	if d <= 0 || d > debugcapture.MaxDuration {
+		return 0, fmt.Errorf("duration must be between 0s and %s", debugcapture.MaxDuration)
+	}
+	return d, nil
+}
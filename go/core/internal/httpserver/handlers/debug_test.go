@@ -0,0 +1,103 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kagent-dev/kagent/go/api/database"
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	"github.com/kagent-dev/kagent/go/core/internal/httpserver/auth"
+	"github.com/kagent-dev/kagent/go/core/internal/httpserver/handlers"
+)
+
+func setupDebugHandler(t *testing.T, objects ...client.Object) (*handlers.DebugHandler, client.Client) {
+	t.Helper()
+	kubeClient := fake.NewClientBuilder().WithScheme(setupScheme()).WithObjects(objects...).Build()
+	return handlers.NewDebugHandler(&handlers.Base{
+		KubeClient:      kubeClient,
+		DatabaseService: setupTestDBClient(t),
+		Authorizer:      &auth.NoopAuthorizer{},
+	}), kubeClient
+}
+
+func TestHandleStartAgentCapture(t *testing.T) {
+	tests := []struct {
+		name       string
+		duration   string
+		wantStatus int
+	}{
+		{name: "starts capture", duration: "5m", wantStatus: http.StatusAccepted},
+		{name: "rejects missing duration", duration: "", wantStatus: http.StatusBadRequest},
+		{name: "rejects duration over the limit", duration: "2h", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agent := createTestAgent("test-agent", createTestModelConfig())
+			handler, kubeClient := setupDebugHandler(t, agent)
+
+			body, _ := json.Marshal(api.StartDebugCaptureRequest{Duration: tt.duration})
+			req := httptest.NewRequest(http.MethodPost, "/api/debug/agents/default/test-agent/capture", bytes.NewReader(body))
+			req = mux.SetURLVars(req, map[string]string{"namespace": "default", "name": "test-agent"})
+			req = setUser(req, "test-user")
+			w := httptest.NewRecorder()
+
+			handler.HandleStartAgentCapture(&testErrorResponseWriter{w}, req)
+			require.Equal(t, tt.wantStatus, w.Code, w.Body.String())
+
+			updated := &v1alpha2.Agent{}
+			require.NoError(t, kubeClient.Get(context.Background(), client.ObjectKeyFromObject(agent), updated))
+			annotation := updated.Annotations[v1alpha2.AgentDebugCaptureUntilAnnotation]
+			if tt.wantStatus != http.StatusAccepted {
+				require.Empty(t, annotation)
+				return
+			}
+			until, err := time.Parse(time.RFC3339, annotation)
+			require.NoError(t, err)
+			require.WithinDuration(t, time.Now().Add(5*time.Minute), until, 5*time.Second)
+		})
+	}
+}
+
+func TestHandleGetAgentCapture(t *testing.T) {
+	until := time.Now().UTC().Add(time.Minute).Format(time.RFC3339)
+	agent := createTestAgent("test-agent", createTestModelConfig())
+	agent.Annotations = map[string]string{v1alpha2.AgentDebugCaptureUntilAnnotation: until}
+	handler, _ := setupDebugHandler(t, agent)
+
+	ctx := context.Background()
+	for _, e := range []database.DebugCaptureEntry{
+		{CaptureID: "stale", Stage: "reconcile", Message: "reconcile started"},
+		{CaptureID: until, Stage: "reconcile", Message: "reconcile started"},
+		{CaptureID: until, Stage: "translate", Message: "built manifest", Data: `{"replicas":1}`},
+	} {
+		e.ObjectKind, e.Namespace, e.Name = "Agent", "default", "test-agent"
+		require.NoError(t, handler.DatabaseService.StoreDebugCaptureEntry(ctx, &e))
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/debug/agents/default/test-agent/capture", nil)
+	req = mux.SetURLVars(req, map[string]string{"namespace": "default", "name": "test-agent"})
+	req = setUser(req, "test-user")
+	w := httptest.NewRecorder()
+
+	handler.HandleGetAgentCapture(&testErrorResponseWriter{w}, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var response api.StandardResponse[api.DebugCaptureResponse]
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.True(t, response.Data.Active)
+	require.Equal(t, until, response.Data.CaptureID)
+	require.Len(t, response.Data.Entries, 2)
+	require.JSONEq(t, `{"replicas":1}`, string(response.Data.Entries[1].Data))
+}
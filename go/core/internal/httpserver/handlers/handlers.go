@@ -25,15 +25,24 @@ type Handlers struct {
 	ModelProviderConfig *ModelProviderConfigHandler
 	Sessions            *SessionsHandler
 	SessionShares       *SessionSharesHandler
+	Compaction          *CompactionHandler
 	Agents              *AgentsHandler
+	Invoke              *InvokeHandler
 	Tools               *ToolsHandler
 	ToolServers         *ToolServersHandler
 	MCPApps             *MCPAppsHandler
 	ToolServerTypes     *ToolServerTypesHandler
 	Memory              *MemoryHandler
 	Feedback            *FeedbackHandler
+	Analytics           *AnalyticsHandler
+	Stats               *StatsHandler
+	Debug               *DebugHandler
+	Lint                *LintHandler
+	Apply               *ApplyHandler
+	Retention           *RetentionHandler
 	Namespaces          *NamespacesHandler
 	PromptTemplates     *PromptTemplatesHandler
+	AgentTemplates      *AgentTemplatesHandler
 	Tasks               *TasksHandler
 	Checkpoints         *CheckpointsHandler
 	CrewAI              *CrewAIHandler
@@ -68,6 +77,12 @@ func NewHandlers(
 	mcpEgressPlaintext bool,
 	substrateSandboxActorBackend *substrate.SandboxAgentActorBackend,
 	agentHarnessSessionActorBackend *substrate.AgentHarnessSessionActorBackend,
+	pushNotifier TaskPushNotifier,
+	compactor SessionCompactor,
+	archiver TaskArtifactArchiver,
+	eventArchive SessionEventArchive,
+	retentionPolicy database.RetentionPolicy,
+	messageSender AgentMessageSender,
 ) *Handlers {
 	base := &Base{
 		KubeClient:         kubeClient,
@@ -88,17 +103,26 @@ func NewHandlers(
 		ModelConfig:              NewModelConfigHandler(base),
 		Model:                    NewModelHandler(base),
 		ModelProviderConfig:      NewModelProviderConfigHandler(base, rcnclr),
-		Sessions:                 NewSessionsHandler(base, substrateSandboxActorBackend),
+		Sessions:                 NewSessionsHandler(base, substrateSandboxActorBackend, eventArchive),
+		Compaction:               NewCompactionHandler(base, compactor),
 		Agents:                   NewAgentsHandler(base),
+		Invoke:                   NewInvokeHandler(base, messageSender),
 		Tools:                    NewToolsHandler(base),
 		ToolServers:              NewToolServersHandler(base),
 		MCPApps:                  NewMCPAppsHandler(base),
 		ToolServerTypes:          NewToolServerTypesHandler(base),
 		Memory:                   NewMemoryHandler(base),
 		Feedback:                 NewFeedbackHandler(base),
+		Analytics:                NewAnalyticsHandler(base),
+		Stats:                    NewStatsHandler(base),
+		Debug:                    NewDebugHandler(base),
+		Lint:                     NewLintHandler(base),
+		Apply:                    NewApplyHandler(base),
+		Retention:                NewRetentionHandler(base, retentionPolicy),
 		Namespaces:               NewNamespacesHandler(base),
 		PromptTemplates:          NewPromptTemplatesHandler(base),
-		Tasks:                    NewTasksHandler(base),
+		AgentTemplates:           NewAgentTemplatesHandler(base),
+		Tasks:                    NewTasksHandler(base, pushNotifier, archiver),
 		Checkpoints:              NewCheckpointsHandler(base),
 		CrewAI:                   NewCrewAIHandler(base),
 		CurrentUser:              NewCurrentUserHandler(),
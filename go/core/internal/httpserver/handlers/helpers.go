@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/gorilla/mux"
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
 	"github.com/kagent-dev/kagent/go/core/internal/httpserver/errors"
 	"github.com/kagent-dev/kagent/go/core/pkg/auth"
 	corev1 "k8s.io/api/core/v1"
@@ -45,7 +46,7 @@ func RespondWithError(w http.ResponseWriter, code int, message string) {
 	log := ctrllog.Log.WithName("http-helpers")
 	log.Info("Responding with error", "statusCode", code, "message", message)
 
-	RespondWithJSON(w, code, map[string]string{"error": message})
+	RespondWithJSON(w, code, api.APIError{Error: message, Code: api.ErrorCodeForStatus(code)})
 }
 
 func GetUserID(r *http.Request) (string, error) {
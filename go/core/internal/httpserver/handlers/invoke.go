@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	a2a "github.com/a2aproject/a2a-go/v2/a2a"
+	"github.com/google/uuid"
+	"github.com/kagent-dev/kagent/go/api/adk"
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	kagenta2a "github.com/kagent-dev/kagent/go/core/internal/a2a"
+	"github.com/kagent-dev/kagent/go/core/internal/httpserver/errors"
+	"github.com/kagent-dev/kagent/go/core/pkg/auth"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// AgentMessageSender sends an A2A message to an agent through the
+// controller's A2A proxy, so tasks and push configs are handled as for
+// requests to the agent's A2A route. a2a.A2AHandlerMux satisfies it.
+type AgentMessageSender interface {
+	SendMessage(ctx context.Context, agentRef string, req *a2a.SendMessageRequest) (a2a.SendMessageResult, error)
+	// ValidatePushURL returns an error when push notifications may not be
+	// delivered to url.
+	ValidatePushURL(url string) error
+}
+
+// InvokeHandler starts agent tasks without waiting for them to finish
+type InvokeHandler struct {
+	*Base
+	sender AgentMessageSender
+}
+
+// NewInvokeHandler creates a new InvokeHandler. sender may be nil, in which
+// case async invocation is not available.
+func NewInvokeHandler(base *Base, sender AgentMessageSender) *InvokeHandler {
+	return &InvokeHandler{Base: base, sender: sender}
+}
+
+// HandleInvokeAsync handles POST /api/agents/{namespace}/{name}/invoke-async.
+// The agent is asked to return its task as soon as it is created and keeps
+// working on it after the response; the callback URL, if any, is registered
+// as a completion-only push notification config on the task. An agent that
+// answers with a message instead of a task is done: its answer is returned
+// with status 200 and the callback URL is not called.
+func (h *InvokeHandler) HandleInvokeAsync(w ErrorResponseWriter, r *http.Request) {
+	log := ctrllog.FromContext(r.Context()).WithName("invoke-handler").WithValues("operation", "invoke-async")
+
+	if h.sender == nil {
+		w.RespondWithError(errors.NewNotImplementedError("Async invocation is not available", nil))
+		return
+	}
+	name, err := GetPathParam(r, "name")
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get name from path", err))
+		return
+	}
+	namespace, err := GetPathParam(r, "namespace")
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get namespace from path", err))
+		return
+	}
+	agentRef := types.NamespacedName{Namespace: namespace, Name: name}
+	if err := Check(h.Authorizer, r, auth.Resource{Type: "Agent", Name: agentRef.String()}); err != nil {
+		w.RespondWithError(err)
+		return
+	}
+	log = log.WithValues("agent", agentRef.String())
+
+	var req api.InvokeAsyncRequest
+	if err := DecodeJSONBody(r, &req); err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Invalid request body", err).WithCode(api.ErrorCodeInvalidRequestBody))
+		return
+	}
+	if req.Task == "" {
+		w.RespondWithError(errors.NewBadRequestError("task is required", nil))
+		return
+	}
+	if err := h.KubeClient.Get(r.Context(), agentRef, &v1alpha2.Agent{}); err != nil {
+		if apierrors.IsNotFound(err) {
+			w.RespondWithError(errors.NewNotFoundError("Agent not found", nil).WithCode(api.ErrorCodeAgentNotFound).WithDetail("agent", agentRef.String()))
+			return
+		}
+		log.Error(err, "Failed to get agent")
+		w.RespondWithError(errors.NewInternalServerError("Failed to get Agent", err))
+		return
+	}
+
+	parts := []*a2a.Part{a2a.NewTextPart(req.Task)}
+	for _, attachment := range req.Attachments {
+		part := a2a.NewRawPart(attachment.Data)
+		part.Filename, part.MediaType = attachment.Name, attachment.MediaType
+		parts = append(parts, part)
+	}
+	message := a2a.NewMessage(a2a.MessageRoleUser, parts...)
+	message.ContextID = req.SessionID
+	if req.Budget != nil {
+		message.Metadata = map[string]any{"kagent_budget": budgetMetadata(req.Budget)}
+	}
+	config := &a2a.SendMessageConfig{ReturnImmediately: true}
+	if req.CallbackURL != "" {
+		if err := h.sender.ValidatePushURL(req.CallbackURL); err != nil {
+			w.RespondWithError(errors.NewBadRequestError("Invalid callbackUrl", err))
+			return
+		}
+		config.PushConfig = &a2a.PushConfig{
+			ID:    kagenta2a.CompletionCallbackIDPrefix + uuid.NewString(),
+			URL:   req.CallbackURL,
+			Token: req.CallbackToken,
+		}
+	}
+
+	result, err := h.sender.SendMessage(r.Context(), agentRef.String(), &a2a.SendMessageRequest{Message: message, Config: config})
+	if stderrors.Is(err, a2a.ErrInvalidParams) {
+		w.RespondWithError(errors.NewBadRequestError("Invalid invocation", err))
+		return
+	}
+	if err != nil {
+		log.Error(err, "Failed to invoke agent")
+		w.RespondWithError(errors.NewInternalServerError("Failed to invoke agent", err))
+		return
+	}
+
+	switch res := result.(type) {
+	case *a2a.Task:
+		resp := api.InvokeAsyncResponse{TaskID: string(res.ID), SessionID: res.ContextID, State: string(res.Status.State)}
+		log.Info("Started async agent task", "task_id", resp.TaskID)
+		RespondWithJSON(w, http.StatusAccepted, api.NewResponse(resp, "Successfully started agent task", false))
+	case *a2a.Message:
+		// Agents that answer without creating a task reply with a message.
+		// There is no task to follow or to notify about, so the answer is
+		// returned now.
+		var text strings.Builder
+		for _, part := range res.Parts {
+			text.WriteString(part.Text())
+		}
+		resp := api.InvokeAsyncResponse{SessionID: res.ContextID, State: string(a2a.TaskStateCompleted), Result: text.String()}
+		log.Info("Agent answered without creating a task")
+		RespondWithJSON(w, http.StatusOK, api.NewResponse(resp, "Agent answered without creating a task; no callback follows", false))
+	default:
+		w.RespondWithError(errors.NewInternalServerError("Failed to invoke agent", fmt.Errorf("unexpected A2A result %T", result)))
+	}
+}
+
+// budgetMetadata returns the kagent_budget message metadata of an
+// invocation's budget, in the agent config's format.
+func budgetMetadata(b *v1alpha2.AgentBudget) *adk.BudgetConfig {
+	cfg := &adk.BudgetConfig{MaxTotalTokens: b.MaxTotalTokens, MaxSessionTokens: b.MaxSessionTokens}
+	if b.MaxToolCalls != nil {
+		cfg.MaxToolCalls = new(int(*b.MaxToolCalls))
+	}
+	if b.MaxTurns != nil {
+		cfg.MaxTurns = new(int(*b.MaxTurns))
+	}
+	return cfg
+}
@@ -0,0 +1,203 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	a2a "github.com/a2aproject/a2a-go/v2/a2a"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kagent-dev/kagent/go/api/adk"
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	kagenta2a "github.com/kagent-dev/kagent/go/core/internal/a2a"
+	"github.com/kagent-dev/kagent/go/core/internal/httpserver/auth"
+	"github.com/kagent-dev/kagent/go/core/internal/httpserver/handlers"
+)
+
+type fakeMessageSender struct {
+	agentRef string
+	req      *a2a.SendMessageRequest
+	// reply, when set, is returned instead of a task.
+	reply *a2a.Message
+	// err, when set, is returned instead of a result.
+	err error
+}
+
+func (f *fakeMessageSender) SendMessage(_ context.Context, agentRef string, req *a2a.SendMessageRequest) (a2a.SendMessageResult, error) {
+	f.agentRef = agentRef
+	f.req = req
+	if f.err != nil {
+		return nil, f.err
+	}
+	if f.reply != nil {
+		return f.reply, nil
+	}
+	return &a2a.Task{ID: "task-1", ContextID: req.Message.ContextID, Status: a2a.TaskStatus{State: a2a.TaskStateSubmitted}}, nil
+}
+
+func (f *fakeMessageSender) ValidatePushURL(url string) error {
+	if strings.Contains(url, "169.254.169.254") || !strings.HasPrefix(url, "http") {
+		return assert.AnError
+	}
+	return nil
+}
+
+func TestHandleInvokeAsync(t *testing.T) {
+	tests := []struct {
+		name       string
+		agent      string
+		request    api.InvokeAsyncRequest
+		wantStatus int
+	}{
+		{
+			name:       "starts task",
+			agent:      "test-agent",
+			request:    api.InvokeAsyncRequest{Task: "hello", SessionID: "session-1"},
+			wantStatus: http.StatusAccepted,
+		},
+		{
+			name:       "registers completion callback",
+			agent:      "test-agent",
+			request:    api.InvokeAsyncRequest{Task: "hello", CallbackURL: "https://example.com/hook", CallbackToken: "secret"},
+			wantStatus: http.StatusAccepted,
+		},
+		{
+			name:       "passes budget",
+			agent:      "test-agent",
+			request:    api.InvokeAsyncRequest{Task: "hello", Budget: &v1alpha2.AgentBudget{MaxToolCalls: new(int32(5))}},
+			wantStatus: http.StatusAccepted,
+		},
+		{
+			name:  "passes attachments",
+			agent: "test-agent",
+			request: api.InvokeAsyncRequest{Task: "what is wrong here?", Attachments: []api.Attachment{
+				{Name: "dashboard.png", MediaType: "image/png", Data: []byte{0x89, 'P', 'N', 'G'}},
+			}},
+			wantStatus: http.StatusAccepted,
+		},
+		{
+			name:       "rejects missing task",
+			agent:      "test-agent",
+			request:    api.InvokeAsyncRequest{},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "rejects relative callback URL",
+			agent:      "test-agent",
+			request:    api.InvokeAsyncRequest{Task: "hello", CallbackURL: "/hook"},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "rejects internal callback URL",
+			agent:      "test-agent",
+			request:    api.InvokeAsyncRequest{Task: "hello", CallbackURL: "http://169.254.169.254/latest/meta-data"},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "unknown agent",
+			agent:      "missing",
+			request:    api.InvokeAsyncRequest{Task: "hello"},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agent := createTestAgent("test-agent", createTestModelConfig())
+			kubeClient := fake.NewClientBuilder().WithScheme(setupScheme()).WithObjects(agent).Build()
+			sender := &fakeMessageSender{}
+			handler := handlers.NewInvokeHandler(&handlers.Base{KubeClient: kubeClient, Authorizer: &auth.NoopAuthorizer{}}, sender)
+
+			body, _ := json.Marshal(tt.request)
+			req := httptest.NewRequest(http.MethodPost, "/api/agents/default/"+tt.agent+"/invoke-async", bytes.NewReader(body))
+			req = mux.SetURLVars(req, map[string]string{"namespace": "default", "name": tt.agent})
+			req = setUser(req, "test-user")
+			w := httptest.NewRecorder()
+
+			handler.HandleInvokeAsync(&testErrorResponseWriter{w}, req)
+			require.Equal(t, tt.wantStatus, w.Code, w.Body.String())
+			if tt.wantStatus != http.StatusAccepted {
+				require.Nil(t, sender.req)
+				return
+			}
+
+			var resp api.StandardResponse[api.InvokeAsyncResponse]
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			require.Equal(t, api.InvokeAsyncResponse{TaskID: "task-1", SessionID: tt.request.SessionID, State: string(a2a.TaskStateSubmitted)}, resp.Data)
+
+			require.Equal(t, "default/test-agent", sender.agentRef)
+			require.True(t, sender.req.Config.ReturnImmediately)
+			require.Equal(t, tt.request.SessionID, sender.req.Message.ContextID)
+			if tt.request.Budget != nil {
+				budget, _ := sender.req.Message.Metadata["kagent_budget"].(*adk.BudgetConfig)
+				require.NotNil(t, budget)
+				require.Equal(t, 5, *budget.MaxToolCalls)
+			} else {
+				require.Nil(t, sender.req.Message.Metadata)
+			}
+			parts := sender.req.Message.Parts
+			require.Len(t, parts, 1+len(tt.request.Attachments))
+			for i, attachment := range tt.request.Attachments {
+				require.Equal(t, a2a.Raw(attachment.Data), parts[i+1].Content)
+				require.Equal(t, attachment.MediaType, parts[i+1].MediaType)
+				require.Equal(t, attachment.Name, parts[i+1].Filename)
+			}
+			push := sender.req.Config.PushConfig
+			if tt.request.CallbackURL == "" {
+				require.Nil(t, push)
+				return
+			}
+			require.Equal(t, tt.request.CallbackURL, push.URL)
+			require.Equal(t, tt.request.CallbackToken, push.Token)
+			require.True(t, strings.HasPrefix(push.ID, kagenta2a.CompletionCallbackIDPrefix))
+		})
+	}
+}
+
+func TestHandleInvokeAsyncMessageReply(t *testing.T) {
+	agent := createTestAgent("test-agent", createTestModelConfig())
+	kubeClient := fake.NewClientBuilder().WithScheme(setupScheme()).WithObjects(agent).Build()
+	reply := a2a.NewMessage(a2a.MessageRoleAgent, a2a.NewTextPart("Hello "), a2a.NewTextPart("there"))
+	reply.ContextID = "session-1"
+	sender := &fakeMessageSender{reply: reply}
+	handler := handlers.NewInvokeHandler(&handlers.Base{KubeClient: kubeClient, Authorizer: &auth.NoopAuthorizer{}}, sender)
+
+	body, _ := json.Marshal(api.InvokeAsyncRequest{Task: "hello", SessionID: "session-1", CallbackURL: "https://example.com/hook"})
+	req := httptest.NewRequest(http.MethodPost, "/api/agents/default/test-agent/invoke-async", bytes.NewReader(body))
+	req = mux.SetURLVars(req, map[string]string{"namespace": "default", "name": "test-agent"})
+	req = setUser(req, "test-user")
+	w := httptest.NewRecorder()
+
+	handler.HandleInvokeAsync(&testErrorResponseWriter{w}, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.NotContains(t, w.Body.String(), "taskId")
+
+	var resp api.StandardResponse[api.InvokeAsyncResponse]
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Equal(t, api.InvokeAsyncResponse{SessionID: "session-1", State: string(a2a.TaskStateCompleted), Result: "Hello there"}, resp.Data)
+}
+
+func TestHandleInvokeAsyncRejectedAttachment(t *testing.T) {
+	agent := createTestAgent("test-agent", createTestModelConfig())
+	kubeClient := fake.NewClientBuilder().WithScheme(setupScheme()).WithObjects(agent).Build()
+	sender := &fakeMessageSender{err: a2a.NewError(a2a.ErrInvalidParams, `attachment "core.bin" has media type "application/octet-stream"`)}
+	handler := handlers.NewInvokeHandler(&handlers.Base{KubeClient: kubeClient, Authorizer: &auth.NoopAuthorizer{}}, sender)
+
+	body, _ := json.Marshal(api.InvokeAsyncRequest{Task: "hello", Attachments: []api.Attachment{{Name: "core.bin", MediaType: "application/octet-stream", Data: []byte{0}}}})
+	req := httptest.NewRequest(http.MethodPost, "/api/agents/default/test-agent/invoke-async", bytes.NewReader(body))
+	req = mux.SetURLVars(req, map[string]string{"namespace": "default", "name": "test-agent"})
+	req = setUser(req, "test-user")
+	w := httptest.NewRecorder()
+
+	handler.HandleInvokeAsync(&testErrorResponseWriter{w}, req)
+	require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+}
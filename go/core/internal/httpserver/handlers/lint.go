@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	"github.com/kagent-dev/kagent/go/core/internal/agentlint"
+	"github.com/kagent-dev/kagent/go/core/internal/httpserver/errors"
+	"github.com/kagent-dev/kagent/go/core/pkg/auth"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// LintHandler checks agent specs against best-practice rules
+type LintHandler struct {
+	*Base
+}
+
+// NewLintHandler creates a new LintHandler
+func NewLintHandler(base *Base) *LintHandler {
+	return &LintHandler{Base: base}
+}
+
+// HandleLintAgent handles POST /api/lint/agent. The body is an Agent manifest;
+// nothing is written to the cluster.
+func (h *LintHandler) HandleLintAgent(w ErrorResponseWriter, r *http.Request) {
+	log := ctrllog.FromContext(r.Context()).WithName("lint-handler").WithValues("operation", "lint-agent")
+
+	if err := Check(h.Authorizer, r, auth.Resource{Type: "Agent"}); err != nil {
+		w.RespondWithError(err)
+		return
+	}
+
+	agent := &v1alpha2.Agent{}
+	if err := DecodeJSONBody(r, agent); err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Invalid request body", err).WithCode(api.ErrorCodeInvalidRequestBody))
+		return
+	}
+	namespace := agent.Namespace
+	if namespace == "" {
+		namespace = h.DefaultModelConfig.Namespace
+	}
+	log = log.WithValues("agent", agent.Name, "namespace", namespace)
+
+	resp := api.LintAgentResponse{Findings: []api.LintFinding{}}
+	for _, f := range agentlint.Lint(h.agentLintInput(r.Context(), log, agent, namespace)) {
+		resp.Findings = append(resp.Findings, api.LintFinding{
+			RuleID:   f.RuleID,
+			Severity: string(f.Severity),
+			Field:    f.Field,
+			Message:  f.Message,
+		})
+		switch f.Severity {
+		case agentlint.SeverityError:
+			resp.Errors++
+		case agentlint.SeverityWarning:
+			resp.Warnings++
+		}
+	}
+
+	log.Info("Linted agent", "findings", len(resp.Findings))
+	RespondWithJSON(w, http.StatusOK, api.NewResponse(resp, "Successfully linted agent", false))
+}
+
+// agentLintInput builds the lint input for agent. The agent's ModelConfig and
+// systemMessageFrom source are looked up when they exist so prompt size can be
+// checked against the model's context window.
+func (b *Base) agentLintInput(ctx context.Context, log logr.Logger, agent *v1alpha2.Agent, namespace string) agentlint.Input {
+	in := agentlint.Input{Spec: agent.Spec}
+	if decl := agent.Spec.Declarative; decl != nil {
+		in.SystemMessage = decl.SystemMessage
+		if decl.SystemMessageFrom != nil {
+			msg, err := decl.SystemMessageFrom.Resolve(ctx, b.KubeClient, namespace)
+			if err != nil {
+				log.V(1).Info("Could not resolve systemMessageFrom, skipping prompt size check", "error", err.Error())
+			} else {
+				in.SystemMessage = msg
+			}
+		}
+
+		modelConfigRef := client.ObjectKey{Namespace: namespace, Name: decl.ModelConfig}
+		if decl.ModelConfig == "" {
+			modelConfigRef = b.DefaultModelConfig
+		}
+		modelConfig := &v1alpha2.ModelConfig{}
+		if err := b.KubeClient.Get(ctx, modelConfigRef, modelConfig); err != nil {
+			log.V(1).Info("Could not get ModelConfig, skipping prompt size check", "modelConfigRef", modelConfigRef, "error", err.Error())
+		} else {
+			in.Model = modelConfig.Spec.Model
+		}
+	}
+	return in
+}
@@ -0,0 +1,85 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	"github.com/kagent-dev/kagent/go/core/internal/httpserver/auth"
+	"github.com/kagent-dev/kagent/go/core/internal/httpserver/handlers"
+)
+
+func TestHandleLintAgent(t *testing.T) {
+	modelConfig := createTestModelConfig()
+	promptMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "prompts", Namespace: "default"},
+		Data:       map[string]string{"huge": strings.Repeat("word ", 8000)},
+	}
+
+	tests := []struct {
+		name      string
+		agent     *v1alpha2.Agent
+		wantRules []string
+	}{
+		{
+			name: "clean agent",
+			agent: func() *v1alpha2.Agent {
+				a := createTestAgent("clean", modelConfig)
+				a.Spec.Description = "Answers cluster questions"
+				return a
+			}(),
+			wantRules: []string{},
+		},
+		{
+			name: "prompt from configmap exceeds model context",
+			agent: func() *v1alpha2.Agent {
+				a := createTestAgent("huge-prompt", modelConfig)
+				a.Spec.Declarative.SystemMessageFrom = &v1alpha2.ValueSource{Type: v1alpha2.ConfigMapValueSource, Name: "prompts", Key: "huge"}
+				return a
+			}(),
+			wantRules: []string{"prompt-exceeds-context", "missing-description"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kubeClient := fake.NewClientBuilder().
+				WithScheme(setupScheme()).
+				WithObjects([]client.Object{modelConfig, promptMap}...).
+				Build()
+			handler := handlers.NewLintHandler(&handlers.Base{
+				KubeClient:         kubeClient,
+				DefaultModelConfig: types.NamespacedName{Name: modelConfig.Name, Namespace: modelConfig.Namespace},
+				Authorizer:         &auth.NoopAuthorizer{},
+			})
+
+			body, _ := json.Marshal(tt.agent)
+			req := httptest.NewRequest(http.MethodPost, "/api/lint/agent", bytes.NewReader(body))
+			req = setUser(req, "test-user")
+			w := httptest.NewRecorder()
+
+			handler.HandleLintAgent(&testErrorResponseWriter{w}, req)
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+			var response api.StandardResponse[api.LintAgentResponse]
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			rules := []string{}
+			for _, f := range response.Data.Findings {
+				rules = append(rules, f.RuleID)
+			}
+			require.Equal(t, tt.wantRules, rules)
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"cmp"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
+	"k8s.io/apimachinery/pkg/labels"
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
+)
+
+// maxListLimit caps the page size a client can request from a list endpoint.
+const maxListLimit = 1000
+
+// listParams holds the pagination, filtering and sorting query parameters
+// shared by the list endpoints:
+//
+//	limit=<n>           return at most n items (1-1000)
+//	offset=<n>          skip the first n items
+//	sort=<field>        sort by field; prefix with "-" for descending order
+//	namespace=<ns>      only items in namespace ns
+//	label=<selector>    only items matching the Kubernetes label selector
+//	created_after=<ts>  only items created after the RFC3339 timestamp
+//
+// Each endpoint documents which filters and sort fields it supports.
+type listParams struct {
+	Limit        int
+	Offset       int
+	SortBy       string
+	SortDesc     bool
+	Namespace    string
+	Selector     labels.Selector
+	CreatedAfter time.Time
+}
+
+// listParamsFromRequest parses the list query parameters, accepting only the
+// given sort fields.
+func listParamsFromRequest(r *http.Request, sortFields ...string) (listParams, error) {
+	q := r.URL.Query()
+	var p listParams
+
+	if v := q.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 1 || limit > maxListLimit {
+			return p, fmt.Errorf("invalid limit %q: must be an integer between 1 and %d", v, maxListLimit)
+		}
+		p.Limit = limit
+	}
+	if v := q.Get("offset"); v != "" {
+		offset, err := strconv.Atoi(v)
+		if err != nil || offset < 0 {
+			return p, fmt.Errorf("invalid offset %q: must be a non-negative integer", v)
+		}
+		p.Offset = offset
+	}
+	if v := q.Get("sort"); v != "" {
+		field, desc := strings.CutPrefix(v, "-")
+		if !slices.Contains(sortFields, field) {
+			return p, fmt.Errorf("invalid sort %q: must be one of %s, optionally prefixed with -", v, strings.Join(sortFields, ", "))
+		}
+		p.SortBy, p.SortDesc = field, desc
+	}
+	if v := q.Get("namespace"); v != "" {
+		if strings.TrimSpace(v) != v {
+			return p, fmt.Errorf("invalid namespace %q: must not contain leading or trailing whitespace", v)
+		}
+		if errs := utilvalidation.IsDNS1123Label(v); len(errs) > 0 {
+			return p, fmt.Errorf("invalid namespace %q: %s", v, strings.Join(errs, "; "))
+		}
+		p.Namespace = v
+	}
+	if v := q.Get("label"); v != "" {
+		selector, err := labels.Parse(v)
+		if err != nil {
+			return p, fmt.Errorf("invalid label selector %q: %w", v, err)
+		}
+		p.Selector = selector
+	}
+	if v := q.Get("created_after"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return p, fmt.Errorf("invalid created_after %q: must be an RFC3339 timestamp", v)
+		}
+		p.CreatedAfter = t
+	}
+	return p, nil
+}
+
+// sortItems stably sorts items by the comparator registered for p.SortBy. The
+// order is left unchanged when no sort was requested.
+func sortItems[T any](items []T, p listParams, comparators map[string]func(a, b T) int) {
+	compare, ok := comparators[p.SortBy]
+	if !ok {
+		return
+	}
+	slices.SortStableFunc(items, func(a, b T) int {
+		if p.SortDesc {
+			return compare(b, a)
+		}
+		return compare(a, b)
+	})
+}
+
+// compareTime orders timestamps chronologically.
+func compareTime(a, b time.Time) int {
+	return a.Compare(b)
+}
+
+// compareStringPtr orders nil before any string.
+func compareStringPtr(a, b *string) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+	return cmp.Compare(*a, *b)
+}
+
+// paginate returns the page of items selected by p, along with the pagination
+// metadata for the response. The metadata is nil when no page was requested,
+// so unpaginated responses keep their original shape.
+func paginate[T any](items []T, p listParams) ([]T, *api.Pagination) {
+	if p.Limit == 0 && p.Offset == 0 {
+		return items, nil
+	}
+	start := min(p.Offset, len(items))
+	end := len(items)
+	if p.Limit > 0 {
+		end = min(start+p.Limit, len(items))
+	}
+	return items[start:end], &api.Pagination{Limit: p.Limit, Offset: p.Offset, HasMore: end < len(items)}
+}
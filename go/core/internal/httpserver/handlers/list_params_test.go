@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"cmp"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
+)
+
+func TestListParamsFromRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		want    listParams
+		wantErr string
+	}{
+		{name: "no params", query: ""},
+		{
+			name:  "paging and sorting",
+			query: "limit=10&offset=20&sort=-name",
+			want:  listParams{Limit: 10, Offset: 20, SortBy: "name", SortDesc: true},
+		},
+		{
+			name:  "filters",
+			query: "namespace=kagent&created_after=2026-01-02T03:04:05Z",
+			want:  listParams{Namespace: "kagent", CreatedAfter: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)},
+		},
+		{name: "zero limit", query: "limit=0", wantErr: "invalid limit"},
+		{name: "limit above maximum", query: "limit=1001", wantErr: "invalid limit"},
+		{name: "negative offset", query: "offset=-1", wantErr: "invalid offset"},
+		{name: "unknown sort field", query: "sort=owner", wantErr: "must be one of name, created_at"},
+		{name: "invalid namespace", query: "namespace=INVALID_NS!", wantErr: "invalid namespace"},
+		{name: "namespace with whitespace", query: "namespace=%20kagent", wantErr: "must not contain leading or trailing whitespace"},
+		{name: "invalid label selector", query: "label=a%20in%20(", wantErr: "invalid label selector"},
+		{name: "invalid created_after", query: "created_after=yesterday", wantErr: "invalid created_after"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/api/things?"+tt.query, nil)
+			got, err := listParamsFromRequest(req, "name", "created_at")
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("label selector", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/things?label=team%3Dplatform", nil)
+		got, err := listParamsFromRequest(req)
+		require.NoError(t, err)
+		require.NotNil(t, got.Selector)
+		assert.Equal(t, "team=platform", got.Selector.String())
+	})
+}
+
+func TestSortAndPaginate(t *testing.T) {
+	comparators := map[string]func(a, b string) int{"name": cmp.Compare[string]}
+
+	tests := []struct {
+		name           string
+		params         listParams
+		want           []string
+		wantPagination *api.Pagination
+	}{
+		{name: "unpaged keeps order", want: []string{"b", "d", "a", "c"}},
+		{
+			name:           "first page",
+			params:         listParams{SortBy: "name", Limit: 3},
+			want:           []string{"a", "b", "c"},
+			wantPagination: &api.Pagination{Limit: 3, HasMore: true},
+		},
+		{
+			name:           "last page",
+			params:         listParams{SortBy: "name", SortDesc: true, Limit: 3, Offset: 3},
+			want:           []string{"a"},
+			wantPagination: &api.Pagination{Limit: 3, Offset: 3},
+		},
+		{
+			name:           "offset past the end",
+			params:         listParams{Offset: 10},
+			want:           []string{},
+			wantPagination: &api.Pagination{Offset: 10},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items := []string{"b", "d", "a", "c"}
+			sortItems(items, tt.params, comparators)
+			got, pagination := paginate(items, tt.params)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantPagination, pagination)
+		})
+	}
+}
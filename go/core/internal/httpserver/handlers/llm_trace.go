@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	dbpkg "github.com/kagent-dev/kagent/go/api/database"
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
+	"github.com/kagent-dev/kagent/go/core/internal/httpserver/errors"
+	"github.com/kagent-dev/kagent/go/core/internal/utils"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// maxLLMTraceBytes bounds the request body of a recorded LLM trace. Long
+// conversations make for large prompts, but a trace is still a debugging aid,
+// not an archive.
+const maxLLMTraceBytes = 8 << 20
+
+// HandleListLLMTraces handles GET /api/sessions/{session_id}/llm-traces requests.
+func (h *SessionsHandler) HandleListLLMTraces(w ErrorResponseWriter, r *http.Request) {
+	log := ctrllog.FromContext(r.Context()).WithName("sessions-handler").WithValues("operation", "list-llm-traces")
+
+	sessionID, err := GetPathParam(r, "session_id")
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get session ID from path", err))
+		return
+	}
+	userID, _, apiErr := h.sessionReader(r, sessionID)
+	if apiErr != nil {
+		w.RespondWithError(apiErr)
+		return
+	}
+	if _, err := h.DatabaseService.GetSession(r.Context(), sessionID, userID); err != nil {
+		w.RespondWithError(errors.NewNotFoundError("Session not found", err).WithCode(api.ErrorCodeSessionNotFound))
+		return
+	}
+
+	traces, err := h.DatabaseService.ListLLMTraces(r.Context(), sessionID, userID)
+	if err != nil {
+		w.RespondWithError(errors.NewInternalServerError("Failed to list LLM traces", err))
+		return
+	}
+
+	log.V(1).Info("Listed LLM traces", "session_id", sessionID, "count", len(traces))
+	RespondWithJSON(w, http.StatusOK, api.NewResponse(traces, "Successfully retrieved LLM traces", false))
+}
+
+// HandleCreateLLMTrace handles POST /api/sessions/{session_id}/llm-traces
+// requests, which agents send for each sampled model call.
+func (h *SessionsHandler) HandleCreateLLMTrace(w ErrorResponseWriter, r *http.Request) {
+	log := ctrllog.FromContext(r.Context()).WithName("sessions-handler").WithValues("operation", "create-llm-trace")
+
+	sessionID, err := GetPathParam(r, "session_id")
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get session ID from path", err))
+		return
+	}
+
+	r.Body = http.MaxBytesReader(nil, r.Body, maxLLMTraceBytes)
+	var body api.LLMTraceRequest
+	if err := DecodeJSONBody(r, &body); err != nil {
+		w.RespondWithError(errors.NewBadRequestError(fmt.Sprintf("Invalid request body (at most %d bytes)", maxLLMTraceBytes), err))
+		return
+	}
+	if len(body.Request) == 0 {
+		w.RespondWithError(errors.NewBadRequestError("request is required", nil))
+		return
+	}
+	if len(body.Response) == 0 && body.Error == "" {
+		w.RespondWithError(errors.NewBadRequestError("response or error is required", nil))
+		return
+	}
+
+	session, apiErr := h.sessionWriter(r, sessionID)
+	if apiErr != nil {
+		w.RespondWithError(apiErr)
+		return
+	}
+	agentID := ""
+	if session.AgentID != nil {
+		agentID = *session.AgentID
+	} else if principal, err := GetPrincipal(r); err == nil && principal.Agent.ID != "" {
+		agentID = utils.ConvertToPythonIdentifier(principal.Agent.ID)
+	}
+
+	trace, err := h.DatabaseService.StoreLLMTrace(r.Context(), &dbpkg.LLMTrace{
+		SessionID: sessionID,
+		UserID:    session.UserID,
+		AgentID:   agentID,
+		Model:     body.Model,
+		Request:   body.Request,
+		Response:  body.Response,
+		Error:     body.Error,
+	})
+	if err != nil {
+		w.RespondWithError(errors.NewInternalServerError("Failed to store LLM trace", err))
+		return
+	}
+
+	log.V(1).Info("Stored LLM trace", "session_id", sessionID, "id", trace.ID)
+	RespondWithJSON(w, http.StatusCreated, api.NewResponse(*trace, "Successfully stored LLM trace", false))
+}
@@ -0,0 +1,109 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbpkg "github.com/kagent-dev/kagent/go/api/database"
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
+	authimpl "github.com/kagent-dev/kagent/go/core/internal/httpserver/auth"
+	"github.com/kagent-dev/kagent/go/core/internal/httpserver/handlers"
+	"github.com/kagent-dev/kagent/go/core/pkg/auth"
+)
+
+func TestLLMTraces(t *testing.T) {
+	setup := func(t *testing.T) *handlers.SessionsHandler {
+		t.Helper()
+		dbClient := setupTestDBClient(t)
+		base := &handlers.Base{
+			DatabaseService: dbClient,
+			Authorizer:      &authimpl.NoopAuthorizer{},
+		}
+		agentID := "agent_1"
+		require.NoError(t, dbClient.StoreSession(context.Background(), &dbpkg.Session{ID: "sess-1", UserID: "owner", AgentID: &agentID}))
+		return handlers.NewSessionsHandler(base, nil, nil)
+	}
+
+	request := func(method string, body any) *http.Request {
+		var buf bytes.Buffer
+		if body != nil {
+			_ = json.NewEncoder(&buf).Encode(body)
+		}
+		req := httptest.NewRequest(method, "/api/sessions/sess-1/llm-traces", &buf)
+		req.Header.Set("Content-Type", "application/json")
+		return mux.SetURLVars(req, map[string]string{"session_id": "sess-1"})
+	}
+
+	asAgent := func(req *http.Request, agentID string) *http.Request {
+		return req.WithContext(auth.AuthSessionTo(req.Context(), &authimpl.SimpleSession{
+			P: auth.Principal{User: auth.User{ID: "owner"}, Agent: auth.Agent{ID: agentID}},
+		}))
+	}
+
+	create := func(h *handlers.SessionsHandler, req *http.Request) *mockErrorResponseWriter {
+		w := newMockErrorResponseWriter()
+		h.HandleCreateLLMTrace(w, req)
+		return w
+	}
+
+	list := func(h *handlers.SessionsHandler, req *http.Request) *mockErrorResponseWriter {
+		w := newMockErrorResponseWriter()
+		h.HandleListLLMTraces(w, req)
+		return w
+	}
+
+	t.Run("agent stores traces the owner can list", func(t *testing.T) {
+		h := setup(t)
+
+		w := create(h, asAgent(request(http.MethodPost, map[string]any{
+			"model":    "gpt-4o",
+			"request":  map[string]any{"contents": []any{}},
+			"response": map[string]any{"content": map[string]any{"role": "model"}},
+		}), "agent_1"))
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		w = create(h, asAgent(request(http.MethodPost, map[string]any{
+			"model":   "gpt-4o",
+			"request": map[string]any{"contents": []any{}},
+			"error":   "rate limited",
+		}), "agent_1"))
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		w = list(h, setUser(request(http.MethodGet, nil), "owner"))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var resp api.StandardResponse[[]api.LLMTrace]
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		require.Len(t, resp.Data, 2)
+		assert.Equal(t, "agent_1", resp.Data[0].AgentID)
+		assert.Equal(t, "gpt-4o", resp.Data[0].Model)
+		assert.JSONEq(t, `{"content":{"role":"model"}}`, string(resp.Data[0].Response))
+		assert.Empty(t, resp.Data[1].Response)
+		assert.Equal(t, "rate limited", resp.Data[1].Error)
+	})
+
+	t.Run("request and a response or error are required", func(t *testing.T) {
+		h := setup(t)
+		w := create(h, asAgent(request(http.MethodPost, map[string]any{"model": "gpt-4o", "error": "boom"}), "agent_1"))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		w = create(h, asAgent(request(http.MethodPost, map[string]any{"model": "gpt-4o", "request": map[string]any{}}), "agent_1"))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("agent must own the session", func(t *testing.T) {
+		h := setup(t)
+		w := create(h, asAgent(request(http.MethodPost, map[string]any{"request": map[string]any{}, "error": "boom"}), "other"))
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("other users cannot list the traces", func(t *testing.T) {
+		h := setup(t)
+		assert.Equal(t, http.StatusNotFound, list(h, setUser(request(http.MethodGet, nil), "alice")).Code)
+	})
+}
@@ -14,10 +14,12 @@ import (
 	"github.com/kagent-dev/kagent/go/api/v1alpha2"
 	agent_translator "github.com/kagent-dev/kagent/go/core/internal/controller/translator/agent"
 	"github.com/kagent-dev/kagent/go/core/internal/httpserver/errors"
+	"github.com/kagent-dev/kagent/go/core/internal/mcpauth"
 	"github.com/kagent-dev/kagent/go/core/internal/version"
 	"github.com/kagent-dev/kagent/go/core/pkg/auth"
 	kmcp "github.com/kagent-dev/kmcp/api/v1alpha1"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/oauth2"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -33,6 +35,9 @@ const mcpUIExtensionName = "io.modelcontextprotocol/ui"
 
 type MCPAppsHandler struct {
 	*Base
+
+	// tokens caches the OAuth2 tokens of RemoteMCPServers with spec.auth.
+	tokens mcpauth.TokenSources
 }
 
 type MCPAppToolResponse struct {
@@ -59,7 +64,7 @@ func (h *MCPAppsHandler) HandleListTools(w ErrorResponseWriter, r *http.Request)
 
 	session, cancel, err := h.connect(r.Context(), namespace, name, groupKind)
 	if err != nil {
-		w.RespondWithError(errors.NewInternalServerError("Failed to connect to MCP server", err))
+		w.RespondWithError(errors.NewInternalServerError("Failed to connect to MCP server", err).WithCode(api.ErrorCodeToolServerUnreachable))
 		return
 	}
 	defer cancel()
@@ -109,7 +114,7 @@ func (h *MCPAppsHandler) HandleCallTool(w ErrorResponseWriter, r *http.Request)
 		}
 		if len(strings.TrimSpace(string(body))) > 0 {
 			if err := json.Unmarshal(body, &req); err != nil {
-				w.RespondWithError(errors.NewBadRequestError("Invalid request body", err))
+				w.RespondWithError(errors.NewBadRequestError("Invalid request body", err).WithCode(api.ErrorCodeInvalidRequestBody))
 				return
 			}
 		}
@@ -117,7 +122,7 @@ func (h *MCPAppsHandler) HandleCallTool(w ErrorResponseWriter, r *http.Request)
 
 	session, cancel, err := h.connect(r.Context(), namespace, name, groupKind)
 	if err != nil {
-		w.RespondWithError(errors.NewInternalServerError("Failed to connect to MCP server", err))
+		w.RespondWithError(errors.NewInternalServerError("Failed to connect to MCP server", err).WithCode(api.ErrorCodeToolServerUnreachable))
 		return
 	}
 	defer cancel()
@@ -133,7 +138,7 @@ func (h *MCPAppsHandler) HandleCallTool(w ErrorResponseWriter, r *http.Request)
 		return
 	}
 	if !found {
-		w.RespondWithError(errors.NewNotFoundError(fmt.Sprintf("MCP tool %q not found", toolName), nil))
+		w.RespondWithError(errors.NewNotFoundError(fmt.Sprintf("MCP tool %q not found", toolName), nil).WithCode(api.ErrorCodeToolNotFound).WithDetail("tool", toolName))
 		return
 	}
 	if !allowed {
@@ -170,7 +175,7 @@ func (h *MCPAppsHandler) HandleReadResource(w ErrorResponseWriter, r *http.Reque
 
 	session, cancel, err := h.connect(r.Context(), namespace, name, groupKind)
 	if err != nil {
-		w.RespondWithError(errors.NewInternalServerError("Failed to connect to MCP server", err))
+		w.RespondWithError(errors.NewInternalServerError("Failed to connect to MCP server", err).WithCode(api.ErrorCodeToolServerUnreachable))
 		return
 	}
 	defer cancel()
@@ -321,7 +326,17 @@ func (h *MCPAppsHandler) connect(ctx context.Context, namespace, name, groupKind
 		return nil, nil, fmt.Errorf("failed to resolve RemoteMCPServer headers: %w", err)
 	}
 
-	httpClient := newMCPAppsHTTPClient(headers)
+	auth, err := mcpauth.Resolve(connectCtx, h.KubeClient, server)
+	if err != nil {
+		cancel()
+		return nil, nil, fmt.Errorf("failed to resolve RemoteMCPServer auth: %w", err)
+	}
+	var tokens oauth2.TokenSource
+	if auth != nil {
+		tokens = h.tokens.Get(namespace+"/"+name, auth)
+	}
+
+	httpClient := newMCPAppsHTTPClient(headers, tokens)
 	var transport mcp.Transport
 	switch server.Spec.Protocol {
 	case v1alpha2.RemoteMCPServerProtocolSse:
@@ -440,14 +455,22 @@ func validateMCPAppResource(result *mcp.ReadResourceResult) error {
 	return nil
 }
 
-func newMCPAppsHTTPClient(headers map[string]string) *http.Client {
+func newMCPAppsHTTPClient(headers map[string]string, tokens oauth2.TokenSource) *http.Client {
+	base := http.DefaultTransport
+	if tokens != nil {
+		// The bearer token replaces any Authorization header from headersFrom.
+		base = &oauth2.Transport{Source: tokens, Base: base}
+	}
 	if len(headers) == 0 {
-		return http.DefaultClient
+		if tokens == nil {
+			return http.DefaultClient
+		}
+		return &http.Client{Transport: base}
 	}
 	return &http.Client{
 		Transport: &mcpAppsHeaderTransport{
 			headers: headers,
-			base:    http.DefaultTransport,
+			base:    base,
 		},
 	}
 }
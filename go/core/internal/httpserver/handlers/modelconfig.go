@@ -92,7 +92,7 @@ func (h *ModelConfigHandler) HandleGetModelConfig(w ErrorResponseWriter, r *http
 	if err := h.KubeClient.Get(r.Context(), client.ObjectKey{Namespace: namespace, Name: configName}, modelConfig); err != nil {
 		if apierrors.IsNotFound(err) {
 			log.Info("ModelConfig not found")
-			w.RespondWithError(errors.NewNotFoundError("ModelConfig not found", nil))
+			w.RespondWithError(errors.NewNotFoundError("ModelConfig not found", nil).WithCode(api.ErrorCodeModelConfigNotFound).WithDetail("modelConfig", namespace+"/"+configName))
 			return
 		}
 		log.Error(err, "Failed to get ModelConfig")
@@ -112,14 +112,14 @@ func (h *ModelConfigHandler) HandleCreateModelConfig(w ErrorResponseWriter, r *h
 	var req api.CreateModelConfigRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		log.Error(err, "Failed to decode request body")
-		w.RespondWithError(errors.NewBadRequestError("Invalid request body", err))
+		w.RespondWithError(errors.NewBadRequestError("Invalid request body", err).WithCode(api.ErrorCodeInvalidRequestBody))
 		return
 	}
 
 	modelConfigRef, err := common.ParseRefString(req.Ref, common.GetResourceNamespace())
 	if err != nil {
 		log.Error(err, "Failed to parse Ref")
-		w.RespondWithError(errors.NewBadRequestError("Invalid Ref", err))
+		w.RespondWithError(errors.NewBadRequestError("Invalid Ref", err).WithCode(api.ErrorCodeModelConfigInvalid))
 		return
 	}
 
@@ -131,11 +131,11 @@ func (h *ModelConfigHandler) HandleCreateModelConfig(w ErrorResponseWriter, r *h
 	}
 
 	if err := validateAPIKeySecretRef(req.Spec.APIKeySecret, req.Spec.APIKeySecretKey, req.Spec.Provider); err != nil {
-		w.RespondWithError(errors.NewBadRequestError(err.Error(), err))
+		w.RespondWithError(errors.NewBadRequestError(err.Error(), err).WithCode(api.ErrorCodeModelConfigInvalid))
 		return
 	}
 	if err := validateSecretMaterials(req.Secrets); err != nil {
-		w.RespondWithError(errors.NewBadRequestError(err.Error(), err))
+		w.RespondWithError(errors.NewBadRequestError(err.Error(), err).WithCode(api.ErrorCodeModelConfigInvalid))
 		return
 	}
 
@@ -143,7 +143,7 @@ func (h *ModelConfigHandler) HandleCreateModelConfig(w ErrorResponseWriter, r *h
 	existingConfig := &v1alpha2.ModelConfig{}
 	if err := h.KubeClient.Get(r.Context(), modelConfigRef, existingConfig); err == nil {
 		log.Info("ModelConfig already exists")
-		w.RespondWithError(errors.NewConflictError("ModelConfig already exists", nil))
+		w.RespondWithError(errors.NewConflictError("ModelConfig already exists", nil).WithCode(api.ErrorCodeModelConfigExists))
 		return
 	} else if !apierrors.IsNotFound(err) {
 		log.Error(err, "Failed to check if ModelConfig exists")
@@ -224,7 +224,7 @@ func (h *ModelConfigHandler) HandleUpdateModelConfig(w ErrorResponseWriter, r *h
 	var req api.UpdateModelConfigRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		log.Error(err, "Failed to decode request body")
-		w.RespondWithError(errors.NewBadRequestError("Invalid request body", err))
+		w.RespondWithError(errors.NewBadRequestError("Invalid request body", err).WithCode(api.ErrorCodeInvalidRequestBody))
 		return
 	}
 
@@ -234,11 +234,11 @@ func (h *ModelConfigHandler) HandleUpdateModelConfig(w ErrorResponseWriter, r *h
 	}
 
 	if err := validateAPIKeySecretRef(req.Spec.APIKeySecret, req.Spec.APIKeySecretKey, req.Spec.Provider); err != nil {
-		w.RespondWithError(errors.NewBadRequestError(err.Error(), err))
+		w.RespondWithError(errors.NewBadRequestError(err.Error(), err).WithCode(api.ErrorCodeModelConfigInvalid))
 		return
 	}
 	if err := validateSecretMaterials(req.Secrets); err != nil {
-		w.RespondWithError(errors.NewBadRequestError(err.Error(), err))
+		w.RespondWithError(errors.NewBadRequestError(err.Error(), err).WithCode(api.ErrorCodeModelConfigInvalid))
 		return
 	}
 
@@ -247,7 +247,7 @@ func (h *ModelConfigHandler) HandleUpdateModelConfig(w ErrorResponseWriter, r *h
 	if err := h.KubeClient.Get(r.Context(), client.ObjectKey{Namespace: namespace, Name: configName}, modelConfig); err != nil {
 		if apierrors.IsNotFound(err) {
 			log.Info("ModelConfig not found")
-			w.RespondWithError(errors.NewNotFoundError("ModelConfig not found", nil))
+			w.RespondWithError(errors.NewNotFoundError("ModelConfig not found", nil).WithCode(api.ErrorCodeModelConfigNotFound).WithDetail("modelConfig", namespace+"/"+configName))
 			return
 		}
 		log.Error(err, "Failed to get ModelConfig")
@@ -355,7 +355,7 @@ func (h *ModelConfigHandler) HandleDeleteModelConfig(w ErrorResponseWriter, r *h
 	if err := h.KubeClient.Get(r.Context(), client.ObjectKey{Namespace: namespace, Name: configName}, existingConfig); err != nil {
 		if apierrors.IsNotFound(err) {
 			log.Info("ModelConfig not found")
-			w.RespondWithError(errors.NewNotFoundError("ModelConfig not found", nil))
+			w.RespondWithError(errors.NewNotFoundError("ModelConfig not found", nil).WithCode(api.ErrorCodeModelConfigNotFound).WithDetail("modelConfig", namespace+"/"+configName))
 			return
 		}
 		log.Error(err, "Failed to get ModelConfig")
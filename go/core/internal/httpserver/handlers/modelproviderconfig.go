@@ -49,7 +49,7 @@ func getRequiredKeysForModelProvider(providerType v1alpha2.ModelProvider) []stri
 		return []string{"azureEndpoint", "apiVersion"}
 	case v1alpha2.ModelProviderBedrock:
 		return []string{"region"}
-	case v1alpha2.ModelProviderSAPAICore:
+	case v1alpha2.ModelProviderSAPAICore, v1alpha2.ModelProviderOpenAICompatible:
 		return []string{"baseUrl"}
 	case v1alpha2.ModelProviderOpenAI, v1alpha2.ModelProviderAnthropic, v1alpha2.ModelProviderOllama:
 		// These providers currently have no fields marked as strictly required in the API definition
@@ -128,6 +128,7 @@ func (h *ModelProviderConfigHandler) HandleListSupportedModelProviders(w ErrorRe
 		{v1alpha2.ModelProviderAnthropicVertexAI, reflect.TypeFor[v1alpha2.AnthropicVertexAIConfig]()},
 		{v1alpha2.ModelProviderBedrock, reflect.TypeFor[v1alpha2.BedrockConfig]()},
 		{v1alpha2.ModelProviderSAPAICore, reflect.TypeFor[v1alpha2.SAPAICoreConfig]()},
+		{v1alpha2.ModelProviderOpenAICompatible, reflect.TypeFor[v1alpha2.OpenAICompatibleConfig]()},
 	}
 
 	providersResponse := []map[string]any{}
@@ -101,7 +101,7 @@ func (h *PromptTemplatesHandler) HandleGetPromptTemplate(w ErrorResponseWriter,
 	cm := &corev1.ConfigMap{}
 	if err := h.KubeClient.Get(r.Context(), client.ObjectKey{Namespace: namespace, Name: name}, cm); err != nil {
 		if apierrors.IsNotFound(err) {
-			w.RespondWithError(errors.NewNotFoundError("ConfigMap not found", err))
+			w.RespondWithError(errors.NewNotFoundError("ConfigMap not found", err).WithCode(api.ErrorCodePromptTemplateNotFound))
 			return
 		}
 		w.RespondWithError(errors.NewInternalServerError("Failed to get ConfigMap", err))
@@ -127,7 +127,7 @@ func (h *PromptTemplatesHandler) HandleCreatePromptTemplate(w ErrorResponseWrite
 
 	var req api.CreatePromptTemplateRequest
 	if err := DecodeJSONBody(r, &req); err != nil {
-		w.RespondWithError(errors.NewBadRequestError("Invalid request body", err))
+		w.RespondWithError(errors.NewBadRequestError("Invalid request body", err).WithCode(api.ErrorCodeInvalidRequestBody))
 		return
 	}
 	if errMsg := validatePromptTemplateRequest(req); errMsg != "" {
@@ -183,7 +183,7 @@ func (h *PromptTemplatesHandler) HandleUpdatePromptTemplate(w ErrorResponseWrite
 
 	var req api.UpdatePromptTemplateRequest
 	if err := DecodeJSONBody(r, &req); err != nil {
-		w.RespondWithError(errors.NewBadRequestError("Invalid request body", err))
+		w.RespondWithError(errors.NewBadRequestError("Invalid request body", err).WithCode(api.ErrorCodeInvalidRequestBody))
 		return
 	}
 	if len(req.Data) == 0 {
@@ -194,7 +194,7 @@ func (h *PromptTemplatesHandler) HandleUpdatePromptTemplate(w ErrorResponseWrite
 	cm := &corev1.ConfigMap{}
 	if err := h.KubeClient.Get(r.Context(), client.ObjectKey{Namespace: namespace, Name: name}, cm); err != nil {
 		if apierrors.IsNotFound(err) {
-			w.RespondWithError(errors.NewNotFoundError("ConfigMap not found", err))
+			w.RespondWithError(errors.NewNotFoundError("ConfigMap not found", err).WithCode(api.ErrorCodePromptTemplateNotFound))
 			return
 		}
 		w.RespondWithError(errors.NewInternalServerError("Failed to get ConfigMap", err))
@@ -238,7 +238,7 @@ func (h *PromptTemplatesHandler) HandleDeletePromptTemplate(w ErrorResponseWrite
 	cm := &corev1.ConfigMap{}
 	if err := h.KubeClient.Get(r.Context(), client.ObjectKey{Namespace: namespace, Name: name}, cm); err != nil {
 		if apierrors.IsNotFound(err) {
-			w.RespondWithError(errors.NewNotFoundError("ConfigMap not found", err))
+			w.RespondWithError(errors.NewNotFoundError("ConfigMap not found", err).WithCode(api.ErrorCodePromptTemplateNotFound))
 			return
 		}
 		w.RespondWithError(errors.NewInternalServerError("Failed to get ConfigMap", err))
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/kagent-dev/kagent/go/api/database"
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
+	"github.com/kagent-dev/kagent/go/core/internal/httpserver/errors"
+	"github.com/kagent-dev/kagent/go/core/internal/retention"
+	"github.com/kagent-dev/kagent/go/core/pkg/auth"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// RetentionHandler prunes session history on demand
+type RetentionHandler struct {
+	*Base
+	policy database.RetentionPolicy
+}
+
+// NewRetentionHandler creates a new RetentionHandler. policy is the
+// controller's retention policy, which requests override limit by limit.
+func NewRetentionHandler(base *Base, policy database.RetentionPolicy) *RetentionHandler {
+	return &RetentionHandler{Base: base, policy: policy}
+}
+
+// HandlePrune handles POST /api/retention/prune. It deletes the sessions,
+// events, tasks and push notifications of every user that are past the
+// retention policy.
+func (h *RetentionHandler) HandlePrune(w ErrorResponseWriter, r *http.Request) {
+	log := ctrllog.FromContext(r.Context()).WithName("retention-handler").WithValues("operation", "prune")
+
+	if err := Check(h.Authorizer, r, auth.Resource{Type: "Retention"}); err != nil {
+		w.RespondWithError(err)
+		return
+	}
+
+	var req api.PruneRequest
+	if err := DecodeJSONBody(r, &req); err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Invalid request body", err).WithCode(api.ErrorCodeInvalidRequestBody))
+		return
+	}
+	policy, err := retentionPolicy(h.policy, &req)
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Invalid retention limits", err))
+		return
+	}
+	if !retention.Enabled(policy) {
+		w.RespondWithError(errors.NewBadRequestError("No retention limits set", fmt.Errorf("set a limit in the request or configure the controller's retention policy")))
+		return
+	}
+
+	result, err := retention.Prune(r.Context(), h.DatabaseService, policy, req.DryRun)
+	if err != nil {
+		w.RespondWithError(errors.NewInternalServerError("Failed to prune history", err))
+		return
+	}
+
+	msg := "Successfully pruned history"
+	if req.DryRun {
+		msg = "Dry run, nothing was deleted"
+	}
+	log.Info(msg, "dryRun", req.DryRun, "sessions", result.Sessions, "events", result.Events,
+		"tasks", result.Tasks, "pushNotifications", result.PushNotifications, "streamEvents", result.StreamEvents)
+	RespondWithJSON(w, http.StatusOK, api.NewResponse(api.PruneResponse{PruneResult: *result, DryRun: req.DryRun}, msg, false))
+}
+
+// retentionPolicy overrides the limits of policy that req sets.
+func retentionPolicy(policy database.RetentionPolicy, req *api.PruneRequest) (database.RetentionPolicy, error) {
+	for _, limit := range []struct {
+		name   string
+		raw    string
+		target *time.Duration
+	}{
+		{"sessionMaxAge", req.SessionMaxAge, &policy.SessionMaxAge},
+		{"taskMaxAge", req.TaskMaxAge, &policy.TaskMaxAge},
+		{"eventMaxAge", req.EventMaxAge, &policy.EventMaxAge},
+		{"pushNotificationMaxAge", req.PushNotificationMaxAge, &policy.PushNotificationMaxAge},
+		{"streamEventMaxAge", req.StreamEventMaxAge, &policy.StreamEventMaxAge},
+	} {
+		if limit.raw == "" {
+			continue
+		}
+		d, err := time.ParseDuration(limit.raw)
+		if err != nil {
+			return policy, fmt.Errorf("invalid %s: %w", limit.name, err)
+		}
+		if d < 0 {
+			return policy, fmt.Errorf("%s must not be negative", limit.name)
+		}
+		*limit.target = d
+	}
+	if req.MaxSessions != nil {
+		if *req.MaxSessions < 0 {
+			return policy, fmt.Errorf("maxSessions must not be negative")
+		}
+		policy.MaxSessions = *req.MaxSessions
+	}
+	return policy, nil
+}
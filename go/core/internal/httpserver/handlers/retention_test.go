@@ -0,0 +1,96 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kagent-dev/kagent/go/api/database"
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
+	"github.com/kagent-dev/kagent/go/core/internal/httpserver/auth"
+	"github.com/kagent-dev/kagent/go/core/internal/httpserver/handlers"
+)
+
+func TestRetentionHandler(t *testing.T) {
+	agentID := "default__NS__test_agent"
+	setup := func(t *testing.T, policy database.RetentionPolicy) (*handlers.RetentionHandler, database.Client) {
+		t.Helper()
+		dbClient := setupTestDBClient(t)
+		ctx := context.Background()
+		for _, id := range []string{"old", "new"} {
+			require.NoError(t, dbClient.StoreSession(ctx, &database.Session{ID: id, UserID: "test-user", AgentID: &agentID}))
+			require.NoError(t, dbClient.StoreEvents(ctx, &database.Event{ID: id + "-event", SessionID: id, UserID: "test-user", Data: "{}"}))
+		}
+		return handlers.NewRetentionHandler(&handlers.Base{
+			DatabaseService: dbClient,
+			Authorizer:      &auth.NoopAuthorizer{},
+		}, policy), dbClient
+	}
+
+	prune := func(t *testing.T, handler *handlers.RetentionHandler, req api.PruneRequest) *httptest.ResponseRecorder {
+		t.Helper()
+		body, err := json.Marshal(req)
+		require.NoError(t, err)
+		w := httptest.NewRecorder()
+		r := setUser(httptest.NewRequest(http.MethodPost, "/api/retention/prune", bytes.NewReader(body)), "test-user")
+		handler.HandlePrune(&testErrorResponseWriter{w}, r)
+		return w
+	}
+
+	decode := func(t *testing.T, w *httptest.ResponseRecorder) api.PruneResponse {
+		t.Helper()
+		var response api.StandardResponse[api.PruneResponse]
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response.Data
+	}
+
+	t.Run("prunes sessions over the count limit with their events", func(t *testing.T) {
+		handler, dbClient := setup(t, database.RetentionPolicy{MaxSessions: 1})
+		w := prune(t, handler, api.PruneRequest{})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.Equal(t, database.PruneResult{Sessions: 1, Events: 1}, decode(t, w).PruneResult)
+
+		_, err := dbClient.GetSession(context.Background(), "old", "test-user")
+		require.Error(t, err)
+		session, err := dbClient.GetSession(context.Background(), "new", "test-user")
+		require.NoError(t, err)
+		require.Equal(t, "new", session.ID)
+	})
+
+	t.Run("dry run deletes nothing", func(t *testing.T) {
+		handler, dbClient := setup(t, database.RetentionPolicy{})
+		w := prune(t, handler, api.PruneRequest{DryRun: true, MaxSessions: new(1)})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		response := decode(t, w)
+		require.True(t, response.DryRun)
+		require.Equal(t, int64(1), response.Sessions)
+
+		sessions, err := dbClient.ListSessions(context.Background(), "test-user")
+		require.NoError(t, err)
+		require.Len(t, sessions, 2)
+	})
+
+	t.Run("request overrides the policy", func(t *testing.T) {
+		handler, _ := setup(t, database.RetentionPolicy{MaxSessions: 1})
+		w := prune(t, handler, api.PruneRequest{MaxSessions: new(0), EventMaxAge: "24h"})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.Equal(t, database.PruneResult{}, decode(t, w).PruneResult)
+	})
+
+	t.Run("no limits is a bad request", func(t *testing.T) {
+		handler, _ := setup(t, database.RetentionPolicy{})
+		w := prune(t, handler, api.PruneRequest{})
+		require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+	})
+
+	t.Run("invalid limit is a bad request", func(t *testing.T) {
+		handler, _ := setup(t, database.RetentionPolicy{})
+		w := prune(t, handler, api.PruneRequest{SessionMaxAge: "a month"})
+		require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+	})
+}
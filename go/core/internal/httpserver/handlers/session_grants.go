@@ -0,0 +1,180 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	dbpkg "github.com/kagent-dev/kagent/go/api/database"
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
+	"github.com/kagent-dev/kagent/go/core/internal/httpserver/errors"
+	"github.com/kagent-dev/kagent/go/core/pkg/auth"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// ResolveSessionGrant returns a ShareContext for a session another user has
+// shared with principal through a session grant, or nil when principal owns
+// the session. Access is checked with authorizer, so a grant the authorizer
+// does not allow to invoke the session yields a read-only context.
+func ResolveSessionGrant(ctx context.Context, db dbpkg.Client, authorizer auth.Authorizer, principal auth.Principal, sessionID string) (*auth.ShareContext, *errors.APIError) {
+	if _, err := db.GetSession(ctx, sessionID, principal.User.ID); err == nil {
+		return nil, nil
+	}
+	grant, err := db.GetSessionGrantForPrincipal(ctx, sessionID, principal.User.ID, principal.Groups())
+	if err != nil {
+		return nil, errors.NewNotFoundError("Session not found", err).WithCode(api.ErrorCodeSessionNotFound)
+	}
+	resource := auth.Resource{Type: "Session", Name: sessionID}
+	if err := authorizer.Check(ctx, principal, auth.VerbGet, resource); err != nil {
+		return nil, errors.NewForbiddenError("Not authorized", err)
+	}
+	return &auth.ShareContext{
+		SessionID: sessionID,
+		UserID:    grant.OwnerID,
+		ReadOnly:  authorizer.Check(ctx, principal, auth.VerbInvoke, resource) != nil,
+	}, nil
+}
+
+// sessionGrantPath parses the {session_id}, {kind} and {principal} path params
+// of the session grant endpoints.
+func sessionGrantPath(r *http.Request) (string, dbpkg.SessionPrincipalKind, string, *errors.APIError) {
+	sessionID, err := GetPathParam(r, "session_id")
+	if err != nil {
+		return "", "", "", errors.NewBadRequestError("missing session_id", err)
+	}
+	kind, err := GetPathParam(r, "kind")
+	if err != nil {
+		return "", "", "", errors.NewBadRequestError("missing kind", err)
+	}
+	switch dbpkg.SessionPrincipalKind(kind) {
+	case dbpkg.SessionPrincipalUser, dbpkg.SessionPrincipalGroup:
+	default:
+		return "", "", "", errors.NewBadRequestError(fmt.Sprintf("kind must be %q or %q", dbpkg.SessionPrincipalUser, dbpkg.SessionPrincipalGroup), nil)
+	}
+	principal, err := GetPathParam(r, "principal")
+	if err != nil {
+		return "", "", "", errors.NewBadRequestError("missing principal", err)
+	}
+	return sessionID, dbpkg.SessionPrincipalKind(kind), principal, nil
+}
+
+// HandleListSessionGrants handles GET /api/sessions/{session_id}/grants.
+// Only the session owner may list grants.
+func (h *SessionSharesHandler) HandleListSessionGrants(w ErrorResponseWriter, r *http.Request) {
+	log := ctrllog.FromContext(r.Context()).WithName("session-grants").WithValues("op", "list")
+
+	sessionID, err := GetPathParam(r, "session_id")
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("missing session_id", err))
+		return
+	}
+
+	userID, err := GetUserID(r)
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("failed to get user ID", err))
+		return
+	}
+
+	// Verify the session belongs to the caller.
+	if _, err := h.DatabaseService.GetSession(r.Context(), sessionID, userID); err != nil {
+		w.RespondWithError(errors.NewNotFoundError("session not found", err).WithCode(api.ErrorCodeSessionNotFound))
+		return
+	}
+
+	grants, err := h.DatabaseService.ListSessionGrants(r.Context(), sessionID, userID)
+	if err != nil {
+		w.RespondWithError(errors.NewInternalServerError("failed to list grants", err))
+		return
+	}
+
+	log.V(1).Info("listed session grants", "sessionID", sessionID, "count", len(grants))
+	RespondWithJSON(w, http.StatusOK, api.NewResponse(grants, "grants listed", false))
+}
+
+// HandlePutSessionGrant handles PUT /api/sessions/{session_id}/grants/{kind}/{principal}.
+// It gives the user or group read or invoke access to the session, replacing
+// any access it had. Only the session owner may grant access.
+func (h *SessionSharesHandler) HandlePutSessionGrant(w ErrorResponseWriter, r *http.Request) {
+	log := ctrllog.FromContext(r.Context()).WithName("session-grants").WithValues("op", "put")
+
+	sessionID, kind, principal, apiErr := sessionGrantPath(r)
+	if apiErr != nil {
+		w.RespondWithError(apiErr)
+		return
+	}
+
+	userID, err := GetUserID(r)
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("failed to get user ID", err))
+		return
+	}
+	if kind == dbpkg.SessionPrincipalUser && principal == userID {
+		w.RespondWithError(errors.NewBadRequestError("cannot grant access to the session owner", nil))
+		return
+	}
+
+	var body api.SessionGrantRequest
+	if err := DecodeJSONBody(r, &body); err != nil {
+		w.RespondWithError(errors.NewBadRequestError("invalid request body", err).WithCode(api.ErrorCodeInvalidRequestBody))
+		return
+	}
+	switch body.Access {
+	case dbpkg.SessionAccessRead, dbpkg.SessionAccessInvoke:
+	default:
+		w.RespondWithError(errors.NewBadRequestError(fmt.Sprintf("access must be %q or %q", dbpkg.SessionAccessRead, dbpkg.SessionAccessInvoke), nil))
+		return
+	}
+
+	// Verify the session belongs to the caller.
+	if _, err := h.DatabaseService.GetSession(r.Context(), sessionID, userID); err != nil {
+		w.RespondWithError(errors.NewNotFoundError("session not found", err).WithCode(api.ErrorCodeSessionNotFound))
+		return
+	}
+
+	grant, err := h.DatabaseService.UpsertSessionGrant(r.Context(), &dbpkg.SessionGrant{
+		SessionID:     sessionID,
+		OwnerID:       userID,
+		PrincipalKind: kind,
+		Principal:     principal,
+		Access:        body.Access,
+	})
+	if err != nil {
+		w.RespondWithError(errors.NewInternalServerError("failed to save grant", err))
+		return
+	}
+
+	log.Info("granted session access", "sessionID", sessionID, "kind", kind, "principal", principal, "access", body.Access)
+	RespondWithJSON(w, http.StatusOK, api.NewResponse(grant, "grant saved", false))
+}
+
+// HandleDeleteSessionGrant handles DELETE /api/sessions/{session_id}/grants/{kind}/{principal}.
+// Only the session owner may revoke access.
+func (h *SessionSharesHandler) HandleDeleteSessionGrant(w ErrorResponseWriter, r *http.Request) {
+	log := ctrllog.FromContext(r.Context()).WithName("session-grants").WithValues("op", "delete")
+
+	sessionID, kind, principal, apiErr := sessionGrantPath(r)
+	if apiErr != nil {
+		w.RespondWithError(apiErr)
+		return
+	}
+
+	userID, err := GetUserID(r)
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("failed to get user ID", err))
+		return
+	}
+
+	// Verify the session belongs to the caller before attempting deletion.
+	if _, err := h.DatabaseService.GetSession(r.Context(), sessionID, userID); err != nil {
+		w.RespondWithError(errors.NewNotFoundError("session not found", err).WithCode(api.ErrorCodeSessionNotFound))
+		return
+	}
+
+	if err := h.DatabaseService.DeleteSessionGrant(r.Context(), sessionID, userID, kind, principal); err != nil {
+		w.RespondWithError(errors.NewInternalServerError("failed to delete grant", err))
+		return
+	}
+
+	log.Info("revoked session access", "sessionID", sessionID, "kind", kind, "principal", principal)
+	RespondWithJSON(w, http.StatusOK, api.NewResponse(struct{}{}, "grant deleted", false))
+}
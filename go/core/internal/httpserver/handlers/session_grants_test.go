@@ -0,0 +1,111 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbpkg "github.com/kagent-dev/kagent/go/api/database"
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
+	authimpl "github.com/kagent-dev/kagent/go/core/internal/httpserver/auth"
+	"github.com/kagent-dev/kagent/go/core/internal/httpserver/handlers"
+)
+
+func TestSessionGrants(t *testing.T) {
+	setup := func(t *testing.T) (*handlers.SessionSharesHandler, *handlers.SessionsHandler, dbpkg.Client) {
+		t.Helper()
+		dbClient := setupTestDBClient(t)
+		base := &handlers.Base{
+			DatabaseService: dbClient,
+			Authorizer:      &authimpl.SessionGrantAuthorizer{Next: &authimpl.NoopAuthorizer{}, Store: dbClient},
+		}
+		agentID := "agent-1"
+		require.NoError(t, dbClient.StoreSession(context.Background(), &dbpkg.Session{ID: "sess-1", UserID: "owner", AgentID: &agentID}))
+		return handlers.NewSessionSharesHandler(base), handlers.NewSessionsHandler(base, nil, nil), dbClient
+	}
+
+	grant := func(t *testing.T, h *handlers.SessionSharesHandler, userID, kind, principal, access string) *mockErrorResponseWriter {
+		t.Helper()
+		body, _ := json.Marshal(map[string]string{"access": access})
+		req := httptest.NewRequest(http.MethodPut, "/api/sessions/sess-1/grants/"+kind+"/"+principal, bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req = mux.SetURLVars(req, map[string]string{"session_id": "sess-1", "kind": kind, "principal": principal})
+		w := newMockErrorResponseWriter()
+		h.HandlePutSessionGrant(w, setUser(req, userID))
+		return w
+	}
+
+	getSession := func(h *handlers.SessionsHandler, userID string) *mockErrorResponseWriter {
+		req := httptest.NewRequest(http.MethodGet, "/api/sessions/sess-1", nil)
+		req = mux.SetURLVars(req, map[string]string{"session_id": "sess-1"})
+		w := newMockErrorResponseWriter()
+		h.HandleGetSession(w, setUser(req, userID))
+		return w
+	}
+
+	t.Run("grantee reads the owner's session read-only", func(t *testing.T) {
+		shares, sessions, _ := setup(t)
+
+		assert.Equal(t, http.StatusNotFound, getSession(sessions, "alice").Code)
+
+		w := grant(t, shares, "owner", "user", "alice", "read")
+		require.Equal(t, http.StatusOK, w.Code)
+
+		w = getSession(sessions, "alice")
+		require.Equal(t, http.StatusOK, w.Code)
+		var response api.StandardResponse[handlers.SessionResponse]
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "owner", response.Data.Session.UserID)
+		require.NotNil(t, response.Data.ReadOnly)
+		assert.True(t, *response.Data.ReadOnly)
+	})
+
+	t.Run("invoke grant is not read-only", func(t *testing.T) {
+		shares, sessions, _ := setup(t)
+		require.Equal(t, http.StatusOK, grant(t, shares, "owner", "user", "alice", "invoke").Code)
+
+		w := getSession(sessions, "alice")
+		require.Equal(t, http.StatusOK, w.Code)
+		var response api.StandardResponse[handlers.SessionResponse]
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Nil(t, response.Data.ReadOnly)
+	})
+
+	t.Run("only the owner manages grants", func(t *testing.T) {
+		shares, _, _ := setup(t)
+		assert.Equal(t, http.StatusNotFound, grant(t, shares, "alice", "user", "bob", "read").Code)
+		assert.Equal(t, http.StatusBadRequest, grant(t, shares, "owner", "user", "owner", "read").Code)
+		assert.Equal(t, http.StatusBadRequest, grant(t, shares, "owner", "team", "sre", "read").Code)
+		assert.Equal(t, http.StatusBadRequest, grant(t, shares, "owner", "group", "sre", "admin").Code)
+	})
+
+	t.Run("list and revoke", func(t *testing.T) {
+		shares, sessions, _ := setup(t)
+		require.Equal(t, http.StatusOK, grant(t, shares, "owner", "user", "alice", "read").Code)
+		require.Equal(t, http.StatusOK, grant(t, shares, "owner", "group", "sre", "invoke").Code)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/sessions/sess-1/grants", nil)
+		req = mux.SetURLVars(req, map[string]string{"session_id": "sess-1"})
+		w := newMockErrorResponseWriter()
+		shares.HandleListSessionGrants(w, setUser(req, "owner"))
+		require.Equal(t, http.StatusOK, w.Code)
+		var response api.StandardResponse[[]dbpkg.SessionGrant]
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Len(t, response.Data, 2)
+
+		req = httptest.NewRequest(http.MethodDelete, "/api/sessions/sess-1/grants/user/alice", nil)
+		req = mux.SetURLVars(req, map[string]string{"session_id": "sess-1", "kind": "user", "principal": "alice"})
+		w = newMockErrorResponseWriter()
+		shares.HandleDeleteSessionGrant(w, setUser(req, "owner"))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		assert.Equal(t, http.StatusNotFound, getSession(sessions, "alice").Code)
+	})
+}
@@ -58,7 +58,7 @@ func (h *SessionSharesHandler) HandleCreateSessionShare(w ErrorResponseWriter, r
 	if r.Body != nil && r.ContentLength != 0 {
 		var body createSessionShareRequest
 		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-			w.RespondWithError(errors.NewBadRequestError("invalid request body", err))
+			w.RespondWithError(errors.NewBadRequestError("invalid request body", err).WithCode(api.ErrorCodeInvalidRequestBody))
 			return
 		}
 		if body.ReadOnly != nil {
@@ -68,7 +68,7 @@ func (h *SessionSharesHandler) HandleCreateSessionShare(w ErrorResponseWriter, r
 
 	// Verify the session belongs to the caller.
 	if _, err := h.DatabaseService.GetSession(r.Context(), sessionID, userID); err != nil {
-		w.RespondWithError(errors.NewNotFoundError("session not found", err))
+		w.RespondWithError(errors.NewNotFoundError("session not found", err).WithCode(api.ErrorCodeSessionNotFound))
 		return
 	}
 
@@ -113,7 +113,7 @@ func (h *SessionSharesHandler) HandleListSessionShares(w ErrorResponseWriter, r
 
 	// Verify the session belongs to the caller.
 	if _, err := h.DatabaseService.GetSession(r.Context(), sessionID, userID); err != nil {
-		w.RespondWithError(errors.NewNotFoundError("session not found", err))
+		w.RespondWithError(errors.NewNotFoundError("session not found", err).WithCode(api.ErrorCodeSessionNotFound))
 		return
 	}
 
@@ -152,7 +152,7 @@ func (h *SessionSharesHandler) HandleDeleteSessionShare(w ErrorResponseWriter, r
 
 	// Verify the session belongs to the caller before attempting deletion.
 	if _, err := h.DatabaseService.GetSession(r.Context(), sessionID, userID); err != nil {
-		w.RespondWithError(errors.NewNotFoundError("session not found", err))
+		w.RespondWithError(errors.NewNotFoundError("session not found", err).WithCode(api.ErrorCodeSessionNotFound))
 		return
 	}
 
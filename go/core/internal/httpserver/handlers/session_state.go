@@ -0,0 +1,193 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	dbpkg "github.com/kagent-dev/kagent/go/api/database"
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
+	"github.com/kagent-dev/kagent/go/core/internal/httpserver/errors"
+	"github.com/kagent-dev/kagent/go/core/internal/utils"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// maxSessionStateKeyLength bounds the length of a session state key.
+	maxSessionStateKeyLength = 256
+	// maxSessionStateValueBytes bounds the JSON encoding of a session state
+	// value. The store is meant for facts an agent remembers, not documents.
+	maxSessionStateValueBytes = 64 << 10
+)
+
+// sessionStatePath parses the {session_id} and {key} path params of the
+// session state endpoints.
+func sessionStatePath(r *http.Request) (string, string, *errors.APIError) {
+	sessionID, err := GetPathParam(r, "session_id")
+	if err != nil {
+		return "", "", errors.NewBadRequestError("Failed to get session ID from path", err)
+	}
+	key, err := GetPathParam(r, "key")
+	if err != nil {
+		return "", "", errors.NewBadRequestError("Failed to get key from path", err)
+	}
+	if len(key) > maxSessionStateKeyLength {
+		return "", "", errors.NewBadRequestError(fmt.Sprintf("key must be at most %d characters", maxSessionStateKeyLength), nil)
+	}
+	return sessionID, key, nil
+}
+
+// sessionWriter returns sessionID's session for a request that writes to its
+// state or traces. The session must exist and, when the caller is an agent,
+// belong to that agent. Read-only shares and grants are rejected by the
+// middleware.
+func (h *SessionsHandler) sessionWriter(r *http.Request, sessionID string) (*dbpkg.Session, *errors.APIError) {
+	principal, err := GetPrincipal(r)
+	if err != nil {
+		return nil, errors.NewBadRequestError("Failed to get user ID", err)
+	}
+	userID, err := getEffectiveUserIDForSession(r, sessionID)
+	if err != nil {
+		return nil, errors.NewBadRequestError("Failed to get user ID", err)
+	}
+	session, err := h.DatabaseService.GetSession(r.Context(), sessionID, userID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("Session not found", err).WithCode(api.ErrorCodeSessionNotFound)
+	}
+	if principal.Agent.ID != "" && session.AgentID != nil && *session.AgentID != utils.ConvertToPythonIdentifier(principal.Agent.ID) {
+		return nil, errors.NewForbiddenError("Session does not belong to this agent", nil)
+	}
+	return session, nil
+}
+
+// HandleListSessionState handles GET /api/sessions/{session_id}/state requests.
+func (h *SessionsHandler) HandleListSessionState(w ErrorResponseWriter, r *http.Request) {
+	log := ctrllog.FromContext(r.Context()).WithName("sessions-handler").WithValues("operation", "list-state")
+
+	sessionID, err := GetPathParam(r, "session_id")
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get session ID from path", err))
+		return
+	}
+	userID, _, apiErr := h.sessionReader(r, sessionID)
+	if apiErr != nil {
+		w.RespondWithError(apiErr)
+		return
+	}
+	if _, err := h.DatabaseService.GetSession(r.Context(), sessionID, userID); err != nil {
+		w.RespondWithError(errors.NewNotFoundError("Session not found", err).WithCode(api.ErrorCodeSessionNotFound))
+		return
+	}
+
+	entries, err := h.DatabaseService.ListSessionState(r.Context(), sessionID, userID)
+	if err != nil {
+		w.RespondWithError(errors.NewInternalServerError("Failed to list session state", err))
+		return
+	}
+
+	log.V(1).Info("Listed session state", "session_id", sessionID, "count", len(entries))
+	RespondWithJSON(w, http.StatusOK, api.NewResponse(entries, "Successfully retrieved session state", false))
+}
+
+// HandleGetSessionState handles GET /api/sessions/{session_id}/state/{key} requests.
+func (h *SessionsHandler) HandleGetSessionState(w ErrorResponseWriter, r *http.Request) {
+	sessionID, key, apiErr := sessionStatePath(r)
+	if apiErr != nil {
+		w.RespondWithError(apiErr)
+		return
+	}
+	userID, _, apiErr := h.sessionReader(r, sessionID)
+	if apiErr != nil {
+		w.RespondWithError(apiErr)
+		return
+	}
+	if _, err := h.DatabaseService.GetSession(r.Context(), sessionID, userID); err != nil {
+		w.RespondWithError(errors.NewNotFoundError("Session not found", err).WithCode(api.ErrorCodeSessionNotFound))
+		return
+	}
+
+	entry, err := h.DatabaseService.GetSessionStateValue(r.Context(), sessionID, userID, key)
+	if err != nil {
+		w.RespondWithError(errors.NewInternalServerError("Failed to get session state", err))
+		return
+	}
+	if entry == nil {
+		w.RespondWithError(errors.NewNotFoundError(fmt.Sprintf("Session state key %q not found", key), nil))
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, api.NewResponse(*entry, "Successfully retrieved session state", false))
+}
+
+// HandlePutSessionState handles PUT /api/sessions/{session_id}/state/{key}
+// requests, setting the key to the JSON value in the body.
+func (h *SessionsHandler) HandlePutSessionState(w ErrorResponseWriter, r *http.Request) {
+	log := ctrllog.FromContext(r.Context()).WithName("sessions-handler").WithValues("operation", "put-state")
+
+	sessionID, key, apiErr := sessionStatePath(r)
+	if apiErr != nil {
+		w.RespondWithError(apiErr)
+		return
+	}
+
+	var body api.SessionStateRequest
+	if err := DecodeJSONBody(r, &body); err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Invalid request body", err).WithCode(api.ErrorCodeInvalidRequestBody))
+		return
+	}
+	if len(body.Value) == 0 {
+		w.RespondWithError(errors.NewBadRequestError("value is required", nil))
+		return
+	}
+	if len(body.Value) > maxSessionStateValueBytes {
+		w.RespondWithError(errors.NewBadRequestError(fmt.Sprintf("value must be at most %d bytes", maxSessionStateValueBytes), nil))
+		return
+	}
+
+	session, apiErr := h.sessionWriter(r, sessionID)
+	if apiErr != nil {
+		w.RespondWithError(apiErr)
+		return
+	}
+
+	entry, err := h.DatabaseService.SetSessionStateValue(r.Context(), &dbpkg.SessionStateEntry{
+		SessionID: sessionID,
+		UserID:    session.UserID,
+		Key:       key,
+		Value:     body.Value,
+	})
+	if err != nil {
+		w.RespondWithError(errors.NewInternalServerError("Failed to set session state", err))
+		return
+	}
+
+	log.V(1).Info("Set session state", "session_id", sessionID, "key", key)
+	RespondWithJSON(w, http.StatusOK, api.NewResponse(*entry, "Successfully set session state", false))
+}
+
+// HandleDeleteSessionState handles DELETE /api/sessions/{session_id}/state/{key} requests.
+func (h *SessionsHandler) HandleDeleteSessionState(w ErrorResponseWriter, r *http.Request) {
+	log := ctrllog.FromContext(r.Context()).WithName("sessions-handler").WithValues("operation", "delete-state")
+
+	sessionID, key, apiErr := sessionStatePath(r)
+	if apiErr != nil {
+		w.RespondWithError(apiErr)
+		return
+	}
+	session, apiErr := h.sessionWriter(r, sessionID)
+	if apiErr != nil {
+		w.RespondWithError(apiErr)
+		return
+	}
+
+	deleted, err := h.DatabaseService.DeleteSessionStateValue(r.Context(), sessionID, session.UserID, key)
+	if err != nil {
+		w.RespondWithError(errors.NewInternalServerError("Failed to delete session state", err))
+		return
+	}
+	if !deleted {
+		w.RespondWithError(errors.NewNotFoundError(fmt.Sprintf("Session state key %q not found", key), nil))
+		return
+	}
+
+	log.V(1).Info("Deleted session state", "session_id", sessionID, "key", key)
+	RespondWithJSON(w, http.StatusOK, api.NewResponse(struct{}{}, "Successfully deleted session state", false))
+}
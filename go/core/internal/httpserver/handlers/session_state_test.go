@@ -0,0 +1,125 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbpkg "github.com/kagent-dev/kagent/go/api/database"
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
+	authimpl "github.com/kagent-dev/kagent/go/core/internal/httpserver/auth"
+	"github.com/kagent-dev/kagent/go/core/internal/httpserver/handlers"
+	"github.com/kagent-dev/kagent/go/core/pkg/auth"
+)
+
+func TestSessionState(t *testing.T) {
+	setup := func(t *testing.T) *handlers.SessionsHandler {
+		t.Helper()
+		dbClient := setupTestDBClient(t)
+		base := &handlers.Base{
+			DatabaseService: dbClient,
+			Authorizer:      &authimpl.NoopAuthorizer{},
+		}
+		agentID := "agent_1"
+		require.NoError(t, dbClient.StoreSession(context.Background(), &dbpkg.Session{ID: "sess-1", UserID: "owner", AgentID: &agentID}))
+		return handlers.NewSessionsHandler(base, nil, nil)
+	}
+
+	request := func(method, key string, body any) *http.Request {
+		var buf bytes.Buffer
+		if body != nil {
+			_ = json.NewEncoder(&buf).Encode(body)
+		}
+		path := "/api/sessions/sess-1/state"
+		vars := map[string]string{"session_id": "sess-1"}
+		if key != "" {
+			path += "/" + key
+			vars["key"] = key
+		}
+		req := httptest.NewRequest(method, path, &buf)
+		req.Header.Set("Content-Type", "application/json")
+		return mux.SetURLVars(req, vars)
+	}
+
+	put := func(h *handlers.SessionsHandler, req *http.Request) *mockErrorResponseWriter {
+		w := newMockErrorResponseWriter()
+		h.HandlePutSessionState(w, req)
+		return w
+	}
+
+	get := func(h *handlers.SessionsHandler, req *http.Request) *mockErrorResponseWriter {
+		w := newMockErrorResponseWriter()
+		h.HandleGetSessionState(w, req)
+		return w
+	}
+
+	t.Run("set, get, list and delete", func(t *testing.T) {
+		h := setup(t)
+
+		w := put(h, setUser(request(http.MethodPut, "city", map[string]any{"value": "Paris"}), "owner"))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		w = put(h, setUser(request(http.MethodPut, "count", map[string]any{"value": 3}), "owner"))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = get(h, setUser(request(http.MethodGet, "city", nil), "owner"))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var entry api.StandardResponse[api.SessionStateEntry]
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &entry))
+		assert.JSONEq(t, `"Paris"`, string(entry.Data.Value))
+
+		w = newMockErrorResponseWriter()
+		h.HandleListSessionState(w, setUser(request(http.MethodGet, "", nil), "owner"))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var list api.StandardResponse[[]api.SessionStateEntry]
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+		require.Len(t, list.Data, 2)
+		assert.Equal(t, "city", list.Data[0].Key)
+		assert.JSONEq(t, `3`, string(list.Data[1].Value))
+
+		w = newMockErrorResponseWriter()
+		h.HandleDeleteSessionState(w, setUser(request(http.MethodDelete, "city", nil), "owner"))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		assert.Equal(t, http.StatusNotFound, get(h, setUser(request(http.MethodGet, "city", nil), "owner")).Code)
+
+		w = newMockErrorResponseWriter()
+		h.HandleDeleteSessionState(w, setUser(request(http.MethodDelete, "city", nil), "owner"))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("value is required", func(t *testing.T) {
+		h := setup(t)
+		w := put(h, setUser(request(http.MethodPut, "city", map[string]any{}), "owner"))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("other users cannot see the state", func(t *testing.T) {
+		h := setup(t)
+		require.Equal(t, http.StatusOK, put(h, setUser(request(http.MethodPut, "city", map[string]any{"value": "Paris"}), "owner")).Code)
+
+		assert.Equal(t, http.StatusNotFound, get(h, setUser(request(http.MethodGet, "city", nil), "alice")).Code)
+		assert.Equal(t, http.StatusNotFound, put(h, setUser(request(http.MethodPut, "city", map[string]any{"value": "Rome"}), "alice")).Code)
+	})
+
+	t.Run("agent must own the session", func(t *testing.T) {
+		h := setup(t)
+		asAgent := func(req *http.Request, agentID string) *http.Request {
+			return req.WithContext(auth.AuthSessionTo(req.Context(), &authimpl.SimpleSession{
+				P: auth.Principal{User: auth.User{ID: "owner"}, Agent: auth.Agent{ID: agentID}},
+			}))
+		}
+
+		w := put(h, asAgent(request(http.MethodPut, "city", map[string]any{"value": "Paris"}), "other"))
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		w = put(h, asAgent(request(http.MethodPut, "city", map[string]any{"value": "Paris"}), "agent_1"))
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	})
+}
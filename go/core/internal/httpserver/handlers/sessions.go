@@ -2,8 +2,10 @@ package handlers
 
 import (
 	"context"
+	stderrors "errors"
 	"fmt"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -25,13 +27,21 @@ import (
 type SessionsHandler struct {
 	*Base
 	SubstrateSandboxActorBackend *substrate.SandboxAgentActorBackend
+	eventArchive                 SessionEventArchive
 }
 
-// NewSessionsHandler creates a new SessionsHandler
-func NewSessionsHandler(base *Base, substrateSandboxActorBackend *substrate.SandboxAgentActorBackend) *SessionsHandler {
+// SessionEventArchive reads back session events moved out of the database
+// into the artifact store. *eventarchive.Archiver satisfies it.
+type SessionEventArchive interface {
+	LoadEvents(ctx context.Context, sessionID, userID string) ([]*database.Event, error)
+}
+
+// NewSessionsHandler creates a new SessionsHandler. eventArchive may be nil.
+func NewSessionsHandler(base *Base, substrateSandboxActorBackend *substrate.SandboxAgentActorBackend, eventArchive SessionEventArchive) *SessionsHandler {
 	return &SessionsHandler{
 		Base:                         base,
 		SubstrateSandboxActorBackend: substrateSandboxActorBackend,
+		eventArchive:                 eventArchive,
 	}
 }
 
@@ -63,11 +73,17 @@ func (h *SessionsHandler) HandleGetSessionsForAgent(w ErrorResponseWriter, r *ht
 		return
 	}
 
+	params, err := listParamsFromRequest(r, sessionSortFields...)
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError(err.Error(), nil))
+		return
+	}
+
 	// Get agent ID from agent ref. AgentHarnesses are recorded in the same
 	// agent table as regular agents, so the lookup is uniform.
 	agentID := utils.ConvertToPythonIdentifier(namespace + "/" + agentName)
 	if _, err := h.DatabaseService.GetAgent(r.Context(), agentID); err != nil {
-		w.RespondWithError(errors.NewNotFoundError("Agent not found", err))
+		w.RespondWithError(errors.NewNotFoundError("Agent not found", err).WithCode(api.ErrorCodeAgentNotFound))
 		return
 	}
 
@@ -78,12 +94,31 @@ func (h *SessionsHandler) HandleGetSessionsForAgent(w ErrorResponseWriter, r *ht
 		return
 	}
 
-	log.Info("Successfully listed sessions", "count", len(sessions))
-	data := api.NewResponse(sessions, "Successfully listed sessions", false)
+	if !params.CreatedAfter.IsZero() {
+		sessions = slices.DeleteFunc(sessions, func(s database.SessionWithShareToken) bool {
+			return !s.CreatedAt.After(params.CreatedAfter)
+		})
+	}
+	sortItems(sessions, params, map[string]func(a, b database.SessionWithShareToken) int{
+		"name":       func(a, b database.SessionWithShareToken) int { return compareStringPtr(a.Name, b.Name) },
+		"created_at": func(a, b database.SessionWithShareToken) int { return compareTime(a.CreatedAt, b.CreatedAt) },
+		"updated_at": func(a, b database.SessionWithShareToken) int { return compareTime(a.UpdatedAt, b.UpdatedAt) },
+	})
+	page, pagination := paginate(sessions, params)
+
+	log.Info("Successfully listed sessions", "count", len(page))
+	data := api.NewResponse(page, "Successfully listed sessions", false)
+	data.Pagination = pagination
 	RespondWithJSON(w, http.StatusOK, data)
 }
 
-// HandleListSessions handles GET /api/sessions requests using database
+// sessionSortFields are the sort keys accepted by the session list endpoints.
+var sessionSortFields = []string{"name", "created_at", "updated_at"}
+
+// HandleListSessions handles GET /api/sessions requests using database.
+// Optional query params: created_after=<ts>, sort=name|created_at|updated_at,
+// limit=<n> and offset=<n>. Sessions are ordered by most recent activity by
+// default.
 func (h *SessionsHandler) HandleListSessions(w ErrorResponseWriter, r *http.Request) {
 	log := ctrllog.FromContext(r.Context()).WithName("sessions-handler").WithValues("operation", "list-db")
 
@@ -94,15 +129,42 @@ func (h *SessionsHandler) HandleListSessions(w ErrorResponseWriter, r *http.Requ
 	}
 	log = log.WithValues("userID", userID)
 
+	params, err := listParamsFromRequest(r, sessionSortFields...)
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError(err.Error(), nil))
+		return
+	}
+
+	opts := database.SessionListOptions{
+		Offset:       params.Offset,
+		CreatedAfter: params.CreatedAfter,
+		SortBy:       params.SortBy,
+		SortAsc:      params.SortBy != "" && !params.SortDesc,
+	}
+	if params.Limit > 0 {
+		// Fetch one extra row to learn whether another page follows.
+		opts.Limit = params.Limit + 1
+	}
+
 	log.V(1).Info("Listing sessions from database")
-	sessions, err := h.DatabaseService.ListSessions(r.Context(), userID)
+	sessions, err := h.DatabaseService.ListSessionsWithOptions(r.Context(), userID, opts)
 	if err != nil {
 		w.RespondWithError(errors.NewInternalServerError("Failed to list sessions", err))
 		return
 	}
 
+	var pagination *api.Pagination
+	if params.Limit > 0 || params.Offset > 0 {
+		pagination = &api.Pagination{Limit: params.Limit, Offset: params.Offset}
+		if params.Limit > 0 && len(sessions) > params.Limit {
+			sessions = sessions[:params.Limit]
+			pagination.HasMore = true
+		}
+	}
+
 	log.Info("Successfully listed sessions", "count", len(sessions))
 	data := api.NewResponse(sessions, "Successfully listed sessions", false)
+	data.Pagination = pagination
 	RespondWithJSON(w, http.StatusOK, data)
 }
 
@@ -112,7 +174,7 @@ func (h *SessionsHandler) HandleCreateSession(w ErrorResponseWriter, r *http.Req
 
 	var sessionRequest api.SessionRequest
 	if err := DecodeJSONBody(r, &sessionRequest); err != nil {
-		w.RespondWithError(errors.NewBadRequestError("Invalid request body", err))
+		w.RespondWithError(errors.NewBadRequestError("Invalid request body", err).WithCode(api.ErrorCodeInvalidRequestBody))
 		return
 	}
 
@@ -209,6 +271,32 @@ func getEffectiveUserIDForSession(r *http.Request, sessionID string) (string, er
 	return getUserIDOrAgentUser(r)
 }
 
+// sessionReader returns the user ID to read sessionID as and whether the caller
+// may only read it. Besides the caller's own sessions and those opened with a
+// share token, a caller may read a session another user has shared with them
+// through a session grant; the grant is checked with the Authorizer.
+func (h *SessionsHandler) sessionReader(r *http.Request, sessionID string) (string, bool, *errors.APIError) {
+	if sc, ok := auth.ShareContextFrom(r.Context()); ok && sc.SessionID == sessionID {
+		return sc.UserID, sc.ReadOnly, nil
+	}
+	userID, err := getUserIDOrAgentUser(r)
+	if err != nil {
+		return "", false, errors.NewBadRequestError("Failed to get user ID", err)
+	}
+	principal, _ := GetPrincipal(r)
+	if principal.User.ID == "" {
+		return userID, false, nil
+	}
+	sc, apiErr := ResolveSessionGrant(r.Context(), h.DatabaseService, h.Authorizer, principal, sessionID)
+	if apiErr != nil {
+		return "", false, apiErr
+	}
+	if sc == nil {
+		return userID, false, nil
+	}
+	return sc.UserID, sc.ReadOnly, nil
+}
+
 // HandleGetSession handles GET /api/sessions/{session_id} requests using database
 func (h *SessionsHandler) HandleGetSession(w ErrorResponseWriter, r *http.Request) {
 	log := ctrllog.FromContext(r.Context()).WithName("sessions-handler").WithValues("operation", "get-db")
@@ -220,9 +308,9 @@ func (h *SessionsHandler) HandleGetSession(w ErrorResponseWriter, r *http.Reques
 	}
 	log = log.WithValues("session_id", sessionID)
 
-	userID, err := getEffectiveUserIDForSession(r, sessionID)
-	if err != nil {
-		w.RespondWithError(errors.NewBadRequestError("Failed to get user ID", err))
+	userID, readOnly, apiErr := h.sessionReader(r, sessionID)
+	if apiErr != nil {
+		w.RespondWithError(apiErr)
 		return
 	}
 	log = log.WithValues("userID", userID)
@@ -230,7 +318,7 @@ func (h *SessionsHandler) HandleGetSession(w ErrorResponseWriter, r *http.Reques
 	log.V(1).Info("Getting session from database")
 	session, err := h.DatabaseService.GetSession(r.Context(), sessionID, userID)
 	if err != nil {
-		w.RespondWithError(errors.NewNotFoundError("Session not found", err))
+		w.RespondWithError(errors.NewNotFoundError("Session not found", err).WithCode(api.ErrorCodeSessionNotFound))
 		return
 	}
 
@@ -245,15 +333,19 @@ func (h *SessionsHandler) HandleGetSession(w ErrorResponseWriter, r *http.Reques
 		w.RespondWithError(errors.NewInternalServerError("Failed to get events for session", err))
 		return
 	}
+	events, err = h.withArchivedEvents(r.Context(), sessionID, userID, events, queryOptions)
+	if err != nil {
+		w.RespondWithError(errors.NewInternalServerError("Failed to get archived events for session", err))
+		return
+	}
 
 	log.Info("Successfully retrieved session")
 	resp := SessionResponse{
 		Session: session,
 		Events:  events,
 	}
-	if sc, ok := auth.ShareContextFrom(r.Context()); ok && sc.SessionID == sessionID && sc.ReadOnly {
-		t := true
-		resp.ReadOnly = &t
+	if readOnly {
+		resp.ReadOnly = &readOnly
 	}
 	data := api.NewResponse(resp, "Successfully retrieved session", false)
 	RespondWithJSON(w, http.StatusOK, data)
@@ -282,6 +374,38 @@ func eventQueryOptionsFromRequest(r *http.Request) (database.QueryOptions, error
 	return opts, nil
 }
 
+// withArchivedEvents merges the archived events of a session into events,
+// listed from the database with opts, applying the same after, order and
+// limit.
+func (h *SessionsHandler) withArchivedEvents(ctx context.Context, sessionID, userID string, events []*database.Event, opts database.QueryOptions) ([]*database.Event, error) {
+	if h.eventArchive == nil {
+		return events, nil
+	}
+	// Archived events predate those left in the database, so a full page of
+	// newest events needs none of them.
+	if !opts.OrderAsc && opts.Limit > 0 && len(events) >= opts.Limit {
+		return events, nil
+	}
+	archived, err := h.eventArchive.LoadEvents(ctx, sessionID, userID)
+	if err != nil || len(archived) == 0 {
+		return events, err
+	}
+	archived = slices.DeleteFunc(archived, func(e *database.Event) bool {
+		return !e.CreatedAt.After(opts.After)
+	})
+	merged := append(archived, events...)
+	slices.SortStableFunc(merged, func(a, b *database.Event) int {
+		if opts.OrderAsc {
+			return a.CreatedAt.Compare(b.CreatedAt)
+		}
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+	if opts.Limit > 0 && len(merged) > opts.Limit {
+		merged = merged[:opts.Limit]
+	}
+	return merged, nil
+}
+
 // substrateSandboxAgentForSession resolves the session's agent to a substrate SandboxAgent CR,
 // returning nil when the session has no agent or its agent is anything else.
 func (h *SessionsHandler) substrateSandboxAgentForSession(ctx context.Context, session *database.Session) (*v1alpha2.SandboxAgent, error) {
@@ -322,7 +446,7 @@ func (h *SessionsHandler) HandleUpdateSession(w ErrorResponseWriter, r *http.Req
 
 	var sessionRequest api.SessionRequest
 	if err := DecodeJSONBody(r, &sessionRequest); err != nil {
-		w.RespondWithError(errors.NewBadRequestError("Invalid request body", err))
+		w.RespondWithError(errors.NewBadRequestError("Invalid request body", err).WithCode(api.ErrorCodeInvalidRequestBody))
 		return
 	}
 
@@ -333,7 +457,7 @@ func (h *SessionsHandler) HandleUpdateSession(w ErrorResponseWriter, r *http.Req
 
 	session, err := h.DatabaseService.GetSession(r.Context(), sessionID, userID)
 	if err != nil {
-		w.RespondWithError(errors.NewNotFoundError("Session not found", err))
+		w.RespondWithError(errors.NewNotFoundError("Session not found", err).WithCode(api.ErrorCodeSessionNotFound))
 		return
 	}
 
@@ -344,7 +468,7 @@ func (h *SessionsHandler) HandleUpdateSession(w ErrorResponseWriter, r *http.Req
 		log = log.WithValues("agentRef", *sessionRequest.AgentRef)
 		agent, err := h.DatabaseService.GetAgent(r.Context(), utils.ConvertToPythonIdentifier(*sessionRequest.AgentRef))
 		if err != nil {
-			w.RespondWithError(errors.NewNotFoundError("Agent not found", err))
+			w.RespondWithError(errors.NewNotFoundError("Agent not found", err).WithCode(api.ErrorCodeAgentNotFound))
 			return
 		}
 		session.AgentID = &agent.ID
@@ -360,6 +484,107 @@ func (h *SessionsHandler) HandleUpdateSession(w ErrorResponseWriter, r *http.Req
 	RespondWithJSON(w, http.StatusOK, data)
 }
 
+// HandleForkSession handles POST /api/sessions/{session_id}/fork requests. It
+// creates a new session for the same agent holding a copy of the source
+// session's events up to and including "event_id", so a conversation can be
+// continued differently from that point while the original is kept.
+func (h *SessionsHandler) HandleForkSession(w ErrorResponseWriter, r *http.Request) {
+	log := ctrllog.FromContext(r.Context()).WithName("sessions-handler").WithValues("operation", "fork-db")
+
+	userID, err := getUserIDOrAgentUser(r)
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get user ID", err))
+		return
+	}
+
+	sessionID, err := GetPathParam(r, "session_id")
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get session ID from path", err))
+		return
+	}
+	log = log.WithValues("userID", userID, "session_id", sessionID)
+
+	var forkRequest api.ForkSessionRequest
+	if err := DecodeJSONBody(r, &forkRequest); err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Invalid request body", err).WithCode(api.ErrorCodeInvalidRequestBody))
+		return
+	}
+
+	source, err := h.DatabaseService.GetSession(r.Context(), sessionID, userID)
+	if err != nil {
+		w.RespondWithError(errors.NewNotFoundError("Session not found", err).WithCode(api.ErrorCodeSessionNotFound))
+		return
+	}
+	if source.AgentID != nil {
+		agent, err := h.DatabaseService.GetAgent(r.Context(), *source.AgentID)
+		if err != nil {
+			w.RespondWithError(errors.NewNotFoundError("Agent not found", err).WithCode(api.ErrorCodeAgentNotFound))
+			return
+		}
+		if agent.WorkloadType == v1alpha2.WorkloadModeSandbox {
+			_, isSubstrateSandbox, lookupErr := h.lookupSubstrateSandboxAgent(r.Context(), *source.AgentID)
+			if lookupErr != nil {
+				w.RespondWithError(errors.NewInternalServerError("Failed to inspect sandbox agent", lookupErr))
+				return
+			}
+			if !isSubstrateSandbox {
+				w.RespondWithError(errors.NewConflictError("Sandbox agents support only one chat session", fmt.Errorf("session %s cannot be forked", sessionID)))
+				return
+			}
+		}
+	}
+
+	fork := &database.Session{
+		ID:      a2a.NewContextID(),
+		UserID:  userID,
+		AgentID: source.AgentID,
+		Source:  source.Source,
+	}
+	if forkRequest.ID != nil && *forkRequest.ID != "" {
+		fork.ID = *forkRequest.ID
+	}
+	if _, err := h.DatabaseService.GetSession(r.Context(), fork.ID, userID); err == nil {
+		w.RespondWithError(errors.NewConflictError("Session already exists", fmt.Errorf("session %s already exists", fork.ID)).WithCode(api.ErrorCodeSessionExists))
+		return
+	}
+	switch {
+	case forkRequest.Name != nil:
+		fork.Name = forkRequest.Name
+	case source.Name != nil:
+		name := *source.Name + " (fork)"
+		fork.Name = &name
+	}
+	var untilEventID string
+	if forkRequest.EventID != nil {
+		untilEventID = *forkRequest.EventID
+		log = log.WithValues("eventID", untilEventID)
+	}
+
+	copied, err := h.DatabaseService.ForkSession(r.Context(), sessionID, untilEventID, fork)
+	if err != nil {
+		if stderrors.Is(err, database.ErrEventNotInSession) {
+			w.RespondWithError(errors.NewNotFoundError("Event not found in session", err))
+			return
+		}
+		w.RespondWithError(errors.NewInternalServerError("Failed to fork session", err))
+		return
+	}
+
+	stored, err := h.DatabaseService.GetSession(r.Context(), fork.ID, userID)
+	if err != nil {
+		w.RespondWithError(errors.NewInternalServerError("Failed to load forked session", err))
+		return
+	}
+
+	log.Info("Successfully forked session", "forkID", stored.ID, "copiedEvents", copied)
+	data := api.NewResponse(api.ForkSessionResponse{
+		Session:      stored,
+		CopiedEvents: copied,
+		ForkedFrom:   sessionID,
+	}, "Successfully forked session", false)
+	RespondWithJSON(w, http.StatusCreated, data)
+}
+
 // HandleDeleteSession handles DELETE /api/sessions/{session_id} requests using database
 func (h *SessionsHandler) HandleDeleteSession(w ErrorResponseWriter, r *http.Request) {
 	log := ctrllog.FromContext(r.Context()).WithName("sessions-handler").WithValues("operation", "delete-db")
@@ -405,7 +630,8 @@ func (h *SessionsHandler) HandleDeleteSession(w ErrorResponseWriter, r *http.Req
 	RespondWithJSON(w, http.StatusOK, data)
 }
 
-// HandleListSessionRuns handles GET /api/sessions/{session_id}/tasks requests using database
+// HandleListSessionRuns handles GET /api/sessions/{session_id}/tasks requests using database.
+// Optional query params: limit=<n> and offset=<n>.
 func (h *SessionsHandler) HandleListTasksForSession(w ErrorResponseWriter, r *http.Request) {
 	log := ctrllog.FromContext(r.Context()).WithName("sessions-handler").WithValues("operation", "list-tasks-db")
 
@@ -416,17 +642,23 @@ func (h *SessionsHandler) HandleListTasksForSession(w ErrorResponseWriter, r *ht
 	}
 	log = log.WithValues("session_id", sessionID)
 
-	userID, err := getEffectiveUserIDForSession(r, sessionID)
-	if err != nil {
-		w.RespondWithError(errors.NewBadRequestError("Failed to get user ID", err))
+	userID, _, apiErr := h.sessionReader(r, sessionID)
+	if apiErr != nil {
+		w.RespondWithError(apiErr)
 		return
 	}
 	log = log.WithValues("userID", userID)
 
+	params, err := listParamsFromRequest(r)
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError(err.Error(), nil))
+		return
+	}
+
 	// Verify session exists
 	_, err = h.DatabaseService.GetSession(r.Context(), sessionID, userID)
 	if err != nil {
-		w.RespondWithError(errors.NewNotFoundError("Session not found for given ID", err))
+		w.RespondWithError(errors.NewNotFoundError("Session not found for given ID", err).WithCode(api.ErrorCodeSessionNotFound))
 		return
 	}
 
@@ -442,6 +674,7 @@ func (h *SessionsHandler) HandleListTasksForSession(w ErrorResponseWriter, r *ht
 		return
 	}
 
+	tasks, pagination := paginate(tasks, params)
 	log.Info("Successfully retrieved session tasks", "count", len(tasks))
 
 	// TODO(0.11.0): Remove legacy API conversion after legacy wire support is no longer supported.
@@ -457,9 +690,11 @@ func (h *SessionsHandler) HandleListTasksForSession(w ErrorResponseWriter, r *ht
 			legacyTasks = append(legacyTasks, legacyTask)
 		}
 		data := api.NewResponse(legacyTasks, "Successfully retrieved session tasks", false)
+		data.Pagination = pagination
 		RespondWithJSON(w, http.StatusOK, data)
 	case utils.A2AWireVersionV1:
 		data := api.NewResponse(tasks, "Successfully retrieved session tasks", false)
+		data.Pagination = pagination
 		RespondWithJSON(w, http.StatusOK, data)
 	default:
 		w.RespondWithError(errors.NewBadRequestError("Unsupported A2A version", fmt.Errorf("unknown negotiated wire version %q", wireVersion)))
@@ -492,14 +727,14 @@ func (h *SessionsHandler) HandleAddEventToSession(w ErrorResponseWriter, r *http
 		Data string `json:"data"`
 	}
 	if err := DecodeJSONBody(r, &eventData); err != nil {
-		w.RespondWithError(errors.NewBadRequestError("Invalid request body", err))
+		w.RespondWithError(errors.NewBadRequestError("Invalid request body", err).WithCode(api.ErrorCodeInvalidRequestBody))
 		return
 	}
 
 	// Get session to verify it exists
 	session, err := h.DatabaseService.GetSession(r.Context(), sessionID, userID)
 	if err != nil {
-		w.RespondWithError(errors.NewNotFoundError("Session not found", err))
+		w.RespondWithError(errors.NewNotFoundError("Session not found", err).WithCode(api.ErrorCodeSessionNotFound))
 		return
 	}
 
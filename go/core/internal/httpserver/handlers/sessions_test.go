@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"testing"
 	"time"
 
@@ -56,7 +57,7 @@ func TestSessionsHandler(t *testing.T) {
 			DatabaseService:    dbClient,
 			DefaultModelConfig: types.NamespacedName{Namespace: "default", Name: "default"},
 		}
-		handler := handlers.NewSessionsHandler(base, nil)
+		handler := handlers.NewSessionsHandler(base, nil, nil)
 		responseRecorder := newMockErrorResponseWriter()
 		return handler, dbClient, responseRecorder
 	}
@@ -120,6 +121,36 @@ func TestSessionsHandler(t *testing.T) {
 			assert.Equal(t, session2.ID, response.Data[1].ID)
 		})
 
+		t.Run("Paginated", func(t *testing.T) {
+			handler, dbClient, responseRecorder := setupHandler(t)
+			userID := "test-user"
+			for _, id := range []string{"session-b", "session-a", "session-c"} {
+				createTestSession(t, dbClient, id, userID, "1")
+			}
+
+			req := httptest.NewRequest("GET", "/api/sessions?sort=name&limit=2&offset=1", nil)
+			req = setUser(req, userID)
+			handler.HandleListSessions(responseRecorder, req)
+
+			assert.Equal(t, http.StatusOK, responseRecorder.Code)
+			var response api.StandardResponse[[]*database.Session]
+			require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &response))
+			require.Len(t, response.Data, 2)
+			assert.Equal(t, "session-b", response.Data[0].ID)
+			assert.Equal(t, "session-c", response.Data[1].ID)
+			assert.Equal(t, &api.Pagination{Limit: 2, Offset: 1}, response.Pagination)
+		})
+
+		t.Run("InvalidSort", func(t *testing.T) {
+			handler, _, responseRecorder := setupHandler(t)
+
+			req := httptest.NewRequest("GET", "/api/sessions?sort=owner", nil)
+			req = setUser(req, "test-user")
+			handler.HandleListSessions(responseRecorder, req)
+
+			assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+		})
+
 		t.Run("MissingUserID", func(t *testing.T) {
 			handler, _, responseRecorder := setupHandler(t)
 
@@ -391,6 +422,35 @@ func TestSessionsHandler(t *testing.T) {
 			assert.Equal(t, event2.ID, response.Data.Events[1].ID)
 		})
 
+		t.Run("MergesArchivedEvents", func(t *testing.T) {
+			_, dbClient, responseRecorder := setupHandler(t)
+			userID := "test-user"
+			sessionID := "test-session"
+			createTestSession(t, dbClient, sessionID, userID, "1")
+
+			now := time.Now()
+			live := &database.Event{ID: "event-3", SessionID: sessionID, UserID: userID, CreatedAt: now.Add(-time.Hour), Data: "{}"}
+			require.NoError(t, dbClient.StoreEvents(context.Background(), live))
+			archive := fakeEventArchive{
+				{ID: "event-1", SessionID: sessionID, UserID: userID, CreatedAt: now.Add(-72 * time.Hour), Data: "{}"},
+				{ID: "event-2", SessionID: sessionID, UserID: userID, CreatedAt: now.Add(-48 * time.Hour), Data: "{}"},
+			}
+			handler := handlers.NewSessionsHandler(&handlers.Base{DatabaseService: dbClient}, nil, archive)
+
+			req := httptest.NewRequest("GET", "/api/sessions/"+sessionID+"?limit=2", nil)
+			req = mux.SetURLVars(req, map[string]string{"session_id": sessionID})
+			req = setUser(req, userID)
+
+			handler.HandleGetSession(responseRecorder, req)
+
+			assert.Equal(t, http.StatusOK, responseRecorder.Code)
+			var response api.StandardResponse[handlers.SessionResponse]
+			require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &response))
+			require.Len(t, response.Data.Events, 2)
+			assert.Equal(t, "event-3", response.Data.Events[0].ID)
+			assert.Equal(t, "event-2", response.Data.Events[1].ID)
+		})
+
 		t.Run("OwnerSeesNilReadOnly", func(t *testing.T) {
 			handler, dbClient, responseRecorder := setupHandler(t)
 			ownerID := "owner-user"
@@ -620,6 +680,94 @@ func TestSessionsHandler(t *testing.T) {
 		})
 	})
 
+	t.Run("HandleForkSession", func(t *testing.T) {
+		forkRequest := func(sessionID, userID string, body api.ForkSessionRequest) *http.Request {
+			jsonBody, _ := json.Marshal(body)
+			req := httptest.NewRequest("POST", "/api/sessions/"+sessionID+"/fork", bytes.NewBuffer(jsonBody))
+			req = mux.SetURLVars(req, map[string]string{"session_id": sessionID})
+			return setUser(req, userID)
+		}
+		storeEvents := func(t *testing.T, dbClient database.Client, sessionID, userID string, ids ...string) {
+			t.Helper()
+			for _, id := range ids {
+				require.NoError(t, dbClient.StoreEvents(context.Background(), &database.Event{
+					ID: id, SessionID: sessionID, UserID: userID, Data: `{"id":"` + id + `"}`,
+				}))
+			}
+		}
+
+		t.Run("UpToEvent", func(t *testing.T) {
+			handler, dbClient, responseRecorder := setupHandler(t)
+			userID := "test-user"
+			createTestAgent(t, dbClient, "1")
+			createTestSession(t, dbClient, "source-session", userID, "1")
+			storeEvents(t, dbClient, "source-session", userID, "event-1", "event-2", "event-3")
+
+			handler.HandleForkSession(responseRecorder, forkRequest("source-session", userID, api.ForkSessionRequest{EventID: new("event-2")}))
+
+			require.Equal(t, http.StatusCreated, responseRecorder.Code, responseRecorder.Body.String())
+			var response api.StandardResponse[api.ForkSessionResponse]
+			require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &response))
+			fork := response.Data.Session
+			assert.NotEqual(t, "source-session", fork.ID)
+			assert.Equal(t, "source-session (fork)", *fork.Name)
+			assert.Equal(t, "1", *fork.AgentID)
+			assert.Equal(t, 2, response.Data.CopiedEvents)
+			assert.Equal(t, "source-session", response.Data.ForkedFrom)
+
+			events, err := dbClient.ListEventsForSession(context.Background(), fork.ID, userID, database.QueryOptions{OrderAsc: true})
+			require.NoError(t, err)
+			require.Len(t, events, 2)
+			assert.Equal(t, `{"id":"event-1"}`, events[0].Data)
+			assert.Equal(t, `{"id":"event-2"}`, events[1].Data)
+
+			source, err := dbClient.ListEventsForSession(context.Background(), "source-session", userID, database.QueryOptions{})
+			require.NoError(t, err)
+			assert.Len(t, source, 3, "the source session is left untouched")
+		})
+
+		t.Run("WholeHistory", func(t *testing.T) {
+			handler, dbClient, responseRecorder := setupHandler(t)
+			userID := "test-user"
+			createTestAgent(t, dbClient, "1")
+			createTestSession(t, dbClient, "source-session", userID, "1")
+			storeEvents(t, dbClient, "source-session", userID, "event-1", "event-2")
+
+			handler.HandleForkSession(responseRecorder, forkRequest("source-session", userID, api.ForkSessionRequest{ID: new("my-fork"), Name: new("alternative")}))
+
+			require.Equal(t, http.StatusCreated, responseRecorder.Code, responseRecorder.Body.String())
+			var response api.StandardResponse[api.ForkSessionResponse]
+			require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &response))
+			assert.Equal(t, "my-fork", response.Data.Session.ID)
+			assert.Equal(t, "alternative", *response.Data.Session.Name)
+			assert.Equal(t, 2, response.Data.CopiedEvents)
+		})
+
+		t.Run("EventNotInSession", func(t *testing.T) {
+			handler, dbClient, responseRecorder := setupHandler(t)
+			userID := "test-user"
+			createTestAgent(t, dbClient, "1")
+			createTestSession(t, dbClient, "source-session", userID, "1")
+			createTestSession(t, dbClient, "other-session", userID, "1")
+			storeEvents(t, dbClient, "other-session", userID, "other-event")
+
+			handler.HandleForkSession(responseRecorder, forkRequest("source-session", userID, api.ForkSessionRequest{EventID: new("other-event")}))
+
+			assert.Equal(t, http.StatusNotFound, responseRecorder.Code)
+			sessions, err := dbClient.ListSessions(context.Background(), userID)
+			require.NoError(t, err)
+			assert.Len(t, sessions, 2, "no fork is created")
+		})
+
+		t.Run("SessionNotFound", func(t *testing.T) {
+			handler, _, responseRecorder := setupHandler(t)
+
+			handler.HandleForkSession(responseRecorder, forkRequest("missing", "test-user", api.ForkSessionRequest{}))
+
+			assert.Equal(t, http.StatusNotFound, responseRecorder.Code)
+		})
+	})
+
 	t.Run("HandleGetSessionsForAgent", func(t *testing.T) {
 		t.Run("Success", func(t *testing.T) {
 			handler, dbClient, responseRecorder := setupHandler(t)
@@ -716,4 +864,64 @@ func TestSessionsHandler(t *testing.T) {
 			assert.NotNil(t, responseRecorder.errorReceived)
 		})
 	})
+
+	t.Run("HandleListSessionApprovals", func(t *testing.T) {
+		handler, dbClient, responseRecorder := setupHandler(t)
+		userID := "test-user"
+		sessionID := "test-session"
+		createTestSession(t, dbClient, sessionID, userID, utils.ConvertToPythonIdentifier("default/k8s-agent"))
+
+		confirmation := a2a.NewDataPart(map[string]any{
+			"name": "adk_request_confirmation",
+			"id":   "adk-confirm-1",
+			"args": map[string]any{
+				"originalFunctionCall": map[string]any{
+					"name": "k8s_delete_resource",
+					"id":   "call-1",
+					"args": map[string]any{"name": "nginx"},
+				},
+				"toolConfirmation": map[string]any{"hint": "Tool 'k8s_delete_resource' requires approval before execution."},
+			},
+		})
+		confirmation.Metadata = map[string]any{"adk_type": "function_call", "adk_is_long_running": true}
+		require.NoError(t, dbClient.StoreTask(context.Background(), &a2a.Task{
+			ID:        "task-waiting",
+			ContextID: sessionID,
+			Status: a2a.TaskStatus{
+				State:   a2a.TaskStateInputRequired,
+				Message: a2a.NewMessage(a2a.MessageRoleAgent, confirmation),
+			},
+		}, userID))
+		require.NoError(t, dbClient.StoreTask(context.Background(), &a2a.Task{
+			ID:        "task-done",
+			ContextID: sessionID,
+			Status:    a2a.TaskStatus{State: a2a.TaskStateCompleted},
+		}, userID))
+
+		req := httptest.NewRequest("GET", "/api/sessions/"+sessionID+"/approvals", nil)
+		req = mux.SetURLVars(req, map[string]string{"session_id": sessionID})
+		req = setUser(req, userID)
+
+		handler.HandleListSessionApprovals(responseRecorder, req)
+
+		require.Equal(t, http.StatusOK, responseRecorder.Code)
+		var response api.StandardResponse[[]api.ToolApproval]
+		require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &response))
+		assert.Equal(t, []api.ToolApproval{{
+			Agent:      "default/k8s-agent",
+			TaskID:     "task-waiting",
+			ContextID:  sessionID,
+			ToolCallID: "call-1",
+			ToolName:   "k8s_delete_resource",
+			Args:       map[string]any{"name": "nginx"},
+			Hint:       "Tool 'k8s_delete_resource' requires approval before execution.",
+		}}, response.Data)
+	})
+}
+
+// fakeEventArchive serves the same archived events for every session.
+type fakeEventArchive []*database.Event
+
+func (f fakeEventArchive) LoadEvents(context.Context, string, string) ([]*database.Event, error) {
+	return slices.Clone(f), nil
 }
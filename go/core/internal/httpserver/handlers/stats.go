@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/a2aproject/a2a-go/v2/a2a"
+	"github.com/kagent-dev/kagent/go/api/database"
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
+	"github.com/kagent-dev/kagent/go/core/internal/httpserver/errors"
+	"github.com/kagent-dev/kagent/go/core/internal/utils"
+	"github.com/kagent-dev/kagent/go/core/pkg/auth"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	defaultStatsWindow = 5 * time.Minute
+	maxStatsWindow     = 24 * time.Hour
+)
+
+// usageMetadataKeys are the message metadata keys the Python and Go agent
+// runtimes report an LLM call's token usage under.
+var usageMetadataKeys = []string{"kagent_usage_metadata", "adk_usage_metadata"}
+
+// StatsHandler serves live agent activity aggregated from the database
+type StatsHandler struct {
+	*Base
+}
+
+// NewStatsHandler creates a new stats handler
+func NewStatsHandler(base *Base) *StatsHandler {
+	return &StatsHandler{Base: base}
+}
+
+// HandleGetStats returns the activity of each agent over the last `window`
+// (default 5m): active sessions, in-flight tasks, tokens per minute, and the
+// average latency and error rate of the tasks that finished. Only the agents
+// the caller may read are included.
+func (h *StatsHandler) HandleGetStats(w ErrorResponseWriter, r *http.Request) {
+	log := ctrllog.FromContext(r.Context()).WithName("stats-handler").WithValues("operation", "get-stats")
+
+	if err := Check(h.Authorizer, r, auth.Resource{Type: "Agent"}); err != nil {
+		w.RespondWithError(err)
+		return
+	}
+
+	window, err := parseStatsWindow(r)
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Invalid window parameter", err))
+		return
+	}
+
+	since := time.Now().Add(-window)
+	sessions, err := h.DatabaseService.CountActiveSessionsByAgent(r.Context(), since)
+	if err != nil {
+		log.Error(err, "Failed to count active sessions")
+		w.RespondWithError(errors.NewInternalServerError("Failed to get stats", err))
+		return
+	}
+	tasks, err := h.DatabaseService.ListAgentTasksUpdatedSince(r.Context(), since)
+	if err != nil {
+		log.Error(err, "Failed to list agent tasks")
+		w.RespondWithError(errors.NewInternalServerError("Failed to get stats", err))
+		return
+	}
+
+	agents := slices.DeleteFunc(summarizeAgentStats(sessions, tasks, window), func(s api.AgentStats) bool {
+		return Check(h.Authorizer, r, auth.Resource{Type: "Agent", Name: s.Agent}) != nil
+	})
+	stats := api.StatsResponse{Window: window.String(), Agents: agents}
+	data := api.NewResponse(stats, "Successfully retrieved stats", false)
+	RespondWithJSON(w, http.StatusOK, data)
+}
+
+func parseStatsWindow(r *http.Request) (time.Duration, error) {
+	raw := r.URL.Query().Get("window")
+	if raw == "" {
+		return defaultStatsWindow, nil
+	}
+	window, err := time.ParseDuration(raw)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse window: %w", err)
+	}
+	if window < time.Second || window > maxStatsWindow {
+		return 0, fmt.Errorf("window must be between 1s and %s", maxStatsWindow)
+	}
+	return window, nil
+}
+
+// summarizeAgentStats rolls sessions and tasks up into one entry per agent,
+// sorted by agent. A task's tokens count toward the window it was last
+// updated in; its latency is the time from creation to its last update.
+func summarizeAgentStats(sessions []database.AgentSessionCount, tasks []database.AgentTask, window time.Duration) []api.AgentStats {
+	index := map[string]*api.AgentStats{}
+	totalLatency := map[string]time.Duration{}
+	failed := map[string]int64{}
+	tokens := map[string]int64{}
+	get := func(agentID string) *api.AgentStats {
+		s, ok := index[agentID]
+		if !ok {
+			s = &api.AgentStats{Agent: utils.ConvertToKubernetesIdentifier(agentID)}
+			index[agentID] = s
+		}
+		return s
+	}
+
+	for _, c := range sessions {
+		get(c.AgentID).ActiveSessions += c.Sessions
+	}
+	for _, t := range tasks {
+		s := get(t.AgentID)
+		tokens[t.AgentID] += taskTokens(t.Task)
+		switch t.Task.Status.State {
+		case a2a.TaskStateSubmitted, a2a.TaskStateWorking:
+			s.InFlightTasks++
+		case a2a.TaskStateCompleted, a2a.TaskStateCanceled:
+			s.FinishedTasks++
+			totalLatency[t.AgentID] += t.UpdatedAt.Sub(t.CreatedAt)
+		case a2a.TaskStateFailed, a2a.TaskStateRejected:
+			s.FinishedTasks++
+			totalLatency[t.AgentID] += t.UpdatedAt.Sub(t.CreatedAt)
+			failed[t.AgentID]++
+		}
+	}
+
+	result := make([]api.AgentStats, 0, len(index))
+	for agentID, s := range index {
+		s.TokensPerMinute = float64(tokens[agentID]) / window.Minutes()
+		if s.FinishedTasks > 0 {
+			s.AvgLatencyMs = float64(totalLatency[agentID].Milliseconds()) / float64(s.FinishedTasks)
+			s.ErrorRate = float64(failed[agentID]) / float64(s.FinishedTasks)
+		}
+		result = append(result, *s)
+	}
+	slices.SortFunc(result, func(a, b api.AgentStats) int { return strings.Compare(a.Agent, b.Agent) })
+	return result
+}
+
+// taskTokens sums the total token counts the agent reported on the messages
+// of task.
+func taskTokens(task *a2a.Task) int64 {
+	var total int64
+	for _, msg := range task.History {
+		if msg == nil || msg.Role == a2a.MessageRoleUser {
+			continue
+		}
+		for _, key := range usageMetadataKeys {
+			if usage, ok := msg.Metadata[key].(map[string]any); ok {
+				if n, ok := usage["totalTokenCount"].(float64); ok {
+					total += int64(n)
+				}
+				break
+			}
+		}
+	}
+	return total
+}
@@ -0,0 +1,82 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/v2/a2a"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kagent-dev/kagent/go/api/database"
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
+	"github.com/kagent-dev/kagent/go/core/internal/httpserver/handlers"
+	pkgauth "github.com/kagent-dev/kagent/go/core/pkg/auth"
+)
+
+// statsDatabase serves the queries of the stats handler; any other call
+// panics on the nil embedded client.
+type statsDatabase struct {
+	database.Client
+}
+
+func (statsDatabase) CountActiveSessionsByAgent(context.Context, time.Time) ([]database.AgentSessionCount, error) {
+	return []database.AgentSessionCount{
+		{AgentID: "kagent__NS__k8s_agent", Sessions: 2},
+		{AgentID: "secret__NS__payroll_agent", Sessions: 1},
+	}, nil
+}
+
+func (statsDatabase) ListAgentTasksUpdatedSince(context.Context, time.Time) ([]database.AgentTask, error) {
+	now := time.Now()
+	return []database.AgentTask{{
+		AgentID:   "secret__NS__payroll_agent",
+		Task:      &a2a.Task{Status: a2a.TaskStatus{State: a2a.TaskStateWorking}},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}}, nil
+}
+
+// namespaceAuthorizer allows listing agents and reading the agents of one
+// namespace.
+type namespaceAuthorizer struct {
+	namespace string
+}
+
+func (a namespaceAuthorizer) Check(_ context.Context, _ pkgauth.Principal, _ pkgauth.Verb, res pkgauth.Resource) error {
+	if res.Type == "Agent" && (res.Name == "" || res.Name == a.namespace+"/k8s-agent") {
+		return nil
+	}
+	return assert.AnError
+}
+
+func TestHandleGetStatsAuthorization(t *testing.T) {
+	get := func(authorizer pkgauth.Authorizer) *httptest.ResponseRecorder {
+		handler := handlers.NewStatsHandler(&handlers.Base{DatabaseService: statsDatabase{}, Authorizer: authorizer})
+		req := setUser(httptest.NewRequest(http.MethodGet, "/api/stats", nil), "test-user")
+		w := httptest.NewRecorder()
+		handler.HandleGetStats(&testErrorResponseWriter{w}, req)
+		return w
+	}
+
+	assert.Equal(t, http.StatusForbidden, get(denyAuthorizer{}).Code)
+
+	w := get(namespaceAuthorizer{namespace: "kagent"})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var resp api.StandardResponse[api.StatsResponse]
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Data.Agents, 1, "agents the caller can't read must be left out")
+	assert.Equal(t, "kagent/k8s-agent", resp.Data.Agents[0].Agent)
+}
+
+func TestHandleListProviderStatsAuthorization(t *testing.T) {
+	handler := handlers.NewAnalyticsHandler(&handlers.Base{Authorizer: denyAuthorizer{}})
+	req := setUser(httptest.NewRequest(http.MethodGet, "/api/analytics/providers", nil), "test-user")
+	w := httptest.NewRecorder()
+	handler.HandleListProviderStats(&testErrorResponseWriter{w}, req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/v2/a2a"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kagent-dev/kagent/go/api/database"
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
+)
+
+func TestParseStatsWindow(t *testing.T) {
+	tests := []struct {
+		query   string
+		want    time.Duration
+		wantErr string
+	}{
+		{query: "", want: defaultStatsWindow},
+		{query: "window=1m", want: time.Minute},
+		{query: "window=soon", wantErr: "failed to parse window"},
+		{query: "window=0s", wantErr: "window must be between"},
+		{query: "window=25h", wantErr: "window must be between"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			got, err := parseStatsWindow(httptest.NewRequest("GET", "/api/stats?"+tt.query, nil))
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSummarizeAgentStats(t *testing.T) {
+	start := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	task := func(agentID string, state a2a.TaskState, latency time.Duration, history ...*a2a.Message) database.AgentTask {
+		return database.AgentTask{
+			AgentID:   agentID,
+			Task:      &a2a.Task{Status: a2a.TaskStatus{State: state}, History: history},
+			CreatedAt: start,
+			UpdatedAt: start.Add(latency),
+		}
+	}
+	usage := func(role a2a.MessageRole, key string, tokens float64) *a2a.Message {
+		return &a2a.Message{Role: role, Metadata: map[string]any{key: map[string]any{"totalTokenCount": tokens}}}
+	}
+
+	sessions := []database.AgentSessionCount{
+		{AgentID: "kagent__NS__k8s_agent", Sessions: 3},
+		{AgentID: "kagent__NS__idle_agent", Sessions: 1},
+	}
+	tasks := []database.AgentTask{
+		task("kagent__NS__k8s_agent", a2a.TaskStateCompleted, time.Second,
+			usage(a2a.MessageRoleAgent, "kagent_usage_metadata", 300),
+			usage(a2a.MessageRoleUser, "kagent_usage_metadata", 1000)),
+		task("kagent__NS__k8s_agent", a2a.TaskStateFailed, 3*time.Second,
+			usage(a2a.MessageRoleAgent, "adk_usage_metadata", 200)),
+		task("kagent__NS__k8s_agent", a2a.TaskStateWorking, time.Minute),
+		task("kagent__NS__k8s_agent", a2a.TaskStateInputRequired, time.Minute),
+	}
+
+	got := summarizeAgentStats(sessions, tasks, 5*time.Minute)
+	assert.Equal(t, []api.AgentStats{
+		{Agent: "kagent/idle-agent", ActiveSessions: 1},
+		{
+			Agent:           "kagent/k8s-agent",
+			ActiveSessions:  3,
+			InFlightTasks:   1,
+			FinishedTasks:   2,
+			TokensPerMinute: 100,
+			AvgLatencyMs:    2000,
+			ErrorRate:       0.5,
+		},
+	}, got)
+}
@@ -1,13 +1,20 @@
 package handlers
 
 import (
+	"cmp"
+	"context"
 	stderrors "errors"
 	"fmt"
+	"io"
+	"mime"
 	"net/http"
+	"strconv"
 
 	a2a "github.com/a2aproject/a2a-go/v2/a2a"
+	"github.com/go-logr/logr"
 	"github.com/kagent-dev/kagent/go/api/database"
 	api "github.com/kagent-dev/kagent/go/api/httpapi"
+	"github.com/kagent-dev/kagent/go/core/internal/artifacts"
 	"github.com/kagent-dev/kagent/go/core/internal/httpserver/errors"
 	"github.com/kagent-dev/kagent/go/core/internal/utils"
 	"github.com/kagent-dev/kagent/go/core/pkg/a2acompat/trpcv0"
@@ -15,14 +22,38 @@ import (
 	"trpc.group/trpc-go/trpc-a2a-go/protocol"
 )
 
+// pushNotificationDeliveriesMetadataKey is the task metadata key under which
+// the latest webhook delivery status of each push notification config is
+// returned.
+const pushNotificationDeliveriesMetadataKey = "kagent_push_notification_deliveries"
+
+// TaskPushNotifier is told about every persisted task so registered push
+// notification webhooks hear about state changes.
+type TaskPushNotifier interface {
+	NotifyTaskUpdate(ctx context.Context, task *a2a.Task) error
+}
+
+// TaskArtifactArchiver uploads the artifacts and attachments of finished
+// tasks to the artifact store and serves them back. *artifacts.Archiver
+// satisfies it.
+type TaskArtifactArchiver interface {
+	ArchiveTask(ctx context.Context, task *a2a.Task) error
+	Open(ctx context.Context, taskID a2a.TaskID, artifactID a2a.ArtifactID) (io.ReadCloser, *artifacts.Object, error)
+	OpenAttachment(ctx context.Context, taskID a2a.TaskID, messageID string, index int) (io.ReadCloser, *artifacts.Object, error)
+	DeleteTask(ctx context.Context, taskID a2a.TaskID) error
+}
+
 // TasksHandler handles task-related requests
 type TasksHandler struct {
 	*Base
+	pushNotifier TaskPushNotifier
+	archiver     TaskArtifactArchiver
 }
 
-// NewTasksHandler creates a new TasksHandler
-func NewTasksHandler(base *Base) *TasksHandler {
-	return &TasksHandler{Base: base}
+// NewTasksHandler creates a new TasksHandler. pushNotifier and archiver may
+// be nil.
+func NewTasksHandler(base *Base, pushNotifier TaskPushNotifier, archiver TaskArtifactArchiver) *TasksHandler {
+	return &TasksHandler{Base: base, pushNotifier: pushNotifier, archiver: archiver}
 }
 
 func (h *TasksHandler) HandleGetTask(w ErrorResponseWriter, r *http.Request) {
@@ -43,7 +74,7 @@ func (h *TasksHandler) HandleGetTask(w ErrorResponseWriter, r *http.Request) {
 
 	task, err := h.DatabaseService.GetTask(r.Context(), taskID, userID)
 	if err != nil {
-		w.RespondWithError(errors.NewNotFoundError("Task not found", err))
+		w.RespondWithError(errors.NewNotFoundError("Task not found", err).WithCode(api.ErrorCodeTaskNotFound).WithDetail("taskId", taskID))
 		return
 	}
 	wireVersion, err := utils.NegotiateA2AWireVersion(r)
@@ -52,6 +83,16 @@ func (h *TasksHandler) HandleGetTask(w ErrorResponseWriter, r *http.Request) {
 		return
 	}
 
+	deliveries, err := h.DatabaseService.ListPushNotificationDeliveries(r.Context(), taskID)
+	if err != nil {
+		log.Error(err, "Failed to list push notification deliveries")
+	} else if len(deliveries) > 0 {
+		if task.Metadata == nil {
+			task.Metadata = map[string]any{}
+		}
+		task.Metadata[pushNotificationDeliveriesMetadataKey] = deliveries
+	}
+
 	log.Info("Successfully retrieved task")
 	// TODO(0.11.0): Remove legacy API conversion after legacy wire support is no longer supported.
 	// Currently this will return either legacy or v1 task depending on the wire version
@@ -89,7 +130,7 @@ func (h *TasksHandler) HandleCreateTask(w ErrorResponseWriter, r *http.Request)
 	case utils.A2AWireVersionLegacy:
 		legacyTask := protocol.Task{}
 		if err := DecodeJSONBody(r, &legacyTask); err != nil {
-			w.RespondWithError(errors.NewBadRequestError("Invalid request body", err))
+			w.RespondWithError(errors.NewBadRequestError("Invalid request body", err).WithCode(api.ErrorCodeInvalidRequestBody))
 			return
 		}
 		converted, convErr := trpcv0.ToV1Task(&legacyTask)
@@ -102,7 +143,7 @@ func (h *TasksHandler) HandleCreateTask(w ErrorResponseWriter, r *http.Request)
 		}
 	case utils.A2AWireVersionV1:
 		if err := DecodeJSONBody(r, &task); err != nil {
-			w.RespondWithError(errors.NewBadRequestError("Invalid request body", err))
+			w.RespondWithError(errors.NewBadRequestError("Invalid request body", err).WithCode(api.ErrorCodeInvalidRequestBody))
 			return
 		}
 	default:
@@ -120,6 +161,13 @@ func (h *TasksHandler) HandleCreateTask(w ErrorResponseWriter, r *http.Request)
 		return
 	}
 
+	if h.archiver != nil {
+		// Artifacts that fail to upload stay inline in the stored task.
+		if err := h.archiver.ArchiveTask(r.Context(), &task); err != nil {
+			log.Error(err, "Failed to archive task artifacts")
+		}
+	}
+
 	if err := h.DatabaseService.StoreTask(r.Context(), &task, userID); err != nil {
 		if stderrors.Is(err, database.ErrTaskOwnedByAnotherUser) {
 			w.RespondWithError(errors.NewConflictError("Task ID is already in use", err))
@@ -129,6 +177,12 @@ func (h *TasksHandler) HandleCreateTask(w ErrorResponseWriter, r *http.Request)
 		return
 	}
 
+	if h.pushNotifier != nil {
+		if err := h.pushNotifier.NotifyTaskUpdate(r.Context(), &task); err != nil {
+			log.Error(err, "Failed to queue push notifications")
+		}
+	}
+
 	log.Info("Successfully created task")
 	var data any
 	switch wireVersion {
@@ -167,13 +221,210 @@ func (h *TasksHandler) HandleDeleteTask(w ErrorResponseWriter, r *http.Request)
 
 	if err := h.DatabaseService.DeleteTask(r.Context(), taskID, userID); err != nil {
 		if stderrors.Is(err, database.ErrTaskOwnedByAnotherUser) {
-			w.RespondWithError(errors.NewNotFoundError("Task not found", err))
+			w.RespondWithError(errors.NewNotFoundError("Task not found", err).WithCode(api.ErrorCodeTaskNotFound).WithDetail("taskId", taskID))
 			return
 		}
 		w.RespondWithError(errors.NewInternalServerError("Failed to delete task", err))
 		return
 	}
 
+	if err := h.DatabaseService.DeletePushNotification(r.Context(), taskID); err != nil {
+		log.Error(err, "Failed to delete push notification configs for task")
+	}
+	if h.archiver != nil {
+		if err := h.archiver.DeleteTask(r.Context(), a2a.TaskID(taskID)); err != nil {
+			log.Error(err, "Failed to delete stored task artifacts")
+		}
+	}
+
 	log.Info("Successfully deleted task")
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// HandleGetTaskArtifact serves the content of a task artifact, addressed by
+// name or, for unnamed artifacts, by id. Archived artifacts are read from the
+// artifact store; others are encoded from the persisted task.
+func (h *TasksHandler) HandleGetTaskArtifact(w ErrorResponseWriter, r *http.Request) {
+	log := ctrllog.FromContext(r.Context()).WithName("tasks-handler").WithValues("operation", "get-task-artifact")
+
+	taskID, err := GetPathParam(r, "task_id")
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get task ID from path", err))
+		return
+	}
+	name, err := GetPathParam(r, "name")
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get artifact name from path", err))
+		return
+	}
+	log = log.WithValues("task_id", taskID, "artifact", name)
+
+	userID, err := getUserIDOrAgentUser(r)
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get user ID", err))
+		return
+	}
+
+	task, err := h.DatabaseService.GetTask(r.Context(), taskID, userID)
+	if err != nil {
+		w.RespondWithError(errors.NewNotFoundError("Task not found", err).WithCode(api.ErrorCodeTaskNotFound).WithDetail("taskId", taskID))
+		return
+	}
+	artifact := findArtifact(task, name)
+	if artifact == nil {
+		w.RespondWithError(errors.NewNotFoundError("Artifact not found", fmt.Errorf("task %s has no artifact %q", taskID, name)).WithCode(api.ErrorCodeArtifactNotFound).WithDetail("artifact", name))
+		return
+	}
+
+	if contentType, archived := artifact.Metadata[artifacts.ContentTypeMetadataKey].(string); archived && h.archiver != nil {
+		body, obj, err := h.archiver.Open(r.Context(), task.ID, artifact.ID)
+		if stderrors.Is(err, artifacts.ErrNotFound) {
+			w.RespondWithError(errors.NewNotFoundError("Artifact has expired from the artifact store", err))
+			return
+		}
+		if err != nil {
+			w.RespondWithError(errors.NewInternalServerError("Failed to read artifact", err))
+			return
+		}
+		defer body.Close()
+		writeStoredObject(w, log, body, obj, contentType, name)
+		return
+	}
+
+	body, contentType, ok := artifacts.EncodeArtifact(artifact)
+	if !ok {
+		w.RespondWithError(errors.NewNotFoundError("Artifact has no stored content", fmt.Errorf("artifact %q only references external files", name)))
+		return
+	}
+	writeContent(w, log, body, contentType, name)
+}
+
+// HandleGetTaskAttachment serves a file a user attached to a message of a
+// task, addressed by message id and part index. Files the artifact store
+// holds are read from it; others are served from the persisted task.
+func (h *TasksHandler) HandleGetTaskAttachment(w ErrorResponseWriter, r *http.Request) {
+	log := ctrllog.FromContext(r.Context()).WithName("tasks-handler").WithValues("operation", "get-task-attachment")
+
+	taskID, err := GetPathParam(r, "task_id")
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get task ID from path", err))
+		return
+	}
+	messageID, err := GetPathParam(r, "message_id")
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get message ID from path", err))
+		return
+	}
+	index, err := GetIntPathParam(r, "index")
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get part index from path", err))
+		return
+	}
+	log = log.WithValues("task_id", taskID, "message_id", messageID, "index", index)
+
+	userID, err := getUserIDOrAgentUser(r)
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get user ID", err))
+		return
+	}
+
+	task, err := h.DatabaseService.GetTask(r.Context(), taskID, userID)
+	if err != nil {
+		w.RespondWithError(errors.NewNotFoundError("Task not found", err).WithCode(api.ErrorCodeTaskNotFound).WithDetail("taskId", taskID))
+		return
+	}
+	part := findAttachment(task, messageID, index)
+	if part == nil {
+		w.RespondWithError(errors.NewNotFoundError("Attachment not found", fmt.Errorf("task %s has no attachment in part %d of message %q", taskID, index, messageID)))
+		return
+	}
+
+	if raw, ok := part.Content.(a2a.Raw); ok {
+		writeContent(w, log, raw, cmp.Or(part.MediaType, "application/octet-stream"), part.Filename)
+		return
+	}
+	contentType, archived := part.Metadata[artifacts.ContentTypeMetadataKey].(string)
+	if !archived || h.archiver == nil {
+		w.RespondWithError(errors.NewNotFoundError("Attachment has no stored content", fmt.Errorf("part %d of message %q references an external file", index, messageID)))
+		return
+	}
+	body, obj, err := h.archiver.OpenAttachment(r.Context(), task.ID, messageID, index)
+	if stderrors.Is(err, artifacts.ErrNotFound) {
+		w.RespondWithError(errors.NewNotFoundError("Attachment has expired from the artifact store", err))
+		return
+	}
+	if err != nil {
+		w.RespondWithError(errors.NewInternalServerError("Failed to read attachment", err))
+		return
+	}
+	defer body.Close()
+	writeStoredObject(w, log, body, obj, contentType, part.Filename)
+}
+
+// findAttachment returns part index of the user message of task with the
+// given id, when it holds a file.
+func findAttachment(task *a2a.Task, messageID string, index int) *a2a.Part {
+	for _, msg := range task.History {
+		if msg == nil || msg.ID != messageID || msg.Role != a2a.MessageRoleUser {
+			continue
+		}
+		if index < 0 || index >= len(msg.Parts) || msg.Parts[index] == nil {
+			return nil
+		}
+		switch msg.Parts[index].Content.(type) {
+		case a2a.Raw, a2a.URL:
+			return msg.Parts[index]
+		}
+		return nil
+	}
+	return nil
+}
+
+// setDownloadHeaders sets the headers of stored content. The content type
+// comes from whoever stored it, so browsers are told to download the content
+// rather than render it on the API's origin, and not to sniff another type.
+func setDownloadHeaders(w http.ResponseWriter, contentType, filename string) {
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	disposition := "attachment"
+	if filename != "" {
+		disposition = mime.FormatMediaType("attachment", map[string]string{"filename": filename})
+	}
+	w.Header().Set("Content-Disposition", disposition)
+}
+
+func writeStoredObject(w http.ResponseWriter, log logr.Logger, body io.Reader, obj *artifacts.Object, contentType, filename string) {
+	setDownloadHeaders(w, contentType, filename)
+	if obj.Size >= 0 {
+		w.Header().Set("Content-Length", strconv.FormatInt(obj.Size, 10))
+	}
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(w, body); err != nil {
+		log.Error(err, "Failed to write stored content")
+	}
+}
+
+func writeContent(w http.ResponseWriter, log logr.Logger, body []byte, contentType, filename string) {
+	setDownloadHeaders(w, contentType, filename)
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(body); err != nil {
+		log.Error(err, "Failed to write content")
+	}
+}
+
+// findArtifact returns the task artifact with the given name, falling back to
+// matching on id.
+func findArtifact(task *a2a.Task, name string) *a2a.Artifact {
+	for _, artifact := range task.Artifacts {
+		if artifact != nil && artifact.Name == name {
+			return artifact
+		}
+	}
+	for _, artifact := range task.Artifacts {
+		if artifact != nil && string(artifact.ID) == name {
+			return artifact
+		}
+	}
+	return nil
+}
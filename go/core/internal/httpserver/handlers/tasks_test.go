@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kagent-dev/kagent/go/core/internal/artifacts"
+)
+
+// TestWriteContentDownloads verifies that stored content is served as a
+// download of the stored type, so that an HTML artifact or attachment is not
+// rendered on the API's origin.
+func TestWriteContentDownloads(t *testing.T) {
+	rec := httptest.NewRecorder()
+	writeContent(rec, logr.Discard(), []byte("<script>alert(1)</script>"), "text/html", `report "final".html`)
+	assert.Equal(t, "text/html", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "nosniff", rec.Header().Get("X-Content-Type-Options"))
+	assert.Equal(t, `attachment; filename="report \"final\".html"`, rec.Header().Get("Content-Disposition"))
+
+	rec = httptest.NewRecorder()
+	writeStoredObject(rec, logr.Discard(), strings.NewReader("<svg/>"), &artifacts.Object{Size: 6}, "image/svg+xml", "")
+	assert.Equal(t, "nosniff", rec.Header().Get("X-Content-Type-Options"))
+	assert.Equal(t, "attachment", rec.Header().Get("Content-Disposition"))
+	assert.Equal(t, "6", rec.Header().Get("Content-Length"))
+	assert.Equal(t, "<svg/>", rec.Body.String())
+}
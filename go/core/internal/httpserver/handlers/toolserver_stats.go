@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/kagent-dev/kagent/go/api/adk"
+	"github.com/kagent-dev/kagent/go/api/database"
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
+	"github.com/kagent-dev/kagent/go/core/internal/httpserver/errors"
+	"github.com/kagent-dev/kagent/go/core/internal/toolstats"
+	"github.com/kagent-dev/kagent/go/core/pkg/auth"
+	"k8s.io/apimachinery/pkg/types"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	defaultToolServerStatsHours = 24
+	maxToolServerStatsHours     = 30 * 24
+)
+
+// HandleReportToolServerStats handles POST
+// /api/toolservers/{namespace}/{name}/stats requests, which agents send with
+// the calls they made to the tool server since their previous report.
+func (h *ToolServersHandler) HandleReportToolServerStats(w ErrorResponseWriter, r *http.Request) {
+	log := ctrllog.FromContext(r.Context()).WithName("toolservers-handler").WithValues("operation", "report-stats")
+
+	ref, apiErr := toolServerRefFromPath(r)
+	if apiErr != nil {
+		w.RespondWithError(apiErr)
+		return
+	}
+
+	var report adk.ToolCallStatsReport
+	if err := DecodeJSONBody(r, &report); err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Invalid request body", err).WithCode(api.ErrorCodeInvalidRequestBody))
+		return
+	}
+	if err := validateToolCallStatsReport(&report); err != nil {
+		w.RespondWithError(errors.NewValidationError(err.Error(), err))
+		return
+	}
+
+	if _, err := h.DatabaseService.GetToolServer(r.Context(), ref.String()); err != nil {
+		w.RespondWithError(errors.NewNotFoundError("ToolServer not found", err).WithCode(api.ErrorCodeToolServerNotFound))
+		return
+	}
+
+	if err := h.DatabaseService.RecordToolCallStats(r.Context(), &database.ToolCallStats{
+		ToolServer:     ref.String(),
+		AgentID:        report.AgentID,
+		CallCount:      report.Calls,
+		ErrorCount:     report.Errors,
+		LatencyBuckets: report.LatencyBuckets,
+	}); err != nil {
+		w.RespondWithError(errors.NewInternalServerError("Failed to record tool call stats", err))
+		return
+	}
+
+	log.V(1).Info("Recorded tool call stats", "toolServer", ref, "agent", report.AgentID, "calls", report.Calls)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleGetToolServerStats handles GET
+// /api/toolservers/{namespace}/{name}/stats requests. It returns the call
+// count, error rate and latency of the calls agents made to the tool server
+// over the last `hours` hours (default 24), per agent and per hour.
+func (h *ToolServersHandler) HandleGetToolServerStats(w ErrorResponseWriter, r *http.Request) {
+	log := ctrllog.FromContext(r.Context()).WithName("toolservers-handler").WithValues("operation", "get-stats")
+
+	ref, apiErr := toolServerRefFromPath(r)
+	if apiErr != nil {
+		w.RespondWithError(apiErr)
+		return
+	}
+	if err := Check(h.Authorizer, r, auth.Resource{Type: "ToolServer", Name: ref.String()}); err != nil {
+		w.RespondWithError(err)
+		return
+	}
+
+	hours, err := parseToolServerStatsHours(r)
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Invalid hours parameter", err))
+		return
+	}
+
+	if _, err := h.DatabaseService.GetToolServer(r.Context(), ref.String()); err != nil {
+		w.RespondWithError(errors.NewNotFoundError("ToolServer not found", err).WithCode(api.ErrorCodeToolServerNotFound))
+		return
+	}
+
+	hourly, err := h.DatabaseService.ListToolCallStats(r.Context(), ref.String(), time.Now().Add(-time.Duration(hours-1)*time.Hour))
+	if err != nil {
+		log.Error(err, "Failed to list tool call stats")
+		w.RespondWithError(errors.NewInternalServerError("Failed to list tool call stats", err))
+		return
+	}
+
+	log.Info("Successfully retrieved tool server stats", "toolServer", ref, "hours", hours, "rows", len(hourly))
+	data := api.NewResponse(api.ToolServerStatsResponse{
+		Ref:             ref.String(),
+		Hours:           hours,
+		ToolCallSummary: toolstats.Summarize(hourly),
+		Agents:          toolstats.SummarizeByAgent(hourly),
+		Hourly:          hourly,
+	}, "Successfully retrieved tool server stats", false)
+	RespondWithJSON(w, http.StatusOK, data)
+}
+
+func toolServerRefFromPath(r *http.Request) (types.NamespacedName, *errors.APIError) {
+	namespace, err := GetPathParam(r, "namespace")
+	if err != nil {
+		return types.NamespacedName{}, errors.NewBadRequestError("Failed to get namespace from path", err)
+	}
+	name, err := GetPathParam(r, "name")
+	if err != nil {
+		return types.NamespacedName{}, errors.NewBadRequestError("Failed to get name from path", err)
+	}
+	return types.NamespacedName{Namespace: namespace, Name: name}, nil
+}
+
+func validateToolCallStatsReport(report *adk.ToolCallStatsReport) error {
+	switch {
+	case report.AgentID == "":
+		return fmt.Errorf("agent_id is required")
+	case report.Calls < 0 || report.Errors < 0 || report.Errors > report.Calls:
+		return fmt.Errorf("calls and errors must be non-negative, with errors at most calls")
+	case len(report.LatencyBuckets) != len(adk.ToolLatencyBucketsMs)+1:
+		return fmt.Errorf("latency_buckets must have %d buckets", len(adk.ToolLatencyBucketsMs)+1)
+	}
+	for _, n := range report.LatencyBuckets {
+		if n < 0 {
+			return fmt.Errorf("latency_buckets must be non-negative")
+		}
+	}
+	return nil
+}
+
+func parseToolServerStatsHours(r *http.Request) (int, error) {
+	raw := r.URL.Query().Get("hours")
+	if raw == "" {
+		return defaultToolServerStatsHours, nil
+	}
+	hours, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse hours: %w", err)
+	}
+	if hours < 1 || hours > maxToolServerStatsHours {
+		return 0, fmt.Errorf("hours must be between 1 and %d", maxToolServerStatsHours)
+	}
+	return hours, nil
+}
@@ -1,11 +1,14 @@
 package handlers
 
 import (
+	"cmp"
 	"fmt"
 	"net/http"
 	"slices"
+	"strings"
 
 	"github.com/go-logr/logr"
+	"github.com/kagent-dev/kagent/go/api/database"
 	api "github.com/kagent-dev/kagent/go/api/httpapi"
 	"github.com/kagent-dev/kagent/go/api/v1alpha2"
 	"github.com/kagent-dev/kagent/go/core/internal/httpserver/errors"
@@ -62,7 +65,9 @@ var (
 	mcpServerGVK       = v1alpha1.GroupVersion.WithKind("MCPServer")
 )
 
-// HandleListToolServers handles GET /api/toolservers requests
+// HandleListToolServers handles GET /api/toolservers requests.
+// Optional query params: namespace=<ns>, created_after=<ts>,
+// sort=name|created_at, limit=<n> and offset=<n>.
 func (h *ToolServersHandler) HandleListToolServers(w ErrorResponseWriter, r *http.Request) {
 	log := ctrllog.FromContext(r.Context()).WithName("toolservers-handler").WithValues("operation", "list")
 	log.Info("Received request to list ToolServers")
@@ -71,12 +76,31 @@ func (h *ToolServersHandler) HandleListToolServers(w ErrorResponseWriter, r *htt
 		return
 	}
 
+	params, err := listParamsFromRequest(r, "name", "created_at")
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError(err.Error(), nil))
+		return
+	}
+
 	toolServers, err := h.DatabaseService.ListToolServers(r.Context())
 	if err != nil {
 		w.RespondWithError(errors.NewInternalServerError("Failed to list ToolServers from database", err))
 		return
 	}
 
+	// Filter and page before looking up each server's tools.
+	toolServers = slices.DeleteFunc(toolServers, func(ts database.ToolServer) bool {
+		if params.Namespace != "" && !strings.HasPrefix(ts.Name, params.Namespace+"/") {
+			return true
+		}
+		return !params.CreatedAfter.IsZero() && !ts.CreatedAt.After(params.CreatedAfter)
+	})
+	sortItems(toolServers, params, map[string]func(a, b database.ToolServer) int{
+		"name":       func(a, b database.ToolServer) int { return cmp.Compare(a.Name, b.Name) },
+		"created_at": func(a, b database.ToolServer) int { return compareTime(a.CreatedAt, b.CreatedAt) },
+	})
+	toolServers, pagination := paginate(toolServers, params)
+
 	toolServerWithTools := make([]api.ToolServerResponse, len(toolServers))
 	for i, toolServer := range toolServers {
 		tools, err := h.DatabaseService.ListToolsForServer(r.Context(), toolServer.Name, toolServer.GroupKind)
@@ -102,6 +126,7 @@ func (h *ToolServersHandler) HandleListToolServers(w ErrorResponseWriter, r *htt
 
 	log.Info("Successfully listed ToolServers", "count", len(toolServerWithTools))
 	data := api.NewResponse(toolServerWithTools, "Successfully listed ToolServers", false)
+	data.Pagination = pagination
 	RespondWithJSON(w, http.StatusOK, data)
 }
 
@@ -113,7 +138,7 @@ func (h *ToolServersHandler) HandleCreateToolServer(w ErrorResponseWriter, r *ht
 	var toolServerRequest ToolServerCreateRequest
 	if err := DecodeJSONBody(r, &toolServerRequest); err != nil {
 		log.Error(err, "Invalid request body")
-		w.RespondWithError(errors.NewBadRequestError("Invalid request body", err))
+		w.RespondWithError(errors.NewBadRequestError("Invalid request body", err).WithCode(api.ErrorCodeInvalidRequestBody))
 		return
 	}
 
@@ -153,7 +178,7 @@ func (h *ToolServersHandler) handleCreateRemoteMCPServer(w ErrorResponseWriter,
 	}
 	toolRef, err := common.ParseRefString(toolServerRequest.Name, toolServerRequest.Namespace)
 	if err != nil {
-		w.RespondWithError(errors.NewBadRequestError("Invalid ToolServer metadata", err))
+		w.RespondWithError(errors.NewBadRequestError("Invalid ToolServer metadata", err).WithCode(api.ErrorCodeToolServerInvalid))
 		return
 	}
 	if toolRef.Namespace == common.GetResourceNamespace() {
@@ -206,7 +231,7 @@ func (h *ToolServersHandler) handleCreateMCPServer(w ErrorResponseWriter, r *htt
 	}
 	toolRef, err := common.ParseRefString(toolServerRequest.Name, toolServerRequest.Namespace)
 	if err != nil {
-		w.RespondWithError(errors.NewBadRequestError("Invalid ToolServer metadata", err))
+		w.RespondWithError(errors.NewBadRequestError("Invalid ToolServer metadata", err).WithCode(api.ErrorCodeToolServerInvalid))
 		return
 	}
 	if toolRef.Namespace == common.GetResourceNamespace() {
@@ -291,7 +316,7 @@ func (h *ToolServersHandler) HandleDeleteToolServer(w ErrorResponseWriter, r *ht
 
 	if groupKind == "" {
 		log.Info("ToolServer not found in database")
-		w.RespondWithError(errors.NewNotFoundError("ToolServer not found", nil))
+		w.RespondWithError(errors.NewNotFoundError("ToolServer not found", nil).WithCode(api.ErrorCodeToolServerNotFound))
 		return
 	}
 
@@ -312,7 +337,7 @@ func (h *ToolServersHandler) HandleDeleteToolServer(w ErrorResponseWriter, r *ht
 		if err != nil {
 			if apierrors.IsNotFound(err) {
 				log.Info("RemoteMCPServer not found")
-				w.RespondWithError(errors.NewNotFoundError("RemoteMCPServer not found", nil))
+				w.RespondWithError(errors.NewNotFoundError("RemoteMCPServer not found", nil).WithCode(api.ErrorCodeToolServerNotFound))
 				return
 			}
 			log.Error(err, "Failed to get RemoteMCPServer")
@@ -340,7 +365,7 @@ func (h *ToolServersHandler) HandleDeleteToolServer(w ErrorResponseWriter, r *ht
 		if err != nil {
 			if apierrors.IsNotFound(err) {
 				log.Info("MCPServer not found")
-				w.RespondWithError(errors.NewNotFoundError("MCPServer not found", nil))
+				w.RespondWithError(errors.NewNotFoundError("MCPServer not found", nil).WithCode(api.ErrorCodeToolServerNotFound))
 				return
 			}
 			log.Error(err, "Failed to get MCPServer")
@@ -368,7 +393,7 @@ func (h *ToolServersHandler) HandleDeleteToolServer(w ErrorResponseWriter, r *ht
 		if err != nil {
 			if apierrors.IsNotFound(err) {
 				log.Info("Service not found")
-				w.RespondWithError(errors.NewNotFoundError("Service not found", nil))
+				w.RespondWithError(errors.NewNotFoundError("Service not found", nil).WithCode(api.ErrorCodeToolServerNotFound))
 				return
 			}
 			log.Error(err, "Failed to get Service")
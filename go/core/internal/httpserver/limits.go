@@ -0,0 +1,166 @@
+package httpserver
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// Reasons requests are rejected for, as the reason label of
+	// kagent_http_rejected_requests_total.
+	rejectBodyTooLarge = "body_too_large"
+	rejectReadTimeout  = "read_timeout"
+)
+
+var rejectedRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "kagent_http_rejected_requests_total",
+	Help: "HTTP API requests rejected for exceeding a limit, by reason (body_too_large or read_timeout).",
+}, []string{"reason"})
+
+// Collectors returns the HTTP server metrics, for registration with the
+// controller's metrics registry.
+func Collectors() []prometheus.Collector {
+	return []prometheus.Collector{rejectedRequests}
+}
+
+// ServerLimits bounds what a single client can hold on the HTTP server. Zero
+// durations and sizes disable the corresponding limit.
+type ServerLimits struct {
+	// MaxRequestBytes caps request bodies. Larger requests are rejected
+	// with 413 Request Entity Too Large.
+	MaxRequestBytes int64
+	// ReadHeaderTimeout bounds reading request headers, so slow clients
+	// cannot hold connections open.
+	ReadHeaderTimeout time.Duration
+	// ReadTimeout bounds reading a request body.
+	ReadTimeout time.Duration
+	// WriteTimeout bounds writing a response.
+	WriteTimeout time.Duration
+	// IdleTimeout closes keep-alive connections idle for longer.
+	IdleTimeout time.Duration
+	// ShutdownTimeout is how long in-flight requests may take to finish
+	// once the server shuts down, before their contexts are cancelled and
+	// their connections closed.
+	ShutdownTimeout time.Duration
+	// Routes overrides the limits of requests under path prefixes. The
+	// longest matching prefix applies.
+	Routes []RouteLimits
+}
+
+// RouteLimits overrides the server's per-request limits under a path
+// prefix. Nil fields keep the server's limit; zero disables it.
+type RouteLimits struct {
+	PathPrefix      string
+	MaxRequestBytes *int64
+	ReadTimeout     *time.Duration
+	WriteTimeout    *time.Duration
+}
+
+// DefaultServerLimits returns the limits the controller serves its API with.
+func DefaultServerLimits() ServerLimits {
+	return ServerLimits{
+		MaxRequestBytes:   10 << 20,
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       time.Minute,
+		WriteTimeout:      2 * time.Minute,
+		IdleTimeout:       2 * time.Minute,
+		ShutdownTimeout:   25 * time.Second,
+		Routes:            StreamingRouteLimits(),
+	}
+}
+
+// StreamingRouteLimits lifts the read and write timeouts of routes that
+// stream or upgrade to WebSockets. Their streams set their own write
+// deadlines.
+func StreamingRouteLimits() []RouteLimits {
+	var routes []RouteLimits
+	for _, prefix := range []string{APIPathA2A, APIPathA2ASandboxes, APIPathMCP, APIPathAgentHarnesses} {
+		routes = append(routes, RouteLimits{PathPrefix: prefix, ReadTimeout: new(time.Duration(0)), WriteTimeout: new(time.Duration(0))})
+	}
+	return routes
+}
+
+// limitsFor returns the limits of a request path.
+func (l ServerLimits) limitsFor(path string) (maxBytes int64, readTimeout, writeTimeout time.Duration) {
+	maxBytes, readTimeout, writeTimeout = l.MaxRequestBytes, l.ReadTimeout, l.WriteTimeout
+	matched := -1
+	for _, route := range l.Routes {
+		if !strings.HasPrefix(path, route.PathPrefix) || len(route.PathPrefix) <= matched {
+			continue
+		}
+		matched = len(route.PathPrefix)
+		maxBytes, readTimeout, writeTimeout = l.MaxRequestBytes, l.ReadTimeout, l.WriteTimeout
+		if route.MaxRequestBytes != nil {
+			maxBytes = *route.MaxRequestBytes
+		}
+		if route.ReadTimeout != nil {
+			readTimeout = *route.ReadTimeout
+		}
+		if route.WriteTimeout != nil {
+			writeTimeout = *route.WriteTimeout
+		}
+	}
+	return maxBytes, readTimeout, writeTimeout
+}
+
+// limitsMiddleware applies the request size limit and the read and write
+// deadlines of the request's route. Requests over the size limit are
+// rejected before their body is read when they declare a Content-Length;
+// otherwise reading past the limit fails.
+func limitsMiddleware(limits ServerLimits) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			maxBytes, readTimeout, writeTimeout := limits.limitsFor(r.URL.Path)
+			if maxBytes > 0 && r.ContentLength > maxBytes {
+				rejectedRequests.WithLabelValues(rejectBodyTooLarge).Inc()
+				w.Header().Set("Connection", "close")
+				http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+				return
+			}
+
+			rc := http.NewResponseController(w)
+			now := time.Now()
+			if readTimeout > 0 {
+				_ = rc.SetReadDeadline(now.Add(readTimeout))
+			}
+			if writeTimeout > 0 {
+				_ = rc.SetWriteDeadline(now.Add(writeTimeout))
+			}
+			if r.Body != nil && r.Body != http.NoBody {
+				body := r.Body
+				if maxBytes > 0 {
+					body = http.MaxBytesReader(w, body, maxBytes)
+				}
+				r.Body = &limitedBody{ReadCloser: body}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// limitedBody counts the first read error caused by a limit.
+type limitedBody struct {
+	io.ReadCloser
+	once sync.Once
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if err != nil && err != io.EOF {
+		var tooLarge *http.MaxBytesError
+		switch {
+		case errors.As(err, &tooLarge):
+			b.once.Do(func() { rejectedRequests.WithLabelValues(rejectBodyTooLarge).Inc() })
+		case errors.Is(err, os.ErrDeadlineExceeded):
+			b.once.Do(func() { rejectedRequests.WithLabelValues(rejectReadTimeout).Inc() })
+		}
+	}
+	return n, err
+}
@@ -0,0 +1,197 @@
+package httpserver
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
+	apierrors "github.com/kagent-dev/kagent/go/core/internal/httpserver/errors"
+	"github.com/kagent-dev/kagent/go/core/internal/httpserver/handlers"
+)
+
+func rejectedCount(t *testing.T, reason string) float64 {
+	t.Helper()
+	m := &dto.Metric{}
+	require.NoError(t, rejectedRequests.WithLabelValues(reason).Write(m))
+	return m.GetCounter().GetValue()
+}
+
+func TestServerLimitsFor(t *testing.T) {
+	limits := DefaultServerLimits()
+	limits.Routes = append(limits.Routes, RouteLimits{PathPrefix: APIPathApply, MaxRequestBytes: new(int64(50 << 20))})
+
+	maxBytes, read, write := limits.limitsFor("/api/sessions/abc")
+	assert.Equal(t, int64(10<<20), maxBytes)
+	assert.Equal(t, time.Minute, read)
+	assert.Equal(t, 2*time.Minute, write)
+
+	maxBytes, read, write = limits.limitsFor(APIPathA2A + "/kagent/k8s-agent/")
+	assert.Equal(t, int64(10<<20), maxBytes, "streaming routes keep the size limit")
+	assert.Zero(t, read)
+	assert.Zero(t, write)
+
+	maxBytes, _, _ = limits.limitsFor(APIPathApply)
+	assert.Equal(t, int64(50<<20), maxBytes)
+}
+
+func TestLimitsMiddleware(t *testing.T) {
+	router := mux.NewRouter()
+	router.HandleFunc("/api/agents", adaptHandler(func(w handlers.ErrorResponseWriter, r *http.Request) {
+		var body map[string]any
+		if err := handlers.DecodeJSONBody(r, &body); err != nil {
+			w.RespondWithError(apierrors.NewBadRequestError("Invalid request body", err).WithCode(api.ErrorCodeInvalidRequestBody))
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	router.Use(limitsMiddleware(ServerLimits{MaxRequestBytes: 64, ReadTimeout: time.Minute, WriteTimeout: time.Minute}))
+	router.Use(errorHandlerMiddleware)
+
+	t.Run("accepts bodies within the limit", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/agents", strings.NewReader(`{"name":"k8s-agent"}`)))
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	})
+
+	t.Run("rejects declared oversized bodies before reading them", func(t *testing.T) {
+		before := rejectedCount(t, rejectBodyTooLarge)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/agents", strings.NewReader(`{"name":"`+strings.Repeat("x", 100)+`"}`)))
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+		assert.Equal(t, before+1, rejectedCount(t, rejectBodyTooLarge))
+	})
+
+	t.Run("rejects chunked bodies once they pass the limit", func(t *testing.T) {
+		before := rejectedCount(t, rejectBodyTooLarge)
+		req := httptest.NewRequest(http.MethodPost, "/api/agents", strings.NewReader(`{"name":"`+strings.Repeat("x", 100)+`"}`))
+		req.ContentLength = -1
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+		var apiErr api.APIError
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &apiErr))
+		assert.Equal(t, api.ErrorCodeRequestTooLarge, apiErr.Code)
+		assert.Equal(t, before+1, rejectedCount(t, rejectBodyTooLarge))
+	})
+}
+
+func TestHTTPServerSlowClient(t *testing.T) {
+	addr, stop := startTestHTTPServer(t, ServerLimits{ReadHeaderTimeout: 100 * time.Millisecond, ReadTimeout: 200 * time.Millisecond}, func(w http.ResponseWriter, r *http.Request) {
+		if _, err := io.ReadAll(r.Body); err != nil {
+			http.Error(w, err.Error(), http.StatusRequestTimeout)
+		}
+	})
+	defer stop()
+
+	before := rejectedCount(t, rejectReadTimeout)
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = io.WriteString(conn, "POST /health HTTP/1.1\r\nHost: kagent\r\nContent-Length: 100\r\n\r\n{")
+	require.NoError(t, err)
+
+	// The server gives up on the body and closes the connection.
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	_, err = io.ReadAll(conn)
+	require.NoError(t, err)
+	assert.Equal(t, before+1, rejectedCount(t, rejectReadTimeout))
+}
+
+func TestHTTPServerShutdown(t *testing.T) {
+	t.Run("drains in-flight requests", func(t *testing.T) {
+		started, release := make(chan struct{}), make(chan struct{})
+		addr, stop := startTestHTTPServer(t, ServerLimits{ShutdownTimeout: 5 * time.Second}, func(w http.ResponseWriter, r *http.Request) {
+			close(started)
+			<-release
+			_, _ = io.WriteString(w, "done")
+		})
+
+		resCh := make(chan *http.Response, 1)
+		go func() {
+			res, err := http.Get("http://" + addr + "/health")
+			assert.NoError(t, err)
+			resCh <- res
+		}()
+		<-started
+		stopped := make(chan error, 1)
+		go func() { stopped <- stop() }()
+		time.Sleep(50 * time.Millisecond)
+		close(release)
+
+		res := <-resCh
+		require.NotNil(t, res)
+		defer res.Body.Close()
+		body, _ := io.ReadAll(res.Body)
+		assert.Equal(t, "done", string(body))
+		assert.NoError(t, <-stopped)
+	})
+
+	t.Run("cancels requests still running after the timeout", func(t *testing.T) {
+		started, cancelled := make(chan struct{}), make(chan struct{})
+		addr, stop := startTestHTTPServer(t, ServerLimits{ShutdownTimeout: 100 * time.Millisecond}, func(w http.ResponseWriter, r *http.Request) {
+			close(started)
+			<-r.Context().Done()
+			close(cancelled)
+		})
+
+		go func() {
+			res, err := http.Get("http://" + addr + "/health")
+			if err == nil {
+				res.Body.Close()
+			}
+		}()
+		<-started
+		require.NoError(t, stop())
+		select {
+		case <-cancelled:
+		case <-time.After(5 * time.Second):
+			t.Fatal("request was not cancelled")
+		}
+	})
+}
+
+// startTestHTTPServer serves handler at /health, which needs no
+// authentication, and returns the server's address and a function that
+// shuts it down and returns the result of Start.
+func startTestHTTPServer(t *testing.T, limits ServerLimits, handler http.HandlerFunc) (string, func() error) {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := l.Addr().String()
+	require.NoError(t, l.Close())
+
+	router := mux.NewRouter()
+	router.HandleFunc(APIPathHealth, handler)
+	s := &HTTPServer{
+		config:   ServerConfig{BindAddr: addr, Limits: limits},
+		router:   router,
+		handlers: &handlers.Handlers{},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- s.Start(ctx) }()
+	require.Eventually(t, func() bool {
+		conn, err := net.Dial("tcp", addr)
+		if err == nil {
+			conn.Close()
+		}
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	return addr, func() error {
+		cancel()
+		return <-done
+	}
+}
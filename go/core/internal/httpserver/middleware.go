@@ -25,7 +25,14 @@ func loggingMiddleware(next http.Handler) http.Handler {
 			"remote_addr", r.RemoteAddr,
 		)
 
-		if userID := r.URL.Query().Get("user_id"); userID != "" {
+		// Log the authenticated identity rather than what the client claims.
+		if session, ok := auth.AuthSessionFrom(r.Context()); ok {
+			principal := session.Principal()
+			log = log.WithValues("user_id", principal.User.ID)
+			if principal.Agent.ID != "" {
+				log = log.WithValues("agent_id", principal.Agent.ID)
+			}
+		} else if userID := r.URL.Query().Get("user_id"); userID != "" {
 			log = log.WithValues("user_id", userID)
 		}
 
@@ -58,6 +65,12 @@ func (w *statusResponseWriter) Flush() {
 	}
 }
 
+// Unwrap lets http.ResponseController reach the underlying writer, e.g. to
+// set write deadlines on streams.
+func (w *statusResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
 func (w *statusResponseWriter) WriteHeader(code int) {
 	w.status = code
 	w.ResponseWriter.WriteHeader(code)
@@ -126,11 +139,9 @@ func (s *HTTPServer) shareTokenMiddleware(next http.Handler) http.Handler {
 		// while still rejecting message sends, cancels, and push-config writes.
 		// Visitors retain full authenticated access to all other endpoints
 		// (creating their own sessions, submitting feedback, etc.).
-		if share.ReadOnly && r.Method != http.MethodGet && r.Method != http.MethodHead {
-			if strings.HasPrefix(r.URL.Path, APIPathSessions+"/") {
-				http.Error(w, "This share link is read-only", http.StatusForbidden)
-				return
-			}
+		if share.ReadOnly && isSessionWrite(r) {
+			http.Error(w, "This share link is read-only", http.StatusForbidden)
+			return
 		}
 
 		callerSession, _ := auth.AuthSessionFrom(r.Context())
@@ -150,3 +161,54 @@ func (s *HTTPServer) shareTokenMiddleware(next http.Handler) http.Handler {
 		next.ServeHTTP(w, r)
 	})
 }
+
+// isSessionWrite reports whether r changes a session through the session REST
+// path, which a read-only share may not do.
+func isSessionWrite(r *http.Request) bool {
+	return r.Method != http.MethodGet && r.Method != http.MethodHead && strings.HasPrefix(r.URL.Path, APIPathSessions+"/")
+}
+
+// sessionGrantMiddleware resolves an X-Shared-Session header, which names a
+// session another user has shared with the caller through a session grant.
+// Like a share token, a grant is stored as a ShareContext, so the session
+// handlers and the A2A proxy act on the owner's session; a grant without
+// invoke access is read-only. The session REST handlers resolve grants
+// themselves, so the header is only needed for A2A requests. A share token
+// takes precedence over the header.
+func (s *HTTPServer) sessionGrantMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sessionID := r.Header.Get("X-Shared-Session")
+		if sessionID == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if _, ok := auth.ShareContextFrom(r.Context()); ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		callerSession, ok := auth.AuthSessionFrom(r.Context())
+		if !ok {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		sc, apiErr := handlers.ResolveSessionGrant(r.Context(), s.config.DbClient, s.config.Authorizer, callerSession.Principal(), sessionID)
+		if apiErr != nil {
+			http.Error(w, apiErr.Message, apiErr.Code)
+			return
+		}
+		if sc == nil {
+			// The caller owns the session.
+			next.ServeHTTP(w, r)
+			return
+		}
+		if sc.ReadOnly && isSessionWrite(r) {
+			http.Error(w, "This session is shared read-only", http.StatusForbidden)
+			return
+		}
+
+		r = r.WithContext(auth.ShareContextTo(r.Context(), sc))
+		next.ServeHTTP(w, r)
+	})
+}
@@ -40,6 +40,11 @@ func (w *errorResponseWriter) Flush() {
 	}
 }
 
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (w *errorResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
 func (w *errorResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
 	hijacker, ok := w.ResponseWriter.(http.Hijacker)
 	if !ok {
@@ -51,33 +56,30 @@ func (w *errorResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
 func (w *errorResponseWriter) RespondWithError(err error) {
 	log := ctrllog.FromContext(w.request.Context())
 
-	statusCode := http.StatusInternalServerError
-	message := "Internal server error"
-
 	if err == nil {
 		err = errors.New("unknown error")
 	}
 
-	var underlying error
-	if apiErr, ok := err.(*apierrors.APIError); ok {
-		statusCode = apiErr.Code
-		message = apiErr.Message
-		underlying = apiErr.Err
-	} else {
-		underlying = err
+	apiErr, ok := err.(*apierrors.APIError)
+	if !ok {
+		apiErr = apierrors.NewInternalServerError("Internal server error", err)
+	}
+	// Handlers report bodies cut off by the request size limit as invalid.
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		apiErr = &apierrors.APIError{
+			Code:    http.StatusRequestEntityTooLarge,
+			Message: fmt.Sprintf("Request body exceeds %d bytes", tooLarge.Limit),
+		}
 	}
 
-	if underlying != nil && !errors.Is(underlying, pgx.ErrNoRows) {
-		log.Error(underlying, message)
+	if apiErr.Err != nil && !errors.Is(apiErr.Err, pgx.ErrNoRows) {
+		log.Error(apiErr.Err, apiErr.Message)
 	} else {
-		log.Info(message)
+		log.Info(apiErr.Message)
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(statusCode)
-	errMsg := message
-	if underlying != nil {
-		errMsg = message + ": " + underlying.Error()
-	}
-	json.NewEncoder(w).Encode(map[string]string{"error": errMsg}) //nolint:errcheck
+	w.WriteHeader(apiErr.Code)
+	json.NewEncoder(w).Encode(apiErr.Response()) //nolint:errcheck
 }
@@ -0,0 +1,72 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
+	apierrors "github.com/kagent-dev/kagent/go/core/internal/httpserver/errors"
+)
+
+func TestRespondWithErrorEnvelope(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantStatus  int
+		wantMessage string
+		wantCode    api.ErrorCode
+		wantDetails map[string]any
+	}{
+		{
+			name:        "specific code",
+			err:         apierrors.NewNotFoundError("Agent not found", nil).WithCode(api.ErrorCodeAgentNotFound).WithDetail("agent", "kagent/k8s-agent"),
+			wantStatus:  http.StatusNotFound,
+			wantMessage: "Agent not found",
+			wantCode:    api.ErrorCodeAgentNotFound,
+			wantDetails: map[string]any{"agent": "kagent/k8s-agent"},
+		},
+		{
+			name:        "code from status",
+			err:         apierrors.NewForbiddenError("Not authorized", errors.New("denied")),
+			wantStatus:  http.StatusForbidden,
+			wantMessage: "Not authorized: denied",
+			wantCode:    api.ErrorCodeForbidden,
+		},
+		{
+			name:        "plain error",
+			err:         errors.New("boom"),
+			wantStatus:  http.StatusInternalServerError,
+			wantMessage: "Internal server error: boom",
+			wantCode:    api.ErrorCodeInternal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			w := &errorResponseWriter{ResponseWriter: rec, request: httptest.NewRequest(http.MethodGet, "/", nil)}
+			w.RespondWithError(tt.err)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			var body api.APIError
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			if body.Error != tt.wantMessage || body.Code != tt.wantCode {
+				t.Errorf("body = %+v, want error %q code %q", body, tt.wantMessage, tt.wantCode)
+			}
+			if len(body.Details) != len(tt.wantDetails) {
+				t.Errorf("details = %v, want %v", body.Details, tt.wantDetails)
+			}
+			for k, v := range tt.wantDetails {
+				if body.Details[k] != v {
+					t.Errorf("details[%q] = %v, want %v", k, body.Details[k], v)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,247 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/a2aproject/a2a-go/v2/a2a"
+	"github.com/kagent-dev/kagent/go/api/adk"
+	kclient "github.com/kagent-dev/kagent/go/api/client"
+	"github.com/kagent-dev/kagent/go/api/database"
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	"github.com/kagent-dev/kagent/go/core/internal/httpserver/handlers"
+	"github.com/kagent-dev/kagent/go/core/internal/httpserver/openapi"
+	"github.com/kagent-dev/kagent/go/core/internal/version"
+	kmcpv1alpha1 "github.com/kagent-dev/kmcp/api/v1alpha1"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// listQuery are the query parameters of the list endpoints that take
+// pagination, filtering and sorting parameters.
+var listQuery = []openapi.Param{
+	{Name: "limit", Type: "integer", Description: "Return at most this many items (1-1000)"},
+	{Name: "offset", Type: "integer", Description: "Skip this many items"},
+	{Name: "sort", Description: "Field to sort by; prefix with - for descending order"},
+	{Name: "namespace", Description: "Only items in this namespace"},
+	{Name: "label", Description: "Only items matching this Kubernetes label selector"},
+	{Name: "created_after", Description: "Only items created after this RFC3339 timestamp"},
+}
+
+// sessionEventRequest is the body of POST /api/sessions/{session_id}/events.
+type sessionEventRequest struct {
+	ID   string `json:"id"`
+	Data string `json:"data"`
+}
+
+// sessionShareRequest is the body of POST /api/sessions/{session_id}/shares.
+type sessionShareRequest struct {
+	ReadOnly *bool `json:"read_only,omitempty"`
+}
+
+// mcpToolCallRequest is the body of POST
+// /api/mcp-apps/{namespace}/{name}/tools/{toolName}/call.
+type mcpToolCallRequest struct {
+	Arguments map[string]any `json:"arguments,omitempty"`
+}
+
+// apiRoutes documents the routes setupRoutes registers, for the OpenAPI
+// document. TestAPIRoutesDocumented keeps the two in sync.
+var apiRoutes = []openapi.Route{
+	{Method: http.MethodGet, Path: APIPathHealth, ID: "getHealth", Tag: "system", Summary: "Check that the controller is serving", Response: map[string]any{}},
+	{Method: http.MethodGet, Path: APIPathVersion, ID: "getVersion", Tag: "system", Summary: "Get the controller version", Response: api.VersionResponse{}, Raw: true},
+	{Method: http.MethodGet, Path: APIPathMe, ID: "getCurrentUser", Tag: "system", Summary: "Get the authenticated user", Response: map[string]any{}, Raw: true},
+	{Method: http.MethodGet, Path: APIPathOpenAPI, ID: "getOpenAPIDocument", Tag: "system", Summary: "Get this OpenAPI document", Response: map[string]any{}, Raw: true},
+
+	{Method: http.MethodGet, Path: APIPathModelConfig, ID: "listModelConfigs", Tag: "modelconfigs", Summary: "List ModelConfigs", Response: []api.ModelConfigResource{}},
+	{Method: http.MethodPost, Path: APIPathModelConfig, ID: "createModelConfig", Tag: "modelconfigs", Summary: "Create a ModelConfig", Request: api.CreateModelConfigRequest{}, Response: api.ModelConfigResource{}, Status: http.StatusCreated},
+	{Method: http.MethodGet, Path: APIPathModelConfig + "/{namespace}/{name}", ID: "getModelConfig", Tag: "modelconfigs", Summary: "Get a ModelConfig", Response: api.ModelConfigResource{}},
+	{Method: http.MethodPut, Path: APIPathModelConfig + "/{namespace}/{name}", ID: "updateModelConfig", Tag: "modelconfigs", Summary: "Update a ModelConfig", Request: api.UpdateModelConfigRequest{}, Response: api.ModelConfigResource{}},
+	{Method: http.MethodDelete, Path: APIPathModelConfig + "/{namespace}/{name}", ID: "deleteModelConfig", Tag: "modelconfigs", Summary: "Delete a ModelConfig"},
+
+	{Method: http.MethodGet, Path: APIPathSessions, ID: "listSessions", Tag: "sessions", Summary: "List the sessions of the user", Query: listQuery, Response: []database.Session{}},
+	{Method: http.MethodPost, Path: APIPathSessions, ID: "createSession", Tag: "sessions", Summary: "Create a session", Request: api.SessionRequest{}, Response: database.Session{}, Status: http.StatusCreated},
+	{Method: http.MethodGet, Path: APIPathSessions + "/agent/{namespace}/{name}", ID: "listAgentSessions", Tag: "sessions", Summary: "List the sessions of the user with an agent", Query: listQuery, Response: []database.SessionWithShareToken{}},
+	{Method: http.MethodGet, Path: APIPathSessions + "/{session_id}", ID: "getSession", Tag: "sessions", Summary: "Get a session with its events", Query: []openapi.Param{
+		{Name: "order", Description: "Order of the events: asc or desc (default)"},
+		{Name: "after", Description: "Only events after this RFC3339 timestamp"},
+		{Name: "limit", Type: "integer", Description: "Return at most this many events"},
+	}, Response: handlers.SessionResponse{}},
+	{Method: http.MethodPut, Path: APIPathSessions + "/{session_id}", ID: "updateSession", Tag: "sessions", Summary: "Update a session", Request: api.SessionRequest{}, Response: database.Session{}},
+	{Method: http.MethodPatch, Path: APIPathSessions + "/{session_id}", ID: "patchSession", Tag: "sessions", Summary: "Update a session", Request: api.SessionRequest{}, Response: database.Session{}},
+	{Method: http.MethodDelete, Path: APIPathSessions + "/{session_id}", ID: "deleteSession", Tag: "sessions", Summary: "Delete a session"},
+	{Method: http.MethodGet, Path: APIPathSessions + "/{session_id}/tasks", ID: "listSessionTasks", Tag: "sessions", Summary: "List the tasks of a session", Query: listQuery, Response: []a2a.Task{}},
+	{Method: http.MethodGet, Path: APIPathSessions + "/{session_id}/approvals", ID: "listSessionApprovals", Tag: "sessions", Summary: "List the tool calls of a session awaiting approval", Response: []api.ToolApproval{}},
+	{Method: http.MethodPost, Path: APIPathSessions + "/{session_id}/events", ID: "addSessionEvent", Tag: "sessions", Summary: "Add an event to a session", Request: sessionEventRequest{}, Response: database.Event{}, Status: http.StatusCreated},
+	{Method: http.MethodGet, Path: APIPathSessions + "/{session_id}/state", ID: "listSessionState", Tag: "sessions", Summary: "List the state of a session", Response: []database.SessionStateEntry{}},
+	{Method: http.MethodGet, Path: APIPathSessions + "/{session_id}/state/{key}", ID: "getSessionState", Tag: "sessions", Summary: "Get a session state value", Response: database.SessionStateEntry{}},
+	{Method: http.MethodPut, Path: APIPathSessions + "/{session_id}/state/{key}", ID: "putSessionState", Tag: "sessions", Summary: "Set a session state value", Request: api.SessionStateRequest{}, Response: database.SessionStateEntry{}},
+	{Method: http.MethodDelete, Path: APIPathSessions + "/{session_id}/state/{key}", ID: "deleteSessionState", Tag: "sessions", Summary: "Delete a session state value"},
+	{Method: http.MethodGet, Path: APIPathSessions + "/{session_id}/llm-traces", ID: "listLLMTraces", Tag: "sessions", Summary: "List the LLM calls of a session", Response: []api.LLMTrace{}},
+	{Method: http.MethodPost, Path: APIPathSessions + "/{session_id}/llm-traces", ID: "createLLMTrace", Tag: "sessions", Summary: "Record an LLM call of a session", Request: api.LLMTraceRequest{}, Response: api.LLMTrace{}, Status: http.StatusCreated},
+	{Method: http.MethodPost, Path: APIPathSessions + "/{session_id}/fork", ID: "forkSession", Tag: "sessions", Summary: "Fork a session", Request: api.ForkSessionRequest{}, Response: api.ForkSessionResponse{}, Status: http.StatusCreated},
+	{Method: http.MethodPost, Path: APIPathSessions + "/{session_id}/compact", ID: "compactSession", Tag: "sessions", Summary: "Compact the history of a session", Response: api.CompactSessionResponse{}},
+	{Method: http.MethodGet, Path: APIPathSessions + "/{session_id}/compaction", ID: "getSessionCompaction", Tag: "sessions", Summary: "Get the compaction status of a session", Response: api.SessionCompactionStatus{}},
+	{Method: http.MethodPost, Path: APIPathSessions + "/{session_id}/shares", ID: "createSessionShare", Tag: "sessions", Summary: "Create a share link to a session", Request: sessionShareRequest{}, Response: database.SessionShare{}, Status: http.StatusCreated},
+	{Method: http.MethodGet, Path: APIPathSessions + "/{session_id}/shares", ID: "listSessionShares", Tag: "sessions", Summary: "List the share links of a session", Response: []database.SessionShare{}},
+	{Method: http.MethodDelete, Path: APIPathSessions + "/{session_id}/shares/{token}", ID: "deleteSessionShare", Tag: "sessions", Summary: "Revoke a share link"},
+	{Method: http.MethodGet, Path: APIPathSessions + "/{session_id}/grants", ID: "listSessionGrants", Tag: "sessions", Summary: "List the grants of access to a session", Response: []api.SessionGrant{}},
+	{Method: http.MethodPut, Path: APIPathSessions + "/{session_id}/grants/{kind}/{principal}", ID: "putSessionGrant", Tag: "sessions", Summary: "Grant a user or group access to a session", Request: api.SessionGrantRequest{}, Response: api.SessionGrant{}},
+	{Method: http.MethodDelete, Path: APIPathSessions + "/{session_id}/grants/{kind}/{principal}", ID: "deleteSessionGrant", Tag: "sessions", Summary: "Revoke the access of a user or group to a session"},
+
+	{Method: http.MethodGet, Path: APIPathTasks + "/{task_id}", ID: "getTask", Tag: "tasks", Summary: "Get a task", Response: a2a.Task{}},
+	{Method: http.MethodPost, Path: APIPathTasks, ID: "createTask", Tag: "tasks", Summary: "Store a task", Request: a2a.Task{}, Response: a2a.Task{}, Status: http.StatusCreated},
+	{Method: http.MethodDelete, Path: APIPathTasks + "/{task_id}", ID: "deleteTask", Tag: "tasks", Summary: "Delete a task", Raw: true, Status: http.StatusNoContent},
+	{Method: http.MethodGet, Path: APIPathTasks + "/{task_id}/artifacts/{name}", ID: "getTaskArtifact", Tag: "tasks", Summary: "Download an artifact of a task", Binary: true},
+	{Method: http.MethodGet, Path: APIPathTasks + "/{task_id}/attachments/{message_id}/{index}", ID: "getTaskAttachment", Tag: "tasks", Summary: "Download a file attached to a message of a task", Binary: true},
+
+	{Method: http.MethodGet, Path: APIPathTools, ID: "listTools", Tag: "tools", Summary: "List the tools of all tool servers", Response: []api.Tool{}},
+	{Method: http.MethodGet, Path: APIPathToolServers, ID: "listToolServers", Tag: "toolservers", Summary: "List tool servers", Query: listQuery, Response: []api.ToolServerResponse{}},
+	{Method: http.MethodPost, Path: APIPathToolServers, ID: "createToolServer", Tag: "toolservers", Summary: "Create a tool server", Request: handlers.ToolServerCreateRequest{}, Response: openapi.OneOf{v1alpha2.RemoteMCPServer{}, kmcpv1alpha1.MCPServer{}}, Status: http.StatusCreated},
+	{Method: http.MethodDelete, Path: APIPathToolServers + "/{namespace}/{name}", ID: "deleteToolServer", Tag: "toolservers", Summary: "Delete a tool server"},
+	{Method: http.MethodGet, Path: APIPathToolServers + "/{namespace}/{name}/stats", ID: "getToolServerStats", Tag: "toolservers", Summary: "Get the tool call stats of a tool server", Query: []openapi.Param{
+		{Name: "hours", Type: "integer", Description: "Number of hours of stats to return (default 24, at most 720)"},
+	}, Response: api.ToolServerStatsResponse{}},
+	{Method: http.MethodPost, Path: APIPathToolServers + "/{namespace}/{name}/stats", ID: "reportToolServerStats", Tag: "toolservers", Summary: "Report the tool calls an agent made to a tool server", Request: adk.ToolCallStatsReport{}, Raw: true, Status: http.StatusNoContent},
+	{Method: http.MethodGet, Path: APIPathToolServerTypes, ID: "listToolServerTypes", Tag: "toolservers", Summary: "List the supported tool server types", Response: handlers.ToolServerTypes{}},
+
+	{Method: http.MethodGet, Path: APIPathMCPApps + "/{namespace}/{name}/tools", ID: "listMCPAppTools", Tag: "mcp-apps", Summary: "List the tools of an MCP server", Query: []openapi.Param{{Name: "groupKind", Description: "Kind of the MCP server"}}, Response: []handlers.MCPAppToolResponse{}},
+	{Method: http.MethodPost, Path: APIPathMCPApps + "/{namespace}/{name}/tools/{toolName}/call", ID: "callMCPAppTool", Tag: "mcp-apps", Summary: "Call a tool of an MCP server", Query: []openapi.Param{{Name: "groupKind", Description: "Kind of the MCP server"}}, Request: mcpToolCallRequest{}, Response: mcp.CallToolResult{}},
+	{Method: http.MethodGet, Path: APIPathMCPApps + "/{namespace}/{name}/resources", ID: "readMCPAppResource", Tag: "mcp-apps", Summary: "Read a resource of an MCP server", Query: []openapi.Param{{Name: "uri", Description: "URI of the resource"}, {Name: "groupKind", Description: "Kind of the MCP server"}}, Response: mcp.ReadResourceResult{}},
+
+	{Method: http.MethodGet, Path: APIPathAgents, ID: "listAgents", Tag: "agents", Summary: "List agents", Query: listQuery, Response: []api.AgentResponse{}},
+	{Method: http.MethodPost, Path: APIPathAgents, ID: "createAgent", Tag: "agents", Summary: "Create an agent", Request: v1alpha2.Agent{}, Response: v1alpha2.Agent{}, Status: http.StatusCreated},
+	{Method: http.MethodPut, Path: APIPathAgents, ID: "updateAgent", Tag: "agents", Summary: "Update an agent", Request: v1alpha2.Agent{}, Response: v1alpha2.Agent{}},
+	{Method: http.MethodPost, Path: APIPathAgents + "/validate", ID: "validateAgent", Tag: "agents", Summary: "Validate an agent without creating it", Request: v1alpha2.Agent{}, Response: api.ValidateAgentResponse{}},
+	{Method: http.MethodGet, Path: APIPathAgents + "/{namespace}/{name}", ID: "getAgent", Tag: "agents", Summary: "Get an agent", Response: api.AgentResponse{}},
+	{Method: http.MethodDelete, Path: APIPathAgents + "/{namespace}/{name}", ID: "deleteAgent", Tag: "agents", Summary: "Delete an agent"},
+	{Method: http.MethodPost, Path: APIPathAgents + "/{namespace}/{name}/invoke-async", ID: "invokeAgentAsync", Tag: "agents", Summary: "Start a task without waiting for it to finish", Request: api.InvokeAsyncRequest{}, Response: api.InvokeAsyncResponse{}, Status: http.StatusAccepted},
+
+	{Method: http.MethodPost, Path: APIPathSandboxAgents, ID: "createSandboxAgent", Tag: "agents", Summary: "Create a sandbox agent", Request: v1alpha2.SandboxAgent{}, Response: api.AgentResponse{}, Status: http.StatusCreated},
+	{Method: http.MethodGet, Path: APIPathSandboxAgents + "/{namespace}/{name}", ID: "getSandboxAgent", Tag: "agents", Summary: "Get a sandbox agent", Response: api.AgentResponse{}},
+	{Method: http.MethodPut, Path: APIPathSandboxAgents + "/{namespace}/{name}", ID: "updateSandboxAgent", Tag: "agents", Summary: "Update a sandbox agent", Request: v1alpha2.SandboxAgent{}, Response: api.AgentResponse{}},
+	{Method: http.MethodDelete, Path: APIPathSandboxAgents + "/{namespace}/{name}", ID: "deleteSandboxAgent", Tag: "agents", Summary: "Delete a sandbox agent"},
+	{Method: http.MethodPost, Path: APIPathAgentHarnesses, ID: "createAgentHarness", Tag: "agents", Summary: "Create an agent harness", Request: v1alpha2.AgentHarness{}, Response: api.AgentResponse{}, Status: http.StatusCreated},
+	{Method: http.MethodGet, Path: APIPathAgentHarnesses + "/{namespace}/{name}", ID: "getAgentHarness", Tag: "agents", Summary: "Get an agent harness", Response: api.AgentResponse{}},
+	{Method: http.MethodDelete, Path: APIPathAgentHarnesses + "/{namespace}/{name}", ID: "deleteAgentHarness", Tag: "agents", Summary: "Delete an agent harness"},
+	{Method: http.MethodPost, Path: APIPathAgentHarnesses + "/{namespace}/{name}/sessions/{session_id}/ensure", ID: "ensureAgentHarnessSessionActor", Tag: "agents", Summary: "Provision the actor of an agent harness session", Response: handlers.AgentHarnessSessionActorResponse{}},
+	{Method: http.MethodPost, Path: APIPathAgentHarnesses + "/{namespace}/{name}/sessions/{session_id}/suspend", ID: "suspendAgentHarnessSessionActor", Tag: "agents", Summary: "Suspend the actor of an agent harness session", Response: handlers.AgentHarnessSessionActorResponse{}},
+	{Method: http.MethodGet, Path: APIPathAgentHarnesses + "/{namespace}/{name}/sessions/{session_id}/status", ID: "getAgentHarnessSessionActor", Tag: "agents", Summary: "Get the actor of an agent harness session", Response: handlers.AgentHarnessSessionActorResponse{}},
+
+	{Method: http.MethodGet, Path: APIPathModelProviderConfigs + "/models", ID: "listSupportedModelProviders", Tag: "models", Summary: "List the supported model providers", Response: []api.ProviderInfo{}},
+	{Method: http.MethodGet, Path: APIPathModelProviderConfigs + "/memories", ID: "listSupportedMemoryProviders", Tag: "models", Summary: "List the supported memory providers", Response: []api.ProviderInfo{}},
+	{Method: http.MethodGet, Path: APIPathModelProviderConfigs + "/configured", ID: "listConfiguredModelProviders", Tag: "models", Summary: "List the ready ModelProviderConfigs", Response: []handlers.ModelProviderResponse{}},
+	{Method: http.MethodGet, Path: APIPathModelProviderConfigs + "/configured/{name}/models", ID: "listProviderModels", Tag: "models", Summary: "List the models of a ModelProviderConfig", Query: []openapi.Param{
+		{Name: "refresh", Type: "boolean", Description: "Fetch the models from the provider instead of the cache"},
+	}, Response: handlers.ModelsResponse{}},
+	{Method: http.MethodGet, Path: APIPathModels, ID: "listSupportedModels", Tag: "models", Summary: "List the supported models by provider", Response: kclient.ProviderModels{}},
+
+	{Method: http.MethodPost, Path: APIPathMemories + "/sessions", ID: "addMemory", Tag: "memories", Summary: "Store a memory of an agent", Request: handlers.AddSessionMemoryRequest{}, Response: map[string]string{}, Raw: true, Status: http.StatusCreated},
+	{Method: http.MethodPost, Path: APIPathMemories + "/sessions/batch", ID: "addMemories", Tag: "memories", Summary: "Store memories of an agent", Request: handlers.AddSessionMemoryBatchRequest{}, Response: map[string]int{}, Raw: true, Status: http.StatusCreated},
+	{Method: http.MethodPost, Path: APIPathMemories + "/search", ID: "searchMemories", Tag: "memories", Summary: "Search the memories of an agent by similarity", Request: handlers.SearchSessionMemoryRequest{}, Response: []handlers.SearchSessionMemoryResponse{}, Raw: true},
+	{Method: http.MethodGet, Path: APIPathMemories, ID: "listMemories", Tag: "memories", Summary: "List the memories of an agent", Query: []openapi.Param{{Name: "agent_name"}, {Name: "user_id"}}, Response: []handlers.ListMemoryResponse{}, Raw: true},
+	{Method: http.MethodDelete, Path: APIPathMemories, ID: "deleteMemories", Tag: "memories", Summary: "Delete the memories of an agent", Query: []openapi.Param{{Name: "agent_name"}, {Name: "user_id"}}, Response: map[string]string{}, Raw: true},
+
+	{Method: http.MethodGet, Path: APIPathNamespaces, ID: "listNamespaces", Tag: "system", Summary: "List the namespaces the controller watches", Response: []api.NamespaceResponse{}},
+	{Method: http.MethodGet, Path: APIPathSubstrateStatus, ID: "getSubstrateStatus", Tag: "system", Summary: "Get the status of the substrate sandbox backend", Query: []openapi.Param{{Name: "namespace"}}, Response: api.SubstrateStatusResponse{}},
+
+	{Method: http.MethodGet, Path: APIPathPromptTemplates, ID: "listPromptTemplates", Tag: "templates", Summary: "List prompt template libraries", Query: []openapi.Param{{Name: "namespace"}}, Response: []api.PromptTemplateSummary{}},
+	{Method: http.MethodPost, Path: APIPathPromptTemplates, ID: "createPromptTemplate", Tag: "templates", Summary: "Create a prompt template library", Request: api.CreatePromptTemplateRequest{}, Response: api.PromptTemplateDetail{}, Status: http.StatusCreated},
+	{Method: http.MethodGet, Path: APIPathPromptTemplates + "/{namespace}/{name}", ID: "getPromptTemplate", Tag: "templates", Summary: "Get a prompt template library", Response: api.PromptTemplateDetail{}},
+	{Method: http.MethodPut, Path: APIPathPromptTemplates + "/{namespace}/{name}", ID: "updatePromptTemplate", Tag: "templates", Summary: "Update a prompt template library", Request: api.UpdatePromptTemplateRequest{}, Response: api.PromptTemplateDetail{}},
+	{Method: http.MethodDelete, Path: APIPathPromptTemplates + "/{namespace}/{name}", ID: "deletePromptTemplate", Tag: "templates", Summary: "Delete a prompt template library"},
+	{Method: http.MethodGet, Path: APIPathAgentTemplates, ID: "listAgentTemplates", Tag: "templates", Summary: "List agent templates", Query: []openapi.Param{{Name: "namespace"}}, Response: []api.AgentTemplate{}},
+	{Method: http.MethodGet, Path: APIPathAgentTemplates + "/{namespace}/{name}", ID: "getAgentTemplate", Tag: "templates", Summary: "Get an agent template", Response: api.AgentTemplate{}},
+	{Method: http.MethodPost, Path: APIPathAgentTemplates + "/{namespace}/{name}/agents", ID: "createAgentFromTemplate", Tag: "templates", Summary: "Create an agent from a template", Request: api.CreateAgentFromTemplateRequest{}, Response: v1alpha2.Agent{}, Status: http.StatusCreated},
+
+	{Method: http.MethodPost, Path: APIPathFeedback, ID: "createFeedback", Tag: "feedback", Summary: "Submit feedback on an agent response", Request: database.Feedback{}},
+	{Method: http.MethodGet, Path: APIPathFeedback, ID: "listFeedback", Tag: "feedback", Summary: "List the feedback of the user", Response: []database.Feedback{}},
+
+	{Method: http.MethodGet, Path: APIPathAnalytics + "/providers", ID: "listProviderStats", Tag: "stats", Summary: "List the daily token usage by model provider", Query: []openapi.Param{
+		{Name: "days", Type: "integer", Description: "Number of days of stats to return"},
+	}, Response: []api.ProviderStatsResponse{}},
+	{Method: http.MethodGet, Path: APIPathStats, ID: "getStats", Tag: "stats", Summary: "Get the live activity of the agents", Query: []openapi.Param{
+		{Name: "window", Description: "Duration to compute rates over (default 5m)"},
+	}, Response: api.StatsResponse{}},
+
+	{Method: http.MethodPost, Path: APIPathDebug + "/agents/{namespace}/{name}/capture", ID: "startAgentCapture", Tag: "debug", Summary: "Start capturing the LLM traffic of an agent", Request: api.StartDebugCaptureRequest{}, Response: api.DebugCaptureResponse{}, Status: http.StatusAccepted},
+	{Method: http.MethodGet, Path: APIPathDebug + "/agents/{namespace}/{name}/capture", ID: "getAgentCapture", Tag: "debug", Summary: "Get the captured LLM traffic of an agent", Response: api.DebugCaptureResponse{}},
+	{Method: http.MethodPost, Path: APIPathLint + "/agent", ID: "lintAgent", Tag: "agents", Summary: "Lint an agent for common mistakes", Request: v1alpha2.Agent{}, Response: api.LintAgentResponse{}},
+	{Method: http.MethodPost, Path: APIPathApply, ID: "apply", Tag: "agents", Summary: "Create or update several resources at once", Request: api.ApplyRequest{}, Response: api.ApplyResponse{}},
+	{Method: http.MethodPost, Path: APIPathRetention + "/prune", ID: "prune", Tag: "system", Summary: "Delete the sessions and tasks older than the retention policy", Request: api.PruneRequest{}, Response: api.PruneResponse{}},
+
+	{Method: http.MethodPost, Path: APIPathLangGraph + "/checkpoints", ID: "putLangGraphCheckpoint", Tag: "frameworks", Summary: "Store a LangGraph checkpoint", Request: handlers.KAgentCheckpointPayload{}, Status: http.StatusCreated},
+	{Method: http.MethodGet, Path: APIPathLangGraph + "/checkpoints", ID: "listLangGraphCheckpoints", Tag: "frameworks", Summary: "List the LangGraph checkpoints of a thread", Query: []openapi.Param{
+		{Name: "thread_id"}, {Name: "checkpoint_ns"}, {Name: "checkpoint_id"}, {Name: "limit", Type: "integer"},
+	}, Response: []handlers.KAgentCheckpointTuple{}},
+	{Method: http.MethodPost, Path: APIPathLangGraph + "/checkpoints/writes", ID: "putLangGraphWrites", Tag: "frameworks", Summary: "Store the pending writes of a LangGraph checkpoint", Request: handlers.KAgentCheckpointWritePayload{}, Status: http.StatusCreated},
+	{Method: http.MethodDelete, Path: APIPathLangGraph + "/checkpoints/{thread_id}", ID: "deleteLangGraphThread", Tag: "frameworks", Summary: "Delete the LangGraph checkpoints of a thread"},
+	{Method: http.MethodPost, Path: APIPathCrewAI + "/memory", ID: "storeCrewAIMemory", Tag: "frameworks", Summary: "Store a CrewAI memory", Request: handlers.KagentMemoryPayload{}, Status: http.StatusCreated},
+	{Method: http.MethodGet, Path: APIPathCrewAI + "/memory", ID: "getCrewAIMemory", Tag: "frameworks", Summary: "Search the CrewAI memories of a thread", Query: []openapi.Param{
+		{Name: "thread_id"}, {Name: "q", Description: "Task description to search for"}, {Name: "limit", Type: "integer"},
+	}, Response: []handlers.KagentMemoryPayload{}},
+	{Method: http.MethodDelete, Path: APIPathCrewAI + "/memory", ID: "resetCrewAIMemory", Tag: "frameworks", Summary: "Delete the CrewAI memories of a thread", Query: []openapi.Param{{Name: "thread_id"}}},
+	{Method: http.MethodPost, Path: APIPathCrewAI + "/flows/state", ID: "storeCrewAIFlowState", Tag: "frameworks", Summary: "Store the state of a CrewAI flow", Request: handlers.KagentFlowStatePayload{}, Status: http.StatusCreated},
+	{Method: http.MethodGet, Path: APIPathCrewAI + "/flows/state", ID: "getCrewAIFlowState", Tag: "frameworks", Summary: "Get the state of a CrewAI flow", Query: []openapi.Param{{Name: "thread_id"}}, Response: handlers.KagentFlowStatePayload{}},
+}
+
+// OpenAPIDocument returns the OpenAPI document of the REST API. The A2A, MCP
+// and agent harness gateway endpoints follow their own protocols and are not
+// part of it.
+func OpenAPIDocument() *openapi.Document {
+	b := openapi.NewBuilder(openapi.Info{
+		Title: "kagent API",
+		Description: "REST API of the kagent controller. Requests are authenticated as configured on the " +
+			"controller; most responses wrap their data in {\"error\", \"data\", \"message\"}.",
+		Version: version.Version,
+	})
+	for _, route := range apiRoutes {
+		b.Add(route)
+	}
+	return b.Document()
+}
+
+var (
+	openAPIOnce sync.Once
+	openAPIJSON []byte
+	openAPIErr  error
+)
+
+// handleOpenAPI serves the OpenAPI document, which only changes with the
+// binary.
+func handleOpenAPI(w http.ResponseWriter, r *http.Request) {
+	openAPIOnce.Do(func() {
+		openAPIJSON, openAPIErr = json.Marshal(OpenAPIDocument())
+	})
+	if openAPIErr != nil {
+		handlers.RespondWithError(w, http.StatusInternalServerError, openAPIErr.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(openAPIJSON) //nolint:errcheck
+}
+
+// apiDocsPage renders the OpenAPI document with Swagger UI.
+const apiDocsPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>kagent API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js" crossorigin></script>
+  <script>
+    window.ui = SwaggerUIBundle({ url: "openapi.json", dom_id: "#swagger-ui" });
+  </script>
+</body>
+</html>
+`
+
+// handleAPIDocs serves Swagger UI for the OpenAPI document.
+func handleAPIDocs(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write([]byte(apiDocsPage)) //nolint:errcheck
+}
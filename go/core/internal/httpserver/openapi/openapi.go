@@ -0,0 +1,418 @@
+// Package openapi builds an OpenAPI 3 document for the controller REST API
+// from its routes and the Go types of their request and response bodies.
+package openapi
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
+)
+
+// Version is the version of the OpenAPI specification the documents follow.
+const Version = "3.0.3"
+
+// Document is an OpenAPI document.
+type Document struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       Info                 `json:"info"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components Components           `json:"components"`
+}
+
+// Info describes the API.
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// PathItem holds the operations of a path, by lower-case HTTP method.
+type PathItem map[string]*Operation
+
+// Operation is an API operation.
+type Operation struct {
+	OperationID string               `json:"operationId"`
+	Summary     string               `json:"summary,omitempty"`
+	Tags        []string             `json:"tags,omitempty"`
+	Parameters  []*Parameter         `json:"parameters,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+// Parameter is a path or query parameter of an operation.
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// RequestBody is the body of a request.
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// Response is a response of an operation.
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// MediaType holds the schema of a body.
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Components holds the schemas operations refer to.
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+// Schema is a JSON schema, as far as the API types need one.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	OneOf                []*Schema          `json:"oneOf,omitempty"`
+}
+
+// Route describes an operation of the API.
+type Route struct {
+	Method string
+	// Path is the route template, e.g. /api/agents/{namespace}/{name}.
+	Path string
+	// ID is the operationId SDK generators name the client method after.
+	ID      string
+	Summary string
+	Tag     string
+	Query   []Param
+	// Request is a value of the type of the request body, or nil when the
+	// operation takes none.
+	Request any
+	// Response is a value of the type of the data field of the standard
+	// response, or of the whole body when Raw is set. Nil when the
+	// operation returns no data.
+	Response any
+	// Raw is set for operations whose body is not wrapped in the standard
+	// response.
+	Raw bool
+	// Binary is set for operations that return a file instead of JSON.
+	Binary bool
+	// Status is the status of successful responses. Defaults to 200.
+	Status int
+}
+
+// Param is a query parameter.
+type Param struct {
+	Name        string
+	Description string
+	// Type is the JSON type of the value: string (the default), integer or
+	// boolean.
+	Type string
+}
+
+// OneOf is a Response or Request that is any one of the types of its values.
+type OneOf []any
+
+// Builder builds a Document from routes.
+type Builder struct {
+	doc *Document
+	// types maps each component name to the type it was generated from.
+	types map[string]reflect.Type
+	// names maps each type to its component name.
+	names map[reflect.Type]string
+}
+
+// NewBuilder returns a Builder of a document about info.
+func NewBuilder(info Info) *Builder {
+	return &Builder{
+		doc: &Document{
+			OpenAPI:    Version,
+			Info:       info,
+			Paths:      map[string]*PathItem{},
+			Components: Components{Schemas: map[string]*Schema{}},
+		},
+		types: map[string]reflect.Type{},
+		names: map[reflect.Type]string{},
+	}
+}
+
+// Add adds the operation of route to the document.
+func (b *Builder) Add(route Route) {
+	p := OpenAPIPath(route.Path)
+	item := b.doc.Paths[p]
+	if item == nil {
+		item = &PathItem{}
+		b.doc.Paths[p] = item
+	}
+
+	op := &Operation{
+		OperationID: route.ID,
+		Summary:     route.Summary,
+		Responses:   map[string]*Response{},
+	}
+	if route.Tag != "" {
+		op.Tags = []string{route.Tag}
+	}
+	for _, name := range pathParams(route.Path) {
+		op.Parameters = append(op.Parameters, &Parameter{Name: name, In: "path", Required: true, Schema: &Schema{Type: "string"}})
+	}
+	for _, q := range route.Query {
+		typ := q.Type
+		if typ == "" {
+			typ = "string"
+		}
+		op.Parameters = append(op.Parameters, &Parameter{Name: q.Name, In: "query", Description: q.Description, Schema: &Schema{Type: typ}})
+	}
+	if route.Request != nil {
+		op.RequestBody = &RequestBody{Required: true, Content: jsonContent(b.valueSchema(route.Request))}
+	}
+
+	status := route.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	success := &Response{Description: http.StatusText(status)}
+	switch {
+	case route.Binary:
+		success.Content = map[string]MediaType{"application/octet-stream": {Schema: &Schema{Type: "string", Format: "binary"}}}
+	case route.Raw && route.Response != nil:
+		success.Content = jsonContent(b.valueSchema(route.Response))
+	case !route.Raw && status != http.StatusNoContent:
+		success.Content = jsonContent(b.standardResponse(route.Response))
+	}
+	op.Responses[strconv.Itoa(status)] = success
+	op.Responses["default"] = &Response{
+		Description: "Error",
+		Content:     jsonContent(b.valueSchema(api.APIError{})),
+	}
+
+	(*item)[strings.ToLower(route.Method)] = op
+}
+
+// Document returns the document of the routes added so far.
+func (b *Builder) Document() *Document {
+	return b.doc
+}
+
+// standardResponse returns the schema of the standard response whose data is
+// of the type of data.
+func (b *Builder) standardResponse(data any) *Schema {
+	s := &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"error":      {Type: "boolean"},
+			"message":    {Type: "string"},
+			"pagination": b.schema(reflect.TypeFor[api.Pagination]()),
+		},
+		Required: []string{"error"},
+	}
+	if data != nil {
+		s.Properties["data"] = b.valueSchema(data)
+	}
+	return s
+}
+
+func jsonContent(s *Schema) map[string]MediaType {
+	return map[string]MediaType{"application/json": {Schema: s}}
+}
+
+var pathParamPattern = regexp.MustCompile(`\{([^}:]+)(:[^}]*)?\}`)
+
+// pathParams returns the names of the variables of a route template.
+func pathParams(template string) []string {
+	var names []string
+	for _, m := range pathParamPattern.FindAllStringSubmatch(template, -1) {
+		names = append(names, m[1])
+	}
+	return names
+}
+
+// OpenAPIPath returns the OpenAPI path of a route template, without the
+// regular expressions of its variables.
+func OpenAPIPath(template string) string {
+	return pathParamPattern.ReplaceAllString(template, "{$1}")
+}
+
+func (b *Builder) valueSchema(v any) *Schema {
+	if oneOf, ok := v.(OneOf); ok {
+		s := &Schema{}
+		for _, alt := range oneOf {
+			s.OneOf = append(s.OneOf, b.valueSchema(alt))
+		}
+		return s
+	}
+	return b.schema(reflect.TypeOf(v))
+}
+
+var (
+	timeType        = reflect.TypeFor[time.Time]()
+	marshalerType   = reflect.TypeFor[json.Marshaler]()
+	textMarshalType = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+// schema returns the schema of values of t as encoding/json encodes them.
+// Named structs become components the schema refers to.
+func (b *Builder) schema(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType || (t.Kind() == reflect.Struct && t.Name() == "Time" && strings.HasPrefix(t.PkgPath(), "k8s.io/apimachinery/")):
+		return &Schema{Type: "string", Format: "date-time"}
+	case t.Implements(marshalerType) || reflect.PointerTo(t).Implements(marshalerType):
+		// Types that encode themselves can be anything, e.g. raw JSON.
+		return &Schema{}
+	case t.Implements(textMarshalType) || reflect.PointerTo(t).Implements(textMarshalType):
+		return &Schema{Type: "string"}
+	case t.Kind() == reflect.Struct && strings.HasPrefix(t.PkgPath(), "k8s.io/api/"):
+		// The Kubernetes workload types are documented by Kubernetes; inlining
+		// them would make most of the document about Pods.
+		return &Schema{
+			Type:        "object",
+			Description: fmt.Sprintf("Kubernetes %s.%s, see https://kubernetes.io/docs/reference/kubernetes-api/", kubeGroupVersion(t.PkgPath()), t.Name()),
+		}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: b.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: b.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return b.structSchema(t)
+		}
+		return b.component(t)
+	default:
+		// Interfaces and anything else encoding/json cannot describe ahead of
+		// time.
+		return &Schema{}
+	}
+}
+
+// component returns a reference to the component of the named struct t,
+// generating it on first use.
+func (b *Builder) component(t reflect.Type) *Schema {
+	name, ok := b.names[t]
+	if !ok {
+		name = b.componentName(t)
+		b.names[t] = name
+		b.types[name] = t
+		// Register the component before its properties, which can refer back
+		// to t.
+		s := &Schema{}
+		b.doc.Components.Schemas[name] = s
+		*s = *b.structSchema(t)
+	}
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+// componentName names the component of t after its package and type, e.g.
+// v1alpha2.Agent, falling back to more of the package path for types of
+// packages with the same name.
+func (b *Builder) componentName(t reflect.Type) string {
+	typeName := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return r
+		}
+		return '_'
+	}, t.Name())
+	pkgPath := strings.Split(t.PkgPath(), "/")
+	for i := len(pkgPath) - 1; i >= 0; i-- {
+		name := strings.Join(pkgPath[i:], ".") + "." + typeName
+		if existing, ok := b.types[name]; !ok || existing == t {
+			return name
+		}
+	}
+	return t.PkgPath() + "." + typeName
+}
+
+// structSchema returns the schema of the fields of struct t, following the
+// encoding/json rules for tags and embedded structs.
+func (b *Builder) structSchema(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	b.addFields(s, t)
+	if len(s.Properties) == 0 {
+		s.Properties = nil
+	}
+	return s
+}
+
+func (b *Builder) addFields(s *Schema, t reflect.Type) {
+	for i := range t.NumField() {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		inline := strings.Contains(","+opts+",", ",inline,")
+
+		if f.Anonymous && (name == "" || inline) {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				b.addFields(s, ft)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		var fs *Schema
+		if strings.Contains(","+opts+",", ",string,") {
+			fs = &Schema{Type: "string"}
+		} else {
+			fs = b.schema(f.Type)
+		}
+		s.Properties[name] = fs
+		if !strings.Contains(","+opts+",", ",omitempty,") && !strings.Contains(","+opts+",", ",omitzero,") {
+			s.Required = append(s.Required, name)
+		}
+	}
+}
+
+// kubeGroupVersion returns e.g. core/v1 for the package k8s.io/api/core/v1.
+func kubeGroupVersion(pkgPath string) string {
+	return path.Join(path.Base(path.Dir(pkgPath)), path.Base(pkgPath))
+}
@@ -0,0 +1,66 @@
+package openapi
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type meta struct {
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+type node struct {
+	meta     `json:",inline"`
+	Name     string      `json:"name"`
+	Size     int64       `json:"size,string"`
+	Children []*node     `json:"children,omitempty"`
+	Created  metav1.Time `json:"created"`
+	Updated  *time.Time  `json:"updated,omitempty"`
+	Pod      corev1.Pod  `json:"pod"`
+	Secret   string      `json:"-"`
+}
+
+func TestBuilder(t *testing.T) {
+	b := NewBuilder(Info{Title: "test", Version: "v1"})
+	b.Add(Route{
+		Method: http.MethodPut, Path: "/api/nodes/{namespace}/{name:[a-z]+}", ID: "putNode", Tag: "nodes",
+		Query:   []Param{{Name: "limit", Type: "integer"}},
+		Request: node{}, Response: []node{}, Status: http.StatusCreated,
+	})
+	b.Add(Route{Method: http.MethodDelete, Path: "/api/nodes/{namespace}/{name:[a-z]+}", ID: "deleteNode", Raw: true, Status: http.StatusNoContent})
+	doc := b.Document()
+
+	item := doc.Paths["/api/nodes/{namespace}/{name}"]
+	require.NotNil(t, item)
+	put := (*item)["put"]
+	require.Len(t, put.Parameters, 3)
+	require.Equal(t, "path", put.Parameters[1].In)
+	require.Equal(t, "name", put.Parameters[1].Name)
+	require.Equal(t, "integer", put.Parameters[2].Schema.Type)
+	require.Equal(t, "#/components/schemas/openapi.node", put.RequestBody.Content["application/json"].Schema.Ref)
+
+	data := put.Responses["201"].Content["application/json"].Schema.Properties["data"]
+	require.Equal(t, "array", data.Type)
+	require.Equal(t, "#/components/schemas/openapi.node", data.Items.Ref)
+	require.NotNil(t, put.Responses["default"])
+
+	del := (*item)["delete"]
+	require.Empty(t, del.Responses["204"].Content)
+
+	s := doc.Components.Schemas["openapi.node"]
+	require.NotNil(t, s)
+	require.ElementsMatch(t, []string{"name", "size", "created", "pod"}, s.Required)
+	require.Contains(t, s.Properties, "labels", "inline fields are flattened")
+	require.NotContains(t, s.Properties, "Secret")
+	require.Equal(t, "string", s.Properties["size"].Type)
+	require.Equal(t, "#/components/schemas/openapi.node", s.Properties["children"].Items.Ref)
+	require.Equal(t, "date-time", s.Properties["created"].Format)
+	require.Equal(t, "date-time", s.Properties["updated"].Format)
+	require.Equal(t, "object", s.Properties["pod"].Type)
+	require.Contains(t, s.Properties["pod"].Description, "Pod")
+}
@@ -0,0 +1,91 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/kagent-dev/kagent/go/core/internal/httpserver/handlers"
+	"github.com/kagent-dev/kagent/go/core/internal/httpserver/openapi"
+	"github.com/stretchr/testify/require"
+)
+
+// publishedOpenAPI is the OpenAPI document published with the repository,
+// which SDKs are generated from.
+var publishedOpenAPI = filepath.Join("..", "..", "..", "api", "openapi", "openapi.json")
+
+func TestAPIRoutesDocumented(t *testing.T) {
+	s := &HTTPServer{router: mux.NewRouter(), handlers: &handlers.Handlers{}}
+	s.setupRoutes()
+
+	documented := map[string]bool{}
+	for _, route := range apiRoutes {
+		key := route.Method + " " + route.Path
+		require.False(t, documented[key], "route %s is documented twice", key)
+		documented[key] = true
+	}
+
+	registered := map[string]bool{}
+	err := s.router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		methods, err := route.GetMethods()
+		if err != nil {
+			// PathPrefix routes (A2A, MCP, agent harness gateway) follow their
+			// own protocols.
+			return nil
+		}
+		path, err := route.GetPathTemplate()
+		if err != nil {
+			return err
+		}
+		if path == APIPathDocs {
+			return nil
+		}
+		for _, method := range methods {
+			key := method + " " + path
+			registered[key] = true
+			require.True(t, documented[key], "route %s is not documented in apiRoutes", key)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	for key := range documented {
+		require.True(t, registered[key], "documented route %s is not registered", key)
+	}
+}
+
+func TestOpenAPIDocumentPublished(t *testing.T) {
+	got, err := json.MarshalIndent(OpenAPIDocument(), "", "  ")
+	require.NoError(t, err)
+	got = append(got, '\n')
+
+	if os.Getenv("UPDATE_GOLDEN") == "true" {
+		require.NoError(t, os.MkdirAll(filepath.Dir(publishedOpenAPI), 0o755))
+		require.NoError(t, os.WriteFile(publishedOpenAPI, got, 0o644))
+		return
+	}
+	want, err := os.ReadFile(publishedOpenAPI)
+	require.NoError(t, err, "Run with UPDATE_GOLDEN=true to create it.")
+	require.Equal(t, string(want), string(got), "The published OpenAPI document is out of date. Run with UPDATE_GOLDEN=true to update.")
+}
+
+func TestHandleOpenAPI(t *testing.T) {
+	rec := httptest.NewRecorder()
+	handleOpenAPI(rec, httptest.NewRequest(http.MethodGet, APIPathOpenAPI, nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var doc openapi.Document
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &doc))
+	require.Equal(t, openapi.Version, doc.OpenAPI)
+	require.Contains(t, doc.Paths, "/api/agents/{namespace}/{name}")
+
+	rec = httptest.NewRecorder()
+	handleAPIDocs(rec, httptest.NewRequest(http.MethodGet, APIPathDocs, nil))
+	require.True(t, strings.HasPrefix(rec.Header().Get("Content-Type"), "text/html"))
+	require.Contains(t, rec.Body.String(), `url: "openapi.json"`)
+}
@@ -2,6 +2,9 @@ package httpserver
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net"
 	"net/http"
 	"strings"
 	"time"
@@ -11,7 +14,9 @@ import (
 	api "github.com/kagent-dev/kagent/go/api/httpapi"
 	"github.com/kagent-dev/kagent/go/core/internal/a2a"
 	"github.com/kagent-dev/kagent/go/core/internal/controller/reconciler"
+	authimpl "github.com/kagent-dev/kagent/go/core/internal/httpserver/auth"
 	"github.com/kagent-dev/kagent/go/core/internal/httpserver/handlers"
+	"github.com/kagent-dev/kagent/go/core/internal/leader"
 	"github.com/kagent-dev/kagent/go/core/internal/mcp"
 	common "github.com/kagent-dev/kagent/go/core/internal/utils"
 	"github.com/kagent-dev/kagent/go/core/internal/version"
@@ -29,6 +34,8 @@ const (
 	APIPathHealth               = "/health"
 	APIPathVersion              = "/version"
 	APIPathMe                   = "/api/me"
+	APIPathOpenAPI              = "/api/openapi.json"
+	APIPathDocs                 = "/api/docs"
 	APIPathModelConfig          = "/api/modelconfigs"
 	APIPathRuns                 = "/api/runs"
 	APIPathSessions             = "/api/sessions"
@@ -45,10 +52,17 @@ const (
 	APIPathMemories             = "/api/memories"
 	APIPathNamespaces           = "/api/namespaces"
 	APIPathPromptTemplates      = "/api/prompttemplates"
+	APIPathAgentTemplates       = "/api/templates"
 	APIPathA2A                  = "/api/a2a"
 	APIPathA2ASandboxes         = "/api/a2a-sandboxes"
 	APIPathMCP                  = "/mcp"
 	APIPathFeedback             = "/api/feedback"
+	APIPathAnalytics            = "/api/analytics"
+	APIPathStats                = "/api/stats"
+	APIPathDebug                = "/api/debug"
+	APIPathLint                 = "/api/lint"
+	APIPathApply                = "/api/apply"
+	APIPathRetention            = "/api/retention"
 	APIPathLangGraph            = "/api/langgraph"
 	APIPathCrewAI               = "/api/crewai"
 	APIPathAgentHarnessHarness  = "/api/agentharnesses/{namespace}/{name}/"
@@ -79,6 +93,16 @@ type ServerConfig struct {
 	MCPEgressPlaintext           bool
 	SubstrateSandboxActorBackend *substrate.SandboxAgentActorBackend
 	AgentHarnessSessionActor     *substrate.AgentHarnessSessionActorBackend
+	PushNotifier                 handlers.TaskPushNotifier
+	SessionCompactor             handlers.SessionCompactor
+	ArtifactArchiver             handlers.TaskArtifactArchiver
+	EventArchive                 handlers.SessionEventArchive
+	RetentionPolicy              dbpkg.RetentionPolicy
+	// LeaderForwarder forwards writes to the leader when several replicas
+	// serve the API. Nil serves every request locally.
+	LeaderForwarder *leader.Forwarder
+	// Limits bounds request sizes, timeouts and shutdown draining.
+	Limits ServerLimits
 }
 
 // HTTPServer is the structure that manages the HTTP server
@@ -92,7 +116,8 @@ type HTTPServer struct {
 
 // NewHTTPServer creates a new HTTP server instance
 func NewHTTPServer(config ServerConfig) (*HTTPServer, error) {
-	// Initialize database
+	// Session grants are enforced on top of whatever authorizer is configured.
+	config.Authorizer = &authimpl.SessionGrantAuthorizer{Next: config.Authorizer, Store: config.DbClient}
 
 	return &HTTPServer{
 		config: config,
@@ -111,12 +136,20 @@ func NewHTTPServer(config ServerConfig) (*HTTPServer, error) {
 			config.MCPEgressPlaintext,
 			config.SubstrateSandboxActorBackend,
 			config.AgentHarnessSessionActor,
+			config.PushNotifier,
+			config.SessionCompactor,
+			config.ArtifactArchiver,
+			config.EventArchive,
+			config.RetentionPolicy,
+			config.A2AHandler,
 		),
 		authenticator: config.Authenticator,
 	}, nil
 }
 
-// Start initializes and starts the HTTP server
+// Start serves the API until ctx is cancelled, then drains in-flight
+// requests for up to the shutdown timeout. Requests still running after it,
+// and hijacked WebSocket connections, are cancelled and closed.
 func (s *HTTPServer) Start(ctx context.Context) error {
 	log := ctrllog.FromContext(ctx).WithName("http-server")
 	log.Info("Starting HTTP server", "address", s.config.BindAddr)
@@ -124,8 +157,15 @@ func (s *HTTPServer) Start(ctx context.Context) error {
 	// Setup routes
 	s.setupRoutes()
 
+	// Request contexts outlive ctx so in-flight requests can finish while
+	// the server drains.
+	requestCtx, cancelRequests := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelRequests()
+
 	// Create HTTP server, wrapping the router with otelhttp for span creation
-	// and W3C TraceContext propagation on every incoming request.
+	// and W3C TraceContext propagation on every incoming request. Read and
+	// write deadlines are set per route by limitsMiddleware, as streaming
+	// routes must not have them.
 	s.httpServer = &http.Server{
 		Addr: s.config.BindAddr,
 		Handler: otelhttp.NewHandler(s.router, "http.server",
@@ -136,27 +176,39 @@ func (s *HTTPServer) Start(ctx context.Context) error {
 				return r.URL.Path != APIPathHealth
 			}),
 		),
+		ReadHeaderTimeout: s.config.Limits.ReadHeaderTimeout,
+		IdleTimeout:       s.config.Limits.IdleTimeout,
+		BaseContext:       func(net.Listener) context.Context { return requestCtx },
 	}
 
-	// Start the server in a separate goroutine
+	errCh := make(chan error, 1)
 	go func() {
-		if err := s.httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Error(err, "HTTP server failed")
-		}
+		errCh <- s.httpServer.ListenAndServe()
 	}()
 
-	// Wait for context cancellation to shut down
-	go func() {
-		<-ctx.Done()
-		log.Info("Shutting down HTTP server")
-		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
-		if err := s.httpServer.Shutdown(shutdownCtx); err != nil {
-			log.Error(err, "Failed to properly shutdown HTTP server")
+	select {
+	case err := <-errCh:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
 		}
-	}()
+		return fmt.Errorf("HTTP server failed: %w", err)
+	case <-ctx.Done():
+	}
 
-	return nil
+	log.Info("Shutting down HTTP server", "timeout", s.config.Limits.ShutdownTimeout)
+	shutdownCtx := context.Background()
+	if timeout := s.config.Limits.ShutdownTimeout; timeout > 0 {
+		var cancel context.CancelFunc
+		shutdownCtx, cancel = context.WithTimeout(shutdownCtx, timeout)
+		defer cancel()
+	}
+	err := s.httpServer.Shutdown(shutdownCtx)
+	cancelRequests()
+	if errors.Is(err, context.DeadlineExceeded) {
+		log.Info("Closing connections of requests still in flight after the shutdown timeout")
+		return s.httpServer.Close()
+	}
+	return err
 }
 
 // MemoryCleanupRunnable is a controller-runtime Runnable that periodically
@@ -208,7 +260,8 @@ func (s *HTTPServer) Stop(ctx context.Context) error {
 
 // NeedLeaderElection implements controller-runtime's LeaderElectionRunnable interface
 func (s *HTTPServer) NeedLeaderElection() bool {
-	// Return false so the HTTP server runs on all instances, not just the leader
+	// Return false so the HTTP server runs on all instances, not just the
+	// leader; writes are forwarded to the leader by the LeaderForwarder.
 	return false
 }
 
@@ -232,6 +285,10 @@ func (s *HTTPServer) setupRoutes() {
 		s.handlers.CurrentUser.HandleGetCurrentUser(erw, r)
 	})).Methods(http.MethodGet)
 
+	// API reference
+	s.router.HandleFunc(APIPathOpenAPI, handleOpenAPI).Methods(http.MethodGet)
+	s.router.HandleFunc(APIPathDocs, handleAPIDocs).Methods(http.MethodGet)
+
 	// Model configs
 	s.router.HandleFunc(APIPathModelConfig, adaptHandler(s.handlers.ModelConfig.HandleListModelConfigs)).Methods(http.MethodGet)
 	s.router.HandleFunc(APIPathModelConfig+"/{namespace}/{name}", adaptHandler(s.handlers.ModelConfig.HandleGetModelConfig)).Methods(http.MethodGet)
@@ -245,17 +302,32 @@ func (s *HTTPServer) setupRoutes() {
 	s.router.HandleFunc(APIPathSessions+"/agent/{namespace}/{name}", adaptHandler(s.handlers.Sessions.HandleGetSessionsForAgent)).Methods(http.MethodGet)
 	s.router.HandleFunc(APIPathSessions+"/{session_id}", adaptHandler(s.handlers.Sessions.HandleGetSession)).Methods(http.MethodGet)
 	s.router.HandleFunc(APIPathSessions+"/{session_id}/tasks", adaptHandler(s.handlers.Sessions.HandleListTasksForSession)).Methods(http.MethodGet)
+	s.router.HandleFunc(APIPathSessions+"/{session_id}/approvals", adaptHandler(s.handlers.Sessions.HandleListSessionApprovals)).Methods(http.MethodGet)
 	s.router.HandleFunc(APIPathSessions+"/{session_id}", adaptHandler(s.handlers.Sessions.HandleDeleteSession)).Methods(http.MethodDelete)
 	s.router.HandleFunc(APIPathSessions+"/{session_id}", adaptHandler(s.handlers.Sessions.HandleUpdateSession)).Methods(http.MethodPut, http.MethodPatch)
 	s.router.HandleFunc(APIPathSessions+"/{session_id}/events", adaptHandler(s.handlers.Sessions.HandleAddEventToSession)).Methods(http.MethodPost)
+	s.router.HandleFunc(APIPathSessions+"/{session_id}/state", adaptHandler(s.handlers.Sessions.HandleListSessionState)).Methods(http.MethodGet)
+	s.router.HandleFunc(APIPathSessions+"/{session_id}/state/{key}", adaptHandler(s.handlers.Sessions.HandleGetSessionState)).Methods(http.MethodGet)
+	s.router.HandleFunc(APIPathSessions+"/{session_id}/state/{key}", adaptHandler(s.handlers.Sessions.HandlePutSessionState)).Methods(http.MethodPut)
+	s.router.HandleFunc(APIPathSessions+"/{session_id}/state/{key}", adaptHandler(s.handlers.Sessions.HandleDeleteSessionState)).Methods(http.MethodDelete)
+	s.router.HandleFunc(APIPathSessions+"/{session_id}/llm-traces", adaptHandler(s.handlers.Sessions.HandleListLLMTraces)).Methods(http.MethodGet)
+	s.router.HandleFunc(APIPathSessions+"/{session_id}/llm-traces", adaptHandler(s.handlers.Sessions.HandleCreateLLMTrace)).Methods(http.MethodPost)
+	s.router.HandleFunc(APIPathSessions+"/{session_id}/fork", adaptHandler(s.handlers.Sessions.HandleForkSession)).Methods(http.MethodPost)
+	s.router.HandleFunc(APIPathSessions+"/{session_id}/compact", adaptHandler(s.handlers.Compaction.HandleCompactSession)).Methods(http.MethodPost)
+	s.router.HandleFunc(APIPathSessions+"/{session_id}/compaction", adaptHandler(s.handlers.Compaction.HandleGetSessionCompaction)).Methods(http.MethodGet)
 	s.router.HandleFunc(APIPathSessions+"/{session_id}/shares", adaptHandler(s.handlers.SessionShares.HandleCreateSessionShare)).Methods(http.MethodPost)
 	s.router.HandleFunc(APIPathSessions+"/{session_id}/shares", adaptHandler(s.handlers.SessionShares.HandleListSessionShares)).Methods(http.MethodGet)
 	s.router.HandleFunc(APIPathSessions+"/{session_id}/shares/{token}", adaptHandler(s.handlers.SessionShares.HandleDeleteSessionShare)).Methods(http.MethodDelete)
+	s.router.HandleFunc(APIPathSessions+"/{session_id}/grants", adaptHandler(s.handlers.SessionShares.HandleListSessionGrants)).Methods(http.MethodGet)
+	s.router.HandleFunc(APIPathSessions+"/{session_id}/grants/{kind}/{principal}", adaptHandler(s.handlers.SessionShares.HandlePutSessionGrant)).Methods(http.MethodPut)
+	s.router.HandleFunc(APIPathSessions+"/{session_id}/grants/{kind}/{principal}", adaptHandler(s.handlers.SessionShares.HandleDeleteSessionGrant)).Methods(http.MethodDelete)
 
 	// Tasks
 	s.router.HandleFunc(APIPathTasks+"/{task_id}", adaptHandler(s.handlers.Tasks.HandleGetTask)).Methods(http.MethodGet)
 	s.router.HandleFunc(APIPathTasks, adaptHandler(s.handlers.Tasks.HandleCreateTask)).Methods(http.MethodPost)
 	s.router.HandleFunc(APIPathTasks+"/{task_id}", adaptHandler(s.handlers.Tasks.HandleDeleteTask)).Methods(http.MethodDelete)
+	s.router.HandleFunc(APIPathTasks+"/{task_id}/artifacts/{name}", adaptHandler(s.handlers.Tasks.HandleGetTaskArtifact)).Methods(http.MethodGet)
+	s.router.HandleFunc(APIPathTasks+"/{task_id}/attachments/{message_id}/{index}", adaptHandler(s.handlers.Tasks.HandleGetTaskAttachment)).Methods(http.MethodGet)
 
 	// Tools - using database handlers
 	s.router.HandleFunc(APIPathTools, adaptHandler(s.handlers.Tools.HandleListTools)).Methods(http.MethodGet)
@@ -264,6 +336,8 @@ func (s *HTTPServer) setupRoutes() {
 	s.router.HandleFunc(APIPathToolServers, adaptHandler(s.handlers.ToolServers.HandleListToolServers)).Methods(http.MethodGet)
 	s.router.HandleFunc(APIPathToolServers, adaptHandler(s.handlers.ToolServers.HandleCreateToolServer)).Methods(http.MethodPost)
 	s.router.HandleFunc(APIPathToolServers+"/{namespace}/{name}", adaptHandler(s.handlers.ToolServers.HandleDeleteToolServer)).Methods(http.MethodDelete)
+	s.router.HandleFunc(APIPathToolServers+"/{namespace}/{name}/stats", adaptHandler(s.handlers.ToolServers.HandleGetToolServerStats)).Methods(http.MethodGet)
+	s.router.HandleFunc(APIPathToolServers+"/{namespace}/{name}/stats", adaptHandler(s.handlers.ToolServers.HandleReportToolServerStats)).Methods(http.MethodPost)
 
 	// MCP Apps
 	s.router.HandleFunc(APIPathMCPApps+"/{namespace}/{name}/tools", adaptHandler(s.handlers.MCPApps.HandleListTools)).Methods(http.MethodGet)
@@ -277,8 +351,10 @@ func (s *HTTPServer) setupRoutes() {
 	s.router.HandleFunc(APIPathAgents, adaptHandler(s.handlers.Agents.HandleListAgents)).Methods(http.MethodGet)
 	s.router.HandleFunc(APIPathAgents, adaptHandler(s.handlers.Agents.HandleCreateAgent)).Methods(http.MethodPost)
 	s.router.HandleFunc(APIPathAgents, adaptHandler(s.handlers.Agents.HandleUpdateAgent)).Methods(http.MethodPut)
+	s.router.HandleFunc(APIPathAgents+"/validate", adaptHandler(s.handlers.Agents.HandleValidateAgent)).Methods(http.MethodPost)
 	s.router.HandleFunc(APIPathAgents+"/{namespace}/{name}", adaptHandler(s.handlers.Agents.HandleGetAgent)).Methods(http.MethodGet)
 	s.router.HandleFunc(APIPathAgents+"/{namespace}/{name}", adaptHandler(s.handlers.Agents.HandleDeleteAgent)).Methods(http.MethodDelete)
+	s.router.HandleFunc(APIPathAgents+"/{namespace}/{name}/invoke-async", adaptHandler(s.handlers.Invoke.HandleInvokeAsync)).Methods(http.MethodPost)
 
 	s.router.HandleFunc(APIPathSandboxAgents, adaptHandler(s.handlers.Agents.HandleCreateSandboxAgent)).Methods(http.MethodPost)
 	s.router.HandleFunc(APIPathAgentHarnesses, adaptHandler(s.handlers.Agents.HandleCreateAgentHarness)).Methods(http.MethodPost)
@@ -317,10 +393,34 @@ func (s *HTTPServer) setupRoutes() {
 	s.router.HandleFunc(APIPathPromptTemplates+"/{namespace}/{name}", adaptHandler(s.handlers.PromptTemplates.HandleUpdatePromptTemplate)).Methods(http.MethodPut)
 	s.router.HandleFunc(APIPathPromptTemplates+"/{namespace}/{name}", adaptHandler(s.handlers.PromptTemplates.HandleDeletePromptTemplate)).Methods(http.MethodDelete)
 
+	// Agent templates (ConfigMaps)
+	s.router.HandleFunc(APIPathAgentTemplates, adaptHandler(s.handlers.AgentTemplates.HandleListAgentTemplates)).Methods(http.MethodGet)
+	s.router.HandleFunc(APIPathAgentTemplates+"/{namespace}/{name}", adaptHandler(s.handlers.AgentTemplates.HandleGetAgentTemplate)).Methods(http.MethodGet)
+	s.router.HandleFunc(APIPathAgentTemplates+"/{namespace}/{name}/agents", adaptHandler(s.handlers.AgentTemplates.HandleCreateAgentFromTemplate)).Methods(http.MethodPost)
+
 	// Feedback - using database handlers
 	s.router.HandleFunc(APIPathFeedback, adaptHandler(s.handlers.Feedback.HandleCreateFeedback)).Methods(http.MethodPost)
 	s.router.HandleFunc(APIPathFeedback, adaptHandler(s.handlers.Feedback.HandleListFeedback)).Methods(http.MethodGet)
 
+	// Analytics
+	s.router.HandleFunc(APIPathAnalytics+"/providers", adaptHandler(s.handlers.Analytics.HandleListProviderStats)).Methods(http.MethodGet)
+
+	// Stats
+	s.router.HandleFunc(APIPathStats, adaptHandler(s.handlers.Stats.HandleGetStats)).Methods(http.MethodGet)
+
+	// Debug capture
+	s.router.HandleFunc(APIPathDebug+"/agents/{namespace}/{name}/capture", adaptHandler(s.handlers.Debug.HandleStartAgentCapture)).Methods(http.MethodPost)
+	s.router.HandleFunc(APIPathDebug+"/agents/{namespace}/{name}/capture", adaptHandler(s.handlers.Debug.HandleGetAgentCapture)).Methods(http.MethodGet)
+
+	// Lint
+	s.router.HandleFunc(APIPathLint+"/agent", adaptHandler(s.handlers.Lint.HandleLintAgent)).Methods(http.MethodPost)
+
+	// Apply
+	s.router.HandleFunc(APIPathApply, adaptHandler(s.handlers.Apply.HandleApply)).Methods(http.MethodPost)
+
+	// Retention
+	s.router.HandleFunc(APIPathRetention+"/prune", adaptHandler(s.handlers.Retention.HandlePrune)).Methods(http.MethodPost)
+
 	// LangGraph Checkpoints
 	s.router.HandleFunc(APIPathLangGraph+"/checkpoints", adaptHandler(s.handlers.Checkpoints.HandlePutCheckpoint)).Methods(http.MethodPost)
 	s.router.HandleFunc(APIPathLangGraph+"/checkpoints", adaptHandler(s.handlers.Checkpoints.HandleListCheckpoints)).Methods(http.MethodGet)
@@ -356,9 +456,14 @@ func (s *HTTPServer) setupRoutes() {
 	}
 
 	// Use middleware for common functionality (first registered runs outermost on incoming requests).
+	s.router.Use(limitsMiddleware(s.config.Limits))
+	if s.config.LeaderForwarder != nil {
+		s.router.Use(s.config.LeaderForwarder.Middleware)
+	}
 	s.router.Use(wsAuthQueryMiddleware)
 	s.router.Use(auth.AuthnMiddleware(s.authenticator))
 	s.router.Use(s.shareTokenMiddleware)
+	s.router.Use(s.sessionGrantMiddleware)
 	s.router.Use(contentTypeMiddleware)
 	s.router.Use(loggingMiddleware)
 	s.router.Use(errorHandlerMiddleware)
@@ -374,7 +479,8 @@ func wsAuthQueryMiddleware(next http.Handler) http.Handler {
 		}
 		var token string
 		switch {
-		case strings.HasSuffix(r.URL.Path, "/ssh"):
+		case strings.HasSuffix(r.URL.Path, "/ssh"),
+			strings.HasPrefix(r.URL.Path, APIPathA2A) && strings.HasSuffix(r.URL.Path, a2a.WebSocketPathSuffix):
 			token = r.URL.Query().Get("access_token")
 		}
 		if token != "" {
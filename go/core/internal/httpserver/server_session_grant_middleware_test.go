@@ -0,0 +1,134 @@
+package httpserver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	dbpkg "github.com/kagent-dev/kagent/go/api/database"
+	authimpl "github.com/kagent-dev/kagent/go/core/internal/httpserver/auth"
+	"github.com/kagent-dev/kagent/go/core/pkg/auth"
+)
+
+// stubGrantDB owns session "sess-1" as "owner-id" and shares it through
+// grants; all other methods panic on call.
+type stubGrantDB struct {
+	dbpkg.Client
+	grants []dbpkg.SessionGrant
+}
+
+func (s *stubGrantDB) GetSession(_ context.Context, sessionID, userID string) (*dbpkg.Session, error) {
+	if sessionID == "sess-1" && userID == "owner-id" {
+		return &dbpkg.Session{ID: sessionID, UserID: userID}, nil
+	}
+	return nil, pgx.ErrNoRows
+}
+
+func (s *stubGrantDB) GetSessionGrantForPrincipal(_ context.Context, sessionID, userID string, groups []string) (*dbpkg.SessionGrant, error) {
+	var best *dbpkg.SessionGrant
+	for i, g := range s.grants {
+		matches := g.PrincipalKind == dbpkg.SessionPrincipalUser && g.Principal == userID ||
+			g.PrincipalKind == dbpkg.SessionPrincipalGroup && slices.Contains(groups, g.Principal)
+		if g.SessionID == sessionID && matches && (best == nil || g.Access == dbpkg.SessionAccessInvoke) {
+			best = &s.grants[i]
+		}
+	}
+	if best == nil {
+		return nil, pgx.ErrNoRows
+	}
+	return best, nil
+}
+
+func TestSessionGrantMiddleware(t *testing.T) {
+	db := &stubGrantDB{grants: []dbpkg.SessionGrant{
+		{SessionID: "sess-1", OwnerID: "owner-id", PrincipalKind: dbpkg.SessionPrincipalUser, Principal: "reader", Access: dbpkg.SessionAccessRead},
+		{SessionID: "sess-1", OwnerID: "owner-id", PrincipalKind: dbpkg.SessionPrincipalGroup, Principal: "sre", Access: dbpkg.SessionAccessInvoke},
+	}}
+	srv := &HTTPServer{config: ServerConfig{
+		DbClient:   db,
+		Authorizer: &authimpl.SessionGrantAuthorizer{Next: &authimpl.NoopAuthorizer{}, Store: db},
+	}}
+
+	request := func(method, path, userID string, groups ...string) *http.Request {
+		r := httptest.NewRequest(method, path, nil)
+		r.Header.Set("X-Shared-Session", "sess-1")
+		claims := map[string]any{"groups": groups}
+		ctx := auth.AuthSessionTo(r.Context(), &authimpl.SimpleSession{
+			P: auth.Principal{User: auth.User{ID: userID}, Claims: claims},
+		})
+		return r.WithContext(ctx)
+	}
+
+	tests := []struct {
+		name         string
+		req          *http.Request
+		wantStatus   int
+		wantShareCtx bool
+		wantReadOnly bool
+	}{
+		{
+			name:       "owner passes through without ShareContext",
+			req:        request(http.MethodPost, APIPathA2A+"/default/my-agent", "owner-id"),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:         "read grant is a read-only ShareContext",
+			req:          request(http.MethodPost, APIPathA2A+"/default/my-agent", "reader"),
+			wantStatus:   http.StatusOK,
+			wantShareCtx: true,
+			wantReadOnly: true,
+		},
+		{
+			name:         "group invoke grant is a writable ShareContext",
+			req:          request(http.MethodPost, APIPathA2A+"/default/my-agent", "alice", "dev", "sre"),
+			wantStatus:   http.StatusOK,
+			wantShareCtx: true,
+		},
+		{
+			name:       "read grant cannot write through the session path",
+			req:        request(http.MethodDelete, APIPathSessions+"/sess-1", "reader"),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "no grant returns 404",
+			req:        request(http.MethodPost, APIPathA2A+"/default/my-agent", "stranger", "dev"),
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var capturedCtx context.Context
+			inner := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				capturedCtx = r.Context()
+				w.WriteHeader(http.StatusOK)
+			})
+
+			w := httptest.NewRecorder()
+			srv.sessionGrantMiddleware(inner).ServeHTTP(w, tt.req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if capturedCtx == nil {
+				return
+			}
+			sc, ok := auth.ShareContextFrom(capturedCtx)
+			if ok != tt.wantShareCtx {
+				t.Fatalf("ShareContext present = %v, want %v", ok, tt.wantShareCtx)
+			}
+			if !ok {
+				return
+			}
+			if sc.ReadOnly != tt.wantReadOnly {
+				t.Errorf("ReadOnly = %v, want %v", sc.ReadOnly, tt.wantReadOnly)
+			}
+			if sc.UserID != "owner-id" || sc.SessionID != "sess-1" {
+				t.Errorf("ShareContext = %+v, want owner-id's sess-1", sc)
+			}
+		})
+	}
+}
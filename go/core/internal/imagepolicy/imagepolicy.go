@@ -0,0 +1,329 @@
+// Package imagepolicy enforces the controller's image policy on agent
+// Deployments before they are created: every container image must come from
+// an allowed registry and, when verification keys are configured, carry a
+// cosign signature made with one of them.
+//
+// Signatures are looked up the way cosign stores them, as the
+// sha256-<digest>.sig tag next to the image. Only key-based signatures are
+// supported; keyless (Fulcio certificate) signatures are not verified. A
+// verified image is pinned to the digest whose signature was checked, so the
+// kubelet cannot pull a different image pushed to the same tag.
+//
+// The registry is accessed with the pod's image pull secrets, falling back to
+// the controller's own credentials.
+package imagepolicy
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	"github.com/kagent-dev/kagent/go/core/pkg/translator"
+)
+
+const (
+	// signatureAnnotation holds the base64 signature of a cosign signature
+	// layer, whose content is the signed payload.
+	signatureAnnotation = "dev.cosignproject.cosign/signature"
+	// maxPayloadBytes bounds the signed payload read from a signature layer.
+	maxPayloadBytes = 1 << 20
+	// verifiedTTL is how long a verified digest is trusted before its
+	// signature is checked again, so a removed signature is noticed.
+	verifiedTTL = 10 * time.Minute
+)
+
+// Violation is returned for an image that does not satisfy the policy.
+type Violation struct {
+	Image  string
+	Reason string
+}
+
+func (v *Violation) Error() string {
+	return fmt.Sprintf("image %s rejected by image policy: %s", v.Image, v.Reason)
+}
+
+// Policy checks agent images. It is a translator plugin, so a violation
+// fails the agent's reconciliation before its Deployment is applied.
+type Policy struct {
+	kube              client.Reader
+	allowedRegistries []string
+	keys              []crypto.PublicKey
+	now               func() time.Time
+
+	mu sync.Mutex
+	// verified holds when each digest reference last passed signature
+	// verification.
+	verified map[string]time.Time
+}
+
+var _ translator.TranslatorPlugin = (*Policy)(nil)
+
+// New returns a Policy allowing images from allowedRegistries, which are
+// registry hosts or repository prefixes such as "ghcr.io/kagent-dev" (empty
+// allows any registry), and requiring a cosign signature by one of the
+// public keys in publicKeysPEM (empty skips signature verification). kube
+// reads the image pull secrets of the agents' pods.
+func New(kube client.Reader, allowedRegistries []string, publicKeysPEM string) (*Policy, error) {
+	p := &Policy{
+		kube:     kube,
+		now:      time.Now,
+		verified: map[string]time.Time{},
+	}
+	for _, allowed := range allowedRegistries {
+		allowed = strings.TrimSuffix(strings.TrimSpace(allowed), "/")
+		if allowed == "" {
+			continue
+		}
+		// Normalize the host, so "docker.io" matches "index.docker.io".
+		host, path, _ := strings.Cut(allowed, "/")
+		registry, err := name.NewRegistry(host)
+		if err != nil {
+			return nil, fmt.Errorf("invalid allowed registry %q: %w", allowed, err)
+		}
+		allowed = registry.Name()
+		if path != "" {
+			allowed += "/" + path
+		}
+		p.allowedRegistries = append(p.allowedRegistries, allowed)
+	}
+
+	rest := []byte(publicKeysPEM)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid image verification public key: %w", err)
+		}
+		p.keys = append(p.keys, key)
+	}
+	if len(p.keys) == 0 && strings.TrimSpace(publicKeysPEM) != "" {
+		return nil, errors.New("invalid image verification public key: no PEM block found")
+	}
+	return p, nil
+}
+
+// Enabled reports whether the policy restricts any image.
+func (p *Policy) Enabled() bool {
+	return len(p.allowedRegistries) > 0 || len(p.keys) > 0
+}
+
+// ProcessAgent checks the images of the agent's Deployments and pins the
+// verified ones to their digest.
+func (p *Policy) ProcessAgent(ctx context.Context, _ v1alpha2.AgentObject, outputs *translator.AgentOutputs) error {
+	var errs error
+	for _, obj := range outputs.Manifest {
+		deployment, ok := obj.(*appsv1.Deployment)
+		if !ok {
+			continue
+		}
+		podSpec := &deployment.Spec.Template.Spec
+		keychain, err := p.pullSecretKeychain(ctx, deployment.Namespace, podSpec.ImagePullSecrets)
+		if err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+		checked := map[string]string{}
+		for _, containers := range [][]corev1.Container{podSpec.InitContainers, podSpec.Containers} {
+			for i := range containers {
+				image := containers[i].Image
+				pinned, ok := checked[image]
+				if !ok {
+					pinned, err = p.Check(ctx, image, keychain)
+					errs = errors.Join(errs, err)
+					checked[image] = pinned
+				}
+				containers[i].Image = pinned
+			}
+		}
+	}
+	return errs
+}
+
+func (p *Policy) GetOwnedResourceTypes() []client.Object {
+	return nil
+}
+
+// Check returns the image to run in place of image: image itself when
+// signatures are not verified, otherwise the digest reference whose
+// signature was verified. It returns a *Violation when image is not allowed
+// by the policy, or another error when its signature could not be looked up.
+// keychain authenticates to the registry; nil uses the controller's own
+// credentials.
+func (p *Policy) Check(ctx context.Context, image string, keychain authn.Keychain) (string, error) {
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return image, &Violation{Image: image, Reason: fmt.Sprintf("invalid image reference: %v", err)}
+	}
+	if !p.registryAllowed(ref) {
+		return image, &Violation{Image: image, Reason: fmt.Sprintf("registry is not one of the allowed registries %s", strings.Join(p.allowedRegistries, ", "))}
+	}
+	if len(p.keys) == 0 {
+		return image, nil
+	}
+	if keychain == nil {
+		keychain = authn.DefaultKeychain
+	}
+	opts := []remote.Option{remote.WithContext(ctx), remote.WithAuthFromKeychain(keychain)}
+
+	// A tag is resolved on every check: only its digest can be trusted.
+	pinned, ok := ref.(name.Digest)
+	if !ok {
+		desc, err := remote.Head(ref, opts...)
+		if err != nil {
+			return image, fmt.Errorf("failed to resolve image %s: %w", ref, err)
+		}
+		pinned = ref.Context().Digest(desc.Digest.String())
+	}
+
+	p.mu.Lock()
+	verifiedAt, ok := p.verified[pinned.String()]
+	p.mu.Unlock()
+	if ok && p.now().Sub(verifiedAt) < verifiedTTL {
+		return pinned.String(), nil
+	}
+	if err := p.verifySignature(image, pinned, opts); err != nil {
+		return image, err
+	}
+	p.mu.Lock()
+	p.verified[pinned.String()] = p.now()
+	p.mu.Unlock()
+	return pinned.String(), nil
+}
+
+func (p *Policy) registryAllowed(ref name.Reference) bool {
+	if len(p.allowedRegistries) == 0 {
+		return true
+	}
+	repository := ref.Context().Name()
+	for _, allowed := range p.allowedRegistries {
+		if repository == allowed || strings.HasPrefix(repository, allowed+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// verifySignature checks that image, resolved to ref, has a cosign
+// signature by one of the policy's keys.
+func (p *Policy) verifySignature(image string, ref name.Digest, opts []remote.Option) error {
+	digest, err := v1.NewHash(ref.DigestStr())
+	if err != nil {
+		return &Violation{Image: image, Reason: fmt.Sprintf("invalid digest: %v", err)}
+	}
+
+	sigRef := ref.Context().Tag(fmt.Sprintf("%s-%s.sig", digest.Algorithm, digest.Hex))
+	sigImage, err := remote.Image(sigRef, opts...)
+	if err != nil {
+		var terr *transport.Error
+		if errors.As(err, &terr) && terr.StatusCode == http.StatusNotFound {
+			return &Violation{Image: image, Reason: fmt.Sprintf("no cosign signature found for %s", digest)}
+		}
+		return fmt.Errorf("failed to fetch signatures of image %s: %w", ref, err)
+	}
+	manifest, err := sigImage.Manifest()
+	if err != nil {
+		return fmt.Errorf("failed to read signatures of image %s: %w", ref, err)
+	}
+	for _, layer := range manifest.Layers {
+		signature, ok := layer.Annotations[signatureAnnotation]
+		if !ok {
+			continue
+		}
+		payload, err := readLayer(sigImage, layer.Digest)
+		if err != nil {
+			return fmt.Errorf("failed to read signature of image %s: %w", ref, err)
+		}
+		if p.verifyPayload(payload, signature, digest) {
+			return nil
+		}
+	}
+	return &Violation{Image: image, Reason: fmt.Sprintf("no cosign signature of %s by a trusted key", digest)}
+}
+
+// verifyPayload reports whether signature is a valid signature of payload
+// by one of the policy's keys, and payload is a cosign signature payload for
+// digest.
+func (p *Policy) verifyPayload(payload []byte, signature string, digest v1.Hash) bool {
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	signed := false
+	for _, key := range p.keys {
+		if verify(key, payload, sig) {
+			signed = true
+			break
+		}
+	}
+	if !signed {
+		return false
+	}
+	var simpleSigning struct {
+		Critical struct {
+			Image struct {
+				DockerManifestDigest string `json:"docker-manifest-digest"`
+			} `json:"image"`
+		} `json:"critical"`
+	}
+	if err := json.Unmarshal(payload, &simpleSigning); err != nil {
+		return false
+	}
+	return simpleSigning.Critical.Image.DockerManifestDigest == digest.String()
+}
+
+// verify checks sig the way cosign signs with each key type: ECDSA and RSA
+// PKCS #1 v1.5 over the SHA-256 of the payload, Ed25519 over the payload.
+func verify(key crypto.PublicKey, payload, sig []byte) bool {
+	hash := sha256.Sum256(payload)
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		return ecdsa.VerifyASN1(k, hash[:], sig)
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(k, crypto.SHA256, hash[:], sig) == nil
+	case ed25519.PublicKey:
+		return ed25519.Verify(k, payload, sig)
+	default:
+		return false
+	}
+}
+
+func readLayer(image v1.Image, digest v1.Hash) ([]byte, error) {
+	layer, err := image.LayerByDigest(digest)
+	if err != nil {
+		return nil, err
+	}
+	rc, err := layer.Compressed()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(io.LimitReader(rc, maxPayloadBytes))
+}
@@ -0,0 +1,227 @@
+package imagepolicy
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kagent-dev/kagent/go/core/pkg/translator"
+)
+
+func TestRegistryAllowed(t *testing.T) {
+	p, err := New(nil, []string{"ghcr.io/kagent-dev/", "docker.io", "registry.example.com:5000"}, "")
+	require.NoError(t, err)
+
+	tests := []struct {
+		image string
+		want  bool
+	}{
+		{"ghcr.io/kagent-dev/kagent/app:0.7.0", true},
+		{"ghcr.io/kagent-dev-fork/app:0.7.0", false},
+		{"ghcr.io/other/app:latest", false},
+		{"nginx:1.27", true},
+		{"docker.io/library/nginx:1.27", true},
+		{"registry.example.com:5000/team/agent@sha256:" + strings.Repeat("a", 64), true},
+		{"registry.example.com/team/agent:v1", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			_, err := p.Check(context.Background(), tt.image, nil)
+			if tt.want {
+				assert.NoError(t, err)
+			} else {
+				var violation *Violation
+				assert.ErrorAs(t, err, &violation)
+			}
+		})
+	}
+}
+
+func TestNewRejectsInvalidKey(t *testing.T) {
+	_, err := New(nil, nil, "not a key")
+	assert.ErrorContains(t, err, "no PEM block found")
+}
+
+// testRegistry is an in-memory registry with images signed the way cosign
+// signs them.
+type testRegistry struct {
+	t    *testing.T
+	host string
+}
+
+func newTestRegistry(t *testing.T) *testRegistry {
+	srv := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	t.Cleanup(srv.Close)
+	return &testRegistry{t: t, host: strings.TrimPrefix(srv.URL, "http://")}
+}
+
+func (r *testRegistry) ref(image string) name.Reference {
+	ref, err := name.ParseReference(image)
+	require.NoError(r.t, err)
+	return ref
+}
+
+// push pushes a random image to repo:tag and returns its reference and digest.
+func (r *testRegistry) push(repoTag string) (string, v1.Hash) {
+	img, err := random.Image(256, 1)
+	require.NoError(r.t, err)
+	ref := r.host + "/" + repoTag
+	require.NoError(r.t, remote.Write(r.ref(ref), img))
+	digest, err := img.Digest()
+	require.NoError(r.t, err)
+	return ref, digest
+}
+
+// sign pushes a cosign signature of digest, made with key, for the image ref.
+func (r *testRegistry) sign(ref string, digest v1.Hash, signedDigest v1.Hash, key *ecdsa.PrivateKey) {
+	payload := fmt.Appendf(nil, `{"critical":{"identity":{"docker-reference":%q},"image":{"docker-manifest-digest":%q},"type":"cosign container image signature"},"optional":null}`,
+		r.ref(ref).Context().Name(), signedDigest.String())
+	hash := sha256.Sum256(payload)
+	sig, err := ecdsa.SignASN1(rand.Reader, key, hash[:])
+	require.NoError(r.t, err)
+
+	sigImage, err := mutate.Append(empty.Image, mutate.Addendum{
+		Layer:       static.NewLayer(payload, "application/vnd.dev.cosign.simplesigning.v1+json"),
+		Annotations: map[string]string{signatureAnnotation: base64.StdEncoding.EncodeToString(sig)},
+	})
+	require.NoError(r.t, err)
+	sigTag := r.ref(ref).Context().Tag(fmt.Sprintf("%s-%s.sig", digest.Algorithm, digest.Hex))
+	require.NoError(r.t, remote.Write(sigTag, sigImage))
+}
+
+func newKey(t *testing.T) (*ecdsa.PrivateKey, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	return key, string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func TestCheckSignature(t *testing.T) {
+	reg := newTestRegistry(t)
+	trusted, trustedPEM := newKey(t)
+	untrusted, _ := newKey(t)
+
+	signed, signedDigest := reg.push("agents/signed:v1")
+	reg.sign(signed, signedDigest, signedDigest, trusted)
+	unsigned, _ := reg.push("agents/unsigned:v1")
+	wrongKey, wrongKeyDigest := reg.push("agents/wrong-key:v1")
+	reg.sign(wrongKey, wrongKeyDigest, wrongKeyDigest, untrusted)
+	otherDigest, otherDigestDigest := reg.push("agents/other-digest:v1")
+	reg.sign(otherDigest, otherDigestDigest, signedDigest, trusted)
+
+	p, err := New(nil, nil, trustedPEM)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		image      string
+		wantReason string
+	}{
+		{name: "signed by the trusted key", image: signed},
+		{name: "signed image by digest", image: strings.TrimSuffix(signed, ":v1") + "@" + signedDigest.String()},
+		{name: "unsigned", image: unsigned, wantReason: "no cosign signature found"},
+		{name: "signed by another key", image: wrongKey, wantReason: "no cosign signature of"},
+		{name: "signature of another image", image: otherDigest, wantReason: "no cosign signature of"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pinned, err := p.Check(context.Background(), tt.image, nil)
+			if tt.wantReason == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, strings.TrimSuffix(signed, ":v1")+"@"+signedDigest.String(), pinned)
+				return
+			}
+			var violation *Violation
+			require.ErrorAs(t, err, &violation)
+			assert.Contains(t, violation.Reason, tt.wantReason)
+		})
+	}
+}
+
+func TestProcessAgent(t *testing.T) {
+	reg := newTestRegistry(t)
+	key, keyPEM := newKey(t)
+	signed, digest := reg.push("agents/signed:v1")
+	reg.sign(signed, digest, digest, key)
+	unsigned, _ := reg.push("agents/unsigned:v1")
+
+	p, err := New(nil, []string{reg.host}, keyPEM)
+	require.NoError(t, err)
+
+	deployment := func(images ...string) *translator.AgentOutputs {
+		d := &appsv1.Deployment{}
+		for _, image := range images {
+			d.Spec.Template.Spec.Containers = append(d.Spec.Template.Spec.Containers, corev1.Container{Image: image})
+		}
+		return &translator.AgentOutputs{Manifest: []client.Object{&corev1.Secret{}, d}}
+	}
+
+	outputs := deployment(signed, signed)
+	require.NoError(t, p.ProcessAgent(context.Background(), nil, outputs))
+	pinned := strings.TrimSuffix(signed, ":v1") + "@" + digest.String()
+	for _, container := range outputs.Manifest[1].(*appsv1.Deployment).Spec.Template.Spec.Containers {
+		assert.Equal(t, pinned, container.Image, "verified images should be pinned to their digest")
+	}
+
+	err = p.ProcessAgent(context.Background(), nil, deployment(signed, unsigned, "ghcr.io/other/sidecar:v1"))
+	var violation *Violation
+	require.ErrorAs(t, err, &violation)
+	assert.Contains(t, err.Error(), unsigned)
+	assert.Contains(t, err.Error(), "ghcr.io/other/sidecar:v1")
+	assert.NotContains(t, err.Error(), signed+" ")
+}
+
+func TestCheckCachesVerifiedImages(t *testing.T) {
+	srv := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	reg := &testRegistry{t: t, host: strings.TrimPrefix(srv.URL, "http://")}
+	key, keyPEM := newKey(t)
+	signed, digest := reg.push("agents/signed:v1")
+	reg.sign(signed, digest, digest, key)
+
+	p, err := New(nil, nil, keyPEM)
+	require.NoError(t, err)
+	pinned, err := p.Check(context.Background(), signed, nil)
+	require.NoError(t, err)
+
+	// A re-pushed tag is resolved again and its new digest verified.
+	reg.push("agents/signed:v1")
+	_, err = p.Check(context.Background(), signed, nil)
+	var violation *Violation
+	require.ErrorAs(t, err, &violation)
+
+	srv.Close()
+	_, err = p.Check(context.Background(), pinned, nil)
+	assert.NoError(t, err, "verified digest should not be looked up again")
+
+	p.verified[pinned] = p.now().Add(-verifiedTTL)
+	_, err = p.Check(context.Background(), pinned, nil)
+	assert.Error(t, err)
+	assert.False(t, errors.As(err, &violation), "registry errors are not policy violations")
+}
@@ -0,0 +1,144 @@
+package imagepolicy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// dockerConfigEntry is the credential of one registry in a docker config.
+type dockerConfigEntry struct {
+	Auth          string `json:"auth,omitempty"`
+	Username      string `json:"username,omitempty"`
+	Password      string `json:"password,omitempty"`
+	IdentityToken string `json:"identitytoken,omitempty"`
+	RegistryToken string `json:"registrytoken,omitempty"`
+}
+
+// pullSecretCredential is a credential from a pull secret and the registry
+// host, optionally followed by a repository path, it applies to.
+type pullSecretCredential struct {
+	host  string
+	path  string
+	entry dockerConfigEntry
+}
+
+// pullSecretKeychain resolves registries to the credentials of a pod's image
+// pull secrets, the way the kubelet does, before the controller's own.
+type pullSecretKeychain []pullSecretCredential
+
+// pullSecretKeychain returns the keychain to access the images of a pod in
+// namespace with the image pull secrets refs.
+func (p *Policy) pullSecretKeychain(ctx context.Context, namespace string, refs []corev1.LocalObjectReference) (authn.Keychain, error) {
+	return PullSecretKeychain(ctx, p.kube, namespace, refs)
+}
+
+// PullSecretKeychain returns the keychain to access the images of a pod in
+// namespace with the image pull secrets refs, read with kube, falling back to
+// the controller's own credentials. Secrets that don't exist are skipped,
+// like the kubelet does.
+func PullSecretKeychain(ctx context.Context, kube client.Reader, namespace string, refs []corev1.LocalObjectReference) (authn.Keychain, error) {
+	if len(refs) == 0 || kube == nil {
+		return authn.DefaultKeychain, nil
+	}
+	var keychain pullSecretKeychain
+	for _, ref := range refs {
+		secret := &corev1.Secret{}
+		if err := kube.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ref.Name}, secret); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get image pull secret %s/%s: %w", namespace, ref.Name, err)
+		}
+		creds, err := parsePullSecret(secret)
+		if err != nil {
+			return nil, fmt.Errorf("invalid image pull secret %s/%s: %w", namespace, ref.Name, err)
+		}
+		keychain = append(keychain, creds...)
+	}
+	return authn.NewMultiKeychain(keychain, authn.DefaultKeychain), nil
+}
+
+// parsePullSecret returns the credentials of a kubernetes.io/dockerconfigjson
+// or kubernetes.io/dockercfg secret.
+func parsePullSecret(secret *corev1.Secret) ([]pullSecretCredential, error) {
+	auths := map[string]dockerConfigEntry{}
+	switch {
+	case len(secret.Data[corev1.DockerConfigJsonKey]) > 0:
+		var config struct {
+			Auths map[string]dockerConfigEntry `json:"auths"`
+		}
+		if err := json.Unmarshal(secret.Data[corev1.DockerConfigJsonKey], &config); err != nil {
+			return nil, err
+		}
+		auths = config.Auths
+	case len(secret.Data[corev1.DockerConfigKey]) > 0:
+		if err := json.Unmarshal(secret.Data[corev1.DockerConfigKey], &auths); err != nil {
+			return nil, err
+		}
+	}
+	creds := make([]pullSecretCredential, 0, len(auths))
+	for key, entry := range auths {
+		host, repoPath := parseRegistryKey(key)
+		creds = append(creds, pullSecretCredential{host: host, path: repoPath, entry: entry})
+	}
+	return creds, nil
+}
+
+// parseRegistryKey splits a docker config key such as
+// "https://index.docker.io/v1/" or "registry.example.com/team" into its host
+// and repository path.
+func parseRegistryKey(key string) (string, string) {
+	key = strings.TrimPrefix(strings.TrimPrefix(key, "https://"), "http://")
+	host, repoPath, _ := strings.Cut(strings.TrimSuffix(key, "/"), "/")
+	if !strings.Contains(host, "*") {
+		if reg, err := name.NewRegistry(host); err == nil {
+			host = reg.Name()
+		}
+	}
+	// The docker hub key carries the path of its legacy API.
+	if repoPath == "v1" || repoPath == "v2" {
+		repoPath = ""
+	}
+	return host, repoPath
+}
+
+// Resolve implements authn.Keychain. The credential with the longest
+// matching repository path wins.
+func (k pullSecretKeychain) Resolve(target authn.Resource) (authn.Authenticator, error) {
+	repoPath := ""
+	if repo, ok := target.(name.Repository); ok {
+		repoPath = repo.RepositoryStr()
+	}
+	var match *pullSecretCredential
+	for i := range k {
+		c := &k[i]
+		if hostMatch, _ := path.Match(c.host, target.RegistryStr()); !hostMatch {
+			continue
+		}
+		if c.path != "" && repoPath != c.path && !strings.HasPrefix(repoPath, c.path+"/") {
+			continue
+		}
+		if match == nil || len(c.path) > len(match.path) {
+			match = c
+		}
+	}
+	if match == nil {
+		return authn.Anonymous, nil
+	}
+	return authn.FromConfig(authn.AuthConfig{
+		Auth:          match.entry.Auth,
+		Username:      match.entry.Username,
+		Password:      match.entry.Password,
+		IdentityToken: match.entry.IdentityToken,
+		RegistryToken: match.entry.RegistryToken,
+	}), nil
+}
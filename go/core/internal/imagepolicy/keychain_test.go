@@ -0,0 +1,82 @@
+package imagepolicy
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestPullSecretKeychain(t *testing.T) {
+	basic := func(user string) string {
+		return base64.StdEncoding.EncodeToString([]byte(user + ":secret"))
+	}
+	kube := fake.NewClientBuilder().WithObjects(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "registry", Namespace: "agents"},
+			Type:       corev1.SecretTypeDockerConfigJson,
+			Data: map[string][]byte{corev1.DockerConfigJsonKey: []byte(`{"auths":{
+				"https://index.docker.io/v1/": {"auth": "` + basic("hub") + `"},
+				"registry.example.com": {"auth": "` + basic("team") + `"},
+				"registry.example.com/platform": {"username": "platform", "password": "secret"},
+				"*.registry.example.org": {"auth": "` + basic("wildcard") + `"}
+			}}`)},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "legacy", Namespace: "agents"},
+			Type:       corev1.SecretTypeDockercfg,
+			Data:       map[string][]byte{corev1.DockerConfigKey: []byte(`{"quay.io": {"auth": "` + basic("quay") + `"}}`)},
+		},
+	).Build()
+	p, err := New(kube, nil, "")
+	require.NoError(t, err)
+
+	keychain, err := p.pullSecretKeychain(context.Background(), "agents", []corev1.LocalObjectReference{
+		{Name: "registry"}, {Name: "legacy"}, {Name: "missing"},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		repo     string
+		wantUser string
+	}{
+		{repo: "nginx", wantUser: "hub"},
+		{repo: "registry.example.com/team/agent", wantUser: "team"},
+		{repo: "registry.example.com/platform/agent", wantUser: "platform"},
+		{repo: "eu.registry.example.org/agent", wantUser: "wildcard"},
+		{repo: "quay.io/team/agent", wantUser: "quay"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.repo, func(t *testing.T) {
+			repo, err := name.NewRepository(tt.repo)
+			require.NoError(t, err)
+			auth, err := keychain.Resolve(repo)
+			require.NoError(t, err)
+			cfg, err := auth.Authorization()
+			require.NoError(t, err)
+			if cfg.Username == "" {
+				// Basic auth in the auth field is split by the transport.
+				decoded, err := base64.StdEncoding.DecodeString(cfg.Auth)
+				require.NoError(t, err)
+				cfg.Username, _, _ = strings.Cut(string(decoded), ":")
+			}
+			assert.Equal(t, tt.wantUser, cfg.Username)
+		})
+	}
+}
+
+func TestPullSecretKeychainWithoutSecrets(t *testing.T) {
+	p, err := New(nil, nil, "")
+	require.NoError(t, err)
+	keychain, err := p.pullSecretKeychain(context.Background(), "agents", nil)
+	require.NoError(t, err)
+	assert.Equal(t, authn.DefaultKeychain, keychain)
+}
@@ -0,0 +1,183 @@
+// Package leader lets every controller replica serve the HTTP API while
+// writes are applied by the elected leader. Replicas serve reads themselves
+// and forward writes to the leader, whose address is part of the identity
+// it holds the leader election lease with.
+package leader
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// ForwardedHeader marks a request forwarded by another replica, with the
+	// address of that replica. Forwarded requests are never forwarded again.
+	ForwardedHeader = "X-Kagent-Forwarded-By"
+
+	// addressSeparator separates the address of a replica from the rest of
+	// its leader election identity.
+	addressSeparator = "@"
+
+	// leaseCacheTTL bounds how long the leader's address is reused before
+	// the lease is read again.
+	leaseCacheTTL = 2 * time.Second
+)
+
+// Identity returns the leader election identity of a replica whose HTTP API
+// listens on address: the host name and a unique suffix, as controller-runtime
+// uses, followed by the address.
+func Identity(address string) (string, error) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return "", fmt.Errorf("failed to get hostname: %w", err)
+	}
+	return hostname + "_" + uuid.NewString() + addressSeparator + address, nil
+}
+
+// Address returns the HTTP API address in a leader election identity, or ""
+// when the identity has none, as for leaders that predate forwarding.
+func Address(identity string) string {
+	i := strings.LastIndex(identity, addressSeparator)
+	if i < 0 {
+		return ""
+	}
+	return identity[i+len(addressSeparator):]
+}
+
+// Forwarder forwards the API writes a replica receives to the leader while
+// the replica isn't the leader.
+type Forwarder struct {
+	// Elected is closed once the replica is the leader.
+	Elected <-chan struct{}
+	// Leases reads the leader election lease.
+	Leases client.Reader
+	// Lease is the leader election lease.
+	Lease types.NamespacedName
+	// Address is the HTTP API address of the replica.
+	Address string
+	// Exempt lists path prefixes whose requests are always served by the
+	// replica that receives them, such as agent invocations.
+	Exempt []string
+
+	mu         sync.Mutex
+	leader     string
+	leaderRead time.Time
+	proxies    map[string]*httputil.ReverseProxy
+}
+
+// IsWrite reports whether r may change state.
+func IsWrite(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	}
+	return true
+}
+
+// Middleware forwards writes to the leader. When the leader is unknown or
+// doesn't advertise an address, such as during a handover or an upgrade,
+// the write is served by the replica that received it: the Kubernetes API
+// and the database still order concurrent writes.
+func (f *Forwarder) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !IsWrite(r) || f.isLeader() || r.Header.Get(ForwardedHeader) != "" || f.exempt(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		leader, err := f.leaderAddress(r.Context())
+		if err != nil {
+			ctrllog.FromContext(r.Context()).Error(err, "Failed to find the leader, serving the write locally")
+		}
+		if leader == "" || leader == f.Address {
+			next.ServeHTTP(w, r)
+			return
+		}
+		r.Header.Set(ForwardedHeader, f.Address)
+		f.proxy(leader).ServeHTTP(w, r)
+	})
+}
+
+func (f *Forwarder) isLeader() bool {
+	select {
+	case <-f.Elected:
+		return true
+	default:
+		return false
+	}
+}
+
+func (f *Forwarder) exempt(path string) bool {
+	for _, prefix := range f.Exempt {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// leaderAddress returns the address of the leader from the lease, reading
+// the lease at most every leaseCacheTTL.
+func (f *Forwarder) leaderAddress(ctx context.Context) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if time.Since(f.leaderRead) < leaseCacheTTL {
+		return f.leader, nil
+	}
+	lease := &coordinationv1.Lease{}
+	if err := f.Leases.Get(ctx, f.Lease, lease); err != nil {
+		return "", fmt.Errorf("failed to get lease %s: %w", f.Lease, err)
+	}
+	f.leader = ""
+	if lease.Spec.HolderIdentity != nil {
+		f.leader = Address(*lease.Spec.HolderIdentity)
+	}
+	f.leaderRead = time.Now()
+	return f.leader, nil
+}
+
+func (f *Forwarder) proxy(address string) *httputil.ReverseProxy {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if p, ok := f.proxies[address]; ok {
+		return p
+	}
+	target := &url.URL{Scheme: "http", Host: address}
+	p := &httputil.ReverseProxy{
+		Rewrite: func(pr *httputil.ProxyRequest) {
+			pr.SetURL(target)
+			pr.Out.Host = pr.In.Host
+		},
+		// Stream responses as the leader writes them.
+		FlushInterval: -1,
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			ctrllog.FromContext(r.Context()).Error(err, "Failed to forward write to the leader", "leader", address)
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusBadGateway)
+			json.NewEncoder(w).Encode(api.APIError{ //nolint:errcheck
+				Error: "Failed to forward the request to the leader",
+				Code:  api.ErrorCodeUnavailable,
+			})
+		},
+	}
+	if f.proxies == nil {
+		f.proxies = map[string]*httputil.ReverseProxy{}
+	}
+	f.proxies[address] = p
+	return p
+}
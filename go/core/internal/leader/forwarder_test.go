@@ -0,0 +1,90 @@
+package leader
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestAddress(t *testing.T) {
+	identity, err := Identity("10.0.0.5:8083")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := Address(identity); got != "10.0.0.5:8083" {
+		t.Errorf("Address(%q) = %q, want 10.0.0.5:8083", identity, got)
+	}
+	if got := Address("kagent-controller-abc_6f1c"); got != "" {
+		t.Errorf("Address of an identity without address = %q, want empty", got)
+	}
+}
+
+func TestForwarderMiddleware(t *testing.T) {
+	leaderServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Write([]byte("leader:" + r.Header.Get(ForwardedHeader) + ":" + string(body))) //nolint:errcheck
+	}))
+	defer leaderServer.Close()
+	leaderURL, _ := url.Parse(leaderServer.URL)
+
+	lease := types.NamespacedName{Namespace: "kagent", Name: "lease"}
+	newForwarder := func(holder string, elected bool) *Forwarder {
+		scheme := runtime.NewScheme()
+		if err := coordinationv1.AddToScheme(scheme); err != nil {
+			t.Fatal(err)
+		}
+		leases := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{Namespace: lease.Namespace, Name: lease.Name},
+			Spec:       coordinationv1.LeaseSpec{HolderIdentity: ptr.To(holder)},
+		}).Build()
+		electedCh := make(chan struct{})
+		if elected {
+			close(electedCh)
+		}
+		return &Forwarder{Elected: electedCh, Leases: leases, Lease: lease, Address: "10.0.0.9:8083", Exempt: []string{"/api/a2a"}}
+	}
+	local := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("local")) //nolint:errcheck
+	})
+
+	tests := []struct {
+		name    string
+		holder  string
+		elected bool
+		method  string
+		path    string
+		header  string
+		want    string
+	}{
+		{name: "read on follower", holder: "pod-a_1@" + leaderURL.Host, method: http.MethodGet, path: "/api/agents", want: "local"},
+		{name: "write on follower", holder: "pod-a_1@" + leaderURL.Host, method: http.MethodPost, path: "/api/agents", want: "leader:10.0.0.9:8083:{}"},
+		{name: "write on leader", holder: "pod-b_2@10.0.0.9:8083", elected: true, method: http.MethodPost, path: "/api/agents", want: "local"},
+		{name: "exempt write", holder: "pod-a_1@" + leaderURL.Host, method: http.MethodPost, path: "/api/a2a/kagent/k8s-agent", want: "local"},
+		{name: "forwarded write", holder: "pod-a_1@" + leaderURL.Host, method: http.MethodPost, path: "/api/agents", header: "10.0.0.7:8083", want: "local"},
+		{name: "leader without address", holder: "pod-a_1", method: http.MethodDelete, path: "/api/agents/kagent/k8s-agent", want: "local"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newForwarder(tt.holder, tt.elected)
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader("{}"))
+			if tt.header != "" {
+				req.Header.Set(ForwardedHeader, tt.header)
+			}
+			w := httptest.NewRecorder()
+			f.Middleware(local).ServeHTTP(w, req)
+			if got := w.Body.String(); got != tt.want {
+				t.Errorf("response = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
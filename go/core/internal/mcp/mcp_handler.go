@@ -252,7 +252,15 @@ func (h *MCPHandler) handleInvokeAgent(ctx context.Context, req *mcpsdk.CallTool
 		log.V(1).Info("Using context_id from client request", "context_id", input.ContextID)
 	}
 
-	result, err := h.agentClients.SendMessage(ctx, agentNS, agentName, &a2atype.SendMessageRequest{Message: message})
+	// Clients that ask for progress get the agent's steps while it works,
+	// which for long-running tools can take minutes.
+	var result a2atype.SendMessageResult
+	var err error
+	if token := req.Params.GetProgressToken(); token != nil {
+		result, err = h.streamAgent(ctx, req, token, agentNS, agentName, message)
+	} else {
+		result, err = h.agentClients.SendMessage(ctx, agentNS, agentName, &a2atype.SendMessageRequest{Message: message})
+	}
 	if err != nil {
 		log.Error(err, "Failed to send A2A message", "agent", agentRef)
 		return &mcpsdk.CallToolResult{
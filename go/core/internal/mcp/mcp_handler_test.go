@@ -3,6 +3,7 @@ package mcp
 import (
 	"context"
 	"encoding/json"
+	"iter"
 	"net/http"
 	"net/http/httptest"
 	"sync"
@@ -11,6 +12,7 @@ import (
 
 	a2atype "github.com/a2aproject/a2a-go/v2/a2a"
 	a2aclient "github.com/a2aproject/a2a-go/v2/a2aclient"
+	"github.com/a2aproject/a2a-go/v2/a2asrv"
 	"github.com/kagent-dev/kagent/go/api/v1alpha2"
 	"github.com/kagent-dev/kagent/go/core/internal/a2a"
 	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
@@ -234,3 +236,91 @@ func TestInvokeAgent_RoutesViaRegistry(t *testing.T) {
 	assert.False(t, result.IsError, "unexpected tool error: %v", result.Content)
 	assert.True(t, backend.wasCalled(), "A2A backend should have received the forwarded request")
 }
+
+// streamingExecutor is an A2A agent that reports a turn and a tool call
+// while it works, then answers with an artifact.
+type streamingExecutor struct{}
+
+func (streamingExecutor) Execute(_ context.Context, execCtx *a2asrv.ExecutorContext) iter.Seq2[a2atype.Event, error] {
+	return func(yield func(a2atype.Event, error) bool) {
+		call := a2atype.NewDataPart(map[string]any{"name": "k8s_get_resources", "id": "call-1"})
+		call.Metadata = map[string]any{"kagent_type": "function_call"}
+		progress := a2atype.NewDataPart(map[string]any{"name": "k8s_get_resources", "id": "call-1", "message": "Listing pods"})
+		progress.Metadata = map[string]any{"kagent_type": "tool_progress"}
+		events := []a2atype.Event{
+			a2atype.NewSubmittedTask(execCtx, execCtx.Message),
+			a2atype.NewStatusUpdateEvent(execCtx, a2atype.TaskStateWorking, a2atype.NewMessage(a2atype.MessageRoleAgent, a2atype.NewTextPart("Let me look."), call)),
+			a2atype.NewStatusUpdateEvent(execCtx, a2atype.TaskStateWorking, a2atype.NewMessage(a2atype.MessageRoleAgent, progress)),
+			a2atype.NewArtifactEvent(execCtx, a2atype.NewTextPart("3 pods are running")),
+			a2atype.NewStatusUpdateEvent(execCtx, a2atype.TaskStateCompleted, nil),
+		}
+		for _, event := range events {
+			if !yield(event, nil) {
+				return
+			}
+		}
+	}
+}
+
+func (streamingExecutor) Cancel(_ context.Context, execCtx *a2asrv.ExecutorContext) iter.Seq2[a2atype.Event, error] {
+	return func(yield func(a2atype.Event, error) bool) {
+		yield(a2atype.NewStatusUpdateEvent(execCtx, a2atype.TaskStateCanceled, nil), nil)
+	}
+}
+
+// TestInvokeAgent_StreamsProgress verifies that invoke_agent streams the
+// agent's work as progress notifications when the client sends a progress
+// token, and still returns the agent's answer.
+func TestInvokeAgent_StreamsProgress(t *testing.T) {
+	backend := httptest.NewServer(a2asrv.NewJSONRPCHandler(a2asrv.NewHandler(streamingExecutor{})))
+	t.Cleanup(backend.Close)
+
+	registry := newTestRegistry(t, "default", "test-agent", backend.URL)
+	mcpHandler, err := NewMCPHandler(nil, registry, nil)
+	require.NoError(t, err)
+
+	mcpServer := httptest.NewServer(mcpHandler)
+	t.Cleanup(mcpServer.Close)
+
+	transport := &mcpsdk.StreamableClientTransport{
+		Endpoint:             mcpServer.URL,
+		DisableStandaloneSSE: true,
+	}
+
+	var mu sync.Mutex
+	var messages []string
+	ctx := context.Background()
+	cs, err := mcpsdk.NewClient(&mcpsdk.Implementation{Name: "test", Version: "1.0"}, &mcpsdk.ClientOptions{
+		ProgressNotificationHandler: func(_ context.Context, req *mcpsdk.ProgressNotificationClientRequest) {
+			mu.Lock()
+			defer mu.Unlock()
+			messages = append(messages, req.Params.Message)
+		},
+	}).Connect(ctx, transport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { cs.Close() })
+
+	params := &mcpsdk.CallToolParams{
+		Name:      "invoke_agent",
+		Arguments: map[string]any{"agent": "default/test-agent", "task": "how many pods are running?"},
+	}
+	params.SetProgressToken("invoke-1")
+	result, err := cs.CallTool(ctx, params)
+	require.NoError(t, err)
+	require.False(t, result.IsError, "unexpected tool error: %v", result.Content)
+	require.Len(t, result.Content, 1)
+	assert.Contains(t, result.Content[0].(*mcpsdk.TextContent).Text, "3 pods are running")
+
+	// Progress notifications are handled concurrently with the result.
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(messages) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{
+		"Let me look.\nCalling k8s_get_resources",
+		"k8s_get_resources: Listing pods",
+	}, messages)
+}
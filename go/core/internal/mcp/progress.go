@@ -0,0 +1,109 @@
+package mcp
+
+import (
+	"context"
+	"strings"
+
+	a2atype "github.com/a2aproject/a2a-go/v2/a2a"
+	"github.com/kagent-dev/kagent/go/api/utils"
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// streamAgent invokes an agent with a streaming A2A request and sends the
+// client a progress notification for each step the agent reports while it
+// works: the text of its turns, the tools it calls and the progress those
+// tools report. It returns the task the agent finished, assembled from the
+// stream, or the agent's message if it answered without a task.
+func (h *MCPHandler) streamAgent(ctx context.Context, req *mcpsdk.CallToolRequest, token any, namespace, name string, message *a2atype.Message) (a2atype.SendMessageResult, error) {
+	log := ctrllog.FromContext(ctx).WithName("mcp-handler").WithValues("tool", "invoke_agent")
+
+	task := &a2atype.Task{}
+	var progress float64
+	for event, err := range h.agentClients.SendStreamingMessage(ctx, namespace, name, &a2atype.SendMessageRequest{Message: message}) {
+		if err != nil {
+			return nil, err
+		}
+		switch ev := event.(type) {
+		case *a2atype.Message:
+			return ev, nil
+		case *a2atype.Task:
+			task = ev
+		case *a2atype.TaskStatusUpdateEvent:
+			task.ContextID, task.Status = ev.ContextID, ev.Status
+			text := progressText(ev)
+			if text == "" {
+				continue
+			}
+			progress++
+			if err := req.Session.NotifyProgress(ctx, &mcpsdk.ProgressNotificationParams{
+				ProgressToken: token,
+				Progress:      progress,
+				Message:       text,
+			}); err != nil {
+				log.V(1).Info("Failed to send progress notification", "error", err)
+			}
+		case *a2atype.TaskArtifactUpdateEvent:
+			task.ContextID = ev.ContextID
+			addArtifactUpdate(task, ev)
+		}
+	}
+	return task, nil
+}
+
+// progressText describes a working status update of an agent for a progress
+// notification, or returns "" for updates not worth one, such as the
+// streamed chunks of a turn, which the complete turn follows.
+func progressText(ev *a2atype.TaskStatusUpdateEvent) string {
+	msg := ev.Status.Message
+	if ev.Status.State != a2atype.TaskStateWorking || msg == nil || isPartial(ev.Metadata) || isPartial(msg.Metadata) {
+		return ""
+	}
+	var steps []string
+	for _, part := range msg.Parts {
+		if part == nil {
+			continue
+		}
+		if text := strings.TrimSpace(part.Text()); text != "" {
+			steps = append(steps, text)
+			continue
+		}
+		data, _ := part.Data().(map[string]any)
+		name, _ := data["name"].(string)
+		kind, _ := utils.GetMetadataValue(part.Metadata, "type")
+		switch kind {
+		case "function_call":
+			steps = append(steps, "Calling "+name)
+		case "tool_progress":
+			if message, _ := data["message"].(string); message != "" {
+				steps = append(steps, name+": "+message)
+			}
+		}
+	}
+	return strings.Join(steps, "\n")
+}
+
+// isPartial reports whether the metadata marks a streamed chunk of a turn.
+func isPartial(metadata map[string]any) bool {
+	partial, _ := utils.GetMetadataValue(metadata, "partial")
+	return partial == true
+}
+
+// addArtifactUpdate applies an artifact update of the stream to task.
+func addArtifactUpdate(task *a2atype.Task, ev *a2atype.TaskArtifactUpdateEvent) {
+	if ev.Artifact == nil {
+		return
+	}
+	for i, artifact := range task.Artifacts {
+		if artifact.ID != ev.Artifact.ID {
+			continue
+		}
+		if ev.Append {
+			artifact.Parts = append(artifact.Parts, ev.Artifact.Parts...)
+		} else {
+			task.Artifacts[i] = ev.Artifact
+		}
+		return
+	}
+	task.Artifacts = append(task.Artifacts, ev.Artifact)
+}
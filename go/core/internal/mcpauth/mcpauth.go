@@ -0,0 +1,103 @@
+// Package mcpauth resolves a RemoteMCPServer's spec.auth and authenticates
+// the controller's own MCP connections with it.
+//
+// The agent translator writes the resolved config into the agent config, and
+// the ADKs fetch and refresh tokens at runtime. The controller connects to the
+// same servers to discover tools and serve MCP Apps; TokenSources keeps its
+// tokens across those short-lived connections so every reconcile does not hit
+// the token endpoint.
+package mcpauth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kagent-dev/kagent/go/api/adk"
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+)
+
+// tokenRequestTimeout bounds a single token request.
+const tokenRequestTimeout = 30 * time.Second
+
+// Resolve returns the OAuth2 client credentials config of the server's
+// spec.auth with the client secret resolved, or nil when the server has none.
+func Resolve(ctx context.Context, kube client.Client, server *v1alpha2.RemoteMCPServer) (*adk.OAuth2ClientCredentialsConfig, error) {
+	if server.Spec.Auth == nil || server.Spec.Auth.OAuth2ClientCredentials == nil {
+		return nil, nil
+	}
+	spec := server.Spec.Auth.OAuth2ClientCredentials
+	secret, err := spec.ClientSecretFrom.Resolve(ctx, kube, server.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve OAuth2 client secret: %w", err)
+	}
+	return &adk.OAuth2ClientCredentialsConfig{
+		TokenURL:     spec.TokenURL,
+		ClientID:     spec.ClientID,
+		ClientSecret: secret,
+		Scopes:       spec.Scopes,
+		Audience:     spec.Audience,
+	}, nil
+}
+
+// NewTokenSource returns a token source that fetches tokens with the client
+// credentials grant and reuses each one until shortly before it expires. The
+// client ID and secret are sent with HTTP Basic authentication, which every
+// compliant authorization server accepts.
+func NewTokenSource(cfg *adk.OAuth2ClientCredentialsConfig) oauth2.TokenSource {
+	cc := &clientcredentials.Config{
+		ClientID:     cfg.ClientID,
+		ClientSecret: cfg.ClientSecret,
+		TokenURL:     cfg.TokenURL,
+		Scopes:       cfg.Scopes,
+		AuthStyle:    oauth2.AuthStyleInHeader,
+	}
+	if cfg.Audience != "" {
+		cc.EndpointParams = url.Values{"audience": {cfg.Audience}}
+	}
+	// The context is kept for every refresh, so it must outlive the caller.
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, &http.Client{Timeout: tokenRequestTimeout})
+	return cc.TokenSource(ctx)
+}
+
+// TokenSources caches one token source per server. The zero value is ready
+// to use.
+type TokenSources struct {
+	mu      sync.Mutex
+	sources map[string]cachedSource
+}
+
+type cachedSource struct {
+	configHash string
+	source     oauth2.TokenSource
+}
+
+// Get returns the token source for cfg. key identifies the server; a changed
+// cfg, such as a rotated client secret, replaces its cached token.
+func (t *TokenSources) Get(key string, cfg *adk.OAuth2ClientCredentialsConfig) oauth2.TokenSource {
+	raw, _ := json.Marshal(cfg)
+	sum := sha256.Sum256(raw)
+	hash := hex.EncodeToString(sum[:])
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if cached, ok := t.sources[key]; ok && cached.configHash == hash {
+		return cached.source
+	}
+	if t.sources == nil {
+		t.sources = map[string]cachedSource{}
+	}
+	source := NewTokenSource(cfg)
+	t.sources[key] = cachedSource{configHash: hash, source: source}
+	return source
+}
@@ -0,0 +1,96 @@
+package mcpauth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kagent-dev/kagent/go/api/adk"
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+)
+
+func TestResolve(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	kube := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "idp-client", Namespace: "kagent"},
+		Data:       map[string][]byte{"secret": []byte("s3cret")},
+	}).Build()
+
+	server := &v1alpha2.RemoteMCPServer{ObjectMeta: metav1.ObjectMeta{Name: "tools", Namespace: "kagent"}}
+	cfg, err := Resolve(context.Background(), kube, server)
+	require.NoError(t, err)
+	assert.Nil(t, cfg)
+
+	server.Spec.Auth = &v1alpha2.RemoteMCPServerAuth{OAuth2ClientCredentials: &v1alpha2.OAuth2ClientCredentials{
+		TokenURL:         "https://idp.example.com/token",
+		ClientID:         "kagent",
+		ClientSecretFrom: v1alpha2.ValueSource{Type: v1alpha2.SecretValueSource, Name: "idp-client", Key: "secret"},
+		Scopes:           []string{"mcp.read"},
+	}}
+	cfg, err = Resolve(context.Background(), kube, server)
+	require.NoError(t, err)
+	assert.Equal(t, &adk.OAuth2ClientCredentialsConfig{
+		TokenURL:     "https://idp.example.com/token",
+		ClientID:     "kagent",
+		ClientSecret: "s3cret",
+		Scopes:       []string{"mcp.read"},
+	}, cfg)
+
+	server.Spec.Auth.OAuth2ClientCredentials.ClientSecretFrom.Name = "missing"
+	_, err = Resolve(context.Background(), kube, server)
+	assert.Error(t, err)
+}
+
+func TestTokenSources(t *testing.T) {
+	requests := 0
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		id, secret, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "kagent", id)
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "mcp.read mcp.write", r.PostForm.Get("scope"))
+		assert.Equal(t, "https://mcp.example.com", r.PostForm.Get("audience"))
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token": "token-for-" + secret,
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+		})
+	}))
+	defer tokenServer.Close()
+
+	cfg := &adk.OAuth2ClientCredentialsConfig{
+		TokenURL:     tokenServer.URL,
+		ClientID:     "kagent",
+		ClientSecret: "v1",
+		Scopes:       []string{"mcp.read", "mcp.write"},
+		Audience:     "https://mcp.example.com",
+	}
+	var sources TokenSources
+
+	for range 2 {
+		token, err := sources.Get("kagent/tools", cfg).Token()
+		require.NoError(t, err)
+		assert.Equal(t, "token-for-v1", token.AccessToken)
+	}
+	assert.Equal(t, 1, requests, "token should be cached across connections")
+
+	rotated := *cfg
+	rotated.ClientSecret = "v2"
+	token, err := sources.Get("kagent/tools", &rotated).Token()
+	require.NoError(t, err)
+	assert.Equal(t, "token-for-v2", token.AccessToken)
+	assert.Equal(t, 2, requests)
+}
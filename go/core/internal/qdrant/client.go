@@ -0,0 +1,168 @@
+// Package qdrant manages the Qdrant collections that hold agent memories.
+//
+// The agent runtime writes and searches memory points; the controller owns
+// the collection itself: it creates the collection and its payload indexes
+// when they are missing and prunes memories whose TTL has passed.
+package qdrant
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// VectorSize is the dimension of memory embeddings. It matches the dimension
+// the agent runtime truncates embeddings to and the pgvector memory table.
+const VectorSize = 768
+
+// Payload fields of a memory point. The agent runtime writes the same fields.
+const (
+	FieldAgentName = "agent_name"
+	FieldUserID    = "user_id"
+	FieldExpiresAt = "expires_at"
+)
+
+// payloadIndexes are the payload fields memory searches and pruning filter on.
+var payloadIndexes = []struct {
+	field  string
+	schema string
+}{
+	{FieldAgentName, "keyword"},
+	{FieldUserID, "keyword"},
+	{FieldExpiresAt, "integer"},
+}
+
+const defaultTimeout = 10 * time.Second
+
+// Client talks to the Qdrant REST API.
+type Client struct {
+	baseURL string
+	apiKey  string
+	http    *http.Client
+}
+
+// NewClient returns a Client for the Qdrant REST API at baseURL. apiKey may
+// be empty. httpClient defaults to a client with a 10s timeout.
+func NewClient(baseURL, apiKey string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: defaultTimeout}
+	}
+	return &Client{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		apiKey:  apiKey,
+		http:    httpClient,
+	}
+}
+
+type collectionInfo struct {
+	Config struct {
+		Params struct {
+			Vectors struct {
+				Size int `json:"size"`
+			} `json:"vectors"`
+		} `json:"params"`
+	} `json:"config"`
+	PayloadSchema map[string]json.RawMessage `json:"payload_schema"`
+}
+
+// EnsureCollection creates the collection name and the payload indexes on it
+// if they don't exist. An existing collection whose vectors aren't
+// VectorSize-dimensional is an error, since memories couldn't be stored in it.
+func (c *Client) EnsureCollection(ctx context.Context, name string) error {
+	var info collectionInfo
+	status, err := c.do(ctx, http.MethodGet, collectionPath(name), nil, &info)
+	switch {
+	case status == http.StatusNotFound:
+		body := map[string]any{
+			"vectors": map[string]any{"size": VectorSize, "distance": "Cosine"},
+		}
+		if _, err := c.do(ctx, http.MethodPut, collectionPath(name), body, nil); err != nil {
+			return fmt.Errorf("failed to create collection %q: %w", name, err)
+		}
+	case err != nil:
+		return fmt.Errorf("failed to get collection %q: %w", name, err)
+	case info.Config.Params.Vectors.Size != VectorSize:
+		return fmt.Errorf("collection %q has %d-dimensional vectors, memory needs %d", name, info.Config.Params.Vectors.Size, VectorSize)
+	}
+
+	for _, idx := range payloadIndexes {
+		if _, ok := info.PayloadSchema[idx.field]; ok {
+			continue
+		}
+		body := map[string]any{"field_name": idx.field, "field_schema": idx.schema}
+		if _, err := c.do(ctx, http.MethodPut, collectionPath(name)+"/index?wait=true", body, nil); err != nil {
+			return fmt.Errorf("failed to index %s in collection %q: %w", idx.field, name, err)
+		}
+	}
+	return nil
+}
+
+// DeleteExpired deletes the memories in the collection name that expired
+// before now.
+func (c *Client) DeleteExpired(ctx context.Context, name string, now time.Time) error {
+	body := map[string]any{
+		"filter": map[string]any{
+			"must": []any{
+				map[string]any{"key": FieldExpiresAt, "range": map[string]any{"lt": now.Unix()}},
+			},
+		},
+	}
+	if _, err := c.do(ctx, http.MethodPost, collectionPath(name)+"/points/delete", body, nil); err != nil {
+		return fmt.Errorf("failed to delete expired memories from collection %q: %w", name, err)
+	}
+	return nil
+}
+
+func collectionPath(name string) string {
+	return "/collections/" + url.PathEscape(name)
+}
+
+// do sends a request to the Qdrant API and decodes the "result" field of the
+// response into result, if non-nil. It returns the response status code
+// along with an error for non-2xx responses.
+func (c *Client) do(ctx context.Context, method, path string, body, result any) (int, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return 0, err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return 0, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.apiKey != "" {
+		req.Header.Set("api-key", c.apiKey)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return resp.StatusCode, fmt.Errorf("qdrant returned %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	if result != nil {
+		envelope := struct {
+			Result any `json:"result"`
+		}{Result: result}
+		if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+			return resp.StatusCode, fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+	return resp.StatusCode, nil
+}
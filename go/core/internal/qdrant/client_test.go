@@ -0,0 +1,121 @@
+package qdrant
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeQdrant records the requests it receives and serves a single collection.
+type fakeQdrant struct {
+	mu       sync.Mutex
+	requests []string
+	bodies   []map[string]any
+	exists   bool
+	size     int
+	indexed  []string
+}
+
+func (f *fakeQdrant) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.Header.Get("api-key") != "secret" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+	var body map[string]any
+	_ = json.NewDecoder(r.Body).Decode(&body)
+	f.bodies = append(f.bodies, body)
+
+	if r.Method == http.MethodGet && r.URL.Path == "/collections/memories" {
+		if !f.exists {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		schema := map[string]any{}
+		for _, field := range f.indexed {
+			schema[field] = map[string]any{"data_type": "keyword"}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"result": map[string]any{
+				"config":         map[string]any{"params": map[string]any{"vectors": map[string]any{"size": f.size}}},
+				"payload_schema": schema,
+			},
+		})
+		return
+	}
+	_ = json.NewEncoder(w).Encode(map[string]any{"result": true, "status": "ok"})
+}
+
+func TestEnsureCollection(t *testing.T) {
+	t.Run("creates a missing collection and its indexes", func(t *testing.T) {
+		fake := &fakeQdrant{}
+		server := httptest.NewServer(fake)
+		defer server.Close()
+
+		require.NoError(t, NewClient(server.URL+"/", "secret", nil).EnsureCollection(context.Background(), "memories"))
+		assert.Equal(t, []string{
+			"GET /collections/memories",
+			"PUT /collections/memories",
+			"PUT /collections/memories/index",
+			"PUT /collections/memories/index",
+			"PUT /collections/memories/index",
+		}, fake.requests)
+		assert.Equal(t, map[string]any{"size": float64(VectorSize), "distance": "Cosine"}, fake.bodies[1]["vectors"])
+		assert.Equal(t, FieldExpiresAt, fake.bodies[4]["field_name"])
+		assert.Equal(t, "integer", fake.bodies[4]["field_schema"])
+	})
+
+	t.Run("only adds missing indexes to an existing collection", func(t *testing.T) {
+		fake := &fakeQdrant{exists: true, size: VectorSize, indexed: []string{FieldAgentName, FieldExpiresAt}}
+		server := httptest.NewServer(fake)
+		defer server.Close()
+
+		require.NoError(t, NewClient(server.URL, "secret", nil).EnsureCollection(context.Background(), "memories"))
+		assert.Equal(t, []string{"GET /collections/memories", "PUT /collections/memories/index"}, fake.requests)
+		assert.Equal(t, FieldUserID, fake.bodies[1]["field_name"])
+	})
+
+	t.Run("rejects a collection with the wrong vector size", func(t *testing.T) {
+		fake := &fakeQdrant{exists: true, size: 1536}
+		server := httptest.NewServer(fake)
+		defer server.Close()
+
+		err := NewClient(server.URL, "secret", nil).EnsureCollection(context.Background(), "memories")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "1536-dimensional")
+	})
+
+	t.Run("surfaces API errors", func(t *testing.T) {
+		server := httptest.NewServer(&fakeQdrant{})
+		defer server.Close()
+
+		err := NewClient(server.URL, "wrong", nil).EnsureCollection(context.Background(), "memories")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "401")
+	})
+}
+
+func TestDeleteExpired(t *testing.T) {
+	fake := &fakeQdrant{}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	now := time.Unix(1700000000, 0)
+	require.NoError(t, NewClient(server.URL, "secret", nil).DeleteExpired(context.Background(), "memories", now))
+	assert.Equal(t, []string{"POST /collections/memories/points/delete"}, fake.requests)
+	must := fake.bodies[0]["filter"].(map[string]any)["must"].([]any)
+	assert.Equal(t, map[string]any{
+		"key":   FieldExpiresAt,
+		"range": map[string]any{"lt": float64(now.Unix())},
+	}, must[0])
+}
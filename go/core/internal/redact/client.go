@@ -0,0 +1,59 @@
+package redact
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	a2a "github.com/a2aproject/a2a-go/v2/a2a"
+	"github.com/kagent-dev/kagent/go/api/database"
+)
+
+// client redacts session events and tasks before storing them.
+type client struct {
+	database.Client
+	redactor *Redactor
+}
+
+// NewClient returns a database client that redacts events and tasks with r
+// before db stores them. It returns db when r is nil.
+func NewClient(db database.Client, r *Redactor) database.Client {
+	if r == nil {
+		return db
+	}
+	return &client{Client: db, redactor: r}
+}
+
+func (c *client) StoreEvents(ctx context.Context, events ...*database.Event) error {
+	redacted := make([]*database.Event, len(events))
+	for i, e := range events {
+		event := *e
+		event.Data = c.redactor.JSON(e.Data)
+		redacted[i] = &event
+	}
+	return c.Client.StoreEvents(ctx, redacted...)
+}
+
+func (c *client) StoreTask(ctx context.Context, task *a2a.Task, userID string) error {
+	redacted, err := c.redactor.Task(task)
+	if err != nil {
+		return err
+	}
+	return c.Client.StoreTask(ctx, redacted, userID)
+}
+
+// Task returns a redacted copy of task.
+func (r *Redactor) Task(task *a2a.Task) (*a2a.Task, error) {
+	if r == nil {
+		return task, nil
+	}
+	data, err := json.Marshal(task)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize task for redaction: %w", err)
+	}
+	redacted := &a2a.Task{}
+	if err := json.Unmarshal([]byte(r.JSON(string(data))), redacted); err != nil {
+		return nil, fmt.Errorf("failed to parse redacted task: %w", err)
+	}
+	return redacted, nil
+}
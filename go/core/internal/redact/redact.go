@@ -0,0 +1,146 @@
+// Package redact masks secrets, and in strict mode personal data, in session
+// events and tasks before the controller stores them, so tool output that
+// echoes a credential does not end up verbatim in session history.
+package redact
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+)
+
+// Rule replaces every match of Pattern.
+type Rule struct {
+	Name    string `json:"name"`
+	Pattern string `json:"pattern"`
+	// Replacement may reference submatches as $1 or ${name}. Defaults to
+	// [REDACTED:<name>].
+	Replacement string `json:"replacement,omitempty"`
+}
+
+// SecretRules detect credentials. They apply whenever redaction is enabled.
+// Values already replaced by an earlier rule are left alone by later ones.
+var SecretRules = []Rule{
+	{Name: "private_key", Pattern: `-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`},
+	{Name: "bearer_token", Pattern: `(?i)\b(bearer)\s+[A-Za-z0-9\-._~+/]{8,}=*`, Replacement: "$1 [REDACTED:bearer_token]"},
+	{Name: "api_key", Pattern: `\bsk-(?:ant-|proj-)?[A-Za-z0-9_\-]{20,}`},
+	{Name: "api_key", Pattern: `\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`},
+	{Name: "api_key", Pattern: `\b(?:ghp|gho|ghu|ghs|ghr|github_pat)_[A-Za-z0-9_]{20,}`},
+	{Name: "api_key", Pattern: `\bxox[abposr]-[A-Za-z0-9\-]{10,}`},
+	{Name: "api_key", Pattern: `\bAIza[0-9A-Za-z_\-]{35}`},
+	{Name: "credential", Pattern: `(?i)\b((?:api[_-]?key|secret|password|passwd|token)["']?\s*[:=]\s*["']?)[^\s"',;\[][^\s"',;]{5,}`, Replacement: "${1}[REDACTED:credential]"},
+}
+
+// PIIRules detect personal data. They apply in strict mode only.
+var PIIRules = []Rule{
+	{Name: "email", Pattern: `\b[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}\b`},
+	{Name: "ip_address", Pattern: `\b(?:(?:25[0-5]|2[0-4]\d|1?\d?\d)\.){3}(?:25[0-5]|2[0-4]\d|1?\d?\d)\b`},
+}
+
+type Config struct {
+	Enabled bool
+	// Strict adds PIIRules to SecretRules.
+	Strict bool
+	// Rules are applied after the built-in ones.
+	Rules []Rule
+}
+
+type compiledRule struct {
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+// Redactor applies redaction rules. A nil Redactor leaves data unchanged.
+type Redactor struct {
+	rules []compiledRule
+}
+
+// New compiles the rules selected by cfg. It returns nil when redaction is
+// disabled.
+func New(cfg Config) (*Redactor, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	rules := append([]Rule{}, SecretRules...)
+	if cfg.Strict {
+		rules = append(rules, PIIRules...)
+	}
+	rules = append(rules, cfg.Rules...)
+
+	r := &Redactor{rules: make([]compiledRule, 0, len(rules))}
+	for _, rule := range rules {
+		if rule.Name == "" {
+			return nil, fmt.Errorf("redaction rule with pattern %q has no name", rule.Pattern)
+		}
+		pattern, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern for redaction rule %s: %w", rule.Name, err)
+		}
+		replacement := rule.Replacement
+		if replacement == "" {
+			replacement = "[REDACTED:" + rule.Name + "]"
+		}
+		r.rules = append(r.rules, compiledRule{pattern: pattern, replacement: replacement})
+	}
+	return r, nil
+}
+
+// String redacts s.
+func (r *Redactor) String(s string) string {
+	if r == nil {
+		return s
+	}
+	for _, rule := range r.rules {
+		s = rule.pattern.ReplaceAllString(s, rule.replacement)
+	}
+	return s
+}
+
+// JSON redacts the string values of a JSON document, leaving its structure
+// intact. Data that is not JSON is redacted as text.
+func (r *Redactor) JSON(data string) string {
+	if r == nil {
+		return data
+	}
+	decoder := json.NewDecoder(bytes.NewReader([]byte(data)))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil || decoder.More() {
+		return r.String(data)
+	}
+	redacted, err := json.Marshal(r.value(value))
+	if err != nil {
+		return r.String(data)
+	}
+	return string(redacted)
+}
+
+func (r *Redactor) value(v any) any {
+	switch v := v.(type) {
+	case string:
+		return r.String(v)
+	case []any:
+		for i := range v {
+			v[i] = r.value(v[i])
+		}
+	case map[string]any:
+		for k := range v {
+			v[k] = r.value(v[k])
+		}
+	}
+	return v
+}
+
+// ParseRules parses a JSON array of rules, as given to the controller's
+// --redaction-rules flag. An empty string holds no rules.
+func ParseRules(s string) ([]Rule, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var rules []Rule
+	if err := json.Unmarshal([]byte(s), &rules); err != nil {
+		return nil, fmt.Errorf("invalid redaction rules: %w", err)
+	}
+	return rules, nil
+}
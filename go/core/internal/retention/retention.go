@@ -0,0 +1,109 @@
+// Package retention deletes session history past the configured retention
+// policy: on a schedule through the Janitor, and on demand through Prune.
+package retention
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kagent-dev/kagent/go/api/database"
+)
+
+var (
+	deletedRows = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kagent_retention_deleted_rows_total",
+		Help: "Rows permanently deleted by the retention policy, by kind.",
+	}, []string{"kind"})
+	runs = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kagent_retention_runs_total",
+		Help: "Retention runs, by result (success or error).",
+	}, []string{"result"})
+	lastSuccess = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "kagent_retention_last_success_timestamp_seconds",
+		Help: "Unix time of the last successful retention run.",
+	})
+)
+
+// Collectors returns the retention metrics, for registration with the
+// controller's metrics registry.
+func Collectors() []prometheus.Collector {
+	return []prometheus.Collector{deletedRows, runs, lastSuccess}
+}
+
+// Enabled reports whether the policy deletes anything.
+func Enabled(policy database.RetentionPolicy) bool {
+	return policy != database.RetentionPolicy{}
+}
+
+// Prune deletes the history the policy no longer retains and records the
+// outcome in the retention metrics. A dry run only reports what it would
+// delete.
+func Prune(ctx context.Context, db database.Client, policy database.RetentionPolicy, dryRun bool) (*database.PruneResult, error) {
+	result, err := db.PruneHistory(ctx, policy, dryRun)
+	if dryRun {
+		return result, err
+	}
+	if err != nil {
+		runs.WithLabelValues("error").Inc()
+		return nil, err
+	}
+	runs.WithLabelValues("success").Inc()
+	lastSuccess.SetToCurrentTime()
+	deletedRows.WithLabelValues("session").Add(float64(result.Sessions))
+	deletedRows.WithLabelValues("event").Add(float64(result.Events))
+	deletedRows.WithLabelValues("task").Add(float64(result.Tasks))
+	deletedRows.WithLabelValues("push_notification").Add(float64(result.PushNotifications))
+	deletedRows.WithLabelValues("stream_event").Add(float64(result.StreamEvents))
+	return result, nil
+}
+
+// Janitor periodically prunes session history with Policy.
+type Janitor struct {
+	DB       database.Client
+	Policy   database.RetentionPolicy
+	Interval time.Duration
+}
+
+// NeedLeaderElection ensures only one replica deletes history.
+func (j *Janitor) NeedLeaderElection() bool { return true }
+
+// NewJanitor returns a Janitor that runs every interval; pass 0 to use the
+// default of 1 hour.
+func NewJanitor(db database.Client, policy database.RetentionPolicy, interval time.Duration) *Janitor {
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	return &Janitor{DB: db, Policy: policy, Interval: interval}
+}
+
+// Start runs the retention loop until ctx is cancelled.
+func (j *Janitor) Start(ctx context.Context) error {
+	log := ctrllog.FromContext(ctx).WithName("retention")
+	log.Info("Starting retention loop", "interval", j.Interval, "policy", j.Policy)
+	ticker := time.NewTicker(j.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			j.runOnce(ctx)
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (j *Janitor) runOnce(ctx context.Context) {
+	log := ctrllog.FromContext(ctx).WithName("retention")
+	result, err := Prune(ctx, j.DB, j.Policy, false)
+	if err != nil {
+		log.Error(err, "Failed to prune history")
+		return
+	}
+	if *result != (database.PruneResult{}) {
+		log.Info("Pruned history", "sessions", result.Sessions, "events", result.Events,
+			"tasks", result.Tasks, "pushNotifications", result.PushNotifications, "streamEvents", result.StreamEvents)
+	}
+}
@@ -0,0 +1,94 @@
+package retention
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kagent-dev/kagent/go/api/database"
+)
+
+// fakeDB implements the retention method of database.Client.
+type fakeDB struct {
+	database.Client
+	result database.PruneResult
+	err    error
+
+	policy database.RetentionPolicy
+	dryRun bool
+}
+
+func (f *fakeDB) PruneHistory(_ context.Context, policy database.RetentionPolicy, dryRun bool) (*database.PruneResult, error) {
+	f.policy = policy
+	f.dryRun = dryRun
+	if f.err != nil {
+		return nil, f.err
+	}
+	result := f.result
+	return &result, nil
+}
+
+func metricValue(t *testing.T, c prometheus.Metric) float64 {
+	t.Helper()
+	var m dto.Metric
+	require.NoError(t, c.Write(&m))
+	if m.Counter != nil {
+		return m.GetCounter().GetValue()
+	}
+	return m.GetGauge().GetValue()
+}
+
+func TestPrune(t *testing.T) {
+	policy := database.RetentionPolicy{SessionMaxAge: 24 * time.Hour, MaxSessions: 10}
+
+	t.Run("records deleted rows", func(t *testing.T) {
+		db := &fakeDB{result: database.PruneResult{Sessions: 2, Events: 7, Tasks: 3, PushNotifications: 1}}
+		sessions := metricValue(t, deletedRows.WithLabelValues("session"))
+		events := metricValue(t, deletedRows.WithLabelValues("event"))
+		successes := metricValue(t, runs.WithLabelValues("success"))
+
+		result, err := Prune(context.Background(), db, policy, false)
+		require.NoError(t, err)
+		assert.Equal(t, db.result, *result)
+		assert.Equal(t, policy, db.policy)
+		assert.False(t, db.dryRun)
+		assert.Equal(t, sessions+2, metricValue(t, deletedRows.WithLabelValues("session")))
+		assert.Equal(t, events+7, metricValue(t, deletedRows.WithLabelValues("event")))
+		assert.Equal(t, successes+1, metricValue(t, runs.WithLabelValues("success")))
+		assert.NotZero(t, metricValue(t, lastSuccess))
+	})
+
+	t.Run("dry run records nothing", func(t *testing.T) {
+		db := &fakeDB{result: database.PruneResult{Sessions: 5}}
+		sessions := metricValue(t, deletedRows.WithLabelValues("session"))
+		successes := metricValue(t, runs.WithLabelValues("success"))
+
+		result, err := Prune(context.Background(), db, policy, true)
+		require.NoError(t, err)
+		assert.Equal(t, int64(5), result.Sessions)
+		assert.True(t, db.dryRun)
+		assert.Equal(t, sessions, metricValue(t, deletedRows.WithLabelValues("session")))
+		assert.Equal(t, successes, metricValue(t, runs.WithLabelValues("success")))
+	})
+
+	t.Run("records failures", func(t *testing.T) {
+		db := &fakeDB{err: errors.New("connection refused")}
+		failures := metricValue(t, runs.WithLabelValues("error"))
+
+		_, err := Prune(context.Background(), db, policy, false)
+		require.Error(t, err)
+		assert.Equal(t, failures+1, metricValue(t, runs.WithLabelValues("error")))
+	})
+}
+
+func TestEnabled(t *testing.T) {
+	assert.False(t, Enabled(database.RetentionPolicy{}))
+	assert.True(t, Enabled(database.RetentionPolicy{EventMaxAge: time.Hour}))
+	assert.True(t, Enabled(database.RetentionPolicy{MaxSessions: 1}))
+}
@@ -0,0 +1,370 @@
+// Package scaletozero scales idle agent Deployments to zero and back. The
+// IdleScaler scales down agents without recent invocations; the Waker wraps
+// the A2A transport of an agent to record invocations and to scale the agent
+// back up, holding the request until a pod is available.
+//
+// Activity is stored in the ActivityAnnotation of the agent Deployment so
+// that every controller replica sees the invocations proxied by the others.
+// Requests that reach the agent Service directly are not counted.
+package scaletozero
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+)
+
+const (
+	// ActivityAnnotation holds the RFC 3339 time of the last invocation of a
+	// scale-to-zero agent, set on its Deployment.
+	ActivityAnnotation = "kagent.dev/last-activity"
+
+	// DefaultColdStartTimeout bounds how long a request is held while a
+	// scaled-down agent starts, unless the agent sets its own.
+	DefaultColdStartTimeout = 2 * time.Minute
+
+	// activityWriteInterval is the least time between two writes of the
+	// activity annotation by one replica, and how often requests still in
+	// flight, such as long streams, are recorded.
+	activityWriteInterval = 30 * time.Second
+
+	// wakePollInterval is how often a held request checks whether the agent
+	// has a pod available.
+	wakePollInterval = 500 * time.Millisecond
+)
+
+// targetReplicas returns the replicas an awake agent runs with.
+func targetReplicas(spec *v1alpha2.SharedDeploymentSpec) int32 {
+	if spec.Replicas != nil {
+		return *spec.Replicas
+	}
+	return 1
+}
+
+// Waker records the invocations of scale-to-zero agents and scales them up on
+// demand. It runs on every controller replica.
+type Waker struct {
+	kube client.Client
+
+	mu     sync.Mutex
+	agents map[types.NamespacedName]*activity
+
+	// now is replaced in tests.
+	now func() time.Time
+}
+
+type activity struct {
+	inFlight int
+	written  time.Time
+}
+
+// NewWaker returns a Waker that reads and scales Deployments with kube.
+func NewWaker(kube client.Client) *Waker {
+	return &Waker{
+		kube:   kube,
+		agents: make(map[types.NamespacedName]*activity),
+		now:    time.Now,
+	}
+}
+
+// NeedLeaderElection ensures every replica records the requests it proxies.
+func (w *Waker) NeedLeaderElection() bool { return false }
+
+// Start records the agents with requests in flight until ctx is cancelled,
+// so long streams keep their agent awake.
+func (w *Waker) Start(ctx context.Context) error {
+	ticker := time.NewTicker(activityWriteInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			w.mu.Lock()
+			var busy []types.NamespacedName
+			for agent, a := range w.agents {
+				if a.inFlight > 0 {
+					busy = append(busy, agent)
+				}
+			}
+			w.mu.Unlock()
+			for _, agent := range busy {
+				w.touch(ctx, agent)
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// RoundTripper returns a transport for requests to agent that records them
+// and scales the agent up first when it has no pod available. spec holds the
+// agent's deployment settings and must set ScaleToZero.
+func (w *Waker) RoundTripper(agent types.NamespacedName, spec *v1alpha2.SharedDeploymentSpec, base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	coldStart := DefaultColdStartTimeout
+	if spec.ScaleToZero.ColdStartTimeout != nil {
+		coldStart = spec.ScaleToZero.ColdStartTimeout.Duration
+	}
+	return &wakeRoundTripper{
+		waker:     w,
+		agent:     agent,
+		replicas:  targetReplicas(spec),
+		coldStart: coldStart,
+		base:      base,
+	}
+}
+
+type wakeRoundTripper struct {
+	waker     *Waker
+	agent     types.NamespacedName
+	replicas  int32
+	coldStart time.Duration
+	base      http.RoundTripper
+}
+
+func (t *wakeRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	t.waker.begin(ctx, t.agent)
+	if err := t.waker.wake(ctx, t.agent, t.replicas, t.coldStart); err != nil {
+		t.waker.end(ctx, t.agent)
+		return nil, err
+	}
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		t.waker.end(ctx, t.agent)
+		return nil, err
+	}
+	// The request stays in flight until its (streaming) response is read.
+	resp.Body = &endOnClose{
+		ReadCloser: resp.Body,
+		end:        sync.OnceFunc(func() { t.waker.end(context.WithoutCancel(ctx), t.agent) }),
+	}
+	return resp, nil
+}
+
+type endOnClose struct {
+	io.ReadCloser
+	end func()
+}
+
+func (b *endOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.end()
+	return err
+}
+
+func (w *Waker) begin(ctx context.Context, agent types.NamespacedName) {
+	w.mu.Lock()
+	a := w.agents[agent]
+	if a == nil {
+		a = &activity{}
+		w.agents[agent] = a
+	}
+	a.inFlight++
+	w.mu.Unlock()
+	w.touch(ctx, agent)
+}
+
+func (w *Waker) end(ctx context.Context, agent types.NamespacedName) {
+	w.mu.Lock()
+	if a := w.agents[agent]; a != nil && a.inFlight > 0 {
+		a.inFlight--
+	}
+	w.mu.Unlock()
+	w.touch(ctx, agent)
+}
+
+// touch records activity on the agent's Deployment, at most once per
+// activityWriteInterval. Failures are logged; they never fail the request.
+func (w *Waker) touch(ctx context.Context, agent types.NamespacedName) {
+	now := w.now()
+	w.mu.Lock()
+	a := w.agents[agent]
+	if a == nil || now.Sub(a.written) < activityWriteInterval {
+		w.mu.Unlock()
+		return
+	}
+	a.written = now
+	w.mu.Unlock()
+
+	deployment := &appsv1.Deployment{}
+	deployment.Name, deployment.Namespace = agent.Name, agent.Namespace
+	if err := w.kube.Patch(ctx, deployment, activityPatch(now)); err != nil {
+		ctrllog.FromContext(ctx).WithName("scale-to-zero").Error(err, "Failed to record agent activity", "agent", agent)
+	}
+}
+
+// wake scales the agent up to replicas when it is scaled to zero and waits
+// until it has a pod available, for at most timeout.
+func (w *Waker) wake(ctx context.Context, agent types.NamespacedName, replicas int32, timeout time.Duration) error {
+	deployment := &appsv1.Deployment{}
+	if err := w.kube.Get(ctx, agent, deployment); err != nil {
+		return fmt.Errorf("failed to get deployment of agent %s: %w", agent, err)
+	}
+	if deployment.Status.AvailableReplicas > 0 {
+		return nil
+	}
+	if deployment.Spec.Replicas != nil && *deployment.Spec.Replicas == 0 {
+		log := ctrllog.FromContext(ctx).WithName("scale-to-zero")
+		log.Info("Scaling up idle agent", "agent", agent, "replicas", replicas)
+		patch := client.MergeFrom(deployment.DeepCopy())
+		deployment.Spec.Replicas = &replicas
+		if err := w.kube.Patch(ctx, deployment, patch); err != nil {
+			return fmt.Errorf("failed to scale up agent %s: %w", agent, err)
+		}
+	}
+
+	err := wait.PollUntilContextTimeout(ctx, wakePollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		if err := w.kube.Get(ctx, agent, deployment); err != nil {
+			return false, err
+		}
+		return deployment.Status.AvailableReplicas > 0, nil
+	})
+	if err != nil {
+		if ctx.Err() == nil && wait.Interrupted(err) {
+			return fmt.Errorf("agent %s did not start within the cold start timeout of %s", agent, timeout)
+		}
+		return fmt.Errorf("failed waiting for agent %s to start: %w", agent, err)
+	}
+	return nil
+}
+
+func activityPatch(at time.Time) client.Patch {
+	return client.RawPatch(types.MergePatchType,
+		fmt.Appendf(nil, `{"metadata":{"annotations":{%q:%q}}}`, ActivityAnnotation, at.UTC().Format(time.RFC3339)))
+}
+
+// IdleScaler scales scale-to-zero agents without invocations for their idle
+// timeout to zero. It also keeps the replicas of awake agents in line with
+// their spec, which the agent reconciler leaves to it, and scales agents back
+// up when scale-to-zero is turned off while they are idle.
+type IdleScaler struct {
+	kube     client.Client
+	interval time.Duration
+
+	// now is replaced in tests.
+	now func() time.Time
+}
+
+// NewIdleScaler returns an IdleScaler that checks agents every interval; pass
+// 0 to use the default of 1 minute.
+func NewIdleScaler(kube client.Client, interval time.Duration) *IdleScaler {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	return &IdleScaler{kube: kube, interval: interval, now: time.Now}
+}
+
+// NeedLeaderElection ensures only one replica scales agents down.
+func (s *IdleScaler) NeedLeaderElection() bool { return true }
+
+// Start runs the scaling loop until ctx is cancelled.
+func (s *IdleScaler) Start(ctx context.Context) error {
+	log := ctrllog.FromContext(ctx).WithName("scale-to-zero")
+	log.Info("Starting idle agent scaler", "interval", s.interval)
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.runOnce(ctx); err != nil {
+				log.Error(err, "Failed to scale idle agents")
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (s *IdleScaler) runOnce(ctx context.Context) error {
+	log := ctrllog.FromContext(ctx).WithName("scale-to-zero")
+	agents := &v1alpha2.AgentList{}
+	if err := s.kube.List(ctx, agents); err != nil {
+		return fmt.Errorf("failed to list agents: %w", err)
+	}
+	for i := range agents.Items {
+		agent := &agents.Items[i]
+		if err := s.scale(ctx, agent); err != nil {
+			log.Error(err, "Failed to scale agent", "agent", client.ObjectKeyFromObject(agent))
+		}
+	}
+	return nil
+}
+
+func (s *IdleScaler) scale(ctx context.Context, agent *v1alpha2.Agent) error {
+	deployment := &appsv1.Deployment{}
+	if err := s.kube.Get(ctx, client.ObjectKeyFromObject(agent), deployment); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	spec := agent.Spec.SharedDeployment()
+	lastActivity, tracked := deployment.Annotations[ActivityAnnotation]
+	current := int32(1)
+	if deployment.Spec.Replicas != nil {
+		current = *deployment.Spec.Replicas
+	}
+	log := ctrllog.FromContext(ctx).WithName("scale-to-zero").WithValues("agent", client.ObjectKeyFromObject(agent))
+
+	if spec == nil || spec.ScaleToZero == nil {
+		if !tracked {
+			return nil
+		}
+		// Scale-to-zero was turned off: give the replicas back to the agent
+		// reconciler, waking the agent if it was idle.
+		patch := client.MergeFrom(deployment.DeepCopy())
+		delete(deployment.Annotations, ActivityAnnotation)
+		if current == 0 {
+			replicas := int32(1)
+			if spec != nil && spec.Replicas != nil {
+				replicas = *spec.Replicas
+			}
+			deployment.Spec.Replicas = &replicas
+			log.Info("Scaling up agent without scale-to-zero", "replicas", replicas)
+		}
+		return s.kube.Patch(ctx, deployment, patch)
+	}
+
+	if current == 0 {
+		return nil
+	}
+	now := s.now()
+	if !tracked {
+		// Count idle time from when scale-to-zero was first seen.
+		return s.kube.Patch(ctx, deployment, activityPatch(now))
+	}
+	last, err := time.Parse(time.RFC3339, lastActivity)
+	if err != nil {
+		return s.kube.Patch(ctx, deployment, activityPatch(now))
+	}
+
+	patch := client.MergeFromWithOptions(deployment.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	switch idle := now.Sub(last); {
+	case idle >= spec.ScaleToZero.IdleTimeout.Duration:
+		log.Info("Scaling idle agent to zero", "idle", idle.Round(time.Second))
+		replicas := int32(0)
+		deployment.Spec.Replicas = &replicas
+	case current != targetReplicas(spec):
+		replicas := targetReplicas(spec)
+		deployment.Spec.Replicas = &replicas
+	default:
+		return nil
+	}
+	// The optimistic lock fails the patch when a request recorded activity
+	// since the Deployment was read; the next run sees it.
+	if err := s.kube.Patch(ctx, deployment, patch); err != nil && !apierrors.IsConflict(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+package scaletozero
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+)
+
+var now = time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+func newScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1alpha2.AddToScheme(scheme))
+	return scheme
+}
+
+func agentWith(scaleToZero *v1alpha2.ScaleToZeroSpec, replicas *int32) *v1alpha2.Agent {
+	return &v1alpha2.Agent{
+		ObjectMeta: metav1.ObjectMeta{Name: "k8s-agent", Namespace: "default"},
+		Spec: v1alpha2.AgentSpec{
+			Type: v1alpha2.AgentType_Declarative,
+			Declarative: &v1alpha2.DeclarativeAgentSpec{
+				Deployment: &v1alpha2.DeclarativeDeploymentSpec{
+					SharedDeploymentSpec: v1alpha2.SharedDeploymentSpec{Replicas: replicas, ScaleToZero: scaleToZero},
+				},
+			},
+		},
+	}
+}
+
+func deploymentWith(replicas int32, available int32, lastActivity *time.Time) *appsv1.Deployment {
+	d := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "k8s-agent", Namespace: "default"},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		Status:     appsv1.DeploymentStatus{AvailableReplicas: available},
+	}
+	if lastActivity != nil {
+		d.Annotations = map[string]string{ActivityAnnotation: lastActivity.Format(time.RFC3339)}
+	}
+	return d
+}
+
+func TestIdleScaler(t *testing.T) {
+	idle := &v1alpha2.ScaleToZeroSpec{IdleTimeout: metav1.Duration{Duration: 30 * time.Minute}}
+	recent, stale := now.Add(-10*time.Minute), now.Add(-time.Hour)
+
+	tests := []struct {
+		name             string
+		agent            *v1alpha2.Agent
+		deployment       *appsv1.Deployment
+		wantReplicas     int32
+		wantLastActivity string
+	}{
+		{
+			name:             "scales an idle agent to zero",
+			agent:            agentWith(idle, nil),
+			deployment:       deploymentWith(1, 1, &stale),
+			wantReplicas:     0,
+			wantLastActivity: stale.Format(time.RFC3339),
+		},
+		{
+			name:             "keeps a recently used agent",
+			agent:            agentWith(idle, nil),
+			deployment:       deploymentWith(1, 1, &recent),
+			wantReplicas:     1,
+			wantLastActivity: recent.Format(time.RFC3339),
+		},
+		{
+			name:             "starts counting idle time for a new agent",
+			agent:            agentWith(idle, nil),
+			deployment:       deploymentWith(1, 1, nil),
+			wantReplicas:     1,
+			wantLastActivity: now.Format(time.RFC3339),
+		},
+		{
+			name:             "applies spec replicas to an awake agent",
+			agent:            agentWith(idle, ptr.To[int32](3)),
+			deployment:       deploymentWith(1, 1, &recent),
+			wantReplicas:     3,
+			wantLastActivity: recent.Format(time.RFC3339),
+		},
+		{
+			name:         "wakes an idle agent when scale-to-zero is turned off",
+			agent:        agentWith(nil, ptr.To[int32](2)),
+			deployment:   deploymentWith(0, 0, &stale),
+			wantReplicas: 2,
+		},
+		{
+			name:         "leaves agents without scale-to-zero alone",
+			agent:        agentWith(nil, nil),
+			deployment:   deploymentWith(4, 4, nil),
+			wantReplicas: 4,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kube := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(tt.agent, tt.deployment).Build()
+			scaler := NewIdleScaler(kube, 0)
+			scaler.now = func() time.Time { return now }
+
+			require.NoError(t, scaler.runOnce(context.Background()))
+
+			got := &appsv1.Deployment{}
+			require.NoError(t, kube.Get(context.Background(), client.ObjectKeyFromObject(tt.deployment), got))
+			assert.Equal(t, tt.wantReplicas, *got.Spec.Replicas)
+			assert.Equal(t, tt.wantLastActivity, got.Annotations[ActivityAnnotation])
+		})
+	}
+}
+
+func TestWaker(t *testing.T) {
+	key := types.NamespacedName{Namespace: "default", Name: "k8s-agent"}
+	spec := &v1alpha2.SharedDeploymentSpec{
+		Replicas: ptr.To[int32](2),
+		ScaleToZero: &v1alpha2.ScaleToZeroSpec{
+			IdleTimeout:      metav1.Duration{Duration: 30 * time.Minute},
+			ColdStartTimeout: &metav1.Duration{Duration: 5 * time.Second},
+		},
+	}
+	agentServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer agentServer.Close()
+
+	t.Run("scales up an idle agent and holds the request until it is available", func(t *testing.T) {
+		stale := now.Add(-time.Hour)
+		kube := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(deploymentWith(0, 0, &stale)).Build()
+		waker := NewWaker(kube)
+		waker.now = func() time.Time { return now }
+
+		// Stand in for the Deployment controller, starting the scaled-up pods.
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go func() {
+			for ctx.Err() == nil {
+				d := &appsv1.Deployment{}
+				if err := kube.Get(ctx, key, d); err == nil && *d.Spec.Replicas > 0 {
+					d.Status.AvailableReplicas = *d.Spec.Replicas
+					if kube.Status().Update(ctx, d) == nil {
+						return
+					}
+				}
+				time.Sleep(10 * time.Millisecond)
+			}
+		}()
+
+		httpClient := &http.Client{Transport: waker.RoundTripper(key, spec, nil)}
+		resp, err := httpClient.Get(agentServer.URL)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		got := &appsv1.Deployment{}
+		require.NoError(t, kube.Get(context.Background(), key, got))
+		assert.Equal(t, int32(2), *got.Spec.Replicas)
+		assert.Equal(t, now.Format(time.RFC3339), got.Annotations[ActivityAnnotation])
+		assert.Zero(t, waker.agents[key].inFlight)
+	})
+
+	t.Run("fails the request after the cold start timeout", func(t *testing.T) {
+		kube := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(deploymentWith(0, 0, nil)).Build()
+		waker := NewWaker(kube)
+		short := *spec
+		short.ScaleToZero = &v1alpha2.ScaleToZeroSpec{ColdStartTimeout: &metav1.Duration{Duration: time.Second}}
+
+		httpClient := &http.Client{Transport: waker.RoundTripper(key, &short, nil)}
+		_, err := httpClient.Get(agentServer.URL)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "did not start within the cold start timeout of 1s")
+		assert.Zero(t, waker.agents[key].inFlight)
+	})
+}
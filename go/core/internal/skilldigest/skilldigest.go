@@ -0,0 +1,117 @@
+// Package skilldigest resolves the OCI images of agent skills to the digests
+// their tags point at. The controller pins the skills-init container to the
+// resolved digests, so every pod of an agent fetches the same skill contents
+// until the tag is resolved to a new digest, and reports them on the Agent
+// status.
+//
+// A tag is looked up again once its resolution is older than the cache TTL,
+// on the agent's next reconciliation. References that already carry a digest
+// are not looked up.
+package skilldigest
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kagent-dev/kagent/go/core/internal/imagepolicy"
+)
+
+// DefaultTTL is how long a resolved tag is reused before it is looked up
+// again.
+const DefaultTTL = 5 * time.Minute
+
+// Resolver resolves skill image references with the registry credentials of
+// the agent's image pull secrets.
+type Resolver struct {
+	kube client.Reader
+	ttl  time.Duration
+	now  func() time.Time
+
+	mu       sync.Mutex
+	resolved map[string]resolution
+}
+
+type resolution struct {
+	digest     string
+	resolvedAt time.Time
+}
+
+// New returns a Resolver that reuses each resolution for ttl. kube reads the
+// image pull secrets of the agents.
+func New(kube client.Reader, ttl time.Duration) *Resolver {
+	return &Resolver{
+		kube:     kube,
+		ttl:      ttl,
+		now:      time.Now,
+		resolved: map[string]resolution{},
+	}
+}
+
+// ResolveSkillDigests returns the digest each of refs resolves to in
+// namespace. A reference that could not be resolved is missing from the
+// result and its error is joined into the returned error, so the caller can
+// still pin the others. insecure allows plain HTTP and untrusted
+// certificates, like spec.skills.insecureSkipVerify does for the pods.
+func (r *Resolver) ResolveSkillDigests(ctx context.Context, namespace string, refs []string, pullSecrets []corev1.LocalObjectReference, insecure bool) (map[string]string, error) {
+	digests := make(map[string]string, len(refs))
+	var opts []remote.Option
+	var errs error
+	for _, image := range refs {
+		var nameOpts []name.Option
+		if insecure {
+			nameOpts = append(nameOpts, name.Insecure)
+		}
+		ref, err := name.ParseReference(image, nameOpts...)
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("invalid skill image %s: %w", image, err))
+			continue
+		}
+		if digest, ok := ref.(name.Digest); ok {
+			digests[image] = digest.DigestStr()
+			continue
+		}
+
+		key := namespace + "/" + ref.Name()
+		r.mu.Lock()
+		cached, ok := r.resolved[key]
+		r.mu.Unlock()
+		if ok && r.now().Sub(cached.resolvedAt) < r.ttl {
+			digests[image] = cached.digest
+			continue
+		}
+
+		if opts == nil {
+			keychain, err := imagepolicy.PullSecretKeychain(ctx, r.kube, namespace, pullSecrets)
+			if err != nil {
+				return digests, err
+			}
+			opts = []remote.Option{remote.WithContext(ctx), remote.WithAuthFromKeychain(keychain)}
+			if insecure {
+				transport := remote.DefaultTransport.(*http.Transport).Clone()
+				transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true} //nolint:gosec // G402: explicit user opt-in via spec.skills.insecureSkipVerify
+				opts = append(opts, remote.WithTransport(transport))
+			}
+		}
+		desc, err := remote.Head(ref, opts...)
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("failed to resolve skill image %s: %w", image, err))
+			continue
+		}
+		digest := desc.Digest.String()
+		digests[image] = digest
+		r.mu.Lock()
+		r.resolved[key] = resolution{digest: digest, resolvedAt: r.now()}
+		r.mu.Unlock()
+	}
+	return digests, errs
+}
@@ -0,0 +1,60 @@
+package skilldigest
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func pushSkill(t *testing.T, ref string) string {
+	t.Helper()
+	img, err := random.Image(64, 1)
+	require.NoError(t, err)
+	tag, err := name.ParseReference(ref)
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(tag, img))
+	digest, err := img.Digest()
+	require.NoError(t, err)
+	return digest.String()
+}
+
+func TestResolveSkillDigests(t *testing.T) {
+	server := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	skill := host + "/skills/kubernetes:v1"
+	first := pushSkill(t, skill)
+	pinned := host + "/skills/helm@" + first
+
+	now := time.Now()
+	r := New(nil, time.Minute)
+	r.now = func() time.Time { return now }
+
+	digests, err := r.ResolveSkillDigests(context.Background(), "kagent", []string{skill, pinned, host + "/skills/missing:v1"}, nil, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "missing:v1")
+	assert.Equal(t, map[string]string{skill: first, pinned: first}, digests)
+
+	// A moved tag is picked up once the cached resolution expires.
+	second := pushSkill(t, skill)
+	digests, err = r.ResolveSkillDigests(context.Background(), "kagent", []string{skill}, nil, false)
+	require.NoError(t, err)
+	assert.Equal(t, first, digests[skill])
+
+	now = now.Add(2 * time.Minute)
+	digests, err = r.ResolveSkillDigests(context.Background(), "kagent", []string{skill}, nil, false)
+	require.NoError(t, err)
+	assert.Equal(t, second, digests[skill])
+}
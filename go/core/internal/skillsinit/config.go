@@ -71,6 +71,11 @@ type GitRef struct {
 type OCIRef struct {
 	Image string `json:"image"`
 	Dest  string `json:"dest"`
+	// Digest, if set, is the manifest digest the controller resolved the
+	// skill to; Image then references it by that digest. The binary refuses
+	// an image that doesn't match it and skips the pull when Dest already
+	// holds it.
+	Digest string `json:"digest,omitempty"`
 }
 
 // SSHHost is a known_hosts entry to seed with ssh-keyscan.
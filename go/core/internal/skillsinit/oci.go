@@ -4,6 +4,7 @@ import (
 	"archive/tar"
 	"fmt"
 	"io"
+	"log"
 	"os"
 	"path"
 	"path/filepath"
@@ -11,13 +12,23 @@ import (
 	"strings"
 
 	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/name"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
 )
 
+// digestMarker is written into the Dest of a skill fetched by digest. It
+// holds the digest, so a restarted init container, which finds the volume
+// as it left it, doesn't pull the skill again.
+const digestMarker = ".kagent-skill-digest"
+
 // FetchOCI pulls the named image, exports its flattened filesystem, and
 // extracts it into ref.Dest. It is the in-process replacement for the old
 // `krane export | tar xf -` pipeline.
 //
+// When ref.Digest is set, ref.Image must reference that digest. The registry
+// client checks every manifest it fetches by digest against it, so the
+// extracted contents are the ones the controller resolved.
+//
 // Auth comes from the standard DOCKER_CONFIG mechanism (set by the caller
 // after MergeDockerConfigs). Platform follows the host arch — same as the
 // old script's case statement on `uname -m`.
@@ -32,6 +43,18 @@ func FetchOCI(ref OCIRef, insecure bool) error {
 		opts = append(opts, crane.Insecure)
 	}
 
+	marker := filepath.Join(ref.Dest, digestMarker)
+	if ref.Digest != "" {
+		if err := checkPinned(ref, insecure); err != nil {
+			return err
+		}
+		if fetched, err := os.ReadFile(marker); err == nil && string(fetched) == ref.Digest {
+			log.Printf("%s already holds %s, skipping pull", ref.Dest, ref.Digest)
+			return nil
+		}
+		_ = os.Remove(marker)
+	}
+
 	img, err := crane.Pull(ref.Image, opts...)
 	if err != nil {
 		return fmt.Errorf("pull %s: %w", ref.Image, err)
@@ -58,6 +81,28 @@ func FetchOCI(ref OCIRef, insecure bool) error {
 	if err := <-errCh; err != nil {
 		return fmt.Errorf("export %s: %w", ref.Image, err)
 	}
+	if ref.Digest != "" {
+		if err := os.WriteFile(marker, []byte(ref.Digest), 0o644); err != nil {
+			return fmt.Errorf("write %s: %w", marker, err)
+		}
+	}
+	return nil
+}
+
+// checkPinned verifies that ref.Image references ref.Digest.
+func checkPinned(ref OCIRef, insecure bool) error {
+	var opts []name.Option
+	if insecure {
+		opts = append(opts, name.Insecure)
+	}
+	parsed, err := name.ParseReference(ref.Image, opts...)
+	if err != nil {
+		return fmt.Errorf("parse %s: %w", ref.Image, err)
+	}
+	digest, ok := parsed.(name.Digest)
+	if !ok || digest.DigestStr() != ref.Digest {
+		return fmt.Errorf("image %s is not pinned to digest %s", ref.Image, ref.Digest)
+	}
 	return nil
 }
 
@@ -3,11 +3,18 @@ package skillsinit
 import (
 	"archive/tar"
 	"bytes"
+	"io"
+	"log"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -118,6 +125,40 @@ func Test_extractTar_writesRegularFiles(t *testing.T) {
 	assert.Equal(t, "hello", string(body))
 }
 
+// Test_FetchOCI_pinnedDigest pulls a skill by digest from an in-memory
+// registry, then checks that a restart with the skill already extracted
+// doesn't reach the registry again and that a mismatched pin is refused.
+func Test_FetchOCI_pinnedDigest(t *testing.T) {
+	server := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	layer, err := crane.Layer(map[string][]byte{"SKILL.md": []byte("# kubernetes")})
+	require.NoError(t, err)
+	img, err := mutate.AppendLayers(empty.Image, layer)
+	require.NoError(t, err)
+	require.NoError(t, crane.Push(img, host+"/skills/kubernetes:v1"))
+	digest, err := img.Digest()
+	require.NoError(t, err)
+
+	ref := OCIRef{
+		Image:  host + "/skills/kubernetes@" + digest.String(),
+		Dest:   filepath.Join(t.TempDir(), "kubernetes"),
+		Digest: digest.String(),
+	}
+	require.NoError(t, FetchOCI(ref, false))
+	body, err := os.ReadFile(filepath.Join(ref.Dest, "SKILL.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "# kubernetes", string(body))
+
+	server.Close()
+	require.NoError(t, FetchOCI(ref, false), "an extracted digest is not pulled again")
+
+	ref.Image = host + "/skills/kubernetes:v1"
+	err = FetchOCI(ref, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is not pinned to digest")
+}
+
 // tarEntry is a minimal description of one tar record.
 type tarEntry struct {
 	Name     string
@@ -0,0 +1,84 @@
+// Package toolstats summarizes the tool call stats agents report per tool
+// server.
+package toolstats
+
+import (
+	"cmp"
+	"math"
+	"slices"
+
+	"github.com/kagent-dev/kagent/go/api/adk"
+	"github.com/kagent-dev/kagent/go/api/database"
+	api "github.com/kagent-dev/kagent/go/api/httpapi"
+)
+
+// Summarize rolls hourly stats up into one summary.
+func Summarize(rows []database.ToolCallStats) api.ToolCallSummary {
+	var calls, errors int64
+	buckets := make([]int64, len(adk.ToolLatencyBucketsMs)+1)
+	for _, row := range rows {
+		calls += row.CallCount
+		errors += row.ErrorCount
+		addBuckets(buckets, row.LatencyBuckets)
+	}
+	return summary(calls, errors, buckets)
+}
+
+// SummarizeByAgent rolls hourly stats up into one summary per agent, ordered
+// by agent.
+func SummarizeByAgent(rows []database.ToolCallStats) []api.ToolServerAgentStats {
+	byAgent := map[string][]database.ToolCallStats{}
+	for _, row := range rows {
+		byAgent[row.AgentID] = append(byAgent[row.AgentID], row)
+	}
+	result := make([]api.ToolServerAgentStats, 0, len(byAgent))
+	for agentID, agentRows := range byAgent {
+		result = append(result, api.ToolServerAgentStats{AgentID: agentID, ToolCallSummary: Summarize(agentRows)})
+	}
+	slices.SortFunc(result, func(a, b api.ToolServerAgentStats) int { return cmp.Compare(a.AgentID, b.AgentID) })
+	return result
+}
+
+// addBuckets adds src to dst. Buckets beyond those of dst, which agents with
+// other bounds could report, count towards the last one.
+func addBuckets(dst, src []int64) {
+	last := len(dst) - 1
+	for i, n := range src {
+		dst[min(i, last)] += n
+	}
+}
+
+func summary(calls, errors int64, buckets []int64) api.ToolCallSummary {
+	s := api.ToolCallSummary{CallCount: calls, ErrorCount: errors}
+	if calls == 0 {
+		return s
+	}
+	s.ErrorRate = float64(errors) / float64(calls)
+	s.P50LatencyMs = percentile(buckets, 0.50)
+	s.P95LatencyMs = percentile(buckets, 0.95)
+	return s
+}
+
+// percentile returns the upper bound of the latency bucket the q-th quantile
+// of the calls falls in. Calls slower than every bound count as the largest
+// bound.
+func percentile(buckets []int64, q float64) int64 {
+	var total int64
+	for _, n := range buckets {
+		total += n
+	}
+	if total == 0 {
+		return 0
+	}
+	bounds := adk.ToolLatencyBucketsMs
+	// Nearest rank: the call at least a q fraction of the calls are as fast as.
+	rank := int64(math.Ceil(q * float64(total)))
+	var seen int64
+	for i, n := range buckets {
+		seen += n
+		if seen >= rank {
+			return bounds[min(i, len(bounds)-1)]
+		}
+	}
+	return bounds[len(bounds)-1]
+}
@@ -0,0 +1,48 @@
+package toolstats
+
+import (
+	"testing"
+
+	"github.com/kagent-dev/kagent/go/api/adk"
+	"github.com/kagent-dev/kagent/go/api/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func buckets(counts map[int]int64) []int64 {
+	b := make([]int64, len(adk.ToolLatencyBucketsMs)+1)
+	for i, n := range counts {
+		b[i] = n
+	}
+	return b
+}
+
+func TestSummarize(t *testing.T) {
+	rows := []database.ToolCallStats{
+		// 90 calls of at most 10ms and 10 of at most 1s, across two agents.
+		{AgentID: "kagent__NS__a", CallCount: 60, ErrorCount: 3, LatencyBuckets: buckets(map[int]int64{0: 60})},
+		{AgentID: "kagent__NS__b", CallCount: 40, ErrorCount: 2, LatencyBuckets: buckets(map[int]int64{0: 30, 6: 10})},
+	}
+
+	s := Summarize(rows)
+	assert.Equal(t, int64(100), s.CallCount)
+	assert.Equal(t, int64(5), s.ErrorCount)
+	assert.InDelta(t, 0.05, s.ErrorRate, 1e-9)
+	assert.Equal(t, int64(10), s.P50LatencyMs)
+	assert.Equal(t, int64(1000), s.P95LatencyMs)
+
+	agents := SummarizeByAgent(rows)
+	if assert.Len(t, agents, 2) {
+		assert.Equal(t, "kagent__NS__a", agents[0].AgentID)
+		assert.Equal(t, int64(10), agents[0].P95LatencyMs)
+		assert.Equal(t, "kagent__NS__b", agents[1].AgentID)
+		assert.Equal(t, int64(1000), agents[1].P95LatencyMs)
+	}
+}
+
+func TestSummarizeSlowAndEmpty(t *testing.T) {
+	assert.Equal(t, int64(0), Summarize(nil).P95LatencyMs)
+
+	last := len(adk.ToolLatencyBucketsMs)
+	s := Summarize([]database.ToolCallStats{{CallCount: 1, LatencyBuckets: buckets(map[int]int64{last: 1})}})
+	assert.Equal(t, adk.ToolLatencyBucketsMs[last-1], s.P95LatencyMs)
+}
@@ -0,0 +1,309 @@
+// Package verification runs an Agent's smoke-test prompts after each
+// completed rollout and reports the results in the PostRolloutVerified
+// condition, optionally rolling the Agent back to its last verified spec.
+package verification
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	a2atype "github.com/a2aproject/a2a-go/v2/a2a"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	"github.com/kagent-dev/kagent/go/core/internal/a2a"
+	"github.com/kagent-dev/kagent/go/core/internal/utils"
+)
+
+const (
+	ReasonSmokeTestsPassed = "SmokeTestsPassed"
+	ReasonSmokeTestsFailed = "SmokeTestsFailed"
+	ReasonRolledBack       = "RolledBack"
+
+	defaultPromptTimeout = 2 * time.Minute
+)
+
+// MessageSender sends an A2A message to an agent. It is satisfied by
+// a2a.AgentClientRegistry.
+type MessageSender interface {
+	SendMessage(ctx context.Context, namespace, name string, req *a2atype.SendMessageRequest) (a2atype.SendMessageResult, error)
+}
+
+// Runner periodically checks Agents with spec.smokeTests for a completed
+// rollout that has not been verified yet, and verifies it.
+type Runner struct {
+	Kube     client.Client
+	Sender   MessageSender
+	Interval time.Duration
+}
+
+// NeedLeaderElection ensures only one replica runs smoke tests.
+func (r *Runner) NeedLeaderElection() bool { return true }
+
+// NewRunner returns a Runner that checks rollouts every interval; pass 0 to
+// use the default of 30 seconds.
+func NewRunner(kube client.Client, sender MessageSender, interval time.Duration) *Runner {
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+	return &Runner{Kube: kube, Sender: sender, Interval: interval}
+}
+
+// Start runs the verification loop until ctx is cancelled.
+func (r *Runner) Start(ctx context.Context) error {
+	log := ctrllog.FromContext(ctx).WithName("post-rollout-verification")
+	log.Info("Starting post-rollout verification loop", "interval", r.Interval)
+	ticker := time.NewTicker(r.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			r.runOnce(ctx)
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (r *Runner) runOnce(ctx context.Context) {
+	log := ctrllog.FromContext(ctx).WithName("post-rollout-verification")
+
+	agents := &v1alpha2.AgentList{}
+	if err := r.Kube.List(ctx, agents); err != nil {
+		log.Error(err, "Failed to list agents")
+		return
+	}
+	for i := range agents.Items {
+		agent := &agents.Items[i]
+		if agent.Spec.SmokeTests == nil || len(agent.Spec.SmokeTests.Prompts) == 0 {
+			continue
+		}
+		if err := r.verify(ctx, agent); err != nil {
+			log.Error(err, "Failed to verify agent rollout", "agent", utils.GetObjectRef(agent))
+		}
+	}
+}
+
+// verify runs the agent's smoke tests if its Deployment has finished rolling
+// out a generation that has not been verified yet.
+func (r *Runner) verify(ctx context.Context, agent *v1alpha2.Agent) error {
+	log := ctrllog.FromContext(ctx).WithName("post-rollout-verification").WithValues("agent", utils.GetObjectRef(agent))
+
+	// Wait for the reconciler to apply the current spec to the Deployment.
+	if agent.Status.ObservedGeneration != agent.Generation {
+		return nil
+	}
+	deployment := &appsv1.Deployment{}
+	if err := r.Kube.Get(ctx, types.NamespacedName{Namespace: agent.Namespace, Name: agent.Name}, deployment); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get deployment: %w", err)
+	}
+	if !RolloutComplete(deployment) {
+		return nil
+	}
+	if st := agent.Status.SmokeTests; st != nil && st.DeploymentGeneration == deployment.Generation {
+		return nil
+	}
+
+	log.Info("Running smoke tests", "deploymentGeneration", deployment.Generation)
+	results := r.runPrompts(ctx, agent)
+	var failed []string
+	for _, res := range results {
+		if !res.Passed {
+			failed = append(failed, res.Name)
+		}
+	}
+
+	specJSON, err := json.Marshal(agent.Spec)
+	if err != nil {
+		return fmt.Errorf("failed to marshal agent spec: %w", err)
+	}
+	lastVerified := agent.Annotations[v1alpha2.AgentLastVerifiedSpecAnnotation]
+
+	condition := metav1.Condition{
+		Type:               v1alpha2.AgentConditionTypePostRolloutVerified,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonSmokeTestsPassed,
+		Message:            fmt.Sprintf("%d/%d smoke tests passed", len(results), len(results)),
+		ObservedGeneration: agent.Generation,
+	}
+	rollback := false
+	if len(failed) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = ReasonSmokeTestsFailed
+		condition.Message = fmt.Sprintf("%d/%d smoke tests failed: %s", len(failed), len(results), strings.Join(failed, ", "))
+		if agent.Spec.SmokeTests.RollbackOnFailure && lastVerified != "" && lastVerified != string(specJSON) {
+			rollback = true
+			condition.Reason = ReasonRolledBack
+			condition.Message += "; rolled back to the last verified spec"
+		}
+	}
+
+	if err := r.updateStatus(ctx, agent, deployment.Generation, results, condition); err != nil {
+		return err
+	}
+
+	switch {
+	case len(failed) == 0 && lastVerified != string(specJSON):
+		patch := client.MergeFrom(agent.DeepCopy())
+		if agent.Annotations == nil {
+			agent.Annotations = map[string]string{}
+		}
+		agent.Annotations[v1alpha2.AgentLastVerifiedSpecAnnotation] = string(specJSON)
+		if err := r.Kube.Patch(ctx, agent, patch); err != nil {
+			return fmt.Errorf("failed to record last verified spec: %w", err)
+		}
+	case rollback:
+		if err := r.rollback(ctx, agent, lastVerified); err != nil {
+			return err
+		}
+		log.Info("Rolled back agent to last verified spec", "failed", failed)
+	}
+	return nil
+}
+
+func (r *Runner) updateStatus(ctx context.Context, agent *v1alpha2.Agent, deploymentGeneration int64, results []v1alpha2.SmokeTestResult, condition metav1.Condition) error {
+	now := metav1.Now()
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest := &v1alpha2.Agent{}
+		if err := r.Kube.Get(ctx, client.ObjectKeyFromObject(agent), latest); err != nil {
+			return err
+		}
+		latest.Status.SmokeTests = &v1alpha2.SmokeTestStatus{
+			DeploymentGeneration: deploymentGeneration,
+			LastRunTime:          &now,
+			Results:              results,
+		}
+		meta.SetStatusCondition(&latest.Status.Conditions, condition)
+		return r.Kube.Status().Update(ctx, latest)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update agent status: %w", err)
+	}
+	return nil
+}
+
+func (r *Runner) rollback(ctx context.Context, agent *v1alpha2.Agent, lastVerified string) error {
+	var spec v1alpha2.AgentSpec
+	if err := json.Unmarshal([]byte(lastVerified), &spec); err != nil {
+		return fmt.Errorf("failed to parse last verified spec: %w", err)
+	}
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest := &v1alpha2.Agent{}
+		if err := r.Kube.Get(ctx, client.ObjectKeyFromObject(agent), latest); err != nil {
+			return err
+		}
+		// Leave the agent alone if it changed while the smoke tests ran.
+		if latest.Generation != agent.Generation {
+			return nil
+		}
+		latest.Spec = spec
+		return r.Kube.Update(ctx, latest)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to roll back agent: %w", err)
+	}
+	return nil
+}
+
+// runPrompts sends each prompt in a new session and checks the reply.
+func (r *Runner) runPrompts(ctx context.Context, agent *v1alpha2.Agent) []v1alpha2.SmokeTestResult {
+	timeout := defaultPromptTimeout
+	if t := agent.Spec.SmokeTests.Timeout; t != nil && t.Duration > 0 {
+		timeout = t.Duration
+	}
+	results := make([]v1alpha2.SmokeTestResult, 0, len(agent.Spec.SmokeTests.Prompts))
+	for _, p := range agent.Spec.SmokeTests.Prompts {
+		res := v1alpha2.SmokeTestResult{Name: p.Name}
+		reply, err := r.send(ctx, agent, p.Prompt, timeout)
+		if err != nil {
+			res.Message = err.Error()
+		} else if msg := checkReply(p.Expect, reply); msg != "" {
+			res.Message = msg
+		} else {
+			res.Passed = true
+		}
+		results = append(results, res)
+	}
+	return results
+}
+
+func (r *Runner) send(ctx context.Context, agent *v1alpha2.Agent, prompt string, timeout time.Duration) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	message := a2atype.NewMessage(a2atype.MessageRoleUser, a2atype.NewTextPart(prompt))
+	result, err := r.Sender.SendMessage(ctx, agent.Namespace, agent.Name, &a2atype.SendMessageRequest{Message: message})
+	if err != nil {
+		return "", fmt.Errorf("failed to send prompt: %w", err)
+	}
+	switch res := result.(type) {
+	case *a2atype.Message:
+		return a2a.ExtractText(res), nil
+	case *a2atype.Task:
+		if res.Status.State == a2atype.TaskStateFailed {
+			return "", fmt.Errorf("task failed: %s", a2a.ExtractText(res.Status.Message))
+		}
+		text := a2a.ExtractText(res.Status.Message)
+		for _, artifact := range res.Artifacts {
+			text += a2a.ExtractText(&a2atype.Message{Parts: artifact.Parts})
+		}
+		return text, nil
+	default:
+		return "", fmt.Errorf("unexpected A2A result %T", result)
+	}
+}
+
+// checkReply returns a description of the first failed assertion, or "" when
+// the reply satisfies them all.
+func checkReply(expect v1alpha2.SmokeTestExpectation, reply string) string {
+	lower := strings.ToLower(reply)
+	for _, s := range expect.Contains {
+		if !strings.Contains(lower, strings.ToLower(s)) {
+			return fmt.Sprintf("reply does not contain %q", s)
+		}
+	}
+	for _, s := range expect.NotContains {
+		if strings.Contains(lower, strings.ToLower(s)) {
+			return fmt.Sprintf("reply contains %q", s)
+		}
+	}
+	if expect.Matches != "" {
+		re, err := regexp.Compile(expect.Matches)
+		if err != nil {
+			return fmt.Sprintf("invalid matches expression: %v", err)
+		}
+		if !re.MatchString(reply) {
+			return fmt.Sprintf("reply does not match %q", expect.Matches)
+		}
+	}
+	return ""
+}
+
+// RolloutComplete reports whether every replica of the Deployment runs the
+// current pod template and is available. Deployments scaled to zero have
+// nothing to verify.
+func RolloutComplete(d *appsv1.Deployment) bool {
+	replicas := int32(1)
+	if d.Spec.Replicas != nil {
+		replicas = *d.Spec.Replicas
+	}
+	return replicas > 0 &&
+		d.Status.ObservedGeneration >= d.Generation &&
+		d.Status.UpdatedReplicas == replicas &&
+		d.Status.AvailableReplicas == replicas &&
+		d.Status.Replicas == replicas
+}
@@ -0,0 +1,173 @@
+package verification
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	a2atype "github.com/a2aproject/a2a-go/v2/a2a"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+)
+
+type fakeSender struct {
+	replies map[string]string
+	calls   int
+}
+
+func (f *fakeSender) SendMessage(_ context.Context, _, _ string, req *a2atype.SendMessageRequest) (a2atype.SendMessageResult, error) {
+	f.calls++
+	reply, ok := f.replies[req.Message.Parts[0].Text()]
+	if !ok {
+		return nil, errors.New("connection refused")
+	}
+	return a2atype.NewMessage(a2atype.MessageRoleAgent, a2atype.NewTextPart(reply)), nil
+}
+
+func newScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha2.AddToScheme(scheme))
+	require.NoError(t, appsv1.AddToScheme(scheme))
+	return scheme
+}
+
+func testAgent(description string) *v1alpha2.Agent {
+	return &v1alpha2.Agent{
+		ObjectMeta: metav1.ObjectMeta{Name: "k8s-agent", Namespace: "kagent", Generation: 2},
+		Spec: v1alpha2.AgentSpec{
+			Type:        v1alpha2.AgentType_Declarative,
+			Description: description,
+			Declarative: &v1alpha2.DeclarativeAgentSpec{SystemMessage: "You are helpful."},
+			SmokeTests: &v1alpha2.SmokeTestSpec{
+				RollbackOnFailure: true,
+				Prompts: []v1alpha2.SmokeTestPrompt{{
+					Name:   "lists-pods",
+					Prompt: "list pods",
+					Expect: v1alpha2.SmokeTestExpectation{Contains: []string{"nginx"}},
+				}},
+			},
+		},
+		Status: v1alpha2.AgentStatus{ObservedGeneration: 2},
+	}
+}
+
+func testDeployment(updated int32) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "k8s-agent", Namespace: "kagent", Generation: 3},
+		Status: appsv1.DeploymentStatus{
+			ObservedGeneration: 3,
+			Replicas:           1,
+			UpdatedReplicas:    updated,
+			AvailableReplicas:  1,
+		},
+	}
+}
+
+func TestRunOnce(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("passing rollout records results and last verified spec", func(t *testing.T) {
+		agent := testAgent("v2")
+		sender := &fakeSender{replies: map[string]string{"list pods": "Found pod NGINX-1"}}
+		kube := fake.NewClientBuilder().WithScheme(newScheme(t)).
+			WithObjects(agent, testDeployment(1)).WithStatusSubresource(agent).Build()
+		r := NewRunner(kube, sender, 0)
+
+		r.runOnce(ctx)
+
+		got := &v1alpha2.Agent{}
+		require.NoError(t, kube.Get(ctx, client.ObjectKeyFromObject(agent), got))
+		cond := meta.FindStatusCondition(got.Status.Conditions, v1alpha2.AgentConditionTypePostRolloutVerified)
+		require.NotNil(t, cond)
+		assert.Equal(t, metav1.ConditionTrue, cond.Status)
+		assert.Equal(t, ReasonSmokeTestsPassed, cond.Reason)
+		require.NotNil(t, got.Status.SmokeTests)
+		assert.Equal(t, int64(3), got.Status.SmokeTests.DeploymentGeneration)
+		assert.Equal(t, []v1alpha2.SmokeTestResult{{Name: "lists-pods", Passed: true}}, got.Status.SmokeTests.Results)
+		specJSON, _ := json.Marshal(agent.Spec)
+		assert.Equal(t, string(specJSON), got.Annotations[v1alpha2.AgentLastVerifiedSpecAnnotation])
+
+		// The same Deployment generation is not verified twice.
+		r.runOnce(ctx)
+		assert.Equal(t, 1, sender.calls)
+	})
+
+	t.Run("failing rollout rolls back to last verified spec", func(t *testing.T) {
+		verified, _ := json.Marshal(testAgent("v1").Spec)
+		agent := testAgent("v2")
+		agent.Annotations = map[string]string{v1alpha2.AgentLastVerifiedSpecAnnotation: string(verified)}
+		sender := &fakeSender{replies: map[string]string{"list pods": "I cannot reach the cluster"}}
+		kube := fake.NewClientBuilder().WithScheme(newScheme(t)).
+			WithObjects(agent, testDeployment(1)).WithStatusSubresource(agent).Build()
+
+		NewRunner(kube, sender, 0).runOnce(ctx)
+
+		got := &v1alpha2.Agent{}
+		require.NoError(t, kube.Get(ctx, client.ObjectKeyFromObject(agent), got))
+		cond := meta.FindStatusCondition(got.Status.Conditions, v1alpha2.AgentConditionTypePostRolloutVerified)
+		require.NotNil(t, cond)
+		assert.Equal(t, metav1.ConditionFalse, cond.Status)
+		assert.Equal(t, ReasonRolledBack, cond.Reason)
+		assert.Equal(t, "v1", got.Spec.Description)
+		assert.Equal(t, `reply does not contain "nginx"`, got.Status.SmokeTests.Results[0].Message)
+	})
+
+	t.Run("failing rollout without verified spec is not rolled back", func(t *testing.T) {
+		agent := testAgent("v2")
+		sender := &fakeSender{}
+		kube := fake.NewClientBuilder().WithScheme(newScheme(t)).
+			WithObjects(agent, testDeployment(1)).WithStatusSubresource(agent).Build()
+
+		NewRunner(kube, sender, 0).runOnce(ctx)
+
+		got := &v1alpha2.Agent{}
+		require.NoError(t, kube.Get(ctx, client.ObjectKeyFromObject(agent), got))
+		cond := meta.FindStatusCondition(got.Status.Conditions, v1alpha2.AgentConditionTypePostRolloutVerified)
+		require.NotNil(t, cond)
+		assert.Equal(t, ReasonSmokeTestsFailed, cond.Reason)
+		assert.Equal(t, "v2", got.Spec.Description)
+		assert.Empty(t, got.Annotations[v1alpha2.AgentLastVerifiedSpecAnnotation])
+	})
+
+	t.Run("rollout in progress is not verified", func(t *testing.T) {
+		agent := testAgent("v2")
+		sender := &fakeSender{}
+		kube := fake.NewClientBuilder().WithScheme(newScheme(t)).
+			WithObjects(agent, testDeployment(0)).WithStatusSubresource(agent).Build()
+
+		NewRunner(kube, sender, 0).runOnce(ctx)
+
+		assert.Equal(t, 0, sender.calls)
+	})
+}
+
+func TestCheckReply(t *testing.T) {
+	tests := []struct {
+		name   string
+		expect v1alpha2.SmokeTestExpectation
+		reply  string
+		want   string
+	}{
+		{name: "no assertions", reply: "anything"},
+		{name: "contains is case-insensitive", expect: v1alpha2.SmokeTestExpectation{Contains: []string{"Running"}}, reply: "pod is running"},
+		{name: "missing substring", expect: v1alpha2.SmokeTestExpectation{Contains: []string{"running"}}, reply: "pod is pending", want: `reply does not contain "running"`},
+		{name: "forbidden substring", expect: v1alpha2.SmokeTestExpectation{NotContains: []string{"error"}}, reply: "Error: forbidden", want: `reply contains "error"`},
+		{name: "matches", expect: v1alpha2.SmokeTestExpectation{Matches: `\d+ pods`}, reply: "found 3 pods"},
+		{name: "no match", expect: v1alpha2.SmokeTestExpectation{Matches: `^\d+ pods$`}, reply: "found 3 pods", want: `reply does not match "^\\d+ pods$"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, checkReply(tt.expect, tt.reply))
+		})
+	}
+}
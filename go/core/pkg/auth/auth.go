@@ -13,6 +13,8 @@ const (
 	VerbCreate Verb = "create"
 	VerbUpdate Verb = "update"
 	VerbDelete Verb = "delete"
+	// VerbInvoke sends messages to an agent within an existing session.
+	VerbInvoke Verb = "invoke"
 )
 
 type Resource struct {
@@ -35,6 +37,22 @@ type Principal struct {
 	Claims map[string]any // Raw JWT claims (nil for non-JWT auth)
 }
 
+// Groups returns the groups in the principal's "groups" claim.
+func (p Principal) Groups() []string {
+	var groups []string
+	switch v := p.Claims["groups"].(type) {
+	case []string:
+		groups = v
+	case []any:
+		for _, g := range v {
+			if s, ok := g.(string); ok {
+				groups = append(groups, s)
+			}
+		}
+	}
+	return groups
+}
+
 type Session interface {
 	Principal() Principal
 }
@@ -25,3 +25,13 @@ func TestPrincipalHasRequiredFields(t *testing.T) {
 		t.Errorf("expected Claims[name] 'Test User', got '%v'", p.Claims["name"])
 	}
 }
+
+func TestPrincipalGroups(t *testing.T) {
+	p := Principal{Claims: map[string]any{"groups": []any{"sre", 42, "dev"}}}
+	if got := p.Groups(); len(got) != 2 || got[0] != "sre" || got[1] != "dev" {
+		t.Errorf("Groups() = %v, want [sre dev]", got)
+	}
+	if got := (Principal{}).Groups(); got != nil {
+		t.Errorf("Groups() without claims = %v, want nil", got)
+	}
+}
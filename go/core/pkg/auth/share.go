@@ -2,9 +2,10 @@ package auth
 
 import "context"
 
-// ShareContext holds the context derived from a validated X-Share-Token header.
+// ShareContext holds the context derived from a validated X-Share-Token header
+// or from a session grant.
 type ShareContext struct {
-	Token     string // the raw share token
+	Token     string // the raw share token; empty for a session grant
 	SessionID string // session this token grants access to
 	UserID    string // owner's user ID — used for DB lookups
 	ReadOnly  bool   // when true, only read operations are allowed
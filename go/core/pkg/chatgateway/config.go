@@ -0,0 +1,156 @@
+// Package chatgateway connects Slack and Microsoft Teams to kagent agents.
+//
+// Mentions of the gateway's bot, and direct messages to it, are sent to an
+// agent over A2A. Every channel thread (or direct-message conversation) maps
+// to one agent session, so follow-up mentions in a thread continue the same
+// conversation. The agent's reply is posted to the thread and edited as it
+// streams in, together with a summary of the tools the agent called.
+//
+// The gateway holds no state: session IDs are derived from the thread, so
+// threads keep their session across restarts.
+package chatgateway
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// Config holds the gateway's runtime configuration.
+type Config struct {
+	// ListenAddr is the address the webhook server binds to, e.g. ":8080".
+	ListenAddr string
+	// KagentURL is the base URL of the kagent controller API, e.g.
+	// "http://kagent-controller.kagent.svc:8083".
+	KagentURL string
+	// APIToken is sent as a bearer token to the controller, for controllers
+	// that do not run in the unsecure auth mode.
+	APIToken string
+	// Agent is the agent, as namespace/name, that answers in channels
+	// without an entry in ChannelAgents.
+	Agent string
+	// ChannelAgents maps Slack channel IDs and Teams conversation IDs to the
+	// agent, as namespace/name, that answers in them.
+	ChannelAgents map[string]string
+	// ReplyTimeout bounds each agent reply.
+	ReplyTimeout time.Duration
+	// UpdateInterval is how often a streaming reply is edited.
+	UpdateInterval time.Duration
+
+	Slack SlackConfig
+	Teams TeamsConfig
+}
+
+// SlackConfig configures the Slack Events API endpoint. Slack is enabled
+// when BotToken is set.
+type SlackConfig struct {
+	// BotToken is the bot user OAuth token (xoxb-...) used to post replies.
+	BotToken string
+	// SigningSecret verifies that requests come from Slack.
+	SigningSecret string
+	// APIURL is the Slack Web API base URL. Defaults to
+	// https://slack.com/api.
+	APIURL string
+}
+
+// TeamsConfig configures the Bot Framework messaging endpoint. Teams is
+// enabled when AppID is set.
+type TeamsConfig struct {
+	// AppID is the Microsoft App ID of the bot registration.
+	AppID string
+	// AppPassword is the client secret of the bot registration.
+	AppPassword string
+	// TenantID is the tenant of single-tenant bot registrations. Multi-tenant
+	// bots leave it empty.
+	TenantID string
+	// OpenIDMetadataURL and TokenURL override the Bot Framework endpoints,
+	// for tests.
+	OpenIDMetadataURL string
+	TokenURL          string
+}
+
+// LoadConfig applies the environment-variable fallbacks to c, so the
+// credentials can be read from Secrets without appearing in the pod's
+// arguments. Call before Validate.
+func LoadConfig(c *Config) {
+	fallback := func(v *string, env string) {
+		if *v == "" {
+			*v = os.Getenv(env)
+		}
+	}
+	fallback(&c.APIToken, "KAGENT_API_TOKEN")
+	fallback(&c.Slack.BotToken, "SLACK_BOT_TOKEN")
+	fallback(&c.Slack.SigningSecret, "SLACK_SIGNING_SECRET")
+	fallback(&c.Teams.AppID, "TEAMS_APP_ID")
+	fallback(&c.Teams.AppPassword, "TEAMS_APP_PASSWORD")
+	fallback(&c.Teams.TenantID, "TEAMS_TENANT_ID")
+}
+
+// Validate checks the config and applies defaults.
+func (c *Config) Validate() error {
+	if c.ListenAddr == "" {
+		return fmt.Errorf("listen address is required")
+	}
+	if c.KagentURL == "" {
+		return fmt.Errorf("kagent URL is required")
+	}
+	if c.Slack.BotToken == "" && c.Teams.AppID == "" {
+		return fmt.Errorf("at least one of Slack or Teams must be configured")
+	}
+	if c.Slack.BotToken != "" && c.Slack.SigningSecret == "" {
+		return fmt.Errorf("the Slack signing secret is required")
+	}
+	if c.Teams.AppID != "" && c.Teams.AppPassword == "" {
+		return fmt.Errorf("the Teams app password is required")
+	}
+	if c.Agent != "" {
+		if err := validateAgentRef(c.Agent); err != nil {
+			return err
+		}
+	}
+	for channel, agent := range c.ChannelAgents {
+		if err := validateAgentRef(agent); err != nil {
+			return fmt.Errorf("channel %s: %w", channel, err)
+		}
+	}
+	if c.Agent == "" && len(c.ChannelAgents) == 0 {
+		return fmt.Errorf("an agent is required")
+	}
+	if c.ReplyTimeout <= 0 {
+		c.ReplyTimeout = 10 * time.Minute
+	}
+	if c.UpdateInterval <= 0 {
+		c.UpdateInterval = time.Second
+	}
+	if c.Slack.APIURL == "" {
+		c.Slack.APIURL = "https://slack.com/api"
+	}
+	if c.Teams.OpenIDMetadataURL == "" {
+		c.Teams.OpenIDMetadataURL = "https://login.botframework.com/v1/.well-known/openidconfiguration"
+	}
+	if c.Teams.TokenURL == "" {
+		tenant := c.Teams.TenantID
+		if tenant == "" {
+			tenant = "botframework.com"
+		}
+		c.Teams.TokenURL = fmt.Sprintf("https://login.microsoftonline.com/%s/oauth2/v2.0/token", tenant)
+	}
+	return nil
+}
+
+// agentFor returns the agent that answers in channel, or "" if none does.
+func (c *Config) agentFor(channel string) string {
+	if agent, ok := c.ChannelAgents[channel]; ok {
+		return agent
+	}
+	return c.Agent
+}
+
+func validateAgentRef(ref string) error {
+	namespace, name, ok := strings.Cut(ref, "/")
+	if !ok || namespace == "" || name == "" {
+		return fmt.Errorf("invalid agent %q: expected namespace/name", ref)
+	}
+	return nil
+}
@@ -0,0 +1,346 @@
+package chatgateway
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
+
+	"github.com/kagent-dev/kagent/go/api/client"
+	"github.com/kagent-dev/kagent/go/api/utils"
+)
+
+const (
+	// maxReplyChars keeps replies below the message size limits of Slack
+	// and Teams.
+	maxReplyChars = 3900
+	workingText   = "Working on it..."
+)
+
+// sessionNamespace derives session IDs from conversations.
+var sessionNamespace = uuid.MustParse("5f0b7c1e-2f7a-4c1d-9a57-6a3f3c1b8e42")
+
+// Conversation is a channel thread or direct-message conversation. Each
+// maps to one agent session.
+type Conversation struct {
+	// Platform is "slack" or "teams".
+	Platform string
+	// Channel is the Slack channel or Teams conversation the message was
+	// posted in. It selects the agent, and its members share the sessions
+	// of its threads.
+	Channel string
+	// Thread identifies the thread within the channel, if any.
+	Thread string
+}
+
+// SessionID is the A2A context ID of the conversation's session.
+func (c Conversation) SessionID() string {
+	return uuid.NewSHA1(sessionNamespace, []byte(c.Platform+"/"+c.Channel+"/"+c.Thread)).String()
+}
+
+// UserID is the kagent user that owns the conversation's session. It is the
+// channel rather than the person, so everyone in a thread continues the
+// same session.
+func (c Conversation) UserID() string {
+	return c.Platform + ":" + c.Channel
+}
+
+// Replier posts a reply to a conversation and edits it.
+type Replier interface {
+	// Post posts text and returns the ID of the new message.
+	Post(ctx context.Context, text string) (string, error)
+	// Update replaces the text of a posted message.
+	Update(ctx context.Context, id, text string) error
+}
+
+// StreamFunc sends text to an agent, given as namespace/name, in a session
+// and returns the agent's events.
+type StreamFunc func(ctx context.Context, agent, sessionID, userID, text string) (<-chan client.A2AEvent, error)
+
+// Gateway relays chat messages to agents and their replies back.
+type Gateway struct {
+	cfg    *Config
+	stream StreamFunc
+
+	mu sync.Mutex
+	// conversations serializes the messages of each conversation, so a
+	// follow-up waits for the previous reply to finish.
+	conversations map[Conversation]*sync.Mutex
+	// wg tracks replies in progress, for Shutdown and tests.
+	wg sync.WaitGroup
+}
+
+// NewGateway creates a Gateway that reaches agents through the kagent
+// controller's A2A API.
+func NewGateway(cfg *Config) *Gateway {
+	return &Gateway{cfg: cfg, stream: a2aStream(cfg), conversations: map[Conversation]*sync.Mutex{}}
+}
+
+func a2aStream(cfg *Config) StreamFunc {
+	return func(ctx context.Context, agent, sessionID, userID, text string) (<-chan client.A2AEvent, error) {
+		namespace, name, _ := strings.Cut(agent, "/")
+		opts := []client.A2AOption{client.WithA2ASessionID(sessionID), client.WithA2AUserID(userID)}
+		if cfg.APIToken != "" {
+			opts = append(opts, client.WithA2ABearerToken(cfg.APIToken))
+		}
+		a2a, err := client.NewA2AClient(client.AgentA2AURL(cfg.KagentURL, namespace, name, nil), opts...)
+		if err != nil {
+			return nil, err
+		}
+		return a2a.Stream(ctx, text)
+	}
+}
+
+// Dispatch replies to text in the background. Slack and Teams expect their
+// webhooks to be acknowledged within seconds, long before agents reply.
+func (g *Gateway) Dispatch(conv Conversation, text string, reply Replier) {
+	g.wg.Go(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), g.cfg.ReplyTimeout)
+		defer cancel()
+		if err := g.Handle(ctx, conv, text, reply); err != nil {
+			log.Printf("chat-gateway: %s reply in %s failed: %v", conv.Platform, conv.Channel, err)
+		}
+	})
+}
+
+// Handle sends text to the conversation's agent and streams the reply into a
+// message posted with reply.
+func (g *Gateway) Handle(ctx context.Context, conv Conversation, text string, reply Replier) error {
+	agent := g.cfg.agentFor(conv.Channel)
+	if agent == "" {
+		return nil
+	}
+	unlock := g.lock(conv)
+	defer unlock()
+
+	id, err := reply.Post(ctx, workingText)
+	if err != nil {
+		return fmt.Errorf("failed to post reply: %w", err)
+	}
+	events, err := g.stream(ctx, agent, conv.SessionID(), conv.UserID(), text)
+	if err != nil {
+		return reply.Update(ctx, id, fmt.Sprintf("Could not reach agent %s: %v", agent, err))
+	}
+
+	r := &replyRenderer{}
+	shown := workingText
+	lastUpdate := time.Now()
+	for event := range events {
+		r.add(event)
+		if time.Since(lastUpdate) < g.cfg.UpdateInterval {
+			continue
+		}
+		if text := r.render(false); text != shown {
+			if err := reply.Update(ctx, id, text); err != nil {
+				log.Printf("chat-gateway: failed to update reply: %v", err)
+			}
+			shown, lastUpdate = text, time.Now()
+		}
+	}
+	if ctx.Err() != nil {
+		r.failure = "The agent did not reply in time."
+	}
+	return reply.Update(ctx, id, r.render(true))
+}
+
+// Shutdown waits for the replies in progress to finish or ctx to end.
+func (g *Gateway) Shutdown(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		g.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (g *Gateway) lock(conv Conversation) func() {
+	g.mu.Lock()
+	mu, ok := g.conversations[conv]
+	if !ok {
+		mu = &sync.Mutex{}
+		g.conversations[conv] = mu
+	}
+	g.mu.Unlock()
+	mu.Lock()
+	return func() {
+		mu.Unlock()
+		g.mu.Lock()
+		if mu.TryLock() {
+			// Nobody is waiting; forget the conversation.
+			delete(g.conversations, conv)
+			mu.Unlock()
+		}
+		g.mu.Unlock()
+	}
+}
+
+// replyRenderer accumulates an agent's stream into the text of a chat
+// message.
+type replyRenderer struct {
+	// turns holds the text of each completed model turn.
+	turns []string
+	// partial is the text streamed so far of the current turn.
+	partial string
+	// artifact is the agent's final answer, once it arrives.
+	artifact string
+	// tools lists the tools called, in order, and running the ones that
+	// have not returned yet.
+	tools   []string
+	calls   map[string]int
+	running map[string]string
+	// failure explains why the task did not complete.
+	failure string
+}
+
+func (r *replyRenderer) add(event client.A2AEvent) {
+	switch {
+	case event.Message != nil:
+		r.addMessage(event.Message, isPartial(event.Message.Metadata))
+	case event.Task != nil:
+		r.addState(event.Task.Status)
+	case event.StatusUpdate != nil:
+		// A failed task's message is its error, which addState reports.
+		if msg := event.StatusUpdate.Status.Message; msg != nil && event.StatusUpdate.Status.State != protocol.TaskStateFailed {
+			r.addMessage(msg, isPartial(msg.Metadata) || isPartial(event.StatusUpdate.Metadata))
+		}
+		r.addState(event.StatusUpdate.Status)
+	case event.ArtifactUpdate != nil:
+		text := partsText(event.ArtifactUpdate.Artifact.Parts)
+		if event.ArtifactUpdate.Append != nil && *event.ArtifactUpdate.Append {
+			r.artifact += text
+		} else {
+			r.artifact = text
+		}
+	}
+}
+
+func (r *replyRenderer) addState(status protocol.TaskStatus) {
+	switch status.State {
+	case protocol.TaskStateFailed:
+		r.failure = "The agent failed."
+		if status.Message != nil {
+			if text := partsText(status.Message.Parts); text != "" {
+				r.failure = "The agent failed: " + text
+			}
+		}
+	case protocol.TaskStateInputRequired:
+		r.failure = "The agent is waiting for a tool approval, which chat does not support yet. Continue the session in the kagent UI."
+	}
+}
+
+func (r *replyRenderer) addMessage(msg *protocol.Message, partial bool) {
+	if msg.Role != protocol.MessageRoleAgent {
+		return
+	}
+	for _, part := range msg.Parts {
+		dp, ok := part.(*protocol.DataPart)
+		if !ok {
+			continue
+		}
+		kind, _ := utils.GetMetadataValue(dp.Metadata, "type")
+		data, _ := dp.Data.(map[string]any)
+		name, _ := data["name"].(string)
+		id, _ := data["id"].(string)
+		switch kind {
+		case "function_call":
+			if r.calls == nil {
+				r.calls, r.running = map[string]int{}, map[string]string{}
+			}
+			if r.calls[name] == 0 {
+				r.tools = append(r.tools, name)
+			}
+			r.calls[name]++
+			r.running[id] = name
+		case "function_response":
+			delete(r.running, id)
+		}
+	}
+
+	text := partsText(msg.Parts)
+	if partial {
+		r.partial += text
+		return
+	}
+	r.partial = ""
+	if strings.TrimSpace(text) != "" {
+		r.turns = append(r.turns, text)
+	}
+}
+
+// render returns the message text. Until done it shows the tools in
+// progress; once done it summarizes the tools called.
+func (r *replyRenderer) render(done bool) string {
+	body := r.artifact
+	if body == "" {
+		body = strings.Join(r.turns, "\n\n")
+		if r.partial != "" {
+			body = strings.TrimSpace(body + "\n\n" + r.partial)
+		}
+	}
+	if r.failure != "" {
+		body = strings.TrimSpace(body + "\n\n" + r.failure)
+	}
+
+	var footer string
+	switch {
+	case !done && len(r.running) > 0:
+		running := make([]string, 0, len(r.running))
+		for _, name := range r.running {
+			running = append(running, "`"+name+"`")
+		}
+		slices.Sort(running)
+		running = slices.Compact(running)
+		footer = "_Running " + strings.Join(running, ", ") + "..._"
+	case len(r.tools) > 0:
+		used := make([]string, len(r.tools))
+		for i, name := range r.tools {
+			used[i] = "`" + name + "`"
+			if n := r.calls[name]; n > 1 {
+				used[i] += fmt.Sprintf(" x%d", n)
+			}
+		}
+		footer = "_Tools used: " + strings.Join(used, ", ") + "_"
+	}
+
+	switch {
+	case body == "" && !done:
+		body = workingText
+	case body == "":
+		body = "The agent finished without a reply."
+	}
+	if len(body)+len(footer) > maxReplyChars {
+		body = strings.ToValidUTF8(body[:max(0, maxReplyChars-len(footer)-4)], "") + "..."
+	}
+	if footer == "" {
+		return body
+	}
+	return body + "\n\n" + footer
+}
+
+// isPartial reports whether the metadata marks a streamed chunk of a model
+// turn, which the complete turn follows.
+func isPartial(metadata map[string]any) bool {
+	partial, _ := utils.GetMetadataValue(metadata, "adk_partial")
+	return partial == true
+}
+
+func partsText(parts []protocol.Part) string {
+	var b strings.Builder
+	for _, part := range parts {
+		if tp, ok := part.(*protocol.TextPart); ok {
+			b.WriteString(tp.Text)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,192 @@
+package chatgateway
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
+
+	"github.com/kagent-dev/kagent/go/api/client"
+)
+
+// fakeReplier records the messages a Replier posts and their edits.
+type fakeReplier struct {
+	mu       sync.Mutex
+	messages map[string]string
+	updates  int
+}
+
+func (f *fakeReplier) Post(_ context.Context, text string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.messages == nil {
+		f.messages = map[string]string{}
+	}
+	id := "m1"
+	f.messages[id] = text
+	return id, nil
+}
+
+func (f *fakeReplier) Update(_ context.Context, id, text string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.messages[id] = text
+	f.updates++
+	return nil
+}
+
+func (f *fakeReplier) text() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.messages["m1"]
+}
+
+func agentStatus(state protocol.TaskState, parts ...protocol.Part) client.A2AEvent {
+	msg := protocol.NewMessage(protocol.MessageRoleAgent, parts)
+	return client.A2AEvent{StatusUpdate: &protocol.TaskStatusUpdateEvent{
+		Status: protocol.TaskStatus{State: state, Message: &msg},
+	}}
+}
+
+func partialText(text string) client.A2AEvent {
+	ev := agentStatus(protocol.TaskStateWorking, &protocol.TextPart{Kind: protocol.KindText, Text: text})
+	ev.StatusUpdate.Metadata = map[string]any{"kagent_adk_partial": true}
+	return ev
+}
+
+func toolPart(kind, name, id string) protocol.Part {
+	return &protocol.DataPart{
+		Kind:     protocol.KindData,
+		Data:     map[string]any{"name": name, "id": id},
+		Metadata: map[string]any{"kagent_type": kind},
+	}
+}
+
+func textPart(text string) protocol.Part {
+	return &protocol.TextPart{Kind: protocol.KindText, Text: text}
+}
+
+func streamOf(events ...client.A2AEvent) <-chan client.A2AEvent {
+	ch := make(chan client.A2AEvent, len(events))
+	for _, ev := range events {
+		ch <- ev
+	}
+	close(ch)
+	return ch
+}
+
+func TestConversationSession(t *testing.T) {
+	thread := Conversation{Platform: "slack", Channel: "C1", Thread: "1700000000.000100"}
+	if thread.SessionID() != thread.SessionID() {
+		t.Fatal("SessionID() is not stable")
+	}
+	other := thread
+	other.Thread = "1700000000.000200"
+	if thread.SessionID() == other.SessionID() {
+		t.Fatal("threads share a session")
+	}
+	if got := thread.UserID(); got != "slack:C1" {
+		t.Fatalf("UserID() = %q, want slack:C1", got)
+	}
+}
+
+func TestReplyRenderer(t *testing.T) {
+	tests := []struct {
+		name   string
+		events []client.A2AEvent
+		done   bool
+		want   string
+	}{
+		{
+			name:   "streams partial text",
+			events: []client.A2AEvent{partialText("The pod "), partialText("is OOMKilled")},
+			want:   "The pod is OOMKilled",
+		},
+		{
+			name: "shows running tools",
+			events: []client.A2AEvent{
+				agentStatus(protocol.TaskStateWorking, toolPart("function_call", "k8s_get_pods", "1"), toolPart("function_call", "k8s_get_events", "2")),
+				agentStatus(protocol.TaskStateWorking, toolPart("function_response", "k8s_get_pods", "1")),
+			},
+			want: "Working on it...\n\n_Running `k8s_get_events`..._",
+		},
+		{
+			name: "summarizes tools once done",
+			events: []client.A2AEvent{
+				agentStatus(protocol.TaskStateWorking, toolPart("function_call", "k8s_get_pods", "1")),
+				agentStatus(protocol.TaskStateWorking, toolPart("function_response", "k8s_get_pods", "1")),
+				agentStatus(protocol.TaskStateWorking, toolPart("function_call", "k8s_get_pods", "2")),
+				partialText("Two pods"),
+				agentStatus(protocol.TaskStateWorking, textPart("Two pods are running.")),
+				{ArtifactUpdate: &protocol.TaskArtifactUpdateEvent{Artifact: protocol.Artifact{Parts: []protocol.Part{textPart("Two pods are running.")}}}},
+			},
+			done: true,
+			want: "Two pods are running.\n\n_Tools used: `k8s_get_pods` x2_",
+		},
+		{
+			name:   "reports failures",
+			events: []client.A2AEvent{agentStatus(protocol.TaskStateFailed, textPart("model quota exceeded"))},
+			done:   true,
+			want:   "The agent failed: model quota exceeded",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &replyRenderer{}
+			for _, ev := range tt.events {
+				r.add(ev)
+			}
+			if got := r.render(tt.done); got != tt.want {
+				t.Fatalf("render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplyRendererTruncates(t *testing.T) {
+	r := &replyRenderer{artifact: strings.Repeat("x", 2*maxReplyChars)}
+	if got := r.render(true); len(got) > maxReplyChars {
+		t.Fatalf("render() is %d characters, want at most %d", len(got), maxReplyChars)
+	}
+}
+
+func TestGatewayHandle(t *testing.T) {
+	cfg := &Config{Agent: "kagent/k8s-agent", ChannelAgents: map[string]string{"C2": "kagent/helm-agent"}}
+	conv := Conversation{Platform: "slack", Channel: "C2", Thread: "1.2"}
+
+	t.Run("streams the reply of the channel's agent", func(t *testing.T) {
+		g := NewGateway(cfg)
+		var gotAgent, gotSession, gotUser, gotText string
+		g.stream = func(_ context.Context, agent, sessionID, userID, text string) (<-chan client.A2AEvent, error) {
+			gotAgent, gotSession, gotUser, gotText = agent, sessionID, userID, text
+			return streamOf(agentStatus(protocol.TaskStateCompleted, textPart("Upgraded."))), nil
+		}
+		reply := &fakeReplier{}
+		if err := g.Handle(context.Background(), conv, "upgrade the chart", reply); err != nil {
+			t.Fatalf("Handle() error = %v", err)
+		}
+		if gotAgent != "kagent/helm-agent" || gotSession != conv.SessionID() || gotUser != "slack:C2" || gotText != "upgrade the chart" {
+			t.Fatalf("stream(%q, %q, %q, %q)", gotAgent, gotSession, gotUser, gotText)
+		}
+		if got := reply.text(); got != "Upgraded." {
+			t.Fatalf("reply = %q, want Upgraded.", got)
+		}
+	})
+
+	t.Run("reports an unreachable agent", func(t *testing.T) {
+		g := NewGateway(cfg)
+		g.stream = func(context.Context, string, string, string, string) (<-chan client.A2AEvent, error) {
+			return nil, errors.New("connection refused")
+		}
+		reply := &fakeReplier{}
+		if err := g.Handle(context.Background(), conv, "hi", reply); err != nil {
+			t.Fatalf("Handle() error = %v", err)
+		}
+		if got := reply.text(); got != "Could not reach agent kagent/helm-agent: connection refused" {
+			t.Fatalf("reply = %q", got)
+		}
+	})
+}
@@ -0,0 +1,80 @@
+package chatgateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"time"
+)
+
+// maxWebhookBytes bounds webhook bodies. Slack and Bot Framework payloads
+// are a few KiB.
+const maxWebhookBytes = 1 << 20
+
+// Server receives the Slack and Teams webhooks.
+type Server struct {
+	cfg     *Config
+	gateway *Gateway
+	httpSrv *http.Server
+	// httpClient calls the Slack and Bot Framework APIs.
+	httpClient *http.Client
+	teams      *teamsAuth
+}
+
+// NewServer creates a Server from a validated Config.
+func NewServer(cfg *Config) *Server {
+	s := &Server{
+		cfg:        cfg,
+		gateway:    NewGateway(cfg),
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /health", s.handleHealth)
+	if cfg.Slack.BotToken != "" {
+		mux.HandleFunc("POST /slack/events", s.handleSlackEvents)
+	}
+	if cfg.Teams.AppID != "" {
+		s.teams = newTeamsAuth(&cfg.Teams, s.httpClient)
+		mux.HandleFunc("POST /teams/messages", s.handleTeamsActivity)
+	}
+	s.httpSrv = &http.Server{
+		Addr:              cfg.ListenAddr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	return s
+}
+
+// ListenAndServe runs the server until Shutdown is called.
+func (s *Server) ListenAndServe() error {
+	l, err := net.Listen("tcp", s.cfg.ListenAddr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.cfg.ListenAddr, err)
+	}
+	return s.Serve(l)
+}
+
+// Serve runs the server on the given listener (used by tests to bind an
+// ephemeral port).
+func (s *Server) Serve(l net.Listener) error {
+	log.Printf("chat-gateway: listening on %s", l.Addr())
+	err := s.httpSrv.Serve(l)
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+// Shutdown stops accepting webhooks and waits for the replies in progress.
+func (s *Server) Shutdown(ctx context.Context) error {
+	err := s.httpSrv.Shutdown(ctx)
+	return errors.Join(err, s.gateway.Shutdown(ctx))
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("ok"))
+}
@@ -0,0 +1,207 @@
+package chatgateway
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// slackMaxSkew is how old a signed Slack request may be, which bounds
+// replays.
+const slackMaxSkew = 5 * time.Minute
+
+// slackMention matches user mentions such as <@U0123ABCD>.
+var slackMention = regexp.MustCompile(`<@[A-Z0-9]+(\|[^>]*)?>`)
+
+// slackEnvelope is the body of an Events API request.
+type slackEnvelope struct {
+	Type      string     `json:"type"`
+	Challenge string     `json:"challenge"`
+	Event     slackEvent `json:"event"`
+}
+
+type slackEvent struct {
+	Type        string `json:"type"`
+	Subtype     string `json:"subtype"`
+	BotID       string `json:"bot_id"`
+	Channel     string `json:"channel"`
+	ChannelType string `json:"channel_type"`
+	Text        string `json:"text"`
+	TS          string `json:"ts"`
+	ThreadTS    string `json:"thread_ts"`
+}
+
+func (s *Server) handleSlackEvents(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBytes))
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+	if err := verifySlackSignature(s.cfg.Slack.SigningSecret, r.Header, body, time.Now()); err != nil {
+		log.Printf("chat-gateway: rejected Slack request: %v", err)
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+	var env slackEnvelope
+	if err := json.Unmarshal(body, &env); err != nil {
+		http.Error(w, "invalid event", http.StatusBadRequest)
+		return
+	}
+	if env.Type == "url_verification" {
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte(env.Challenge))
+		return
+	}
+	// Slack retries events it thinks were not acknowledged in time; the
+	// first delivery is already being answered.
+	if r.Header.Get("X-Slack-Retry-Num") != "" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	if env.Type == "event_callback" {
+		if conv, text, ok := slackMessage(env.Event); ok {
+			s.gateway.Dispatch(conv, text, &slackReplier{s: s, channel: conv.Channel, threadTS: slackReplyThread(env.Event)})
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// slackMessage returns the conversation and text of an event the gateway
+// answers: mentions of the bot, and direct messages to it.
+func slackMessage(e slackEvent) (Conversation, string, bool) {
+	// Subtypes are edits, deletions, joins and the like; bot_id is set on
+	// the gateway's own replies.
+	if e.Subtype != "" || e.BotID != "" {
+		return Conversation{}, "", false
+	}
+	conv := Conversation{Platform: "slack", Channel: e.Channel}
+	switch {
+	case e.Type == "app_mention":
+		conv.Thread = slackReplyThread(e)
+	case e.Type == "message" && e.ChannelType == "im":
+		// A direct-message conversation is one session, unless the user
+		// starts a thread in it.
+		conv.Thread = e.ThreadTS
+	default:
+		return Conversation{}, "", false
+	}
+	text := strings.TrimSpace(slackMention.ReplaceAllString(e.Text, ""))
+	if text == "" {
+		return Conversation{}, "", false
+	}
+	return conv, text, true
+}
+
+// slackReplyThread returns the thread a reply to e belongs in: e's thread,
+// or a new one under e. Direct messages are answered inline.
+func slackReplyThread(e slackEvent) string {
+	if e.ThreadTS != "" {
+		return e.ThreadTS
+	}
+	if e.ChannelType == "im" {
+		return ""
+	}
+	return e.TS
+}
+
+// verifySlackSignature checks the v0 request signature Slack computes with
+// the app's signing secret.
+func verifySlackSignature(secret string, header http.Header, body []byte, now time.Time) error {
+	ts := header.Get("X-Slack-Request-Timestamp")
+	sig := header.Get("X-Slack-Signature")
+	if ts == "" || sig == "" {
+		return fmt.Errorf("missing signature headers")
+	}
+	sec, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid timestamp %q", ts)
+	}
+	if skew := now.Sub(time.Unix(sec, 0)); skew > slackMaxSkew || skew < -slackMaxSkew {
+		return fmt.Errorf("timestamp %s is too old", ts)
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "v0:%s:", ts)
+	mac.Write(body)
+	want := "v0=" + hex.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(sig), []byte(want)) {
+		return fmt.Errorf("signature mismatch")
+	}
+	return nil
+}
+
+// slackReplier posts to a Slack channel with the Web API.
+type slackReplier struct {
+	s        *Server
+	channel  string
+	threadTS string
+}
+
+func (r *slackReplier) Post(ctx context.Context, text string) (string, error) {
+	var resp struct {
+		TS string `json:"ts"`
+	}
+	req := map[string]string{"channel": r.channel, "text": text}
+	if r.threadTS != "" {
+		req["thread_ts"] = r.threadTS
+	}
+	if err := r.call(ctx, "chat.postMessage", req, &resp); err != nil {
+		return "", err
+	}
+	return resp.TS, nil
+}
+
+func (r *slackReplier) Update(ctx context.Context, id, text string) error {
+	return r.call(ctx, "chat.update", map[string]string{"channel": r.channel, "ts": id, "text": text}, nil)
+}
+
+// call invokes a Web API method. Slack reports most errors in the body of a
+// 200 response.
+func (r *slackReplier) call(ctx context.Context, method string, in any, out any) error {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(r.s.cfg.Slack.APIURL, "/")+"/"+method, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set("Authorization", "Bearer "+r.s.cfg.Slack.BotToken)
+	resp, err := r.s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("slack %s: %w", method, err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxWebhookBytes))
+	if err != nil {
+		return fmt.Errorf("slack %s: %w", method, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("slack %s: %s", method, resp.Status)
+	}
+	var result struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return fmt.Errorf("slack %s: invalid response: %w", method, err)
+	}
+	if !result.OK {
+		return fmt.Errorf("slack %s: %s", method, result.Error)
+	}
+	if out != nil {
+		return json.Unmarshal(data, out)
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+package chatgateway
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
+
+	"github.com/kagent-dev/kagent/go/api/client"
+)
+
+const testSigningSecret = "8f742231b10e8888abcd99yyyzzz85a5"
+
+func signSlack(t *testing.T, req *http.Request, body string, at time.Time) {
+	t.Helper()
+	ts := strconv.FormatInt(at.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(testSigningSecret))
+	fmt.Fprintf(mac, "v0:%s:%s", ts, body)
+	req.Header.Set("X-Slack-Request-Timestamp", ts)
+	req.Header.Set("X-Slack-Signature", "v0="+hex.EncodeToString(mac.Sum(nil)))
+}
+
+func TestVerifySlackSignature(t *testing.T) {
+	now := time.Now()
+	body := `{"type":"event_callback"}`
+	tests := []struct {
+		name    string
+		sign    func(req *http.Request)
+		wantErr bool
+	}{
+		{name: "valid", sign: func(req *http.Request) { signSlack(t, req, body, now) }},
+		{name: "unsigned", sign: func(*http.Request) {}, wantErr: true},
+		{name: "stale", sign: func(req *http.Request) { signSlack(t, req, body, now.Add(-10*time.Minute)) }, wantErr: true},
+		{name: "tampered", sign: func(req *http.Request) { signSlack(t, req, `{"type":"url_verification"}`, now) }, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/slack/events", nil)
+			tt.sign(req)
+			err := verifySlackSignature(testSigningSecret, req.Header, []byte(body), now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("verifySlackSignature() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSlackMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		event    slackEvent
+		wantOK   bool
+		wantConv Conversation
+		wantText string
+	}{
+		{
+			name:     "mention starts a thread",
+			event:    slackEvent{Type: "app_mention", Channel: "C1", Text: "<@U0BOT> why is web crashing?", TS: "1.1"},
+			wantOK:   true,
+			wantConv: Conversation{Platform: "slack", Channel: "C1", Thread: "1.1"},
+			wantText: "why is web crashing?",
+		},
+		{
+			name:     "mention in a thread continues it",
+			event:    slackEvent{Type: "app_mention", Channel: "C1", Text: "<@U0BOT> and now?", TS: "1.5", ThreadTS: "1.1"},
+			wantOK:   true,
+			wantConv: Conversation{Platform: "slack", Channel: "C1", Thread: "1.1"},
+			wantText: "and now?",
+		},
+		{
+			name:     "direct message",
+			event:    slackEvent{Type: "message", ChannelType: "im", Channel: "D1", Text: "list pods", TS: "2.1"},
+			wantOK:   true,
+			wantConv: Conversation{Platform: "slack", Channel: "D1"},
+			wantText: "list pods",
+		},
+		{name: "own reply", event: slackEvent{Type: "message", ChannelType: "im", Channel: "D1", Text: "Working on it...", BotID: "B1"}},
+		{name: "edit", event: slackEvent{Type: "message", Subtype: "message_changed", ChannelType: "im", Channel: "D1"}},
+		{name: "channel message", event: slackEvent{Type: "message", ChannelType: "channel", Channel: "C1", Text: "hello"}},
+		{name: "bare mention", event: slackEvent{Type: "app_mention", Channel: "C1", Text: "<@U0BOT>"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conv, text, ok := slackMessage(tt.event)
+			if ok != tt.wantOK || conv != tt.wantConv || text != tt.wantText {
+				t.Fatalf("slackMessage() = %+v, %q, %v; want %+v, %q, %v", conv, text, ok, tt.wantConv, tt.wantText, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestSlackEvents(t *testing.T) {
+	var mu sync.Mutex
+	var calls []map[string]string
+	slackAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer xoxb-test" {
+			_, _ = w.Write([]byte(`{"ok":false,"error":"invalid_auth"}`))
+			return
+		}
+		var req map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		req["method"] = strings.TrimPrefix(r.URL.Path, "/")
+		mu.Lock()
+		calls = append(calls, req)
+		mu.Unlock()
+		_, _ = w.Write([]byte(`{"ok":true,"ts":"9.9"}`))
+	}))
+	defer slackAPI.Close()
+
+	cfg := &Config{
+		ListenAddr: ":0",
+		KagentURL:  "http://kagent-controller:8083",
+		Agent:      "kagent/k8s-agent",
+		Slack:      SlackConfig{BotToken: "xoxb-test", SigningSecret: testSigningSecret, APIURL: slackAPI.URL},
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	s := NewServer(cfg)
+	var prompt string
+	s.gateway.stream = func(_ context.Context, _, _, _, text string) (<-chan client.A2AEvent, error) {
+		prompt = text
+		return streamOf(agentStatus(protocol.TaskStateCompleted, textPart("web is OOMKilled."))), nil
+	}
+
+	post := func(body string, header http.Header) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/slack/events", strings.NewReader(body))
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		signSlack(t, req, body, time.Now())
+		rec := httptest.NewRecorder()
+		s.httpSrv.Handler.ServeHTTP(rec, req)
+		s.gateway.wg.Wait()
+		return rec
+	}
+
+	rec := post(`{"type":"url_verification","challenge":"3eZbrw1aBm2rZgRNFdxV2595E9CY3gmdALWMmHkvFXO7tYXAYM8P"}`, nil)
+	if rec.Code != http.StatusOK || rec.Body.String() != "3eZbrw1aBm2rZgRNFdxV2595E9CY3gmdALWMmHkvFXO7tYXAYM8P" {
+		t.Fatalf("url_verification = %d %q", rec.Code, rec.Body.String())
+	}
+
+	mention := `{"type":"event_callback","event":{"type":"app_mention","channel":"C1","text":"<@U0BOT> why is web crashing?","ts":"1.1"}}`
+	if rec := post(mention, http.Header{"X-Slack-Retry-Num": {"1"}}); rec.Code != http.StatusOK || len(calls) != 0 {
+		t.Fatalf("retry = %d with %d API calls, want 200 with none", rec.Code, len(calls))
+	}
+	if rec := post(mention, nil); rec.Code != http.StatusOK {
+		t.Fatalf("app_mention = %d", rec.Code)
+	}
+	if prompt != "why is web crashing?" {
+		t.Fatalf("prompt = %q", prompt)
+	}
+	want := []map[string]string{
+		{"method": "chat.postMessage", "channel": "C1", "thread_ts": "1.1", "text": workingText},
+		{"method": "chat.update", "channel": "C1", "ts": "9.9", "text": "web is OOMKilled."},
+	}
+	if fmt.Sprint(calls) != fmt.Sprint(want) {
+		t.Fatalf("Slack API calls = %v, want %v", calls, want)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/slack/events", strings.NewReader(mention))
+	rec = httptest.NewRecorder()
+	s.httpSrv.Handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("unsigned request = %d, want 401", rec.Code)
+	}
+}
@@ -0,0 +1,245 @@
+package chatgateway
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+const (
+	// botFrameworkIssuer issues the tokens the Bot Framework sends with
+	// activities.
+	botFrameworkIssuer = "https://api.botframework.com"
+	botFrameworkScope  = "https://api.botframework.com/.default"
+)
+
+// teamsMention matches the <at>Name</at> tags Teams puts around mentions.
+var teamsMention = regexp.MustCompile(`<at>[^<]*</at>`)
+
+// teamsActivity is the subset of a Bot Framework activity the gateway reads.
+type teamsActivity struct {
+	Type         string `json:"type"`
+	ID           string `json:"id"`
+	Text         string `json:"text"`
+	ServiceURL   string `json:"serviceUrl"`
+	Conversation struct {
+		ID               string `json:"id"`
+		ConversationType string `json:"conversationType"`
+	} `json:"conversation"`
+	From struct {
+		ID string `json:"id"`
+	} `json:"from"`
+	Recipient struct {
+		ID string `json:"id"`
+	} `json:"recipient"`
+}
+
+func (s *Server) handleTeamsActivity(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBytes))
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+	var activity teamsActivity
+	if err := json.Unmarshal(body, &activity); err != nil {
+		http.Error(w, "invalid activity", http.StatusBadRequest)
+		return
+	}
+	if err := s.teams.verify(r.Context(), r.Header.Get("Authorization"), activity.ServiceURL); err != nil {
+		log.Printf("chat-gateway: rejected Teams activity: %v", err)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if conv, text, ok := teamsMessage(activity); ok {
+		s.gateway.Dispatch(conv, text, &teamsReplier{
+			auth:           s.teams,
+			serviceURL:     activity.ServiceURL,
+			conversationID: activity.Conversation.ID,
+			replyToID:      activity.ID,
+		})
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// teamsMessage returns the conversation and text of an activity the gateway
+// answers. The Bot Framework delivers channel messages only when they
+// mention the bot, and every message of personal and group chats.
+func teamsMessage(a teamsActivity) (Conversation, string, bool) {
+	if a.Type != "message" || a.From.ID == a.Recipient.ID {
+		return Conversation{}, "", false
+	}
+	text := strings.TrimSpace(teamsMention.ReplaceAllString(a.Text, ""))
+	if text == "" {
+		return Conversation{}, "", false
+	}
+	// A channel thread's conversation ID is the channel's followed by
+	// ";messageid=<root message>".
+	channel, _, _ := strings.Cut(a.Conversation.ID, ";")
+	return Conversation{Platform: "teams", Channel: channel, Thread: a.Conversation.ID}, text, true
+}
+
+// teamsAuth verifies the tokens of incoming activities and gets the token
+// replies are sent with.
+type teamsAuth struct {
+	cfg        *TeamsConfig
+	httpClient *http.Client
+	tokens     oauth2.TokenSource
+
+	mu   sync.Mutex
+	keys jwk.Set
+}
+
+func newTeamsAuth(cfg *TeamsConfig, httpClient *http.Client) *teamsAuth {
+	cc := &clientcredentials.Config{
+		ClientID:     cfg.AppID,
+		ClientSecret: cfg.AppPassword,
+		TokenURL:     cfg.TokenURL,
+		Scopes:       []string{botFrameworkScope},
+	}
+	// The token source caches the token until shortly before it expires.
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, httpClient)
+	return &teamsAuth{cfg: cfg, httpClient: httpClient, tokens: cc.TokenSource(ctx)}
+}
+
+// verify checks the bearer token the Bot Framework sends with an activity,
+// including that it was issued for the activity's service URL.
+func (a *teamsAuth) verify(ctx context.Context, authHeader, serviceURL string) error {
+	raw, ok := strings.CutPrefix(authHeader, "Bearer ")
+	if !ok {
+		return fmt.Errorf("missing bearer token")
+	}
+	keys, err := a.keySet(ctx)
+	if err != nil {
+		return err
+	}
+	token, err := jwt.Parse([]byte(raw),
+		jwt.WithKeySet(keys),
+		jwt.WithIssuer(botFrameworkIssuer),
+		jwt.WithAudience(a.cfg.AppID),
+		jwt.WithAcceptableSkew(5*time.Minute),
+	)
+	if err != nil {
+		return err
+	}
+	claim, _ := token.Get("serviceurl")
+	if s, _ := claim.(string); s == "" || strings.TrimSuffix(s, "/") != strings.TrimSuffix(serviceURL, "/") {
+		return fmt.Errorf("token is not for service URL %q", serviceURL)
+	}
+	return nil
+}
+
+// keySet returns the Bot Framework signing keys, discovering them on first
+// use so a metadata outage at startup does not stop the gateway.
+func (a *teamsAuth) keySet(ctx context.Context) (jwk.Set, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.keys != nil {
+		return a.keys, nil
+	}
+	jwksURL, err := a.discoverJWKSURL(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// The cache outlives the request that discovered it.
+	cache := jwk.NewCache(context.Background())
+	if err := cache.Register(jwksURL, jwk.WithMinRefreshInterval(time.Hour), jwk.WithHTTPClient(a.httpClient)); err != nil {
+		return nil, fmt.Errorf("failed to register JWKS %s: %w", jwksURL, err)
+	}
+	if _, err := cache.Refresh(ctx, jwksURL); err != nil {
+		return nil, fmt.Errorf("failed to fetch JWKS %s: %w", jwksURL, err)
+	}
+	a.keys = jwk.NewCachedSet(cache, jwksURL)
+	return a.keys, nil
+}
+
+func (a *teamsAuth) discoverJWKSURL(ctx context.Context) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.cfg.OpenIDMetadataURL, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch Bot Framework OpenID metadata: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to fetch Bot Framework OpenID metadata: %s", resp.Status)
+	}
+	var metadata struct {
+		JWKSURI string `json:"jwks_uri"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&metadata); err != nil {
+		return "", fmt.Errorf("invalid Bot Framework OpenID metadata: %w", err)
+	}
+	if metadata.JWKSURI == "" {
+		return "", fmt.Errorf("Bot Framework OpenID metadata has no jwks_uri")
+	}
+	return metadata.JWKSURI, nil
+}
+
+// teamsReplier replies to an activity with the Bot Framework connector API.
+type teamsReplier struct {
+	auth           *teamsAuth
+	serviceURL     string
+	conversationID string
+	replyToID      string
+}
+
+func (r *teamsReplier) Post(ctx context.Context, text string) (string, error) {
+	var resp struct {
+		ID string `json:"id"`
+	}
+	if err := r.send(ctx, http.MethodPost, r.replyToID, text, &resp); err != nil {
+		return "", err
+	}
+	return resp.ID, nil
+}
+
+func (r *teamsReplier) Update(ctx context.Context, id, text string) error {
+	return r.send(ctx, http.MethodPut, id, text, nil)
+}
+
+func (r *teamsReplier) send(ctx context.Context, method, activityID, text string, out any) error {
+	token, err := r.auth.tokens.Token()
+	if err != nil {
+		return fmt.Errorf("failed to get Bot Framework token: %w", err)
+	}
+	body, err := json.Marshal(map[string]string{"type": "message", "text": text, "textFormat": "markdown"})
+	if err != nil {
+		return err
+	}
+	u := fmt.Sprintf("%s/v3/conversations/%s/activities/%s",
+		strings.TrimSuffix(r.serviceURL, "/"), url.PathEscape(r.conversationID), url.PathEscape(activityID))
+	req, err := http.NewRequestWithContext(ctx, method, u, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	token.SetAuthHeader(req)
+	resp, err := r.auth.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("teams %s activity: %w", strings.ToLower(method), err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("teams %s activity: %s", strings.ToLower(method), resp.Status)
+	}
+	if out != nil {
+		return json.NewDecoder(resp.Body).Decode(out)
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+package chatgateway
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
+
+	"github.com/kagent-dev/kagent/go/api/client"
+)
+
+func TestTeamsMessage(t *testing.T) {
+	a := teamsActivity{Type: "message", ID: "1700000000123", Text: "<at>kagent</at> why is web crashing?"}
+	a.Conversation.ID = "19:abc@thread.tacv2;messageid=1700000000000"
+	a.From.ID, a.Recipient.ID = "29:user", "28:bot"
+
+	conv, text, ok := teamsMessage(a)
+	want := Conversation{Platform: "teams", Channel: "19:abc@thread.tacv2", Thread: "19:abc@thread.tacv2;messageid=1700000000000"}
+	if !ok || conv != want || text != "why is web crashing?" {
+		t.Fatalf("teamsMessage() = %+v, %q, %v", conv, text, ok)
+	}
+
+	a.Type = "conversationUpdate"
+	if _, _, ok := teamsMessage(a); ok {
+		t.Fatal("teamsMessage() answered a conversationUpdate")
+	}
+}
+
+func TestTeamsActivity(t *testing.T) {
+	rawKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := jwk.FromRaw(rawKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = key.Set(jwk.KeyIDKey, "test-key")
+	_ = key.Set(jwk.AlgorithmKey, jwa.RS256)
+	publicKeys, err := jwk.PublicSetOf(func() jwk.Set { s := jwk.NewSet(); _ = s.AddKey(key); return s }())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var mu sync.Mutex
+	var activities []string
+	mux := http.NewServeMux()
+	bf := httptest.NewServer(mux)
+	defer bf.Close()
+	mux.HandleFunc("GET /openid", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{"issuer": botFrameworkIssuer, "jwks_uri": bf.URL + "/keys"})
+	})
+	mux.HandleFunc("GET /keys", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(publicKeys)
+	})
+	mux.HandleFunc("POST /token", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("scope") != botFrameworkScope {
+			http.Error(w, "invalid scope", http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"bot-token","token_type":"Bearer","expires_in":3600}`))
+	})
+	mux.HandleFunc("/v3/conversations/{conversation}/activities/{activity}", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer bot-token" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		mu.Lock()
+		activities = append(activities, r.Method+" "+r.PathValue("conversation")+" "+r.PathValue("activity")+" "+body["text"])
+		mu.Unlock()
+		_, _ = w.Write([]byte(`{"id":"reply-1"}`))
+	})
+
+	cfg := &Config{
+		ListenAddr: ":0",
+		KagentURL:  "http://kagent-controller:8083",
+		Agent:      "kagent/k8s-agent",
+		Teams: TeamsConfig{
+			AppID:             "app-id",
+			AppPassword:       "app-password",
+			OpenIDMetadataURL: bf.URL + "/openid",
+			TokenURL:          bf.URL + "/token",
+		},
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	s := NewServer(cfg)
+	s.gateway.stream = func(context.Context, string, string, string, string) (<-chan client.A2AEvent, error) {
+		return streamOf(agentStatus(protocol.TaskStateCompleted, textPart("web is OOMKilled."))), nil
+	}
+
+	sign := func(claims map[string]any) string {
+		tok := jwt.New()
+		for k, v := range claims {
+			_ = tok.Set(k, v)
+		}
+		signed, err := jwt.Sign(tok, jwt.WithKey(jwa.RS256, key))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(signed)
+	}
+	claims := func(serviceURL string) map[string]any {
+		return map[string]any{
+			"iss":        botFrameworkIssuer,
+			"aud":        "app-id",
+			"serviceurl": serviceURL,
+			"exp":        time.Now().Add(time.Hour).Unix(),
+		}
+	}
+	activity := `{"type":"message","id":"act-1","text":"<at>kagent</at> why is web crashing?","serviceUrl":"` + bf.URL + `",` +
+		`"conversation":{"id":"19:abc@thread.tacv2;messageid=1"},"from":{"id":"29:user"},"recipient":{"id":"28:bot"}}`
+	post := func(token string) int {
+		req := httptest.NewRequest(http.MethodPost, "/teams/messages", strings.NewReader(activity))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		s.httpSrv.Handler.ServeHTTP(rec, req)
+		s.gateway.wg.Wait()
+		return rec.Code
+	}
+
+	for name, token := range map[string]string{
+		"no token":          "",
+		"wrong audience":    sign(map[string]any{"iss": botFrameworkIssuer, "aud": "other-app", "serviceurl": bf.URL, "exp": time.Now().Add(time.Hour).Unix()}),
+		"wrong service URL": sign(claims("https://smba.example.com")),
+		"wrong issuer":      sign(map[string]any{"iss": "https://evil.example.com", "aud": "app-id", "serviceurl": bf.URL, "exp": time.Now().Add(time.Hour).Unix()}),
+	} {
+		if code := post(token); code != http.StatusUnauthorized {
+			t.Errorf("%s: status = %d, want 401", name, code)
+		}
+	}
+	if len(activities) != 0 {
+		t.Fatalf("replied to unauthenticated activities: %v", activities)
+	}
+
+	if code := post(sign(claims(bf.URL))); code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", code)
+	}
+	want := []string{
+		"POST 19:abc@thread.tacv2;messageid=1 act-1 " + workingText,
+		"PUT 19:abc@thread.tacv2;messageid=1 reply-1 web is OOMKilled.",
+	}
+	if strings.Join(activities, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("activities = %q, want %q", activities, want)
+	}
+}
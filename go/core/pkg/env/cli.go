@@ -30,3 +30,42 @@ var (
 		ComponentCLI,
 	)
 )
+
+// CLI connection settings. They override the selected CLI context and the
+// config file, and are overridden by the matching flags.
+var (
+	KagentCLIContext = RegisterStringVar(
+		"KAGENT_CLI_CONTEXT",
+		"",
+		"CLI context to use instead of the current context (see kagent config use-context).",
+		ComponentCLI,
+	)
+
+	KagentCLIURL = RegisterStringVar(
+		"KAGENT_CLI_URL",
+		"",
+		"URL of the kagent API server used by the CLI (--kagent-url).",
+		ComponentCLI,
+	)
+
+	KagentCLIA2AURL = RegisterStringVar(
+		"KAGENT_CLI_A2A_URL",
+		"",
+		"Base URL of the agents' A2A endpoints when not served by the API server (--a2a-url).",
+		ComponentCLI,
+	)
+
+	KagentCLIUserID = RegisterStringVar(
+		"KAGENT_CLI_USER_ID",
+		"",
+		"User ID the CLI sends with API requests (--user-id).",
+		ComponentCLI,
+	)
+
+	KagentCLINamespace = RegisterStringVar(
+		"KAGENT_CLI_NAMESPACE",
+		"",
+		"Default namespace for CLI commands (--namespace).",
+		ComponentCLI,
+	)
+)
@@ -33,6 +33,33 @@ var (
 		ComponentController,
 	)
 
+	KagentSSEFlushInterval = RegisterDurationVar(
+		"KAGENT_SSE_FLUSH_INTERVAL",
+		0,
+		"How often the controller flushes A2A streaming (SSE) responses proxied from agents. "+
+			"0 (the default) flushes every event as soon as it arrives. A positive duration batches "+
+			"events, which helps with intermediaries that handle many tiny writes poorly. "+
+			"Agents can override this with spec.streaming.flushInterval.",
+		ComponentController,
+	)
+
+	KagentSSEWriteTimeout = RegisterDurationVar(
+		"KAGENT_SSE_WRITE_TIMEOUT",
+		0,
+		"Deadline for each write of an A2A streaming (SSE) response to the client. "+
+			"0 (the default) means no deadline. Agents can override this with spec.streaming.writeTimeout.",
+		ComponentController,
+	)
+
+	KagentSSEMaxEventSize = RegisterIntVar(
+		"KAGENT_SSE_MAX_EVENT_SIZE",
+		0,
+		"Largest single A2A streaming (SSE) event, in bytes, the controller forwards to clients; "+
+			"a larger event aborts the stream. 0 (the default) means no limit. "+
+			"Agents can override this with spec.streaming.maxEventSize.",
+		ComponentController,
+	)
+
 	KagentMCPStateless = RegisterBoolVar(
 		"KAGENT_MCP_STATELESS",
 		false,
@@ -81,6 +108,14 @@ var (
 		ComponentAgentRuntime,
 	)
 
+	KagentModelPrewarm = RegisterStringVar(
+		"KAGENT_MODEL_PREWARM",
+		"connect",
+		"How the agent runtime warms its model provider connection on startup: "+
+			"off, connect (open a keep-alive connection) or ping (also send a one-token request).",
+		ComponentAgentRuntime,
+	)
+
 	KagentPropagateToken = RegisterStringVar(
 		"KAGENT_PROPAGATE_TOKEN",
 		"",
@@ -94,4 +129,18 @@ var (
 		"Well-known endpoint for the Security Token Service (STS) used for token exchange.",
 		ComponentAgentRuntime,
 	)
+
+	QdrantAPIKey = RegisterStringVar(
+		"QDRANT_API_KEY",
+		"",
+		"API key for the Qdrant memory provider.",
+		ComponentAgentRuntime,
+	)
+
+	ModerationAPIKey = RegisterStringVar(
+		"KAGENT_MODERATION_API_KEY",
+		"",
+		"API key for the agent's moderation provider.",
+		ComponentAgentRuntime,
+	)
 )
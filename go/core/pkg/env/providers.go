@@ -1,7 +1,9 @@
 package env
 
 // LLM provider environment variables. These are injected into agent pods
-// by the controller and consumed by the agent runtime.
+// by the controller and consumed by the agent runtime. An API key can also be
+// given as the path of a file holding it in <NAME>_FILE, e.g.
+// OPENAI_API_KEY_FILE, which the runtime loads into <NAME> at startup.
 
 // OpenAI
 var (
@@ -0,0 +1,14 @@
+-- Daily per-provider/model outcome counters for agent invocations proxied
+-- through the controller. One row per (UTC day, provider, model); writers
+-- increment in place so the table stays small regardless of traffic.
+CREATE TABLE IF NOT EXISTS provider_daily_stats (
+    day              TIMESTAMPTZ NOT NULL,
+    provider         TEXT        NOT NULL,
+    model            TEXT        NOT NULL,
+    request_count    BIGINT      NOT NULL DEFAULT 0,
+    error_count      BIGINT      NOT NULL DEFAULT 0,
+    total_latency_ms BIGINT      NOT NULL DEFAULT 0,
+    max_latency_ms   BIGINT      NOT NULL DEFAULT 0,
+    updated_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (day, provider, model)
+);
@@ -0,0 +1,15 @@
+-- Latest webhook delivery outcome per push notification config. The
+-- controller's dispatcher overwrites the row on every attempt, so it always
+-- reflects the most recent task state sent (or being retried) to the config.
+CREATE TABLE IF NOT EXISTS push_notification_delivery (
+    task_id          TEXT        NOT NULL,
+    config_id        TEXT        NOT NULL,
+    task_state       TEXT        NOT NULL,
+    status           TEXT        NOT NULL,
+    attempts         INTEGER     NOT NULL DEFAULT 0,
+    last_status_code INTEGER,
+    last_error       TEXT,
+    delivered_at     TIMESTAMPTZ,
+    updated_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (task_id, config_id)
+);
@@ -0,0 +1,15 @@
+-- Entries recorded while an object carries the kagent.dev/debug-capture-until
+-- annotation. capture_id is the annotation value, so each capture window is a
+-- separate bundle. Rows for an object are replaced when a new capture starts.
+CREATE TABLE IF NOT EXISTS debug_capture_entry (
+    id          BIGSERIAL   PRIMARY KEY,
+    capture_id  TEXT        NOT NULL,
+    object_kind TEXT        NOT NULL,
+    namespace   TEXT        NOT NULL,
+    name        TEXT        NOT NULL,
+    stage       TEXT        NOT NULL,
+    message     TEXT        NOT NULL,
+    data        TEXT,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_debug_capture_entry_object ON debug_capture_entry(object_kind, namespace, name, capture_id);
@@ -0,0 +1,3 @@
+-- Supports paginated session listings, which filter by user and order by
+-- most recent activity.
+CREATE INDEX IF NOT EXISTS idx_session_user_updated_at ON session(user_id, updated_at DESC, created_at DESC) WHERE deleted_at IS NULL;
@@ -0,0 +1,12 @@
+-- Grants give another user, or every member of a group, access to a session
+-- without a share link. owner_id is the session's user_id, which the session
+-- row is read under. access is 'read' or 'invoke'.
+CREATE TABLE IF NOT EXISTS session_grant (
+    session_id     TEXT        NOT NULL,
+    owner_id       TEXT        NOT NULL,
+    principal_kind TEXT        NOT NULL,
+    principal      TEXT        NOT NULL,
+    access         TEXT        NOT NULL,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (session_id, owner_id, principal_kind, principal)
+);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_push_notification_updated_at;
+DROP INDEX IF EXISTS idx_task_updated_at;
+DROP INDEX IF EXISTS idx_event_created_at;
+DROP INDEX IF EXISTS idx_session_updated_at;
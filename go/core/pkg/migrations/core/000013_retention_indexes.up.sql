@@ -0,0 +1,5 @@
+-- Support the retention janitor, which deletes rows older than a cutoff.
+CREATE INDEX IF NOT EXISTS idx_session_updated_at           ON session(updated_at);
+CREATE INDEX IF NOT EXISTS idx_event_created_at             ON event(created_at);
+CREATE INDEX IF NOT EXISTS idx_task_updated_at              ON task(updated_at);
+CREATE INDEX IF NOT EXISTS idx_push_notification_updated_at ON push_notification(updated_at);
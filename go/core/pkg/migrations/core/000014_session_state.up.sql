@@ -0,0 +1,11 @@
+-- Session state is a key/value store scoped to a session, which agents read
+-- and write as ADK session state so it survives restarts. value holds any
+-- JSON value.
+CREATE TABLE IF NOT EXISTS session_state (
+    session_id TEXT        NOT NULL,
+    user_id    TEXT        NOT NULL,
+    key        TEXT        NOT NULL,
+    value      JSONB       NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (session_id, user_id, key)
+);
@@ -0,0 +1,16 @@
+-- LLM traces are the redacted request/response payloads of sampled model
+-- calls, recorded by agents with LLM trace capture enabled for debugging.
+-- request and response hold the provider-neutral ADK payloads; response is
+-- NULL and error is set when the call failed.
+CREATE TABLE IF NOT EXISTS llm_trace (
+    id         BIGSERIAL   PRIMARY KEY,
+    session_id TEXT        NOT NULL,
+    user_id    TEXT        NOT NULL,
+    agent_id   TEXT        NOT NULL,
+    model      TEXT        NOT NULL,
+    request    JSONB       NOT NULL,
+    response   JSONB,
+    error      TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_llm_trace_session ON llm_trace(session_id, user_id, id);
@@ -0,0 +1,15 @@
+-- Event archives point at session events the event archiver moved out of the
+-- event table into the artifact store. object_key holds the archived events
+-- as gzip-compressed JSON Lines, which are read back when the session is
+-- opened.
+CREATE TABLE IF NOT EXISTS event_archive (
+    id             BIGSERIAL   PRIMARY KEY,
+    session_id     TEXT        NOT NULL,
+    user_id        TEXT        NOT NULL,
+    object_key     TEXT        NOT NULL,
+    event_count    INTEGER     NOT NULL,
+    first_event_at TIMESTAMPTZ NOT NULL,
+    last_event_at  TIMESTAMPTZ NOT NULL,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_event_archive_session ON event_archive(session_id, user_id);
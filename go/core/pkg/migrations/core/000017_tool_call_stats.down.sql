@@ -0,0 +1 @@
+DROP TABLE IF EXISTS tool_call_hourly_stats;
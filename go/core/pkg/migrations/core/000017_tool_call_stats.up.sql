@@ -0,0 +1,15 @@
+-- Hourly per-tool-server call counters reported by agents. One row per (UTC
+-- hour, tool server, agent); reports increment in place. latency_buckets
+-- counts the calls per latency bucket, with the bounds defined by the agent
+-- API, so percentiles can be estimated across agents and hours.
+CREATE TABLE IF NOT EXISTS tool_call_hourly_stats (
+    hour            TIMESTAMPTZ NOT NULL,
+    tool_server     TEXT        NOT NULL,
+    agent_id        TEXT        NOT NULL,
+    call_count      BIGINT      NOT NULL DEFAULT 0,
+    error_count     BIGINT      NOT NULL DEFAULT 0,
+    latency_buckets BIGINT[]    NOT NULL,
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (hour, tool_server, agent_id)
+);
+CREATE INDEX IF NOT EXISTS idx_tool_call_hourly_stats_server ON tool_call_hourly_stats(tool_server, hour);
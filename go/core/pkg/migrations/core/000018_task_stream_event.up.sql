@@ -0,0 +1,13 @@
+-- Events of the A2A streams the controller relays, so a client whose
+-- connection dropped can resume a stream with Last-Event-ID. seq numbers the
+-- events of a task from 1 across all of its streams; data is the JSON-RPC
+-- response of the event.
+CREATE TABLE IF NOT EXISTS task_stream_event (
+    task_id    TEXT        NOT NULL,
+    seq        BIGINT      NOT NULL,
+    user_id    TEXT        NOT NULL,
+    data       TEXT        NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (task_id, seq)
+);
+CREATE INDEX IF NOT EXISTS idx_task_stream_event_created_at ON task_stream_event(created_at);
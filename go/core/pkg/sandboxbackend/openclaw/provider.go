@@ -29,6 +29,10 @@ func modelConfigExplicitBaseURL(mc *v1alpha2.ModelConfig) string {
 		if mc.Spec.SAPAICore != nil && strings.TrimSpace(mc.Spec.SAPAICore.BaseURL) != "" {
 			return strings.TrimSpace(mc.Spec.SAPAICore.BaseURL)
 		}
+	case v1alpha2.ModelProviderOpenAICompatible:
+		if mc.Spec.OpenAICompatible != nil && strings.TrimSpace(mc.Spec.OpenAICompatible.BaseURL) != "" {
+			return strings.TrimSpace(mc.Spec.OpenAICompatible.BaseURL)
+		}
 	}
 	return ""
 }
@@ -49,7 +53,7 @@ func providerAuth(mc *v1alpha2.ModelConfig) string {
 
 func providerAPI(mc *v1alpha2.ModelConfig) (string, error) {
 	switch mc.Spec.Provider {
-	case v1alpha2.ModelProviderOpenAI:
+	case v1alpha2.ModelProviderOpenAI, v1alpha2.ModelProviderOpenAICompatible:
 		return "openai-completions", nil
 	case v1alpha2.ModelProviderAnthropic:
 		return "anthropic-messages", nil
@@ -112,6 +112,22 @@ Engine selector labels
 app.kubernetes.io/component: engine
 {{- end }}
 
+{{/*
+Chat gateway selector labels
+*/}}
+{{- define "kagent.chatGateway.selectorLabels" -}}
+{{ include "kagent.selectorLabels" . }}
+app.kubernetes.io/component: chat-gateway
+{{- end }}
+
+{{/*
+Chat gateway labels
+*/}}
+{{- define "kagent.chatGateway.labels" -}}
+{{ include "kagent.labels" . }}
+app.kubernetes.io/component: chat-gateway
+{{- end }}
+
 {{/*
 Controller labels
 */}}
@@ -74,5 +74,9 @@ Join registry/repository for grafana-mcp image, skipping empty segments, then ap
 {{- define "grafana-mcp.image" -}}
 {{- $img := .Values.image -}}
 {{- $parts := compact (list $img.registry $img.repository) -}}
+{{- if $img.digest -}}
+{{- printf "%s@%s" (join "/" $parts) $img.digest -}}
+{{- else -}}
 {{- printf "%s:%s" (join "/" $parts) $img.tag -}}
+{{- end -}}
 {{- end -}}
\ No newline at end of file
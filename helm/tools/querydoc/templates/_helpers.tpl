@@ -74,5 +74,9 @@ Constructs the full image reference from registry/repository/tag for querydoc im
 {{- define "querydoc.image" -}}
 {{- $img := .Values.image -}}
 {{- $parts := compact (list $img.registry $img.repository) -}}
+{{- if $img.digest -}}
+{{- printf "%s@%s" (join "/" $parts) $img.digest -}}
+{{- else -}}
 {{- printf "%s:%s" (join "/" $parts) ($img.tag | default .Chart.AppVersion) -}}
+{{- end -}}
 {{- end }}
\ No newline at end of file
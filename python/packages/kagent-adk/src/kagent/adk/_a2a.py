@@ -157,7 +157,13 @@ class KAgentApp:
 
         agent_executor = A2aAgentExecutor(
             runner=create_runner,
-            config=A2aAgentExecutorConfig(stream=self.stream),
+            config=A2aAgentExecutorConfig(
+                stream=self.stream,
+                task_timeout=self.agent_config.timeouts.task
+                if self.agent_config and self.agent_config.timeouts
+                else None,
+                budget=self.agent_config.budget if self.agent_config else None,
+            ),
             task_store=task_store,
         )
 
@@ -51,7 +51,13 @@ from kagent.core.tracing._span_processor import (
 from pydantic import BaseModel
 from typing_extensions import override
 
+from ._budget import EXCEEDED_METADATA_KEY as BUDGET_EXCEEDED_METADATA_KEY
+from ._budget import METADATA_KEY as BUDGET_METADATA_KEY
+from ._budget import BudgetConfig, current_tracker, new_tracker, parse_override, session_tokens
+from ._dry_run import METADATA_KEY as DRY_RUN_METADATA_KEY
+from ._dry_run import dry_run_enabled
 from ._mcp_toolset import is_anyio_cross_task_cancel_scope_error
+from ._model_fallback import SERVED_MODEL_KEY
 from ._remote_a2a_tool import SubagentSessionProvider
 from .converters.event_converter import convert_event_to_a2a_events, serialize_metadata_value
 from .converters.part_converter import convert_a2a_part_to_genai_part, convert_genai_part_to_a2a_part
@@ -64,6 +70,8 @@ class A2aAgentExecutorConfig(BaseModel):
     """Configuration for the KAgent A2aAgentExecutor."""
 
     stream: bool = False
+    task_timeout: float | None = None  # Bounds each run of the agent on a task, in seconds
+    budget: BudgetConfig | None = None  # Bounds the tokens, tool calls and model turns of each task
 
 
 def _kagent_request_converter(request, _part_converter=None):
@@ -241,8 +249,20 @@ class A2aAgentExecutor(UpstreamA2aAgentExecutor):
 
             # Handle the request and publish updates to the event queue
             runner = await self._resolve_runner()
+            task_timeout = self._kagent_config.task_timeout if self._kagent_config is not None else None
+            timeout = asyncio.timeout(task_timeout)
             try:
-                await self._handle_request(context, event_queue, runner, run_args)
+                async with timeout:
+                    await self._handle_request(context, event_queue, runner, run_args)
+            except TimeoutError as e:
+                if not timeout.expired():
+                    logger.error("Error handling A2A request: %s", e, exc_info=True)
+                    await self._publish_failed_status_event(context, event_queue, str(e))
+                else:
+                    logger.warning("A2A request timed out after %gs", task_timeout)
+                    await self._publish_failed_status_event(
+                        context, event_queue, f"task timed out after {task_timeout:g}s"
+                    )
             except asyncio.CancelledError as e:
                 logger.error("A2A request execution was cancelled", exc_info=True)
                 error_message = str(e) or "A2A request execution was cancelled."
@@ -553,6 +573,26 @@ class A2aAgentExecutor(UpstreamA2aAgentExecutor):
 
             await runner.session_service.append_event(session, system_event)
 
+        # A dry-run invocation previews mutating tool calls instead of running them.
+        # Set on every request so the flag never carries over to the next one.
+        dry_run = (context.message.metadata or {}).get(get_kagent_metadata_key(DRY_RUN_METADATA_KEY)) is True
+        dry_run_enabled.set(dry_run)
+
+        # The invocation's budget can only tighten the agent's. Like dry-run, it is
+        # set on every request so a tracker never carries over to the next one.
+        limits = self._kagent_config.budget if self._kagent_config is not None else None
+        try:
+            override = parse_override(
+                (context.message.metadata or {}).get(get_kagent_metadata_key(BUDGET_METADATA_KEY))
+            )
+        except ValueError as e:
+            logger.warning("Ignoring invalid budget metadata: %s", e)
+            override = None
+        if override is not None:
+            limits = limits.tighten(override) if limits is not None else override
+        tracker = new_tracker(limits, session_tokens(session.events or []))
+        current_tracker.set(tracker)
+
         # create invocation context
         invocation_context = runner._new_invocation_context(
             session=session,
@@ -566,6 +606,8 @@ class A2aAgentExecutor(UpstreamA2aAgentExecutor):
             get_kagent_metadata_key("user_id"): run_args["user_id"],
             get_kagent_metadata_key("session_id"): run_args["session_id"],
         }
+        if dry_run:
+            run_metadata[get_kagent_metadata_key(DRY_RUN_METADATA_KEY)] = True
 
         # publish the task working event
         await event_queue.enqueue_event(
@@ -586,6 +628,7 @@ class A2aAgentExecutor(UpstreamA2aAgentExecutor):
         # This adds the invocation_id of the run to the metadata of the FINAL event (completed or failed)
         real_invocation_id: str | None = None
         last_usage_metadata = None
+        served_model: str | None = None
 
         # Build a mapping of tool name -> subagent session ID once so the
         # event converter can stamp it onto function_call DataParts.
@@ -608,6 +651,10 @@ class A2aAgentExecutor(UpstreamA2aAgentExecutor):
                 # task.metadata, making it available to callers (e.g. KAgentRemoteA2ATool).
                 if getattr(adk_event, "usage_metadata", None) is not None:
                     last_usage_metadata = adk_event.usage_metadata
+                # Set by FallbackLlm; reports which model of the chain answered.
+                event_served_model = (getattr(adk_event, "custom_metadata", None) or {}).get(SERVED_MODEL_KEY)
+                if event_served_model:
+                    served_model = event_served_model
 
                 for a2a_event in convert_event_to_a2a_events(
                     adk_event,
@@ -630,6 +677,11 @@ class A2aAgentExecutor(UpstreamA2aAgentExecutor):
         # merges it into task.metadata on the completed Task object.
         if last_usage_metadata is not None:
             run_metadata[get_kagent_metadata_key("usage_metadata")] = serialize_metadata_value(last_usage_metadata)
+        if served_model is not None:
+            run_metadata[get_kagent_metadata_key(SERVED_MODEL_KEY)] = served_model
+        if tracker is not None and tracker.exceeded is not None:
+            logger.info("Task %s ended over its %s budget", context.task_id, tracker.exceeded.limit)
+            run_metadata[get_kagent_metadata_key(BUDGET_EXCEEDED_METADATA_KEY)] = tracker.exceeded.metadata()
 
         # publish the task result event - this is final
         if (
@@ -0,0 +1,234 @@
+"""Token, tool call and model turn budgets of tasks.
+
+Mirrors the Go ADK behavior in ``go/adk/pkg/budget``. When a task reaches a
+limit the agent makes no further tool calls, and its next model request is
+answered with a summary of the work done, so the task completes instead of
+looping until it times out. Limits come from the agent config and can be
+lowered for one invocation with the ``kagent_budget`` A2A message metadata key
+(``kagent invoke --max-turns``).
+"""
+
+from __future__ import annotations
+
+import contextvars
+import logging
+from typing import Any, Iterable, Optional
+
+from google.adk.agents.callback_context import CallbackContext
+from google.adk.models.llm_request import LlmRequest
+from google.adk.models.llm_response import LlmResponse
+from google.adk.tools.base_tool import BaseTool
+from google.adk.tools.tool_context import ToolContext
+from google.genai import types
+from pydantic import BaseModel
+
+logger = logging.getLogger("kagent_adk." + __name__)
+
+# A2A message metadata key (with the kagent_ prefix) holding a BudgetConfig
+# that tightens the limits of the agent for an invocation.
+METADATA_KEY = "budget"
+# Event custom metadata key, and A2A metadata key with the kagent_ prefix,
+# holding the limit a task reached.
+EXCEEDED_METADATA_KEY = "budget_exceeded"
+# Returned to the model for tool calls over the budget.
+NOT_EXECUTED = "Budget exceeded: this tool call was not executed."
+
+LIMIT_TOTAL_TOKENS = "max_total_tokens"
+LIMIT_SESSION_TOKENS = "max_session_tokens"
+LIMIT_TOOL_CALLS = "max_tool_calls"
+LIMIT_TURNS = "max_turns"
+
+
+class BudgetConfig(BaseModel):
+    max_total_tokens: int | None = None
+    max_session_tokens: int | None = None
+    max_tool_calls: int | None = None
+    max_turns: int | None = None
+
+    def enabled(self) -> bool:
+        return any(
+            v is not None
+            for v in (self.max_total_tokens, self.max_session_tokens, self.max_tool_calls, self.max_turns)
+        )
+
+    def tighten(self, other: Optional["BudgetConfig"]) -> "BudgetConfig":
+        """Return the smaller of each limit of this budget and ``other``."""
+        if other is None:
+            return self.model_copy()
+
+        def smaller(a: int | None, b: int | None) -> int | None:
+            if a is None:
+                return b
+            if b is None:
+                return a
+            return min(a, b)
+
+        return BudgetConfig(
+            max_total_tokens=smaller(self.max_total_tokens, other.max_total_tokens),
+            max_session_tokens=smaller(self.max_session_tokens, other.max_session_tokens),
+            max_tool_calls=smaller(self.max_tool_calls, other.max_tool_calls),
+            max_turns=smaller(self.max_turns, other.max_turns),
+        )
+
+
+class Exceeded(BaseModel):
+    """The limit a task reached."""
+
+    limit: str
+    max: int
+    used: int
+
+    def metadata(self) -> dict:
+        return {"limit": self.limit, "max": self.max, "used": self.used}
+
+
+class Tracker:
+    """Counts the work of one run of the agent on a task against its limits."""
+
+    def __init__(self, limits: BudgetConfig, session_tokens: int = 0):
+        self.limits = limits
+        # Tokens the session used before the run.
+        self.session_tokens = session_tokens
+        self.tokens = 0
+        self.turns = 0
+        self.tool_calls = 0
+        self.tools: dict[str, int] = {}
+        self.last_text = ""
+        self.exceeded: Exceeded | None = None
+
+    def _token_limit(self) -> Exceeded | None:
+        if self.exceeded is not None:
+            return self.exceeded
+        limits = self.limits
+        if limits.max_total_tokens is not None and self.tokens >= limits.max_total_tokens:
+            self.exceeded = Exceeded(limit=LIMIT_TOTAL_TOKENS, max=limits.max_total_tokens, used=self.tokens)
+        elif (
+            limits.max_session_tokens is not None
+            and self.session_tokens + self.tokens >= limits.max_session_tokens
+        ):
+            self.exceeded = Exceeded(
+                limit=LIMIT_SESSION_TOKENS,
+                max=limits.max_session_tokens,
+                used=self.session_tokens + self.tokens,
+            )
+        return self.exceeded
+
+    def start_turn(self) -> Exceeded | None:
+        """Count a model request, or return the limit that stops it."""
+        if (exceeded := self._token_limit()) is not None:
+            return exceeded
+        if self.limits.max_turns is not None and self.turns >= self.limits.max_turns:
+            self.exceeded = Exceeded(limit=LIMIT_TURNS, max=self.limits.max_turns, used=self.turns)
+            return self.exceeded
+        self.turns += 1
+        return None
+
+    def end_turn(self, response: LlmResponse) -> None:
+        """Count the tokens of a model response."""
+        if response.usage_metadata is not None:
+            self.tokens += response.usage_metadata.total_token_count or 0
+        text = _text_of(response.content)
+        if text:
+            self.last_text = text
+
+    def start_tool_call(self, name: str) -> Exceeded | None:
+        """Count a call of the named tool, or return the limit that stops it."""
+        if (exceeded := self._token_limit()) is not None:
+            return exceeded
+        if self.limits.max_tool_calls is not None and self.tool_calls >= self.limits.max_tool_calls:
+            self.exceeded = Exceeded(limit=LIMIT_TOOL_CALLS, max=self.limits.max_tool_calls, used=self.tool_calls)
+            return self.exceeded
+        self.tool_calls += 1
+        self.tools[name] = self.tools.get(name, 0) + 1
+        return None
+
+    def summary(self) -> str:
+        """Describe the work of the run for the user once it stopped."""
+        text = ""
+        if self.exceeded is not None:
+            text += f"I stopped working on this task because it reached {_describe(self.exceeded)}.\n\n"
+        text += f"Work done: {self.turns} model turns, {self.tool_calls} tool calls and {self.tokens} tokens."
+        if self.tools:
+            calls = ", ".join(f"{name} ({self.tools[name]})" for name in sorted(self.tools))
+            text += f"\nTools called: {calls}."
+        if self.last_text:
+            text += f"\n\nLast update:\n{self.last_text}"
+        return text
+
+
+def _describe(exceeded: Exceeded) -> str:
+    return {
+        LIMIT_TOTAL_TOKENS: f"its budget of {exceeded.max} tokens",
+        LIMIT_SESSION_TOKENS: f"the session's budget of {exceeded.max} tokens",
+        LIMIT_TOOL_CALLS: f"its budget of {exceeded.max} tool calls",
+        LIMIT_TURNS: f"its budget of {exceeded.max} model turns",
+    }.get(exceeded.limit, "its budget")
+
+
+def _text_of(content: types.Content | None) -> str:
+    if content is None or not content.parts:
+        return ""
+    return "".join(part.text for part in content.parts if part.text and not part.thought)
+
+
+# Set by the agent executor for the duration of a run with a budget.
+current_tracker: contextvars.ContextVar[Tracker | None] = contextvars.ContextVar("kagent_budget", default=None)
+
+
+def new_tracker(limits: BudgetConfig | None, session_tokens: int = 0) -> Tracker | None:
+    """Return a Tracker for a run, or None when ``limits`` sets no limit."""
+    if limits is None or not limits.enabled():
+        return None
+    return Tracker(limits, session_tokens)
+
+
+def session_tokens(events: Iterable[Any]) -> int:
+    """Return the tokens used by the model responses among a session's events."""
+    total = 0
+    for event in events:
+        usage = getattr(event, "usage_metadata", None)
+        if usage is not None and not getattr(event, "partial", False):
+            total += usage.total_token_count or 0
+    return total
+
+
+def parse_override(value: Any) -> BudgetConfig | None:
+    """Read the budget of an invocation from its ``kagent_budget`` message metadata."""
+    if value is None:
+        return None
+    return BudgetConfig.model_validate(value)
+
+
+def before_model(callback_context: CallbackContext, llm_request: LlmRequest) -> Optional[LlmResponse]:
+    """Answer the model requests of a run over its budget with the run's summary, which ends it."""
+    tracker = current_tracker.get()
+    if tracker is None:
+        return None
+    exceeded = tracker.start_turn()
+    if exceeded is None:
+        return None
+    logger.info("Task reached its %s budget", exceeded.limit)
+    return LlmResponse(
+        content=types.Content(role="model", parts=[types.Part(text=tracker.summary())]),
+        custom_metadata={EXCEEDED_METADATA_KEY: exceeded.metadata()},
+        turn_complete=True,
+    )
+
+
+def after_model(callback_context: CallbackContext, llm_response: LlmResponse) -> Optional[LlmResponse]:
+    """Count the tokens of the model responses of a run."""
+    tracker = current_tracker.get()
+    if tracker is not None and not llm_response.partial:
+        tracker.end_turn(llm_response)
+    return None
+
+
+def before_tool(tool: BaseTool, args: dict[str, Any], tool_context: ToolContext) -> dict | None:
+    """Skip the tool calls of a run over its budget."""
+    tracker = current_tracker.get()
+    if tracker is None:
+        return None
+    exceeded = tracker.start_tool_call(tool.name)
+    if exceeded is None:
+        return None
+    return {"executed": False, "result": NOT_EXECUTED, EXCEEDED_METADATA_KEY: exceeded.metadata()}
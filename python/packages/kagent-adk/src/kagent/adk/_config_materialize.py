@@ -55,3 +55,20 @@ def materialize_from_env(config_dir: str) -> None:
         _materialize_env_to_file(_KAGENT_TOKEN_ENV, _KAGENT_TOKEN_PATH)
     except OSError as e:
         logger.warning("Could not materialize %s to %s: %s", _KAGENT_TOKEN_ENV, _KAGENT_TOKEN_PATH, e)
+
+
+# Marks an env var holding the path of a file with an API key, e.g. OPENAI_API_KEY_FILE for
+# OPENAI_API_KEY. The controller sets them for ModelConfigs whose key is read from a file in the
+# pod, such as a CSI volume. Mirrors ``LoadAPIKeyFiles`` in the Go ADK.
+_API_KEY_FILE_SUFFIX = "_API_KEY_FILE"
+
+
+def load_api_key_files() -> None:
+    """Set every API key env var given as a file to the file's content, so model clients find
+    the key where they always look for it. A missing file fails startup."""
+    for name, path in list(os.environ.items()):
+        if not name.endswith(_API_KEY_FILE_SUFFIX) or not path:
+            continue
+        with open(path) as f:
+            os.environ[name.removesuffix("_FILE")] = f.read().strip()
+        logger.info("Loaded %s from %s", name.removesuffix("_FILE"), name)
@@ -0,0 +1,100 @@
+"""Dry-run previews of mutating tool calls.
+
+Mirrors ``go/adk/pkg/dryrun/dryrun.go``. A caller turns dry-run on for one
+invocation with the ``kagent_dry_run`` A2A message metadata key
+(``kagent invoke --dry-run``). Tools matching the agent's dry-run patterns then
+either run with ``dry_run=true``, when their input schema declares that
+parameter, or are not called at all and the model is told what would have run.
+"""
+
+from __future__ import annotations
+
+import contextvars
+import logging
+from fnmatch import fnmatchcase
+from typing import Any
+
+from google.adk.tools.base_tool import BaseTool
+from google.adk.tools.tool_context import ToolContext
+
+logger = logging.getLogger("kagent_adk." + __name__)
+
+# A2A message metadata key (with the kagent_ prefix) that enables dry-run.
+METADATA_KEY = "dry_run"
+# Tool parameter through which a tool server is asked to preview a change.
+ARG_NAME = "dry_run"
+NOT_EXECUTED = (
+    "Dry run: this tool call was not executed. Do not retry it; "
+    "describe to the user what it would change so they can review the plan."
+)
+
+# The mutating tools of the kagent tool server. Mirrors DefaultDryRunTools in go/api/adk/types.go.
+DEFAULT_DRY_RUN_TOOLS = [
+    "k8s_apply_manifest",
+    "k8s_create_resource*",
+    "k8s_patch_resource",
+    "k8s_delete_resource",
+    "k8s_scale*",
+    "k8s_rollout*",
+    "k8s_annotate_resource",
+    "k8s_label_resource",
+    "k8s_remove_annotation",
+    "k8s_remove_label",
+    "k8s_execute_command",
+    "helm_install",
+    "helm_upgrade",
+    "helm_uninstall",
+    "istio_install_istio",
+    "istio_apply_waypoint",
+    "istio_delete_waypoint",
+    "cilium_install_cilium",
+    "cilium_upgrade_cilium",
+]
+
+# Set by the agent executor for the duration of a dry-run invocation.
+dry_run_enabled: contextvars.ContextVar[bool] = contextvars.ContextVar("kagent_dry_run", default=False)
+
+
+def _accepts_dry_run(tool: BaseTool) -> bool:
+    """Report whether the tool's declared parameters include dry_run."""
+    raw = getattr(tool, "raw_mcp_tool", None)
+    schema = getattr(raw, "inputSchema", None)
+    if isinstance(schema, dict):
+        return ARG_NAME in (schema.get("properties") or {})
+    try:
+        declaration = tool._get_declaration()
+    except Exception:
+        return False
+    if declaration is None:
+        return False
+    if declaration.parameters is not None:
+        return ARG_NAME in (declaration.parameters.properties or {})
+    json_schema = declaration.parameters_json_schema
+    return isinstance(json_schema, dict) and ARG_NAME in (json_schema.get("properties") or {})
+
+
+def make_dry_run_callback(patterns: list[str] | None):
+    """Create a before_tool_callback that previews matching tools in dry-run invocations."""
+    patterns = patterns or DEFAULT_DRY_RUN_TOOLS
+
+    def before_tool(
+        tool: BaseTool,
+        args: dict[str, Any],
+        tool_context: ToolContext,
+    ) -> dict | None:
+        if not dry_run_enabled.get() or not any(fnmatchcase(tool.name, p) for p in patterns):
+            return None
+        if _accepts_dry_run(tool):
+            args[ARG_NAME] = True
+            logger.debug("Running tool %s in dry-run mode", tool.name)
+            return None
+        logger.info("Skipped tool call %s in dry-run mode", tool.name)
+        return {
+            "dry_run": True,
+            "executed": False,
+            "tool": tool.name,
+            "args": dict(args),
+            "result": NOT_EXECUTED,
+        }
+
+    return before_tool
@@ -0,0 +1,114 @@
+"""OAuth2 client credentials authentication for MCP servers.
+
+Mirrors ``newOAuth2TokenSource`` in ``go/adk/pkg/mcp/registry.go``. The
+controller resolves a RemoteMCPServer's ``spec.auth.oauth2ClientCredentials``
+into the agent config; ``OAuth2ClientCredentialsAuth`` fetches an access token
+with the client credentials grant, reuses it across every MCP session of the
+toolset until shortly before it expires, and sends it as a bearer token. The
+token replaces an Authorization header from any other source.
+"""
+
+from __future__ import annotations
+
+import asyncio
+import logging
+import time
+from typing import AsyncGenerator, Callable
+from urllib.parse import quote_plus
+
+import httpx
+from pydantic import BaseModel
+
+logger = logging.getLogger("kagent_adk." + __name__)
+
+# Tokens are refreshed this long before they expire, matching golang.org/x/oauth2.
+_EXPIRY_DELTA_SECONDS = 10
+_TOKEN_REQUEST_TIMEOUT_SECONDS = 30
+
+
+class OAuth2ClientCredentialsConfig(BaseModel):
+    token_url: str
+    client_id: str
+    client_secret: str
+    scopes: list[str] | None = None
+    audience: str | None = None
+
+
+class OAuth2ClientCredentialsAuth(httpx.Auth):
+    """httpx auth that adds a cached client credentials access token to each request."""
+
+    def __init__(self, config: OAuth2ClientCredentialsConfig) -> None:
+        self._config = config
+        self._token: str | None = None
+        # None means the token does not expire.
+        self._expires_at: float | None = None
+        self._lock = asyncio.Lock()
+
+    async def async_auth_flow(self, request: httpx.Request) -> AsyncGenerator[httpx.Request, httpx.Response]:
+        token = await self._get_token()
+        request.headers["Authorization"] = f"Bearer {token}"
+        response = yield request
+        if response.status_code == 401:
+            # The token may have been revoked before it expired; retry once with a new one.
+            token = await self._get_token(invalid=token)
+            request.headers["Authorization"] = f"Bearer {token}"
+            yield request
+
+    async def _get_token(self, invalid: str | None = None) -> str:
+        async with self._lock:
+            if self._token is not None and self._token != invalid and not self._expired():
+                return self._token
+            self._token, self._expires_at = await self._fetch_token()
+            return self._token
+
+    def _expired(self) -> bool:
+        return self._expires_at is not None and time.monotonic() >= self._expires_at - _EXPIRY_DELTA_SECONDS
+
+    async def _fetch_token(self) -> tuple[str, float | None]:
+        data = {"grant_type": "client_credentials"}
+        if self._config.scopes:
+            data["scope"] = " ".join(self._config.scopes)
+        if self._config.audience:
+            data["audience"] = self._config.audience
+        # RFC 6749 section 2.3.1: credentials are form-encoded before Basic authentication.
+        auth = (quote_plus(self._config.client_id), quote_plus(self._config.client_secret))
+        async with httpx.AsyncClient(timeout=_TOKEN_REQUEST_TIMEOUT_SECONDS) as client:
+            response = await client.post(self._config.token_url, data=data, auth=auth)
+        if response.status_code >= 300:
+            raise httpx.HTTPStatusError(
+                f"OAuth2 token request to {self._config.token_url} failed with {response.status_code}: {response.text}",
+                request=response.request,
+                response=response,
+            )
+        body = response.json()
+        token = body.get("access_token")
+        if not token:
+            raise ValueError(f"OAuth2 token response from {self._config.token_url} has no access_token")
+        expires_in = body.get("expires_in")
+        expires_at = time.monotonic() + float(expires_in) if expires_in else None
+        logger.debug("Fetched OAuth2 token from %s, expires in %s seconds", self._config.token_url, expires_in)
+        return token, expires_at
+
+
+def with_oauth2(
+    factory: Callable[..., httpx.AsyncClient] | None, config: OAuth2ClientCredentialsConfig
+) -> Callable[..., httpx.AsyncClient]:
+    """Wrap an httpx client factory so its clients authenticate with config.
+
+    The auth object is created once, so all clients the factory creates share
+    the cached token.
+    """
+    if factory is None:
+        from mcp.shared._httpx_utils import create_mcp_http_client
+
+        factory = create_mcp_http_client
+    oauth2_auth = OAuth2ClientCredentialsAuth(config)
+
+    def _factory(
+        headers: dict[str, str] | None = None,
+        timeout: httpx.Timeout | None = None,
+        auth: httpx.Auth | None = None,
+    ) -> httpx.AsyncClient:
+        return factory(headers=headers, timeout=timeout, auth=oauth2_auth)
+
+    return _factory
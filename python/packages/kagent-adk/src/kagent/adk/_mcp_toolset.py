@@ -12,6 +12,10 @@ from google.adk.tools.tool_context import ToolContext
 from mcp.shared.exceptions import McpError
 
 from kagent.adk._mcp_apps import MCPAppToolNames
+from kagent.adk._timeouts import TimeoutsConfig
+from kagent.adk._tool_policy import ToolPolicy
+from kagent.adk._tool_result_limit import ToolResultLimiter
+from kagent.adk._tool_result_validation import ToolResultValidator
 
 logger = logging.getLogger("kagent_adk." + __name__)
 
@@ -84,14 +88,34 @@ class ConnectionSafeMcpTool(McpTool):
     upstream McpTool adds __slots__, properties, or post-init hooks.
 
     See: https://github.com/kagent-dev/kagent/issues/1530
+
+    When a ``result_validator`` is supplied, results are checked against the
+    tool's output schema; when a ``result_limiter`` is supplied, results over
+    its size limit are cut down. Both run before the result is added to the
+    conversation, validation first so the schema sees the whole result.
+
+    When a ``timeout`` in seconds is supplied, a call that takes longer is
+    cancelled and reaches the model as a tool error.
     """
 
     _inner_tool: McpTool
+    _result_limiter: Optional[ToolResultLimiter] = None
+    _result_validator: Optional[ToolResultValidator] = None
+    _timeout: Optional[float] = None
 
-    def __init__(self, inner_tool: McpTool):
+    def __init__(
+        self,
+        inner_tool: McpTool,
+        result_limiter: Optional[ToolResultLimiter] = None,
+        result_validator: Optional[ToolResultValidator] = None,
+        timeout: Optional[float] = None,
+    ):
         # Store the inner tool without calling McpTool.__init__
         # (which requires connection params we don't have).
         object.__setattr__(self, "_inner_tool", inner_tool)
+        object.__setattr__(self, "_result_limiter", result_limiter)
+        object.__setattr__(self, "_result_validator", result_validator)
+        object.__setattr__(self, "_timeout", timeout)
 
     def __getattr__(self, name: str) -> Any:
         return getattr(self._inner_tool, name)
@@ -112,14 +136,37 @@ class ConnectionSafeMcpTool(McpTool):
         args: dict[str, Any],
         tool_context: ToolContext,
     ) -> dict[str, Any]:
+        # Checked before the connection errors, since a timeout is a TimeoutError.
+        timeout = asyncio.timeout(self._timeout)
         try:
-            return await self._inner_tool.run_async(args=args, tool_context=tool_context)
+            async with timeout:
+                result = await self._inner_tool.run_async(args=args, tool_context=tool_context)
+        except TimeoutError as error:
+            if not timeout.expired():
+                return self._connection_error_response(error)
+            logger.info("Tool call timed out", extra={"tool": self.name, "timeout": self._timeout})
+            return {
+                "error": (
+                    f"Tool {self.name} timed out after {self._timeout:g}s; "
+                    "retry it with narrower arguments or continue without its result."
+                )
+            }
         except _CONNECTION_ERROR_TYPES as error:
             return self._connection_error_response(error)
         except McpError as error:
             if not _is_transport_mcp_error(error):
                 raise
             return self._connection_error_response(error)
+        if self._result_validator is not None:
+            output_schema = getattr(getattr(self._inner_tool, "raw_mcp_tool", None), "outputSchema", None)
+            validated = self._result_validator.apply(self.name, output_schema, result)
+            if validated is not None:
+                result = validated
+        if self._result_limiter is not None:
+            limited = await self._result_limiter.apply(self.name, result, tool_context)
+            if limited is not None:
+                return limited
+        return result
 
 
 class KAgentMcpToolset(McpToolset):
@@ -133,15 +180,41 @@ class KAgentMcpToolset(McpToolset):
     (UI-rendering) tools are recorded into it as tools are resolved, so the
     agent's before-model callback can compact their results for the model
     (see ``_mcp_apps.make_mcp_app_model_result_callback``).
+
+    When a ``tool_policy`` is supplied, tools it denies are dropped each time
+    the toolset is listed, so tools the server adds later are filtered too.
+
+    When a ``result_limiter`` is supplied, it caps the size of every tool
+    result (see ``_tool_result_limit``). When a ``result_validator`` is
+    supplied, it checks every tool result against the tool's output schema
+    (see ``_tool_result_validation``). When ``timeouts`` are supplied, each
+    tool call is bounded by the timeout of its tool (see ``_timeouts``).
     """
 
     # Class-level default so instances created via __new__ (e.g. in tests that
     # bypass __init__) still resolve the attribute.
     _app_tool_names: Optional[MCPAppToolNames] = None
+    _tool_policy: Optional[ToolPolicy] = None
+    _result_limiter: Optional[ToolResultLimiter] = None
+    _result_validator: Optional[ToolResultValidator] = None
+    _timeouts: Optional[TimeoutsConfig] = None
 
-    def __init__(self, *args: Any, app_tool_names: Optional[MCPAppToolNames] = None, **kwargs: Any) -> None:
+    def __init__(
+        self,
+        *args: Any,
+        app_tool_names: Optional[MCPAppToolNames] = None,
+        tool_policy: Optional[ToolPolicy] = None,
+        result_limiter: Optional[ToolResultLimiter] = None,
+        result_validator: Optional[ToolResultValidator] = None,
+        timeouts: Optional[TimeoutsConfig] = None,
+        **kwargs: Any,
+    ) -> None:
         super().__init__(*args, **kwargs)
         self._app_tool_names = app_tool_names
+        self._tool_policy = tool_policy
+        self._result_limiter = result_limiter
+        self._result_validator = result_validator
+        self._timeouts = timeouts
 
     async def get_tools(self, readonly_context: Optional[ReadonlyContext] = None) -> list[BaseTool]:
         try:
@@ -153,6 +226,8 @@ class KAgentMcpToolset(McpToolset):
         # errors are returned as error text instead of raised.
         wrapped_tools: list[BaseTool] = []
         for tool in tools:
+            if self._tool_policy is not None and not self._tool_policy.allows(tool.name):
+                continue
             if isinstance(tool, McpTool):
                 # getattr guards against partially-constructed McpTool stubs
                 # whose visibility/mcp_app_resource_uri properties would raise.
@@ -166,7 +241,10 @@ class KAgentMcpToolset(McpToolset):
                 if self._app_tool_names is not None and getattr(tool, "mcp_app_resource_uri", None):
                     self._app_tool_names.add(tool.name)
                 if not isinstance(tool, ConnectionSafeMcpTool):
-                    wrapped_tools.append(ConnectionSafeMcpTool(tool))
+                    timeout = self._timeouts.tool_timeout(tool.name) if self._timeouts is not None else None
+                    wrapped_tools.append(
+                        ConnectionSafeMcpTool(tool, self._result_limiter, self._result_validator, timeout)
+                    )
                     continue
             wrapped_tools.append(tool)
         return wrapped_tools
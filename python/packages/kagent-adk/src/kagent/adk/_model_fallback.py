@@ -0,0 +1,173 @@
+"""Failover from a primary model to fallback models.
+
+Mirrors ``FallbackLLM`` in ``go/adk/pkg/models/fallback.go``. When the primary
+model fails with a server error, a rate limit or a timeout before producing any
+response, the request is retried against the first fallback whose triggers
+include that error class. Once a model has produced a response its stream is
+passed through unchanged. Responses are tagged with the model that served them
+in ``custom_metadata["served_model"]``.
+"""
+
+from __future__ import annotations
+
+import asyncio
+import logging
+import re
+from typing import AsyncGenerator, Literal, Optional
+
+import httpx
+from google.adk.models.base_llm import BaseLlm
+from google.adk.models.llm_request import LlmRequest
+from google.adk.models.llm_response import LlmResponse
+from pydantic import BaseModel
+
+logger = logging.getLogger(__name__)
+
+SERVER_ERROR = "server_error"
+RATE_LIMITED = "rate_limited"
+TIMEOUT = "timeout"
+
+# custom_metadata keys set on every response of a FallbackLlm.
+SERVED_MODEL_KEY = "served_model"
+FALLBACK_INDEX_KEY = "model_fallback_index"
+
+_RATE_LIMITED_MESSAGE = re.compile(r"\b429\b|rate.?limit|too many requests", re.IGNORECASE)
+_SERVER_ERROR_MESSAGE = re.compile(
+    r"\b5\d\d\b|internal server error|service unavailable|bad gateway|overloaded", re.IGNORECASE
+)
+_TIMEOUT_MESSAGE = re.compile(r"time[d ]?out|deadline exceeded", re.IGNORECASE)
+
+
+class Fallback(BaseModel):
+    """A model to fail over to; ``triggers`` empty means every error class."""
+
+    model_config = {"arbitrary_types_allowed": True}
+
+    llm: BaseLlm
+    triggers: list[Literal["server_error", "rate_limited", "timeout"]] | None = None
+
+    def applies_to(self, error_class: str) -> bool:
+        return not self.triggers or error_class in self.triggers
+
+
+def classify_status_code(status: int) -> Optional[str]:
+    if status == 429:
+        return RATE_LIMITED
+    if status in (408, 504):
+        return TIMEOUT
+    if status >= 500:
+        return SERVER_ERROR
+    return None
+
+
+def classify_message(message: str) -> Optional[str]:
+    if _RATE_LIMITED_MESSAGE.search(message):
+        return RATE_LIMITED
+    if _TIMEOUT_MESSAGE.search(message):
+        return TIMEOUT
+    if _SERVER_ERROR_MESSAGE.search(message):
+        return SERVER_ERROR
+    return None
+
+
+def classify_exception(exc: BaseException) -> Optional[str]:
+    """Return the error class of a failed model call, or None if it should not fail over."""
+    if isinstance(exc, (asyncio.TimeoutError, TimeoutError, httpx.TimeoutException)):
+        return TIMEOUT
+    # openai and anthropic expose status_code, google-genai exposes code.
+    for attr in ("status_code", "code"):
+        status = getattr(exc, attr, None)
+        if isinstance(status, int) and status >= 400:
+            return classify_status_code(status)
+    response = getattr(exc, "response", None)
+    if isinstance(response, httpx.Response):
+        return classify_status_code(response.status_code)
+    return classify_message(str(exc))
+
+
+def classify_response(response: LlmResponse) -> Optional[str]:
+    if not response.error_code:
+        return None
+    return classify_message(f"{response.error_code} {response.error_message or ''}")
+
+
+class FallbackLlm(BaseLlm):
+    """Forwards requests to ``primary`` and fails over to ``fallbacks`` in order."""
+
+    model_config = {"arbitrary_types_allowed": True}
+
+    primary: BaseLlm
+    fallbacks: list[Fallback]
+
+    def __init__(self, primary: BaseLlm, fallbacks: list[Fallback], **kwargs):
+        super().__init__(model=primary.model, primary=primary, fallbacks=fallbacks, **kwargs)
+
+    @property
+    def api_key_passthrough(self) -> bool:
+        return any(getattr(llm, "api_key_passthrough", False) for llm in self._chain())
+
+    def set_passthrough_key(self, token: str) -> None:
+        """Forward a passthrough key to every model in the chain that accepts one."""
+        for llm in self._chain():
+            if getattr(llm, "api_key_passthrough", False):
+                llm.set_passthrough_key(token)
+
+    def _chain(self) -> list[BaseLlm]:
+        return [self.primary, *(f.llm for f in self.fallbacks)]
+
+    def _next(self, start: int, error_class: str) -> int:
+        """Return the 1-based chain position of the next fallback handling error_class, or 0."""
+        for i in range(start, len(self.fallbacks)):
+            if self.fallbacks[i].applies_to(error_class):
+                return i + 1
+        return 0
+
+    async def generate_content_async(
+        self, llm_request: LlmRequest, stream: bool = False
+    ) -> AsyncGenerator[LlmResponse, None]:
+        llm, index = self.primary, 0
+        while True:
+            request = llm_request
+            if index > 0:
+                # The agent fills in the primary's name, which several providers
+                # prefer over their own configured model.
+                request = llm_request.model_copy()
+                request.model = llm.model
+
+            started = False
+            failed_with: Optional[str] = None
+            try:
+                async for response in llm.generate_content_async(request, stream=stream):
+                    if not started:
+                        started = True
+                        error_class = classify_response(response)
+                        if error_class and self._next(index, error_class):
+                            logger.warning(
+                                "Model %s failed (%s: %s), failing over",
+                                llm.model,
+                                error_class,
+                                response.error_message,
+                            )
+                            failed_with = error_class
+                            break
+                    self._tag(response, llm.model, index)
+                    yield response
+            except Exception as exc:
+                error_class = classify_exception(exc)
+                if started or not error_class or not self._next(index, error_class):
+                    raise
+                logger.warning("Model %s failed (%s: %s), failing over", llm.model, error_class, exc)
+                failed_with = error_class
+
+            if failed_with is None:
+                return
+            index = self._next(index, failed_with)
+            llm = self.fallbacks[index - 1].llm
+
+    @staticmethod
+    def _tag(response: LlmResponse, model: str, index: int) -> None:
+        metadata = dict(response.custom_metadata or {})
+        metadata[SERVED_MODEL_KEY] = model
+        if index > 0:
+            metadata[FALLBACK_INDEX_KEY] = index
+        response.custom_metadata = metadata
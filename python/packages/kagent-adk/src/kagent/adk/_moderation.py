@@ -0,0 +1,284 @@
+"""Content moderation of model responses and user messages.
+
+Mirrors the Go ADK behavior in ``go/adk/pkg/moderation``. A message is checked
+against keywords and patterns first, then against a moderation service if one
+is configured; a failing message is blocked, redacted or flagged in the
+``moderation`` key of its event's custom metadata. Model responses are checked
+by an ``after_model_callback``; with ``check_input``, the user's messages are
+checked by a ``before_model_callback`` before they are sent to the model.
+"""
+
+from __future__ import annotations
+
+import logging
+import os
+import re
+from typing import Literal, Optional
+
+import httpx
+from google.adk.agents.callback_context import CallbackContext
+from google.adk.models.llm_request import LlmRequest
+from google.adk.models.llm_response import LlmResponse
+from google.genai import types
+from opentelemetry import trace
+from pydantic import BaseModel
+
+logger = logging.getLogger(__name__)
+
+# Event custom metadata key holding the verdict of a message that failed moderation.
+METADATA_KEY = "moderation"
+# Replaces a blocked model response.
+BLOCKED_OUTPUT = "The response was withheld because it failed content moderation."
+# Answers a blocked user message instead of the model.
+BLOCKED_INPUT = "Your message was not sent to the model because it failed content moderation."
+# Trace event recorded for each failed message.
+SPAN_EVENT_NAME = "kagent.security.moderation"
+# Environment variable holding the moderation service's API key.
+API_KEY_ENV = "KAGENT_MODERATION_API_KEY"
+
+REASON_KEYWORD = "keyword"
+REASON_PATTERN = "pattern"
+REASON_PROVIDER_ERROR = "provider_error"
+
+_REDACTED = "[removed by moderation]"
+_DEFAULT_OPENAI_URL = "https://api.openai.com/v1"
+_DEFAULT_OPENAI_MODEL = "omni-moderation-latest"
+_PROVIDER_TIMEOUT = 10.0
+
+
+class ModerationProviderConfig(BaseModel):
+    type: Literal["openai", "http"]
+    url: str | None = None
+    model: str | None = None
+    fail_open: bool = False
+
+
+class ModerationConfig(BaseModel):
+    action: Literal["block", "redact", "flag"] = "block"
+    keywords: list[str] | None = None
+    patterns: list[str] | None = None
+    provider: ModerationProviderConfig | None = None
+    check_input: bool = False
+
+
+class Verdict(BaseModel):
+    """The outcome of checking a message that failed moderation."""
+
+    direction: Literal["input", "output"]
+    action: str
+    reasons: list[str]
+    # Whether every reason has a match that redaction removes. Provider
+    # categories don't say which text they apply to.
+    located: bool = True
+
+    @property
+    def blocks(self) -> bool:
+        return self.action == "block" or (self.action == "redact" and not self.located)
+
+    def metadata(self) -> dict:
+        return {"direction": self.direction, "action": self.action, "reasons": list(self.reasons)}
+
+
+class Moderator:
+    """Applies the configured action to messages that fail moderation."""
+
+    def __init__(self, config: ModerationConfig, api_key: Optional[str] = None) -> None:
+        self.action = config.action
+        self.check_input = config.check_input
+        self.keywords = (
+            re.compile(r"(?i)\b(?:" + "|".join(re.escape(k) for k in config.keywords) + r")\b")
+            if config.keywords
+            else None
+        )
+        self.rules = [re.compile(p) for p in config.patterns or []]
+        self.provider = config.provider
+        if self.provider is not None and self.provider.type == "http" and not self.provider.url:
+            raise ValueError("the http moderation provider requires a url")
+        self.api_key = api_key
+        # Verdicts of flagged user messages by invocation, to be attached to
+        # the model's response.
+        self._flagged: dict[str, Verdict] = {}
+
+    async def check(self, text: str, direction: Literal["input", "output"]) -> Optional[Verdict]:
+        """Return the verdict on text, or None when it passes moderation."""
+        verdict = Verdict(direction=direction, action=self.action, reasons=[])
+        if self.keywords is not None and self.keywords.search(text):
+            verdict.reasons.append(REASON_KEYWORD)
+        if any(rule.search(text) for rule in self.rules):
+            verdict.reasons.append(REASON_PATTERN)
+        # The service is only asked about text the local rules pass, or, when
+        # redacting, the text that remains after redaction.
+        if self.provider is not None and (not verdict.reasons or self.action == "redact"):
+            try:
+                categories = await self._classify(self.redact(text), direction)
+            except Exception:
+                if self.provider.fail_open:
+                    logger.exception("Moderation provider failed, passing the message through")
+                else:
+                    logger.exception("Moderation provider failed, failing the message")
+                    verdict.reasons.append(REASON_PROVIDER_ERROR)
+                    verdict.located = False
+            else:
+                if categories:
+                    verdict.reasons.extend(categories)
+                    verdict.located = False
+        return verdict if verdict.reasons else None
+
+    def apply(self, text: str, verdict: Optional[Verdict], blocked: str) -> str:
+        """Return the text to pass on for a message with the given verdict."""
+        if verdict is None or verdict.action == "flag":
+            return text
+        if verdict.blocks:
+            return blocked
+        return self.redact(text)
+
+    def redact(self, text: str) -> str:
+        if self.keywords is not None:
+            text = self.keywords.sub(_REDACTED, text)
+        for rule in self.rules:
+            text = rule.sub(_REDACTED, text)
+        return text
+
+    async def _classify(self, text: str, direction: str) -> list[str]:
+        """Return the categories the moderation service flagged text for."""
+        headers = {"Authorization": f"Bearer {self.api_key}"} if self.api_key else {}
+        async with httpx.AsyncClient(timeout=_PROVIDER_TIMEOUT) as client:
+            if self.provider.type == "openai":
+                url = (self.provider.url or _DEFAULT_OPENAI_URL).rstrip("/") + "/moderations"
+                body = {"model": self.provider.model or _DEFAULT_OPENAI_MODEL, "input": text}
+            else:
+                url = self.provider.url
+                body = {"text": text, "direction": direction}
+            response = await client.post(url, json=body, headers=headers)
+            response.raise_for_status()
+            data = response.json()
+
+        if self.provider.type == "http":
+            if not data.get("flagged"):
+                return []
+            return list(data.get("categories") or ["flagged"])
+        categories: list[str] = []
+        for result in data.get("results") or []:
+            if not result.get("flagged"):
+                continue
+            categories.extend(
+                name for name, flagged in (result.get("categories") or {}).items() if flagged and name not in categories
+            )
+            if not categories:
+                categories.append("flagged")
+        return sorted(categories)
+
+    def _record(self, callback_context: CallbackContext, verdict: Verdict) -> None:
+        """Log a failed message as a security event and add it to the active trace span."""
+        logger.warning(
+            "Message failed moderation",
+            extra={
+                "security_event": "moderation",
+                "direction": verdict.direction,
+                "reasons": verdict.reasons,
+                "action": verdict.action,
+                "invocation_id": callback_context.invocation_id,
+            },
+        )
+        trace.get_current_span().add_event(
+            SPAN_EVENT_NAME,
+            attributes={
+                "kagent.security.direction": verdict.direction,
+                "kagent.security.reasons": verdict.reasons,
+                "kagent.security.action": verdict.action,
+            },
+        )
+
+    async def after_model(self, callback_context: CallbackContext, llm_response: LlmResponse) -> Optional[LlmResponse]:
+        """Moderate the text of the model's final responses.
+
+        Under block and redact the text of partial responses is held back,
+        since it can only be moderated once complete.
+        """
+        if llm_response.content is None:
+            return None
+        if llm_response.partial:
+            if self.action == "flag" or not _has_text(llm_response.content):
+                return None
+            return llm_response.model_copy(update={"content": _without_text(llm_response.content)})
+
+        flagged_input = self._flagged.pop(callback_context.invocation_id, None)
+        text = _text_of(llm_response.content)
+        verdict = await self.check(text, "output") if text else None
+        if verdict is None and flagged_input is None:
+            return None
+        custom_metadata = dict(llm_response.custom_metadata or {})
+        update: dict = {"custom_metadata": custom_metadata}
+        if verdict is not None:
+            self._record(callback_context, verdict)
+            update["content"] = _with_text(llm_response.content, self.apply(text, verdict, BLOCKED_OUTPUT))
+            custom_metadata[METADATA_KEY] = verdict.metadata()
+        else:
+            custom_metadata[METADATA_KEY] = flagged_input.metadata()
+        return llm_response.model_copy(update=update)
+
+    async def before_model(self, callback_context: CallbackContext, llm_request: LlmRequest) -> Optional[LlmResponse]:
+        """Moderate the user's latest message before it is sent to the model.
+
+        A blocked message is answered with a notice instead of a model response.
+        """
+        if not llm_request.contents or not _is_user_text(llm_request.contents[-1]):
+            return None
+        content = llm_request.contents[-1]
+        text = _text_of(content)
+        verdict = await self.check(text, "input")
+        if verdict is None:
+            return None
+        self._record(callback_context, verdict)
+        if verdict.action == "flag":
+            self._flagged[callback_context.invocation_id] = verdict
+        elif verdict.blocks:
+            return LlmResponse(
+                content=types.Content(role="model", parts=[types.Part(text=BLOCKED_INPUT)]),
+                custom_metadata={METADATA_KEY: verdict.metadata()},
+                turn_complete=True,
+            )
+        else:
+            # The contents are shared with the session's events, which keep
+            # the message as the user sent it.
+            llm_request.contents[-1] = _with_text(content, self.apply(text, verdict, BLOCKED_INPUT))
+        return None
+
+
+def _is_text(part: types.Part) -> bool:
+    return bool(part.text) and not part.thought
+
+
+def _has_text(content: types.Content) -> bool:
+    return any(_is_text(p) for p in content.parts or [])
+
+
+def _is_user_text(content: types.Content) -> bool:
+    """Whether content is a message from the user, rather than the result of a tool call."""
+    return content.role == "user" and _has_text(content)
+
+
+def _text_of(content: types.Content) -> str:
+    return "".join(p.text for p in content.parts or [] if _is_text(p))
+
+
+def _without_text(content: types.Content) -> types.Content:
+    return types.Content(role=content.role, parts=[p for p in content.parts or [] if not _is_text(p)])
+
+
+def _with_text(content: types.Content, text: str) -> types.Content:
+    """Return a copy of content whose text parts are replaced by one part holding text, in the place of the first."""
+    parts: list[types.Part] = []
+    replaced = False
+    for part in content.parts or []:
+        if not _is_text(part):
+            parts.append(part)
+        elif not replaced:
+            parts.append(types.Part(text=text))
+            replaced = True
+    return types.Content(role=content.role, parts=parts)
+
+
+def make_moderator(config: ModerationConfig) -> Moderator:
+    """Create a Moderator authenticated with the API key from the environment."""
+    return Moderator(config, api_key=os.environ.get(API_KEY_ENV))
@@ -0,0 +1,78 @@
+"""Timeouts of model requests, tool calls and tasks.
+
+Mirrors the Go ADK behavior in ``go/adk/pkg/models/timeout.go``,
+``go/adk/pkg/tooltimeout/tooltimeout.go`` and ``go/adk/pkg/a2a/executor.go``.
+A model request that times out raises ``TimeoutError``, so ``FallbackLlm``
+can fail over; a tool call that times out reaches the model as a tool error;
+a task that times out is marked failed. Durations are in seconds.
+"""
+
+from __future__ import annotations
+
+import asyncio
+from typing import AsyncGenerator, Optional
+
+from google.adk.models.base_llm import BaseLlm
+from google.adk.models.llm_request import LlmRequest
+from google.adk.models.llm_response import LlmResponse
+from pydantic import BaseModel
+
+
+class TimeoutsConfig(BaseModel):
+    llm_request: float | None = None
+    tool_call: float | None = None
+    tools: dict[str, float] | None = None  # Overrides tool_call by tool name
+    task: float | None = None
+
+    def tool_timeout(self, name: str) -> Optional[float]:
+        """Return how long a call of the named tool may take, or None when it is not bounded."""
+        if self.tools and name in self.tools:
+            return self.tools[name]
+        return self.tool_call
+
+
+class TimeoutLlm(BaseLlm):
+    """Bounds each request to ``inner``, including the streaming of its response."""
+
+    model_config = {"arbitrary_types_allowed": True}
+
+    inner: BaseLlm
+    timeout: float
+
+    def __init__(self, inner: BaseLlm, timeout: float, **kwargs):
+        super().__init__(model=inner.model, inner=inner, timeout=timeout, **kwargs)
+
+    @property
+    def api_key_passthrough(self) -> bool:
+        return getattr(self.inner, "api_key_passthrough", False)
+
+    def set_passthrough_key(self, token: str) -> None:
+        self.inner.set_passthrough_key(token)
+
+    async def generate_content_async(
+        self, llm_request: LlmRequest, stream: bool = False
+    ) -> AsyncGenerator[LlmResponse, None]:
+        deadline = asyncio.get_running_loop().time() + self.timeout
+        agen = self.inner.generate_content_async(llm_request, stream=stream)
+        try:
+            while True:
+                timeout = asyncio.timeout_at(deadline)
+                try:
+                    async with timeout:
+                        response = await anext(agen)
+                except StopAsyncIteration:
+                    return
+                except TimeoutError as e:
+                    if not timeout.expired():
+                        raise
+                    raise TimeoutError(f"request to model {self.model} timed out after {self.timeout:g}s") from e
+                yield response
+        finally:
+            await agen.aclose()
+
+
+def with_llm_timeout(llm: BaseLlm, timeouts: Optional[TimeoutsConfig]) -> BaseLlm:
+    """Return llm with its requests bounded by the configured timeout, or llm itself when there is none."""
+    if timeouts is None or not timeouts.llm_request:
+        return llm
+    return TimeoutLlm(llm, timeouts.llm_request)
@@ -0,0 +1,22 @@
+"""Tool-name allow/deny policy for MCP toolsets.
+
+Mirrors ``ToolPolicy`` in ``go/api/adk/types.go``. Entries are glob patterns
+such as ``kubectl_*`` or ``*delete*``; a tool is usable when it matches an
+allow entry (or allow is empty) and no deny entry.
+"""
+
+from __future__ import annotations
+
+from fnmatch import fnmatchcase
+
+from pydantic import BaseModel
+
+
+class ToolPolicy(BaseModel):
+    allow: list[str] | None = None
+    deny: list[str] | None = None
+
+    def allows(self, name: str) -> bool:
+        if self.allow and not any(fnmatchcase(name, pattern) for pattern in self.allow):
+            return False
+        return not any(fnmatchcase(name, pattern) for pattern in self.deny or [])
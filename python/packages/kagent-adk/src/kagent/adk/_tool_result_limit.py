@@ -0,0 +1,197 @@
+"""Size limits on MCP tool results.
+
+Mirrors the Go ADK behavior in ``go/adk/pkg/toolresult/toolresult.go``. A single
+``kubectl get -o json`` can be larger than the model's context window, so
+``ConnectionSafeMcpTool`` passes each result through a ``ToolResultLimiter``
+before it is added to the conversation. Results over the limit are cut down
+with the configured strategy and carry a note telling the model what was left
+out:
+
+- ``head`` / ``tail`` keep the beginning / end of the result.
+- ``summarize`` replaces the result with a summary written by the agent's
+  model, falling back to ``head`` when that fails.
+- ``paginate`` returns the first page, keeps the full result in session state
+  and lets the model read the other pages with ``read_tool_result_page``.
+"""
+
+from __future__ import annotations
+
+import json
+import logging
+from typing import Any, Literal
+
+from google.adk.models.llm_request import LlmRequest
+from google.adk.tools.function_tool import FunctionTool
+from google.adk.tools.tool_context import ToolContext
+from google.genai.types import Content, Part
+from pydantic import BaseModel
+
+logger = logging.getLogger("kagent_adk." + __name__)
+
+# Added to every limited tool result.
+NOTE_KEY = "kagent_truncation_note"
+# Tool the model calls to read the later pages of a paginated result.
+PAGE_TOOL_NAME = "read_tool_result_page"
+# Namespaces paginated results in session state; the function call ID completes the key.
+_STATE_KEY_PREFIX = "kagent_tool_result:"
+# Bounds how much of an oversized result is sent to the model for
+# summarization, as a multiple of the limit.
+_SUMMARY_INPUT_FACTOR = 16
+
+
+class ToolResultLimitConfig(BaseModel):
+    max_bytes: int
+    strategy: Literal["head", "tail", "summarize", "paginate"] = "head"
+    exclude_tools: list[str] | None = None
+
+
+def _head(text: str, n: int) -> str:
+    """Return at most n UTF-8 bytes from the start of text without splitting a character."""
+    return text.encode()[:n].decode(errors="ignore")
+
+
+def _tail(text: str, n: int) -> str:
+    """Return at most n UTF-8 bytes from the end of text without splitting a character."""
+    data = text.encode()
+    return data[max(len(data) - n, 0) :].decode(errors="ignore")
+
+
+def _split(text: str, n: int) -> list[str]:
+    """Cut text into pages of at most n UTF-8 bytes without splitting characters."""
+    pages: list[str] = []
+    while len(text.encode()) > n:
+        # n is smaller than the character; take it whole rather than loop.
+        page = _head(text, n) or text[0]
+        pages.append(page)
+        text = text[len(page) :]
+    pages.append(text)
+    return pages
+
+
+def _result_text(result: Any) -> str:
+    """Return the text the model would see for an MCP tool result."""
+    if isinstance(result, dict):
+        if result.get("structuredContent") is not None:
+            return json.dumps(result["structuredContent"])
+        content = result.get("content")
+        if isinstance(content, list) and all(isinstance(c, dict) and c.get("type") == "text" for c in content):
+            return "".join(c.get("text", "") for c in content)
+    if isinstance(result, str):
+        return result
+    return json.dumps(result, default=str)
+
+
+def _head_note(total: int, limit: int, kept: int) -> str:
+    return (
+        f"This tool result was {total} bytes, over the {limit}-byte limit. Only the first {kept} bytes are shown; "
+        "the rest was cut off. If you need the missing part, call the tool again with narrower arguments."
+    )
+
+
+class ToolResultLimiter:
+    """Applies the configured size limit to tool results."""
+
+    def __init__(self, config: ToolResultLimitConfig) -> None:
+        if config.max_bytes <= 0:
+            raise ValueError(f"invalid tool result limit {config.max_bytes}: must be positive")
+        self.max_bytes = config.max_bytes
+        self.strategy = config.strategy
+        self.exclude_tools = set(config.exclude_tools or [])
+
+    async def apply(self, tool_name: str, result: Any, tool_context: ToolContext) -> dict | None:
+        """Return None when the result is within the limit or the tool is
+        excluded, otherwise the result the model should see."""
+        if result is None or tool_name in self.exclude_tools:
+            return None
+        if isinstance(result, dict) and result.get("isError"):
+            return None
+        text = _result_text(result)
+        size = len(text.encode())
+        if size <= self.max_bytes:
+            return None
+
+        if self.strategy == "tail":
+            out = _tail(text, self.max_bytes)
+            note = (
+                f"This tool result was {size} bytes, over the {self.max_bytes}-byte limit. "
+                f"Only the last {len(out.encode())} bytes are shown; the beginning was cut off."
+            )
+        elif self.strategy == "summarize":
+            out, note = await self._summarize(tool_name, text, size, tool_context)
+        elif self.strategy == "paginate":
+            out, note = self._paginate(text, size, tool_context)
+        else:
+            out = _head(text, self.max_bytes)
+            note = _head_note(size, self.max_bytes, len(out.encode()))
+        logger.info(
+            "Tool result over the size limit",
+            extra={
+                "tool": tool_name,
+                "strategy": self.strategy,
+                "bytes": size,
+                "limit": self.max_bytes,
+                "function_call_id": tool_context.function_call_id,
+            },
+        )
+        return {"content": [{"type": "text", "text": out}], "isError": False, NOTE_KEY: note}
+
+    async def _summarize(self, tool_name: str, text: str, size: int, tool_context: ToolContext) -> tuple[str, str]:
+        """Ask the agent's model for a summary that fits the limit, falling back
+        to the head of the result when that fails."""
+        summary_input = _head(text, self.max_bytes * _SUMMARY_INPUT_FACTOR)
+        prompt = (
+            f'Summarize the following output of the tool "{tool_name}" in at most {self.max_bytes} characters.\n'
+            "Keep identifiers, names, counts, error messages and anything that looks abnormal; "
+            "drop repetitive detail.\nOutput only the summary.\n\n"
+            f"Tool output:\n{summary_input}"
+        )
+        summary = ""
+        try:
+            model = tool_context._invocation_context.agent.canonical_model
+            request = LlmRequest(model=model.model, contents=[Content(role="user", parts=[Part(text=prompt)])])
+            async for response in model.generate_content_async(request, stream=False):
+                if response.content and response.content.parts:
+                    summary += "".join(part.text for part in response.content.parts if part.text)
+        except Exception:
+            logger.exception("Failed to summarize tool result %s, keeping its beginning instead", tool_name)
+            summary = ""
+        summary = summary.strip()
+        if not summary:
+            kept = _head(text, self.max_bytes)
+            return kept, _head_note(size, self.max_bytes, len(kept.encode()))
+
+        note = f"This tool result was {size} bytes, over the {self.max_bytes}-byte limit, and was replaced by a summary."
+        if len(summary_input) < len(text):
+            note += f" Only the first {len(summary_input.encode())} bytes were summarized."
+        note += " Call the tool again with narrower arguments if you need exact details."
+        return _head(summary, self.max_bytes), note
+
+    def _paginate(self, text: str, size: int, tool_context: ToolContext) -> tuple[str, str]:
+        """Store the full result in session state and return its first page."""
+        pages = _split(text, self.max_bytes)
+        result_id = tool_context.function_call_id
+        tool_context.state[_STATE_KEY_PREFIX + result_id] = text
+        note = (
+            f"This tool result was {size} bytes, over the {self.max_bytes}-byte limit, and was split into "
+            f"{len(pages)} pages. This is page 1. To read another page, call {PAGE_TOOL_NAME} with "
+            f'result_id "{result_id}" and the page number (2 to {len(pages)}). Only read the pages you need.'
+        )
+        return pages[0], note
+
+    def read_page(self, result_id: str, page: int, tool_context: ToolContext) -> dict:
+        text = tool_context.state.get(_STATE_KEY_PREFIX + result_id)
+        if text is None:
+            return {"error": f'no paginated tool result with result_id "{result_id}"'}
+        pages = _split(text, self.max_bytes)
+        if page < 1 or page > len(pages):
+            return {"error": f"page {page} is out of range: the result has {len(pages)} pages"}
+        return {"output": pages[page - 1], "page": page, "pages": len(pages)}
+
+    def page_tool(self) -> FunctionTool:
+        """Return the tool that reads the later pages of paginated results."""
+
+        def read_tool_result_page(result_id: str, page: int, tool_context: ToolContext) -> dict:
+            """Read a page of a tool result that was too large to return at once and was split into pages."""
+            return self.read_page(result_id, page, tool_context)
+
+        return FunctionTool(read_tool_result_page)
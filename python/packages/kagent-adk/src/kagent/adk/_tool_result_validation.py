@@ -0,0 +1,155 @@
+"""Output schema validation of MCP tool results.
+
+Mirrors the Go ADK behavior in ``go/adk/pkg/toolschema/toolschema.go``.
+``ConnectionSafeMcpTool`` passes each result of a tool that declares an
+``outputSchema`` through a ``ToolResultValidator`` before it is added to the
+conversation. A result that does not match is replaced by a tool error naming
+the violation, so the model does not reason over malformed data. With
+``coerce`` set, values that only differ from the schema in their JSON type,
+such as numbers sent as strings, are converted instead of rejected.
+"""
+
+from __future__ import annotations
+
+import json
+import logging
+from typing import Any
+
+from jsonschema.exceptions import best_match
+from jsonschema.validators import validator_for
+from pydantic import BaseModel
+
+logger = logging.getLogger("kagent_adk." + __name__)
+
+# Holds, on a rejected result, why it did not match the schema.
+VIOLATION_KEY = "kagent_schema_violation"
+
+
+class ToolResultValidationConfig(BaseModel):
+    coerce: bool = True
+    exclude_tools: list[str] | None = None
+
+
+def _allows(schema: dict, json_type: str) -> bool:
+    """Report whether the schema declares the JSON type. A schema without a
+    type declares none, so nothing is coerced to match it."""
+    declared = schema.get("type")
+    if isinstance(declared, list):
+        return json_type in declared
+    return declared == json_type
+
+
+def _coerce(schema: Any, value: Any) -> Any:
+    """Return value with the scalars whose JSON type the schema does not allow
+    converted to one it does, where that is lossless."""
+    if not isinstance(schema, dict):
+        return value
+    if isinstance(value, str):
+        if _allows(schema, "string"):
+            return value
+        trimmed = value.strip()
+        if _allows(schema, "integer"):
+            try:
+                return int(trimmed)
+            except ValueError:
+                pass
+        if _allows(schema, "number"):
+            try:
+                return float(trimmed)
+            except ValueError:
+                pass
+        if _allows(schema, "boolean") and trimmed.lower() in ("true", "false"):
+            return trimmed.lower() == "true"
+        return value
+    if isinstance(value, bool):
+        if _allows(schema, "string") and not _allows(schema, "boolean"):
+            return "true" if value else "false"
+        return value
+    if isinstance(value, (int, float)):
+        if _allows(schema, "string") and not _allows(schema, "number") and not _allows(schema, "integer"):
+            return json.dumps(value)
+        return value
+    if isinstance(value, dict):
+        properties = schema.get("properties") or {}
+        additional = schema.get("additionalProperties")
+        return {key: _coerce(properties.get(key, additional), item) for key, item in value.items()}
+    if isinstance(value, list):
+        prefix = schema.get("prefixItems") or []
+        items = schema.get("items")
+        return [_coerce(prefix[i] if i < len(prefix) else items, item) for i, item in enumerate(value)]
+    return value
+
+
+def _structured_value(result: dict) -> tuple[Any, bool]:
+    """Return the JSON value of an MCP tool result and whether it had to be
+    parsed from text content. Raises ValueError when the text is not JSON."""
+    if result.get("structuredContent") is not None:
+        return result["structuredContent"], False
+    content = result.get("content") or []
+    text = "".join(c.get("text", "") for c in content if isinstance(c, dict) and c.get("type") == "text")
+    try:
+        return json.loads(text), True
+    except json.JSONDecodeError as e:
+        raise ValueError(f"result is not JSON: {e}") from e
+
+
+def _first_violation(validator: Any, value: Any) -> str | None:
+    error = best_match(validator.iter_errors(value))
+    if error is None:
+        return None
+    path = "".join(f"[{p}]" if isinstance(p, int) else f".{p}" for p in error.absolute_path)
+    return f"{path or '.'}: {error.message}"
+
+
+class ToolResultValidator:
+    """Checks tool results against their tool's output schema."""
+
+    def __init__(self, config: ToolResultValidationConfig) -> None:
+        self.coerce = config.coerce
+        self.exclude_tools = set(config.exclude_tools or [])
+
+    def apply(self, tool_name: str, output_schema: dict | None, result: Any) -> dict | None:
+        """Return None when the result needs no change: the tool is excluded,
+        declares no output schema, or its result matches the schema as is.
+        Otherwise return the coerced result, or a tool error when the result
+        does not match."""
+        if not output_schema or tool_name in self.exclude_tools or not isinstance(result, dict):
+            return None
+        if result.get("isError"):
+            return None
+        try:
+            value, parsed = _structured_value(result)
+        except ValueError as e:
+            return self._reject(tool_name, str(e))
+
+        try:
+            validator = validator_for(output_schema)(output_schema)
+        except Exception:
+            logger.exception("Ignoring unusable output schema of tool %s", tool_name)
+            return None
+
+        violation = _first_violation(validator, value)
+        if violation is not None and self.coerce:
+            coerced = _coerce(output_schema, value)
+            if _first_violation(validator, coerced) is None:
+                logger.debug("Coerced result of tool %s to its output schema: %s", tool_name, violation)
+                return {**result, "structuredContent": coerced}
+        if violation is not None:
+            return self._reject(tool_name, violation)
+        if parsed:
+            return {**result, "structuredContent": value}
+        return None
+
+    def _reject(self, tool_name: str, violation: str) -> dict:
+        logger.info(
+            "Tool result does not match its output schema",
+            extra={"tool": tool_name, "violation": violation},
+        )
+        return {
+            "error": (
+                f"Tool {tool_name} returned a result that does not match its declared output schema, "
+                "so the result was discarded. Do not guess its content; call the tool again or tell "
+                "the user it returned malformed data."
+            ),
+            VIOLATION_KEY: violation,
+        }
@@ -14,7 +14,7 @@ from google.adk.cli.utils.agent_loader import AgentLoader
 from kagent.core import KAgentConfig, configure_logging, configure_tracing
 
 from . import AgentConfig, KAgentApp
-from ._config_materialize import materialize_from_env
+from ._config_materialize import load_api_key_files, materialize_from_env
 from .tools import add_skills_tool_to_agent
 
 logger = logging.getLogger(__name__)
@@ -64,6 +64,8 @@ def static(
     # On Agent Substrate the config is injected as secret-backed env vars rather than mounted
     # files; materialize them into `filepath` before loading. No-op on the Deployment path.
     materialize_from_env(filepath)
+    # Keys of ModelConfigs with apiKeyFrom.file are read from the pod, e.g. a CSI volume.
+    load_api_key_files()
 
     with open(os.path.join(filepath, "config.json"), "r") as f:
         config = json.load(f)
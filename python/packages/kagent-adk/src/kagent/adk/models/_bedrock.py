@@ -82,12 +82,15 @@ def _sanitize_tool_id(tool_id: str, id_map: dict[str, str], counter: list[int])
 
 def _get_bedrock_client(
     extra_headers: Optional[dict[str, str]] = None,
+    endpoint: Optional[str] = None,
     tls_disable_verify: Optional[bool] = None,
     tls_ca_cert_path: Optional[str] = None,
     tls_disable_system_cas: Optional[bool] = None,
 ):
     region = os.environ.get("AWS_DEFAULT_REGION") or os.environ.get("AWS_REGION") or "us-east-1"
     kwargs: dict[str, Any] = {"region_name": region}
+    if endpoint:
+        kwargs["endpoint_url"] = endpoint
 
     if extra_headers:
         # boto3 doesn't support custom headers natively; log and ignore
@@ -268,6 +271,8 @@ class KAgentBedrockLlm(KAgentTLSMixin, BaseLlm):
     """
 
     extra_headers: Optional[dict[str, str]] = None
+    # Overrides the regional bedrock-runtime endpoint, e.g. with a VPC endpoint.
+    endpoint: Optional[str] = None
     additional_model_request_fields: Optional[dict[str, Any]] = None
     # When True, append a CachePoint block to the end of the Converse
     # request's `system` content array and the end of the `toolConfig.tools`
@@ -286,6 +291,7 @@ class KAgentBedrockLlm(KAgentTLSMixin, BaseLlm):
     def _client(self):
         return _get_bedrock_client(
             extra_headers=self.extra_headers,
+            endpoint=self.endpoint,
             tls_disable_verify=self.tls_disable_verify,
             tls_ca_cert_path=self.tls_ca_cert_path,
             tls_disable_system_cas=self.tls_disable_system_cas,
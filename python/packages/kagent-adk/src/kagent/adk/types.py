@@ -13,10 +13,21 @@ from google.adk.models.google_llm import Gemini as GeminiLLM
 from google.adk.tools.mcp_tool import SseConnectionParams, StreamableHTTPConnectionParams
 from pydantic import AliasChoices, BaseModel, Field, field_validator, model_validator
 
+from kagent.adk import _budget
 from kagent.adk._approval import make_approval_callback, strip_confirmation_parts_callback
+from kagent.adk._budget import BudgetConfig
+from kagent.adk._dry_run import make_dry_run_callback
 from kagent.adk._mcp_apps import MCPAppToolNames, make_mcp_app_model_result_callback
+from kagent.adk._mcp_oauth2 import OAuth2ClientCredentialsConfig, with_oauth2
 from kagent.adk._mcp_toolset import KAgentMcpToolset
+from kagent.adk._model_fallback import Fallback, FallbackLlm
+from kagent.adk._moderation import ModerationConfig, make_moderator
+from kagent.adk._prompt_guard import PromptInjectionConfig, make_prompt_guard_callback
 from kagent.adk._remote_a2a_tool import KAgentRemoteA2AToolset
+from kagent.adk._tool_policy import ToolPolicy
+from kagent.adk._tool_result_limit import ToolResultLimitConfig, ToolResultLimiter
+from kagent.adk._timeouts import TimeoutsConfig, with_llm_timeout
+from kagent.adk._tool_result_validation import ToolResultValidationConfig, ToolResultValidator
 from kagent.adk.models._anthropic import KAgentAnthropicLlm
 from kagent.adk.models._bedrock import KAgentBedrockLlm
 from kagent.adk.models._gemini import KAgentGeminiLlm
@@ -181,6 +192,7 @@ class _McpTlsMixin(BaseModel):
     tls_insecure_skip_verify: bool | None = None
     tls_ca_cert_path: str | None = None
     tls_disable_system_cas: bool | None = None
+    oauth2: OAuth2ClientCredentialsConfig | None = None
     tools: list[str] = Field(default_factory=list)
 
     @model_validator(mode="before")
@@ -188,7 +200,7 @@ class _McpTlsMixin(BaseModel):
     def _lift_tls_from_params(cls, values: Any) -> Any:
         if isinstance(values, dict) and isinstance(values.get("params"), dict):
             params = values["params"]
-            for key in ("tls_insecure_skip_verify", "tls_ca_cert_path", "tls_disable_system_cas"):
+            for key in ("tls_insecure_skip_verify", "tls_ca_cert_path", "tls_disable_system_cas", "oauth2"):
                 if key in params and key not in values:
                     values[key] = params[key]
         return values
@@ -220,6 +232,24 @@ class _McpTlsMixin(BaseModel):
             )
 
 
+    def _apply_oauth2_to_params(self, params: Any) -> None:
+        """Authenticate every MCP session with the client credentials token.
+
+        Must run after ``_apply_tls_to_params`` so the wrapped factory keeps
+        the TLS settings.
+        """
+        if self.oauth2 is None:
+            return
+        if not hasattr(params, "httpx_client_factory"):
+            logger.warning(
+                "OAuth2 configuration ignored on %s: google-adk does not expose "
+                "httpx_client_factory on this params type — upgrade to >= 1.28.1.",
+                type(params).__name__,
+            )
+            return
+        params.httpx_client_factory = with_oauth2(params.httpx_client_factory, self.oauth2)
+
+
 class HttpMcpServerConfig(_McpTlsMixin):
     params: StreamableHTTPConnectionParams
     allowed_headers: list[str] | None = None
@@ -314,6 +344,9 @@ class Gemini(BaseLLM):
 
 class Bedrock(BaseLLM):
     region: str | None = None
+    # endpoint overrides the Bedrock runtime endpoint derived from the region,
+    # e.g. a VPC interface endpoint.
+    endpoint: str | None = None
     # additional_model_request_fields passes model-specific parameters to Bedrock's
     # additionalModelRequestFields in the Converse API. Use this for provider-specific
     # options outside the standard InferenceConfiguration block.
@@ -373,6 +406,13 @@ class NetworkConfig(BaseModel):
     allowed_domains: list[str] = Field(default_factory=list)
 
 
+class ModelFallback(BaseModel):
+    """A model to fail over to. Mirrors ``ModelFallback`` in ``go/api/adk/types.go``."""
+
+    model: ModelUnion = Field(discriminator="type")
+    triggers: list[Literal["server_error", "rate_limited", "timeout"]] | None = None
+
+
 class AgentConfig(BaseModel):
     model: ModelUnion = Field(discriminator="type")
     description: str
@@ -389,6 +429,15 @@ class AgentConfig(BaseModel):
     # Selects a local (in-actor) session store when set — substrate sandbox agents with
     # durable-dir session storage. Set by the controller in the rendered config.
     session_db_url: str | None = None
+    prompt_injection: PromptInjectionConfig | None = None  # Scan tool results for prompt injection
+    tool_policy: ToolPolicy | None = None  # Allow/deny MCP tools by name glob
+    model_fallbacks: list[ModelFallback] | None = None  # Tried in order when the model fails
+    tool_result_limit: ToolResultLimitConfig | None = None  # Cap the size of MCP tool results
+    tool_result_validation: ToolResultValidationConfig | None = None  # Check MCP tool results against their schema
+    dry_run_tools: list[str] | None = None  # Tools dry-run invocations preview; None uses the defaults
+    timeouts: TimeoutsConfig | None = None  # Bound model requests, tool calls and tasks
+    moderation: ModerationConfig | None = None  # Moderate model responses and, optionally, user messages
+    budget: BudgetConfig | None = None  # Bound the tokens, tool calls and model turns of tasks
 
     def to_agent(
         self, name: str, sts_integration: Optional[ADKTokenPropagationPlugin] = None, propagate_token: bool = False
@@ -400,6 +449,8 @@ class AgentConfig(BaseModel):
         # Names of MCP App (UI-rendering) tools, filled in lazily as MCP tools
         # are resolved; used to compact their results for the model.
         mcp_app_tool_names = MCPAppToolNames()
+        result_limiter = ToolResultLimiter(self.tool_result_limit) if self.tool_result_limit else None
+        result_validator = ToolResultValidator(self.tool_result_validation) if self.tool_result_validation else None
         sts_header_provider = None
         if sts_integration:
             sts_header_provider = sts_integration.header_provider
@@ -409,6 +460,7 @@ class AgentConfig(BaseModel):
                 # before constructing the toolset, so every MCP session
                 # the session manager opens trusts the configured CA.
                 http_tool._apply_tls_to_params(http_tool.params)
+                http_tool._apply_oauth2_to_params(http_tool.params)
                 # Create header provider combining STS and allowed headers for this tool
                 tool_header_provider = create_header_provider(
                     allowed_headers=http_tool.allowed_headers,
@@ -420,6 +472,10 @@ class AgentConfig(BaseModel):
                         tool_filter=http_tool.tools,
                         header_provider=tool_header_provider,
                         app_tool_names=mcp_app_tool_names,
+                        tool_policy=self.tool_policy,
+                        result_limiter=result_limiter,
+                        result_validator=result_validator,
+                        timeouts=self.timeouts,
                     )
                 )
                 if http_tool.require_approval:
@@ -427,6 +483,7 @@ class AgentConfig(BaseModel):
         if self.sse_tools:
             for sse_tool in self.sse_tools:  # add sse tools
                 sse_tool._apply_tls_to_params(sse_tool.params)
+                sse_tool._apply_oauth2_to_params(sse_tool.params)
                 # Create header provider combining STS and allowed headers for this tool
                 tool_header_provider = create_header_provider(
                     allowed_headers=sse_tool.allowed_headers,
@@ -438,6 +495,10 @@ class AgentConfig(BaseModel):
                         tool_filter=sse_tool.tools,
                         header_provider=tool_header_provider,
                         app_tool_names=mcp_app_tool_names,
+                        tool_policy=self.tool_policy,
+                        result_limiter=result_limiter,
+                        result_validator=result_validator,
+                        timeouts=self.timeouts,
                     )
                 )
                 if sse_tool.require_approval:
@@ -518,13 +579,35 @@ class AgentConfig(BaseModel):
                 )
 
         code_executor = SandboxedLocalCodeExecutor() if self.execute_code else None
-        model = _create_llm_from_model_config(self.model)
+        # Each model in the fallback chain gets the whole timeout, so a request
+        # that times out can still fail over.
+        model = with_llm_timeout(_create_llm_from_model_config(self.model), self.timeouts)
+        if self.model_fallbacks:
+            model = FallbackLlm(
+                primary=model,
+                fallbacks=[
+                    Fallback(
+                        llm=with_llm_timeout(_create_llm_from_model_config(f.model), self.timeouts),
+                        triggers=f.triggers,
+                    )
+                    for f in self.model_fallbacks
+                ],
+            )
+
+        if result_limiter is not None and result_limiter.strategy == "paginate":
+            tools.append(result_limiter.page_tool())
 
         # Add built-in ask_user tool unconditionally — every agent can ask the user questions.
         tools.append(AskUserTool())
 
-        # Build before_tool_callback if any tools require approval
-        before_tool_callback = make_approval_callback(tools_requiring_approval) if tools_requiring_approval else None
+        # before_tool callbacks run in order. Dry-run previews come first, so a
+        # call that is only previewed is not held for approval.
+        before_tool_callbacks = [make_dry_run_callback(self.dry_run_tools)]
+        if tools_requiring_approval:
+            before_tool_callbacks.append(make_approval_callback(tools_requiring_approval))
+        # Budgets can be set per invocation, so they are always wired. Calls held
+        # for approval count once they run.
+        before_tool_callbacks.append(_budget.before_tool)
         # before_model callbacks run in order. Strip synthetic HITL confirmation
         # parts (when approval is in play), then compact MCP App tool results so
         # the model treats a rendered widget as terminal instead of re-calling it.
@@ -532,6 +615,15 @@ class AgentConfig(BaseModel):
         if tools_requiring_approval:
             before_model_callbacks.append(strip_confirmation_parts_callback)
         before_model_callbacks.append(make_mcp_app_model_result_callback(mcp_app_tool_names))
+        before_model_callbacks.append(_budget.before_model)
+        after_tool_callback = make_prompt_guard_callback(self.prompt_injection) if self.prompt_injection else None
+        after_model_callbacks = [_budget.after_model]
+        if self.moderation is not None:
+            moderator = make_moderator(self.moderation)
+            after_model_callbacks.append(moderator.after_model)
+            # Input is moderated last, once the other callbacks have shaped the request.
+            if moderator.check_input:
+                before_model_callbacks.append(moderator.before_model)
 
         # static_instruction is sent directly to the model without any placeholder processing
         agent = Agent(
@@ -541,8 +633,10 @@ class AgentConfig(BaseModel):
             static_instruction=self.instruction,
             tools=tools,
             code_executor=code_executor,
-            before_tool_callback=before_tool_callback,
+            before_tool_callback=before_tool_callbacks,
             before_model_callback=before_model_callbacks,
+            after_model_callback=after_model_callbacks,
+            after_tool_callback=after_tool_callback,
         )
 
         # Configure memory if enabled
@@ -708,6 +802,7 @@ def _create_llm_from_model_config(model_config: ModelUnion):
         return KAgentBedrockLlm(
             model=model_config.model,
             extra_headers=extra_headers,
+            endpoint=model_config.endpoint,
             additional_model_request_fields=model_config.additional_model_request_fields,
             prompt_caching=model_config.prompt_caching,
             cache_ttl=model_config.cache_ttl,
@@ -0,0 +1,100 @@
+"""Tests for token, tool call and model turn budgets."""
+
+from types import SimpleNamespace
+
+import pytest
+from google.adk.models.llm_response import LlmResponse
+from google.genai import types
+
+from kagent.adk._budget import (
+    EXCEEDED_METADATA_KEY,
+    LIMIT_SESSION_TOKENS,
+    LIMIT_TOOL_CALLS,
+    NOT_EXECUTED,
+    BudgetConfig,
+    after_model,
+    before_model,
+    before_tool,
+    current_tracker,
+    new_tracker,
+    parse_override,
+    session_tokens,
+)
+
+
+def _tool(name: str):
+    return SimpleNamespace(name=name)
+
+
+def _response(text: str, tokens: int) -> LlmResponse:
+    return LlmResponse(
+        content=types.Content(role="model", parts=[types.Part(text=text)]),
+        usage_metadata=types.GenerateContentResponseUsageMetadata(total_token_count=tokens),
+    )
+
+
+@pytest.fixture
+def run():
+    tokens = []
+
+    def start(limits: BudgetConfig, used: int = 0):
+        tracker = new_tracker(limits, used)
+        tokens.append(current_tracker.set(tracker))
+        return tracker
+
+    yield start
+    for token in reversed(tokens):
+        current_tracker.reset(token)
+
+
+def test_no_budget():
+    assert before_model(None, None) is None
+    assert before_tool(_tool("k8s_get_resources"), {}, None) is None
+    assert new_tracker(BudgetConfig()) is None
+
+
+def test_tool_calls(run):
+    tracker = run(BudgetConfig(max_tool_calls=2))
+    assert before_model(None, None) is None
+    after_model(None, _response("Checking the pods.", 100))
+    for _ in range(2):
+        assert before_tool(_tool("k8s_get_resources"), {}, None) is None
+    result = before_tool(_tool("k8s_get_resources"), {}, None)
+    assert result["executed"] is False
+    assert result["result"] == NOT_EXECUTED
+
+    response = before_model(None, None)
+    assert response is not None
+    summary = response.content.parts[0].text
+    assert "budget of 2 tool calls" in summary
+    assert "1 model turns, 2 tool calls and 100 tokens" in summary
+    assert "k8s_get_resources (2)" in summary
+    assert "Checking the pods." in summary
+    assert response.custom_metadata[EXCEEDED_METADATA_KEY] == {"limit": LIMIT_TOOL_CALLS, "max": 2, "used": 2}
+    assert tracker.exceeded.limit == LIMIT_TOOL_CALLS
+
+
+def test_turns(run):
+    run(BudgetConfig(max_turns=1))
+    assert before_model(None, None) is None
+    response = before_model(None, None)
+    assert response is not None and EXCEEDED_METADATA_KEY in response.custom_metadata
+
+
+def test_session_tokens(run):
+    events = [SimpleNamespace(usage_metadata=types.GenerateContentResponseUsageMetadata(total_token_count=900))]
+    tracker = run(BudgetConfig(max_session_tokens=1000), session_tokens(events))
+    assert before_model(None, None) is None
+    after_model(None, _response("", 150))
+    assert before_tool(_tool("helm_list"), {}, None) is not None
+    assert tracker.exceeded.limit == LIMIT_SESSION_TOKENS
+    assert tracker.exceeded.used == 1050
+
+
+def test_tighten():
+    agent_budget = BudgetConfig(max_tool_calls=20, max_turns=10)
+    override = parse_override({"max_tool_calls": 50, "max_turns": 5, "max_total_tokens": 10000})
+    got = agent_budget.tighten(override)
+    assert got == BudgetConfig(max_tool_calls=20, max_turns=5, max_total_tokens=10000)
+    assert agent_budget.tighten(None) == agent_budget
+    assert parse_override(None) is None
@@ -2,7 +2,7 @@ import os
 
 import pytest
 
-from kagent.adk._config_materialize import materialize_from_env
+from kagent.adk._config_materialize import load_api_key_files, materialize_from_env
 
 
 def test_materializes_present_env_vars(tmp_path, monkeypatch):
@@ -79,3 +79,21 @@ def test_unwritable_token_path_does_not_crash(tmp_path, monkeypatch):
     materialize_from_env(str(config_dir))  # must not raise
 
     assert (config_dir / "config.json").exists()
+
+
+def test_loads_api_key_files(tmp_path, monkeypatch):
+    key_file = tmp_path / "openai"
+    key_file.write_text("sk-test\n")
+    monkeypatch.setenv("OPENAI_API_KEY_FILE", str(key_file))
+    monkeypatch.delenv("OPENAI_API_KEY", raising=False)
+
+    load_api_key_files()
+
+    assert os.environ["OPENAI_API_KEY"] == "sk-test"
+
+
+def test_missing_api_key_file_fails(tmp_path, monkeypatch):
+    monkeypatch.setenv("ANTHROPIC_API_KEY_FILE", str(tmp_path / "missing"))
+
+    with pytest.raises(FileNotFoundError):
+        load_api_key_files()
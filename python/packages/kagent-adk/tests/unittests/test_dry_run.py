@@ -0,0 +1,54 @@
+"""Tests for dry-run previews of mutating tool calls."""
+
+from types import SimpleNamespace
+
+import pytest
+
+from kagent.adk._dry_run import ARG_NAME, NOT_EXECUTED, dry_run_enabled, make_dry_run_callback
+
+
+def _tool(name: str, properties: dict | None = None):
+    return SimpleNamespace(
+        name=name,
+        raw_mcp_tool=SimpleNamespace(inputSchema={"type": "object", "properties": properties or {}}),
+    )
+
+
+@pytest.fixture
+def dry_run():
+    token = dry_run_enabled.set(True)
+    yield
+    dry_run_enabled.reset(token)
+
+
+def test_not_a_dry_run_runs_the_tool():
+    callback = make_dry_run_callback(None)
+    args = {"manifest": "kind: Pod"}
+    assert callback(_tool("k8s_apply_manifest"), args, None) is None
+    assert args == {"manifest": "kind: Pod"}
+
+
+def test_unmatched_tool_runs(dry_run):
+    callback = make_dry_run_callback(["helm_*"])
+    assert callback(_tool("k8s_get_resources"), {}, None) is None
+
+
+def test_tool_with_dry_run_parameter_runs_with_it_set(dry_run):
+    callback = make_dry_run_callback(None)
+    args = {"manifest": "kind: Pod"}
+    assert callback(_tool("k8s_apply_manifest", {"manifest": {}, ARG_NAME: {}}), args, None) is None
+    assert args[ARG_NAME] is True
+
+
+def test_tool_without_dry_run_parameter_is_not_run(dry_run):
+    callback = make_dry_run_callback(None)
+    args = {"release": "kagent"}
+    result = callback(_tool("helm_upgrade"), args, None)
+    assert result == {
+        "dry_run": True,
+        "executed": False,
+        "tool": "helm_upgrade",
+        "args": {"release": "kagent"},
+        "result": NOT_EXECUTED,
+    }
+    assert ARG_NAME not in args
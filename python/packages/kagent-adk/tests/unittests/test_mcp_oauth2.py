@@ -0,0 +1,134 @@
+"""Tests for OAuth2 client credentials authentication of MCP servers."""
+
+import base64
+from unittest import mock
+from urllib.parse import parse_qs
+
+import httpx
+import pytest
+from google.adk.tools.mcp_tool import StreamableHTTPConnectionParams
+
+from kagent.adk._mcp_oauth2 import OAuth2ClientCredentialsAuth, OAuth2ClientCredentialsConfig, with_oauth2
+from kagent.adk.types import HttpMcpServerConfig
+
+_AsyncClient = httpx.AsyncClient
+
+
+def _config(**kwargs) -> OAuth2ClientCredentialsConfig:
+    return OAuth2ClientCredentialsConfig(
+        token_url="https://idp.example.com/token", client_id="kagent", client_secret="s3cret", **kwargs
+    )
+
+
+class _TokenServer:
+    def __init__(self, expires_in=3600):
+        self.requests: list[httpx.Request] = []
+        self.expires_in = expires_in
+
+    def handler(self, request: httpx.Request) -> httpx.Response:
+        self.requests.append(request)
+        body = {"access_token": f"token-{len(self.requests)}", "token_type": "Bearer"}
+        if self.expires_in is not None:
+            body["expires_in"] = self.expires_in
+        return httpx.Response(200, json=body)
+
+    def patch(self):
+        transport = httpx.MockTransport(self.handler)
+        return mock.patch(
+            "kagent.adk._mcp_oauth2.httpx.AsyncClient",
+            side_effect=lambda **kwargs: _AsyncClient(transport=transport, **kwargs),
+        )
+
+
+def _mcp_server(seen: list[str], reject_first: bool = False) -> httpx.MockTransport:
+    def handler(request: httpx.Request) -> httpx.Response:
+        seen.append(request.headers.get("Authorization"))
+        if reject_first and len(seen) == 1:
+            return httpx.Response(401)
+        return httpx.Response(200)
+
+    return httpx.MockTransport(handler)
+
+
+@pytest.mark.asyncio
+async def test_token_is_cached_and_replaces_static_authorization():
+    tokens = _TokenServer()
+    seen: list[str] = []
+    auth = OAuth2ClientCredentialsAuth(_config(scopes=["mcp.read", "mcp.write"], audience="https://mcp"))
+    with tokens.patch():
+        async with _AsyncClient(transport=_mcp_server(seen), auth=auth) as client:
+            for _ in range(2):
+                await client.get("https://mcp.example.com/mcp", headers={"Authorization": "Bearer static"})
+
+    assert seen == ["Bearer token-1", "Bearer token-1"]
+    assert len(tokens.requests) == 1
+    request = tokens.requests[0]
+    assert request.headers["Authorization"] == "Basic " + base64.b64encode(b"kagent:s3cret").decode()
+    assert parse_qs(request.content.decode()) == {
+        "grant_type": ["client_credentials"],
+        "scope": ["mcp.read mcp.write"],
+        "audience": ["https://mcp"],
+    }
+
+
+@pytest.mark.asyncio
+async def test_expired_token_is_refreshed():
+    tokens = _TokenServer(expires_in=5)  # within the expiry delta
+    seen: list[str] = []
+    with tokens.patch():
+        async with _AsyncClient(transport=_mcp_server(seen), auth=OAuth2ClientCredentialsAuth(_config())) as client:
+            await client.get("https://mcp.example.com/mcp")
+            await client.get("https://mcp.example.com/mcp")
+    assert seen == ["Bearer token-1", "Bearer token-2"]
+
+
+@pytest.mark.asyncio
+async def test_rejected_token_is_replaced_once():
+    tokens = _TokenServer()
+    seen: list[str] = []
+    with tokens.patch():
+        async with _AsyncClient(
+            transport=_mcp_server(seen, reject_first=True), auth=OAuth2ClientCredentialsAuth(_config())
+        ) as client:
+            response = await client.get("https://mcp.example.com/mcp")
+    assert response.status_code == 200
+    assert seen == ["Bearer token-1", "Bearer token-2"]
+
+
+def test_wrapped_factory_passes_shared_auth():
+    inner = mock.Mock()
+    factory = with_oauth2(inner, _config())
+    factory(headers={"x": "1"})
+    factory()
+    first, second = inner.call_args_list
+    assert first.kwargs["headers"] == {"x": "1"}
+    assert isinstance(first.kwargs["auth"], OAuth2ClientCredentialsAuth)
+    assert first.kwargs["auth"] is second.kwargs["auth"]
+
+
+def test_lifts_oauth2_from_params_and_installs_factory():
+    cfg = HttpMcpServerConfig.model_validate(
+        {
+            "params": {
+                "url": "https://mcp.example.com/mcp",
+                "oauth2": {
+                    "token_url": "https://idp.example.com/token",
+                    "client_id": "kagent",
+                    "client_secret": "s3cret",
+                },
+            },
+        }
+    )
+    assert cfg.oauth2 == _config()
+
+    original = cfg.params.httpx_client_factory
+    cfg._apply_oauth2_to_params(cfg.params)
+    assert cfg.params.httpx_client_factory is not original
+
+
+def test_no_oauth2_leaves_factory_default():
+    params = StreamableHTTPConnectionParams(url="https://mcp.example.com/mcp")
+    original = params.httpx_client_factory
+    cfg = HttpMcpServerConfig(params=params)
+    cfg._apply_oauth2_to_params(cfg.params)
+    assert cfg.params.httpx_client_factory is original
@@ -0,0 +1,112 @@
+"""Tests for failover between models."""
+
+from typing import AsyncGenerator
+
+import httpx
+import pytest
+from google.adk.models.base_llm import BaseLlm
+from google.adk.models.llm_request import LlmRequest
+from google.adk.models.llm_response import LlmResponse
+from google.genai import types
+
+from kagent.adk._model_fallback import (
+    FALLBACK_INDEX_KEY,
+    RATE_LIMITED,
+    SERVED_MODEL_KEY,
+    SERVER_ERROR,
+    TIMEOUT,
+    Fallback,
+    FallbackLlm,
+    classify_exception,
+)
+
+
+class _StatusError(Exception):
+    def __init__(self, status_code: int):
+        super().__init__(f"status {status_code}")
+        self.status_code = status_code
+
+
+class _FakeLlm(BaseLlm):
+    """Yields ``text`` or raises ``error``, recording the requested model names."""
+
+    text: str = ""
+    error: Exception | None = None
+    calls: list[str] = []
+
+    async def generate_content_async(
+        self, llm_request: LlmRequest, stream: bool = False
+    ) -> AsyncGenerator[LlmResponse, None]:
+        self.calls.append(llm_request.model)
+        if self.error is not None:
+            raise self.error
+        yield LlmResponse(content=types.Content(role="model", parts=[types.Part(text=self.text)]))
+
+
+async def _collect(llm: BaseLlm) -> list[LlmResponse]:
+    request = LlmRequest(model=llm.model)
+    return [r async for r in llm.generate_content_async(request)]
+
+
+@pytest.mark.parametrize(
+    "exc,expected",
+    [
+        (_StatusError(429), RATE_LIMITED),
+        (_StatusError(503), SERVER_ERROR),
+        (_StatusError(504), TIMEOUT),
+        (_StatusError(400), None),
+        (httpx.ReadTimeout("read timed out"), TIMEOUT),
+        (RuntimeError("upstream overloaded"), SERVER_ERROR),
+        (ValueError("invalid tool schema"), None),
+    ],
+)
+def test_classify_exception(exc, expected):
+    assert classify_exception(exc) == expected
+
+
+async def test_primary_serves():
+    primary = _FakeLlm(model="gpt-4o", text="hi", calls=[])
+    backup = _FakeLlm(model="claude", text="backup", calls=[])
+    llm = FallbackLlm(primary=primary, fallbacks=[Fallback(llm=backup)])
+
+    responses = await _collect(llm)
+
+    assert [r.content.parts[0].text for r in responses] == ["hi"]
+    assert responses[0].custom_metadata == {SERVED_MODEL_KEY: "gpt-4o"}
+    assert backup.calls == []
+
+
+async def test_fails_over_to_matching_fallback():
+    primary = _FakeLlm(model="gpt-4o", error=_StatusError(429), calls=[])
+    server_only = _FakeLlm(model="azure", text="azure", calls=[])
+    any_error = _FakeLlm(model="claude", text="claude", calls=[])
+    llm = FallbackLlm(
+        primary=primary,
+        fallbacks=[Fallback(llm=server_only, triggers=[SERVER_ERROR]), Fallback(llm=any_error)],
+    )
+
+    responses = await _collect(llm)
+
+    assert [r.content.parts[0].text for r in responses] == ["claude"]
+    assert responses[0].custom_metadata == {SERVED_MODEL_KEY: "claude", FALLBACK_INDEX_KEY: 2}
+    assert server_only.calls == []
+    assert any_error.calls == ["claude"]
+
+
+async def test_non_retryable_error_is_raised():
+    primary = _FakeLlm(model="gpt-4o", error=_StatusError(400), calls=[])
+    backup = _FakeLlm(model="claude", text="backup", calls=[])
+    llm = FallbackLlm(primary=primary, fallbacks=[Fallback(llm=backup)])
+
+    with pytest.raises(_StatusError):
+        await _collect(llm)
+    assert backup.calls == []
+
+
+async def test_last_error_is_raised_when_chain_is_exhausted():
+    primary = _FakeLlm(model="gpt-4o", error=_StatusError(503), calls=[])
+    backup = _FakeLlm(model="claude", error=_StatusError(500), calls=[])
+    llm = FallbackLlm(primary=primary, fallbacks=[Fallback(llm=backup)])
+
+    with pytest.raises(_StatusError, match="status 500"):
+        await _collect(llm)
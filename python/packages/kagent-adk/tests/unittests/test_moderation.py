@@ -0,0 +1,144 @@
+"""Tests for content moderation of model responses and user messages."""
+
+from types import SimpleNamespace
+
+import httpx
+import pytest
+from google.adk.models.llm_request import LlmRequest
+from google.adk.models.llm_response import LlmResponse
+from google.genai import types
+
+from kagent.adk._moderation import (
+    BLOCKED_INPUT,
+    BLOCKED_OUTPUT,
+    METADATA_KEY,
+    REASON_KEYWORD,
+    REASON_PATTERN,
+    REASON_PROVIDER_ERROR,
+    ModerationConfig,
+    ModerationProviderConfig,
+    Moderator,
+)
+
+CTX = SimpleNamespace(invocation_id="inv-1")
+TEXT = "Project falcon ships Monday."
+
+
+def _response(text: str, partial: bool = False) -> LlmResponse:
+    return LlmResponse(content=types.Content(role="model", parts=[types.Part(text=text)]), partial=partial)
+
+
+def _request(text: str) -> LlmRequest:
+    return LlmRequest(contents=[types.Content(role="user", parts=[types.Part(text=text)])])
+
+
+@pytest.mark.asyncio
+@pytest.mark.parametrize(
+    "text,expected",
+    [
+        ("The launch date of project falcon is secret.", [REASON_KEYWORD]),
+        ("Use password: hunter2", [REASON_PATTERN]),
+        ("Project Falcons migrate in winter.", None),
+        ("The deployment is healthy.", None),
+    ],
+)
+async def test_check(text, expected):
+    moderator = Moderator(ModerationConfig(keywords=["Project Falcon"], patterns=[r"(?i)password\s*[:=]\s*\S+"]))
+    verdict = await moderator.check(text, "output")
+    assert (verdict.reasons if verdict else None) == expected
+
+
+@pytest.mark.asyncio
+async def test_after_model_blocks_and_redacts():
+    blocked = await Moderator(ModerationConfig(keywords=["falcon"])).after_model(CTX, _response(TEXT))
+    assert blocked.content.parts[0].text == BLOCKED_OUTPUT
+    assert blocked.custom_metadata[METADATA_KEY] == {"direction": "output", "action": "block", "reasons": ["keyword"]}
+
+    moderator = Moderator(ModerationConfig(action="redact", keywords=["falcon"]))
+    redacted = await moderator.after_model(CTX, _response(TEXT))
+    assert redacted.content.parts[0].text == "Project [removed by moderation] ships Monday."
+    partial = await moderator.after_model(CTX, _response("Project", partial=True))
+    assert partial.content.parts == []
+    assert await moderator.after_model(CTX, _response("All good.")) is None
+
+
+@pytest.mark.asyncio
+async def test_after_model_flags():
+    moderator = Moderator(ModerationConfig(action="flag", keywords=["falcon"]))
+    assert await moderator.after_model(CTX, _response("Project", partial=True)) is None
+    flagged = await moderator.after_model(CTX, _response(TEXT))
+    assert flagged.content.parts[0].text == TEXT
+    assert flagged.custom_metadata[METADATA_KEY]["action"] == "flag"
+
+
+@pytest.mark.asyncio
+async def test_before_model():
+    message = "Tell me about falcon."
+
+    blocked = await Moderator(ModerationConfig(keywords=["falcon"], check_input=True)).before_model(
+        CTX, _request(message)
+    )
+    assert blocked.content.parts[0].text == BLOCKED_INPUT
+
+    request = _request(message)
+    original = request.contents[0]
+    moderator = Moderator(ModerationConfig(action="redact", keywords=["falcon"], check_input=True))
+    assert await moderator.before_model(CTX, request) is None
+    assert request.contents[0].parts[0].text == "Tell me about [removed by moderation]."
+    assert original.parts[0].text == message
+
+    moderator = Moderator(ModerationConfig(action="flag", keywords=["falcon"], check_input=True))
+    assert await moderator.before_model(CTX, _request(message)) is None
+    response = await moderator.after_model(CTX, _response("Falcons are birds of prey."))
+    assert response.custom_metadata[METADATA_KEY]["direction"] == "input"
+
+
+@pytest.mark.asyncio
+async def test_openai_provider(monkeypatch):
+    seen = []
+
+    async def post(self, url, json=None, headers=None):
+        seen.append((url, json["input"], headers))
+        flagged = json["input"] == "I will hurt them."
+        body = {"results": [{"flagged": flagged, "categories": {"violence": flagged, "harassment": False}}]}
+        return httpx.Response(200, json=body, request=httpx.Request("POST", url))
+
+    monkeypatch.setattr(httpx.AsyncClient, "post", post)
+    moderator = Moderator(
+        ModerationConfig(action="redact", keywords=["falcon"], provider=ModerationProviderConfig(type="openai")),
+        api_key="sk-test",
+    )
+
+    verdict = await moderator.check("I will hurt them.", "output")
+    assert verdict.reasons == ["violence"]
+    assert moderator.apply("I will hurt them.", verdict, BLOCKED_OUTPUT) == BLOCKED_OUTPUT
+    assert seen[0] == (
+        "https://api.openai.com/v1/moderations",
+        "I will hurt them.",
+        {"Authorization": "Bearer sk-test"},
+    )
+
+    verdict = await moderator.check(TEXT, "output")
+    assert not verdict.blocks
+    assert seen[-1][1] == "Project [removed by moderation] ships Monday."
+
+
+@pytest.mark.asyncio
+async def test_provider_failure(monkeypatch):
+    async def post(self, url, json=None, headers=None):
+        return httpx.Response(503, request=httpx.Request("POST", url))
+
+    monkeypatch.setattr(httpx.AsyncClient, "post", post)
+    provider = ModerationProviderConfig(type="http", url="http://moderator.example/check")
+
+    verdict = await Moderator(ModerationConfig(provider=provider)).check("hello", "input")
+    assert verdict.reasons == [REASON_PROVIDER_ERROR]
+    assert verdict.blocks
+
+    provider.fail_open = True
+    assert await Moderator(ModerationConfig(provider=provider)).check("hello", "input") is None
+
+
+def test_http_provider_requires_url():
+    with pytest.raises(ValueError):
+        Moderator(ModerationConfig(provider=ModerationProviderConfig(type="http")))
@@ -0,0 +1,86 @@
+"""Tests for model request and tool call timeouts."""
+
+import asyncio
+from types import SimpleNamespace
+from unittest.mock import MagicMock
+
+import pytest
+from google.adk.models.base_llm import BaseLlm
+from google.adk.models.llm_request import LlmRequest
+from google.adk.models.llm_response import LlmResponse
+from google.genai import types as genai_types
+
+from kagent.adk._mcp_toolset import ConnectionSafeMcpTool
+from kagent.adk._model_fallback import Fallback, FallbackLlm
+from kagent.adk._timeouts import TimeoutLlm, TimeoutsConfig, with_llm_timeout
+
+
+class _SlowLlm(BaseLlm):
+    delay: float = 0.0
+
+    async def generate_content_async(self, llm_request, stream=False):
+        await asyncio.sleep(self.delay)
+        yield LlmResponse(content=genai_types.Content(role="model", parts=[genai_types.Part(text=self.model)]))
+
+
+def test_tool_timeout_overrides():
+    timeouts = TimeoutsConfig(tool_call=30, tools={"helm_upgrade": 600})
+    assert timeouts.tool_timeout("helm_upgrade") == 600
+    assert timeouts.tool_timeout("k8s_get_resources") == 30
+    assert TimeoutsConfig().tool_timeout("k8s_get_resources") is None
+
+
+def test_with_llm_timeout_without_timeout_returns_the_model():
+    llm = _SlowLlm(model="gpt-4o")
+    assert with_llm_timeout(llm, None) is llm
+    assert with_llm_timeout(llm, TimeoutsConfig(tool_call=30)) is llm
+
+
+@pytest.mark.asyncio
+async def test_llm_request_times_out():
+    llm = TimeoutLlm(_SlowLlm(model="slow", delay=10), 0.01)
+    with pytest.raises(TimeoutError, match="request to model slow timed out after 0.01s"):
+        async for _ in llm.generate_content_async(LlmRequest()):
+            pass
+
+    fast = TimeoutLlm(_SlowLlm(model="fast"), 10)
+    responses = [r async for r in fast.generate_content_async(LlmRequest())]
+    assert responses[0].content.parts[0].text == "fast"
+
+
+@pytest.mark.asyncio
+async def test_llm_request_timeout_fails_over():
+    llm = FallbackLlm(
+        primary=TimeoutLlm(_SlowLlm(model="slow", delay=10), 0.01),
+        fallbacks=[Fallback(llm=_SlowLlm(model="claude"))],
+    )
+    responses = [r async for r in llm.generate_content_async(LlmRequest())]
+    assert responses[0].content.parts[0].text == "claude"
+
+
+@pytest.mark.asyncio
+async def test_tool_call_times_out():
+    async def hang(**_):
+        await asyncio.sleep(10)
+
+    inner = MagicMock()
+    inner.name = "k8s_get_resources"
+    inner.run_async = hang
+    tool = ConnectionSafeMcpTool(inner, timeout=0.01)
+
+    out = await tool.run_async(args={}, tool_context=SimpleNamespace())
+    assert "Tool k8s_get_resources timed out after 0.01s" in out["error"]
+
+
+@pytest.mark.asyncio
+async def test_tool_timeout_error_is_a_connection_error():
+    async def fail(**_):
+        raise TimeoutError("socket timed out")
+
+    inner = MagicMock()
+    inner.name = "k8s_get_resources"
+    inner.run_async = fail
+    tool = ConnectionSafeMcpTool(inner, timeout=10)
+
+    out = await tool.run_async(args={}, tool_context=SimpleNamespace())
+    assert "connection error" in out["error"]
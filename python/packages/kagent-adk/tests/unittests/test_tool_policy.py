@@ -0,0 +1,48 @@
+"""Tests for the tool-name allow/deny policy applied to MCP toolsets."""
+
+from unittest.mock import patch
+
+import pytest
+from google.adk.tools.mcp_tool.mcp_tool import McpTool
+from google.adk.tools.mcp_tool.mcp_toolset import McpToolset
+
+from kagent.adk._mcp_toolset import KAgentMcpToolset
+from kagent.adk._tool_policy import ToolPolicy
+
+
+@pytest.mark.parametrize(
+    "policy,name,expected",
+    [
+        (ToolPolicy(), "anything", True),
+        (ToolPolicy(allow=["kubectl_*"]), "kubectl_get", True),
+        (ToolPolicy(allow=["kubectl_*"]), "istio_analyze", False),
+        (ToolPolicy(allow=["kubectl_*"], deny=["*delete*"]), "kubectl_delete_pod", False),
+        (ToolPolicy(deny=["helm_[ur]*"]), "helm_uninstall", False),
+        (ToolPolicy(deny=["*delete*"]), "istio_analyze", True),
+        (ToolPolicy(allow=["get_pods"]), "get_pods_logs", False),
+    ],
+)
+def test_allows(policy, name, expected):
+    assert policy.allows(name) is expected
+
+
+@pytest.mark.asyncio
+async def test_get_tools_applies_policy():
+    """Denied tools are dropped every time the toolset is listed, including
+    tools the server did not have when the agent started."""
+    tools = []
+    for name in ("k8s_get_pods", "k8s_delete_pod", "helm_list"):
+        tool = McpTool.__new__(McpTool)
+        tool.name = name
+        tools.append(tool)
+
+    toolset = KAgentMcpToolset.__new__(KAgentMcpToolset)
+    toolset._tool_policy = ToolPolicy(allow=["k8s_*"], deny=["*delete*"])
+
+    async def mock_super_get_tools(self_arg, readonly_context=None):
+        return tools
+
+    with patch.object(McpToolset, "get_tools", mock_super_get_tools):
+        got = await toolset.get_tools()
+
+    assert [t.name for t in got] == ["k8s_get_pods"]
@@ -0,0 +1,130 @@
+"""Tests for size limits on MCP tool results."""
+
+from types import SimpleNamespace
+from unittest.mock import AsyncMock, MagicMock
+
+import pytest
+from google.genai.types import Content, Part
+
+from kagent.adk._mcp_toolset import ConnectionSafeMcpTool
+from kagent.adk._tool_result_limit import (
+    NOTE_KEY,
+    ToolResultLimitConfig,
+    ToolResultLimiter,
+    _head,
+    _split,
+    _tail,
+)
+
+
+def _mcp_result(text: str) -> dict:
+    return {"content": [{"type": "text", "text": text}], "isError": False}
+
+
+def _text(result: dict) -> str:
+    return result["content"][0]["text"]
+
+
+def _tool_context(model=None) -> SimpleNamespace:
+    agent = SimpleNamespace(canonical_model=model)
+    return SimpleNamespace(
+        function_call_id="call-1",
+        state={},
+        _invocation_context=SimpleNamespace(agent=agent),
+    )
+
+
+def test_cuts_do_not_split_characters():
+    text = "aé" + "b" * 5 + "é"
+    assert _head(text, 2) == "a"
+    assert _tail(text, 3) == "bé"
+    assert _tail(text, 1) == ""
+    pages = _split(text, 4)
+    assert "".join(pages) == text
+    assert all(len(p.encode()) <= 4 for p in pages)
+
+
+@pytest.mark.asyncio
+async def test_passes_through_small_excluded_and_error_results():
+    limiter = ToolResultLimiter(ToolResultLimitConfig(max_bytes=10, exclude_tools=["get_release_notes"]))
+    ctx = _tool_context()
+    assert await limiter.apply("kubectl_get", _mcp_result("small"), ctx) is None
+    assert await limiter.apply("get_release_notes", _mcp_result("x" * 100), ctx) is None
+    error = {"content": [{"type": "text", "text": "x" * 100}], "isError": True}
+    assert await limiter.apply("kubectl_get", error, ctx) is None
+
+
+@pytest.mark.asyncio
+@pytest.mark.parametrize("strategy,expected", [("head", "0123456789"), ("tail", "KLMNOPQRST")])
+async def test_head_and_tail(strategy, expected):
+    limiter = ToolResultLimiter(ToolResultLimitConfig(max_bytes=10, strategy=strategy))
+    out = await limiter.apply("kubectl_get", _mcp_result("0123456789abcdefKLMNOPQRST"), _tool_context())
+    assert _text(out) == expected
+    assert "26 bytes, over the 10-byte limit" in out[NOTE_KEY]
+
+
+@pytest.mark.asyncio
+async def test_structured_content_is_measured():
+    limiter = ToolResultLimiter(ToolResultLimitConfig(max_bytes=10))
+    result = {"content": [], "structuredContent": {"items": ["a" * 20]}, "isError": False}
+    out = await limiter.apply("kubectl_get", result, _tool_context())
+    assert _text(out) == '{"items": '
+
+
+@pytest.mark.asyncio
+async def test_summarize():
+    async def generate(request, stream=False):
+        assert '"kubectl_get"' in request.contents[0].parts[0].text
+        yield SimpleNamespace(content=Content(role="model", parts=[Part(text="  3 pods, all running  ")]))
+
+    model = MagicMock(model="gpt-4o")
+    model.generate_content_async = generate
+    limiter = ToolResultLimiter(ToolResultLimitConfig(max_bytes=100, strategy="summarize"))
+    out = await limiter.apply("kubectl_get", _mcp_result("x" * 1000), _tool_context(model))
+    assert _text(out) == "3 pods, all running"
+    assert "replaced by a summary" in out[NOTE_KEY]
+
+
+@pytest.mark.asyncio
+async def test_summarize_falls_back_to_head():
+    async def generate(request, stream=False):
+        raise RuntimeError("rate limited")
+        yield  # pragma: no cover
+
+    model = MagicMock(model="gpt-4o")
+    model.generate_content_async = generate
+    limiter = ToolResultLimiter(ToolResultLimitConfig(max_bytes=100, strategy="summarize"))
+    out = await limiter.apply("kubectl_get", _mcp_result("x" * 1000), _tool_context(model))
+    assert _text(out) == "x" * 100
+    assert "first 100 bytes" in out[NOTE_KEY]
+
+
+@pytest.mark.asyncio
+async def test_paginate():
+    limiter = ToolResultLimiter(ToolResultLimitConfig(max_bytes=10, strategy="paginate"))
+    ctx = _tool_context()
+    out = await limiter.apply("kubectl_get", _mcp_result("0123456789abcdefghijKLMNO"), ctx)
+    assert _text(out) == "0123456789"
+    assert "3 pages" in out[NOTE_KEY]
+    assert '"call-1"' in out[NOTE_KEY]
+
+    assert limiter.read_page("call-1", 3, ctx) == {"output": "KLMNO", "page": 3, "pages": 3}
+    assert "out of range" in limiter.read_page("call-1", 4, ctx)["error"]
+    assert "no paginated tool result" in limiter.read_page("call-2", 1, ctx)["error"]
+    assert limiter.page_tool().name == "read_tool_result_page"
+
+
+@pytest.mark.asyncio
+async def test_connection_safe_tool_applies_limit():
+    inner = MagicMock()
+    inner.name = "kubectl_get"
+    inner.run_async = AsyncMock(return_value=_mcp_result("x" * 100))
+    limiter = ToolResultLimiter(ToolResultLimitConfig(max_bytes=10))
+    tool = ConnectionSafeMcpTool(inner, limiter)
+
+    out = await tool.run_async(args={}, tool_context=_tool_context())
+    assert _text(out) == "x" * 10
+    assert NOTE_KEY in out
+
+    unlimited = ConnectionSafeMcpTool(inner)
+    assert await unlimited.run_async(args={}, tool_context=_tool_context()) == _mcp_result("x" * 100)
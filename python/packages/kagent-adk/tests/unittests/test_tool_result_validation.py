@@ -0,0 +1,107 @@
+"""Tests for output schema validation of MCP tool results."""
+
+from types import SimpleNamespace
+from unittest.mock import AsyncMock, MagicMock
+
+import pytest
+
+from kagent.adk._mcp_toolset import ConnectionSafeMcpTool
+from kagent.adk._tool_result_validation import (
+    VIOLATION_KEY,
+    ToolResultValidationConfig,
+    ToolResultValidator,
+)
+
+POD_SCHEMA = {
+    "type": "object",
+    "properties": {
+        "name": {"type": "string"},
+        "replicas": {"type": "integer"},
+        "ready": {"type": "boolean"},
+        "cpu": {"type": "number"},
+        "labels": {"type": "object", "additionalProperties": {"type": "string"}},
+        "ports": {"type": "array", "items": {"type": "integer"}},
+    },
+    "required": ["name", "replicas"],
+}
+
+
+def _structured(value) -> dict:
+    return {"content": [], "structuredContent": value, "isError": False}
+
+
+def _text_result(text: str) -> dict:
+    return {"content": [{"type": "text", "text": text}], "isError": False}
+
+
+def test_valid_result_passes_through():
+    validator = ToolResultValidator(ToolResultValidationConfig())
+    assert validator.apply("get_pod", POD_SCHEMA, _structured({"name": "web", "replicas": 3})) is None
+
+
+def test_no_schema_excluded_and_error_results_pass_through():
+    validator = ToolResultValidator(ToolResultValidationConfig(exclude_tools=["get_release_notes"]))
+    assert validator.apply("get_pod", None, _text_result("not json")) is None
+    assert validator.apply("get_release_notes", POD_SCHEMA, _text_result("not json")) is None
+    error = {"content": [{"type": "text", "text": "boom"}], "isError": True}
+    assert validator.apply("get_pod", POD_SCHEMA, error) is None
+
+
+def test_json_text_becomes_structured():
+    validator = ToolResultValidator(ToolResultValidationConfig(coerce=False))
+    out = validator.apply("get_pod", POD_SCHEMA, _text_result('{"name": "web", "replicas": 3}'))
+    assert out["structuredContent"] == {"name": "web", "replicas": 3}
+
+
+def test_coerces_to_the_schema():
+    validator = ToolResultValidator(ToolResultValidationConfig())
+    result = _structured(
+        {
+            "name": "web",
+            "replicas": "3",
+            "ready": "True",
+            "cpu": "0.5",
+            "labels": {"tier": 1},
+            "ports": ["80", 443],
+        }
+    )
+    out = validator.apply("get_pod", POD_SCHEMA, result)
+    assert out["structuredContent"] == {
+        "name": "web",
+        "replicas": 3,
+        "ready": True,
+        "cpu": 0.5,
+        "labels": {"tier": "1"},
+        "ports": [80, 443],
+    }
+
+
+@pytest.mark.parametrize(
+    "coerce,result,violation",
+    [
+        (False, _structured({"name": "web", "replicas": "3"}), ".replicas"),
+        (True, _structured({"name": "web", "replicas": "three"}), ".replicas"),
+        (True, _structured({"name": "web"}), "'replicas' is a required property"),
+        (True, _text_result("deployment scaled"), "not JSON"),
+    ],
+)
+def test_rejects_mismatches(coerce, result, violation):
+    validator = ToolResultValidator(ToolResultValidationConfig(coerce=coerce))
+    out = validator.apply("get_pod", POD_SCHEMA, result)
+    assert violation in out[VIOLATION_KEY]
+    assert "get_pod" in out["error"]
+
+
+@pytest.mark.asyncio
+async def test_connection_safe_tool_applies_validation():
+    inner = MagicMock()
+    inner.name = "get_pod"
+    inner.raw_mcp_tool = SimpleNamespace(outputSchema=POD_SCHEMA)
+    inner.run_async = AsyncMock(return_value=_structured({"name": "web"}))
+    tool = ConnectionSafeMcpTool(inner, result_validator=ToolResultValidator(ToolResultValidationConfig()))
+
+    out = await tool.run_async(args={}, tool_context=SimpleNamespace(function_call_id="call-1"))
+    assert VIOLATION_KEY in out
+
+    unchecked = ConnectionSafeMcpTool(inner)
+    assert await unchecked.run_async(args={}, tool_context=SimpleNamespace()) == _structured({"name": "web"})
@@ -84,12 +84,18 @@ export interface TLSConfig {
   disableSystemCAs?: boolean;
 }
 
+export interface APIKeySource {
+  envVar?: string;
+  file?: string;
+}
+
 export interface ModelConfigSpec {
   model: string;
   provider: string;
   apiKeySecret?: string;
   apiKeySecretKey?: string;
   apiKeyPassthrough?: boolean;
+  apiKeyFrom?: APIKeySource;
   defaultHeaders?: Record<string, string>;
   tls?: TLSConfig;
   openAI?: OpenAIConfig;
@@ -401,6 +407,15 @@ export interface ContextSummarizerConfig {
 export interface MemorySpec {
   modelConfig: string;
   ttlDays?: number;
+  provider?: "Postgres" | "Qdrant";
+  qdrant?: QdrantMemoryConfig;
+}
+
+export interface QdrantMemoryConfig {
+  url: string;
+  collection?: string;
+  apiKeySecret?: string;
+  apiKeySecretKey?: string;
 }
 
 export interface BYOAgentSpec {
@@ -426,6 +441,26 @@ export interface BYODeploymentSpec {
 
 export interface A2AConfig {
   skills: AgentSkill[];
+  defaultInputModes?: string[];
+  defaultOutputModes?: string[];
+  securitySchemes?: A2ASecurityScheme[];
+}
+
+export interface A2ASecurityScheme {
+  name: string;
+  description?: string;
+  scopes?: string[];
+  apiKey?: { location: "header" | "query" | "cookie"; parameterName: string };
+  http?: { scheme: string; bearerFormat?: string };
+  oauth2?: {
+    flow: "ClientCredentials" | "AuthorizationCode";
+    tokenUrl: string;
+    authorizationUrl?: string;
+    scopes?: Record<string, string>;
+    metadataUrl?: string;
+  };
+  openIdConnect?: { url: string };
+  mutualTLS?: Record<string, never>;
 }
 
 export interface AgentSkill {